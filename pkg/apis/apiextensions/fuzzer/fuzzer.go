@@ -17,14 +17,18 @@ limitations under the License.
 package fuzzer
 
 import (
+	"reflect"
 	"strings"
 
 	"github.com/google/gofuzz"
 
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtimeserializer "k8s.io/apimachinery/pkg/runtime/serializer"
 )
 
+var swaggerMetadataDescriptions = metav1.ObjectMeta{}.SwaggerDoc()
+
 // Funcs returns the fuzzer functions for the apiextensions apis.
 func Funcs(codecs runtimeserializer.CodecFactory) []interface{} {
 	return []interface{}{
@@ -41,6 +45,124 @@ func Funcs(codecs runtimeserializer.CodecFactory) []interface{} {
 			if len(obj.Names.ListKind) == 0 && len(obj.Names.Kind) > 0 {
 				obj.Names.ListKind = obj.Names.Kind + "List"
 			}
+			if obj.PreserveUnknownFields == nil {
+				preserveUnknownFields := true
+				obj.PreserveUnknownFields = &preserveUnknownFields
+			}
+			if len(obj.Versions) == 0 && len(obj.Version) != 0 {
+				obj.Versions = []apiextensions.CustomResourceDefinitionVersion{
+					{
+						Name:    obj.Version,
+						Served:  true,
+						Storage: true,
+					},
+				}
+			} else if len(obj.Versions) != 0 {
+				obj.Version = obj.Versions[0].Name
+			}
+			if obj.Conversion == nil {
+				obj.Conversion = &apiextensions.CustomResourceConversion{
+					Strategy: apiextensions.NoneConverter,
+				}
+			}
+			if obj.Conversion.Strategy == apiextensions.WebhookConverter && len(obj.Conversion.ConversionReviewVersions) == 0 {
+				obj.Conversion.ConversionReviewVersions = []string{"v1beta1"}
+			}
+			if len(obj.AdditionalPrinterColumns) == 0 && !apiextensions.HasPerVersionColumns(obj.Versions) {
+				obj.AdditionalPrinterColumns = []apiextensions.CustomResourceColumnDefinition{
+					{Name: "Age", Type: "date", Description: swaggerMetadataDescriptions["creationTimestamp"], JSONPath: ".metadata.creationTimestamp"},
+				}
+			}
+		},
+		func(obj *apiextensions.JSONSchemaProps, c fuzz.Continue) {
+			// we cannot use c.FuzzNoCustom because of the interface{} fields. So let's loop with reflection.
+			vobj := reflect.ValueOf(obj).Elem()
+			tobj := reflect.TypeOf(obj).Elem()
+			for i := 0; i < tobj.NumField(); i++ {
+				field := tobj.Field(i)
+				switch field.Name {
+				case "Default", "Enum", "Example":
+					continue
+				default:
+					isValue := true
+					switch field.Type.Kind() {
+					case reflect.Interface, reflect.Map, reflect.Slice, reflect.Ptr:
+						isValue = false
+					}
+					if isValue || c.Intn(10) == 0 {
+						c.Fuzz(vobj.Field(i).Addr().Interface())
+					}
+				}
+			}
+			if c.RandBool() {
+				validJSON := apiextensions.JSON(`{"some": {"json": "test"}, "string": 42}`)
+				obj.Default = &validJSON
+			}
+			if c.RandBool() {
+				obj.Enum = []apiextensions.JSON{c.Float64(), c.RandString(), c.RandBool()}
+			}
+			if c.RandBool() {
+				validJSON := apiextensions.JSON(`"foobarbaz"`)
+				obj.Example = &validJSON
+			}
+		},
+		func(obj *apiextensions.CustomResourceDefinitionVersion, c fuzz.Continue) {
+			// the example is an interface{}, so the other fields are fuzzed one by one.
+			vobj := reflect.ValueOf(obj).Elem()
+			tobj := reflect.TypeOf(obj).Elem()
+			for i := 0; i < tobj.NumField(); i++ {
+				if tobj.Field(i).Name != "Example" {
+					c.Fuzz(vobj.Field(i).Addr().Interface())
+				}
+			}
+			if c.RandBool() {
+				example := apiextensions.JSON(map[string]interface{}{"spec": map[string]interface{}{"host": c.RandString()}})
+				obj.Example = &example
+			}
+		},
+		func(obj *apiextensions.CustomResourceSubresourceStatus, c fuzz.Continue) {
+			// the initial status is an interface{} too, so it is set by hand.
+			if c.RandBool() {
+				initialStatus := apiextensions.JSON(map[string]interface{}{"phase": c.RandString()})
+				obj.InitialStatus = &initialStatus
+			}
+		},
+		func(obj *apiextensions.CustomResourceSubresourceCustom, c fuzz.Continue) {
+			c.FuzzNoCustom(obj)
+
+			// match our defaulter
+			if len(obj.Verbs) == 0 {
+				obj.Verbs = []string{"get", "update", "patch"}
+			}
+		},
+		func(obj *apiextensions.JSONSchemaPropsOrBool, c fuzz.Continue) {
+			if c.RandBool() {
+				obj.Allows = true
+				obj.Schema = &apiextensions.JSONSchemaProps{}
+				c.Fuzz(obj.Schema)
+			} else {
+				obj.Allows = c.RandBool()
+			}
+		},
+		func(obj *apiextensions.JSONSchemaPropsOrArray, c fuzz.Continue) {
+			// disallow both Schema and JSONSchemas to be nil.
+			if c.RandBool() {
+				obj.Schema = &apiextensions.JSONSchemaProps{}
+				c.Fuzz(obj.Schema)
+			} else {
+				obj.JSONSchemas = make([]apiextensions.JSONSchemaProps, c.Intn(3)+1)
+				for i := range obj.JSONSchemas {
+					c.Fuzz(&obj.JSONSchemas[i])
+				}
+			}
+		},
+		func(obj *apiextensions.JSONSchemaPropsOrStringArray, c fuzz.Continue) {
+			if c.RandBool() {
+				obj.Schema = &apiextensions.JSONSchemaProps{}
+				c.Fuzz(obj.Schema)
+			} else {
+				c.Fuzz(&obj.Property)
+			}
 		},
 	}
 }
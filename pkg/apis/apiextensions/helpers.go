@@ -16,9 +16,24 @@ limitations under the License.
 
 package apiextensions
 
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // SetCRDCondition sets the status condition.  It either overwrites the existing one or
-// creates a new one
+// creates a new one. The lastTransitionTime is only changed if the status changes, to the
+// lastTransitionTime of newCondition or to the current time if that is not set.
 func SetCRDCondition(crd *CustomResourceDefinition, newCondition CustomResourceDefinitionCondition) {
+	if newCondition.LastTransitionTime.IsZero() {
+		newCondition.LastTransitionTime = metav1.NewTime(time.Now())
+	}
+
 	existingCondition := FindCRDCondition(crd, newCondition.Type)
 	if existingCondition == nil {
 		crd.Status.Conditions = append(crd.Status.Conditions, newCondition)
@@ -32,6 +47,7 @@ func SetCRDCondition(crd *CustomResourceDefinition, newCondition CustomResourceD
 
 	existingCondition.Reason = newCondition.Reason
 	existingCondition.Message = newCondition.Message
+	existingCondition.ObservedGeneration = newCondition.ObservedGeneration
 }
 
 // RemoveCRDCondition removes the status condition.
@@ -85,7 +101,7 @@ func IsCRDConditionEquivalent(lhs, rhs *CustomResourceDefinitionCondition) bool
 		return false
 	}
 
-	return lhs.Message == rhs.Message && lhs.Reason == rhs.Reason && lhs.Status == rhs.Status && lhs.Type == rhs.Type
+	return lhs.Message == rhs.Message && lhs.Reason == rhs.Reason && lhs.Status == rhs.Status && lhs.Type == rhs.Type && lhs.ObservedGeneration == rhs.ObservedGeneration
 }
 
 // CRDHasFinalizer returns true if the finalizer is in the list
@@ -109,3 +125,288 @@ func CRDRemoveFinalizer(crd *CustomResourceDefinition, needle string) {
 	}
 	crd.Finalizers = newFinalizers
 }
+
+// HasServedCRDVersion returns true if the given version is in the list of CRD's versions and the Served flag is set.
+func HasServedCRDVersion(crd *CustomResourceDefinition, version string) bool {
+	for _, v := range crd.Spec.Versions {
+		if v.Name == version {
+			return v.Served
+		}
+	}
+	return false
+}
+
+// GetCRDStorageVersion returns the storage version for given CRD.
+func GetCRDStorageVersion(crd *CustomResourceDefinition) (string, error) {
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			return v.Name, nil
+		}
+	}
+	// This should not happen if crd is valid
+	return "", fmt.Errorf("invalid CustomResourceDefinition, no storage version")
+}
+
+// GetCRDMirrorStorageVersion returns the version in which custom resources are persisted in addition
+// to the storage version, or an empty string if there is none.
+func GetCRDMirrorStorageVersion(crd *CustomResourceDefinition) string {
+	for _, v := range crd.Spec.Versions {
+		if v.MirrorStorage {
+			return v.Name
+		}
+	}
+	return ""
+}
+
+// IsStoredVersion returns whether the given version is one of the stored versions of the CRD, i.e.
+// whether objects of the CRD might be persisted in that version.
+func IsStoredVersion(crd *CustomResourceDefinition, version string) bool {
+	for _, v := range crd.Status.StoredVersions {
+		if version == v {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPerVersionSchema returns true if any of the versions specifies a schema.
+func HasPerVersionSchema(versions []CustomResourceDefinitionVersion) bool {
+	for _, v := range versions {
+		if v.Schema != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPerVersionSubresources returns true if any of the versions specifies subresources.
+func HasPerVersionSubresources(versions []CustomResourceDefinitionVersion) bool {
+	for _, v := range versions {
+		if v.Subresources != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPerVersionColumns returns true if any of the versions specifies printer columns.
+func HasPerVersionColumns(versions []CustomResourceDefinitionVersion) bool {
+	for _, v := range versions {
+		if len(v.AdditionalPrinterColumns) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSchemaForVersion returns the validation schema of the given version, which is the top-level
+// one unless the versions specify their own. It may be nil. References to definitions are expanded.
+func GetSchemaForVersion(crd *CustomResourceDefinition, version string) (*CustomResourceValidation, error) {
+	if !HasPerVersionSchema(crd.Spec.Versions) {
+		return expandValidationReferences(crd, crd.Spec.Validation)
+	}
+	if crd.Spec.Validation != nil {
+		return nil, fmt.Errorf("malformed CustomResourceDefinition %s: top-level and per-version schemas are mutually exclusive", crd.Name)
+	}
+	for _, v := range crd.Spec.Versions {
+		if v.Name == version {
+			return expandValidationReferences(crd, v.Schema)
+		}
+	}
+	return nil, fmt.Errorf("version %s not found in CustomResourceDefinition %s", version, crd.Name)
+}
+
+// expandValidationReferences returns the validation with the references of its schema expanded.
+func expandValidationReferences(crd *CustomResourceDefinition, validation *CustomResourceValidation) (*CustomResourceValidation, error) {
+	if validation == nil || !HasReferences(validation.OpenAPIV3Schema) {
+		return validation, nil
+	}
+	expanded, err := ExpandReferences(validation.OpenAPIV3Schema)
+	if err != nil {
+		return nil, fmt.Errorf("malformed CustomResourceDefinition %s: %v", crd.Name, err)
+	}
+	return &CustomResourceValidation{OpenAPIV3Schema: expanded}, nil
+}
+
+// GetSubresourcesForVersion returns the subresources of the given version, which are the top-level
+// ones unless the versions specify their own. They may be nil.
+func GetSubresourcesForVersion(crd *CustomResourceDefinition, version string) (*CustomResourceSubresources, error) {
+	if !HasPerVersionSubresources(crd.Spec.Versions) {
+		return crd.Spec.Subresources, nil
+	}
+	if crd.Spec.Subresources != nil {
+		return nil, fmt.Errorf("malformed CustomResourceDefinition %s: top-level and per-version subresources are mutually exclusive", crd.Name)
+	}
+	for _, v := range crd.Spec.Versions {
+		if v.Name == version {
+			return v.Subresources, nil
+		}
+	}
+	return nil, fmt.Errorf("version %s not found in CustomResourceDefinition %s", version, crd.Name)
+}
+
+// GetColumnsForVersion returns the printer columns of the given version, which are the top-level
+// ones unless the versions specify their own.
+func GetColumnsForVersion(crd *CustomResourceDefinition, version string) ([]CustomResourceColumnDefinition, error) {
+	if !HasPerVersionColumns(crd.Spec.Versions) {
+		return crd.Spec.AdditionalPrinterColumns, nil
+	}
+	if len(crd.Spec.AdditionalPrinterColumns) > 0 {
+		return nil, fmt.Errorf("malformed CustomResourceDefinition %s: top-level and per-version printer columns are mutually exclusive", crd.Name)
+	}
+	for _, v := range crd.Spec.Versions {
+		if v.Name == version {
+			return v.AdditionalPrinterColumns, nil
+		}
+	}
+	return nil, fmt.Errorf("version %s not found in CustomResourceDefinition %s", version, crd.Name)
+}
+
+// GetDeprecationWarning returns the warning returned to clients of the given version if it is
+// deprecated, and nil otherwise. Unless the version specifies its own warning, the first served
+// version which is not deprecated is recommended.
+func GetDeprecationWarning(crd *CustomResourceDefinition, version string) *string {
+	for _, v := range crd.Spec.Versions {
+		if v.Name != version {
+			continue
+		}
+		if !v.Deprecated {
+			return nil
+		}
+		if v.DeprecationWarning != nil {
+			warning := *v.DeprecationWarning
+			return &warning
+		}
+		warning := fmt.Sprintf("%s/%s %s is deprecated", crd.Spec.Group, version, crd.Spec.Names.Kind)
+		for _, other := range crd.Spec.Versions {
+			if other.Served && !other.Deprecated {
+				warning += fmt.Sprintf("; use %s/%s %s", crd.Spec.Group, other.Name, crd.Spec.Names.Kind)
+				break
+			}
+		}
+		return &warning
+	}
+	return nil
+}
+
+// KubernetesAPIApprovedAnnotation links the approval of a CustomResourceDefinition in a group owned by
+// the Kubernetes project. Values starting with "unapproved" mark APIs which are not approved.
+const KubernetesAPIApprovedAnnotation = "api-approved.kubernetes.io"
+
+// APIApprovalState is the state of the KubernetesAPIApprovedAnnotation of a CustomResourceDefinition.
+type APIApprovalState int
+
+const (
+	// APIApprovalMissing means the annotation is not set.
+	APIApprovalMissing APIApprovalState = iota
+	// APIApprovalInvalid means the annotation is neither a URL nor starts with "unapproved".
+	APIApprovalInvalid
+	// APIApprovalBypassed means the annotation marks the API as not approved.
+	APIApprovalBypassed
+	// APIApproved means the annotation links the approval of the API.
+	APIApproved
+)
+
+// ParseRelease parses a Kubernetes release of the form <major>.<minor>, e.g. 1.25, optionally with a
+// leading v.
+func ParseRelease(release string) (major, minor int, err error) {
+	parts := strings.Split(strings.TrimPrefix(release, "v"), ".")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("must be of the form <major>.<minor>")
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || strings.HasPrefix(p, "+") || (len(p) > 1 && p[0] == '0') {
+			return 0, 0, fmt.Errorf("must be of the form <major>.<minor>")
+		}
+		if i == 0 {
+			major = n
+		} else {
+			minor = n
+		}
+	}
+	return major, minor, nil
+}
+
+// IsProtectedGroup returns true if the group is owned by the Kubernetes project.
+func IsProtectedGroup(group string) bool {
+	return group == "k8s.io" || strings.HasSuffix(group, ".k8s.io") || group == "kubernetes.io" || strings.HasSuffix(group, ".kubernetes.io")
+}
+
+// GetAPIApprovalState returns the state of the KubernetesAPIApprovedAnnotation in the given annotations.
+func GetAPIApprovalState(annotations map[string]string) APIApprovalState {
+	approval, found := annotations[KubernetesAPIApprovedAnnotation]
+	if !found {
+		return APIApprovalMissing
+	}
+	if strings.HasPrefix(approval, "unapproved") {
+		return APIApprovalBypassed
+	}
+	if u, err := url.Parse(approval); err != nil || len(u.Scheme) == 0 || len(u.Host) == 0 {
+		return APIApprovalInvalid
+	}
+	return APIApproved
+}
+
+const (
+	// MaxCustomResourcesAnnotation overrides the maximum number of custom resources of a
+	// CustomResourceDefinition configured for the server. Zero means no limit.
+	MaxCustomResourcesAnnotation = "apiextensions.k8s.io/max-custom-resources"
+	// MaxCustomResourceBytesAnnotation overrides the maximum size in bytes of the JSON encoding of
+	// the custom resources of a CustomResourceDefinition configured for the server. Zero means no limit.
+	MaxCustomResourceBytesAnnotation = "apiextensions.k8s.io/max-custom-resource-bytes"
+)
+
+const (
+	// RequestLogVerbosityAnnotation logs the requests for the custom resources of a
+	// CustomResourceDefinition at the given log verbosity, e.g. 0 to log them regardless of the
+	// verbosity of the server.
+	RequestLogVerbosityAnnotation = "apiextensions.k8s.io/request-log-verbosity"
+	// SlowRequestThresholdAnnotation logs the requests for the custom resources of a
+	// CustomResourceDefinition which take longer than the given duration, e.g. 500ms, as slow
+	// regardless of the verbosity of the server. Watches are never slow.
+	SlowRequestThresholdAnnotation = "apiextensions.k8s.io/slow-request-threshold"
+)
+
+// ReadOnlyAnnotation rejects writes of the custom resources of a CustomResourceDefinition while
+// reads continue, e.g. during migrations or while the custom resources are synced from another
+// cluster. Its value is the message of the rejections. Members of system:masters can still write.
+const ReadOnlyAnnotation = "apiextensions.k8s.io/read-only"
+
+// ScalarCoercionAnnotation, if "true", coerces the scalars of created and updated custom resources of
+// a CustomResourceDefinition to the types of their schema where they are compatible, e.g. "5" to 5
+// for integer fields, before they are validated. This eases the migration of clients which send
+// loosely-typed YAML.
+const ScalarCoercionAnnotation = "apiextensions.k8s.io/coerce-scalars"
+
+// DeepCopyJSONValue returns a deep copy of the JSON value x as produced by encoding/json, i.e. one
+// of bool, int64, float64, string, []interface{}, map[string]interface{} or nil. It fails for other
+// types.
+func DeepCopyJSONValue(x interface{}) (interface{}, error) {
+	switch x := x.(type) {
+	case map[string]interface{}:
+		clone := make(map[string]interface{}, len(x))
+		for k, v := range x {
+			c, err := DeepCopyJSONValue(v)
+			if err != nil {
+				return nil, err
+			}
+			clone[k] = c
+		}
+		return clone, nil
+	case []interface{}:
+		clone := make([]interface{}, len(x))
+		for i, v := range x {
+			c, err := DeepCopyJSONValue(v)
+			if err != nil {
+				return nil, err
+			}
+			clone[i] = c
+		}
+		return clone, nil
+	case string, int64, bool, float64, nil:
+		return x, nil
+	default:
+		return nil, fmt.Errorf("cannot deep copy %T", x)
+	}
+}
@@ -19,10 +19,58 @@ package apiextensions
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+func TestSetCRDCondition(t *testing.T) {
+	crd := &CustomResourceDefinition{}
+	transition := metav1.NewTime(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	// a new condition gets the current time unless a time is given
+	SetCRDCondition(crd, CustomResourceDefinitionCondition{Type: Established, Status: ConditionFalse, Reason: "NotAccepted", ObservedGeneration: 1})
+	established := FindCRDCondition(crd, Established)
+	if established == nil || established.LastTransitionTime.IsZero() {
+		t.Fatalf("expected the lastTransitionTime to be set, got %#v", established)
+	}
+	SetCRDCondition(crd, CustomResourceDefinitionCondition{Type: NamesAccepted, Status: ConditionTrue, LastTransitionTime: transition})
+	if namesAccepted := FindCRDCondition(crd, NamesAccepted); namesAccepted == nil || !namesAccepted.LastTransitionTime.Equal(transition) {
+		t.Errorf("expected the given lastTransitionTime, got %#v", namesAccepted)
+	}
+
+	// the time is kept unless the status changes
+	SetCRDCondition(crd, CustomResourceDefinitionCondition{Type: NamesAccepted, Status: ConditionTrue, Reason: "NoConflicts", ObservedGeneration: 2})
+	namesAccepted := FindCRDCondition(crd, NamesAccepted)
+	if !namesAccepted.LastTransitionTime.Equal(transition) {
+		t.Errorf("expected the lastTransitionTime to be kept, got %v", namesAccepted.LastTransitionTime)
+	}
+	if namesAccepted.Reason != "NoConflicts" || namesAccepted.ObservedGeneration != 2 {
+		t.Errorf("expected the reason and observedGeneration to be updated, got %#v", namesAccepted)
+	}
+	SetCRDCondition(crd, CustomResourceDefinitionCondition{Type: NamesAccepted, Status: ConditionFalse, Reason: "KindConflict", ObservedGeneration: 3})
+	if namesAccepted := FindCRDCondition(crd, NamesAccepted); namesAccepted.LastTransitionTime.Equal(transition) {
+		t.Errorf("expected the lastTransitionTime to change with the status")
+	}
+
+	if len(crd.Status.Conditions) != 2 {
+		t.Errorf("expected two conditions, got %#v", crd.Status.Conditions)
+	}
+}
+
+func TestIsCRDConditionEquivalent(t *testing.T) {
+	condition := &CustomResourceDefinitionCondition{Type: Established, Status: ConditionTrue, Reason: "InitialNamesAccepted", ObservedGeneration: 1}
+	other := *condition
+	other.LastTransitionTime = metav1.NewTime(time.Now())
+	if !IsCRDConditionEquivalent(condition, &other) {
+		t.Errorf("expected conditions differing in time only to be equivalent")
+	}
+	other.ObservedGeneration = 2
+	if IsCRDConditionEquivalent(condition, &other) {
+		t.Errorf("expected conditions of different generations not to be equivalent")
+	}
+}
+
 func TestCRDHasFinalizer(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -88,3 +136,171 @@ func TestCRDRemoveFinalizer(t *testing.T) {
 		}
 	}
 }
+
+func TestGetSchemaForVersion(t *testing.T) {
+	topLevel := &CustomResourceValidation{OpenAPIV3Schema: &JSONSchemaProps{Type: "object"}}
+	v1 := &CustomResourceValidation{OpenAPIV3Schema: &JSONSchemaProps{Type: "object", Description: "v1"}}
+	tests := []struct {
+		name    string
+		spec    CustomResourceDefinitionSpec
+		version string
+
+		expected    *CustomResourceValidation
+		expectedErr bool
+	}{
+		{
+			name:     "top-level",
+			spec:     CustomResourceDefinitionSpec{Validation: topLevel, Versions: []CustomResourceDefinitionVersion{{Name: "v1"}, {Name: "v2"}}},
+			version:  "v2",
+			expected: topLevel,
+		},
+		{
+			name:     "per-version",
+			spec:     CustomResourceDefinitionSpec{Versions: []CustomResourceDefinitionVersion{{Name: "v1", Schema: v1}, {Name: "v2"}}},
+			version:  "v1",
+			expected: v1,
+		},
+		{
+			name:    "per-version without schema",
+			spec:    CustomResourceDefinitionSpec{Versions: []CustomResourceDefinitionVersion{{Name: "v1", Schema: v1}, {Name: "v2"}}},
+			version: "v2",
+		},
+		{
+			name:        "unknown version",
+			spec:        CustomResourceDefinitionSpec{Versions: []CustomResourceDefinitionVersion{{Name: "v1", Schema: v1}}},
+			version:     "v3",
+			expectedErr: true,
+		},
+		{
+			name:        "top-level and per-version",
+			spec:        CustomResourceDefinitionSpec{Validation: topLevel, Versions: []CustomResourceDefinitionVersion{{Name: "v1", Schema: v1}}},
+			version:     "v1",
+			expectedErr: true,
+		},
+	}
+	for _, tc := range tests {
+		actual, err := GetSchemaForVersion(&CustomResourceDefinition{Spec: tc.spec}, tc.version)
+		if tc.expectedErr != (err != nil) {
+			t.Errorf("%v: expected error %v, got %v", tc.name, tc.expectedErr, err)
+			continue
+		}
+		if actual != tc.expected {
+			t.Errorf("%v: expected %v, got %v", tc.name, tc.expected, actual)
+		}
+	}
+}
+
+func TestGetColumnsForVersion(t *testing.T) {
+	topLevel := []CustomResourceColumnDefinition{{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"}}
+	v1 := []CustomResourceColumnDefinition{{Name: "Replicas", Type: "integer", JSONPath: ".spec.replicas"}}
+	crd := &CustomResourceDefinition{Spec: CustomResourceDefinitionSpec{
+		Versions: []CustomResourceDefinitionVersion{{Name: "v1", AdditionalPrinterColumns: v1}, {Name: "v2"}},
+	}}
+	if actual, err := GetColumnsForVersion(crd, "v1"); err != nil || !reflect.DeepEqual(actual, v1) {
+		t.Errorf("expected the columns of v1, got %v, %v", actual, err)
+	}
+	if actual, err := GetColumnsForVersion(crd, "v2"); err != nil || len(actual) != 0 {
+		t.Errorf("expected no columns for v2, got %v, %v", actual, err)
+	}
+	crd.Spec.AdditionalPrinterColumns = topLevel
+	if _, err := GetColumnsForVersion(crd, "v1"); err == nil {
+		t.Errorf("expected an error for top-level and per-version columns")
+	}
+	crd.Spec.Versions[0].AdditionalPrinterColumns = nil
+	if actual, err := GetColumnsForVersion(crd, "v1"); err != nil || !reflect.DeepEqual(actual, topLevel) {
+		t.Errorf("expected the top-level columns, got %v, %v", actual, err)
+	}
+}
+
+func TestGetDeprecationWarning(t *testing.T) {
+	custom := "v1alpha1 is going away"
+	crd := &CustomResourceDefinition{Spec: CustomResourceDefinitionSpec{
+		Group: "mygroup.example.com",
+		Names: CustomResourceDefinitionNames{Kind: "Noxu"},
+		Versions: []CustomResourceDefinitionVersion{
+			{Name: "v1beta1", Served: true, Deprecated: true},
+			{Name: "v1alpha1", Served: true, Deprecated: true, DeprecationWarning: &custom},
+			{Name: "v1", Served: false},
+			{Name: "v2", Served: true, Storage: true},
+		},
+	}}
+	tests := []struct {
+		version  string
+		expected *string
+	}{
+		{version: "v1beta1", expected: strPtr("mygroup.example.com/v1beta1 Noxu is deprecated; use mygroup.example.com/v2 Noxu")},
+		{version: "v1alpha1", expected: &custom},
+		{version: "v2", expected: nil},
+		{version: "v3", expected: nil},
+	}
+	for _, tt := range tests {
+		if actual := GetDeprecationWarning(crd, tt.version); !reflect.DeepEqual(actual, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.version, tt.expected, actual)
+		}
+	}
+
+	crd.Spec.Versions[3].Deprecated = true
+	if actual, expected := GetDeprecationWarning(crd, "v1beta1"), "mygroup.example.com/v1beta1 Noxu is deprecated"; actual == nil || *actual != expected {
+		t.Errorf("expected %q without a served version which is not deprecated, got %v", expected, actual)
+	}
+}
+
+func TestParseRelease(t *testing.T) {
+	tests := []struct {
+		release      string
+		major, minor int
+		valid        bool
+	}{
+		{release: "1.25", major: 1, minor: 25, valid: true},
+		{release: "v1.9", major: 1, minor: 9, valid: true},
+		{release: "2.0", major: 2, minor: 0, valid: true},
+		{release: "1"},
+		{release: "1.25.3"},
+		{release: "1.x"},
+		{release: "1.-1"},
+		{release: "1.+2"},
+		{release: "1.05"},
+		{release: ""},
+	}
+	for _, tt := range tests {
+		major, minor, err := ParseRelease(tt.release)
+		if !tt.valid {
+			if err == nil {
+				t.Errorf("%q: expected an error", tt.release)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.release, err)
+		} else if major != tt.major || minor != tt.minor {
+			t.Errorf("%q: expected %d.%d, got %d.%d", tt.release, tt.major, tt.minor, major, minor)
+		}
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestDeepCopyJSONValue(t *testing.T) {
+	x := map[string]interface{}{
+		"a": []interface{}{"b", int64(1), 1.5, true, nil},
+		"c": map[string]interface{}{"d": "e"},
+	}
+	clone, err := DeepCopyJSONValue(x)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(clone, x) {
+		t.Errorf("expected %v, got %v", x, clone)
+	}
+	clone.(map[string]interface{})["a"].([]interface{})[0] = "f"
+	clone.(map[string]interface{})["c"].(map[string]interface{})["d"] = "f"
+	if x["a"].([]interface{})[0] != "b" || x["c"].(map[string]interface{})["d"] != "e" {
+		t.Errorf("the copy shares memory with the original: %v", x)
+	}
+
+	if _, err := DeepCopyJSONValue(map[string]interface{}{"a": []interface{}{1}}); err == nil {
+		t.Errorf("expected an error for a value of type int")
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiextensions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefinitionsRefPrefix is the prefix of the $ref of schemas which refer to a definition of the root
+// schema. Other references are not supported.
+const DefinitionsRefPrefix = "#/definitions/"
+
+// maxExpandedSchemas is the maximum number of schemas a schema with references may expand to. It
+// protects against definitions which refer to each other such that the expansion grows exponentially.
+const maxExpandedSchemas = 100000
+
+// HasReferences returns true if the schema has definitions or any of its nested schemas refers to one.
+func HasReferences(s *JSONSchemaProps) bool {
+	if s == nil {
+		return false
+	}
+	if s.Ref != nil || len(s.Definitions) > 0 {
+		return true
+	}
+	found := false
+	forEachNestedSchema(s, func(nested *JSONSchemaProps) error {
+		found = found || HasReferences(nested)
+		return nil
+	})
+	return found
+}
+
+// ExpandReferences returns a copy of the schema in which every schema referring to a definition of
+// the root schema by its $ref is replaced by the definition, keeping the description of the
+// referring schema if it has one. The definitions are dropped. The schema itself is returned if it
+// has no references.
+func ExpandReferences(s *JSONSchemaProps) (*JSONSchemaProps, error) {
+	if !HasReferences(s) {
+		return s, nil
+	}
+	e := &referenceExpander{definitions: s.Definitions, expanding: map[string]bool{}}
+	expanded := s.DeepCopy()
+	expanded.Definitions = nil
+	if err := e.expand(expanded); err != nil {
+		return nil, err
+	}
+	return expanded, nil
+}
+
+// referenceExpander replaces references by the definitions they refer to.
+// +k8s:deepcopy-gen=false
+type referenceExpander struct {
+	definitions JSONSchemaDefinitions
+	// expanding are the definitions currently being expanded, to detect cycles.
+	expanding map[string]bool
+	count     int
+}
+
+func (e *referenceExpander) expand(s *JSONSchemaProps) error {
+	if e.count++; e.count > maxExpandedSchemas {
+		return fmt.Errorf("the references of the schema must not expand to more than %d schemas", maxExpandedSchemas)
+	}
+
+	if s.Ref != nil {
+		ref := *s.Ref
+		if !strings.HasPrefix(ref, DefinitionsRefPrefix) {
+			return fmt.Errorf("unsupported $ref %q: only references to %s<name> are supported", ref, DefinitionsRefPrefix)
+		}
+		name := strings.TrimPrefix(ref, DefinitionsRefPrefix)
+		definition, ok := e.definitions[name]
+		if !ok {
+			return fmt.Errorf("$ref %q refers to an unknown definition", ref)
+		}
+		if e.expanding[name] {
+			return fmt.Errorf("$ref %q is part of a cycle of definitions", ref)
+		}
+		replacement := definition.DeepCopy()
+		e.expanding[name] = true
+		err := e.expand(replacement)
+		delete(e.expanding, name)
+		if err != nil {
+			return err
+		}
+		if len(s.Description) > 0 {
+			replacement.Description = s.Description
+		}
+		*s = *replacement
+		return nil
+	}
+
+	return forEachNestedSchema(s, e.expand)
+}
+
+// forEachNestedSchema calls f for each of the schemas directly nested in s, stopping at the first
+// error. f may modify the schemas in place.
+func forEachNestedSchema(s *JSONSchemaProps, f func(*JSONSchemaProps) error) error {
+	eachInMap := func(schemas map[string]JSONSchemaProps) error {
+		for k, nested := range schemas {
+			if err := f(&nested); err != nil {
+				return err
+			}
+			schemas[k] = nested
+		}
+		return nil
+	}
+	eachInSlice := func(schemas []JSONSchemaProps) error {
+		for i := range schemas {
+			if err := f(&schemas[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	eachInPtr := func(nested *JSONSchemaProps) error {
+		if nested == nil {
+			return nil
+		}
+		return f(nested)
+	}
+
+	if err := eachInMap(s.Properties); err != nil {
+		return err
+	}
+	if err := eachInMap(s.PatternProperties); err != nil {
+		return err
+	}
+	for _, schemas := range [][]JSONSchemaProps{s.AllOf, s.OneOf, s.AnyOf} {
+		if err := eachInSlice(schemas); err != nil {
+			return err
+		}
+	}
+	if err := eachInPtr(s.Not); err != nil {
+		return err
+	}
+	if s.Items != nil {
+		if err := eachInPtr(s.Items.Schema); err != nil {
+			return err
+		}
+		if err := eachInSlice(s.Items.JSONSchemas); err != nil {
+			return err
+		}
+	}
+	if s.AdditionalProperties != nil {
+		if err := eachInPtr(s.AdditionalProperties.Schema); err != nil {
+			return err
+		}
+	}
+	if s.AdditionalItems != nil {
+		if err := eachInPtr(s.AdditionalItems.Schema); err != nil {
+			return err
+		}
+	}
+	for _, dependency := range s.Dependencies {
+		if err := eachInPtr(dependency.Schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiextensions
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpandReferences(t *testing.T) {
+	ref := func(name string) *string {
+		s := DefinitionsRefPrefix + name
+		return &s
+	}
+	port := JSONSchemaProps{Type: "integer", Description: "a port"}
+	tests := []struct {
+		name   string
+		schema *JSONSchemaProps
+
+		expected    *JSONSchemaProps
+		expectedErr string
+	}{
+		{
+			name:     "no references",
+			schema:   &JSONSchemaProps{Type: "object", Properties: map[string]JSONSchemaProps{"a": {Type: "string"}}},
+			expected: &JSONSchemaProps{Type: "object", Properties: map[string]JSONSchemaProps{"a": {Type: "string"}}},
+		},
+		{
+			name: "references in properties and items",
+			schema: &JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]JSONSchemaProps{
+					"source": {Ref: ref("port")},
+					"ports":  {Type: "array", Items: &JSONSchemaPropsOrArray{Schema: &JSONSchemaProps{Ref: ref("port"), Description: "another port"}}},
+				},
+				Definitions: JSONSchemaDefinitions{"port": port},
+			},
+			expected: &JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]JSONSchemaProps{
+					"source": port,
+					"ports":  {Type: "array", Items: &JSONSchemaPropsOrArray{Schema: &JSONSchemaProps{Type: "integer", Description: "another port"}}},
+				},
+			},
+		},
+		{
+			name: "references within definitions",
+			schema: &JSONSchemaProps{
+				Type:       "object",
+				Properties: map[string]JSONSchemaProps{"endpoint": {Ref: ref("endpoint")}},
+				Definitions: JSONSchemaDefinitions{
+					"port":     port,
+					"endpoint": {Type: "object", Properties: map[string]JSONSchemaProps{"port": {Ref: ref("port")}}},
+				},
+			},
+			expected: &JSONSchemaProps{
+				Type:       "object",
+				Properties: map[string]JSONSchemaProps{"endpoint": {Type: "object", Properties: map[string]JSONSchemaProps{"port": port}}},
+			},
+		},
+		{
+			name: "unused definitions",
+			schema: &JSONSchemaProps{
+				Type:        "object",
+				Definitions: JSONSchemaDefinitions{"port": port},
+			},
+			expected: &JSONSchemaProps{Type: "object"},
+		},
+		{
+			name: "unknown definition",
+			schema: &JSONSchemaProps{
+				Type:       "object",
+				Properties: map[string]JSONSchemaProps{"source": {Ref: ref("unknown")}},
+			},
+			expectedErr: "unknown definition",
+		},
+		{
+			name: "non-local reference",
+			schema: &JSONSchemaProps{
+				Type:       "object",
+				Properties: map[string]JSONSchemaProps{"source": {Ref: func() *string { s := "http://example.com/schema.json"; return &s }()}},
+			},
+			expectedErr: "unsupported $ref",
+		},
+		{
+			name: "cycle",
+			schema: &JSONSchemaProps{
+				Type:       "object",
+				Properties: map[string]JSONSchemaProps{"tree": {Ref: ref("tree")}},
+				Definitions: JSONSchemaDefinitions{
+					"tree": {Type: "object", Properties: map[string]JSONSchemaProps{"children": {Type: "array", Items: &JSONSchemaPropsOrArray{Schema: &JSONSchemaProps{Ref: ref("tree")}}}}},
+				},
+			},
+			expectedErr: "cycle",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := ExpandReferences(tc.schema)
+			if len(tc.expectedErr) > 0 {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected %#v, got %#v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestExpandReferencesLimit(t *testing.T) {
+	// every definition refers to the previous one twice, doubling the size of the expansion
+	definitions := JSONSchemaDefinitions{"d0": {Type: "string"}}
+	for i := 1; i <= 20; i++ {
+		previous := fmt.Sprintf("%sd%d", DefinitionsRefPrefix, i-1)
+		definitions[fmt.Sprintf("d%d", i)] = JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]JSONSchemaProps{
+				"left":  {Ref: &previous},
+				"right": {Ref: &previous},
+			},
+		}
+	}
+	last := DefinitionsRefPrefix + "d20"
+	schema := &JSONSchemaProps{Type: "object", Properties: map[string]JSONSchemaProps{"root": {Ref: &last}}, Definitions: definitions}
+	if _, err := ExpandReferences(schema); err == nil || !strings.Contains(err.Error(), "must not expand to more than") {
+		t.Errorf("expected an error about the size of the expansion, got %v", err)
+	}
+}
+
+func TestExpandReferencesDoesNotModifySchema(t *testing.T) {
+	ref := DefinitionsRefPrefix + "port"
+	schema := &JSONSchemaProps{
+		Type:        "object",
+		Properties:  map[string]JSONSchemaProps{"port": {Ref: &ref}},
+		Definitions: JSONSchemaDefinitions{"port": {Type: "integer"}},
+	}
+	original := schema.DeepCopy()
+	if _, err := ExpandReferences(schema); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(schema, original) {
+		t.Errorf("expected the schema to be unchanged, got %#v", schema)
+	}
+}
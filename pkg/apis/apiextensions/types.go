@@ -29,6 +29,286 @@ type CustomResourceDefinitionSpec struct {
 
 	// Scope indicates whether this resource is cluster or namespace scoped.  Default is namespaced
 	Scope ResourceScope
+	// Validation describes the validation methods for CustomResources
+	Validation *CustomResourceValidation
+	// PreserveUnknownFields disables pruning of object fields which are not
+	// specified in the validation schema. Defaults to true.
+	PreserveUnknownFields *bool
+	// Versions is the list of all supported versions for this resource.
+	// If Version field is provided, this field is optional.
+	// Validation, subresources and printer columns: each version may specify its own, in which case
+	// the corresponding top-level field must be empty. Otherwise the top-level field applies to all versions.
+	// Order: The first version in the list is the version reported in the Version field.
+	Versions []CustomResourceDefinitionVersion
+	// Conversion defines conversion settings for the CRD.
+	Conversion *CustomResourceConversion
+	// Subresources describes the subresources for CustomResources
+	Subresources *CustomResourceSubresources
+	// AdditionalPrinterColumns are additional columns shown e.g. in kubectl next to the name. Defaults to a created-at column.
+	AdditionalPrinterColumns []CustomResourceColumnDefinition
+	// SelectableFields specifies paths to fields that may be used as field selectors.
+	SelectableFields []SelectableField
+	// Storage overrides where custom resources are stored in etcd. It can only be set if the server
+	// allows the prefix, and cannot be changed after creation.
+	Storage *CustomResourceStorage
+	// AllowedFinalizerDomains restricts the finalizers which can be added to custom resources to the
+	// ones with a domain prefix which is one of these domains or a subdomain of them, e.g. example.com
+	// allows example.com/cleanup and backup.example.com/snapshot. The orphan and foregroundDeletion
+	// finalizers of the garbage collector are always allowed. Finalizers can always be removed.
+	AllowedFinalizerDomains []string
+}
+
+// CustomResourceStorage describes the etcd storage location of custom resources.
+type CustomResourceStorage struct {
+	// Prefix is the etcd key prefix of the custom resources, relative to the storage prefix of the
+	// server. It replaces the default prefix <group>/<plural> and must not be shared with other
+	// resources.
+	Prefix string
+}
+
+// SelectableField specifies the JSON path of a field that may be used with field selectors.
+type SelectableField struct {
+	// JSONPath is a simple JSON path, i.e. without array notation, which is evaluated against
+	// each custom resource to produce a field selector value. It must point to a string, integer
+	// or boolean field under .spec or .status. The field selector label is the path without the
+	// leading dot, e.g. spec.color for .spec.color.
+	JSONPath string
+}
+
+// CustomResourceDefinitionVersion describes a version of a custom resource.
+// +k8s:deepcopy-gen=false
+type CustomResourceDefinitionVersion struct {
+	// Name is the version name, e.g. "v1", "v2beta1", etc.
+	Name string
+	// Served is a flag enabling/disabling this version from being served via REST APIs
+	Served bool
+	// Storage flags the version as storage version. There must be exactly one flagged
+	// as storage version.
+	Storage bool
+	// Schema describes the schema for CustomResources of this version used in validation, pruning,
+	// and defaulting. Top-level and per-version schemas are mutually exclusive.
+	Schema *CustomResourceValidation
+	// Subresources describes the subresources for CustomResources of this version.
+	// Top-level and per-version subresources are mutually exclusive.
+	Subresources *CustomResourceSubresources
+	// AdditionalPrinterColumns are additional columns shown e.g. in kubectl next to the name for
+	// this version. Top-level and per-version columns are mutually exclusive.
+	AdditionalPrinterColumns []CustomResourceColumnDefinition
+	// Deprecated indicates this version of the custom resource API is deprecated. API requests to
+	// this version receive a warning header in the server response.
+	Deprecated bool
+	// DeprecationWarning overrides the default warning returned to API clients. It may only be set
+	// when Deprecated is true.
+	DeprecationWarning *string
+	// RemovedInRelease is the Kubernetes release, e.g. 1.25, from which on this version is planned to
+	// be removed. It may only be set when Deprecated is true.
+	RemovedInRelease *string
+	// MirrorStorage flags the version in which custom resources are persisted in addition to the
+	// storage version, so that the storage version can be rolled back. At most one version which is
+	// not the storage version may be flagged.
+	MirrorStorage bool
+	// Example is an example custom resource of this version, which must validate against the schema
+	// of this version. apiVersion and kind may be omitted.
+	Example *JSON
+}
+
+// CustomResourceColumnDefinition specifies a column for server side printing.
+type CustomResourceColumnDefinition struct {
+	// name is a human readable name for the column.
+	Name string
+	// type is an OpenAPI type definition for this column.
+	// See https://github.com/OAI/OpenAPI-Specification/blob/master/versions/2.0.md#data-types for more.
+	Type string
+	// format is an optional OpenAPI type definition for this column. The 'name' format is applied
+	// to the primary identifier column to assist in clients identifying column is the resource name.
+	// See https://github.com/OAI/OpenAPI-Specification/blob/master/versions/2.0.md#data-types for more.
+	Format string
+	// description is a human readable description of this column.
+	Description string
+	// priority is an integer defining the relative importance of this column compared to others. Lower
+	// numbers are considered higher priority. Columns that may be omitted in limited space scenarios
+	// should be given a higher priority.
+	Priority int32
+
+	// JSONPath is a simple JSON path, i.e. without array notation.
+	JSONPath string
+}
+
+// ConversionStrategyType describes different conversion types.
+type ConversionStrategyType string
+
+const (
+	// NoneConverter is a converter that only sets apiversion of the CR and leave everything else unchanged.
+	NoneConverter ConversionStrategyType = "None"
+	// WebhookConverter is a converter that calls to an external webhook to convert the CR.
+	WebhookConverter ConversionStrategyType = "Webhook"
+	// ExpressionConverter is a converter that evaluates the field mapping expressions of the CRD to convert the CR.
+	ExpressionConverter ConversionStrategyType = "Expression"
+)
+
+// ConversionFailurePolicyType describes how reads of custom resources are handled if their
+// conversion webhook fails.
+type ConversionFailurePolicyType string
+
+const (
+	// ConversionFailurePolicyFail fails the reads of custom resources which cannot be converted.
+	ConversionFailurePolicyFail ConversionFailurePolicyType = "Fail"
+	// ConversionFailurePolicyIgnore serves custom resources which cannot be converted in the version
+	// they are persisted in, with a warning.
+	ConversionFailurePolicyIgnore ConversionFailurePolicyType = "Ignore"
+)
+
+// CustomResourceConversion describes how to convert different versions of a CR.
+type CustomResourceConversion struct {
+	// Strategy specifies the conversion strategy. Allowed values are:
+	// - `None`: The converter only change the apiVersion and would not touch any other field in the CR.
+	// - `Webhook`: API Server will call to an external webhook to do the conversion. Additional information is needed for this option.
+	// - `Expression`: API Server will evaluate the field mappings in `expressions` to do the conversion.
+	Strategy ConversionStrategyType
+
+	// WebhookClientConfig is the instructions for how to call the webhook if strategy is `Webhook`.
+	WebhookClientConfig *WebhookClientConfig
+
+	// ConversionReviewVersions is an ordered list of preferred `ConversionReview` versions the webhook
+	// expects. The API server uses the first version in the list which it supports, and falls back to the
+	// next one if the webhook does not respond in the version it was sent. Required if strategy is `Webhook`.
+	ConversionReviewVersions []string
+
+	// Expressions describe the conversion between pairs of versions if strategy is `Expression`.
+	// Custom resources converted between versions without an entry only get their apiVersion changed.
+	Expressions []ConversionExpression
+
+	// FailurePolicy defines how reads of custom resources are handled if the webhook fails to convert
+	// them. Allowed values are `Fail`, which fails the reads, and `Ignore`, which serves the custom
+	// resources in the versions they are persisted in, with a Warning header. Writes always fail.
+	// It may only be set if strategy is `Webhook`, nil means `Fail`.
+	FailurePolicy *ConversionFailurePolicyType
+}
+
+// ConversionExpression describes how custom resources are converted from one version to another.
+// Fields without a mapping are copied unchanged.
+type ConversionExpression struct {
+	// FromVersion is the version the custom resources are converted from.
+	FromVersion string
+	// ToVersion is the version the custom resources are converted to.
+	ToVersion string
+	// FieldMappings set fields of the converted custom resources. They are applied in order.
+	FieldMappings []ConversionFieldMapping
+}
+
+// ConversionFieldMapping sets a field of a converted custom resource.
+type ConversionFieldMapping struct {
+	// Path is the dot-separated path of the field, e.g. `spec.replicas`. Missing parent objects
+	// are created. The path must not start with apiVersion, kind or metadata.
+	Path string
+	// Expression is a CEL expression computing the value of the field. `self` is the custom resource
+	// in the version it is converted from. The field is removed if the expression evaluates to null.
+	Expression string
+}
+
+// WebhookClientConfig contains the information to make a TLS
+// connection with the webhook. It has the same field as admissionregistration.internal.WebhookClientConfig.
+type WebhookClientConfig struct {
+	// URL gives the location of the webhook, in standard URL form
+	// (`scheme://host:port/path`). Exactly one of `url` or `service`
+	// must be specified.
+	//
+	// The scheme must be "https"; the URL must begin with "https://".
+	//
+	// Attempting to use a user or basic auth e.g. "user:password@" is not
+	// allowed. Fragments ("#...") and query parameters ("?...") are not
+	// allowed, either.
+	URL *string
+
+	// Service is a reference to the service for this webhook. Either
+	// Service or URL must be specified.
+	//
+	// If the webhook is running within the cluster, then you should use `service`.
+	//
+	// Port 443 will be used if it is open, otherwise it is an error.
+	Service *ServiceReference
+
+	// CABundle is a PEM encoded CA bundle which will be used to validate the webhook's server certificate.
+	// If unspecified, system trust roots on the apiserver are used.
+	CABundle []byte
+}
+
+// ServiceReference holds a reference to Service.legacy.k8s.io
+type ServiceReference struct {
+	// Namespace is the namespace of the service.
+	// Required
+	Namespace string
+	// Name is the name of the service.
+	// Required
+	Name string
+
+	// Path is an optional URL path which will be sent in any request to
+	// this service.
+	Path *string
+}
+
+// CustomResourceSubresources defines the status and scale subresources for CustomResources.
+type CustomResourceSubresources struct {
+	// Scale denotes the scale subresource for CustomResources
+	Scale *CustomResourceSubresourceScale
+	// Status denotes the status subresource for CustomResources
+	Status *CustomResourceSubresourceStatus
+	// Custom are additional subresources for CustomResources, which update a subset of their fields
+	Custom []CustomResourceSubresourceCustom
+}
+
+// CustomResourceSubresourceCustom defines an additional subresource for CustomResources, e.g. /approve.
+// Like the status subresource, it takes and returns custom resource objects, but updates only the
+// fields of its FieldPaths. Access to it is authorized separately, e.g. by RBAC rules for
+// <plural>/<name>, such that privileged partial updates can be granted without granting updates of
+// the whole custom resource.
+type CustomResourceSubresourceCustom struct {
+	// Name is the name of the subresource. It must be a lowercase DNS label other than status and scale.
+	Name string
+	// FieldPaths are the JSON paths of the fields which are updated through the subresource, e.g.
+	// .spec.approved. Changes to all other fields are ignored. Only JSON paths without the array
+	// notation under .spec or .status are allowed.
+	FieldPaths []string
+	// Verbs are the verbs the subresource is served for: get, update and patch.
+	Verbs []string
+	// Validation describes the schema the custom resources updated through the subresource must
+	// satisfy, in addition to the schema of the version, e.g. to restrict the values of the fields.
+	Validation *CustomResourceValidation
+}
+
+// CustomResourceSubresourceStatus defines how to serve the status subresource for CustomResources.
+// Status is represented by the `.status` JSON path inside of a CustomResource. When set,
+// * exposes a /status subresource for the custom resource
+// * PUT requests to the /status subresource take a custom resource object, and ignore changes to anything except the status stanza
+// * PUT/POST/PATCH requests to the custom resource ignore changes to the status stanza
+// * metadata.generation is incremented on every change of the custom resource except for changes to the status stanza
+// +k8s:deepcopy-gen=false
+type CustomResourceSubresourceStatus struct {
+	// InitialStatus is the status stanza of newly created custom resources, e.g. a phase or seeded conditions,
+	// such that clients never read an empty status before the controller first updates it. The defaults of the
+	// status schema are applied to it. It must be an object.
+	InitialStatus *JSON
+}
+
+// CustomResourceSubresourceScale defines how to serve the scale subresource for CustomResources.
+type CustomResourceSubresourceScale struct {
+	// SpecReplicasPath defines the JSON path inside of a CustomResource that corresponds to Scale.Spec.Replicas.
+	// Only JSON paths without the array notation are allowed.
+	// Must be a JSON Path under .spec.
+	// If there is no value under the given path in the CustomResource, the /scale subresource will return an error on GET.
+	SpecReplicasPath string
+	// StatusReplicasPath defines the JSON path inside of a CustomResource that corresponds to Scale.Status.Replicas.
+	// Only JSON paths without the array notation are allowed.
+	// Must be a JSON Path under .status.
+	// If there is no value under the given path in the CustomResource, the status replica value in the /scale subresource
+	// will default to 0.
+	StatusReplicasPath string
+	// LabelSelectorPath defines the JSON path inside of a CustomResource that corresponds to Scale.Status.Selector.
+	// Only JSON paths without the array notation are allowed.
+	// Must be a JSON Path under .status or .spec.
+	// If there is no value under the given path in the CustomResource, the status label selector value in the /scale
+	// subresource will default to the empty string.
+	LabelSelectorPath *string
 }
 
 // CustomResourceDefinitionNames indicates the names to serve this CustomResourceDefinition
@@ -44,6 +324,8 @@ type CustomResourceDefinitionNames struct {
 	Kind string
 	// ListKind is the serialized kind of the list for this resource.  Defaults to <kind>List.
 	ListKind string
+	// Categories is a list of grouped resources custom resources belong to (e.g. 'all')
+	Categories []string
 }
 
 // ResourceScope is an enum defining the different scopes availabe to a custom resource
@@ -79,6 +361,34 @@ const (
 	NamesAccepted CustomResourceDefinitionConditionType = "NamesAccepted"
 	// Terminating means that the CustomResourceDefinition has been deleted and is cleaning up.
 	Terminating CustomResourceDefinitionConditionType = "Terminating"
+	// ConversionReviewNegotiated means that the conversion webhook responded to a ConversionReview of one of
+	// the conversionReviewVersions. The message names the version which is used.
+	ConversionReviewNegotiated CustomResourceDefinitionConditionType = "ConversionReviewNegotiated"
+	// ScopeChangeRejected means that the last change of the scope was rejected. The message explains why.
+	ScopeChangeRejected CustomResourceDefinitionConditionType = "ScopeChangeRejected"
+	// ConversionDegraded means that the conversion webhook failed repeatedly and conversions fail fast
+	// without calling it for a while. It is false again once the webhook responds.
+	ConversionDegraded CustomResourceDefinitionConditionType = "ConversionDegraded"
+	// KubernetesAPIApprovalPolicyConformant indicates that a CustomResourceDefinition in a group owned by the
+	// Kubernetes project, i.e. *.k8s.io or *.kubernetes.io, links its API approval in the
+	// api-approved.kubernetes.io annotation. It is only set for CustomResourceDefinitions in these groups.
+	KubernetesAPIApprovalPolicyConformant CustomResourceDefinitionConditionType = "KubernetesAPIApprovalPolicyConformant"
+	// StoredVersionsMigrated means that all custom resources were migrated to the storage version and the
+	// other versions were removed from status.storedVersions. It is false if the last migration failed. It is
+	// set once a migration was necessary.
+	StoredVersionsMigrated CustomResourceDefinitionConditionType = "StoredVersionsMigrated"
+	// NonStructuralSchema means that the schema of a served version is not structural. Such versions are
+	// published in the OpenAPI documents without their schema, e.g. kubectl explain does not know their
+	// fields. The message lists the violations. It is only set while a schema is not structural.
+	NonStructuralSchema CustomResourceDefinitionConditionType = "NonStructuralSchema"
+	// RemovalReleasePassed means that the cluster runs at or past the removedInRelease of a served version.
+	// The message lists these versions. It is false while the removal releases of all served versions are
+	// ahead, and only set while a served version has a removedInRelease.
+	RemovalReleasePassed CustomResourceDefinitionConditionType = "RemovalReleasePassed"
+	// BreakingSchemaChange means that the last change of the spec changed the schemas of served versions
+	// incompatibly, e.g. removed properties, and was accepted by the schema compatibility policy of the
+	// server. The message lists the breaking changes. It is removed by the next change of the spec.
+	BreakingSchemaChange CustomResourceDefinitionConditionType = "BreakingSchemaChange"
 )
 
 // CustomResourceDefinitionCondition contains details for the current condition of this pod.
@@ -97,6 +407,10 @@ type CustomResourceDefinitionCondition struct {
 	// Human-readable message indicating details about last transition.
 	// +optional
 	Message string
+	// ObservedGeneration is the metadata.generation of the CustomResourceDefinition the condition was
+	// set for. The condition is outdated if it is lower than the current metadata.generation.
+	// +optional
+	ObservedGeneration int64
 }
 
 // CustomResourceDefinitionStatus indicates the state of the CustomResourceDefinition
@@ -107,6 +421,26 @@ type CustomResourceDefinitionStatus struct {
 	// AcceptedNames are the names that are actually being used to serve discovery
 	// They may be different than the names in spec.
 	AcceptedNames CustomResourceDefinitionNames
+
+	// StoredVersions are all versions of CustomResources that were ever persisted. Tracking these
+	// versions allows a migration path for stored versions in etcd. The field is mutable
+	// so the migration controller can first finish a migration to another version (i.e.
+	// that no old objects are left in the storage), and then remove the rest of the
+	// versions from this list.
+	// None of the versions in this list can be removed from the spec.Versions field.
+	StoredVersions []string
+
+	// PersistedVersions are the numbers of CustomResources persisted in each version, as counted
+	// periodically by the server. Versions without persisted objects are not listed.
+	PersistedVersions []PersistedVersionCount
+}
+
+// PersistedVersionCount is the number of CustomResources persisted in a version.
+type PersistedVersionCount struct {
+	// Version is the name of the version.
+	Version string
+	// Count is the number of CustomResources persisted in the version.
+	Count int64
 }
 
 // CustomResourceCleanupFinalizer is the name of the finalizer which will delete instances of
@@ -139,3 +473,9 @@ type CustomResourceDefinitionList struct {
 	// Items individual CustomResourceDefinitions
 	Items []CustomResourceDefinition
 }
+
+// CustomResourceValidation is a list of validation methods for CustomResources.
+type CustomResourceValidation struct {
+	// OpenAPIV3Schema is the OpenAPI v3 schema to be validated against.
+	OpenAPIV3Schema *JSONSchemaProps
+}
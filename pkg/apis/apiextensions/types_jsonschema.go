@@ -0,0 +1,171 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiextensions
+
+// JSONSchemaProps is a JSON-Schema following Specification Draft 4 (http://json-schema.org/).
+// +k8s:deepcopy-gen=false
+type JSONSchemaProps struct {
+	ID                   string
+	Schema               JSONSchemaURL
+	Ref                  *string
+	Description          string
+	Type                 string
+	Format               string
+	Title                string
+	Default              *JSON
+	Maximum              *float64
+	ExclusiveMaximum     bool
+	Minimum              *float64
+	ExclusiveMinimum     bool
+	MaxLength            *int64
+	MinLength            *int64
+	Pattern              string
+	MaxItems             *int64
+	MinItems             *int64
+	UniqueItems          bool
+	MultipleOf           *float64
+	Enum                 []JSON
+	MaxProperties        *int64
+	MinProperties        *int64
+	Required             []string
+	Items                *JSONSchemaPropsOrArray
+	AllOf                []JSONSchemaProps
+	OneOf                []JSONSchemaProps
+	AnyOf                []JSONSchemaProps
+	Not                  *JSONSchemaProps
+	Properties           map[string]JSONSchemaProps
+	AdditionalProperties *JSONSchemaPropsOrBool
+	PatternProperties    map[string]JSONSchemaProps
+	Dependencies         JSONSchemaDependencies
+	AdditionalItems      *JSONSchemaPropsOrBool
+	Definitions          JSONSchemaDefinitions
+	ExternalDocs         *ExternalDocumentation
+	Example              *JSON
+
+	// XValidations describes a list of validation rules written in the CEL expression language.
+	XValidations ValidationRules
+
+	// XPreserveUnknownFields stops the pruning of fields which are not specified in the schema of an
+	// object. Specified fields are still pruned recursively. It must be true or nil.
+	XPreserveUnknownFields *bool
+	// XEmbeddedResource defines that the value is an embedded Kubernetes runtime.Object, with
+	// apiVersion, kind and metadata. These are implicitly specified and not pruned. The type must be
+	// object or empty.
+	XEmbeddedResource bool
+	// XIntOrString specifies that the value is either an integer or a string. The type must be empty.
+	XIntOrString bool
+	// XListType specifies how apply merges arrays: "atomic" (the default) replaces the whole array,
+	// "set" merges scalar items by value and "map" merges object items by the properties in XListMapKeys.
+	XListType *string
+	// XListMapKeys are the names of the item properties which identify the items of an array with
+	// XListType "map". The properties must be scalar and required.
+	XListMapKeys []string
+	// XMapType specifies how apply merges objects: "granular" merges them field by field, "atomic"
+	// replaces the whole object. By default objects with specified fields are granular.
+	XMapType *string
+	// XImmutable specifies that the value must not be changed or removed on update once it is set. It
+	// is not allowed at the root of the schema and within the items of arrays other than map lists.
+	XImmutable bool
+}
+
+// ValidationRules describes a list of validation rules written in the CEL expression language.
+type ValidationRules []ValidationRule
+
+// ValidationRule describes a validation rule written in the CEL expression language.
+type ValidationRule struct {
+	// Rule represents the expression which will be evaluated by CEL.
+	// The `self` variable in the CEL expression is bound to the scoped value.
+	// Rules referencing the `oldSelf` variable, which is bound to the old value, are transition
+	// rules. They are only evaluated on updates of values which existed before, and are not allowed
+	// within array items unless the array has x-kubernetes-list-type map.
+	// Example:
+	// - Rule scoped to the root of a resource with a status subresource: {"rule": "self.status.actual <= self.spec.maxDesired"}
+	// - Rule of an immutable field: {"rule": "self == oldSelf"}
+	Rule string
+	// Message represents the message displayed when validation fails. If unset, the message is
+	// "failed rule: {Rule}".
+	Message string
+	// MessageExpression is a CEL expression which computes the message displayed when validation
+	// fails, with the same variables as the rule. It must evaluate to a string and takes precedence
+	// over Message. If it fails to evaluate or evaluates to an empty string, Message or the default
+	// message is used instead.
+	MessageExpression string
+	// Reason is the reason of the validation error returned if the rule fails. If unset, it is
+	// FieldValueInvalid.
+	Reason FieldValueErrorReason
+	// FieldPath is the JSON path, relative to the value the schema applies to, of the field the
+	// validation error is reported for if the rule fails, e.g. ".spec.replicas". Only fields
+	// declared in the schema are allowed. If unset, the error is reported for the value the schema
+	// applies to.
+	FieldPath string
+}
+
+// FieldValueErrorReason is the reason of the validation error of a validation rule.
+type FieldValueErrorReason string
+
+const (
+	// FieldValueRequired is used to report required values that are not provided.
+	FieldValueRequired FieldValueErrorReason = "FieldValueRequired"
+	// FieldValueDuplicate is used to report collisions of values that must be unique.
+	FieldValueDuplicate FieldValueErrorReason = "FieldValueDuplicate"
+	// FieldValueInvalid is used to report malformed values.
+	FieldValueInvalid FieldValueErrorReason = "FieldValueInvalid"
+	// FieldValueForbidden is used to report valid but forbidden values.
+	FieldValueForbidden FieldValueErrorReason = "FieldValueForbidden"
+)
+
+// JSON represents any valid JSON value.
+// These types are supported: bool, int64, float64, string, []interface{}, map[string]interface{} and nil.
+type JSON interface{}
+
+// JSONSchemaURL represents a schema url.
+type JSONSchemaURL string
+
+// JSONSchemaPropsOrArray represents a value that can either be a JSONSchemaProps
+// or an array of JSONSchemaProps. Mainly here for serialization purposes.
+type JSONSchemaPropsOrArray struct {
+	Schema      *JSONSchemaProps
+	JSONSchemas []JSONSchemaProps
+}
+
+// JSONSchemaPropsOrBool represents JSONSchemaProps or a boolean value.
+// Defaults to true for the boolean property.
+type JSONSchemaPropsOrBool struct {
+	Allows bool
+	Schema *JSONSchemaProps
+}
+
+// JSONSchemaDependencies represent a dependencies property.
+type JSONSchemaDependencies map[string]JSONSchemaPropsOrStringArray
+
+// JSONSchemaPropsOrStringArray represents a JSONSchemaProps or a string array.
+type JSONSchemaPropsOrStringArray struct {
+	Schema   *JSONSchemaProps
+	Property []string
+}
+
+// JSONSchemaDefinitions contains the models explicitly defined in this spec.
+// Definitions may only be specified at the root of a schema. Nested schemas refer to them with a
+// $ref of the form "#/definitions/<name>", along with which only a description may be specified.
+// The referring schema is replaced by the definition, keeping its description if it has one.
+type JSONSchemaDefinitions map[string]JSONSchemaProps
+
+// ExternalDocumentation allows referencing an external resource for extended documentation.
+type ExternalDocumentation struct {
+	Description string
+	URL         string
+}
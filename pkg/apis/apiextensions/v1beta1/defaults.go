@@ -19,9 +19,12 @@ package v1beta1
 import (
 	"strings"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+var swaggerMetadataDescriptions = metav1.ObjectMeta{}.SwaggerDoc()
+
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
 	scheme.AddTypeDefaultingFunc(&CustomResourceDefinition{}, func(obj interface{}) { SetDefaults_CustomResourceDefinition(obj.(*CustomResourceDefinition)) })
 	// TODO figure out why I can't seem to get my defaulter generated
@@ -43,4 +46,66 @@ func SetDefaults_CustomResourceDefinitionSpec(obj *CustomResourceDefinitionSpec)
 	if len(obj.Names.ListKind) == 0 && len(obj.Names.Kind) > 0 {
 		obj.Names.ListKind = obj.Names.Kind + "List"
 	}
+	if obj.PreserveUnknownFields == nil {
+		obj.PreserveUnknownFields = boolPtr(true)
+	}
+	// If there is no list of versions, create one using the deprecated Version field.
+	if len(obj.Versions) == 0 && len(obj.Version) != 0 {
+		obj.Versions = []CustomResourceDefinitionVersion{{
+			Name:    obj.Version,
+			Served:  true,
+			Storage: true,
+		}}
+	}
+	// For backward compatibility set the version field to the first item in the versions list.
+	if len(obj.Version) == 0 && len(obj.Versions) != 0 {
+		obj.Version = obj.Versions[0].Name
+	}
+	if obj.Conversion == nil {
+		obj.Conversion = &CustomResourceConversion{
+			Strategy: NoneConverter,
+		}
+	}
+	if obj.Conversion.Strategy == WebhookConverter && len(obj.Conversion.ConversionReviewVersions) == 0 {
+		obj.Conversion.ConversionReviewVersions = []string{"v1beta1"}
+	}
+	if obj.Conversion.Strategy == WebhookConverter && obj.Conversion.FailurePolicy == nil {
+		failurePolicy := ConversionFailurePolicyFail
+		obj.Conversion.FailurePolicy = &failurePolicy
+	}
+	setDefaultsCustomSubresources(obj.Subresources)
+	for i := range obj.Versions {
+		setDefaultsCustomSubresources(obj.Versions[i].Subresources)
+	}
+	if len(obj.AdditionalPrinterColumns) == 0 && !hasPerVersionColumns(obj.Versions) {
+		obj.AdditionalPrinterColumns = []CustomResourceColumnDefinition{
+			{Name: "Age", Type: "date", Description: swaggerMetadataDescriptions["creationTimestamp"], JSONPath: ".metadata.creationTimestamp"},
+		}
+	}
+}
+
+// setDefaultsCustomSubresources serves custom subresources for all verbs by default.
+func setDefaultsCustomSubresources(subresources *CustomResourceSubresources) {
+	if subresources == nil {
+		return
+	}
+	for i := range subresources.Custom {
+		if len(subresources.Custom[i].Verbs) == 0 {
+			subresources.Custom[i].Verbs = []string{"get", "update", "patch"}
+		}
+	}
+}
+
+// hasPerVersionColumns returns true if any of the versions specifies printer columns.
+func hasPerVersionColumns(versions []CustomResourceDefinitionVersion) bool {
+	for _, v := range versions {
+		if len(v.AdditionalPrinterColumns) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func boolPtr(b bool) *bool {
+	return &b
 }
@@ -16,7 +16,11 @@ limitations under the License.
 
 package v1beta1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
 
 // CustomResourceDefinitionSpec describes how a user wants their resource to appear
 type CustomResourceDefinitionSpec struct {
@@ -29,6 +33,335 @@ type CustomResourceDefinitionSpec struct {
 
 	// Scope indicates whether this resource is cluster or namespace scoped.  Default is namespaced
 	Scope ResourceScope `json:"scope" protobuf:"bytes,4,opt,name=scope,casttype=ResourceScope"`
+	// Validation describes the validation methods for CustomResources
+	// +optional
+	Validation *CustomResourceValidation `json:"validation,omitempty" protobuf:"bytes,5,opt,name=validation"`
+	// PreserveUnknownFields disables pruning of object fields which are not
+	// specified in the validation schema. If false, fields of custom resources
+	// which are not specified in validation.openAPIV3Schema are dropped before
+	// the object is persisted. Defaults to true.
+	// +optional
+	PreserveUnknownFields *bool `json:"preserveUnknownFields,omitempty" protobuf:"varint,6,opt,name=preserveUnknownFields"`
+	// Versions is the list of all supported versions for this resource.
+	// If Version field is provided, this field is optional.
+	// Validation, subresources and printer columns: each version may specify its own, in which case
+	// the corresponding top-level field must be empty. Otherwise the top-level field applies to all versions.
+	// Order: The first version in the list is the version reported in the Version field.
+	// +optional
+	Versions []CustomResourceDefinitionVersion `json:"versions,omitempty" protobuf:"bytes,7,rep,name=versions"`
+	// Conversion defines conversion settings for the CRD.
+	// +optional
+	Conversion *CustomResourceConversion `json:"conversion,omitempty" protobuf:"bytes,8,opt,name=conversion"`
+	// Subresources describes the subresources for CustomResources
+	// +optional
+	Subresources *CustomResourceSubresources `json:"subresources,omitempty" protobuf:"bytes,9,opt,name=subresources"`
+	// AdditionalPrinterColumns are additional columns shown e.g. in kubectl next to the name. Defaults to a created-at column.
+	// +optional
+	AdditionalPrinterColumns []CustomResourceColumnDefinition `json:"additionalPrinterColumns,omitempty" protobuf:"bytes,10,rep,name=additionalPrinterColumns"`
+	// SelectableFields specifies paths to fields that may be used as field selectors.
+	// +optional
+	SelectableFields []SelectableField `json:"selectableFields,omitempty" protobuf:"bytes,11,rep,name=selectableFields"`
+	// Storage overrides where custom resources are stored in etcd. It can only be set if the server
+	// allows the prefix, and cannot be changed after creation.
+	// +optional
+	Storage *CustomResourceStorage `json:"storage,omitempty" protobuf:"bytes,12,opt,name=storage"`
+	// AllowedFinalizerDomains restricts the finalizers which can be added to custom resources to the
+	// ones with a domain prefix which is one of these domains or a subdomain of them, e.g. example.com
+	// allows example.com/cleanup and backup.example.com/snapshot. The orphan and foregroundDeletion
+	// finalizers of the garbage collector are always allowed. Finalizers can always be removed.
+	// +optional
+	AllowedFinalizerDomains []string `json:"allowedFinalizerDomains,omitempty" protobuf:"bytes,13,rep,name=allowedFinalizerDomains"`
+}
+
+// CustomResourceStorage describes the etcd storage location of custom resources.
+type CustomResourceStorage struct {
+	// Prefix is the etcd key prefix of the custom resources, relative to the storage prefix of the
+	// server. It replaces the default prefix <group>/<plural> and must not be shared with other
+	// resources.
+	Prefix string `json:"prefix" protobuf:"bytes,1,opt,name=prefix"`
+}
+
+// SelectableField specifies the JSON path of a field that may be used with field selectors.
+type SelectableField struct {
+	// JSONPath is a simple JSON path, i.e. without array notation, which is evaluated against
+	// each custom resource to produce a field selector value. It must point to a string, integer
+	// or boolean field under .spec or .status. The field selector label is the path without the
+	// leading dot, e.g. spec.color for .spec.color.
+	JSONPath string `json:"jsonPath" protobuf:"bytes,1,opt,name=jsonPath"`
+}
+
+// CustomResourceDefinitionVersion describes a version of a custom resource.
+type CustomResourceDefinitionVersion struct {
+	// Name is the version name, e.g. "v1", "v2beta1", etc.
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Served is a flag enabling/disabling this version from being served via REST APIs
+	Served bool `json:"served" protobuf:"varint,2,opt,name=served"`
+	// Storage flags the version as storage version. There must be exactly one
+	// flagged as storage version.
+	Storage bool `json:"storage" protobuf:"varint,3,opt,name=storage"`
+	// Schema describes the schema for CustomResources of this version used in validation, pruning,
+	// and defaulting. Top-level and per-version schemas are mutually exclusive. Per-version schemas
+	// must not all be set to identical values, the top-level validation should be used instead.
+	// +optional
+	Schema *CustomResourceValidation `json:"schema,omitempty" protobuf:"bytes,4,opt,name=schema"`
+	// Subresources describes the subresources for CustomResources of this version.
+	// Top-level and per-version subresources are mutually exclusive. Per-version subresources must
+	// not all be set to identical values, the top-level subresources should be used instead.
+	// +optional
+	Subresources *CustomResourceSubresources `json:"subresources,omitempty" protobuf:"bytes,5,opt,name=subresources"`
+	// AdditionalPrinterColumns are additional columns shown e.g. in kubectl next to the name for
+	// this version. Top-level and per-version columns are mutually exclusive. Per-version columns
+	// must not all be set to identical values, the top-level columns should be used instead.
+	// If neither are specified, a created-at column is used.
+	// +optional
+	AdditionalPrinterColumns []CustomResourceColumnDefinition `json:"additionalPrinterColumns,omitempty" protobuf:"bytes,6,rep,name=additionalPrinterColumns"`
+	// Deprecated indicates this version of the custom resource API is deprecated. When set to true,
+	// API requests to this version receive a warning header in the server response.
+	// Defaults to false.
+	// +optional
+	Deprecated bool `json:"deprecated,omitempty" protobuf:"varint,7,opt,name=deprecated"`
+	// DeprecationWarning overrides the default warning returned to API clients. It may only be set
+	// when Deprecated is true. The default warning indicates this version is deprecated and
+	// recommends the first served version in Versions which is not deprecated, if one exists.
+	// +optional
+	DeprecationWarning *string `json:"deprecationWarning,omitempty" protobuf:"bytes,8,opt,name=deprecationWarning"`
+	// RemovedInRelease is the Kubernetes release, in the form <major>.<minor> like 1.25, from which on
+	// this version is planned to be removed. It may only be set when Deprecated is true. It is
+	// informational: the version is still served, but the RemovalReleasePassed condition is set
+	// once the cluster runs this release or a later one.
+	// +optional
+	RemovedInRelease *string `json:"removedInRelease,omitempty" protobuf:"bytes,10,opt,name=removedInRelease"`
+	// MirrorStorage flags the version in which custom resources are persisted in addition to the
+	// storage version while a change of the storage version is rolled out. Custom resources written
+	// while it is set can be read without conversion after the storage version is changed back to
+	// this version. The mirrored copy is persisted alongside the object and counts against its size.
+	// At most one version which is not the storage version may be flagged. Defaults to false.
+	// +optional
+	MirrorStorage bool `json:"mirrorStorage,omitempty" protobuf:"varint,9,opt,name=mirrorStorage"`
+	// Example is an example custom resource of this version, e.g. a starter manifest offered by UIs.
+	// It must validate against the schema of this version. apiVersion and kind may be omitted, they
+	// are set when the example is served at /crd-examples/<name>/<version>.
+	// +optional
+	Example *JSON `json:"example,omitempty" protobuf:"bytes,11,opt,name=example"`
+}
+
+// CustomResourceColumnDefinition specifies a column for server side printing.
+type CustomResourceColumnDefinition struct {
+	// name is a human readable name for the column.
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// type is an OpenAPI type definition for this column.
+	// See https://github.com/OAI/OpenAPI-Specification/blob/master/versions/2.0.md#data-types for more.
+	Type string `json:"type" protobuf:"bytes,2,opt,name=type"`
+	// format is an optional OpenAPI type definition for this column. The 'name' format is applied
+	// to the primary identifier column to assist in clients identifying column is the resource name.
+	// See https://github.com/OAI/OpenAPI-Specification/blob/master/versions/2.0.md#data-types for more.
+	// +optional
+	Format string `json:"format,omitempty" protobuf:"bytes,3,opt,name=format"`
+	// description is a human readable description of this column.
+	// +optional
+	Description string `json:"description,omitempty" protobuf:"bytes,4,opt,name=description"`
+	// priority is an integer defining the relative importance of this column compared to others. Lower
+	// numbers are considered higher priority. Columns that may be omitted in limited space scenarios
+	// should be given a higher priority.
+	// +optional
+	Priority int32 `json:"priority,omitempty" protobuf:"varint,5,opt,name=priority"`
+
+	// JSONPath is a simple JSON path, i.e. without array notation.
+	JSONPath string `json:"JSONPath" protobuf:"bytes,6,opt,name=JSONPath"`
+}
+
+// ConversionStrategyType describes different conversion types.
+type ConversionStrategyType string
+
+const (
+	// NoneConverter is a converter that only sets apiversion of the CR and leave everything else unchanged.
+	NoneConverter ConversionStrategyType = "None"
+	// WebhookConverter is a converter that calls to an external webhook to convert the CR.
+	WebhookConverter ConversionStrategyType = "Webhook"
+	// ExpressionConverter is a converter that evaluates the field mapping expressions of the CRD to convert the CR.
+	ExpressionConverter ConversionStrategyType = "Expression"
+)
+
+// ConversionFailurePolicyType describes how reads of custom resources are handled if their
+// conversion webhook fails.
+type ConversionFailurePolicyType string
+
+const (
+	// ConversionFailurePolicyFail fails the reads of custom resources which cannot be converted.
+	ConversionFailurePolicyFail ConversionFailurePolicyType = "Fail"
+	// ConversionFailurePolicyIgnore serves custom resources which cannot be converted in the version
+	// they are persisted in, with a warning.
+	ConversionFailurePolicyIgnore ConversionFailurePolicyType = "Ignore"
+)
+
+// CustomResourceConversion describes how to convert different versions of a CR.
+type CustomResourceConversion struct {
+	// Strategy specifies the conversion strategy. Allowed values are:
+	// - `None`: The converter only change the apiVersion and would not touch any other field in the CR.
+	// - `Webhook`: API Server will call to an external webhook to do the conversion. Additional information is needed for this option.
+	// - `Expression`: API Server will evaluate the field mappings in `expressions` to do the conversion.
+	Strategy ConversionStrategyType `json:"strategy" protobuf:"bytes,1,name=strategy"`
+
+	// WebhookClientConfig is the instructions for how to call the webhook if strategy is `Webhook`.
+	// +optional
+	WebhookClientConfig *WebhookClientConfig `json:"webhookClientConfig,omitempty" protobuf:"bytes,2,name=webhookClientConfig"`
+
+	// ConversionReviewVersions is an ordered list of preferred `ConversionReview` versions the webhook
+	// expects. The API server uses the first version in the list which it supports, and falls back to the
+	// next one if the webhook does not respond in the version it was sent. Defaults to `["v1beta1"]`
+	// if strategy is `Webhook`.
+	// +optional
+	ConversionReviewVersions []string `json:"conversionReviewVersions,omitempty" protobuf:"bytes,3,rep,name=conversionReviewVersions"`
+
+	// Expressions describe the conversion between pairs of versions if strategy is `Expression`.
+	// Custom resources converted between versions without an entry only get their apiVersion changed.
+	// +optional
+	Expressions []ConversionExpression `json:"expressions,omitempty" protobuf:"bytes,4,rep,name=expressions"`
+
+	// FailurePolicy defines how reads of custom resources are handled if the webhook fails to convert
+	// them. Allowed values are `Fail`, which fails the reads, and `Ignore`, which serves the custom
+	// resources in the versions they are persisted in, with a Warning header. Writes always fail.
+	// It may only be set if strategy is `Webhook`. Defaults to `Fail` if strategy is `Webhook`.
+	// +optional
+	FailurePolicy *ConversionFailurePolicyType `json:"failurePolicy,omitempty" protobuf:"bytes,5,opt,name=failurePolicy,casttype=ConversionFailurePolicyType"`
+}
+
+// ConversionExpression describes how custom resources are converted from one version to another.
+// Fields without a mapping are copied unchanged.
+type ConversionExpression struct {
+	// FromVersion is the version the custom resources are converted from.
+	FromVersion string `json:"fromVersion" protobuf:"bytes,1,opt,name=fromVersion"`
+	// ToVersion is the version the custom resources are converted to.
+	ToVersion string `json:"toVersion" protobuf:"bytes,2,opt,name=toVersion"`
+	// FieldMappings set fields of the converted custom resources. They are applied in order.
+	FieldMappings []ConversionFieldMapping `json:"fieldMappings" protobuf:"bytes,3,rep,name=fieldMappings"`
+}
+
+// ConversionFieldMapping sets a field of a converted custom resource.
+type ConversionFieldMapping struct {
+	// Path is the dot-separated path of the field, e.g. `spec.replicas`. Missing parent objects
+	// are created. The path must not start with apiVersion, kind or metadata.
+	Path string `json:"path" protobuf:"bytes,1,opt,name=path"`
+	// Expression is a CEL expression computing the value of the field. `self` is the custom resource
+	// in the version it is converted from. The field is removed if the expression evaluates to null.
+	Expression string `json:"expression" protobuf:"bytes,2,opt,name=expression"`
+}
+
+// WebhookClientConfig contains the information to make a TLS
+// connection with the webhook.
+type WebhookClientConfig struct {
+	// URL gives the location of the webhook, in standard URL form
+	// (`scheme://host:port/path`). Exactly one of `url` or `service`
+	// must be specified.
+	//
+	// The scheme must be "https"; the URL must begin with "https://".
+	//
+	// Attempting to use a user or basic auth e.g. "user:password@" is not
+	// allowed. Fragments ("#...") and query parameters ("?...") are not
+	// allowed, either.
+	//
+	// +optional
+	URL *string `json:"url,omitempty" protobuf:"bytes,3,opt,name=url"`
+
+	// Service is a reference to the service for this webhook. Either
+	// Service or URL must be specified.
+	//
+	// If the webhook is running within the cluster, then you should use `service`.
+	//
+	// Port 443 will be used if it is open, otherwise it is an error.
+	//
+	// +optional
+	Service *ServiceReference `json:"service,omitempty" protobuf:"bytes,1,opt,name=service"`
+
+	// CABundle is a PEM encoded CA bundle which will be used to validate the webhook's server certificate.
+	// If unspecified, system trust roots on the apiserver are used.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty" protobuf:"bytes,2,opt,name=caBundle"`
+}
+
+// ServiceReference holds a reference to Service.legacy.k8s.io
+type ServiceReference struct {
+	// Namespace is the namespace of the service.
+	// Required
+	Namespace string `json:"namespace" protobuf:"bytes,1,opt,name=namespace"`
+	// Name is the name of the service.
+	// Required
+	Name string `json:"name" protobuf:"bytes,2,opt,name=name"`
+
+	// Path is an optional URL path which will be sent in any request to
+	// this service.
+	// +optional
+	Path *string `json:"path,omitempty" protobuf:"bytes,3,opt,name=path"`
+}
+
+// CustomResourceSubresources defines the status and scale subresources for CustomResources.
+type CustomResourceSubresources struct {
+	// Scale denotes the scale subresource for CustomResources
+	// +optional
+	Scale *CustomResourceSubresourceScale `json:"scale,omitempty" protobuf:"bytes,1,opt,name=scale"`
+	// Status denotes the status subresource for CustomResources
+	// +optional
+	Status *CustomResourceSubresourceStatus `json:"status,omitempty" protobuf:"bytes,2,opt,name=status"`
+	// Custom are additional subresources for CustomResources, which update a subset of their fields
+	// +optional
+	Custom []CustomResourceSubresourceCustom `json:"custom,omitempty" protobuf:"bytes,3,rep,name=custom"`
+}
+
+// CustomResourceSubresourceCustom defines an additional subresource for CustomResources, e.g. /approve.
+// When set,
+// * exposes a /<name> subresource for the custom resource
+// * PUT and PATCH requests to the subresource take a custom resource object, and ignore changes to anything except the fields of fieldPaths
+// * access to the subresource is authorized separately, e.g. by RBAC rules for <plural>/<name>, so privileged partial updates can be granted without granting updates of the whole custom resource
+type CustomResourceSubresourceCustom struct {
+	// Name is the name of the subresource. It must be a lowercase DNS label other than status and scale.
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// FieldPaths are the JSON paths of the fields which are updated through the subresource, e.g.
+	// .spec.approved. Changes to all other fields are ignored. Only JSON paths without the array
+	// notation under .spec or .status are allowed.
+	FieldPaths []string `json:"fieldPaths" protobuf:"bytes,2,rep,name=fieldPaths"`
+	// Verbs are the verbs the subresource is served for: get, update and patch. Defaults to all of them.
+	// +optional
+	Verbs []string `json:"verbs,omitempty" protobuf:"bytes,3,rep,name=verbs"`
+	// Validation describes the schema the custom resources updated through the subresource must
+	// satisfy, in addition to the schema of the version, e.g. to restrict the values of the fields.
+	// +optional
+	Validation *CustomResourceValidation `json:"validation,omitempty" protobuf:"bytes,4,opt,name=validation"`
+}
+
+// CustomResourceSubresourceStatus defines how to serve the status subresource for CustomResources.
+// Status is represented by the `.status` JSON path inside of a CustomResource. When set,
+// * exposes a /status subresource for the custom resource
+// * PUT requests to the /status subresource take a custom resource object, and ignore changes to anything except the status stanza
+// * PUT/POST/PATCH requests to the custom resource ignore changes to the status stanza
+// * metadata.generation is incremented on every change of the custom resource except for changes to the status stanza
+type CustomResourceSubresourceStatus struct {
+	// InitialStatus is the status stanza of newly created custom resources, e.g. a phase or seeded conditions,
+	// such that clients never read an empty status before the controller first updates it. The defaults of the
+	// status schema are applied to it. It must be an object.
+	// +optional
+	InitialStatus *JSON `json:"initialStatus,omitempty" protobuf:"bytes,1,opt,name=initialStatus"`
+}
+
+// CustomResourceSubresourceScale defines how to serve the scale subresource for CustomResources.
+type CustomResourceSubresourceScale struct {
+	// SpecReplicasPath defines the JSON path inside of a CustomResource that corresponds to Scale.Spec.Replicas.
+	// Only JSON paths without the array notation are allowed.
+	// Must be a JSON Path under .spec.
+	// If there is no value under the given path in the CustomResource, the /scale subresource will return an error on GET.
+	SpecReplicasPath string `json:"specReplicasPath" protobuf:"bytes,1,name=specReplicasPath"`
+	// StatusReplicasPath defines the JSON path inside of a CustomResource that corresponds to Scale.Status.Replicas.
+	// Only JSON paths without the array notation are allowed.
+	// Must be a JSON Path under .status.
+	// If there is no value under the given path in the CustomResource, the status replica value in the /scale subresource
+	// will default to 0.
+	StatusReplicasPath string `json:"statusReplicasPath" protobuf:"bytes,2,opt,name=statusReplicasPath"`
+	// LabelSelectorPath defines the JSON path inside of a CustomResource that corresponds to Scale.Status.Selector.
+	// Only JSON paths without the array notation are allowed.
+	// Must be a JSON Path under .status or .spec.
+	// If there is no value under the given path in the CustomResource, the status label selector value in the /scale
+	// subresource will default to the empty string.
+	// +optional
+	LabelSelectorPath *string `json:"labelSelectorPath,omitempty" protobuf:"bytes,3,opt,name=labelSelectorPath"`
 }
 
 // CustomResourceDefinitionNames indicates the names to serve this CustomResourceDefinition
@@ -44,6 +377,9 @@ type CustomResourceDefinitionNames struct {
 	Kind string `json:"kind" protobuf:"bytes,4,opt,name=kind"`
 	// ListKind is the serialized kind of the list for this resource.  Defaults to <kind>List.
 	ListKind string `json:"listKind,omitempty" protobuf:"bytes,5,opt,name=listKind"`
+	// Categories is a list of grouped resources custom resources belong to (e.g. 'all')
+	// +optional
+	Categories []string `json:"categories,omitempty" protobuf:"bytes,6,rep,name=categories"`
 }
 
 // ResourceScope is an enum defining the different scopes availabe to a custom resource
@@ -79,6 +415,34 @@ const (
 	NamesAccepted CustomResourceDefinitionConditionType = "NamesAccepted"
 	// Terminating means that the CustomResourceDefinition has been deleted and is cleaning up.
 	Terminating CustomResourceDefinitionConditionType = "Terminating"
+	// ConversionReviewNegotiated means that the conversion webhook responded to a ConversionReview of one of
+	// the conversionReviewVersions. The message names the version which is used.
+	ConversionReviewNegotiated CustomResourceDefinitionConditionType = "ConversionReviewNegotiated"
+	// ScopeChangeRejected means that the last change of the scope was rejected. The message explains why.
+	ScopeChangeRejected CustomResourceDefinitionConditionType = "ScopeChangeRejected"
+	// ConversionDegraded means that the conversion webhook failed repeatedly and conversions fail fast
+	// without calling it for a while. It is false again once the webhook responds.
+	ConversionDegraded CustomResourceDefinitionConditionType = "ConversionDegraded"
+	// KubernetesAPIApprovalPolicyConformant indicates that a CustomResourceDefinition in a group owned by the
+	// Kubernetes project, i.e. *.k8s.io or *.kubernetes.io, links its API approval in the
+	// api-approved.kubernetes.io annotation. It is only set for CustomResourceDefinitions in these groups.
+	KubernetesAPIApprovalPolicyConformant CustomResourceDefinitionConditionType = "KubernetesAPIApprovalPolicyConformant"
+	// StoredVersionsMigrated means that all custom resources were migrated to the storage version and the
+	// other versions were removed from status.storedVersions. It is false if the last migration failed. It is
+	// set once a migration was necessary.
+	StoredVersionsMigrated CustomResourceDefinitionConditionType = "StoredVersionsMigrated"
+	// NonStructuralSchema means that the schema of a served version is not structural. Such versions are
+	// published in the OpenAPI documents without their schema, e.g. kubectl explain does not know their
+	// fields. The message lists the violations. It is only set while a schema is not structural.
+	NonStructuralSchema CustomResourceDefinitionConditionType = "NonStructuralSchema"
+	// RemovalReleasePassed means that the cluster runs at or past the removedInRelease of a served version.
+	// The message lists these versions. It is false while the removal releases of all served versions are
+	// ahead, and only set while a served version has a removedInRelease.
+	RemovalReleasePassed CustomResourceDefinitionConditionType = "RemovalReleasePassed"
+	// BreakingSchemaChange means that the last change of the spec changed the schemas of served versions
+	// incompatibly, e.g. removed properties, and was accepted by the schema compatibility policy of the
+	// server. The message lists the breaking changes. It is removed by the next change of the spec.
+	BreakingSchemaChange CustomResourceDefinitionConditionType = "BreakingSchemaChange"
 )
 
 // CustomResourceDefinitionCondition contains details for the current condition of this pod.
@@ -97,6 +461,10 @@ type CustomResourceDefinitionCondition struct {
 	// Human-readable message indicating details about last transition.
 	// +optional
 	Message string `json:"message,omitempty" protobuf:"bytes,5,opt,name=message"`
+	// ObservedGeneration is the metadata.generation of the CustomResourceDefinition the condition was
+	// set for. The condition is outdated if it is lower than the current metadata.generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty" protobuf:"varint,6,opt,name=observedGeneration"`
 }
 
 // CustomResourceDefinitionStatus indicates the state of the CustomResourceDefinition
@@ -107,6 +475,28 @@ type CustomResourceDefinitionStatus struct {
 	// AcceptedNames are the names that are actually being used to serve discovery
 	// They may be different than the names in spec.
 	AcceptedNames CustomResourceDefinitionNames `json:"acceptedNames" protobuf:"bytes,2,opt,name=acceptedNames"`
+
+	// StoredVersions are all versions of CustomResources that were ever persisted. Tracking these
+	// versions allows a migration path for stored versions in etcd. The field is mutable
+	// so the migration controller can first finish a migration to another version (i.e.
+	// that no old objects are left in the storage), and then remove the rest of the
+	// versions from this list.
+	// None of the versions in this list can be removed from the spec.Versions field.
+	StoredVersions []string `json:"storedVersions" protobuf:"bytes,3,rep,name=storedVersions"`
+
+	// PersistedVersions are the numbers of CustomResources persisted in each version, as counted
+	// periodically by the server. Versions without persisted objects are not listed. A version can
+	// be removed from spec.versions safely once it has no persisted objects anymore.
+	// +optional
+	PersistedVersions []PersistedVersionCount `json:"persistedVersions,omitempty" protobuf:"bytes,4,rep,name=persistedVersions"`
+}
+
+// PersistedVersionCount is the number of CustomResources persisted in a version.
+type PersistedVersionCount struct {
+	// Version is the name of the version.
+	Version string `json:"version" protobuf:"bytes,1,opt,name=version"`
+	// Count is the number of CustomResources persisted in the version.
+	Count int64 `json:"count" protobuf:"varint,2,opt,name=count"`
 }
 
 // CustomResourceCleanupFinalizer is the name of the finalizer which will delete instances of
@@ -139,3 +529,52 @@ type CustomResourceDefinitionList struct {
 	// Items individual CustomResourceDefinitions
 	Items []CustomResourceDefinition `json:"items" protobuf:"bytes,2,rep,name=items"`
 }
+
+// CustomResourceValidation is a list of validation methods for CustomResources.
+type CustomResourceValidation struct {
+	// OpenAPIV3Schema is the OpenAPI v3 schema to be validated against.
+	OpenAPIV3Schema *JSONSchemaProps `json:"openAPIV3Schema,omitempty" protobuf:"bytes,1,opt,name=openAPIV3Schema"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ConversionReview describes a conversion request/response.
+type ConversionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	// Request describes the attributes for the conversion request.
+	// +optional
+	Request *ConversionRequest `json:"request,omitempty" protobuf:"bytes,1,opt,name=request"`
+	// Response describes the attributes for the conversion response.
+	// +optional
+	Response *ConversionResponse `json:"response,omitempty" protobuf:"bytes,2,opt,name=response"`
+}
+
+// ConversionRequest describes the conversion request parameters.
+type ConversionRequest struct {
+	// UID is an identifier for the individual request/response. It allows us to distinguish instances of requests which are
+	// otherwise identical (parallel requests, requests when earlier requests did not modify etc)
+	// The UID is meant to track the round trip (request/response) between the KAS and the WebHook, not the user request.
+	// It is suitable for correlating log entries between the webhook and apiserver, for either auditing or debugging.
+	UID types.UID `json:"uid" protobuf:"bytes,1,name=uid"`
+	// DesiredAPIVersion is the version to convert given objects to. e.g. "myapi.example.com/v1"
+	DesiredAPIVersion string `json:"desiredAPIVersion" protobuf:"bytes,2,name=desiredAPIVersion"`
+	// Objects is the list of CR objects to be converted.
+	Objects []runtime.RawExtension `json:"objects" protobuf:"bytes,3,rep,name=objects"`
+}
+
+// ConversionResponse describes a conversion response.
+type ConversionResponse struct {
+	// UID is an identifier for the individual request/response.
+	// This should be copied over from the corresponding ConversionRequest.
+	UID types.UID `json:"uid" protobuf:"bytes,1,name=uid"`
+	// ConvertedObjects is the list of converted version of `request.objects` if the `result` is successful otherwise empty.
+	// The webhook is expected to set apiVersion of these objects to the ConversionRequest.desiredAPIVersion. The list
+	// must also has the same size as input list with the same objects in the same order(i.e. equal UIDs and object meta)
+	ConvertedObjects []runtime.RawExtension `json:"convertedObjects" protobuf:"bytes,2,rep,name=convertedObjects"`
+	// Result contains the result of conversion with extra details if the conversion failed. `result.status` determines if
+	// the conversion failed or succeeded. The `result.status` field is required and represent the success or failure of the
+	// conversion. A successful conversion must set `result.status` to `Success`. A failed conversion must set
+	// `result.status` to `Failure` and provide more details in `result.message` and return http status 200. The `result.message`
+	// will be used to construct an error message for the end user.
+	Result metav1.Status `json:"result" protobuf:"bytes,3,name=result"`
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// JSONSchemaProps is a JSON-Schema following Specification Draft 4 (http://json-schema.org/).
+type JSONSchemaProps struct {
+	ID                   string                     `json:"id,omitempty" protobuf:"bytes,1,opt,name=id"`
+	Schema               JSONSchemaURL              `json:"$schema,omitempty" protobuf:"bytes,2,opt,name=schema"`
+	Ref                  *string                    `json:"$ref,omitempty" protobuf:"bytes,3,opt,name=ref"`
+	Description          string                     `json:"description,omitempty" protobuf:"bytes,4,opt,name=description"`
+	Type                 string                     `json:"type,omitempty" protobuf:"bytes,5,opt,name=type"`
+	Format               string                     `json:"format,omitempty" protobuf:"bytes,6,opt,name=format"`
+	Title                string                     `json:"title,omitempty" protobuf:"bytes,7,opt,name=title"`
+	Default              *JSON                      `json:"default,omitempty" protobuf:"bytes,8,opt,name=default"`
+	Maximum              *float64                   `json:"maximum,omitempty" protobuf:"bytes,9,opt,name=maximum"`
+	ExclusiveMaximum     bool                       `json:"exclusiveMaximum,omitempty" protobuf:"bytes,10,opt,name=exclusiveMaximum"`
+	Minimum              *float64                   `json:"minimum,omitempty" protobuf:"bytes,11,opt,name=minimum"`
+	ExclusiveMinimum     bool                       `json:"exclusiveMinimum,omitempty" protobuf:"bytes,12,opt,name=exclusiveMinimum"`
+	MaxLength            *int64                     `json:"maxLength,omitempty" protobuf:"bytes,13,opt,name=maxLength"`
+	MinLength            *int64                     `json:"minLength,omitempty" protobuf:"bytes,14,opt,name=minLength"`
+	Pattern              string                     `json:"pattern,omitempty" protobuf:"bytes,15,opt,name=pattern"`
+	MaxItems             *int64                     `json:"maxItems,omitempty" protobuf:"bytes,16,opt,name=maxItems"`
+	MinItems             *int64                     `json:"minItems,omitempty" protobuf:"bytes,17,opt,name=minItems"`
+	UniqueItems          bool                       `json:"uniqueItems,omitempty" protobuf:"bytes,18,opt,name=uniqueItems"`
+	MultipleOf           *float64                   `json:"multipleOf,omitempty" protobuf:"bytes,19,opt,name=multipleOf"`
+	Enum                 []JSON                     `json:"enum,omitempty" protobuf:"bytes,20,rep,name=enum"`
+	MaxProperties        *int64                     `json:"maxProperties,omitempty" protobuf:"bytes,21,opt,name=maxProperties"`
+	MinProperties        *int64                     `json:"minProperties,omitempty" protobuf:"bytes,22,opt,name=minProperties"`
+	Required             []string                   `json:"required,omitempty" protobuf:"bytes,23,rep,name=required"`
+	Items                *JSONSchemaPropsOrArray    `json:"items,omitempty" protobuf:"bytes,24,opt,name=items"`
+	AllOf                []JSONSchemaProps          `json:"allOf,omitempty" protobuf:"bytes,25,rep,name=allOf"`
+	OneOf                []JSONSchemaProps          `json:"oneOf,omitempty" protobuf:"bytes,26,rep,name=oneOf"`
+	AnyOf                []JSONSchemaProps          `json:"anyOf,omitempty" protobuf:"bytes,27,rep,name=anyOf"`
+	Not                  *JSONSchemaProps           `json:"not,omitempty" protobuf:"bytes,28,opt,name=not"`
+	Properties           map[string]JSONSchemaProps `json:"properties,omitempty" protobuf:"bytes,29,rep,name=properties"`
+	AdditionalProperties *JSONSchemaPropsOrBool     `json:"additionalProperties,omitempty" protobuf:"bytes,30,opt,name=additionalProperties"`
+	PatternProperties    map[string]JSONSchemaProps `json:"patternProperties,omitempty" protobuf:"bytes,31,rep,name=patternProperties"`
+	Dependencies         JSONSchemaDependencies     `json:"dependencies,omitempty" protobuf:"bytes,32,opt,name=dependencies"`
+	AdditionalItems      *JSONSchemaPropsOrBool     `json:"additionalItems,omitempty" protobuf:"bytes,33,opt,name=additionalItems"`
+	Definitions          JSONSchemaDefinitions      `json:"definitions,omitempty" protobuf:"bytes,34,opt,name=definitions"`
+	ExternalDocs         *ExternalDocumentation     `json:"externalDocs,omitempty" protobuf:"bytes,35,opt,name=externalDocs"`
+	Example              *JSON                      `json:"example,omitempty" protobuf:"bytes,36,opt,name=example"`
+
+	// x-kubernetes-validations describes a list of validation rules written in the CEL expression language.
+	// The `self` variable in each rule is bound to the value the schema applies to.
+	// +optional
+	XValidations ValidationRules `json:"x-kubernetes-validations,omitempty" protobuf:"bytes,37,rep,name=xKubernetesValidations"`
+
+	// x-kubernetes-preserve-unknown-fields stops the API server from pruning fields which are not
+	// specified in the schema of an object. Specified fields are still pruned recursively.
+	// It must be true or undefined.
+	// +optional
+	XPreserveUnknownFields *bool `json:"x-kubernetes-preserve-unknown-fields,omitempty" protobuf:"bytes,38,opt,name=xKubernetesPreserveUnknownFields"`
+	// x-kubernetes-embedded-resource defines that the value is an embedded Kubernetes runtime.Object,
+	// like a RawExtension of built-in types. apiVersion, kind and metadata are implicitly specified,
+	// and apiVersion and kind are required. The type must be object or empty.
+	// +optional
+	XEmbeddedResource bool `json:"x-kubernetes-embedded-resource,omitempty" protobuf:"bytes,39,opt,name=xKubernetesEmbeddedResource"`
+	// x-kubernetes-int-or-string specifies that the value is either an integer or a string, like an
+	// IntOrString of built-in types. The type must be empty.
+	// +optional
+	XIntOrString bool `json:"x-kubernetes-int-or-string,omitempty" protobuf:"bytes,40,opt,name=xKubernetesIntOrString"`
+
+	// x-kubernetes-list-type specifies how apply merges arrays. One of:
+	//
+	//   - atomic (the default): the array is replaced as a whole.
+	//   - set: the items are scalars or atomic and merged by value. Items must be unique.
+	//   - map: the items are objects and merged by the properties in x-kubernetes-list-map-keys,
+	//     which must be unique.
+	//
+	// +optional
+	XListType *string `json:"x-kubernetes-list-type,omitempty" protobuf:"bytes,41,opt,name=xKubernetesListType"`
+	// x-kubernetes-list-map-keys are the names of the item properties which identify the items of an
+	// array with x-kubernetes-list-type map. The properties must be scalar and required.
+	// +optional
+	XListMapKeys []string `json:"x-kubernetes-list-map-keys,omitempty" protobuf:"bytes,42,rep,name=xKubernetesListMapKeys"`
+	// x-kubernetes-map-type specifies how apply merges objects. One of:
+	//
+	//   - granular: the object is merged field by field. This is the default for objects with
+	//     specified properties or additionalProperties.
+	//   - atomic: the object is replaced as a whole.
+	//
+	// +optional
+	XMapType *string `json:"x-kubernetes-map-type,omitempty" protobuf:"bytes,43,opt,name=xKubernetesMapType"`
+
+	// x-kubernetes-immutable specifies that the value must not be changed or removed on update once
+	// it is set, like `self == oldSelf` validation rules but also forbidding to remove the value. It
+	// is not allowed at the root of the schema and within the items of arrays other than
+	// x-kubernetes-list-type map, whose items cannot be correlated with the old items.
+	// +optional
+	XImmutable bool `json:"x-kubernetes-immutable,omitempty" protobuf:"bytes,44,opt,name=xKubernetesImmutable"`
+}
+
+// ValidationRules describes a list of validation rules written in the CEL expression language.
+type ValidationRules []ValidationRule
+
+// ValidationRule describes a validation rule written in the CEL expression language.
+type ValidationRule struct {
+	// Rule represents the expression which will be evaluated by CEL.
+	// The `self` variable in the CEL expression is bound to the scoped value.
+	// Rules referencing the `oldSelf` variable, which is bound to the old value, are transition
+	// rules. They are only evaluated on updates of values which existed before, and are not allowed
+	// within array items unless the array has x-kubernetes-list-type map.
+	// Example:
+	// - Rule scoped to the root of a resource with a status subresource: {"rule": "self.status.actual <= self.spec.maxDesired"}
+	// - Rule of an immutable field: {"rule": "self == oldSelf"}
+	Rule string `json:"rule" protobuf:"bytes,1,opt,name=rule"`
+	// Message represents the message displayed when validation fails. If unset, the message is
+	// "failed rule: {Rule}".
+	// +optional
+	Message string `json:"message,omitempty" protobuf:"bytes,2,opt,name=message"`
+	// MessageExpression is a CEL expression which computes the message displayed when validation
+	// fails, with the same variables as the rule. It must evaluate to a string and takes precedence
+	// over Message. If it fails to evaluate or evaluates to an empty string, Message or the default
+	// message is used instead.
+	// Example: "'replicas must not exceed ' + string(self.maxReplicas)"
+	// +optional
+	MessageExpression string `json:"messageExpression,omitempty" protobuf:"bytes,3,opt,name=messageExpression"`
+	// Reason is the reason of the validation error returned if the rule fails: FieldValueInvalid,
+	// FieldValueForbidden, FieldValueRequired or FieldValueDuplicate. If unset, it is FieldValueInvalid.
+	// +optional
+	Reason FieldValueErrorReason `json:"reason,omitempty" protobuf:"bytes,4,opt,name=reason,casttype=FieldValueErrorReason"`
+	// FieldPath is the JSON path, relative to the value the schema applies to, of the field the
+	// validation error is reported for if the rule fails, e.g. ".spec.replicas" or
+	// ".metadata.labels['app']". Only fields declared in the schema are allowed, array items cannot
+	// be referenced. If unset, the error is reported for the value the schema applies to.
+	// +optional
+	FieldPath string `json:"fieldPath,omitempty" protobuf:"bytes,5,opt,name=fieldPath"`
+}
+
+// FieldValueErrorReason is the reason of the validation error of a validation rule.
+type FieldValueErrorReason string
+
+const (
+	// FieldValueRequired is used to report required values that are not provided.
+	FieldValueRequired FieldValueErrorReason = "FieldValueRequired"
+	// FieldValueDuplicate is used to report collisions of values that must be unique.
+	FieldValueDuplicate FieldValueErrorReason = "FieldValueDuplicate"
+	// FieldValueInvalid is used to report malformed values.
+	FieldValueInvalid FieldValueErrorReason = "FieldValueInvalid"
+	// FieldValueForbidden is used to report valid but forbidden values.
+	FieldValueForbidden FieldValueErrorReason = "FieldValueForbidden"
+)
+
+// JSON represents any valid JSON value.
+// These types are supported: bool, int64, float64, string, []interface{}, map[string]interface{} and nil.
+type JSON struct {
+	Raw []byte `protobuf:"bytes,1,opt,name=raw"`
+}
+
+// JSONSchemaURL represents a schema url.
+type JSONSchemaURL string
+
+// JSONSchemaPropsOrArray represents a value that can either be a JSONSchemaProps
+// or an array of JSONSchemaProps. Mainly here for serialization purposes.
+type JSONSchemaPropsOrArray struct {
+	Schema      *JSONSchemaProps  `protobuf:"bytes,1,opt,name=schema"`
+	JSONSchemas []JSONSchemaProps `protobuf:"bytes,2,rep,name=jSONSchemas"`
+}
+
+// JSONSchemaPropsOrBool represents JSONSchemaProps or a boolean value.
+// Defaults to true for the boolean property.
+type JSONSchemaPropsOrBool struct {
+	Allows bool             `protobuf:"varint,1,opt,name=allows"`
+	Schema *JSONSchemaProps `protobuf:"bytes,2,opt,name=schema"`
+}
+
+// JSONSchemaDependencies represent a dependencies property.
+type JSONSchemaDependencies map[string]JSONSchemaPropsOrStringArray
+
+// JSONSchemaPropsOrStringArray represents a JSONSchemaProps or a string array.
+type JSONSchemaPropsOrStringArray struct {
+	Schema   *JSONSchemaProps `protobuf:"bytes,1,opt,name=schema"`
+	Property []string         `protobuf:"bytes,2,rep,name=property"`
+}
+
+// JSONSchemaDefinitions contains the models explicitly defined in this spec.
+// Definitions may only be specified at the root of a schema. Nested schemas refer to them with a
+// $ref of the form "#/definitions/<name>", along with which only a description may be specified.
+// The referring schema is replaced by the definition, keeping its description if it has one.
+type JSONSchemaDefinitions map[string]JSONSchemaProps
+
+// ExternalDocumentation allows referencing an external resource for extended documentation.
+type ExternalDocumentation struct {
+	Description string `json:"description,omitempty" protobuf:"bytes,1,opt,name=description"`
+	URL         string `json:"url,omitempty" protobuf:"bytes,2,opt,name=url"`
+}
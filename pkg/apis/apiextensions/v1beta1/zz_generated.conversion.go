@@ -35,6 +35,14 @@ func init() {
 // Public to allow building arbitrary schemes.
 func RegisterConversions(scheme *runtime.Scheme) error {
 	return scheme.AddGeneratedConversionFuncs(
+		Convert_v1beta1_ConversionExpression_To_apiextensions_ConversionExpression,
+		Convert_apiextensions_ConversionExpression_To_v1beta1_ConversionExpression,
+		Convert_v1beta1_ConversionFieldMapping_To_apiextensions_ConversionFieldMapping,
+		Convert_apiextensions_ConversionFieldMapping_To_v1beta1_ConversionFieldMapping,
+		Convert_v1beta1_CustomResourceColumnDefinition_To_apiextensions_CustomResourceColumnDefinition,
+		Convert_apiextensions_CustomResourceColumnDefinition_To_v1beta1_CustomResourceColumnDefinition,
+		Convert_v1beta1_CustomResourceConversion_To_apiextensions_CustomResourceConversion,
+		Convert_apiextensions_CustomResourceConversion_To_v1beta1_CustomResourceConversion,
 		Convert_v1beta1_CustomResourceDefinition_To_apiextensions_CustomResourceDefinition,
 		Convert_apiextensions_CustomResourceDefinition_To_v1beta1_CustomResourceDefinition,
 		Convert_v1beta1_CustomResourceDefinitionCondition_To_apiextensions_CustomResourceDefinitionCondition,
@@ -47,9 +55,153 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_apiextensions_CustomResourceDefinitionSpec_To_v1beta1_CustomResourceDefinitionSpec,
 		Convert_v1beta1_CustomResourceDefinitionStatus_To_apiextensions_CustomResourceDefinitionStatus,
 		Convert_apiextensions_CustomResourceDefinitionStatus_To_v1beta1_CustomResourceDefinitionStatus,
+		Convert_v1beta1_CustomResourceDefinitionVersion_To_apiextensions_CustomResourceDefinitionVersion,
+		Convert_apiextensions_CustomResourceDefinitionVersion_To_v1beta1_CustomResourceDefinitionVersion,
+		Convert_v1beta1_CustomResourceStorage_To_apiextensions_CustomResourceStorage,
+		Convert_apiextensions_CustomResourceStorage_To_v1beta1_CustomResourceStorage,
+		Convert_v1beta1_CustomResourceSubresourceCustom_To_apiextensions_CustomResourceSubresourceCustom,
+		Convert_apiextensions_CustomResourceSubresourceCustom_To_v1beta1_CustomResourceSubresourceCustom,
+		Convert_v1beta1_CustomResourceSubresourceScale_To_apiextensions_CustomResourceSubresourceScale,
+		Convert_apiextensions_CustomResourceSubresourceScale_To_v1beta1_CustomResourceSubresourceScale,
+		Convert_v1beta1_CustomResourceSubresourceStatus_To_apiextensions_CustomResourceSubresourceStatus,
+		Convert_apiextensions_CustomResourceSubresourceStatus_To_v1beta1_CustomResourceSubresourceStatus,
+		Convert_v1beta1_CustomResourceSubresources_To_apiextensions_CustomResourceSubresources,
+		Convert_apiextensions_CustomResourceSubresources_To_v1beta1_CustomResourceSubresources,
+		Convert_v1beta1_CustomResourceValidation_To_apiextensions_CustomResourceValidation,
+		Convert_apiextensions_CustomResourceValidation_To_v1beta1_CustomResourceValidation,
+		Convert_v1beta1_ExternalDocumentation_To_apiextensions_ExternalDocumentation,
+		Convert_apiextensions_ExternalDocumentation_To_v1beta1_ExternalDocumentation,
+		Convert_v1beta1_JSON_To_apiextensions_JSON,
+		Convert_apiextensions_JSON_To_v1beta1_JSON,
+		Convert_v1beta1_JSONSchemaProps_To_apiextensions_JSONSchemaProps,
+		Convert_apiextensions_JSONSchemaProps_To_v1beta1_JSONSchemaProps,
+		Convert_v1beta1_JSONSchemaPropsOrArray_To_apiextensions_JSONSchemaPropsOrArray,
+		Convert_apiextensions_JSONSchemaPropsOrArray_To_v1beta1_JSONSchemaPropsOrArray,
+		Convert_v1beta1_JSONSchemaPropsOrBool_To_apiextensions_JSONSchemaPropsOrBool,
+		Convert_apiextensions_JSONSchemaPropsOrBool_To_v1beta1_JSONSchemaPropsOrBool,
+		Convert_v1beta1_JSONSchemaPropsOrStringArray_To_apiextensions_JSONSchemaPropsOrStringArray,
+		Convert_apiextensions_JSONSchemaPropsOrStringArray_To_v1beta1_JSONSchemaPropsOrStringArray,
+		Convert_v1beta1_PersistedVersionCount_To_apiextensions_PersistedVersionCount,
+		Convert_apiextensions_PersistedVersionCount_To_v1beta1_PersistedVersionCount,
+		Convert_v1beta1_SelectableField_To_apiextensions_SelectableField,
+		Convert_apiextensions_SelectableField_To_v1beta1_SelectableField,
+		Convert_v1beta1_ServiceReference_To_apiextensions_ServiceReference,
+		Convert_apiextensions_ServiceReference_To_v1beta1_ServiceReference,
+		Convert_v1beta1_ValidationRule_To_apiextensions_ValidationRule,
+		Convert_apiextensions_ValidationRule_To_v1beta1_ValidationRule,
+		Convert_v1beta1_WebhookClientConfig_To_apiextensions_WebhookClientConfig,
+		Convert_apiextensions_WebhookClientConfig_To_v1beta1_WebhookClientConfig,
 	)
 }
 
+func autoConvert_v1beta1_ConversionExpression_To_apiextensions_ConversionExpression(in *ConversionExpression, out *apiextensions.ConversionExpression, s conversion.Scope) error {
+	out.FromVersion = in.FromVersion
+	out.ToVersion = in.ToVersion
+	out.FieldMappings = *(*[]apiextensions.ConversionFieldMapping)(unsafe.Pointer(&in.FieldMappings))
+	return nil
+}
+
+// Convert_v1beta1_ConversionExpression_To_apiextensions_ConversionExpression is an autogenerated conversion function.
+func Convert_v1beta1_ConversionExpression_To_apiextensions_ConversionExpression(in *ConversionExpression, out *apiextensions.ConversionExpression, s conversion.Scope) error {
+	return autoConvert_v1beta1_ConversionExpression_To_apiextensions_ConversionExpression(in, out, s)
+}
+
+func autoConvert_apiextensions_ConversionExpression_To_v1beta1_ConversionExpression(in *apiextensions.ConversionExpression, out *ConversionExpression, s conversion.Scope) error {
+	out.FromVersion = in.FromVersion
+	out.ToVersion = in.ToVersion
+	if in.FieldMappings == nil {
+		out.FieldMappings = make([]ConversionFieldMapping, 0)
+	} else {
+		out.FieldMappings = *(*[]ConversionFieldMapping)(unsafe.Pointer(&in.FieldMappings))
+	}
+	return nil
+}
+
+// Convert_apiextensions_ConversionExpression_To_v1beta1_ConversionExpression is an autogenerated conversion function.
+func Convert_apiextensions_ConversionExpression_To_v1beta1_ConversionExpression(in *apiextensions.ConversionExpression, out *ConversionExpression, s conversion.Scope) error {
+	return autoConvert_apiextensions_ConversionExpression_To_v1beta1_ConversionExpression(in, out, s)
+}
+
+func autoConvert_v1beta1_ConversionFieldMapping_To_apiextensions_ConversionFieldMapping(in *ConversionFieldMapping, out *apiextensions.ConversionFieldMapping, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Expression = in.Expression
+	return nil
+}
+
+// Convert_v1beta1_ConversionFieldMapping_To_apiextensions_ConversionFieldMapping is an autogenerated conversion function.
+func Convert_v1beta1_ConversionFieldMapping_To_apiextensions_ConversionFieldMapping(in *ConversionFieldMapping, out *apiextensions.ConversionFieldMapping, s conversion.Scope) error {
+	return autoConvert_v1beta1_ConversionFieldMapping_To_apiextensions_ConversionFieldMapping(in, out, s)
+}
+
+func autoConvert_apiextensions_ConversionFieldMapping_To_v1beta1_ConversionFieldMapping(in *apiextensions.ConversionFieldMapping, out *ConversionFieldMapping, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Expression = in.Expression
+	return nil
+}
+
+// Convert_apiextensions_ConversionFieldMapping_To_v1beta1_ConversionFieldMapping is an autogenerated conversion function.
+func Convert_apiextensions_ConversionFieldMapping_To_v1beta1_ConversionFieldMapping(in *apiextensions.ConversionFieldMapping, out *ConversionFieldMapping, s conversion.Scope) error {
+	return autoConvert_apiextensions_ConversionFieldMapping_To_v1beta1_ConversionFieldMapping(in, out, s)
+}
+
+func autoConvert_v1beta1_CustomResourceColumnDefinition_To_apiextensions_CustomResourceColumnDefinition(in *CustomResourceColumnDefinition, out *apiextensions.CustomResourceColumnDefinition, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Type = in.Type
+	out.Format = in.Format
+	out.Description = in.Description
+	out.Priority = in.Priority
+	out.JSONPath = in.JSONPath
+	return nil
+}
+
+// Convert_v1beta1_CustomResourceColumnDefinition_To_apiextensions_CustomResourceColumnDefinition is an autogenerated conversion function.
+func Convert_v1beta1_CustomResourceColumnDefinition_To_apiextensions_CustomResourceColumnDefinition(in *CustomResourceColumnDefinition, out *apiextensions.CustomResourceColumnDefinition, s conversion.Scope) error {
+	return autoConvert_v1beta1_CustomResourceColumnDefinition_To_apiextensions_CustomResourceColumnDefinition(in, out, s)
+}
+
+func autoConvert_apiextensions_CustomResourceColumnDefinition_To_v1beta1_CustomResourceColumnDefinition(in *apiextensions.CustomResourceColumnDefinition, out *CustomResourceColumnDefinition, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Type = in.Type
+	out.Format = in.Format
+	out.Description = in.Description
+	out.Priority = in.Priority
+	out.JSONPath = in.JSONPath
+	return nil
+}
+
+// Convert_apiextensions_CustomResourceColumnDefinition_To_v1beta1_CustomResourceColumnDefinition is an autogenerated conversion function.
+func Convert_apiextensions_CustomResourceColumnDefinition_To_v1beta1_CustomResourceColumnDefinition(in *apiextensions.CustomResourceColumnDefinition, out *CustomResourceColumnDefinition, s conversion.Scope) error {
+	return autoConvert_apiextensions_CustomResourceColumnDefinition_To_v1beta1_CustomResourceColumnDefinition(in, out, s)
+}
+
+func autoConvert_v1beta1_CustomResourceConversion_To_apiextensions_CustomResourceConversion(in *CustomResourceConversion, out *apiextensions.CustomResourceConversion, s conversion.Scope) error {
+	out.Strategy = apiextensions.ConversionStrategyType(in.Strategy)
+	out.WebhookClientConfig = (*apiextensions.WebhookClientConfig)(unsafe.Pointer(in.WebhookClientConfig))
+	out.ConversionReviewVersions = *(*[]string)(unsafe.Pointer(&in.ConversionReviewVersions))
+	out.Expressions = *(*[]apiextensions.ConversionExpression)(unsafe.Pointer(&in.Expressions))
+	out.FailurePolicy = (*apiextensions.ConversionFailurePolicyType)(unsafe.Pointer(in.FailurePolicy))
+	return nil
+}
+
+// Convert_v1beta1_CustomResourceConversion_To_apiextensions_CustomResourceConversion is an autogenerated conversion function.
+func Convert_v1beta1_CustomResourceConversion_To_apiextensions_CustomResourceConversion(in *CustomResourceConversion, out *apiextensions.CustomResourceConversion, s conversion.Scope) error {
+	return autoConvert_v1beta1_CustomResourceConversion_To_apiextensions_CustomResourceConversion(in, out, s)
+}
+
+func autoConvert_apiextensions_CustomResourceConversion_To_v1beta1_CustomResourceConversion(in *apiextensions.CustomResourceConversion, out *CustomResourceConversion, s conversion.Scope) error {
+	out.Strategy = ConversionStrategyType(in.Strategy)
+	out.WebhookClientConfig = (*WebhookClientConfig)(unsafe.Pointer(in.WebhookClientConfig))
+	out.ConversionReviewVersions = *(*[]string)(unsafe.Pointer(&in.ConversionReviewVersions))
+	out.Expressions = *(*[]ConversionExpression)(unsafe.Pointer(&in.Expressions))
+	out.FailurePolicy = (*ConversionFailurePolicyType)(unsafe.Pointer(in.FailurePolicy))
+	return nil
+}
+
+// Convert_apiextensions_CustomResourceConversion_To_v1beta1_CustomResourceConversion is an autogenerated conversion function.
+func Convert_apiextensions_CustomResourceConversion_To_v1beta1_CustomResourceConversion(in *apiextensions.CustomResourceConversion, out *CustomResourceConversion, s conversion.Scope) error {
+	return autoConvert_apiextensions_CustomResourceConversion_To_v1beta1_CustomResourceConversion(in, out, s)
+}
+
 func autoConvert_v1beta1_CustomResourceDefinition_To_apiextensions_CustomResourceDefinition(in *CustomResourceDefinition, out *apiextensions.CustomResourceDefinition, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_CustomResourceDefinitionSpec_To_apiextensions_CustomResourceDefinitionSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -88,6 +240,7 @@ func autoConvert_v1beta1_CustomResourceDefinitionCondition_To_apiextensions_Cust
 	out.LastTransitionTime = in.LastTransitionTime
 	out.Reason = in.Reason
 	out.Message = in.Message
+	out.ObservedGeneration = in.ObservedGeneration
 	return nil
 }
 
@@ -102,6 +255,7 @@ func autoConvert_apiextensions_CustomResourceDefinitionCondition_To_v1beta1_Cust
 	out.LastTransitionTime = in.LastTransitionTime
 	out.Reason = in.Reason
 	out.Message = in.Message
+	out.ObservedGeneration = in.ObservedGeneration
 	return nil
 }
 
@@ -112,7 +266,17 @@ func Convert_apiextensions_CustomResourceDefinitionCondition_To_v1beta1_CustomRe
 
 func autoConvert_v1beta1_CustomResourceDefinitionList_To_apiextensions_CustomResourceDefinitionList(in *CustomResourceDefinitionList, out *apiextensions.CustomResourceDefinitionList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]apiextensions.CustomResourceDefinition)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]apiextensions.CustomResourceDefinition, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_CustomResourceDefinition_To_apiextensions_CustomResourceDefinition(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -123,10 +287,16 @@ func Convert_v1beta1_CustomResourceDefinitionList_To_apiextensions_CustomResourc
 
 func autoConvert_apiextensions_CustomResourceDefinitionList_To_v1beta1_CustomResourceDefinitionList(in *apiextensions.CustomResourceDefinitionList, out *CustomResourceDefinitionList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	if in.Items == nil {
-		out.Items = make([]CustomResourceDefinition, 0)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CustomResourceDefinition, len(*in))
+		for i := range *in {
+			if err := Convert_apiextensions_CustomResourceDefinition_To_v1beta1_CustomResourceDefinition(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
 	} else {
-		out.Items = *(*[]CustomResourceDefinition)(unsafe.Pointer(&in.Items))
+		out.Items = make([]CustomResourceDefinition, 0)
 	}
 	return nil
 }
@@ -142,6 +312,7 @@ func autoConvert_v1beta1_CustomResourceDefinitionNames_To_apiextensions_CustomRe
 	out.ShortNames = *(*[]string)(unsafe.Pointer(&in.ShortNames))
 	out.Kind = in.Kind
 	out.ListKind = in.ListKind
+	out.Categories = *(*[]string)(unsafe.Pointer(&in.Categories))
 	return nil
 }
 
@@ -156,6 +327,7 @@ func autoConvert_apiextensions_CustomResourceDefinitionNames_To_v1beta1_CustomRe
 	out.ShortNames = *(*[]string)(unsafe.Pointer(&in.ShortNames))
 	out.Kind = in.Kind
 	out.ListKind = in.ListKind
+	out.Categories = *(*[]string)(unsafe.Pointer(&in.Categories))
 	return nil
 }
 
@@ -171,6 +343,41 @@ func autoConvert_v1beta1_CustomResourceDefinitionSpec_To_apiextensions_CustomRes
 		return err
 	}
 	out.Scope = apiextensions.ResourceScope(in.Scope)
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		*out = new(apiextensions.CustomResourceValidation)
+		if err := Convert_v1beta1_CustomResourceValidation_To_apiextensions_CustomResourceValidation(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Validation = nil
+	}
+	out.PreserveUnknownFields = (*bool)(unsafe.Pointer(in.PreserveUnknownFields))
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]apiextensions.CustomResourceDefinitionVersion, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_CustomResourceDefinitionVersion_To_apiextensions_CustomResourceDefinitionVersion(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Versions = nil
+	}
+	out.Conversion = (*apiextensions.CustomResourceConversion)(unsafe.Pointer(in.Conversion))
+	if in.Subresources != nil {
+		in, out := &in.Subresources, &out.Subresources
+		*out = new(apiextensions.CustomResourceSubresources)
+		if err := Convert_v1beta1_CustomResourceSubresources_To_apiextensions_CustomResourceSubresources(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Subresources = nil
+	}
+	out.AdditionalPrinterColumns = *(*[]apiextensions.CustomResourceColumnDefinition)(unsafe.Pointer(&in.AdditionalPrinterColumns))
+	out.SelectableFields = *(*[]apiextensions.SelectableField)(unsafe.Pointer(&in.SelectableFields))
+	out.Storage = (*apiextensions.CustomResourceStorage)(unsafe.Pointer(in.Storage))
+	out.AllowedFinalizerDomains = *(*[]string)(unsafe.Pointer(&in.AllowedFinalizerDomains))
 	return nil
 }
 
@@ -186,6 +393,41 @@ func autoConvert_apiextensions_CustomResourceDefinitionSpec_To_v1beta1_CustomRes
 		return err
 	}
 	out.Scope = ResourceScope(in.Scope)
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		*out = new(CustomResourceValidation)
+		if err := Convert_apiextensions_CustomResourceValidation_To_v1beta1_CustomResourceValidation(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Validation = nil
+	}
+	out.PreserveUnknownFields = (*bool)(unsafe.Pointer(in.PreserveUnknownFields))
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]CustomResourceDefinitionVersion, len(*in))
+		for i := range *in {
+			if err := Convert_apiextensions_CustomResourceDefinitionVersion_To_v1beta1_CustomResourceDefinitionVersion(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Versions = nil
+	}
+	out.Conversion = (*CustomResourceConversion)(unsafe.Pointer(in.Conversion))
+	if in.Subresources != nil {
+		in, out := &in.Subresources, &out.Subresources
+		*out = new(CustomResourceSubresources)
+		if err := Convert_apiextensions_CustomResourceSubresources_To_v1beta1_CustomResourceSubresources(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Subresources = nil
+	}
+	out.AdditionalPrinterColumns = *(*[]CustomResourceColumnDefinition)(unsafe.Pointer(&in.AdditionalPrinterColumns))
+	out.SelectableFields = *(*[]SelectableField)(unsafe.Pointer(&in.SelectableFields))
+	out.Storage = (*CustomResourceStorage)(unsafe.Pointer(in.Storage))
+	out.AllowedFinalizerDomains = *(*[]string)(unsafe.Pointer(&in.AllowedFinalizerDomains))
 	return nil
 }
 
@@ -199,6 +441,8 @@ func autoConvert_v1beta1_CustomResourceDefinitionStatus_To_apiextensions_CustomR
 	if err := Convert_v1beta1_CustomResourceDefinitionNames_To_apiextensions_CustomResourceDefinitionNames(&in.AcceptedNames, &out.AcceptedNames, s); err != nil {
 		return err
 	}
+	out.StoredVersions = *(*[]string)(unsafe.Pointer(&in.StoredVersions))
+	out.PersistedVersions = *(*[]apiextensions.PersistedVersionCount)(unsafe.Pointer(&in.PersistedVersions))
 	return nil
 }
 
@@ -216,6 +460,12 @@ func autoConvert_apiextensions_CustomResourceDefinitionStatus_To_v1beta1_CustomR
 	if err := Convert_apiextensions_CustomResourceDefinitionNames_To_v1beta1_CustomResourceDefinitionNames(&in.AcceptedNames, &out.AcceptedNames, s); err != nil {
 		return err
 	}
+	if in.StoredVersions == nil {
+		out.StoredVersions = make([]string, 0)
+	} else {
+		out.StoredVersions = *(*[]string)(unsafe.Pointer(&in.StoredVersions))
+	}
+	out.PersistedVersions = *(*[]PersistedVersionCount)(unsafe.Pointer(&in.PersistedVersions))
 	return nil
 }
 
@@ -223,3 +473,969 @@ func autoConvert_apiextensions_CustomResourceDefinitionStatus_To_v1beta1_CustomR
 func Convert_apiextensions_CustomResourceDefinitionStatus_To_v1beta1_CustomResourceDefinitionStatus(in *apiextensions.CustomResourceDefinitionStatus, out *CustomResourceDefinitionStatus, s conversion.Scope) error {
 	return autoConvert_apiextensions_CustomResourceDefinitionStatus_To_v1beta1_CustomResourceDefinitionStatus(in, out, s)
 }
+
+func autoConvert_v1beta1_CustomResourceDefinitionVersion_To_apiextensions_CustomResourceDefinitionVersion(in *CustomResourceDefinitionVersion, out *apiextensions.CustomResourceDefinitionVersion, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Served = in.Served
+	out.Storage = in.Storage
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(apiextensions.CustomResourceValidation)
+		if err := Convert_v1beta1_CustomResourceValidation_To_apiextensions_CustomResourceValidation(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Schema = nil
+	}
+	if in.Subresources != nil {
+		in, out := &in.Subresources, &out.Subresources
+		*out = new(apiextensions.CustomResourceSubresources)
+		if err := Convert_v1beta1_CustomResourceSubresources_To_apiextensions_CustomResourceSubresources(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Subresources = nil
+	}
+	out.AdditionalPrinterColumns = *(*[]apiextensions.CustomResourceColumnDefinition)(unsafe.Pointer(&in.AdditionalPrinterColumns))
+	out.Deprecated = in.Deprecated
+	out.DeprecationWarning = (*string)(unsafe.Pointer(in.DeprecationWarning))
+	out.RemovedInRelease = (*string)(unsafe.Pointer(in.RemovedInRelease))
+	out.MirrorStorage = in.MirrorStorage
+	if in.Example != nil {
+		in, out := &in.Example, &out.Example
+		*out = new(apiextensions.JSON)
+		if err := Convert_v1beta1_JSON_To_apiextensions_JSON(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Example = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_CustomResourceDefinitionVersion_To_apiextensions_CustomResourceDefinitionVersion is an autogenerated conversion function.
+func Convert_v1beta1_CustomResourceDefinitionVersion_To_apiextensions_CustomResourceDefinitionVersion(in *CustomResourceDefinitionVersion, out *apiextensions.CustomResourceDefinitionVersion, s conversion.Scope) error {
+	return autoConvert_v1beta1_CustomResourceDefinitionVersion_To_apiextensions_CustomResourceDefinitionVersion(in, out, s)
+}
+
+func autoConvert_apiextensions_CustomResourceDefinitionVersion_To_v1beta1_CustomResourceDefinitionVersion(in *apiextensions.CustomResourceDefinitionVersion, out *CustomResourceDefinitionVersion, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Served = in.Served
+	out.Storage = in.Storage
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(CustomResourceValidation)
+		if err := Convert_apiextensions_CustomResourceValidation_To_v1beta1_CustomResourceValidation(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Schema = nil
+	}
+	if in.Subresources != nil {
+		in, out := &in.Subresources, &out.Subresources
+		*out = new(CustomResourceSubresources)
+		if err := Convert_apiextensions_CustomResourceSubresources_To_v1beta1_CustomResourceSubresources(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Subresources = nil
+	}
+	out.AdditionalPrinterColumns = *(*[]CustomResourceColumnDefinition)(unsafe.Pointer(&in.AdditionalPrinterColumns))
+	out.Deprecated = in.Deprecated
+	out.DeprecationWarning = (*string)(unsafe.Pointer(in.DeprecationWarning))
+	out.RemovedInRelease = (*string)(unsafe.Pointer(in.RemovedInRelease))
+	out.MirrorStorage = in.MirrorStorage
+	if in.Example != nil {
+		in, out := &in.Example, &out.Example
+		*out = new(JSON)
+		if err := Convert_apiextensions_JSON_To_v1beta1_JSON(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Example = nil
+	}
+	return nil
+}
+
+// Convert_apiextensions_CustomResourceDefinitionVersion_To_v1beta1_CustomResourceDefinitionVersion is an autogenerated conversion function.
+func Convert_apiextensions_CustomResourceDefinitionVersion_To_v1beta1_CustomResourceDefinitionVersion(in *apiextensions.CustomResourceDefinitionVersion, out *CustomResourceDefinitionVersion, s conversion.Scope) error {
+	return autoConvert_apiextensions_CustomResourceDefinitionVersion_To_v1beta1_CustomResourceDefinitionVersion(in, out, s)
+}
+
+func autoConvert_v1beta1_CustomResourceStorage_To_apiextensions_CustomResourceStorage(in *CustomResourceStorage, out *apiextensions.CustomResourceStorage, s conversion.Scope) error {
+	out.Prefix = in.Prefix
+	return nil
+}
+
+// Convert_v1beta1_CustomResourceStorage_To_apiextensions_CustomResourceStorage is an autogenerated conversion function.
+func Convert_v1beta1_CustomResourceStorage_To_apiextensions_CustomResourceStorage(in *CustomResourceStorage, out *apiextensions.CustomResourceStorage, s conversion.Scope) error {
+	return autoConvert_v1beta1_CustomResourceStorage_To_apiextensions_CustomResourceStorage(in, out, s)
+}
+
+func autoConvert_apiextensions_CustomResourceStorage_To_v1beta1_CustomResourceStorage(in *apiextensions.CustomResourceStorage, out *CustomResourceStorage, s conversion.Scope) error {
+	out.Prefix = in.Prefix
+	return nil
+}
+
+// Convert_apiextensions_CustomResourceStorage_To_v1beta1_CustomResourceStorage is an autogenerated conversion function.
+func Convert_apiextensions_CustomResourceStorage_To_v1beta1_CustomResourceStorage(in *apiextensions.CustomResourceStorage, out *CustomResourceStorage, s conversion.Scope) error {
+	return autoConvert_apiextensions_CustomResourceStorage_To_v1beta1_CustomResourceStorage(in, out, s)
+}
+
+func autoConvert_v1beta1_CustomResourceSubresourceCustom_To_apiextensions_CustomResourceSubresourceCustom(in *CustomResourceSubresourceCustom, out *apiextensions.CustomResourceSubresourceCustom, s conversion.Scope) error {
+	out.Name = in.Name
+	out.FieldPaths = *(*[]string)(unsafe.Pointer(&in.FieldPaths))
+	out.Verbs = *(*[]string)(unsafe.Pointer(&in.Verbs))
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		*out = new(apiextensions.CustomResourceValidation)
+		if err := Convert_v1beta1_CustomResourceValidation_To_apiextensions_CustomResourceValidation(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Validation = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_CustomResourceSubresourceCustom_To_apiextensions_CustomResourceSubresourceCustom is an autogenerated conversion function.
+func Convert_v1beta1_CustomResourceSubresourceCustom_To_apiextensions_CustomResourceSubresourceCustom(in *CustomResourceSubresourceCustom, out *apiextensions.CustomResourceSubresourceCustom, s conversion.Scope) error {
+	return autoConvert_v1beta1_CustomResourceSubresourceCustom_To_apiextensions_CustomResourceSubresourceCustom(in, out, s)
+}
+
+func autoConvert_apiextensions_CustomResourceSubresourceCustom_To_v1beta1_CustomResourceSubresourceCustom(in *apiextensions.CustomResourceSubresourceCustom, out *CustomResourceSubresourceCustom, s conversion.Scope) error {
+	out.Name = in.Name
+	if in.FieldPaths == nil {
+		out.FieldPaths = make([]string, 0)
+	} else {
+		out.FieldPaths = *(*[]string)(unsafe.Pointer(&in.FieldPaths))
+	}
+	out.Verbs = *(*[]string)(unsafe.Pointer(&in.Verbs))
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		*out = new(CustomResourceValidation)
+		if err := Convert_apiextensions_CustomResourceValidation_To_v1beta1_CustomResourceValidation(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Validation = nil
+	}
+	return nil
+}
+
+// Convert_apiextensions_CustomResourceSubresourceCustom_To_v1beta1_CustomResourceSubresourceCustom is an autogenerated conversion function.
+func Convert_apiextensions_CustomResourceSubresourceCustom_To_v1beta1_CustomResourceSubresourceCustom(in *apiextensions.CustomResourceSubresourceCustom, out *CustomResourceSubresourceCustom, s conversion.Scope) error {
+	return autoConvert_apiextensions_CustomResourceSubresourceCustom_To_v1beta1_CustomResourceSubresourceCustom(in, out, s)
+}
+
+func autoConvert_v1beta1_CustomResourceSubresourceScale_To_apiextensions_CustomResourceSubresourceScale(in *CustomResourceSubresourceScale, out *apiextensions.CustomResourceSubresourceScale, s conversion.Scope) error {
+	out.SpecReplicasPath = in.SpecReplicasPath
+	out.StatusReplicasPath = in.StatusReplicasPath
+	out.LabelSelectorPath = (*string)(unsafe.Pointer(in.LabelSelectorPath))
+	return nil
+}
+
+// Convert_v1beta1_CustomResourceSubresourceScale_To_apiextensions_CustomResourceSubresourceScale is an autogenerated conversion function.
+func Convert_v1beta1_CustomResourceSubresourceScale_To_apiextensions_CustomResourceSubresourceScale(in *CustomResourceSubresourceScale, out *apiextensions.CustomResourceSubresourceScale, s conversion.Scope) error {
+	return autoConvert_v1beta1_CustomResourceSubresourceScale_To_apiextensions_CustomResourceSubresourceScale(in, out, s)
+}
+
+func autoConvert_apiextensions_CustomResourceSubresourceScale_To_v1beta1_CustomResourceSubresourceScale(in *apiextensions.CustomResourceSubresourceScale, out *CustomResourceSubresourceScale, s conversion.Scope) error {
+	out.SpecReplicasPath = in.SpecReplicasPath
+	out.StatusReplicasPath = in.StatusReplicasPath
+	out.LabelSelectorPath = (*string)(unsafe.Pointer(in.LabelSelectorPath))
+	return nil
+}
+
+// Convert_apiextensions_CustomResourceSubresourceScale_To_v1beta1_CustomResourceSubresourceScale is an autogenerated conversion function.
+func Convert_apiextensions_CustomResourceSubresourceScale_To_v1beta1_CustomResourceSubresourceScale(in *apiextensions.CustomResourceSubresourceScale, out *CustomResourceSubresourceScale, s conversion.Scope) error {
+	return autoConvert_apiextensions_CustomResourceSubresourceScale_To_v1beta1_CustomResourceSubresourceScale(in, out, s)
+}
+
+func autoConvert_v1beta1_CustomResourceSubresourceStatus_To_apiextensions_CustomResourceSubresourceStatus(in *CustomResourceSubresourceStatus, out *apiextensions.CustomResourceSubresourceStatus, s conversion.Scope) error {
+	if in.InitialStatus != nil {
+		in, out := &in.InitialStatus, &out.InitialStatus
+		*out = new(apiextensions.JSON)
+		if err := Convert_v1beta1_JSON_To_apiextensions_JSON(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.InitialStatus = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_CustomResourceSubresourceStatus_To_apiextensions_CustomResourceSubresourceStatus is an autogenerated conversion function.
+func Convert_v1beta1_CustomResourceSubresourceStatus_To_apiextensions_CustomResourceSubresourceStatus(in *CustomResourceSubresourceStatus, out *apiextensions.CustomResourceSubresourceStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_CustomResourceSubresourceStatus_To_apiextensions_CustomResourceSubresourceStatus(in, out, s)
+}
+
+func autoConvert_apiextensions_CustomResourceSubresourceStatus_To_v1beta1_CustomResourceSubresourceStatus(in *apiextensions.CustomResourceSubresourceStatus, out *CustomResourceSubresourceStatus, s conversion.Scope) error {
+	if in.InitialStatus != nil {
+		in, out := &in.InitialStatus, &out.InitialStatus
+		*out = new(JSON)
+		if err := Convert_apiextensions_JSON_To_v1beta1_JSON(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.InitialStatus = nil
+	}
+	return nil
+}
+
+// Convert_apiextensions_CustomResourceSubresourceStatus_To_v1beta1_CustomResourceSubresourceStatus is an autogenerated conversion function.
+func Convert_apiextensions_CustomResourceSubresourceStatus_To_v1beta1_CustomResourceSubresourceStatus(in *apiextensions.CustomResourceSubresourceStatus, out *CustomResourceSubresourceStatus, s conversion.Scope) error {
+	return autoConvert_apiextensions_CustomResourceSubresourceStatus_To_v1beta1_CustomResourceSubresourceStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_CustomResourceSubresources_To_apiextensions_CustomResourceSubresources(in *CustomResourceSubresources, out *apiextensions.CustomResourceSubresources, s conversion.Scope) error {
+	out.Scale = (*apiextensions.CustomResourceSubresourceScale)(unsafe.Pointer(in.Scale))
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(apiextensions.CustomResourceSubresourceStatus)
+		if err := Convert_v1beta1_CustomResourceSubresourceStatus_To_apiextensions_CustomResourceSubresourceStatus(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Status = nil
+	}
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = make([]apiextensions.CustomResourceSubresourceCustom, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_CustomResourceSubresourceCustom_To_apiextensions_CustomResourceSubresourceCustom(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Custom = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_CustomResourceSubresources_To_apiextensions_CustomResourceSubresources is an autogenerated conversion function.
+func Convert_v1beta1_CustomResourceSubresources_To_apiextensions_CustomResourceSubresources(in *CustomResourceSubresources, out *apiextensions.CustomResourceSubresources, s conversion.Scope) error {
+	return autoConvert_v1beta1_CustomResourceSubresources_To_apiextensions_CustomResourceSubresources(in, out, s)
+}
+
+func autoConvert_apiextensions_CustomResourceSubresources_To_v1beta1_CustomResourceSubresources(in *apiextensions.CustomResourceSubresources, out *CustomResourceSubresources, s conversion.Scope) error {
+	out.Scale = (*CustomResourceSubresourceScale)(unsafe.Pointer(in.Scale))
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(CustomResourceSubresourceStatus)
+		if err := Convert_apiextensions_CustomResourceSubresourceStatus_To_v1beta1_CustomResourceSubresourceStatus(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Status = nil
+	}
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = make([]CustomResourceSubresourceCustom, len(*in))
+		for i := range *in {
+			if err := Convert_apiextensions_CustomResourceSubresourceCustom_To_v1beta1_CustomResourceSubresourceCustom(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Custom = nil
+	}
+	return nil
+}
+
+// Convert_apiextensions_CustomResourceSubresources_To_v1beta1_CustomResourceSubresources is an autogenerated conversion function.
+func Convert_apiextensions_CustomResourceSubresources_To_v1beta1_CustomResourceSubresources(in *apiextensions.CustomResourceSubresources, out *CustomResourceSubresources, s conversion.Scope) error {
+	return autoConvert_apiextensions_CustomResourceSubresources_To_v1beta1_CustomResourceSubresources(in, out, s)
+}
+
+func autoConvert_v1beta1_CustomResourceValidation_To_apiextensions_CustomResourceValidation(in *CustomResourceValidation, out *apiextensions.CustomResourceValidation, s conversion.Scope) error {
+	if in.OpenAPIV3Schema != nil {
+		in, out := &in.OpenAPIV3Schema, &out.OpenAPIV3Schema
+		*out = new(apiextensions.JSONSchemaProps)
+		if err := Convert_v1beta1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.OpenAPIV3Schema = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_CustomResourceValidation_To_apiextensions_CustomResourceValidation is an autogenerated conversion function.
+func Convert_v1beta1_CustomResourceValidation_To_apiextensions_CustomResourceValidation(in *CustomResourceValidation, out *apiextensions.CustomResourceValidation, s conversion.Scope) error {
+	return autoConvert_v1beta1_CustomResourceValidation_To_apiextensions_CustomResourceValidation(in, out, s)
+}
+
+func autoConvert_apiextensions_CustomResourceValidation_To_v1beta1_CustomResourceValidation(in *apiextensions.CustomResourceValidation, out *CustomResourceValidation, s conversion.Scope) error {
+	if in.OpenAPIV3Schema != nil {
+		in, out := &in.OpenAPIV3Schema, &out.OpenAPIV3Schema
+		*out = new(JSONSchemaProps)
+		if err := Convert_apiextensions_JSONSchemaProps_To_v1beta1_JSONSchemaProps(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.OpenAPIV3Schema = nil
+	}
+	return nil
+}
+
+// Convert_apiextensions_CustomResourceValidation_To_v1beta1_CustomResourceValidation is an autogenerated conversion function.
+func Convert_apiextensions_CustomResourceValidation_To_v1beta1_CustomResourceValidation(in *apiextensions.CustomResourceValidation, out *CustomResourceValidation, s conversion.Scope) error {
+	return autoConvert_apiextensions_CustomResourceValidation_To_v1beta1_CustomResourceValidation(in, out, s)
+}
+
+func autoConvert_v1beta1_ExternalDocumentation_To_apiextensions_ExternalDocumentation(in *ExternalDocumentation, out *apiextensions.ExternalDocumentation, s conversion.Scope) error {
+	out.Description = in.Description
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_v1beta1_ExternalDocumentation_To_apiextensions_ExternalDocumentation is an autogenerated conversion function.
+func Convert_v1beta1_ExternalDocumentation_To_apiextensions_ExternalDocumentation(in *ExternalDocumentation, out *apiextensions.ExternalDocumentation, s conversion.Scope) error {
+	return autoConvert_v1beta1_ExternalDocumentation_To_apiextensions_ExternalDocumentation(in, out, s)
+}
+
+func autoConvert_apiextensions_ExternalDocumentation_To_v1beta1_ExternalDocumentation(in *apiextensions.ExternalDocumentation, out *ExternalDocumentation, s conversion.Scope) error {
+	out.Description = in.Description
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_apiextensions_ExternalDocumentation_To_v1beta1_ExternalDocumentation is an autogenerated conversion function.
+func Convert_apiextensions_ExternalDocumentation_To_v1beta1_ExternalDocumentation(in *apiextensions.ExternalDocumentation, out *ExternalDocumentation, s conversion.Scope) error {
+	return autoConvert_apiextensions_ExternalDocumentation_To_v1beta1_ExternalDocumentation(in, out, s)
+}
+
+func autoConvert_v1beta1_JSON_To_apiextensions_JSON(in *JSON, out *apiextensions.JSON, s conversion.Scope) error {
+	// WARNING: in.Raw requires manual conversion: does not exist in peer-type
+	return nil
+}
+
+func autoConvert_apiextensions_JSON_To_v1beta1_JSON(in *apiextensions.JSON, out *JSON, s conversion.Scope) error {
+	return nil
+}
+
+func autoConvert_v1beta1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(in *JSONSchemaProps, out *apiextensions.JSONSchemaProps, s conversion.Scope) error {
+	out.ID = in.ID
+	out.Schema = apiextensions.JSONSchemaURL(in.Schema)
+	out.Ref = (*string)(unsafe.Pointer(in.Ref))
+	out.Description = in.Description
+	out.Type = in.Type
+	out.Format = in.Format
+	out.Title = in.Title
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(apiextensions.JSON)
+		if err := Convert_v1beta1_JSON_To_apiextensions_JSON(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Default = nil
+	}
+	out.Maximum = (*float64)(unsafe.Pointer(in.Maximum))
+	out.ExclusiveMaximum = in.ExclusiveMaximum
+	out.Minimum = (*float64)(unsafe.Pointer(in.Minimum))
+	out.ExclusiveMinimum = in.ExclusiveMinimum
+	out.MaxLength = (*int64)(unsafe.Pointer(in.MaxLength))
+	out.MinLength = (*int64)(unsafe.Pointer(in.MinLength))
+	out.Pattern = in.Pattern
+	out.MaxItems = (*int64)(unsafe.Pointer(in.MaxItems))
+	out.MinItems = (*int64)(unsafe.Pointer(in.MinItems))
+	out.UniqueItems = in.UniqueItems
+	out.MultipleOf = (*float64)(unsafe.Pointer(in.MultipleOf))
+	if in.Enum != nil {
+		in, out := &in.Enum, &out.Enum
+		*out = make([]apiextensions.JSON, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_JSON_To_apiextensions_JSON(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Enum = nil
+	}
+	out.MaxProperties = (*int64)(unsafe.Pointer(in.MaxProperties))
+	out.MinProperties = (*int64)(unsafe.Pointer(in.MinProperties))
+	out.Required = *(*[]string)(unsafe.Pointer(&in.Required))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = new(apiextensions.JSONSchemaPropsOrArray)
+		if err := Convert_v1beta1_JSONSchemaPropsOrArray_To_apiextensions_JSONSchemaPropsOrArray(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Items = nil
+	}
+	if in.AllOf != nil {
+		in, out := &in.AllOf, &out.AllOf
+		*out = make([]apiextensions.JSONSchemaProps, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AllOf = nil
+	}
+	if in.OneOf != nil {
+		in, out := &in.OneOf, &out.OneOf
+		*out = make([]apiextensions.JSONSchemaProps, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.OneOf = nil
+	}
+	if in.AnyOf != nil {
+		in, out := &in.AnyOf, &out.AnyOf
+		*out = make([]apiextensions.JSONSchemaProps, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AnyOf = nil
+	}
+	if in.Not != nil {
+		in, out := &in.Not, &out.Not
+		*out = new(apiextensions.JSONSchemaProps)
+		if err := Convert_v1beta1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Not = nil
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]apiextensions.JSONSchemaProps, len(*in))
+		for key, val := range *in {
+			newVal := new(apiextensions.JSONSchemaProps)
+			if err := Convert_v1beta1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Properties = nil
+	}
+	if in.AdditionalProperties != nil {
+		in, out := &in.AdditionalProperties, &out.AdditionalProperties
+		*out = new(apiextensions.JSONSchemaPropsOrBool)
+		if err := Convert_v1beta1_JSONSchemaPropsOrBool_To_apiextensions_JSONSchemaPropsOrBool(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AdditionalProperties = nil
+	}
+	if in.PatternProperties != nil {
+		in, out := &in.PatternProperties, &out.PatternProperties
+		*out = make(map[string]apiextensions.JSONSchemaProps, len(*in))
+		for key, val := range *in {
+			newVal := new(apiextensions.JSONSchemaProps)
+			if err := Convert_v1beta1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.PatternProperties = nil
+	}
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make(apiextensions.JSONSchemaDependencies, len(*in))
+		for key, val := range *in {
+			newVal := new(apiextensions.JSONSchemaPropsOrStringArray)
+			if err := Convert_v1beta1_JSONSchemaPropsOrStringArray_To_apiextensions_JSONSchemaPropsOrStringArray(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Dependencies = nil
+	}
+	if in.AdditionalItems != nil {
+		in, out := &in.AdditionalItems, &out.AdditionalItems
+		*out = new(apiextensions.JSONSchemaPropsOrBool)
+		if err := Convert_v1beta1_JSONSchemaPropsOrBool_To_apiextensions_JSONSchemaPropsOrBool(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AdditionalItems = nil
+	}
+	if in.Definitions != nil {
+		in, out := &in.Definitions, &out.Definitions
+		*out = make(apiextensions.JSONSchemaDefinitions, len(*in))
+		for key, val := range *in {
+			newVal := new(apiextensions.JSONSchemaProps)
+			if err := Convert_v1beta1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Definitions = nil
+	}
+	out.ExternalDocs = (*apiextensions.ExternalDocumentation)(unsafe.Pointer(in.ExternalDocs))
+	if in.Example != nil {
+		in, out := &in.Example, &out.Example
+		*out = new(apiextensions.JSON)
+		if err := Convert_v1beta1_JSON_To_apiextensions_JSON(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Example = nil
+	}
+	out.XValidations = *(*apiextensions.ValidationRules)(unsafe.Pointer(&in.XValidations))
+	out.XPreserveUnknownFields = (*bool)(unsafe.Pointer(in.XPreserveUnknownFields))
+	out.XEmbeddedResource = in.XEmbeddedResource
+	out.XIntOrString = in.XIntOrString
+	out.XListType = (*string)(unsafe.Pointer(in.XListType))
+	out.XListMapKeys = *(*[]string)(unsafe.Pointer(&in.XListMapKeys))
+	out.XMapType = (*string)(unsafe.Pointer(in.XMapType))
+	out.XImmutable = in.XImmutable
+	return nil
+}
+
+// Convert_v1beta1_JSONSchemaProps_To_apiextensions_JSONSchemaProps is an autogenerated conversion function.
+func Convert_v1beta1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(in *JSONSchemaProps, out *apiextensions.JSONSchemaProps, s conversion.Scope) error {
+	return autoConvert_v1beta1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(in, out, s)
+}
+
+func autoConvert_apiextensions_JSONSchemaProps_To_v1beta1_JSONSchemaProps(in *apiextensions.JSONSchemaProps, out *JSONSchemaProps, s conversion.Scope) error {
+	out.ID = in.ID
+	out.Schema = JSONSchemaURL(in.Schema)
+	out.Ref = (*string)(unsafe.Pointer(in.Ref))
+	out.Description = in.Description
+	out.Type = in.Type
+	out.Format = in.Format
+	out.Title = in.Title
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(JSON)
+		if err := Convert_apiextensions_JSON_To_v1beta1_JSON(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Default = nil
+	}
+	out.Maximum = (*float64)(unsafe.Pointer(in.Maximum))
+	out.ExclusiveMaximum = in.ExclusiveMaximum
+	out.Minimum = (*float64)(unsafe.Pointer(in.Minimum))
+	out.ExclusiveMinimum = in.ExclusiveMinimum
+	out.MaxLength = (*int64)(unsafe.Pointer(in.MaxLength))
+	out.MinLength = (*int64)(unsafe.Pointer(in.MinLength))
+	out.Pattern = in.Pattern
+	out.MaxItems = (*int64)(unsafe.Pointer(in.MaxItems))
+	out.MinItems = (*int64)(unsafe.Pointer(in.MinItems))
+	out.UniqueItems = in.UniqueItems
+	out.MultipleOf = (*float64)(unsafe.Pointer(in.MultipleOf))
+	if in.Enum != nil {
+		in, out := &in.Enum, &out.Enum
+		*out = make([]JSON, len(*in))
+		for i := range *in {
+			if err := Convert_apiextensions_JSON_To_v1beta1_JSON(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Enum = nil
+	}
+	out.MaxProperties = (*int64)(unsafe.Pointer(in.MaxProperties))
+	out.MinProperties = (*int64)(unsafe.Pointer(in.MinProperties))
+	out.Required = *(*[]string)(unsafe.Pointer(&in.Required))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = new(JSONSchemaPropsOrArray)
+		if err := Convert_apiextensions_JSONSchemaPropsOrArray_To_v1beta1_JSONSchemaPropsOrArray(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Items = nil
+	}
+	if in.AllOf != nil {
+		in, out := &in.AllOf, &out.AllOf
+		*out = make([]JSONSchemaProps, len(*in))
+		for i := range *in {
+			if err := Convert_apiextensions_JSONSchemaProps_To_v1beta1_JSONSchemaProps(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AllOf = nil
+	}
+	if in.OneOf != nil {
+		in, out := &in.OneOf, &out.OneOf
+		*out = make([]JSONSchemaProps, len(*in))
+		for i := range *in {
+			if err := Convert_apiextensions_JSONSchemaProps_To_v1beta1_JSONSchemaProps(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.OneOf = nil
+	}
+	if in.AnyOf != nil {
+		in, out := &in.AnyOf, &out.AnyOf
+		*out = make([]JSONSchemaProps, len(*in))
+		for i := range *in {
+			if err := Convert_apiextensions_JSONSchemaProps_To_v1beta1_JSONSchemaProps(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AnyOf = nil
+	}
+	if in.Not != nil {
+		in, out := &in.Not, &out.Not
+		*out = new(JSONSchemaProps)
+		if err := Convert_apiextensions_JSONSchemaProps_To_v1beta1_JSONSchemaProps(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Not = nil
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]JSONSchemaProps, len(*in))
+		for key, val := range *in {
+			newVal := new(JSONSchemaProps)
+			if err := Convert_apiextensions_JSONSchemaProps_To_v1beta1_JSONSchemaProps(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Properties = nil
+	}
+	if in.AdditionalProperties != nil {
+		in, out := &in.AdditionalProperties, &out.AdditionalProperties
+		*out = new(JSONSchemaPropsOrBool)
+		if err := Convert_apiextensions_JSONSchemaPropsOrBool_To_v1beta1_JSONSchemaPropsOrBool(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AdditionalProperties = nil
+	}
+	if in.PatternProperties != nil {
+		in, out := &in.PatternProperties, &out.PatternProperties
+		*out = make(map[string]JSONSchemaProps, len(*in))
+		for key, val := range *in {
+			newVal := new(JSONSchemaProps)
+			if err := Convert_apiextensions_JSONSchemaProps_To_v1beta1_JSONSchemaProps(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.PatternProperties = nil
+	}
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make(JSONSchemaDependencies, len(*in))
+		for key, val := range *in {
+			newVal := new(JSONSchemaPropsOrStringArray)
+			if err := Convert_apiextensions_JSONSchemaPropsOrStringArray_To_v1beta1_JSONSchemaPropsOrStringArray(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Dependencies = nil
+	}
+	if in.AdditionalItems != nil {
+		in, out := &in.AdditionalItems, &out.AdditionalItems
+		*out = new(JSONSchemaPropsOrBool)
+		if err := Convert_apiextensions_JSONSchemaPropsOrBool_To_v1beta1_JSONSchemaPropsOrBool(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AdditionalItems = nil
+	}
+	if in.Definitions != nil {
+		in, out := &in.Definitions, &out.Definitions
+		*out = make(JSONSchemaDefinitions, len(*in))
+		for key, val := range *in {
+			newVal := new(JSONSchemaProps)
+			if err := Convert_apiextensions_JSONSchemaProps_To_v1beta1_JSONSchemaProps(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Definitions = nil
+	}
+	out.ExternalDocs = (*ExternalDocumentation)(unsafe.Pointer(in.ExternalDocs))
+	if in.Example != nil {
+		in, out := &in.Example, &out.Example
+		*out = new(JSON)
+		if err := Convert_apiextensions_JSON_To_v1beta1_JSON(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Example = nil
+	}
+	out.XValidations = *(*ValidationRules)(unsafe.Pointer(&in.XValidations))
+	out.XPreserveUnknownFields = (*bool)(unsafe.Pointer(in.XPreserveUnknownFields))
+	out.XEmbeddedResource = in.XEmbeddedResource
+	out.XIntOrString = in.XIntOrString
+	out.XListType = (*string)(unsafe.Pointer(in.XListType))
+	out.XListMapKeys = *(*[]string)(unsafe.Pointer(&in.XListMapKeys))
+	out.XMapType = (*string)(unsafe.Pointer(in.XMapType))
+	out.XImmutable = in.XImmutable
+	return nil
+}
+
+func autoConvert_v1beta1_JSONSchemaPropsOrArray_To_apiextensions_JSONSchemaPropsOrArray(in *JSONSchemaPropsOrArray, out *apiextensions.JSONSchemaPropsOrArray, s conversion.Scope) error {
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(apiextensions.JSONSchemaProps)
+		if err := Convert_v1beta1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Schema = nil
+	}
+	if in.JSONSchemas != nil {
+		in, out := &in.JSONSchemas, &out.JSONSchemas
+		*out = make([]apiextensions.JSONSchemaProps, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.JSONSchemas = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_JSONSchemaPropsOrArray_To_apiextensions_JSONSchemaPropsOrArray is an autogenerated conversion function.
+func Convert_v1beta1_JSONSchemaPropsOrArray_To_apiextensions_JSONSchemaPropsOrArray(in *JSONSchemaPropsOrArray, out *apiextensions.JSONSchemaPropsOrArray, s conversion.Scope) error {
+	return autoConvert_v1beta1_JSONSchemaPropsOrArray_To_apiextensions_JSONSchemaPropsOrArray(in, out, s)
+}
+
+func autoConvert_apiextensions_JSONSchemaPropsOrArray_To_v1beta1_JSONSchemaPropsOrArray(in *apiextensions.JSONSchemaPropsOrArray, out *JSONSchemaPropsOrArray, s conversion.Scope) error {
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(JSONSchemaProps)
+		if err := Convert_apiextensions_JSONSchemaProps_To_v1beta1_JSONSchemaProps(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Schema = nil
+	}
+	if in.JSONSchemas != nil {
+		in, out := &in.JSONSchemas, &out.JSONSchemas
+		*out = make([]JSONSchemaProps, len(*in))
+		for i := range *in {
+			if err := Convert_apiextensions_JSONSchemaProps_To_v1beta1_JSONSchemaProps(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.JSONSchemas = nil
+	}
+	return nil
+}
+
+// Convert_apiextensions_JSONSchemaPropsOrArray_To_v1beta1_JSONSchemaPropsOrArray is an autogenerated conversion function.
+func Convert_apiextensions_JSONSchemaPropsOrArray_To_v1beta1_JSONSchemaPropsOrArray(in *apiextensions.JSONSchemaPropsOrArray, out *JSONSchemaPropsOrArray, s conversion.Scope) error {
+	return autoConvert_apiextensions_JSONSchemaPropsOrArray_To_v1beta1_JSONSchemaPropsOrArray(in, out, s)
+}
+
+func autoConvert_v1beta1_JSONSchemaPropsOrBool_To_apiextensions_JSONSchemaPropsOrBool(in *JSONSchemaPropsOrBool, out *apiextensions.JSONSchemaPropsOrBool, s conversion.Scope) error {
+	out.Allows = in.Allows
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(apiextensions.JSONSchemaProps)
+		if err := Convert_v1beta1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Schema = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_JSONSchemaPropsOrBool_To_apiextensions_JSONSchemaPropsOrBool is an autogenerated conversion function.
+func Convert_v1beta1_JSONSchemaPropsOrBool_To_apiextensions_JSONSchemaPropsOrBool(in *JSONSchemaPropsOrBool, out *apiextensions.JSONSchemaPropsOrBool, s conversion.Scope) error {
+	return autoConvert_v1beta1_JSONSchemaPropsOrBool_To_apiextensions_JSONSchemaPropsOrBool(in, out, s)
+}
+
+func autoConvert_apiextensions_JSONSchemaPropsOrBool_To_v1beta1_JSONSchemaPropsOrBool(in *apiextensions.JSONSchemaPropsOrBool, out *JSONSchemaPropsOrBool, s conversion.Scope) error {
+	out.Allows = in.Allows
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(JSONSchemaProps)
+		if err := Convert_apiextensions_JSONSchemaProps_To_v1beta1_JSONSchemaProps(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Schema = nil
+	}
+	return nil
+}
+
+// Convert_apiextensions_JSONSchemaPropsOrBool_To_v1beta1_JSONSchemaPropsOrBool is an autogenerated conversion function.
+func Convert_apiextensions_JSONSchemaPropsOrBool_To_v1beta1_JSONSchemaPropsOrBool(in *apiextensions.JSONSchemaPropsOrBool, out *JSONSchemaPropsOrBool, s conversion.Scope) error {
+	return autoConvert_apiextensions_JSONSchemaPropsOrBool_To_v1beta1_JSONSchemaPropsOrBool(in, out, s)
+}
+
+func autoConvert_v1beta1_JSONSchemaPropsOrStringArray_To_apiextensions_JSONSchemaPropsOrStringArray(in *JSONSchemaPropsOrStringArray, out *apiextensions.JSONSchemaPropsOrStringArray, s conversion.Scope) error {
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(apiextensions.JSONSchemaProps)
+		if err := Convert_v1beta1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Schema = nil
+	}
+	out.Property = *(*[]string)(unsafe.Pointer(&in.Property))
+	return nil
+}
+
+// Convert_v1beta1_JSONSchemaPropsOrStringArray_To_apiextensions_JSONSchemaPropsOrStringArray is an autogenerated conversion function.
+func Convert_v1beta1_JSONSchemaPropsOrStringArray_To_apiextensions_JSONSchemaPropsOrStringArray(in *JSONSchemaPropsOrStringArray, out *apiextensions.JSONSchemaPropsOrStringArray, s conversion.Scope) error {
+	return autoConvert_v1beta1_JSONSchemaPropsOrStringArray_To_apiextensions_JSONSchemaPropsOrStringArray(in, out, s)
+}
+
+func autoConvert_apiextensions_JSONSchemaPropsOrStringArray_To_v1beta1_JSONSchemaPropsOrStringArray(in *apiextensions.JSONSchemaPropsOrStringArray, out *JSONSchemaPropsOrStringArray, s conversion.Scope) error {
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(JSONSchemaProps)
+		if err := Convert_apiextensions_JSONSchemaProps_To_v1beta1_JSONSchemaProps(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Schema = nil
+	}
+	out.Property = *(*[]string)(unsafe.Pointer(&in.Property))
+	return nil
+}
+
+// Convert_apiextensions_JSONSchemaPropsOrStringArray_To_v1beta1_JSONSchemaPropsOrStringArray is an autogenerated conversion function.
+func Convert_apiextensions_JSONSchemaPropsOrStringArray_To_v1beta1_JSONSchemaPropsOrStringArray(in *apiextensions.JSONSchemaPropsOrStringArray, out *JSONSchemaPropsOrStringArray, s conversion.Scope) error {
+	return autoConvert_apiextensions_JSONSchemaPropsOrStringArray_To_v1beta1_JSONSchemaPropsOrStringArray(in, out, s)
+}
+
+func autoConvert_v1beta1_PersistedVersionCount_To_apiextensions_PersistedVersionCount(in *PersistedVersionCount, out *apiextensions.PersistedVersionCount, s conversion.Scope) error {
+	out.Version = in.Version
+	out.Count = in.Count
+	return nil
+}
+
+// Convert_v1beta1_PersistedVersionCount_To_apiextensions_PersistedVersionCount is an autogenerated conversion function.
+func Convert_v1beta1_PersistedVersionCount_To_apiextensions_PersistedVersionCount(in *PersistedVersionCount, out *apiextensions.PersistedVersionCount, s conversion.Scope) error {
+	return autoConvert_v1beta1_PersistedVersionCount_To_apiextensions_PersistedVersionCount(in, out, s)
+}
+
+func autoConvert_apiextensions_PersistedVersionCount_To_v1beta1_PersistedVersionCount(in *apiextensions.PersistedVersionCount, out *PersistedVersionCount, s conversion.Scope) error {
+	out.Version = in.Version
+	out.Count = in.Count
+	return nil
+}
+
+// Convert_apiextensions_PersistedVersionCount_To_v1beta1_PersistedVersionCount is an autogenerated conversion function.
+func Convert_apiextensions_PersistedVersionCount_To_v1beta1_PersistedVersionCount(in *apiextensions.PersistedVersionCount, out *PersistedVersionCount, s conversion.Scope) error {
+	return autoConvert_apiextensions_PersistedVersionCount_To_v1beta1_PersistedVersionCount(in, out, s)
+}
+
+func autoConvert_v1beta1_SelectableField_To_apiextensions_SelectableField(in *SelectableField, out *apiextensions.SelectableField, s conversion.Scope) error {
+	out.JSONPath = in.JSONPath
+	return nil
+}
+
+// Convert_v1beta1_SelectableField_To_apiextensions_SelectableField is an autogenerated conversion function.
+func Convert_v1beta1_SelectableField_To_apiextensions_SelectableField(in *SelectableField, out *apiextensions.SelectableField, s conversion.Scope) error {
+	return autoConvert_v1beta1_SelectableField_To_apiextensions_SelectableField(in, out, s)
+}
+
+func autoConvert_apiextensions_SelectableField_To_v1beta1_SelectableField(in *apiextensions.SelectableField, out *SelectableField, s conversion.Scope) error {
+	out.JSONPath = in.JSONPath
+	return nil
+}
+
+// Convert_apiextensions_SelectableField_To_v1beta1_SelectableField is an autogenerated conversion function.
+func Convert_apiextensions_SelectableField_To_v1beta1_SelectableField(in *apiextensions.SelectableField, out *SelectableField, s conversion.Scope) error {
+	return autoConvert_apiextensions_SelectableField_To_v1beta1_SelectableField(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceReference_To_apiextensions_ServiceReference(in *ServiceReference, out *apiextensions.ServiceReference, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.Path = (*string)(unsafe.Pointer(in.Path))
+	return nil
+}
+
+// Convert_v1beta1_ServiceReference_To_apiextensions_ServiceReference is an autogenerated conversion function.
+func Convert_v1beta1_ServiceReference_To_apiextensions_ServiceReference(in *ServiceReference, out *apiextensions.ServiceReference, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceReference_To_apiextensions_ServiceReference(in, out, s)
+}
+
+func autoConvert_apiextensions_ServiceReference_To_v1beta1_ServiceReference(in *apiextensions.ServiceReference, out *ServiceReference, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.Path = (*string)(unsafe.Pointer(in.Path))
+	return nil
+}
+
+// Convert_apiextensions_ServiceReference_To_v1beta1_ServiceReference is an autogenerated conversion function.
+func Convert_apiextensions_ServiceReference_To_v1beta1_ServiceReference(in *apiextensions.ServiceReference, out *ServiceReference, s conversion.Scope) error {
+	return autoConvert_apiextensions_ServiceReference_To_v1beta1_ServiceReference(in, out, s)
+}
+
+func autoConvert_v1beta1_ValidationRule_To_apiextensions_ValidationRule(in *ValidationRule, out *apiextensions.ValidationRule, s conversion.Scope) error {
+	out.Rule = in.Rule
+	out.Message = in.Message
+	out.MessageExpression = in.MessageExpression
+	out.Reason = apiextensions.FieldValueErrorReason(in.Reason)
+	out.FieldPath = in.FieldPath
+	return nil
+}
+
+// Convert_v1beta1_ValidationRule_To_apiextensions_ValidationRule is an autogenerated conversion function.
+func Convert_v1beta1_ValidationRule_To_apiextensions_ValidationRule(in *ValidationRule, out *apiextensions.ValidationRule, s conversion.Scope) error {
+	return autoConvert_v1beta1_ValidationRule_To_apiextensions_ValidationRule(in, out, s)
+}
+
+func autoConvert_apiextensions_ValidationRule_To_v1beta1_ValidationRule(in *apiextensions.ValidationRule, out *ValidationRule, s conversion.Scope) error {
+	out.Rule = in.Rule
+	out.Message = in.Message
+	out.MessageExpression = in.MessageExpression
+	out.Reason = FieldValueErrorReason(in.Reason)
+	out.FieldPath = in.FieldPath
+	return nil
+}
+
+// Convert_apiextensions_ValidationRule_To_v1beta1_ValidationRule is an autogenerated conversion function.
+func Convert_apiextensions_ValidationRule_To_v1beta1_ValidationRule(in *apiextensions.ValidationRule, out *ValidationRule, s conversion.Scope) error {
+	return autoConvert_apiextensions_ValidationRule_To_v1beta1_ValidationRule(in, out, s)
+}
+
+func autoConvert_v1beta1_WebhookClientConfig_To_apiextensions_WebhookClientConfig(in *WebhookClientConfig, out *apiextensions.WebhookClientConfig, s conversion.Scope) error {
+	out.URL = (*string)(unsafe.Pointer(in.URL))
+	out.Service = (*apiextensions.ServiceReference)(unsafe.Pointer(in.Service))
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	return nil
+}
+
+// Convert_v1beta1_WebhookClientConfig_To_apiextensions_WebhookClientConfig is an autogenerated conversion function.
+func Convert_v1beta1_WebhookClientConfig_To_apiextensions_WebhookClientConfig(in *WebhookClientConfig, out *apiextensions.WebhookClientConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_WebhookClientConfig_To_apiextensions_WebhookClientConfig(in, out, s)
+}
+
+func autoConvert_apiextensions_WebhookClientConfig_To_v1beta1_WebhookClientConfig(in *apiextensions.WebhookClientConfig, out *WebhookClientConfig, s conversion.Scope) error {
+	out.URL = (*string)(unsafe.Pointer(in.URL))
+	out.Service = (*ServiceReference)(unsafe.Pointer(in.Service))
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	return nil
+}
+
+// Convert_apiextensions_WebhookClientConfig_To_v1beta1_WebhookClientConfig is an autogenerated conversion function.
+func Convert_apiextensions_WebhookClientConfig_To_v1beta1_WebhookClientConfig(in *apiextensions.WebhookClientConfig, out *WebhookClientConfig, s conversion.Scope) error {
+	return autoConvert_apiextensions_WebhookClientConfig_To_v1beta1_WebhookClientConfig(in, out, s)
+}
@@ -35,6 +35,34 @@ func init() {
 // to allow building arbitrary schemes.
 func RegisterDeepCopies(scheme *runtime.Scheme) error {
 	return scheme.AddGeneratedDeepCopyFuncs(
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*ConversionExpression).DeepCopyInto(out.(*ConversionExpression))
+			return nil
+		}, InType: reflect.TypeOf(&ConversionExpression{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*ConversionFieldMapping).DeepCopyInto(out.(*ConversionFieldMapping))
+			return nil
+		}, InType: reflect.TypeOf(&ConversionFieldMapping{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*ConversionRequest).DeepCopyInto(out.(*ConversionRequest))
+			return nil
+		}, InType: reflect.TypeOf(&ConversionRequest{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*ConversionResponse).DeepCopyInto(out.(*ConversionResponse))
+			return nil
+		}, InType: reflect.TypeOf(&ConversionResponse{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*ConversionReview).DeepCopyInto(out.(*ConversionReview))
+			return nil
+		}, InType: reflect.TypeOf(&ConversionReview{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*CustomResourceColumnDefinition).DeepCopyInto(out.(*CustomResourceColumnDefinition))
+			return nil
+		}, InType: reflect.TypeOf(&CustomResourceColumnDefinition{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*CustomResourceConversion).DeepCopyInto(out.(*CustomResourceConversion))
+			return nil
+		}, InType: reflect.TypeOf(&CustomResourceConversion{})},
 		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
 			in.(*CustomResourceDefinition).DeepCopyInto(out.(*CustomResourceDefinition))
 			return nil
@@ -59,9 +87,271 @@ func RegisterDeepCopies(scheme *runtime.Scheme) error {
 			in.(*CustomResourceDefinitionStatus).DeepCopyInto(out.(*CustomResourceDefinitionStatus))
 			return nil
 		}, InType: reflect.TypeOf(&CustomResourceDefinitionStatus{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*CustomResourceDefinitionVersion).DeepCopyInto(out.(*CustomResourceDefinitionVersion))
+			return nil
+		}, InType: reflect.TypeOf(&CustomResourceDefinitionVersion{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*CustomResourceStorage).DeepCopyInto(out.(*CustomResourceStorage))
+			return nil
+		}, InType: reflect.TypeOf(&CustomResourceStorage{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*CustomResourceSubresourceCustom).DeepCopyInto(out.(*CustomResourceSubresourceCustom))
+			return nil
+		}, InType: reflect.TypeOf(&CustomResourceSubresourceCustom{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*CustomResourceSubresourceScale).DeepCopyInto(out.(*CustomResourceSubresourceScale))
+			return nil
+		}, InType: reflect.TypeOf(&CustomResourceSubresourceScale{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*CustomResourceSubresourceStatus).DeepCopyInto(out.(*CustomResourceSubresourceStatus))
+			return nil
+		}, InType: reflect.TypeOf(&CustomResourceSubresourceStatus{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*CustomResourceSubresources).DeepCopyInto(out.(*CustomResourceSubresources))
+			return nil
+		}, InType: reflect.TypeOf(&CustomResourceSubresources{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*CustomResourceValidation).DeepCopyInto(out.(*CustomResourceValidation))
+			return nil
+		}, InType: reflect.TypeOf(&CustomResourceValidation{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*ExternalDocumentation).DeepCopyInto(out.(*ExternalDocumentation))
+			return nil
+		}, InType: reflect.TypeOf(&ExternalDocumentation{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*JSON).DeepCopyInto(out.(*JSON))
+			return nil
+		}, InType: reflect.TypeOf(&JSON{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*JSONSchemaProps).DeepCopyInto(out.(*JSONSchemaProps))
+			return nil
+		}, InType: reflect.TypeOf(&JSONSchemaProps{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*JSONSchemaPropsOrArray).DeepCopyInto(out.(*JSONSchemaPropsOrArray))
+			return nil
+		}, InType: reflect.TypeOf(&JSONSchemaPropsOrArray{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*JSONSchemaPropsOrBool).DeepCopyInto(out.(*JSONSchemaPropsOrBool))
+			return nil
+		}, InType: reflect.TypeOf(&JSONSchemaPropsOrBool{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*JSONSchemaPropsOrStringArray).DeepCopyInto(out.(*JSONSchemaPropsOrStringArray))
+			return nil
+		}, InType: reflect.TypeOf(&JSONSchemaPropsOrStringArray{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*PersistedVersionCount).DeepCopyInto(out.(*PersistedVersionCount))
+			return nil
+		}, InType: reflect.TypeOf(&PersistedVersionCount{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*SelectableField).DeepCopyInto(out.(*SelectableField))
+			return nil
+		}, InType: reflect.TypeOf(&SelectableField{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*ServiceReference).DeepCopyInto(out.(*ServiceReference))
+			return nil
+		}, InType: reflect.TypeOf(&ServiceReference{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*ValidationRule).DeepCopyInto(out.(*ValidationRule))
+			return nil
+		}, InType: reflect.TypeOf(&ValidationRule{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*WebhookClientConfig).DeepCopyInto(out.(*WebhookClientConfig))
+			return nil
+		}, InType: reflect.TypeOf(&WebhookClientConfig{})},
 	)
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConversionExpression) DeepCopyInto(out *ConversionExpression) {
+	*out = *in
+	if in.FieldMappings != nil {
+		in, out := &in.FieldMappings, &out.FieldMappings
+		*out = make([]ConversionFieldMapping, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new ConversionExpression.
+func (x *ConversionExpression) DeepCopy() *ConversionExpression {
+	if x == nil {
+		return nil
+	}
+	out := new(ConversionExpression)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConversionFieldMapping) DeepCopyInto(out *ConversionFieldMapping) {
+	*out = *in
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new ConversionFieldMapping.
+func (x *ConversionFieldMapping) DeepCopy() *ConversionFieldMapping {
+	if x == nil {
+		return nil
+	}
+	out := new(ConversionFieldMapping)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConversionRequest) DeepCopyInto(out *ConversionRequest) {
+	*out = *in
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new ConversionRequest.
+func (x *ConversionRequest) DeepCopy() *ConversionRequest {
+	if x == nil {
+		return nil
+	}
+	out := new(ConversionRequest)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConversionResponse) DeepCopyInto(out *ConversionResponse) {
+	*out = *in
+	if in.ConvertedObjects != nil {
+		in, out := &in.ConvertedObjects, &out.ConvertedObjects
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Result.DeepCopyInto(&out.Result)
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new ConversionResponse.
+func (x *ConversionResponse) DeepCopy() *ConversionResponse {
+	if x == nil {
+		return nil
+	}
+	out := new(ConversionResponse)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConversionReview) DeepCopyInto(out *ConversionReview) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ConversionRequest)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Response != nil {
+		in, out := &in.Response, &out.Response
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ConversionResponse)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new ConversionReview.
+func (x *ConversionReview) DeepCopy() *ConversionReview {
+	if x == nil {
+		return nil
+	}
+	out := new(ConversionReview)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (x *ConversionReview) DeepCopyObject() runtime.Object {
+	if c := x.DeepCopy(); c != nil {
+		return c
+	} else {
+		return nil
+	}
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResourceColumnDefinition) DeepCopyInto(out *CustomResourceColumnDefinition) {
+	*out = *in
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new CustomResourceColumnDefinition.
+func (x *CustomResourceColumnDefinition) DeepCopy() *CustomResourceColumnDefinition {
+	if x == nil {
+		return nil
+	}
+	out := new(CustomResourceColumnDefinition)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResourceConversion) DeepCopyInto(out *CustomResourceConversion) {
+	*out = *in
+	if in.WebhookClientConfig != nil {
+		in, out := &in.WebhookClientConfig, &out.WebhookClientConfig
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(WebhookClientConfig)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.ConversionReviewVersions != nil {
+		in, out := &in.ConversionReviewVersions, &out.ConversionReviewVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Expressions != nil {
+		in, out := &in.Expressions, &out.Expressions
+		*out = make([]ConversionExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ConversionFailurePolicyType)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new CustomResourceConversion.
+func (x *CustomResourceConversion) DeepCopy() *CustomResourceConversion {
+	if x == nil {
+		return nil
+	}
+	out := new(CustomResourceConversion)
+	x.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomResourceDefinition) DeepCopyInto(out *CustomResourceDefinition) {
 	*out = *in
@@ -150,6 +440,11 @@ func (in *CustomResourceDefinitionNames) DeepCopyInto(out *CustomResourceDefinit
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Categories != nil {
+		in, out := &in.Categories, &out.Categories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -167,6 +462,73 @@ func (x *CustomResourceDefinitionNames) DeepCopy() *CustomResourceDefinitionName
 func (in *CustomResourceDefinitionSpec) DeepCopyInto(out *CustomResourceDefinitionSpec) {
 	*out = *in
 	in.Names.DeepCopyInto(&out.Names)
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CustomResourceValidation)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.PreserveUnknownFields != nil {
+		in, out := &in.PreserveUnknownFields, &out.PreserveUnknownFields
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(bool)
+			**out = **in
+		}
+	}
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]CustomResourceDefinitionVersion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conversion != nil {
+		in, out := &in.Conversion, &out.Conversion
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CustomResourceConversion)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Subresources != nil {
+		in, out := &in.Subresources, &out.Subresources
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CustomResourceSubresources)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.AdditionalPrinterColumns != nil {
+		in, out := &in.AdditionalPrinterColumns, &out.AdditionalPrinterColumns
+		*out = make([]CustomResourceColumnDefinition, len(*in))
+		copy(*out, *in)
+	}
+	if in.SelectableFields != nil {
+		in, out := &in.SelectableFields, &out.SelectableFields
+		*out = make([]SelectableField, len(*in))
+		copy(*out, *in)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CustomResourceStorage)
+			**out = **in
+		}
+	}
+	if in.AllowedFinalizerDomains != nil {
+		in, out := &in.AllowedFinalizerDomains, &out.AllowedFinalizerDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -191,6 +553,16 @@ func (in *CustomResourceDefinitionStatus) DeepCopyInto(out *CustomResourceDefini
 		}
 	}
 	in.AcceptedNames.DeepCopyInto(&out.AcceptedNames)
+	if in.StoredVersions != nil {
+		in, out := &in.StoredVersions, &out.StoredVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PersistedVersions != nil {
+		in, out := &in.PersistedVersions, &out.PersistedVersions
+		*out = make([]PersistedVersionCount, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -203,3 +575,747 @@ func (x *CustomResourceDefinitionStatus) DeepCopy() *CustomResourceDefinitionSta
 	x.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResourceDefinitionVersion) DeepCopyInto(out *CustomResourceDefinitionVersion) {
+	*out = *in
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CustomResourceValidation)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Subresources != nil {
+		in, out := &in.Subresources, &out.Subresources
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CustomResourceSubresources)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.AdditionalPrinterColumns != nil {
+		in, out := &in.AdditionalPrinterColumns, &out.AdditionalPrinterColumns
+		*out = make([]CustomResourceColumnDefinition, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeprecationWarning != nil {
+		in, out := &in.DeprecationWarning, &out.DeprecationWarning
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	if in.RemovedInRelease != nil {
+		in, out := &in.RemovedInRelease, &out.RemovedInRelease
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	if in.Example != nil {
+		in, out := &in.Example, &out.Example
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(JSON)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new CustomResourceDefinitionVersion.
+func (x *CustomResourceDefinitionVersion) DeepCopy() *CustomResourceDefinitionVersion {
+	if x == nil {
+		return nil
+	}
+	out := new(CustomResourceDefinitionVersion)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResourceStorage) DeepCopyInto(out *CustomResourceStorage) {
+	*out = *in
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new CustomResourceStorage.
+func (x *CustomResourceStorage) DeepCopy() *CustomResourceStorage {
+	if x == nil {
+		return nil
+	}
+	out := new(CustomResourceStorage)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResourceSubresourceCustom) DeepCopyInto(out *CustomResourceSubresourceCustom) {
+	*out = *in
+	if in.FieldPaths != nil {
+		in, out := &in.FieldPaths, &out.FieldPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Verbs != nil {
+		in, out := &in.Verbs, &out.Verbs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CustomResourceValidation)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new CustomResourceSubresourceCustom.
+func (x *CustomResourceSubresourceCustom) DeepCopy() *CustomResourceSubresourceCustom {
+	if x == nil {
+		return nil
+	}
+	out := new(CustomResourceSubresourceCustom)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResourceSubresourceScale) DeepCopyInto(out *CustomResourceSubresourceScale) {
+	*out = *in
+	if in.LabelSelectorPath != nil {
+		in, out := &in.LabelSelectorPath, &out.LabelSelectorPath
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new CustomResourceSubresourceScale.
+func (x *CustomResourceSubresourceScale) DeepCopy() *CustomResourceSubresourceScale {
+	if x == nil {
+		return nil
+	}
+	out := new(CustomResourceSubresourceScale)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResourceSubresourceStatus) DeepCopyInto(out *CustomResourceSubresourceStatus) {
+	*out = *in
+	if in.InitialStatus != nil {
+		in, out := &in.InitialStatus, &out.InitialStatus
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(JSON)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new CustomResourceSubresourceStatus.
+func (x *CustomResourceSubresourceStatus) DeepCopy() *CustomResourceSubresourceStatus {
+	if x == nil {
+		return nil
+	}
+	out := new(CustomResourceSubresourceStatus)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResourceSubresources) DeepCopyInto(out *CustomResourceSubresources) {
+	*out = *in
+	if in.Scale != nil {
+		in, out := &in.Scale, &out.Scale
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CustomResourceSubresourceScale)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CustomResourceSubresourceStatus)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = make([]CustomResourceSubresourceCustom, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new CustomResourceSubresources.
+func (x *CustomResourceSubresources) DeepCopy() *CustomResourceSubresources {
+	if x == nil {
+		return nil
+	}
+	out := new(CustomResourceSubresources)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResourceValidation) DeepCopyInto(out *CustomResourceValidation) {
+	*out = *in
+	if in.OpenAPIV3Schema != nil {
+		in, out := &in.OpenAPIV3Schema, &out.OpenAPIV3Schema
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(JSONSchemaProps)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new CustomResourceValidation.
+func (x *CustomResourceValidation) DeepCopy() *CustomResourceValidation {
+	if x == nil {
+		return nil
+	}
+	out := new(CustomResourceValidation)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDocumentation) DeepCopyInto(out *ExternalDocumentation) {
+	*out = *in
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDocumentation.
+func (x *ExternalDocumentation) DeepCopy() *ExternalDocumentation {
+	if x == nil {
+		return nil
+	}
+	out := new(ExternalDocumentation)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSON) DeepCopyInto(out *JSON) {
+	*out = *in
+	if in.Raw != nil {
+		in, out := &in.Raw, &out.Raw
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new JSON.
+func (x *JSON) DeepCopy() *JSON {
+	if x == nil {
+		return nil
+	}
+	out := new(JSON)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONSchemaProps) DeepCopyInto(out *JSONSchemaProps) {
+	*out = *in
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(JSON)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Maximum != nil {
+		in, out := &in.Maximum, &out.Maximum
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(float64)
+			**out = **in
+		}
+	}
+	if in.Minimum != nil {
+		in, out := &in.Minimum, &out.Minimum
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(float64)
+			**out = **in
+		}
+	}
+	if in.MaxLength != nil {
+		in, out := &in.MaxLength, &out.MaxLength
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(int64)
+			**out = **in
+		}
+	}
+	if in.MinLength != nil {
+		in, out := &in.MinLength, &out.MinLength
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(int64)
+			**out = **in
+		}
+	}
+	if in.MaxItems != nil {
+		in, out := &in.MaxItems, &out.MaxItems
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(int64)
+			**out = **in
+		}
+	}
+	if in.MinItems != nil {
+		in, out := &in.MinItems, &out.MinItems
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(int64)
+			**out = **in
+		}
+	}
+	if in.MultipleOf != nil {
+		in, out := &in.MultipleOf, &out.MultipleOf
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(float64)
+			**out = **in
+		}
+	}
+	if in.Enum != nil {
+		in, out := &in.Enum, &out.Enum
+		*out = make([]JSON, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxProperties != nil {
+		in, out := &in.MaxProperties, &out.MaxProperties
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(int64)
+			**out = **in
+		}
+	}
+	if in.MinProperties != nil {
+		in, out := &in.MinProperties, &out.MinProperties
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(int64)
+			**out = **in
+		}
+	}
+	if in.Required != nil {
+		in, out := &in.Required, &out.Required
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(JSONSchemaPropsOrArray)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.AllOf != nil {
+		in, out := &in.AllOf, &out.AllOf
+		*out = make([]JSONSchemaProps, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OneOf != nil {
+		in, out := &in.OneOf, &out.OneOf
+		*out = make([]JSONSchemaProps, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AnyOf != nil {
+		in, out := &in.AnyOf, &out.AnyOf
+		*out = make([]JSONSchemaProps, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Not != nil {
+		in, out := &in.Not, &out.Not
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(JSONSchemaProps)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]JSONSchemaProps, len(*in))
+		for key, val := range *in {
+			newVal := new(JSONSchemaProps)
+			val.DeepCopyInto(newVal)
+			(*out)[key] = *newVal
+		}
+	}
+	if in.AdditionalProperties != nil {
+		in, out := &in.AdditionalProperties, &out.AdditionalProperties
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(JSONSchemaPropsOrBool)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.PatternProperties != nil {
+		in, out := &in.PatternProperties, &out.PatternProperties
+		*out = make(map[string]JSONSchemaProps, len(*in))
+		for key, val := range *in {
+			newVal := new(JSONSchemaProps)
+			val.DeepCopyInto(newVal)
+			(*out)[key] = *newVal
+		}
+	}
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make(JSONSchemaDependencies, len(*in))
+		for key, val := range *in {
+			newVal := new(JSONSchemaPropsOrStringArray)
+			val.DeepCopyInto(newVal)
+			(*out)[key] = *newVal
+		}
+	}
+	if in.AdditionalItems != nil {
+		in, out := &in.AdditionalItems, &out.AdditionalItems
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(JSONSchemaPropsOrBool)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Definitions != nil {
+		in, out := &in.Definitions, &out.Definitions
+		*out = make(JSONSchemaDefinitions, len(*in))
+		for key, val := range *in {
+			newVal := new(JSONSchemaProps)
+			val.DeepCopyInto(newVal)
+			(*out)[key] = *newVal
+		}
+	}
+	if in.ExternalDocs != nil {
+		in, out := &in.ExternalDocs, &out.ExternalDocs
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ExternalDocumentation)
+			**out = **in
+		}
+	}
+	if in.Example != nil {
+		in, out := &in.Example, &out.Example
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(JSON)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.XValidations != nil {
+		in, out := &in.XValidations, &out.XValidations
+		*out = make(ValidationRules, len(*in))
+		copy(*out, *in)
+	}
+	if in.XPreserveUnknownFields != nil {
+		in, out := &in.XPreserveUnknownFields, &out.XPreserveUnknownFields
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(bool)
+			**out = **in
+		}
+	}
+	if in.XListType != nil {
+		in, out := &in.XListType, &out.XListType
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	if in.XListMapKeys != nil {
+		in, out := &in.XListMapKeys, &out.XListMapKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.XMapType != nil {
+		in, out := &in.XMapType, &out.XMapType
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new JSONSchemaProps.
+func (x *JSONSchemaProps) DeepCopy() *JSONSchemaProps {
+	if x == nil {
+		return nil
+	}
+	out := new(JSONSchemaProps)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONSchemaPropsOrArray) DeepCopyInto(out *JSONSchemaPropsOrArray) {
+	*out = *in
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(JSONSchemaProps)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.JSONSchemas != nil {
+		in, out := &in.JSONSchemas, &out.JSONSchemas
+		*out = make([]JSONSchemaProps, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new JSONSchemaPropsOrArray.
+func (x *JSONSchemaPropsOrArray) DeepCopy() *JSONSchemaPropsOrArray {
+	if x == nil {
+		return nil
+	}
+	out := new(JSONSchemaPropsOrArray)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONSchemaPropsOrBool) DeepCopyInto(out *JSONSchemaPropsOrBool) {
+	*out = *in
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(JSONSchemaProps)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new JSONSchemaPropsOrBool.
+func (x *JSONSchemaPropsOrBool) DeepCopy() *JSONSchemaPropsOrBool {
+	if x == nil {
+		return nil
+	}
+	out := new(JSONSchemaPropsOrBool)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONSchemaPropsOrStringArray) DeepCopyInto(out *JSONSchemaPropsOrStringArray) {
+	*out = *in
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(JSONSchemaProps)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Property != nil {
+		in, out := &in.Property, &out.Property
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new JSONSchemaPropsOrStringArray.
+func (x *JSONSchemaPropsOrStringArray) DeepCopy() *JSONSchemaPropsOrStringArray {
+	if x == nil {
+		return nil
+	}
+	out := new(JSONSchemaPropsOrStringArray)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistedVersionCount) DeepCopyInto(out *PersistedVersionCount) {
+	*out = *in
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new PersistedVersionCount.
+func (x *PersistedVersionCount) DeepCopy() *PersistedVersionCount {
+	if x == nil {
+		return nil
+	}
+	out := new(PersistedVersionCount)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectableField) DeepCopyInto(out *SelectableField) {
+	*out = *in
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new SelectableField.
+func (x *SelectableField) DeepCopy() *SelectableField {
+	if x == nil {
+		return nil
+	}
+	out := new(SelectableField)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceReference) DeepCopyInto(out *ServiceReference) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new ServiceReference.
+func (x *ServiceReference) DeepCopy() *ServiceReference {
+	if x == nil {
+		return nil
+	}
+	out := new(ServiceReference)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationRule) DeepCopyInto(out *ValidationRule) {
+	*out = *in
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new ValidationRule.
+func (x *ValidationRule) DeepCopy() *ValidationRule {
+	if x == nil {
+		return nil
+	}
+	out := new(ValidationRule)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookClientConfig) DeepCopyInto(out *WebhookClientConfig) {
+	*out = *in
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ServiceReference)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new WebhookClientConfig.
+func (x *WebhookClientConfig) DeepCopy() *WebhookClientConfig {
+	if x == nil {
+		return nil
+	}
+	out := new(WebhookClientConfig)
+	x.DeepCopyInto(out)
+	return out
+}
@@ -17,11 +17,28 @@ limitations under the License.
 package validation
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/decimal"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/extensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/structural"
+	schemavalidation "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/validation"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	genericvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
 	validationutil "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -38,16 +55,36 @@ func ValidateCustomResourceDefinition(obj *apiextensions.CustomResourceDefinitio
 	}
 
 	allErrs := genericvalidation.ValidateObjectMeta(&obj.ObjectMeta, false, nameValidationFn, field.NewPath("metadata"))
+	allErrs = append(allErrs, validateLimitAnnotations(obj.Annotations, field.NewPath("metadata", "annotations"))...)
+	allErrs = append(allErrs, validateRequestLoggingAnnotations(obj.Annotations, field.NewPath("metadata", "annotations"))...)
+	allErrs = append(allErrs, validateScalarCoercionAnnotation(obj.Annotations, field.NewPath("metadata", "annotations"))...)
 	allErrs = append(allErrs, ValidateCustomResourceDefinitionSpec(&obj.Spec, field.NewPath("spec"))...)
+	expanded := expandSchemaReferences(&obj.Spec)
+	allErrs = append(allErrs, validateSchemas(expanded, field.NewPath("spec"), validateStructuralSchema)...)
+	allErrs = append(allErrs, validateSchemas(expanded, field.NewPath("spec"), validateMetadataSchema)...)
 	allErrs = append(allErrs, ValidateCustomResourceDefinitionStatus(&obj.Status, field.NewPath("status"))...)
+	allErrs = append(allErrs, ValidateCustomResourceDefinitionStoredVersions(obj.Status.StoredVersions, obj.Spec.Versions, field.NewPath("status").Child("storedVersions"))...)
 	return allErrs
 }
 
 // ValidateCustomResourceDefinitionUpdate statically validates
 func ValidateCustomResourceDefinitionUpdate(obj, oldObj *apiextensions.CustomResourceDefinition) field.ErrorList {
 	allErrs := genericvalidation.ValidateObjectMetaUpdate(&obj.ObjectMeta, &oldObj.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, validateLimitAnnotations(obj.Annotations, field.NewPath("metadata", "annotations"))...)
+	allErrs = append(allErrs, validateRequestLoggingAnnotations(obj.Annotations, field.NewPath("metadata", "annotations"))...)
+	allErrs = append(allErrs, validateScalarCoercionAnnotation(obj.Annotations, field.NewPath("metadata", "annotations"))...)
 	allErrs = append(allErrs, ValidateCustomResourceDefinitionSpecUpdate(&obj.Spec, &oldObj.Spec, apiextensions.IsCRDConditionTrue(oldObj, apiextensions.Established), field.NewPath("spec"))...)
+	expanded, oldExpanded := expandSchemaReferences(&obj.Spec), expandSchemaReferences(&oldObj.Spec)
+	// CRDs created before schemas had to be structural are not forced to become structural on update
+	if len(validateSchemas(oldExpanded, field.NewPath("spec"), validateStructuralSchema)) == 0 {
+		allErrs = append(allErrs, validateSchemas(expanded, field.NewPath("spec"), validateStructuralSchema)...)
+	}
+	// neither are schemas restricting metadata forced to drop the restrictions, which are ignored when serving
+	if len(validateSchemas(oldExpanded, field.NewPath("spec"), validateMetadataSchema)) == 0 {
+		allErrs = append(allErrs, validateSchemas(expanded, field.NewPath("spec"), validateMetadataSchema)...)
+	}
 	allErrs = append(allErrs, ValidateCustomResourceDefinitionStatus(&obj.Status, field.NewPath("status"))...)
+	allErrs = append(allErrs, ValidateCustomResourceDefinitionStoredVersions(obj.Status.StoredVersions, obj.Spec.Versions, field.NewPath("status").Child("storedVersions"))...)
 	return allErrs
 }
 
@@ -55,6 +92,86 @@ func ValidateCustomResourceDefinitionUpdate(obj, oldObj *apiextensions.CustomRes
 func ValidateUpdateCustomResourceDefinitionStatus(obj, oldObj *apiextensions.CustomResourceDefinition) field.ErrorList {
 	allErrs := genericvalidation.ValidateObjectMetaUpdate(&obj.ObjectMeta, &oldObj.ObjectMeta, field.NewPath("metadata"))
 	allErrs = append(allErrs, ValidateCustomResourceDefinitionStatus(&obj.Status, field.NewPath("status"))...)
+	allErrs = append(allErrs, ValidateCustomResourceDefinitionStoredVersions(obj.Status.StoredVersions, obj.Spec.Versions, field.NewPath("status").Child("storedVersions"))...)
+	return allErrs
+}
+
+// ValidateCustomResourceDefinitionStoredVersions statically validates that the stored versions of a
+// CustomResourceDefinition include its storage version and are still part of its versions.
+func ValidateCustomResourceDefinitionStoredVersions(storedVersions []string, versions []apiextensions.CustomResourceDefinitionVersion, fldPath *field.Path) field.ErrorList {
+	if len(storedVersions) == 0 {
+		return field.ErrorList{field.Invalid(fldPath, storedVersions, "must have at least one stored version")}
+	}
+	allErrs := field.ErrorList{}
+
+	versionsMap := map[string]bool{}
+	for _, v := range versions {
+		versionsMap[v.Name] = true
+	}
+	storedVersionsMap := map[string]bool{}
+	for i, v := range storedVersions {
+		if storedVersionsMap[v] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), v))
+		} else if !versionsMap[v] {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), v, "must appear in spec.versions"))
+		}
+		storedVersionsMap[v] = true
+	}
+	for _, v := range versions {
+		if v.Storage && !storedVersionsMap[v.Name] {
+			allErrs = append(allErrs, field.Invalid(fldPath, storedVersions, "must have the storage version "+v.Name))
+		}
+	}
+
+	return allErrs
+}
+
+// validateLimitAnnotations validates that the annotations overriding the limits of the custom
+// resources are non-negative integers.
+func validateLimitAnnotations(annotations map[string]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, key := range []string{apiextensions.MaxCustomResourcesAnnotation, apiextensions.MaxCustomResourceBytesAnnotation} {
+		value, found := annotations[key]
+		if !found {
+			continue
+		}
+		if n, err := strconv.ParseInt(value, 10, 64); err != nil || n < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), value, "must be a non-negative integer"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateRequestLoggingAnnotations validates the annotations configuring the logging of the
+// requests for the custom resources.
+func validateRequestLoggingAnnotations(annotations map[string]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if value, found := annotations[apiextensions.RequestLogVerbosityAnnotation]; found {
+		if n, err := strconv.ParseInt(value, 10, 32); err != nil || n < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(apiextensions.RequestLogVerbosityAnnotation), value, "must be a non-negative integer"))
+		}
+	}
+	if value, found := annotations[apiextensions.SlowRequestThresholdAnnotation]; found {
+		if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(apiextensions.SlowRequestThresholdAnnotation), value, "must be a positive duration, e.g. 500ms"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateScalarCoercionAnnotation validates that the annotation enabling the coercion of the scalars
+// of the custom resources is true or false.
+func validateScalarCoercionAnnotation(annotations map[string]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if value, found := annotations[apiextensions.ScalarCoercionAnnotation]; found && value != "true" && value != "false" {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Key(apiextensions.ScalarCoercionAnnotation), value, []string{"true", "false"}))
+	}
+
 	return allErrs
 }
 
@@ -74,8 +191,12 @@ func ValidateCustomResourceDefinitionSpec(spec *apiextensions.CustomResourceDefi
 		allErrs = append(allErrs, field.Required(fldPath.Child("version"), ""))
 	} else if errs := validationutil.IsDNS1035Label(spec.Version); len(errs) > 0 {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("version"), spec.Version, strings.Join(errs, ",")))
+	} else if len(spec.Versions) > 0 && spec.Versions[0].Name != spec.Version {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("version"), spec.Version, "must match the first version in spec.versions"))
 	}
 
+	allErrs = append(allErrs, ValidateCustomResourceDefinitionVersions(spec.Versions, fldPath.Child("versions"))...)
+
 	switch spec.Scope {
 	case "":
 		allErrs = append(allErrs, field.Required(fldPath.Child("scope"), ""))
@@ -100,60 +221,1755 @@ func ValidateCustomResourceDefinitionSpec(spec *apiextensions.CustomResourceDefi
 
 	allErrs = append(allErrs, ValidateCustomResourceDefinitionNames(&spec.Names, fldPath.Child("names"))...)
 
+	// references are validated in the schemas as specified, everything else in the expanded schemas
+	allErrs = append(allErrs, validateSchemas(spec, fldPath, validateSchemaReferences)...)
+	spec = expandSchemaReferences(spec)
+
+	allErrs = append(allErrs, ValidateCustomResourceDefinitionValidation(spec.Validation, fldPath.Child("validation"))...)
+
+	if spec.PreserveUnknownFields != nil && !*spec.PreserveUnknownFields {
+		if !apiextensions.HasPerVersionSchema(spec.Versions) {
+			if spec.Validation == nil || spec.Validation.OpenAPIV3Schema == nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("preserveUnknownFields"), *spec.PreserveUnknownFields, "must be true if validation.openAPIV3Schema is not specified"))
+			}
+		} else {
+			for i, version := range spec.Versions {
+				if version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("preserveUnknownFields"), *spec.PreserveUnknownFields, fmt.Sprintf("must be true if versions[%d].schema.openAPIV3Schema is not specified", i)))
+				}
+			}
+		}
+	}
+
+	allErrs = append(allErrs, ValidateCustomResourceConversion(spec.Conversion, fldPath.Child("conversion"))...)
+	if spec.Conversion != nil && spec.Conversion.Strategy == apiextensions.ExpressionConverter {
+		allErrs = append(allErrs, validateConversionExpressions(spec, fldPath.Child("conversion", "expressions"))...)
+	}
+
+	allErrs = append(allErrs, ValidateCustomResourceDefinitionSubresources(spec.Subresources, fldPath.Child("subresources"))...)
+
+	for i := range spec.AdditionalPrinterColumns {
+		allErrs = append(allErrs, ValidateCustomResourceColumnDefinition(&spec.AdditionalPrinterColumns[i], fldPath.Child("additionalPrinterColumns").Index(i))...)
+	}
+
+	allErrs = append(allErrs, validatePerVersionFields(spec, fldPath)...)
+	allErrs = append(allErrs, validateInitialStatus(spec, fldPath)...)
+	allErrs = append(allErrs, validateExamples(spec, fldPath)...)
+
+	allErrs = append(allErrs, ValidateSelectableFields(spec.SelectableFields, spec, fldPath.Child("selectableFields"))...)
+	allErrs = append(allErrs, validateAllowedFinalizerDomains(spec.AllowedFinalizerDomains, fldPath.Child("allowedFinalizerDomains"))...)
+
+	if spec.Storage != nil {
+		allErrs = append(allErrs, ValidateCustomResourceStorage(spec.Storage, fldPath.Child("storage"))...)
+	}
+
 	return allErrs
 }
 
-// ValidateCustomResourceDefinitionSpecUpdate statically validates
-func ValidateCustomResourceDefinitionSpecUpdate(spec, oldSpec *apiextensions.CustomResourceDefinitionSpec, established bool, fldPath *field.Path) field.ErrorList {
-	allErrs := ValidateCustomResourceDefinitionSpec(spec, fldPath)
+// validateAllowedFinalizerDomains checks that the allowed finalizer domains are unique DNS subdomains.
+func validateAllowedFinalizerDomains(domains []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
 
-	if established {
-		// these effect the storage and cannot be changed therefore
-		allErrs = append(allErrs, genericvalidation.ValidateImmutableField(spec.Version, oldSpec.Version, fldPath.Child("version"))...)
-		allErrs = append(allErrs, genericvalidation.ValidateImmutableField(spec.Scope, oldSpec.Scope, fldPath.Child("scope"))...)
-		allErrs = append(allErrs, genericvalidation.ValidateImmutableField(spec.Names.Kind, oldSpec.Names.Kind, fldPath.Child("names", "kind"))...)
+	seen := sets.NewString()
+	for i, domain := range domains {
+		for _, msg := range validationutil.IsDNS1123Subdomain(domain) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), domain, msg))
+		}
+		if seen.Has(domain) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), domain))
+		}
+		seen.Insert(domain)
 	}
 
-	// these affects the resource name, which is always immutable, so this can't be updated.
-	allErrs = append(allErrs, genericvalidation.ValidateImmutableField(spec.Group, oldSpec.Group, fldPath.Child("group"))...)
-	allErrs = append(allErrs, genericvalidation.ValidateImmutableField(spec.Names.Plural, oldSpec.Names.Plural, fldPath.Child("names", "plural"))...)
+	return allErrs
+}
+
+// validateInitialStatus checks that the initial status of the status subresource validates against
+// the status schema of every version it applies to. Like a default, it is validated with the
+// defaults of the status schema applied to it.
+func validateInitialStatus(spec *apiextensions.CustomResourceDefinitionSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	reported := sets.NewString()
+
+	for i, version := range spec.Versions {
+		subresources, subresourcesPath := spec.Subresources, fldPath.Child("subresources")
+		if version.Subresources != nil {
+			subresources, subresourcesPath = version.Subresources, fldPath.Child("versions").Index(i).Child("subresources")
+		}
+		if subresources == nil || subresources.Status == nil || subresources.Status.InitialStatus == nil {
+			continue
+		}
+		if _, ok := (*subresources.Status.InitialStatus).(map[string]interface{}); !ok {
+			// reported by ValidateCustomResourceDefinitionSubresources
+			continue
+		}
+		customResourceValidation := spec.Validation
+		if version.Schema != nil {
+			customResourceValidation = version.Schema
+		}
+		if customResourceValidation == nil || customResourceValidation.OpenAPIV3Schema == nil {
+			continue
+		}
+		statusSchema, ok := customResourceValidation.OpenAPIV3Schema.Properties["status"]
+		if !ok {
+			continue
+		}
+
+		initialStatusPath := subresourcesPath.Child("status", "initialStatus")
+		x := *subresources.Status.DeepCopy().InitialStatus
+		var errs field.ErrorList
+		if err := defaulting.Default(x, &statusSchema); err != nil {
+			errs = append(errs, field.Invalid(initialStatusPath, x, err.Error()))
+		} else {
+			errs = append(errs, schemavalidation.Validate(x, &statusSchema, initialStatusPath)...)
+			errs = append(errs, extensions.Validate(x, &statusSchema, initialStatusPath)...)
+		}
+		// a top-level initial status is validated once per version, but reported once per error
+		for _, err := range errs {
+			if !reported.Has(err.Error()) {
+				reported.Insert(err.Error())
+				allErrs = append(allErrs, err)
+			}
+		}
+	}
 
 	return allErrs
 }
 
-// ValidateCustomResourceDefinitionStatus statically validates
-func ValidateCustomResourceDefinitionStatus(status *apiextensions.CustomResourceDefinitionStatus, fldPath *field.Path) field.ErrorList {
+// validateExamples checks that the examples of the versions are objects of the group, version and
+// kind of the CRD which validate against the schema of their version. Like a default, an example is
+// validated with the defaults of the schema applied to it.
+func validateExamples(spec *apiextensions.CustomResourceDefinitionSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	allErrs = append(allErrs, ValidateCustomResourceDefinitionNames(&status.AcceptedNames, fldPath.Child("acceptedNames"))...)
+
+	for i, version := range spec.Versions {
+		if version.Example == nil {
+			continue
+		}
+		examplePath := fldPath.Child("versions").Index(i).Child("example")
+		example, ok := (*version.DeepCopy().Example).(map[string]interface{})
+		if !ok {
+			allErrs = append(allErrs, field.Invalid(examplePath, *version.Example, "must be an object"))
+			continue
+		}
+		apiVersion := spec.Group + "/" + version.Name
+		if v, ok := example["apiVersion"]; ok && v != apiVersion {
+			allErrs = append(allErrs, field.Invalid(examplePath.Child("apiVersion"), v, fmt.Sprintf("must be %s", apiVersion)))
+		}
+		if v, ok := example["kind"]; ok && v != spec.Names.Kind {
+			allErrs = append(allErrs, field.Invalid(examplePath.Child("kind"), v, fmt.Sprintf("must be %s", spec.Names.Kind)))
+		}
+		if v, ok := example["metadata"]; ok {
+			if _, ok := v.(map[string]interface{}); !ok {
+				allErrs = append(allErrs, field.Invalid(examplePath.Child("metadata"), v, "must be an object"))
+			}
+		}
+
+		customResourceValidation := spec.Validation
+		if version.Schema != nil {
+			customResourceValidation = version.Schema
+		}
+		customResourceValidation = expandValidationReferences(customResourceValidation)
+		if customResourceValidation == nil || customResourceValidation.OpenAPIV3Schema == nil {
+			continue
+		}
+		// the example is served with apiVersion and kind. Like for custom resources, the metadata is
+		// not validated against the schema.
+		example["apiVersion"] = apiVersion
+		example["kind"] = spec.Names.Kind
+		delete(example, "metadata")
+		if err := defaulting.Default(example, customResourceValidation.OpenAPIV3Schema); err != nil {
+			allErrs = append(allErrs, field.Invalid(examplePath, example, err.Error()))
+			continue
+		}
+		allErrs = append(allErrs, schemavalidation.Validate(example, customResourceValidation.OpenAPIV3Schema, examplePath)...)
+		allErrs = append(allErrs, extensions.Validate(example, customResourceValidation.OpenAPIV3Schema, examplePath)...)
+	}
+
 	return allErrs
 }
 
-// ValidateCustomResourceDefinitionNames statically validates
-func ValidateCustomResourceDefinitionNames(names *apiextensions.CustomResourceDefinitionNames, fldPath *field.Path) field.ErrorList {
+// validatePerVersionFields validates the schemas, subresources and printer columns of the versions,
+// which are mutually exclusive with the top-level ones. Per-version fields which are identical for
+// all versions must be specified at the top-level instead.
+func validatePerVersionFields(spec *apiextensions.CustomResourceDefinitionSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	if errs := validationutil.IsDNS1035Label(names.Plural); len(names.Plural) > 0 && len(errs) > 0 {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("plural"), names.Plural, strings.Join(errs, ",")))
+
+	for i, version := range spec.Versions {
+		versionPath := fldPath.Child("versions").Index(i)
+		allErrs = append(allErrs, ValidateCustomResourceDefinitionValidation(version.Schema, versionPath.Child("schema"))...)
+		allErrs = append(allErrs, ValidateCustomResourceDefinitionSubresources(version.Subresources, versionPath.Child("subresources"))...)
+		for j := range version.AdditionalPrinterColumns {
+			allErrs = append(allErrs, ValidateCustomResourceColumnDefinition(&version.AdditionalPrinterColumns[j], versionPath.Child("additionalPrinterColumns").Index(j))...)
+		}
 	}
-	if errs := validationutil.IsDNS1035Label(names.Singular); len(names.Singular) > 0 && len(errs) > 0 {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("singular"), names.Singular, strings.Join(errs, ",")))
+
+	if apiextensions.HasPerVersionSchema(spec.Versions) && spec.Validation != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("validation"), "top-level and per-version schemas are mutually exclusive"))
 	}
-	if errs := validationutil.IsDNS1035Label(strings.ToLower(names.Kind)); len(names.Kind) > 0 && len(errs) > 0 {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("kind"), names.Kind, "may have mixed case, but should otherwise match: "+strings.Join(errs, ",")))
+	if apiextensions.HasPerVersionSubresources(spec.Versions) && spec.Subresources != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("subresources"), "top-level and per-version subresources are mutually exclusive"))
 	}
-	if errs := validationutil.IsDNS1035Label(strings.ToLower(names.ListKind)); len(names.ListKind) > 0 && len(errs) > 0 {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("listKind"), names.ListKind, "may have mixed case, but should otherwise match: "+strings.Join(errs, ",")))
+	if apiextensions.HasPerVersionColumns(spec.Versions) && len(spec.AdditionalPrinterColumns) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("additionalPrinterColumns"), "top-level and per-version printer columns are mutually exclusive"))
 	}
 
-	for i, shortName := range names.ShortNames {
-		if errs := validationutil.IsDNS1035Label(shortName); len(errs) > 0 {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("shortNames").Index(i), shortName, strings.Join(errs, ",")))
+	if len(spec.Versions) > 0 {
+		identicalSchemas, identicalSubresources, identicalColumns := true, true, true
+		first := spec.Versions[0]
+		for _, version := range spec.Versions {
+			identicalSchemas = identicalSchemas && version.Schema != nil && apiequality.Semantic.DeepEqual(version.Schema, first.Schema)
+			identicalSubresources = identicalSubresources && version.Subresources != nil && apiequality.Semantic.DeepEqual(version.Subresources, first.Subresources)
+			identicalColumns = identicalColumns && len(version.AdditionalPrinterColumns) > 0 && apiequality.Semantic.DeepEqual(version.AdditionalPrinterColumns, first.AdditionalPrinterColumns)
+		}
+		if identicalSchemas {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("versions"), spec.Versions, "per-version schemas may not all be set to identical values (top-level validation should be used instead)"))
+		}
+		if identicalSubresources {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("versions"), spec.Versions, "per-version subresources may not all be set to identical values (top-level subresources should be used instead)"))
+		}
+		if identicalColumns {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("versions"), spec.Versions, "per-version additionalPrinterColumns may not all be set to identical values (top-level additionalPrinterColumns should be used instead)"))
 		}
+	}
+
+	return allErrs
+}
 
+const maxSelectableFields = 8
+
+var selectableFieldTypes = sets.NewString("string", "integer", "boolean")
+
+// ValidateSelectableFields statically validates the selectable fields of a CustomResourceDefinition.
+// Every selectable field must be specified with a scalar type in the validation schema, or in the
+// schemas of all versions if they specify their own.
+func ValidateSelectableFields(selectableFields []apiextensions.SelectableField, spec *apiextensions.CustomResourceDefinitionSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(selectableFields) > maxSelectableFields {
+		allErrs = append(allErrs, field.Invalid(fldPath, len(selectableFields), fmt.Sprintf("must not have more than %d selectable fields", maxSelectableFields)))
 	}
 
-	// kind and listKind may not be the same or parsing become ambiguous
-	if len(names.Kind) > 0 && names.Kind == names.ListKind {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("listKind"), names.ListKind, "kind and listKind may not be the same"))
+	// the schemas to check, with the suffix of the error messages naming them
+	var schemas []*apiextensions.JSONSchemaProps
+	var schemaNames []string
+	openAPIV3Schema := func(v *apiextensions.CustomResourceValidation) *apiextensions.JSONSchemaProps {
+		if v == nil {
+			return nil
+		}
+		return v.OpenAPIV3Schema
+	}
+	if apiextensions.HasPerVersionSchema(spec.Versions) {
+		for _, version := range spec.Versions {
+			schemas = append(schemas, openAPIV3Schema(version.Schema))
+			schemaNames = append(schemaNames, " of version "+version.Name)
+		}
+	} else {
+		schemas = append(schemas, openAPIV3Schema(spec.Validation))
+		schemaNames = append(schemaNames, "")
+	}
+
+	seen := sets.NewString()
+	for i, selectableField := range selectableFields {
+		jsonPath := fldPath.Index(i).Child("jsonPath")
+
+		if len(selectableField.JSONPath) == 0 {
+			allErrs = append(allErrs, field.Required(jsonPath, ""))
+			continue
+		}
+		if errs := validateSimpleJSONPath(selectableField.JSONPath, jsonPath, ".spec.", ".status."); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+			continue
+		}
+		if seen.Has(selectableField.JSONPath) {
+			allErrs = append(allErrs, field.Duplicate(jsonPath, selectableField.JSONPath))
+			continue
+		}
+		seen.Insert(selectableField.JSONPath)
+
+		for j, schema := range schemas {
+			fieldSchema := schemaForSimpleJSONPath(schema, selectableField.JSONPath)
+			if fieldSchema == nil {
+				allErrs = append(allErrs, field.Invalid(jsonPath, selectableField.JSONPath, "must point to a field specified in the validation schema"+schemaNames[j]))
+			} else if !selectableFieldTypes.Has(fieldSchema.Type) {
+				allErrs = append(allErrs, field.Invalid(jsonPath, selectableField.JSONPath, fmt.Sprintf("must point to a field of type %s%s", strings.Join(selectableFieldTypes.List(), ", "), schemaNames[j])))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// schemaForSimpleJSONPath returns the schema of the properties along the given simple JSON path, or nil
+// if the path is not specified in the schema.
+func schemaForSimpleJSONPath(schema *apiextensions.JSONSchemaProps, path string) *apiextensions.JSONSchemaProps {
+	for _, component := range strings.Split(strings.TrimPrefix(path, "."), ".") {
+		if schema == nil {
+			return nil
+		}
+		property, found := schema.Properties[component]
+		if !found {
+			return nil
+		}
+		schema = &property
+	}
+	return schema
+}
+
+var printerColumnDatatypes = sets.NewString("integer", "number", "string", "boolean", "date")
+var customResourceColumnDefinitionFormats = sets.NewString("int32", "int64", "float", "double", "byte", "date", "date-time", "password")
+
+// ValidateCustomResourceColumnDefinition statically validates a printer column.
+func ValidateCustomResourceColumnDefinition(col *apiextensions.CustomResourceColumnDefinition, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(col.Name) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	}
+
+	if len(col.Type) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("type"), fmt.Sprintf("must be one of %s", strings.Join(printerColumnDatatypes.List(), ","))))
+	} else if !printerColumnDatatypes.Has(col.Type) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), col.Type, printerColumnDatatypes.List()))
+	}
+
+	if len(col.Format) > 0 && !customResourceColumnDefinitionFormats.Has(col.Format) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("format"), col.Format, customResourceColumnDefinitionFormats.List()))
+	}
+
+	if col.Priority < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("priority"), col.Priority, "must be non-negative"))
+	}
+
+	if len(col.JSONPath) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("JSONPath"), ""))
+	} else {
+		allErrs = append(allErrs, validateSimpleJSONPath(col.JSONPath, fldPath.Child("JSONPath"), ".")...)
+	}
+
+	return allErrs
+}
+
+// ValidateCustomResourceDefinitionSubresources statically validates the subresources of a CustomResourceDefinition.
+func ValidateCustomResourceDefinitionSubresources(subresources *apiextensions.CustomResourceSubresources, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if subresources == nil {
+		return allErrs
+	}
+
+	if subresources.Status != nil && subresources.Status.InitialStatus != nil {
+		if _, ok := (*subresources.Status.InitialStatus).(map[string]interface{}); !ok {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("status", "initialStatus"), *subresources.Status.InitialStatus, "must be an object"))
+		}
+	}
+
+	if subresources.Scale != nil {
+		scalePath := fldPath.Child("scale")
+
+		if len(subresources.Scale.SpecReplicasPath) == 0 {
+			allErrs = append(allErrs, field.Required(scalePath.Child("specReplicasPath"), ""))
+		} else {
+			allErrs = append(allErrs, validateSimpleJSONPath(subresources.Scale.SpecReplicasPath, scalePath.Child("specReplicasPath"), ".spec.")...)
+		}
+
+		if len(subresources.Scale.StatusReplicasPath) == 0 {
+			allErrs = append(allErrs, field.Required(scalePath.Child("statusReplicasPath"), ""))
+		} else {
+			allErrs = append(allErrs, validateSimpleJSONPath(subresources.Scale.StatusReplicasPath, scalePath.Child("statusReplicasPath"), ".status.")...)
+		}
+
+		if subresources.Scale.LabelSelectorPath != nil {
+			allErrs = append(allErrs, validateSimpleJSONPath(*subresources.Scale.LabelSelectorPath, scalePath.Child("labelSelectorPath"), ".spec.", ".status.")...)
+		}
+	}
+
+	names := sets.NewString()
+	for i := range subresources.Custom {
+		custom := &subresources.Custom[i]
+		customPath := fldPath.Child("custom").Index(i)
+		allErrs = append(allErrs, validateCustomSubresource(custom, customPath)...)
+		if names.Has(custom.Name) {
+			allErrs = append(allErrs, field.Duplicate(customPath.Child("name"), custom.Name))
+		}
+		names.Insert(custom.Name)
+	}
+
+	return allErrs
+}
+
+// customSubresourceVerbs are the verbs custom subresources can be served for.
+var customSubresourceVerbs = sets.NewString("get", "update", "patch")
+
+// validateCustomSubresource statically validates a custom subresource.
+func validateCustomSubresource(custom *apiextensions.CustomResourceSubresourceCustom, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch {
+	case len(custom.Name) == 0:
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	case custom.Name == "status" || custom.Name == "scale":
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), custom.Name, "must not be the name of a built-in subresource"))
+	default:
+		for _, msg := range validationutil.IsDNS1123Label(custom.Name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), custom.Name, msg))
+		}
+	}
+
+	if len(custom.FieldPaths) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("fieldPaths"), ""))
+	}
+	paths := sets.NewString()
+	for i, path := range custom.FieldPaths {
+		if paths.Has(path) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("fieldPaths").Index(i), path))
+		}
+		paths.Insert(path)
+		allErrs = append(allErrs, validateSimpleJSONPath(path, fldPath.Child("fieldPaths").Index(i), ".spec.", ".status.")...)
+	}
+
+	if len(custom.Verbs) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("verbs"), ""))
+	}
+	verbs := sets.NewString()
+	for i, verb := range custom.Verbs {
+		switch {
+		case !customSubresourceVerbs.Has(verb):
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("verbs").Index(i), verb, customSubresourceVerbs.List()))
+		case verbs.Has(verb):
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("verbs").Index(i), verb))
+		}
+		verbs.Insert(verb)
+	}
+
+	allErrs = append(allErrs, ValidateCustomResourceDefinitionValidation(custom.Validation, fldPath.Child("validation"))...)
+
+	return allErrs
+}
+
+// validateSimpleJSONPath checks that path is a JSON path without array notation, e.g. .spec.replicas,
+// which starts with one of the given prefixes.
+func validateSimpleJSONPath(path string, fldPath *field.Path, prefixes ...string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	hasPrefix := false
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			hasPrefix = true
+			break
+		}
+	}
+	if !hasPrefix {
+		return append(allErrs, field.Invalid(fldPath, path, fmt.Sprintf("should be a json path under %s", strings.Join(prefixes, " or "))))
+	}
+
+	for _, component := range strings.Split(path[1:], ".") {
+		if len(component) == 0 || strings.ContainsAny(component, "[]") {
+			return append(allErrs, field.Invalid(fldPath, path, "should be a json path without array notation"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateCustomResourceDefinitionVersions statically validates the versions of a CustomResourceDefinition.
+func ValidateCustomResourceDefinitionVersions(versions []apiextensions.CustomResourceDefinitionVersion, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(versions) == 0 {
+		return append(allErrs, field.Required(fldPath, ""))
+	}
+
+	storageFlagCount, mirrorStorageFlagCount := 0, 0
+	versionsMap := map[string]bool{}
+	for i, version := range versions {
+		if version.Storage {
+			storageFlagCount++
+		}
+		if version.MirrorStorage {
+			mirrorStorageFlagCount++
+			if version.Storage {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("mirrorStorage"), version.MirrorStorage, "must not be set for the storage version"))
+			}
+		}
+		if len(version.Name) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("name"), ""))
+		} else if errs := validationutil.IsDNS1035Label(version.Name); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("name"), version.Name, strings.Join(errs, ",")))
+		} else if versionsMap[version.Name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), version.Name))
+		}
+		versionsMap[version.Name] = true
+		if version.DeprecationWarning != nil {
+			allErrs = append(allErrs, validateDeprecationWarning(version.Deprecated, *version.DeprecationWarning, fldPath.Index(i).Child("deprecationWarning"))...)
+		}
+		if version.RemovedInRelease != nil {
+			if !version.Deprecated {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("removedInRelease"), "must not be set unless deprecated is true"))
+			} else if _, _, err := apiextensions.ParseRelease(*version.RemovedInRelease); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("removedInRelease"), *version.RemovedInRelease, err.Error()))
+			}
+		}
+	}
+	if storageFlagCount != 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath, storageFlagCount, "must have exactly one version marked as storage version"))
+	}
+	if mirrorStorageFlagCount > 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath, mirrorStorageFlagCount, "must have at most one version marked as mirror storage version"))
+	}
+
+	return allErrs
+}
+
+// maxDeprecationWarningLength is the maximum length of the deprecation warning of a version.
+const maxDeprecationWarningLength = 256
+
+// validateDeprecationWarning validates the deprecation warning of a version, which is returned to
+// clients in a Warning header.
+func validateDeprecationWarning(deprecated bool, warning string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !deprecated {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "must not be set unless deprecated is true"))
+		return allErrs
+	}
+	if len(warning) > maxDeprecationWarningLength {
+		allErrs = append(allErrs, field.TooLong(fldPath, warning, maxDeprecationWarningLength))
+	}
+	if !utf8.ValidString(warning) {
+		allErrs = append(allErrs, field.Invalid(fldPath, warning, "must be valid UTF-8"))
+	} else if strings.IndexFunc(warning, unicode.IsControl) >= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, warning, "must not contain control characters"))
+	}
+
+	return allErrs
+}
+
+// ValidateCustomResourceConversion statically validates the conversion settings of a CustomResourceDefinition.
+func ValidateCustomResourceConversion(conversion *apiextensions.CustomResourceConversion, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if conversion == nil {
+		return allErrs
+	}
+
+	if conversion.Strategy != apiextensions.ExpressionConverter && len(conversion.Expressions) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("expressions"), "must not be set unless strategy is Expression"))
+	}
+
+	switch conversion.Strategy {
+	case "":
+		allErrs = append(allErrs, field.Required(fldPath.Child("strategy"), ""))
+	case apiextensions.NoneConverter, apiextensions.ExpressionConverter:
+		if conversion.WebhookClientConfig != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("webhookClientConfig"), "must not be set unless strategy is Webhook"))
+		}
+		if len(conversion.ConversionReviewVersions) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("conversionReviewVersions"), "must not be set unless strategy is Webhook"))
+		}
+		if conversion.FailurePolicy != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("failurePolicy"), "must not be set unless strategy is Webhook"))
+		}
+	case apiextensions.WebhookConverter:
+		if conversion.WebhookClientConfig == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("webhookClientConfig"), "required when strategy is Webhook"))
+		} else {
+			allErrs = append(allErrs, ValidateWebhookClientConfig(conversion.WebhookClientConfig, fldPath.Child("webhookClientConfig"))...)
+		}
+		allErrs = append(allErrs, validateConversionReviewVersions(conversion.ConversionReviewVersions, fldPath.Child("conversionReviewVersions"))...)
+		if conversion.FailurePolicy != nil {
+			switch *conversion.FailurePolicy {
+			case apiextensions.ConversionFailurePolicyFail, apiextensions.ConversionFailurePolicyIgnore:
+			default:
+				allErrs = append(allErrs, field.NotSupported(fldPath.Child("failurePolicy"), *conversion.FailurePolicy, []string{string(apiextensions.ConversionFailurePolicyFail), string(apiextensions.ConversionFailurePolicyIgnore)}))
+			}
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("strategy"), conversion.Strategy, []string{string(apiextensions.NoneConverter), string(apiextensions.WebhookConverter), string(apiextensions.ExpressionConverter)}))
+	}
+
+	return allErrs
+}
+
+// validateConversionExpressions checks that the conversion expressions name pairs of distinct
+// versions of the spec at most once, and that their field mappings compile against the schema
+// of the version converted from.
+func validateConversionExpressions(spec *apiextensions.CustomResourceDefinitionSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	versions := sets.NewString()
+	schemas := map[string]*apiextensions.JSONSchemaProps{}
+	for _, version := range spec.Versions {
+		versions.Insert(version.Name)
+		validation := spec.Validation
+		if apiextensions.HasPerVersionSchema(spec.Versions) {
+			validation = version.Schema
+		}
+		if validation != nil {
+			schemas[version.Name] = validation.OpenAPIV3Schema
+		}
+	}
+
+	seenPairs := sets.NewString()
+	for i, e := range spec.Conversion.Expressions {
+		idxPath := fldPath.Index(i)
+
+		validFrom := false
+		switch {
+		case len(e.FromVersion) == 0:
+			allErrs = append(allErrs, field.Required(idxPath.Child("fromVersion"), ""))
+		case !versions.Has(e.FromVersion):
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("fromVersion"), e.FromVersion, versions.List()))
+		default:
+			validFrom = true
+		}
+		switch {
+		case len(e.ToVersion) == 0:
+			allErrs = append(allErrs, field.Required(idxPath.Child("toVersion"), ""))
+		case !versions.Has(e.ToVersion):
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("toVersion"), e.ToVersion, versions.List()))
+		case e.ToVersion == e.FromVersion:
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("toVersion"), e.ToVersion, "must differ from fromVersion"))
+		}
+		pair := e.FromVersion + " to " + e.ToVersion
+		if seenPairs.Has(pair) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, pair))
+		}
+		seenPairs.Insert(pair)
+
+		if len(e.FieldMappings) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("fieldMappings"), ""))
+		}
+		seenPaths := sets.NewString()
+		for j, m := range e.FieldMappings {
+			mappingPath := idxPath.Child("fieldMappings").Index(j)
+
+			switch {
+			case len(m.Path) == 0:
+				allErrs = append(allErrs, field.Required(mappingPath.Child("path"), ""))
+			case seenPaths.Has(m.Path):
+				allErrs = append(allErrs, field.Duplicate(mappingPath.Child("path"), m.Path))
+			default:
+				allErrs = append(allErrs, validateConversionFieldPath(m.Path, mappingPath.Child("path"))...)
+			}
+			seenPaths.Insert(m.Path)
+
+			if len(m.Expression) == 0 {
+				allErrs = append(allErrs, field.Required(mappingPath.Child("expression"), ""))
+			} else if validFrom {
+				if _, err := cel.CompileValue(m.Expression, schemas[e.FromVersion], true); err != nil {
+					allErrs = append(allErrs, field.Invalid(mappingPath.Child("expression"), m.Expression, err.Error()))
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateConversionFieldPath checks that the path of a conversion field mapping names a field
+// outside of apiVersion, kind and metadata.
+func validateConversionFieldPath(path string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := strings.Split(path, ".")
+	for _, name := range names {
+		if len(name) == 0 {
+			return append(allErrs, field.Invalid(fldPath, path, "must be a dot-separated path of field names"))
+		}
+	}
+	switch names[0] {
+	case "apiVersion", "kind", "metadata":
+		allErrs = append(allErrs, field.Invalid(fldPath, path, "must not start with apiVersion, kind or metadata"))
+	}
+
+	return allErrs
+}
+
+// supportedConversionReviewVersions are the ConversionReview versions the conversion webhook client can send.
+var supportedConversionReviewVersions = []string{"v1", "v1beta1"}
+
+// validateConversionReviewVersions checks that the versions are unique and include at least one
+// supported version.
+func validateConversionReviewVersions(versions []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(versions) == 0 {
+		return append(allErrs, field.Required(fldPath, "required when strategy is Webhook"))
+	}
+
+	seen := sets.NewString()
+	supported := false
+	for i, v := range versions {
+		if seen.Has(v) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), v))
+			continue
+		}
+		seen.Insert(v)
+		for _, msg := range validationutil.IsDNS1035Label(v) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), v, msg))
+		}
+		if sets.NewString(supportedConversionReviewVersions...).Has(v) {
+			supported = true
+		}
+	}
+	if !supported {
+		allErrs = append(allErrs, field.Invalid(fldPath, versions, fmt.Sprintf("must include at least one of %s", strings.Join(supportedConversionReviewVersions, ", "))))
+	}
+
+	return allErrs
+}
+
+// ValidateWebhookClientConfig statically validates how a webhook is called.
+func ValidateWebhookClientConfig(cc *apiextensions.WebhookClientConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch {
+	case (cc.URL == nil) == (cc.Service == nil):
+		allErrs = append(allErrs, field.Required(fldPath, "exactly one of url or service is required"))
+	case cc.URL != nil:
+		u, err := url.Parse(*cc.URL)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), *cc.URL, err.Error()))
+			break
+		}
+		if u.Scheme != "https" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), u.Scheme, "must be https"))
+		}
+		if len(u.Host) == 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), u.Host, "host must be provided"))
+		}
+		if u.User != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), u.User.String(), "user information is not permitted in the URL"))
+		}
+		if len(u.Fragment) != 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), u.Fragment, "fragments are not permitted in the URL"))
+		}
+		if len(u.RawQuery) != 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), u.RawQuery, "query parameters are not permitted in the URL"))
+		}
+	case cc.Service != nil:
+		if len(cc.Service.Name) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("service", "name"), ""))
+		}
+		if len(cc.Service.Namespace) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("service", "namespace"), ""))
+		}
+		if cc.Service.Path != nil && !strings.HasPrefix(*cc.Service.Path, "/") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("service", "path"), *cc.Service.Path, "must start with a '/'"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateCustomResourceStorage statically validates the storage location of custom resources.
+func ValidateCustomResourceStorage(storage *apiextensions.CustomResourceStorage, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(storage.Prefix) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("prefix"), ""))
+		return allErrs
+	}
+	if strings.HasPrefix(storage.Prefix, "/") || strings.HasSuffix(storage.Prefix, "/") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("prefix"), storage.Prefix, "must not start or end with a '/'"))
+		return allErrs
+	}
+	for _, segment := range strings.Split(storage.Prefix, "/") {
+		if errs := validationutil.IsDNS1123Subdomain(segment); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("prefix"), storage.Prefix, fmt.Sprintf("segment %q: %s", segment, strings.Join(errs, ","))))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateCustomResourceStorageAllowed validates that the storage prefix of custom resources is within one of
+// the prefixes allowed by the server. Without allowed prefixes, custom storage locations are forbidden.
+func ValidateCustomResourceStorageAllowed(storage *apiextensions.CustomResourceStorage, allowedPrefixes []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if storage == nil {
+		return allErrs
+	}
+	if len(allowedPrefixes) == 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "custom storage locations are not allowed by the server"))
+		return allErrs
+	}
+	for _, allowed := range allowedPrefixes {
+		if storage.Prefix == allowed || strings.HasPrefix(storage.Prefix, allowed+"/") {
+			return allErrs
+		}
+	}
+	allErrs = append(allErrs, field.Forbidden(fldPath.Child("prefix"), fmt.Sprintf("must be within one of the storage prefixes allowed by the server: %s", strings.Join(allowedPrefixes, ", "))))
+
+	return allErrs
+}
+
+// ValidateAPIApproval validates that CustomResourceDefinitions in groups owned by the Kubernetes project
+// carry a valid api-approved.kubernetes.io annotation. On update, CustomResourceDefinitions which did
+// not have a valid annotation before are only rejected if the annotation is changed.
+func ValidateAPIApproval(crd, oldCRD *apiextensions.CustomResourceDefinition) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !apiextensions.IsProtectedGroup(crd.Spec.Group) {
+		return allErrs
+	}
+	state := apiextensions.GetAPIApprovalState(crd.Annotations)
+	if state == apiextensions.APIApproved || state == apiextensions.APIApprovalBypassed {
+		return allErrs
+	}
+	approval, found := crd.Annotations[apiextensions.KubernetesAPIApprovedAnnotation]
+	if oldCRD != nil {
+		oldState := apiextensions.GetAPIApprovalState(oldCRD.Annotations)
+		oldApproval, oldFound := oldCRD.Annotations[apiextensions.KubernetesAPIApprovedAnnotation]
+		if (oldState == apiextensions.APIApprovalMissing || oldState == apiextensions.APIApprovalInvalid) && found == oldFound && approval == oldApproval {
+			return allErrs
+		}
+	}
+
+	fldPath := field.NewPath("metadata", "annotations").Key(apiextensions.KubernetesAPIApprovedAnnotation)
+	if !found {
+		allErrs = append(allErrs, field.Required(fldPath, fmt.Sprintf("protected groups must have the approval annotation %q, see https://github.com/kubernetes/enhancements/pull/1111", apiextensions.KubernetesAPIApprovedAnnotation)))
+	} else {
+		allErrs = append(allErrs, field.Invalid(fldPath, approval, `must be the URL of the approval or start with "unapproved"`))
+	}
+
+	return allErrs
+}
+
+// SchemaLimits bounds the size of each validation schema of a CustomResourceDefinition, so that a
+// single CustomResourceDefinition cannot exhaust the memory of the server or slow down the
+// publishing of the OpenAPI specs. Zero values are not enforced.
+type SchemaLimits struct {
+	// MaxBytes is the maximum size of the JSON encoding of a schema.
+	MaxBytes int
+	// MaxDepth is the maximum nesting depth of a schema. The root schema has depth 1.
+	MaxDepth int
+	// MaxProperties is the maximum number of properties of a schema, summed up over all nesting levels.
+	MaxProperties int
+	// MaxRuleCost is the maximum estimated cost of the x-kubernetes-validations rules of a schema,
+	// summed up over all nesting levels.
+	MaxRuleCost uint64
+	// RuleCostBudget is the runtime cost budget of all x-kubernetes-validations rules evaluated for
+	// a single custom resource request. Rules whose estimated cost exceeds it are rejected, because
+	// they could never be evaluated completely.
+	RuleCostBudget uint64
+}
+
+// ValidateCustomResourceDefinitionSchemaLimits validates the top-level and the per-version schemas of
+// spec against the limits of the server. Schemas which are unchanged compared to oldSpec are not
+// validated, so that existing CustomResourceDefinitions stay updatable if the limits are lowered.
+// oldSpec is nil for new CustomResourceDefinitions.
+func ValidateCustomResourceDefinitionSchemaLimits(spec, oldSpec *apiextensions.CustomResourceDefinitionSpec, limits SchemaLimits, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	oldSchemas := map[string]*apiextensions.CustomResourceValidation{}
+	if oldSpec != nil {
+		oldSchemas[""] = oldSpec.Validation
+		for i := range oldSpec.Versions {
+			oldSchemas[oldSpec.Versions[i].Name] = oldSpec.Versions[i].Schema
+		}
+	}
+	validate := func(name string, customResourceValidation *apiextensions.CustomResourceValidation, fldPath *field.Path) {
+		if customResourceValidation == nil || customResourceValidation.OpenAPIV3Schema == nil {
+			return
+		}
+		if old, ok := oldSchemas[name]; ok && apiequality.Semantic.DeepEqual(old, customResourceValidation) {
+			return
+		}
+		expanded := expandValidationReferences(customResourceValidation)
+		allErrs = append(allErrs, validateSchemaLimits(customResourceValidation.OpenAPIV3Schema, expanded.OpenAPIV3Schema, limits, fldPath.Child("openAPIV3Schema"))...)
+	}
+	validate("", spec.Validation, fldPath.Child("validation"))
+	for i := range spec.Versions {
+		validate(spec.Versions[i].Name, spec.Versions[i].Schema, fldPath.Child("versions").Index(i).Child("schema"))
+	}
+
+	return allErrs
+}
+
+// validateSchemaLimits validates a single schema against limits. The size in bytes is the one of the
+// schema as specified, the other limits apply to the schema with its references expanded.
+func validateSchemaLimits(schema, expanded *apiextensions.JSONSchemaProps, limits SchemaLimits, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if limits.MaxBytes > 0 {
+		external := &v1beta1.JSONSchemaProps{}
+		if err := v1beta1.Convert_apiextensions_JSONSchemaProps_To_v1beta1_JSONSchemaProps(schema, external, nil); err != nil {
+			allErrs = append(allErrs, field.InternalError(fldPath, err))
+		} else if data, err := json.Marshal(external); err != nil {
+			allErrs = append(allErrs, field.InternalError(fldPath, err))
+		} else if len(data) > limits.MaxBytes {
+			allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("must not be larger than %d bytes, but is %d bytes", limits.MaxBytes, len(data))))
+		}
+	}
+
+	size := schemaSize{}
+	size.add(expanded, 1, true)
+	if limits.MaxDepth > 0 && size.depth > limits.MaxDepth {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("must not be nested deeper than %d levels, but is nested %d levels", limits.MaxDepth, size.depth)))
+	}
+	if limits.MaxProperties > 0 && size.properties > limits.MaxProperties {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("must not have more than %d properties, but has %d", limits.MaxProperties, size.properties)))
+	}
+	if limits.MaxRuleCost > 0 && size.ruleCost > limits.MaxRuleCost {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("x-kubernetes-validations"), fmt.Sprintf("the estimated cost of the rules of the schema must not exceed %d, but is %d", limits.MaxRuleCost, size.ruleCost)))
+	}
+	if limits.RuleCostBudget > 0 {
+		allErrs = append(allErrs, validateRuleCostBudget(schema, limits.RuleCostBudget, fldPath, true)...)
+	}
+
+	return allErrs
+}
+
+// validateRuleCostBudget rejects the x-kubernetes-validations rules of the schema and its nested
+// schemas whose estimated cost exceeds the runtime cost budget.
+func validateRuleCostBudget(schema *apiextensions.JSONSchemaProps, budget uint64, fldPath *field.Path, isResourceRoot bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if schema == nil {
+		return allErrs
+	}
+	for i, result := range cel.Compile(schema, isResourceRoot) {
+		if result.Program == nil {
+			continue
+		}
+		if cost := result.Program.EstimatedCost(); cost > budget {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("x-kubernetes-validations").Index(i).Child("rule"), fmt.Sprintf("the estimated cost of the rule must not exceed the runtime cost budget of %d, but is %d", budget, cost)))
+		}
+	}
+	for property, jsonSchema := range schema.Properties {
+		allErrs = append(allErrs, validateRuleCostBudget(&jsonSchema, budget, fldPath.Child("properties").Key(property), false)...)
+	}
+	if schema.AdditionalProperties != nil {
+		allErrs = append(allErrs, validateRuleCostBudget(schema.AdditionalProperties.Schema, budget, fldPath.Child("additionalProperties"), false)...)
+	}
+	if schema.Items != nil {
+		allErrs = append(allErrs, validateRuleCostBudget(schema.Items.Schema, budget, fldPath.Child("items"), false)...)
+	}
+	return allErrs
+}
+
+// schemaSize is the size of a schema and its nested schemas.
+type schemaSize struct {
+	depth      int
+	properties int
+	ruleCost   uint64
+}
+
+func (s *schemaSize) add(schema *apiextensions.JSONSchemaProps, depth int, isResourceRoot bool) {
+	if schema == nil {
+		return
+	}
+	if depth > s.depth {
+		s.depth = depth
+	}
+	s.properties += len(schema.Properties) + len(schema.PatternProperties)
+	for _, result := range cel.Compile(schema, isResourceRoot) {
+		// rules which do not compile are rejected by the schema validation
+		if result.Program != nil {
+			s.ruleCost += result.Program.EstimatedCost()
+		}
+	}
+
+	addAll := func(schemas []apiextensions.JSONSchemaProps) {
+		for i := range schemas {
+			s.add(&schemas[i], depth+1, false)
+		}
+	}
+	addMap := func(schemas map[string]apiextensions.JSONSchemaProps) {
+		for _, nested := range schemas {
+			s.add(&nested, depth+1, false)
+		}
+	}
+	addMap(schema.Properties)
+	addMap(schema.PatternProperties)
+	addMap(schema.Definitions)
+	addAll(schema.AllOf)
+	addAll(schema.OneOf)
+	addAll(schema.AnyOf)
+	s.add(schema.Not, depth+1, false)
+	if schema.Items != nil {
+		s.add(schema.Items.Schema, depth+1, false)
+		addAll(schema.Items.JSONSchemas)
+	}
+	if schema.AdditionalProperties != nil {
+		s.add(schema.AdditionalProperties.Schema, depth+1, false)
+	}
+	if schema.AdditionalItems != nil {
+		s.add(schema.AdditionalItems.Schema, depth+1, false)
+	}
+	for _, dependency := range schema.Dependencies {
+		s.add(dependency.Schema, depth+1, false)
+	}
+}
+
+// SchemaCompatibilityPolicy is the handling of breaking changes of the schemas of served versions by
+// updates of CustomResourceDefinitions.
+type SchemaCompatibilityPolicy string
+
+const (
+	// SchemaCompatibilityIgnore accepts breaking changes without notice. It is the default.
+	SchemaCompatibilityIgnore SchemaCompatibilityPolicy = "Ignore"
+	// SchemaCompatibilityWarn accepts breaking changes and lists them in the BreakingSchemaChange condition.
+	SchemaCompatibilityWarn SchemaCompatibilityPolicy = "Warn"
+	// SchemaCompatibilityReject rejects updates with breaking changes.
+	SchemaCompatibilityReject SchemaCompatibilityPolicy = "Reject"
+)
+
+// ValidateSchemaCompatibility returns the breaking changes of the schemas of the versions which are
+// served before and after the update, i.e. changes which make existing custom resources invalid or
+// drop their fields: changed types, removed properties, newly required fields and narrowed enums.
+// Added and removed schemas are not compared.
+func ValidateSchemaCompatibility(crd, oldCRD *apiextensions.CustomResourceDefinition) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// all versions share the top-level schema unless they specify their own
+	topLevel := !apiextensions.HasPerVersionSchema(crd.Spec.Versions)
+	for i, version := range crd.Spec.Versions {
+		if !version.Served || !apiextensions.HasServedCRDVersion(oldCRD, version.Name) {
+			continue
+		}
+		schema := expandValidationReferences(validationForVersion(&crd.Spec, version.Name))
+		oldSchema := expandValidationReferences(validationForVersion(&oldCRD.Spec, version.Name))
+		if schema == nil || schema.OpenAPIV3Schema == nil || oldSchema == nil || oldSchema.OpenAPIV3Schema == nil {
+			continue
+		}
+		fldPath := field.NewPath("spec", "versions").Index(i).Child("schema", "openAPIV3Schema")
+		if topLevel {
+			fldPath = field.NewPath("spec", "validation", "openAPIV3Schema")
+		}
+		allErrs = append(allErrs, validateSchemaCompatibility(schema.OpenAPIV3Schema, oldSchema.OpenAPIV3Schema, fldPath)...)
+		if topLevel && !apiextensions.HasPerVersionSchema(oldCRD.Spec.Versions) {
+			// the same schemas are compared for the other versions
+			break
+		}
+	}
+
+	return allErrs
+}
+
+// validationForVersion returns the top-level validation of spec, or the one of the given version if
+// the versions specify their own.
+func validationForVersion(spec *apiextensions.CustomResourceDefinitionSpec, version string) *apiextensions.CustomResourceValidation {
+	if !apiextensions.HasPerVersionSchema(spec.Versions) {
+		return spec.Validation
+	}
+	for i := range spec.Versions {
+		if spec.Versions[i].Name == version {
+			return spec.Versions[i].Schema
+		}
+	}
+	return nil
+}
+
+// validateSchemaCompatibility returns the breaking changes of schema compared to oldSchema. Nested
+// schemas are compared recursively unless their type changed.
+func validateSchemaCompatibility(schema, oldSchema *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// an empty type allows every type, and every integer is a number
+	if len(schema.Type) > 0 && schema.Type != oldSchema.Type && !(schema.Type == "number" && oldSchema.Type == "integer") {
+		return append(allErrs, field.Forbidden(fldPath.Child("type"), fmt.Sprintf("changing the type from %q to %q breaks existing custom resources", oldSchema.Type, schema.Type)))
+	}
+
+	if len(schema.Enum) > 0 {
+		if len(oldSchema.Enum) == 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("enum"), "adding an enum breaks existing custom resources with other values"))
+		} else {
+			removed := []string{}
+			for _, old := range oldSchema.Enum {
+				found := false
+				for _, v := range schema.Enum {
+					if reflect.DeepEqual(v, old) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					js, _ := json.Marshal(old)
+					removed = append(removed, string(js))
+				}
+			}
+			if len(removed) > 0 {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("enum"), fmt.Sprintf("removing the values %s breaks existing custom resources", strings.Join(removed, ", "))))
+			}
+		}
+	}
+
+	oldRequired := sets.NewString(oldSchema.Required...)
+	for i, name := range schema.Required {
+		if !oldRequired.Has(name) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("required").Index(i), fmt.Sprintf("requiring %q breaks existing custom resources without it", name)))
+		}
+	}
+
+	for _, name := range sets.StringKeySet(oldSchema.Properties).List() {
+		property, ok := schema.Properties[name]
+		if !ok {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("properties").Key(name), "removing the property breaks existing custom resources which specify it"))
+			continue
+		}
+		oldProperty := oldSchema.Properties[name]
+		allErrs = append(allErrs, validateSchemaCompatibility(&property, &oldProperty, fldPath.Child("properties").Key(name))...)
+	}
+	if schema.Items != nil && schema.Items.Schema != nil && oldSchema.Items != nil && oldSchema.Items.Schema != nil {
+		allErrs = append(allErrs, validateSchemaCompatibility(schema.Items.Schema, oldSchema.Items.Schema, fldPath.Child("items"))...)
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil && oldSchema.AdditionalProperties != nil && oldSchema.AdditionalProperties.Schema != nil {
+		allErrs = append(allErrs, validateSchemaCompatibility(schema.AdditionalProperties.Schema, oldSchema.AdditionalProperties.Schema, fldPath.Child("additionalProperties"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateCustomResourceDefinitionSpecUpdate statically validates
+func ValidateCustomResourceDefinitionSpecUpdate(spec, oldSpec *apiextensions.CustomResourceDefinitionSpec, established bool, fldPath *field.Path) field.ErrorList {
+	allErrs := ValidateCustomResourceDefinitionSpec(spec, fldPath)
+
+	if established {
+		// these effect the storage and cannot be changed therefore. The scope can be changed as long
+		// as no custom resources exist, which is checked by the registry.
+		allErrs = append(allErrs, genericvalidation.ValidateImmutableField(spec.Version, oldSpec.Version, fldPath.Child("version"))...)
+		allErrs = append(allErrs, genericvalidation.ValidateImmutableField(spec.Names.Kind, oldSpec.Names.Kind, fldPath.Child("names", "kind"))...)
+	}
+
+	// these affects the resource name, which is always immutable, so this can't be updated.
+	allErrs = append(allErrs, genericvalidation.ValidateImmutableField(spec.Group, oldSpec.Group, fldPath.Child("group"))...)
+	allErrs = append(allErrs, genericvalidation.ValidateImmutableField(spec.Names.Plural, oldSpec.Names.Plural, fldPath.Child("names", "plural"))...)
+	// the custom resources would be orphaned in the old location
+	allErrs = append(allErrs, genericvalidation.ValidateImmutableField(spec.Storage, oldSpec.Storage, fldPath.Child("storage"))...)
+
+	return allErrs
+}
+
+// ValidateCustomResourceDefinitionStatus statically validates
+func ValidateCustomResourceDefinitionStatus(status *apiextensions.CustomResourceDefinitionStatus, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, ValidateCustomResourceDefinitionNames(&status.AcceptedNames, fldPath.Child("acceptedNames"))...)
+	versions := sets.NewString()
+	for i, persisted := range status.PersistedVersions {
+		idxPath := fldPath.Child("persistedVersions").Index(i)
+		if len(persisted.Version) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("version"), ""))
+		} else if versions.Has(persisted.Version) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("version"), persisted.Version))
+		}
+		versions.Insert(persisted.Version)
+		allErrs = append(allErrs, genericvalidation.ValidateNonnegativeField(persisted.Count, idxPath.Child("count"))...)
+	}
+	return allErrs
+}
+
+// ValidateCustomResourceDefinitionNames statically validates
+func ValidateCustomResourceDefinitionNames(names *apiextensions.CustomResourceDefinitionNames, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if errs := validationutil.IsDNS1035Label(names.Plural); len(names.Plural) > 0 && len(errs) > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("plural"), names.Plural, strings.Join(errs, ",")))
+	}
+	if errs := validationutil.IsDNS1035Label(names.Singular); len(names.Singular) > 0 && len(errs) > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("singular"), names.Singular, strings.Join(errs, ",")))
+	}
+	if errs := validationutil.IsDNS1035Label(strings.ToLower(names.Kind)); len(names.Kind) > 0 && len(errs) > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("kind"), names.Kind, "may have mixed case, but should otherwise match: "+strings.Join(errs, ",")))
+	}
+	if errs := validationutil.IsDNS1035Label(strings.ToLower(names.ListKind)); len(names.ListKind) > 0 && len(errs) > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("listKind"), names.ListKind, "may have mixed case, but should otherwise match: "+strings.Join(errs, ",")))
+	}
+
+	for i, shortName := range names.ShortNames {
+		if errs := validationutil.IsDNS1035Label(shortName); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("shortNames").Index(i), shortName, strings.Join(errs, ",")))
+		}
+
+	}
+
+	for i, category := range names.Categories {
+		if errs := validationutil.IsDNS1035Label(category); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("categories").Index(i), category, strings.Join(errs, ",")))
+		}
+	}
+
+	// kind and listKind may not be the same or parsing become ambiguous
+	if len(names.Kind) > 0 && names.Kind == names.ListKind {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("listKind"), names.ListKind, "kind and listKind may not be the same"))
+	}
+
+	return allErrs
+}
+
+// ValidateCustomResourceDefinitionValidation statically validates
+func ValidateCustomResourceDefinitionValidation(customResourceValidation *apiextensions.CustomResourceValidation, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if customResourceValidation == nil {
+		return allErrs
+	}
+
+	if customResourceValidation.OpenAPIV3Schema != nil {
+		if customResourceValidation.OpenAPIV3Schema.Default != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("openAPIV3Schema", "default"), "default is not supported at the root of the schema"))
+		}
+		if customResourceValidation.OpenAPIV3Schema.XImmutable {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("openAPIV3Schema", "x-kubernetes-immutable"), "x-kubernetes-immutable is not supported at the root of the schema"))
+		}
+		openAPIV3Schema := &specStandardValidatorV3{}
+		allErrs = append(allErrs, ValidateCustomResourceDefinitionOpenAPISchema(customResourceValidation.OpenAPIV3Schema, fldPath.Child("openAPIV3Schema"), openAPIV3Schema)...)
+		allErrs = append(allErrs, validateValidationRules(customResourceValidation.OpenAPIV3Schema, fldPath.Child("openAPIV3Schema"), true, nil)...)
+		allErrs = append(allErrs, defaulting.ValidateDefaults(customResourceValidation.OpenAPIV3Schema, fldPath.Child("openAPIV3Schema"))...)
+	}
+
+	return allErrs
+}
+
+// validateSchemas applies validate to the top-level and the per-version validation schemas of spec.
+func validateSchemas(spec *apiextensions.CustomResourceDefinitionSpec, fldPath *field.Path, validate func(*apiextensions.CustomResourceValidation, *field.Path) field.ErrorList) field.ErrorList {
+	allErrs := validate(spec.Validation, fldPath.Child("validation"))
+	for i := range spec.Versions {
+		allErrs = append(allErrs, validate(spec.Versions[i].Schema, fldPath.Child("versions").Index(i).Child("schema"))...)
+	}
+	return allErrs
+}
+
+// expandSchemaReferences returns spec with the references of its top-level and per-version schemas
+// expanded, or spec itself if they have none. Schemas whose references cannot be expanded are kept
+// as they are; the errors are reported by validateSchemaReferences.
+func expandSchemaReferences(spec *apiextensions.CustomResourceDefinitionSpec) *apiextensions.CustomResourceDefinitionSpec {
+	hasReferences := spec.Validation != nil && apiextensions.HasReferences(spec.Validation.OpenAPIV3Schema)
+	for _, version := range spec.Versions {
+		hasReferences = hasReferences || version.Schema != nil && apiextensions.HasReferences(version.Schema.OpenAPIV3Schema)
+	}
+	if !hasReferences {
+		return spec
+	}
+
+	expanded := *spec
+	expanded.Validation = expandValidationReferences(spec.Validation)
+	expanded.Versions = make([]apiextensions.CustomResourceDefinitionVersion, len(spec.Versions))
+	for i, version := range spec.Versions {
+		version.Schema = expandValidationReferences(version.Schema)
+		expanded.Versions[i] = version
+	}
+	return &expanded
+}
+
+// expandValidationReferences returns the validation with the references of its schema expanded, or
+// the validation itself if they have none or cannot be expanded.
+func expandValidationReferences(customResourceValidation *apiextensions.CustomResourceValidation) *apiextensions.CustomResourceValidation {
+	if customResourceValidation == nil || !apiextensions.HasReferences(customResourceValidation.OpenAPIV3Schema) {
+		return customResourceValidation
+	}
+	expanded, err := apiextensions.ExpandReferences(customResourceValidation.OpenAPIV3Schema)
+	if err != nil {
+		return customResourceValidation
+	}
+	return &apiextensions.CustomResourceValidation{OpenAPIV3Schema: expanded}
+}
+
+// validateSchemaReferences checks that the definitions of the validation schema, if any, are only
+// specified at its root, that the $refs refer to them and specify nothing but a description along
+// with them, and that the references can be expanded.
+func validateSchemaReferences(customResourceValidation *apiextensions.CustomResourceValidation, fldPath *field.Path) field.ErrorList {
+	if customResourceValidation == nil || !apiextensions.HasReferences(customResourceValidation.OpenAPIV3Schema) {
+		return nil
+	}
+	schema := customResourceValidation.OpenAPIV3Schema
+	fldPath = fldPath.Child("openAPIV3Schema")
+
+	allErrs := validateReferences(schema, schema.Definitions, fldPath, true)
+	for name, definition := range schema.Definitions {
+		allErrs = append(allErrs, validateReferences(&definition, schema.Definitions, fldPath.Child("definitions").Key(name), false)...)
+	}
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+	// cycles and expansions which are too large
+	if _, err := apiextensions.ExpandReferences(schema); err != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, err.Error()))
+	}
+	return allErrs
+}
+
+// validateReferences validates the $refs of schema and its nested schemas against definitions.
+func validateReferences(schema *apiextensions.JSONSchemaProps, definitions apiextensions.JSONSchemaDefinitions, fldPath *field.Path, isRoot bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if schema == nil {
+		return allErrs
+	}
+	if !isRoot && len(schema.Definitions) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("definitions"), "definitions are only supported at the root of the schema"))
+	}
+	if schema.Ref != nil {
+		ref := *schema.Ref
+		if !strings.HasPrefix(ref, apiextensions.DefinitionsRefPrefix) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("$ref"), ref, fmt.Sprintf("only references to %s<name> are supported", apiextensions.DefinitionsRefPrefix)))
+		} else if _, ok := definitions[strings.TrimPrefix(ref, apiextensions.DefinitionsRefPrefix)]; !ok {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("$ref"), ref, "must refer to a definition at the root of the schema"))
+		}
+		rest := *schema
+		rest.Ref, rest.Description = nil, ""
+		if !reflect.DeepEqual(rest, apiextensions.JSONSchemaProps{}) {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "only description may be specified along with $ref"))
+		}
+		return allErrs
+	}
+
+	visit := func(nested *apiextensions.JSONSchemaProps, fldPath *field.Path) {
+		allErrs = append(allErrs, validateReferences(nested, definitions, fldPath, false)...)
+	}
+	for name, property := range schema.Properties {
+		visit(&property, fldPath.Child("properties").Key(name))
+	}
+	for name, property := range schema.PatternProperties {
+		visit(&property, fldPath.Child("patternProperties").Key(name))
+	}
+	for i := range schema.AllOf {
+		visit(&schema.AllOf[i], fldPath.Child("allOf").Index(i))
+	}
+	for i := range schema.OneOf {
+		visit(&schema.OneOf[i], fldPath.Child("oneOf").Index(i))
+	}
+	for i := range schema.AnyOf {
+		visit(&schema.AnyOf[i], fldPath.Child("anyOf").Index(i))
+	}
+	visit(schema.Not, fldPath.Child("not"))
+	if schema.Items != nil {
+		visit(schema.Items.Schema, fldPath.Child("items"))
+		for i := range schema.Items.JSONSchemas {
+			visit(&schema.Items.JSONSchemas[i], fldPath.Child("items").Index(i))
+		}
+	}
+	if schema.AdditionalProperties != nil {
+		visit(schema.AdditionalProperties.Schema, fldPath.Child("additionalProperties"))
+	}
+	if schema.AdditionalItems != nil {
+		visit(schema.AdditionalItems.Schema, fldPath.Child("additionalItems"))
+	}
+	for name, dependency := range schema.Dependencies {
+		visit(dependency.Schema, fldPath.Child("dependencies").Key(name))
+	}
+
+	return allErrs
+}
+
+// validateStructuralSchema checks that the validation schema, if any, is structural.
+func validateStructuralSchema(customResourceValidation *apiextensions.CustomResourceValidation, fldPath *field.Path) field.ErrorList {
+	if customResourceValidation == nil {
+		return nil
+	}
+	return structural.Validate(customResourceValidation.OpenAPIV3Schema, fldPath.Child("openAPIV3Schema"))
+}
+
+// validateMetadataSchema checks that the validation schema, if any, neither constrains nor defaults
+// metadata other than restricting name and generateName by patterns. Everything else in metadata is
+// validated as ObjectMeta. Properties other than name and generateName are rejected by the structural
+// schema validation.
+func validateMetadataSchema(customResourceValidation *apiextensions.CustomResourceValidation, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if customResourceValidation == nil || customResourceValidation.OpenAPIV3Schema == nil {
+		return allErrs
+	}
+	metadata, found := customResourceValidation.OpenAPIV3Schema.Properties["metadata"]
+	if !found {
+		return allErrs
+	}
+	metadataPath := fldPath.Child("openAPIV3Schema", "properties").Key("metadata")
+
+	if metadata.Default != nil {
+		allErrs = append(allErrs, field.Forbidden(metadataPath.Child("default"), "metadata must not be defaulted"))
+	}
+	rest := metadata
+	rest.Type, rest.Description, rest.Default, rest.Properties = "", "", nil, nil
+	if !reflect.DeepEqual(rest, apiextensions.JSONSchemaProps{}) {
+		allErrs = append(allErrs, field.Forbidden(metadataPath, "only type, description and the properties name and generateName may be specified for metadata"))
+	}
+
+	for _, property := range []string{"name", "generateName"} {
+		propertySchema, found := metadata.Properties[property]
+		if !found {
+			continue
+		}
+		propertyPath := metadataPath.Child("properties").Key(property)
+		if len(propertySchema.Type) > 0 && propertySchema.Type != "string" {
+			allErrs = append(allErrs, field.Invalid(propertyPath.Child("type"), propertySchema.Type, "must be string"))
+		}
+		if propertySchema.Default != nil {
+			allErrs = append(allErrs, field.Forbidden(propertyPath.Child("default"), "metadata must not be defaulted"))
+		}
+		if _, err := regexp.Compile(propertySchema.Pattern); err != nil {
+			allErrs = append(allErrs, field.Invalid(propertyPath.Child("pattern"), propertySchema.Pattern, fmt.Sprintf("must be a valid regular expression: %v", err)))
+		}
+		rest := propertySchema
+		rest.Type, rest.Description, rest.Default, rest.Pattern = "", "", nil, ""
+		if !reflect.DeepEqual(rest, apiextensions.JSONSchemaProps{}) {
+			allErrs = append(allErrs, field.Forbidden(propertyPath, "only type, description and pattern may be specified for "+property))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateCustomResourceDefinitionOpenAPISchema statically validates
+func ValidateCustomResourceDefinitionOpenAPISchema(schema *apiextensions.JSONSchemaProps, fldPath *field.Path, ssv specStandardValidator) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if schema == nil {
+		return allErrs
+	}
+
+	allErrs = append(allErrs, ssv.validate(schema, fldPath)...)
+
+	if schema.UniqueItems == true {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("uniqueItems"), "uniqueItems cannot be set to true since the runtime complexity becomes quadratic"))
+	}
+
+	if schema.XPreserveUnknownFields != nil && !*schema.XPreserveUnknownFields {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("x-kubernetes-preserve-unknown-fields"), *schema.XPreserveUnknownFields, "must be true or undefined"))
+	}
+
+	allErrs = append(allErrs, validateListAndMapType(schema, fldPath)...)
+	allErrs = append(allErrs, validateValueExtensions(schema, fldPath)...)
+
+	// additionalProperties contradicts Kubernetes API convention to ignore unknown fields
+	if schema.AdditionalProperties != nil {
+		if schema.AdditionalProperties.Allows == false {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("additionalProperties"), "additionalProperties cannot be set to false"))
+		}
+		allErrs = append(allErrs, ValidateCustomResourceDefinitionOpenAPISchema(schema.AdditionalProperties.Schema, fldPath.Child("additionalProperties"), ssv)...)
+	}
+
+	if len(schema.Properties) != 0 {
+		for property, jsonSchema := range schema.Properties {
+			allErrs = append(allErrs, ValidateCustomResourceDefinitionOpenAPISchema(&jsonSchema, fldPath.Child("properties").Key(property), ssv)...)
+		}
+	}
+
+	if len(schema.PatternProperties) != 0 {
+		for property, jsonSchema := range schema.PatternProperties {
+			allErrs = append(allErrs, ValidateCustomResourceDefinitionOpenAPISchema(&jsonSchema, fldPath.Child("patternProperties").Key(property), ssv)...)
+		}
+	}
+
+	if schema.AdditionalItems != nil {
+		allErrs = append(allErrs, ValidateCustomResourceDefinitionOpenAPISchema(schema.AdditionalItems.Schema, fldPath.Child("additionalItems"), ssv)...)
+	}
+
+	allErrs = append(allErrs, ValidateCustomResourceDefinitionOpenAPISchema(schema.Not, fldPath.Child("not"), ssv)...)
+	allErrs = append(allErrs, forbidInLogicalJunctors(schema.Not, fldPath.Child("not"))...)
+
+	if len(schema.AllOf) != 0 {
+		for i, jsonSchema := range schema.AllOf {
+			allErrs = append(allErrs, ValidateCustomResourceDefinitionOpenAPISchema(&jsonSchema, fldPath.Child("allOf").Index(i), ssv)...)
+			allErrs = append(allErrs, forbidInLogicalJunctors(&jsonSchema, fldPath.Child("allOf").Index(i))...)
+		}
+	}
+
+	if len(schema.OneOf) != 0 {
+		for i, jsonSchema := range schema.OneOf {
+			allErrs = append(allErrs, ValidateCustomResourceDefinitionOpenAPISchema(&jsonSchema, fldPath.Child("oneOf").Index(i), ssv)...)
+			allErrs = append(allErrs, forbidInLogicalJunctors(&jsonSchema, fldPath.Child("oneOf").Index(i))...)
+		}
+	}
+
+	if len(schema.AnyOf) != 0 {
+		for i, jsonSchema := range schema.AnyOf {
+			allErrs = append(allErrs, ValidateCustomResourceDefinitionOpenAPISchema(&jsonSchema, fldPath.Child("anyOf").Index(i), ssv)...)
+			allErrs = append(allErrs, forbidInLogicalJunctors(&jsonSchema, fldPath.Child("anyOf").Index(i))...)
+		}
+	}
+
+	if len(schema.Definitions) != 0 {
+		for definition, jsonSchema := range schema.Definitions {
+			allErrs = append(allErrs, ValidateCustomResourceDefinitionOpenAPISchema(&jsonSchema, fldPath.Child("definitions").Key(definition), ssv)...)
+		}
+	}
+
+	if schema.Items != nil {
+		allErrs = append(allErrs, ValidateCustomResourceDefinitionOpenAPISchema(schema.Items.Schema, fldPath.Child("items"), ssv)...)
+		if len(schema.Items.JSONSchemas) != 0 {
+			for i, jsonSchema := range schema.Items.JSONSchemas {
+				allErrs = append(allErrs, ValidateCustomResourceDefinitionOpenAPISchema(&jsonSchema, fldPath.Child("items").Index(i), ssv)...)
+			}
+		}
+	}
+
+	if schema.Dependencies != nil {
+		for dependency, jsonSchemaPropsOrStringArray := range schema.Dependencies {
+			allErrs = append(allErrs, ValidateCustomResourceDefinitionOpenAPISchema(jsonSchemaPropsOrStringArray.Schema, fldPath.Child("dependencies").Key(dependency), ssv)...)
+		}
+	}
+
+	return allErrs
+}
+
+type specStandardValidator interface {
+	validate(spec *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList
+}
+
+type specStandardValidatorV3 struct{}
+
+// validate validates against OpenAPI Schema v3.
+func (v *specStandardValidatorV3) validate(schema *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if schema == nil {
+		return allErrs
+	}
+
+	if schema.ID != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("id"), "id is not supported"))
+	}
+
+	if schema.AdditionalItems != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("additionalItems"), "additionalItems is not supported"))
+	}
+
+	if len(schema.PatternProperties) != 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("patternProperties"), "patternProperties is not supported"))
+	}
+
+	if schema.Dependencies != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("dependencies"), "dependencies is not supported"))
+	}
+
+	if schema.Type == "null" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("type"), "type cannot be set to null"))
+	}
+
+	if schema.Format == decimal.Format && schema.Type != "string" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("type"), schema.Type, "must be string for format decimal"))
+	}
+
+	if schema.Items != nil && len(schema.Items.JSONSchemas) != 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("items"), "items must be a schema object and not an array"))
+	}
+
+	return allErrs
+}
+
+// supportedRuleReasons are the reasons validation rules may report.
+var supportedRuleReasons = sets.NewString(
+	string(apiextensions.FieldValueInvalid),
+	string(apiextensions.FieldValueForbidden),
+	string(apiextensions.FieldValueRequired),
+	string(apiextensions.FieldValueDuplicate),
+)
+
+// validateValidationRules compiles the x-kubernetes-validations rules of the schema and of the
+// nested schemas the rules are enforced for, i.e. properties, additionalProperties and items.
+// uncorrelatable is the path of the array whose items cannot be correlated with old items on
+// update, if the schema is nested in one. Transition rules and x-kubernetes-immutable, which are
+// enforced against the old value, are forbidden there.
+func validateValidationRules(schema *apiextensions.JSONSchemaProps, fldPath *field.Path, isResourceRoot bool, uncorrelatable *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if schema == nil {
+		return allErrs
+	}
+
+	if schema.XImmutable && uncorrelatable != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("x-kubernetes-immutable"), fmt.Sprintf("cannot be used on the uncorrelatable portion of the schema within %v", uncorrelatable)))
+	}
+
+	for i, result := range cel.Compile(schema, isResourceRoot) {
+		rule := schema.XValidations[i]
+		rulePath := fldPath.Child("x-kubernetes-validations").Index(i)
+		switch {
+		case result.Error != nil:
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("rule"), rule.Rule, result.Error.Error()))
+		case result.Program.IsTransitionRule() && uncorrelatable != nil:
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("rule"), rule.Rule, fmt.Sprintf("oldSelf cannot be used on the uncorrelatable portion of the schema within %v", uncorrelatable)))
+		}
+		if result.MessageExpressionError != nil {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("messageExpression"), rule.MessageExpression, result.MessageExpressionError.Error()))
+		}
+		if result.FieldPathError != nil {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("fieldPath"), rule.FieldPath, result.FieldPathError.Error()))
+		}
+		if len(rule.Reason) > 0 && !supportedRuleReasons.Has(string(rule.Reason)) {
+			allErrs = append(allErrs, field.NotSupported(rulePath.Child("reason"), rule.Reason, supportedRuleReasons.List()))
+		}
+	}
+
+	for property, jsonSchema := range schema.Properties {
+		allErrs = append(allErrs, validateValidationRules(&jsonSchema, fldPath.Child("properties").Key(property), false, uncorrelatable)...)
+	}
+	if schema.AdditionalProperties != nil {
+		allErrs = append(allErrs, validateValidationRules(schema.AdditionalProperties.Schema, fldPath.Child("additionalProperties"), false, uncorrelatable)...)
+	}
+	if schema.Items != nil {
+		// only the items of map lists are correlated by their keys
+		itemsUncorrelatable := uncorrelatable
+		if itemsUncorrelatable == nil && (schema.XListType == nil || *schema.XListType != "map") {
+			itemsUncorrelatable = fldPath
+		}
+		allErrs = append(allErrs, validateValidationRules(schema.Items.Schema, fldPath.Child("items"), false, itemsUncorrelatable)...)
+	}
+
+	return allErrs
+}
+
+// validateListAndMapType checks the x-kubernetes-list-type, x-kubernetes-list-map-keys and
+// x-kubernetes-map-type extensions of schema.
+func validateListAndMapType(schema *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	listType := ""
+	if schema.XListType != nil {
+		listType = *schema.XListType
+		switch {
+		case listType != "atomic" && listType != "set" && listType != "map":
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("x-kubernetes-list-type"), listType, []string{"atomic", "set", "map"}))
+		case schema.Type != "array":
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("x-kubernetes-list-type"), listType, "must only be used if type is array"))
+		}
+	}
+	if listType != "map" && len(schema.XListMapKeys) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("x-kubernetes-list-map-keys"), "must be empty if x-kubernetes-list-type is not map"))
+	}
+
+	var items *apiextensions.JSONSchemaProps
+	if schema.Items != nil {
+		items = schema.Items.Schema
+	}
+	switch listType {
+	case "set":
+		if items != nil && !isAtomic(items) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("items"), items.Type, "must be a scalar, or an atomic object or array if x-kubernetes-list-type is set"))
+		}
+	case "map":
+		if len(schema.XListMapKeys) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("x-kubernetes-list-map-keys"), "must not be empty if x-kubernetes-list-type is map"))
+		}
+		if items == nil || items.Type != "object" {
+			itemsType := ""
+			if items != nil {
+				itemsType = items.Type
+			}
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("items", "type"), itemsType, "must be object if x-kubernetes-list-type is map"))
+			break
+		}
+		required := sets.NewString(items.Required...)
+		seen := sets.NewString()
+		for i, key := range schema.XListMapKeys {
+			keyPath := fldPath.Child("x-kubernetes-list-map-keys").Index(i)
+			if seen.Has(key) {
+				allErrs = append(allErrs, field.Duplicate(keyPath, key))
+				continue
+			}
+			seen.Insert(key)
+			prop, found := items.Properties[key]
+			switch {
+			case !found:
+				allErrs = append(allErrs, field.Invalid(keyPath, key, "must be a property of the items"))
+			case !isScalar(&prop):
+				allErrs = append(allErrs, field.Invalid(keyPath, key, "must be a scalar property of the items"))
+			case !required.Has(key):
+				allErrs = append(allErrs, field.Invalid(keyPath, key, "must be a required property of the items"))
+			}
+		}
+	}
+
+	if schema.XMapType != nil {
+		switch mapType := *schema.XMapType; {
+		case mapType != "atomic" && mapType != "granular":
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("x-kubernetes-map-type"), mapType, []string{"atomic", "granular"}))
+		case schema.Type != "object":
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("x-kubernetes-map-type"), mapType, "must only be used if type is object"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateValueExtensions checks that the x-kubernetes-int-or-string, x-kubernetes-embedded-resource
+// and x-kubernetes-preserve-unknown-fields extensions of schema are consistent with its type and
+// structure, which otherwise only fail when custom resources are validated and pruned. The type
+// itself is checked for structural schemas.
+func validateValueExtensions(schema *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	hasProperties := len(schema.Properties) > 0
+	hasAdditionalProperties := schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil
+	hasItems := schema.Items != nil && (schema.Items.Schema != nil || len(schema.Items.JSONSchemas) > 0)
+	preserveUnknownFields := schema.XPreserveUnknownFields != nil && *schema.XPreserveUnknownFields
+
+	if schema.XIntOrString {
+		if hasProperties {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("properties"), "must be empty if x-kubernetes-int-or-string is true"))
+		}
+		if hasAdditionalProperties {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("additionalProperties"), "must be undefined if x-kubernetes-int-or-string is true"))
+		}
+		if hasItems {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("items"), "must be undefined if x-kubernetes-int-or-string is true"))
+		}
+		if schema.XEmbeddedResource {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("x-kubernetes-embedded-resource"), "must be false if x-kubernetes-int-or-string is true"))
+		}
+		if preserveUnknownFields {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("x-kubernetes-preserve-unknown-fields"), "must be undefined if x-kubernetes-int-or-string is true"))
+		}
+	}
+
+	if schema.XEmbeddedResource && hasItems {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("items"), "must be undefined if x-kubernetes-embedded-resource is true"))
+	}
+
+	if preserveUnknownFields && len(schema.Type) > 0 && schema.Type != "object" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("type"), schema.Type, "must be object or empty if x-kubernetes-preserve-unknown-fields is true"))
+	}
+
+	return allErrs
+}
+
+// isScalar returns true if the values of schema are strings, numbers or booleans.
+func isScalar(schema *apiextensions.JSONSchemaProps) bool {
+	switch schema.Type {
+	case "string", "integer", "number", "boolean":
+		return true
+	}
+	return schema.XIntOrString
+}
+
+// isAtomic returns true if the values of schema are merged by apply as a whole.
+func isAtomic(schema *apiextensions.JSONSchemaProps) bool {
+	switch schema.Type {
+	case "object":
+		return schema.XMapType != nil && *schema.XMapType == "atomic"
+	case "array":
+		return schema.XListType == nil || *schema.XListType == "atomic"
+	}
+	return isScalar(schema)
+}
+
+// forbidInLogicalJunctors rejects x-kubernetes-validations rules and defaults anywhere in the given
+// schema. Both are only applied along properties, additionalProperties and items, not inside of not,
+// allOf, oneOf and anyOf.
+func forbidInLogicalJunctors(schema *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if schema == nil {
+		return allErrs
+	}
+
+	if len(schema.XValidations) != 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("x-kubernetes-validations"), "x-kubernetes-validations is not supported inside of not, allOf, oneOf and anyOf"))
+	}
+	if schema.Default != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("default"), "default is not supported inside of not, allOf, oneOf and anyOf"))
+	}
+
+	for property, jsonSchema := range schema.Properties {
+		allErrs = append(allErrs, forbidInLogicalJunctors(&jsonSchema, fldPath.Child("properties").Key(property))...)
+	}
+	if schema.AdditionalProperties != nil {
+		allErrs = append(allErrs, forbidInLogicalJunctors(schema.AdditionalProperties.Schema, fldPath.Child("additionalProperties"))...)
+	}
+	if schema.Items != nil {
+		allErrs = append(allErrs, forbidInLogicalJunctors(schema.Items.Schema, fldPath.Child("items"))...)
 	}
 
 	return allErrs
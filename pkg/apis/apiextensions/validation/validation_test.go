@@ -17,6 +17,7 @@ limitations under the License.
 package validation
 
 import (
+	"strings"
 	"testing"
 
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
@@ -38,14 +39,31 @@ func invalid(path ...string) validationMatch {
 func unsupported(path ...string) validationMatch {
 	return validationMatch{path: field.NewPath(path[0], path[1:]...), errorType: field.ErrorTypeNotSupported}
 }
+func forbidden(path ...string) validationMatch {
+	return validationMatch{path: field.NewPath(path[0], path[1:]...), errorType: field.ErrorTypeForbidden}
+}
 func immutable(path ...string) validationMatch {
 	return validationMatch{path: field.NewPath(path[0], path[1:]...), errorType: field.ErrorTypeInvalid}
 }
+func duplicate(path ...string) validationMatch {
+	return validationMatch{path: field.NewPath(path[0], path[1:]...), errorType: field.ErrorTypeDuplicate}
+}
+func tooLong(path ...string) validationMatch {
+	return validationMatch{path: field.NewPath(path[0], path[1:]...), errorType: field.ErrorTypeTooLong}
+}
 
 func (v validationMatch) matches(err *field.Error) bool {
 	return err.Type == v.errorType && err.Field == v.path.String()
 }
 
+var singleVersionList = []apiextensions.CustomResourceDefinitionVersion{
+	{
+		Name:    "version",
+		Served:  true,
+		Storage: true,
+	},
+}
+
 func TestValidateCustomResourceDefinition(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -66,10 +84,12 @@ func TestValidateCustomResourceDefinition(t *testing.T) {
 			errors: []validationMatch{
 				invalid("metadata", "name"),
 				required("spec", "version"),
+				required("spec", "versions"),
 				required("spec", "scope"),
 				required("spec", "names", "singular"),
 				required("spec", "names", "kind"),
 				required("spec", "names", "listKind"),
+				invalid("status", "storedVersions"),
 			},
 		},
 		{
@@ -81,11 +101,13 @@ func TestValidateCustomResourceDefinition(t *testing.T) {
 				invalid("metadata", "name"),
 				required("spec", "group"),
 				required("spec", "version"),
+				required("spec", "versions"),
 				required("spec", "scope"),
 				required("spec", "names", "plural"),
 				required("spec", "names", "singular"),
 				required("spec", "names", "kind"),
 				required("spec", "names", "listKind"),
+				invalid("status", "storedVersions"),
 			},
 		},
 		{
@@ -97,10 +119,11 @@ func TestValidateCustomResourceDefinition(t *testing.T) {
 					Version: "ve()*rsion",
 					Scope:   apiextensions.ResourceScope("foo"),
 					Names: apiextensions.CustomResourceDefinitionNames{
-						Plural:   "pl()*ural",
-						Singular: "value()*a",
-						Kind:     "value()*a",
-						ListKind: "value()*a",
+						Plural:     "pl()*ural",
+						Singular:   "value()*a",
+						Kind:       "value()*a",
+						ListKind:   "value()*a",
+						Categories: []string{"all", "Bad_Category"},
 					},
 				},
 				Status: apiextensions.CustomResourceDefinitionStatus{
@@ -116,17 +139,20 @@ func TestValidateCustomResourceDefinition(t *testing.T) {
 				invalid("metadata", "name"),
 				invalid("spec", "group"),
 				invalid("spec", "version"),
+				required("spec", "versions"),
 				unsupported("spec", "scope"),
 				invalid("spec", "names", "plural"),
 				invalid("spec", "names", "singular"),
 				invalid("spec", "names", "kind"),
 				invalid("spec", "names", "listKind"), // invalid format
 				invalid("spec", "names", "listKind"), // kind == listKind
+				invalid("spec", "names", "categories[1]"),
 				invalid("status", "acceptedNames", "plural"),
 				invalid("status", "acceptedNames", "singular"),
 				invalid("status", "acceptedNames", "kind"),
 				invalid("status", "acceptedNames", "listKind"), // invalid format
 				invalid("status", "acceptedNames", "listKind"), // kind == listKind
+				invalid("status", "storedVersions"),
 			},
 		},
 		{
@@ -134,8 +160,9 @@ func TestValidateCustomResourceDefinition(t *testing.T) {
 			resource: &apiextensions.CustomResourceDefinition{
 				ObjectMeta: metav1.ObjectMeta{Name: "plural.group"},
 				Spec: apiextensions.CustomResourceDefinitionSpec{
-					Group:   "group.c(*&om",
-					Version: "version",
+					Group:    "group.c(*&om",
+					Version:  "version",
+					Versions: singleVersionList,
 					Names: apiextensions.CustomResourceDefinitionNames{
 						Plural:   "plural",
 						Singular: "singular",
@@ -144,6 +171,7 @@ func TestValidateCustomResourceDefinition(t *testing.T) {
 					},
 				},
 				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
 					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
 						Plural:   "plural",
 						Singular: "singular",
@@ -160,292 +188,4291 @@ func TestValidateCustomResourceDefinition(t *testing.T) {
 				invalid("status", "acceptedNames", "listKind"),
 			},
 		},
-	}
-
-	for _, tc := range tests {
-		errs := ValidateCustomResourceDefinition(tc.resource)
-		seenErrs := make([]bool, len(errs))
-
-		for _, expectedError := range tc.errors {
-			found := false
-			for i, err := range errs {
-				if expectedError.matches(err) && !seenErrs[i] {
-					found = true
-					seenErrs[i] = true
-					break
-				}
-			}
-
-			if !found {
-				t.Errorf("%s: expected %v at %v, got %v", tc.name, expectedError.errorType, expectedError.path.String(), errs)
-			}
-		}
-
-		for i, seen := range seenErrs {
-			if !seen {
-				t.Errorf("%s: unexpected error: %v", tc.name, errs[i])
-			}
-		}
-	}
-}
-
-func TestValidateCustomResourceDefinitionUpdate(t *testing.T) {
-	tests := []struct {
-		name     string
-		old      *apiextensions.CustomResourceDefinition
-		resource *apiextensions.CustomResourceDefinition
-		errors   []validationMatch
-	}{
 		{
-			name: "unchanged",
-			old: &apiextensions.CustomResourceDefinition{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:            "plural.group.com",
-					ResourceVersion: "42",
-				},
+			name: "validation rules",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
 				Spec: apiextensions.CustomResourceDefinitionSpec{
-					Group:   "group.com",
-					Version: "version",
-					Scope:   apiextensions.ResourceScope("Cluster"),
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
 					Names: apiextensions.CustomResourceDefinitionNames{
 						Plural:   "plural",
 						Singular: "singular",
-						Kind:     "kind",
-						ListKind: "listkind",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"spec": {
+									Type: "object",
+									Properties: map[string]apiextensions.JSONSchemaProps{
+										"replicas":    {Type: "integer"},
+										"maxReplicas": {Type: "integer"},
+									},
+									XValidations: apiextensions.ValidationRules{
+										{Rule: "self.replicas <= self.maxReplicas"},
+										{Rule: "self.replicas <="},
+										{Rule: "self.minReplicas <= self.replicas"},
+										{Rule: "self.replicas + 1"},
+										{Rule: "self.replicas >= oldSelf.replicas"},
+										{Rule: "self.replicas > 0", MessageExpression: "self.replicas"},
+										{Rule: "self.replicas > 0", FieldPath: ".minReplicas"},
+										{Rule: "self.replicas > 0", Reason: "FieldValueWrong"},
+										{Rule: "self.replicas > 0", MessageExpression: "'too few: ' + string(oldSelf.replicas)"},
+										{Rule: "self.replicas <= self.maxReplicas", MessageExpression: "'must not exceed ' + string(self.maxReplicas)", FieldPath: ".replicas", Reason: apiextensions.FieldValueForbidden},
+									},
+								},
+								"hosts": {
+									Type: "array",
+									Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{
+										Type:         "string",
+										XValidations: apiextensions.ValidationRules{{Rule: "self == oldSelf"}},
+									}},
+								},
+							},
+							XValidations: apiextensions.ValidationRules{
+								{Rule: "self.metadata.name.startsWith('a')", Message: "name must start with a"},
+							},
+							AllOf: []apiextensions.JSONSchemaProps{
+								{XValidations: apiextensions.ValidationRules{{Rule: "true"}}},
+							},
+						},
 					},
 				},
 				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
 					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
 						Plural:   "plural",
 						Singular: "singular",
-						Kind:     "kind",
-						ListKind: "listkind",
+						Kind:     "Plural",
+						ListKind: "PluralList",
 					},
 				},
 			},
+			errors: []validationMatch{
+				{path: field.NewPath("spec", "validation", "openAPIV3Schema", "properties").Key("spec").Child("x-kubernetes-validations").Index(1).Child("rule"), errorType: field.ErrorTypeInvalid},
+				{path: field.NewPath("spec", "validation", "openAPIV3Schema", "properties").Key("spec").Child("x-kubernetes-validations").Index(2).Child("rule"), errorType: field.ErrorTypeInvalid},
+				{path: field.NewPath("spec", "validation", "openAPIV3Schema", "properties").Key("spec").Child("x-kubernetes-validations").Index(3).Child("rule"), errorType: field.ErrorTypeInvalid},
+				{path: field.NewPath("spec", "validation", "openAPIV3Schema", "properties").Key("hosts").Child("items", "x-kubernetes-validations").Index(0).Child("rule"), errorType: field.ErrorTypeInvalid},
+				{path: field.NewPath("spec", "validation", "openAPIV3Schema", "properties").Key("spec").Child("x-kubernetes-validations").Index(5).Child("messageExpression"), errorType: field.ErrorTypeInvalid},
+				{path: field.NewPath("spec", "validation", "openAPIV3Schema", "properties").Key("spec").Child("x-kubernetes-validations").Index(6).Child("fieldPath"), errorType: field.ErrorTypeInvalid},
+				{path: field.NewPath("spec", "validation", "openAPIV3Schema", "properties").Key("spec").Child("x-kubernetes-validations").Index(7).Child("reason"), errorType: field.ErrorTypeNotSupported},
+				{path: field.NewPath("spec", "validation", "openAPIV3Schema", "properties").Key("spec").Child("x-kubernetes-validations").Index(8).Child("messageExpression"), errorType: field.ErrorTypeInvalid},
+				{path: field.NewPath("spec", "validation", "openAPIV3Schema", "allOf").Index(0).Child("x-kubernetes-validations"), errorType: field.ErrorTypeForbidden},
+			},
+		},
+		{
+			name: "unsupported schema keywords",
 			resource: &apiextensions.CustomResourceDefinition{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:            "plural.group.com",
-					ResourceVersion: "42",
-				},
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
 				Spec: apiextensions.CustomResourceDefinitionSpec{
-					Group:   "group.com",
-					Version: "version",
-					Scope:   apiextensions.ResourceScope("Cluster"),
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
 					Names: apiextensions.CustomResourceDefinitionNames{
 						Plural:   "plural",
 						Singular: "singular",
-						Kind:     "kind",
-						ListKind: "listkind",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							ID:                   "id",
+							Type:                 "null",
+							UniqueItems:          true,
+							AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{Allows: false},
+						},
 					},
 				},
 				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
 					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
 						Plural:   "plural",
 						Singular: "singular",
-						Kind:     "kind",
-						ListKind: "listkind",
+						Kind:     "Plural",
+						ListKind: "PluralList",
 					},
 				},
 			},
-			errors: []validationMatch{},
+			errors: []validationMatch{
+				forbidden("spec", "validation", "openAPIV3Schema", "id"),
+				forbidden("spec", "validation", "openAPIV3Schema", "type"),
+				invalid("spec", "validation", "openAPIV3Schema", "type"),
+				forbidden("spec", "validation", "openAPIV3Schema", "uniqueItems"),
+				forbidden("spec", "validation", "openAPIV3Schema", "additionalProperties"),
+			},
 		},
 		{
-			name: "unchanged-established",
-			old: &apiextensions.CustomResourceDefinition{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:            "plural.group.com",
-					ResourceVersion: "42",
-				},
+			name: "decimal format",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
 				Spec: apiextensions.CustomResourceDefinitionSpec{
-					Group:   "group.com",
-					Version: "version",
-					Scope:   apiextensions.ResourceScope("Cluster"),
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
 					Names: apiextensions.CustomResourceDefinitionNames{
 						Plural:   "plural",
 						Singular: "singular",
-						Kind:     "kind",
-						ListKind: "listkind",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"amount": {Type: "string", Format: "decimal"},
+								"price":  {Type: "number", Format: "decimal"},
+							},
+						},
 					},
 				},
 				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
 					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
 						Plural:   "plural",
 						Singular: "singular",
-						Kind:     "kind",
-						ListKind: "listkind",
-					},
-					Conditions: []apiextensions.CustomResourceDefinitionCondition{
-						{Type: apiextensions.Established, Status: apiextensions.ConditionTrue},
+						Kind:     "Plural",
+						ListKind: "PluralList",
 					},
 				},
 			},
+			errors: []validationMatch{
+				{path: field.NewPath("spec", "validation", "openAPIV3Schema", "properties").Key("price").Child("type"), errorType: field.ErrorTypeInvalid},
+			},
+		},
+		{
+			name: "references to definitions",
 			resource: &apiextensions.CustomResourceDefinition{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:            "plural.group.com",
-					ResourceVersion: "42",
-				},
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
 				Spec: apiextensions.CustomResourceDefinitionSpec{
-					Group:   "group.com",
-					Version: "version",
-					Scope:   apiextensions.ResourceScope("Cluster"),
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
 					Names: apiextensions.CustomResourceDefinitionNames{
 						Plural:   "plural",
 						Singular: "singular",
-						Kind:     "kind",
-						ListKind: "listkind",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"source":      {Ref: strPtr("#/definitions/endpoint"), Description: "the source"},
+								"destination": {Ref: strPtr("#/definitions/endpoint")},
+							},
+							Definitions: apiextensions.JSONSchemaDefinitions{
+								"endpoint": {
+									Type: "object",
+									Properties: map[string]apiextensions.JSONSchemaProps{
+										"host": {Type: "string"},
+										"port": {Ref: strPtr("#/definitions/port")},
+									},
+								},
+								"port": {Type: "integer", Minimum: float64Ptr(1)},
+							},
+						},
 					},
 				},
 				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
 					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
 						Plural:   "plural",
 						Singular: "singular",
-						Kind:     "kind",
-						ListKind: "listkind",
+						Kind:     "Plural",
+						ListKind: "PluralList",
 					},
 				},
 			},
 			errors: []validationMatch{},
 		},
 		{
-			name: "changes",
-			old: &apiextensions.CustomResourceDefinition{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:            "plural.group.com",
-					ResourceVersion: "42",
-				},
+			name: "invalid references",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
 				Spec: apiextensions.CustomResourceDefinitionSpec{
-					Group:   "group.com",
-					Version: "version",
-					Scope:   apiextensions.ResourceScope("Cluster"),
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
 					Names: apiextensions.CustomResourceDefinitionNames{
 						Plural:   "plural",
 						Singular: "singular",
-						Kind:     "kind",
-						ListKind: "listkind",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"external": {Ref: strPtr("http://example.com/schema.json")},
+								"unknown":  {Ref: strPtr("#/definitions/unknown")},
+								"typed":    {Ref: strPtr("#/definitions/port"), Type: "integer"},
+								"nested": {
+									Type:        "object",
+									Definitions: apiextensions.JSONSchemaDefinitions{"port": {Type: "integer"}},
+								},
+							},
+							Definitions: apiextensions.JSONSchemaDefinitions{
+								"port": {Type: "integer"},
+							},
+						},
 					},
 				},
 				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
 					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
 						Plural:   "plural",
 						Singular: "singular",
-						Kind:     "kind",
-						ListKind: "listkind",
-					},
-					Conditions: []apiextensions.CustomResourceDefinitionCondition{
-						{Type: apiextensions.Established, Status: apiextensions.ConditionFalse},
+						Kind:     "Plural",
+						ListKind: "PluralList",
 					},
 				},
 			},
+			errors: []validationMatch{
+				invalid("spec", "validation", "openAPIV3Schema", "properties[external]", "$ref"),
+				invalid("spec", "validation", "openAPIV3Schema", "properties[unknown]", "$ref"),
+				// schemas whose references cannot be expanded are validated as they are
+				required("spec", "validation", "openAPIV3Schema", "properties[external]", "type"),
+				required("spec", "validation", "openAPIV3Schema", "properties[unknown]", "type"),
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[typed]"),
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[nested]", "definitions"),
+			},
+		},
+		{
+			name: "cyclic references",
 			resource: &apiextensions.CustomResourceDefinition{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:            "plural.group.com",
-					ResourceVersion: "42",
-				},
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
 				Spec: apiextensions.CustomResourceDefinitionSpec{
-					Group:   "abc.com",
-					Version: "version2",
-					Scope:   apiextensions.ResourceScope("Namespaced"),
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
 					Names: apiextensions.CustomResourceDefinitionNames{
-						Plural:   "plural2",
-						Singular: "singular2",
-						Kind:     "kind2",
-						ListKind: "listkind2",
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type:       "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{"tree": {Ref: strPtr("#/definitions/tree")}},
+							Definitions: apiextensions.JSONSchemaDefinitions{
+								"tree": {
+									Type: "object",
+									Properties: map[string]apiextensions.JSONSchemaProps{
+										"children": {Type: "array", Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Ref: strPtr("#/definitions/tree")}}},
+									},
+								},
+							},
+						},
 					},
 				},
 				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
 					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
-						Plural:   "plural2",
-						Singular: "singular2",
-						Kind:     "kind2",
-						ListKind: "listkind2",
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
 					},
 				},
 			},
 			errors: []validationMatch{
-				immutable("spec", "group"),
-				immutable("spec", "names", "plural"),
+				forbidden("spec", "validation", "openAPIV3Schema"),
+				required("spec", "validation", "openAPIV3Schema", "properties[tree]", "type"),
 			},
 		},
 		{
-			name: "changes-established",
-			old: &apiextensions.CustomResourceDefinition{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:            "plural.group.com",
-					ResourceVersion: "42",
-				},
+			name: "vendor extensions",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
 				Spec: apiextensions.CustomResourceDefinitionSpec{
-					Group:   "group.com",
-					Version: "version",
-					Scope:   apiextensions.ResourceScope("Cluster"),
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
 					Names: apiextensions.CustomResourceDefinitionNames{
 						Plural:   "plural",
 						Singular: "singular",
-						Kind:     "kind",
-						ListKind: "listkind",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"port":     {XIntOrString: true},
+								"template": {Type: "object", XEmbeddedResource: true, XPreserveUnknownFields: boolPtr(true)},
+								"unknown":  {Type: "object", XPreserveUnknownFields: boolPtr(false)},
+							},
+						},
 					},
 				},
 				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
 					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
 						Plural:   "plural",
 						Singular: "singular",
-						Kind:     "kind",
-						ListKind: "listkind",
+						Kind:     "Plural",
+						ListKind: "PluralList",
 					},
-					Conditions: []apiextensions.CustomResourceDefinitionCondition{
-						{Type: apiextensions.Established, Status: apiextensions.ConditionTrue},
+				},
+			},
+			errors: []validationMatch{
+				invalid("spec", "validation", "openAPIV3Schema", "properties[unknown]", "x-kubernetes-preserve-unknown-fields"),
+			},
+		},
+		{
+			name: "list and map types",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"ports": {
+									Type:         "array",
+									XListType:    strPtr("map"),
+									XListMapKeys: []string{"port", "protocol"},
+									Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{
+										Type:     "object",
+										Required: []string{"port", "protocol"},
+										Properties: map[string]apiextensions.JSONSchemaProps{
+											"port":     {Type: "integer"},
+											"protocol": {Type: "string"},
+										},
+									}},
+								},
+								"finalizers": {
+									Type:      "array",
+									XListType: strPtr("set"),
+									Items:     &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string"}},
+								},
+								"selector": {Type: "object", XMapType: strPtr("atomic")},
+								"badKeys": {
+									Type:         "array",
+									XListType:    strPtr("map"),
+									XListMapKeys: []string{"name", "name", "missing", "optional", "nested"},
+									Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{
+										Type:     "object",
+										Required: []string{"name", "nested"},
+										Properties: map[string]apiextensions.JSONSchemaProps{
+											"name":     {Type: "string"},
+											"optional": {Type: "string"},
+											"nested":   {Type: "object"},
+										},
+									}},
+								},
+								"noKeys": {
+									Type:      "array",
+									XListType: strPtr("map"),
+									Items:     &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string"}},
+								},
+								"granularSet": {
+									Type:      "array",
+									XListType: strPtr("set"),
+									Items:     &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "object"}},
+								},
+								"atomicKeys": {
+									Type:         "array",
+									XListType:    strPtr("atomic"),
+									XListMapKeys: []string{"name"},
+									Items:        &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string"}},
+								},
+								"badListType":      {Type: "array", XListType: strPtr("sorted"), Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string"}}},
+								"listTypeOnObject": {Type: "object", XListType: strPtr("set")},
+								"badMapType":       {Type: "object", XMapType: strPtr("separable")},
+								"mapTypeOnArray":   {Type: "array", XMapType: strPtr("atomic"), Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string"}}},
+							},
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
 					},
 				},
 			},
+			errors: []validationMatch{
+				duplicate("spec", "validation", "openAPIV3Schema", "properties[badKeys]", "x-kubernetes-list-map-keys[1]"),
+				invalid("spec", "validation", "openAPIV3Schema", "properties[badKeys]", "x-kubernetes-list-map-keys[2]"),
+				invalid("spec", "validation", "openAPIV3Schema", "properties[badKeys]", "x-kubernetes-list-map-keys[3]"),
+				invalid("spec", "validation", "openAPIV3Schema", "properties[badKeys]", "x-kubernetes-list-map-keys[4]"),
+				required("spec", "validation", "openAPIV3Schema", "properties[noKeys]", "x-kubernetes-list-map-keys"),
+				invalid("spec", "validation", "openAPIV3Schema", "properties[noKeys]", "items", "type"),
+				invalid("spec", "validation", "openAPIV3Schema", "properties[granularSet]", "items"),
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[atomicKeys]", "x-kubernetes-list-map-keys"),
+				unsupported("spec", "validation", "openAPIV3Schema", "properties[badListType]", "x-kubernetes-list-type"),
+				invalid("spec", "validation", "openAPIV3Schema", "properties[listTypeOnObject]", "x-kubernetes-list-type"),
+				unsupported("spec", "validation", "openAPIV3Schema", "properties[badMapType]", "x-kubernetes-map-type"),
+				invalid("spec", "validation", "openAPIV3Schema", "properties[mapTypeOnArray]", "x-kubernetes-map-type"),
+			},
+		},
+		{
+			name: "placement of value extensions",
 			resource: &apiextensions.CustomResourceDefinition{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:            "plural.group.com",
-					ResourceVersion: "42",
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"port": {
+									XIntOrString: true,
+									AnyOf:        []apiextensions.JSONSchemaProps{{Type: "integer"}, {Type: "string"}},
+								},
+								"embedded":      {Type: "object", XEmbeddedResource: true, XPreserveUnknownFields: boolPtr(true)},
+								"preserved":     {Type: "object", XPreserveUnknownFields: boolPtr(true)},
+								"typedPort":     {Type: "string", XIntOrString: true},
+								"objectPort":    {XIntOrString: true, Properties: map[string]apiextensions.JSONSchemaProps{"value": {Type: "integer"}}},
+								"mapPort":       {XIntOrString: true, AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{Allows: true, Schema: &apiextensions.JSONSchemaProps{Type: "integer"}}},
+								"listPort":      {XIntOrString: true, Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "integer"}}},
+								"embeddedPort":  {XIntOrString: true, XEmbeddedResource: true},
+								"preservedPort": {XIntOrString: true, XPreserveUnknownFields: boolPtr(true)},
+								"embeddedList": {
+									Type:              "array",
+									XEmbeddedResource: true,
+									Items:             &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "object"}},
+								},
+								"preservedString": {Type: "string", XPreserveUnknownFields: boolPtr(true)},
+							},
+						},
+					},
 				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				invalid("spec", "validation", "openAPIV3Schema", "properties[typedPort]", "type"),
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[objectPort]", "properties"),
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[mapPort]", "additionalProperties"),
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[listPort]", "items"),
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[embeddedPort]", "x-kubernetes-embedded-resource"),
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[preservedPort]", "x-kubernetes-preserve-unknown-fields"),
+				invalid("spec", "validation", "openAPIV3Schema", "properties[embeddedList]", "type"),
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[embeddedList]", "items"),
+				invalid("spec", "validation", "openAPIV3Schema", "properties[preservedString]", "type"),
+			},
+		},
+		{
+			name: "immutable values",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
 				Spec: apiextensions.CustomResourceDefinitionSpec{
-					Group:   "abc.com",
-					Version: "version2",
-					Scope:   apiextensions.ResourceScope("Namespaced"),
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
 					Names: apiextensions.CustomResourceDefinitionNames{
-						Plural:   "plural2",
-						Singular: "singular2",
-						Kind:     "kind2",
-						ListKind: "listkind2",
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type:       "object",
+							XImmutable: true,
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"storageClass": {Type: "string", XImmutable: true},
+								"labels": {
+									Type:                 "object",
+									AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{Allows: true, Schema: &apiextensions.JSONSchemaProps{Type: "string", XImmutable: true}},
+								},
+								"volumes": {
+									Type:         "array",
+									XListType:    strPtr("map"),
+									XListMapKeys: []string{"name"},
+									Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{
+										Type:     "object",
+										Required: []string{"name"},
+										Properties: map[string]apiextensions.JSONSchemaProps{
+											"name":   {Type: "string"},
+											"source": {Type: "string", XImmutable: true},
+										},
+									}},
+								},
+								"args": {
+									Type:  "array",
+									Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string", XImmutable: true}},
+								},
+							},
+						},
 					},
 				},
 				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
 					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
-						Plural:   "plural2",
-						Singular: "singular2",
-						Kind:     "kind2",
-						ListKind: "listkind2",
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
 					},
 				},
 			},
 			errors: []validationMatch{
-				immutable("spec", "group"),
-				immutable("spec", "version"),
-				immutable("spec", "scope"),
-				immutable("spec", "names", "kind"),
-				immutable("spec", "names", "plural"),
+				forbidden("spec", "validation", "openAPIV3Schema", "x-kubernetes-immutable"),
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[args]", "items", "x-kubernetes-immutable"),
 			},
 		},
-	}
-
-	for _, tc := range tests {
-		errs := ValidateCustomResourceDefinitionUpdate(tc.resource, tc.old)
-		seenErrs := make([]bool, len(errs))
-
-		for _, expectedError := range tc.errors {
-			found := false
-			for i, err := range errs {
-				if expectedError.matches(err) && !seenErrs[i] {
-					found = true
-					seenErrs[i] = true
-					break
-				}
-			}
-
+		{
+			name: "defaults",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type:    "object",
+							Default: jsonPtr(map[string]interface{}{}),
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"valid": {
+									Type:    "string",
+									Default: jsonPtr("foo"),
+								},
+								"junctor": {
+									Type: "string",
+									AnyOf: []apiextensions.JSONSchemaProps{
+										{Default: jsonPtr("foo")},
+									},
+								},
+							},
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				forbidden("spec", "validation", "openAPIV3Schema", "default"),
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[junctor]", "anyOf[0]", "default"),
+			},
+		},
+		{
+			name: "metadata restrictions",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"metadata": {
+									Type:     "object",
+									Default:  jsonPtr(map[string]interface{}{}),
+									Required: []string{"name"},
+									Properties: map[string]apiextensions.JSONSchemaProps{
+										"name": {
+											Type:      "string",
+											Pattern:   "^[a-z]+$",
+											MaxLength: int64Ptr(10),
+										},
+										"generateName": {
+											Type:    "integer",
+											Pattern: "[",
+											Default: jsonPtr("foo-"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[metadata]", "default"),
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[metadata]"),
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[metadata]", "properties[name]"),
+				invalid("spec", "validation", "openAPIV3Schema", "properties[metadata]", "properties[generateName]", "type"),
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[metadata]", "properties[generateName]", "default"),
+				invalid("spec", "validation", "openAPIV3Schema", "properties[metadata]", "properties[generateName]", "pattern"),
+				required("spec", "validation", "openAPIV3Schema", "properties[metadata]", "default", "name"),
+				invalid("spec", "validation", "openAPIV3Schema", "properties[metadata]", "default", "generateName"),
+				invalid("spec", "validation", "openAPIV3Schema", "properties[metadata]", "properties[generateName]", "default"),
+			},
+		},
+		{
+			name: "defaults must validate",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"valid": {
+									Type:    "string",
+									Enum:    []apiextensions.JSON{"foo", "bar"},
+									Default: jsonPtr("foo"),
+								},
+								"wrongType": {
+									Type:    "integer",
+									Default: jsonPtr("foo"),
+								},
+								"spec": {
+									Type:     "object",
+									Required: []string{"replicas", "image"},
+									Default:  jsonPtr(map[string]interface{}{"replicas": int64(-1)}),
+									Properties: map[string]apiextensions.JSONSchemaProps{
+										"replicas": {
+											Type:    "integer",
+											Minimum: float64Ptr(0),
+										},
+										"image": {
+											Type:    "string",
+											Default: jsonPtr("busybox"),
+										},
+									},
+								},
+								"items": {
+									Type: "array",
+									Items: &apiextensions.JSONSchemaPropsOrArray{
+										Schema: &apiextensions.JSONSchemaProps{
+											Type:    "string",
+											Pattern: "^[a-z]+$",
+											Default: jsonPtr("Foo"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				invalid("spec", "validation", "openAPIV3Schema", "properties[wrongType]", "default"),
+				invalid("spec", "validation", "openAPIV3Schema", "properties[spec]", "default", "replicas"),
+				invalid("spec", "validation", "openAPIV3Schema", "properties[items]", "items", "default"),
+			},
+		},
+		{
+			name: "metadata name pattern",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"metadata": {
+									Type:        "object",
+									Description: "standard object metadata",
+									Properties: map[string]apiextensions.JSONSchemaProps{
+										"name":         {Type: "string", Pattern: "^[a-z]+$"},
+										"generateName": {Type: "string", Description: "prefix of generated names", Pattern: "^[a-z]+-$"},
+									},
+								},
+							},
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{},
+		},
+		{
+			name: "pruning without schema",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					PreserveUnknownFields: boolPtr(false),
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				invalid("spec", "preserveUnknownFields"),
+			},
+		},
+		{
+			name: "bad versions",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "version",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{Name: "other", Served: true, Storage: true},
+						{Name: "Bad_Name", Served: true, Storage: true},
+						{Name: "other", Served: false, Storage: false},
+						{Name: "", Served: true, Storage: false},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"other", "Bad_Name"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				invalid("spec", "version"),
+				invalid("spec", "versions[1]", "name"),
+				duplicate("spec", "versions[2]", "name"),
+				required("spec", "versions[3]", "name"),
+				invalid("spec", "versions"),
+			},
+		},
+		{
+			name: "deprecation warnings",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "v1",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{Name: "v1", Served: true, Storage: true},
+						{Name: "v2", Served: true, Deprecated: true},
+						{Name: "v3", Served: true, Deprecated: true, DeprecationWarning: strPtr("v3 is deprecated, use v1")},
+						{Name: "v4", Served: true, DeprecationWarning: strPtr("v4 is deprecated")},
+						{Name: "v5", Served: true, Deprecated: true, DeprecationWarning: strPtr(strings.Repeat("x", 257))},
+						{Name: "v6", Served: true, Deprecated: true, DeprecationWarning: strPtr("v6 is\ndeprecated")},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"v1"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				forbidden("spec", "versions[3]", "deprecationWarning"),
+				tooLong("spec", "versions[4]", "deprecationWarning"),
+				invalid("spec", "versions[5]", "deprecationWarning"),
+			},
+		},
+		{
+			name: "removal releases",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "v1",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{Name: "v1", Served: true, Storage: true},
+						{Name: "v2", Served: true, Deprecated: true, RemovedInRelease: strPtr("1.25")},
+						{Name: "v3", Served: true, RemovedInRelease: strPtr("1.25")},
+						{Name: "v4", Served: true, Deprecated: true, RemovedInRelease: strPtr("1.25.0")},
+						{Name: "v5", Served: false, Deprecated: true, RemovedInRelease: strPtr("v1.30")},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"v1"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				forbidden("spec", "versions[2]", "removedInRelease"),
+				invalid("spec", "versions[3]", "removedInRelease"),
+			},
+		},
+		{
+			name: "mirror storage version",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "v1",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{Name: "v1", Served: true, Storage: true, MirrorStorage: false},
+						{Name: "v2", Served: true, MirrorStorage: true},
+						{Name: "v3", Served: false, MirrorStorage: false},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"v1"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{},
+		},
+		{
+			name: "invalid mirror storage versions",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "v1",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{Name: "v1", Served: true, Storage: true, MirrorStorage: true},
+						{Name: "v2", Served: true, MirrorStorage: true},
+						{Name: "v3", Served: false, MirrorStorage: true},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"v1"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				invalid("spec", "versions[0]", "mirrorStorage"),
+				invalid("spec", "versions"),
+			},
+		},
+		{
+			name: "per-version fields",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "v1",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{
+							Name:    "v1",
+							Served:  true,
+							Storage: true,
+							Schema: &apiextensions.CustomResourceValidation{OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+								Type:       "object",
+								Properties: map[string]apiextensions.JSONSchemaProps{"spec": {Type: "object", Properties: map[string]apiextensions.JSONSchemaProps{"color": {Type: "string"}}}},
+							}},
+							Subresources:             &apiextensions.CustomResourceSubresources{Status: &apiextensions.CustomResourceSubresourceStatus{}},
+							AdditionalPrinterColumns: []apiextensions.CustomResourceColumnDefinition{{Name: "Color", Type: "string", JSONPath: ".spec.color"}},
+						},
+						{
+							Name:   "v2",
+							Served: true,
+							Schema: &apiextensions.CustomResourceValidation{OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+								Type:        "object",
+								UniqueItems: true,
+								Properties:  map[string]apiextensions.JSONSchemaProps{"spec": {}},
+							}},
+							Subresources:             &apiextensions.CustomResourceSubresources{Scale: &apiextensions.CustomResourceSubresourceScale{}},
+							AdditionalPrinterColumns: []apiextensions.CustomResourceColumnDefinition{{Name: "Color", Type: "color", JSONPath: ".spec.color"}},
+						},
+						{Name: "v3", Served: true},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					PreserveUnknownFields: boolPtr(false),
+					SelectableFields:      []apiextensions.SelectableField{{JSONPath: ".spec.color"}},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"v1"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				invalid("spec", "preserveUnknownFields"),
+				forbidden("spec", "versions[1]", "schema", "openAPIV3Schema", "uniqueItems"),
+				required("spec", "versions[1]", "subresources", "scale", "specReplicasPath"),
+				required("spec", "versions[1]", "subresources", "scale", "statusReplicasPath"),
+				unsupported("spec", "versions[1]", "additionalPrinterColumns[0]", "type"),
+				invalid("spec", "selectableFields[0]", "jsonPath"),
+				invalid("spec", "selectableFields[0]", "jsonPath"),
+				required("spec", "versions[1]", "schema", "openAPIV3Schema", "properties[spec]", "type"),
+			},
+		},
+		{
+			name: "per-version and top-level fields",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "v1",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{
+							Name:                     "v1",
+							Served:                   true,
+							Storage:                  true,
+							Schema:                   &apiextensions.CustomResourceValidation{OpenAPIV3Schema: &apiextensions.JSONSchemaProps{Type: "object"}},
+							Subresources:             &apiextensions.CustomResourceSubresources{Status: &apiextensions.CustomResourceSubresourceStatus{}},
+							AdditionalPrinterColumns: []apiextensions.CustomResourceColumnDefinition{{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"}},
+						},
+						{Name: "v2", Served: true},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation:               &apiextensions.CustomResourceValidation{OpenAPIV3Schema: &apiextensions.JSONSchemaProps{Type: "object"}},
+					Subresources:             &apiextensions.CustomResourceSubresources{Status: &apiextensions.CustomResourceSubresourceStatus{}},
+					AdditionalPrinterColumns: []apiextensions.CustomResourceColumnDefinition{{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"}},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"v1"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				forbidden("spec", "validation"),
+				forbidden("spec", "subresources"),
+				forbidden("spec", "additionalPrinterColumns"),
+			},
+		},
+		{
+			name: "identical per-version fields",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "v1",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{
+							Name:                     "v1",
+							Served:                   true,
+							Storage:                  true,
+							Schema:                   &apiextensions.CustomResourceValidation{OpenAPIV3Schema: &apiextensions.JSONSchemaProps{Type: "object"}},
+							Subresources:             &apiextensions.CustomResourceSubresources{Status: &apiextensions.CustomResourceSubresourceStatus{}},
+							AdditionalPrinterColumns: []apiextensions.CustomResourceColumnDefinition{{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"}},
+						},
+						{
+							Name:                     "v2",
+							Served:                   true,
+							Schema:                   &apiextensions.CustomResourceValidation{OpenAPIV3Schema: &apiextensions.JSONSchemaProps{Type: "object"}},
+							Subresources:             &apiextensions.CustomResourceSubresources{Status: &apiextensions.CustomResourceSubresourceStatus{}},
+							AdditionalPrinterColumns: []apiextensions.CustomResourceColumnDefinition{{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"}},
+						},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"v1"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				invalid("spec", "versions"),
+				invalid("spec", "versions"),
+				invalid("spec", "versions"),
+			},
+		},
+		{
+			name: "bad conversion strategy",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Conversion: &apiextensions.CustomResourceConversion{
+						Strategy: "Magic",
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				unsupported("spec", "conversion", "strategy"),
+			},
+		},
+		{
+			name: "webhook client config with none strategy",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Conversion: &apiextensions.CustomResourceConversion{
+						Strategy: apiextensions.NoneConverter,
+						WebhookClientConfig: &apiextensions.WebhookClientConfig{
+							URL: strPtr("https://example.com/convert"),
+						},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				forbidden("spec", "conversion", "webhookClientConfig"),
+			},
+		},
+		{
+			name: "failure policy with none strategy",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Conversion: &apiextensions.CustomResourceConversion{
+						Strategy:      apiextensions.NoneConverter,
+						FailurePolicy: conversionFailurePolicyPtr(apiextensions.ConversionFailurePolicyIgnore),
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				forbidden("spec", "conversion", "failurePolicy"),
+			},
+		},
+		{
+			name: "webhook with ignore failure policy",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Conversion: &apiextensions.CustomResourceConversion{
+						Strategy: apiextensions.WebhookConverter,
+						WebhookClientConfig: &apiextensions.WebhookClientConfig{
+							URL: strPtr("https://example.com/convert"),
+						},
+						ConversionReviewVersions: []string{"v1beta1"},
+						FailurePolicy:            conversionFailurePolicyPtr(apiextensions.ConversionFailurePolicyIgnore),
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{},
+		},
+		{
+			name: "webhook with unknown failure policy",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Conversion: &apiextensions.CustomResourceConversion{
+						Strategy: apiextensions.WebhookConverter,
+						WebhookClientConfig: &apiextensions.WebhookClientConfig{
+							URL: strPtr("https://example.com/convert"),
+						},
+						ConversionReviewVersions: []string{"v1beta1"},
+						FailurePolicy:            conversionFailurePolicyPtr("Retry"),
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				unsupported("spec", "conversion", "failurePolicy"),
+			},
+		},
+		{
+			name: "webhook without client config",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Conversion: &apiextensions.CustomResourceConversion{
+						Strategy:                 apiextensions.WebhookConverter,
+						ConversionReviewVersions: []string{"v1beta1"},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				required("spec", "conversion", "webhookClientConfig"),
+			},
+		},
+		{
+			name: "webhook with url and service",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Conversion: &apiextensions.CustomResourceConversion{
+						Strategy:                 apiextensions.WebhookConverter,
+						ConversionReviewVersions: []string{"v1beta1"},
+						WebhookClientConfig: &apiextensions.WebhookClientConfig{
+							URL:     strPtr("https://example.com/convert"),
+							Service: &apiextensions.ServiceReference{Namespace: "ns", Name: "name"},
+						},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				required("spec", "conversion", "webhookClientConfig"),
+			},
+		},
+		{
+			name: "webhook with bad url",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Conversion: &apiextensions.CustomResourceConversion{
+						Strategy:                 apiextensions.WebhookConverter,
+						ConversionReviewVersions: []string{"v1beta1"},
+						WebhookClientConfig: &apiextensions.WebhookClientConfig{
+							URL: strPtr("http://user@example.com/convert?foo=bar#fragment"),
+						},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				invalid("spec", "conversion", "webhookClientConfig", "url"),
+				invalid("spec", "conversion", "webhookClientConfig", "url"),
+				invalid("spec", "conversion", "webhookClientConfig", "url"),
+				invalid("spec", "conversion", "webhookClientConfig", "url"),
+			},
+		},
+		{
+			name: "webhook with bad service",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Conversion: &apiextensions.CustomResourceConversion{
+						Strategy:                 apiextensions.WebhookConverter,
+						ConversionReviewVersions: []string{"v1beta1"},
+						WebhookClientConfig: &apiextensions.WebhookClientConfig{
+							Service: &apiextensions.ServiceReference{Path: strPtr("convert")},
+						},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				required("spec", "conversion", "webhookClientConfig", "service", "name"),
+				required("spec", "conversion", "webhookClientConfig", "service", "namespace"),
+				invalid("spec", "conversion", "webhookClientConfig", "service", "path"),
+			},
+		},
+		{
+			name: "multiple versions with webhook",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "v1",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{Name: "v1", Served: true, Storage: false},
+						{Name: "v2", Served: true, Storage: true},
+					},
+					Conversion: &apiextensions.CustomResourceConversion{
+						Strategy:                 apiextensions.WebhookConverter,
+						ConversionReviewVersions: []string{"v1beta1"},
+						WebhookClientConfig: &apiextensions.WebhookClientConfig{
+							Service:  &apiextensions.ServiceReference{Namespace: "ns", Name: "name", Path: strPtr("/convert")},
+							CABundle: []byte("ca"),
+						},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"v2"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{},
+		},
+		{
+			name: "multiple versions with expressions",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "v1",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{Name: "v1", Served: true, Storage: true},
+						{Name: "v2", Served: true, Storage: false},
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"spec": {
+									Type:       "object",
+									Properties: map[string]apiextensions.JSONSchemaProps{"size": {Type: "integer"}},
+								},
+							},
+						},
+					},
+					Conversion: &apiextensions.CustomResourceConversion{
+						Strategy: apiextensions.ExpressionConverter,
+						Expressions: []apiextensions.ConversionExpression{
+							{
+								FromVersion: "v1",
+								ToVersion:   "v2",
+								FieldMappings: []apiextensions.ConversionFieldMapping{
+									{Path: "spec.replicas", Expression: "self.spec.size"},
+									{Path: "spec.size", Expression: "null"},
+								},
+							},
+						},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"v1"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{},
+		},
+		{
+			name: "invalid conversion expressions",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "v1",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{Name: "v1", Served: true, Storage: true},
+						{Name: "v2", Served: true, Storage: false},
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"spec": {
+									Type:       "object",
+									Properties: map[string]apiextensions.JSONSchemaProps{"size": {Type: "integer"}},
+								},
+							},
+						},
+					},
+					Conversion: &apiextensions.CustomResourceConversion{
+						Strategy:                 apiextensions.ExpressionConverter,
+						ConversionReviewVersions: []string{"v1beta1"},
+						Expressions: []apiextensions.ConversionExpression{
+							{
+								FromVersion: "v1",
+								ToVersion:   "v1",
+								FieldMappings: []apiextensions.ConversionFieldMapping{
+									{Path: "spec.replicas", Expression: "self.spec.other"},
+									{Path: "spec.replicas", Expression: "self.spec.size"},
+									{Path: "metadata.name", Expression: "'foo'"},
+									{Path: "spec..size"},
+								},
+							},
+							{
+								FromVersion: "v1",
+								ToVersion:   "v1",
+							},
+							{
+								FromVersion: "v3",
+								FieldMappings: []apiextensions.ConversionFieldMapping{
+									{Path: "spec.replicas", Expression: "self.spec.other"},
+								},
+							},
+						},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"v1"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				forbidden("spec", "conversion", "conversionReviewVersions"),
+				invalid("spec", "conversion", "expressions[0]", "toVersion"),
+				invalid("spec", "conversion", "expressions[0]", "fieldMappings[0]", "expression"),
+				duplicate("spec", "conversion", "expressions[0]", "fieldMappings[1]", "path"),
+				invalid("spec", "conversion", "expressions[0]", "fieldMappings[2]", "path"),
+				invalid("spec", "conversion", "expressions[0]", "fieldMappings[3]", "path"),
+				required("spec", "conversion", "expressions[0]", "fieldMappings[3]", "expression"),
+				invalid("spec", "conversion", "expressions[1]", "toVersion"),
+				duplicate("spec", "conversion", "expressions[1]"),
+				required("spec", "conversion", "expressions[1]", "fieldMappings"),
+				unsupported("spec", "conversion", "expressions[2]", "fromVersion"),
+				required("spec", "conversion", "expressions[2]", "toVersion"),
+			},
+		},
+		{
+			name: "conversion expressions without expression strategy",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "v1",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{Name: "v1", Served: true, Storage: true},
+						{Name: "v2", Served: true, Storage: false},
+					},
+					Conversion: &apiextensions.CustomResourceConversion{
+						Strategy: apiextensions.NoneConverter,
+						Expressions: []apiextensions.ConversionExpression{
+							{
+								FromVersion: "v1",
+								ToVersion:   "v2",
+								FieldMappings: []apiextensions.ConversionFieldMapping{
+									{Path: "spec.replicas", Expression: "self.spec.size"},
+								},
+							},
+						},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"v1"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				forbidden("spec", "conversion", "expressions"),
+			},
+		},
+		{
+			name: "webhook without conversion review versions",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Conversion: &apiextensions.CustomResourceConversion{
+						Strategy: apiextensions.WebhookConverter,
+						WebhookClientConfig: &apiextensions.WebhookClientConfig{
+							URL: strPtr("https://example.com/convert"),
+						},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				required("spec", "conversion", "conversionReviewVersions"),
+			},
+		},
+		{
+			name: "webhook with bad conversion review versions",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Conversion: &apiextensions.CustomResourceConversion{
+						Strategy:                 apiextensions.WebhookConverter,
+						ConversionReviewVersions: []string{"v2", "v2", "Bad"},
+						WebhookClientConfig: &apiextensions.WebhookClientConfig{
+							URL: strPtr("https://example.com/convert"),
+						},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				duplicate("spec", "conversion", "conversionReviewVersions[1]"),
+				invalid("spec", "conversion", "conversionReviewVersions[2]"),
+				invalid("spec", "conversion", "conversionReviewVersions"),
+			},
+		},
+		{
+			name: "webhook with an unsupported conversion review version first",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Conversion: &apiextensions.CustomResourceConversion{
+						Strategy:                 apiextensions.WebhookConverter,
+						ConversionReviewVersions: []string{"v2", "v1"},
+						WebhookClientConfig: &apiextensions.WebhookClientConfig{
+							URL: strPtr("https://example.com/convert"),
+						},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{},
+		},
+		{
+			name: "conversion review versions without webhook",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Conversion: &apiextensions.CustomResourceConversion{
+						Strategy:                 apiextensions.NoneConverter,
+						ConversionReviewVersions: []string{"v1beta1"},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				forbidden("spec", "conversion", "conversionReviewVersions"),
+			},
+		},
+		{
+			name: "missing scale paths",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Subresources: &apiextensions.CustomResourceSubresources{
+						Scale: &apiextensions.CustomResourceSubresourceScale{},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				required("spec", "subresources", "scale", "specReplicasPath"),
+				required("spec", "subresources", "scale", "statusReplicasPath"),
+			},
+		},
+		{
+			name: "bad scale paths",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Subresources: &apiextensions.CustomResourceSubresources{
+						Scale: &apiextensions.CustomResourceSubresourceScale{
+							SpecReplicasPath:   ".status.replicas",
+							StatusReplicasPath: ".status.replicas[0]",
+							LabelSelectorPath:  strPtr(".metadata.labels"),
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				invalid("spec", "subresources", "scale", "specReplicasPath"),
+				invalid("spec", "subresources", "scale", "statusReplicasPath"),
+				invalid("spec", "subresources", "scale", "labelSelectorPath"),
+			},
+		},
+		{
+			name: "bad custom subresources",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Subresources: &apiextensions.CustomResourceSubresources{
+						Custom: []apiextensions.CustomResourceSubresourceCustom{
+							{Name: "approve", FieldPaths: []string{".spec.approved", ".status.approvedBy"}, Verbs: []string{"get", "update", "patch"}},
+							{Name: "approve", FieldPaths: []string{".spec.approved"}, Verbs: []string{"update"}},
+							{Name: "status", FieldPaths: []string{".metadata.labels", ".spec.items[0]"}, Verbs: []string{"delete"}},
+							{Name: "Bind", FieldPaths: []string{".spec.node", ".spec.node"}, Verbs: []string{"patch", "patch"}},
+							{},
+							{
+								Name:       "validated",
+								FieldPaths: []string{".spec.approved"},
+								Verbs:      []string{"update"},
+								Validation: &apiextensions.CustomResourceValidation{
+									OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+										Type:         "object",
+										XValidations: apiextensions.ValidationRules{{Rule: "self.spec.approved =="}},
+									},
+								},
+							},
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				duplicate("spec", "subresources", "custom[1]", "name"),
+				invalid("spec", "subresources", "custom[2]", "name"),
+				invalid("spec", "subresources", "custom[2]", "fieldPaths[0]"),
+				invalid("spec", "subresources", "custom[2]", "fieldPaths[1]"),
+				unsupported("spec", "subresources", "custom[2]", "verbs[0]"),
+				invalid("spec", "subresources", "custom[3]", "name"),
+				duplicate("spec", "subresources", "custom[3]", "fieldPaths[1]"),
+				duplicate("spec", "subresources", "custom[3]", "verbs[1]"),
+				required("spec", "subresources", "custom[4]", "name"),
+				required("spec", "subresources", "custom[4]", "fieldPaths"),
+				required("spec", "subresources", "custom[4]", "verbs"),
+				invalid("spec", "subresources", "custom[5]", "validation", "openAPIV3Schema", "x-kubernetes-validations[0]", "rule"),
+			},
+		},
+		{
+			name: "bad allowed finalizer domains",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					AllowedFinalizerDomains: []string{"example.com", "Example_com", "example.com"},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				invalid("spec", "allowedFinalizerDomains[1]"),
+				duplicate("spec", "allowedFinalizerDomains[2]"),
+			},
+		},
+		{
+			name: "initial status",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"status": {
+									Type:     "object",
+									Required: []string{"phase", "replicas"},
+									Properties: map[string]apiextensions.JSONSchemaProps{
+										"phase":    {Type: "string", Enum: []apiextensions.JSON{"Pending", "Running"}},
+										"replicas": {Type: "integer", Default: jsonPtr(int64(0))},
+									},
+								},
+							},
+						},
+					},
+					Subresources: &apiextensions.CustomResourceSubresources{
+						Status: &apiextensions.CustomResourceSubresourceStatus{
+							InitialStatus: jsonPtr(map[string]interface{}{"phase": "Pending"}),
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{},
+		},
+		{
+			name: "initial status not an object",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"status": {
+									Type:     "object",
+									Required: []string{"phase", "replicas"},
+									Properties: map[string]apiextensions.JSONSchemaProps{
+										"phase":    {Type: "string", Enum: []apiextensions.JSON{"Pending", "Running"}},
+										"replicas": {Type: "integer", Default: jsonPtr(int64(0))},
+									},
+								},
+							},
+						},
+					},
+					Subresources: &apiextensions.CustomResourceSubresources{
+						Status: &apiextensions.CustomResourceSubresourceStatus{
+							InitialStatus: jsonPtr("Pending"),
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				invalid("spec", "subresources", "status", "initialStatus"),
+			},
+		},
+		{
+			name: "initial status not matching the status schema",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"status": {
+									Type:     "object",
+									Required: []string{"phase", "replicas"},
+									Properties: map[string]apiextensions.JSONSchemaProps{
+										"phase":    {Type: "string", Enum: []apiextensions.JSON{"Pending", "Running"}},
+										"replicas": {Type: "integer", Default: jsonPtr(int64(0))},
+									},
+								},
+							},
+						},
+					},
+					Subresources: &apiextensions.CustomResourceSubresources{
+						Status: &apiextensions.CustomResourceSubresourceStatus{
+							InitialStatus: jsonPtr(map[string]interface{}{"phase": "Unknown"}),
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				unsupported("spec", "subresources", "status", "initialStatus", "phase"),
+			},
+		},
+		{
+			name: "example",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "version",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{
+							Name:    "version",
+							Served:  true,
+							Storage: true,
+							Example: jsonPtr(map[string]interface{}{
+								"apiVersion": "group.com/version",
+								"metadata":   map[string]interface{}{"name": "example"},
+								"spec":       map[string]interface{}{"mode": "Fast"},
+							}),
+						},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"spec": {
+									Type:     "object",
+									Required: []string{"mode", "replicas"},
+									Properties: map[string]apiextensions.JSONSchemaProps{
+										"mode":     {Type: "string", Enum: []apiextensions.JSON{"Fast", "Safe"}},
+										"replicas": {Type: "integer", Default: jsonPtr(int64(1))},
+									},
+								},
+							},
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{},
+		},
+		{
+			name: "example not an object",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "version",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{
+							Name:    "version",
+							Served:  true,
+							Storage: true,
+							Example: jsonPtr("Fast"),
+						},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"spec": {
+									Type:     "object",
+									Required: []string{"mode", "replicas"},
+									Properties: map[string]apiextensions.JSONSchemaProps{
+										"mode":     {Type: "string", Enum: []apiextensions.JSON{"Fast", "Safe"}},
+										"replicas": {Type: "integer", Default: jsonPtr(int64(1))},
+									},
+								},
+							},
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				invalid("spec", "versions[0]", "example"),
+			},
+		},
+		{
+			name: "example of another kind not matching the schema",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "version",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{
+							Name:    "version",
+							Served:  true,
+							Storage: true,
+							Example: jsonPtr(map[string]interface{}{
+								"apiVersion": "group.com/other",
+								"kind":       "Other",
+								"metadata":   "example",
+								"spec":       map[string]interface{}{"mode": "Slow"},
+							}),
+						},
+					},
+					Scope: apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"spec": {
+									Type:     "object",
+									Required: []string{"mode", "replicas"},
+									Properties: map[string]apiextensions.JSONSchemaProps{
+										"mode":     {Type: "string", Enum: []apiextensions.JSON{"Fast", "Safe"}},
+										"replicas": {Type: "integer", Default: jsonPtr(int64(1))},
+									},
+								},
+							},
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				invalid("spec", "versions[0]", "example", "apiVersion"),
+				invalid("spec", "versions[0]", "example", "kind"),
+				invalid("spec", "versions[0]", "example", "metadata"),
+				unsupported("spec", "versions[0]", "example", "spec", "mode"),
+			},
+		},
+		{
+			name: "scale",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Subresources: &apiextensions.CustomResourceSubresources{
+						Scale: &apiextensions.CustomResourceSubresourceScale{
+							SpecReplicasPath:   ".spec.replicas",
+							StatusReplicasPath: ".status.replicas",
+							LabelSelectorPath:  strPtr(".status.selector"),
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{},
+		},
+		{
+			name: "bad printer columns",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					AdditionalPrinterColumns: []apiextensions.CustomResourceColumnDefinition{
+						{Type: "string", JSONPath: ".spec.foo"},
+						{Name: "Foo", JSONPath: ".spec.foo"},
+						{Name: "Foo", Type: "object", Format: "uuid", Priority: -1, JSONPath: ".spec.foo"},
+						{Name: "Foo", Type: "string"},
+						{Name: "Foo", Type: "string", JSONPath: "spec.foo"},
+						{Name: "Foo", Type: "string", JSONPath: ".spec.foo[0]"},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				required("spec", "additionalPrinterColumns[0]", "name"),
+				required("spec", "additionalPrinterColumns[1]", "type"),
+				unsupported("spec", "additionalPrinterColumns[2]", "type"),
+				unsupported("spec", "additionalPrinterColumns[2]", "format"),
+				invalid("spec", "additionalPrinterColumns[2]", "priority"),
+				required("spec", "additionalPrinterColumns[3]", "JSONPath"),
+				invalid("spec", "additionalPrinterColumns[4]", "JSONPath"),
+				invalid("spec", "additionalPrinterColumns[5]", "JSONPath"),
+			},
+		},
+		{
+			name: "bad selectable fields",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"spec": {
+									Type: "object",
+									Properties: map[string]apiextensions.JSONSchemaProps{
+										"color":    {Type: "string"},
+										"replicas": {Type: "integer"},
+										"tags":     {Type: "array", Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string"}}},
+									},
+								},
+								"status": {
+									Type: "object",
+									Properties: map[string]apiextensions.JSONSchemaProps{
+										"ready": {Type: "boolean"},
+									},
+								},
+							},
+						},
+					},
+					SelectableFields: []apiextensions.SelectableField{
+						{},
+						{JSONPath: ".metadata.name"},
+						{JSONPath: ".spec.color"},
+						{JSONPath: ".spec.color"},
+						{JSONPath: ".spec.size"},
+						{JSONPath: ".spec.tags"},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				required("spec", "selectableFields[0]", "jsonPath"),
+				invalid("spec", "selectableFields[1]", "jsonPath"),
+				duplicate("spec", "selectableFields[3]", "jsonPath"),
+				invalid("spec", "selectableFields[4]", "jsonPath"),
+				invalid("spec", "selectableFields[5]", "jsonPath"),
+			},
+		},
+		{
+			name: "selectable fields",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"spec": {
+									Type: "object",
+									Properties: map[string]apiextensions.JSONSchemaProps{
+										"color":    {Type: "string"},
+										"replicas": {Type: "integer"},
+										"tags":     {Type: "array", Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string"}}},
+									},
+								},
+								"status": {
+									Type: "object",
+									Properties: map[string]apiextensions.JSONSchemaProps{
+										"ready": {Type: "boolean"},
+									},
+								},
+							},
+						},
+					},
+					SelectableFields: []apiextensions.SelectableField{
+						{JSONPath: ".spec.color"},
+						{JSONPath: ".spec.replicas"},
+						{JSONPath: ".status.ready"},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{},
+		},
+		{
+			name: "printer columns",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					AdditionalPrinterColumns: []apiextensions.CustomResourceColumnDefinition{
+						{Name: "Replicas", Type: "integer", Format: "int32", Priority: 1, JSONPath: ".spec.replicas"},
+						{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{},
+		},
+		{
+			name: "missing storage prefix",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Storage: &apiextensions.CustomResourceStorage{Prefix: ""},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				required("spec", "storage", "prefix"),
+			},
+		},
+		{
+			name: "absolute storage prefix",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Storage: &apiextensions.CustomResourceStorage{Prefix: "/isolated/plural"},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				invalid("spec", "storage", "prefix"),
+			},
+		},
+		{
+			name: "bad storage prefix segments",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Storage: &apiextensions.CustomResourceStorage{Prefix: "isolated/../Plural"},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				invalid("spec", "storage", "prefix"),
+				invalid("spec", "storage", "prefix"),
+			},
+		},
+		{
+			name: "storage prefix",
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "plural.group.com"},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Storage: &apiextensions.CustomResourceStorage{Prefix: "isolated/group.com/plural"},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{},
+		},
+	}
+
+	for _, tc := range tests {
+		errs := ValidateCustomResourceDefinition(tc.resource)
+		seenErrs := make([]bool, len(errs))
+
+		for _, expectedError := range tc.errors {
+			found := false
+			for i, err := range errs {
+				if expectedError.matches(err) && !seenErrs[i] {
+					found = true
+					seenErrs[i] = true
+					break
+				}
+			}
+
+			if !found {
+				t.Errorf("%s: expected %v at %v, got %v", tc.name, expectedError.errorType, expectedError.path.String(), errs)
+			}
+		}
+
+		for i, seen := range seenErrs {
+			if !seen {
+				t.Errorf("%s: unexpected error: %v", tc.name, errs[i])
+			}
+		}
+	}
+}
+
+func TestValidateCustomResourceDefinitionUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		old      *apiextensions.CustomResourceDefinition
+		resource *apiextensions.CustomResourceDefinition
+		errors   []validationMatch
+	}{
+		{
+			name: "unchanged",
+			old: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.ResourceScope("Cluster"),
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+				},
+			},
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.ResourceScope("Cluster"),
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+				},
+			},
+			errors: []validationMatch{},
+		},
+		{
+			name: "unchanged-established",
+			old: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.ResourceScope("Cluster"),
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+					Conditions: []apiextensions.CustomResourceDefinitionCondition{
+						{Type: apiextensions.Established, Status: apiextensions.ConditionTrue},
+					},
+				},
+			},
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.ResourceScope("Cluster"),
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+				},
+			},
+			errors: []validationMatch{},
+		},
+		{
+			name: "changes",
+			old: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.ResourceScope("Cluster"),
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+					Conditions: []apiextensions.CustomResourceDefinitionCondition{
+						{Type: apiextensions.Established, Status: apiextensions.ConditionFalse},
+					},
+				},
+			},
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "abc.com",
+					Version: "version2",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{Name: "version2", Served: true, Storage: true},
+					},
+					Scope: apiextensions.ResourceScope("Namespaced"),
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural2",
+						Singular: "singular2",
+						Kind:     "kind2",
+						ListKind: "listkind2",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version2"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural2",
+						Singular: "singular2",
+						Kind:     "kind2",
+						ListKind: "listkind2",
+					},
+				},
+			},
+			errors: []validationMatch{
+				immutable("spec", "group"),
+				immutable("spec", "names", "plural"),
+			},
+		},
+		{
+			name: "changes-established",
+			old: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.ResourceScope("Cluster"),
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+					Conditions: []apiextensions.CustomResourceDefinitionCondition{
+						{Type: apiextensions.Established, Status: apiextensions.ConditionTrue},
+					},
+				},
+			},
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "abc.com",
+					Version: "version2",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{Name: "version2", Served: true, Storage: true},
+					},
+					Scope: apiextensions.ResourceScope("Namespaced"),
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural2",
+						Singular: "singular2",
+						Kind:     "kind2",
+						ListKind: "listkind2",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version2"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural2",
+						Singular: "singular2",
+						Kind:     "kind2",
+						ListKind: "listkind2",
+					},
+				},
+			},
+			errors: []validationMatch{
+				immutable("spec", "group"),
+				immutable("spec", "version"),
+				immutable("spec", "names", "kind"),
+				immutable("spec", "names", "plural"),
+			},
+		},
+		{
+			name: "non-structural schema stays allowed",
+			old: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"spec": {Type: "object"},
+							},
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"spec":   {Type: "object"},
+								"status": {},
+							},
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{},
+		},
+		{
+			name: "structural schema must stay structural",
+			old: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"spec": {Type: "object"},
+							},
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"spec":   {Type: "object"},
+								"status": {},
+							},
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				required("spec", "validation", "openAPIV3Schema", "type"),
+				required("spec", "validation", "openAPIV3Schema", "properties[status]", "type"),
+			},
+		},
+		{
+			name: "metadata restrictions stay allowed",
+			old: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"metadata": {Type: "object", Required: []string{"name"}},
+								"spec":     {Type: "object"},
+							},
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"metadata": {Type: "object", Required: []string{"name"}},
+								"spec":     {Type: "object"},
+							},
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{},
+		},
+		{
+			name: "metadata must stay unrestricted",
+			old: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"metadata": {Type: "object"},
+								"spec":     {Type: "object"},
+							},
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.NamespaceScoped,
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+					Validation: &apiextensions.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"metadata": {Type: "object", Required: []string{"name"}},
+								"spec":     {Type: "object"},
+							},
+						},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "Plural",
+						ListKind: "PluralList",
+					},
+				},
+			},
+			errors: []validationMatch{
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[metadata]"),
+			},
+		},
+		{
+			name: "change storage version",
+			old: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "version",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{Name: "version", Served: true, Storage: true},
+						{Name: "version2", Served: true, Storage: false},
+					},
+					Scope: apiextensions.ResourceScope("Cluster"),
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+					Conditions: []apiextensions.CustomResourceDefinitionCondition{
+						{Type: apiextensions.Established, Status: apiextensions.ConditionTrue},
+					},
+				},
+			},
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "version",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{Name: "version", Served: true, Storage: false},
+						{Name: "version2", Served: true, Storage: true},
+					},
+					Scope: apiextensions.ResourceScope("Cluster"),
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version", "version2"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+					Conditions: []apiextensions.CustomResourceDefinitionCondition{
+						{Type: apiextensions.Established, Status: apiextensions.ConditionTrue},
+					},
+				},
+			},
+			errors: []validationMatch{},
+		},
+		{
+			name: "remove stored version",
+			old: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "version",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{Name: "version", Served: true, Storage: true},
+						{Name: "version2", Served: true, Storage: false},
+					},
+					Scope: apiextensions.ResourceScope("Cluster"),
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version", "version2"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+					Conditions: []apiextensions.CustomResourceDefinitionCondition{
+						{Type: apiextensions.Established, Status: apiextensions.ConditionTrue},
+					},
+				},
+			},
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "version",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{Name: "version", Served: true, Storage: true},
+					},
+					Scope: apiextensions.ResourceScope("Cluster"),
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version", "version2"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+					Conditions: []apiextensions.CustomResourceDefinitionCondition{
+						{Type: apiextensions.Established, Status: apiextensions.ConditionTrue},
+					},
+				},
+			},
+			errors: []validationMatch{
+				invalid("status", "storedVersions[1]"),
+			},
+		},
+		{
+			name: "storage version not stored",
+			old: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "version",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{Name: "version", Served: true, Storage: true},
+						{Name: "version2", Served: true, Storage: false},
+					},
+					Scope: apiextensions.ResourceScope("Cluster"),
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+					Conditions: []apiextensions.CustomResourceDefinitionCondition{
+						{Type: apiextensions.Established, Status: apiextensions.ConditionTrue},
+					},
+				},
+			},
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:   "group.com",
+					Version: "version",
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{Name: "version", Served: true, Storage: false},
+						{Name: "version2", Served: true, Storage: true},
+					},
+					Scope: apiextensions.ResourceScope("Cluster"),
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+					Conditions: []apiextensions.CustomResourceDefinitionCondition{
+						{Type: apiextensions.Established, Status: apiextensions.ConditionTrue},
+					},
+				},
+			},
+			errors: []validationMatch{
+				invalid("status", "storedVersions"),
+			},
+		},
+		{
+			name: "change storage prefix",
+			old: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.ResourceScope("Cluster"),
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+					Storage: &apiextensions.CustomResourceStorage{Prefix: "isolated/plural"},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+				},
+			},
+			resource: &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "plural.group.com",
+					ResourceVersion: "42",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group:    "group.com",
+					Version:  "version",
+					Versions: singleVersionList,
+					Scope:    apiextensions.ResourceScope("Cluster"),
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+					Storage: &apiextensions.CustomResourceStorage{Prefix: "other/plural"},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"version"},
+					AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+						Plural:   "plural",
+						Singular: "singular",
+						Kind:     "kind",
+						ListKind: "listkind",
+					},
+				},
+			},
+			errors: []validationMatch{
+				immutable("spec", "storage"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		errs := ValidateCustomResourceDefinitionUpdate(tc.resource, tc.old)
+		seenErrs := make([]bool, len(errs))
+
+		for _, expectedError := range tc.errors {
+			found := false
+			for i, err := range errs {
+				if expectedError.matches(err) && !seenErrs[i] {
+					found = true
+					seenErrs[i] = true
+					break
+				}
+			}
+
+			if !found {
+				t.Errorf("%s: expected %v at %v, got %v", tc.name, expectedError.errorType, expectedError.path.String(), errs)
+			}
+		}
+
+		for i, seen := range seenErrs {
+			if !seen {
+				t.Errorf("%s: unexpected error: %v", tc.name, errs[i])
+			}
+		}
+	}
+}
+
+func TestValidateCustomResourceStorageAllowed(t *testing.T) {
+	tests := []struct {
+		name            string
+		storage         *apiextensions.CustomResourceStorage
+		allowedPrefixes []string
+		errors          []validationMatch
+	}{
+		{
+			name:   "default storage",
+			errors: []validationMatch{},
+		},
+		{
+			name:    "no allowed prefixes",
+			storage: &apiextensions.CustomResourceStorage{Prefix: "isolated/plural"},
+			errors: []validationMatch{
+				forbidden("spec", "storage"),
+			},
+		},
+		{
+			name:            "allowed prefix",
+			storage:         &apiextensions.CustomResourceStorage{Prefix: "isolated/plural"},
+			allowedPrefixes: []string{"other", "isolated"},
+			errors:          []validationMatch{},
+		},
+		{
+			name:            "exact allowed prefix",
+			storage:         &apiextensions.CustomResourceStorage{Prefix: "isolated"},
+			allowedPrefixes: []string{"isolated"},
+			errors:          []validationMatch{},
+		},
+		{
+			name:            "not allowed prefix",
+			storage:         &apiextensions.CustomResourceStorage{Prefix: "isolated-other/plural"},
+			allowedPrefixes: []string{"isolated"},
+			errors: []validationMatch{
+				forbidden("spec", "storage", "prefix"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		errs := ValidateCustomResourceStorageAllowed(tc.storage, tc.allowedPrefixes, field.NewPath("spec", "storage"))
+		seenErrs := make([]bool, len(errs))
+
+		for _, expectedError := range tc.errors {
+			found := false
+			for i, err := range errs {
+				if expectedError.matches(err) && !seenErrs[i] {
+					found = true
+					seenErrs[i] = true
+					break
+				}
+			}
+
+			if !found {
+				t.Errorf("%s: expected %v at %v, got %v", tc.name, expectedError.errorType, expectedError.path.String(), errs)
+			}
+		}
+
+		for i, seen := range seenErrs {
+			if !seen {
+				t.Errorf("%s: unexpected error: %v", tc.name, errs[i])
+			}
+		}
+	}
+}
+
+func TestValidateLimitAnnotations(t *testing.T) {
+	annotationsPath := field.NewPath("metadata", "annotations")
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		errors      []validationMatch
+	}{
+		{
+			name:   "no limits",
+			errors: []validationMatch{},
+		},
+		{
+			name: "valid limits",
+			annotations: map[string]string{
+				apiextensions.MaxCustomResourcesAnnotation:     "100",
+				apiextensions.MaxCustomResourceBytesAnnotation: "0",
+			},
+			errors: []validationMatch{},
+		},
+		{
+			name: "invalid limits",
+			annotations: map[string]string{
+				apiextensions.MaxCustomResourcesAnnotation:     "-1",
+				apiextensions.MaxCustomResourceBytesAnnotation: "1Ki",
+			},
+			errors: []validationMatch{
+				{path: annotationsPath.Key(apiextensions.MaxCustomResourcesAnnotation), errorType: field.ErrorTypeInvalid},
+				{path: annotationsPath.Key(apiextensions.MaxCustomResourceBytesAnnotation), errorType: field.ErrorTypeInvalid},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		errs := validateLimitAnnotations(tc.annotations, annotationsPath)
+		seenErrs := make([]bool, len(errs))
+
+		for _, expectedError := range tc.errors {
+			found := false
+			for i, err := range errs {
+				if expectedError.matches(err) && !seenErrs[i] {
+					found = true
+					seenErrs[i] = true
+					break
+				}
+			}
+
+			if !found {
+				t.Errorf("%s: expected %v at %v, got %v", tc.name, expectedError.errorType, expectedError.path.String(), errs)
+			}
+		}
+
+		for i, seen := range seenErrs {
+			if !seen {
+				t.Errorf("%s: unexpected error: %v", tc.name, errs[i])
+			}
+		}
+	}
+}
+
+func TestValidateRequestLoggingAnnotations(t *testing.T) {
+	annotationsPath := field.NewPath("metadata", "annotations")
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		errors      []validationMatch
+	}{
+		{
+			name:   "no request logging",
+			errors: []validationMatch{},
+		},
+		{
+			name: "valid request logging",
+			annotations: map[string]string{
+				apiextensions.RequestLogVerbosityAnnotation:  "0",
+				apiextensions.SlowRequestThresholdAnnotation: "500ms",
+			},
+			errors: []validationMatch{},
+		},
+		{
+			name: "invalid request logging",
+			annotations: map[string]string{
+				apiextensions.RequestLogVerbosityAnnotation:  "-1",
+				apiextensions.SlowRequestThresholdAnnotation: "500",
+			},
+			errors: []validationMatch{
+				{path: annotationsPath.Key(apiextensions.RequestLogVerbosityAnnotation), errorType: field.ErrorTypeInvalid},
+				{path: annotationsPath.Key(apiextensions.SlowRequestThresholdAnnotation), errorType: field.ErrorTypeInvalid},
+			},
+		},
+		{
+			name: "non-positive slow request threshold",
+			annotations: map[string]string{
+				apiextensions.RequestLogVerbosityAnnotation:  "high",
+				apiextensions.SlowRequestThresholdAnnotation: "0s",
+			},
+			errors: []validationMatch{
+				{path: annotationsPath.Key(apiextensions.RequestLogVerbosityAnnotation), errorType: field.ErrorTypeInvalid},
+				{path: annotationsPath.Key(apiextensions.SlowRequestThresholdAnnotation), errorType: field.ErrorTypeInvalid},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		errs := validateRequestLoggingAnnotations(tc.annotations, annotationsPath)
+		seenErrs := make([]bool, len(errs))
+
+		for _, expectedError := range tc.errors {
+			found := false
+			for i, err := range errs {
+				if expectedError.matches(err) && !seenErrs[i] {
+					found = true
+					seenErrs[i] = true
+					break
+				}
+			}
+
+			if !found {
+				t.Errorf("%s: expected %v at %v, got %v", tc.name, expectedError.errorType, expectedError.path.String(), errs)
+			}
+		}
+
+		for i, seen := range seenErrs {
+			if !seen {
+				t.Errorf("%s: unexpected error: %v", tc.name, errs[i])
+			}
+		}
+	}
+}
+
+func TestValidateScalarCoercionAnnotation(t *testing.T) {
+	annotationsPath := field.NewPath("metadata", "annotations")
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		errors      []validationMatch
+	}{
+		{
+			name:   "no coercion",
+			errors: []validationMatch{},
+		},
+		{
+			name:        "coercion enabled",
+			annotations: map[string]string{apiextensions.ScalarCoercionAnnotation: "true"},
+			errors:      []validationMatch{},
+		},
+		{
+			name:        "coercion disabled",
+			annotations: map[string]string{apiextensions.ScalarCoercionAnnotation: "false"},
+			errors:      []validationMatch{},
+		},
+		{
+			name:        "invalid coercion",
+			annotations: map[string]string{apiextensions.ScalarCoercionAnnotation: "yes"},
+			errors: []validationMatch{
+				{path: annotationsPath.Key(apiextensions.ScalarCoercionAnnotation), errorType: field.ErrorTypeNotSupported},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		errs := validateScalarCoercionAnnotation(tc.annotations, annotationsPath)
+		seenErrs := make([]bool, len(errs))
+
+		for _, expectedError := range tc.errors {
+			found := false
+			for i, err := range errs {
+				if expectedError.matches(err) && !seenErrs[i] {
+					found = true
+					seenErrs[i] = true
+					break
+				}
+			}
+
+			if !found {
+				t.Errorf("%s: expected %v at %v, got %v", tc.name, expectedError.errorType, expectedError.path.String(), errs)
+			}
+		}
+
+		for i, seen := range seenErrs {
+			if !seen {
+				t.Errorf("%s: unexpected error: %v", tc.name, errs[i])
+			}
+		}
+	}
+}
+
+func TestValidateAPIApproval(t *testing.T) {
+	newCRD := func(group, approval string) *apiextensions.CustomResourceDefinition {
+		crd := &apiextensions.CustomResourceDefinition{
+			Spec: apiextensions.CustomResourceDefinitionSpec{Group: group},
+		}
+		if len(approval) > 0 {
+			crd.Annotations = map[string]string{apiextensions.KubernetesAPIApprovedAnnotation: approval}
+		}
+		return crd
+	}
+	approvalPath := field.NewPath("metadata", "annotations").Key(apiextensions.KubernetesAPIApprovedAnnotation)
+	missing := validationMatch{path: approvalPath, errorType: field.ErrorTypeRequired}
+	invalidApproval := validationMatch{path: approvalPath, errorType: field.ErrorTypeInvalid}
+
+	tests := []struct {
+		name   string
+		crd    *apiextensions.CustomResourceDefinition
+		oldCRD *apiextensions.CustomResourceDefinition
+		errors []validationMatch
+	}{
+		{
+			name:   "unprotected group",
+			crd:    newCRD("example.com", ""),
+			errors: []validationMatch{},
+		},
+		{
+			name:   "similar unprotected group",
+			crd:    newCRD("notk8s.io", ""),
+			errors: []validationMatch{},
+		},
+		{
+			name:   "missing annotation",
+			crd:    newCRD("sigs.k8s.io", ""),
+			errors: []validationMatch{missing},
+		},
+		{
+			name:   "invalid annotation",
+			crd:    newCRD("kubernetes.io", "approved"),
+			errors: []validationMatch{invalidApproval},
+		},
+		{
+			name:   "approved",
+			crd:    newCRD("sigs.k8s.io", "https://github.com/kubernetes/kubernetes/pull/78458"),
+			errors: []validationMatch{},
+		},
+		{
+			name:   "unapproved",
+			crd:    newCRD("x.kubernetes.io", "unapproved, experimental"),
+			errors: []validationMatch{},
+		},
+		{
+			name:   "update with unchanged missing annotation",
+			crd:    newCRD("sigs.k8s.io", ""),
+			oldCRD: newCRD("sigs.k8s.io", ""),
+			errors: []validationMatch{},
+		},
+		{
+			name:   "update with unchanged invalid annotation",
+			crd:    newCRD("sigs.k8s.io", "approved"),
+			oldCRD: newCRD("sigs.k8s.io", "approved"),
+			errors: []validationMatch{},
+		},
+		{
+			name:   "update changing an invalid annotation",
+			crd:    newCRD("sigs.k8s.io", "still approved"),
+			oldCRD: newCRD("sigs.k8s.io", "approved"),
+			errors: []validationMatch{invalidApproval},
+		},
+		{
+			name:   "update removing the annotation",
+			crd:    newCRD("sigs.k8s.io", ""),
+			oldCRD: newCRD("sigs.k8s.io", "https://github.com/kubernetes/kubernetes/pull/78458"),
+			errors: []validationMatch{missing},
+		},
+	}
+
+	for _, tc := range tests {
+		errs := ValidateAPIApproval(tc.crd, tc.oldCRD)
+		seenErrs := make([]bool, len(errs))
+
+		for _, expectedError := range tc.errors {
+			found := false
+			for i, err := range errs {
+				if expectedError.matches(err) && !seenErrs[i] {
+					found = true
+					seenErrs[i] = true
+					break
+				}
+			}
+
+			if !found {
+				t.Errorf("%s: expected %v at %v, got %v", tc.name, expectedError.errorType, expectedError.path.String(), errs)
+			}
+		}
+
+		for i, seen := range seenErrs {
+			if !seen {
+				t.Errorf("%s: unexpected error: %v", tc.name, errs[i])
+			}
+		}
+	}
+}
+
+func TestValidateCustomResourceDefinitionSchemaLimits(t *testing.T) {
+	schema := func(schema *apiextensions.JSONSchemaProps) *apiextensions.CustomResourceValidation {
+		return &apiextensions.CustomResourceValidation{OpenAPIV3Schema: schema}
+	}
+	nested := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"replicas": {Type: "integer"},
+					"hosts":    {Type: "array", Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string"}}},
+				},
+				XValidations: apiextensions.ValidationRules{{Rule: "self.hosts.all(h, h.size() < 64)"}},
+			},
+		},
+	}
+	// the definition is nested three levels deep wherever it is referenced
+	withReferences := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"spec":   {Ref: strPtr("#/definitions/spec")},
+			"status": {Ref: strPtr("#/definitions/spec")},
+		},
+		Definitions: apiextensions.JSONSchemaDefinitions{"spec": nested.Properties["spec"]},
+	}
+	tests := []struct {
+		name    string
+		spec    *apiextensions.CustomResourceDefinitionSpec
+		oldSpec *apiextensions.CustomResourceDefinitionSpec
+		limits  SchemaLimits
+		errors  []validationMatch
+	}{
+		{
+			name:   "no limits",
+			spec:   &apiextensions.CustomResourceDefinitionSpec{Validation: schema(nested)},
+			errors: []validationMatch{},
+		},
+		{
+			name:   "within limits",
+			spec:   &apiextensions.CustomResourceDefinitionSpec{Validation: schema(nested)},
+			limits: SchemaLimits{MaxBytes: 1024, MaxDepth: 4, MaxProperties: 3, MaxRuleCost: 100, RuleCostBudget: 100},
+			errors: []validationMatch{},
+		},
+		{
+			name:   "too large",
+			spec:   &apiextensions.CustomResourceDefinitionSpec{Validation: schema(nested)},
+			limits: SchemaLimits{MaxBytes: 64},
+			errors: []validationMatch{
+				forbidden("spec", "validation", "openAPIV3Schema"),
+			},
+		},
+		{
+			name:   "too deep with references",
+			spec:   &apiextensions.CustomResourceDefinitionSpec{Validation: schema(withReferences)},
+			limits: SchemaLimits{MaxDepth: 3},
+			errors: []validationMatch{
+				forbidden("spec", "validation", "openAPIV3Schema"),
+			},
+		},
+		{
+			name:   "too many properties with references",
+			spec:   &apiextensions.CustomResourceDefinitionSpec{Validation: schema(withReferences)},
+			limits: SchemaLimits{MaxProperties: 5},
+			errors: []validationMatch{
+				forbidden("spec", "validation", "openAPIV3Schema"),
+			},
+		},
+		{
+			name:   "too deep",
+			spec:   &apiextensions.CustomResourceDefinitionSpec{Validation: schema(nested)},
+			limits: SchemaLimits{MaxDepth: 3},
+			errors: []validationMatch{
+				forbidden("spec", "validation", "openAPIV3Schema"),
+			},
+		},
+		{
+			name:   "too many properties",
+			spec:   &apiextensions.CustomResourceDefinitionSpec{Validation: schema(nested)},
+			limits: SchemaLimits{MaxProperties: 2},
+			errors: []validationMatch{
+				forbidden("spec", "validation", "openAPIV3Schema"),
+			},
+		},
+		{
+			name:   "too expensive rules",
+			spec:   &apiextensions.CustomResourceDefinitionSpec{Validation: schema(nested)},
+			limits: SchemaLimits{MaxRuleCost: 10},
+			errors: []validationMatch{
+				forbidden("spec", "validation", "openAPIV3Schema", "x-kubernetes-validations"),
+			},
+		},
+		{
+			name:   "rule exceeding the runtime cost budget",
+			spec:   &apiextensions.CustomResourceDefinitionSpec{Validation: schema(nested)},
+			limits: SchemaLimits{RuleCostBudget: 10},
+			errors: []validationMatch{
+				{path: field.NewPath("spec", "validation", "openAPIV3Schema", "properties").Key("spec").Child("x-kubernetes-validations").Index(0).Child("rule"), errorType: field.ErrorTypeForbidden},
+			},
+		},
+		{
+			name: "per-version schema",
+			spec: &apiextensions.CustomResourceDefinitionSpec{
+				Versions: []apiextensions.CustomResourceDefinitionVersion{
+					{Name: "v1", Schema: schema(&apiextensions.JSONSchemaProps{Type: "object"})},
+					{Name: "v2", Schema: schema(nested)},
+				},
+			},
+			limits: SchemaLimits{MaxDepth: 3},
+			errors: []validationMatch{
+				{path: field.NewPath("spec", "versions").Index(1).Child("schema", "openAPIV3Schema"), errorType: field.ErrorTypeForbidden},
+			},
+		},
+		{
+			name:    "unchanged schema",
+			spec:    &apiextensions.CustomResourceDefinitionSpec{Validation: schema(nested)},
+			oldSpec: &apiextensions.CustomResourceDefinitionSpec{Validation: schema(nested)},
+			limits:  SchemaLimits{MaxDepth: 3},
+			errors:  []validationMatch{},
+		},
+		{
+			name:    "changed schema",
+			spec:    &apiextensions.CustomResourceDefinitionSpec{Validation: schema(nested)},
+			oldSpec: &apiextensions.CustomResourceDefinitionSpec{Validation: schema(&apiextensions.JSONSchemaProps{Type: "object"})},
+			limits:  SchemaLimits{MaxDepth: 3},
+			errors: []validationMatch{
+				forbidden("spec", "validation", "openAPIV3Schema"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		errs := ValidateCustomResourceDefinitionSchemaLimits(tc.spec, tc.oldSpec, tc.limits, field.NewPath("spec"))
+		seenErrs := make([]bool, len(errs))
+
+		for _, expectedError := range tc.errors {
+			found := false
+			for i, err := range errs {
+				if expectedError.matches(err) && !seenErrs[i] {
+					found = true
+					seenErrs[i] = true
+					break
+				}
+			}
+
+			if !found {
+				t.Errorf("%s: expected %v at %v, got %v", tc.name, expectedError.errorType, expectedError.path.String(), errs)
+			}
+		}
+
+		for i, seen := range seenErrs {
+			if !seen {
+				t.Errorf("%s: unexpected error: %v", tc.name, errs[i])
+			}
+		}
+	}
+}
+
+func TestValidateSchemaCompatibility(t *testing.T) {
+	object := func(properties map[string]apiextensions.JSONSchemaProps, required ...string) *apiextensions.JSONSchemaProps {
+		return &apiextensions.JSONSchemaProps{Type: "object", Properties: properties, Required: required}
+	}
+	enum := func(values ...interface{}) []apiextensions.JSON {
+		js := []apiextensions.JSON{}
+		for _, v := range values {
+			js = append(js, v)
+		}
+		return js
+	}
+	topLevel := func(schema *apiextensions.JSONSchemaProps, versions ...apiextensions.CustomResourceDefinitionVersion) *apiextensions.CustomResourceDefinition {
+		if len(versions) == 0 {
+			versions = []apiextensions.CustomResourceDefinitionVersion{{Name: "v1", Served: true, Storage: true}}
+		}
+		return &apiextensions.CustomResourceDefinition{Spec: apiextensions.CustomResourceDefinitionSpec{
+			Versions:   versions,
+			Validation: &apiextensions.CustomResourceValidation{OpenAPIV3Schema: schema},
+		}}
+	}
+	version := func(name string, served bool, schema *apiextensions.JSONSchemaProps) apiextensions.CustomResourceDefinitionVersion {
+		return apiextensions.CustomResourceDefinitionVersion{Name: name, Served: served, Schema: &apiextensions.CustomResourceValidation{OpenAPIV3Schema: schema}}
+	}
+	perVersion := func(versions ...apiextensions.CustomResourceDefinitionVersion) *apiextensions.CustomResourceDefinition {
+		return &apiextensions.CustomResourceDefinition{Spec: apiextensions.CustomResourceDefinitionSpec{Versions: versions}}
+	}
+
+	spec := object(map[string]apiextensions.JSONSchemaProps{
+		"replicas": {Type: "integer"},
+		"mode":     {Type: "string", Enum: enum("Fast", "Safe")},
+		"hosts":    {Type: "array", Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string"}}},
+	}, "replicas")
+
+	tests := []struct {
+		name   string
+		crd    *apiextensions.CustomResourceDefinition
+		oldCRD *apiextensions.CustomResourceDefinition
+		errors []validationMatch
+	}{
+		{
+			name:   "unchanged",
+			crd:    topLevel(spec),
+			oldCRD: topLevel(spec),
+			errors: []validationMatch{},
+		},
+		{
+			name: "compatible changes",
+			crd: topLevel(object(map[string]apiextensions.JSONSchemaProps{
+				"replicas": {Type: "number"},
+				"mode":     {Type: "string", Enum: enum("Fast", "Safe", "Slow")},
+				"hosts":    {Type: "array"},
+				"paused":   {Type: "boolean"},
+			})),
+			oldCRD: topLevel(spec),
+			errors: []validationMatch{},
+		},
+		{
+			name: "breaking changes",
+			crd: topLevel(object(map[string]apiextensions.JSONSchemaProps{
+				"replicas": {Type: "string"},
+				"mode":     {Type: "string", Enum: enum("Fast")},
+				"hosts":    {Type: "array", Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string", Enum: enum("a")}}},
+				"paused":   {Type: "boolean"},
+			}, "replicas", "paused")),
+			oldCRD: topLevel(spec),
+			errors: []validationMatch{
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[replicas]", "type"),
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[mode]", "enum"),
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[hosts]", "items", "enum"),
+				forbidden("spec", "validation", "openAPIV3Schema", "required[1]"),
+			},
+		},
+		{
+			name:   "removed property",
+			crd:    topLevel(object(nil)),
+			oldCRD: topLevel(object(map[string]apiextensions.JSONSchemaProps{"spec": *spec})),
+			errors: []validationMatch{
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[spec]"),
+			},
+		},
+		{
+			name:   "top-level schema compared once",
+			crd:    topLevel(object(nil), apiextensions.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true}, apiextensions.CustomResourceDefinitionVersion{Name: "v2", Served: true}),
+			oldCRD: topLevel(spec, apiextensions.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true}, apiextensions.CustomResourceDefinitionVersion{Name: "v2", Served: true}),
+			errors: []validationMatch{
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[replicas]"),
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[mode]"),
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[hosts]"),
+			},
+		},
+		{
+			name:   "per-version schemas of versions served before and after",
+			crd:    perVersion(version("v1", true, object(nil)), version("v2", false, object(nil)), version("v3", true, object(nil))),
+			oldCRD: perVersion(version("v1", true, object(map[string]apiextensions.JSONSchemaProps{"spec": *spec})), version("v2", true, spec), version("v3", false, spec)),
+			errors: []validationMatch{
+				forbidden("spec", "versions[0]", "schema", "openAPIV3Schema", "properties[spec]"),
+			},
+		},
+		{
+			name:   "added schema",
+			crd:    topLevel(spec),
+			oldCRD: topLevel(nil),
+			errors: []validationMatch{},
+		},
+		{
+			name:   "changed type stops the comparison",
+			crd:    topLevel(object(map[string]apiextensions.JSONSchemaProps{"spec": {Type: "string"}})),
+			oldCRD: topLevel(object(map[string]apiextensions.JSONSchemaProps{"spec": *spec})),
+			errors: []validationMatch{
+				forbidden("spec", "validation", "openAPIV3Schema", "properties[spec]", "type"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		errs := ValidateSchemaCompatibility(tc.crd, tc.oldCRD)
+		seenErrs := make([]bool, len(errs))
+
+		for _, expectedError := range tc.errors {
+			found := false
+			for i, err := range errs {
+				if expectedError.matches(err) && !seenErrs[i] {
+					found = true
+					seenErrs[i] = true
+					break
+				}
+			}
+
+			if !found {
+				t.Errorf("%s: expected %v at %v, got %v", tc.name, expectedError.errorType, expectedError.path.String(), errs)
+			}
+		}
+
+		for i, seen := range seenErrs {
+			if !seen {
+				t.Errorf("%s: unexpected error: %v", tc.name, errs[i])
+			}
+		}
+	}
+}
+
+func TestValidateCustomResourceDefinitionStatusPersistedVersions(t *testing.T) {
+	tests := []struct {
+		name      string
+		persisted []apiextensions.PersistedVersionCount
+		errors    []validationMatch
+	}{
+		{
+			name:      "valid",
+			persisted: []apiextensions.PersistedVersionCount{{Version: "v1", Count: 3}, {Version: "v2", Count: 0}},
+			errors:    []validationMatch{},
+		},
+		{
+			name:      "invalid",
+			persisted: []apiextensions.PersistedVersionCount{{Count: 1}, {Version: "v1", Count: -1}, {Version: "v1", Count: 1}},
+			errors: []validationMatch{
+				required("status", "persistedVersions[0]", "version"),
+				invalid("status", "persistedVersions[1]", "count"),
+				duplicate("status", "persistedVersions[2]", "version"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		status := &apiextensions.CustomResourceDefinitionStatus{
+			AcceptedNames: apiextensions.CustomResourceDefinitionNames{
+				Plural:   "plural",
+				Singular: "singular",
+				Kind:     "Plural",
+				ListKind: "PluralList",
+			},
+			PersistedVersions: tc.persisted,
+		}
+		errs := ValidateCustomResourceDefinitionStatus(status, field.NewPath("status"))
+		seenErrs := make([]bool, len(errs))
+
+		for _, expectedError := range tc.errors {
+			found := false
+			for i, err := range errs {
+				if expectedError.matches(err) && !seenErrs[i] {
+					found = true
+					seenErrs[i] = true
+					break
+				}
+			}
+
 			if !found {
 				t.Errorf("%s: expected %v at %v, got %v", tc.name, expectedError.errorType, expectedError.path.String(), errs)
 			}
@@ -458,3 +4485,28 @@ func TestValidateCustomResourceDefinitionUpdate(t *testing.T) {
 		}
 	}
 }
+
+func jsonPtr(x interface{}) *apiextensions.JSON {
+	ret := apiextensions.JSON(x)
+	return &ret
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func conversionFailurePolicyPtr(p apiextensions.ConversionFailurePolicyType) *apiextensions.ConversionFailurePolicyType {
+	return &p
+}
+
+func strPtr(s string) *string {
+	return &s
+}
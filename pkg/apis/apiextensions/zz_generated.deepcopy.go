@@ -35,6 +35,22 @@ func init() {
 // to allow building arbitrary schemes.
 func RegisterDeepCopies(scheme *runtime.Scheme) error {
 	return scheme.AddGeneratedDeepCopyFuncs(
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*ConversionExpression).DeepCopyInto(out.(*ConversionExpression))
+			return nil
+		}, InType: reflect.TypeOf(&ConversionExpression{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*ConversionFieldMapping).DeepCopyInto(out.(*ConversionFieldMapping))
+			return nil
+		}, InType: reflect.TypeOf(&ConversionFieldMapping{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*CustomResourceColumnDefinition).DeepCopyInto(out.(*CustomResourceColumnDefinition))
+			return nil
+		}, InType: reflect.TypeOf(&CustomResourceColumnDefinition{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*CustomResourceConversion).DeepCopyInto(out.(*CustomResourceConversion))
+			return nil
+		}, InType: reflect.TypeOf(&CustomResourceConversion{})},
 		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
 			in.(*CustomResourceDefinition).DeepCopyInto(out.(*CustomResourceDefinition))
 			return nil
@@ -59,9 +75,164 @@ func RegisterDeepCopies(scheme *runtime.Scheme) error {
 			in.(*CustomResourceDefinitionStatus).DeepCopyInto(out.(*CustomResourceDefinitionStatus))
 			return nil
 		}, InType: reflect.TypeOf(&CustomResourceDefinitionStatus{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*CustomResourceStorage).DeepCopyInto(out.(*CustomResourceStorage))
+			return nil
+		}, InType: reflect.TypeOf(&CustomResourceStorage{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*CustomResourceSubresourceCustom).DeepCopyInto(out.(*CustomResourceSubresourceCustom))
+			return nil
+		}, InType: reflect.TypeOf(&CustomResourceSubresourceCustom{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*CustomResourceSubresourceScale).DeepCopyInto(out.(*CustomResourceSubresourceScale))
+			return nil
+		}, InType: reflect.TypeOf(&CustomResourceSubresourceScale{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*CustomResourceSubresources).DeepCopyInto(out.(*CustomResourceSubresources))
+			return nil
+		}, InType: reflect.TypeOf(&CustomResourceSubresources{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*CustomResourceValidation).DeepCopyInto(out.(*CustomResourceValidation))
+			return nil
+		}, InType: reflect.TypeOf(&CustomResourceValidation{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*ExternalDocumentation).DeepCopyInto(out.(*ExternalDocumentation))
+			return nil
+		}, InType: reflect.TypeOf(&ExternalDocumentation{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*JSONSchemaPropsOrArray).DeepCopyInto(out.(*JSONSchemaPropsOrArray))
+			return nil
+		}, InType: reflect.TypeOf(&JSONSchemaPropsOrArray{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*JSONSchemaPropsOrBool).DeepCopyInto(out.(*JSONSchemaPropsOrBool))
+			return nil
+		}, InType: reflect.TypeOf(&JSONSchemaPropsOrBool{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*JSONSchemaPropsOrStringArray).DeepCopyInto(out.(*JSONSchemaPropsOrStringArray))
+			return nil
+		}, InType: reflect.TypeOf(&JSONSchemaPropsOrStringArray{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*PersistedVersionCount).DeepCopyInto(out.(*PersistedVersionCount))
+			return nil
+		}, InType: reflect.TypeOf(&PersistedVersionCount{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*SelectableField).DeepCopyInto(out.(*SelectableField))
+			return nil
+		}, InType: reflect.TypeOf(&SelectableField{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*ServiceReference).DeepCopyInto(out.(*ServiceReference))
+			return nil
+		}, InType: reflect.TypeOf(&ServiceReference{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*ValidationRule).DeepCopyInto(out.(*ValidationRule))
+			return nil
+		}, InType: reflect.TypeOf(&ValidationRule{})},
+		conversion.GeneratedDeepCopyFunc{Fn: func(in interface{}, out interface{}, c *conversion.Cloner) error {
+			in.(*WebhookClientConfig).DeepCopyInto(out.(*WebhookClientConfig))
+			return nil
+		}, InType: reflect.TypeOf(&WebhookClientConfig{})},
 	)
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConversionExpression) DeepCopyInto(out *ConversionExpression) {
+	*out = *in
+	if in.FieldMappings != nil {
+		in, out := &in.FieldMappings, &out.FieldMappings
+		*out = make([]ConversionFieldMapping, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new ConversionExpression.
+func (x *ConversionExpression) DeepCopy() *ConversionExpression {
+	if x == nil {
+		return nil
+	}
+	out := new(ConversionExpression)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConversionFieldMapping) DeepCopyInto(out *ConversionFieldMapping) {
+	*out = *in
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new ConversionFieldMapping.
+func (x *ConversionFieldMapping) DeepCopy() *ConversionFieldMapping {
+	if x == nil {
+		return nil
+	}
+	out := new(ConversionFieldMapping)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResourceColumnDefinition) DeepCopyInto(out *CustomResourceColumnDefinition) {
+	*out = *in
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new CustomResourceColumnDefinition.
+func (x *CustomResourceColumnDefinition) DeepCopy() *CustomResourceColumnDefinition {
+	if x == nil {
+		return nil
+	}
+	out := new(CustomResourceColumnDefinition)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResourceConversion) DeepCopyInto(out *CustomResourceConversion) {
+	*out = *in
+	if in.WebhookClientConfig != nil {
+		in, out := &in.WebhookClientConfig, &out.WebhookClientConfig
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(WebhookClientConfig)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.ConversionReviewVersions != nil {
+		in, out := &in.ConversionReviewVersions, &out.ConversionReviewVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Expressions != nil {
+		in, out := &in.Expressions, &out.Expressions
+		*out = make([]ConversionExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ConversionFailurePolicyType)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new CustomResourceConversion.
+func (x *CustomResourceConversion) DeepCopy() *CustomResourceConversion {
+	if x == nil {
+		return nil
+	}
+	out := new(CustomResourceConversion)
+	x.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomResourceDefinition) DeepCopyInto(out *CustomResourceDefinition) {
 	*out = *in
@@ -150,6 +321,11 @@ func (in *CustomResourceDefinitionNames) DeepCopyInto(out *CustomResourceDefinit
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Categories != nil {
+		in, out := &in.Categories, &out.Categories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -167,6 +343,73 @@ func (x *CustomResourceDefinitionNames) DeepCopy() *CustomResourceDefinitionName
 func (in *CustomResourceDefinitionSpec) DeepCopyInto(out *CustomResourceDefinitionSpec) {
 	*out = *in
 	in.Names.DeepCopyInto(&out.Names)
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CustomResourceValidation)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.PreserveUnknownFields != nil {
+		in, out := &in.PreserveUnknownFields, &out.PreserveUnknownFields
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(bool)
+			**out = **in
+		}
+	}
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]CustomResourceDefinitionVersion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conversion != nil {
+		in, out := &in.Conversion, &out.Conversion
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CustomResourceConversion)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Subresources != nil {
+		in, out := &in.Subresources, &out.Subresources
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CustomResourceSubresources)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.AdditionalPrinterColumns != nil {
+		in, out := &in.AdditionalPrinterColumns, &out.AdditionalPrinterColumns
+		*out = make([]CustomResourceColumnDefinition, len(*in))
+		copy(*out, *in)
+	}
+	if in.SelectableFields != nil {
+		in, out := &in.SelectableFields, &out.SelectableFields
+		*out = make([]SelectableField, len(*in))
+		copy(*out, *in)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CustomResourceStorage)
+			**out = **in
+		}
+	}
+	if in.AllowedFinalizerDomains != nil {
+		in, out := &in.AllowedFinalizerDomains, &out.AllowedFinalizerDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -191,6 +434,16 @@ func (in *CustomResourceDefinitionStatus) DeepCopyInto(out *CustomResourceDefini
 		}
 	}
 	in.AcceptedNames.DeepCopyInto(&out.AcceptedNames)
+	if in.StoredVersions != nil {
+		in, out := &in.StoredVersions, &out.StoredVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PersistedVersions != nil {
+		in, out := &in.PersistedVersions, &out.PersistedVersions
+		*out = make([]PersistedVersionCount, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -203,3 +456,360 @@ func (x *CustomResourceDefinitionStatus) DeepCopy() *CustomResourceDefinitionSta
 	x.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResourceStorage) DeepCopyInto(out *CustomResourceStorage) {
+	*out = *in
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new CustomResourceStorage.
+func (x *CustomResourceStorage) DeepCopy() *CustomResourceStorage {
+	if x == nil {
+		return nil
+	}
+	out := new(CustomResourceStorage)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResourceSubresourceCustom) DeepCopyInto(out *CustomResourceSubresourceCustom) {
+	*out = *in
+	if in.FieldPaths != nil {
+		in, out := &in.FieldPaths, &out.FieldPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Verbs != nil {
+		in, out := &in.Verbs, &out.Verbs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CustomResourceValidation)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new CustomResourceSubresourceCustom.
+func (x *CustomResourceSubresourceCustom) DeepCopy() *CustomResourceSubresourceCustom {
+	if x == nil {
+		return nil
+	}
+	out := new(CustomResourceSubresourceCustom)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResourceSubresourceScale) DeepCopyInto(out *CustomResourceSubresourceScale) {
+	*out = *in
+	if in.LabelSelectorPath != nil {
+		in, out := &in.LabelSelectorPath, &out.LabelSelectorPath
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new CustomResourceSubresourceScale.
+func (x *CustomResourceSubresourceScale) DeepCopy() *CustomResourceSubresourceScale {
+	if x == nil {
+		return nil
+	}
+	out := new(CustomResourceSubresourceScale)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResourceSubresources) DeepCopyInto(out *CustomResourceSubresources) {
+	*out = *in
+	if in.Scale != nil {
+		in, out := &in.Scale, &out.Scale
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CustomResourceSubresourceScale)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CustomResourceSubresourceStatus)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = make([]CustomResourceSubresourceCustom, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new CustomResourceSubresources.
+func (x *CustomResourceSubresources) DeepCopy() *CustomResourceSubresources {
+	if x == nil {
+		return nil
+	}
+	out := new(CustomResourceSubresources)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResourceValidation) DeepCopyInto(out *CustomResourceValidation) {
+	*out = *in
+	if in.OpenAPIV3Schema != nil {
+		in, out := &in.OpenAPIV3Schema, &out.OpenAPIV3Schema
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(JSONSchemaProps)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new CustomResourceValidation.
+func (x *CustomResourceValidation) DeepCopy() *CustomResourceValidation {
+	if x == nil {
+		return nil
+	}
+	out := new(CustomResourceValidation)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDocumentation) DeepCopyInto(out *ExternalDocumentation) {
+	*out = *in
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDocumentation.
+func (x *ExternalDocumentation) DeepCopy() *ExternalDocumentation {
+	if x == nil {
+		return nil
+	}
+	out := new(ExternalDocumentation)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONSchemaPropsOrArray) DeepCopyInto(out *JSONSchemaPropsOrArray) {
+	*out = *in
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(JSONSchemaProps)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.JSONSchemas != nil {
+		in, out := &in.JSONSchemas, &out.JSONSchemas
+		*out = make([]JSONSchemaProps, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new JSONSchemaPropsOrArray.
+func (x *JSONSchemaPropsOrArray) DeepCopy() *JSONSchemaPropsOrArray {
+	if x == nil {
+		return nil
+	}
+	out := new(JSONSchemaPropsOrArray)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONSchemaPropsOrBool) DeepCopyInto(out *JSONSchemaPropsOrBool) {
+	*out = *in
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(JSONSchemaProps)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new JSONSchemaPropsOrBool.
+func (x *JSONSchemaPropsOrBool) DeepCopy() *JSONSchemaPropsOrBool {
+	if x == nil {
+		return nil
+	}
+	out := new(JSONSchemaPropsOrBool)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONSchemaPropsOrStringArray) DeepCopyInto(out *JSONSchemaPropsOrStringArray) {
+	*out = *in
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(JSONSchemaProps)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Property != nil {
+		in, out := &in.Property, &out.Property
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new JSONSchemaPropsOrStringArray.
+func (x *JSONSchemaPropsOrStringArray) DeepCopy() *JSONSchemaPropsOrStringArray {
+	if x == nil {
+		return nil
+	}
+	out := new(JSONSchemaPropsOrStringArray)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistedVersionCount) DeepCopyInto(out *PersistedVersionCount) {
+	*out = *in
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new PersistedVersionCount.
+func (x *PersistedVersionCount) DeepCopy() *PersistedVersionCount {
+	if x == nil {
+		return nil
+	}
+	out := new(PersistedVersionCount)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectableField) DeepCopyInto(out *SelectableField) {
+	*out = *in
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new SelectableField.
+func (x *SelectableField) DeepCopy() *SelectableField {
+	if x == nil {
+		return nil
+	}
+	out := new(SelectableField)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceReference) DeepCopyInto(out *ServiceReference) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new ServiceReference.
+func (x *ServiceReference) DeepCopy() *ServiceReference {
+	if x == nil {
+		return nil
+	}
+	out := new(ServiceReference)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationRule) DeepCopyInto(out *ValidationRule) {
+	*out = *in
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new ValidationRule.
+func (x *ValidationRule) DeepCopy() *ValidationRule {
+	if x == nil {
+		return nil
+	}
+	out := new(ValidationRule)
+	x.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookClientConfig) DeepCopyInto(out *WebhookClientConfig) {
+	*out = *in
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ServiceReference)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, creating a new WebhookClientConfig.
+func (x *WebhookClientConfig) DeepCopy() *WebhookClientConfig {
+	if x == nil {
+		return nil
+	}
+	out := new(WebhookClientConfig)
+	x.DeepCopyInto(out)
+	return out
+}
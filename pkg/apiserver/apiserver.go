@@ -24,6 +24,7 @@ import (
 
 	"github.com/golang/glog"
 
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	"k8s.io/apimachinery/pkg/apimachinery/announced"
 	"k8s.io/apimachinery/pkg/apimachinery/registered"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,7 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/version"
-	"k8s.io/apiserver/pkg/endpoints/discovery"
+	"k8s.io/apiserver/pkg/admission"
 	genericregistry "k8s.io/apiserver/pkg/registry/generic"
 	"k8s.io/apiserver/pkg/registry/rest"
 	genericapiserver "k8s.io/apiserver/pkg/server"
@@ -39,10 +40,17 @@ import (
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/install"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/validation"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/conversion"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/metrics"
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/internalclientset"
 	internalinformers "k8s.io/apiextensions-apiserver/pkg/client/informers/internalversion"
 	"k8s.io/apiextensions-apiserver/pkg/controller/finalizer"
+	"k8s.io/apiextensions-apiserver/pkg/controller/openapi"
+	"k8s.io/apiextensions-apiserver/pkg/controller/quota"
 	"k8s.io/apiextensions-apiserver/pkg/controller/status"
+	"k8s.io/apiextensions-apiserver/pkg/controller/storageversion"
+	"k8s.io/apiextensions-apiserver/pkg/registry/customresource"
 	"k8s.io/apiextensions-apiserver/pkg/registry/customresourcedefinition"
 
 	// make sure the generated client works
@@ -76,12 +84,76 @@ func init() {
 	metav1.AddToGroupVersion(Scheme, schema.GroupVersion{Group: "", Version: "v1"})
 
 	Scheme.AddUnversionedTypes(unversionedVersion, unversionedTypes...)
+
+	// the scale subresource of custom resources is served as autoscaling/v1 Scale. There is
+	// no internal version of it in this apiserver, hence it is unversioned.
+	Scheme.AddUnversionedTypes(autoscalingv1.SchemeGroupVersion, &autoscalingv1.Scale{})
 }
 
 type Config struct {
 	GenericConfig *genericapiserver.Config
 
 	CRDRESTOptionsGetter genericregistry.RESTOptionsGetter
+
+	// AllowedStoragePrefixes are the etcd key prefixes, relative to the storage prefix, under which
+	// CustomResourceDefinitions may store their custom resources using spec.storage.
+	AllowedStoragePrefixes []string
+
+	// SchemaLimits bound the size of the validation schemas of CustomResourceDefinitions.
+	SchemaLimits validation.SchemaLimits
+
+	// RequireAPIApproval rejects CustomResourceDefinitions in groups owned by the Kubernetes project,
+	// like *.k8s.io, without a valid api-approved.kubernetes.io annotation.
+	RequireAPIApproval bool
+	// SchemaCompatibilityPolicy is the handling of breaking changes of the schemas of served versions
+	// by updates of CustomResourceDefinitions. Empty ignores them.
+	SchemaCompatibilityPolicy validation.SchemaCompatibilityPolicy
+	// CustomResourceDefinitionValidators are the in-process validators of CustomResourceDefinitions
+	// registered by an embedding server, e.g. for naming policies. They are optional.
+	CustomResourceDefinitionValidators []customresourcedefinition.Validator
+
+	// GenerateNameRetries is how often the creation of a custom resource is retried with a new name
+	// generated from metadata.generateName if the generated name is already taken.
+	GenerateNameRetries int
+	// ListCompressionThreshold is the size in bytes above which the responses of custom resource list
+	// requests are compressed with gzip for clients accepting it. Zero disables compression.
+	ListCompressionThreshold int
+	// OptimisticServing serves the custom resources of new CustomResourceDefinitions as soon as their
+	// names are accepted, without waiting until they are established. Before, requests get
+	// ServiceUnavailable and a Retry-After header instead of NotFound.
+	OptimisticServing bool
+
+	// ClusterRelease is the Kubernetes release of the cluster, e.g. 1.25, which the removedInRelease of
+	// the versions of CustomResourceDefinitions is checked against. Empty disables the check.
+	ClusterRelease string
+	// EventRecorder records events about CustomResourceDefinitions. It is optional.
+	EventRecorder status.EventRecorder
+
+	// CustomResourceLimits bound the number and size of the custom resources of each
+	// CustomResourceDefinition. They are overridden by the apiextensions.k8s.io/max-custom-resources
+	// and apiextensions.k8s.io/max-custom-resource-bytes annotations of the CustomResourceDefinition.
+	CustomResourceLimits customresource.Limits
+
+	// ConversionWebhookOptions configure the clients of conversion webhooks.
+	ConversionWebhookOptions conversion.WebhookOptions
+	// ConverterFactory creates the converters of custom resources. An embedding server may register
+	// in-process converters with it, which replace the conversion strategies of CustomResourceDefinitions
+	// of the registered API groups. It is optional.
+	ConverterFactory *conversion.CRConverterFactory
+
+	// PriorityClassifier classifies the requests for custom resources into PriorityLevels. It is
+	// optional, e.g. an embedding server may classify by the user or the custom resource kind.
+	PriorityClassifier PriorityClassifier
+	// PriorityLevels are the maximum numbers of concurrent requests of the priority levels by name.
+	PriorityLevels map[string]int
+
+	// CustomResourceHooks are the in-process mutators and validators of custom resources registered by
+	// an embedding server. They are optional.
+	CustomResourceHooks *CustomResourceHooks
+
+	// QuotaRegistry is the registry of quota evaluators an object count evaluator is registered
+	// with for each established CustomResourceDefinition. It is optional.
+	QuotaRegistry quota.Registry
 }
 
 type CustomResourceDefinitions struct {
@@ -89,6 +161,22 @@ type CustomResourceDefinitions struct {
 
 	// provided for easier embedding
 	Informers internalinformers.SharedInformerFactory
+
+	// RESTMapper maps the kinds and resources of the established custom resources, e.g. for the
+	// garbage collector of an embedding server.
+	RESTMapper *CustomResourceRESTMapper
+
+	watchTerminator *watchTerminator
+}
+
+// TerminateWatches ends the watches of custom resources and waits up to the given timeout for their
+// streams to end. Servers call it when they shut down, after they stopped accepting connections,
+// such that clients resume their watches right away. Responses for custom resources close their
+// connection afterwards, with a GOAWAY on HTTP/2, such that the clients resume on another replica.
+func (s *CustomResourceDefinitions) TerminateWatches(timeout time.Duration) {
+	if s.watchTerminator != nil {
+		s.watchTerminator.terminate(timeout)
+	}
 }
 
 type completedConfig struct {
@@ -124,17 +212,7 @@ func (c completedConfig) New(delegationTarget genericapiserver.DelegationTarget)
 		GenericAPIServer: genericServer,
 	}
 
-	apiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(apiextensions.GroupName, registry, Scheme, metav1.ParameterCodec, Codecs)
-	apiGroupInfo.GroupMeta.GroupVersion = v1beta1.SchemeGroupVersion
-	customResourceDefintionStorage := customresourcedefinition.NewREST(Scheme, c.GenericConfig.RESTOptionsGetter)
-	v1beta1storage := map[string]rest.Storage{}
-	v1beta1storage["customresourcedefinitions"] = customResourceDefintionStorage
-	v1beta1storage["customresourcedefinitions/status"] = customresourcedefinition.NewStatusREST(Scheme, customResourceDefintionStorage)
-	apiGroupInfo.VersionedResourcesStorageMap["v1beta1"] = v1beta1storage
-
-	if err := s.GenericAPIServer.InstallAPIGroup(&apiGroupInfo); err != nil {
-		return nil, err
-	}
+	metrics.Register()
 
 	crdClient, err := internalclientset.NewForConfig(s.GenericAPIServer.LoopbackClientConfig)
 	if err != nil {
@@ -159,32 +237,113 @@ func (c completedConfig) New(delegationTarget genericapiserver.DelegationTarget)
 	}
 
 	versionDiscoveryHandler := &versionDiscoveryHandler{
-		discovery: map[schema.GroupVersion]*discovery.APIVersionHandler{},
-		delegate:  delegateHandler,
+		delegate: delegateHandler,
 	}
 	groupDiscoveryHandler := &groupDiscoveryHandler{
-		discovery: map[string]*discovery.APIGroupHandler{},
-		delegate:  delegateHandler,
+		delegate: delegateHandler,
+	}
+	aggregatedDiscoveryHandler := &aggregatedDiscoveryHandler{
+		delegate: delegateHandler,
 	}
+	// the custom resource hooks run after the configured admission plugins
+	customResourceAdmission := c.GenericConfig.AdmissionControl
+	if c.CustomResourceHooks != nil {
+		if customResourceAdmission == nil {
+			customResourceAdmission = c.CustomResourceHooks
+		} else {
+			customResourceAdmission = admission.NewChainHandler(customResourceAdmission, c.CustomResourceHooks)
+		}
+	}
+	conversionReviewController := status.NewConversionReviewConditionController(s.Informers.Apiextensions().InternalVersion().CustomResourceDefinitions(), crdClient)
 	crdHandler := NewCustomResourceDefinitionHandler(
 		versionDiscoveryHandler,
 		groupDiscoveryHandler,
+		aggregatedDiscoveryHandler,
 		s.GenericAPIServer.RequestContextMapper(),
-		s.Informers.Apiextensions().InternalVersion().CustomResourceDefinitions().Lister(),
+		s.Informers.Apiextensions().InternalVersion().CustomResourceDefinitions(),
 		delegateHandler,
 		c.CRDRESTOptionsGetter,
-		c.GenericConfig.AdmissionControl,
+		customResourceAdmission,
+		CustomResourceDefinitionHandlerOptions{
+			ConversionReviewRecorder: conversionReviewController,
+			ConversionWebhookOptions: c.ConversionWebhookOptions,
+			ConverterFactory:         c.ConverterFactory,
+			GenerateNameRetries:      c.GenerateNameRetries,
+			ListCompressionThreshold: c.ListCompressionThreshold,
+			CustomResourceLimits:     c.CustomResourceLimits,
+			PriorityClassifier:       c.PriorityClassifier,
+			PriorityLimits:           c.PriorityLevels,
+			RuleCostBudget:           c.SchemaLimits.RuleCostBudget,
+			OptimisticServing:        c.OptimisticServing,
+		},
 	)
+	s.RESTMapper = crdHandler.ownerMapper
+	s.watchTerminator = crdHandler.watchTerminator
 	s.GenericAPIServer.Handler.NonGoRestfulMux.Handle("/apis", crdHandler)
 	s.GenericAPIServer.Handler.NonGoRestfulMux.HandlePrefix("/apis/", crdHandler)
 
-	crdController := NewDiscoveryController(s.Informers.Apiextensions().InternalVersion().CustomResourceDefinitions(), versionDiscoveryHandler, groupDiscoveryHandler, c.GenericConfig.RequestContextMapper)
-	namingController := status.NewNamingConditionController(s.Informers.Apiextensions().InternalVersion().CustomResourceDefinitions(), crdClient)
+	// the crdHandler checks for custom resources when the scope of a CRD is changed
+	apiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(apiextensions.GroupName, registry, Scheme, metav1.ParameterCodec, Codecs)
+	apiGroupInfo.GroupMeta.GroupVersion = v1beta1.SchemeGroupVersion
+	customResourceDefintionStorage := customresourcedefinition.NewREST(Scheme, c.GenericConfig.RESTOptionsGetter, c.AllowedStoragePrefixes, c.SchemaLimits, crdHandler, c.RequireAPIApproval, c.SchemaCompatibilityPolicy, c.CustomResourceDefinitionValidators)
+	v1beta1storage := map[string]rest.Storage{}
+	v1beta1storage["customresourcedefinitions"] = customResourceDefintionStorage
+	v1beta1storage["customresourcedefinitions/status"] = customresourcedefinition.NewStatusREST(Scheme, customResourceDefintionStorage)
+	apiGroupInfo.VersionedResourcesStorageMap["v1beta1"] = v1beta1storage
+
+	if err := s.GenericAPIServer.InstallAPIGroup(&apiGroupInfo); err != nil {
+		return nil, err
+	}
+
+	openAPIService := openapi.NewService()
+	s.GenericAPIServer.Handler.NonGoRestfulMux.Handle(openapi.V2Path, openAPIService)
+	s.GenericAPIServer.Handler.NonGoRestfulMux.Handle(openapi.V3Path, openAPIService)
+	s.GenericAPIServer.Handler.NonGoRestfulMux.HandlePrefix(openapi.V3Path+"/", openAPIService)
+	s.GenericAPIServer.Handler.NonGoRestfulMux.Handle(openapi.JSONSchemaPath, openAPIService)
+	s.GenericAPIServer.Handler.NonGoRestfulMux.HandlePrefix(openapi.JSONSchemaPath+"/", openAPIService)
+
+	crdController := NewDiscoveryController(s.Informers.Apiextensions().InternalVersion().CustomResourceDefinitions(), versionDiscoveryHandler, groupDiscoveryHandler, aggregatedDiscoveryHandler, c.GenericConfig.RequestContextMapper)
+	namingController := status.NewNamingConditionController(s.Informers.Apiextensions().InternalVersion().CustomResourceDefinitions(), crdClient, crdClient.Discovery())
+	apiApprovalController := status.NewAPIApprovalConditionController(s.Informers.Apiextensions().InternalVersion().CustomResourceDefinitions(), crdClient)
 	finalizingController := finalizer.NewCRDFinalizer(
 		s.Informers.Apiextensions().InternalVersion().CustomResourceDefinitions(),
 		crdClient,
 		crdHandler,
 	)
+	storageVersionMigrator := storageversion.NewStorageVersionMigrator(
+		s.Informers.Apiextensions().InternalVersion().CustomResourceDefinitions(),
+		crdClient,
+		crdHandler,
+	)
+	persistedVersionController := status.NewPersistedVersionController(s.Informers.Apiextensions().InternalVersion().CustomResourceDefinitions(), crdClient, crdHandler, 5*time.Minute)
+	openAPIController := openapi.NewController(s.Informers.Apiextensions().InternalVersion().CustomResourceDefinitions(), crdClient, openAPIService)
+	crdReadiness := &crdReadinessHandler{
+		crdLister:        s.Informers.Apiextensions().InternalVersion().CustomResourceDefinitions().Lister(),
+		publishedChecker: openAPIController,
+	}
+	s.GenericAPIServer.Handler.NonGoRestfulMux.Handle(crdReadinessPath, crdReadiness)
+	s.GenericAPIServer.Handler.NonGoRestfulMux.HandlePrefix(crdReadinessPath+"/", crdReadiness)
+	crdExamples := &crdExampleHandler{
+		crdLister: s.Informers.Apiextensions().InternalVersion().CustomResourceDefinitions().Lister(),
+	}
+	s.GenericAPIServer.Handler.NonGoRestfulMux.HandlePrefix(crdExamplesPath+"/", crdExamples)
+	crdServing := &crdServingHandler{
+		crdLister: s.Informers.Apiextensions().InternalVersion().CustomResourceDefinitions().Lister(),
+		admission: customResourceAdmission,
+		hooks:     c.CustomResourceHooks,
+	}
+	s.GenericAPIServer.Handler.NonGoRestfulMux.HandlePrefix(crdServingPath+"/", crdServing)
+	var removalReleaseController *status.RemovalReleaseConditionController
+	if len(c.ClusterRelease) > 0 {
+		removalReleaseController, err = status.NewRemovalReleaseConditionController(s.Informers.Apiextensions().InternalVersion().CustomResourceDefinitions(), crdClient, c.ClusterRelease, c.EventRecorder)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var quotaController *quota.Controller
+	if c.QuotaRegistry != nil {
+		quotaController = quota.NewController(s.Informers.Apiextensions().InternalVersion().CustomResourceDefinitions(), crdHandler, c.QuotaRegistry)
+	}
 
 	// this only happens when KUBE_API_VERSIONS is set.  We must return without adding poststarthooks which would affect healthz
 	if crdClient == nil {
@@ -198,7 +357,18 @@ func (c completedConfig) New(delegationTarget genericapiserver.DelegationTarget)
 	s.GenericAPIServer.AddPostStartHook("start-apiextensions-controllers", func(context genericapiserver.PostStartHookContext) error {
 		go crdController.Run(context.StopCh)
 		go namingController.Run(context.StopCh)
+		go apiApprovalController.Run(context.StopCh)
+		go conversionReviewController.Run(context.StopCh)
 		go finalizingController.Run(5, context.StopCh)
+		go storageVersionMigrator.Run(2, context.StopCh)
+		go persistedVersionController.Run(context.StopCh)
+		go openAPIController.Run(context.StopCh)
+		if removalReleaseController != nil {
+			go removalReleaseController.Run(context.StopCh)
+		}
+		if quotaController != nil {
+			go quotaController.Run(context.StopCh)
+		}
 		return nil
 	})
 
@@ -0,0 +1,172 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// StorageMirrorAnnotation holds the JSON encoding of a persisted custom resource in the mirror
+// storage version, without its metadata. It is only set in storage and never returned to clients.
+const StorageMirrorAnnotation = "apiextensions.k8s.io/storage-mirror"
+
+// NewStorageCodec returns a codec which converts custom resources to encodeVersion before
+// they are written to storage, and to decodeVersion after they are read from storage. With an
+// empty decodeVersion, custom resources are read in the version they are persisted in. With a
+// mirrorVersion, custom resources are also persisted in that version, and are read from that copy
+// instead of being converted if it is the decodeVersion.
+func NewStorageCodec(delegate runtime.Codec, converter Converter, encodeVersion, decodeVersion, mirrorVersion schema.GroupVersion) runtime.Codec {
+	return &storageCodec{
+		delegate:      delegate,
+		converter:     converter,
+		encodeVersion: encodeVersion,
+		decodeVersion: decodeVersion,
+		mirrorVersion: mirrorVersion,
+	}
+}
+
+type storageCodec struct {
+	delegate      runtime.Codec
+	converter     Converter
+	encodeVersion schema.GroupVersion
+	decodeVersion schema.GroupVersion
+	mirrorVersion schema.GroupVersion
+}
+
+var _ runtime.Codec = &storageCodec{}
+
+func (c *storageCodec) Encode(obj runtime.Object, w io.Writer) error {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return c.delegate.Encode(obj, w)
+	}
+
+	mirror := ""
+	if !c.mirrorVersion.Empty() {
+		var err error
+		if mirror, err = c.encodeMirror(u); err != nil {
+			return err
+		}
+	}
+	if u.GetAPIVersion() != c.encodeVersion.String() {
+		converted, err := c.converter.Convert(u, c.encodeVersion)
+		if err != nil {
+			return err
+		}
+		if u, ok = converted.(*unstructured.Unstructured); !ok {
+			return fmt.Errorf("unexpected type %T after conversion", converted)
+		}
+	}
+	// the annotation cannot be set by clients
+	if _, found := u.GetAnnotations()[StorageMirrorAnnotation]; found || len(mirror) > 0 {
+		u = u.DeepCopy()
+		setStorageMirror(u, mirror)
+	}
+	return c.delegate.Encode(u, w)
+}
+
+// encodeMirror returns the JSON encoding of the custom resource in the mirror version, without
+// its metadata.
+func (c *storageCodec) encodeMirror(u *unstructured.Unstructured) (string, error) {
+	mirrored := u
+	if u.GetAPIVersion() != c.mirrorVersion.String() {
+		converted, err := c.converter.Convert(u, c.mirrorVersion)
+		if err != nil {
+			return "", fmt.Errorf("unable to convert to the mirror storage version %s: %v", c.mirrorVersion, err)
+		}
+		var ok bool
+		if mirrored, ok = converted.(*unstructured.Unstructured); !ok {
+			return "", fmt.Errorf("unexpected type %T after conversion", converted)
+		}
+	}
+	content := make(map[string]interface{}, len(mirrored.Object))
+	for k, v := range mirrored.Object {
+		if k != "metadata" {
+			content[k] = v
+		}
+	}
+	data, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// setStorageMirror sets the StorageMirrorAnnotation to mirror, or removes it if mirror is empty.
+func setStorageMirror(u *unstructured.Unstructured, mirror string) {
+	annotations := u.GetAnnotations()
+	delete(annotations, StorageMirrorAnnotation)
+	if len(mirror) > 0 {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[StorageMirrorAnnotation] = mirror
+	}
+	if len(annotations) > 0 {
+		u.SetAnnotations(annotations)
+	} else if metadata, ok := u.Object["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "annotations")
+	}
+}
+
+func (c *storageCodec) Decode(data []byte, defaults *schema.GroupVersionKind, into runtime.Object) (runtime.Object, *schema.GroupVersionKind, error) {
+	obj, gvk, err := c.delegate.Decode(data, defaults, into)
+	if err != nil {
+		return nil, gvk, err
+	}
+
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return obj, gvk, nil
+	}
+	mirror, mirrored := u.GetAnnotations()[StorageMirrorAnnotation]
+	if mirrored {
+		setStorageMirror(u, "")
+	}
+	if c.decodeVersion.Empty() || u.GetAPIVersion() == c.decodeVersion.String() {
+		return u, gvk, nil
+	}
+
+	// the mirrored copy allows reading custom resources persisted in a rolled back storage version
+	// without conversion
+	mirroredObject := &unstructured.Unstructured{}
+	if mirrored && mirroredObject.UnmarshalJSON([]byte(mirror)) == nil && mirroredObject.GetAPIVersion() == c.decodeVersion.String() {
+		if metadata, found := u.Object["metadata"]; found {
+			mirroredObject.Object["metadata"] = metadata
+		}
+		u.Object = mirroredObject.Object
+	} else {
+		converted, err := c.converter.Convert(u, c.decodeVersion)
+		if err != nil {
+			return nil, gvk, err
+		}
+		convertedUnstructured, ok := converted.(*unstructured.Unstructured)
+		if !ok {
+			return nil, gvk, fmt.Errorf("unexpected type %T after conversion", converted)
+		}
+		u.Object = convertedUnstructured.Object
+	}
+
+	decodedGVK := u.GroupVersionKind()
+	return u, &decodedGVK, nil
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"fmt"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Converter converts custom resources between the versions of a CustomResourceDefinition.
+type Converter interface {
+	// Convert returns a copy of in converted to the given version. in must be an
+	// *unstructured.Unstructured or an *unstructured.UnstructuredList and is not mutated.
+	Convert(in runtime.Object, targetGV schema.GroupVersion) (runtime.Object, error)
+}
+
+// NewConverter returns the converter for the custom resources of the given CustomResourceDefinition,
+// according to its conversion strategy. The webhook options configure the client of a conversion
+// webhook. The recorder is notified of the ConversionReview version negotiated with a conversion
+// webhook and of its degradation. It is optional.
+func NewConverter(crd *apiextensions.CustomResourceDefinition, webhookOptions WebhookOptions, recorder WebhookRecorder) (Converter, error) {
+	strategy := apiextensions.NoneConverter
+	if crd.Spec.Conversion != nil {
+		strategy = crd.Spec.Conversion.Strategy
+	}
+
+	var delegate converter
+	switch strategy {
+	case apiextensions.NoneConverter:
+		delegate = nopConverter{}
+	case apiextensions.WebhookConverter:
+		webhook, err := newWebhookConverter(crd, webhookOptions, recorder)
+		if err != nil {
+			return nil, err
+		}
+		delegate = webhook
+	case apiextensions.ExpressionConverter:
+		expression, err := newExpressionConverter(crd)
+		if err != nil {
+			return nil, err
+		}
+		delegate = expression
+	default:
+		return nil, fmt.Errorf("unknown conversion strategy %q for CRD %s", strategy, crd.Name)
+	}
+
+	return newCRConverter(crd, delegate), nil
+}
+
+// newCRConverter returns a converter between the versions of the given CustomResourceDefinition,
+// which passes the objects to convert to delegate.
+func newCRConverter(crd *apiextensions.CustomResourceDefinition, delegate converter) *crConverter {
+	validVersions := map[schema.GroupVersion]bool{}
+	for _, v := range crd.Spec.Versions {
+		validVersions[schema.GroupVersion{Group: crd.Spec.Group, Version: v.Name}] = true
+	}
+	return &crConverter{
+		validVersions: validVersions,
+		delegate:      delegate,
+	}
+}
+
+// converter converts a list of objects, none of which is in the target version already.
+type converter interface {
+	convert(in []*unstructured.Unstructured, targetGV schema.GroupVersion) ([]*unstructured.Unstructured, error)
+}
+
+// crConverter checks versions and skips objects which are in the target version already,
+// before it passes the remaining objects to the strategy specific converter.
+type crConverter struct {
+	validVersions map[schema.GroupVersion]bool
+	delegate      converter
+}
+
+func (c *crConverter) Convert(in runtime.Object, targetGV schema.GroupVersion) (runtime.Object, error) {
+	if !c.validVersions[targetGV] {
+		return nil, fmt.Errorf("request to convert CR to an invalid group/version: %s", targetGV.String())
+	}
+
+	switch in := in.(type) {
+	case *unstructured.Unstructured:
+		out, err := c.convertObjects([]*unstructured.Unstructured{in}, targetGV)
+		if err != nil {
+			return nil, err
+		}
+		return out[0], nil
+	case *unstructured.UnstructuredList:
+		items := make([]*unstructured.Unstructured, len(in.Items))
+		for i := range in.Items {
+			items[i] = &in.Items[i]
+		}
+		converted, err := c.convertObjects(items, targetGV)
+		if err != nil {
+			return nil, err
+		}
+		out := &unstructured.UnstructuredList{
+			Object: (&unstructured.Unstructured{Object: in.Object}).DeepCopy().Object,
+			Items:  make([]unstructured.Unstructured, len(converted)),
+		}
+		for i := range converted {
+			out.Items[i] = *converted[i]
+		}
+		out.SetAPIVersion(targetGV.String())
+		return out, nil
+	}
+	return nil, fmt.Errorf("unexpected type %T for conversion", in)
+}
+
+func (c *crConverter) convertObjects(in []*unstructured.Unstructured, targetGV schema.GroupVersion) ([]*unstructured.Unstructured, error) {
+	out := make([]*unstructured.Unstructured, len(in))
+	var toConvert []*unstructured.Unstructured
+	var indexes []int
+	for i, obj := range in {
+		fromGV, err := schema.ParseGroupVersion(obj.GetAPIVersion())
+		if err != nil {
+			return nil, err
+		}
+		if !c.validVersions[fromGV] {
+			return nil, fmt.Errorf("request to convert CR from an invalid group/version: %s", obj.GetAPIVersion())
+		}
+		if fromGV == targetGV {
+			out[i] = obj.DeepCopy()
+			continue
+		}
+		toConvert = append(toConvert, obj)
+		indexes = append(indexes, i)
+	}
+	if len(toConvert) == 0 {
+		return out, nil
+	}
+
+	converted, err := c.delegate.convert(toConvert, targetGV)
+	if err != nil {
+		return nil, err
+	}
+	for i, obj := range converted {
+		out[indexes[i]] = obj
+	}
+	return out, nil
+}
+
+// nopConverter only sets the apiVersion of the objects.
+type nopConverter struct{}
+
+func (nopConverter) convert(in []*unstructured.Unstructured, targetGV schema.GroupVersion) ([]*unstructured.Unstructured, error) {
+	out := make([]*unstructured.Unstructured, len(in))
+	for i, obj := range in {
+		out[i] = obj.DeepCopy()
+		out[i].SetAPIVersion(targetGV.String())
+	}
+	return out, nil
+}
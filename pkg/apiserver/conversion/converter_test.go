@@ -0,0 +1,885 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var (
+	v1GV = schema.GroupVersion{Group: "stable.example.com", Version: "v1"}
+	v2GV = schema.GroupVersion{Group: "stable.example.com", Version: "v2"}
+)
+
+func newTestCRD(conversion *apiextensions.CustomResourceConversion) *apiextensions.CustomResourceDefinition {
+	return &apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "foos.stable.example.com"},
+		Spec: apiextensions.CustomResourceDefinitionSpec{
+			Group:   "stable.example.com",
+			Version: "v1",
+			Versions: []apiextensions.CustomResourceDefinitionVersion{
+				{Name: "v1", Served: true, Storage: true},
+				{Name: "v2", Served: true, Storage: false},
+			},
+			Conversion: conversion,
+		},
+	}
+}
+
+func newTestObject(apiVersion, name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       "Foo",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{"size": int64(1)},
+	}}
+}
+
+func TestNopConverter(t *testing.T) {
+	c, err := NewConverter(newTestCRD(&apiextensions.CustomResourceConversion{Strategy: apiextensions.NoneConverter}), WebhookOptions{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		in       runtime.Object
+		targetGV schema.GroupVersion
+		expected runtime.Object
+		wantErr  string
+	}{
+		{
+			name:     "same version",
+			in:       newTestObject("stable.example.com/v1", "a"),
+			targetGV: v1GV,
+			expected: newTestObject("stable.example.com/v1", "a"),
+		},
+		{
+			name:     "other version",
+			in:       newTestObject("stable.example.com/v1", "a"),
+			targetGV: v2GV,
+			expected: newTestObject("stable.example.com/v2", "a"),
+		},
+		{
+			name: "list",
+			in: &unstructured.UnstructuredList{
+				Object: map[string]interface{}{"apiVersion": "stable.example.com/v1", "kind": "FooList"},
+				Items:  []unstructured.Unstructured{*newTestObject("stable.example.com/v1", "a"), *newTestObject("stable.example.com/v2", "b")},
+			},
+			targetGV: v2GV,
+			expected: &unstructured.UnstructuredList{
+				Object: map[string]interface{}{"apiVersion": "stable.example.com/v2", "kind": "FooList"},
+				Items:  []unstructured.Unstructured{*newTestObject("stable.example.com/v2", "a"), *newTestObject("stable.example.com/v2", "b")},
+			},
+		},
+		{
+			name:     "invalid target version",
+			in:       newTestObject("stable.example.com/v1", "a"),
+			targetGV: schema.GroupVersion{Group: "stable.example.com", Version: "v3"},
+			wantErr:  "invalid group/version",
+		},
+		{
+			name:     "invalid source version",
+			in:       newTestObject("other.example.com/v1", "a"),
+			targetGV: v2GV,
+			wantErr:  "invalid group/version",
+		},
+	}
+
+	for _, tc := range tests {
+		inCopy := tc.in.DeepCopyObject()
+		out, err := c.Convert(tc.in, tc.targetGV)
+		if len(tc.wantErr) > 0 {
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(out, tc.expected) {
+			t.Errorf("%s: expected %#v, got %#v", tc.name, tc.expected, out)
+		}
+		if !reflect.DeepEqual(tc.in, inCopy) {
+			t.Errorf("%s: input was mutated", tc.name)
+		}
+	}
+}
+
+func TestExpressionConverter(t *testing.T) {
+	c, err := NewConverter(newTestCRD(&apiextensions.CustomResourceConversion{
+		Strategy: apiextensions.ExpressionConverter,
+		Expressions: []apiextensions.ConversionExpression{
+			{
+				FromVersion: "v1",
+				ToVersion:   "v2",
+				FieldMappings: []apiextensions.ConversionFieldMapping{
+					{Path: "spec.replicas", Expression: "self.spec.size"},
+					{Path: "spec.size", Expression: "null"},
+					{Path: "status.observed.size", Expression: "[self.spec.size]"},
+				},
+			},
+			{
+				FromVersion: "v2",
+				ToVersion:   "v1",
+				FieldMappings: []apiextensions.ConversionFieldMapping{
+					{Path: "spec.size", Expression: "self.spec.replicas"},
+					{Path: "spec.replicas", Expression: "null"},
+				},
+			},
+		},
+	}), WebhookOptions{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in := newTestObject("stable.example.com/v1", "a")
+	out, err := c.Convert(in, v2GV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := newTestObject("stable.example.com/v2", "a")
+	expected.Object["spec"] = map[string]interface{}{"replicas": int64(1)}
+	expected.Object["status"] = map[string]interface{}{"observed": map[string]interface{}{"size": []interface{}{int64(1)}}}
+	if !reflect.DeepEqual(out, expected) {
+		t.Errorf("expected %#v, got %#v", expected, out)
+	}
+	if !reflect.DeepEqual(in, newTestObject("stable.example.com/v1", "a")) {
+		t.Errorf("input was mutated: %#v", in)
+	}
+
+	back, err := c.Convert(out, v1GV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = newTestObject("stable.example.com/v1", "a")
+	expected.Object["status"] = map[string]interface{}{"observed": map[string]interface{}{"size": []interface{}{int64(1)}}}
+	if !reflect.DeepEqual(back, expected) {
+		t.Errorf("expected %#v, got %#v", expected, back)
+	}
+}
+
+func TestExpressionConverterErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		mapping   apiextensions.ConversionFieldMapping
+		createErr string
+		wantErr   string
+	}{
+		{
+			name:      "syntax error",
+			mapping:   apiextensions.ConversionFieldMapping{Path: "spec.replicas", Expression: "self.spec.size +"},
+			createErr: "invalid expression for spec.replicas",
+		},
+		{
+			name:    "evaluation error",
+			mapping: apiextensions.ConversionFieldMapping{Path: "spec.replicas", Expression: "self.spec.size / 0"},
+			wantErr: "conversion of a from v1 to v2",
+		},
+		{
+			name:    "parent is not an object",
+			mapping: apiextensions.ConversionFieldMapping{Path: "spec.size.value", Expression: "self.spec.size"},
+			wantErr: "spec.size is not an object",
+		},
+	}
+	for _, tc := range tests {
+		c, err := NewConverter(newTestCRD(&apiextensions.CustomResourceConversion{
+			Strategy: apiextensions.ExpressionConverter,
+			Expressions: []apiextensions.ConversionExpression{
+				{FromVersion: "v1", ToVersion: "v2", FieldMappings: []apiextensions.ConversionFieldMapping{tc.mapping}},
+			},
+		}), WebhookOptions{}, nil)
+		if len(tc.createErr) > 0 {
+			if err == nil || !strings.Contains(err.Error(), tc.createErr) {
+				t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.createErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		_, err = c.Convert(newTestObject("stable.example.com/v1", "a"), v2GV)
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.wantErr, err)
+		}
+	}
+}
+
+// customConverterFunc converts custom resources with a function.
+type customConverterFunc func(in []*unstructured.Unstructured, targetGV schema.GroupVersion) ([]*unstructured.Unstructured, error)
+
+func (f customConverterFunc) Convert(_ *apiextensions.CustomResourceDefinition, in []*unstructured.Unstructured, targetGV schema.GroupVersion) ([]*unstructured.Unstructured, error) {
+	return f(in, targetGV)
+}
+
+func TestCRConverterFactory(t *testing.T) {
+	setSize := customConverterFunc(func(in []*unstructured.Unstructured, targetGV schema.GroupVersion) ([]*unstructured.Unstructured, error) {
+		out := make([]*unstructured.Unstructured, 0, len(in))
+		for _, obj := range in {
+			converted := obj.DeepCopy()
+			converted.SetAPIVersion(targetGV.String())
+			converted.Object["spec"] = map[string]interface{}{"size": int64(2)}
+			out = append(out, converted)
+		}
+		return out, nil
+	})
+	url := "https://127.0.0.1:1/convert"
+	webhookCRD := newTestCRD(&apiextensions.CustomResourceConversion{
+		Strategy:            apiextensions.WebhookConverter,
+		WebhookClientConfig: &apiextensions.WebhookClientConfig{URL: &url},
+	})
+
+	tests := []struct {
+		name      string
+		group     string
+		converter CustomConverter
+		expected  runtime.Object
+		wantErr   string
+	}{
+		{
+			name:      "custom converter replaces the webhook",
+			group:     "stable.example.com",
+			converter: setSize,
+			expected: func() runtime.Object {
+				obj := newTestObject("stable.example.com/v2", "a")
+				obj.Object["spec"] = map[string]interface{}{"size": int64(2)}
+				return obj
+			}(),
+		},
+		{
+			name:      "custom converter of another group",
+			group:     "other.example.com",
+			converter: setSize,
+			wantErr:   "conversion webhook",
+		},
+		{
+			name:  "custom converter error",
+			group: "stable.example.com",
+			converter: customConverterFunc(func(in []*unstructured.Unstructured, targetGV schema.GroupVersion) ([]*unstructured.Unstructured, error) {
+				return nil, fmt.Errorf("unsupported")
+			}),
+			wantErr: "custom conversion to stable.example.com/v2 failed: unsupported",
+		},
+		{
+			name:  "missing objects",
+			group: "stable.example.com",
+			converter: customConverterFunc(func(in []*unstructured.Unstructured, targetGV schema.GroupVersion) ([]*unstructured.Unstructured, error) {
+				return nil, nil
+			}),
+			wantErr: "returned 0 objects, expected 1",
+		},
+		{
+			name:  "wrong version",
+			group: "stable.example.com",
+			converter: customConverterFunc(func(in []*unstructured.Unstructured, targetGV schema.GroupVersion) ([]*unstructured.Unstructured, error) {
+				return in, nil
+			}),
+			wantErr: `returned an object of version "stable.example.com/v1"`,
+		},
+	}
+
+	for _, tc := range tests {
+		f := NewCRConverterFactory()
+		if err := f.Register(tc.group, tc.converter); err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		c, err := f.NewConverter(webhookCRD, WebhookOptions{}, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		out, err := c.Convert(newTestObject("stable.example.com/v1", "a"), v2GV)
+		if len(tc.wantErr) > 0 {
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(out, tc.expected) {
+			t.Errorf("%s: expected %#v, got %#v", tc.name, tc.expected, out)
+		}
+	}
+
+	f := NewCRConverterFactory()
+	if err := f.Register("stable.example.com", setSize); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Register("stable.example.com", setSize); err == nil {
+		t.Errorf("expected an error registering a second converter for the same group")
+	}
+	var nilFactory *CRConverterFactory
+	if _, err := nilFactory.NewConverter(newTestCRD(nil), WebhookOptions{}, nil); err != nil {
+		t.Errorf("unexpected error creating a converter with a nil factory: %v", err)
+	}
+}
+
+func TestWebhookConverter(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func(review *v1beta1.ConversionReview) *v1beta1.ConversionResponse
+		wantErr string
+	}{
+		{
+			name: "success",
+			handler: func(review *v1beta1.ConversionReview) *v1beta1.ConversionResponse {
+				resp := &v1beta1.ConversionResponse{UID: review.Request.UID, Result: metav1.Status{Status: metav1.StatusSuccess}}
+				for _, raw := range review.Request.Objects {
+					u := map[string]interface{}{}
+					if err := json.Unmarshal(raw.Raw, &u); err != nil {
+						t.Fatal(err)
+					}
+					u["apiVersion"] = review.Request.DesiredAPIVersion
+					u["spec"] = map[string]interface{}{"replicas": u["spec"].(map[string]interface{})["size"]}
+					metadata := u["metadata"].(map[string]interface{})
+					metadata["labels"] = map[string]interface{}{"converted": "true"}
+					metadata["generation"] = 42
+					data, _ := json.Marshal(u)
+					resp.ConvertedObjects = append(resp.ConvertedObjects, runtime.RawExtension{Raw: data})
+				}
+				return resp
+			},
+		},
+		{
+			name: "failure",
+			handler: func(review *v1beta1.ConversionReview) *v1beta1.ConversionResponse {
+				return &v1beta1.ConversionResponse{UID: review.Request.UID, Result: metav1.Status{Status: metav1.StatusFailure, Message: "no way"}}
+			},
+			wantErr: "no way",
+		},
+		{
+			name: "wrong uid",
+			handler: func(review *v1beta1.ConversionReview) *v1beta1.ConversionResponse {
+				return &v1beta1.ConversionResponse{UID: "wrong", Result: metav1.Status{Status: metav1.StatusSuccess}}
+			},
+			wantErr: "uid",
+		},
+		{
+			name: "missing objects",
+			handler: func(review *v1beta1.ConversionReview) *v1beta1.ConversionResponse {
+				return &v1beta1.ConversionResponse{UID: review.Request.UID, Result: metav1.Status{Status: metav1.StatusSuccess}}
+			},
+			wantErr: "returned 0 objects, expected 1",
+		},
+		{
+			name: "unconverted apiVersion",
+			handler: func(review *v1beta1.ConversionReview) *v1beta1.ConversionResponse {
+				return &v1beta1.ConversionResponse{UID: review.Request.UID, Result: metav1.Status{Status: metav1.StatusSuccess}, ConvertedObjects: review.Request.Objects}
+			},
+			wantErr: "must have apiVersion",
+		},
+	}
+
+	for _, tc := range tests {
+		handler := tc.handler
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			review := &v1beta1.ConversionReview{}
+			if err := json.NewDecoder(r.Body).Decode(review); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			review.Response = handler(review)
+			review.Request = nil
+			json.NewEncoder(w).Encode(review)
+		}))
+
+		caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.TLS.Certificates[0].Certificate[0]})
+		url := server.URL
+		c, err := NewConverter(newTestCRD(&apiextensions.CustomResourceConversion{
+			Strategy:            apiextensions.WebhookConverter,
+			WebhookClientConfig: &apiextensions.WebhookClientConfig{URL: &url, CABundle: caBundle},
+		}), WebhookOptions{}, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+
+		out, err := c.Convert(newTestObject("stable.example.com/v1", "a"), v2GV)
+		server.Close()
+
+		if len(tc.wantErr) > 0 {
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.wantErr, err)
+			} else if !IsWebhookError(err) {
+				t.Errorf("%s: expected a webhook error, got %T", tc.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+
+		expected := newTestObject("stable.example.com/v2", "a")
+		expected.Object["spec"] = map[string]interface{}{"replicas": int64(1)}
+		expected.SetLabels(map[string]string{"converted": "true"})
+		if !reflect.DeepEqual(out, expected) {
+			t.Errorf("%s: expected %#v, got %#v", tc.name, expected, out)
+		}
+	}
+}
+
+type fakeReviewVersionRecorder struct {
+	recorded []string
+	degraded []bool
+}
+
+func (r *fakeReviewVersionRecorder) RecordConversionReviewVersion(name string, uid types.UID, version string) {
+	r.recorded = append(r.recorded, version)
+}
+
+func (r *fakeReviewVersionRecorder) RecordConversionDegraded(name string, uid types.UID, degraded bool, message string) {
+	r.degraded = append(r.degraded, degraded)
+}
+
+func TestWebhookConverterReviewVersionNegotiation(t *testing.T) {
+	tests := []struct {
+		name string
+		// accepted are the ConversionReview apiVersions understood by the webhook
+		accepted       map[string]bool
+		reviewVersions []string
+		// rejectWith is the status code of the webhook for other apiVersions. By default, it
+		// answers with a v1beta1 ConversionReview.
+		rejectWith       int
+		expectedRequests []string
+		expectedRecorded []string
+		wantErr          string
+	}{
+		{
+			name:             "v1",
+			accepted:         map[string]bool{"apiextensions.k8s.io/v1": true},
+			reviewVersions:   []string{"v1", "v1beta1"},
+			expectedRequests: []string{"apiextensions.k8s.io/v1", "apiextensions.k8s.io/v1"},
+			expectedRecorded: []string{"v1"},
+		},
+		{
+			name:             "fallback on unsupported media type",
+			accepted:         map[string]bool{"apiextensions.k8s.io/v1beta1": true},
+			reviewVersions:   []string{"v1", "v1beta1"},
+			rejectWith:       http.StatusUnsupportedMediaType,
+			expectedRequests: []string{"apiextensions.k8s.io/v1", "apiextensions.k8s.io/v1beta1", "apiextensions.k8s.io/v1beta1"},
+			expectedRecorded: []string{"v1beta1"},
+		},
+		{
+			name:             "fallback on mismatched apiVersion",
+			accepted:         map[string]bool{"apiextensions.k8s.io/v1beta1": true},
+			reviewVersions:   []string{"v1", "v1beta1"},
+			expectedRequests: []string{"apiextensions.k8s.io/v1", "apiextensions.k8s.io/v1beta1", "apiextensions.k8s.io/v1beta1"},
+			expectedRecorded: []string{"v1beta1"},
+		},
+		{
+			name:             "unknown versions are skipped",
+			accepted:         map[string]bool{"apiextensions.k8s.io/v1beta1": true},
+			reviewVersions:   []string{"v2", "v1beta1"},
+			expectedRequests: []string{"apiextensions.k8s.io/v1beta1", "apiextensions.k8s.io/v1beta1"},
+			expectedRecorded: []string{"v1beta1"},
+		},
+		{
+			name:             "no accepted version",
+			accepted:         map[string]bool{"apiextensions.k8s.io/v1beta1": true},
+			reviewVersions:   []string{"v1"},
+			rejectWith:       http.StatusUnsupportedMediaType,
+			expectedRequests: []string{"apiextensions.k8s.io/v1", "apiextensions.k8s.io/v1"},
+			wantErr:          "ConversionReview v1 not supported",
+		},
+	}
+
+	for _, tc := range tests {
+		var requests []string
+		accepted, rejectWith := tc.accepted, tc.rejectWith
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			review := &v1beta1.ConversionReview{}
+			if err := json.NewDecoder(r.Body).Decode(review); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			requests = append(requests, review.APIVersion)
+			if !accepted[review.APIVersion] {
+				if rejectWith != 0 {
+					http.Error(w, "unsupported", rejectWith)
+					return
+				}
+				review.APIVersion = v1beta1.SchemeGroupVersion.String()
+			}
+			review.Response = &v1beta1.ConversionResponse{UID: review.Request.UID, Result: metav1.Status{Status: metav1.StatusSuccess}}
+			for _, raw := range review.Request.Objects {
+				u := map[string]interface{}{}
+				if err := json.Unmarshal(raw.Raw, &u); err != nil {
+					t.Fatal(err)
+				}
+				u["apiVersion"] = review.Request.DesiredAPIVersion
+				data, _ := json.Marshal(u)
+				review.Response.ConvertedObjects = append(review.Response.ConvertedObjects, runtime.RawExtension{Raw: data})
+			}
+			review.Request = nil
+			json.NewEncoder(w).Encode(review)
+		}))
+
+		caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.TLS.Certificates[0].Certificate[0]})
+		url := server.URL
+		recorder := &fakeReviewVersionRecorder{}
+		c, err := NewConverter(newTestCRD(&apiextensions.CustomResourceConversion{
+			Strategy:                 apiextensions.WebhookConverter,
+			WebhookClientConfig:      &apiextensions.WebhookClientConfig{URL: &url, CABundle: caBundle},
+			ConversionReviewVersions: tc.reviewVersions,
+		}), WebhookOptions{}, recorder)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+
+		// the second conversion uses the negotiated version right away
+		for i := 0; i < 2; i++ {
+			_, err = c.Convert(newTestObject("stable.example.com/v1", "a"), v2GV)
+			if len(tc.wantErr) > 0 {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.wantErr, err)
+				}
+			} else if err != nil {
+				t.Errorf("%s: unexpected error: %v", tc.name, err)
+			}
+		}
+		server.Close()
+
+		if !reflect.DeepEqual(requests, tc.expectedRequests) {
+			t.Errorf("%s: expected requests %v, got %v", tc.name, tc.expectedRequests, requests)
+		}
+		if !reflect.DeepEqual(recorder.recorded, tc.expectedRecorded) {
+			t.Errorf("%s: expected recorded versions %v, got %v", tc.name, tc.expectedRecorded, recorder.recorded)
+		}
+	}
+}
+
+func TestWebhookConverterRetriesAndDegradation(t *testing.T) {
+	var lock sync.Mutex
+	requests, failing := 0, true
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		requests++
+		fail := failing
+		lock.Unlock()
+		if fail {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		review := &v1beta1.ConversionReview{}
+		if err := json.NewDecoder(r.Body).Decode(review); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		review.Response = &v1beta1.ConversionResponse{UID: review.Request.UID, Result: metav1.Status{Status: metav1.StatusSuccess}}
+		for _, raw := range review.Request.Objects {
+			u := map[string]interface{}{}
+			if err := json.Unmarshal(raw.Raw, &u); err != nil {
+				t.Fatal(err)
+			}
+			u["apiVersion"] = review.Request.DesiredAPIVersion
+			data, _ := json.Marshal(u)
+			review.Response.ConvertedObjects = append(review.Response.ConvertedObjects, runtime.RawExtension{Raw: data})
+		}
+		review.Request = nil
+		json.NewEncoder(w).Encode(review)
+	}))
+	defer server.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.TLS.Certificates[0].Certificate[0]})
+	url := server.URL
+	recorder := &fakeReviewVersionRecorder{}
+	c, err := NewConverter(newTestCRD(&apiextensions.CustomResourceConversion{
+		Strategy:            apiextensions.WebhookConverter,
+		WebhookClientConfig: &apiextensions.WebhookClientConfig{URL: &url, CABundle: caBundle},
+	}), WebhookOptions{
+		Retries:          2,
+		RetryBackoff:     time.Millisecond,
+		FailureThreshold: 2,
+		DegradedCooldown: time.Hour,
+	}, recorder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	webhook := c.(*crConverter).delegate.(*webhookConverter)
+	countRequests := func() int {
+		lock.Lock()
+		defer lock.Unlock()
+		return requests
+	}
+
+	// every failed conversion calls the webhook once and retries twice
+	for i := 0; i < 2; i++ {
+		if _, err := c.Convert(newTestObject("stable.example.com/v1", "a"), v2GV); err == nil || !strings.Contains(err.Error(), "unexpected status code 503") {
+			t.Errorf("expected the webhook to fail, got %v", err)
+		}
+	}
+	if n := countRequests(); n != 6 {
+		t.Errorf("expected 6 requests, got %d", n)
+	}
+	if expected := []bool{true}; !reflect.DeepEqual(recorder.degraded, expected) {
+		t.Errorf("expected recorded degradation %v, got %v", expected, recorder.degraded)
+	}
+
+	// the degraded webhook is not called during the cooldown
+	lock.Lock()
+	failing = false
+	lock.Unlock()
+	if _, err := c.Convert(newTestObject("stable.example.com/v1", "a"), v2GV); err == nil || !strings.Contains(err.Error(), "is degraded after 2 consecutive failures") {
+		t.Errorf("expected the conversion to fail fast, got %v", err)
+	}
+	if n := countRequests(); n != 6 {
+		t.Errorf("expected no further request, got %d", n)
+	}
+
+	// after the cooldown, a successful call ends the degradation
+	webhook.lock.Lock()
+	webhook.degradedUntil = time.Time{}
+	webhook.lock.Unlock()
+	if _, err := c.Convert(newTestObject("stable.example.com/v1", "a"), v2GV); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if expected := []bool{true, false}; !reflect.DeepEqual(recorder.degraded, expected) {
+		t.Errorf("expected recorded degradation %v, got %v", expected, recorder.degraded)
+	}
+}
+
+func TestWebhookConverterThrottling(t *testing.T) {
+	called, unblock := make(chan struct{}, 10), make(chan struct{})
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called <- struct{}{}
+		<-unblock
+		review := &v1beta1.ConversionReview{}
+		if err := json.NewDecoder(r.Body).Decode(review); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		review.Response = &v1beta1.ConversionResponse{UID: review.Request.UID, Result: metav1.Status{Status: metav1.StatusSuccess}}
+		for _, raw := range review.Request.Objects {
+			u := map[string]interface{}{}
+			if err := json.Unmarshal(raw.Raw, &u); err != nil {
+				t.Fatal(err)
+			}
+			u["apiVersion"] = review.Request.DesiredAPIVersion
+			data, _ := json.Marshal(u)
+			review.Response.ConvertedObjects = append(review.Response.ConvertedObjects, runtime.RawExtension{Raw: data})
+		}
+		review.Request = nil
+		json.NewEncoder(w).Encode(review)
+	}))
+	defer server.Close()
+	defer close(unblock)
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.TLS.Certificates[0].Certificate[0]})
+	url := server.URL
+	newConverter := func(options WebhookOptions) Converter {
+		c, err := NewConverter(newTestCRD(&apiextensions.CustomResourceConversion{
+			Strategy:            apiextensions.WebhookConverter,
+			WebhookClientConfig: &apiextensions.WebhookClientConfig{URL: &url, CABundle: caBundle},
+		}), options, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	// a conversion waiting longer than the timeout for the call in flight fails
+	c := newConverter(WebhookOptions{MaxInFlight: 1})
+	c.(*crConverter).delegate.(*webhookConverter).queueTimeout = 10 * time.Millisecond
+	done := make(chan error)
+	go func() {
+		_, err := c.Convert(newTestObject("stable.example.com/v1", "a"), v2GV)
+		done <- err
+	}()
+	<-called
+	_, err := c.Convert(newTestObject("stable.example.com/v1", "b"), v2GV)
+	if err == nil || !strings.Contains(err.Error(), "1 calls in flight") {
+		t.Errorf("expected the conversion to be throttled, got %v", err)
+	} else if !IsWebhookError(err) {
+		t.Errorf("expected a webhook error, got %v", err)
+	}
+	unblock <- struct{}{}
+	if err := <-done; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// conversions exceeding the rate limit fail without calling the webhook
+	c = newConverter(WebhookOptions{QPS: 0.001, Burst: 1})
+	go func() {
+		_, err := c.Convert(newTestObject("stable.example.com/v1", "a"), v2GV)
+		done <- err
+	}()
+	<-called
+	unblock <- struct{}{}
+	if err := <-done; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := c.Convert(newTestObject("stable.example.com/v1", "b"), v2GV); err == nil || !strings.Contains(err.Error(), "calls per second") {
+		t.Errorf("expected the conversion to be throttled, got %v", err)
+	}
+	if len(called) != 0 {
+		t.Errorf("expected no further call of the webhook")
+	}
+}
+
+func TestWebhookConverterInvalidCABundle(t *testing.T) {
+	url := "https://example.com/convert"
+	_, err := NewConverter(newTestCRD(&apiextensions.CustomResourceConversion{
+		Strategy:            apiextensions.WebhookConverter,
+		WebhookClientConfig: &apiextensions.WebhookClientConfig{URL: &url, CABundle: []byte("garbage")},
+	}), WebhookOptions{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid caBundle") {
+		t.Errorf("expected invalid caBundle error, got %v", err)
+	}
+}
+
+func TestStorageCodec(t *testing.T) {
+	c, err := NewConverter(newTestCRD(nil), WebhookOptions{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	codec := NewStorageCodec(unstructured.UnstructuredJSONScheme, c, v1GV, v2GV, schema.GroupVersion{})
+
+	buf := &bytes.Buffer{}
+	if err := codec.Encode(newTestObject("stable.example.com/v2", "a"), buf); err != nil {
+		t.Fatal(err)
+	}
+	stored := &unstructured.Unstructured{}
+	if err := json.Unmarshal(buf.Bytes(), &stored.Object); err != nil {
+		t.Fatal(err)
+	}
+	if stored.GetAPIVersion() != "stable.example.com/v1" {
+		t.Errorf("expected object to be stored as stable.example.com/v1, got %s", stored.GetAPIVersion())
+	}
+
+	into := &unstructured.Unstructured{}
+	obj, gvk, err := codec.Decode(buf.Bytes(), nil, into)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj != into {
+		t.Errorf("expected decoded object to be returned in into")
+	}
+	if into.GetAPIVersion() != "stable.example.com/v2" || gvk.Version != "v2" {
+		t.Errorf("expected object to be decoded as stable.example.com/v2, got %s and gvk %v", into.GetAPIVersion(), gvk)
+	}
+}
+
+func TestStorageCodecWithoutDecodeVersion(t *testing.T) {
+	c, err := NewConverter(newTestCRD(nil), WebhookOptions{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	codec := NewStorageCodec(unstructured.UnstructuredJSONScheme, c, v1GV, schema.GroupVersion{}, schema.GroupVersion{})
+
+	buf := &bytes.Buffer{}
+	if err := unstructured.UnstructuredJSONScheme.Encode(newTestObject("stable.example.com/v2", "a"), buf); err != nil {
+		t.Fatal(err)
+	}
+	obj, _, err := codec.Decode(buf.Bytes(), nil, &unstructured.Unstructured{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if apiVersion := obj.(*unstructured.Unstructured).GetAPIVersion(); apiVersion != "stable.example.com/v2" {
+		t.Errorf("expected object to be decoded in the persisted version stable.example.com/v2, got %s", apiVersion)
+	}
+}
+
+func TestStorageCodecMirror(t *testing.T) {
+	c, err := NewConverter(newTestCRD(nil), WebhookOptions{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// objects are stored in v2 and mirrored in v1, as while rolling out v2 as storage version
+	codec := NewStorageCodec(unstructured.UnstructuredJSONScheme, c, v2GV, v2GV, v1GV)
+
+	obj := newTestObject("stable.example.com/v1", "a")
+	// clients cannot set the mirrored copy
+	obj.SetAnnotations(map[string]string{StorageMirrorAnnotation: `{"apiVersion":"stable.example.com/v1","spec":{"size":5}}`})
+	buf := &bytes.Buffer{}
+	if err := codec.Encode(obj, buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, found := obj.GetAnnotations()[StorageMirrorAnnotation]; !found {
+		t.Errorf("expected the encoded object not to be mutated")
+	}
+	stored := &unstructured.Unstructured{}
+	if err := json.Unmarshal(buf.Bytes(), &stored.Object); err != nil {
+		t.Fatal(err)
+	}
+	if stored.GetAPIVersion() != "stable.example.com/v2" {
+		t.Errorf("expected object to be stored as stable.example.com/v2, got %s", stored.GetAPIVersion())
+	}
+	mirror := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(stored.GetAnnotations()[StorageMirrorAnnotation]), &mirror); err != nil {
+		t.Fatalf("expected the mirrored copy in the annotation: %v", err)
+	}
+	expected := map[string]interface{}{"apiVersion": "stable.example.com/v1", "kind": "Foo", "spec": map[string]interface{}{"size": float64(1)}}
+	if !reflect.DeepEqual(expected, mirror) {
+		t.Errorf("expected mirrored copy %v, got %v", expected, mirror)
+	}
+
+	// the annotation is not returned to clients
+	decoded, _, err := codec.Decode(buf.Bytes(), nil, &unstructured.Unstructured{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if annotations := decoded.(*unstructured.Unstructured).GetAnnotations(); len(annotations) > 0 {
+		t.Errorf("expected no annotations, got %v", annotations)
+	}
+
+	// after rolling back the storage version, objects are read from the mirrored copy
+	stored.SetAnnotations(map[string]string{StorageMirrorAnnotation: `{"apiVersion":"stable.example.com/v1","kind":"Foo","spec":{"size":2}}`})
+	data, err := json.Marshal(stored.Object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rolledBack := NewStorageCodec(unstructured.UnstructuredJSONScheme, c, v1GV, v1GV, schema.GroupVersion{})
+	decoded, gvk, err := rolledBack.Decode(data, nil, &unstructured.Unstructured{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := decoded.(*unstructured.Unstructured)
+	if u.GetAPIVersion() != "stable.example.com/v1" || gvk.Version != "v1" {
+		t.Errorf("expected object to be decoded as stable.example.com/v1, got %s and gvk %v", u.GetAPIVersion(), gvk)
+	}
+	if spec := u.Object["spec"]; !reflect.DeepEqual(spec, map[string]interface{}{"size": int64(2)}) {
+		t.Errorf("expected the mirrored spec, got %v", spec)
+	}
+	if u.GetName() != "a" || len(u.GetAnnotations()) > 0 {
+		t.Errorf("expected the metadata of the stored object without annotations, got %v", u.Object["metadata"])
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// CustomConverter converts custom resources in-process. It is implemented by an embedding server
+// and registered with a CRConverterFactory for the CustomResourceDefinitions of an API group.
+type CustomConverter interface {
+	// Convert returns copies of the objects converted to the target version, in the same order. The
+	// objects are custom resources of the given CustomResourceDefinition, none of them in the target
+	// version, and must not be mutated.
+	Convert(crd *apiextensions.CustomResourceDefinition, in []*unstructured.Unstructured, targetGV schema.GroupVersion) ([]*unstructured.Unstructured, error)
+}
+
+// CRConverterFactory creates the converters of CustomResourceDefinitions. The custom resources of
+// API groups with a registered CustomConverter are converted by it, bypassing the conversion
+// strategy of the CustomResourceDefinitions, i.e. without calling any webhook. A nil factory creates
+// the converters of the conversion strategies.
+type CRConverterFactory struct {
+	lock             sync.RWMutex
+	customConverters map[string]CustomConverter
+}
+
+// NewCRConverterFactory returns a factory without custom converters.
+func NewCRConverterFactory() *CRConverterFactory {
+	return &CRConverterFactory{customConverters: map[string]CustomConverter{}}
+}
+
+// Register registers the converter for the CustomResourceDefinitions of the given API group. It
+// applies to the converters created afterwards, hence converters should be registered before the
+// server is started. Only one converter can be registered per group.
+func (f *CRConverterFactory) Register(group string, converter CustomConverter) error {
+	if len(group) == 0 {
+		return fmt.Errorf("the group of a custom converter must not be empty")
+	}
+	if converter == nil {
+		return fmt.Errorf("the custom converter of group %q must not be nil", group)
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if _, found := f.customConverters[group]; found {
+		return fmt.Errorf("a custom converter is already registered for group %q", group)
+	}
+	f.customConverters[group] = converter
+	return nil
+}
+
+// NewConverter returns the converter for the custom resources of the given CustomResourceDefinition.
+// It is the registered custom converter of its group, if any, and otherwise the converter of its
+// conversion strategy as returned by NewConverter.
+func (f *CRConverterFactory) NewConverter(crd *apiextensions.CustomResourceDefinition, webhookOptions WebhookOptions, recorder WebhookRecorder) (Converter, error) {
+	if f != nil {
+		f.lock.RLock()
+		custom, found := f.customConverters[crd.Spec.Group]
+		f.lock.RUnlock()
+		if found {
+			return newCRConverter(crd, &customConverter{crd: crd, converter: custom}), nil
+		}
+	}
+	return NewConverter(crd, webhookOptions, recorder)
+}
+
+// customConverter adapts a CustomConverter and checks the objects it returns.
+type customConverter struct {
+	crd       *apiextensions.CustomResourceDefinition
+	converter CustomConverter
+}
+
+func (c *customConverter) convert(in []*unstructured.Unstructured, targetGV schema.GroupVersion) ([]*unstructured.Unstructured, error) {
+	out, err := c.converter.Convert(c.crd, in, targetGV)
+	if err != nil {
+		return nil, fmt.Errorf("custom conversion to %s failed: %v", targetGV, err)
+	}
+	if len(out) != len(in) {
+		return nil, fmt.Errorf("custom conversion to %s returned %d objects, expected %d", targetGV, len(out), len(in))
+	}
+	for i, obj := range out {
+		if obj == nil {
+			return nil, fmt.Errorf("custom conversion to %s returned no object at index %d", targetGV, i)
+		}
+		if obj.GetAPIVersion() != targetGV.String() {
+			return nil, fmt.Errorf("custom conversion to %s returned an object of version %q at index %d", targetGV, obj.GetAPIVersion(), i)
+		}
+	}
+	return out, nil
+}
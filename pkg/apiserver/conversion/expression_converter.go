@@ -0,0 +1,153 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// expressionConverter converts objects by evaluating the field mappings of a
+// CustomResourceDefinition in-process.
+type expressionConverter struct {
+	name string
+	// mappings are keyed by the versions converted from and to.
+	mappings map[versionPair][]fieldMapping
+}
+
+type versionPair struct {
+	from, to string
+}
+
+// fieldMapping is a compiled ConversionFieldMapping.
+type fieldMapping struct {
+	path    []string
+	program *cel.ValueProgram
+}
+
+func newExpressionConverter(crd *apiextensions.CustomResourceDefinition) (*expressionConverter, error) {
+	mappings := map[versionPair][]fieldMapping{}
+	for _, e := range crd.Spec.Conversion.Expressions {
+		validation, err := apiextensions.GetSchemaForVersion(crd, e.FromVersion)
+		if err != nil {
+			return nil, err
+		}
+		var s *apiextensions.JSONSchemaProps
+		if validation != nil {
+			s = validation.OpenAPIV3Schema
+		}
+
+		pair := versionPair{from: e.FromVersion, to: e.ToVersion}
+		for _, m := range e.FieldMappings {
+			program, err := cel.CompileValue(m.Expression, s, true)
+			if err != nil {
+				return nil, fmt.Errorf("invalid expression for %s in the conversion from %s to %s of CRD %s: %v", m.Path, e.FromVersion, e.ToVersion, crd.Name, err)
+			}
+			mappings[pair] = append(mappings[pair], fieldMapping{path: strings.Split(m.Path, "."), program: program})
+		}
+	}
+
+	return &expressionConverter{
+		name:     crd.Name,
+		mappings: mappings,
+	}, nil
+}
+
+func (c *expressionConverter) convert(in []*unstructured.Unstructured, targetGV schema.GroupVersion) ([]*unstructured.Unstructured, error) {
+	out := make([]*unstructured.Unstructured, len(in))
+	for i, obj := range in {
+		fromGV, err := schema.ParseGroupVersion(obj.GetAPIVersion())
+		if err != nil {
+			return nil, err
+		}
+
+		converted := obj.DeepCopy()
+		converted.SetAPIVersion(targetGV.String())
+		for _, m := range c.mappings[versionPair{from: fromGV.Version, to: targetGV.Version}] {
+			v, err := m.program.Eval(obj.Object)
+			if err != nil {
+				return nil, fmt.Errorf("conversion of %s from %s to %s for CRD %s failed: %s: %v", obj.GetName(), fromGV.Version, targetGV.Version, c.name, strings.Join(m.path, "."), err)
+			}
+			if v == nil {
+				removeField(converted.Object, m.path)
+				continue
+			}
+			if err := setField(converted.Object, m.path, deepCopyJSON(v)); err != nil {
+				return nil, fmt.Errorf("conversion of %s from %s to %s for CRD %s failed: %s: %v", obj.GetName(), fromGV.Version, targetGV.Version, c.name, strings.Join(m.path, "."), err)
+			}
+		}
+		out[i] = converted
+	}
+	return out, nil
+}
+
+// setField sets the field at the given path, creating missing parent objects.
+func setField(obj map[string]interface{}, path []string, value interface{}) error {
+	m := obj
+	for i, name := range path[:len(path)-1] {
+		switch child := m[name].(type) {
+		case map[string]interface{}:
+			m = child
+		case nil:
+			created := map[string]interface{}{}
+			m[name] = created
+			m = created
+		default:
+			return fmt.Errorf("%s is not an object", strings.Join(path[:i+1], "."))
+		}
+	}
+	m[path[len(path)-1]] = value
+	return nil
+}
+
+// removeField removes the field at the given path if it exists.
+func removeField(obj map[string]interface{}, path []string) {
+	m := obj
+	for _, name := range path[:len(path)-1] {
+		child, ok := m[name].(map[string]interface{})
+		if !ok {
+			return
+		}
+		m = child
+	}
+	delete(m, path[len(path)-1])
+}
+
+// deepCopyJSON copies a result of an expression, which can share values with the evaluated object.
+func deepCopyJSON(x interface{}) interface{} {
+	switch x := x.(type) {
+	case map[string]interface{}:
+		clone := make(map[string]interface{}, len(x))
+		for k, v := range x {
+			clone[k] = deepCopyJSON(v)
+		}
+		return clone
+	case []interface{}:
+		clone := make([]interface{}, len(x))
+		for i, v := range x {
+			clone[i] = deepCopyJSON(v)
+		}
+		return clone
+	default:
+		return x
+	}
+}
@@ -0,0 +1,535 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/metrics"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// defaultWebhookTimeout is the time after which a conversion webhook call is aborted if the
+// WebhookOptions do not set a timeout.
+const defaultWebhookTimeout = 30 * time.Second
+
+// WebhookOptions configure the clients of conversion webhooks.
+type WebhookOptions struct {
+	// Timeout is the time after which a single call of the webhook is aborted. If zero, 30s are used.
+	Timeout time.Duration
+	// Retries is how often a call is repeated if the webhook cannot be reached or responds with a
+	// server error.
+	Retries int
+	// RetryBackoff is the wait before the first retry. It doubles with every further retry.
+	RetryBackoff time.Duration
+	// FailureThreshold is the number of consecutive failed conversions after which the webhook is
+	// considered degraded and conversions fail fast without calling it. Zero disables this.
+	FailureThreshold int
+	// DegradedCooldown is the time conversions fail fast after the webhook became degraded, before
+	// it is called again.
+	DegradedCooldown time.Duration
+	// MaxInFlight is the maximum number of conversions of a CustomResourceDefinition which call its
+	// webhook concurrently. Further conversions wait up to Timeout for a free slot. Zero means no limit.
+	MaxInFlight int
+	// QPS is the maximum rate of conversions of a CustomResourceDefinition which call its webhook.
+	// Conversions exceeding it, after a burst of Burst conversions, fail fast. Zero means no limit.
+	QPS float32
+	// Burst is the number of conversions which may exceed QPS at once.
+	Burst int
+}
+
+// WebhookRecorder is notified of the state of the conversion webhook of a CustomResourceDefinition.
+type WebhookRecorder interface {
+	ReviewVersionRecorder
+	DegradationRecorder
+}
+
+// supportedReviewVersions are the ConversionReview versions this server can send. The v1 and
+// v1beta1 ConversionReviews only differ in their apiVersion.
+var supportedReviewVersions = map[string]bool{"v1": true, "v1beta1": true}
+
+// ReviewVersionRecorder is notified of the ConversionReview version negotiated with the
+// conversion webhook of a CustomResourceDefinition.
+type ReviewVersionRecorder interface {
+	RecordConversionReviewVersion(name string, uid types.UID, version string)
+}
+
+// DegradationRecorder is notified when the conversion webhook of a CustomResourceDefinition becomes
+// degraded, and when it responds again. The message explains the last failure.
+type DegradationRecorder interface {
+	RecordConversionDegraded(name string, uid types.UID, degraded bool, message string)
+}
+
+// webhookConverter converts objects by sending a ConversionReview to the webhook of a
+// CustomResourceDefinition.
+type webhookConverter struct {
+	client *http.Client
+	url    string
+	name   string
+	uid    types.UID
+	// resource is the plural name of the custom resources, used in the metrics.
+	resource string
+
+	// reviewVersions are the ConversionReview versions accepted by the webhook which this
+	// server supports, in order of preference.
+	reviewVersions []string
+	// recorder is optional.
+	recorder WebhookRecorder
+
+	retries          int
+	retryBackoff     time.Duration
+	failureThreshold int
+	degradedCooldown time.Duration
+
+	lock sync.Mutex
+	// negotiated is the index in reviewVersions of the version the webhook accepted last.
+	negotiated int
+	// recorded is the version last passed to the recorder.
+	recorded string
+	// failures is the number of consecutive failed calls of the webhook.
+	failures int
+	// degraded is true if failures reached the failureThreshold. Conversions fail fast until
+	// degradedUntil.
+	degraded      bool
+	degradedUntil time.Time
+
+	// inFlight holds a token for every conversion calling the webhook. It is nil without limit.
+	inFlight chan struct{}
+	// queueTimeout is how long a conversion waits for a token in inFlight.
+	queueTimeout time.Duration
+	// rateLimiter limits the rate of conversions calling the webhook. It is nil without limit.
+	rateLimiter flowcontrol.RateLimiter
+}
+
+// unsupportedReviewVersionError is returned by call if the webhook rejects the version of the
+// ConversionReview.
+type unsupportedReviewVersionError struct {
+	version string
+	reason  string
+}
+
+func (e *unsupportedReviewVersionError) Error() string {
+	return fmt.Sprintf("ConversionReview %s not supported: %s", e.version, e.reason)
+}
+
+// webhookError is returned by the converter if the conversion webhook fails.
+type webhookError struct {
+	err error
+}
+
+func (e *webhookError) Error() string {
+	return e.err.Error()
+}
+
+// IsWebhookError returns true if err is returned because the conversion webhook of a
+// CustomResourceDefinition failed.
+func IsWebhookError(err error) bool {
+	_, ok := err.(*webhookError)
+	return ok
+}
+
+func newWebhookConverter(crd *apiextensions.CustomResourceDefinition, options WebhookOptions, recorder WebhookRecorder) (*webhookConverter, error) {
+	cc := crd.Spec.Conversion.WebhookClientConfig
+	if cc == nil {
+		return nil, fmt.Errorf("missing webhookClientConfig for conversion webhook of CRD %s", crd.Name)
+	}
+
+	var url string
+	switch {
+	case cc.URL != nil:
+		url = *cc.URL
+	case cc.Service != nil:
+		url = fmt.Sprintf("https://%s.%s.svc:443", cc.Service.Name, cc.Service.Namespace)
+		if cc.Service.Path != nil {
+			url += *cc.Service.Path
+		}
+	default:
+		return nil, fmt.Errorf("missing url or service for conversion webhook of CRD %s", crd.Name)
+	}
+
+	tlsConfig := &tls.Config{}
+	if len(cc.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(cc.CABundle) {
+			return nil, fmt.Errorf("invalid caBundle for conversion webhook of CRD %s", crd.Name)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	reviewVersions := negotiableReviewVersions(crd.Spec.Conversion.ConversionReviewVersions)
+
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+	// connections are kept alive and reused by all conversions of the custom resources
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		Dial: (&net.Dialer{
+			Timeout:   timeout,
+			KeepAlive: 30 * time.Second,
+		}).Dial,
+		TLSHandshakeTimeout: 10 * time.Second,
+		TLSClientConfig:     tlsConfig,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	// a custom TLS config disables HTTP/2 unless it is configured explicitly
+	if err := http2.ConfigureTransport(transport); err != nil {
+		return nil, fmt.Errorf("unable to enable HTTP/2 for conversion webhook of CRD %s: %v", crd.Name, err)
+	}
+
+	var inFlight chan struct{}
+	if options.MaxInFlight > 0 {
+		inFlight = make(chan struct{}, options.MaxInFlight)
+	}
+	var rateLimiter flowcontrol.RateLimiter
+	if options.QPS > 0 {
+		burst := options.Burst
+		if burst < 1 {
+			burst = 1
+		}
+		rateLimiter = flowcontrol.NewTokenBucketRateLimiter(options.QPS, burst)
+	}
+
+	return &webhookConverter{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+		},
+		url:              url,
+		name:             crd.Name,
+		uid:              crd.UID,
+		resource:         crd.Spec.Names.Plural,
+		reviewVersions:   reviewVersions,
+		recorder:         recorder,
+		retries:          options.Retries,
+		retryBackoff:     options.RetryBackoff,
+		failureThreshold: options.FailureThreshold,
+		degradedCooldown: options.DegradedCooldown,
+		inFlight:         inFlight,
+		queueTimeout:     timeout,
+		rateLimiter:      rateLimiter,
+	}, nil
+}
+
+// negotiableReviewVersions returns the ConversionReview versions accepted by the webhook which
+// this server supports, defaulting to v1beta1 if the CustomResourceDefinition lists none.
+func negotiableReviewVersions(versions []string) []string {
+	var ret []string
+	for _, v := range versions {
+		if supportedReviewVersions[v] {
+			ret = append(ret, v)
+		}
+	}
+	if len(ret) == 0 {
+		ret = []string{v1beta1.SchemeGroupVersion.Version}
+	}
+	return ret
+}
+
+func (c *webhookConverter) convert(in []*unstructured.Unstructured, targetGV schema.GroupVersion) (out []*unstructured.Unstructured, err error) {
+	defer func(start time.Time) {
+		metrics.ObserveConversionWebhook(targetGV.WithResource(c.resource), start, err)
+	}(time.Now())
+	defer func() {
+		if err != nil {
+			err = &webhookError{err: err}
+		}
+	}()
+
+	review := &v1beta1.ConversionReview{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "ConversionReview",
+		},
+		Request: &v1beta1.ConversionRequest{
+			UID:               uuid.NewUUID(),
+			DesiredAPIVersion: targetGV.String(),
+			Objects:           make([]runtime.RawExtension, len(in)),
+		},
+	}
+	for i, obj := range in {
+		review.Request.Objects[i] = runtime.RawExtension{Object: obj}
+	}
+
+	if err := c.checkDegraded(); err != nil {
+		return nil, err
+	}
+	release, err := c.acquire(targetGV.WithResource(c.resource))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.negotiateAndCall(review)
+	release()
+	c.recordCall(err)
+	if err != nil {
+		return nil, fmt.Errorf("conversion webhook for %s failed: %v", c.name, err)
+	}
+	if response.UID != review.Request.UID {
+		return nil, fmt.Errorf("conversion webhook for %s returned a response with uid %q, expected %q", c.name, response.UID, review.Request.UID)
+	}
+	if response.Result.Status != metav1.StatusSuccess {
+		return nil, fmt.Errorf("conversion webhook for %s failed: %v", c.name, response.Result.Message)
+	}
+	if len(response.ConvertedObjects) != len(in) {
+		return nil, fmt.Errorf("conversion webhook for %s returned %d objects, expected %d", c.name, len(response.ConvertedObjects), len(in))
+	}
+
+	out = make([]*unstructured.Unstructured, len(in))
+	for i, raw := range response.ConvertedObjects {
+		converted := &unstructured.Unstructured{}
+		if _, _, err := unstructured.UnstructuredJSONScheme.Decode(raw.Raw, nil, converted); err != nil {
+			return nil, fmt.Errorf("conversion webhook for %s returned an invalid object at index %d: %v", c.name, i, err)
+		}
+		if err := validateConvertedObject(in[i], converted, targetGV); err != nil {
+			return nil, fmt.Errorf("conversion webhook for %s returned an invalid object at index %d: %v", c.name, i, err)
+		}
+		restoreObjectMeta(in[i], converted)
+		out[i] = converted
+	}
+	return out, nil
+}
+
+// negotiateAndCall sends the review in the version the webhook accepted last. If the webhook
+// rejects that version, the following accepted versions are tried in turn.
+func (c *webhookConverter) negotiateAndCall(review *v1beta1.ConversionReview) (*v1beta1.ConversionResponse, error) {
+	c.lock.Lock()
+	start := c.negotiated
+	c.lock.Unlock()
+
+	var lastErr error
+	for i := 0; i < len(c.reviewVersions); i++ {
+		index := (start + i) % len(c.reviewVersions)
+		version := c.reviewVersions[index]
+		response, err := c.call(review, version)
+		if _, ok := err.(*unsupportedReviewVersionError); ok {
+			lastErr = err
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		c.negotiatedVersion(index)
+		return response, nil
+	}
+	return nil, lastErr
+}
+
+// negotiatedVersion remembers that the webhook accepted the review version at the given index and
+// notifies the recorder if it changed.
+func (c *webhookConverter) negotiatedVersion(index int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.negotiated = index
+	version := c.reviewVersions[index]
+	if c.recorder != nil && c.recorded != version {
+		c.recorded = version
+		c.recorder.RecordConversionReviewVersion(c.name, c.uid, version)
+	}
+}
+
+// checkDegraded returns an error if the webhook is degraded and must not be called yet.
+func (c *webhookConverter) checkDegraded() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.degraded && time.Now().Before(c.degradedUntil) {
+		return fmt.Errorf("conversion webhook for %s is degraded after %d consecutive failures, it is called again after %s", c.name, c.failures, c.degradedUntil.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// acquire returns an error if the call of the webhook exceeds its rate limit, or if no slot for
+// the call becomes free within the queue timeout. Otherwise, the returned function must be called
+// when the call is done.
+func (c *webhookConverter) acquire(resource schema.GroupVersionResource) (func(), error) {
+	if c.rateLimiter != nil && !c.rateLimiter.TryAccept() {
+		metrics.IncConversionWebhookThrottled(resource)
+		return nil, fmt.Errorf("conversion webhook for %s is throttled: more than %v calls per second", c.name, c.rateLimiter.QPS())
+	}
+	if c.inFlight == nil {
+		return func() {}, nil
+	}
+	release := func() { <-c.inFlight }
+	select {
+	case c.inFlight <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	metrics.IncConversionWebhookQueueDepth(resource)
+	defer metrics.DecConversionWebhookQueueDepth(resource)
+	timer := time.NewTimer(c.queueTimeout)
+	defer timer.Stop()
+	select {
+	case c.inFlight <- struct{}{}:
+		return release, nil
+	case <-timer.C:
+		metrics.IncConversionWebhookThrottled(resource)
+		return nil, fmt.Errorf("conversion webhook for %s is throttled: %d calls in flight for more than %s", c.name, cap(c.inFlight), c.queueTimeout)
+	}
+}
+
+// recordCall counts consecutive failed calls of the webhook. The webhook becomes degraded when the
+// failure threshold is reached, and is no longer degraded when a call succeeds. The recorder is
+// notified of both transitions.
+func (c *webhookConverter) recordCall(err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err == nil {
+		c.failures = 0
+		if c.degraded {
+			c.degraded = false
+			if c.recorder != nil {
+				c.recorder.RecordConversionDegraded(c.name, c.uid, false, "")
+			}
+		}
+		return
+	}
+
+	c.failures++
+	if c.failureThreshold <= 0 || c.failures < c.failureThreshold {
+		return
+	}
+	// a failed call of a degraded webhook extends the cooldown
+	c.degradedUntil = time.Now().Add(c.degradedCooldown)
+	if !c.degraded {
+		c.degraded = true
+		if c.recorder != nil {
+			c.recorder.RecordConversionDegraded(c.name, c.uid, true, fmt.Sprintf("the conversion webhook failed %d times in a row: %v", c.failures, err))
+		}
+	}
+}
+
+// call sends the review in the given ConversionReview version.
+func (c *webhookConverter) call(review *v1beta1.ConversionReview, version string) (*v1beta1.ConversionResponse, error) {
+	apiVersion := schema.GroupVersion{Group: v1beta1.GroupName, Version: version}.String()
+	review.APIVersion = apiVersion
+	body, err := json.Marshal(review)
+	if err != nil {
+		return nil, err
+	}
+	statusCode, data, err := c.post(body)
+	if err != nil {
+		return nil, err
+	}
+	if statusCode == http.StatusUnsupportedMediaType {
+		return nil, &unsupportedReviewVersionError{version: version, reason: fmt.Sprintf("unexpected status code %d: %s", statusCode, string(data))}
+	}
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d: %s", statusCode, string(data))
+	}
+
+	result := &v1beta1.ConversionReview{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, err
+	}
+	if result.APIVersion != apiVersion {
+		return nil, &unsupportedReviewVersionError{version: version, reason: fmt.Sprintf("webhook responded with apiVersion %q", result.APIVersion)}
+	}
+	if result.Response == nil {
+		return nil, fmt.Errorf("no response provided")
+	}
+	return result.Response, nil
+}
+
+// post sends the body to the webhook and returns the status code and body of the response. Calls
+// which cannot reach the webhook, or which it answers with a server error or 429 Too Many Requests,
+// are retried with exponential backoff.
+func (c *webhookConverter) post(body []byte) (int, []byte, error) {
+	backoff := c.retryBackoff
+	for attempt := 0; ; attempt++ {
+		statusCode, data, err := c.postOnce(body)
+		retriable := err != nil || statusCode >= http.StatusInternalServerError || statusCode == http.StatusTooManyRequests
+		if !retriable || attempt >= c.retries {
+			return statusCode, data, err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (c *webhookConverter) postOnce(body []byte) (int, []byte, error) {
+	resp, err := c.client.Post(c.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, data, nil
+}
+
+// validateConvertedObject checks that the webhook changed the apiVersion as requested, and left
+// the kind and the identity of the object untouched.
+func validateConvertedObject(in, out *unstructured.Unstructured, targetGV schema.GroupVersion) error {
+	if out.GetAPIVersion() != targetGV.String() {
+		return fmt.Errorf("must have apiVersion %q, got %q", targetGV.String(), out.GetAPIVersion())
+	}
+	if out.GetKind() != in.GetKind() {
+		return fmt.Errorf("must have kind %q, got %q", in.GetKind(), out.GetKind())
+	}
+	if out.GetName() != in.GetName() || out.GetNamespace() != in.GetNamespace() || out.GetUID() != in.GetUID() {
+		return fmt.Errorf("must not change metadata.name, metadata.namespace or metadata.uid")
+	}
+	return nil
+}
+
+// restoreObjectMeta copies the metadata of the original object to the converted one. Webhooks
+// may only change labels and annotations.
+func restoreObjectMeta(in, out *unstructured.Unstructured) {
+	labels, annotations := out.GetLabels(), out.GetAnnotations()
+	metadata, found := in.DeepCopy().Object["metadata"]
+	if !found {
+		delete(out.Object, "metadata")
+	} else {
+		if m, ok := metadata.(map[string]interface{}); ok {
+			delete(m, "labels")
+			delete(m, "annotations")
+		}
+		out.Object["metadata"] = metadata
+	}
+	if len(labels) > 0 {
+		out.SetLabels(labels)
+	}
+	if len(annotations) > 0 {
+		out.SetAnnotations(annotations)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"k8s.io/apiserver/pkg/admission"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+// CustomResourceAttributes are the admission attributes of requests for custom resources and their
+// status. Admission plugins can assert them to mutate custom resources according to their schema
+// without looking up the CustomResourceDefinition.
+type CustomResourceAttributes interface {
+	admission.Attributes
+	// GetOpenAPIV3Schema returns the schema of the requested version with references to
+	// definitions expanded, or nil if the version has no schema. It must not be changed.
+	GetOpenAPIV3Schema() *apiextensions.JSONSchemaProps
+}
+
+// GetOpenAPIV3SchemaFrom returns the schema of the custom resource of the request, or nil if a
+// does not carry one.
+func GetOpenAPIV3SchemaFrom(a admission.Attributes) *apiextensions.JSONSchemaProps {
+	if a, ok := a.(CustomResourceAttributes); ok {
+		return a.GetOpenAPIV3Schema()
+	}
+	return nil
+}
+
+type customResourceAttributes struct {
+	admission.Attributes
+	schema *apiextensions.JSONSchemaProps
+}
+
+var _ CustomResourceAttributes = &customResourceAttributes{}
+
+func (a *customResourceAttributes) GetOpenAPIV3Schema() *apiextensions.JSONSchemaProps {
+	return a.schema
+}
+
+// schemaAdmission passes the schema of the served version to the admission plugins with the
+// attributes of each request.
+type schemaAdmission struct {
+	delegate admission.Interface
+	schema   *apiextensions.JSONSchemaProps
+}
+
+// withSchema returns admit, passing CustomResourceAttributes with the given schema to it. A nil
+// admit stays nil.
+func withSchema(admit admission.Interface, schema *apiextensions.JSONSchemaProps) admission.Interface {
+	if admit == nil {
+		return nil
+	}
+	return &schemaAdmission{delegate: admit, schema: schema}
+}
+
+func (s *schemaAdmission) Handles(operation admission.Operation) bool {
+	return s.delegate.Handles(operation)
+}
+
+func (s *schemaAdmission) Admit(a admission.Attributes) error {
+	return s.delegate.Admit(&customResourceAttributes{Attributes: a, schema: s.schema})
+}
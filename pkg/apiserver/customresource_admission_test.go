@@ -0,0 +1,65 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+func TestWithSchema(t *testing.T) {
+	if admit := withSchema(nil, nil); admit != nil {
+		t.Errorf("expected nil admission to stay nil, got %#v", admit)
+	}
+
+	openAPIV3Schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"spec": {Type: "object"},
+		},
+	}
+	var received *apiextensions.JSONSchemaProps
+	hooks := NewCustomResourceHooks()
+	hooks.RegisterMutator("example.com", mutatorFunc(func(obj, oldObj *unstructured.Unstructured, a admission.Attributes) error {
+		received = GetOpenAPIV3SchemaFrom(a)
+		return nil
+	}))
+	admit := withSchema(hooks, openAPIV3Schema)
+	if !admit.Handles(admission.Create) || admit.Handles(admission.Delete) {
+		t.Errorf("expected Handles to be delegated")
+	}
+
+	kind := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Foo"}
+	resource := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "foos"}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": "example.com/v1", "kind": "Foo"}}
+	obj.SetName("a")
+	if err := admit.Admit(admission.NewAttributesRecord(obj, nil, kind, "", "a", resource, "", admission.Create, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if received != openAPIV3Schema {
+		t.Errorf("expected the schema to be passed to the mutator, got %#v", received)
+	}
+
+	if s := GetOpenAPIV3SchemaFrom(admission.NewAttributesRecord(obj, nil, kind, "", "a", resource, "", admission.Create, nil)); s != nil {
+		t.Errorf("expected no schema from plain attributes, got %#v", s)
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+)
+
+const (
+	// aggregatedDiscoveryGroup and aggregatedDiscoveryVersion are the group and version of the aggregated
+	// discovery format.
+	aggregatedDiscoveryGroup   = "apidiscovery.k8s.io"
+	aggregatedDiscoveryVersion = "v2beta1"
+	aggregatedDiscoveryKind    = "APIGroupDiscoveryList"
+
+	// aggregatedDiscoveryMediaType is the content type of aggregated discovery documents.
+	aggregatedDiscoveryMediaType = "application/json;g=" + aggregatedDiscoveryGroup + ";v=" + aggregatedDiscoveryVersion + ";as=" + aggregatedDiscoveryKind
+)
+
+// apiGroupDiscoveryList is an aggregated discovery document, listing all groups with their versions
+// and resources.
+type apiGroupDiscoveryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []apiGroupDiscovery `json:"items"`
+}
+
+// apiGroupDiscovery holds the served versions of a group.
+type apiGroupDiscovery struct {
+	// ObjectMeta.Name is the name of the group
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Versions          []apiVersionDiscovery `json:"versions,omitempty"`
+}
+
+// apiVersionDiscovery holds the resources of a group version.
+type apiVersionDiscovery struct {
+	Version   string                 `json:"version"`
+	Resources []apiResourceDiscovery `json:"resources,omitempty"`
+	Freshness string                 `json:"freshness,omitempty"`
+}
+
+// apiResourceDiscovery describes a resource and its subresources.
+type apiResourceDiscovery struct {
+	Resource         string                    `json:"resource"`
+	ResponseKind     *metav1.GroupVersionKind  `json:"responseKind"`
+	Scope            string                    `json:"scope"`
+	SingularResource string                    `json:"singularResource"`
+	Verbs            []string                  `json:"verbs"`
+	ShortNames       []string                  `json:"shortNames,omitempty"`
+	Categories       []string                  `json:"categories,omitempty"`
+	Subresources     []apiSubresourceDiscovery `json:"subresources,omitempty"`
+}
+
+// apiSubresourceDiscovery describes a subresource.
+type apiSubresourceDiscovery struct {
+	Subresource  string                   `json:"subresource"`
+	ResponseKind *metav1.GroupVersionKind `json:"responseKind,omitempty"`
+	Verbs        []string                 `json:"verbs"`
+}
+
+// aggregatedDiscoveryHandler serves the custom resources of all groups in a single aggregated discovery
+// document at /apis. Requests which do not accept that format go to the delegate.
+type aggregatedDiscoveryHandler struct {
+	// discoveryLock serializes writers. Readers load discovery without locking.
+	discoveryLock sync.Mutex
+	// discovery contains an aggregatedDiscovery, which is replaced instead of written to
+	discovery atomic.Value
+
+	delegate http.Handler
+}
+
+// aggregatedDiscovery is the state of an aggregatedDiscoveryHandler.
+type aggregatedDiscovery struct {
+	// groupVersions are the served versions of each group, in discovery order
+	groupVersions map[string][]string
+	// versions are the resources of each group version
+	versions map[schema.GroupVersion]apiVersionDiscovery
+}
+
+func (r *aggregatedDiscoveryHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" || !acceptsAggregatedDiscovery(req) {
+		r.delegate.ServeHTTP(w, req)
+		return
+	}
+
+	discovery := r.loadDiscovery()
+	list := apiGroupDiscoveryList{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: aggregatedDiscoveryGroup + "/" + aggregatedDiscoveryVersion,
+			Kind:       aggregatedDiscoveryKind,
+		},
+		Items: []apiGroupDiscovery{},
+	}
+	groups := make([]string, 0, len(discovery.groupVersions))
+	for group := range discovery.groupVersions {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	for _, group := range groups {
+		apiGroup := apiGroupDiscovery{ObjectMeta: metav1.ObjectMeta{Name: group}}
+		for _, version := range discovery.groupVersions[group] {
+			if v, ok := discovery.versions[schema.GroupVersion{Group: group, Version: version}]; ok {
+				apiGroup.Versions = append(apiGroup.Versions, v)
+			}
+		}
+		if len(apiGroup.Versions) > 0 {
+			list.Items = append(list.Items, apiGroup)
+		}
+	}
+
+	data, err := json.Marshal(list)
+	if err != nil {
+		utilruntime.HandleError(err)
+		http.Error(w, "unable to encode the aggregated discovery document", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", aggregatedDiscoveryMediaType)
+	w.Header().Set("Vary", "Accept")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// acceptsAggregatedDiscovery returns true if the Accept header of req asks for the aggregated discovery format.
+func acceptsAggregatedDiscovery(req *http.Request) bool {
+	for _, accept := range strings.Split(req.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err != nil || mediaType != "application/json" {
+			continue
+		}
+		if params["g"] == aggregatedDiscoveryGroup && params["v"] == aggregatedDiscoveryVersion && params["as"] == aggregatedDiscoveryKind {
+			return true
+		}
+	}
+	return false
+}
+
+// setGroup sets the served versions of a group, in discovery order.
+func (r *aggregatedDiscoveryHandler) setGroup(group string, versions []string) {
+	r.updateDiscovery(func(discovery *aggregatedDiscovery) {
+		discovery.groupVersions[group] = versions
+	})
+}
+
+func (r *aggregatedDiscoveryHandler) unsetGroup(group string) {
+	r.updateDiscovery(func(discovery *aggregatedDiscovery) {
+		delete(discovery.groupVersions, group)
+	})
+}
+
+// setVersion sets the resources of a group version.
+func (r *aggregatedDiscoveryHandler) setVersion(gv schema.GroupVersion, resources []apiResourceDiscovery) {
+	r.updateDiscovery(func(discovery *aggregatedDiscovery) {
+		discovery.versions[gv] = apiVersionDiscovery{
+			Version:   gv.Version,
+			Resources: resources,
+			Freshness: "Current",
+		}
+	})
+}
+
+func (r *aggregatedDiscoveryHandler) unsetVersion(gv schema.GroupVersion) {
+	r.updateDiscovery(func(discovery *aggregatedDiscovery) {
+		delete(discovery.versions, gv)
+	})
+}
+
+func (r *aggregatedDiscoveryHandler) loadDiscovery() aggregatedDiscovery {
+	discovery, _ := r.discovery.Load().(aggregatedDiscovery)
+	return discovery
+}
+
+// updateDiscovery stores an updated copy of the discovery state.
+func (r *aggregatedDiscoveryHandler) updateDiscovery(update func(discovery *aggregatedDiscovery)) {
+	r.discoveryLock.Lock()
+	defer r.discoveryLock.Unlock()
+
+	old := r.loadDiscovery()
+	discovery := aggregatedDiscovery{
+		groupVersions: make(map[string][]string, len(old.groupVersions)+1),
+		versions:      make(map[schema.GroupVersion]apiVersionDiscovery, len(old.versions)+1),
+	}
+	for k, v := range old.groupVersions {
+		discovery.groupVersions[k] = v
+	}
+	for k, v := range old.versions {
+		discovery.versions[k] = v
+	}
+	update(&discovery)
+	r.discovery.Store(discovery)
+}
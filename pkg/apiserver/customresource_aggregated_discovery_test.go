@@ -0,0 +1,104 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestAggregatedDiscovery(t *testing.T) {
+	delegated := false
+	r := &aggregatedDiscoveryHandler{
+		delegate: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			delegated = true
+		}),
+	}
+
+	noxus := apiResourceDiscovery{
+		Resource:         "noxus",
+		ResponseKind:     &metav1.GroupVersionKind{Group: "mygroup.example.com", Version: "v1", Kind: "Noxu"},
+		Scope:            "Namespaced",
+		SingularResource: "noxu",
+		Verbs:            []string{"get", "list"},
+		Subresources: []apiSubresourceDiscovery{
+			{Subresource: "status", ResponseKind: &metav1.GroupVersionKind{Group: "mygroup.example.com", Version: "v1", Kind: "Noxu"}, Verbs: []string{"get"}},
+		},
+	}
+	r.setGroup("mygroup.example.com", []string{"v1", "v1beta1"})
+	r.setVersion(schema.GroupVersion{Group: "mygroup.example.com", Version: "v1"}, []apiResourceDiscovery{noxus})
+	r.setVersion(schema.GroupVersion{Group: "mygroup.example.com", Version: "v1beta1"}, []apiResourceDiscovery{})
+	r.setGroup("another.example.com", []string{"v1"})
+	r.setVersion(schema.GroupVersion{Group: "another.example.com", Version: "v1"}, []apiResourceDiscovery{})
+	r.setGroup("removed.example.com", []string{"v1"})
+	r.setVersion(schema.GroupVersion{Group: "removed.example.com", Version: "v1"}, []apiResourceDiscovery{})
+	r.unsetGroup("removed.example.com")
+	r.unsetVersion(schema.GroupVersion{Group: "removed.example.com", Version: "v1"})
+
+	// without the aggregated media type the request is delegated
+	req := httptest.NewRequest("GET", "/apis", nil)
+	req.Header.Set("Accept", "application/json")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	if !delegated {
+		t.Fatalf("expected a request for plain JSON to be delegated")
+	}
+
+	delegated = false
+	req = httptest.NewRequest("GET", "/apis", nil)
+	req.Header.Set("Accept", "application/json;g=apidiscovery.k8s.io;v=v2beta1;as=APIGroupDiscoveryList, application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if delegated {
+		t.Fatalf("expected the aggregated discovery request to be served")
+	}
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != aggregatedDiscoveryMediaType {
+		t.Errorf("expected content type %q, got %q", aggregatedDiscoveryMediaType, contentType)
+	}
+
+	var list apiGroupDiscoveryList
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if list.APIVersion != "apidiscovery.k8s.io/v2beta1" || list.Kind != "APIGroupDiscoveryList" {
+		t.Errorf("unexpected type %v", list.TypeMeta)
+	}
+	expected := []apiGroupDiscovery{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "another.example.com"},
+			Versions:   []apiVersionDiscovery{{Version: "v1", Freshness: "Current"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "mygroup.example.com"},
+			Versions: []apiVersionDiscovery{
+				{Version: "v1", Resources: []apiResourceDiscovery{noxus}, Freshness: "Current"},
+				{Version: "v1beta1", Freshness: "Current"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(list.Items, expected) {
+		t.Errorf("expected groups %#v, got %#v", expected, list.Items)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/audit"
+	"k8s.io/apiserver/pkg/endpoints/handlers"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/fieldmanager"
+	"k8s.io/apiextensions-apiserver/pkg/registry/customresource"
+)
+
+// isApplyRequest returns true if req is a patch with an apply configuration.
+func isApplyRequest(req *http.Request) bool {
+	contentType := req.Header.Get("Content-Type")
+	// Remove "; charset=" if included in header.
+	if idx := strings.Index(contentType, ";"); idx > 0 {
+		contentType = contentType[:idx]
+	}
+	return types.PatchType(contentType) == fieldmanager.ApplyPatchType
+}
+
+// applyResource returns a handler which merges the apply configuration in the request body into the
+// custom resource, or creates the custom resource from it if it does not exist yet.
+func applyResource(r *customresource.REST, scope handlers.RequestScope, fieldManager *fieldmanager.FieldManager, served []string, admit admission.Interface) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		ctx := scope.ContextFunc(req)
+		writeError := func(err error) {
+			responsewriters.ErrorNegotiated(ctx, err, scope.Serializer, scope.Kind.GroupVersion(), w, req)
+		}
+
+		namespace, name, err := scope.Namer.Name(req)
+		if err != nil {
+			writeError(err)
+			return
+		}
+		ctx = apirequest.WithNamespace(ctx, namespace)
+
+		manager := req.URL.Query().Get("fieldManager")
+		if len(manager) == 0 {
+			writeError(apierrors.NewBadRequest("fieldManager must be set for apply requests"))
+			return
+		}
+		if len(manager) > fieldmanager.MaxManagerLength {
+			writeError(apierrors.NewBadRequest(fmt.Sprintf("fieldManager must not be longer than %d characters", fieldmanager.MaxManagerLength)))
+			return
+		}
+		force := false
+		if s := req.URL.Query().Get("force"); len(s) > 0 {
+			if force, err = strconv.ParseBool(s); err != nil {
+				writeError(apierrors.NewBadRequest(fmt.Sprintf("invalid force parameter %q: %v", s, err)))
+				return
+			}
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			writeError(err)
+			return
+		}
+		audit.LogRequestPatch(apirequest.AuditEventFrom(ctx), body)
+
+		config, err := decodeApplyConfiguration(body, scope, namespace, name, served)
+		if err != nil {
+			writeError(err)
+			return
+		}
+
+		ctx = fieldmanager.WithApply(ctx)
+		userInfo, _ := apirequest.UserFrom(ctx)
+
+		applyConfig := func(_ apirequest.Context, _, currentObject runtime.Object) (runtime.Object, error) {
+			current, ok := currentObject.(*unstructured.Unstructured)
+			if !ok {
+				return nil, fmt.Errorf("unexpected object type %T", currentObject)
+			}
+			if len(current.GetUID()) == 0 {
+				return nil, apierrors.NewNotFound(scope.Resource.GroupResource(), name)
+			}
+			applied, err := fieldManager.Apply(current.UnstructuredContent(), config.UnstructuredContent(), manager, force)
+			if err != nil {
+				return nil, err
+			}
+			return &unstructured.Unstructured{Object: applied}, nil
+		}
+		admitUpdate := func(_ apirequest.Context, updatedObject, currentObject runtime.Object) (runtime.Object, error) {
+			if admit != nil && admit.Handles(admission.Update) {
+				return updatedObject, admit.Admit(admission.NewAttributesRecord(updatedObject, currentObject, scope.Kind, namespace, name, scope.Resource, scope.Subresource, admission.Update, userInfo))
+			}
+			return updatedObject, nil
+		}
+
+		status := http.StatusOK
+		result, _, err := r.Update(ctx, name, rest.DefaultUpdatedObjectInfo(nil, scope.Copier, applyConfig, admitUpdate))
+		if apierrors.IsNotFound(err) {
+			// apply creates the custom resource if it does not exist yet
+			var applied map[string]interface{}
+			applied, err = fieldManager.Apply(nil, config.UnstructuredContent(), manager, force)
+			if err != nil {
+				writeError(err)
+				return
+			}
+			obj := &unstructured.Unstructured{Object: applied}
+			if admit != nil && admit.Handles(admission.Create) {
+				if err := admit.Admit(admission.NewAttributesRecord(obj, nil, scope.Kind, namespace, name, scope.Resource, scope.Subresource, admission.Create, userInfo)); err != nil {
+					writeError(err)
+					return
+				}
+			}
+			result, err = r.Create(ctx, obj, false)
+			status = http.StatusCreated
+		}
+		if err != nil {
+			writeError(err)
+			return
+		}
+
+		if requestInfo, ok := apirequest.RequestInfoFrom(ctx); ok {
+			if uri, err := scope.Namer.GenerateLink(requestInfo, result); err == nil {
+				if err := scope.Namer.SetSelfLink(result, uri); err != nil {
+					writeError(err)
+					return
+				}
+			}
+		}
+
+		responsewriters.WriteObject(ctx, status, scope.Kind.GroupVersion(), scope.Serializer, result, w, req)
+	}
+}
+
+// decodeApplyConfiguration decodes the YAML or JSON apply configuration in body and checks that it
+// matches the apiVersion, kind, namespace and name of the request. served are the served versions,
+// which are listed if the apiVersion has a version of the group which is not served.
+func decodeApplyConfiguration(body []byte, scope handlers.RequestScope, namespace, name string, served []string) (*unstructured.Unstructured, error) {
+	js, err := yaml.YAMLToJSON(body)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("error decoding YAML: %v", err))
+	}
+	config := &unstructured.Unstructured{}
+	if err := config.UnmarshalJSON(js); err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("error decoding apply configuration: %v", err))
+	}
+	if err := checkAPIVersion(config.GetAPIVersion(), scope.Kind.GroupVersion(), served, "apply configuration"); err != nil {
+		return nil, err
+	}
+	if gvk := config.GroupVersionKind(); gvk != scope.Kind {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("apply configuration of kind %v does not match the expected kind %v", gvk, scope.Kind))
+	}
+	if len(config.GetName()) > 0 && config.GetName() != name {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("the name of the apply configuration (%s) does not match the name of the request (%s)", config.GetName(), name))
+	}
+	if len(config.GetNamespace()) > 0 && config.GetNamespace() != namespace {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("the namespace of the apply configuration (%s) does not match the namespace of the request (%s)", config.GetNamespace(), namespace))
+	}
+	config.SetName(name)
+	if len(namespace) > 0 {
+		config.SetNamespace(namespace)
+	}
+	return config, nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/coercion"
+)
+
+// coercionAdmission coerces the scalars of created and updated custom resources to the types of the
+// schema of the served version before passing them to the admission plugins.
+type coercionAdmission struct {
+	delegate admission.Interface
+	// schema is the schema of the served version without metadata, which is not coerced
+	schema *apiextensions.JSONSchemaProps
+}
+
+// withCoercion returns admit, coercing the scalars of created and updated custom resources to the
+// types of the given schema first if coerce is true. A nil admit stays nil unless coercion is
+// enabled.
+func withCoercion(admit admission.Interface, schema *apiextensions.JSONSchemaProps, coerce bool) admission.Interface {
+	if !coerce || schema == nil {
+		return admit
+	}
+	if _, found := schema.Properties["metadata"]; found {
+		withoutMetadata := *schema
+		withoutMetadata.Properties = make(map[string]apiextensions.JSONSchemaProps, len(schema.Properties))
+		for k, v := range schema.Properties {
+			if k != "metadata" {
+				withoutMetadata.Properties[k] = v
+			}
+		}
+		schema = &withoutMetadata
+	}
+	return &coercionAdmission{delegate: admit, schema: schema}
+}
+
+func (c *coercionAdmission) Handles(operation admission.Operation) bool {
+	return operation == admission.Create || operation == admission.Update || c.delegateHandles(operation)
+}
+
+func (c *coercionAdmission) delegateHandles(operation admission.Operation) bool {
+	return c.delegate != nil && c.delegate.Handles(operation)
+}
+
+func (c *coercionAdmission) Admit(a admission.Attributes) error {
+	if operation := a.GetOperation(); operation == admission.Create || operation == admission.Update {
+		if u, ok := a.GetObject().(runtime.Unstructured); ok {
+			coercion.Coerce(u.UnstructuredContent(), c.schema)
+		}
+	}
+	if !c.delegateHandles(a.GetOperation()) {
+		return nil
+	}
+	return c.delegate.Admit(a)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+func TestWithCoercion(t *testing.T) {
+	openAPIV3Schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"metadata": {
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"generation": {Type: "string"},
+				},
+			},
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"replicas": {Type: "integer"},
+				},
+			},
+		},
+	}
+	if admit := withCoercion(nil, openAPIV3Schema, false); admit != nil {
+		t.Errorf("expected nil admission to stay nil without coercion, got %#v", admit)
+	}
+	if admit := withCoercion(nil, nil, true); admit != nil {
+		t.Errorf("expected nil admission to stay nil without schema, got %#v", admit)
+	}
+
+	kind := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Foo"}
+	resource := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "foos"}
+	newObj := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "example.com/v1",
+			"kind":       "Foo",
+			"metadata":   map[string]interface{}{"name": "a", "generation": int64(1)},
+			"spec":       map[string]interface{}{"replicas": "3"},
+		}}
+	}
+	expected := map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Foo",
+		"metadata":   map[string]interface{}{"name": "a", "generation": int64(1)},
+		"spec":       map[string]interface{}{"replicas": int64(3)},
+	}
+
+	admit := withCoercion(nil, openAPIV3Schema, true)
+	if !admit.Handles(admission.Create) || !admit.Handles(admission.Update) || admit.Handles(admission.Delete) {
+		t.Errorf("expected Handles to be true for create and update only")
+	}
+	for _, operation := range []admission.Operation{admission.Create, admission.Update} {
+		obj := newObj()
+		if err := admit.Admit(admission.NewAttributesRecord(obj, nil, kind, "", "a", resource, "", operation, nil)); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(obj.Object, expected) {
+			t.Errorf("%s: expected %#v, got %#v", operation, expected, obj.Object)
+		}
+	}
+
+	var received interface{}
+	hooks := NewCustomResourceHooks()
+	hooks.RegisterMutator("example.com", mutatorFunc(func(obj, oldObj *unstructured.Unstructured, a admission.Attributes) error {
+		received = obj.Object["spec"].(map[string]interface{})["replicas"]
+		return nil
+	}))
+	admit = withSchema(withCoercion(hooks, openAPIV3Schema, true), openAPIV3Schema)
+	if err := admit.Admit(admission.NewAttributesRecord(newObj(), nil, kind, "", "a", resource, "", admission.Create, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if received != int64(3) {
+		t.Errorf("expected the mutator to receive the coerced replicas, got %#v", received)
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// withListCompression returns a handler which compresses the responses of handler with gzip if
+// they are larger than threshold bytes and the client accepts gzip. Smaller responses are not
+// worth the compression time. A threshold of zero or less disables compression.
+func withListCompression(handler http.HandlerFunc, threshold int) http.HandlerFunc {
+	if handler == nil || threshold <= 0 {
+		return handler
+	}
+	return func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(req.Header.Get("Accept-Encoding")) {
+			handler(w, req)
+			return
+		}
+		cw := &compressingResponseWriter{ResponseWriter: w, threshold: threshold}
+		defer cw.Close()
+		handler(cw, req)
+	}
+}
+
+// acceptsGzip returns true if the Accept-Encoding header does not exclude gzip with a quality of zero.
+func acceptsGzip(header string) bool {
+	accepted := false
+	for _, coding := range strings.Split(header, ",") {
+		params := strings.Split(coding, ";")
+		name := strings.ToLower(strings.TrimSpace(params[0]))
+		if name != "gzip" && name != "*" {
+			continue
+		}
+		q := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				var err error
+				if q, err = strconv.ParseFloat(param[2:], 64); err != nil {
+					q = 0
+				}
+			}
+		}
+		// an explicit gzip coding takes precedence over the wildcard
+		if name == "gzip" {
+			return q > 0
+		}
+		accepted = q > 0
+	}
+	return accepted
+}
+
+// compressingResponseWriter buffers the response until it exceeds the threshold, and compresses
+// it with gzip from then on. Responses which are not larger than the threshold are written
+// uncompressed on Close.
+type compressingResponseWriter struct {
+	http.ResponseWriter
+	threshold int
+
+	status  int
+	buffer  bytes.Buffer
+	gzip    *gzip.Writer
+	written bool
+}
+
+func (w *compressingResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *compressingResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if w.gzip != nil {
+		return w.gzip.Write(p)
+	}
+	if w.written {
+		return w.ResponseWriter.Write(p)
+	}
+	if w.buffer.Len()+len(p) <= w.threshold {
+		return w.buffer.Write(p)
+	}
+	if len(w.Header().Get("Content-Encoding")) > 0 {
+		// the response is already encoded
+		if err := w.writeBuffer(); err != nil {
+			return 0, err
+		}
+		return w.ResponseWriter.Write(p)
+	}
+
+	w.Header().Set("Content-Encoding", "gzip")
+	w.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.status)
+	w.written = true
+	// the fastest compression level keeps most of the size reduction of JSON at a fraction of the time
+	w.gzip, _ = gzip.NewWriterLevel(w.ResponseWriter, gzip.BestSpeed)
+	if _, err := w.gzip.Write(w.buffer.Bytes()); err != nil {
+		return 0, err
+	}
+	w.buffer.Reset()
+	return w.gzip.Write(p)
+}
+
+// writeBuffer writes the status and the buffered response uncompressed.
+func (w *compressingResponseWriter) writeBuffer() error {
+	w.ResponseWriter.WriteHeader(w.status)
+	w.written = true
+	_, err := w.ResponseWriter.Write(w.buffer.Bytes())
+	w.buffer.Reset()
+	return err
+}
+
+// Close finishes the response.
+func (w *compressingResponseWriter) Close() error {
+	switch {
+	case w.gzip != nil:
+		return w.gzip.Close()
+	case !w.written && w.status != 0:
+		return w.writeBuffer()
+	}
+	return nil
+}
+
+// CloseNotify is part of the http.CloseNotifier interface.
+func (w *compressingResponseWriter) CloseNotify() <-chan bool {
+	return w.ResponseWriter.(http.CloseNotifier).CloseNotify()
+}
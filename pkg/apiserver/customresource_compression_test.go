@@ -0,0 +1,114 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected bool
+	}{
+		{"", false},
+		{"gzip", true},
+		{"deflate, GZIP", true},
+		{"gzip;q=0.5, br", true},
+		{"gzip;q=0", false},
+		{"*", true},
+		{"*;q=0", false},
+		{"gzip;q=0, *", false},
+		{"identity", false},
+	}
+	for _, tc := range tests {
+		if actual := acceptsGzip(tc.header); actual != tc.expected {
+			t.Errorf("%q: expected %v, got %v", tc.header, tc.expected, actual)
+		}
+	}
+}
+
+func TestWithListCompression(t *testing.T) {
+	small := `{"kind":"FooList","items":[]}`
+	large := `{"kind":"FooList","items":[` + strings.Repeat(`{"kind":"Foo"},`, 100) + `{"kind":"Foo"}]}`
+
+	tests := []struct {
+		name           string
+		threshold      int
+		acceptEncoding string
+		body           string
+		compressed     bool
+	}{
+		{"large response", 1024, "gzip", large, true},
+		{"small response", 1024, "gzip", small, false},
+		{"gzip not accepted", 1024, "", large, false},
+		{"disabled", 0, "gzip", large, false},
+	}
+	for _, tc := range tests {
+		handler := withListCompression(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			// write in chunks to cross the threshold in the middle of a write
+			for i := 0; i < len(tc.body); i += 100 {
+				end := i + 100
+				if end > len(tc.body) {
+					end = len(tc.body)
+				}
+				w.Write([]byte(tc.body[i:end]))
+			}
+		}, tc.threshold)
+
+		req := httptest.NewRequest("GET", "/apis/mygroup.example.com/v1/foos", nil)
+		if len(tc.acceptEncoding) > 0 {
+			req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+		}
+		w := httptest.NewRecorder()
+		handler(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected status 200, got %d", tc.name, w.Code)
+		}
+		if actual := w.Header().Get("Content-Type"); actual != "application/json" {
+			t.Errorf("%s: expected content type application/json, got %q", tc.name, actual)
+		}
+		body := w.Body.Bytes()
+		if encoding := w.Header().Get("Content-Encoding"); tc.compressed != (encoding == "gzip") {
+			t.Errorf("%s: expected compression %v, got content encoding %q", tc.name, tc.compressed, encoding)
+			continue
+		}
+		if tc.compressed {
+			r, err := gzip.NewReader(bytes.NewReader(body))
+			if err != nil {
+				t.Errorf("%s: %v", tc.name, err)
+				continue
+			}
+			if body, err = ioutil.ReadAll(r); err != nil {
+				t.Errorf("%s: %v", tc.name, err)
+				continue
+			}
+		}
+		if string(body) != tc.body {
+			t.Errorf("%s: expected body %q, got %q", tc.name, tc.body, body)
+		}
+	}
+}
@@ -20,15 +20,17 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apiserver/pkg/endpoints/discovery"
 )
 
 type versionDiscoveryHandler struct {
-	// TODO, writing is infrequent, optimize this
-	discoveryLock sync.RWMutex
-	discovery     map[schema.GroupVersion]*discovery.APIVersionHandler
+	// discoveryLock serializes writers. Readers load discovery without locking.
+	discoveryLock sync.Mutex
+	// discovery contains a versionDiscoveryMap, which is replaced instead of written to
+	discovery atomic.Value
 
 	delegate http.Handler
 }
@@ -50,10 +52,8 @@ func (r *versionDiscoveryHandler) ServeHTTP(w http.ResponseWriter, req *http.Req
 }
 
 func (r *versionDiscoveryHandler) getDiscovery(gv schema.GroupVersion) (*discovery.APIVersionHandler, bool) {
-	r.discoveryLock.RLock()
-	defer r.discoveryLock.RUnlock()
-
-	ret, ok := r.discovery[gv]
+	// the map is nil until the first discovery is set
+	ret, ok := r.loadDiscovery()[gv]
 	return ret, ok
 }
 
@@ -61,20 +61,45 @@ func (r *versionDiscoveryHandler) setDiscovery(gv schema.GroupVersion, discovery
 	r.discoveryLock.Lock()
 	defer r.discoveryLock.Unlock()
 
-	r.discovery[gv] = discovery
+	discoveryMap := r.loadDiscovery().clone()
+	discoveryMap[gv] = discovery
+	r.discovery.Store(discoveryMap)
 }
 
 func (r *versionDiscoveryHandler) unsetDiscovery(gv schema.GroupVersion) {
 	r.discoveryLock.Lock()
 	defer r.discoveryLock.Unlock()
 
-	delete(r.discovery, gv)
+	discoveryMap := r.loadDiscovery()
+	if _, found := discoveryMap[gv]; !found {
+		return
+	}
+	discoveryMap = discoveryMap.clone()
+	delete(discoveryMap, gv)
+	r.discovery.Store(discoveryMap)
+}
+
+func (r *versionDiscoveryHandler) loadDiscovery() versionDiscoveryMap {
+	discoveryMap, _ := r.discovery.Load().(versionDiscoveryMap)
+	return discoveryMap
+}
+
+// versionDiscoveryMap goes from group version to its discovery handler
+type versionDiscoveryMap map[schema.GroupVersion]*discovery.APIVersionHandler
+
+func (m versionDiscoveryMap) clone() versionDiscoveryMap {
+	ret := make(versionDiscoveryMap, len(m)+1)
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
 }
 
 type groupDiscoveryHandler struct {
-	// TODO, writing is infrequent, optimize this
-	discoveryLock sync.RWMutex
-	discovery     map[string]*discovery.APIGroupHandler
+	// discoveryLock serializes writers. Readers load discovery without locking.
+	discoveryLock sync.Mutex
+	// discovery contains a groupDiscoveryMap, which is replaced instead of written to
+	discovery atomic.Value
 
 	delegate http.Handler
 }
@@ -96,10 +121,8 @@ func (r *groupDiscoveryHandler) ServeHTTP(w http.ResponseWriter, req *http.Reque
 }
 
 func (r *groupDiscoveryHandler) getDiscovery(group string) (*discovery.APIGroupHandler, bool) {
-	r.discoveryLock.RLock()
-	defer r.discoveryLock.RUnlock()
-
-	ret, ok := r.discovery[group]
+	// the map is nil until the first discovery is set
+	ret, ok := r.loadDiscovery()[group]
 	return ret, ok
 }
 
@@ -107,14 +130,38 @@ func (r *groupDiscoveryHandler) setDiscovery(group string, discovery *discovery.
 	r.discoveryLock.Lock()
 	defer r.discoveryLock.Unlock()
 
-	r.discovery[group] = discovery
+	discoveryMap := r.loadDiscovery().clone()
+	discoveryMap[group] = discovery
+	r.discovery.Store(discoveryMap)
 }
 
 func (r *groupDiscoveryHandler) unsetDiscovery(group string) {
 	r.discoveryLock.Lock()
 	defer r.discoveryLock.Unlock()
 
-	delete(r.discovery, group)
+	discoveryMap := r.loadDiscovery()
+	if _, found := discoveryMap[group]; !found {
+		return
+	}
+	discoveryMap = discoveryMap.clone()
+	delete(discoveryMap, group)
+	r.discovery.Store(discoveryMap)
+}
+
+func (r *groupDiscoveryHandler) loadDiscovery() groupDiscoveryMap {
+	discoveryMap, _ := r.discovery.Load().(groupDiscoveryMap)
+	return discoveryMap
+}
+
+// groupDiscoveryMap goes from group to its discovery handler
+type groupDiscoveryMap map[string]*discovery.APIGroupHandler
+
+func (m groupDiscoveryMap) clone() groupDiscoveryMap {
+	ret := make(groupDiscoveryMap, len(m)+1)
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
 }
 
 // splitPath returns the segments for a URL path.
@@ -22,6 +22,7 @@ import (
 
 	"github.com/golang/glog"
 
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -38,9 +39,10 @@ import (
 )
 
 type DiscoveryController struct {
-	versionHandler *versionDiscoveryHandler
-	groupHandler   *groupDiscoveryHandler
-	contextMapper  request.RequestContextMapper
+	versionHandler    *versionDiscoveryHandler
+	groupHandler      *groupDiscoveryHandler
+	aggregatedHandler *aggregatedDiscoveryHandler
+	contextMapper     request.RequestContextMapper
 
 	crdLister  listers.CustomResourceDefinitionLister
 	crdsSynced cache.InformerSynced
@@ -51,13 +53,14 @@ type DiscoveryController struct {
 	queue workqueue.RateLimitingInterface
 }
 
-func NewDiscoveryController(crdInformer informers.CustomResourceDefinitionInformer, versionHandler *versionDiscoveryHandler, groupHandler *groupDiscoveryHandler, contextMapper request.RequestContextMapper) *DiscoveryController {
+func NewDiscoveryController(crdInformer informers.CustomResourceDefinitionInformer, versionHandler *versionDiscoveryHandler, groupHandler *groupDiscoveryHandler, aggregatedHandler *aggregatedDiscoveryHandler, contextMapper request.RequestContextMapper) *DiscoveryController {
 	c := &DiscoveryController{
-		versionHandler: versionHandler,
-		groupHandler:   groupHandler,
-		crdLister:      crdInformer.Lister(),
-		crdsSynced:     crdInformer.Informer().HasSynced,
-		contextMapper:  contextMapper,
+		versionHandler:    versionHandler,
+		groupHandler:      groupHandler,
+		aggregatedHandler: aggregatedHandler,
+		crdLister:         crdInformer.Lister(),
+		crdsSynced:        crdInformer.Informer().HasSynced,
+		contextMapper:     contextMapper,
 
 		queue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "DiscoveryController"),
 	}
@@ -77,6 +80,7 @@ func (c *DiscoveryController) sync(version schema.GroupVersion) error {
 
 	apiVersionsForDiscovery := []metav1.GroupVersionForDiscovery{}
 	apiResourcesForDiscovery := []metav1.APIResource{}
+	aggregatedResources := []apiResourceDiscovery{}
 
 	crds, err := c.crdLister.List(labels.Everything())
 	if err != nil {
@@ -84,6 +88,7 @@ func (c *DiscoveryController) sync(version schema.GroupVersion) error {
 	}
 	foundVersion := false
 	foundGroup := false
+	versionsForDiscoveryMap := map[metav1.GroupVersionForDiscovery]bool{}
 	for _, crd := range crds {
 		if !apiextensions.IsCRDConditionTrue(crd, apiextensions.Established) {
 			continue
@@ -92,13 +97,27 @@ func (c *DiscoveryController) sync(version schema.GroupVersion) error {
 		if crd.Spec.Group != version.Group {
 			continue
 		}
-		foundGroup = true
-		apiVersionsForDiscovery = append(apiVersionsForDiscovery, metav1.GroupVersionForDiscovery{
-			GroupVersion: crd.Spec.Group + "/" + crd.Spec.Version,
-			Version:      crd.Spec.Version,
-		})
 
-		if crd.Spec.Version != version.Version {
+		foundThisVersion := false
+		for _, v := range crd.Spec.Versions {
+			if !v.Served {
+				continue
+			}
+			foundGroup = true
+			gv := metav1.GroupVersionForDiscovery{
+				GroupVersion: crd.Spec.Group + "/" + v.Name,
+				Version:      v.Name,
+			}
+			if !versionsForDiscoveryMap[gv] {
+				versionsForDiscoveryMap[gv] = true
+				apiVersionsForDiscovery = append(apiVersionsForDiscovery, gv)
+			}
+			if v.Name == version.Version {
+				foundThisVersion = true
+			}
+		}
+
+		if !foundThisVersion {
 			continue
 		}
 		foundVersion = true
@@ -116,12 +135,73 @@ func (c *DiscoveryController) sync(version schema.GroupVersion) error {
 			Kind:         crd.Status.AcceptedNames.Kind,
 			Verbs:        verbs,
 			ShortNames:   crd.Status.AcceptedNames.ShortNames,
+			Categories:   crd.Status.AcceptedNames.Categories,
 		})
+		aggregatedResource := apiResourceDiscovery{
+			Resource:         crd.Status.AcceptedNames.Plural,
+			ResponseKind:     &metav1.GroupVersionKind{Group: version.Group, Version: version.Version, Kind: crd.Status.AcceptedNames.Kind},
+			Scope:            string(crd.Spec.Scope),
+			SingularResource: crd.Status.AcceptedNames.Singular,
+			Verbs:            verbs,
+			ShortNames:       crd.Status.AcceptedNames.ShortNames,
+			Categories:       crd.Status.AcceptedNames.Categories,
+		}
+
+		subresources, err := apiextensions.GetSubresourcesForVersion(crd, version.Version)
+		if err != nil {
+			return err
+		}
+		if subresources != nil && subresources.Status != nil {
+			apiResourcesForDiscovery = append(apiResourcesForDiscovery, metav1.APIResource{
+				Name:       crd.Status.AcceptedNames.Plural + "/status",
+				Namespaced: crd.Spec.Scope == apiextensions.NamespaceScoped,
+				Kind:       crd.Status.AcceptedNames.Kind,
+				Verbs:      metav1.Verbs([]string{"get", "patch", "update"}),
+			})
+			aggregatedResource.Subresources = append(aggregatedResource.Subresources, apiSubresourceDiscovery{
+				Subresource:  "status",
+				ResponseKind: aggregatedResource.ResponseKind,
+				Verbs:        []string{"get", "patch", "update"},
+			})
+		}
+
+		if subresources != nil && subresources.Scale != nil {
+			apiResourcesForDiscovery = append(apiResourcesForDiscovery, metav1.APIResource{
+				Name:       crd.Status.AcceptedNames.Plural + "/scale",
+				Namespaced: crd.Spec.Scope == apiextensions.NamespaceScoped,
+				Kind:       "Scale",
+				Verbs:      metav1.Verbs([]string{"get", "patch", "update"}),
+			})
+			aggregatedResource.Subresources = append(aggregatedResource.Subresources, apiSubresourceDiscovery{
+				Subresource:  "scale",
+				ResponseKind: &metav1.GroupVersionKind{Group: autoscalingv1.GroupName, Version: "v1", Kind: "Scale"},
+				Verbs:        []string{"get", "patch", "update"},
+			})
+		}
+
+		if subresources != nil {
+			for _, custom := range subresources.Custom {
+				apiResourcesForDiscovery = append(apiResourcesForDiscovery, metav1.APIResource{
+					Name:       crd.Status.AcceptedNames.Plural + "/" + custom.Name,
+					Namespaced: crd.Spec.Scope == apiextensions.NamespaceScoped,
+					Kind:       crd.Status.AcceptedNames.Kind,
+					Verbs:      metav1.Verbs(custom.Verbs),
+				})
+				aggregatedResource.Subresources = append(aggregatedResource.Subresources, apiSubresourceDiscovery{
+					Subresource:  custom.Name,
+					ResponseKind: aggregatedResource.ResponseKind,
+					Verbs:        custom.Verbs,
+				})
+			}
+		}
+		aggregatedResources = append(aggregatedResources, aggregatedResource)
 	}
 
 	if !foundGroup {
 		c.groupHandler.unsetDiscovery(version.Group)
 		c.versionHandler.unsetDiscovery(version)
+		c.aggregatedHandler.unsetGroup(version.Group)
+		c.aggregatedHandler.unsetVersion(version)
 		return nil
 	}
 
@@ -132,14 +212,21 @@ func (c *DiscoveryController) sync(version schema.GroupVersion) error {
 		PreferredVersion: apiVersionsForDiscovery[0],
 	}
 	c.groupHandler.setDiscovery(version.Group, discovery.NewAPIGroupHandler(Codecs, apiGroup, c.contextMapper))
+	versions := make([]string, 0, len(apiVersionsForDiscovery))
+	for _, v := range apiVersionsForDiscovery {
+		versions = append(versions, v.Version)
+	}
+	c.aggregatedHandler.setGroup(version.Group, versions)
 
 	if !foundVersion {
 		c.versionHandler.unsetDiscovery(version)
+		c.aggregatedHandler.unsetVersion(version)
 		return nil
 	}
 	c.versionHandler.setDiscovery(version, discovery.NewAPIVersionHandler(Codecs, version, discovery.APIResourceListerFunc(func() []metav1.APIResource {
 		return apiResourcesForDiscovery
 	}), c.contextMapper))
+	c.aggregatedHandler.setVersion(version, aggregatedResources)
 
 	return nil
 }
@@ -188,7 +275,9 @@ func (c *DiscoveryController) processNextWorkItem() bool {
 }
 
 func (c *DiscoveryController) enqueue(obj *apiextensions.CustomResourceDefinition) {
-	c.queue.Add(schema.GroupVersion{Group: obj.Spec.Group, Version: obj.Spec.Version})
+	for _, v := range obj.Spec.Versions {
+		c.queue.Add(schema.GroupVersion{Group: obj.Spec.Group, Version: v.Name})
+	}
 }
 
 func (c *DiscoveryController) addCustomResourceDefinition(obj interface{}) {
@@ -197,10 +286,13 @@ func (c *DiscoveryController) addCustomResourceDefinition(obj interface{}) {
 	c.enqueue(castObj)
 }
 
-func (c *DiscoveryController) updateCustomResourceDefinition(obj, _ interface{}) {
-	castObj := obj.(*apiextensions.CustomResourceDefinition)
-	glog.V(4).Infof("Updating customresourcedefinition %s", castObj.Name)
-	c.enqueue(castObj)
+func (c *DiscoveryController) updateCustomResourceDefinition(oldObj, newObj interface{}) {
+	castNewObj := newObj.(*apiextensions.CustomResourceDefinition)
+	castOldObj := oldObj.(*apiextensions.CustomResourceDefinition)
+	glog.V(4).Infof("Updating customresourcedefinition %s", castOldObj.Name)
+	// enqueue both old and new object to make sure we remove and add the appropriate versions
+	c.enqueue(castNewObj)
+	c.enqueue(castOldObj)
 }
 
 func (c *DiscoveryController) deleteCustomResourceDefinition(obj interface{}) {
@@ -0,0 +1,112 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/audit"
+	"k8s.io/apiserver/pkg/endpoints/handlers"
+	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"k8s.io/apiextensions-apiserver/pkg/registry/customresource"
+)
+
+// dryRunAll is the only supported value of the dryRun parameter.
+const dryRunAll = "All"
+
+// validateDryRun returns an error if the request has a dryRun parameter which is not supported. Only
+// the creation of custom resources can be dry-run, other requests would silently persist their changes.
+func validateDryRun(req *http.Request, requestInfo *apirequest.RequestInfo) error {
+	dryRun, found := req.URL.Query()["dryRun"]
+	if !found {
+		return nil
+	}
+	if len(dryRun) != 1 || dryRun[0] != dryRunAll {
+		return apierrors.NewBadRequest(fmt.Sprintf("unsupported dryRun value %q, only %q is supported", dryRun, dryRunAll))
+	}
+	if requestInfo.Verb != "create" || len(requestInfo.Subresource) > 0 {
+		return apierrors.NewBadRequest(fmt.Sprintf("dryRun is not supported for %s requests, only for create", requestInfo.Verb))
+	}
+	return nil
+}
+
+// isDryRunRequest returns true if req only validates the custom resource in its body.
+func isDryRunRequest(req *http.Request) bool {
+	return req.URL.Query().Get("dryRun") == dryRunAll
+}
+
+// dryRunCreateResource returns a handler which prunes, defaults and validates the custom resource in
+// the request body like a create, including the schema, metadata and x-kubernetes-validations rules,
+// and responds with the resulting object or with all violations. Neither admission nor the storage
+// are involved, so the result does not reflect admission webhooks or conflicts with existing objects.
+func dryRunCreateResource(r *customresource.REST, scope handlers.RequestScope) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		ctx := scope.ContextFunc(req)
+		writeError := func(err error) {
+			responsewriters.ErrorNegotiated(ctx, err, scope.Serializer, scope.Kind.GroupVersion(), w, req)
+		}
+
+		namespace, err := scope.Namer.Namespace(req)
+		if err != nil {
+			writeError(err)
+			return
+		}
+		ctx = apirequest.WithNamespace(ctx, namespace)
+
+		gv := scope.Kind.GroupVersion()
+		s, err := negotiation.NegotiateInputSerializer(req, scope.Serializer)
+		if err != nil {
+			writeError(err)
+			return
+		}
+		decoder := scope.Serializer.DecoderToVersion(s.Serializer, schema.GroupVersion{Group: gv.Group, Version: runtime.APIVersionInternal})
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			writeError(err)
+			return
+		}
+		defaultGVK := scope.Kind
+		obj, gvk, err := decoder.Decode(body, &defaultGVK, &unstructured.Unstructured{})
+		if err != nil {
+			writeError(apierrors.NewBadRequest(err.Error()))
+			return
+		}
+		if gvk.GroupVersion() != gv {
+			writeError(apierrors.NewBadRequest(fmt.Sprintf("the API version in the data (%s) does not match the expected API version (%v)", gvk.GroupVersion().String(), gv.String())))
+			return
+		}
+		audit.LogRequestObject(apirequest.AuditEventFrom(ctx), obj, scope.Resource, scope.Subresource, scope.Serializer)
+
+		if err := rest.BeforeCreate(r.CreateStrategy, ctx, obj); err != nil {
+			writeError(err)
+			return
+		}
+
+		responsewriters.WriteObject(ctx, http.StatusCreated, gv, scope.Serializer, obj, w, req)
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"net/http"
+	"testing"
+
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func TestValidateDryRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		verb        string
+		subresource string
+		expectErr   bool
+	}{
+		{name: "no dry run", query: "", verb: "update"},
+		{name: "dry run create", query: "dryRun=All", verb: "create"},
+		{name: "unsupported value", query: "dryRun=Some", verb: "create", expectErr: true},
+		{name: "empty value", query: "dryRun=", verb: "create", expectErr: true},
+		{name: "multiple values", query: "dryRun=All&dryRun=All", verb: "create", expectErr: true},
+		{name: "dry run update", query: "dryRun=All", verb: "update", expectErr: true},
+		{name: "dry run delete", query: "dryRun=All", verb: "delete", expectErr: true},
+		{name: "dry run subresource", query: "dryRun=All", verb: "create", subresource: "status", expectErr: true},
+	}
+
+	for _, tc := range tests {
+		req, err := http.NewRequest("POST", "/apis/mygroup.example.com/v1beta1/noxus?"+tc.query, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = validateDryRun(req, &apirequest.RequestInfo{Verb: tc.verb, Subresource: tc.subresource})
+		if tc.expectErr && err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+		if !tc.expectErr && err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+)
+
+// crdExamplesPath is the path below which the examples of the versions of CRDs are served, at
+// crdExamplesPath/<name>/<version>.
+const crdExamplesPath = "/crd-examples"
+
+// crdExampleHandler serves the examples of the served versions of the CRDs, such that UIs and tooling
+// can offer them as starter manifests. apiVersion and kind are set if the example omits them.
+type crdExampleHandler struct {
+	crdLister listers.CustomResourceDefinitionLister
+}
+
+func (h *crdExampleHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(req.URL.Path, crdExamplesPath), "/"), "/")
+	if len(parts) != 2 {
+		http.NotFound(w, req)
+		return
+	}
+	name, version := parts[0], parts[1]
+	crd, err := h.crdLister.Get(name)
+	if apierrors.IsNotFound(err) {
+		http.NotFound(w, req)
+		return
+	}
+	if err != nil {
+		utilruntime.HandleError(err)
+		http.Error(w, "unable to get the CustomResourceDefinition", http.StatusInternalServerError)
+		return
+	}
+
+	var example map[string]interface{}
+	for i := range crd.Spec.Versions {
+		if v := &crd.Spec.Versions[i]; v.Name == version && v.Served && v.Example != nil {
+			// the example is shared with the lister
+			example, _ = (*v.DeepCopy().Example).(map[string]interface{})
+		}
+	}
+	if example == nil {
+		http.NotFound(w, req)
+		return
+	}
+	example["apiVersion"] = crd.Spec.Group + "/" + version
+	example["kind"] = crd.Spec.Names.Kind
+
+	data, err := json.Marshal(example)
+	if err != nil {
+		utilruntime.HandleError(err)
+		http.Error(w, "unable to encode the example", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+)
+
+func TestCRDExamples(t *testing.T) {
+	example := apiextensions.JSON(map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "example"},
+		"spec":     map[string]interface{}{"replicas": int64(1)},
+	})
+	crd := &apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "foos.example.com"},
+		Spec: apiextensions.CustomResourceDefinitionSpec{
+			Group: "example.com",
+			Names: apiextensions.CustomResourceDefinitionNames{Plural: "foos", Kind: "Foo"},
+			Versions: []apiextensions.CustomResourceDefinitionVersion{
+				{Name: "v1", Served: true, Storage: true, Example: &example},
+				{Name: "v1beta1", Served: false, Example: &example},
+				{Name: "v1alpha1", Served: true},
+			},
+		},
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	indexer.Add(crd)
+	h := &crdExampleHandler{crdLister: listers.NewCustomResourceDefinitionLister(indexer)}
+
+	tests := []struct {
+		path     string
+		code     int
+		expected map[string]interface{}
+	}{
+		{crdExamplesPath + "/foos.example.com/v1", http.StatusOK, map[string]interface{}{
+			"apiVersion": "example.com/v1",
+			"kind":       "Foo",
+			"metadata":   map[string]interface{}{"name": "example"},
+			"spec":       map[string]interface{}{"replicas": float64(1)},
+		}},
+		{crdExamplesPath + "/foos.example.com/v1beta1", http.StatusNotFound, nil},
+		{crdExamplesPath + "/foos.example.com/v1alpha1", http.StatusNotFound, nil},
+		{crdExamplesPath + "/foos.example.com/v2", http.StatusNotFound, nil},
+		{crdExamplesPath + "/foos.example.com", http.StatusNotFound, nil},
+		{crdExamplesPath + "/bars.example.com/v1", http.StatusNotFound, nil},
+	}
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", tc.path, nil))
+		if w.Code != tc.code {
+			t.Errorf("%s: expected status %d, got %d", tc.path, tc.code, w.Code)
+			continue
+		}
+		if tc.expected == nil {
+			continue
+		}
+		actual := map[string]interface{}{}
+		if err := json.Unmarshal(w.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("%s: %v", tc.path, err)
+		}
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%s: expected %#v, got %#v", tc.path, tc.expected, actual)
+		}
+	}
+
+	// the example in the lister is not modified
+	if _, found := example.(map[string]interface{})["apiVersion"]; found {
+		t.Errorf("expected the example of the CustomResourceDefinition to be unchanged, got %v", example)
+	}
+}
@@ -22,44 +22,72 @@ import (
 	"io"
 	"net/http"
 	"path"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/coreos/etcd/clientv3"
+	"github.com/golang/glog"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/apimachinery/pkg/runtime/serializer/protobuf"
 	"k8s.io/apimachinery/pkg/runtime/serializer/versioning"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apiserver/pkg/admission"
 	"k8s.io/apiserver/pkg/endpoints/handlers"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
 	apirequest "k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/generic"
 	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/apiserver/pkg/registry/rest"
 	"k8s.io/apiserver/pkg/storage/storagebackend"
+	"k8s.io/apiserver/pkg/storage/value"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/tools/cache"
 
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/conversion"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/fieldmanager"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/decimal"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/serializer/cbor"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/serializer/yaml"
+	informers "k8s.io/apiextensions-apiserver/pkg/client/informers/internalversion/apiextensions/internalversion"
 	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
 	"k8s.io/apiextensions-apiserver/pkg/controller/finalizer"
+	"k8s.io/apiextensions-apiserver/pkg/controller/storageversion"
 	"k8s.io/apiextensions-apiserver/pkg/registry/customresource"
 )
 
 // crdHandler serves the `/apis` endpoint.
 // This is registered as a filter so that it never collides with any explictly registered endpoints
 type crdHandler struct {
-	versionDiscoveryHandler *versionDiscoveryHandler
-	groupDiscoveryHandler   *groupDiscoveryHandler
+	versionDiscoveryHandler    *versionDiscoveryHandler
+	groupDiscoveryHandler      *groupDiscoveryHandler
+	aggregatedDiscoveryHandler *aggregatedDiscoveryHandler
 
+	// customStorageLock serializes the changes of customStorage and storageBuilds. Requests read
+	// customStorage without it.
 	customStorageLock sync.Mutex
 	// customStorage contains a crdStorageMap
 	customStorage atomic.Value
+	// storageBuilds are the builds of storage in progress by the UIDs of the CustomResourceDefinitions
+	storageBuilds map[types.UID]*storageBuild
+	// servingInfoBuilder builds the storage of a CustomResourceDefinition
+	servingInfoBuilder func(*apiextensions.CustomResourceDefinition) (*crdInfo, error)
 
 	requestContextMapper apirequest.RequestContextMapper
 
@@ -68,37 +96,165 @@ type crdHandler struct {
 	delegate          http.Handler
 	restOptionsGetter generic.RESTOptionsGetter
 	admission         admission.Interface
+
+	// conversionReviewRecorder is notified of the ConversionReview versions negotiated with
+	// conversion webhooks and of their degradation. It is optional.
+	conversionReviewRecorder conversion.WebhookRecorder
+	// conversionWebhookOptions configure the clients of conversion webhooks.
+	conversionWebhookOptions conversion.WebhookOptions
+	// converterFactory creates the converters of custom resources. It is optional.
+	converterFactory *conversion.CRConverterFactory
+
+	// generateNameRetries is how often the creation of a custom resource is retried with a new name
+	// generated from metadata.generateName if the generated name is taken.
+	generateNameRetries int
+	// listCompressionThreshold is the size in bytes above which list responses are compressed.
+	listCompressionThreshold int
+
+	// customResourceLimits bound the number and size of the custom resources of each
+	// CustomResourceDefinition, unless they are overridden by its annotations.
+	customResourceLimits customresource.Limits
+
+	// priorityClassifier classifies the requests for custom resources into the priorityLevels. It is optional.
+	priorityClassifier PriorityClassifier
+	priorityLevels     priorityLevels
+
+	// ruleCostBudget is the runtime cost budget of the validation rules evaluated for a single
+	// request. Zero is unlimited.
+	ruleCostBudget uint64
+	// optimisticServing serves the custom resources of CustomResourceDefinitions as soon as their
+	// names are accepted. Before, their requests get ServiceUnavailable and a Retry-After header
+	// instead of the NotFound of the delegate.
+	optimisticServing bool
+
+	// ownerMapper resolves the kinds of owner references of custom resources.
+	ownerMapper *CustomResourceRESTMapper
+
+	// watchTerminator ends the watches of custom resources when the server shuts down.
+	watchTerminator *watchTerminator
 }
 
 // crdInfo stores enough information to serve the storage for the custom resource
 type crdInfo struct {
-	storage      *customresource.REST
-	requestScope handlers.RequestScope
+	// spec, limits and coerceScalars are used to detect changes of the CustomResourceDefinition which
+	// require new storage
+	spec   *apiextensions.CustomResourceDefinitionSpec
+	limits customresource.Limits
+	// coerceScalars enables the coercion of the scalars of created and updated custom resources to
+	// the types of their schema
+	coerceScalars bool
+
+	// storages and the request scopes are keyed by the served version names
+	storages            map[string]customresource.CustomResourceStorage
+	requestScopes       map[string]handlers.RequestScope
+	statusRequestScopes map[string]handlers.RequestScope
+	scaleRequestScopes  map[string]handlers.RequestScope
+	// customRequestScopes are additionally keyed by the names of the custom subresources
+	customRequestScopes map[string]map[string]handlers.RequestScope
+
+	// fieldManagers merge apply configurations into the custom resources, keyed by the served version names
+	fieldManagers map[string]*fieldmanager.FieldManager
+	// schemas are the validation schemas used for strategic merge patches and passed to admission,
+	// keyed by the served version names
+	schemas map[string]*apiextensions.JSONSchemaProps
+
+	// persisted lists the custom resources in the versions they are persisted in
+	persisted *customresource.REST
+	// counter counts the custom resources persisted in each version by watching persisted until
+	// stopCounter is closed
+	counter     *customresource.Counter
+	stopCounter chan struct{}
+
+	storageVersion string
+
+	// requests are the requests served from the storage
+	requests storageRequests
 }
 
 // crdStorageMap goes from customresourcedefinition to its storage
 type crdStorageMap map[types.UID]*crdInfo
 
+// storageBuild is the build of the storage of a CustomResourceDefinition. info and err are set
+// before done is closed.
+type storageBuild struct {
+	crd  *apiextensions.CustomResourceDefinition
+	done chan struct{}
+	info *crdInfo
+	err  error
+}
+
+// CustomResourceDefinitionHandlerOptions configure how the handler serves custom resources. The zero
+// value serves them without limits, conversion webhook recorder or priorities.
+type CustomResourceDefinitionHandlerOptions struct {
+	// ConversionReviewRecorder is notified of the ConversionReview versions negotiated with conversion
+	// webhooks and of their degradation. It is optional.
+	ConversionReviewRecorder conversion.WebhookRecorder
+	// ConversionWebhookOptions configure the clients of conversion webhooks.
+	ConversionWebhookOptions conversion.WebhookOptions
+	// ConverterFactory creates the converters of custom resources. It is optional.
+	ConverterFactory *conversion.CRConverterFactory
+	// GenerateNameRetries is how often the creation of a custom resource is retried with a new name
+	// generated from metadata.generateName if the generated name is taken.
+	GenerateNameRetries int
+	// ListCompressionThreshold is the size in bytes above which list responses are compressed.
+	ListCompressionThreshold int
+	// CustomResourceLimits bound the number and size of the custom resources of each
+	// CustomResourceDefinition, unless they are overridden by its annotations.
+	CustomResourceLimits customresource.Limits
+	// PriorityClassifier classifies the requests for custom resources into the priority levels of
+	// PriorityLimits, which limit the number of their concurrent requests. It is optional.
+	PriorityClassifier PriorityClassifier
+	PriorityLimits     map[string]int
+	// RuleCostBudget is the runtime cost budget of the validation rules evaluated for a single
+	// request. Zero is unlimited.
+	RuleCostBudget uint64
+	// OptimisticServing serves the custom resources of CustomResourceDefinitions as soon as their
+	// names are accepted, without waiting until they are established.
+	OptimisticServing bool
+}
+
 func NewCustomResourceDefinitionHandler(
 	versionDiscoveryHandler *versionDiscoveryHandler,
 	groupDiscoveryHandler *groupDiscoveryHandler,
+	aggregatedDiscoveryHandler *aggregatedDiscoveryHandler,
 	requestContextMapper apirequest.RequestContextMapper,
-	crdLister listers.CustomResourceDefinitionLister,
+	crdInformer informers.CustomResourceDefinitionInformer,
 	delegate http.Handler,
 	restOptionsGetter generic.RESTOptionsGetter,
-	admission admission.Interface) *crdHandler {
+	admission admission.Interface,
+	options CustomResourceDefinitionHandlerOptions) *crdHandler {
 	ret := &crdHandler{
-		versionDiscoveryHandler: versionDiscoveryHandler,
-		groupDiscoveryHandler:   groupDiscoveryHandler,
-		customStorage:           atomic.Value{},
-		requestContextMapper:    requestContextMapper,
-		crdLister:               crdLister,
-		delegate:                delegate,
-		restOptionsGetter:       restOptionsGetter,
-		admission:               admission,
+		versionDiscoveryHandler:    versionDiscoveryHandler,
+		groupDiscoveryHandler:      groupDiscoveryHandler,
+		aggregatedDiscoveryHandler: aggregatedDiscoveryHandler,
+		customStorage:              atomic.Value{},
+		storageBuilds:              map[types.UID]*storageBuild{},
+		requestContextMapper:       requestContextMapper,
+		crdLister:                  crdInformer.Lister(),
+		delegate:                   delegate,
+		restOptionsGetter:          restOptionsGetter,
+		admission:                  admission,
+		conversionReviewRecorder:   options.ConversionReviewRecorder,
+		conversionWebhookOptions:   options.ConversionWebhookOptions,
+		converterFactory:           options.ConverterFactory,
+		generateNameRetries:        options.GenerateNameRetries,
+		listCompressionThreshold:   options.ListCompressionThreshold,
+		customResourceLimits:       options.CustomResourceLimits,
+		priorityClassifier:         options.PriorityClassifier,
+		priorityLevels:             newPriorityLevels(options.PriorityLimits),
+		ruleCostBudget:             options.RuleCostBudget,
+		optimisticServing:          options.OptimisticServing,
+		ownerMapper:                NewCustomResourceRESTMapper(crdInformer.Lister()),
+		watchTerminator:            newWatchTerminator(),
 	}
 
+	crdInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: ret.updateCustomResourceDefinition,
+		DeleteFunc: ret.removeCustomResourceDefinition,
+	})
+
 	ret.customStorage.Store(crdStorageMap{})
+	ret.servingInfoBuilder = ret.newServingInfo
 	return ret
 }
 
@@ -126,14 +282,33 @@ func (r *crdHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			r.groupDiscoveryHandler.ServeHTTP(w, req)
 			return
 		}
+		// only match /apis
+		if len(pathParts) == 1 {
+			r.aggregatedDiscoveryHandler.ServeHTTP(w, req)
+			return
+		}
 
 		r.delegate.ServeHTTP(w, req)
 		return
 	}
 
+	// the responses of the terminating server close their connection, with a GOAWAY on HTTP/2, such
+	// that the clients of its watches resume on other replicas
+	if r.watchTerminator.terminated() {
+		w.Header().Set("Connection", "close")
+	}
+
 	crdName := requestInfo.Resource + "." + requestInfo.APIGroup
 	crd, err := r.crdLister.Get(crdName)
 	if apierrors.IsNotFound(err) {
+		// misspelled resources of groups with CustomResourceDefinitions get a NotFound status
+		// suggesting the resources with similar names instead of the generic NotFound of the delegate
+		if crds, err := r.crdLister.List(labels.Everything()); err == nil {
+			if suggestions := resourceSuggestions(crds, requestInfo.APIGroup, requestInfo.Resource); len(suggestions) > 0 {
+				writeResourceNotFoundError(ctx, w, req, requestInfo, suggestions)
+				return
+			}
+		}
 		r.delegate.ServeHTTP(w, req)
 		return
 	}
@@ -141,244 +316,921 @@ func (r *crdHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	if crd.Spec.Version != requestInfo.APIVersion {
+	if !apiextensions.HasServedCRDVersion(crd, requestInfo.APIVersion) {
+		// patches and applies of versions which are not served, e.g. because they were removed, get
+		// an error naming the served versions instead of the generic NotFound of the delegate
+		if requestInfo.Verb == "patch" && apiextensions.IsCRDConditionTrue(crd, apiextensions.Established) {
+			writeUnservedVersionError(ctx, w, req, crd, requestInfo)
+			return
+		}
 		r.delegate.ServeHTTP(w, req)
 		return
 	}
 	if !apiextensions.IsCRDConditionTrue(crd, apiextensions.Established) {
-		r.delegate.ServeHTTP(w, req)
+		// with optimistic serving, new CustomResourceDefinitions are served as soon as their names are
+		// accepted instead of once the establishing controller established them. Until then clients
+		// retry shortly instead of failing. Rejected names and deleted CustomResourceDefinitions are
+		// not served.
+		optimistic := r.optimisticServing && crd.DeletionTimestamp == nil && !apiextensions.IsCRDConditionFalse(crd, apiextensions.NamesAccepted)
+		switch {
+		case optimistic && apiextensions.IsCRDConditionTrue(crd, apiextensions.NamesAccepted):
+		case optimistic:
+			writeNotEstablishedError(ctx, w, req, crd, requestInfo)
+			return
+		default:
+			r.delegate.ServeHTTP(w, req)
+			return
+		}
+	}
+	// the deletion timestamp is checked too because the Terminating condition is set back to false
+	// once all instances are removed, before the CustomResourceDefinition itself is gone.
+	terminating := apiextensions.IsCRDConditionTrue(crd, apiextensions.Terminating) || crd.DeletionTimestamp != nil
+
+	if logging := requestLoggingFor(crd); logging.enabled() {
+		recorder := &statusRecordingResponseWriter{ResponseWriter: w}
+		start := time.Now()
+		defer func() {
+			logging.logRequest(ctx, req, requestInfo, crd.Name, recorder, start)
+		}()
+		w = recorder
+	}
+
+	crdInfo, err := r.getServingInfoFor(crd)
+	if err != nil {
+		utilruntime.HandleError(err)
+		http.Error(w, fmt.Sprintf("error resolving resource: %v", err), http.StatusInternalServerError)
+		return
+	}
+	// the storage is destroyed after the requests served from it finished
+	if !crdInfo.requests.add() {
+		writeStorageReplacedError(ctx, w, req, crdInfo, requestInfo)
+		return
+	}
+	defer crdInfo.requests.done()
+
+	if warning := apiextensions.GetDeprecationWarning(crd, requestInfo.APIVersion); warning != nil {
+		addWarningHeader(w, *warning)
+	}
+	if err := r.requestContextMapper.Update(req, customresource.WithWarningHandler(ctx, func(text string) {
+		addWarningHeader(w, text)
+	})); err != nil {
+		utilruntime.HandleError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := validateDryRun(req, requestInfo); err != nil {
+		scope := crdInfo.requestScopes[requestInfo.APIVersion]
+		responsewriters.ErrorNegotiated(ctx, err, scope.Serializer, scope.Kind.GroupVersion(), w, req)
+		return
 	}
-	if len(requestInfo.Subresource) > 0 {
+	if err := checkReadOnly(ctx, crd, requestInfo); err != nil {
+		scope := crdInfo.requestScopes[requestInfo.APIVersion]
+		responsewriters.ErrorNegotiated(ctx, err, scope.Serializer, scope.Kind.GroupVersion(), w, req)
+		return
+	}
+
+	release, err := r.admitPriorityLevel(ctx, crd, requestInfo)
+	if err != nil {
+		scope := crdInfo.requestScopes[requestInfo.APIVersion]
+		responsewriters.ErrorNegotiated(ctx, err, scope.Serializer, scope.Kind.GroupVersion(), w, req)
+		return
+	}
+	defer release()
+
+	var handler http.HandlerFunc
+	subresources, err := apiextensions.GetSubresourcesForVersion(crd, requestInfo.APIVersion)
+	if err != nil {
+		utilruntime.HandleError(err)
+		http.Error(w, "the server could not properly serve the CR subresources", http.StatusInternalServerError)
+		return
+	}
+	switch {
+	case requestInfo.Subresource == "status" && subresources != nil && subresources.Status != nil:
+		handler = r.serveStatus(w, req, requestInfo, crdInfo, terminating)
+	case requestInfo.Subresource == "scale" && subresources != nil && subresources.Scale != nil:
+		handler = r.serveScale(w, req, requestInfo, crdInfo, terminating)
+	case len(requestInfo.Subresource) > 0 && customSubresource(subresources, requestInfo.Subresource) != nil:
+		handler = r.serveCustomSubresource(w, req, requestInfo, crdInfo, customSubresource(subresources, requestInfo.Subresource), terminating)
+	case len(requestInfo.Subresource) == 0:
+		handler = r.serveResource(w, req, requestInfo, crdInfo, terminating)
+	default:
 		http.NotFound(w, req)
 		return
 	}
 
-	terminating := apiextensions.IsCRDConditionTrue(crd, apiextensions.Terminating)
+	if handler != nil {
+		handler(w, req)
+	}
+}
+
+// warningTextEscaper escapes the text of a Warning header as a quoted string.
+var warningTextEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// addWarningHeader adds an RFC 7234 Warning header with the code 299, i.e. a miscellaneous
+// persistent warning, and an unknown agent.
+func addWarningHeader(w http.ResponseWriter, text string) {
+	w.Header().Add("Warning", fmt.Sprintf(`299 - "%s"`, warningTextEscaper.Replace(text)))
+}
 
-	crdInfo := r.getServingInfoFor(crd)
-	storage := crdInfo.storage
-	requestScope := crdInfo.requestScope
+func (r *crdHandler) serveResource(w http.ResponseWriter, req *http.Request, requestInfo *apirequest.RequestInfo, crdInfo *crdInfo, terminating bool) http.HandlerFunc {
+	storage := crdInfo.storages[requestInfo.APIVersion].CustomResource
+	requestScope := crdInfo.requestScopes[requestInfo.APIVersion]
+	versionSchema := crdInfo.schemas[requestInfo.APIVersion]
+	admit := withSchema(withCoercion(r.admission, versionSchema, crdInfo.coerceScalars), versionSchema)
 	minRequestTimeout := 1 * time.Minute
 
 	switch requestInfo.Verb {
 	case "get":
-		handler := handlers.GetResource(storage, storage, requestScope)
-		handler(w, req)
-		return
+		return handlers.GetResource(storage, storage, requestScope)
 	case "list":
 		forceWatch := false
-		handler := handlers.ListResource(storage, storage, requestScope, forceWatch, minRequestTimeout)
-		handler(w, req)
-		return
+		return withListCompression(handlers.ListResource(storage, storage, requestScope, forceWatch, minRequestTimeout), r.listCompressionThreshold)
 	case "watch":
 		forceWatch := true
-		handler := handlers.ListResource(storage, storage, requestScope, forceWatch, minRequestTimeout)
-		handler(w, req)
-		return
+		watcher := r.watchTerminator.watcher(storage)
+		return handlers.ListResource(storage, watcher, requestScope, forceWatch, minRequestTimeout)
 	case "create":
 		if terminating {
-			http.Error(w, fmt.Sprintf("%v not allowed while CustomResourceDefinition is terminating", requestInfo.Verb), http.StatusMethodNotAllowed)
-			return
+			writeTerminatingError(w, req, requestInfo, requestScope)
+			return nil
 		}
-		handler := handlers.CreateResource(storage, requestScope, discovery.NewUnstructuredObjectTyper(nil), r.admission)
-		handler(w, req)
-		return
+		if isDryRunRequest(req) {
+			return dryRunCreateResource(storage, requestScope)
+		}
+		return handlers.CreateResource(storage, requestScope, discovery.NewUnstructuredObjectTyper(nil), admit)
 	case "update":
 		if terminating {
-			http.Error(w, fmt.Sprintf("%v not allowed while CustomResourceDefinition is terminating", requestInfo.Verb), http.StatusMethodNotAllowed)
-			return
+			writeTerminatingError(w, req, requestInfo, requestScope)
+			return nil
 		}
-		handler := handlers.UpdateResource(storage, requestScope, discovery.NewUnstructuredObjectTyper(nil), r.admission)
-		handler(w, req)
-		return
+		return handlers.UpdateResource(storage, requestScope, discovery.NewUnstructuredObjectTyper(nil), admit)
 	case "patch":
 		if terminating {
-			http.Error(w, fmt.Sprintf("%v not allowed while CustomResourceDefinition is terminating", requestInfo.Verb), http.StatusMethodNotAllowed)
-			return
+			writeTerminatingError(w, req, requestInfo, requestScope)
+			return nil
 		}
-		handler := handlers.PatchResource(storage, requestScope, r.admission, unstructured.UnstructuredObjectConverter{})
-		handler(w, req)
-		return
+		if isApplyRequest(req) {
+			return applyResource(storage, requestScope, crdInfo.fieldManagers[requestInfo.APIVersion], servedVersions(crdInfo.spec), admit)
+		}
+		if err := checkPatchAPIVersion(req, requestScope, servedVersions(crdInfo.spec)); err != nil {
+			responsewriters.ErrorNegotiated(requestScope.ContextFunc(req), err, requestScope.Serializer, requestScope.Kind.GroupVersion(), w, req)
+			return nil
+		}
+		if isStrategicMergePatchRequest(req) {
+			return strategicMergePatchResource(storage, requestScope, crdInfo.schemas[requestInfo.APIVersion], admit)
+		}
+		return handlers.PatchResource(storage, requestScope, admit, unstructured.UnstructuredObjectConverter{})
 	case "delete":
 		allowsOptions := true
-		handler := handlers.DeleteResource(storage, allowsOptions, requestScope, r.admission)
-		handler(w, req)
-		return
+		return handlers.DeleteResource(storage, allowsOptions, requestScope, admit)
 	case "deletecollection":
 		checkBody := true
-		handler := handlers.DeleteCollection(storage, checkBody, requestScope, r.admission)
-		handler(w, req)
-		return
+		return handlers.DeleteCollection(storage, checkBody, requestScope, admit)
+	default:
+		http.Error(w, fmt.Sprintf("unhandled verb %q", requestInfo.Verb), http.StatusMethodNotAllowed)
+		return nil
+	}
+}
 
+func (r *crdHandler) serveStatus(w http.ResponseWriter, req *http.Request, requestInfo *apirequest.RequestInfo, crdInfo *crdInfo, terminating bool) http.HandlerFunc {
+	storage := crdInfo.storages[requestInfo.APIVersion].Status
+	requestScope := crdInfo.statusRequestScopes[requestInfo.APIVersion]
+	versionSchema := crdInfo.schemas[requestInfo.APIVersion]
+	admit := withSchema(withCoercion(r.admission, versionSchema, crdInfo.coerceScalars), versionSchema)
+
+	switch requestInfo.Verb {
+	case "get":
+		return handlers.GetResource(storage, nil, requestScope)
+	case "update":
+		if terminating {
+			writeTerminatingError(w, req, requestInfo, requestScope)
+			return nil
+		}
+		return handlers.UpdateResource(storage, requestScope, discovery.NewUnstructuredObjectTyper(nil), admit)
+	case "patch":
+		if terminating {
+			writeTerminatingError(w, req, requestInfo, requestScope)
+			return nil
+		}
+		if err := checkPatchAPIVersion(req, requestScope, servedVersions(crdInfo.spec)); err != nil {
+			responsewriters.ErrorNegotiated(requestScope.ContextFunc(req), err, requestScope.Serializer, requestScope.Kind.GroupVersion(), w, req)
+			return nil
+		}
+		if isStrategicMergePatchRequest(req) {
+			return strategicMergePatchResource(storage, requestScope, crdInfo.schemas[requestInfo.APIVersion], admit)
+		}
+		return handlers.PatchResource(storage, requestScope, admit, unstructured.UnstructuredObjectConverter{})
 	default:
 		http.Error(w, fmt.Sprintf("unhandled verb %q", requestInfo.Verb), http.StatusMethodNotAllowed)
-		return
+		return nil
 	}
 }
 
-// removeDeadStorage removes REST storage that isn't being used
-func (r *crdHandler) removeDeadStorage() {
-	// these don't have to be live.  A snapshot is fine
-	// if we wrongly delete, that's ok.  The rest storage will be recreated on the next request
-	// if we wrongly miss one, that's ok.  We'll get it next time
+func (r *crdHandler) serveScale(w http.ResponseWriter, req *http.Request, requestInfo *apirequest.RequestInfo, crdInfo *crdInfo, terminating bool) http.HandlerFunc {
+	scaleStorage := crdInfo.storages[requestInfo.APIVersion].Scale
+	requestScope := crdInfo.scaleRequestScopes[requestInfo.APIVersion]
+
+	switch requestInfo.Verb {
+	case "get":
+		return handlers.GetResource(scaleStorage, nil, requestScope)
+	case "update":
+		if terminating {
+			writeTerminatingError(w, req, requestInfo, requestScope)
+			return nil
+		}
+		return handlers.UpdateResource(scaleStorage, requestScope, Scheme, r.admission)
+	case "patch":
+		if terminating {
+			writeTerminatingError(w, req, requestInfo, requestScope)
+			return nil
+		}
+		return handlers.PatchResource(scaleStorage, requestScope, r.admission, Scheme)
+	default:
+		http.Error(w, fmt.Sprintf("unhandled verb %q", requestInfo.Verb), http.StatusMethodNotAllowed)
+		return nil
+	}
+}
+
+// customSubresource returns the custom subresource with the given name, or nil if there is none.
+func customSubresource(subresources *apiextensions.CustomResourceSubresources, name string) *apiextensions.CustomResourceSubresourceCustom {
+	if subresources == nil {
+		return nil
+	}
+	for i := range subresources.Custom {
+		if subresources.Custom[i].Name == name {
+			return &subresources.Custom[i]
+		}
+	}
+	return nil
+}
+
+func (r *crdHandler) serveCustomSubresource(w http.ResponseWriter, req *http.Request, requestInfo *apirequest.RequestInfo, crdInfo *crdInfo, subresource *apiextensions.CustomResourceSubresourceCustom, terminating bool) http.HandlerFunc {
+	storage := crdInfo.storages[requestInfo.APIVersion].Custom[subresource.Name]
+	requestScope := crdInfo.customRequestScopes[requestInfo.APIVersion][subresource.Name]
+	versionSchema := crdInfo.schemas[requestInfo.APIVersion]
+	admit := withSchema(withCoercion(r.admission, versionSchema, crdInfo.coerceScalars), versionSchema)
+
+	allowed := false
+	for _, verb := range subresource.Verbs {
+		if verb == requestInfo.Verb {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		http.Error(w, fmt.Sprintf("verb %q is not allowed for the %s subresource", requestInfo.Verb, subresource.Name), http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	switch requestInfo.Verb {
+	case "get":
+		return handlers.GetResource(storage, nil, requestScope)
+	case "update":
+		if terminating {
+			writeTerminatingError(w, req, requestInfo, requestScope)
+			return nil
+		}
+		return handlers.UpdateResource(storage, requestScope, discovery.NewUnstructuredObjectTyper(nil), admit)
+	case "patch":
+		if terminating {
+			writeTerminatingError(w, req, requestInfo, requestScope)
+			return nil
+		}
+		if err := checkPatchAPIVersion(req, requestScope, servedVersions(crdInfo.spec)); err != nil {
+			responsewriters.ErrorNegotiated(requestScope.ContextFunc(req), err, requestScope.Serializer, requestScope.Kind.GroupVersion(), w, req)
+			return nil
+		}
+		if isStrategicMergePatchRequest(req) {
+			return strategicMergePatchResource(storage, requestScope, crdInfo.schemas[requestInfo.APIVersion], admit)
+		}
+		return handlers.PatchResource(storage, requestScope, admit, unstructured.UnstructuredObjectConverter{})
+	default:
+		http.Error(w, fmt.Sprintf("unhandled verb %q", requestInfo.Verb), http.StatusMethodNotAllowed)
+		return nil
+	}
+}
+
+// writeTerminatingError responds with a MethodNotAllowed status to a write which is rejected because
+// the CustomResourceDefinition is terminating.
+func writeTerminatingError(w http.ResponseWriter, req *http.Request, requestInfo *apirequest.RequestInfo, scope handlers.RequestScope) {
+	err := apierrors.NewMethodNotSupported(schema.GroupResource{Group: requestInfo.APIGroup, Resource: requestInfo.Resource}, requestInfo.Verb)
+	err.ErrStatus.Message = fmt.Sprintf("%v not allowed while CustomResourceDefinition is terminating", requestInfo.Verb)
+	responsewriters.ErrorNegotiated(scope.ContextFunc(req), err, scope.Serializer, scope.Kind.GroupVersion(), w, req)
+}
+
+// notEstablishedRetryAfterSeconds is the Retry-After of requests for the custom resources of
+// CustomResourceDefinitions whose names are about to be accepted.
+const notEstablishedRetryAfterSeconds = 1
+
+// writeNotEstablishedError responds with a ServiceUnavailable status and a Retry-After header to a
+// request for the custom resources of a CustomResourceDefinition whose names are not accepted yet.
+func writeNotEstablishedError(ctx apirequest.Context, w http.ResponseWriter, req *http.Request, crd *apiextensions.CustomResourceDefinition, requestInfo *apirequest.RequestInfo) {
+	err := apierrors.NewServiceUnavailable(fmt.Sprintf("the CustomResourceDefinition %s is not established yet", crd.Name))
+	err.ErrStatus.Details = &metav1.StatusDetails{
+		Group:             requestInfo.APIGroup,
+		Kind:              requestInfo.Resource,
+		Name:              requestInfo.Name,
+		RetryAfterSeconds: notEstablishedRetryAfterSeconds,
+	}
+	responsewriters.ErrorNegotiated(ctx, err, Codecs, schema.GroupVersion{Group: requestInfo.APIGroup, Version: requestInfo.APIVersion}, w, req)
+}
+
+// storageReplacedRetryAfterSeconds is the Retry-After of requests which found replaced storage.
+const storageReplacedRetryAfterSeconds = 1
+
+// writeStorageReplacedError writes a ServiceUnavailable status for a request which found the storage
+// of the CustomResourceDefinition just as it was replaced. The retry is served from the new storage.
+func writeStorageReplacedError(ctx apirequest.Context, w http.ResponseWriter, req *http.Request, crdInfo *crdInfo, requestInfo *apirequest.RequestInfo) {
+	err := newStorageReplacedError()
+	err.ErrStatus.Details = &metav1.StatusDetails{
+		Group:             requestInfo.APIGroup,
+		Kind:              requestInfo.Resource,
+		Name:              requestInfo.Name,
+		RetryAfterSeconds: storageReplacedRetryAfterSeconds,
+	}
+	scope := crdInfo.requestScopes[requestInfo.APIVersion]
+	responsewriters.ErrorNegotiated(ctx, err, scope.Serializer, scope.Kind.GroupVersion(), w, req)
+}
+
+// updateCustomResourceDefinition drops the storage of a CustomResourceDefinition whose spec, limits
+// or scalar coercion changed. It is recreated from the new spec on the next request.
+func (r *crdHandler) updateCustomResourceDefinition(oldObj, newObj interface{}) {
+	newCRD := newObj.(*apiextensions.CustomResourceDefinition)
+
+	r.customStorageLock.Lock()
+	defer r.customStorageLock.Unlock()
+
+	// a build from the previous spec is dropped, the next request builds the storage again
+	if build, found := r.storageBuilds[newCRD.UID]; found && r.storageChanged(newCRD, &build.crd.Spec, customResourceLimitsFor(build.crd, r.customResourceLimits), coerceScalars(build.crd)) {
+		delete(r.storageBuilds, newCRD.UID)
+	}
+
 	storageMap := r.customStorage.Load().(crdStorageMap)
-	allCustomResourceDefinitions, err := r.crdLister.List(labels.Everything())
-	if err != nil {
-		utilruntime.HandleError(err)
+	oldInfo, found := storageMap[newCRD.UID]
+	if !found {
+		return
+	}
+	if !r.storageChanged(newCRD, oldInfo.spec, oldInfo.limits, oldInfo.coerceScalars) {
+		glog.V(6).Infof("Ignoring customresourcedefinition %s update because the spec, limits and scalar coercion did not change", newCRD.Name)
 		return
 	}
 
-	for uid := range storageMap {
-		found := false
-		for _, crd := range allCustomResourceDefinitions {
-			if crd.UID == uid {
-				found = true
-				break
-			}
+	glog.V(4).Infof("Updating customresourcedefinition %s", newCRD.Name)
+
+	r.updateStorageMap(func(storageMap crdStorageMap) {
+		delete(storageMap, newCRD.UID)
+	})
+	destroyStorage(newCRD.Name, oldInfo)
+}
+
+// storageChanged returns true if storage built from the given spec, limits and scalar coercion
+// does not serve crd.
+func (r *crdHandler) storageChanged(crd *apiextensions.CustomResourceDefinition, spec *apiextensions.CustomResourceDefinitionSpec, limits customresource.Limits, coerce bool) bool {
+	return !apiequality.Semantic.DeepEqual(&crd.Spec, spec) || customResourceLimitsFor(crd, r.customResourceLimits) != limits || coerceScalars(crd) != coerce
+}
+
+// removeCustomResourceDefinition drops the storage of a deleted CustomResourceDefinition.
+func (r *crdHandler) removeCustomResourceDefinition(obj interface{}) {
+	crd, ok := obj.(*apiextensions.CustomResourceDefinition)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			glog.Errorf("Couldn't get object from tombstone %#v", obj)
+			return
 		}
-		if !found {
-			delete(storageMap, uid)
+		crd, ok = tombstone.Obj.(*apiextensions.CustomResourceDefinition)
+		if !ok {
+			glog.Errorf("Tombstone contained object that is not expected %#v", obj)
+			return
 		}
 	}
 
 	r.customStorageLock.Lock()
 	defer r.customStorageLock.Unlock()
 
-	r.customStorage.Store(storageMap)
+	delete(r.storageBuilds, crd.UID)
+	oldInfo, found := r.customStorage.Load().(crdStorageMap)[crd.UID]
+	if !found {
+		return
+	}
+	glog.V(4).Infof("Removing storage of customresourcedefinition %s", crd.Name)
+	r.updateStorageMap(func(storageMap crdStorageMap) {
+		delete(storageMap, crd.UID)
+	})
+	destroyStorage(crd.Name, oldInfo)
+}
+
+// updateStorageMap replaces the storage map by an updated copy. The map is read without the lock,
+// so it is never written to: requests which loaded it keep serving from the storage they found, and
+// the storage of other CustomResourceDefinitions stays untouched. The caller must hold customStorageLock.
+func (r *crdHandler) updateStorageMap(update func(storageMap crdStorageMap)) {
+	storageMap := r.customStorage.Load().(crdStorageMap)
+	storageMap2 := make(crdStorageMap, len(storageMap)+1)
+	for k, v := range storageMap {
+		storageMap2[k] = v
+	}
+	update(storageMap2)
+	r.customStorage.Store(storageMap2)
 }
 
 // GetCustomResourceListerCollectionDeleter returns the ListerCollectionDeleter for
-// the given uid, or nil if one does not exist.
+// the given uid, or nil if one does not exist. Its calls are counted as requests of the storage.
 func (r *crdHandler) GetCustomResourceListerCollectionDeleter(crd *apiextensions.CustomResourceDefinition) finalizer.ListerCollectionDeleter {
-	info := r.getServingInfoFor(crd)
-	return info.storage
+	info, err := r.getServingInfoFor(crd)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return nil
+	}
+	return countedStorage{info: info, storage: info.storages[info.storageVersion].CustomResource}
+}
+
+// GetCustomResourceLister returns the Lister for the custom resources of the given CRD. Its calls
+// are counted as requests of the storage.
+func (r *crdHandler) GetCustomResourceLister(crd *apiextensions.CustomResourceDefinition) (rest.Lister, error) {
+	info, err := r.getServingInfoFor(crd)
+	if err != nil {
+		return nil, err
+	}
+	return countedStorage{info: info, storage: info.storages[info.storageVersion].CustomResource}, nil
+}
+
+// HasCustomResources returns true if custom resources of the given CRD exist in any namespace. They
+// are listed from storage, consistently with etcd, since the counter of the storage lags behind and
+// the check guards changes of the storage path.
+func (r *crdHandler) HasCustomResources(crd *apiextensions.CustomResourceDefinition) (bool, error) {
+	info, err := r.getServingInfoFor(crd)
+	if err != nil {
+		return false, err
+	}
+	if !info.requests.add() {
+		return false, newStorageReplacedError()
+	}
+	defer info.requests.done()
+	obj, err := info.storages[info.storageVersion].CustomResource.Store.List(apirequest.NewContext(), &metainternalversion.ListOptions{})
+	if err != nil {
+		return false, err
+	}
+	list, ok := obj.(*unstructured.UnstructuredList)
+	if !ok {
+		return false, fmt.Errorf("unexpected list type %T", obj)
+	}
+	return len(list.Items) > 0, nil
 }
 
-func (r *crdHandler) getServingInfoFor(crd *apiextensions.CustomResourceDefinition) *crdInfo {
+// CountPersistedVersions returns the number of custom resources of the given CRD persisted in each
+// version, according to the counter of the storage. Versions without persisted custom resources are
+// omitted.
+func (r *crdHandler) CountPersistedVersions(crd *apiextensions.CustomResourceDefinition) (map[string]int64, error) {
+	info, err := r.getServingInfoFor(crd)
+	if err != nil {
+		return nil, err
+	}
+	if !info.requests.add() {
+		return nil, newStorageReplacedError()
+	}
+	defer info.requests.done()
+	return info.counter.Counts()
+}
+
+// GetCustomResourceListerUpdater returns the ListerUpdater for the given CRD. It fails if the
+// storage was not recreated from the current spec of the CRD yet. Its calls are counted as requests
+// of the storage.
+func (r *crdHandler) GetCustomResourceListerUpdater(crd *apiextensions.CustomResourceDefinition) (storageversion.ListerUpdater, error) {
+	info, err := r.getServingInfoFor(crd)
+	if err != nil {
+		return nil, err
+	}
+	if !apiequality.Semantic.DeepEqual(&crd.Spec, info.spec) {
+		return nil, fmt.Errorf("the storage of %s is not up to date yet", crd.Name)
+	}
+	return countedStorage{info: info, storage: info.storages[info.storageVersion].CustomResource}, nil
+}
+
+func (r *crdHandler) getServingInfoFor(crd *apiextensions.CustomResourceDefinition) (*crdInfo, error) {
 	storageMap := r.customStorage.Load().(crdStorageMap)
 	ret, ok := storageMap[crd.UID]
 	if ok {
-		return ret
+		return ret, nil
 	}
 
 	r.customStorageLock.Lock()
-	defer r.customStorageLock.Unlock()
-
-	ret, ok = storageMap[crd.UID]
+	// another request might have created the storage while we were waiting for the lock
+	ret, ok = r.customStorage.Load().(crdStorageMap)[crd.UID]
 	if ok {
-		return ret
-	}
-
-	// In addition to Unstructured objects (Custom Resources), we also may sometimes need to
-	// decode unversioned Options objects, so we delegate to parameterScheme for such types.
-	parameterScheme := runtime.NewScheme()
-	parameterScheme.AddUnversionedTypes(schema.GroupVersion{Group: crd.Spec.Group, Version: crd.Spec.Version},
-		&metav1.ListOptions{},
-		&metav1.ExportOptions{},
-		&metav1.GetOptions{},
-		&metav1.DeleteOptions{},
-	)
-	parameterScheme.AddGeneratedDeepCopyFuncs(metav1.GetGeneratedDeepCopyFuncs()...)
-	parameterCodec := runtime.NewParameterCodec(parameterScheme)
-
-	kind := schema.GroupVersionKind{Group: crd.Spec.Group, Version: crd.Spec.Version, Kind: crd.Spec.Names.Kind}
-	typer := unstructuredObjectTyper{
-		delegate:          parameterScheme,
-		unstructuredTyper: discovery.NewUnstructuredObjectTyper(nil),
-	}
-	creator := unstructuredCreator{}
-	storage := customresource.NewREST(
-		schema.GroupResource{Group: crd.Spec.Group, Resource: crd.Spec.Names.Plural},
-		schema.GroupVersionKind{Group: crd.Spec.Group, Version: crd.Spec.Version, Kind: crd.Spec.Names.ListKind},
-		UnstructuredCopier{},
-		customresource.NewStrategy(
+		r.customStorageLock.Unlock()
+		return ret, nil
+	}
+	// the storage is built without holding the lock, such that building it does not block the
+	// requests and informer events of other CustomResourceDefinitions. Concurrent requests for the
+	// same CustomResourceDefinition wait for the same build.
+	if build, ok := r.storageBuilds[crd.UID]; ok {
+		r.customStorageLock.Unlock()
+		<-build.done
+		return build.info, build.err
+	}
+	build := &storageBuild{crd: crd, done: make(chan struct{})}
+	r.storageBuilds[crd.UID] = build
+	r.customStorageLock.Unlock()
+	defer close(build.done)
+
+	for {
+		info, err := r.servingInfoBuilder(build.crd)
+
+		r.customStorageLock.Lock()
+		if r.storageBuilds[crd.UID] == build {
+			delete(r.storageBuilds, crd.UID)
+			if err == nil {
+				r.updateStorageMap(func(storageMap crdStorageMap) {
+					storageMap[crd.UID] = info
+				})
+			}
+			r.customStorageLock.Unlock()
+			build.info, build.err = info, err
+			return info, err
+		}
+		// the build was dropped by an update or removal of the CustomResourceDefinition. Nothing was
+		// served from it yet, so it is destroyed and built again from the current CustomResourceDefinition.
+		latest, getErr := r.crdLister.Get(crd.Name)
+		if getErr == nil && latest.UID == crd.UID {
+			build.crd = latest
+			r.storageBuilds[crd.UID] = build
+		}
+		r.customStorageLock.Unlock()
+
+		if err == nil {
+			info.destroy()
+		}
+		if getErr != nil || latest.UID != crd.UID {
+			build.err = apierrors.NewNotFound(apiextensions.Resource("customresourcedefinitions"), crd.Name)
+			return nil, build.err
+		}
+	}
+}
+
+// newServingInfo builds the storage and request scopes of the served versions of the CustomResourceDefinition.
+func (r *crdHandler) newServingInfo(crd *apiextensions.CustomResourceDefinition) (*crdInfo, error) {
+	storageVersion, err := apiextensions.GetCRDStorageVersion(crd)
+	if err != nil {
+		return nil, err
+	}
+	mirrorVersion := schema.GroupVersion{}
+	if v := apiextensions.GetCRDMirrorStorageVersion(crd); len(v) > 0 {
+		mirrorVersion = schema.GroupVersion{Group: crd.Spec.Group, Version: v}
+	}
+	converter, err := r.converterFactory.NewConverter(crd, r.conversionWebhookOptions, r.conversionReviewRecorder)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := customResourceLimitsFor(crd, r.customResourceLimits)
+	storages := map[string]customresource.CustomResourceStorage{}
+	requestScopes := map[string]handlers.RequestScope{}
+	statusRequestScopes := map[string]handlers.RequestScope{}
+	scaleRequestScopes := map[string]handlers.RequestScope{}
+	customRequestScopes := map[string]map[string]handlers.RequestScope{}
+	fieldManagers := map[string]*fieldmanager.FieldManager{}
+	schemas := map[string]*apiextensions.JSONSchemaProps{}
+	var persisted *customresource.REST
+	// the storage created before a failure is destroyed
+	built := false
+	defer func() {
+		if !built {
+			(&crdInfo{storages: storages, persisted: persisted}).destroy()
+		}
+	}()
+
+	preserveUnknownFields := crd.Spec.PreserveUnknownFields == nil || *crd.Spec.PreserveUnknownFields
+
+	restOptionsGetter := r.restOptionsGetter
+	if crd.Spec.Storage != nil {
+		restOptionsGetter = crdStorageRESTOptionsGetter{
+			RESTOptionsGetter: r.restOptionsGetter,
+			resourcePrefix:    crd.Spec.Storage.Prefix,
+		}
+	}
+
+	for _, v := range crd.Spec.Versions {
+		validation, err := apiextensions.GetSchemaForVersion(crd, v.Name)
+		if err != nil {
+			return nil, err
+		}
+		var openAPIV3Schema *apiextensions.JSONSchemaProps
+		if validation != nil {
+			openAPIV3Schema = validation.OpenAPIV3Schema
+		}
+		subresources, err := apiextensions.GetSubresourcesForVersion(crd, v.Name)
+		if err != nil {
+			return nil, err
+		}
+		var status *apiextensions.CustomResourceSubresourceStatus
+		if subresources != nil {
+			status = subresources.Status
+		}
+		columns, err := apiextensions.GetColumnsForVersion(crd, v.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		// In addition to Unstructured objects (Custom Resources), we also may sometimes need to
+		// decode unversioned Options objects, so we delegate to parameterScheme for such types.
+		parameterScheme := runtime.NewScheme()
+		parameterScheme.AddUnversionedTypes(schema.GroupVersion{Group: crd.Spec.Group, Version: v.Name},
+			&metav1.ListOptions{},
+			&metav1.ExportOptions{},
+			&metav1.GetOptions{},
+			&metav1.DeleteOptions{},
+		)
+		parameterScheme.AddGeneratedDeepCopyFuncs(metav1.GetGeneratedDeepCopyFuncs()...)
+		parameterCodec := runtime.NewParameterCodec(parameterScheme)
+
+		kind := schema.GroupVersionKind{Group: crd.Spec.Group, Version: v.Name, Kind: crd.Spec.Names.Kind}
+		typer := unstructuredObjectTyper{
+			delegate:          parameterScheme,
+			unstructuredTyper: discovery.NewUnstructuredObjectTyper(nil),
+		}
+		creator := unstructuredCreator{}
+		var decimalSchema *apiextensions.JSONSchemaProps
+		if decimal.HasDecimals(openAPIV3Schema) {
+			decimalSchema = openAPIV3Schema
+		}
+
+		strategy := customresource.NewStrategy(
 			typer,
-			crd.Spec.Scope == apiextensions.NamespaceScoped,
-			kind,
-		),
-		r.restOptionsGetter,
-	)
-
-	selfLinkPrefix := ""
-	switch crd.Spec.Scope {
-	case apiextensions.ClusterScoped:
-		selfLinkPrefix = "/" + path.Join("apis", crd.Spec.Group, crd.Spec.Version) + "/"
-	case apiextensions.NamespaceScoped:
-		selfLinkPrefix = "/" + path.Join("apis", crd.Spec.Group, crd.Spec.Version, "namespaces") + "/"
-	}
-
-	requestScope := handlers.RequestScope{
-		Namer: handlers.ContextBasedNaming{
-			GetContext: func(req *http.Request) apirequest.Context {
+			customresource.StrategyOptions{
+				NamespaceScoped:         crd.Spec.Scope == apiextensions.NamespaceScoped,
+				Kind:                    kind,
+				Plural:                  crd.Spec.Names.Plural,
+				Schema:                  openAPIV3Schema,
+				PreserveUnknownFields:   preserveUnknownFields,
+				Status:                  status,
+				SelectableFields:        crd.Spec.SelectableFields,
+				AllowedFinalizerDomains: crd.Spec.AllowedFinalizerDomains,
+			},
+			r.ownerMapper,
+			r.ruleCostBudget,
+		)
+		storage := customresource.NewStorage(
+			schema.GroupResource{Group: crd.Spec.Group, Resource: crd.Spec.Names.Plural},
+			schema.GroupVersionKind{Group: crd.Spec.Group, Version: v.Name, Kind: crd.Spec.Names.ListKind},
+			UnstructuredCopier{},
+			strategy,
+			crdConversionRESTOptionsGetter{
+				RESTOptionsGetter: restOptionsGetter,
+				converter:         converter,
+				encoderVersion:    schema.GroupVersion{Group: crd.Spec.Group, Version: storageVersion},
+				decoderVersion:    schema.GroupVersion{Group: crd.Spec.Group, Version: v.Name},
+				mirrorVersion:     mirrorVersion,
+			},
+			subresources,
+			customresource.NewTableConvertor(columns),
+			r.generateNameRetries,
+		)
+		storages[v.Name] = storage
+		if err := storageError(storage.CustomResource.Storage); err != nil {
+			return nil, err
+		}
+		if v.Name == storageVersion {
+			// lists the custom resources in the versions they are persisted in, bypassing the watch cache
+			persisted = customresource.NewREST(
+				schema.GroupResource{Group: crd.Spec.Group, Resource: crd.Spec.Names.Plural},
+				schema.GroupVersionKind{Group: crd.Spec.Group, Version: v.Name, Kind: crd.Spec.Names.ListKind},
+				UnstructuredCopier{},
+				strategy,
+				crdConversionRESTOptionsGetter{
+					RESTOptionsGetter: undecoratedRESTOptionsGetter{restOptionsGetter},
+					converter:         converter,
+					encoderVersion:    schema.GroupVersion{Group: crd.Spec.Group, Version: storageVersion},
+					mirrorVersion:     mirrorVersion,
+				},
+				customresource.NewTableConvertor(columns),
+				0,
+			)
+			if err := storageError(persisted.Storage); err != nil {
+				return nil, err
+			}
+		}
+
+		selfLinkPrefix := ""
+		switch crd.Spec.Scope {
+		case apiextensions.ClusterScoped:
+			selfLinkPrefix = "/" + path.Join("apis", crd.Spec.Group, v.Name) + "/"
+		case apiextensions.NamespaceScoped:
+			selfLinkPrefix = "/" + path.Join("apis", crd.Spec.Group, v.Name, "namespaces") + "/"
+		}
+
+		fieldLabels := sets.NewString("metadata.name")
+		if crd.Spec.Scope == apiextensions.NamespaceScoped {
+			fieldLabels.Insert("metadata.namespace")
+		}
+		fieldLabels.Insert(strategy.SelectableFieldLabels()...)
+
+		requestScope := handlers.RequestScope{
+			Namer: handlers.ContextBasedNaming{
+				GetContext: func(req *http.Request) apirequest.Context {
+					ret, _ := r.requestContextMapper.Get(req)
+					return ret
+				},
+				SelfLinker:         meta.NewAccessor(),
+				ClusterScoped:      crd.Spec.Scope == apiextensions.ClusterScoped,
+				SelfLinkPathPrefix: selfLinkPrefix,
+			},
+			ContextFunc: func(req *http.Request) apirequest.Context {
 				ret, _ := r.requestContextMapper.Get(req)
-				return ret
+				ret = customresource.WithListParameters(ret, customresource.ListParameters{
+					Limit:                req.URL.Query().Get("limit"),
+					Continue:             req.URL.Query().Get("continue"),
+					ResourceVersionMatch: req.URL.Query().Get("resourceVersionMatch"),
+					SendInitialEvents:    req.URL.Query().Get("sendInitialEvents"),
+					AllowWatchBookmarks:  req.URL.Query().Get("allowWatchBookmarks"),
+				})
+				return fieldmanager.WithManager(ret, fieldmanager.ManagerFromRequest(req))
 			},
+
+			Serializer:     newUnstructuredNegotiatedSerializer(typer, creator, openAPIV3Schema, decimalSchema),
+			ParameterCodec: parameterCodec,
+
+			Creater:         creator,
+			Convertor:       unstructuredObjectConvertor{fieldLabels: fieldLabels},
+			Defaulter:       unstructuredDefaulter{parameterScheme},
+			Copier:          UnstructuredCopier{},
+			Typer:           typer,
+			UnsafeConvertor: unstructured.UnstructuredObjectConverter{},
+
+			Resource:    schema.GroupVersionResource{Group: crd.Spec.Group, Version: v.Name, Resource: crd.Spec.Names.Plural},
+			Kind:        kind,
+			Subresource: "",
+
+			MetaGroupVersion: metav1.SchemeGroupVersion,
+
+			TableConvertor: storage.CustomResource,
+		}
+		requestScopes[v.Name] = requestScope
+		fieldManagers[v.Name] = fieldmanager.NewFieldManager(openAPIV3Schema)
+		schemas[v.Name] = openAPIV3Schema
+
+		statusRequestScope := requestScope
+		statusRequestScope.Namer = handlers.ContextBasedNaming{
+			GetContext:         requestScope.ContextFunc,
 			SelfLinker:         meta.NewAccessor(),
 			ClusterScoped:      crd.Spec.Scope == apiextensions.ClusterScoped,
 			SelfLinkPathPrefix: selfLinkPrefix,
-		},
-		ContextFunc: func(req *http.Request) apirequest.Context {
-			ret, _ := r.requestContextMapper.Get(req)
-			return ret
-		},
+			SelfLinkPathSuffix: "/status",
+		}
+		statusRequestScope.Subresource = "status"
+		statusRequestScopes[v.Name] = statusRequestScope
 
-		Serializer:     unstructuredNegotiatedSerializer{typer: typer, creator: creator},
-		ParameterCodec: parameterCodec,
+		// the scale subresource is served as autoscaling/v1 Scale from the scheme of this apiserver
+		scaleRequestScope := requestScope
+		scaleRequestScope.Namer = handlers.ContextBasedNaming{
+			GetContext:         requestScope.ContextFunc,
+			SelfLinker:         meta.NewAccessor(),
+			ClusterScoped:      crd.Spec.Scope == apiextensions.ClusterScoped,
+			SelfLinkPathPrefix: selfLinkPrefix,
+			SelfLinkPathSuffix: "/scale",
+		}
+		scaleRequestScope.Serializer = Codecs
+		scaleRequestScope.Creater = Scheme
+		scaleRequestScope.Convertor = Scheme
+		scaleRequestScope.Defaulter = Scheme
+		scaleRequestScope.Copier = Scheme
+		scaleRequestScope.Typer = Scheme
+		scaleRequestScope.UnsafeConvertor = Scheme
+		scaleRequestScope.Kind = autoscalingv1.SchemeGroupVersion.WithKind("Scale")
+		scaleRequestScope.Subresource = "scale"
+		scaleRequestScope.TableConvertor = nil
+		scaleRequestScopes[v.Name] = scaleRequestScope
 
-		Creater:         creator,
-		Convertor:       unstructured.UnstructuredObjectConverter{},
-		Defaulter:       unstructuredDefaulter{parameterScheme},
-		Copier:          UnstructuredCopier{},
-		Typer:           typer,
-		UnsafeConvertor: unstructured.UnstructuredObjectConverter{},
+		if subresources != nil && len(subresources.Custom) > 0 {
+			customRequestScopes[v.Name] = make(map[string]handlers.RequestScope, len(subresources.Custom))
+			for _, custom := range subresources.Custom {
+				customRequestScope := requestScope
+				customRequestScope.Namer = handlers.ContextBasedNaming{
+					GetContext:         requestScope.ContextFunc,
+					SelfLinker:         meta.NewAccessor(),
+					ClusterScoped:      crd.Spec.Scope == apiextensions.ClusterScoped,
+					SelfLinkPathPrefix: selfLinkPrefix,
+					SelfLinkPathSuffix: "/" + custom.Name,
+				}
+				customRequestScope.Subresource = custom.Name
+				customRequestScopes[v.Name][custom.Name] = customRequestScope
+			}
+		}
+	}
 
-		Resource:    schema.GroupVersionResource{Group: crd.Spec.Group, Version: crd.Spec.Version, Resource: crd.Spec.Names.Plural},
-		Kind:        kind,
-		Subresource: "",
+	if crd.Spec.Conversion != nil && crd.Spec.Conversion.FailurePolicy != nil && *crd.Spec.Conversion.FailurePolicy == apiextensions.ConversionFailurePolicyIgnore {
+		for _, storage := range storages {
+			storage.CustomResource.SetConversionFallback(persisted)
+		}
+	}
 
-		MetaGroupVersion: metav1.SchemeGroupVersion,
+	counter := customresource.NewCounter(persisted)
+	for _, storage := range storages {
+		storage.CustomResource.SetLimits(limits, counter)
 	}
+	stopCounter := make(chan struct{})
+	go counter.Run(stopCounter)
 
-	ret = &crdInfo{
-		storage:      storage,
-		requestScope: requestScope,
+	built = true
+	ret := &crdInfo{
+		spec:                &crd.Spec,
+		limits:              limits,
+		coerceScalars:       coerceScalars(crd),
+		storages:            storages,
+		requestScopes:       requestScopes,
+		statusRequestScopes: statusRequestScopes,
+		scaleRequestScopes:  scaleRequestScopes,
+		customRequestScopes: customRequestScopes,
+		fieldManagers:       fieldManagers,
+		schemas:             schemas,
+		persisted:           persisted,
+		counter:             counter,
+		stopCounter:         stopCounter,
+		storageVersion:      storageVersion,
 	}
-	storageMap[crd.UID] = ret
-	r.customStorage.Store(storageMap)
-	return ret
+	return ret, nil
 }
 
 type unstructuredNegotiatedSerializer struct {
-	typer   runtime.ObjectTyper
-	creator runtime.ObjectCreater
+	// supportedMediaTypes are built once per version of a CRD, not for every request which is negotiated.
+	supportedMediaTypes []runtime.SerializerInfo
+
+	// decimalSchema is the schema of the custom resources if it has fields with the format decimal.
+	// JSON numbers in these fields are decoded into strings without losing precision.
+	decimalSchema *apiextensions.JSONSchemaProps
 }
 
-func (s unstructuredNegotiatedSerializer) SupportedMediaTypes() []runtime.SerializerInfo {
-	return []runtime.SerializerInfo{
-		{
-			MediaType:        "application/json",
-			EncodesAsText:    true,
-			Serializer:       json.NewSerializer(json.DefaultMetaFactory, s.creator, s.typer, false),
-			PrettySerializer: json.NewSerializer(json.DefaultMetaFactory, s.creator, s.typer, true),
-			StreamSerializer: &runtime.StreamSerializerInfo{
+// newUnstructuredNegotiatedSerializer returns the serializer of custom resources with the schema s,
+// which may be nil. decimalSchema is s if it has fields with the format decimal.
+func newUnstructuredNegotiatedSerializer(typer runtime.ObjectTyper, creator runtime.ObjectCreater, s, decimalSchema *apiextensions.JSONSchemaProps) unstructuredNegotiatedSerializer {
+	jsonSerializer := json.NewSerializer(json.DefaultMetaFactory, creator, typer, false)
+	cborSerializer := cbor.NewSerializer(creator, typer)
+	yamlSerializer := yaml.NewSerializer(creator, typer, s)
+	return unstructuredNegotiatedSerializer{
+		supportedMediaTypes: []runtime.SerializerInfo{
+			{
+				MediaType:        "application/json",
+				EncodesAsText:    true,
+				Serializer:       jsonSerializer,
+				PrettySerializer: json.NewSerializer(json.DefaultMetaFactory, creator, typer, true),
+				StreamSerializer: &runtime.StreamSerializerInfo{
+					EncodesAsText: true,
+					Serializer:    jsonSerializer,
+					Framer:        json.Framer,
+				},
+			},
+			{
+				MediaType:     cbor.ContentTypeCBOR,
+				EncodesAsText: false,
+				Serializer:    cborSerializer,
+				StreamSerializer: &runtime.StreamSerializerInfo{
+					Serializer: cborSerializer,
+					Framer:     protobuf.LengthDelimitedFramer,
+				},
+			},
+			{
+				MediaType:     yaml.ContentTypeYAML,
 				EncodesAsText: true,
-				Serializer:    json.NewSerializer(json.DefaultMetaFactory, s.creator, s.typer, false),
-				Framer:        json.Framer,
+				Serializer:    yamlSerializer,
+				StreamSerializer: &runtime.StreamSerializerInfo{
+					EncodesAsText: true,
+					Serializer:    yamlSerializer,
+					Framer:        json.YAMLFramer,
+				},
 			},
 		},
+		decimalSchema: decimalSchema,
 	}
 }
 
+func (s unstructuredNegotiatedSerializer) SupportedMediaTypes() []runtime.SerializerInfo {
+	return s.supportedMediaTypes
+}
+
 func (s unstructuredNegotiatedSerializer) EncoderForVersion(serializer runtime.Encoder, gv runtime.GroupVersioner) runtime.Encoder {
+	// the CBOR and YAML serializers encode Status and WatchEvent themselves
+	switch serializer.(type) {
+	case *cbor.Serializer, *yaml.Serializer:
+		return versioning.NewDefaultingCodecForScheme(Scheme, serializer, nil, gv, nil)
+	}
 	return versioning.NewDefaultingCodecForScheme(Scheme, crEncoderInstance, nil, gv, nil)
 }
 
 func (s unstructuredNegotiatedSerializer) DecoderToVersion(serializer runtime.Decoder, gv runtime.GroupVersioner) runtime.Decoder {
-	return unstructuredDecoder{delegate: Codecs.DecoderToVersion(serializer, gv)}
+	var unstructuredDelegate runtime.Decoder = unstructured.UnstructuredJSONScheme
+	decimalSchema := s.decimalSchema
+	switch serializer.(type) {
+	case *cbor.Serializer, *yaml.Serializer:
+		unstructuredDelegate = serializer
+		decimalSchema = nil
+	}
+	return unstructuredDecoder{delegate: Codecs.DecoderToVersion(serializer, gv), unstructuredDelegate: unstructuredDelegate, decimalSchema: decimalSchema}
 }
 
 type unstructuredDecoder struct {
-	delegate runtime.Decoder
+	delegate             runtime.Decoder
+	unstructuredDelegate runtime.Decoder
+	decimalSchema        *apiextensions.JSONSchemaProps
 }
 
 func (d unstructuredDecoder) Decode(data []byte, defaults *schema.GroupVersionKind, into runtime.Object) (runtime.Object, *schema.GroupVersionKind, error) {
@@ -386,7 +1238,25 @@ func (d unstructuredDecoder) Decode(data []byte, defaults *schema.GroupVersionKi
 	if _, ok := into.(runtime.Unstructured); !ok && into != nil {
 		return d.delegate.Decode(data, defaults, into)
 	}
-	return unstructured.UnstructuredJSONScheme.Decode(data, defaults, into)
+	obj, gvk, err := d.unstructuredDelegate.Decode(data, defaults, into)
+	if err != nil || d.decimalSchema == nil {
+		return obj, gvk, err
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return obj, gvk, nil
+	}
+	// decode the JSON again to preserve the decimals which the delegate coerced into float64 values
+	content, err := decimal.Unmarshal(data, d.decimalSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, v := range content {
+		if k != "apiVersion" && k != "kind" {
+			u.Object[k] = v
+		}
+	}
+	return u, gvk, nil
 }
 
 type unstructuredObjectTyper struct {
@@ -408,8 +1278,8 @@ func (t unstructuredObjectTyper) Recognizes(gvk schema.GroupVersionKind) bool {
 
 var crEncoderInstance = crEncoder{}
 
-// crEncoder *usually* encodes using the unstructured.UnstructuredJSONScheme, but if the type is Status or WatchEvent
-// it will serialize them out using the converting codec.
+// crEncoder *usually* encodes like the unstructured.UnstructuredJSONScheme, passing the stored JSON of custom resources
+// through if possible, but if the type is Status or WatchEvent it will serialize them out using the converting codec.
 type crEncoder struct{}
 
 func (crEncoder) Encode(obj runtime.Object, w io.Writer) error {
@@ -425,7 +1295,7 @@ func (crEncoder) Encode(obj runtime.Object, w io.Writer) error {
 		return fmt.Errorf("unable to find json serializer for %T", t)
 
 	default:
-		return unstructured.UnstructuredJSONScheme.Encode(obj, w)
+		return encodeUnstructuredJSON(obj, w)
 	}
 }
 
@@ -439,6 +1309,13 @@ func (c unstructuredCreator) New(kind schema.GroupVersionKind) (runtime.Object,
 
 type UnstructuredCopier struct{}
 
+// copyBufferPool holds the buffers of UnstructuredCopier, which copies on every write of a custom resource.
+var copyBufferPool = sync.Pool{
+	New: func() interface{} {
+		return &bytes.Buffer{}
+	},
+}
+
 func (UnstructuredCopier) Copy(obj runtime.Object) (runtime.Object, error) {
 	if _, ok := obj.(runtime.Unstructured); !ok {
 		// Callers should not use this UnstructuredCopier for things other than Unstructured.
@@ -449,7 +1326,9 @@ func (UnstructuredCopier) Copy(obj runtime.Object) (runtime.Object, error) {
 	}
 
 	// serialize and deserialize to ensure a clean copy
-	buf := &bytes.Buffer{}
+	buf := copyBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer copyBufferPool.Put(buf)
 	err := unstructured.UnstructuredJSONScheme.Encode(obj, buf)
 	if err != nil {
 		return nil, err
@@ -470,6 +1349,20 @@ func (d unstructuredDefaulter) Default(in runtime.Object) {
 	}
 }
 
+// unstructuredObjectConvertor converts Unstructured objects and accepts the given field labels,
+// i.e. the metadata fields and the selectable fields of a custom resource, in field selectors.
+type unstructuredObjectConvertor struct {
+	unstructured.UnstructuredObjectConverter
+	fieldLabels sets.String
+}
+
+func (c unstructuredObjectConvertor) ConvertFieldLabel(version, kind, label, value string) (string, string, error) {
+	if !c.fieldLabels.Has(label) {
+		return "", "", fmt.Errorf("field label not supported: %s", label)
+	}
+	return label, value, nil
+}
+
 type CRDRESTOptionsGetter struct {
 	StorageConfig           storagebackend.Config
 	StoragePrefix           string
@@ -477,18 +1370,137 @@ type CRDRESTOptionsGetter struct {
 	DefaultWatchCacheSize   int
 	EnableGarbageCollection bool
 	DeleteCollectionWorkers int
+	// EtcdServersOverrides are the etcd servers of custom resources which are stored in a separate etcd cluster.
+	EtcdServersOverrides map[schema.GroupResource][]string
+	// WatchCacheSizes override DefaultWatchCacheSize for the custom resources of individual CRDs.
+	// A size of zero disables the watch cache of the custom resources.
+	WatchCacheSizes map[schema.GroupResource]int
+	// Transformers override the transformer of StorageConfig for the custom resources of individual CRDs,
+	// e.g. to encrypt them with a different encryption provider.
+	Transformers map[schema.GroupResource]value.Transformer
+	// EtcdClient is an existing etcd3 client of an embedding server which custom resources are stored
+	// with, instead of clients connecting to the servers of StorageConfig. Custom resources with
+	// EtcdServersOverrides still use their own clients. It is optional.
+	EtcdClient *clientv3.Client
+	// ConsistentListFromCache serves lists of custom resources without resourceVersion from the watch
+	// cache if it is verified to be up to date with etcd, instead of reading them from etcd. It only
+	// applies to custom resources stored in etcd3. Their storages do not compact etcd, hence the etcd
+	// servers of EtcdServersOverrides must be compacted by their owner.
+	ConsistentListFromCache bool
+	// StorageBackends store the custom resources of the API groups they are keyed by, instead of etcd.
+	// The other options of the storage, like the watch cache, do not apply to them. They are optional.
+	StorageBackends map[string]CustomResourceStorageBackend
 }
 
 func (t CRDRESTOptionsGetter) GetRESTOptions(resource schema.GroupResource) (generic.RESTOptions, error) {
+	storageConfig := t.StorageConfig
+	servers, overridden := t.EtcdServersOverrides[resource]
+	if overridden {
+		storageConfig.ServerList = servers
+	}
+	if transformer, ok := t.Transformers[resource]; ok {
+		storageConfig.Transformer = transformer
+	}
 	ret := generic.RESTOptions{
-		StorageConfig:           &t.StorageConfig,
+		StorageConfig:           &storageConfig,
 		Decorator:               generic.UndecoratedStorage,
 		EnableGarbageCollection: t.EnableGarbageCollection,
 		DeleteCollectionWorkers: t.DeleteCollectionWorkers,
 		ResourcePrefix:          resource.Group + "/" + resource.Resource,
 	}
+	size := 0
 	if t.EnableWatchCache {
-		ret.Decorator = genericregistry.StorageWithCacher(t.DefaultWatchCacheSize)
+		size = t.DefaultWatchCacheSize
+		if override, ok := t.WatchCacheSizes[resource]; ok {
+			size = override
+		}
+	}
+	switch backend, found := t.StorageBackends[resource.Group]; {
+	case found:
+		storageConfig.Type = storageBackendType
+		ret.Decorator = storageBackendDecorator(resource, backend)
+	case t.EtcdClient != nil && !overridden:
+		ret.Decorator = newEtcdClientStorageDecorator(t.EtcdClient, size, t.ConsistentListFromCache)
+	case size > 0 && t.ConsistentListFromCache:
+		ret.Decorator = newConsistentListStorageDecorator(size)
+	case size > 0:
+		ret.Decorator = genericregistry.StorageWithCacher(size)
+	}
+	return ret, nil
+}
+
+// crdStorageRESTOptionsGetter wraps the RESTOptionsGetter of the custom resources to store them under the
+// prefix set in the storage of the CRD.
+type crdStorageRESTOptionsGetter struct {
+	generic.RESTOptionsGetter
+	resourcePrefix string
+}
+
+func (t crdStorageRESTOptionsGetter) GetRESTOptions(resource schema.GroupResource) (generic.RESTOptions, error) {
+	ret, err := t.RESTOptionsGetter.GetRESTOptions(resource)
+	if err != nil {
+		return ret, err
+	}
+	ret.ResourcePrefix = t.resourcePrefix
+	return ret, nil
+}
+
+// undecoratedRESTOptionsGetter wraps a RESTOptionsGetter to access the storage without watch cache.
+type undecoratedRESTOptionsGetter struct {
+	generic.RESTOptionsGetter
+}
+
+func (t undecoratedRESTOptionsGetter) GetRESTOptions(resource schema.GroupResource) (generic.RESTOptions, error) {
+	ret, err := t.RESTOptionsGetter.GetRESTOptions(resource)
+	if err != nil {
+		return ret, err
+	}
+	// storage backends have no watch cache
+	if ret.StorageConfig.Type != storageBackendType {
+		ret.Decorator = generic.UndecoratedStorage
+	}
+	return ret, nil
+}
+
+// customResourceLimitsFor returns the limits of the custom resources of the CustomResourceDefinition,
+// which are the given defaults unless they are overridden by its annotations.
+func customResourceLimitsFor(crd *apiextensions.CustomResourceDefinition, defaults customresource.Limits) customresource.Limits {
+	limits := defaults
+	// the annotations are validated to be non-negative integers
+	if n, err := strconv.ParseInt(crd.Annotations[apiextensions.MaxCustomResourcesAnnotation], 10, 64); err == nil {
+		limits.MaxObjects = n
+	}
+	if n, err := strconv.ParseInt(crd.Annotations[apiextensions.MaxCustomResourceBytesAnnotation], 10, 64); err == nil {
+		limits.MaxObjectBytes = n
+	}
+	return limits
+}
+
+// coerceScalars returns true if the scalars of the custom resources of the CustomResourceDefinition
+// are coerced to the types of their schema.
+func coerceScalars(crd *apiextensions.CustomResourceDefinition) bool {
+	return crd.Annotations[apiextensions.ScalarCoercionAnnotation] == "true"
+}
+
+// crdConversionRESTOptionsGetter wraps the RESTOptionsGetter of the custom resources to convert
+// objects to the storage version when they are written, and to the served version when they are read.
+// If mirrorVersion is set, objects are also persisted in that version.
+type crdConversionRESTOptionsGetter struct {
+	generic.RESTOptionsGetter
+	converter      conversion.Converter
+	encoderVersion schema.GroupVersion
+	decoderVersion schema.GroupVersion
+	mirrorVersion  schema.GroupVersion
+}
+
+func (t crdConversionRESTOptionsGetter) GetRESTOptions(resource schema.GroupResource) (generic.RESTOptions, error) {
+	ret, err := t.RESTOptionsGetter.GetRESTOptions(resource)
+	if err != nil {
+		return ret, err
 	}
+	// copy because the StorageConfig is shared between all versions
+	storageConfig := *ret.StorageConfig
+	storageConfig.Codec = storedJSONCodec{conversion.NewStorageCodec(storageConfig.Codec, t.converter, t.encoderVersion, t.decoderVersion, t.mirrorVersion)}
+	ret.StorageConfig = &storageConfig
 	return ret, nil
 }
@@ -0,0 +1,552 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/endpoints/discovery"
+	"k8s.io/apiserver/pkg/endpoints/handlers"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/generic"
+	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/serializer/cbor"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/serializer/yaml"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+	"k8s.io/apiextensions-apiserver/pkg/registry/customresource"
+)
+
+func TestCustomResourceDefinitionStorageUpdates(t *testing.T) {
+	newCRD := func(uid, group string) *apiextensions.CustomResourceDefinition {
+		crd := &apiextensions.CustomResourceDefinition{}
+		crd.Name = "noxus." + group
+		crd.UID = types.UID(uid)
+		crd.Spec.Group = group
+		return crd
+	}
+	a, b := newCRD("a", "a.example.com"), newCRD("b", "b.example.com")
+	infoA, infoB := &crdInfo{spec: &a.Spec}, &crdInfo{spec: &b.Spec}
+
+	r := &crdHandler{}
+	r.customStorage.Store(crdStorageMap{a.UID: infoA, b.UID: infoB})
+	initial := r.customStorage.Load().(crdStorageMap)
+
+	// status updates keep the storage
+	r.updateCustomResourceDefinition(a, a.DeepCopy())
+	if r.customStorage.Load().(crdStorageMap)[a.UID] != infoA {
+		t.Errorf("expected the storage of %s to be kept when its spec did not change", a.Name)
+	}
+
+	// spec updates drop only the storage of the updated CustomResourceDefinition
+	updatedA := a.DeepCopy()
+	updatedA.Spec.Group = "other.example.com"
+	r.updateCustomResourceDefinition(a, updatedA)
+	storageMap := r.customStorage.Load().(crdStorageMap)
+	if _, found := storageMap[a.UID]; found {
+		t.Errorf("expected the storage of %s to be dropped after a spec change", a.Name)
+	}
+	if storageMap[b.UID] != infoB {
+		t.Errorf("expected the storage of %s to be kept", b.Name)
+	}
+
+	r.removeCustomResourceDefinition(cache.DeletedFinalStateUnknown{Key: b.Name, Obj: b})
+	if storageMap := r.customStorage.Load().(crdStorageMap); len(storageMap) != 0 {
+		t.Errorf("expected no storage left, got %v", storageMap)
+	}
+
+	// requests which loaded the map before keep the storage they found
+	if len(initial) != 2 || initial[a.UID] != infoA || initial[b.UID] != infoB {
+		t.Errorf("expected the initial storage map to be unchanged, got %v", initial)
+	}
+}
+
+func TestCustomResourceDefinitionStorageBuilds(t *testing.T) {
+	newCRD := func(uid, group string) *apiextensions.CustomResourceDefinition {
+		crd := &apiextensions.CustomResourceDefinition{}
+		crd.Name = "noxus." + group
+		crd.UID = types.UID(uid)
+		crd.Spec.Group = group
+		return crd
+	}
+	a, b := newCRD("a", "a.example.com"), newCRD("b", "b.example.com")
+	updatedA := a.DeepCopy()
+	updatedA.Spec.Group = "other.example.com"
+
+	// builds block until the channel of the group of the CustomResourceDefinition is closed
+	started := make(chan string, 10)
+	release := map[string]chan struct{}{}
+	for _, group := range []string{a.Spec.Group, b.Spec.Group, updatedA.Spec.Group} {
+		release[group] = make(chan struct{})
+	}
+	// destroyed receives the groups of the destroyed storage
+	destroyed := make(chan string, 10)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	indexer.Add(a)
+	indexer.Add(b)
+	r := &crdHandler{storageBuilds: map[types.UID]*storageBuild{}, crdLister: listers.NewCustomResourceDefinitionLister(indexer)}
+	r.customStorage.Store(crdStorageMap{})
+	r.servingInfoBuilder = func(crd *apiextensions.CustomResourceDefinition) (*crdInfo, error) {
+		group := crd.Spec.Group
+		started <- group
+		<-release[group]
+		storage := &customresource.REST{Store: &genericregistry.Store{DestroyFunc: func() { destroyed <- group }}}
+		return &crdInfo{spec: &crd.Spec, storages: map[string]customresource.CustomResourceStorage{"v1": {CustomResource: storage}}}, nil
+	}
+
+	get := func(crd *apiextensions.CustomResourceDefinition) <-chan *crdInfo {
+		ch := make(chan *crdInfo, 1)
+		go func() {
+			info, err := r.getServingInfoFor(crd)
+			if err != nil {
+				t.Error(err)
+			}
+			ch <- info
+		}()
+		return ch
+	}
+	waitStarted := func(group string) {
+		select {
+		case started := <-started:
+			if started != group {
+				t.Fatalf("expected the build for %s to start, got %s", group, started)
+			}
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatalf("expected the build for %s to start", group)
+		}
+	}
+	waitInfo := func(ch <-chan *crdInfo) *crdInfo {
+		select {
+		case info := <-ch:
+			return info
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatal("expected the storage to be returned")
+		}
+		return nil
+	}
+	stored := func(crd *apiextensions.CustomResourceDefinition) *crdInfo {
+		return r.customStorage.Load().(crdStorageMap)[crd.UID]
+	}
+	waitDestroyed := func(group string) {
+		select {
+		case destroyed := <-destroyed:
+			if destroyed != group {
+				t.Fatalf("expected the storage for %s to be destroyed, got %s", group, destroyed)
+			}
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatalf("expected the storage for %s to be destroyed", group)
+		}
+	}
+
+	// the build of a does not block the build of b, concurrent requests for a share one build
+	a1 := get(a)
+	waitStarted(a.Spec.Group)
+	a2 := get(a)
+	b1 := get(b)
+	waitStarted(b.Spec.Group)
+	close(release[b.Spec.Group])
+	if info := waitInfo(b1); stored(b) != info {
+		t.Errorf("expected the storage of %s to be stored", b.Name)
+	}
+	close(release[a.Spec.Group])
+	info1, info2 := waitInfo(a1), waitInfo(a2)
+	if info1 != info2 || stored(a) != info1 {
+		t.Errorf("expected the requests for %s to share one stored build", a.Name)
+	}
+
+	// a spec update destroys the replaced storage
+	indexer.Update(updatedA)
+	r.updateCustomResourceDefinition(a, updatedA)
+	waitDestroyed(a.Spec.Group)
+
+	// a spec update during the build drops it. The dropped build is destroyed and built again from the
+	// current CustomResourceDefinition for the waiting request.
+	a3 := get(updatedA)
+	waitStarted(updatedA.Spec.Group)
+	indexer.Update(a)
+	r.updateCustomResourceDefinition(updatedA, a)
+	close(release[updatedA.Spec.Group])
+	waitDestroyed(updatedA.Spec.Group)
+	waitStarted(a.Spec.Group)
+	if info := waitInfo(a3); info == nil || info.spec != &a.Spec || stored(a) != info {
+		t.Errorf("expected the storage of the current %s to be built again and stored, got %v", a.Name, info)
+	}
+
+	// the removal destroys the storage once the requests served from it finished
+	info := stored(a)
+	if !info.requests.add() {
+		t.Fatalf("expected a request to be served from the storage of %s", a.Name)
+	}
+	indexer.Delete(a)
+	r.removeCustomResourceDefinition(a)
+	select {
+	case group := <-destroyed:
+		t.Errorf("expected the storage for %s to be destroyed after the request, got it destroyed before", group)
+	case <-time.After(100 * time.Millisecond):
+	}
+	if info.requests.add() {
+		t.Errorf("expected no new requests to be served from the removed storage of %s", a.Name)
+	}
+	info.requests.done()
+	waitDestroyed(a.Spec.Group)
+
+	select {
+	case group := <-started:
+		t.Errorf("expected no other build, got one for %s", group)
+	case group := <-destroyed:
+		t.Errorf("expected no other storage to be destroyed, got %s", group)
+	default:
+	}
+}
+
+// isServiceUnavailable returns true if err is a ServiceUnavailable status.
+func isServiceUnavailable(err error) bool {
+	statusErr, ok := err.(*apierrors.StatusError)
+	return ok && statusErr.ErrStatus.Reason == metav1.StatusReasonServiceUnavailable
+}
+
+func TestCountedStorage(t *testing.T) {
+	// updates block in the store until release is closed
+	started, release := make(chan struct{}), make(chan struct{})
+	storage := &customresource.REST{Store: &genericregistry.Store{
+		KeyFunc: func(ctx apirequest.Context, name string) (string, error) {
+			close(started)
+			<-release
+			return "", fmt.Errorf("no key for %s", name)
+		},
+	}}
+	info := &crdInfo{storages: map[string]customresource.CustomResourceStorage{"v1": {CustomResource: storage}}, storageVersion: "v1"}
+	s := countedStorage{info: info, storage: storage}
+
+	updated := make(chan error, 1)
+	go func() {
+		_, _, err := s.Update(apirequest.NewContext(), "foo", nil)
+		updated <- err
+	}()
+	<-started
+	closed := make(chan struct{})
+	go func() {
+		info.requests.close(wait.ForeverTestTimeout)
+		close(closed)
+	}()
+	select {
+	case <-closed:
+		t.Fatalf("expected the storage to wait for the update")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case <-closed:
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("expected the storage to be closed after the update")
+	}
+	if err := <-updated; err == nil || isServiceUnavailable(err) {
+		t.Errorf("expected the error of the store, got %v", err)
+	}
+
+	if _, err := s.List(apirequest.NewContext(), nil); !isServiceUnavailable(err) {
+		t.Errorf("expected lists of the closed storage to be rejected, got %v", err)
+	}
+	if _, err := s.DeleteCollection(apirequest.NewContext(), nil, nil); !isServiceUnavailable(err) {
+		t.Errorf("expected deletions of the closed storage to be rejected, got %v", err)
+	}
+	if _, _, err := s.Update(apirequest.NewContext(), "foo", nil); !isServiceUnavailable(err) {
+		t.Errorf("expected updates of the closed storage to be rejected, got %v", err)
+	}
+}
+
+func TestDiscoveryHandlerUpdates(t *testing.T) {
+	r := &versionDiscoveryHandler{}
+	gv := schema.GroupVersion{Group: "mygroup.example.com", Version: "v1"}
+	if _, ok := r.getDiscovery(gv); ok {
+		t.Fatalf("expected no discovery for %v", gv)
+	}
+
+	handler := &discovery.APIVersionHandler{}
+	r.setDiscovery(gv, handler)
+	if ret, ok := r.getDiscovery(gv); !ok || ret != handler {
+		t.Fatalf("expected the discovery of %v to be set", gv)
+	}
+	before := r.loadDiscovery()
+
+	r.unsetDiscovery(gv)
+	if _, ok := r.getDiscovery(gv); ok {
+		t.Errorf("expected the discovery of %v to be unset", gv)
+	}
+	if before[gv] != handler {
+		t.Errorf("expected the previous discovery map to be unchanged, got %v", before)
+	}
+}
+
+func TestCRDRESTOptionsGetterWatchCacheSizes(t *testing.T) {
+	getter := CRDRESTOptionsGetter{
+		EnableWatchCache:      true,
+		DefaultWatchCacheSize: 100,
+		WatchCacheSizes: map[schema.GroupResource]int{
+			{Group: "a.example.com", Resource: "noxus"}: 0,
+			{Group: "b.example.com", Resource: "noxus"}: 1000,
+		},
+	}
+	undecorated := reflect.ValueOf(generic.UndecoratedStorage).Pointer()
+	tests := []struct {
+		resource schema.GroupResource
+		cached   bool
+	}{
+		{schema.GroupResource{Group: "a.example.com", Resource: "noxus"}, false},
+		{schema.GroupResource{Group: "b.example.com", Resource: "noxus"}, true},
+		{schema.GroupResource{Group: "c.example.com", Resource: "noxus"}, true},
+	}
+	for _, tc := range tests {
+		opts, err := getter.GetRESTOptions(tc.resource)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cached := reflect.ValueOf(opts.Decorator).Pointer() != undecorated; cached != tc.cached {
+			t.Errorf("%v: expected watch cache %v, got %v", tc.resource, tc.cached, cached)
+		}
+	}
+}
+
+func TestUnstructuredCopier(t *testing.T) {
+	objs := []*unstructured.Unstructured{
+		{Object: map[string]interface{}{"apiVersion": "mygroup.example.com/v1", "kind": "Noxu", "spec": map[string]interface{}{"replicas": int64(2)}}},
+		{Object: map[string]interface{}{"apiVersion": "mygroup.example.com/v1", "kind": "Noxu", "spec": map[string]interface{}{"names": []interface{}{"a", "b"}}}},
+	}
+	// the copies are decoded from pooled buffers, which must not leak into them
+	var copies []runtime.Object
+	for _, obj := range objs {
+		c, err := UnstructuredCopier{}.Copy(obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		copies = append(copies, c)
+	}
+	for i, obj := range objs {
+		if !reflect.DeepEqual(copies[i], obj) {
+			t.Errorf("expected copy %v, got %v", obj, copies[i])
+		}
+		copies[i].(*unstructured.Unstructured).Object["spec"] = "changed"
+		if obj.Object["spec"] == "changed" {
+			t.Errorf("expected the copy of %v to be independent", obj)
+		}
+	}
+
+	if _, err := (UnstructuredCopier{}).Copy(&metav1.Status{}); err == nil {
+		t.Errorf("expected an error copying a typed object")
+	}
+}
+
+func TestUnstructuredNegotiatedSerializerMediaTypes(t *testing.T) {
+	s := newUnstructuredNegotiatedSerializer(nil, nil, nil, nil)
+	first, second := s.SupportedMediaTypes(), s.SupportedMediaTypes()
+	if len(first) != 3 || first[0].MediaType != "application/json" || first[1].MediaType != cbor.ContentTypeCBOR || first[2].MediaType != yaml.ContentTypeYAML {
+		t.Fatalf("unexpected media types %v", first)
+	}
+	for i := range first {
+		if first[i].Serializer != second[i].Serializer || first[i].StreamSerializer.Serializer != first[i].Serializer {
+			t.Errorf("%s: expected the serializers to be built once and shared", first[i].MediaType)
+		}
+	}
+}
+
+func TestCheckPatchAPIVersion(t *testing.T) {
+	scope := handlers.RequestScope{Kind: schema.GroupVersionKind{Group: "stable.example.com", Version: "v1", Kind: "Noxu"}}
+	served := []string{"v1", "v2"}
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expectError string
+	}{
+		{
+			name:        "same version",
+			contentType: string(types.MergePatchType),
+			body:        `{"apiVersion":"stable.example.com/v1","spec":{"replicas":1}}`,
+		},
+		{
+			name:        "no apiVersion",
+			contentType: string(types.StrategicMergePatchType),
+			body:        `{"spec":{"replicas":1}}`,
+		},
+		{
+			name:        "served version",
+			contentType: string(types.MergePatchType) + "; charset=UTF-8",
+			body:        `{"apiVersion":"stable.example.com/v2"}`,
+			expectError: "apiVersion stable.example.com/v2 of the patch does not match the apiVersion stable.example.com/v1 of the request",
+		},
+		{
+			name:        "unserved version",
+			contentType: string(types.StrategicMergePatchType),
+			body:        `{"apiVersion":"stable.example.com/v3"}`,
+			expectError: "apiVersion stable.example.com/v3 is not served, the served versions are v1, v2 in the patch",
+		},
+		{
+			name:        "other group",
+			contentType: string(types.MergePatchType),
+			body:        `{"apiVersion":"other.example.com/v3"}`,
+			expectError: "apiVersion other.example.com/v3 of the patch does not match the apiVersion stable.example.com/v1 of the request",
+		},
+		{
+			name:        "invalid apiVersion",
+			contentType: string(types.MergePatchType),
+			body:        `{"apiVersion":"a/b/c"}`,
+			expectError: `invalid apiVersion "a/b/c" in the patch`,
+		},
+		{
+			name:        "json patch",
+			contentType: string(types.JSONPatchType),
+			body:        `[{"op":"replace","path":"/apiVersion","value":"stable.example.com/v3"}]`,
+		},
+	}
+	for _, tc := range tests {
+		req := httptest.NewRequest("PATCH", "/apis/stable.example.com/v1/noxus/foo", strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", tc.contentType)
+		err := checkPatchAPIVersion(req, scope, served)
+		switch {
+		case len(tc.expectError) == 0 && err != nil:
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		case len(tc.expectError) > 0 && err == nil:
+			t.Errorf("%s: expected error containing %q", tc.name, tc.expectError)
+		case len(tc.expectError) > 0 && (!apierrors.IsBadRequest(err) || !strings.Contains(err.Error(), tc.expectError)):
+			t.Errorf("%s: expected BadRequest error containing %q, got %v", tc.name, tc.expectError, err)
+		}
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if string(body) != tc.body {
+			t.Errorf("%s: expected the request body to be restored, got %q", tc.name, string(body))
+		}
+	}
+}
+
+func TestDecodeApplyConfigurationUnservedVersion(t *testing.T) {
+	scope := handlers.RequestScope{Kind: schema.GroupVersionKind{Group: "stable.example.com", Version: "v1", Kind: "Noxu"}}
+	body := []byte("apiVersion: stable.example.com/v1beta1\nkind: Noxu\n")
+	_, err := decodeApplyConfiguration(body, scope, "default", "foo", []string{"v1", "v2"})
+	expected := "apiVersion stable.example.com/v1beta1 is not served, the served versions are v1, v2 in the apply configuration"
+	if !apierrors.IsBadRequest(err) || !strings.Contains(err.Error(), expected) {
+		t.Errorf("expected BadRequest error containing %q, got %v", expected, err)
+	}
+}
+
+func TestWriteUnservedVersionError(t *testing.T) {
+	crd := &apiextensions.CustomResourceDefinition{
+		Spec: apiextensions.CustomResourceDefinitionSpec{
+			Group: "stable.example.com",
+			Versions: []apiextensions.CustomResourceDefinitionVersion{
+				{Name: "v1", Served: true},
+				{Name: "v1beta1", Served: false},
+				{Name: "v2", Served: true},
+			},
+		},
+	}
+	requestInfo := &apirequest.RequestInfo{APIGroup: "stable.example.com", APIVersion: "v1beta1", Resource: "noxus", Name: "foo", Verb: "patch"}
+	req := httptest.NewRequest("PATCH", "/apis/stable.example.com/v1beta1/namespaces/default/noxus/foo", nil)
+	w := httptest.NewRecorder()
+	writeUnservedVersionError(apirequest.NewContext(), w, req, crd, requestInfo)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+	expected := "apiVersion stable.example.com/v1beta1 is not served, the served versions are v1, v2"
+	if !strings.Contains(w.Body.String(), expected) {
+		t.Errorf("expected the response to contain %q, got %q", expected, w.Body.String())
+	}
+}
+
+func TestOptimisticServing(t *testing.T) {
+	newCRD := func(name string, conditions ...apiextensions.CustomResourceDefinitionCondition) *apiextensions.CustomResourceDefinition {
+		return &apiextensions.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: name + ".stable.example.com"},
+			Spec: apiextensions.CustomResourceDefinitionSpec{
+				Group:    "stable.example.com",
+				Versions: []apiextensions.CustomResourceDefinitionVersion{{Name: "v1", Served: true, Storage: true}},
+			},
+			Status: apiextensions.CustomResourceDefinitionStatus{Conditions: conditions},
+		}
+	}
+	deleted := newCRD("deleted")
+	now := metav1.Now()
+	deleted.DeletionTimestamp = &now
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	indexer.Add(newCRD("new"))
+	indexer.Add(newCRD("accepted", apiextensions.CustomResourceDefinitionCondition{Type: apiextensions.NamesAccepted, Status: apiextensions.ConditionTrue}))
+	indexer.Add(newCRD("rejected", apiextensions.CustomResourceDefinitionCondition{Type: apiextensions.NamesAccepted, Status: apiextensions.ConditionFalse}))
+	indexer.Add(deleted)
+
+	tests := []struct {
+		resource          string
+		optimisticServing bool
+		code              int
+	}{
+		{"new", true, http.StatusServiceUnavailable},
+		{"accepted", true, http.StatusInternalServerError},
+		{"accepted", false, http.StatusNotFound},
+		{"rejected", true, http.StatusNotFound},
+		{"deleted", true, http.StatusNotFound},
+		{"unknown", true, http.StatusNotFound},
+		{"new", false, http.StatusNotFound},
+	}
+	for _, tc := range tests {
+		mapper := apirequest.NewRequestContextMapper()
+		r := &crdHandler{
+			requestContextMapper: mapper,
+			crdLister:            listers.NewCustomResourceDefinitionLister(indexer),
+			delegate:             http.NotFoundHandler(),
+			optimisticServing:    tc.optimisticServing,
+			watchTerminator:      newWatchTerminator(),
+			storageBuilds:        map[types.UID]*storageBuild{},
+		}
+		r.customStorage.Store(crdStorageMap{})
+		// served requests fail to build the storage
+		r.servingInfoBuilder = func(crd *apiextensions.CustomResourceDefinition) (*crdInfo, error) {
+			return nil, fmt.Errorf("no storage for %s", crd.Name)
+		}
+		req := httptest.NewRequest("GET", "/apis/stable.example.com/v1/"+tc.resource, nil)
+		requestInfo := &apirequest.RequestInfo{IsResourceRequest: true, APIGroup: "stable.example.com", APIVersion: "v1", Resource: tc.resource, Verb: "list"}
+		w := httptest.NewRecorder()
+		apirequest.WithRequestContext(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			mapper.Update(req, apirequest.WithRequestInfo(apirequest.NewContext(), requestInfo))
+			r.ServeHTTP(w, req)
+		}), mapper).ServeHTTP(w, req)
+
+		if w.Code != tc.code {
+			t.Errorf("%s (optimistic serving %v): expected status %d, got %d", tc.resource, tc.optimisticServing, tc.code, w.Code)
+			continue
+		}
+		if retryAfter := w.Header().Get("Retry-After"); tc.code == http.StatusServiceUnavailable && retryAfter != "1" {
+			t.Errorf("%s: expected Retry-After 1, got %q", tc.resource, retryAfter)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"fmt"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/admission"
+)
+
+// CustomResourceMutator normalizes custom resources in-process when they are created or updated,
+// e.g. to set defaults which cannot be expressed in the schema of the CustomResourceDefinition.
+type CustomResourceMutator interface {
+	// Mutate changes obj in place. oldObj is the custom resource before an update and nil on create.
+	// It must not change the apiVersion, kind, name or namespace. A returned error rejects the request.
+	Mutate(obj, oldObj *unstructured.Unstructured, a admission.Attributes) error
+}
+
+// CustomResourceValidator enforces invariants of custom resources in-process when they are created
+// or updated, after all mutators ran.
+type CustomResourceValidator interface {
+	// Validate returns the violations of the invariants of obj. oldObj is the custom resource before
+	// an update and nil on create.
+	Validate(obj, oldObj *unstructured.Unstructured, a admission.Attributes) field.ErrorList
+}
+
+// CustomResourceHooks are the mutators and validators of custom resources registered by servers
+// embedding the apiextensions-apiserver, keyed by the API group of the CustomResourceDefinitions.
+// They run as the last admission plugin for the custom resources of these groups, in the order of
+// their registration, before the custom resources are defaulted, pruned and validated against
+// their schema. Subresources other than status are not passed to the hooks.
+type CustomResourceHooks struct {
+	lock       sync.RWMutex
+	mutators   map[string][]CustomResourceMutator
+	validators map[string][]CustomResourceValidator
+}
+
+var _ admission.Interface = &CustomResourceHooks{}
+
+// NewCustomResourceHooks returns hooks without any registered mutators or validators.
+func NewCustomResourceHooks() *CustomResourceHooks {
+	return &CustomResourceHooks{
+		mutators:   map[string][]CustomResourceMutator{},
+		validators: map[string][]CustomResourceValidator{},
+	}
+}
+
+// RegisterMutator registers a mutator for the custom resources of the given API group.
+func (h *CustomResourceHooks) RegisterMutator(group string, m CustomResourceMutator) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.mutators[group] = append(h.mutators[group], m)
+}
+
+// RegisterValidator registers a validator for the custom resources of the given API group.
+func (h *CustomResourceHooks) RegisterValidator(group string, v CustomResourceValidator) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.validators[group] = append(h.validators[group], v)
+}
+
+// registered returns the numbers of mutators and validators registered for the given API group.
+func (h *CustomResourceHooks) registered(group string) (mutators, validators int) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	return len(h.mutators[group]), len(h.validators[group])
+}
+
+// Handles returns true for creates and updates.
+func (h *CustomResourceHooks) Handles(operation admission.Operation) bool {
+	return operation == admission.Create || operation == admission.Update
+}
+
+// Admit runs the mutators and then the validators registered for the API group of the custom
+// resource of the request.
+func (h *CustomResourceHooks) Admit(a admission.Attributes) error {
+	if len(a.GetSubresource()) > 0 && a.GetSubresource() != "status" {
+		return nil
+	}
+	obj, ok := a.GetObject().(*unstructured.Unstructured)
+	if !ok {
+		return nil
+	}
+	oldObj, _ := a.GetOldObject().(*unstructured.Unstructured)
+
+	group := a.GetResource().Group
+	h.lock.RLock()
+	mutators, validators := h.mutators[group], h.validators[group]
+	h.lock.RUnlock()
+
+	for _, m := range mutators {
+		apiVersion, kind, name, namespace := obj.GetAPIVersion(), obj.GetKind(), obj.GetName(), obj.GetNamespace()
+		if err := m.Mutate(obj, oldObj, a); err != nil {
+			return admission.NewForbidden(a, err)
+		}
+		if obj.GetAPIVersion() != apiVersion || obj.GetKind() != kind || obj.GetName() != name || obj.GetNamespace() != namespace {
+			return apierrors.NewInternalError(fmt.Errorf("mutator %T changed the apiVersion, kind, name or namespace of the custom resource", m))
+		}
+	}
+
+	var errs field.ErrorList
+	for _, v := range validators {
+		errs = append(errs, v.Validate(obj, oldObj, a)...)
+	}
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(a.GetKind().GroupKind(), obj.GetName(), errs)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"errors"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/admission"
+)
+
+type mutatorFunc func(obj, oldObj *unstructured.Unstructured, a admission.Attributes) error
+
+func (f mutatorFunc) Mutate(obj, oldObj *unstructured.Unstructured, a admission.Attributes) error {
+	return f(obj, oldObj, a)
+}
+
+type validatorFunc func(obj, oldObj *unstructured.Unstructured, a admission.Attributes) field.ErrorList
+
+func (f validatorFunc) Validate(obj, oldObj *unstructured.Unstructured, a admission.Attributes) field.ErrorList {
+	return f(obj, oldObj, a)
+}
+
+func TestCustomResourceHooks(t *testing.T) {
+	hooks := NewCustomResourceHooks()
+	hooks.RegisterMutator("example.com", mutatorFunc(func(obj, oldObj *unstructured.Unstructured, a admission.Attributes) error {
+		if _, found := obj.Object["spec"]; !found {
+			obj.Object["spec"] = map[string]interface{}{"replicas": int64(1)}
+		}
+		return nil
+	}))
+	hooks.RegisterMutator("example.com", mutatorFunc(func(obj, oldObj *unstructured.Unstructured, a admission.Attributes) error {
+		if obj.GetName() == "forbidden" {
+			return errors.New("forbidden name")
+		}
+		if obj.GetName() == "renamed" {
+			obj.SetName("other")
+		}
+		return nil
+	}))
+	hooks.RegisterValidator("example.com", validatorFunc(func(obj, oldObj *unstructured.Unstructured, a admission.Attributes) field.ErrorList {
+		if oldObj != nil && oldObj.Object["spec"] != nil && obj.Object["spec"] == nil {
+			return field.ErrorList{field.Required(field.NewPath("spec"), "must not be removed")}
+		}
+		return nil
+	}))
+
+	kind := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Foo"}
+	resource := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "foos"}
+	newObj := func(name string, spec interface{}) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": "example.com/v1", "kind": "Foo"}}
+		obj.SetName(name)
+		obj.SetNamespace("default")
+		if spec != nil {
+			obj.Object["spec"] = spec
+		}
+		return obj
+	}
+
+	// mutators run in order on create
+	obj := newObj("a", nil)
+	if err := hooks.Admit(admission.NewAttributesRecord(obj, nil, kind, "default", "a", resource, "", admission.Create, nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, found := obj.Object["spec"]; !found {
+		t.Errorf("expected spec to be defaulted")
+	}
+
+	if err := hooks.Admit(admission.NewAttributesRecord(newObj("forbidden", nil), nil, kind, "default", "forbidden", resource, "", admission.Create, nil)); !apierrors.IsForbidden(err) {
+		t.Errorf("expected a forbidden error, got %v", err)
+	}
+	if err := hooks.Admit(admission.NewAttributesRecord(newObj("renamed", nil), nil, kind, "default", "renamed", resource, "", admission.Create, nil)); !apierrors.IsInternalError(err) {
+		t.Errorf("expected an internal error for a renamed custom resource, got %v", err)
+	}
+
+	// validators see the old object on update. The scale subresource is skipped.
+	old := newObj("a", map[string]interface{}{})
+	updated := newObj("a", nil)
+	updated.Object["spec"] = nil
+	if err := hooks.Admit(admission.NewAttributesRecord(updated, old, kind, "default", "a", resource, "", admission.Update, nil)); !apierrors.IsInvalid(err) {
+		t.Errorf("expected an invalid error, got %v", err)
+	}
+	if err := hooks.Admit(admission.NewAttributesRecord(updated, old, kind, "default", "a", resource, "scale", admission.Update, nil)); err != nil {
+		t.Errorf("expected the scale subresource to be skipped, got %v", err)
+	}
+
+	// other groups are not affected
+	other := newObj("forbidden", nil)
+	other.SetAPIVersion("other.com/v1")
+	if err := hooks.Admit(admission.NewAttributesRecord(other, nil, kind, "default", "forbidden", schema.GroupVersionResource{Group: "other.com", Version: "v1", Resource: "foos"}, "", admission.Create, nil)); err != nil {
+		t.Errorf("unexpected error for another group: %v", err)
+	}
+	if _, found := other.Object["spec"]; found {
+		t.Errorf("expected a custom resource of another group not to be mutated")
+	}
+
+	if hooks.Handles(admission.Delete) {
+		t.Errorf("expected deletes not to be handled")
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"bytes"
+	encodingjson "encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/endpoints/handlers"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+// servedVersions returns the names of the served versions in the order of spec.versions.
+func servedVersions(spec *apiextensions.CustomResourceDefinitionSpec) []string {
+	var served []string
+	for _, v := range spec.Versions {
+		if v.Served {
+			served = append(served, v.Name)
+		}
+	}
+	return served
+}
+
+// unservedVersionMessage returns the message of the error for an apiVersion of the group of the
+// CustomResourceDefinition whose version is not served.
+func unservedVersionMessage(apiVersion string, served []string) string {
+	if len(served) == 0 {
+		return fmt.Sprintf("apiVersion %s is not served", apiVersion)
+	}
+	return fmt.Sprintf("apiVersion %s is not served, the served versions are %s", apiVersion, strings.Join(served, ", "))
+}
+
+// writeUnservedVersionError responds with a NotFound status naming the served versions to a patch of
+// a version which the CustomResourceDefinition does not serve.
+func writeUnservedVersionError(ctx apirequest.Context, w http.ResponseWriter, req *http.Request, crd *apiextensions.CustomResourceDefinition, requestInfo *apirequest.RequestInfo) {
+	gv := schema.GroupVersion{Group: requestInfo.APIGroup, Version: requestInfo.APIVersion}
+	err := apierrors.NewNotFound(schema.GroupResource{Group: requestInfo.APIGroup, Resource: requestInfo.Resource}, requestInfo.Name)
+	err.ErrStatus.Message = unservedVersionMessage(gv.String(), servedVersions(&crd.Spec))
+	responsewriters.ErrorNegotiated(ctx, err, Codecs, gv, w, req)
+}
+
+// checkPatchAPIVersion rejects merge patches and strategic merge patches which change the apiVersion
+// to a different version than the one of the request. JSON patches and apply configurations are not
+// checked here. The request body is restored for the patch handler.
+func checkPatchAPIVersion(req *http.Request, scope handlers.RequestScope, served []string) error {
+	contentType := req.Header.Get("Content-Type")
+	// Remove "; charset=" if included in header.
+	if idx := strings.Index(contentType, ";"); idx > 0 {
+		contentType = contentType[:idx]
+	}
+	if patchType := types.PatchType(contentType); patchType != types.MergePatchType && patchType != types.StrategicMergePatchType {
+		return nil
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	var patch struct {
+		APIVersion *string `json:"apiVersion"`
+	}
+	if err := encodingjson.Unmarshal(body, &patch); err != nil || patch.APIVersion == nil {
+		// malformed patches are rejected by the patch handler
+		return nil
+	}
+	return checkAPIVersion(*patch.APIVersion, scope.Kind.GroupVersion(), served, "patch")
+}
+
+// checkAPIVersion returns a BadRequest error if apiVersion, taken from the given kind of request
+// body, is not the group version of the request. Versions of the group which are not served are
+// reported with the served versions.
+func checkAPIVersion(apiVersion string, requestGV schema.GroupVersion, served []string, body string) error {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return apierrors.NewBadRequest(fmt.Sprintf("invalid apiVersion %q in the %s: %v", apiVersion, body, err))
+	}
+	if gv == requestGV {
+		return nil
+	}
+	if gv.Group == requestGV.Group && !sets.NewString(served...).Has(gv.Version) {
+		return apierrors.NewBadRequest(fmt.Sprintf("%s in the %s", unservedVersionMessage(apiVersion, served), body))
+	}
+	return apierrors.NewBadRequest(fmt.Sprintf("apiVersion %s of the %s does not match the apiVersion %s of the request", apiVersion, body, requestGV))
+}
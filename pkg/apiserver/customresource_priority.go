@@ -0,0 +1,137 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authentication/user"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/metrics"
+)
+
+// FlowDescriptor describes a request for custom resources for its classification into a priority level.
+type FlowDescriptor struct {
+	// User is the user of the request, if it is authenticated.
+	User user.Info
+	// Verb is the verb of the request, e.g. list or create.
+	Verb string
+	// Resource and Kind are the resource and kind of the custom resources of the request.
+	Resource schema.GroupVersionResource
+	Kind     string
+	// Subresource, Namespace and Name identify the custom resource of the request, if any.
+	Subresource string
+	Namespace   string
+	Name        string
+}
+
+// PriorityClassifier classifies requests for custom resources into priority levels, e.g. to limit the
+// concurrency of the requests for an expensive CustomResourceDefinition. It returns the name of the
+// priority level, or the empty string if the request is not limited beyond the limits of the server.
+type PriorityClassifier interface {
+	Classify(crd *apiextensions.CustomResourceDefinition, flow FlowDescriptor) string
+}
+
+// FlowSchema matches the requests of a verb for the custom resources of a resource. The empty verb
+// matches all verbs.
+type FlowSchema struct {
+	Resource schema.GroupResource
+	Verb     string
+}
+
+// FlowSchemaClassifier classifies requests into the priority level of the flow schema matching them.
+// A flow schema with a verb takes precedence over the flow schema of the resource without verb.
+type FlowSchemaClassifier map[FlowSchema]string
+
+var _ PriorityClassifier = FlowSchemaClassifier{}
+
+func (c FlowSchemaClassifier) Classify(crd *apiextensions.CustomResourceDefinition, flow FlowDescriptor) string {
+	resource := flow.Resource.GroupResource()
+	if level, ok := c[FlowSchema{Resource: resource, Verb: flow.Verb}]; ok {
+		return level
+	}
+	return c[FlowSchema{Resource: resource}]
+}
+
+// priorityLevels limit the number of concurrent requests of each priority level. Requests of priority
+// levels without limit are not limited.
+type priorityLevels map[string]chan struct{}
+
+func newPriorityLevels(limits map[string]int) priorityLevels {
+	ret := priorityLevels{}
+	for level, limit := range limits {
+		ret[level] = make(chan struct{}, limit)
+	}
+	return ret
+}
+
+// admitPriorityLevel classifies the request into its priority level and takes one of the concurrent
+// requests of the priority level. The returned function frees it again. A 429 error is returned if the
+// priority level is at its limit. Watches are long-running and privileged users must always get an
+// answer, hence both are never limited, like by the max-in-flight limit of the server.
+func (r *crdHandler) admitPriorityLevel(ctx apirequest.Context, crd *apiextensions.CustomResourceDefinition, requestInfo *apirequest.RequestInfo) (func(), error) {
+	if r.priorityClassifier == nil || requestInfo.Verb == "watch" {
+		return func() {}, nil
+	}
+	u, _ := apirequest.UserFrom(ctx)
+	if u != nil {
+		for _, group := range u.GetGroups() {
+			if group == user.SystemPrivilegedGroup {
+				return func() {}, nil
+			}
+		}
+	}
+
+	resource := schema.GroupVersionResource{Group: requestInfo.APIGroup, Version: requestInfo.APIVersion, Resource: requestInfo.Resource}
+	level := r.priorityClassifier.Classify(crd, FlowDescriptor{
+		User:        u,
+		Verb:        requestInfo.Verb,
+		Resource:    resource,
+		Kind:        crd.Spec.Names.Kind,
+		Subresource: requestInfo.Subresource,
+		Namespace:   requestInfo.Namespace,
+		Name:        requestInfo.Name,
+	})
+	seats, ok := r.priorityLevels[level]
+	if !ok {
+		return func() {}, nil
+	}
+	select {
+	case seats <- struct{}{}:
+		return func() { <-seats }, nil
+	default:
+		metrics.IncPriorityLevelRejected(resource, level)
+		return nil, &errors.StatusError{ErrStatus: metav1.Status{
+			Status: metav1.StatusFailure,
+			Code:   http.StatusTooManyRequests,
+			Reason: metav1.StatusReasonServerTimeout,
+			Details: &metav1.StatusDetails{
+				Group:             resource.Group,
+				Kind:              resource.Resource,
+				Name:              requestInfo.Name,
+				RetryAfterSeconds: 1,
+			},
+			Message: fmt.Sprintf("too many concurrent requests of the priority level %s, please try again later", level),
+		}}
+	}
+}
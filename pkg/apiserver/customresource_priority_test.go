@@ -0,0 +1,87 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"net/http"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authentication/user"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+func TestFlowSchemaClassifier(t *testing.T) {
+	noxus := schema.GroupResource{Group: "mygroup.example.com", Resource: "noxus"}
+	classifier := FlowSchemaClassifier{
+		{Resource: noxus}:               "noxus",
+		{Resource: noxus, Verb: "list"}: "noxu-lists",
+	}
+	tests := []struct {
+		name     string
+		flow     FlowDescriptor
+		expected string
+	}{
+		{"resource", FlowDescriptor{Verb: "get", Resource: noxus.WithVersion("v1")}, "noxus"},
+		{"verb", FlowDescriptor{Verb: "list", Resource: noxus.WithVersion("v1")}, "noxu-lists"},
+		{"other resource", FlowDescriptor{Verb: "list", Resource: schema.GroupVersionResource{Group: "mygroup.example.com", Version: "v1", Resource: "curlets"}}, ""},
+	}
+	for _, tc := range tests {
+		if got := classifier.Classify(&apiextensions.CustomResourceDefinition{}, tc.flow); got != tc.expected {
+			t.Errorf("%s: expected priority level %q, got %q", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestAdmitPriorityLevel(t *testing.T) {
+	noxus := schema.GroupResource{Group: "mygroup.example.com", Resource: "noxus"}
+	r := &crdHandler{
+		priorityClassifier: FlowSchemaClassifier{{Resource: noxus, Verb: "list"}: "noxu-lists"},
+		priorityLevels:     newPriorityLevels(map[string]int{"noxu-lists": 1}),
+	}
+	crd := &apiextensions.CustomResourceDefinition{}
+	ctx := apirequest.WithUser(apirequest.NewContext(), &user.DefaultInfo{Name: "bob"})
+	requestInfo := func(verb string) *apirequest.RequestInfo {
+		return &apirequest.RequestInfo{IsResourceRequest: true, Verb: verb, APIGroup: noxus.Group, APIVersion: "v1", Resource: noxus.Resource}
+	}
+
+	release, err := r.admitPriorityLevel(ctx, crd, requestInfo("list"))
+	if err != nil {
+		t.Fatalf("unexpected error admitting the first list: %v", err)
+	}
+	_, err = r.admitPriorityLevel(ctx, crd, requestInfo("list"))
+	if statusErr, ok := err.(*apierrors.StatusError); !ok || statusErr.ErrStatus.Code != http.StatusTooManyRequests {
+		t.Errorf("expected a 429 error for the second list, got %v", err)
+	}
+	for _, verb := range []string{"get", "watch"} {
+		if _, err := r.admitPriorityLevel(ctx, crd, requestInfo(verb)); err != nil {
+			t.Errorf("expected %s not to be limited, got %v", verb, err)
+		}
+	}
+	privileged := apirequest.WithUser(apirequest.NewContext(), &user.DefaultInfo{Name: "admin", Groups: []string{user.SystemPrivilegedGroup}})
+	if _, err := r.admitPriorityLevel(privileged, crd, requestInfo("list")); err != nil {
+		t.Errorf("expected privileged users not to be limited, got %v", err)
+	}
+
+	release()
+	if _, err := r.admitPriorityLevel(ctx, crd, requestInfo("list")); err != nil {
+		t.Errorf("unexpected error admitting a list after the first one finished: %v", err)
+	}
+}
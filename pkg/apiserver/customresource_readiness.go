@@ -0,0 +1,125 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+)
+
+// crdReadinessPath is the path of the readiness report of all CRDs. The report of a single CRD is
+// served below it, at crdReadinessPath/<name>.
+const crdReadinessPath = "/crds"
+
+// PublishedChecker knows whether the OpenAPI documents of a CRD are published.
+type PublishedChecker interface {
+	// IsPublished returns true if the OpenAPI documents contain the current spec of the CRD.
+	IsPublished(crd *apiextensions.CustomResourceDefinition) bool
+}
+
+// crdReadiness reports how far a CRD is served.
+type crdReadiness struct {
+	Name             string `json:"name"`
+	Established      bool   `json:"established"`
+	NamesAccepted    bool   `json:"namesAccepted"`
+	OpenAPIPublished bool   `json:"openAPIPublished"`
+	// Ready is true if the CRD is established, its names are accepted and its OpenAPI documents are published.
+	Ready bool `json:"ready"`
+}
+
+// crdReadinessList is the readiness report of all CRDs.
+type crdReadinessList struct {
+	Items []crdReadiness `json:"items"`
+}
+
+// crdReadinessHandler serves the readiness reports of the CRDs, such that deployment tooling can wait
+// for CRDs to be fully served. The report of a single CRD is served with status 503 until it is ready.
+type crdReadinessHandler struct {
+	crdLister        listers.CustomResourceDefinitionLister
+	publishedChecker PublishedChecker
+}
+
+func (h *crdReadinessHandler) readiness(crd *apiextensions.CustomResourceDefinition) crdReadiness {
+	ret := crdReadiness{
+		Name:             crd.Name,
+		Established:      apiextensions.IsCRDConditionTrue(crd, apiextensions.Established),
+		NamesAccepted:    apiextensions.IsCRDConditionTrue(crd, apiextensions.NamesAccepted),
+		OpenAPIPublished: h.publishedChecker.IsPublished(crd),
+	}
+	ret.Ready = ret.Established && ret.NamesAccepted && ret.OpenAPIPublished
+	return ret
+}
+
+func (h *crdReadinessHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var report interface{}
+	status := http.StatusOK
+	switch name := strings.Trim(strings.TrimPrefix(req.URL.Path, crdReadinessPath), "/"); {
+	case len(name) == 0:
+		crds, err := h.crdLister.List(labels.Everything())
+		if err != nil {
+			utilruntime.HandleError(err)
+			http.Error(w, "unable to list the CustomResourceDefinitions", http.StatusInternalServerError)
+			return
+		}
+		list := crdReadinessList{Items: make([]crdReadiness, 0, len(crds))}
+		for _, crd := range crds {
+			list.Items = append(list.Items, h.readiness(crd))
+		}
+		sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+		report = list
+	default:
+		crd, err := h.crdLister.Get(name)
+		if apierrors.IsNotFound(err) {
+			http.NotFound(w, req)
+			return
+		}
+		if err != nil {
+			utilruntime.HandleError(err)
+			http.Error(w, "unable to get the CustomResourceDefinition", http.StatusInternalServerError)
+			return
+		}
+		readiness := h.readiness(crd)
+		if !readiness.Ready {
+			status = http.StatusServiceUnavailable
+		}
+		report = readiness
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		utilruntime.HandleError(err)
+		http.Error(w, "unable to encode the readiness report", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
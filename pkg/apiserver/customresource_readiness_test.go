@@ -0,0 +1,88 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+)
+
+type fakePublishedChecker map[string]bool
+
+func (c fakePublishedChecker) IsPublished(crd *apiextensions.CustomResourceDefinition) bool {
+	return c[crd.Name]
+}
+
+func TestCRDReadiness(t *testing.T) {
+	newCRD := func(name string, conditions ...apiextensions.CustomResourceDefinitionConditionType) *apiextensions.CustomResourceDefinition {
+		crd := &apiextensions.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		for _, condition := range conditions {
+			apiextensions.SetCRDCondition(crd, apiextensions.CustomResourceDefinitionCondition{Type: condition, Status: apiextensions.ConditionTrue})
+		}
+		return crd
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	indexer.Add(newCRD("ready.example.com", apiextensions.Established, apiextensions.NamesAccepted))
+	indexer.Add(newCRD("unpublished.example.com", apiextensions.Established, apiextensions.NamesAccepted))
+	indexer.Add(newCRD("pending.example.com", apiextensions.NamesAccepted))
+	h := &crdReadinessHandler{
+		crdLister:        listers.NewCustomResourceDefinitionLister(indexer),
+		publishedChecker: fakePublishedChecker{"ready.example.com": true},
+	}
+
+	tests := []struct {
+		path     string
+		code     int
+		expected interface{}
+	}{
+		{crdReadinessPath, http.StatusOK, &crdReadinessList{Items: []crdReadiness{
+			{Name: "pending.example.com", NamesAccepted: true},
+			{Name: "ready.example.com", Established: true, NamesAccepted: true, OpenAPIPublished: true, Ready: true},
+			{Name: "unpublished.example.com", Established: true, NamesAccepted: true},
+		}}},
+		{crdReadinessPath + "/ready.example.com", http.StatusOK, &crdReadiness{Name: "ready.example.com", Established: true, NamesAccepted: true, OpenAPIPublished: true, Ready: true}},
+		{crdReadinessPath + "/unpublished.example.com", http.StatusServiceUnavailable, &crdReadiness{Name: "unpublished.example.com", Established: true, NamesAccepted: true}},
+		{crdReadinessPath + "/missing.example.com", http.StatusNotFound, nil},
+	}
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", tc.path, nil))
+		if w.Code != tc.code {
+			t.Errorf("%s: expected status %d, got %d", tc.path, tc.code, w.Code)
+			continue
+		}
+		if tc.expected == nil {
+			continue
+		}
+		actual := reflect.New(reflect.TypeOf(tc.expected).Elem()).Interface()
+		if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
+			t.Fatalf("%s: %v", tc.path, err)
+		}
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%s: expected %#v, got %#v", tc.path, tc.expected, actual)
+		}
+	}
+}
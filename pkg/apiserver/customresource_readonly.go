@@ -0,0 +1,57 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authentication/user"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+// writeVerbs are the verbs which change custom resources.
+var writeVerbs = sets.NewString("create", "update", "patch", "delete", "deletecollection")
+
+// checkReadOnly returns an error for writes of the custom resources of a CustomResourceDefinition in
+// read-only mode, i.e. with the apiextensions.k8s.io/read-only annotation, whose value is the message
+// of the error. Privileged users can still write, e.g. to sync the custom resources from another
+// cluster or to finish a migration.
+func checkReadOnly(ctx apirequest.Context, crd *apiextensions.CustomResourceDefinition, requestInfo *apirequest.RequestInfo) error {
+	message, readOnly := crd.Annotations[apiextensions.ReadOnlyAnnotation]
+	if !readOnly || !writeVerbs.Has(requestInfo.Verb) {
+		return nil
+	}
+	if u, ok := apirequest.UserFrom(ctx); ok {
+		for _, group := range u.GetGroups() {
+			if group == user.SystemPrivilegedGroup {
+				return nil
+			}
+		}
+	}
+
+	if len(message) == 0 {
+		message = fmt.Sprintf("the custom resources of CustomResourceDefinition %s are read-only", crd.Name)
+	}
+	err := apierrors.NewMethodNotSupported(schema.GroupResource{Group: requestInfo.APIGroup, Resource: requestInfo.Resource}, requestInfo.Verb)
+	err.ErrStatus.Message = fmt.Sprintf("%v not allowed: %s", requestInfo.Verb, message)
+	return err
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"net/http"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+func TestCheckReadOnly(t *testing.T) {
+	bob := &user.DefaultInfo{Name: "bob"}
+	admin := &user.DefaultInfo{Name: "admin", Groups: []string{user.SystemPrivilegedGroup}}
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		verb            string
+		user            user.Info
+		expectedMessage string
+	}{
+		{
+			name: "writable",
+			verb: "create",
+			user: bob,
+		},
+		{
+			name:        "read-only get",
+			annotations: map[string]string{apiextensions.ReadOnlyAnnotation: ""},
+			verb:        "get",
+			user:        bob,
+		},
+		{
+			name:        "read-only watch",
+			annotations: map[string]string{apiextensions.ReadOnlyAnnotation: ""},
+			verb:        "watch",
+			user:        bob,
+		},
+		{
+			name:            "read-only create",
+			annotations:     map[string]string{apiextensions.ReadOnlyAnnotation: ""},
+			verb:            "create",
+			user:            bob,
+			expectedMessage: "create not allowed: the custom resources of CustomResourceDefinition noxus.mygroup.example.com are read-only",
+		},
+		{
+			name:            "read-only patch with message",
+			annotations:     map[string]string{apiextensions.ReadOnlyAnnotation: "synced from cluster east, edit them there"},
+			verb:            "patch",
+			user:            bob,
+			expectedMessage: "patch not allowed: synced from cluster east, edit them there",
+		},
+		{
+			name:            "read-only deletecollection",
+			annotations:     map[string]string{apiextensions.ReadOnlyAnnotation: "migrating"},
+			verb:            "deletecollection",
+			user:            bob,
+			expectedMessage: "deletecollection not allowed: migrating",
+		},
+		{
+			name:        "read-only update by privileged user",
+			annotations: map[string]string{apiextensions.ReadOnlyAnnotation: "migrating"},
+			verb:        "update",
+			user:        admin,
+		},
+	}
+	for _, tc := range tests {
+		crd := &apiextensions.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "noxus.mygroup.example.com", Annotations: tc.annotations}}
+		ctx := apirequest.WithUser(apirequest.NewContext(), tc.user)
+		requestInfo := &apirequest.RequestInfo{IsResourceRequest: true, Verb: tc.verb, APIGroup: "mygroup.example.com", APIVersion: "v1", Resource: "noxus"}
+		err := checkReadOnly(ctx, crd, requestInfo)
+		if len(tc.expectedMessage) == 0 {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+		statusErr, ok := err.(*apierrors.StatusError)
+		if !ok || statusErr.ErrStatus.Code != http.StatusMethodNotAllowed {
+			t.Errorf("%s: expected a 405 error, got %v", tc.name, err)
+			continue
+		}
+		if statusErr.ErrStatus.Message != tc.expectedMessage {
+			t.Errorf("%s: expected message %q, got %q", tc.name, tc.expectedMessage, statusErr.ErrStatus.Message)
+		}
+	}
+}
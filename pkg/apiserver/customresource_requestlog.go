@@ -0,0 +1,123 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/golang/glog"
+
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+// requestLogging is the logging of the requests for the custom resources of a
+// CustomResourceDefinition, configured by its annotations. It allows debugging the clients of a
+// single CustomResourceDefinition without raising the verbosity of the whole server.
+type requestLogging struct {
+	// logged is true if all requests are logged at verbosity.
+	logged    bool
+	verbosity glog.Level
+	// slowThreshold is the latency above which requests are logged as slow. Zero disables it.
+	slowThreshold time.Duration
+}
+
+// requestLoggingFor returns the request logging configured by the annotations of the
+// CustomResourceDefinition.
+func requestLoggingFor(crd *apiextensions.CustomResourceDefinition) requestLogging {
+	logging := requestLogging{}
+	// the annotations are validated to be a non-negative integer and a positive duration
+	if n, err := strconv.ParseInt(crd.Annotations[apiextensions.RequestLogVerbosityAnnotation], 10, 32); err == nil {
+		logging.logged = true
+		logging.verbosity = glog.Level(n)
+	}
+	if d, err := time.ParseDuration(crd.Annotations[apiextensions.SlowRequestThresholdAnnotation]); err == nil {
+		logging.slowThreshold = d
+	}
+	return logging
+}
+
+// enabled returns true if any requests are logged.
+func (l requestLogging) enabled() bool {
+	return l.logged || l.slowThreshold > 0
+}
+
+// slow returns true if a request with the given verb and latency is slow. Watches are long-running
+// and never slow.
+func (l requestLogging) slow(verb string, latency time.Duration) bool {
+	return l.slowThreshold > 0 && verb != "watch" && latency > l.slowThreshold
+}
+
+// logRequest logs the request started at start, whose response was written to w, if it is slow or
+// all requests are logged at a verbosity of the server.
+func (l requestLogging) logRequest(ctx apirequest.Context, req *http.Request, requestInfo *apirequest.RequestInfo, crdName string, w *statusRecordingResponseWriter, start time.Time) {
+	latency := time.Since(start)
+	user := ""
+	if u, ok := apirequest.UserFrom(ctx); ok {
+		user = u.GetName()
+	}
+	if l.slow(requestInfo.Verb, latency) {
+		glog.Warningf("Slow %s request %s for customresourcedefinition %s by %q: %d in %v, exceeding %v", requestInfo.Verb, req.URL.RequestURI(), crdName, user, w.statusCode(), latency, l.slowThreshold)
+		return
+	}
+	if l.logged && bool(glog.V(l.verbosity)) {
+		glog.Infof("%s request %s for customresourcedefinition %s by %q: %d in %v", requestInfo.Verb, req.URL.RequestURI(), crdName, user, w.statusCode(), latency)
+	}
+}
+
+// statusRecordingResponseWriter records the status code of the response.
+type statusRecordingResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusRecordingResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusRecordingResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// statusCode returns the status code of the response, which is 200 OK if nothing was written.
+func (w *statusRecordingResponseWriter) statusCode() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+// Flush is part of the http.Flusher interface, which watches require.
+func (w *statusRecordingResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// CloseNotify is part of the http.CloseNotifier interface.
+func (w *statusRecordingResponseWriter) CloseNotify() <-chan bool {
+	return w.ResponseWriter.(http.CloseNotifier).CloseNotify()
+}
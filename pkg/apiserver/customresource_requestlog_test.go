@@ -0,0 +1,134 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/glog"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+func TestRequestLoggingFor(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    requestLogging
+		enabled     bool
+	}{
+		{
+			name: "no annotations",
+		},
+		{
+			name:        "verbosity",
+			annotations: map[string]string{apiextensions.RequestLogVerbosityAnnotation: "2"},
+			expected:    requestLogging{logged: true, verbosity: glog.Level(2)},
+			enabled:     true,
+		},
+		{
+			name:        "zero verbosity",
+			annotations: map[string]string{apiextensions.RequestLogVerbosityAnnotation: "0"},
+			expected:    requestLogging{logged: true},
+			enabled:     true,
+		},
+		{
+			name:        "slow request threshold",
+			annotations: map[string]string{apiextensions.SlowRequestThresholdAnnotation: "500ms"},
+			expected:    requestLogging{slowThreshold: 500 * time.Millisecond},
+			enabled:     true,
+		},
+	}
+	for _, tc := range tests {
+		crd := &apiextensions.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+		logging := requestLoggingFor(crd)
+		if logging != tc.expected {
+			t.Errorf("%s: expected %+v, got %+v", tc.name, tc.expected, logging)
+		}
+		if logging.enabled() != tc.enabled {
+			t.Errorf("%s: expected enabled %v, got %v", tc.name, tc.enabled, logging.enabled())
+		}
+	}
+}
+
+func TestRequestLoggingSlow(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		verb      string
+		latency   time.Duration
+		expected  bool
+	}{
+		{name: "no threshold", verb: "list", latency: time.Hour},
+		{name: "below threshold", threshold: time.Second, verb: "list", latency: time.Second},
+		{name: "above threshold", threshold: time.Second, verb: "list", latency: 2 * time.Second, expected: true},
+		{name: "watch", threshold: time.Second, verb: "watch", latency: time.Hour},
+	}
+	for _, tc := range tests {
+		logging := requestLogging{slowThreshold: tc.threshold}
+		if got := logging.slow(tc.verb, tc.latency); got != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestStatusRecordingResponseWriter(t *testing.T) {
+	tests := []struct {
+		name     string
+		write    func(w http.ResponseWriter)
+		expected int
+	}{
+		{
+			name:     "nothing written",
+			write:    func(w http.ResponseWriter) {},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "body",
+			write:    func(w http.ResponseWriter) { w.Write([]byte("{}")) },
+			expected: http.StatusOK,
+		},
+		{
+			name: "status",
+			write: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte("{}"))
+			},
+			expected: http.StatusNotFound,
+		},
+	}
+	for _, tc := range tests {
+		recorder := httptest.NewRecorder()
+		w := &statusRecordingResponseWriter{ResponseWriter: recorder}
+		tc.write(w)
+		w.Flush()
+		if got := w.statusCode(); got != tc.expected {
+			t.Errorf("%s: expected status %d, got %d", tc.name, tc.expected, got)
+		}
+		if recorder.Code != tc.expected {
+			t.Errorf("%s: expected status %d to be written, got %d", tc.name, tc.expected, recorder.Code)
+		}
+		if !recorder.Flushed {
+			t.Errorf("%s: expected the response to be flushed", tc.name)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+)
+
+// CustomResourceRESTMapper maps between the kinds and resources of the served versions of all
+// established CustomResourceDefinitions, using their accepted names. It can be combined with the
+// RESTMapper of other resources, e.g. by the garbage collector, to resolve owner references to
+// custom resources.
+type CustomResourceRESTMapper struct {
+	crdLister listers.CustomResourceDefinitionLister
+}
+
+var _ meta.RESTMapper = &CustomResourceRESTMapper{}
+
+// NewCustomResourceRESTMapper returns a RESTMapper for the custom resources of the
+// CustomResourceDefinitions of the lister.
+func NewCustomResourceRESTMapper(crdLister listers.CustomResourceDefinitionLister) *CustomResourceRESTMapper {
+	return &CustomResourceRESTMapper{crdLister: crdLister}
+}
+
+// customResourceMapping is the mapping of one served version of a CustomResourceDefinition.
+type customResourceMapping struct {
+	resource schema.GroupVersionResource
+	singular string
+	kind     schema.GroupVersionKind
+	scope    meta.RESTScope
+}
+
+// mappings returns the mappings of the served versions, ordered by CustomResourceDefinition name
+// and, within a CustomResourceDefinition, by the order of spec.versions, i.e. the first served
+// version is preferred.
+func (m *CustomResourceRESTMapper) mappings() ([]customResourceMapping, error) {
+	crds, err := m.crdLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(crds, func(i, j int) bool { return crds[i].Name < crds[j].Name })
+
+	var ret []customResourceMapping
+	for _, crd := range crds {
+		if !apiextensions.IsCRDConditionTrue(crd, apiextensions.Established) {
+			continue
+		}
+		scope := meta.RESTScopeRoot
+		if crd.Spec.Scope == apiextensions.NamespaceScoped {
+			scope = meta.RESTScopeNamespace
+		}
+		for _, v := range crd.Spec.Versions {
+			if !v.Served {
+				continue
+			}
+			ret = append(ret, customResourceMapping{
+				resource: schema.GroupVersionResource{Group: crd.Spec.Group, Version: v.Name, Resource: crd.Status.AcceptedNames.Plural},
+				singular: crd.Status.AcceptedNames.Singular,
+				kind:     schema.GroupVersionKind{Group: crd.Spec.Group, Version: v.Name, Kind: crd.Status.AcceptedNames.Kind},
+				scope:    scope,
+			})
+		}
+	}
+	return ret, nil
+}
+
+// matchesResource returns true if the mapping matches the partial resource. The resource may be
+// given in its plural or singular form.
+func (mapping customResourceMapping) matchesResource(input schema.GroupVersionResource) bool {
+	if len(input.Group) > 0 && input.Group != mapping.resource.Group {
+		return false
+	}
+	if len(input.Version) > 0 && input.Version != runtime.APIVersionInternal && input.Version != mapping.resource.Version {
+		return false
+	}
+	resource := strings.ToLower(input.Resource)
+	return len(resource) == 0 || resource == mapping.resource.Resource || resource == mapping.singular
+}
+
+// ServesGroup returns true if the API group is served by an established CustomResourceDefinition.
+func (m *CustomResourceRESTMapper) ServesGroup(group string) bool {
+	mappings, err := m.mappings()
+	if err != nil {
+		return false
+	}
+	for _, mapping := range mappings {
+		if mapping.resource.Group == group {
+			return true
+		}
+	}
+	return false
+}
+
+// KindsFor returns the kinds of the custom resources matching the partial resource, in order of
+// preference.
+func (m *CustomResourceRESTMapper) KindsFor(input schema.GroupVersionResource) ([]schema.GroupVersionKind, error) {
+	mappings, err := m.mappings()
+	if err != nil {
+		return nil, err
+	}
+	var ret []schema.GroupVersionKind
+	for _, mapping := range mappings {
+		if mapping.matchesResource(input) {
+			ret = append(ret, mapping.kind)
+		}
+	}
+	if len(ret) == 0 {
+		return nil, &meta.NoResourceMatchError{PartialResource: input}
+	}
+	return ret, nil
+}
+
+// KindFor returns the preferred kind of the custom resources matching the partial resource. It
+// is ambiguous if the partial resource matches more than one CustomResourceDefinition.
+func (m *CustomResourceRESTMapper) KindFor(input schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	kinds, err := m.KindsFor(input)
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+	for _, kind := range kinds[1:] {
+		if kind.GroupKind() != kinds[0].GroupKind() {
+			return schema.GroupVersionKind{}, &meta.AmbiguousResourceError{PartialResource: input, MatchingKinds: kinds}
+		}
+	}
+	return kinds[0], nil
+}
+
+// ResourcesFor returns the custom resources matching the partial resource, in order of preference.
+func (m *CustomResourceRESTMapper) ResourcesFor(input schema.GroupVersionResource) ([]schema.GroupVersionResource, error) {
+	mappings, err := m.mappings()
+	if err != nil {
+		return nil, err
+	}
+	var ret []schema.GroupVersionResource
+	for _, mapping := range mappings {
+		if mapping.matchesResource(input) {
+			ret = append(ret, mapping.resource)
+		}
+	}
+	if len(ret) == 0 {
+		return nil, &meta.NoResourceMatchError{PartialResource: input}
+	}
+	return ret, nil
+}
+
+// ResourceFor returns the preferred custom resource matching the partial resource. It is
+// ambiguous if the partial resource matches more than one CustomResourceDefinition.
+func (m *CustomResourceRESTMapper) ResourceFor(input schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	resources, err := m.ResourcesFor(input)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	for _, resource := range resources[1:] {
+		if resource.GroupResource() != resources[0].GroupResource() {
+			return schema.GroupVersionResource{}, &meta.AmbiguousResourceError{PartialResource: input, MatchingResources: resources}
+		}
+	}
+	return resources[0], nil
+}
+
+// RESTMappings returns the mappings of the given kind. If versions are given, only the mapping of
+// the first of them which is served is returned. Otherwise all served versions are returned in
+// order of preference.
+func (m *CustomResourceRESTMapper) RESTMappings(gk schema.GroupKind, versions ...string) ([]*meta.RESTMapping, error) {
+	mappings, err := m.mappings()
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[string]customResourceMapping{}
+	var all []customResourceMapping
+	for _, mapping := range mappings {
+		if mapping.kind.GroupKind() == gk {
+			byVersion[mapping.kind.Version] = mapping
+			all = append(all, mapping)
+		}
+	}
+
+	var matching []customResourceMapping
+	hadVersion := false
+	for _, version := range versions {
+		if len(version) == 0 || version == runtime.APIVersionInternal {
+			continue
+		}
+		hadVersion = true
+		if mapping, ok := byVersion[version]; ok {
+			matching = []customResourceMapping{mapping}
+			break
+		}
+	}
+	if !hadVersion {
+		matching = all
+	}
+	if len(matching) == 0 {
+		return nil, &meta.NoKindMatchError{PartialKind: gk.WithVersion("")}
+	}
+
+	ret := make([]*meta.RESTMapping, 0, len(matching))
+	for _, mapping := range matching {
+		ret = append(ret, &meta.RESTMapping{
+			Resource:         mapping.resource.Resource,
+			GroupVersionKind: mapping.kind,
+			Scope:            mapping.scope,
+			ObjectConvertor:  unstructured.UnstructuredObjectConverter{},
+			MetadataAccessor: meta.NewAccessor(),
+		})
+	}
+	return ret, nil
+}
+
+// RESTMapping returns the preferred mapping of the given kind, see RESTMappings.
+func (m *CustomResourceRESTMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	mappings, err := m.RESTMappings(gk, versions...)
+	if err != nil {
+		return nil, err
+	}
+	return mappings[0], nil
+}
+
+// ResourceSingularizer returns the singular name of the custom resources with the given plural name.
+func (m *CustomResourceRESTMapper) ResourceSingularizer(resource string) (string, error) {
+	mappings, err := m.mappings()
+	if err != nil {
+		return resource, err
+	}
+	for _, mapping := range mappings {
+		if mapping.resource.Resource == resource {
+			return mapping.singular, nil
+		}
+	}
+	return resource, fmt.Errorf("no singular of resource %v has been defined", resource)
+}
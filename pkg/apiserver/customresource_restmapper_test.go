@@ -0,0 +1,112 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+)
+
+func TestCustomResourceRESTMapper(t *testing.T) {
+	newCRD := func(name, group, plural, singular, kind string, scope apiextensions.ResourceScope, established bool, versions ...apiextensions.CustomResourceDefinitionVersion) *apiextensions.CustomResourceDefinition {
+		crd := &apiextensions.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: apiextensions.CustomResourceDefinitionSpec{
+				Group:    group,
+				Versions: versions,
+				Scope:    scope,
+			},
+			Status: apiextensions.CustomResourceDefinitionStatus{
+				AcceptedNames: apiextensions.CustomResourceDefinitionNames{Plural: plural, Singular: singular, Kind: kind},
+			},
+		}
+		if established {
+			apiextensions.SetCRDCondition(crd, apiextensions.CustomResourceDefinitionCondition{Type: apiextensions.Established, Status: apiextensions.ConditionTrue})
+		}
+		return crd
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	indexer.Add(newCRD("mice.example.com", "example.com", "mice", "mouse", "Mouse", apiextensions.NamespaceScoped, true,
+		apiextensions.CustomResourceDefinitionVersion{Name: "v2", Served: true},
+		apiextensions.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true},
+		apiextensions.CustomResourceDefinitionVersion{Name: "v0", Served: false},
+	))
+	indexer.Add(newCRD("clusters.example.com", "example.com", "clusters", "cluster", "Cluster", apiextensions.ClusterScoped, true,
+		apiextensions.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true},
+	))
+	indexer.Add(newCRD("pending.other.com", "other.com", "pendings", "pending", "Pending", apiextensions.NamespaceScoped, false,
+		apiextensions.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true},
+	))
+	m := NewCustomResourceRESTMapper(listers.NewCustomResourceDefinitionLister(indexer))
+
+	if !m.ServesGroup("example.com") || m.ServesGroup("other.com") {
+		t.Errorf("expected only example.com to be served")
+	}
+
+	// irregular plurals are mapped with the accepted names, the first served version is preferred
+	kinds, err := m.KindsFor(schema.GroupVersionResource{Resource: "mouse"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []schema.GroupVersionKind{{Group: "example.com", Version: "v2", Kind: "Mouse"}, {Group: "example.com", Version: "v1", Kind: "Mouse"}}; !reflect.DeepEqual(kinds, expected) {
+		t.Errorf("expected kinds %v, got %v", expected, kinds)
+	}
+	if kind, err := m.KindFor(schema.GroupVersionResource{Group: "example.com", Resource: "mice"}); err != nil || kind.Version != "v2" {
+		t.Errorf("expected the kind in v2, got %v, %v", kind, err)
+	}
+	if resource, err := m.ResourceFor(schema.GroupVersionResource{Version: "v1", Resource: "Mice"}); err != nil || resource != (schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "mice"}) {
+		t.Errorf("unexpected resource %v, %v", resource, err)
+	}
+	if _, err := m.KindFor(schema.GroupVersionResource{Group: "example.com"}); !meta.IsAmbiguousError(err) {
+		t.Errorf("expected an ambiguous error, got %v", err)
+	}
+	if _, err := m.KindsFor(schema.GroupVersionResource{Version: "v0", Resource: "mice"}); !meta.IsNoMatchError(err) {
+		t.Errorf("expected no match for the unserved version, got %v", err)
+	}
+	if _, err := m.KindsFor(schema.GroupVersionResource{Resource: "pendings"}); !meta.IsNoMatchError(err) {
+		t.Errorf("expected no match for the CRD which is not established, got %v", err)
+	}
+
+	mapping, err := m.RESTMapping(schema.GroupKind{Group: "example.com", Kind: "Mouse"}, "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mapping.Resource != "mice" || mapping.GroupVersionKind.Version != "v1" || mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		t.Errorf("unexpected mapping %#v", mapping)
+	}
+	if mapping, err := m.RESTMapping(schema.GroupKind{Group: "example.com", Kind: "Cluster"}); err != nil || mapping.Scope.Name() != meta.RESTScopeNameRoot {
+		t.Errorf("expected a cluster-scoped mapping, got %#v, %v", mapping, err)
+	}
+	if _, err := m.RESTMapping(schema.GroupKind{Group: "example.com", Kind: "Mouse"}, "v0"); !meta.IsNoMatchError(err) {
+		t.Errorf("expected no match for the unserved version, got %v", err)
+	}
+	if mappings, err := m.RESTMappings(schema.GroupKind{Group: "example.com", Kind: "Mouse"}); err != nil || len(mappings) != 2 {
+		t.Errorf("expected mappings of both served versions, got %v, %v", mappings, err)
+	}
+
+	if singular, err := m.ResourceSingularizer("mice"); err != nil || singular != "mouse" {
+		t.Errorf("expected singular mouse, got %q, %v", singular, err)
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apiserver/pkg/admission"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+)
+
+// crdServingPath is the path below which the serving chains of the custom resources are reported, at
+// crdServingPath/<group>/<version>/<resource>.
+const crdServingPath = "/debug/crd-serving"
+
+// crdServingHandler reports how the custom resources of a version of a CRD are served: the schema,
+// conversion, subresources and admission which apply to their requests. It makes it easier to debug
+// why custom resources behave the way they do.
+type crdServingHandler struct {
+	crdLister listers.CustomResourceDefinitionLister
+	// admission is the admission chain of the custom resources. It is optional.
+	admission admission.Interface
+	// hooks are the custom resource hooks in the admission chain. They are optional.
+	hooks *CustomResourceHooks
+}
+
+// crdServingReport is the serving chain of a version of a CRD.
+type crdServingReport struct {
+	CustomResourceDefinition string `json:"customResourceDefinition"`
+	Kind                     string `json:"kind"`
+	Scope                    string `json:"scope"`
+	Established              bool   `json:"established"`
+	Terminating              bool   `json:"terminating"`
+
+	Served             bool   `json:"served"`
+	Storage            bool   `json:"storage"`
+	MirrorStorage      bool   `json:"mirrorStorage"`
+	StorageVersion     string `json:"storageVersion"`
+	DeprecationWarning string `json:"deprecationWarning,omitempty"`
+
+	Schema       crdServingSchema     `json:"schema"`
+	Conversion   crdServingConversion `json:"conversion"`
+	Subresources []string             `json:"subresources"`
+	Admission    crdServingAdmission  `json:"admission"`
+}
+
+// crdServingSchema is the schema which custom resources of a version are validated against.
+type crdServingSchema struct {
+	// Source is the field of the CRD with the schema, empty if the version has no schema.
+	Source string `json:"source,omitempty"`
+	// Error is why the schema cannot be used.
+	Error string `json:"error,omitempty"`
+}
+
+// crdServingConversion is how custom resources are converted between the versions of a CRD.
+type crdServingConversion struct {
+	Strategy                 string   `json:"strategy"`
+	ConversionReviewVersions []string `json:"conversionReviewVersions,omitempty"`
+	FailurePolicy            string   `json:"failurePolicy,omitempty"`
+}
+
+// crdServingAdmission is the admission of requests for custom resources of a CRD.
+type crdServingAdmission struct {
+	// Operations are the operations handled by the admission chain.
+	Operations []admission.Operation `json:"operations"`
+	// Mutators and Validators are the numbers of custom resource hooks registered for the group.
+	Mutators   int `json:"mutators"`
+	Validators int `json:"validators"`
+}
+
+func (h *crdServingHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(req.URL.Path, crdServingPath), "/"), "/")
+	if len(parts) != 3 {
+		http.NotFound(w, req)
+		return
+	}
+	group, version, resource := parts[0], parts[1], parts[2]
+	crd, err := h.crdLister.Get(resource + "." + group)
+	if apierrors.IsNotFound(err) {
+		http.NotFound(w, req)
+		return
+	}
+	if err != nil {
+		utilruntime.HandleError(err)
+		http.Error(w, "unable to get the CustomResourceDefinition", http.StatusInternalServerError)
+		return
+	}
+	report, found := h.report(crd, version)
+	if !found {
+		http.NotFound(w, req)
+		return
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		utilruntime.HandleError(err)
+		http.Error(w, "unable to encode the serving chain", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+// report returns the serving chain of the given version of the CRD. It returns false if the CRD has
+// no such version.
+func (h *crdServingHandler) report(crd *apiextensions.CustomResourceDefinition, version string) (*crdServingReport, bool) {
+	index := -1
+	for i := range crd.Spec.Versions {
+		if crd.Spec.Versions[i].Name == version {
+			index = i
+		}
+	}
+	if index < 0 {
+		return nil, false
+	}
+	v := &crd.Spec.Versions[index]
+
+	report := &crdServingReport{
+		CustomResourceDefinition: crd.Name,
+		Kind:                     crd.Spec.Names.Kind,
+		Scope:                    string(crd.Spec.Scope),
+		Established:              apiextensions.IsCRDConditionTrue(crd, apiextensions.Established),
+		Terminating:              apiextensions.IsCRDConditionTrue(crd, apiextensions.Terminating) || crd.DeletionTimestamp != nil,
+		Served:                   v.Served,
+		Storage:                  v.Storage,
+		MirrorStorage:            v.MirrorStorage,
+		Subresources:             []string{},
+	}
+	if storageVersion, err := apiextensions.GetCRDStorageVersion(crd); err == nil {
+		report.StorageVersion = storageVersion
+	}
+	if warning := apiextensions.GetDeprecationWarning(crd, version); warning != nil {
+		report.DeprecationWarning = *warning
+	}
+
+	switch validation, err := apiextensions.GetSchemaForVersion(crd, version); {
+	case err != nil:
+		report.Schema.Error = err.Error()
+	case validation == nil || validation.OpenAPIV3Schema == nil:
+	case apiextensions.HasPerVersionSchema(crd.Spec.Versions):
+		report.Schema.Source = fmt.Sprintf("spec.versions[%d].schema.openAPIV3Schema", index)
+	default:
+		report.Schema.Source = "spec.validation.openAPIV3Schema"
+	}
+
+	report.Conversion.Strategy = string(apiextensions.NoneConverter)
+	if c := crd.Spec.Conversion; c != nil {
+		report.Conversion.Strategy = string(c.Strategy)
+		report.Conversion.ConversionReviewVersions = c.ConversionReviewVersions
+		if c.Strategy == apiextensions.WebhookConverter {
+			report.Conversion.FailurePolicy = string(apiextensions.ConversionFailurePolicyFail)
+			if c.FailurePolicy != nil {
+				report.Conversion.FailurePolicy = string(*c.FailurePolicy)
+			}
+		}
+	}
+
+	if subresources, err := apiextensions.GetSubresourcesForVersion(crd, version); err == nil && subresources != nil {
+		if subresources.Status != nil {
+			report.Subresources = append(report.Subresources, "status")
+		}
+		if subresources.Scale != nil {
+			report.Subresources = append(report.Subresources, "scale")
+		}
+		for _, custom := range subresources.Custom {
+			report.Subresources = append(report.Subresources, custom.Name)
+		}
+	}
+
+	report.Admission.Operations = []admission.Operation{}
+	if h.admission != nil {
+		for _, operation := range []admission.Operation{admission.Create, admission.Update, admission.Delete, admission.Connect} {
+			if h.admission.Handles(operation) {
+				report.Admission.Operations = append(report.Admission.Operations, operation)
+			}
+		}
+	}
+	if h.hooks != nil {
+		report.Admission.Mutators, report.Admission.Validators = h.hooks.registered(crd.Spec.Group)
+	}
+	return report, true
+}
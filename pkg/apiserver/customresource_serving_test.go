@@ -0,0 +1,129 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+)
+
+func TestCRDServing(t *testing.T) {
+	warning := "use v1"
+	ignore := apiextensions.ConversionFailurePolicyIgnore
+	schema := &apiextensions.CustomResourceValidation{OpenAPIV3Schema: &apiextensions.JSONSchemaProps{Type: "object"}}
+	crd := &apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "foos.example.com"},
+		Spec: apiextensions.CustomResourceDefinitionSpec{
+			Group: "example.com",
+			Names: apiextensions.CustomResourceDefinitionNames{Plural: "foos", Kind: "Foo"},
+			Scope: apiextensions.NamespaceScoped,
+			Versions: []apiextensions.CustomResourceDefinitionVersion{
+				{Name: "v1", Served: true, Storage: true, Schema: schema, Subresources: &apiextensions.CustomResourceSubresources{
+					Status: &apiextensions.CustomResourceSubresourceStatus{},
+					Custom: []apiextensions.CustomResourceSubresourceCustom{{Name: "approve", FieldPaths: []string{".spec.approved"}}},
+				}},
+				{Name: "v1beta1", Served: true, Deprecated: true, DeprecationWarning: &warning},
+			},
+			Conversion: &apiextensions.CustomResourceConversion{
+				Strategy:                 apiextensions.WebhookConverter,
+				ConversionReviewVersions: []string{"v1beta1"},
+				FailurePolicy:            &ignore,
+			},
+		},
+		Status: apiextensions.CustomResourceDefinitionStatus{
+			Conditions: []apiextensions.CustomResourceDefinitionCondition{{Type: apiextensions.Established, Status: apiextensions.ConditionTrue}},
+		},
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	indexer.Add(crd)
+	hooks := NewCustomResourceHooks()
+	hooks.RegisterValidator("example.com", nil)
+	h := &crdServingHandler{
+		crdLister: listers.NewCustomResourceDefinitionLister(indexer),
+		admission: hooks,
+		hooks:     hooks,
+	}
+
+	tests := []struct {
+		path     string
+		code     int
+		expected *crdServingReport
+	}{
+		{crdServingPath + "/example.com/v1/foos", http.StatusOK, &crdServingReport{
+			CustomResourceDefinition: "foos.example.com",
+			Kind:                     "Foo",
+			Scope:                    "Namespaced",
+			Established:              true,
+			Served:                   true,
+			Storage:                  true,
+			StorageVersion:           "v1",
+			Schema:                   crdServingSchema{Source: "spec.versions[0].schema.openAPIV3Schema"},
+			Conversion:               crdServingConversion{Strategy: "Webhook", ConversionReviewVersions: []string{"v1beta1"}, FailurePolicy: "Ignore"},
+			Subresources:             []string{"status", "approve"},
+			Admission:                crdServingAdmission{Operations: []admission.Operation{admission.Create, admission.Update}, Validators: 1},
+		}},
+		{crdServingPath + "/example.com/v1beta1/foos", http.StatusOK, &crdServingReport{
+			CustomResourceDefinition: "foos.example.com",
+			Kind:                     "Foo",
+			Scope:                    "Namespaced",
+			Established:              true,
+			Served:                   true,
+			StorageVersion:           "v1",
+			DeprecationWarning:       "use v1",
+			Conversion:               crdServingConversion{Strategy: "Webhook", ConversionReviewVersions: []string{"v1beta1"}, FailurePolicy: "Ignore"},
+			Subresources:             []string{},
+			Admission:                crdServingAdmission{Operations: []admission.Operation{admission.Create, admission.Update}, Validators: 1},
+		}},
+		{crdServingPath + "/example.com/v2/foos", http.StatusNotFound, nil},
+		{crdServingPath + "/example.com/v1/bars", http.StatusNotFound, nil},
+		{crdServingPath + "/example.com/v1", http.StatusNotFound, nil},
+	}
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", tc.path, nil))
+		if w.Code != tc.code {
+			t.Errorf("%s: expected status %d, got %d", tc.path, tc.code, w.Code)
+			continue
+		}
+		if tc.expected == nil {
+			continue
+		}
+		actual := &crdServingReport{}
+		if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
+			t.Fatalf("%s: %v", tc.path, err)
+		}
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%s: expected %#v, got %#v", tc.path, tc.expected, actual)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", crdServingPath+"/example.com/v1/foos", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status %d for POST, got %d", http.StatusMethodNotAllowed, w.Code)
+	}
+}
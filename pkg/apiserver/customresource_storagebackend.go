@@ -0,0 +1,152 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/registry/generic"
+	"k8s.io/apiserver/pkg/storage"
+	"k8s.io/apiserver/pkg/storage/etcd"
+	"k8s.io/apiserver/pkg/storage/storagebackend"
+	"k8s.io/apiserver/pkg/storage/storagebackend/factory"
+)
+
+// storageBackendType is the storage type of custom resources stored by a CustomResourceStorageBackend.
+const storageBackendType = "apiextensions.k8s.io/storage-backend"
+
+// CustomResourceStorageBackend stores custom resources in a backend other than etcd, e.g. a SQL
+// database or memory. Embedding servers plug in backends for the custom resources of individual API
+// groups, e.g. for lightweight control planes without etcd.
+type CustomResourceStorageBackend interface {
+	// NewStorage returns the storage of the custom resources of a version of a CustomResourceDefinition,
+	// and a function releasing it when the CustomResourceDefinition changes or is deleted. The storage
+	// is not wrapped in a watch cache.
+	NewStorage(config CustomResourceStorageConfig) (storage.Interface, factory.DestroyFunc, error)
+}
+
+// CustomResourceStorageConfig configures the storage of the custom resources of a version of a
+// CustomResourceDefinition.
+type CustomResourceStorageConfig struct {
+	// Resource is the group and plural name of the custom resources.
+	Resource schema.GroupResource
+	// Codec encodes the custom resources in the storage version and decodes them into the served version.
+	// Storing the encoded custom resources keeps the conversion of the CustomResourceDefinition.
+	Codec runtime.Codec
+	// Copier deep copies the custom resources.
+	Copier runtime.ObjectCopier
+	// ResourcePrefix is the key prefix of the custom resources, which the keys of KeyFunc start with.
+	ResourcePrefix string
+	// KeyFunc returns the key of a custom resource.
+	KeyFunc func(obj runtime.Object) (string, error)
+	// NewListFunc returns an empty list of custom resources.
+	NewListFunc func() runtime.Object
+	// GetAttrsFunc returns the labels and fields of a custom resource which selectors match.
+	GetAttrsFunc storage.AttrFunc
+}
+
+// storageBackendDecorator returns a storage decorator creating the storage of the custom resources of
+// resource with the backend.
+func storageBackendDecorator(resource schema.GroupResource, backend CustomResourceStorageBackend) generic.StorageDecorator {
+	return func(
+		copier runtime.ObjectCopier,
+		config *storagebackend.Config,
+		requestedSize *int,
+		objectType runtime.Object,
+		resourcePrefix string,
+		keyFunc func(obj runtime.Object) (string, error),
+		newListFunc func() runtime.Object,
+		getAttrsFunc storage.AttrFunc,
+		triggerFunc storage.TriggerPublisherFunc) (storage.Interface, factory.DestroyFunc) {
+
+		s, destroy, err := backend.NewStorage(CustomResourceStorageConfig{
+			Resource:       resource,
+			Codec:          config.Codec,
+			Copier:         copier,
+			ResourcePrefix: resourcePrefix,
+			KeyFunc:        keyFunc,
+			NewListFunc:    newListFunc,
+			GetAttrsFunc:   getAttrsFunc,
+		})
+		if err != nil {
+			// storage decorators cannot return errors, the error is returned when the storage is checked
+			// with storageError
+			return failedStorage{err: fmt.Errorf("unable to create the storage of %v: %v", resource, err)}, func() {}
+		}
+		if destroy == nil {
+			destroy = func() {}
+		}
+		return s, destroy
+	}
+}
+
+// storageError returns the error of storage which a storage backend failed to create, or nil.
+func storageError(s storage.Interface) error {
+	if failed, ok := s.(failedStorage); ok {
+		return failed.err
+	}
+	return nil
+}
+
+// failedStorage is the storage of a storage backend which failed to create it. It is not served
+// from, getServingInfoFor returns its error instead. It fails all its calls with the error all the same.
+type failedStorage struct {
+	err error
+}
+
+var _ storage.Interface = failedStorage{}
+
+func (s failedStorage) Versioner() storage.Versioner {
+	return etcd.APIObjectVersioner{}
+}
+
+func (s failedStorage) Create(ctx context.Context, key string, obj, out runtime.Object, ttl uint64) error {
+	return s.err
+}
+
+func (s failedStorage) Delete(ctx context.Context, key string, out runtime.Object, preconditions *storage.Preconditions) error {
+	return s.err
+}
+
+func (s failedStorage) Watch(ctx context.Context, key string, resourceVersion string, p storage.SelectionPredicate) (watch.Interface, error) {
+	return nil, s.err
+}
+
+func (s failedStorage) WatchList(ctx context.Context, key string, resourceVersion string, p storage.SelectionPredicate) (watch.Interface, error) {
+	return nil, s.err
+}
+
+func (s failedStorage) Get(ctx context.Context, key string, resourceVersion string, objPtr runtime.Object, ignoreNotFound bool) error {
+	return s.err
+}
+
+func (s failedStorage) GetToList(ctx context.Context, key string, resourceVersion string, p storage.SelectionPredicate, listObj runtime.Object) error {
+	return s.err
+}
+
+func (s failedStorage) List(ctx context.Context, key string, resourceVersion string, p storage.SelectionPredicate, listObj runtime.Object) error {
+	return s.err
+}
+
+func (s failedStorage) GuaranteedUpdate(ctx context.Context, key string, ptrToType runtime.Object, ignoreNotFound bool, preconditions *storage.Preconditions, tryUpdate storage.UpdateFunc, suggestion ...runtime.Object) error {
+	return s.err
+}
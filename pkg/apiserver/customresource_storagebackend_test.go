@@ -0,0 +1,127 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/registry/generic"
+	"k8s.io/apiserver/pkg/storage"
+	"k8s.io/apiserver/pkg/storage/storagebackend"
+	"k8s.io/apiserver/pkg/storage/storagebackend/factory"
+)
+
+// recordingStorageBackend records the configs of the storages it creates.
+type recordingStorageBackend struct {
+	configs []CustomResourceStorageConfig
+}
+
+func (b *recordingStorageBackend) NewStorage(config CustomResourceStorageConfig) (storage.Interface, factory.DestroyFunc, error) {
+	b.configs = append(b.configs, config)
+	return nil, nil, nil
+}
+
+func TestCRDRESTOptionsGetterStorageBackends(t *testing.T) {
+	backend := &recordingStorageBackend{}
+	getter := CRDRESTOptionsGetter{
+		EnableWatchCache:      true,
+		DefaultWatchCacheSize: 100,
+		StorageBackends:       map[string]CustomResourceStorageBackend{"sql.example.com": backend},
+	}
+	getter.StorageConfig.Codec = unstructured.UnstructuredJSONScheme
+	resource := schema.GroupResource{Group: "sql.example.com", Resource: "noxus"}
+
+	tests := []struct {
+		name   string
+		getter generic.RESTOptionsGetter
+	}{
+		{name: "cached", getter: getter},
+		{name: "undecorated", getter: undecoratedRESTOptionsGetter{getter}},
+		{name: "storage prefix", getter: crdStorageRESTOptionsGetter{RESTOptionsGetter: getter, resourcePrefix: "custom/noxus"}},
+	}
+	for _, tc := range tests {
+		backend.configs = nil
+		options, err := tc.getter.GetRESTOptions(resource)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		keyFunc := func(obj runtime.Object) (string, error) { return "", nil }
+		_, destroy := options.Decorator(UnstructuredCopier{}, options.StorageConfig, nil, &unstructured.Unstructured{}, options.ResourcePrefix, keyFunc, nil, nil, nil)
+		destroy()
+		if len(backend.configs) != 1 {
+			t.Errorf("%s: expected the storage backend to create one storage, got %d", tc.name, len(backend.configs))
+			continue
+		}
+		config := backend.configs[0]
+		if config.Resource != resource {
+			t.Errorf("%s: expected resource %v, got %v", tc.name, resource, config.Resource)
+		}
+		if config.ResourcePrefix != options.ResourcePrefix {
+			t.Errorf("%s: expected resource prefix %q, got %q", tc.name, options.ResourcePrefix, config.ResourcePrefix)
+		}
+		if config.Codec != unstructured.UnstructuredJSONScheme {
+			t.Errorf("%s: expected the codec of the storage config, got %v", tc.name, config.Codec)
+		}
+	}
+
+	// other groups are stored in etcd
+	options, err := getter.GetRESTOptions(schema.GroupResource{Group: "mygroup.example.com", Resource: "noxus"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if options.StorageConfig.Type == storageBackendType {
+		t.Errorf("expected other groups not to use the storage backend")
+	}
+}
+
+// failingStorageBackend fails to create storage.
+type failingStorageBackend struct{}
+
+func (failingStorageBackend) NewStorage(config CustomResourceStorageConfig) (storage.Interface, factory.DestroyFunc, error) {
+	return nil, nil, fmt.Errorf("backend unavailable")
+}
+
+func TestStorageBackendDecoratorError(t *testing.T) {
+	resource := schema.GroupResource{Group: "sql.example.com", Resource: "noxus"}
+	decorator := storageBackendDecorator(resource, failingStorageBackend{})
+	config := &storagebackend.Config{Codec: unstructured.UnstructuredJSONScheme}
+	keyFunc := func(obj runtime.Object) (string, error) { return "", nil }
+	s, destroy := decorator(UnstructuredCopier{}, config, nil, &unstructured.Unstructured{}, "sql.example.com/noxus", keyFunc, nil, nil, nil)
+	destroy()
+
+	err := storageError(s)
+	if err == nil || !strings.Contains(err.Error(), "backend unavailable") {
+		t.Fatalf("expected the error of the storage backend, got %v", err)
+	}
+	if getErr := s.Get(context.TODO(), "sql.example.com/noxus/foo", "", &unstructured.Unstructured{}, false); getErr != err {
+		t.Errorf("expected the failed storage to fail its calls with %v, got %v", err, getErr)
+	}
+
+	// the storage of working backends has no error
+	s, _ = storageBackendDecorator(resource, &recordingStorageBackend{})(UnstructuredCopier{}, config, nil, &unstructured.Unstructured{}, "sql.example.com/noxus", keyFunc, nil, nil, nil)
+	if err := storageError(s); err != nil {
+		t.Errorf("expected no error of the storage of a working backend, got %v", err)
+	}
+}
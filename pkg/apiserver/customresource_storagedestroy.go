@@ -0,0 +1,150 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+
+	"k8s.io/apiextensions-apiserver/pkg/registry/customresource"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+)
+
+// storageDestroyTimeout bounds how long replaced storage waits for the requests served from it before
+// it is destroyed. It matches the timeout of non-long-running requests, hence it is only reached by
+// watches, which end when the storage is destroyed.
+const storageDestroyTimeout = time.Minute
+
+// storageRequests counts the requests served from the storage of a CustomResourceDefinition, such
+// that replaced storage is destroyed only after they finished.
+type storageRequests struct {
+	lock    sync.Mutex
+	closed  bool
+	serving sync.WaitGroup
+}
+
+// add counts a request. It returns false if the storage is being destroyed.
+func (s *storageRequests) add() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return false
+	}
+	s.serving.Add(1)
+	return true
+}
+
+// done marks a request counted by add as finished.
+func (s *storageRequests) done() {
+	s.serving.Done()
+}
+
+// close rejects new requests and waits up to the given timeout for the counted ones to finish.
+func (s *storageRequests) close(timeout time.Duration) {
+	s.lock.Lock()
+	s.closed = true
+	s.lock.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.serving.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+	}
+}
+
+// destroy stops the watch caches and closes the storage clients of the served versions and of the
+// lister of the persisted versions, after stopping the counter watching the latter. The storages of
+// the subresources share them with the main storage.
+func (info *crdInfo) destroy() {
+	if info.stopCounter != nil {
+		close(info.stopCounter)
+	}
+	for _, storage := range info.storages {
+		if storage.CustomResource != nil && storage.CustomResource.DestroyFunc != nil {
+			storage.CustomResource.DestroyFunc()
+		}
+	}
+	if info.persisted != nil && info.persisted.DestroyFunc != nil {
+		info.persisted.DestroyFunc()
+	}
+}
+
+// destroyStorage destroys storage which was replaced or removed in the background, once the requests
+// served from it finished.
+func destroyStorage(name string, info *crdInfo) {
+	go func() {
+		info.requests.close(storageDestroyTimeout)
+		glog.V(4).Infof("Destroying the storage of customresourcedefinition %s", name)
+		info.destroy()
+	}()
+}
+
+// newStorageReplacedError returns the error of a request which found the storage of the
+// CustomResourceDefinition just as it was replaced.
+func newStorageReplacedError() *apierrors.StatusError {
+	return apierrors.NewServiceUnavailable("the storage of the resource was replaced, please retry")
+}
+
+// countedStorage is the storage of the custom resources used by the controllers, which counts each
+// call as a request of the storage, like requests served by the handler.
+type countedStorage struct {
+	info    *crdInfo
+	storage *customresource.REST
+}
+
+func (s countedStorage) NewList() runtime.Object {
+	return s.storage.NewList()
+}
+
+func (s countedStorage) List(ctx apirequest.Context, options *metainternalversion.ListOptions) (runtime.Object, error) {
+	if !s.info.requests.add() {
+		return nil, newStorageReplacedError()
+	}
+	defer s.info.requests.done()
+	return s.storage.List(ctx, options)
+}
+
+func (s countedStorage) DeleteCollection(ctx apirequest.Context, options *metav1.DeleteOptions, listOptions *metainternalversion.ListOptions) (runtime.Object, error) {
+	if !s.info.requests.add() {
+		return nil, newStorageReplacedError()
+	}
+	defer s.info.requests.done()
+	return s.storage.DeleteCollection(ctx, options, listOptions)
+}
+
+func (s countedStorage) New() runtime.Object {
+	return s.storage.New()
+}
+
+func (s countedStorage) Update(ctx apirequest.Context, name string, objInfo rest.UpdatedObjectInfo) (runtime.Object, bool, error) {
+	if !s.info.requests.add() {
+		return nil, false, newStorageReplacedError()
+	}
+	defer s.info.requests.done()
+	return s.storage.Update(ctx, name, objInfo)
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"sync"
+
+	"github.com/hashicorp/golang-lru"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// storedJSONEntries and storedJSONBytes bound the stored JSON which is kept to serve custom
+// resources without encoding them again: the number of entries, and the total size of the JSON and
+// of the decoded content the entries hold on to.
+const (
+	storedJSONEntries = 10000
+	storedJSONBytes   = 64 << 20
+)
+
+// storedJSON keeps the JSON of custom resources as they were read from storage. Reads which need no
+// conversion only change the metadata of the decoded objects, e.g. the resourceVersion and the
+// selfLink, so JSON responses pass the stored JSON through with the current metadata instead of
+// encoding the whole object, see encodeStoredJSON.
+//
+// The JSON is kept by the identity of the decoded content, which the watch cache shares with the
+// objects it lists. The entries hold on to the content, so its address is not reused meanwhile.
+// Decoded content must not be changed in place other than its metadata unless it is written to
+// storage, which drops its entry.
+var storedJSON = newStoredJSONCache(storedJSONEntries, storedJSONBytes)
+
+type storedJSONCache struct {
+	lock     sync.Mutex
+	entries  *lru.Cache
+	bytes    int
+	maxBytes int
+}
+
+type storedJSONEntry struct {
+	content map[string]interface{}
+	data    []byte
+	// size is the size of data and the estimated size of content.
+	size int
+}
+
+func newStoredJSONCache(maxEntries, maxBytes int) *storedJSONCache {
+	c := &storedJSONCache{maxBytes: maxBytes}
+	// this never fails for a positive size
+	c.entries, _ = lru.NewWithEvict(maxEntries, func(key, value interface{}) {
+		c.bytes -= value.(storedJSONEntry).size
+	})
+	return c
+}
+
+// add keeps data, the stored JSON of the decoded content. data must not be changed afterwards.
+func (c *storedJSONCache) add(content map[string]interface{}, data []byte) {
+	if len(data) > c.maxBytes {
+		return
+	}
+	size := len(data) + decodedSize(content)
+	if size > c.maxBytes {
+		return
+	}
+	key := reflect.ValueOf(content).Pointer()
+	entry := storedJSONEntry{content: content, data: data, size: size}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries.Remove(key)
+	c.entries.Add(key, entry)
+	c.bytes += entry.size
+	for c.bytes > c.maxBytes {
+		c.entries.RemoveOldest()
+	}
+}
+
+// get returns the stored JSON of the decoded content.
+func (c *storedJSONCache) get(content map[string]interface{}) ([]byte, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	value, ok := c.entries.Get(reflect.ValueOf(content).Pointer())
+	if !ok {
+		return nil, false
+	}
+	return value.(storedJSONEntry).data, true
+}
+
+// remove drops the stored JSON of the decoded content.
+func (c *storedJSONCache) remove(content map[string]interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries.Remove(reflect.ValueOf(content).Pointer())
+}
+
+// decodedSize roughly estimates the memory of decoded JSON content, i.e. of the maps, slices, strings
+// and interface values holding it.
+func decodedSize(v interface{}) int {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		size := 48
+		for k, v := range t {
+			size += 32 + len(k) + decodedSize(v)
+		}
+		return size
+	case []interface{}:
+		size := 24
+		for _, v := range t {
+			size += 16 + decodedSize(v)
+		}
+		return size
+	case string:
+		return 16 + len(t)
+	default:
+		return 8
+	}
+}
+
+// storedJSONCodec keeps the JSON of the custom resources it decodes from storage in storedJSON, and
+// drops it when they are written.
+type storedJSONCodec struct {
+	runtime.Codec
+}
+
+func (c storedJSONCodec) Decode(data []byte, defaults *schema.GroupVersionKind, into runtime.Object) (runtime.Object, *schema.GroupVersionKind, error) {
+	obj, gvk, err := c.Codec.Decode(data, defaults, into)
+	// the storage does not reuse the data it decodes, so it is kept without copying
+	if u, ok := obj.(*unstructured.Unstructured); ok && err == nil && len(data) > 0 && data[0] == '{' {
+		storedJSON.add(u.Object, data)
+	}
+	return obj, gvk, err
+}
+
+func (c storedJSONCodec) Encode(obj runtime.Object, w io.Writer) error {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		storedJSON.remove(u.Object)
+	}
+	return c.Codec.Encode(obj, w)
+}
+
+// encodeStoredJSON returns the stored JSON of u with its current apiVersion, kind and metadata. It
+// returns false if u was not decoded from storage as it is, e.g. because it was converted to another
+// version.
+func encodeStoredJSON(u *unstructured.Unstructured) ([]byte, bool, error) {
+	data, ok := storedJSON.get(u.Object)
+	if !ok {
+		return nil, false, nil
+	}
+	stored := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &stored); err != nil || len(stored) != len(u.Object) {
+		return nil, false, nil
+	}
+	var apiVersion string
+	if err := json.Unmarshal(stored["apiVersion"], &apiVersion); err != nil || apiVersion != u.GetAPIVersion() {
+		return nil, false, nil
+	}
+	for k, v := range u.Object {
+		if _, ok := stored[k]; !ok {
+			return nil, false, nil
+		}
+		switch k {
+		case "apiVersion", "kind", "metadata":
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, false, err
+			}
+			stored[k] = encoded
+		}
+	}
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+// encodeUnstructuredJSON encodes custom resources like unstructured.UnstructuredJSONScheme, passing
+// their stored JSON through if they are served as they were read from storage.
+func encodeUnstructuredJSON(obj runtime.Object, w io.Writer) error {
+	switch t := obj.(type) {
+	case *unstructured.Unstructured:
+		data, ok, err := encodeStoredJSON(t)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return unstructured.UnstructuredJSONScheme.Encode(obj, w)
+		}
+		_, err = w.Write(append(data, '\n'))
+		return err
+	case *unstructured.UnstructuredList:
+		items := make([]json.RawMessage, 0, len(t.Items))
+		for i := range t.Items {
+			data, ok, err := encodeStoredJSON(&t.Items[i])
+			if err == nil && !ok {
+				data, err = json.Marshal(t.Items[i].Object)
+			}
+			if err != nil {
+				return err
+			}
+			items = append(items, data)
+		}
+		content := make(map[string]interface{}, len(t.Object)+1)
+		for k, v := range t.Object {
+			content[k] = v
+		}
+		content["items"] = items
+		return json.NewEncoder(w).Encode(content)
+	}
+	return unstructured.UnstructuredJSONScheme.Encode(obj, w)
+}
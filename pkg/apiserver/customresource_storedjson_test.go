@@ -0,0 +1,161 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"bytes"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestStoredJSONPassthrough(t *testing.T) {
+	stored := `{"apiVersion":"mygroup.example.com/v1beta1","kind":"Noxu","metadata":{"name":"foo","uid":"1234"},"spec":{"weight":1.50}}`
+	decode := func() *unstructured.Unstructured {
+		obj, err := runtime.Decode(storedJSONCodec{unstructured.UnstructuredJSONScheme}, []byte(stored))
+		if err != nil {
+			t.Fatal(err)
+		}
+		u := obj.(*unstructured.Unstructured)
+		// set by the storage and the handler after decoding
+		u.SetResourceVersion("42")
+		u.SetSelfLink("/apis/mygroup.example.com/v1beta1/noxus/foo")
+		return u
+	}
+	passedThrough := `{"apiVersion":"mygroup.example.com/v1beta1","kind":"Noxu","metadata":{"name":"foo","resourceVersion":"42","selfLink":"/apis/mygroup.example.com/v1beta1/noxus/foo","uid":"1234"},"spec":{"weight":1.50}}` + "\n"
+	encoded := `{"apiVersion":"mygroup.example.com/v1beta1","kind":"Noxu","metadata":{"name":"foo","resourceVersion":"42","selfLink":"/apis/mygroup.example.com/v1beta1/noxus/foo","uid":"1234"},"spec":{"weight":1.5}}` + "\n"
+
+	tests := []struct {
+		name     string
+		modify   func(u *unstructured.Unstructured)
+		expected string
+	}{
+		{name: "unmodified", expected: passedThrough},
+		{
+			name:     "converted",
+			modify:   func(u *unstructured.Unstructured) { u.SetAPIVersion("mygroup.example.com/v1") },
+			expected: `{"apiVersion":"mygroup.example.com/v1","kind":"Noxu","metadata":{"name":"foo","resourceVersion":"42","selfLink":"/apis/mygroup.example.com/v1beta1/noxus/foo","uid":"1234"},"spec":{"weight":1.5}}` + "\n",
+		},
+		{
+			name:     "replaced content",
+			modify:   func(u *unstructured.Unstructured) { u.Object = u.DeepCopy().Object },
+			expected: encoded,
+		},
+		{
+			name: "added field",
+			modify: func(u *unstructured.Unstructured) {
+				u.Object["status"] = map[string]interface{}{}
+			},
+			expected: `{"apiVersion":"mygroup.example.com/v1beta1","kind":"Noxu","metadata":{"name":"foo","resourceVersion":"42","selfLink":"/apis/mygroup.example.com/v1beta1/noxus/foo","uid":"1234"},"spec":{"weight":1.5},"status":{}}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		u := decode()
+		if tt.modify != nil {
+			tt.modify(u)
+		}
+		buf := &bytes.Buffer{}
+		if err := crEncoderInstance.Encode(u, buf); err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if buf.String() != tt.expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", tt.name, tt.expected, buf.String())
+		}
+	}
+
+	list := &unstructured.UnstructuredList{Object: map[string]interface{}{"apiVersion": "mygroup.example.com/v1beta1", "kind": "NoxuList"}}
+	replaced := decode()
+	replaced.Object = replaced.DeepCopy().Object
+	list.Items = []unstructured.Unstructured{*decode(), *replaced}
+	buf := &bytes.Buffer{}
+	if err := crEncoderInstance.Encode(list, buf); err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"apiVersion":"mygroup.example.com/v1beta1","items":[` + passedThrough[:len(passedThrough)-1] + "," + encoded[:len(encoded)-1] + `],"kind":"NoxuList"}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("expected list\n%s\ngot\n%s", expected, buf.String())
+	}
+}
+
+func TestStoredJSONDroppedOnWrite(t *testing.T) {
+	codec := storedJSONCodec{unstructured.UnstructuredJSONScheme}
+	obj, err := runtime.Decode(codec, []byte(`{"apiVersion":"mygroup.example.com/v1beta1","kind":"Noxu","metadata":{"name":"foo"},"spec":{"weight":1.50}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := obj.(*unstructured.Unstructured)
+	u.Object["spec"].(map[string]interface{})["weight"] = int64(2)
+	if _, err := runtime.Encode(codec, u); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := crEncoderInstance.Encode(u, buf); err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"apiVersion":"mygroup.example.com/v1beta1","kind":"Noxu","metadata":{"name":"foo"},"spec":{"weight":2}}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+}
+
+func TestStoredJSONCacheSize(t *testing.T) {
+	contents := []map[string]interface{}{{}, {}, {}}
+	entrySize := 10 + decodedSize(contents[0])
+	c := newStoredJSONCache(10, 2*entrySize+1)
+	for _, content := range contents {
+		c.add(content, []byte("0123456789"))
+	}
+	if _, ok := c.get(contents[0]); ok {
+		t.Errorf("expected the oldest entry to be evicted")
+	}
+	for _, content := range contents[1:] {
+		if _, ok := c.get(content); !ok {
+			t.Errorf("expected the newer entries to be kept")
+		}
+	}
+	if c.bytes != 2*entrySize {
+		t.Errorf("expected %d bytes, got %d", 2*entrySize, c.bytes)
+	}
+
+	c.remove(contents[1])
+	if _, ok := c.get(contents[1]); ok {
+		t.Errorf("expected the removed entry to be dropped")
+	}
+	if c.bytes != entrySize {
+		t.Errorf("expected %d bytes, got %d", entrySize, c.bytes)
+	}
+}
+
+func TestStoredJSONCacheEntries(t *testing.T) {
+	c := newStoredJSONCache(2, 1<<20)
+	contents := []map[string]interface{}{{}, {}, {}}
+	for _, content := range contents {
+		c.add(content, []byte("0123456789"))
+	}
+	if c.entries.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", c.entries.Len())
+	}
+	if _, ok := c.get(contents[0]); ok {
+		t.Errorf("expected the oldest entry to be evicted")
+	}
+	if expected := 2 * (10 + decodedSize(contents[0])); c.bytes != expected {
+		t.Errorf("expected %d bytes, got %d", expected, c.bytes)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/audit"
+	"k8s.io/apiserver/pkg/endpoints/handlers"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/strategicmerge"
+)
+
+// isStrategicMergePatchRequest returns true if req is a strategic merge patch.
+func isStrategicMergePatchRequest(req *http.Request) bool {
+	contentType := req.Header.Get("Content-Type")
+	// Remove "; charset=" if included in header.
+	if idx := strings.Index(contentType, ";"); idx > 0 {
+		contentType = contentType[:idx]
+	}
+	return types.PatchType(contentType) == types.StrategicMergePatchType
+}
+
+// strategicMergePatchResource returns a handler which applies the strategic merge patch in the request
+// body to the custom resource, using the merge strategies declared by the validation schema.
+func strategicMergePatchResource(r rest.Updater, scope handlers.RequestScope, schema *apiextensions.JSONSchemaProps, admit admission.Interface) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		ctx := scope.ContextFunc(req)
+		writeError := func(err error) {
+			responsewriters.ErrorNegotiated(ctx, err, scope.Serializer, scope.Kind.GroupVersion(), w, req)
+		}
+
+		namespace, name, err := scope.Namer.Name(req)
+		if err != nil {
+			writeError(err)
+			return
+		}
+		ctx = apirequest.WithNamespace(ctx, namespace)
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			writeError(err)
+			return
+		}
+		audit.LogRequestPatch(apirequest.AuditEventFrom(ctx), body)
+
+		patch := map[string]interface{}{}
+		if err := utiljson.Unmarshal(body, &patch); err != nil {
+			writeError(apierrors.NewBadRequest(fmt.Sprintf("error decoding patch: %v", err)))
+			return
+		}
+
+		userInfo, _ := apirequest.UserFrom(ctx)
+
+		// the patch is applied to the current object again on conflicts
+		applyPatch := func(_ apirequest.Context, _, currentObject runtime.Object) (runtime.Object, error) {
+			current, ok := currentObject.(*unstructured.Unstructured)
+			if !ok {
+				return nil, fmt.Errorf("unexpected object type %T", currentObject)
+			}
+			if len(current.GetUID()) == 0 {
+				return nil, apierrors.NewNotFound(scope.Resource.GroupResource(), name)
+			}
+			patched, err := strategicmerge.Patch(current.UnstructuredContent(), patch, schema)
+			if err != nil {
+				return nil, apierrors.NewBadRequest(fmt.Sprintf("error applying strategic merge patch: %v", err))
+			}
+			obj := &unstructured.Unstructured{Object: patched}
+			if gvk := obj.GroupVersionKind(); gvk != scope.Kind {
+				return nil, apierrors.NewBadRequest(fmt.Sprintf("the kind of the patched object (%v) does not match the expected kind %v", gvk, scope.Kind))
+			}
+			if obj.GetName() != name {
+				return nil, apierrors.NewBadRequest(fmt.Sprintf("the name of the patched object (%s) does not match the name of the request (%s)", obj.GetName(), name))
+			}
+			if obj.GetNamespace() != namespace {
+				return nil, apierrors.NewBadRequest(fmt.Sprintf("the namespace of the patched object (%s) does not match the namespace of the request (%s)", obj.GetNamespace(), namespace))
+			}
+			return obj, nil
+		}
+		admitUpdate := func(_ apirequest.Context, updatedObject, currentObject runtime.Object) (runtime.Object, error) {
+			if admit != nil && admit.Handles(admission.Update) {
+				return updatedObject, admit.Admit(admission.NewAttributesRecord(updatedObject, currentObject, scope.Kind, namespace, name, scope.Resource, scope.Subresource, admission.Update, userInfo))
+			}
+			return updatedObject, nil
+		}
+
+		result, _, err := r.Update(ctx, name, rest.DefaultUpdatedObjectInfo(nil, scope.Copier, applyPatch, admitUpdate))
+		if err != nil {
+			writeError(err)
+			return
+		}
+
+		if requestInfo, ok := apirequest.RequestInfoFrom(ctx); ok {
+			if uri, err := scope.Namer.GenerateLink(requestInfo, result); err == nil {
+				if err := scope.Namer.SetSelfLink(result, uri); err != nil {
+					writeError(err)
+					return
+				}
+			}
+		}
+
+		responsewriters.WriteObject(ctx, http.StatusOK, scope.Kind.GroupVersion(), scope.Serializer, result, w, req)
+	}
+}
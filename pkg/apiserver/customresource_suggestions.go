@@ -0,0 +1,98 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+// maxSuggestionDistance is the largest edit distance of a requested resource to the names of a
+// CustomResourceDefinition for which the resource is suggested.
+const maxSuggestionDistance = 2
+
+// resourceSuggestions returns the sorted plural names of the established CustomResourceDefinitions of
+// the group whose plural, singular, short names or kind are similar to the requested resource.
+func resourceSuggestions(crds []*apiextensions.CustomResourceDefinition, group, resource string) []string {
+	resource = strings.ToLower(resource)
+	suggestions := sets.NewString()
+	for _, crd := range crds {
+		if crd.Spec.Group != group || !apiextensions.IsCRDConditionTrue(crd, apiextensions.Established) {
+			continue
+		}
+		names := crd.Status.AcceptedNames
+		candidates := append([]string{names.Plural, names.Singular, strings.ToLower(names.Kind)}, names.ShortNames...)
+		for _, candidate := range candidates {
+			if len(candidate) == 0 {
+				continue
+			}
+			// short names only match similar resources of a comparable length
+			if d := editDistance(resource, candidate); d <= maxSuggestionDistance && d <= len(candidate)/3 {
+				suggestions.Insert(names.Plural)
+				break
+			}
+		}
+	}
+	return suggestions.List()
+}
+
+// editDistance returns the Levenshtein distance of a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			// deletion, insertion and substitution
+			current[j] = previous[j] + 1
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+			substitution := previous[j-1]
+			if a[i-1] != b[j-1] {
+				substitution++
+			}
+			if substitution < current[j] {
+				current[j] = substitution
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// writeResourceNotFoundError responds with a NotFound status suggesting the resources with names
+// similar to the requested resource, which does not exist in a group of CustomResourceDefinitions.
+func writeResourceNotFoundError(ctx apirequest.Context, w http.ResponseWriter, req *http.Request, requestInfo *apirequest.RequestInfo, suggestions []string) {
+	gv := schema.GroupVersion{Group: requestInfo.APIGroup, Version: requestInfo.APIVersion}
+	gr := schema.GroupResource{Group: requestInfo.APIGroup, Resource: requestInfo.Resource}
+	err := apierrors.NewNotFound(gr, requestInfo.Name)
+	err.ErrStatus.Message = fmt.Sprintf("the server could not find the requested resource %s, did you mean %s?", gr.String(), strings.Join(suggestions, ", "))
+	responsewriters.ErrorNegotiated(ctx, err, Codecs, gv, w, req)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+func newSuggestionTestCRD(group, plural, singular, kind string, shortNames []string, established bool) *apiextensions.CustomResourceDefinition {
+	crd := &apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: plural + "." + group},
+		Spec:       apiextensions.CustomResourceDefinitionSpec{Group: group},
+		Status: apiextensions.CustomResourceDefinitionStatus{
+			AcceptedNames: apiextensions.CustomResourceDefinitionNames{Plural: plural, Singular: singular, Kind: kind, ShortNames: shortNames},
+		},
+	}
+	if established {
+		crd.Status.Conditions = []apiextensions.CustomResourceDefinitionCondition{{Type: apiextensions.Established, Status: apiextensions.ConditionTrue}}
+	}
+	return crd
+}
+
+func TestResourceSuggestions(t *testing.T) {
+	crds := []*apiextensions.CustomResourceDefinition{
+		newSuggestionTestCRD("mygroup.example.com", "noxus", "noxu", "WishIHadChosenNoxu", []string{"nx"}, true),
+		newSuggestionTestCRD("mygroup.example.com", "noxes", "noxe", "Noxe", nil, true),
+		newSuggestionTestCRD("mygroup.example.com", "curlets", "curlet", "Curlet", []string{"cl"}, true),
+		newSuggestionTestCRD("mygroup.example.com", "pending", "pending", "Pending", nil, false),
+		newSuggestionTestCRD("other.example.com", "noxis", "noxi", "Noxi", nil, true),
+	}
+
+	tests := []struct {
+		name     string
+		group    string
+		resource string
+		expected []string
+	}{
+		{"misspelled plural", "mygroup.example.com", "curlts", []string{"curlets"}},
+		{"singular", "mygroup.example.com", "curlet", []string{"curlets"}},
+		{"kind", "mygroup.example.com", "WishIHadChosenNoxu", []string{"noxus"}},
+		{"several matches", "mygroup.example.com", "noxu", []string{"noxes", "noxus"}},
+		{"short name", "mygroup.example.com", "nx", []string{"noxus"}},
+		{"similar short name", "mygroup.example.com", "ny", nil},
+		{"not established", "mygroup.example.com", "pendings", nil},
+		{"other group", "unknown.example.com", "noxu", nil},
+		{"unrelated", "mygroup.example.com", "widgets", nil},
+	}
+	for _, tc := range tests {
+		actual := resourceSuggestions(crds, tc.group, tc.resource)
+		if len(actual) == 0 && len(tc.expected) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, actual)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"noxus", "noxus", 0},
+		{"noxu", "noxus", 1},
+		{"curlts", "curlets", 1},
+		{"noxus", "nuxos", 2},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+	}
+	for _, tc := range tests {
+		if actual := editDistance(tc.a, tc.b); actual != tc.expected {
+			t.Errorf("%q, %q: expected %d, got %d", tc.a, tc.b, tc.expected, actual)
+		}
+	}
+}
+
+func TestWriteResourceNotFoundError(t *testing.T) {
+	req := httptest.NewRequest("GET", "/apis/mygroup.example.com/v1beta1/namespaces/default/noxu/foo", nil)
+	requestInfo := &apirequest.RequestInfo{APIGroup: "mygroup.example.com", APIVersion: "v1beta1", Resource: "noxu", Name: "foo"}
+	w := httptest.NewRecorder()
+	writeResourceNotFoundError(apirequest.NewContext(), w, req, requestInfo, []string{"noxes", "noxus"})
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+	status := &metav1.Status{}
+	if err := json.Unmarshal(w.Body.Bytes(), status); err != nil {
+		t.Fatal(err)
+	}
+	expected := "the server could not find the requested resource noxu.mygroup.example.com, did you mean noxes, noxus?"
+	if status.Reason != metav1.StatusReasonNotFound || status.Message != expected {
+		t.Errorf("expected reason %s and message %q, got %s and %q", metav1.StatusReasonNotFound, expected, status.Reason, status.Message)
+	}
+}
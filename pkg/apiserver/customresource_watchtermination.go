@@ -0,0 +1,142 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"sync"
+	"time"
+
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	"k8s.io/apimachinery/pkg/watch"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+)
+
+// watchTerminator ends the watches of custom resources gracefully when the server shuts down. Their
+// streams end instead of being cut off or running into their timeout, such that clients resume them
+// right away. Responses of the terminating server close their connection, with a GOAWAY on HTTP/2,
+// such that the clients resume on another replica.
+type watchTerminator struct {
+	lock    sync.Mutex
+	stopped bool
+	// stopCh is closed when the watches are terminated. It is read without the lock by every request.
+	stopCh   chan struct{}
+	watches  map[*terminatingWatch]struct{}
+	watching sync.WaitGroup
+}
+
+func newWatchTerminator() *watchTerminator {
+	return &watchTerminator{stopCh: make(chan struct{}), watches: map[*terminatingWatch]struct{}{}}
+}
+
+// watcher returns a watcher whose watches are stopped when the watches are terminated.
+func (t *watchTerminator) watcher(delegate rest.Watcher) rest.Watcher {
+	return terminatingWatcher{terminator: t, delegate: delegate}
+}
+
+// terminated returns true once the watches are terminated.
+func (t *watchTerminator) terminated() bool {
+	select {
+	case <-t.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// terminate stops all watches and waits up to the given timeout for their streams to end. Watches
+// started afterwards are stopped right away.
+func (t *watchTerminator) terminate(timeout time.Duration) {
+	t.lock.Lock()
+	if !t.stopped {
+		t.stopped = true
+		close(t.stopCh)
+	}
+	watches := make([]*terminatingWatch, 0, len(t.watches))
+	for w := range t.watches {
+		watches = append(watches, w)
+	}
+	t.lock.Unlock()
+
+	// the result channels of the stopped watches are closed, which ends their streams. Their handlers
+	// stop the watches again when they return.
+	for _, w := range watches {
+		w.Interface.Stop()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		t.watching.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+	}
+}
+
+// track registers the watch until it is stopped, or stops it if the watches are terminated.
+func (t *watchTerminator) track(delegate watch.Interface) watch.Interface {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.stopped {
+		delegate.Stop()
+		return delegate
+	}
+	w := &terminatingWatch{Interface: delegate, terminator: t}
+	t.watches[w] = struct{}{}
+	t.watching.Add(1)
+	return w
+}
+
+func (t *watchTerminator) untrack(w *terminatingWatch) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.watches, w)
+	t.watching.Done()
+}
+
+// terminatingWatcher starts the watches of its delegate such that they are stopped by the terminator.
+type terminatingWatcher struct {
+	terminator *watchTerminator
+	delegate   rest.Watcher
+}
+
+var _ rest.Watcher = terminatingWatcher{}
+
+func (w terminatingWatcher) Watch(ctx apirequest.Context, options *metainternalversion.ListOptions) (watch.Interface, error) {
+	delegate, err := w.delegate.Watch(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	return w.terminator.track(delegate), nil
+}
+
+// terminatingWatch is a watch registered with the terminator. Its events are not proxied, they are
+// read from the result channel of the watch itself.
+type terminatingWatch struct {
+	watch.Interface
+	terminator *watchTerminator
+	stopOnce   sync.Once
+}
+
+func (w *terminatingWatch) Stop() {
+	w.stopOnce.Do(func() {
+		w.Interface.Stop()
+		w.terminator.untrack(w)
+	})
+}
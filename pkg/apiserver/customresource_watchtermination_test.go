@@ -0,0 +1,121 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"testing"
+	"time"
+
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+type fakeRESTWatcher struct {
+	watches []*watch.FakeWatcher
+}
+
+func (f *fakeRESTWatcher) Watch(ctx apirequest.Context, options *metainternalversion.ListOptions) (watch.Interface, error) {
+	w := watch.NewFakeWithChanSize(10, false)
+	f.watches = append(f.watches, w)
+	return w, nil
+}
+
+func receiveEvent(t *testing.T, w watch.Interface) (watch.Event, bool) {
+	select {
+	case event, ok := <-w.ResultChan():
+		return event, ok
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("timed out waiting for a watch event")
+		return watch.Event{}, false
+	}
+}
+
+func TestWatchTerminator(t *testing.T) {
+	terminator := newWatchTerminator()
+	delegate := &fakeRESTWatcher{}
+
+	first, err := terminator.watcher(delegate).Watch(apirequest.NewContext(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := terminator.watcher(delegate).Watch(apirequest.NewContext(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stopped, err := terminator.watcher(delegate).Watch(apirequest.NewContext(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stopped.Stop()
+	if terminator.terminated() {
+		t.Fatalf("expected the watches not to be terminated yet")
+	}
+
+	// the events are read from the delegates
+	if first.ResultChan() != delegate.watches[0].ResultChan() {
+		t.Errorf("expected the events of the watch not to be proxied")
+	}
+	delegate.watches[0].Modify(&unstructured.Unstructured{})
+	if event, _ := receiveEvent(t, first); event.Type != watch.Modified {
+		t.Errorf("expected the MODIFIED event, got %v", event)
+	}
+
+	// the termination stops the watches and waits for their handlers to stop them too
+	terminated := make(chan struct{})
+	go func() {
+		terminator.terminate(wait.ForeverTestTimeout)
+		close(terminated)
+	}()
+	for i, w := range []watch.Interface{first, second} {
+		if event, ok := receiveEvent(t, w); ok {
+			t.Errorf("expected watch %d to end, got %v", i, event)
+		}
+	}
+	if !terminator.terminated() {
+		t.Errorf("expected the watches to be terminated")
+	}
+	first.Stop()
+	select {
+	case <-terminated:
+		t.Fatalf("expected the termination to wait for the handlers of all watches")
+	case <-time.After(100 * time.Millisecond):
+	}
+	second.Stop()
+	select {
+	case <-terminated:
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("timed out waiting for the termination")
+	}
+	for i, w := range delegate.watches {
+		if !w.IsStopped() {
+			t.Errorf("expected the delegate of watch %d to be stopped", i)
+		}
+	}
+
+	// watches started after the termination end right away
+	late, err := terminator.watcher(delegate).Watch(apirequest.NewContext(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event, ok := receiveEvent(t, late); ok {
+		t.Errorf("expected the watch started after the termination to end, got %v", event)
+	}
+	late.Stop()
+}
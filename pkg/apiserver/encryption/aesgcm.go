@@ -0,0 +1,63 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"io"
+
+	"k8s.io/apiserver/pkg/storage/value"
+)
+
+// aesGCMTransformer encrypts values with AES-GCM. The random nonce is stored in front of the cipher
+// text, and the authenticated data of the context, i.e. the etcd key, is authenticated with it.
+type aesGCMTransformer struct {
+	aead cipher.AEAD
+}
+
+func newAESGCMTransformer(key []byte) (*aesGCMTransformer, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &aesGCMTransformer{aead: aead}, nil
+}
+
+func (t *aesGCMTransformer) TransformFromStorage(data []byte, context value.Context) ([]byte, bool, error) {
+	nonceSize := t.aead.NonceSize()
+	if len(data) < nonceSize {
+		return nil, false, fmt.Errorf("the stored value is too short")
+	}
+	result, err := t.aead.Open(nil, data[:nonceSize], data[nonceSize:], context.AuthenticatedData())
+	return result, false, err
+}
+
+func (t *aesGCMTransformer) TransformToStorage(data []byte, context value.Context) ([]byte, error) {
+	nonceSize := t.aead.NonceSize()
+	result := make([]byte, nonceSize, nonceSize+len(data)+t.aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, result); err != nil {
+		return nil, err
+	}
+	return t.aead.Seal(result, result, data, context.AuthenticatedData()), nil
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package encryption implements the encryption at rest of custom resources. The encryption
+// configuration defines named providers, and the custom resources of each CustomResourceDefinition
+// are encrypted by one of them.
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/apiserver/pkg/storage/value"
+)
+
+// Config is the encryption configuration, e.g.:
+//
+//	kind: EncryptionConfig
+//	apiVersion: v1
+//	providers:
+//	- name: default
+//	  aesgcm:
+//	    keys:
+//	    - name: key1
+//	      secret: <base64 encoded 16, 24 or 32 byte key>
+//	- name: plaintext
+//	  identity: {}
+type Config struct {
+	Kind       string           `json:"kind"`
+	APIVersion string           `json:"apiVersion"`
+	Providers  []ProviderConfig `json:"providers"`
+}
+
+// ProviderConfig is a named provider. Exactly one of the provider types must be set.
+type ProviderConfig struct {
+	Name string `json:"name"`
+	// AESGCM encrypts with AES-GCM using the first key. All keys are used to decrypt.
+	AESGCM *KeysConfig `json:"aesgcm,omitempty"`
+	// Identity stores custom resources unencrypted.
+	Identity *IdentityConfig `json:"identity,omitempty"`
+}
+
+// KeysConfig are the keys of a provider.
+type KeysConfig struct {
+	Keys []Key `json:"keys"`
+}
+
+// Key is a named, base64 encoded key.
+type Key struct {
+	Name   string `json:"name"`
+	Secret string `json:"secret"`
+}
+
+// IdentityConfig configures the identity provider. It has no options.
+type IdentityConfig struct{}
+
+// encryptedPrefix is the common prefix of all encrypted values.
+var encryptedPrefix = []byte("k8s:enc:")
+
+// Providers are the providers of an encryption configuration.
+type Providers struct {
+	names        []string
+	transformers map[string][]value.PrefixTransformer
+}
+
+// LoadProviders reads the encryption configuration in the given file.
+func LoadProviders(filepath string) (*Providers, error) {
+	data, err := ioutil.ReadFile(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading encryption configuration %q: %v", filepath, err)
+	}
+	providers, err := ParseProviders(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption configuration %q: %v", filepath, err)
+	}
+	return providers, nil
+}
+
+// ParseProviders parses a YAML or JSON encryption configuration.
+func ParseProviders(data []byte) (*Providers, error) {
+	config := Config{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	if config.Kind != "EncryptionConfig" {
+		return nil, fmt.Errorf("kind must be EncryptionConfig, got %q", config.Kind)
+	}
+	if len(config.Providers) == 0 {
+		return nil, fmt.Errorf("at least one provider must be specified")
+	}
+
+	ret := &Providers{transformers: map[string][]value.PrefixTransformer{}}
+	for i, provider := range config.Providers {
+		if len(provider.Name) == 0 {
+			return nil, fmt.Errorf("providers[%d]: a name must be specified", i)
+		}
+		if _, found := ret.transformers[provider.Name]; found {
+			return nil, fmt.Errorf("providers[%d]: duplicate name %q", i, provider.Name)
+		}
+		var transformers []value.PrefixTransformer
+		switch {
+		case provider.AESGCM != nil && provider.Identity != nil:
+			return nil, fmt.Errorf("providers[%d]: only one of aesgcm and identity may be specified", i)
+		case provider.AESGCM != nil:
+			var err error
+			if transformers, err = aesGCMTransformers(provider.AESGCM); err != nil {
+				return nil, fmt.Errorf("providers[%d].aesgcm: %v", i, err)
+			}
+		case provider.Identity != nil:
+			transformers = []value.PrefixTransformer{{Transformer: identityTransformer{}}}
+		default:
+			return nil, fmt.Errorf("providers[%d]: one of aesgcm and identity must be specified", i)
+		}
+		ret.names = append(ret.names, provider.Name)
+		ret.transformers[provider.Name] = transformers
+	}
+	return ret, nil
+}
+
+// aesGCMTransformers returns the transformers of the keys, each under the prefix naming its key.
+func aesGCMTransformers(config *KeysConfig) ([]value.PrefixTransformer, error) {
+	if len(config.Keys) == 0 {
+		return nil, fmt.Errorf("at least one key must be specified")
+	}
+	names := map[string]bool{}
+	var ret []value.PrefixTransformer
+	for i, key := range config.Keys {
+		if len(key.Name) == 0 {
+			return nil, fmt.Errorf("keys[%d]: a name must be specified", i)
+		}
+		if names[key.Name] {
+			return nil, fmt.Errorf("keys[%d]: duplicate name %q", i, key.Name)
+		}
+		names[key.Name] = true
+		secret, err := base64.StdEncoding.DecodeString(key.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("keys[%d]: the secret must be base64 encoded: %v", i, err)
+		}
+		transformer, err := newAESGCMTransformer(secret)
+		if err != nil {
+			return nil, fmt.Errorf("keys[%d]: %v", i, err)
+		}
+		ret = append(ret, value.PrefixTransformer{
+			Prefix:      []byte(fmt.Sprintf("%saesgcm:v1:%s:", encryptedPrefix, key.Name)),
+			Transformer: transformer,
+		})
+	}
+	return ret, nil
+}
+
+// Has returns true if a provider with the given name exists.
+func (p *Providers) Has(name string) bool {
+	_, found := p.transformers[name]
+	return found
+}
+
+// TransformerFor returns the transformer which writes with the named provider. Values written by any
+// other provider of the configuration are still read, and are rewritten with the named provider when
+// they are updated.
+func (p *Providers) TransformerFor(name string) (value.Transformer, error) {
+	transformers, found := p.transformers[name]
+	if !found {
+		return nil, fmt.Errorf("unknown encryption provider %q", name)
+	}
+	transformers = append([]value.PrefixTransformer(nil), transformers...)
+	for _, other := range p.names {
+		if other != name {
+			transformers = append(transformers, p.transformers[other]...)
+		}
+	}
+	return value.NewPrefixTransformers(fmt.Errorf("no encryption provider can read the stored value"), transformers...), nil
+}
+
+// identityTransformer stores values unencrypted. It refuses to read encrypted values, such that the
+// providers after it are tried.
+type identityTransformer struct{}
+
+func (identityTransformer) TransformFromStorage(data []byte, context value.Context) ([]byte, bool, error) {
+	if bytes.HasPrefix(data, encryptedPrefix) {
+		return nil, false, fmt.Errorf("the stored value is encrypted")
+	}
+	return data, false, nil
+}
+
+func (identityTransformer) TransformToStorage(data []byte, context value.Context) ([]byte, error) {
+	return data, nil
+}
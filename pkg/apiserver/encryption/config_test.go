@@ -0,0 +1,120 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"k8s.io/apiserver/pkg/storage/value"
+)
+
+const testConfig = `
+kind: EncryptionConfig
+apiVersion: v1
+providers:
+- name: default
+  aesgcm:
+    keys:
+    - name: key1
+      secret: c2VjcmV0IGlzIHNlY3VyZQ==
+- name: credentials
+  aesgcm:
+    keys:
+    - name: key2
+      secret: dGhpcyBpcyBwYXNzd29yZA==
+    - name: key1
+      secret: c2VjcmV0IGlzIHNlY3VyZQ==
+- name: plaintext
+  identity: {}
+`
+
+func TestTransformerFor(t *testing.T) {
+	providers, err := ParseProviders([]byte(testConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	transformer := func(name string) value.Transformer {
+		ret, err := providers.TransformerFor(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ret
+	}
+	defaultTransformer, credentials, plaintext := transformer("default"), transformer("credentials"), transformer("plaintext")
+	context := value.DefaultContext([]byte("/noxus/foo"))
+	data := []byte(`{"kind":"Noxu"}`)
+
+	stored, err := credentials.TransformToStorage(data, context)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(stored, []byte("k8s:enc:aesgcm:v1:key2:")) || bytes.Contains(stored, data) {
+		t.Fatalf("expected the value to be encrypted with key2, got %q", stored)
+	}
+	if read, stale, err := credentials.TransformFromStorage(stored, context); err != nil || stale || !bytes.Equal(read, data) {
+		t.Errorf("expected to read %q, got %q, stale %v, error %v", data, read, stale, err)
+	}
+	// values of other providers remain readable, but are stale
+	for name, other := range map[string]value.Transformer{"default": defaultTransformer, "plaintext": plaintext} {
+		if read, stale, err := other.TransformFromStorage(stored, context); err != nil || !stale || !bytes.Equal(read, data) {
+			t.Errorf("%s: expected to read %q stale, got %q, stale %v, error %v", name, data, read, stale, err)
+		}
+	}
+	if _, _, err := credentials.TransformFromStorage(stored, value.DefaultContext([]byte("/noxus/bar"))); err == nil {
+		t.Errorf("expected an error reading the value under another key")
+	}
+
+	stored, err = plaintext.TransformToStorage(data, context)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(stored, data) {
+		t.Errorf("expected the value to be stored unencrypted, got %q", stored)
+	}
+	if read, stale, err := credentials.TransformFromStorage(stored, context); err != nil || !stale || !bytes.Equal(read, data) {
+		t.Errorf("expected to read the unencrypted value %q stale, got %q, stale %v, error %v", data, read, stale, err)
+	}
+
+	if _, err := providers.TransformerFor("unknown"); err == nil {
+		t.Errorf("expected an error for an unknown provider")
+	}
+}
+
+func TestParseProvidersErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		expected string
+	}{
+		{"wrong kind", "kind: Foo\nproviders:\n- name: a\n  identity: {}", "kind must be EncryptionConfig"},
+		{"no providers", "kind: EncryptionConfig", "at least one provider"},
+		{"missing name", "kind: EncryptionConfig\nproviders:\n- identity: {}", "a name must be specified"},
+		{"duplicate name", "kind: EncryptionConfig\nproviders:\n- name: a\n  identity: {}\n- name: a\n  identity: {}", "duplicate name"},
+		{"no type", "kind: EncryptionConfig\nproviders:\n- name: a", "one of aesgcm and identity"},
+		{"no keys", "kind: EncryptionConfig\nproviders:\n- name: a\n  aesgcm: {}", "at least one key"},
+		{"invalid secret", "kind: EncryptionConfig\nproviders:\n- name: a\n  aesgcm:\n    keys:\n    - name: k\n      secret: '!'", "base64"},
+		{"invalid key size", "kind: EncryptionConfig\nproviders:\n- name: a\n  aesgcm:\n    keys:\n    - name: k\n      secret: c2VjcmV0", "invalid key size"},
+	}
+	for _, tc := range tests {
+		_, err := ParseProviders([]byte(tc.config))
+		if err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("%s: expected an error containing %q, got %v", tc.name, tc.expected, err)
+		}
+	}
+}
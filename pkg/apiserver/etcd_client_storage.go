@@ -0,0 +1,91 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"github.com/coreos/etcd/clientv3"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/generic"
+	"k8s.io/apiserver/pkg/storage"
+	etcdstorage "k8s.io/apiserver/pkg/storage/etcd"
+	"k8s.io/apiserver/pkg/storage/etcd3"
+	"k8s.io/apiserver/pkg/storage/storagebackend"
+	"k8s.io/apiserver/pkg/storage/storagebackend/factory"
+	"k8s.io/apiserver/pkg/storage/value"
+)
+
+// NewEtcdClientStorageDecorator returns a storage decorator which stores objects with an existing
+// etcd3 client of an embedding server, instead of connecting to the servers of the storage config.
+// The codec, prefix, transformer and quorum setting of the storage config still apply. The client is
+// owned by the caller, i.e. it is neither closed nor compacted by the storage. With a positive watch
+// cache size the storage is wrapped in a watch cache.
+func NewEtcdClientStorageDecorator(client *clientv3.Client, watchCacheSize int) generic.StorageDecorator {
+	return newEtcdClientStorageDecorator(client, watchCacheSize, false)
+}
+
+// newEtcdClientStorageDecorator is NewEtcdClientStorageDecorator, whose watch cache serves consistent
+// lists if it is up to date and consistentListFromCache is true, see consistentListCacher.
+func newEtcdClientStorageDecorator(client *clientv3.Client, watchCacheSize int, consistentListFromCache bool) generic.StorageDecorator {
+	return func(
+		copier runtime.ObjectCopier,
+		config *storagebackend.Config,
+		requestedSize *int,
+		objectType runtime.Object,
+		resourcePrefix string,
+		keyFunc func(obj runtime.Object) (string, error),
+		newListFunc func() runtime.Object,
+		getAttrsFunc storage.AttrFunc,
+		triggerFunc storage.TriggerPublisherFunc) (storage.Interface, factory.DestroyFunc) {
+
+		transformer := config.Transformer
+		if transformer == nil {
+			transformer = value.IdentityTransformer
+		}
+		var s storage.Interface
+		if config.Quorum {
+			s = etcd3.New(client, config.Codec, config.Prefix, transformer)
+		} else {
+			s = etcd3.NewWithNoQuorumRead(client, config.Codec, config.Prefix, transformer)
+		}
+
+		capacity := watchCacheSize
+		if requestedSize != nil {
+			capacity = *requestedSize
+		}
+		if capacity <= 0 {
+			return s, func() {}
+		}
+		cacher := storage.NewCacherFromConfig(storage.CacherConfig{
+			CacheCapacity:        capacity,
+			Storage:              s,
+			Versioner:            etcdstorage.APIObjectVersioner{},
+			Copier:               copier,
+			Type:                 objectType,
+			ResourcePrefix:       resourcePrefix,
+			KeyFunc:              keyFunc,
+			NewListFunc:          newListFunc,
+			GetAttrsFunc:         getAttrsFunc,
+			TriggerPublisherFunc: triggerFunc,
+			Codec:                config.Codec,
+		})
+		if consistentListFromCache {
+			return newConsistentListCacher(cacher, client, config.Prefix, newListFunc), cacher.Stop
+		}
+		return cacher, cacher.Stop
+	}
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/pkg/transport"
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/generic"
+	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/apiserver/pkg/storage"
+	"k8s.io/apiserver/pkg/storage/storagebackend"
+	"k8s.io/apiserver/pkg/storage/storagebackend/factory"
+)
+
+// unusedKeySegment is a key segment which is neither a valid namespace nor a valid name, hence
+// there are no objects below it.
+const unusedKeySegment = "~"
+
+// consistentListCacher serves lists without resourceVersion, which must be consistent with etcd,
+// from the watch cache if it is verified to be up to date, instead of reading all objects from etcd.
+//
+// The etcd watch of the cache does not report progress, so the cache only knows the revision of the
+// last event of its objects, which usually lags behind the revision of etcd. The cache is up to date
+// if no object was created, updated or deleted since, which is verified with cheap range requests
+// returning no objects: no key may have a newer modification revision, and the number of keys must
+// not have changed since the revision of the cache. Otherwise the list is read from etcd.
+type consistentListCacher struct {
+	*storage.Cacher
+
+	client *clientv3.Client
+	// pathPrefix is the etcd key prefix of the storage, which the keys of the storage are relative to.
+	pathPrefix  string
+	newListFunc func() runtime.Object
+}
+
+var _ storage.Interface = &consistentListCacher{}
+
+func newConsistentListCacher(cacher *storage.Cacher, client *clientv3.Client, pathPrefix string, newListFunc func() runtime.Object) *consistentListCacher {
+	return &consistentListCacher{
+		Cacher:      cacher,
+		client:      client,
+		pathPrefix:  pathPrefix,
+		newListFunc: newListFunc,
+	}
+}
+
+// List lists the objects below key. Lists without resourceVersion are served from the watch cache if
+// it is up to date with etcd.
+func (c *consistentListCacher) List(ctx context.Context, key string, resourceVersion string, pred storage.SelectionPredicate, listObj runtime.Object) error {
+	if len(resourceVersion) > 0 {
+		return c.Cacher.List(ctx, key, resourceVersion, pred, listObj)
+	}
+	rv, upToDate, err := c.upToDateResourceVersion(ctx, key)
+	if err != nil {
+		glog.V(4).Infof("Unable to verify that the watch cache of %s is up to date, listing from etcd: %v", key, err)
+	}
+	if !upToDate {
+		return c.Cacher.List(ctx, key, "", pred, listObj)
+	}
+	return c.Cacher.List(ctx, key, strconv.FormatUint(rv, 10), pred, listObj)
+}
+
+// ConsistentResourceVersion returns the resourceVersion of the watch cache if the objects below key in
+// the cache are the same as in etcd, or an empty string. Watches with sendInitialEvents stream their
+// initial events from the cache at this resourceVersion.
+func (c *consistentListCacher) ConsistentResourceVersion(ctx context.Context, key string) (string, error) {
+	rv, upToDate, err := c.upToDateResourceVersion(ctx, key)
+	if err != nil || !upToDate {
+		return "", err
+	}
+	return strconv.FormatUint(rv, 10), nil
+}
+
+// upToDateResourceVersion returns the resourceVersion of the watch cache and whether the objects below
+// key in the cache are the same as in etcd. It waits until the cache is initialized.
+func (c *consistentListCacher) upToDateResourceVersion(ctx context.Context, key string) (uint64, bool, error) {
+	// no objects are below the unused key segment, so nothing is copied from the cache
+	list := c.newListFunc()
+	if err := c.Cacher.List(ctx, path.Join(key, unusedKeySegment), "1", storage.Everything, list); err != nil {
+		return 0, false, err
+	}
+	listMeta, err := meta.ListAccessor(list)
+	if err != nil {
+		return 0, false, err
+	}
+	cached, err := strconv.ParseUint(listMeta.GetResourceVersion(), 10, 64)
+	if err != nil {
+		return 0, false, err
+	}
+
+	// the same prefix as the etcd3 storage lists
+	prefix := path.Join(c.pathPrefix, key)
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	current, err := c.client.Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithCountOnly())
+	if err != nil {
+		return 0, false, err
+	}
+	if int64(cached) >= current.Header.Revision {
+		return cached, true, nil
+	}
+	// created or updated since the revision of the cache
+	modified, err := c.client.Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithKeysOnly(), clientv3.WithLimit(1),
+		clientv3.WithRev(current.Header.Revision), clientv3.WithMinModRev(int64(cached)+1))
+	if err != nil {
+		return 0, false, err
+	}
+	if len(modified.Kvs) > 0 {
+		return 0, false, nil
+	}
+	// without creations, deletions change the number of keys. This fails if the revision of the cache
+	// is compacted.
+	previous, err := c.client.Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithCountOnly(), clientv3.WithRev(int64(cached)))
+	if err != nil {
+		return 0, false, err
+	}
+	return cached, previous.Count == current.Count, nil
+}
+
+// newConsistentListStorageDecorator returns a storage decorator which wraps the etcd3 storage of the
+// storage config in a watch cache with the given default size, which serves consistent lists if it
+// is up to date, see consistentListCacher. Other storage types get a plain watch cache.
+//
+// The storages share one etcd3 client per set of servers. They do not compact etcd: only one compactor
+// runs per process and etcd cluster, and it stops for good when the storage that started it is
+// destroyed. Compaction is left to the owner of the etcd cluster, e.g. the storage of the CRDs.
+func newConsistentListStorageDecorator(watchCacheSize int) generic.StorageDecorator {
+	return func(
+		copier runtime.ObjectCopier,
+		config *storagebackend.Config,
+		requestedSize *int,
+		objectType runtime.Object,
+		resourcePrefix string,
+		keyFunc func(obj runtime.Object) (string, error),
+		newListFunc func() runtime.Object,
+		getAttrsFunc storage.AttrFunc,
+		triggerFunc storage.TriggerPublisherFunc) (storage.Interface, factory.DestroyFunc) {
+
+		if config.Type != storagebackend.StorageTypeUnset && config.Type != storagebackend.StorageTypeETCD3 {
+			return genericregistry.StorageWithCacher(watchCacheSize)(copier, config, requestedSize, objectType, resourcePrefix, keyFunc, newListFunc, getAttrsFunc, triggerFunc)
+		}
+		client, release, err := sharedEtcdClients.get(config)
+		if err != nil {
+			glog.Fatalf("Unable to create storage backend: config (%v), err (%v)", config, err)
+		}
+
+		s, destroy := newEtcdClientStorageDecorator(client, watchCacheSize, true)(copier, config, requestedSize, objectType, resourcePrefix, keyFunc, newListFunc, getAttrsFunc, triggerFunc)
+		return s, func() {
+			destroy()
+			release()
+		}
+	}
+}
+
+// sharedEtcdClients are the etcd3 clients of the storages of newConsistentListStorageDecorator.
+var sharedEtcdClients = &etcdClients{clients: map[string]*sharedEtcdClient{}}
+
+// etcdClients shares etcd3 clients between the storages connecting to the same servers with the same
+// credentials. A client is closed when the last storage using it is destroyed.
+type etcdClients struct {
+	lock    sync.Mutex
+	clients map[string]*sharedEtcdClient
+}
+
+type sharedEtcdClient struct {
+	client *clientv3.Client
+	// users is the number of storages using the client.
+	users int
+}
+
+// get returns the client for the servers of the storage config, and a func releasing it which must be
+// called once the client is not used anymore.
+func (c *etcdClients) get(config *storagebackend.Config) (*clientv3.Client, func(), error) {
+	key := strings.Join([]string{strings.Join(config.ServerList, ","), config.CertFile, config.KeyFile, config.CAFile}, "|")
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	shared, ok := c.clients[key]
+	if !ok {
+		client, err := newEtcdClient(config)
+		if err != nil {
+			return nil, nil, err
+		}
+		shared = &sharedEtcdClient{client: client}
+		c.clients[key] = shared
+	}
+	shared.users++
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			c.lock.Lock()
+			defer c.lock.Unlock()
+			shared.users--
+			if shared.users > 0 {
+				return
+			}
+			delete(c.clients, key)
+			shared.client.Close()
+		})
+	}
+	return shared.client, release, nil
+}
+
+// newEtcdClient returns an etcd3 client connecting to the servers of the storage config, like the
+// client of the etcd3 storage of the storage backend factory.
+func newEtcdClient(config *storagebackend.Config) (*clientv3.Client, error) {
+	tlsInfo := transport.TLSInfo{
+		CertFile: config.CertFile,
+		KeyFile:  config.KeyFile,
+		CAFile:   config.CAFile,
+	}
+	tlsConfig, err := tlsInfo.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	// the client relies on a nil tlsConfig for non-secure connections
+	if len(config.CertFile) == 0 && len(config.KeyFile) == 0 && len(config.CAFile) == 0 {
+		tlsConfig = nil
+	}
+	return clientv3.New(clientv3.Config{
+		Endpoints: config.ServerList,
+		TLS:       tlsConfig,
+	})
+}
@@ -0,0 +1,613 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+const (
+	// ApplyPatchType is the content type of apply requests.
+	ApplyPatchType types.PatchType = "application/apply-patch+yaml"
+
+	// ConflictCauseType is the type of the status causes of apply conflicts.
+	ConflictCauseType metav1.CauseType = "FieldManagerConflict"
+
+	// MaxManagerLength is the maximal length of the name of a field manager.
+	MaxManagerLength = 128
+)
+
+// FieldManager tracks in metadata.managedFields which manager set which fields of a custom resource,
+// and merges apply configurations into custom resources.
+//
+// The fields are determined by the validation schema: objects are tracked field by field, while
+// scalars, arrays and objects whose fields the schema does not specify are tracked as a whole.
+// x-kubernetes-map-type overrides this for objects. Arrays with x-kubernetes-list-type map are tracked
+// item by item, identified by their x-kubernetes-list-map-keys, and those with x-kubernetes-list-type
+// set value by value. Of the metadata only labels and annotations are tracked.
+type FieldManager struct {
+	schema *apiextensions.JSONSchemaProps
+	now    func() time.Time
+}
+
+// NewFieldManager returns a field manager for custom resources of the given validation schema,
+// which may be nil.
+func NewFieldManager(schema *apiextensions.JSONSchemaProps) *FieldManager {
+	return &FieldManager{
+		schema: schema,
+		now:    time.Now,
+	}
+}
+
+// Update records the fields of obj whose values differ from liveObj as managed by manager. liveObj is
+// nil on create. If obj does not set managed fields, those of liveObj are taken over. If manager is
+// empty, the changed fields are released by their previous managers without being assigned to a new one.
+// obj is left unchanged if its fields cannot be determined.
+func (f *FieldManager) Update(liveObj, obj map[string]interface{}, manager string) error {
+	entries, found := decodeManagedFields(obj)
+	if !found && liveObj != nil {
+		entries, _ = decodeManagedFields(liveObj)
+	}
+
+	fields, err := f.fieldsOf(obj)
+	if err != nil {
+		return err
+	}
+	changed := Set{}
+	for _, path := range fields.Paths() {
+		if liveObj == nil {
+			changed.Insert(path...)
+			continue
+		}
+		val, _, err := nestedValue(obj, path)
+		if err != nil {
+			return err
+		}
+		liveVal, found, err := nestedValue(liveObj, path)
+		if err != nil {
+			return err
+		}
+		if !found || !apiequality.Semantic.DeepEqual(liveVal, val) {
+			changed.Insert(path...)
+		}
+	}
+
+	now := f.now().UTC().Format(time.RFC3339)
+	ret := make([]managedFieldsEntry, 0, len(entries)+1)
+	recorded := false
+	for _, entry := range entries {
+		if entry.Manager == manager && entry.Operation == OperationUpdate {
+			if !changed.Empty() {
+				entry.Fields = entry.Fields.Union(changed)
+				entry.Time = now
+			}
+			recorded = true
+		} else {
+			entry.Fields = entry.Fields.Difference(changed)
+		}
+		entry.Fields = entry.Fields.Intersection(fields)
+		if !entry.Fields.Empty() {
+			ret = append(ret, entry)
+		}
+	}
+	if !recorded && len(manager) > 0 && !changed.Empty() {
+		apiVersion, _ := obj["apiVersion"].(string)
+		ret = append(ret, managedFieldsEntry{
+			Manager:    manager,
+			Operation:  OperationUpdate,
+			APIVersion: apiVersion,
+			Time:       now,
+			Fields:     changed,
+		})
+	}
+	encodeManagedFields(obj, ret)
+	return nil
+}
+
+// Apply merges the apply configuration of manager into liveObj and returns the result. liveObj is nil
+// if the custom resource does not exist yet. Neither liveObj nor config are mutated.
+//
+// Fields of the configuration which are managed by other managers with a different value are conflicts.
+// They fail the apply unless force is true, in which case manager takes them over. Fields which manager
+// applied before, but which are not part of the configuration anymore, are removed unless other managers
+// manage them as well.
+func (f *FieldManager) Apply(liveObj, config map[string]interface{}, manager string, force bool) (map[string]interface{}, error) {
+	var entries []managedFieldsEntry
+	if liveObj != nil {
+		entries, _ = decodeManagedFields(liveObj)
+	}
+
+	applied, err := f.fieldsOf(config)
+	if err != nil {
+		return nil, err
+	}
+	var previouslyApplied Set
+	others := Set{}
+	for _, entry := range entries {
+		if entry.Manager == manager && entry.Operation == OperationApply {
+			previouslyApplied = entry.Fields
+			continue
+		}
+		others = others.Union(entry.Fields)
+	}
+
+	merged, err := f.merge(liveObj, config)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range previouslyApplied.Difference(applied).Difference(others).Paths() {
+		if err := removeNestedValue(merged, path); err != nil {
+			return nil, err
+		}
+	}
+	mergedFields, err := f.fieldsOf(merged)
+	if err != nil {
+		return nil, err
+	}
+
+	// fields of other managers conflict if apply changes their value or replaces them by a value of another structure
+	var conflicts []metav1.StatusCause
+	conflicting := make(map[int]Set)
+	for i, entry := range entries {
+		if entry.Manager == manager {
+			continue
+		}
+		for _, path := range entry.Fields.Paths() {
+			if applied.Has(path...) {
+				liveVal, _, err := nestedValue(liveObj, path)
+				if err != nil {
+					return nil, err
+				}
+				val, _, err := nestedValue(config, path)
+				if err != nil {
+					return nil, err
+				}
+				if apiequality.Semantic.DeepEqual(liveVal, val) {
+					continue
+				}
+			} else if mergedFields.Has(path...) {
+				continue
+			}
+			if conflicting[i] == nil {
+				conflicting[i] = Set{}
+			}
+			conflicting[i].Insert(path...)
+			conflicts = append(conflicts, metav1.StatusCause{
+				Type:    ConflictCauseType,
+				Message: fmt.Sprintf("conflict with %q", entry.Manager),
+				Field:   pathString(path),
+			})
+		}
+	}
+	if len(conflicts) > 0 && !force {
+		return nil, newConflictError(conflicts)
+	}
+
+	now := f.now().UTC().Format(time.RFC3339)
+	apiVersion, _ := config["apiVersion"].(string)
+	applyEntry := managedFieldsEntry{
+		Manager:    manager,
+		Operation:  OperationApply,
+		APIVersion: apiVersion,
+		Time:       now,
+		Fields:     applied.Intersection(mergedFields),
+	}
+	ret := make([]managedFieldsEntry, 0, len(entries)+1)
+	recorded := false
+	for i, entry := range entries {
+		switch {
+		case entry.Manager == manager && entry.Operation == OperationApply:
+			entry = applyEntry
+			recorded = true
+		case entry.Manager == manager:
+			entry.Fields = entry.Fields.Difference(applied)
+		case conflicting[i] != nil:
+			entry.Fields = entry.Fields.Difference(conflicting[i])
+		}
+		entry.Fields = entry.Fields.Intersection(mergedFields)
+		if !entry.Fields.Empty() {
+			ret = append(ret, entry)
+		}
+	}
+	if !recorded && !applyEntry.Fields.Empty() {
+		ret = append(ret, applyEntry)
+	}
+	encodeManagedFields(merged, ret)
+
+	return merged, nil
+}
+
+// newConflictError returns a conflict error listing the given apply conflicts.
+func newConflictError(conflicts []metav1.StatusCause) *apierrors.StatusError {
+	messages := make([]string, 0, len(conflicts))
+	for _, c := range conflicts {
+		messages = append(messages, fmt.Sprintf("%s: %s", c.Message, c.Field))
+	}
+	return &apierrors.StatusError{ErrStatus: metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    http.StatusConflict,
+		Reason:  metav1.StatusReasonConflict,
+		Message: fmt.Sprintf("Apply failed with %d conflicts: %s", len(conflicts), strings.Join(messages, ", ")),
+		Details: &metav1.StatusDetails{
+			Causes: conflicts,
+		},
+	}}
+}
+
+// fieldsOf returns the fields of obj which are tracked.
+func (f *FieldManager) fieldsOf(obj map[string]interface{}) (Set, error) {
+	ret := Set{}
+	for k, v := range obj {
+		switch k {
+		case "apiVersion", "kind":
+		case "metadata":
+			metadata, _ := v.(map[string]interface{})
+			for _, field := range []string{"labels", "annotations"} {
+				if val, found := metadata[field]; found {
+					if err := collectFields(ret, []string{fieldElement("metadata"), fieldElement(field)}, val, nil); err != nil {
+						return nil, err
+					}
+				}
+			}
+		default:
+			if err := collectFields(ret, []string{fieldElement(k)}, v, propertySchema(f.schema, k)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return ret, nil
+}
+
+// collectFields adds the path of val to fields, or the paths of its fields or items if val is an object
+// tracked field by field or an array tracked item by item.
+func collectFields(fields Set, path []string, val interface{}, s *apiextensions.JSONSchemaProps) error {
+	switch val := val.(type) {
+	case map[string]interface{}:
+		if len(val) > 0 && isGranular(s) {
+			for k, v := range val {
+				if err := collectFields(fields, appendPath(path, fieldElement(k)), v, propertySchema(s, k)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case []interface{}:
+		if len(val) > 0 && listType(s) != "atomic" {
+			for _, item := range val {
+				elem, err := itemElement(item, s)
+				if err != nil {
+					return err
+				}
+				if err := collectFields(fields, appendPath(path, elem), item, itemsSchema(s)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	fields.Insert(path...)
+	return nil
+}
+
+// merge returns a copy of liveObj with config merged into it. Of the metadata of config only labels
+// and annotations are merged, unless liveObj is nil.
+func (f *FieldManager) merge(liveObj, config map[string]interface{}) (map[string]interface{}, error) {
+	if liveObj == nil {
+		clone, err := apiextensions.DeepCopyJSONValue(config)
+		if err != nil {
+			return nil, err
+		}
+		ret := clone.(map[string]interface{})
+		if metadata, ok := ret["metadata"].(map[string]interface{}); ok {
+			delete(metadata, "managedFields")
+		}
+		return ret, nil
+	}
+
+	clone, err := apiextensions.DeepCopyJSONValue(liveObj)
+	if err != nil {
+		return nil, err
+	}
+	ret := clone.(map[string]interface{})
+	for k, v := range config {
+		switch k {
+		case "apiVersion", "kind":
+		case "metadata":
+			configMetadata, _ := v.(map[string]interface{})
+			metadata, ok := ret["metadata"].(map[string]interface{})
+			if !ok {
+				metadata = map[string]interface{}{}
+				ret["metadata"] = metadata
+			}
+			for _, field := range []string{"labels", "annotations"} {
+				if val, found := configMetadata[field]; found {
+					if metadata[field], err = mergeValue(metadata[field], val, nil); err != nil {
+						return nil, err
+					}
+				}
+			}
+		default:
+			if ret[k], err = mergeValue(ret[k], v, propertySchema(f.schema, k)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return ret, nil
+}
+
+// mergeValue returns val merged into liveVal. liveVal is mutated. Items of associative lists and sets
+// which are not in liveVal yet are appended. Merged values are copied, such that they do not share
+// memory with the apply configuration.
+func mergeValue(liveVal, val interface{}, s *apiextensions.JSONSchemaProps) (interface{}, error) {
+	switch val := val.(type) {
+	case map[string]interface{}:
+		liveMap, ok := liveVal.(map[string]interface{})
+		if !ok || len(val) == 0 || !isGranular(s) {
+			break
+		}
+		for k, v := range val {
+			merged, err := mergeValue(liveMap[k], v, propertySchema(s, k))
+			if err != nil {
+				return nil, err
+			}
+			liveMap[k] = merged
+		}
+		return liveMap, nil
+	case []interface{}:
+		liveList, ok := liveVal.([]interface{})
+		if !ok || len(val) == 0 || listType(s) == "atomic" {
+			break
+		}
+		for _, item := range val {
+			elem, err := itemElement(item, s)
+			if err != nil {
+				return nil, err
+			}
+			i, err := itemIndex(liveList, elem)
+			if err != nil {
+				return nil, err
+			}
+			if i < 0 {
+				clone, err := apiextensions.DeepCopyJSONValue(item)
+				if err != nil {
+					return nil, err
+				}
+				liveList = append(liveList, clone)
+				continue
+			}
+			if liveList[i], err = mergeValue(liveList[i], item, itemsSchema(s)); err != nil {
+				return nil, err
+			}
+		}
+		return liveList, nil
+	}
+	return apiextensions.DeepCopyJSONValue(val)
+}
+
+// isGranular returns true if objects of the given schema are tracked field by field. Without a schema
+// this is the case for all objects.
+func isGranular(s *apiextensions.JSONSchemaProps) bool {
+	if s == nil {
+		return true
+	}
+	if s.XMapType != nil {
+		return *s.XMapType == "granular"
+	}
+	if len(s.Properties) > 0 {
+		return true
+	}
+	return s.AdditionalProperties != nil && (s.AdditionalProperties.Allows || s.AdditionalProperties.Schema != nil)
+}
+
+// listType returns the x-kubernetes-list-type of arrays of the given schema. Without a schema arrays are atomic.
+func listType(s *apiextensions.JSONSchemaProps) string {
+	if s == nil || s.XListType == nil {
+		return "atomic"
+	}
+	return *s.XListType
+}
+
+// itemElement returns the path element of an item of an associative list or set of the given schema.
+func itemElement(item interface{}, s *apiextensions.JSONSchemaProps) (string, error) {
+	if listType(s) == "map" {
+		m, _ := item.(map[string]interface{})
+		return keyElement(m, s.XListMapKeys)
+	}
+	return valueElement(item)
+}
+
+// itemsSchema returns the schema of the items of arrays of schema s, or nil if it is not specified.
+func itemsSchema(s *apiextensions.JSONSchemaProps) *apiextensions.JSONSchemaProps {
+	if s == nil || s.Items == nil {
+		return nil
+	}
+	return s.Items.Schema
+}
+
+// appendPath returns a copy of path with elem appended.
+func appendPath(path []string, elem string) []string {
+	return append(append(make([]string, 0, len(path)+1), path...), elem)
+}
+
+// propertySchema returns the schema of the given property of objects of schema s, or nil if it is not specified.
+func propertySchema(s *apiextensions.JSONSchemaProps, property string) *apiextensions.JSONSchemaProps {
+	if s == nil {
+		return nil
+	}
+	if prop, ok := s.Properties[property]; ok {
+		return &prop
+	}
+	if s.AdditionalProperties != nil {
+		return s.AdditionalProperties.Schema
+	}
+	return nil
+}
+
+// nestedValue returns the value at the given path of obj.
+func nestedValue(obj map[string]interface{}, path []string) (interface{}, bool, error) {
+	var val interface{} = obj
+	for _, elem := range path {
+		switch v := val.(type) {
+		case map[string]interface{}:
+			if !strings.HasPrefix(elem, "f:") {
+				return nil, false, nil
+			}
+			var found bool
+			if val, found = v[elem[2:]]; !found {
+				return nil, false, nil
+			}
+		case []interface{}:
+			i, err := itemIndex(v, elem)
+			if err != nil {
+				return nil, false, err
+			}
+			if i < 0 {
+				return nil, false, nil
+			}
+			val = v[i]
+		default:
+			return nil, false, nil
+		}
+	}
+	return val, true, nil
+}
+
+// removeNestedValue removes the value at the given path of obj. The keys of associative list items
+// are kept as long as other fields of the item remain, otherwise the whole item is removed.
+func removeNestedValue(obj map[string]interface{}, path []string) error {
+	_, err := removeValue(obj, path)
+	return err
+}
+
+// removeValue removes the value at the given path of val and returns the result. Objects are mutated.
+func removeValue(val interface{}, path []string) (interface{}, error) {
+	elem := path[0]
+	switch v := val.(type) {
+	case map[string]interface{}:
+		if !strings.HasPrefix(elem, "f:") {
+			return val, nil
+		}
+		name := elem[2:]
+		child, found := v[name]
+		if !found {
+			return val, nil
+		}
+		if len(path) == 1 {
+			delete(v, name)
+			return v, nil
+		}
+		removed, err := removeValue(child, path[1:])
+		if err != nil {
+			return nil, err
+		}
+		v[name] = removed
+		return v, nil
+	case []interface{}:
+		i, err := itemIndex(v, elem)
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 {
+			return val, nil
+		}
+		if len(path) > 1 {
+			keys := elementKeys(elem)
+			item := v[i]
+			if len(path) > 2 || !keys[strings.TrimPrefix(path[1], "f:")] {
+				if item, err = removeValue(item, path[1:]); err != nil {
+					return nil, err
+				}
+			}
+			if !onlyKeys(item, keys) {
+				v[i] = item
+				return v, nil
+			}
+		}
+		return append(append(make([]interface{}, 0, len(v)-1), v[:i]...), v[i+1:]...), nil
+	}
+	return val, nil
+}
+
+// onlyKeys returns true if item is an object without other fields than the given keys.
+func onlyKeys(item interface{}, keys map[string]bool) bool {
+	m, ok := item.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for k := range m {
+		if !keys[k] {
+			return false
+		}
+	}
+	return true
+}
+
+type contextKey int
+
+const (
+	managerContextKey contextKey = iota
+	applyContextKey
+)
+
+// WithManager returns a copy of ctx with the name of the field manager of the request set.
+func WithManager(ctx genericapirequest.Context, manager string) genericapirequest.Context {
+	return genericapirequest.WithValue(ctx, managerContextKey, manager)
+}
+
+// ManagerFrom returns the name of the field manager of the request.
+func ManagerFrom(ctx genericapirequest.Context) string {
+	manager, _ := ctx.Value(managerContextKey).(string)
+	return manager
+}
+
+// WithApply returns a copy of ctx which marks the request as apply request, whose managed fields are
+// set by Apply instead of Update.
+func WithApply(ctx genericapirequest.Context) genericapirequest.Context {
+	return genericapirequest.WithValue(ctx, applyContextKey, true)
+}
+
+// IsApply returns true if the request is an apply request.
+func IsApply(ctx genericapirequest.Context) bool {
+	apply, _ := ctx.Value(applyContextKey).(bool)
+	return apply
+}
+
+// ManagerFromRequest returns the name of the field manager of the request, i.e. the fieldManager
+// parameter if set, and the user agent up to the first slash otherwise.
+func ManagerFromRequest(req *http.Request) string {
+	if manager := req.URL.Query().Get("fieldManager"); len(manager) > 0 {
+		return manager
+	}
+	userAgent := req.Header.Get("User-Agent")
+	if i := strings.Index(userAgent, "/"); i >= 0 {
+		userAgent = userAgent[:i]
+	}
+	if len(userAgent) > MaxManagerLength {
+		userAgent = userAgent[:MaxManagerLength]
+	}
+	return userAgent
+}
@@ -0,0 +1,402 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+var testSchema = &apiextensions.JSONSchemaProps{
+	Type: "object",
+	Properties: map[string]apiextensions.JSONSchemaProps{
+		"spec": {
+			Type: "object",
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"replicas": {Type: "integer"},
+				"image":    {Type: "string"},
+				"ports": {
+					Type:  "array",
+					Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "integer"}},
+				},
+				"selector": {Type: "object"},
+				"env": {
+					Type:                 "object",
+					AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{Allows: true, Schema: &apiextensions.JSONSchemaProps{Type: "string"}},
+				},
+				"containers": {
+					Type:         "array",
+					XListType:    strPtr("map"),
+					XListMapKeys: []string{"name"},
+					Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{
+						Type:     "object",
+						Required: []string{"name"},
+						Properties: map[string]apiextensions.JSONSchemaProps{
+							"name":  {Type: "string"},
+							"image": {Type: "string"},
+							"port":  {Type: "integer"},
+						},
+					}},
+				},
+				"finalizers": {
+					Type:      "array",
+					XListType: strPtr("set"),
+					Items:     &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string"}},
+				},
+				"config": {
+					Type:       "object",
+					XMapType:   strPtr("atomic"),
+					Properties: map[string]apiextensions.JSONSchemaProps{"key": {Type: "string"}},
+				},
+			},
+		},
+	},
+}
+
+func newTestFieldManager() *FieldManager {
+	f := NewFieldManager(testSchema)
+	f.now = func() time.Time { return time.Date(2017, 10, 1, 0, 0, 0, 0, time.UTC) }
+	return f
+}
+
+func mustUnmarshal(t *testing.T, s string) map[string]interface{} {
+	var ret map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &ret); err != nil {
+		t.Fatal(err)
+	}
+	return ret
+}
+
+// managedFields returns the paths managed by each manager and operation of obj.
+func managedFields(t *testing.T, obj map[string]interface{}) map[string][]string {
+	entries, _ := decodeManagedFields(obj)
+	ret := map[string][]string{}
+	for _, entry := range entries {
+		key := entry.Manager + "/" + entry.Operation
+		if _, found := ret[key]; found {
+			t.Errorf("duplicate managed fields entry %s", key)
+		}
+		ret[key] = []string{}
+		for _, p := range entry.Fields.Paths() {
+			ret[key] = append(ret[key], pathString(p))
+		}
+	}
+	return ret
+}
+
+func TestFieldsOf(t *testing.T) {
+	obj := mustUnmarshal(t, `{
+		"apiVersion": "mygroup.example.com/v1beta1",
+		"kind": "Noxu",
+		"metadata": {"name": "foo", "labels": {"a": "b"}, "resourceVersion": "42"},
+		"spec": {"replicas": 1, "ports": [80], "selector": {"x": "y"}, "env": {"FOO": "bar"}, "unknown": {"z": 1},
+			"containers": [{"name": "a", "image": "x"}], "finalizers": ["f"], "config": {"key": "v"}}
+	}`)
+
+	fields, err := newTestFieldManager().fieldsOf(obj)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var paths []string
+	for _, p := range fields.Paths() {
+		paths = append(paths, pathString(p))
+	}
+	expected := []string{
+		".metadata.labels.a",
+		".spec.config",
+		`.spec.containers[{"name":"a"}].image`,
+		`.spec.containers[{"name":"a"}].name`,
+		".spec.env.FOO",
+		`.spec.finalizers["f"]`,
+		".spec.ports",
+		".spec.replicas",
+		".spec.selector",
+		".spec.unknown.z",
+	}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected fields %v, got %v", expected, paths)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	f := newTestFieldManager()
+
+	obj := mustUnmarshal(t, `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo"}, "spec": {"replicas": 1, "image": "a"}}`)
+	if err := f.Update(nil, obj, "creator"); err != nil {
+		t.Fatalf("create: unexpected error: %v", err)
+	}
+	if expected := map[string][]string{"creator/Update": {".spec.image", ".spec.replicas"}}; !reflect.DeepEqual(managedFields(t, obj), expected) {
+		t.Errorf("create: expected %v, got %v", expected, managedFields(t, obj))
+	}
+
+	// managed fields are taken over from the live object if not set
+	updated := mustUnmarshal(t, `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo"}, "spec": {"replicas": 2, "ports": [80]}}`)
+	if err := f.Update(obj, updated, "updater"); err != nil {
+		t.Fatalf("update: unexpected error: %v", err)
+	}
+	if expected := map[string][]string{"updater/Update": {".spec.ports", ".spec.replicas"}}; !reflect.DeepEqual(managedFields(t, updated), expected) {
+		t.Errorf("update: expected %v, got %v", expected, managedFields(t, updated))
+	}
+
+	// without manager the changed fields are released
+	released := mustUnmarshal(t, `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo"}, "spec": {"replicas": 3, "ports": [80]}}`)
+	if err := f.Update(updated, released, ""); err != nil {
+		t.Fatalf("update without manager: unexpected error: %v", err)
+	}
+	if expected := map[string][]string{"updater/Update": {".spec.ports"}}; !reflect.DeepEqual(managedFields(t, released), expected) {
+		t.Errorf("update without manager: expected %v, got %v", expected, managedFields(t, released))
+	}
+}
+
+func TestApply(t *testing.T) {
+	live := `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo", "resourceVersion": "42"}, "spec": {"replicas": 1, "image": "a"}}`
+
+	tests := []struct {
+		name     string
+		live     string
+		managers [][]string // manager, operation, paths...
+		config   string
+		force    bool
+
+		expectedConflicts []string
+		expected          string
+		expectedManaged   map[string][]string
+	}{
+		{
+			name:            "create",
+			config:          `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo", "labels": {"a": "b"}}, "spec": {"replicas": 1}}`,
+			expected:        `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo", "labels": {"a": "b"}}, "spec": {"replicas": 1}}`,
+			expectedManaged: map[string][]string{"applier/Apply": {".metadata.labels.a", ".spec.replicas"}},
+		},
+		{
+			name:            "merge into unmanaged fields",
+			live:            live,
+			config:          `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo"}, "spec": {"replicas": 2, "env": {"FOO": "bar"}}}`,
+			expected:        `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo", "resourceVersion": "42"}, "spec": {"replicas": 2, "image": "a", "env": {"FOO": "bar"}}}`,
+			expectedManaged: map[string][]string{"applier/Apply": {".spec.env.FOO", ".spec.replicas"}},
+		},
+		{
+			name:              "conflict",
+			live:              live,
+			managers:          [][]string{{"other", OperationUpdate, "f:spec", "f:replicas"}, {"third", OperationApply, "f:spec", "f:image"}},
+			config:            `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "spec": {"replicas": 2, "image": "b"}}`,
+			expectedConflicts: []string{".spec.replicas", ".spec.image"},
+		},
+		{
+			name:            "force",
+			live:            live,
+			managers:        [][]string{{"other", OperationUpdate, "f:spec", "f:replicas"}, {"third", OperationApply, "f:spec", "f:image"}},
+			config:          `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "spec": {"replicas": 2}}`,
+			force:           true,
+			expected:        `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo", "resourceVersion": "42"}, "spec": {"replicas": 2, "image": "a"}}`,
+			expectedManaged: map[string][]string{"third/Apply": {".spec.image"}, "applier/Apply": {".spec.replicas"}},
+		},
+		{
+			name:            "shared ownership of equal values",
+			live:            live,
+			managers:        [][]string{{"other", OperationUpdate, "f:spec", "f:replicas"}},
+			config:          `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "spec": {"replicas": 1}}`,
+			expected:        live,
+			expectedManaged: map[string][]string{"other/Update": {".spec.replicas"}, "applier/Apply": {".spec.replicas"}},
+		},
+		{
+			name:            "own updates are taken over",
+			live:            live,
+			managers:        [][]string{{"applier", OperationUpdate, "f:spec", "f:replicas"}},
+			config:          `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "spec": {"replicas": 3}}`,
+			expected:        `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo", "resourceVersion": "42"}, "spec": {"replicas": 3, "image": "a"}}`,
+			expectedManaged: map[string][]string{"applier/Apply": {".spec.replicas"}},
+		},
+		{
+			name:            "fields removed from the configuration are removed",
+			live:            live,
+			managers:        [][]string{{"applier", OperationApply, "f:spec", "f:replicas"}, {"applier", OperationApply, "f:spec", "f:image"}},
+			config:          `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "spec": {"replicas": 1}}`,
+			expected:        `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo", "resourceVersion": "42"}, "spec": {"replicas": 1}}`,
+			expectedManaged: map[string][]string{"applier/Apply": {".spec.replicas"}},
+		},
+		{
+			name:            "removed fields managed by others are kept",
+			live:            live,
+			managers:        [][]string{{"applier", OperationApply, "f:spec", "f:replicas"}, {"applier", OperationApply, "f:spec", "f:image"}, {"other", OperationUpdate, "f:spec", "f:image"}},
+			config:          `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "spec": {"replicas": 1}}`,
+			expected:        live,
+			expectedManaged: map[string][]string{"applier/Apply": {".spec.replicas"}, "other/Update": {".spec.image"}},
+		},
+		{
+			name:              "atomic objects of the schema conflict as a whole",
+			live:              `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo"}, "spec": {"selector": {"a": "b"}}}`,
+			managers:          [][]string{{"other", OperationUpdate, "f:spec", "f:selector"}},
+			config:            `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "spec": {"selector": {"c": "d"}}}`,
+			expectedConflicts: []string{".spec.selector"},
+		},
+		{
+			name:              "replacing the structure of managed fields conflicts",
+			live:              `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo"}, "spec": {"unknown": {"a": "b"}}}`,
+			managers:          [][]string{{"other", OperationUpdate, "f:spec", "f:unknown", "f:a"}},
+			config:            `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "spec": {"unknown": "c"}}`,
+			expectedConflicts: []string{".spec.unknown.a"},
+		},
+		{
+			name:     "associative list items are merged by key",
+			live:     `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo"}, "spec": {"containers": [{"name": "a", "image": "x"}]}}`,
+			managers: [][]string{{"other", OperationUpdate, "f:spec", "f:containers", `k:{"name":"a"}`, "f:name"}, {"other", OperationUpdate, "f:spec", "f:containers", `k:{"name":"a"}`, "f:image"}},
+			config:   `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "spec": {"containers": [{"name": "b", "image": "y"}, {"name": "a", "port": 80}]}}`,
+			expected: `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo"}, "spec": {"containers": [{"name": "a", "image": "x", "port": 80}, {"name": "b", "image": "y"}]}}`,
+			expectedManaged: map[string][]string{
+				"other/Update":  {`.spec.containers[{"name":"a"}].image`, `.spec.containers[{"name":"a"}].name`},
+				"applier/Apply": {`.spec.containers[{"name":"a"}].name`, `.spec.containers[{"name":"a"}].port`, `.spec.containers[{"name":"b"}].image`, `.spec.containers[{"name":"b"}].name`},
+			},
+		},
+		{
+			name:              "associative list items conflict field by field",
+			live:              `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo"}, "spec": {"containers": [{"name": "a", "image": "x"}]}}`,
+			managers:          [][]string{{"other", OperationUpdate, "f:spec", "f:containers", `k:{"name":"a"}`, "f:image"}},
+			config:            `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "spec": {"containers": [{"name": "a", "image": "y"}]}}`,
+			expectedConflicts: []string{`.spec.containers[{"name":"a"}].image`},
+		},
+		{
+			name:            "associative list items removed from the configuration are removed",
+			live:            `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo"}, "spec": {"containers": [{"name": "a", "image": "x"}, {"name": "b"}]}}`,
+			managers:        [][]string{{"applier", OperationApply, "f:spec", "f:containers", `k:{"name":"a"}`, "f:name"}, {"applier", OperationApply, "f:spec", "f:containers", `k:{"name":"a"}`, "f:image"}, {"applier", OperationApply, "f:spec", "f:containers", `k:{"name":"b"}`, "f:name"}},
+			config:          `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "spec": {"containers": [{"name": "b"}]}}`,
+			expected:        `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo"}, "spec": {"containers": [{"name": "b"}]}}`,
+			expectedManaged: map[string][]string{"applier/Apply": {`.spec.containers[{"name":"b"}].name`}},
+		},
+		{
+			name:            "associative list items keep their keys while fields managed by others remain",
+			live:            `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo"}, "spec": {"containers": [{"name": "a", "image": "x", "port": 80}]}}`,
+			managers:        [][]string{{"applier", OperationApply, "f:spec", "f:containers", `k:{"name":"a"}`, "f:name"}, {"applier", OperationApply, "f:spec", "f:containers", `k:{"name":"a"}`, "f:image"}, {"other", OperationUpdate, "f:spec", "f:containers", `k:{"name":"a"}`, "f:port"}},
+			config:          `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "spec": {"replicas": 1}}`,
+			expected:        `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo"}, "spec": {"replicas": 1, "containers": [{"name": "a", "port": 80}]}}`,
+			expectedManaged: map[string][]string{"applier/Apply": {".spec.replicas"}, "other/Update": {`.spec.containers[{"name":"a"}].port`}},
+		},
+		{
+			name:            "set items are merged by value",
+			live:            `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo"}, "spec": {"finalizers": ["a"]}}`,
+			managers:        [][]string{{"other", OperationUpdate, "f:spec", "f:finalizers", `v:"a"`}},
+			config:          `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "spec": {"finalizers": ["b", "a"]}}`,
+			expected:        `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo"}, "spec": {"finalizers": ["a", "b"]}}`,
+			expectedManaged: map[string][]string{"other/Update": {`.spec.finalizers["a"]`}, "applier/Apply": {`.spec.finalizers["a"]`, `.spec.finalizers["b"]`}},
+		},
+		{
+			name:              "atomic maps conflict as a whole",
+			live:              `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "metadata": {"name": "foo"}, "spec": {"config": {"key": "a"}}}`,
+			managers:          [][]string{{"other", OperationUpdate, "f:spec", "f:config"}},
+			config:            `{"apiVersion": "mygroup.example.com/v1beta1", "kind": "Noxu", "spec": {"config": {"key": "b"}}}`,
+			expectedConflicts: []string{".spec.config"},
+		},
+	}
+
+	for _, tc := range tests {
+		f := newTestFieldManager()
+
+		var liveObj map[string]interface{}
+		if len(tc.live) > 0 {
+			liveObj = mustUnmarshal(t, tc.live)
+			var entries []managedFieldsEntry
+			for _, m := range tc.managers {
+				if len(entries) == 0 || entries[len(entries)-1].Manager != m[0] || entries[len(entries)-1].Operation != m[1] {
+					entries = append(entries, managedFieldsEntry{Manager: m[0], Operation: m[1], Fields: Set{}})
+				}
+				entries[len(entries)-1].Fields.Insert(m[2:]...)
+			}
+			encodeManagedFields(liveObj, entries)
+		}
+		liveCopy, _ := apiextensions.DeepCopyJSONValue(liveObj)
+		config := mustUnmarshal(t, tc.config)
+
+		result, err := f.Apply(liveObj, config, "applier", tc.force)
+		if liveObj != nil && !reflect.DeepEqual(liveObj, liveCopy) {
+			t.Errorf("%s: live object was mutated", tc.name)
+		}
+		if len(tc.expectedConflicts) > 0 {
+			if !apierrors.IsConflict(err) {
+				t.Errorf("%s: expected conflict, got %v", tc.name, err)
+				continue
+			}
+			var fields []string
+			for _, cause := range err.(*apierrors.StatusError).ErrStatus.Details.Causes {
+				fields = append(fields, cause.Field)
+			}
+			if !reflect.DeepEqual(fields, tc.expectedConflicts) {
+				t.Errorf("%s: expected conflicts at %v, got %v", tc.name, tc.expectedConflicts, fields)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+
+		if managed := managedFields(t, result); !reflect.DeepEqual(managed, tc.expectedManaged) {
+			t.Errorf("%s: expected managed fields %v, got %v", tc.name, tc.expectedManaged, managed)
+		}
+		encodeManagedFields(result, nil)
+		if expected := mustUnmarshal(t, tc.expected); !reflect.DeepEqual(result, expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, expected, result)
+		}
+	}
+}
+
+func TestApplyInvalidValues(t *testing.T) {
+	f := newTestFieldManager()
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+	}{
+		{name: "unencodable set item", config: map[string]interface{}{"spec": map[string]interface{}{"finalizers": []interface{}{math.NaN()}}}},
+		{name: "non-JSON value", config: map[string]interface{}{"spec": map[string]interface{}{"replicas": 1}}},
+	}
+	for _, tc := range tests {
+		if _, err := f.Apply(nil, tc.config, "applier", false); err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+	}
+}
+
+func TestManagedFieldsRoundtrip(t *testing.T) {
+	fields := Set{}
+	fields.Insert("f:spec", "f:replicas")
+	fields.Insert("f:metadata", "f:labels", "f:app.kubernetes.io/name")
+	entries := []managedFieldsEntry{{Manager: "m", Operation: OperationApply, APIVersion: "mygroup.example.com/v1beta1", Time: "2017-10-01T00:00:00Z", Fields: fields}}
+
+	obj := map[string]interface{}{}
+	encodeManagedFields(obj, entries)
+	js, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, found := decodeManagedFields(mustUnmarshal(t, string(js)))
+	if !found || !reflect.DeepEqual(decoded, entries) {
+		t.Errorf("expected %v, got %v", entries, decoded)
+	}
+
+	if decoded, found := decodeManagedFields(mustUnmarshal(t, `{"metadata": {"managedFields": [{"manager": "m", "operation": "Foo"}]}}`)); !found || decoded != nil {
+		t.Errorf("expected malformed managed fields to be dropped, got %v", decoded)
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Set is a set of field paths, stored as a tree. The paths of the set are those leading to empty leaves.
+//
+// The elements of the paths are those of the FieldsV1 representation: "f:<name>" for the fields of
+// objects, "k:<keys>" for the items of associative lists, identified by the JSON encoding of their keys,
+// and "v:<value>" for the items of sets, identified by their JSON encoding.
+type Set map[string]Set
+
+// Insert adds the given path to the set.
+func (s Set) Insert(path ...string) {
+	for _, elem := range path {
+		child, ok := s[elem]
+		if !ok {
+			child = Set{}
+			s[elem] = child
+		}
+		s = child
+	}
+}
+
+// Has returns true if the given path is in the set.
+func (s Set) Has(path ...string) bool {
+	if len(path) == 0 {
+		return false
+	}
+	for _, elem := range path {
+		child, ok := s[elem]
+		if !ok {
+			return false
+		}
+		s = child
+	}
+	return len(s) == 0
+}
+
+// Delete removes the given path from the set, together with the nodes which become empty.
+func (s Set) Delete(path ...string) {
+	if len(path) == 0 {
+		return
+	}
+	child, ok := s[path[0]]
+	if !ok {
+		return
+	}
+	if len(path) == 1 {
+		if len(child) == 0 {
+			delete(s, path[0])
+		}
+		return
+	}
+	child.Delete(path[1:]...)
+	if len(child) == 0 {
+		delete(s, path[0])
+	}
+}
+
+// Empty returns true if the set contains no paths.
+func (s Set) Empty() bool {
+	return len(s) == 0
+}
+
+// Paths returns the paths of the set in lexical order.
+func (s Set) Paths() [][]string {
+	var ret [][]string
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		child := s[k]
+		if len(child) == 0 {
+			ret = append(ret, []string{k})
+			continue
+		}
+		for _, p := range child.Paths() {
+			ret = append(ret, append([]string{k}, p...))
+		}
+	}
+	return ret
+}
+
+// Union returns the paths which are in s or in other.
+func (s Set) Union(other Set) Set {
+	ret := Set{}
+	for _, p := range s.Paths() {
+		ret.Insert(p...)
+	}
+	for _, p := range other.Paths() {
+		ret.Insert(p...)
+	}
+	return ret
+}
+
+// Intersection returns the paths which are in s and in other.
+func (s Set) Intersection(other Set) Set {
+	ret := Set{}
+	for _, p := range s.Paths() {
+		if other.Has(p...) {
+			ret.Insert(p...)
+		}
+	}
+	return ret
+}
+
+// Difference returns the paths which are in s, but not in other.
+func (s Set) Difference(other Set) Set {
+	ret := Set{}
+	for _, p := range s.Paths() {
+		if !other.Has(p...) {
+			ret.Insert(p...)
+		}
+	}
+	return ret
+}
+
+// toFieldsV1 returns the FieldsV1 representation of the set, i.e. a tree of objects whose keys are the
+// path elements.
+func (s Set) toFieldsV1() map[string]interface{} {
+	ret := make(map[string]interface{}, len(s))
+	for k, child := range s {
+		ret[k] = child.toFieldsV1()
+	}
+	return ret
+}
+
+// setFromFieldsV1 parses the FieldsV1 representation of a set.
+func setFromFieldsV1(fields map[string]interface{}) (Set, error) {
+	ret := make(Set, len(fields))
+	for k, v := range fields {
+		if !strings.HasPrefix(k, "f:") && !strings.HasPrefix(k, "k:") && !strings.HasPrefix(k, "v:") {
+			return nil, fmt.Errorf("unexpected key %q, expected a path element prefixed with f:, k: or v:", k)
+		}
+		childFields, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected value of type %T at %q, expected an object", v, k)
+		}
+		child, err := setFromFieldsV1(childFields)
+		if err != nil {
+			return nil, err
+		}
+		ret[k] = child
+	}
+	return ret, nil
+}
+
+// pathString returns the path in JSONPath-like notation, e.g. .spec.replicas, with list items in
+// brackets, e.g. .spec.ports[{"port":80}].
+func pathString(path []string) string {
+	ret := ""
+	for _, elem := range path {
+		if strings.HasPrefix(elem, "f:") {
+			ret += "." + elem[2:]
+		} else {
+			ret += "[" + elem[2:] + "]"
+		}
+	}
+	return ret
+}
+
+// fieldElement returns the path element of the given field of an object.
+func fieldElement(name string) string {
+	return "f:" + name
+}
+
+// keyElement returns the path element of an item of an associative list with the given keys.
+func keyElement(item map[string]interface{}, keys []string) (string, error) {
+	values := make(map[string]interface{}, len(keys))
+	for _, k := range keys {
+		values[k] = item[k]
+	}
+	js, err := jsonString(values)
+	if err != nil {
+		return "", err
+	}
+	return "k:" + js, nil
+}
+
+// valueElement returns the path element of an item of a set.
+func valueElement(item interface{}) (string, error) {
+	js, err := jsonString(item)
+	if err != nil {
+		return "", err
+	}
+	return "v:" + js, nil
+}
+
+// jsonString returns the JSON encoding of a JSON value. encoding/json sorts the fields of objects, such
+// that equal values have equal encodings.
+func jsonString(x interface{}) (string, error) {
+	js, err := json.Marshal(x)
+	if err != nil {
+		return "", fmt.Errorf("cannot encode %T: %v", x, err)
+	}
+	return string(js), nil
+}
+
+// elementKeys returns the names of the keys of a "k:" path element, or nil if elem is not one.
+func elementKeys(elem string) map[string]bool {
+	if !strings.HasPrefix(elem, "k:") {
+		return nil
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal([]byte(elem[2:]), &values); err != nil {
+		return nil
+	}
+	keys := make(map[string]bool, len(values))
+	for k := range values {
+		keys[k] = true
+	}
+	return keys
+}
+
+// itemIndex returns the index of the item of list with the given "k:" or "v:" path element, or -1 if there is none.
+func itemIndex(list []interface{}, elem string) (int, error) {
+	keys := elementKeys(elem)
+	if keys == nil && !strings.HasPrefix(elem, "v:") {
+		return -1, nil
+	}
+	names := make([]string, 0, len(keys))
+	for k := range keys {
+		names = append(names, k)
+	}
+	for i, item := range list {
+		var itemElem string
+		var err error
+		if keys != nil {
+			m, _ := item.(map[string]interface{})
+			itemElem, err = keyElement(m, names)
+		} else {
+			itemElem, err = valueElement(item)
+		}
+		if err != nil {
+			return -1, err
+		}
+		if itemElem == elem {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
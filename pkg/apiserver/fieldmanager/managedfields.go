@@ -0,0 +1,123 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldmanager
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// OperationApply is the operation of managed fields set by apply requests.
+	OperationApply = "Apply"
+	// OperationUpdate is the operation of managed fields set by create, update and patch requests.
+	OperationUpdate = "Update"
+
+	fieldsTypeV1 = "FieldsV1"
+)
+
+// managedFieldsEntry is an entry of metadata.managedFields of a custom resource.
+type managedFieldsEntry struct {
+	Manager    string
+	Operation  string
+	APIVersion string
+	Time       string
+	Fields     Set
+}
+
+// decodeManagedFields returns the managed fields of obj, or false if metadata.managedFields is not set.
+// Malformed managed fields are dropped.
+func decodeManagedFields(obj map[string]interface{}) ([]managedFieldsEntry, bool) {
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	val, found := metadata["managedFields"]
+	if !found {
+		return nil, false
+	}
+	entries, err := decodeManagedFieldsEntries(val)
+	if err != nil {
+		glog.V(4).Infof("Dropping malformed managed fields: %v", err)
+		return nil, true
+	}
+	return entries, true
+}
+
+func decodeManagedFieldsEntries(val interface{}) ([]managedFieldsEntry, error) {
+	list, ok := val.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a list, got %T", val)
+	}
+	ret := make([]managedFieldsEntry, 0, len(list))
+	for i, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected an object at index %d, got %T", i, item)
+		}
+		entry := managedFieldsEntry{}
+		entry.Manager, _ = m["manager"].(string)
+		entry.Operation, _ = m["operation"].(string)
+		entry.APIVersion, _ = m["apiVersion"].(string)
+		entry.Time, _ = m["time"].(string)
+		if len(entry.Manager) == 0 {
+			return nil, fmt.Errorf("missing manager at index %d", i)
+		}
+		if entry.Operation != OperationApply && entry.Operation != OperationUpdate {
+			return nil, fmt.Errorf("unsupported operation %q at index %d", entry.Operation, i)
+		}
+		if fieldsType, _ := m["fieldsType"].(string); fieldsType != fieldsTypeV1 {
+			return nil, fmt.Errorf("unsupported fieldsType %q at index %d", fieldsType, i)
+		}
+		fields, ok := m["fieldsV1"].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected fieldsV1 to be an object at index %d", i)
+		}
+		var err error
+		if entry.Fields, err = setFromFieldsV1(fields); err != nil {
+			return nil, fmt.Errorf("invalid fieldsV1 at index %d: %v", i, err)
+		}
+		ret = append(ret, entry)
+	}
+	return ret, nil
+}
+
+// encodeManagedFields sets metadata.managedFields of obj to the given entries, or removes it if there are none.
+func encodeManagedFields(obj map[string]interface{}, entries []managedFieldsEntry) {
+	metadata, ok := obj["metadata"].(map[string]interface{})
+	if !ok {
+		if len(entries) == 0 {
+			return
+		}
+		metadata = map[string]interface{}{}
+		obj["metadata"] = metadata
+	}
+	if len(entries) == 0 {
+		delete(metadata, "managedFields")
+		return
+	}
+	list := make([]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		list = append(list, map[string]interface{}{
+			"manager":    entry.Manager,
+			"operation":  entry.Operation,
+			"apiVersion": entry.APIVersion,
+			"time":       entry.Time,
+			"fieldsType": fieldsTypeV1,
+			"fieldsV1":   entry.Fields.toFieldsV1(),
+		})
+	}
+	metadata["managedFields"] = list
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics provides the Prometheus metrics of custom resource validation, pruning and conversion.
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	validationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "apiextensions_apiserver_validation_duration_seconds",
+			Help: "Validation duration distribution in seconds of custom resources against their schema for each group, version and resource.",
+			// Use buckets ranging from 100 microseconds to 1.6 seconds.
+			Buckets: prometheus.ExponentialBuckets(0.0001, 4.0, 8),
+		},
+		[]string{"group", "version", "resource"},
+	)
+	conversionWebhookDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "apiextensions_apiserver_conversion_webhook_duration_seconds",
+			Help: "Conversion webhook request latency distribution in seconds for each group, target version and resource.",
+			// Use buckets ranging from 1 millisecond to 16 seconds.
+			Buckets: prometheus.ExponentialBuckets(0.001, 4.0, 8),
+		},
+		[]string{"group", "version", "resource"},
+	)
+	conversionWebhookFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "apiextensions_apiserver_conversion_webhook_failures_total",
+			Help: "Counter of failed conversion webhook requests for each group, target version and resource.",
+		},
+		[]string{"group", "version", "resource"},
+	)
+	conversionWebhookQueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "apiextensions_apiserver_conversion_webhook_queue_depth",
+			Help: "Number of conversions waiting for a free slot to call the conversion webhook, for each group, target version and resource.",
+		},
+		[]string{"group", "version", "resource"},
+	)
+	conversionWebhookThrottled = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "apiextensions_apiserver_conversion_webhook_throttled_total",
+			Help: "Counter of conversions rejected without calling the conversion webhook because of its rate or concurrency limit, for each group, target version and resource.",
+		},
+		[]string{"group", "version", "resource"},
+	)
+	prunedObjects = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "apiextensions_apiserver_pruned_objects_total",
+			Help: "Counter of custom resources of which unknown fields were pruned before they were persisted, for each group, version and resource.",
+		},
+		[]string{"group", "version", "resource"},
+	)
+	priorityLevelRejected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "apiextensions_apiserver_priority_level_rejected_total",
+			Help: "Counter of custom resource requests rejected because their priority level was at its concurrency limit, for each group, version, resource and priority level.",
+		},
+		[]string{"group", "version", "resource", "priority_level"},
+	)
+	ruleCostBudgetExceeded = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "apiextensions_apiserver_validation_rule_cost_budget_exceeded_total",
+			Help: "Counter of custom resource requests which exhausted the runtime cost budget of the validation rules, for each group, version and resource.",
+		},
+		[]string{"group", "version", "resource"},
+	)
+)
+
+var registerMetrics sync.Once
+
+// Register registers the metrics with the default Prometheus registry, which is served at /metrics.
+func Register() {
+	registerMetrics.Do(func() {
+		prometheus.MustRegister(validationDuration)
+		prometheus.MustRegister(conversionWebhookDuration)
+		prometheus.MustRegister(conversionWebhookFailures)
+		prometheus.MustRegister(conversionWebhookQueueDepth)
+		prometheus.MustRegister(conversionWebhookThrottled)
+		prometheus.MustRegister(prunedObjects)
+		prometheus.MustRegister(priorityLevelRejected)
+		prometheus.MustRegister(ruleCostBudgetExceeded)
+	})
+}
+
+// ObserveValidation records the duration of the schema validation of a custom resource of the given resource.
+func ObserveValidation(resource schema.GroupVersionResource, start time.Time) {
+	validationDuration.WithLabelValues(resource.Group, resource.Version, resource.Resource).Observe(time.Since(start).Seconds())
+}
+
+// ObserveConversionWebhook records a conversion webhook request for the given resource and target version,
+// which failed if err is not nil.
+func ObserveConversionWebhook(resource schema.GroupVersionResource, start time.Time, err error) {
+	conversionWebhookDuration.WithLabelValues(resource.Group, resource.Version, resource.Resource).Observe(time.Since(start).Seconds())
+	if err != nil {
+		conversionWebhookFailures.WithLabelValues(resource.Group, resource.Version, resource.Resource).Inc()
+	}
+}
+
+// IncConversionWebhookQueueDepth counts a conversion of the given resource and target version which
+// waits to call the conversion webhook.
+func IncConversionWebhookQueueDepth(resource schema.GroupVersionResource) {
+	conversionWebhookQueueDepth.WithLabelValues(resource.Group, resource.Version, resource.Resource).Inc()
+}
+
+// DecConversionWebhookQueueDepth uncounts a conversion of the given resource and target version which
+// stopped waiting to call the conversion webhook.
+func DecConversionWebhookQueueDepth(resource schema.GroupVersionResource) {
+	conversionWebhookQueueDepth.WithLabelValues(resource.Group, resource.Version, resource.Resource).Dec()
+}
+
+// IncConversionWebhookThrottled counts a conversion of the given resource and target version which was
+// rejected because of the rate or concurrency limit of the conversion webhook.
+func IncConversionWebhookThrottled(resource schema.GroupVersionResource) {
+	conversionWebhookThrottled.WithLabelValues(resource.Group, resource.Version, resource.Resource).Inc()
+}
+
+// IncPrunedObjects counts a custom resource of the given resource of which unknown fields were pruned.
+func IncPrunedObjects(resource schema.GroupVersionResource) {
+	prunedObjects.WithLabelValues(resource.Group, resource.Version, resource.Resource).Inc()
+}
+
+// IncPriorityLevelRejected counts a request for a custom resource of the given resource which was
+// rejected because its priority level was at its concurrency limit.
+func IncPriorityLevelRejected(resource schema.GroupVersionResource, priorityLevel string) {
+	priorityLevelRejected.WithLabelValues(resource.Group, resource.Version, resource.Resource, priorityLevel).Inc()
+}
+
+// IncRuleCostBudgetExceeded counts a request for a custom resource of the given resource which
+// exhausted the runtime cost budget of the validation rules.
+func IncRuleCostBudgetExceeded(resource schema.GroupVersionResource) {
+	ruleCostBudgetExceeded.WithLabelValues(resource.Group, resource.Version, resource.Resource).Inc()
+}
+
+// Reset resets all metrics.
+func Reset() {
+	validationDuration.Reset()
+	conversionWebhookDuration.Reset()
+	conversionWebhookFailures.Reset()
+	conversionWebhookQueueDepth.Reset()
+	conversionWebhookThrottled.Reset()
+	prunedObjects.Reset()
+	priorityLevelRejected.Reset()
+	ruleCostBudgetExceeded.Reset()
+}
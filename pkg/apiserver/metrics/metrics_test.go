@@ -0,0 +1,120 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// gather returns the sample counts of the histograms and the values of the counters and gauges of this package
+// by metric name and group/version/resource.
+func gather(t *testing.T) map[string]map[string]uint64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ret := map[string]map[string]uint64{}
+	for _, family := range families {
+		switch family.GetName() {
+		case "apiextensions_apiserver_validation_duration_seconds", "apiextensions_apiserver_conversion_webhook_duration_seconds",
+			"apiextensions_apiserver_conversion_webhook_failures_total", "apiextensions_apiserver_pruned_objects_total",
+			"apiextensions_apiserver_validation_rule_cost_budget_exceeded_total", "apiextensions_apiserver_conversion_webhook_queue_depth",
+			"apiextensions_apiserver_conversion_webhook_throttled_total", "apiextensions_apiserver_priority_level_rejected_total":
+		default:
+			continue
+		}
+		values := map[string]uint64{}
+		for _, m := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			key := labels["group"] + "/" + labels["version"] + "/" + labels["resource"]
+			if h := m.GetHistogram(); h != nil {
+				values[key] = h.GetSampleCount()
+			} else if g := m.GetGauge(); g != nil {
+				values[key] = uint64(g.GetValue())
+			} else {
+				values[key] = uint64(m.GetCounter().GetValue())
+			}
+		}
+		ret[family.GetName()] = values
+	}
+	return ret
+}
+
+func TestMetrics(t *testing.T) {
+	Register()
+	// registering twice must not panic
+	Register()
+	Reset()
+	defer Reset()
+
+	noxus := schema.GroupVersionResource{Group: "mygroup.example.com", Version: "v1beta1", Resource: "noxus"}
+	curlets := schema.GroupVersionResource{Group: "mygroup.example.com", Version: "v1", Resource: "curlets"}
+
+	ObserveValidation(noxus, time.Now())
+	ObserveValidation(noxus, time.Now())
+	ObserveValidation(curlets, time.Now())
+	ObserveConversionWebhook(noxus, time.Now(), nil)
+	ObserveConversionWebhook(noxus, time.Now(), errors.New("webhook failed"))
+	IncConversionWebhookQueueDepth(noxus)
+	IncConversionWebhookQueueDepth(noxus)
+	DecConversionWebhookQueueDepth(noxus)
+	IncConversionWebhookThrottled(noxus)
+	IncPrunedObjects(curlets)
+	IncPriorityLevelRejected(curlets, "expensive")
+	IncRuleCostBudgetExceeded(noxus)
+
+	expected := map[string]map[string]uint64{
+		"apiextensions_apiserver_validation_duration_seconds": {
+			"mygroup.example.com/v1beta1/noxus": 2,
+			"mygroup.example.com/v1/curlets":    1,
+		},
+		"apiextensions_apiserver_conversion_webhook_duration_seconds": {
+			"mygroup.example.com/v1beta1/noxus": 2,
+		},
+		"apiextensions_apiserver_conversion_webhook_failures_total": {
+			"mygroup.example.com/v1beta1/noxus": 1,
+		},
+		"apiextensions_apiserver_conversion_webhook_queue_depth": {
+			"mygroup.example.com/v1beta1/noxus": 1,
+		},
+		"apiextensions_apiserver_conversion_webhook_throttled_total": {
+			"mygroup.example.com/v1beta1/noxus": 1,
+		},
+		"apiextensions_apiserver_pruned_objects_total": {
+			"mygroup.example.com/v1/curlets": 1,
+		},
+		"apiextensions_apiserver_priority_level_rejected_total": {
+			"mygroup.example.com/v1/curlets": 1,
+		},
+		"apiextensions_apiserver_validation_rule_cost_budget_exceeded_total": {
+			"mygroup.example.com/v1beta1/noxus": 1,
+		},
+	}
+	if actual := gather(t); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
@@ -0,0 +1,395 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"fmt"
+)
+
+type typeKind int
+
+const (
+	dynKind typeKind = iota
+	nullKind
+	boolKind
+	intKind
+	doubleKind
+	stringKind
+	listKind
+	mapKind
+)
+
+// celType is the static type of an expression as far as it is known from the schema.
+type celType struct {
+	kind typeKind
+	// elem is the element type of lists and the value type of maps.
+	elem *celType
+	// fields are the known properties of an object.
+	fields map[string]*celType
+	// closed is true if fields lists all properties an object can have.
+	closed bool
+}
+
+var (
+	dynType    = &celType{kind: dynKind}
+	nullType   = &celType{kind: nullKind}
+	boolType   = &celType{kind: boolKind}
+	intType    = &celType{kind: intKind}
+	doubleType = &celType{kind: doubleKind}
+	stringType = &celType{kind: stringKind}
+)
+
+func (t *celType) String() string {
+	switch t.kind {
+	case nullKind:
+		return "null_type"
+	case boolKind:
+		return "bool"
+	case intKind:
+		return "int"
+	case doubleKind:
+		return "double"
+	case stringKind:
+		return "string"
+	case listKind:
+		return fmt.Sprintf("list(%s)", elemOrDyn(t))
+	case mapKind:
+		if t.fields != nil {
+			return "object"
+		}
+		return fmt.Sprintf("map(string, %s)", elemOrDyn(t))
+	}
+	return "dyn"
+}
+
+func elemOrDyn(t *celType) *celType {
+	if t.elem == nil {
+		return dynType
+	}
+	return t.elem
+}
+
+func isDyn(t *celType) bool {
+	return t.kind == dynKind
+}
+
+func isNumber(t *celType) bool {
+	return t.kind == intKind || t.kind == doubleKind
+}
+
+// scope maps variable names to their types during checking.
+type scope struct {
+	name   string
+	t      *celType
+	parent *scope
+	// used is set if the variable is referenced.
+	used bool
+}
+
+func (s *scope) lookup(name string) (*celType, bool) {
+	for ; s != nil; s = s.parent {
+		if s.name == name {
+			s.used = true
+			return s.t, true
+		}
+	}
+	return nil, false
+}
+
+// checker infers the static type of an expression and reports the errors which
+// can be found without evaluating it.
+type checker struct{}
+
+func (c *checker) check(e expr, sc *scope) (*celType, error) {
+	switch e := e.(type) {
+	case *literalExpr:
+		return typeOfValue(e.val), nil
+	case *identExpr:
+		t, ok := sc.lookup(e.name)
+		if !ok {
+			return nil, fmt.Errorf("undeclared reference to '%s'", e.name)
+		}
+		return t, nil
+	case *selectExpr:
+		operand, err := c.check(e.operand, sc)
+		if err != nil {
+			return nil, err
+		}
+		t, err := selectType(operand, e.field)
+		if err != nil {
+			return nil, err
+		}
+		if e.testOnly {
+			return boolType, nil
+		}
+		return t, nil
+	case *indexExpr:
+		operand, err := c.check(e.operand, sc)
+		if err != nil {
+			return nil, err
+		}
+		index, err := c.check(e.index, sc)
+		if err != nil {
+			return nil, err
+		}
+		switch operand.kind {
+		case dynKind:
+			return dynType, nil
+		case listKind:
+			if !isDyn(index) && !isNumber(index) {
+				return nil, fmt.Errorf("found no matching overload for '_[_]' applied to '(%s, %s)'", operand, index)
+			}
+			return elemOrDyn(operand), nil
+		case mapKind:
+			if !isDyn(index) && index.kind != stringKind {
+				return nil, fmt.Errorf("found no matching overload for '_[_]' applied to '(%s, %s)'", operand, index)
+			}
+			if operand.fields != nil {
+				return dynType, nil
+			}
+			return elemOrDyn(operand), nil
+		}
+		return nil, fmt.Errorf("found no matching overload for '_[_]' applied to '(%s, %s)'", operand, index)
+	case *listExpr:
+		var elem *celType
+		for _, el := range e.elements {
+			t, err := c.check(el, sc)
+			if err != nil {
+				return nil, err
+			}
+			elem = commonType(elem, t)
+		}
+		return &celType{kind: listKind, elem: elem}, nil
+	case *mapExpr:
+		var elem *celType
+		for i := range e.keys {
+			k, err := c.check(e.keys[i], sc)
+			if err != nil {
+				return nil, err
+			}
+			if !isDyn(k) && k.kind != stringKind {
+				return nil, fmt.Errorf("unsupported map key type '%s'", k)
+			}
+			v, err := c.check(e.values[i], sc)
+			if err != nil {
+				return nil, err
+			}
+			elem = commonType(elem, v)
+		}
+		return &celType{kind: mapKind, elem: elem}, nil
+	case *unaryExpr:
+		t, err := c.check(e.operand, sc)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case isDyn(t):
+			if e.op == "!" {
+				return boolType, nil
+			}
+			return dynType, nil
+		case e.op == "!" && t.kind == boolKind:
+			return boolType, nil
+		case e.op == "-" && isNumber(t):
+			return t, nil
+		}
+		return nil, fmt.Errorf("found no matching overload for '%s_' applied to '(%s)'", e.op, t)
+	case *binaryExpr:
+		l, err := c.check(e.left, sc)
+		if err != nil {
+			return nil, err
+		}
+		r, err := c.check(e.right, sc)
+		if err != nil {
+			return nil, err
+		}
+		return binaryType(e.op, l, r)
+	case *conditionalExpr:
+		cond, err := c.check(e.cond, sc)
+		if err != nil {
+			return nil, err
+		}
+		if !isDyn(cond) && cond.kind != boolKind {
+			return nil, fmt.Errorf("found no matching overload for '_?_:_' applied to '(%s, ...)'", cond)
+		}
+		t, err := c.check(e.trueBranch, sc)
+		if err != nil {
+			return nil, err
+		}
+		f, err := c.check(e.falseBranch, sc)
+		if err != nil {
+			return nil, err
+		}
+		return commonType(t, f), nil
+	case *comprehensionExpr:
+		rng, err := c.check(e.iterRange, sc)
+		if err != nil {
+			return nil, err
+		}
+		var iterType *celType
+		switch rng.kind {
+		case dynKind:
+			iterType = dynType
+		case listKind:
+			iterType = elemOrDyn(rng)
+		case mapKind:
+			iterType = stringType
+		default:
+			return nil, fmt.Errorf("%s() cannot be applied to type '%s'", e.macro, rng)
+		}
+		inner := &scope{name: e.iterVar, t: iterType, parent: sc}
+		if e.predicate != nil {
+			p, err := c.check(e.predicate, inner)
+			if err != nil {
+				return nil, err
+			}
+			if !isDyn(p) && p.kind != boolKind {
+				return nil, fmt.Errorf("%s() predicate must evaluate to bool, got '%s'", e.macro, p)
+			}
+		}
+		switch e.macro {
+		case "map":
+			t, err := c.check(e.transform, inner)
+			if err != nil {
+				return nil, err
+			}
+			return &celType{kind: listKind, elem: t}, nil
+		case "filter":
+			return &celType{kind: listKind, elem: iterType}, nil
+		}
+		return boolType, nil
+	case *callExpr:
+		var args []*celType
+		if e.target != nil {
+			t, err := c.check(e.target, sc)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, t)
+		}
+		for _, a := range e.args {
+			t, err := c.check(a, sc)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, t)
+		}
+		fn, ok := lookupFunction(e.function, e.target != nil)
+		if !ok {
+			if e.target != nil {
+				return nil, fmt.Errorf("undeclared reference to '%s' (in container '%s')", e.function, args[0])
+			}
+			return nil, fmt.Errorf("undeclared reference to '%s'", e.function)
+		}
+		if fn.arity >= 0 && len(args) != fn.arity {
+			return nil, fmt.Errorf("found no matching overload for '%s' applied to %d arguments", e.function, len(args))
+		}
+		return fn.result, nil
+	}
+	return nil, fmt.Errorf("unexpected expression %T", e)
+}
+
+func binaryType(op string, l, r *celType) (*celType, error) {
+	switch op {
+	case "&&", "||":
+		if (isDyn(l) || l.kind == boolKind) && (isDyn(r) || r.kind == boolKind) {
+			return boolType, nil
+		}
+	case "==", "!=":
+		return boolType, nil
+	case "<", "<=", ">", ">=":
+		if isDyn(l) || isDyn(r) || (isNumber(l) && isNumber(r)) {
+			return boolType, nil
+		}
+		if l.kind == r.kind && (l.kind == stringKind || l.kind == boolKind) {
+			return boolType, nil
+		}
+	case "in":
+		switch r.kind {
+		case dynKind, listKind, mapKind:
+			return boolType, nil
+		}
+	case "+", "-", "*", "/", "%":
+		if isDyn(l) || isDyn(r) {
+			if isDyn(l) {
+				return r, nil
+			}
+			return l, nil
+		}
+		if l.kind != r.kind {
+			break
+		}
+		switch {
+		case l.kind == intKind:
+			return intType, nil
+		case l.kind == doubleKind && op != "%":
+			return doubleType, nil
+		case op == "+" && l.kind == stringKind:
+			return stringType, nil
+		case op == "+" && l.kind == listKind:
+			return commonType(l, r), nil
+		}
+	}
+	return nil, fmt.Errorf("found no matching overload for '_%s_' applied to '(%s, %s)'", op, l, r)
+}
+
+func selectType(operand *celType, field string) (*celType, error) {
+	switch operand.kind {
+	case dynKind:
+		return dynType, nil
+	case mapKind:
+		if t, ok := operand.fields[field]; ok {
+			return t, nil
+		}
+		if operand.closed {
+			return nil, fmt.Errorf("undefined field '%s'", field)
+		}
+		return elemOrDyn(operand), nil
+	}
+	return nil, fmt.Errorf("type '%s' does not support field selection", operand)
+}
+
+// commonType returns the common type of a and b, or dyn if they differ.
+func commonType(a, b *celType) *celType {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.kind != b.kind || a.kind == mapKind:
+		return dynType
+	case a.kind == listKind:
+		return &celType{kind: listKind, elem: commonType(elemOrDyn(a), elemOrDyn(b))}
+	}
+	return a
+}
+
+func typeOfValue(v interface{}) *celType {
+	switch v.(type) {
+	case nil:
+		return nullType
+	case bool:
+		return boolType
+	case int64:
+		return intType
+	case float64:
+		return doubleType
+	case string:
+		return stringType
+	}
+	return dynType
+}
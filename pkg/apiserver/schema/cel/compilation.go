@@ -0,0 +1,292 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+// ScopedVarName is the variable name assigned to the locally scoped data element of a CEL validation
+// expression.
+const ScopedVarName = "self"
+
+// OldScopedVarName is the variable name assigned to the old value of the locally scoped data element
+// of a CEL validation rule on update.
+const OldScopedVarName = "oldSelf"
+
+// Program is a compiled validation rule.
+type Program struct {
+	source string
+	ast    expr
+	// transition is set if the rule references oldSelf.
+	transition bool
+	// messageExpression is the compiled messageExpression of the rule, if any.
+	messageExpression *ValueProgram
+	// fieldPath is the resolved fieldPath of the rule, if any.
+	fieldPath []fieldPathElement
+}
+
+// CompilationResult represents the result of compiling a validation rule.
+type CompilationResult struct {
+	Program *Program
+	Error   error
+	// MessageExpressionError is the error compiling the messageExpression of the rule, if any.
+	MessageExpressionError error
+	// FieldPathError is the error resolving the fieldPath of the rule against the schema, if any.
+	FieldPathError error
+}
+
+// Compile compiles all the x-kubernetes-validations rules of the given schema node and returns
+// one CompilationResult per rule, in the order of the rules. Nested schemas are not compiled.
+// isResourceRoot must be true if s is the root schema of a custom resource, in which case
+// apiVersion, kind and metadata are accessible.
+func Compile(s *apiextensions.JSONSchemaProps, isResourceRoot bool) []CompilationResult {
+	if s == nil || len(s.XValidations) == 0 {
+		return nil
+	}
+	self := schemaType(s)
+	if isResourceRoot {
+		self = withResourceRootFields(self)
+	}
+	results := make([]CompilationResult, len(s.XValidations))
+	for i, rule := range s.XValidations {
+		results[i] = compileRule(rule, self)
+		if results[i].Program == nil {
+			continue
+		}
+		if len(rule.FieldPath) > 0 {
+			results[i].Program.fieldPath, results[i].FieldPathError = parseFieldPath(rule.FieldPath, s, isResourceRoot)
+		}
+	}
+	return results
+}
+
+// ValueProgram is a compiled expression which computes a value.
+type ValueProgram struct {
+	source string
+	ast    expr
+}
+
+// EstimatedCost returns a static estimate of the cost of evaluating the expression, like
+// Program.EstimatedCost.
+func (p *ValueProgram) EstimatedCost() uint64 {
+	return estimateCost(p.ast)
+}
+
+// CompileValue compiles an expression which computes a value from self, whose type is described
+// by the given schema. s may be nil, in which case self is untyped. isResourceRoot has the same
+// meaning as for Compile.
+func CompileValue(expression string, s *apiextensions.JSONSchemaProps, isResourceRoot bool) (*ValueProgram, error) {
+	if len(expression) == 0 {
+		return nil, fmt.Errorf("expression is not specified")
+	}
+	self := schemaType(s)
+	if isResourceRoot {
+		self = withResourceRootFields(self)
+	}
+	ast, _, err := compileExpr(expression, &scope{name: ScopedVarName, t: self})
+	if err != nil {
+		return nil, err
+	}
+	return &ValueProgram{source: expression, ast: ast}, nil
+}
+
+// Eval evaluates the program with self bound to the given value. The result is a JSON compatible
+// value, i.e. nil, a bool, an int64, a float64, a string, a []interface{} or a
+// map[string]interface{}.
+func (p *ValueProgram) Eval(self interface{}) (interface{}, error) {
+	return p.eval(&activation{name: ScopedVarName, value: self}, nil)
+}
+
+func (p *ValueProgram) eval(act *activation, budget *costBudget) (interface{}, error) {
+	in := &interpreter{budget: budget}
+	return in.eval(p.ast, act)
+}
+
+// String returns the source of the expression.
+func (p *ValueProgram) String() string {
+	return p.source
+}
+
+// compileExpr parses and type checks the given expression with the variables of the given scope.
+func compileExpr(src string, sc *scope) (expr, *celType, error) {
+	ast, err := parse(src)
+	if err != nil {
+		return nil, nil, fmt.Errorf("compilation failed: %v", err)
+	}
+	t, err := (&checker{}).check(ast, sc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("compilation failed: %v", err)
+	}
+	return ast, t, nil
+}
+
+func compileRule(rule apiextensions.ValidationRule, self *celType) CompilationResult {
+	if len(rule.Rule) == 0 {
+		return CompilationResult{Error: fmt.Errorf("rule is not specified")}
+	}
+	// oldSelf has the same type as self
+	oldSelf := &scope{name: OldScopedVarName, t: self}
+	ast, t, err := compileExpr(rule.Rule, &scope{name: ScopedVarName, t: self, parent: oldSelf})
+	if err != nil {
+		return CompilationResult{Error: err}
+	}
+	if !isDyn(t) && t.kind != boolKind {
+		return CompilationResult{Error: fmt.Errorf("cel expression must evaluate to a bool, found %s", t)}
+	}
+	result := CompilationResult{Program: &Program{source: rule.Rule, ast: ast, transition: oldSelf.used}}
+	if len(rule.MessageExpression) > 0 {
+		result.Program.messageExpression, result.MessageExpressionError = compileMessageExpression(rule.MessageExpression, self, result.Program.transition)
+	}
+	return result
+}
+
+// compileMessageExpression compiles the messageExpression of a rule, which may only reference
+// oldSelf if the rule is a transition rule.
+func compileMessageExpression(expression string, self *celType, transition bool) (*ValueProgram, error) {
+	sc := &scope{name: ScopedVarName, t: self}
+	if transition {
+		sc.parent = &scope{name: OldScopedVarName, t: self}
+	}
+	ast, t, err := compileExpr(expression, sc)
+	if err != nil {
+		if !transition {
+			if _, _, oldSelfErr := compileExpr(expression, &scope{name: ScopedVarName, t: self, parent: &scope{name: OldScopedVarName, t: self}}); oldSelfErr == nil {
+				return nil, fmt.Errorf("messageExpression must not reference oldSelf unless the rule does")
+			}
+		}
+		return nil, err
+	}
+	if !isDyn(t) && t.kind != stringKind {
+		return nil, fmt.Errorf("messageExpression must evaluate to a string, found %s", t)
+	}
+	return &ValueProgram{source: expression, ast: ast}, nil
+}
+
+// IsTransitionRule returns true if the rule references oldSelf. Transition rules are only
+// evaluated on updates, if the old value exists.
+func (p *Program) IsTransitionRule() bool {
+	return p.transition
+}
+
+// message evaluates the messageExpression of the rule in the given activation. It returns an empty
+// string if the rule has no messageExpression or it fails to evaluate to a string.
+func (p *Program) message(act *activation, budget *costBudget) string {
+	if p.messageExpression == nil {
+		return ""
+	}
+	v, err := p.messageExpression.eval(act, budget)
+	if err != nil {
+		return ""
+	}
+	s, _ := v.(string)
+	return strings.TrimSpace(s)
+}
+
+// Eval evaluates the program with self bound to the given value. The result must be a bool.
+func (p *Program) Eval(self interface{}) (bool, error) {
+	return p.eval(&activation{name: ScopedVarName, value: self}, nil)
+}
+
+// EvalTransition evaluates the program with self bound to the given value and oldSelf bound to the
+// old value. The result must be a bool.
+func (p *Program) EvalTransition(self, oldSelf interface{}) (bool, error) {
+	return p.eval(&activation{vars: map[string]interface{}{ScopedVarName: self, OldScopedVarName: oldSelf}}, nil)
+}
+
+func (p *Program) eval(act *activation, budget *costBudget) (bool, error) {
+	in := &interpreter{budget: budget}
+	v, err := in.eval(p.ast, act)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("cel expression must evaluate to a bool, found %s", typeName(v))
+	}
+	return b, nil
+}
+
+// String returns the source of the rule.
+func (p *Program) String() string {
+	return p.source
+}
+
+// schemaType derives the static type of values described by the given schema.
+func schemaType(s *apiextensions.JSONSchemaProps) *celType {
+	if s == nil || s.XIntOrString {
+		return dynType
+	}
+	switch s.Type {
+	case "boolean":
+		return boolType
+	case "integer":
+		return intType
+	case "number":
+		return doubleType
+	case "string":
+		return stringType
+	case "array":
+		if s.Items != nil && s.Items.Schema != nil {
+			return &celType{kind: listKind, elem: schemaType(s.Items.Schema)}
+		}
+		return &celType{kind: listKind}
+	case "object", "":
+		if s.Type == "" && len(s.Properties) == 0 && s.AdditionalProperties == nil {
+			return dynType
+		}
+		t := &celType{kind: mapKind}
+		if len(s.Properties) > 0 {
+			t.fields = make(map[string]*celType, len(s.Properties))
+			for name := range s.Properties {
+				prop := s.Properties[name]
+				t.fields[name] = schemaType(&prop)
+			}
+		}
+		switch {
+		case s.AdditionalProperties == nil:
+			t.closed = len(s.Properties) > 0 && (s.XPreserveUnknownFields == nil || !*s.XPreserveUnknownFields)
+		case s.AdditionalProperties.Schema != nil:
+			t.elem = schemaType(s.AdditionalProperties.Schema)
+		case !s.AdditionalProperties.Allows:
+			t.closed = true
+		}
+		if s.XEmbeddedResource {
+			return withResourceRootFields(t)
+		}
+		return t
+	}
+	return dynType
+}
+
+// withResourceRootFields adds the fields every custom resource has to the type of the root schema.
+func withResourceRootFields(t *celType) *celType {
+	if t.kind != mapKind || t.fields == nil {
+		return t
+	}
+	root := &celType{kind: mapKind, elem: t.elem, closed: t.closed, fields: make(map[string]*celType, len(t.fields)+3)}
+	for name, f := range t.fields {
+		root.fields[name] = f
+	}
+	root.fields["apiVersion"] = stringType
+	root.fields["kind"] = stringType
+	root.fields["metadata"] = &celType{kind: mapKind}
+	return root
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"errors"
+)
+
+// errCostBudgetExceeded is returned by the interpreter if the cost budget is exhausted.
+var errCostBudgetExceeded = errors.New("the runtime cost budget is exceeded")
+
+// costBudget is the remaining runtime cost of the rules evaluated for a single request, in the units
+// of EstimatedCost, i.e. evaluated expression nodes.
+type costBudget struct {
+	remaining uint64
+	exceeded  bool
+}
+
+// newCostBudget returns a budget of the given cost, or nil, i.e. no budget, if it is zero.
+func newCostBudget(cost uint64) *costBudget {
+	if cost == 0 {
+		return nil
+	}
+	return &costBudget{remaining: cost}
+}
+
+func (b *costBudget) isExceeded() bool {
+	return b != nil && b.exceeded
+}
+
+// charge charges the evaluation of a single expression node to the budget. It returns
+// errCostBudgetExceeded once the budget is exhausted.
+func (b *costBudget) charge() error {
+	if b == nil {
+		return nil
+	}
+	if b.remaining == 0 {
+		b.exceeded = true
+		return errCostBudgetExceeded
+	}
+	b.remaining--
+	return nil
+}
+
+// comprehensionCostFactor is the number of iterations assumed for each comprehension when estimating
+// the cost of a rule, as the sizes of lists and maps are not known statically.
+const comprehensionCostFactor = 10
+
+// EstimatedCost returns a static estimate of the cost of evaluating the rule, i.e. the number of
+// expression nodes which are evaluated. The predicate and transform of a comprehension are counted
+// comprehensionCostFactor times. The cost of the messageExpression of the rule is included.
+func (p *Program) EstimatedCost() uint64 {
+	cost := estimateCost(p.ast)
+	if p.messageExpression != nil {
+		cost += p.messageExpression.EstimatedCost()
+	}
+	return cost
+}
+
+func estimateCost(e expr) uint64 {
+	switch e := e.(type) {
+	case nil:
+		return 0
+	case *selectExpr:
+		return 1 + estimateCost(e.operand)
+	case *indexExpr:
+		return 1 + estimateCost(e.operand) + estimateCost(e.index)
+	case *callExpr:
+		cost := 1 + estimateCost(e.target)
+		for _, arg := range e.args {
+			cost += estimateCost(arg)
+		}
+		return cost
+	case *listExpr:
+		cost := uint64(1)
+		for _, element := range e.elements {
+			cost += estimateCost(element)
+		}
+		return cost
+	case *mapExpr:
+		cost := uint64(1)
+		for i := range e.keys {
+			cost += estimateCost(e.keys[i]) + estimateCost(e.values[i])
+		}
+		return cost
+	case *unaryExpr:
+		return 1 + estimateCost(e.operand)
+	case *binaryExpr:
+		return 1 + estimateCost(e.left) + estimateCost(e.right)
+	case *conditionalExpr:
+		return 1 + estimateCost(e.cond) + estimateCost(e.trueBranch) + estimateCost(e.falseBranch)
+	case *comprehensionExpr:
+		return 1 + estimateCost(e.iterRange) + comprehensionCostFactor*(estimateCost(e.predicate)+estimateCost(e.transform))
+	default:
+		// literals and identifiers
+		return 1
+	}
+}
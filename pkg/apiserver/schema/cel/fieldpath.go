@@ -0,0 +1,97 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// fieldPathElement is a property, or a key of additionalProperties, in the fieldPath of a rule.
+type fieldPathElement struct {
+	name string
+	key  bool
+}
+
+// parseFieldPath parses the fieldPath of a rule, i.e. a sequence of ".name" and "['name']"
+// selectors relative to the value described by s, and resolves it against the schema. Properties
+// must be declared in the schema or be the keys of additionalProperties, arrays cannot be
+// traversed. isResourceRoot has the same meaning as for Compile.
+func parseFieldPath(fieldPath string, s *apiextensions.JSONSchemaProps, isResourceRoot bool) ([]fieldPathElement, error) {
+	var ret []fieldPathElement
+	rest := fieldPath
+	for len(rest) > 0 {
+		var name string
+		switch {
+		case rest[0] == '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			name, rest = rest[1:end+1], rest[end+1:]
+		case strings.HasPrefix(rest, "['"):
+			end := strings.Index(rest, "']")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated selector in %q", fieldPath)
+			}
+			name, rest = rest[2:end], rest[end+2:]
+		default:
+			return nil, fmt.Errorf("expected a '.name' or \"['name']\" selector at %q", rest)
+		}
+		if len(name) == 0 {
+			return nil, fmt.Errorf("empty selector in %q", fieldPath)
+		}
+
+		switch {
+		case s == nil:
+			// the value is not described further, e.g. within metadata of the resource root
+			ret = append(ret, fieldPathElement{name: name})
+		case s.Type == "array":
+			return nil, fmt.Errorf("%q refers to the items of an array, which cannot be referenced", fieldPath)
+		default:
+			if prop, ok := s.Properties[name]; ok {
+				ret = append(ret, fieldPathElement{name: name})
+				s = &prop
+			} else if (isResourceRoot || s.XEmbeddedResource) && (name == "apiVersion" || name == "kind" || name == "metadata") {
+				ret = append(ret, fieldPathElement{name: name})
+				s = nil
+			} else if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+				ret = append(ret, fieldPathElement{name: name, key: true})
+				s = s.AdditionalProperties.Schema
+			} else {
+				return nil, fmt.Errorf("%q does not refer to a field declared in the schema", fieldPath)
+			}
+		}
+		isResourceRoot = false
+	}
+	return ret, nil
+}
+
+// withFieldPath returns fldPath extended by the parsed fieldPath of a rule.
+func withFieldPath(fldPath *field.Path, elements []fieldPathElement) *field.Path {
+	for _, e := range elements {
+		if e.key {
+			fldPath = fldPath.Key(e.name)
+		} else {
+			fldPath = fldPath.Child(e.name)
+		}
+	}
+	return fldPath
+}
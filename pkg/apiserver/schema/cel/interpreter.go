@@ -0,0 +1,881 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Values handled by the interpreter are the ones produced by decoding JSON into
+// unstructured content: nil, bool, int64, float64, string, []interface{} and
+// map[string]interface{}.
+
+// activation holds the variables visible to an expression. Comprehensions push
+// their iteration variable on top of the enclosing activation.
+type activation struct {
+	name   string
+	value  interface{}
+	vars   map[string]interface{}
+	parent *activation
+}
+
+func (a *activation) lookup(name string) (interface{}, bool) {
+	for ; a != nil; a = a.parent {
+		if a.vars != nil {
+			if v, ok := a.vars[name]; ok {
+				return v, true
+			}
+			continue
+		}
+		if a.name == name {
+			return a.value, true
+		}
+	}
+	return nil, false
+}
+
+// interpreter evaluates a single expression. It is not safe for concurrent use.
+type interpreter struct {
+	// regexps caches the patterns compiled by matches() during the evaluation.
+	regexps map[string]*regexp.Regexp
+	// budget is charged for every evaluated expression node. It is unlimited if nil.
+	budget *costBudget
+}
+
+func (in *interpreter) eval(e expr, act *activation) (interface{}, error) {
+	if err := in.budget.charge(); err != nil {
+		return nil, err
+	}
+	switch e := e.(type) {
+	case *literalExpr:
+		return e.val, nil
+	case *identExpr:
+		v, ok := act.lookup(e.name)
+		if !ok {
+			return nil, fmt.Errorf("undeclared reference to '%s'", e.name)
+		}
+		return v, nil
+	case *selectExpr:
+		operand, err := in.eval(e.operand, act)
+		if err != nil {
+			return nil, err
+		}
+		m, ok := operand.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("type '%s' does not support field selection", typeName(operand))
+		}
+		v, ok := m[e.field]
+		if e.testOnly {
+			return ok, nil
+		}
+		if !ok {
+			return nil, fmt.Errorf("no such key: %s", e.field)
+		}
+		return v, nil
+	case *indexExpr:
+		operand, err := in.eval(e.operand, act)
+		if err != nil {
+			return nil, err
+		}
+		index, err := in.eval(e.index, act)
+		if err != nil {
+			return nil, err
+		}
+		return indexValue(operand, index)
+	case *listExpr:
+		list := make([]interface{}, 0, len(e.elements))
+		for _, elem := range e.elements {
+			v, err := in.eval(elem, act)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	case *mapExpr:
+		m := make(map[string]interface{}, len(e.keys))
+		for i := range e.keys {
+			k, err := in.eval(e.keys[i], act)
+			if err != nil {
+				return nil, err
+			}
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("unsupported map key type '%s'", typeName(k))
+			}
+			if _, ok := m[key]; ok {
+				return nil, fmt.Errorf("duplicate map key: %s", key)
+			}
+			v, err := in.eval(e.values[i], act)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = v
+		}
+		return m, nil
+	case *unaryExpr:
+		v, err := in.eval(e.operand, act)
+		if err != nil {
+			return nil, err
+		}
+		return unaryOp(e.op, v)
+	case *binaryExpr:
+		switch e.op {
+		case "&&", "||":
+			return in.logical(e, act)
+		}
+		l, err := in.eval(e.left, act)
+		if err != nil {
+			return nil, err
+		}
+		r, err := in.eval(e.right, act)
+		if err != nil {
+			return nil, err
+		}
+		return binaryOp(e.op, l, r)
+	case *conditionalExpr:
+		c, err := in.eval(e.cond, act)
+		if err != nil {
+			return nil, err
+		}
+		b, ok := c.(bool)
+		if !ok {
+			return nil, noSuchOverload("_?_:_", c)
+		}
+		if b {
+			return in.eval(e.trueBranch, act)
+		}
+		return in.eval(e.falseBranch, act)
+	case *comprehensionExpr:
+		return in.comprehension(e, act)
+	case *callExpr:
+		return in.call(e, act)
+	}
+	return nil, fmt.Errorf("unexpected expression %T", e)
+}
+
+// logical implements the commutative short-circuit semantics of && and ||:
+// an error on one side is absorbed if the other side determines the result.
+func (in *interpreter) logical(e *binaryExpr, act *activation) (interface{}, error) {
+	decisive := e.op == "||"
+	l, lerr := in.eval(e.left, act)
+	if lerr == nil {
+		lb, ok := l.(bool)
+		if !ok {
+			lerr = noSuchOverload(e.op, l)
+		} else if lb == decisive {
+			return decisive, nil
+		}
+	}
+	r, rerr := in.eval(e.right, act)
+	if rerr == nil {
+		rb, ok := r.(bool)
+		if !ok {
+			rerr = noSuchOverload(e.op, r)
+		} else if rb == decisive {
+			return decisive, nil
+		}
+	}
+	if lerr != nil {
+		return nil, lerr
+	}
+	if rerr != nil {
+		return nil, rerr
+	}
+	return !decisive, nil
+}
+
+func (in *interpreter) comprehension(e *comprehensionExpr, act *activation) (interface{}, error) {
+	rng, err := in.eval(e.iterRange, act)
+	if err != nil {
+		return nil, err
+	}
+	var items []interface{}
+	switch r := rng.(type) {
+	case []interface{}:
+		items = r
+	case map[string]interface{}:
+		keys := make([]string, 0, len(r))
+		for k := range r {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			items = append(items, k)
+		}
+	default:
+		return nil, fmt.Errorf("%s() cannot be applied to type '%s'", e.macro, typeName(rng))
+	}
+
+	var result []interface{}
+	count := 0
+	var firstErr error
+	for _, item := range items {
+		scope := &activation{name: e.iterVar, value: item, parent: act}
+		if e.predicate != nil {
+			v, err := in.eval(e.predicate, scope)
+			if err != nil {
+				// for all and exists a later decisive element absorbs the error
+				if e.macro == "all" || e.macro == "exists" {
+					if firstErr == nil {
+						firstErr = err
+					}
+					continue
+				}
+				return nil, err
+			}
+			b, ok := v.(bool)
+			if !ok {
+				return nil, fmt.Errorf("%s() predicate must evaluate to bool, got '%s'", e.macro, typeName(v))
+			}
+			switch e.macro {
+			case "all":
+				if !b {
+					return false, nil
+				}
+				continue
+			case "exists":
+				if b {
+					return true, nil
+				}
+				continue
+			case "exists_one":
+				if b {
+					count++
+				}
+				continue
+			case "filter":
+				if b {
+					result = append(result, item)
+				}
+				continue
+			}
+			if !b {
+				continue
+			}
+		}
+		// map transform
+		v, err := in.eval(e.transform, scope)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+	switch e.macro {
+	case "all":
+		if firstErr != nil {
+			return nil, firstErr
+		}
+		return true, nil
+	case "exists":
+		if firstErr != nil {
+			return nil, firstErr
+		}
+		return false, nil
+	case "exists_one":
+		return count == 1, nil
+	}
+	if result == nil {
+		result = []interface{}{}
+	}
+	return result, nil
+}
+
+func (in *interpreter) call(e *callExpr, act *activation) (interface{}, error) {
+	var args []interface{}
+	if e.target != nil {
+		t, err := in.eval(e.target, act)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, t)
+	}
+	for _, a := range e.args {
+		v, err := in.eval(a, act)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+	fn, ok := lookupFunction(e.function, e.target != nil)
+	if !ok {
+		return nil, fmt.Errorf("undeclared reference to '%s'", e.function)
+	}
+	if fn.arity >= 0 && len(args) != fn.arity {
+		return nil, noSuchOverload(e.function, args...)
+	}
+	if e.function == "matches" {
+		return in.matches(args)
+	}
+	return fn.impl(args)
+}
+
+func (in *interpreter) matches(args []interface{}) (interface{}, error) {
+	s, ok1 := args[0].(string)
+	pattern, ok2 := args[1].(string)
+	if !ok1 || !ok2 {
+		return nil, noSuchOverload("matches", args...)
+	}
+	re, ok := in.regexps[pattern]
+	if !ok {
+		var err error
+		if re, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q: %v", pattern, err)
+		}
+		if in.regexps == nil {
+			in.regexps = map[string]*regexp.Regexp{}
+		}
+		in.regexps[pattern] = re
+	}
+	return re.MatchString(s), nil
+}
+
+func indexValue(operand, index interface{}) (interface{}, error) {
+	switch o := operand.(type) {
+	case []interface{}:
+		var i int64
+		switch idx := index.(type) {
+		case int64:
+			i = idx
+		case float64:
+			if idx != math.Trunc(idx) {
+				return nil, fmt.Errorf("unsupported index value %v in list", idx)
+			}
+			i = int64(idx)
+		default:
+			return nil, noSuchOverload("_[_]", operand, index)
+		}
+		if i < 0 || i >= int64(len(o)) {
+			return nil, fmt.Errorf("index out of bounds: %d", i)
+		}
+		return o[i], nil
+	case map[string]interface{}:
+		key, ok := index.(string)
+		if !ok {
+			return nil, fmt.Errorf("no such key: %v", index)
+		}
+		v, ok := o[key]
+		if !ok {
+			return nil, fmt.Errorf("no such key: %s", key)
+		}
+		return v, nil
+	}
+	return nil, noSuchOverload("_[_]", operand, index)
+}
+
+func unaryOp(op string, v interface{}) (interface{}, error) {
+	switch op {
+	case "!":
+		if b, ok := v.(bool); ok {
+			return !b, nil
+		}
+	case "-":
+		switch n := v.(type) {
+		case int64:
+			if n == math.MinInt64 {
+				return nil, errIntOverflow
+			}
+			return -n, nil
+		case float64:
+			return -n, nil
+		}
+	}
+	return nil, noSuchOverload(op+"_", v)
+}
+
+var errIntOverflow = fmt.Errorf("integer overflow")
+
+func binaryOp(op string, l, r interface{}) (interface{}, error) {
+	switch op {
+	case "==":
+		return equal(l, r), nil
+	case "!=":
+		return !equal(l, r), nil
+	case "<", "<=", ">", ">=":
+		c, err := compare(l, r)
+		if err != nil {
+			return nil, noSuchOverload(op, l, r)
+		}
+		if c == unordered {
+			return false, nil
+		}
+		switch op {
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		}
+		return c >= 0, nil
+	case "in":
+		switch rr := r.(type) {
+		case []interface{}:
+			for _, elem := range rr {
+				if equal(l, elem) {
+					return true, nil
+				}
+			}
+			return false, nil
+		case map[string]interface{}:
+			k, ok := l.(string)
+			if !ok {
+				return false, nil
+			}
+			_, found := rr[k]
+			return found, nil
+		}
+		return nil, noSuchOverload(op, l, r)
+	}
+
+	switch lv := l.(type) {
+	case int64:
+		rv, ok := r.(int64)
+		if !ok {
+			break
+		}
+		return intArithmetic(op, lv, rv)
+	case float64:
+		rv, ok := r.(float64)
+		if !ok {
+			break
+		}
+		switch op {
+		case "+":
+			return lv + rv, nil
+		case "-":
+			return lv - rv, nil
+		case "*":
+			return lv * rv, nil
+		case "/":
+			return lv / rv, nil
+		}
+	case string:
+		if rv, ok := r.(string); ok && op == "+" {
+			return lv + rv, nil
+		}
+	case []interface{}:
+		if rv, ok := r.([]interface{}); ok && op == "+" {
+			list := make([]interface{}, 0, len(lv)+len(rv))
+			list = append(list, lv...)
+			return append(list, rv...), nil
+		}
+	}
+	return nil, noSuchOverload(op, l, r)
+}
+
+func intArithmetic(op string, l, r int64) (interface{}, error) {
+	switch op {
+	case "+":
+		if (r > 0 && l > math.MaxInt64-r) || (r < 0 && l < math.MinInt64-r) {
+			return nil, errIntOverflow
+		}
+		return l + r, nil
+	case "-":
+		if (r < 0 && l > math.MaxInt64+r) || (r > 0 && l < math.MinInt64+r) {
+			return nil, errIntOverflow
+		}
+		return l - r, nil
+	case "*":
+		if l != 0 && r != 0 {
+			p := l * r
+			if p/r != l || (l == -1 && r == math.MinInt64) || (r == -1 && l == math.MinInt64) {
+				return nil, errIntOverflow
+			}
+			return p, nil
+		}
+		return int64(0), nil
+	case "/", "%":
+		if r == 0 {
+			if op == "/" {
+				return nil, fmt.Errorf("division by zero")
+			}
+			return nil, fmt.Errorf("modulus by zero")
+		}
+		if l == math.MinInt64 && r == -1 {
+			if op == "%" {
+				return int64(0), nil
+			}
+			return nil, errIntOverflow
+		}
+		if op == "/" {
+			return l / r, nil
+		}
+		return l % r, nil
+	}
+	return nil, noSuchOverload(op, l, r)
+}
+
+// equal implements heterogeneous equality: numbers compare by value regardless
+// of int or double representation, all other values of different types are unequal.
+func equal(l, r interface{}) bool {
+	switch lv := l.(type) {
+	case nil:
+		return r == nil
+	case bool:
+		rv, ok := r.(bool)
+		return ok && lv == rv
+	case string:
+		rv, ok := r.(string)
+		return ok && lv == rv
+	case int64, float64:
+		c, err := compare(l, r)
+		return err == nil && c == 0
+	case []interface{}:
+		rv, ok := r.([]interface{})
+		if !ok || len(lv) != len(rv) {
+			return false
+		}
+		for i := range lv {
+			if !equal(lv[i], rv[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		rv, ok := r.(map[string]interface{})
+		if !ok || len(lv) != len(rv) {
+			return false
+		}
+		for k, v := range lv {
+			other, ok := rv[k]
+			if !ok || !equal(v, other) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// unordered is returned by compare if one of the operands is NaN.
+const unordered = 2
+
+// compare orders numbers, strings and bools. Other values are not ordered.
+func compare(l, r interface{}) (int, error) {
+	switch lv := l.(type) {
+	case int64:
+		switch rv := r.(type) {
+		case int64:
+			return compareInts(lv, rv), nil
+		case float64:
+			if c := compareDoubleInt(rv, lv); c != unordered {
+				return -c, nil
+			}
+			return unordered, nil
+		}
+	case float64:
+		switch rv := r.(type) {
+		case float64:
+			switch {
+			case lv < rv:
+				return -1, nil
+			case lv > rv:
+				return 1, nil
+			case lv == rv:
+				return 0, nil
+			}
+			return unordered, nil
+		case int64:
+			return compareDoubleInt(lv, rv), nil
+		}
+	case string:
+		if rv, ok := r.(string); ok {
+			return strings.Compare(lv, rv), nil
+		}
+	case bool:
+		if rv, ok := r.(bool); ok {
+			switch {
+			case lv == rv:
+				return 0, nil
+			case !lv:
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+	return 0, fmt.Errorf("values are not comparable")
+}
+
+func compareInts(l, r int64) int {
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	}
+	return 0
+}
+
+func compareDoubleInt(d float64, i int64) int {
+	switch {
+	case math.IsNaN(d):
+		return unordered
+	case d < math.MinInt64:
+		return -1
+	case d >= math.MaxInt64:
+		return 1
+	}
+	if t := math.Trunc(d); int64(t) != i {
+		return compareInts(int64(t), i)
+	}
+	switch {
+	case d > math.Trunc(d):
+		return 1
+	case d < math.Trunc(d):
+		return -1
+	}
+	return 0
+}
+
+func noSuchOverload(function string, args ...interface{}) error {
+	names := make([]string, 0, len(args))
+	for _, a := range args {
+		names = append(names, typeName(a))
+	}
+	return fmt.Errorf("no such overload: %s(%s)", function, strings.Join(names, ", "))
+}
+
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null_type"
+	case bool:
+		return "bool"
+	case int64:
+		return "int"
+	case float64:
+		return "double"
+	case string:
+		return "string"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "map"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// function describes a builtin function. arity counts the receiver of
+// method-style calls and is -1 for variadic functions.
+type function struct {
+	arity int
+	impl  func(args []interface{}) (interface{}, error)
+	// result is the static result type for the type checker
+	result *celType
+}
+
+var (
+	globalFunctions = map[string]function{
+		"size":    {arity: 1, impl: size, result: intType},
+		"int":     {arity: 1, impl: toInt, result: intType},
+		"double":  {arity: 1, impl: toDouble, result: doubleType},
+		"string":  {arity: 1, impl: toString, result: stringType},
+		"bool":    {arity: 1, impl: toBool, result: boolType},
+		"dyn":     {arity: 1, impl: func(args []interface{}) (interface{}, error) { return args[0], nil }, result: dynType},
+		"matches": {arity: 2, result: boolType},
+	}
+	memberFunctions = map[string]function{
+		"size":       {arity: 1, impl: size, result: intType},
+		"contains":   {arity: 2, impl: stringPredicate("contains", strings.Contains), result: boolType},
+		"startsWith": {arity: 2, impl: stringPredicate("startsWith", strings.HasPrefix), result: boolType},
+		"endsWith":   {arity: 2, impl: stringPredicate("endsWith", strings.HasSuffix), result: boolType},
+		"matches":    {arity: 2, result: boolType},
+		"lowerAscii": {arity: 1, impl: stringMapping("lowerAscii", asciiMapping(strings.ToLower)), result: stringType},
+		"upperAscii": {arity: 1, impl: stringMapping("upperAscii", asciiMapping(strings.ToUpper)), result: stringType},
+		"trim":       {arity: 1, impl: stringMapping("trim", strings.TrimSpace), result: stringType},
+		"indexOf":    {arity: 2, impl: indexOf, result: intType},
+		"split":      {arity: 2, impl: split, result: &celType{kind: listKind, elem: stringType}},
+		"join":       {arity: -1, impl: join, result: stringType},
+	}
+)
+
+func lookupFunction(name string, member bool) (function, bool) {
+	if member {
+		fn, ok := memberFunctions[name]
+		return fn, ok
+	}
+	fn, ok := globalFunctions[name]
+	return fn, ok
+}
+
+func size(args []interface{}) (interface{}, error) {
+	switch v := args[0].(type) {
+	case string:
+		return int64(utf8.RuneCountInString(v)), nil
+	case []interface{}:
+		return int64(len(v)), nil
+	case map[string]interface{}:
+		return int64(len(v)), nil
+	}
+	return nil, noSuchOverload("size", args...)
+}
+
+func toInt(args []interface{}) (interface{}, error) {
+	switch v := args[0].(type) {
+	case int64:
+		return v, nil
+	case float64:
+		if math.IsNaN(v) || v <= math.MinInt64 || v >= math.MaxInt64 {
+			return nil, fmt.Errorf("double is out of range for int conversion")
+		}
+		return int64(v), nil
+	case string:
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert %q to int", v)
+		}
+		return i, nil
+	}
+	return nil, noSuchOverload("int", args...)
+}
+
+func toDouble(args []interface{}) (interface{}, error) {
+	switch v := args[0].(type) {
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert %q to double", v)
+		}
+		return f, nil
+	}
+	return nil, noSuchOverload("double", args...)
+}
+
+func toString(args []interface{}) (interface{}, error) {
+	switch v := args[0].(type) {
+	case string:
+		return v, nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return nil, noSuchOverload("string", args...)
+}
+
+func toBool(args []interface{}) (interface{}, error) {
+	switch v := args[0].(type) {
+	case bool:
+		return v, nil
+	case string:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert %q to bool", v)
+		}
+		return b, nil
+	}
+	return nil, noSuchOverload("bool", args...)
+}
+
+func stringPredicate(name string, fn func(s, arg string) bool) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		s, ok1 := args[0].(string)
+		arg, ok2 := args[1].(string)
+		if !ok1 || !ok2 {
+			return nil, noSuchOverload(name, args...)
+		}
+		return fn(s, arg), nil
+	}
+}
+
+func stringMapping(name string, fn func(s string) string) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		s, ok := args[0].(string)
+		if !ok {
+			return nil, noSuchOverload(name, args...)
+		}
+		return fn(s), nil
+	}
+}
+
+// asciiMapping applies fn to the ASCII letters of a string only.
+func asciiMapping(fn func(string) string) func(string) string {
+	return func(s string) string {
+		b := []byte(s)
+		for i, c := range b {
+			if c < utf8.RuneSelf {
+				b[i] = fn(string(c))[0]
+			}
+		}
+		return string(b)
+	}
+}
+
+func indexOf(args []interface{}) (interface{}, error) {
+	s, ok1 := args[0].(string)
+	sub, ok2 := args[1].(string)
+	if !ok1 || !ok2 {
+		return nil, noSuchOverload("indexOf", args...)
+	}
+	i := strings.Index(s, sub)
+	if i < 0 {
+		return int64(-1), nil
+	}
+	return int64(utf8.RuneCountInString(s[:i])), nil
+}
+
+func split(args []interface{}) (interface{}, error) {
+	s, ok1 := args[0].(string)
+	sep, ok2 := args[1].(string)
+	if !ok1 || !ok2 {
+		return nil, noSuchOverload("split", args...)
+	}
+	parts := strings.Split(s, sep)
+	list := make([]interface{}, len(parts))
+	for i, p := range parts {
+		list[i] = p
+	}
+	return list, nil
+}
+
+func join(args []interface{}) (interface{}, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, noSuchOverload("join", args...)
+	}
+	list, ok := args[0].([]interface{})
+	if !ok {
+		return nil, noSuchOverload("join", args...)
+	}
+	sep := ""
+	if len(args) == 2 {
+		if sep, ok = args[1].(string); !ok {
+			return nil, noSuchOverload("join", args...)
+		}
+	}
+	parts := make([]string, len(list))
+	for i, elem := range list {
+		s, ok := elem.(string)
+		if !ok {
+			return nil, noSuchOverload("join", args...)
+		}
+		parts[i] = s
+	}
+	return strings.Join(parts, sep), nil
+}
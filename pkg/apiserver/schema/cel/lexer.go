@@ -0,0 +1,270 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenInt
+	tokenUint
+	tokenDouble
+	tokenString
+	tokenPunct
+)
+
+type token struct {
+	kind tokenKind
+	pos  int
+	// text is the identifier or punctuation, or the raw literal text
+	text string
+	// val holds the decoded value of literals
+	val interface{}
+}
+
+// punctuation is ordered so that longer operators are matched first.
+var punctuation = []string{
+	"&&", "||", "==", "!=", "<=", ">=",
+	"<", ">", "!", "?", ":", "+", "-", "*", "/", "%",
+	".", ",", "(", ")", "[", "]", "{", "}",
+}
+
+type lexer struct {
+	src string
+	pos int
+}
+
+// tokenize splits src into tokens. The last token is always tokenEOF.
+func tokenize(src string) ([]token, error) {
+	l := &lexer{src: src}
+	var tokens []token
+	for {
+		t, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+		if t.kind == tokenEOF {
+			return tokens, nil
+		}
+	}
+}
+
+func (l *lexer) errorf(pos int, format string, args ...interface{}) error {
+	return fmt.Errorf("syntax error at position %d: %s", pos, fmt.Sprintf(format, args...))
+}
+
+func (l *lexer) skipSpaceAndComments() {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			l.pos++
+		case strings.HasPrefix(l.src[l.pos:], "//"):
+			if i := strings.IndexByte(l.src[l.pos:], '\n'); i >= 0 {
+				l.pos += i + 1
+			} else {
+				l.pos = len(l.src)
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipSpaceAndComments()
+	start := l.pos
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+	c := l.src[l.pos]
+	switch {
+	case c == '_' || isLetter(c):
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.pos++
+		}
+		ident := l.src[start:l.pos]
+		// raw and bytes string prefixes
+		if l.pos < len(l.src) && (l.src[l.pos] == '"' || l.src[l.pos] == '\'') {
+			switch strings.ToLower(ident) {
+			case "r":
+				return l.scanString(start, true)
+			case "b", "br", "rb":
+				return token{}, l.errorf(start, "bytes literals are not supported")
+			}
+		}
+		return token{kind: tokenIdent, pos: start, text: ident}, nil
+	case isDigit(c) || (c == '.' && l.pos+1 < len(l.src) && isDigit(l.src[l.pos+1])):
+		return l.scanNumber(start)
+	case c == '"' || c == '\'':
+		return l.scanString(start, false)
+	}
+	for _, p := range punctuation {
+		if strings.HasPrefix(l.src[l.pos:], p) {
+			l.pos += len(p)
+			return token{kind: tokenPunct, pos: start, text: p}, nil
+		}
+	}
+	r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+	return token{}, l.errorf(start, "unexpected character %q", r)
+}
+
+func (l *lexer) scanNumber(start int) (token, error) {
+	if strings.HasPrefix(l.src[l.pos:], "0x") || strings.HasPrefix(l.src[l.pos:], "0X") {
+		l.pos += 2
+		for l.pos < len(l.src) && isHexDigit(l.src[l.pos]) {
+			l.pos++
+		}
+		return l.finishInt(start, l.src[start:l.pos], 0)
+	}
+	isDouble := false
+	for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+		l.pos++
+	}
+	if l.pos+1 < len(l.src) && l.src[l.pos] == '.' && isDigit(l.src[l.pos+1]) {
+		isDouble = true
+		l.pos++
+		for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.pos++
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		isDouble = true
+		l.pos++
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.pos++
+		}
+		if l.pos >= len(l.src) || !isDigit(l.src[l.pos]) {
+			return token{}, l.errorf(start, "malformed exponent in %q", l.src[start:l.pos])
+		}
+		for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.pos++
+		}
+	}
+	text := l.src[start:l.pos]
+	if isDouble {
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return token{}, l.errorf(start, "invalid double literal %q", text)
+		}
+		return token{kind: tokenDouble, pos: start, text: text, val: f}, nil
+	}
+	return l.finishInt(start, text, 10)
+}
+
+func (l *lexer) finishInt(start int, text string, base int) (token, error) {
+	if l.pos < len(l.src) && (l.src[l.pos] == 'u' || l.src[l.pos] == 'U') {
+		l.pos++
+		u, err := strconv.ParseUint(text, base, 64)
+		if err != nil || u > 1<<63-1 {
+			return token{}, l.errorf(start, "invalid uint literal %q", l.src[start:l.pos])
+		}
+		return token{kind: tokenUint, pos: start, text: l.src[start:l.pos], val: int64(u)}, nil
+	}
+	// the minus sign is a separate token, so allow 1<<63 here and let the parser negate it
+	u, err := strconv.ParseUint(text, base, 64)
+	if err != nil || u > 1<<63 {
+		return token{}, l.errorf(start, "invalid int literal %q", text)
+	}
+	return token{kind: tokenInt, pos: start, text: text, val: u}, nil
+}
+
+func (l *lexer) scanString(start int, raw bool) (token, error) {
+	quote := l.src[l.pos]
+	delim := string(quote)
+	if strings.HasPrefix(l.src[l.pos:], strings.Repeat(delim, 3)) {
+		delim = strings.Repeat(delim, 3)
+	}
+	l.pos += len(delim)
+	var b strings.Builder
+	for {
+		if l.pos >= len(l.src) {
+			return token{}, l.errorf(start, "unterminated string literal")
+		}
+		if strings.HasPrefix(l.src[l.pos:], delim) {
+			l.pos += len(delim)
+			return token{kind: tokenString, pos: start, text: l.src[start:l.pos], val: b.String()}, nil
+		}
+		c := l.src[l.pos]
+		if c == '\n' && len(delim) == 1 {
+			return token{}, l.errorf(start, "newline in string literal")
+		}
+		if c != '\\' || raw {
+			b.WriteByte(c)
+			l.pos++
+			continue
+		}
+		if l.pos+1 >= len(l.src) {
+			return token{}, l.errorf(start, "unterminated string literal")
+		}
+		l.pos++
+		switch e := l.src[l.pos]; e {
+		case 'a':
+			b.WriteByte('\a')
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'v':
+			b.WriteByte('\v')
+		case '\\', '\'', '"', '`', '?':
+			b.WriteByte(e)
+		case 'u', 'U', 'x':
+			n := map[byte]int{'u': 4, 'U': 8, 'x': 2}[e]
+			if l.pos+n >= len(l.src) {
+				return token{}, l.errorf(l.pos, "invalid escape sequence")
+			}
+			code, err := strconv.ParseUint(l.src[l.pos+1:l.pos+1+n], 16, 32)
+			if err != nil || !utf8.ValidRune(rune(code)) {
+				return token{}, l.errorf(l.pos, "invalid escape sequence")
+			}
+			b.WriteRune(rune(code))
+			l.pos += n
+		default:
+			return token{}, l.errorf(l.pos, "invalid escape sequence \\%c", e)
+		}
+		l.pos++
+	}
+}
+
+func isLetter(c byte) bool {
+	return c < utf8.RuneSelf && unicode.IsLetter(rune(c))
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
@@ -0,0 +1,523 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"fmt"
+)
+
+// maxNestingDepth bounds the recursion of the parser for pathological input.
+const maxNestingDepth = 100
+
+// expr is a node of the abstract syntax tree of an expression.
+type expr interface {
+	position() int
+}
+
+type literalExpr struct {
+	pos int
+	val interface{}
+}
+
+type identExpr struct {
+	pos  int
+	name string
+}
+
+// selectExpr is a field selection operand.field. If testOnly is set, the node
+// is the argument of the has() macro and evaluates to whether the field is present.
+type selectExpr struct {
+	pos      int
+	operand  expr
+	field    string
+	testOnly bool
+}
+
+type indexExpr struct {
+	pos     int
+	operand expr
+	index   expr
+}
+
+// callExpr is a global function call (target is nil) or a receiver-style
+// method call target.function(args).
+type callExpr struct {
+	pos      int
+	function string
+	target   expr
+	args     []expr
+}
+
+type listExpr struct {
+	pos      int
+	elements []expr
+}
+
+type mapExpr struct {
+	pos    int
+	keys   []expr
+	values []expr
+}
+
+type unaryExpr struct {
+	pos     int
+	op      string
+	operand expr
+}
+
+type binaryExpr struct {
+	pos   int
+	op    string
+	left  expr
+	right expr
+}
+
+type conditionalExpr struct {
+	pos         int
+	cond        expr
+	trueBranch  expr
+	falseBranch expr
+}
+
+// comprehensionExpr is the expanded form of the all, exists, exists_one, map
+// and filter macros. filter is optional for map, and nil for the other macros.
+type comprehensionExpr struct {
+	pos       int
+	macro     string
+	iterRange expr
+	iterVar   string
+	predicate expr
+	transform expr
+}
+
+func (e *literalExpr) position() int       { return e.pos }
+func (e *identExpr) position() int         { return e.pos }
+func (e *selectExpr) position() int        { return e.pos }
+func (e *indexExpr) position() int         { return e.pos }
+func (e *callExpr) position() int          { return e.pos }
+func (e *listExpr) position() int          { return e.pos }
+func (e *mapExpr) position() int           { return e.pos }
+func (e *unaryExpr) position() int         { return e.pos }
+func (e *binaryExpr) position() int        { return e.pos }
+func (e *conditionalExpr) position() int   { return e.pos }
+func (e *comprehensionExpr) position() int { return e.pos }
+
+var reservedWords = map[string]bool{
+	"as": true, "break": true, "const": true, "continue": true, "else": true,
+	"for": true, "function": true, "if": true, "import": true, "let": true,
+	"loop": true, "package": true, "namespace": true, "return": true,
+	"var": true, "void": true, "while": true,
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+// parse parses a CEL expression into its abstract syntax tree.
+func parse(src string) (expr, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	e, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.errorf(t, "unexpected %s", describe(t))
+	}
+	return e, nil
+}
+
+func describe(t token) string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenPunct:
+		return fmt.Sprintf("'%s'", t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+func (p *parser) errorf(t token, format string, args ...interface{}) error {
+	return fmt.Errorf("syntax error at position %d: %s", t.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) advance() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isPunct(text string) bool {
+	t := p.peek()
+	return t.kind == tokenPunct && t.text == text
+}
+
+func (p *parser) isIdent(text string) bool {
+	t := p.peek()
+	return t.kind == tokenIdent && t.text == text
+}
+
+func (p *parser) expect(text string) (token, error) {
+	if !p.isPunct(text) {
+		t := p.peek()
+		return t, p.errorf(t, "expected '%s', found %s", text, describe(t))
+	}
+	return p.advance(), nil
+}
+
+func (p *parser) enter() error {
+	p.depth++
+	if p.depth > maxNestingDepth {
+		return p.errorf(p.peek(), "expression nesting exceeds maximum depth of %d", maxNestingDepth)
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+// expr = conditionalOr ["?" conditionalOr ":" expr]
+func (p *parser) expr() (expr, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	cond, err := p.conditionalOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.isPunct("?") {
+		return cond, nil
+	}
+	q := p.advance()
+	t, err := p.conditionalOr()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	f, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	return &conditionalExpr{pos: q.pos, cond: cond, trueBranch: t, falseBranch: f}, nil
+}
+
+func (p *parser) conditionalOr() (expr, error) {
+	return p.binary([]string{"||"}, p.conditionalAnd)
+}
+
+func (p *parser) conditionalAnd() (expr, error) {
+	return p.binary([]string{"&&"}, p.relation)
+}
+
+func (p *parser) relation() (expr, error) {
+	return p.binary([]string{"<", "<=", ">", ">=", "==", "!=", "in"}, p.addition)
+}
+
+func (p *parser) addition() (expr, error) {
+	return p.binary([]string{"+", "-"}, p.multiplication)
+}
+
+func (p *parser) multiplication() (expr, error) {
+	return p.binary([]string{"*", "/", "%"}, p.unary)
+}
+
+// binary parses a left-associative chain of the given operators.
+func (p *parser) binary(ops []string, operand func() (expr, error)) (expr, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if !isOperator(t, ops) {
+			return left, nil
+		}
+		p.advance()
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{pos: t.pos, op: t.text, left: left, right: right}
+	}
+}
+
+func isOperator(t token, ops []string) bool {
+	if t.kind != tokenPunct && !(t.kind == tokenIdent && t.text == "in") {
+		return false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+// unary = member | "!" {"!"} member | "-" {"-"} member
+func (p *parser) unary() (expr, error) {
+	if p.isPunct("!") || p.isPunct("-") {
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+
+		t := p.advance()
+		// fold negative numeric literals, this is required for math.MinInt64
+		if t.text == "-" {
+			if n := p.peek(); n.kind == tokenInt || n.kind == tokenDouble {
+				operand, err := p.member()
+				if err != nil {
+					return nil, err
+				}
+				if lit, ok := operand.(*literalExpr); ok && lit.pos == n.pos {
+					switch v := lit.val.(type) {
+					case int64:
+						if v == -1<<63 {
+							// the literal was 9223372036854775808, which negates to math.MinInt64
+							return &literalExpr{pos: t.pos, val: v}, nil
+						}
+						return &literalExpr{pos: t.pos, val: -v}, nil
+					case float64:
+						return &literalExpr{pos: t.pos, val: -v}, nil
+					}
+				}
+				return &unaryExpr{pos: t.pos, op: t.text, operand: operand}, nil
+			}
+		}
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{pos: t.pos, op: t.text, operand: operand}, nil
+	}
+	return p.member()
+}
+
+// member = primary {"." IDENT ["(" [exprList] ")"] | "[" expr "]"}
+func (p *parser) member() (expr, error) {
+	operand, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.isPunct("."):
+			dot := p.advance()
+			name := p.advance()
+			if name.kind != tokenIdent {
+				return nil, p.errorf(name, "expected field or method name, found %s", describe(name))
+			}
+			if !p.isPunct("(") {
+				operand = &selectExpr{pos: dot.pos, operand: operand, field: name.text}
+				continue
+			}
+			p.advance()
+			args, err := p.exprList(")")
+			if err != nil {
+				return nil, err
+			}
+			operand, err = p.methodOrMacro(name, operand, args)
+			if err != nil {
+				return nil, err
+			}
+		case p.isPunct("["):
+			t := p.advance()
+			index, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			operand = &indexExpr{pos: t.pos, operand: operand, index: index}
+		default:
+			return operand, nil
+		}
+	}
+}
+
+func (p *parser) methodOrMacro(name token, target expr, args []expr) (expr, error) {
+	switch name.text {
+	case "all", "exists", "exists_one", "filter", "map":
+		maxArgs := 2
+		if name.text == "map" {
+			maxArgs = 3
+		}
+		if len(args) < 2 || len(args) > maxArgs {
+			break
+		}
+		v, ok := args[0].(*identExpr)
+		if !ok {
+			return nil, p.errorf(name, "argument must be a simple name")
+		}
+		c := &comprehensionExpr{pos: name.pos, macro: name.text, iterRange: target, iterVar: v.name}
+		switch {
+		case name.text == "map" && len(args) == 2:
+			c.transform = args[1]
+		case name.text == "map":
+			c.predicate = args[1]
+			c.transform = args[2]
+		default:
+			c.predicate = args[1]
+		}
+		return c, nil
+	}
+	return &callExpr{pos: name.pos, function: name.text, target: target, args: args}, nil
+}
+
+// primary = ["."] IDENT ["(" [exprList] ")"] | "(" expr ")" | "[" [exprList] "]" | "{" [mapInits] "}" | literal
+func (p *parser) primary() (expr, error) {
+	t := p.advance()
+	switch t.kind {
+	case tokenInt:
+		u := t.val.(uint64)
+		if u > 1<<63-1 {
+			// only valid as the operand of a unary minus, see unary()
+			if p.pos < 2 || p.tokens[p.pos-2].text != "-" {
+				return nil, p.errorf(t, "int literal %s overflows", t.text)
+			}
+		}
+		return &literalExpr{pos: t.pos, val: int64(u)}, nil
+	case tokenUint, tokenDouble, tokenString:
+		return &literalExpr{pos: t.pos, val: t.val}, nil
+	case tokenIdent:
+		switch t.text {
+		case "true":
+			return &literalExpr{pos: t.pos, val: true}, nil
+		case "false":
+			return &literalExpr{pos: t.pos, val: false}, nil
+		case "null":
+			return &literalExpr{pos: t.pos, val: nil}, nil
+		case "in":
+			return nil, p.errorf(t, "unexpected %s", describe(t))
+		}
+		if reservedWords[t.text] {
+			return nil, p.errorf(t, "reserved identifier %q", t.text)
+		}
+		if !p.isPunct("(") {
+			return &identExpr{pos: t.pos, name: t.text}, nil
+		}
+		p.advance()
+		args, err := p.exprList(")")
+		if err != nil {
+			return nil, err
+		}
+		if t.text == "has" {
+			if len(args) != 1 {
+				return nil, p.errorf(t, "has() requires exactly one argument")
+			}
+			sel, ok := args[0].(*selectExpr)
+			if !ok {
+				return nil, p.errorf(t, "invalid argument to has() macro")
+			}
+			return &selectExpr{pos: sel.pos, operand: sel.operand, field: sel.field, testOnly: true}, nil
+		}
+		return &callExpr{pos: t.pos, function: t.text, args: args}, nil
+	case tokenPunct:
+		switch t.text {
+		case ".":
+			// leading dot for the root namespace, which has no special meaning here
+			name := p.peek()
+			if name.kind != tokenIdent {
+				return nil, p.errorf(name, "expected identifier, found %s", describe(name))
+			}
+			return p.primary()
+		case "(":
+			e, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return e, nil
+		case "[":
+			elems, err := p.exprList("]")
+			if err != nil {
+				return nil, err
+			}
+			return &listExpr{pos: t.pos, elements: elems}, nil
+		case "{":
+			return p.mapInits(t)
+		}
+	}
+	return nil, p.errorf(t, "unexpected %s", describe(t))
+}
+
+// exprList parses a comma separated list of expressions up to and including
+// the closing token. A trailing comma is allowed.
+func (p *parser) exprList(closing string) ([]expr, error) {
+	var list []expr
+	for !p.isPunct(closing) {
+		e, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+		if !p.isPunct(",") {
+			break
+		}
+		p.advance()
+	}
+	if _, err := p.expect(closing); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (p *parser) mapInits(open token) (expr, error) {
+	m := &mapExpr{pos: open.pos}
+	for !p.isPunct("}") {
+		k, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		v, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		m.keys = append(m.keys, k)
+		m.values = append(m.values, v)
+		if !p.isPunct(",") {
+			break
+		}
+		p.advance()
+	}
+	if _, err := p.expect("}"); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
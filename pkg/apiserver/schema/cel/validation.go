@@ -0,0 +1,286 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"fmt"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validator validates custom resources against the x-kubernetes-validations rules of a schema.
+type Validator struct {
+	schema               *apiextensions.JSONSchemaProps
+	compiledRules        []CompilationResult
+	Items                *Validator
+	Properties           map[string]*Validator
+	AdditionalProperties *Validator
+}
+
+// NewValidator returns a validator for the x-kubernetes-validations rules of the given schema and
+// all its nested schemas. It returns nil if the schema does not contain any rules. isResourceRoot
+// must be true if s is the root schema of a custom resource.
+func NewValidator(s *apiextensions.JSONSchemaProps, isResourceRoot bool) *Validator {
+	if s == nil {
+		return nil
+	}
+	v := &Validator{schema: s, compiledRules: Compile(s, isResourceRoot)}
+	found := len(v.compiledRules) > 0
+	if s.Items != nil && s.Items.Schema != nil {
+		v.Items = NewValidator(s.Items.Schema, false)
+		found = found || v.Items != nil
+	}
+	for name := range s.Properties {
+		prop := s.Properties[name]
+		if p := NewValidator(&prop, false); p != nil {
+			if v.Properties == nil {
+				v.Properties = map[string]*Validator{}
+			}
+			v.Properties[name] = p
+			found = true
+		}
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		v.AdditionalProperties = NewValidator(s.AdditionalProperties.Schema, false)
+		found = found || v.AdditionalProperties != nil
+	}
+	if !found {
+		return nil
+	}
+	return v
+}
+
+// Validate validates all x-kubernetes-validations rules in Validator against obj and returns any
+// errors. obj is the unstructured content of the value the schema of the validator describes.
+// The rules share the given runtime cost budget, in the units of Program.EstimatedCost. Once it is
+// exhausted, no further rules are evaluated, an error is returned and budgetExceeded is true.
+// A zero budget is unlimited.
+func (v *Validator) Validate(fldPath *field.Path, obj interface{}, costBudget uint64) (errs field.ErrorList, budgetExceeded bool) {
+	if v == nil || obj == nil {
+		return nil, false
+	}
+	budget := newCostBudget(costBudget)
+	errs = v.validate(fldPath, toCELValue(v.schema, obj), nil, false, budget, nil)
+	return errs, budget.isExceeded()
+}
+
+// ValidateUpdate validates obj like Validate, but ratchets against oldObj: values which are
+// unchanged compared to the corresponding values of oldObj are not validated again. Hence,
+// objects persisted before a rule was added or tightened can still be updated as long as the
+// violating values are left alone. Map values are correlated by key, the items of arrays with
+// x-kubernetes-list-type map by their x-kubernetes-list-map-keys. Other array items are not
+// correlated, i.e. they are validated again if anything in the array changed.
+//
+// Transition rules, i.e. rules referencing oldSelf, are evaluated only for changed values which
+// are correlated with an old value, with oldSelf bound to the old value.
+func (v *Validator) ValidateUpdate(fldPath *field.Path, obj, oldObj interface{}, costBudget uint64) (errs field.ErrorList, budgetExceeded bool) {
+	if v == nil || obj == nil {
+		return nil, false
+	}
+	budget := newCostBudget(costBudget)
+	errs = v.validate(fldPath, toCELValue(v.schema, obj), toCELValue(v.schema, oldObj), oldObj != nil, budget, nil)
+	return errs, budget.isExceeded()
+}
+
+// RuleFailure is a rule which failed.
+type RuleFailure struct {
+	apiextensions.ValidationRule
+	// Transition is set if the rule is a transition rule, i.e. references oldSelf.
+	Transition bool
+}
+
+// RuleFailures maps the errors of failed rules to the rules which failed.
+type RuleFailures map[*field.Error]RuleFailure
+
+// ValidateWithFailures validates obj like ValidateUpdate, or like Validate if oldObj is nil, and
+// records the rule which failed for every error of a failed rule in failures. Errors which are not
+// caused by a failed rule, like compile errors or an exceeded cost budget, are not recorded.
+func (v *Validator) ValidateWithFailures(fldPath *field.Path, obj, oldObj interface{}, costBudget uint64, failures RuleFailures) (errs field.ErrorList, budgetExceeded bool) {
+	if v == nil || obj == nil {
+		return nil, false
+	}
+	budget := newCostBudget(costBudget)
+	errs = v.validate(fldPath, toCELValue(v.schema, obj), toCELValue(v.schema, oldObj), oldObj != nil, budget, failures)
+	return errs, budget.isExceeded()
+}
+
+func (v *Validator) validate(fldPath *field.Path, obj, oldObj interface{}, correlated bool, budget *costBudget, failures RuleFailures) field.ErrorList {
+	if v == nil || obj == nil || budget.isExceeded() {
+		return nil
+	}
+	if correlated && apiequality.Semantic.DeepEqual(obj, oldObj) {
+		return nil
+	}
+	allErrs := v.validateExpressions(fldPath, obj, oldObj, correlated, budget, failures)
+	switch obj := obj.(type) {
+	case map[string]interface{}:
+		oldMap, _ := oldObj.(map[string]interface{})
+		for k, val := range obj {
+			oldVal, found := oldMap[k]
+			if p, ok := v.Properties[k]; ok {
+				allErrs = append(allErrs, p.validate(fldPath.Child(k), val, oldVal, correlated && found, budget, failures)...)
+			} else if _, ok := v.schema.Properties[k]; !ok && v.AdditionalProperties != nil {
+				allErrs = append(allErrs, v.AdditionalProperties.validate(fldPath.Key(k), val, oldVal, correlated && found, budget, failures)...)
+			}
+		}
+	case []interface{}:
+		var oldItems map[string]interface{}
+		if correlated {
+			oldItems = v.mapListItems(oldObj)
+		}
+		for i, val := range obj {
+			oldVal, found := oldItems[v.mapListKey(val)]
+			allErrs = append(allErrs, v.Items.validate(fldPath.Index(i), val, oldVal, oldItems != nil && found, budget, failures)...)
+		}
+	}
+	return allErrs
+}
+
+// mapListItems returns the items of the array list by their map list key if the schema is an
+// array with x-kubernetes-list-type map, and nil otherwise.
+func (v *Validator) mapListItems(list interface{}) map[string]interface{} {
+	items, ok := list.([]interface{})
+	if !ok || v.schema.XListType == nil || *v.schema.XListType != "map" || len(v.schema.XListMapKeys) == 0 {
+		return nil
+	}
+	ret := make(map[string]interface{}, len(items))
+	for _, item := range items {
+		ret[v.mapListKey(item)] = item
+	}
+	return ret
+}
+
+// mapListKey returns the values of the x-kubernetes-list-map-keys properties of item as a string.
+func (v *Validator) mapListKey(item interface{}) string {
+	m, _ := item.(map[string]interface{})
+	key := make([]interface{}, 0, len(v.schema.XListMapKeys))
+	for _, k := range v.schema.XListMapKeys {
+		key = append(key, m[k])
+	}
+	return fmt.Sprintf("%#v", key)
+}
+
+func (v *Validator) validateExpressions(fldPath *field.Path, obj, oldObj interface{}, correlated bool, budget *costBudget, failures RuleFailures) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, compiled := range v.compiledRules {
+		rule := v.schema.XValidations[i]
+		if compiled.Error != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, v.schema.Type, fmt.Sprintf("rule compile error: %v", compiled.Error)))
+			continue
+		}
+		var act *activation
+		switch {
+		case !compiled.Program.IsTransitionRule():
+			act = &activation{name: ScopedVarName, value: obj}
+		case correlated:
+			act = &activation{vars: map[string]interface{}{ScopedVarName: obj, OldScopedVarName: oldObj}}
+		default:
+			// transition rules do not apply to creations and new values
+			continue
+		}
+		ok, err := compiled.Program.eval(act, budget)
+		if budget.isExceeded() {
+			return append(allErrs, v.costBudgetExceededError(fldPath))
+		}
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, v.schema.Type, fmt.Sprintf("error evaluating rule %q: %v", rule.Rule, err)))
+			continue
+		}
+		if !ok {
+			message := compiled.Program.message(act, budget)
+			if len(message) == 0 {
+				message = ruleErrorString(rule)
+			}
+			err := ruleError(withFieldPath(fldPath, compiled.Program.fieldPath), rule.Reason, v.schema.Type, message)
+			if failures != nil {
+				failures[err] = RuleFailure{ValidationRule: rule, Transition: compiled.Program.IsTransitionRule()}
+			}
+			allErrs = append(allErrs, err)
+			if budget.isExceeded() {
+				return append(allErrs, v.costBudgetExceededError(fldPath))
+			}
+		}
+	}
+	return allErrs
+}
+
+func (v *Validator) costBudgetExceededError(fldPath *field.Path) *field.Error {
+	return field.Invalid(fldPath, v.schema.Type, "validation failed due to running out of cost budget, no further validation rules will be run")
+}
+
+// ruleError returns the error of a failed rule with the given reason, FieldValueInvalid by default.
+func ruleError(fldPath *field.Path, reason apiextensions.FieldValueErrorReason, schemaType, message string) *field.Error {
+	errorType := field.ErrorTypeInvalid
+	switch reason {
+	case apiextensions.FieldValueRequired:
+		errorType = field.ErrorTypeRequired
+	case apiextensions.FieldValueDuplicate:
+		errorType = field.ErrorTypeDuplicate
+	case apiextensions.FieldValueForbidden:
+		errorType = field.ErrorTypeForbidden
+	}
+	return &field.Error{Type: errorType, Field: fldPath.String(), BadValue: schemaType, Detail: message}
+}
+
+func ruleErrorString(rule apiextensions.ValidationRule) string {
+	if len(rule.Message) > 0 {
+		return rule.Message
+	}
+	return fmt.Sprintf("failed rule: %s", rule.Rule)
+}
+
+// toCELValue returns a copy of obj in which integral values of number typed
+// schemas are converted to float64, such that expressions see a double as
+// declared by the schema.
+func toCELValue(s *apiextensions.JSONSchemaProps, obj interface{}) interface{} {
+	if s == nil {
+		return obj
+	}
+	switch obj := obj.(type) {
+	case int:
+		return toCELValue(s, int64(obj))
+	case int64:
+		if s.Type == "number" {
+			return float64(obj)
+		}
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(obj))
+		for k, val := range obj {
+			if prop, ok := s.Properties[k]; ok {
+				out[k] = toCELValue(&prop, val)
+			} else if s.AdditionalProperties != nil {
+				out[k] = toCELValue(s.AdditionalProperties.Schema, val)
+			} else {
+				out[k] = val
+			}
+		}
+		return out
+	case []interface{}:
+		var items *apiextensions.JSONSchemaProps
+		if s.Items != nil {
+			items = s.Items.Schema
+		}
+		out := make([]interface{}, len(obj))
+		for i, val := range obj {
+			out[i] = toCELValue(items, val)
+		}
+		return out
+	}
+	return obj
+}
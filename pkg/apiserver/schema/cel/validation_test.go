@@ -0,0 +1,668 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestEval(t *testing.T) {
+	self := map[string]interface{}{
+		"replicas":    int64(3),
+		"maxReplicas": int64(5),
+		"ratio":       float64(0.5),
+		"name":        "foo-bar",
+		"tags":        []interface{}{"a", "b", "c"},
+		"labels":      map[string]interface{}{"app": "web"},
+		"enabled":     true,
+	}
+	tests := []struct {
+		rule    string
+		want    bool
+		wantErr string
+	}{
+		{rule: "self.replicas <= self.maxReplicas", want: true},
+		{rule: "self.replicas > self.maxReplicas", want: false},
+		{rule: "self.replicas + 2 == self.maxReplicas", want: true},
+		{rule: "self.replicas * 2 - 1 == 5 && self.replicas % 2 == 1", want: true},
+		{rule: "self.ratio < 1.0 && self.ratio * 2.0 == 1.0", want: true},
+		{rule: "self.replicas == 3.0", want: true},
+		{rule: "-self.replicas == -3", want: true},
+		{rule: "!self.enabled || self.name.startsWith('foo')", want: true},
+		{rule: "self.name.endsWith('bar') && self.name.contains('-')", want: true},
+		{rule: "self.name.matches('^[a-z]+-[a-z]+$')", want: true},
+		{rule: "self.name.upperAscii() == 'FOO-BAR'", want: true},
+		{rule: "size(self.name) == 7 && self.tags.size() == 3", want: true},
+		{rule: "'b' in self.tags && !('d' in self.tags)", want: true},
+		{rule: "'app' in self.labels && self.labels['app'] == 'web'", want: true},
+		{rule: "self.tags.all(t, t.size() == 1)", want: true},
+		{rule: "self.tags.exists(t, t == 'c')", want: true},
+		{rule: "self.tags.exists_one(t, t < 'c')", want: false},
+		{rule: "self.tags.map(t, t + t) == ['aa', 'bb', 'cc']", want: true},
+		{rule: "self.tags.filter(t, t != 'b') == ['a', 'c']", want: true},
+		{rule: "self.labels.all(k, k == 'app')", want: true},
+		{rule: "has(self.name) && !has(self.missing)", want: true},
+		{rule: "self.replicas > 1 ? self.enabled : false", want: true},
+		{rule: "int('42') == 42 && double(self.replicas) == 3.0 && string(self.replicas) == '3'", want: true},
+		{rule: "{'a': 1}.a == 1 && [1, 2][1] == 2", want: true},
+		{rule: `"a\tb" == 'a' + "\x09" + 'b'`, want: true},
+		{rule: "self.missing == 1 || true", want: true},
+		{rule: "false && self.missing == 1", want: false},
+		{rule: "self.missing == 1", wantErr: "no such key: missing"},
+		{rule: "self.tags[3] == 'd'", wantErr: "index out of bounds"},
+		{rule: "self.replicas / 0 == 1", wantErr: "division by zero"},
+		{rule: "9223372036854775807 + 1 > 0", wantErr: "integer overflow"},
+		{rule: "-9223372036854775808 < 0", want: true},
+		{rule: "self.replicas + 'a' == 'a'", wantErr: "no such overload"},
+	}
+	for _, tt := range tests {
+		result := compileRule(apiextensions.ValidationRule{Rule: tt.rule}, dynType)
+		if result.Error != nil {
+			t.Errorf("%q: unexpected compilation error: %v", tt.rule, result.Error)
+			continue
+		}
+		got, err := result.Program.Eval(self)
+		if len(tt.wantErr) > 0 {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%q: expected error containing %q, got %v", tt.rule, tt.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.rule, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q: expected %v, got %v", tt.rule, tt.want, got)
+		}
+	}
+}
+
+func TestCompile(t *testing.T) {
+	schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"replicas": {Type: "integer"},
+					"name":     {Type: "string"},
+					"port":     {XIntOrString: true},
+					"template": {
+						Type:              "object",
+						XEmbeddedResource: true,
+						Properties: map[string]apiextensions.JSONSchemaProps{
+							"spec": {Type: "object"},
+						},
+					},
+					"extra": {
+						Type:                   "object",
+						XPreserveUnknownFields: boolPtr(true),
+						Properties: map[string]apiextensions.JSONSchemaProps{
+							"a": {Type: "string"},
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		rule    string
+		wantErr string
+	}{
+		{rule: "self.spec.port == 80 || self.spec.port == 'http'"},
+		{rule: "self.spec.template.kind == 'Pod'"},
+		{rule: "self.spec.template.other == 1", wantErr: "undefined field 'other'"},
+		{rule: "self.spec.extra.b == 1"},
+		{rule: "self.spec.replicas >= 0"},
+		{rule: "self.metadata.name == self.spec.name"},
+		{rule: "self.kind == 'Noxu'"},
+		{rule: "", wantErr: "rule is not specified"},
+		{rule: "self.spec.replicas >=", wantErr: "syntax error"},
+		{rule: "self.spec.replicas", wantErr: "must evaluate to a bool"},
+		{rule: "self.spec.other == 1", wantErr: "undefined field 'other'"},
+		{rule: "other.replicas == 1", wantErr: "undeclared reference to 'other'"},
+		{rule: "self.spec.name > 1", wantErr: "no matching overload"},
+		{rule: "self.spec.name.unknown()", wantErr: "undeclared reference to 'unknown'"},
+		{rule: "self.spec.replicas.foo == 1", wantErr: "does not support field selection"},
+	}
+	for _, tt := range tests {
+		s := *schema
+		s.XValidations = apiextensions.ValidationRules{{Rule: tt.rule}}
+		results := Compile(&s, true)
+		if len(results) != 1 {
+			t.Fatalf("%q: expected one result, got %d", tt.rule, len(results))
+		}
+		err := results[0].Error
+		if len(tt.wantErr) == 0 && err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.rule, err)
+		}
+		if len(tt.wantErr) > 0 && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("%q: expected error containing %q, got %v", tt.rule, tt.wantErr, err)
+		}
+	}
+}
+
+func TestCompileValue(t *testing.T) {
+	schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"size": {Type: "integer"},
+					"host": {Type: "string"},
+				},
+			},
+		},
+	}
+	self := map[string]interface{}{
+		"kind": "Noxu",
+		"spec": map[string]interface{}{"size": int64(3), "host": "example.com"},
+	}
+	tests := []struct {
+		expression string
+		schema     *apiextensions.JSONSchemaProps
+		expected   interface{}
+		wantErr    string
+	}{
+		{expression: "self.spec.size * 2", schema: schema, expected: int64(6)},
+		{expression: "{'host': self.spec.host, 'port': 80}", schema: schema, expected: map[string]interface{}{"host": "example.com", "port": int64(80)}},
+		{expression: "has(self.spec.port) ? self.spec.port : null", expected: nil},
+		{expression: "self.kind.lowerAscii()", schema: schema, expected: "noxu"},
+		{expression: "self.spec.other", schema: schema, wantErr: "undefined field 'other'"},
+		{expression: "", wantErr: "expression is not specified"},
+	}
+	for _, tt := range tests {
+		p, err := CompileValue(tt.expression, tt.schema, true)
+		if err == nil {
+			var v interface{}
+			if v, err = p.Eval(self); err == nil && !reflect.DeepEqual(v, tt.expected) {
+				t.Errorf("%q: expected %#v, got %#v", tt.expression, tt.expected, v)
+			}
+		}
+		if len(tt.wantErr) == 0 && err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.expression, err)
+		}
+		if len(tt.wantErr) > 0 && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("%q: expected error containing %q, got %v", tt.expression, tt.wantErr, err)
+		}
+	}
+}
+
+func TestEstimatedCost(t *testing.T) {
+	tests := []struct {
+		rule     string
+		expected uint64
+	}{
+		{rule: "self.size > 1", expected: 4},
+		{rule: "self.items.all(x, x > 0)", expected: 33},
+		{rule: "self.items.all(x, self.items.exists(y, y == x))", expected: 333},
+		{rule: "self.items.map(x, x * 2).size() == self.size", expected: 37},
+	}
+	for _, tt := range tests {
+		schema := &apiextensions.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"size":  {Type: "integer"},
+				"items": {Type: "array", Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "integer"}}},
+			},
+			XValidations: apiextensions.ValidationRules{{Rule: tt.rule}},
+		}
+		result := Compile(schema, false)[0]
+		if result.Error != nil {
+			t.Errorf("%q: unexpected error: %v", tt.rule, result.Error)
+			continue
+		}
+		if cost := result.Program.EstimatedCost(); cost != tt.expected {
+			t.Errorf("%q: expected cost %d, got %d", tt.rule, tt.expected, cost)
+		}
+	}
+}
+
+func TestValidator(t *testing.T) {
+	schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"replicas":    {Type: "integer"},
+					"maxReplicas": {Type: "integer"},
+					"ratio":       {Type: "number"},
+					"ports": {
+						Type: "array",
+						Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{
+							Type:         "integer",
+							XValidations: apiextensions.ValidationRules{{Rule: "self > 0 && self < 65536", Message: "must be a valid port"}},
+						}},
+					},
+				},
+				XValidations: apiextensions.ValidationRules{
+					{Rule: "self.replicas <= self.maxReplicas"},
+					{Rule: "!has(self.ratio) || self.ratio * 2.0 <= 1.0"},
+				},
+			},
+		},
+		XValidations: apiextensions.ValidationRules{{Rule: "self.metadata.name.startsWith('a')"}},
+	}
+	v := NewValidator(schema, true)
+	if v == nil {
+		t.Fatal("expected a validator")
+	}
+
+	tests := []struct {
+		name string
+		obj  map[string]interface{}
+		want field.ErrorList
+	}{
+		{
+			name: "valid",
+			obj: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "abc"},
+				"spec":     map[string]interface{}{"replicas": int64(1), "maxReplicas": int64(2), "ratio": int64(0), "ports": []interface{}{int64(80)}},
+			},
+		},
+		{
+			name: "invalid",
+			obj: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "bcd"},
+				"spec":     map[string]interface{}{"replicas": int64(3), "maxReplicas": int64(2), "ratio": 0.75, "ports": []interface{}{int64(80), int64(0)}},
+			},
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("root"), "object", "failed rule: self.metadata.name.startsWith('a')"),
+				field.Invalid(field.NewPath("root", "spec"), "object", "failed rule: self.replicas <= self.maxReplicas"),
+				field.Invalid(field.NewPath("root", "spec"), "object", "failed rule: !has(self.ratio) || self.ratio * 2.0 <= 1.0"),
+				field.Invalid(field.NewPath("root", "spec", "ports").Index(1), "integer", "must be a valid port"),
+			},
+		},
+		{
+			name: "evaluation error",
+			obj: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "abc"},
+				"spec":     map[string]interface{}{"replicas": int64(1)},
+			},
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("root", "spec"), "object", `error evaluating rule "self.replicas <= self.maxReplicas": no such key: maxReplicas`),
+			},
+		},
+	}
+	for _, tt := range tests {
+		got, _ := v.Validate(field.NewPath("root"), tt.obj, 0)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+			continue
+		}
+		for _, want := range tt.want {
+			found := false
+			for _, err := range got {
+				if err.Error() == want.Error() {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("%s: expected %v, got %v", tt.name, want, got)
+			}
+		}
+	}
+
+	if NewValidator(&apiextensions.JSONSchemaProps{Type: "object"}, true) != nil {
+		t.Errorf("expected no validator for a schema without rules")
+	}
+}
+
+func TestValidatorRatcheting(t *testing.T) {
+	schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"name": {Type: "string", XValidations: apiextensions.ValidationRules{{Rule: "self.size() <= 3", Message: "too long"}}},
+					"labels": {
+						Type: "object",
+						AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{Allows: true, Schema: &apiextensions.JSONSchemaProps{
+							Type:         "string",
+							XValidations: apiextensions.ValidationRules{{Rule: "self != ''", Message: "must not be empty"}},
+						}},
+					},
+					"ports": {
+						Type: "array",
+						Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{
+							Type:         "integer",
+							XValidations: apiextensions.ValidationRules{{Rule: "self > 0", Message: "must be positive"}},
+						}},
+					},
+				},
+			},
+		},
+	}
+	v := NewValidator(schema, true)
+
+	old := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"name":   "toolong",
+			"labels": map[string]interface{}{"a": "", "b": "x"},
+			"ports":  []interface{}{int64(0), int64(80)},
+		},
+	}
+
+	tests := []struct {
+		name string
+		obj  map[string]interface{}
+		want []string
+	}{
+		{
+			name: "unchanged invalid values",
+			obj: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"name":   "toolong",
+					"labels": map[string]interface{}{"a": "", "b": "y"},
+					"ports":  []interface{}{int64(0), int64(80)},
+				},
+			},
+		},
+		{
+			name: "changed invalid values",
+			obj: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"name":   "stilltoolong",
+					"labels": map[string]interface{}{"a": "", "c": ""},
+					"ports":  []interface{}{int64(0), int64(81)},
+				},
+			},
+			want: []string{
+				"root.spec.name: Invalid value: \"string\": too long",
+				"root.spec.labels[c]: Invalid value: \"string\": must not be empty",
+				"root.spec.ports[0]: Invalid value: \"integer\": must be positive",
+			},
+		},
+	}
+	for _, tt := range tests {
+		got, _ := v.ValidateUpdate(field.NewPath("root"), tt.obj, old, 0)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+			continue
+		}
+		for _, want := range tt.want {
+			found := false
+			for _, err := range got {
+				if err.Error() == want {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("%s: expected %v, got %v", tt.name, want, got)
+			}
+		}
+	}
+
+	if errs, _ := v.ValidateUpdate(field.NewPath("root"), old, nil, 0); len(errs) != 3 {
+		t.Errorf("expected all invalid values to be validated without an old object, got %v", errs)
+	}
+}
+
+func TestTransitionRules(t *testing.T) {
+	mapList := "map"
+	schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"id":       {Type: "string", XValidations: apiextensions.ValidationRules{{Rule: "self == oldSelf", Message: "id is immutable"}}},
+					"replicas": {Type: "integer", XValidations: apiextensions.ValidationRules{{Rule: "self >= oldSelf", Message: "replicas may only increase"}}},
+					"ports": {
+						Type:         "array",
+						XListType:    &mapList,
+						XListMapKeys: []string{"name"},
+						Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"name": {Type: "string"},
+								"port": {Type: "integer"},
+							},
+							XValidations: apiextensions.ValidationRules{{Rule: "self.port == oldSelf.port", Message: "port is immutable"}},
+						}},
+					},
+				},
+			},
+		},
+	}
+	v := NewValidator(schema, true)
+	if !v.Properties["spec"].Properties["id"].compiledRules[0].Program.IsTransitionRule() {
+		t.Fatalf("expected a rule referencing oldSelf to be a transition rule")
+	}
+
+	old := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"id":       "a",
+			"replicas": int64(2),
+			"ports":    []interface{}{map[string]interface{}{"name": "http", "port": int64(80)}},
+		},
+	}
+
+	tests := []struct {
+		name string
+		obj  map[string]interface{}
+		want []string
+	}{
+		{
+			name: "allowed transitions",
+			obj: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"id":       "a",
+					"replicas": int64(3),
+					"ports": []interface{}{
+						map[string]interface{}{"name": "https", "port": int64(443)},
+						map[string]interface{}{"name": "http", "port": int64(80)},
+					},
+				},
+			},
+		},
+		{
+			name: "forbidden transitions",
+			obj: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"id":       "b",
+					"replicas": int64(1),
+					"ports":    []interface{}{map[string]interface{}{"name": "http", "port": int64(8080)}},
+				},
+			},
+			want: []string{
+				"root.spec.id: Invalid value: \"string\": id is immutable",
+				"root.spec.replicas: Invalid value: \"integer\": replicas may only increase",
+				"root.spec.ports[0]: Invalid value: \"object\": port is immutable",
+			},
+		},
+	}
+	for _, tt := range tests {
+		got, _ := v.ValidateUpdate(field.NewPath("root"), tt.obj, old, 0)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+			continue
+		}
+		for _, want := range tt.want {
+			found := false
+			for _, err := range got {
+				if err.Error() == want {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("%s: expected %v, got %v", tt.name, want, got)
+			}
+		}
+	}
+
+	// transition rules do not apply to creations
+	if errs, _ := v.Validate(field.NewPath("root"), old, 0); len(errs) != 0 {
+		t.Errorf("expected transition rules to be skipped on create, got %v", errs)
+	}
+	if errs, _ := v.ValidateUpdate(field.NewPath("root"), old, nil, 0); len(errs) != 0 {
+		t.Errorf("expected transition rules to be skipped without an old object, got %v", errs)
+	}
+}
+
+func TestValidatorRuleErrors(t *testing.T) {
+	schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"replicas":    {Type: "integer"},
+			"maxReplicas": {Type: "integer"},
+			"labels": {
+				Type:                 "object",
+				AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{Allows: true, Schema: &apiextensions.JSONSchemaProps{Type: "string"}},
+			},
+		},
+		XValidations: apiextensions.ValidationRules{
+			{
+				Rule:              "self.replicas <= self.maxReplicas",
+				MessageExpression: "'replicas must not exceed ' + string(self.maxReplicas)",
+				FieldPath:         ".replicas",
+				Reason:            apiextensions.FieldValueForbidden,
+			},
+			{Rule: "'app' in self.labels", Message: "app label is required", FieldPath: ".labels['app']", Reason: apiextensions.FieldValueRequired},
+			// the message falls back to Message if the messageExpression fails or is empty
+			{Rule: "self.replicas > 1", MessageExpression: "self.labels['missing']", Message: "too few replicas"},
+			{Rule: "self.replicas > 2", MessageExpression: "' '"},
+		},
+	}
+	for i, result := range Compile(schema, false) {
+		if result.Error != nil || result.MessageExpressionError != nil || result.FieldPathError != nil {
+			t.Fatalf("unexpected compilation errors of rule %d: %v, %v, %v", i, result.Error, result.MessageExpressionError, result.FieldPathError)
+		}
+	}
+
+	errs, _ := NewValidator(schema, false).Validate(field.NewPath("root"), map[string]interface{}{
+		"replicas":    int64(1),
+		"maxReplicas": int64(0),
+		"labels":      map[string]interface{}{},
+	}, 0)
+	want := []string{
+		"root.replicas: Forbidden: replicas must not exceed 0",
+		"root.labels[app]: Required value: app label is required",
+		"root: Invalid value: \"object\": too few replicas",
+		"root: Invalid value: \"object\": failed rule: self.replicas > 2",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %v, got %v", want, errs)
+	}
+	for i := range want {
+		if errs[i].Error() != want[i] {
+			t.Errorf("expected %q, got %q", want[i], errs[i].Error())
+		}
+	}
+}
+
+func TestValidatorCostBudget(t *testing.T) {
+	schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"hosts": {
+				Type:         "array",
+				Items:        &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string"}},
+				XValidations: apiextensions.ValidationRules{{Rule: "self.all(h, self.exists_one(o, o == h))", Message: "hosts must be unique"}},
+			},
+			"replicas": {Type: "integer", XValidations: apiextensions.ValidationRules{{Rule: "self > 0"}}},
+		},
+	}
+	v := NewValidator(schema, false)
+	var hosts []interface{}
+	for i := 0; i < 100; i++ {
+		hosts = append(hosts, fmt.Sprintf("host-%d", i))
+	}
+	obj := map[string]interface{}{"hosts": hosts, "replicas": int64(1)}
+
+	if errs, exceeded := v.Validate(field.NewPath("root"), obj, 0); len(errs) != 0 || exceeded {
+		t.Errorf("expected no errors without a budget, got %v", errs)
+	}
+	if errs, exceeded := v.Validate(field.NewPath("root"), obj, 1000000); len(errs) != 0 || exceeded {
+		t.Errorf("expected no errors within the budget, got %v", errs)
+	}
+
+	errs, exceeded := v.Validate(field.NewPath("root"), obj, 1000)
+	if !exceeded {
+		t.Errorf("expected the budget to be exceeded")
+	}
+	if len(errs) != 1 || errs[0].Field != "root.hosts" || !strings.Contains(errs[0].Detail, "running out of cost budget") {
+		t.Errorf("expected a single cost budget error, got %v", errs)
+	}
+}
+
+func TestParseFieldPath(t *testing.T) {
+	schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"replicas": {Type: "integer"},
+					"ports":    {Type: "array", Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "integer"}}},
+					"labels": {
+						Type:                 "object",
+						AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{Allows: true, Schema: &apiextensions.JSONSchemaProps{Type: "string"}},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		fieldPath string
+		want      string
+		wantErr   bool
+	}{
+		{fieldPath: ".spec.replicas", want: "root.spec.replicas"},
+		{fieldPath: "['spec'].replicas", want: "root.spec.replicas"},
+		{fieldPath: ".spec.labels['app.kubernetes.io/name']", want: "root.spec.labels[app.kubernetes.io/name]"},
+		{fieldPath: ".metadata.name", want: "root.metadata.name"},
+		{fieldPath: ".spec.unknown", wantErr: true},
+		{fieldPath: ".spec.ports.x", wantErr: true},
+		{fieldPath: "spec", wantErr: true},
+		{fieldPath: ".spec..replicas", wantErr: true},
+		{fieldPath: ".spec['replicas'", wantErr: true},
+	}
+	for _, tt := range tests {
+		elements, err := parseFieldPath(tt.fieldPath, schema, true)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected an error", tt.fieldPath)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.fieldPath, err)
+			continue
+		}
+		if got := withFieldPath(field.NewPath("root"), elements).String(); got != tt.want {
+			t.Errorf("%q: expected %s, got %s", tt.fieldPath, tt.want, got)
+		}
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
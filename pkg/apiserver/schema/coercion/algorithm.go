@@ -0,0 +1,122 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coercion
+
+import (
+	"math"
+	"strconv"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+// Coerce converts the scalars of x, which is the unstructured content of a custom resource or of a
+// value inside of it, to the types declared in the schema if they are compatible, and returns the
+// coerced x. Objects and arrays are coerced in place. Strings are coerced to integers and numbers if
+// they can be parsed as such, "true" and "false" to booleans, integral numbers to integers, and
+// integers, numbers and booleans to strings. Values of other types, and values of int-or-string fields, are kept, so that
+// validation rejects them as before.
+func Coerce(x interface{}, s *apiextensions.JSONSchemaProps) interface{} {
+	if s == nil {
+		return x
+	}
+
+	switch x := x.(type) {
+	case map[string]interface{}:
+		for k, v := range x {
+			if prop, found := s.Properties[k]; found {
+				x[k] = Coerce(v, &prop)
+			} else if s.AdditionalProperties != nil {
+				x[k] = Coerce(v, s.AdditionalProperties.Schema)
+			}
+		}
+		return x
+	case []interface{}:
+		if s.Items == nil {
+			return x
+		}
+		for i := range x {
+			x[i] = Coerce(x[i], s.Items.Schema)
+		}
+		return x
+	}
+
+	if s.XIntOrString {
+		return x
+	}
+	switch s.Type {
+	case "integer":
+		return toInteger(x)
+	case "number":
+		return toNumber(x)
+	case "boolean":
+		return toBoolean(x)
+	case "string":
+		return toString(x)
+	}
+	return x
+}
+
+func toInteger(x interface{}) interface{} {
+	switch x := x.(type) {
+	case string:
+		if i, err := strconv.ParseInt(x, 10, 64); err == nil {
+			return i
+		}
+	case float64:
+		if x == math.Trunc(x) && x >= math.MinInt64 && x < math.MaxInt64 {
+			return int64(x)
+		}
+	}
+	return x
+}
+
+func toNumber(x interface{}) interface{} {
+	s, ok := x.(string)
+	if !ok {
+		return x
+	}
+	// integral numbers are decoded as integers, too
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		return f
+	}
+	return x
+}
+
+func toBoolean(x interface{}) interface{} {
+	switch x {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	return x
+}
+
+func toString(x interface{}) interface{} {
+	switch x := x.(type) {
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case float64:
+		return strconv.FormatFloat(x, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(x)
+	}
+	return x
+}
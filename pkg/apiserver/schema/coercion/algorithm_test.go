@@ -0,0 +1,107 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coercion
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/util/json"
+)
+
+func TestCoerce(t *testing.T) {
+	scalars := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"integer":     {Type: "integer"},
+			"number":      {Type: "number"},
+			"boolean":     {Type: "boolean"},
+			"string":      {Type: "string"},
+			"intOrString": {XIntOrString: true},
+			"untyped":     {},
+		},
+	}
+	tests := []struct {
+		name     string
+		json     string
+		schema   *apiextensions.JSONSchemaProps
+		expected string
+	}{
+		{"empty", "null", nil, "null"},
+		{"no schema", `{"integer":"5"}`, nil, `{"integer":"5"}`},
+		{"scalar", `"5"`, &apiextensions.JSONSchemaProps{Type: "integer"}, "5"},
+		{"compatible scalars",
+			`{"integer":"5","number":"1.5","boolean":"true","string":42,"intOrString":"5","untyped":"5"}`, scalars,
+			`{"integer":5,"number":1.5,"boolean":true,"string":"42","intOrString":"5","untyped":"5"}`},
+		{"integral scalars",
+			`{"integer":5.0,"number":"2","boolean":"false","string":false}`, scalars,
+			`{"integer":5,"number":2,"boolean":false,"string":"false"}`},
+		{"float to string", `{"string":1.25}`, scalars, `{"string":"1.25"}`},
+		{"incompatible scalars",
+			`{"integer":"five","number":"NaN","boolean":"yes","string":{"a":1}}`, scalars,
+			`{"integer":"five","number":"NaN","boolean":"yes","string":{"a":1}}`},
+		{"fractional integer", `{"integer":5.5}`, scalars, `{"integer":5.5}`},
+		{"nested", `{"spec":{"replicas":"3","ports":["80",443],"labels":{"a":1}},"unknown":"3"}`, &apiextensions.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"spec": {
+					Type: "object",
+					Properties: map[string]apiextensions.JSONSchemaProps{
+						"replicas": {Type: "integer"},
+						"ports": {
+							Type: "array",
+							Items: &apiextensions.JSONSchemaPropsOrArray{
+								Schema: &apiextensions.JSONSchemaProps{Type: "integer"},
+							},
+						},
+						"labels": {
+							Type: "object",
+							AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{
+								Schema: &apiextensions.JSONSchemaProps{Type: "string"},
+							},
+						},
+					},
+				},
+			},
+		}, `{"spec":{"replicas":3,"ports":[80,443],"labels":{"a":"1"}},"unknown":"3"}`},
+	}
+	for _, tt := range tests {
+		in, err := unmarshal(tt.json)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected, err := unmarshal(tt.expected)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := Coerce(in, tt.schema); !reflect.DeepEqual(got, expected) {
+			t.Errorf("%s: expected %#v, got %#v", tt.name, expected, got)
+		}
+	}
+}
+
+// unmarshal decodes the JSON value in s with integers decoded as int64, like custom resources are.
+func unmarshal(s string) (interface{}, error) {
+	var values []interface{}
+	if err := json.Unmarshal([]byte("["+s+"]"), &values); err != nil {
+		return nil, err
+	}
+	return values[0], nil
+}
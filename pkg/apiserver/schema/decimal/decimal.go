@@ -0,0 +1,133 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package decimal preserves the arbitrary precision decimals of custom resources. Fields with the
+// schema format decimal are of type string. JSON numbers in such fields are decoded into their exact
+// string representation instead of being coerced into int64 or float64 values.
+package decimal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+// Format is the schema format of arbitrary precision decimals.
+const Format = "decimal"
+
+// decimalRegexp matches the JSON number syntax.
+var decimalRegexp = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+
+// IsDecimal returns true if s is a decimal in the JSON number syntax.
+func IsDecimal(s string) bool {
+	return decimalRegexp.MatchString(s)
+}
+
+// HasDecimals returns true if the schema or any schema nested along properties, additionalProperties
+// and items has the format decimal.
+func HasDecimals(s *apiextensions.JSONSchemaProps) bool {
+	if s == nil {
+		return false
+	}
+	if s.Format == Format {
+		return true
+	}
+	for _, prop := range s.Properties {
+		if HasDecimals(&prop) {
+			return true
+		}
+	}
+	if s.AdditionalProperties != nil && HasDecimals(s.AdditionalProperties.Schema) {
+		return true
+	}
+	return s.Items != nil && HasDecimals(s.Items.Schema)
+}
+
+// Unmarshal decodes the JSON object in data. Numbers in fields with the format decimal are decoded
+// into strings, other numbers into int64 values if they are integers and float64 values otherwise.
+func Unmarshal(data []byte, s *apiextensions.JSONSchemaProps) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var content map[string]interface{}
+	if err := decoder.Decode(&content); err != nil {
+		return nil, err
+	}
+	if err := convertNumbers(content, s); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// convertNumbers replaces the json.Numbers in the objects and arrays of x.
+func convertNumbers(x interface{}, s *apiextensions.JSONSchemaProps) error {
+	switch x := x.(type) {
+	case map[string]interface{}:
+		for k, v := range x {
+			var err error
+			if x[k], err = convertNumber(v, propertySchema(s, k)); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		var items *apiextensions.JSONSchemaProps
+		if s != nil && s.Items != nil {
+			items = s.Items.Schema
+		}
+		for i, v := range x {
+			var err error
+			if x[i], err = convertNumber(v, items); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// convertNumber returns x with its json.Numbers replaced.
+func convertNumber(x interface{}, s *apiextensions.JSONSchemaProps) (interface{}, error) {
+	n, ok := x.(json.Number)
+	if !ok {
+		return x, convertNumbers(x, s)
+	}
+	if s != nil && s.Format == Format {
+		return string(n), nil
+	}
+	if i, err := n.Int64(); err == nil {
+		return i, nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode number %s: %v", n, err)
+	}
+	return f, nil
+}
+
+// propertySchema returns the schema of the property k of an object with the schema s.
+func propertySchema(s *apiextensions.JSONSchemaProps, k string) *apiextensions.JSONSchemaProps {
+	if s == nil {
+		return nil
+	}
+	if prop, found := s.Properties[k]; found {
+		return &prop
+	}
+	if s.AdditionalProperties != nil {
+		return s.AdditionalProperties.Schema
+	}
+	return nil
+}
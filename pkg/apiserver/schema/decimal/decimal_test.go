@@ -0,0 +1,112 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decimal
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+func TestUnmarshal(t *testing.T) {
+	schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"amount": {Type: "string", Format: Format},
+			"prices": {
+				Type:  "array",
+				Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string", Format: Format}},
+			},
+			"rates": {
+				Type: "object",
+				AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{
+					Schema: &apiextensions.JSONSchemaProps{Type: "string", Format: Format},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		json     string
+		expected map[string]interface{}
+	}{
+		{"decimals", `{"amount":123456789012345678901234567890.123456789,"prices":[1.10,2e-30],"rates":{"a":0.1}}`, map[string]interface{}{
+			"amount": "123456789012345678901234567890.123456789",
+			"prices": []interface{}{"1.10", "2e-30"},
+			"rates":  map[string]interface{}{"a": "0.1"},
+		}},
+		{"decimal strings", `{"amount":"1.5"}`, map[string]interface{}{"amount": "1.5"}},
+		{"other numbers", `{"replicas":3,"ratio":0.5,"nested":{"big":12345678901234567890}}`, map[string]interface{}{
+			"replicas": int64(3),
+			"ratio":    float64(0.5),
+			"nested":   map[string]interface{}{"big": float64(12345678901234567890)},
+		}},
+	}
+	for _, tt := range tests {
+		got, err := Unmarshal([]byte(tt.json), schema)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: expected %#v, got %#v", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestIsDecimal(t *testing.T) {
+	for s, expected := range map[string]bool{
+		"0":             true,
+		"-1.5":          true,
+		"1e10":          true,
+		"2.5E-3":        true,
+		"123456789.012": true,
+		"":              false,
+		"01":            false,
+		"1.":            false,
+		".5":            false,
+		"+1":            false,
+		"1.5.0":         false,
+		"NaN":           false,
+	} {
+		if got := IsDecimal(s); got != expected {
+			t.Errorf("%q: expected %v, got %v", s, expected, got)
+		}
+	}
+}
+
+func TestHasDecimals(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   *apiextensions.JSONSchemaProps
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"no decimals", &apiextensions.JSONSchemaProps{Properties: map[string]apiextensions.JSONSchemaProps{"a": {Type: "string"}}}, false},
+		{"property", &apiextensions.JSONSchemaProps{Properties: map[string]apiextensions.JSONSchemaProps{"a": {Type: "string", Format: Format}}}, true},
+		{"items", &apiextensions.JSONSchemaProps{Properties: map[string]apiextensions.JSONSchemaProps{"a": {
+			Type:  "array",
+			Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string", Format: Format}},
+		}}}, true},
+	}
+	for _, tt := range tests {
+		if got := HasDecimals(tt.schema); got != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaulting
+
+import (
+	"fmt"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+// Default sets the default values declared in the schema on x, which is the unstructured
+// content of a custom resource or of a value inside of it. Defaults are only applied to
+// absent fields of objects, i.e. along properties. Nested objects are defaulted after their
+// parent, such that the default of an object is itself defaulted. It fails if a default is not a
+// JSON value.
+func Default(x interface{}, s *apiextensions.JSONSchemaProps) error {
+	if s == nil {
+		return nil
+	}
+
+	switch x := x.(type) {
+	case map[string]interface{}:
+		for k, prop := range s.Properties {
+			if prop.Default == nil {
+				continue
+			}
+			if _, found := x[k]; !found {
+				// the default is copied, such that objects do not share memory with the schema
+				def, err := apiextensions.DeepCopyJSONValue(*prop.Default)
+				if err != nil {
+					return fmt.Errorf("invalid default of %s: %v", k, err)
+				}
+				x[k] = def
+			}
+		}
+		for k, v := range x {
+			if prop, found := s.Properties[k]; found {
+				if err := Default(v, &prop); err != nil {
+					return err
+				}
+			} else if s.AdditionalProperties != nil {
+				if err := Default(v, s.AdditionalProperties.Schema); err != nil {
+					return err
+				}
+			}
+		}
+	case []interface{}:
+		if s.Items == nil {
+			return nil
+		}
+		for i := range x {
+			if err := Default(x[i], s.Items.Schema); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
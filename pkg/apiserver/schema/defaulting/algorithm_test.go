@@ -0,0 +1,188 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaulting
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/util/json"
+)
+
+func TestDefault(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		schema   *apiextensions.JSONSchemaProps
+		expected string
+	}{
+		{"empty", "null", nil, "null"},
+		{"scalar", "4", &apiextensions.JSONSchemaProps{
+			Default: jsonPtr("foo"),
+		}, "4"},
+		{"scalar array", "[1,2]", &apiextensions.JSONSchemaProps{
+			Items: &apiextensions.JSONSchemaPropsOrArray{
+				Schema: &apiextensions.JSONSchemaProps{
+					Default: jsonPtr("foo"),
+				},
+			},
+		}, "[1,2]"},
+		{"object array", `[{"a":1},{"b":1},{"c":1}]`, &apiextensions.JSONSchemaProps{
+			Items: &apiextensions.JSONSchemaPropsOrArray{
+				Schema: &apiextensions.JSONSchemaProps{
+					Properties: map[string]apiextensions.JSONSchemaProps{
+						"a": {
+							Default: jsonPtr("A"),
+						},
+						"b": {
+							Default: jsonPtr("B"),
+						},
+					},
+				},
+			},
+		}, `[{"a":1,"b":"B"},{"a":"A","b":1},{"c":1,"a":"A","b":"B"}]`},
+		{"object array object", `{"array":[{"a":1},{"b":2}],"object":{"a":1},"additionalProperties":{"x":{"a":1},"y":{"b":2}}}`, &apiextensions.JSONSchemaProps{
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"array": {
+					Items: &apiextensions.JSONSchemaPropsOrArray{
+						Schema: &apiextensions.JSONSchemaProps{
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"a": {
+									Default: jsonPtr("A"),
+								},
+								"b": {
+									Default: jsonPtr("B"),
+								},
+							},
+						},
+					},
+				},
+				"object": {
+					Properties: map[string]apiextensions.JSONSchemaProps{
+						"a": {
+							Default: jsonPtr("N"),
+						},
+						"b": {
+							Default: jsonPtr("O"),
+						},
+					},
+				},
+				"additionalProperties": {
+					AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{
+						Schema: &apiextensions.JSONSchemaProps{
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"a": {
+									Default: jsonPtr("alpha"),
+								},
+								"b": {
+									Default: jsonPtr("beta"),
+								},
+							},
+						},
+					},
+				},
+				"foo": {
+					Default: jsonPtr("bar"),
+				},
+			},
+		}, `{"array":[{"a":1,"b":"B"},{"a":"A","b":2}],"object":{"a":1,"b":"O"},"additionalProperties":{"x":{"a":1,"b":"beta"},"y":{"a":"alpha","b":2}},"foo":"bar"}`},
+		{"defaulted object is defaulted", `{}`, &apiextensions.JSONSchemaProps{
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"spec": {
+					Default: jsonPtr(map[string]interface{}{"a": "A"}),
+					Properties: map[string]apiextensions.JSONSchemaProps{
+						"b": {
+							Default: jsonPtr("B"),
+						},
+					},
+				},
+			},
+		}, `{"spec":{"a":"A","b":"B"}}`},
+		{"null is not defaulted", `{"a":null}`, &apiextensions.JSONSchemaProps{
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"a": {
+					Default: jsonPtr("A"),
+				},
+			},
+		}, `{"a":null}`},
+	}
+	for _, tt := range tests {
+		var in interface{}
+		if err := json.Unmarshal([]byte(tt.json), &in); err != nil {
+			t.Fatal(err)
+		}
+
+		var expected interface{}
+		if err := json.Unmarshal([]byte(tt.expected), &expected); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := Default(in, tt.schema); err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(in, expected) {
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(in); err != nil {
+				t.Fatalf("unexpected result marshalling error: %v", err)
+			}
+			t.Errorf("%s: expected: %s\ngot: %s", tt.name, tt.expected, buf.String())
+		}
+	}
+}
+
+func TestDefaultDoesNotShareMemory(t *testing.T) {
+	s := &apiextensions.JSONSchemaProps{
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"a": {
+				Default: jsonPtr(map[string]interface{}{"b": []interface{}{"c"}}),
+			},
+		},
+	}
+	x := map[string]interface{}{}
+	if err := Default(x, s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	x["a"].(map[string]interface{})["b"].([]interface{})[0] = "d"
+
+	expected := map[string]interface{}{"b": []interface{}{"c"}}
+	if !reflect.DeepEqual(*s.Properties["a"].Default, expected) {
+		t.Errorf("schema default was modified: %v", *s.Properties["a"].Default)
+	}
+}
+
+func jsonPtr(x interface{}) *apiextensions.JSON {
+	ret := apiextensions.JSON(x)
+	return &ret
+}
+
+func TestDefaultInvalidDefault(t *testing.T) {
+	s := &apiextensions.JSONSchemaProps{
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"a": {
+				Default: jsonPtr(map[string]interface{}{"b": 1}),
+			},
+		},
+	}
+	if err := Default(map[string]interface{}{}, s); err == nil {
+		t.Errorf("expected an error for a default of type int")
+	}
+}
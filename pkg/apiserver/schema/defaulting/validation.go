@@ -0,0 +1,88 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaulting
+
+import (
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/extensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// PruneDefaults removes the fields of the default values in s which are not specified by the schema
+// they are the default of, such that defaulting does not add fields which pruning would remove.
+// Defaults are considered along properties, additionalProperties and items, like in Default, and
+// within the definitions, which apply wherever they are referenced.
+func PruneDefaults(s *apiextensions.JSONSchemaProps) {
+	if s == nil {
+		return
+	}
+
+	if s.Default != nil {
+		pruning.Prune(*s.Default, s, false)
+	}
+	for k, prop := range s.Properties {
+		PruneDefaults(&prop)
+		s.Properties[k] = prop
+	}
+	if s.AdditionalProperties != nil {
+		PruneDefaults(s.AdditionalProperties.Schema)
+	}
+	if s.Items != nil {
+		PruneDefaults(s.Items.Schema)
+	}
+	for k, definition := range s.Definitions {
+		PruneDefaults(&definition)
+		s.Definitions[k] = definition
+	}
+}
+
+// ValidateDefaults checks that the default values in s validate against the schema they are the
+// default of. A default is validated as it is applied, i.e. with the nested defaults of its schema
+// set on it. Defaults are considered along properties, additionalProperties and items, like in Default.
+func ValidateDefaults(s *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if s == nil {
+		return allErrs
+	}
+
+	if s.Default != nil {
+		x, err := apiextensions.DeepCopyJSONValue(*s.Default)
+		if err == nil {
+			err = Default(x, s)
+		}
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("default"), *s.Default, err.Error()))
+		} else {
+			allErrs = append(allErrs, validation.Validate(x, s, fldPath.Child("default"))...)
+			allErrs = append(allErrs, extensions.Validate(x, s, fldPath.Child("default"))...)
+		}
+	}
+	for k, prop := range s.Properties {
+		allErrs = append(allErrs, ValidateDefaults(&prop, fldPath.Child("properties").Key(k))...)
+	}
+	if s.AdditionalProperties != nil {
+		allErrs = append(allErrs, ValidateDefaults(s.AdditionalProperties.Schema, fldPath.Child("additionalProperties"))...)
+	}
+	if s.Items != nil {
+		allErrs = append(allErrs, ValidateDefaults(s.Items.Schema, fldPath.Child("items"))...)
+	}
+
+	return allErrs
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaulting
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+func TestPruneDefaults(t *testing.T) {
+	schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"spec": {
+				Type:    "object",
+				Default: jsonPtr(map[string]interface{}{"a": "A", "unknown": "x"}),
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"a": {Type: "string"},
+					"items": {
+						Type: "array",
+						Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{
+							Type:    "object",
+							Default: jsonPtr(map[string]interface{}{"b": "B", "unknown": "x"}),
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"b": {Type: "string"},
+							},
+						}},
+					},
+				},
+			},
+			"preserved": {
+				Type:                   "object",
+				XPreserveUnknownFields: boolPtr(true),
+				Default:                jsonPtr(map[string]interface{}{"unknown": "x"}),
+			},
+		},
+	}
+
+	PruneDefaults(schema)
+
+	if expected, got := map[string]interface{}{"a": "A"}, *schema.Properties["spec"].Default; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected spec default %v, got %v", expected, got)
+	}
+	if expected, got := map[string]interface{}{"b": "B"}, *schema.Properties["spec"].Properties["items"].Items.Schema.Default; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected items default %v, got %v", expected, got)
+	}
+	if expected, got := map[string]interface{}{"unknown": "x"}, *schema.Properties["preserved"].Default; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected preserved default %v, got %v", expected, got)
+	}
+}
+
+func TestValidateDefaults(t *testing.T) {
+	schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"spec": {
+				Type:     "object",
+				Required: []string{"image", "port"},
+				Default:  jsonPtr(map[string]interface{}{"port": true}),
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"image": {Type: "string", Default: jsonPtr("busybox")},
+					"port":  {XIntOrString: true},
+				},
+			},
+			"labels": {
+				Type: "object",
+				AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{
+					Schema: &apiextensions.JSONSchemaProps{Type: "string", Default: jsonPtr(int64(1))},
+				},
+			},
+		},
+	}
+
+	var paths []string
+	for _, err := range ValidateDefaults(schema, nil) {
+		paths = append(paths, err.Field)
+	}
+	sort.Strings(paths)
+	// the nested default of image is applied before the default of spec is validated
+	if expected := []string{"properties[labels].additionalProperties.default", "properties[spec].default.port"}; !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected errors at %v, got %v", expected, paths)
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
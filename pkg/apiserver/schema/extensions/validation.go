@@ -0,0 +1,216 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package extensions validates custom resources against the x-kubernetes-int-or-string,
+// x-kubernetes-embedded-resource, x-kubernetes-list-type and x-kubernetes-immutable vendor
+// extensions of their schema.
+package extensions
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate checks x, the unstructured content of a custom resource or of a value inside of it,
+// against the vendor extensions of the schema:
+//
+//   - values with x-kubernetes-int-or-string are integers or strings,
+//   - values with x-kubernetes-embedded-resource are objects with apiVersion and kind, and with an
+//     object as metadata if it is specified,
+//   - arrays with x-kubernetes-list-type set have unique items, and arrays with
+//     x-kubernetes-list-type map have items with unique x-kubernetes-list-map-keys.
+//
+// Extensions are applied along properties, additionalProperties and items. Null values are not validated.
+func Validate(x interface{}, s *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if s == nil || x == nil {
+		return allErrs
+	}
+
+	if s.XIntOrString {
+		switch x.(type) {
+		case int64, string:
+		default:
+			allErrs = append(allErrs, field.Invalid(fldPath, x, "must be an integer or a string"))
+		}
+	}
+	if s.XEmbeddedResource {
+		allErrs = append(allErrs, validateEmbeddedResource(x, fldPath)...)
+	}
+
+	switch x := x.(type) {
+	case map[string]interface{}:
+		for k, v := range x {
+			if prop, found := s.Properties[k]; found {
+				allErrs = append(allErrs, Validate(v, &prop, fldPath.Child(k))...)
+			} else if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+				allErrs = append(allErrs, Validate(v, s.AdditionalProperties.Schema, fldPath.Key(k))...)
+			}
+		}
+	case []interface{}:
+		allErrs = append(allErrs, validateListType(x, s, fldPath)...)
+		if s.Items != nil && s.Items.Schema != nil {
+			for i, v := range x {
+				allErrs = append(allErrs, Validate(v, s.Items.Schema, fldPath.Index(i))...)
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateUpdate checks x, the unstructured content of an updated custom resource or of a value
+// inside of it, against the x-kubernetes-immutable extensions of the schema: immutable values which
+// are set in oldX must be kept unchanged, also if the object containing them is removed. Values are
+// correlated with the old values along properties, additionalProperties and the items of arrays with
+// x-kubernetes-list-type map, by their x-kubernetes-list-map-keys. Items of map lists may be removed
+// as a whole. The values of other arrays cannot be correlated.
+func ValidateUpdate(x, oldX interface{}, s *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if s == nil || oldX == nil {
+		return allErrs
+	}
+
+	if s.XImmutable {
+		if !apiequality.Semantic.DeepEqual(x, oldX) {
+			allErrs = append(allErrs, field.Invalid(fldPath, x, "field is immutable"))
+		}
+		return allErrs
+	}
+
+	switch oldX := oldX.(type) {
+	case map[string]interface{}:
+		// a nil map removes all values
+		m, _ := x.(map[string]interface{})
+		for k, oldV := range oldX {
+			if prop, found := s.Properties[k]; found {
+				allErrs = append(allErrs, ValidateUpdate(m[k], oldV, &prop, fldPath.Child(k))...)
+			} else if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+				allErrs = append(allErrs, ValidateUpdate(m[k], oldV, s.AdditionalProperties.Schema, fldPath.Key(k))...)
+			}
+		}
+	case []interface{}:
+		items, ok := x.([]interface{})
+		if !ok || s.XListType == nil || *s.XListType != "map" || s.Items == nil || s.Items.Schema == nil {
+			return allErrs
+		}
+		oldItems := make(map[string]interface{}, len(oldX))
+		for _, oldItem := range oldX {
+			if key, err := mapListKey(oldItem, s.XListMapKeys); err == nil {
+				oldItems[key] = oldItem
+			}
+		}
+		for i, item := range items {
+			key, err := mapListKey(item, s.XListMapKeys)
+			if err != nil {
+				continue
+			}
+			if oldItem, found := oldItems[key]; found {
+				allErrs = append(allErrs, ValidateUpdate(item, oldItem, s.Items.Schema, fldPath.Index(i))...)
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateEmbeddedResource checks the type meta of an embedded object. The embedded object is not
+// persisted on its own, so its metadata is not validated as ObjectMeta.
+func validateEmbeddedResource(x interface{}, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	m, ok := x.(map[string]interface{})
+	if !ok {
+		return append(allErrs, field.Invalid(fldPath, x, "must be an object"))
+	}
+	for _, f := range []string{"apiVersion", "kind"} {
+		v, found := m[f]
+		if !found {
+			allErrs = append(allErrs, field.Required(fldPath.Child(f), ""))
+			continue
+		}
+		if s, ok := v.(string); !ok || len(s) == 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(f), v, "must be a non-empty string"))
+		}
+	}
+	if metadata, found := m["metadata"]; found && metadata != nil {
+		if _, ok := metadata.(map[string]interface{}); !ok {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("metadata"), metadata, "must be an object"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateListType checks that the items of sets and the keys of the items of associative lists are unique.
+func validateListType(x []interface{}, s *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if s.XListType == nil || (*s.XListType != "set" && *s.XListType != "map") {
+		return allErrs
+	}
+	seen := make(map[string]bool, len(x))
+	for i, item := range x {
+		var key interface{} = item
+		if *s.XListType == "map" {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i), item, "must be an object"))
+				continue
+			}
+			key = mapListKeys(m, s.XListMapKeys)
+		}
+		// encoding/json sorts the fields of objects, such that equal values have equal encodings
+		js, err := json.Marshal(key)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), item, err.Error()))
+			continue
+		}
+		if seen[string(js)] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), key))
+			continue
+		}
+		seen[string(js)] = true
+	}
+
+	return allErrs
+}
+
+// mapListKeys returns the values of the x-kubernetes-list-map-keys properties of the item of a map list.
+func mapListKeys(item map[string]interface{}, keys []string) map[string]interface{} {
+	ret := make(map[string]interface{}, len(keys))
+	for _, k := range keys {
+		ret[k] = item[k]
+	}
+	return ret
+}
+
+// mapListKey returns the encoding of the x-kubernetes-list-map-keys properties of the item of a map
+// list, which is equal for items with equal keys.
+func mapListKey(item interface{}, keys []string) (string, error) {
+	m, ok := item.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("must be an object")
+	}
+	js, err := json.Marshal(mapListKeys(m, keys))
+	return string(js), err
+}
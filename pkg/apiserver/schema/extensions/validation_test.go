@@ -0,0 +1,186 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extensions
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/util/json"
+)
+
+func TestValidate(t *testing.T) {
+	schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"port": {XIntOrString: true},
+			"ports": {
+				Type:  "array",
+				Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{XIntOrString: true}},
+			},
+			"templates": {
+				Type: "object",
+				AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{
+					Schema: &apiextensions.JSONSchemaProps{XEmbeddedResource: true},
+				},
+			},
+			"finalizers": {
+				Type:      "array",
+				XListType: strPtr("set"),
+				Items:     &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string"}},
+			},
+			"containers": {
+				Type:         "array",
+				XListType:    strPtr("map"),
+				XListMapKeys: []string{"name", "port"},
+				Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{
+					Type: "object",
+					Properties: map[string]apiextensions.JSONSchemaProps{
+						"name":  {Type: "string"},
+						"port":  {Type: "integer"},
+						"image": {Type: "string"},
+					},
+				}},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		json     string
+		expected []string
+	}{
+		{"empty", `{}`, nil},
+		{"valid", `{"port":80,"ports":["http",443,null],"templates":{"a":{"apiVersion":"v1","kind":"Pod","metadata":{"name":"foo"}},"b":null}}`, nil},
+		{"invalid int-or-string", `{"port":1.5,"ports":[true,{}]}`, []string{"port", "ports[0]", "ports[1]"}},
+		{"invalid embedded resources", `{"templates":{"a":"foo","b":{"metadata":"foo"},"c":{"apiVersion":"","kind":1}}}`, []string{
+			"templates[a]",
+			"templates[b].apiVersion",
+			"templates[b].kind",
+			"templates[b].metadata",
+			"templates[c].apiVersion",
+			"templates[c].kind",
+		}},
+		{"unique list items", `{"finalizers":["a","b"],"containers":[{"name":"a","port":80,"image":"x"},{"name":"a","port":443,"image":"x"},{"name":"b","port":80}]}`, nil},
+		{"duplicate list items", `{"finalizers":["a","b","a"],"containers":[{"name":"a","port":80,"image":"x"},{"name":"a","port":80,"image":"y"},"c"]}`, []string{
+			"containers[1]",
+			"containers[2]",
+			"finalizers[2]",
+		}},
+	}
+	for _, tt := range tests {
+		var in map[string]interface{}
+		if err := json.Unmarshal([]byte(tt.json), &in); err != nil {
+			t.Fatal(err)
+		}
+		var paths []string
+		for _, err := range Validate(in, schema, nil) {
+			paths = append(paths, err.Field)
+		}
+		sort.Strings(paths)
+		if !reflect.DeepEqual(paths, tt.expected) {
+			t.Errorf("%s: expected errors at %v, got %v", tt.name, tt.expected, paths)
+		}
+	}
+}
+
+func TestValidateUpdate(t *testing.T) {
+	schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"storageClass": {Type: "string", XImmutable: true},
+					"selector":     {Type: "object", XImmutable: true, XPreserveUnknownFields: boolPtr(true)},
+					"labels": {
+						Type:                 "object",
+						AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{Schema: &apiextensions.JSONSchemaProps{Type: "string", XImmutable: true}},
+					},
+					"volumes": {
+						Type:         "array",
+						XListType:    strPtr("map"),
+						XListMapKeys: []string{"name"},
+						Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"name":   {Type: "string"},
+								"source": {Type: "string", XImmutable: true},
+								"size":   {Type: "integer"},
+							},
+						}},
+					},
+				},
+			},
+		},
+	}
+	old := `{"spec":{"storageClass":"fast","selector":{"app":"a"},"labels":{"a":"1"},"volumes":[{"name":"a","source":"x","size":1},{"name":"b","source":"y"}]}}`
+	tests := []struct {
+		name     string
+		old      string
+		json     string
+		expected []string
+	}{
+		{"unchanged", old, old, nil},
+		{"mutable values changed", old, `{"spec":{"storageClass":"fast","selector":{"app":"a"},"labels":{"a":"1","b":"2"},"volumes":[{"name":"b","source":"y","size":2},{"name":"a","source":"x"},{"name":"c","source":"z"}]}}`, nil},
+		{"immutable values set", `{"spec":{"volumes":[{"name":"a"}]}}`, old, nil},
+		{"immutable values changed", old, `{"spec":{"storageClass":"slow","selector":{"app":"b"},"labels":{"a":"2"},"volumes":[{"name":"b","source":"z"},{"name":"a","source":"x"}]}}`, []string{
+			"spec.labels[a]",
+			"spec.selector",
+			"spec.storageClass",
+			"spec.volumes[0].source",
+		}},
+		{"immutable values removed", old, `{"spec":{"storageClass":null,"labels":{},"volumes":[{"name":"a","size":1}]}}`, []string{
+			"spec.labels[a]",
+			"spec.selector",
+			"spec.storageClass",
+			"spec.volumes[0].source",
+		}},
+		{"object removed", old, `{"spec":null}`, []string{
+			"spec.labels[a]",
+			"spec.selector",
+			"spec.storageClass",
+		}},
+		{"list item removed", old, `{"spec":{"storageClass":"fast","selector":{"app":"a"},"labels":{"a":"1"},"volumes":[{"name":"b","source":"y"}]}}`, nil},
+	}
+	for _, tt := range tests {
+		var in, oldIn map[string]interface{}
+		if err := json.Unmarshal([]byte(tt.json), &in); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal([]byte(tt.old), &oldIn); err != nil {
+			t.Fatal(err)
+		}
+		var paths []string
+		for _, err := range ValidateUpdate(in, oldIn, schema, nil) {
+			paths = append(paths, err.Field)
+		}
+		sort.Strings(paths)
+		if !reflect.DeepEqual(paths, tt.expected) {
+			t.Errorf("%s: expected errors at %v, got %v", tt.name, tt.expected, paths)
+		}
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,493 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fuzzing generates random custom resources which are valid against the schema of their
+// CustomResourceDefinition, e.g. to fuzz the round-tripping, pruning and conversion of custom
+// resources in tests.
+package fuzzing
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	schemavalidation "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/validation"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// maxAttempts is how often a value is generated again if it does not validate, e.g. because of
+// junctors or validation rules, which the generation does not take into account.
+const maxAttempts = 20
+
+// Fuzzer generates random values which are valid against a schema. Values honor the type, enum,
+// format, the numeric, string, array and object constraints, required properties and the list
+// types of the schema. Values which violate junctors or validation rules are discarded and
+// generated again. A Fuzzer is not safe for concurrent use.
+type Fuzzer struct {
+	r *rand.Rand
+
+	// MaxDepth is the nesting depth below which optional properties, additional properties and
+	// array items are only generated as far as required by the schema.
+	MaxDepth int
+	// MaxItems is the maximum number of generated array items and additional properties beyond the
+	// minimum required by the schema.
+	MaxItems int
+}
+
+// NewFuzzer returns a Fuzzer which generates the same values for the same seed.
+func NewFuzzer(seed int64) *Fuzzer {
+	return &Fuzzer{
+		r:        rand.New(rand.NewSource(seed)),
+		MaxDepth: 5,
+		MaxItems: 3,
+	}
+}
+
+// CustomResource returns a random custom resource of the given version, which is valid against the
+// schema of the version including its validation rules. The namespace is empty for cluster scoped
+// custom resources.
+func (f *Fuzzer) CustomResource(crd *apiextensions.CustomResourceDefinition, version, namespace, name string) (*unstructured.Unstructured, error) {
+	validation, err := apiextensions.GetSchemaForVersion(crd, version)
+	if err != nil {
+		return nil, err
+	}
+	var s *apiextensions.JSONSchemaProps
+	if validation != nil {
+		s = validation.OpenAPIV3Schema
+	}
+
+	celValidator := cel.NewValidator(s, true)
+	var errs field.ErrorList
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		obj := map[string]interface{}{}
+		if s != nil {
+			if m, ok := f.generate(s, 0).(map[string]interface{}); ok {
+				obj = m
+			}
+		}
+		// apiVersion, kind and metadata are not subject to the schema
+		obj["apiVersion"] = crd.Spec.Group + "/" + version
+		obj["kind"] = crd.Spec.Names.Kind
+		metadata := map[string]interface{}{"name": name}
+		if len(namespace) > 0 {
+			metadata["namespace"] = namespace
+		}
+		obj["metadata"] = metadata
+
+		if errs = validate(obj, s, celValidator); len(errs) == 0 {
+			return &unstructured.Unstructured{Object: obj}, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to generate a valid %s after %d attempts: %v", crd.Spec.Names.Kind, maxAttempts, errs.ToAggregate())
+}
+
+// Value returns a random value which is valid against s, including its validation rules.
+func (f *Fuzzer) Value(s *apiextensions.JSONSchemaProps) (interface{}, error) {
+	celValidator := cel.NewValidator(s, false)
+	var errs field.ErrorList
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		x := f.generate(s, 0)
+		if errs = validate(x, s, celValidator); len(errs) == 0 {
+			return x, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to generate a valid value after %d attempts: %v", maxAttempts, errs.ToAggregate())
+}
+
+// validate validates x against the schema and its validation rules, without a cost budget.
+func validate(x interface{}, s *apiextensions.JSONSchemaProps, celValidator *cel.Validator) field.ErrorList {
+	if s == nil {
+		return nil
+	}
+	if errs := schemavalidation.Validate(x, s, nil); len(errs) > 0 {
+		return errs
+	}
+	errs, _ := celValidator.Validate(nil, x, 0)
+	return errs
+}
+
+// generate returns a random value for s at the given nesting depth.
+func (f *Fuzzer) generate(s *apiextensions.JSONSchemaProps, depth int) interface{} {
+	if len(s.Enum) > 0 {
+		return deepCopyJSON(s.Enum[f.r.Intn(len(s.Enum))])
+	}
+	if s.Default != nil && f.r.Intn(4) == 0 {
+		return deepCopyJSON(*s.Default)
+	}
+
+	t := s.Type
+	switch {
+	case s.XIntOrString:
+		t = "integer"
+		if f.r.Intn(2) == 0 {
+			t = "string"
+		}
+	case len(t) == 0 && (s.XEmbeddedResource || len(s.Properties) > 0 || s.AdditionalProperties != nil):
+		t = "object"
+	case len(t) == 0 && s.Items != nil:
+		t = "array"
+	case len(t) == 0:
+		// the schema allows any value, e.g. with x-kubernetes-preserve-unknown-fields
+		t = []string{"boolean", "integer", "number", "string"}[f.r.Intn(4)]
+	}
+
+	switch t {
+	case "object":
+		return f.generateObject(s, depth)
+	case "array":
+		return f.generateArray(s, depth)
+	case "string":
+		return f.generateString(s)
+	case "integer":
+		return f.generateInteger(s)
+	case "number":
+		return f.generateNumber(s)
+	case "boolean":
+		return f.r.Intn(2) == 0
+	}
+	return nil
+}
+
+func (f *Fuzzer) generateObject(s *apiextensions.JSONSchemaProps, depth int) map[string]interface{} {
+	obj := map[string]interface{}{}
+	required := map[string]bool{}
+	for _, k := range s.Required {
+		required[k] = true
+	}
+	// iterate in a stable order to generate the same values for the same seed
+	keys := make([]string, 0, len(s.Properties))
+	for k := range s.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if !required[k] && (depth >= f.MaxDepth || f.r.Intn(2) == 0) {
+			continue
+		}
+		if s.MaxProperties != nil && int64(len(obj)) >= *s.MaxProperties && !required[k] {
+			continue
+		}
+		prop := s.Properties[k]
+		obj[k] = f.generate(&prop, depth+1)
+	}
+
+	if s.AdditionalProperties != nil && (s.AdditionalProperties.Schema != nil || s.AdditionalProperties.Allows) {
+		n := 0
+		if depth < f.MaxDepth {
+			n = f.r.Intn(f.MaxItems + 1)
+		}
+		if s.MinProperties != nil && int64(len(obj)+n) < *s.MinProperties {
+			n = int(*s.MinProperties) - len(obj)
+		}
+		if s.MaxProperties != nil && int64(len(obj)+n) > *s.MaxProperties {
+			n = int(*s.MaxProperties) - len(obj)
+		}
+		for i := 0; i < n; i++ {
+			k := f.randomName(8)
+			if _, found := s.Properties[k]; found {
+				continue
+			}
+			if s.AdditionalProperties.Schema != nil {
+				obj[k] = f.generate(s.AdditionalProperties.Schema, depth+1)
+			} else {
+				obj[k] = f.randomName(8)
+			}
+		}
+	}
+
+	if s.XEmbeddedResource {
+		obj["apiVersion"] = "example.com/v1"
+		obj["kind"] = "Embedded"
+		obj["metadata"] = map[string]interface{}{"name": f.randomName(8)}
+	}
+	return obj
+}
+
+func (f *Fuzzer) generateArray(s *apiextensions.JSONSchemaProps, depth int) []interface{} {
+	min, max := 0, f.MaxItems
+	if s.MinItems != nil {
+		min = int(*s.MinItems)
+	}
+	if s.MaxItems != nil && int(*s.MaxItems) < max {
+		max = int(*s.MaxItems)
+	}
+	if max < min {
+		max = min
+	}
+	n := min
+	if depth < f.MaxDepth {
+		n += f.r.Intn(max - min + 1)
+	}
+
+	var items *apiextensions.JSONSchemaProps
+	if s.Items != nil {
+		items = s.Items.Schema
+	}
+	if items == nil {
+		items = &apiextensions.JSONSchemaProps{Type: "string"}
+	}
+	// the items of sets and the keys of map lists must be unique
+	var unique func(interface{}) string
+	switch {
+	case s.XListType != nil && *s.XListType == "map":
+		unique = func(x interface{}) string {
+			m, _ := x.(map[string]interface{})
+			var key []string
+			for _, k := range s.XListMapKeys {
+				key = append(key, fmt.Sprintf("%#v", m[k]))
+			}
+			return strings.Join(key, ",")
+		}
+	case s.UniqueItems || (s.XListType != nil && *s.XListType == "set"):
+		unique = func(x interface{}) string {
+			return fmt.Sprintf("%#v", x)
+		}
+	}
+
+	ret := []interface{}{}
+	seen := map[string]bool{}
+	for i := 0; len(ret) < n && i < maxAttempts*n; i++ {
+		x := f.generate(items, depth+1)
+		if unique != nil {
+			if key := unique(x); seen[key] {
+				continue
+			} else {
+				seen[key] = true
+			}
+		}
+		ret = append(ret, x)
+	}
+	return ret
+}
+
+func (f *Fuzzer) generateString(s *apiextensions.JSONSchemaProps) string {
+	switch s.Format {
+	case "date":
+		return f.randomTime().Format("2006-01-02")
+	case "date-time":
+		return f.randomTime().Format(time.RFC3339)
+	case "uuid":
+		b := make([]byte, 16)
+		f.r.Read(b)
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	case "email":
+		return f.randomName(6) + "@" + f.randomName(6) + ".example.com"
+	case "hostname":
+		return f.randomName(6) + ".example.com"
+	case "ipv4":
+		return fmt.Sprintf("%d.%d.%d.%d", f.r.Intn(256), f.r.Intn(256), f.r.Intn(256), f.r.Intn(256))
+	case "ipv6":
+		return fmt.Sprintf("2001:db8::%x:%x", f.r.Intn(0x10000), f.r.Intn(0x10000))
+	case "uri":
+		return "https://" + f.randomName(6) + ".example.com/" + f.randomName(6)
+	case "byte":
+		b := make([]byte, 1+f.r.Intn(12))
+		f.r.Read(b)
+		return base64.StdEncoding.EncodeToString(b)
+	case "decimal":
+		return fmt.Sprintf("%d.%02d", f.r.Intn(2000)-1000, f.r.Intn(100))
+	}
+
+	min, max := 0, 12
+	if s.MinLength != nil {
+		min = int(*s.MinLength)
+	}
+	if s.MaxLength != nil {
+		max = int(*s.MaxLength)
+	} else if max < min {
+		max = min + 12
+	}
+	if len(s.Pattern) > 0 {
+		if re, err := syntax.Parse(s.Pattern, syntax.Perl); err == nil {
+			// a match of the pattern is padded to the minimum length if the pattern is not anchored
+			// at its end, and retried by the validation otherwise
+			x := f.generateMatch(re.Simplify())
+			if n := len([]rune(x)); n < min && !strings.HasSuffix(s.Pattern, "$") {
+				x += f.randomName(min - n)
+			}
+			return x
+		}
+	}
+	if max < min {
+		max = min
+	}
+	return f.randomName(min + f.r.Intn(max-min+1))
+}
+
+// generateMatch returns a random string matching re.
+func (f *Fuzzer) generateMatch(re *syntax.Regexp) string {
+	repeat := func(min, max int) string {
+		if max < 0 {
+			max = min + f.MaxItems
+		}
+		var b strings.Builder
+		for i, n := 0, min+f.r.Intn(max-min+1); i < n; i++ {
+			b.WriteString(f.generateMatch(re.Sub[0]))
+		}
+		return b.String()
+	}
+
+	switch re.Op {
+	case syntax.OpLiteral:
+		return string(re.Rune)
+	case syntax.OpCharClass:
+		if len(re.Rune) == 0 {
+			return ""
+		}
+		i := 2 * f.r.Intn(len(re.Rune)/2)
+		lo, hi := re.Rune[i], re.Rune[i+1]
+		// prefer printable ASCII in wide ranges like negated classes
+		if hi-lo > 95 && lo <= '~' {
+			if lo < ' ' {
+				lo = ' '
+			}
+			hi = '~'
+		}
+		return string(lo + rune(f.r.Intn(int(hi-lo)+1)))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return f.randomName(1)
+	case syntax.OpCapture:
+		return f.generateMatch(re.Sub[0])
+	case syntax.OpStar:
+		return repeat(0, -1)
+	case syntax.OpPlus:
+		return repeat(1, -1)
+	case syntax.OpQuest:
+		return repeat(0, 1)
+	case syntax.OpRepeat:
+		return repeat(re.Min, re.Max)
+	case syntax.OpConcat:
+		var b strings.Builder
+		for _, sub := range re.Sub {
+			b.WriteString(f.generateMatch(sub))
+		}
+		return b.String()
+	case syntax.OpAlternate:
+		return f.generateMatch(re.Sub[f.r.Intn(len(re.Sub))])
+	}
+	// empty matches and anchors
+	return ""
+}
+
+// bounds returns the inclusive bounds of the numbers valid against s, with a range of 2000 by default.
+// Exclusive bounds are moved inwards by one for integers, and to the next float otherwise.
+func bounds(s *apiextensions.JSONSchemaProps, integer bool) (float64, float64) {
+	lo, hi := -1000.0, 1000.0
+	switch {
+	case s.Minimum != nil && s.Maximum != nil:
+		lo, hi = *s.Minimum, *s.Maximum
+	case s.Minimum != nil:
+		lo, hi = *s.Minimum, *s.Minimum+2000
+	case s.Maximum != nil:
+		lo, hi = *s.Maximum-2000, *s.Maximum
+	}
+	if s.Minimum != nil && s.ExclusiveMinimum {
+		if integer {
+			lo = math.Floor(lo) + 1
+		} else {
+			lo = math.Nextafter(lo, math.Inf(1))
+		}
+	}
+	if s.Maximum != nil && s.ExclusiveMaximum {
+		if integer {
+			hi = math.Ceil(hi) - 1
+		} else {
+			hi = math.Nextafter(hi, math.Inf(-1))
+		}
+	}
+	return lo, hi
+}
+
+func (f *Fuzzer) generateInteger(s *apiextensions.JSONSchemaProps) int64 {
+	lo, hi := bounds(s, true)
+	if s.MultipleOf != nil && *s.MultipleOf > 0 {
+		return int64(f.multiple(lo, hi, *s.MultipleOf))
+	}
+	l, h := int64(math.Ceil(lo)), int64(math.Floor(hi))
+	if h < l {
+		return l
+	}
+	return l + f.r.Int63n(h-l+1)
+}
+
+func (f *Fuzzer) generateNumber(s *apiextensions.JSONSchemaProps) float64 {
+	lo, hi := bounds(s, false)
+	if s.MultipleOf != nil && *s.MultipleOf > 0 {
+		return f.multiple(lo, hi, *s.MultipleOf)
+	}
+	if f.r.Intn(2) == 0 {
+		// whole numbers exercise the ambiguity of integers and floats in JSON
+		if l, h := math.Ceil(lo), math.Floor(hi); l <= h {
+			return l + float64(f.r.Int63n(int64(h-l)+1))
+		}
+	}
+	return lo + f.r.Float64()*(hi-lo)
+}
+
+// multiple returns a random multiple of m between lo and hi.
+func (f *Fuzzer) multiple(lo, hi, m float64) float64 {
+	l, h := int64(math.Ceil(lo/m)), int64(math.Floor(hi/m))
+	if h < l {
+		return float64(l) * m
+	}
+	return float64(l+f.r.Int63n(h-l+1)) * m
+}
+
+const nameChars = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// randomName returns a random string of lowercase letters and digits starting with a letter.
+func (f *Fuzzer) randomName(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		if i == 0 {
+			b[i] = nameChars[f.r.Intn(26)]
+		} else {
+			b[i] = nameChars[f.r.Intn(len(nameChars))]
+		}
+	}
+	return string(b)
+}
+
+func (f *Fuzzer) randomTime() time.Time {
+	return time.Unix(f.r.Int63n(4102444800), 0).UTC()
+}
+
+// deepCopyJSON returns a deep copy of the JSON value x, e.g. of an enum value.
+func deepCopyJSON(x interface{}) interface{} {
+	switch x := x.(type) {
+	case map[string]interface{}:
+		ret := make(map[string]interface{}, len(x))
+		for k, v := range x {
+			ret[k] = deepCopyJSON(v)
+		}
+		return ret
+	case []interface{}:
+		ret := make([]interface{}, len(x))
+		for i, v := range x {
+			ret[i] = deepCopyJSON(v)
+		}
+		return ret
+	}
+	return x
+}
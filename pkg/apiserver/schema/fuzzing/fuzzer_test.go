@@ -0,0 +1,148 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fuzzing
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	schemavalidation "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/validation"
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func newFuzzingCRD() *apiextensions.CustomResourceDefinition {
+	return &apiextensions.CustomResourceDefinition{
+		Spec: apiextensions.CustomResourceDefinitionSpec{
+			Group:    "mygroup.example.com",
+			Versions: []apiextensions.CustomResourceDefinitionVersion{{Name: "v1beta1", Served: true, Storage: true}},
+			Names:    apiextensions.CustomResourceDefinitionNames{Plural: "noxus", Kind: "Noxu"},
+			Validation: &apiextensions.CustomResourceValidation{
+				OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+					Type:     "object",
+					Required: []string{"spec"},
+					Properties: map[string]apiextensions.JSONSchemaProps{
+						"spec": {
+							Type:     "object",
+							Required: []string{"image", "minReplicas", "maxReplicas"},
+							XValidations: apiextensions.ValidationRules{
+								{Rule: "self.minReplicas <= self.maxReplicas"},
+							},
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"image":       {Type: "string", Pattern: `^[a-z]+(:[0-9]{1,3})?$`, MaxLength: int64Ptr(20)},
+								"minReplicas": {Type: "integer", Minimum: float64Ptr(0), Maximum: float64Ptr(10)},
+								"maxReplicas": {Type: "integer", Minimum: float64Ptr(0), Maximum: float64Ptr(10), ExclusiveMaximum: true},
+								"ratio":       {Type: "number", Minimum: float64Ptr(0), Maximum: float64Ptr(1), MultipleOf: float64Ptr(0.25)},
+								"policy":      {Type: "string", Enum: []apiextensions.JSON{"Always", "Never"}},
+								"created":     {Type: "string", Format: "date-time"},
+								"id":          {Type: "string", Format: "uuid"},
+								"port":        {XIntOrString: true},
+								"name":        {Type: "string", MinLength: int64Ptr(3), MaxLength: int64Ptr(5)},
+								"labels": {
+									Type:                 "object",
+									MaxProperties:        int64Ptr(2),
+									AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{Schema: &apiextensions.JSONSchemaProps{Type: "string"}},
+								},
+								"tags": {
+									Type:      "array",
+									MinItems:  int64Ptr(1),
+									XListType: strPtr("set"),
+									Items:     &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string", Enum: []apiextensions.JSON{"a", "b", "c"}}},
+								},
+								"ports": {
+									Type:         "array",
+									XListType:    strPtr("map"),
+									XListMapKeys: []string{"name"},
+									Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{
+										Type:     "object",
+										Required: []string{"name"},
+										Properties: map[string]apiextensions.JSONSchemaProps{
+											"name":     {Type: "string", Enum: []apiextensions.JSON{"http", "https"}},
+											"protocol": {Type: "string"},
+										},
+									}},
+								},
+								"template": {Type: "object", XEmbeddedResource: true, XPreserveUnknownFields: func() *bool { b := true; return &b }()},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCustomResource(t *testing.T) {
+	crd := newFuzzingCRD()
+	s := crd.Spec.Validation.OpenAPIV3Schema
+	image := regexp.MustCompile(`^[a-z]+(:[0-9]{1,3})?$`)
+	for seed := int64(0); seed < 200; seed++ {
+		cr, err := NewFuzzer(seed).CustomResource(crd, "v1beta1", "default", "foo")
+		if err != nil {
+			t.Errorf("seed %d: unexpected error: %v", seed, err)
+			continue
+		}
+		if errs := schemavalidation.Validate(cr.Object, s, nil); len(errs) > 0 {
+			t.Errorf("seed %d: invalid custom resource %v: %v", seed, cr.Object, errs)
+		}
+		if pruned := pruning.Prune(cr.DeepCopy().Object, s, true); pruned {
+			t.Errorf("seed %d: expected nothing to be pruned from %v", seed, cr.Object)
+		}
+		if cr.GetAPIVersion() != "mygroup.example.com/v1beta1" || cr.GetKind() != "Noxu" || cr.GetNamespace() != "default" || cr.GetName() != "foo" {
+			t.Errorf("seed %d: unexpected type or object meta: %v", seed, cr.Object)
+		}
+		spec := cr.Object["spec"].(map[string]interface{})
+		if !image.MatchString(spec["image"].(string)) {
+			t.Errorf("seed %d: expected the image to match the pattern, got %q", seed, spec["image"])
+		}
+		if spec["minReplicas"].(int64) > spec["maxReplicas"].(int64) {
+			t.Errorf("seed %d: expected the validation rule to hold, got %v", seed, spec)
+		}
+		if ports, ok := spec["ports"].([]interface{}); ok && len(ports) == 2 && reflect.DeepEqual(ports[0].(map[string]interface{})["name"], ports[1].(map[string]interface{})["name"]) {
+			t.Errorf("seed %d: expected unique map list keys, got %v", seed, ports)
+		}
+	}
+
+	a, _ := NewFuzzer(42).CustomResource(crd, "v1beta1", "default", "foo")
+	b, _ := NewFuzzer(42).CustomResource(crd, "v1beta1", "default", "foo")
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("expected the same custom resource for the same seed, got %v and %v", a, b)
+	}
+}
+
+func TestValueUnsatisfiable(t *testing.T) {
+	s := &apiextensions.JSONSchemaProps{
+		Type: "integer",
+		Not:  &apiextensions.JSONSchemaProps{Type: "integer"},
+	}
+	if _, err := NewFuzzer(0).Value(s); err == nil {
+		t.Errorf("expected an error for an unsatisfiable schema")
+	}
+}
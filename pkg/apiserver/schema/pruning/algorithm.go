@@ -0,0 +1,84 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruning
+
+import (
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+// Prune removes object fields in x which are not specified in the schema. x is the unstructured
+// content of a custom resource or of a value inside of it. Fields are specified by properties and
+// by additionalProperties, where additionalProperties: true keeps all fields of an object. Values
+// without a schema are pruned completely. x-kubernetes-preserve-unknown-fields keeps the fields of an
+// object which are not specified, but specified fields are still pruned. isResourceRoot must be true
+// if s is the root schema of a custom resource, in which case apiVersion, kind and metadata are kept
+// regardless of the schema, like for objects with x-kubernetes-embedded-resource. It returns true if
+// any field was removed.
+func Prune(x interface{}, s *apiextensions.JSONSchemaProps, isResourceRoot bool) bool {
+	if isResourceRoot {
+		if m, ok := x.(map[string]interface{}); ok {
+			return prune(m, s, embeddedResourceFields)
+		}
+	}
+	return prune(x, s, nil)
+}
+
+var embeddedResourceFields = map[string]bool{"apiVersion": true, "kind": true, "metadata": true}
+
+func prune(x interface{}, s *apiextensions.JSONSchemaProps, skip map[string]bool) bool {
+	pruned := false
+	preserveUnknownFields := s != nil && s.XPreserveUnknownFields != nil && *s.XPreserveUnknownFields
+
+	switch x := x.(type) {
+	case map[string]interface{}:
+		if s != nil && s.XEmbeddedResource {
+			skip = embeddedResourceFields
+		}
+		for k, v := range x {
+			if skip[k] {
+				continue
+			}
+			if s == nil {
+				delete(x, k)
+				pruned = true
+				continue
+			}
+			if prop, found := s.Properties[k]; found {
+				pruned = prune(v, &prop, nil) || pruned
+			} else if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+				pruned = prune(v, s.AdditionalProperties.Schema, nil) || pruned
+			} else if preserveUnknownFields {
+				continue
+			} else if s.AdditionalProperties == nil || !s.AdditionalProperties.Allows {
+				delete(x, k)
+				pruned = true
+			}
+		}
+	case []interface{}:
+		var items *apiextensions.JSONSchemaProps
+		if s != nil && s.Items != nil {
+			items = s.Items.Schema
+		}
+		if items == nil && preserveUnknownFields {
+			return false
+		}
+		for _, v := range x {
+			pruned = prune(v, items, nil) || pruned
+		}
+	}
+	return pruned
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruning
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/util/json"
+)
+
+func TestPrune(t *testing.T) {
+	tests := []struct {
+		name           string
+		json           string
+		isResourceRoot bool
+		schema         *apiextensions.JSONSchemaProps
+		expected       string
+	}{
+		{"empty", "null", false, nil, "null"},
+		{"scalars", `{"a":1,"b":"x","c":true}`, false, &apiextensions.JSONSchemaProps{
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"a": {Type: "integer"},
+				"c": {Type: "boolean"},
+			},
+		}, `{"a":1,"c":true}`},
+		{"no schema", `{"a":1,"b":{"c":2}}`, false, nil, `{}`},
+		{"nested objects", `{"a":{"b":1,"c":2},"d":{"e":{"f":1,"g":2}}}`, false, &apiextensions.JSONSchemaProps{
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"a": {
+					Properties: map[string]apiextensions.JSONSchemaProps{
+						"b": {},
+					},
+				},
+				"d": {
+					Properties: map[string]apiextensions.JSONSchemaProps{
+						"e": {
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"g": {},
+							},
+						},
+					},
+				},
+			},
+		}, `{"a":{"b":1},"d":{"e":{"g":2}}}`},
+		{"arrays", `{"a":[{"b":1,"c":2},{"b":3}],"d":[{"e":1}],"f":[1,2]}`, false, &apiextensions.JSONSchemaProps{
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"a": {
+					Items: &apiextensions.JSONSchemaPropsOrArray{
+						Schema: &apiextensions.JSONSchemaProps{
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"b": {},
+							},
+						},
+					},
+				},
+				"d": {Type: "array"},
+				"f": {Type: "array"},
+			},
+		}, `{"a":[{"b":1},{"b":3}],"d":[{}],"f":[1,2]}`},
+		{"additionalProperties", `{"a":{"x":{"b":1,"c":2},"y":{"c":3}},"d":{"x":{"e":1}}}`, false, &apiextensions.JSONSchemaProps{
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"a": {
+					AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{
+						Allows: true,
+						Schema: &apiextensions.JSONSchemaProps{
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"b": {},
+							},
+						},
+					},
+				},
+				"d": {
+					AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{Allows: true},
+				},
+			},
+		}, `{"a":{"x":{"b":1},"y":{}},"d":{"x":{"e":1}}}`},
+		{"resource root", `{"apiVersion":"a/v1","kind":"Foo","metadata":{"name":"foo","unknown":1},"spec":{"a":1,"b":2},"status":{}}`, true, &apiextensions.JSONSchemaProps{
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"spec": {
+					Properties: map[string]apiextensions.JSONSchemaProps{
+						"a": {},
+					},
+				},
+			},
+		}, `{"apiVersion":"a/v1","kind":"Foo","metadata":{"name":"foo","unknown":1},"spec":{"a":1}}`},
+		{"metadata below the root", `{"metadata":{"name":"foo"},"kind":"Foo"}`, false, &apiextensions.JSONSchemaProps{
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"kind": {},
+			},
+		}, `{"kind":"Foo"}`},
+		{"preserve unknown fields", `{"a":{"b":1,"c":{"d":1,"e":2}},"f":[{"g":1}],"h":{"i":1}}`, false, &apiextensions.JSONSchemaProps{
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"a": {
+					XPreserveUnknownFields: boolPtr(true),
+					Properties: map[string]apiextensions.JSONSchemaProps{
+						"c": {
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"d": {},
+							},
+						},
+					},
+				},
+				"f": {XPreserveUnknownFields: boolPtr(true)},
+				"h": {},
+			},
+		}, `{"a":{"b":1,"c":{"d":1}},"f":[{"g":1}],"h":{}}`},
+		{"embedded resource", `{"template":{"apiVersion":"v1","kind":"Pod","metadata":{"name":"foo"},"spec":{"a":1,"b":2},"status":{}},"raw":{"apiVersion":"v1","kind":"Pod","spec":{"a":1}}}`, false, &apiextensions.JSONSchemaProps{
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"template": {
+					XEmbeddedResource: true,
+					Properties: map[string]apiextensions.JSONSchemaProps{
+						"spec": {
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"a": {},
+							},
+						},
+					},
+				},
+				"raw": {
+					XEmbeddedResource:      true,
+					XPreserveUnknownFields: boolPtr(true),
+				},
+			},
+		}, `{"template":{"apiVersion":"v1","kind":"Pod","metadata":{"name":"foo"},"spec":{"a":1}},"raw":{"apiVersion":"v1","kind":"Pod","spec":{"a":1}}}`},
+	}
+	for _, tt := range tests {
+		var in interface{}
+		if err := json.Unmarshal([]byte(tt.json), &in); err != nil {
+			t.Fatal(err)
+		}
+
+		var expected interface{}
+		if err := json.Unmarshal([]byte(tt.expected), &expected); err != nil {
+			t.Fatal(err)
+		}
+
+		var original interface{}
+		if err := json.Unmarshal([]byte(tt.json), &original); err != nil {
+			t.Fatal(err)
+		}
+
+		pruned := Prune(in, tt.schema, tt.isResourceRoot)
+		if expectedPruned := !reflect.DeepEqual(original, expected); pruned != expectedPruned {
+			t.Errorf("%s: expected pruned to be %v, got %v", tt.name, expectedPruned, pruned)
+		}
+		if !reflect.DeepEqual(in, expected) {
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(in); err != nil {
+				t.Fatalf("unexpected result marshalling error: %v", err)
+			}
+			t.Errorf("%s: expected: %s\ngot: %s", tt.name, tt.expected, buf.String())
+		}
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
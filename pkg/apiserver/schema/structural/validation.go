@@ -0,0 +1,210 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package structural
+
+import (
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var allowedMetadataFields = map[string]bool{
+	"name":         true,
+	"generateName": true,
+}
+
+// Validate checks that the given schema of a custom resource is structural, i.e.:
+//
+//   - the type is specified for the root, for every property, for additionalProperties and for items,
+//     unless x-kubernetes-int-or-string, x-kubernetes-embedded-resource or x-kubernetes-preserve-unknown-fields
+//     is set below the root,
+//   - the root is of type object and metadata may only restrict name and generateName,
+//   - the type is empty for x-kubernetes-int-or-string and object or empty for x-kubernetes-embedded-resource,
+//   - properties and additionalProperties are mutually exclusive and only specified for objects,
+//   - items are specified exactly for arrays,
+//   - inside of not, allOf, oneOf and anyOf neither type, description, title, additionalProperties nor
+//     vendor extensions are specified, and properties and items are also specified outside of the
+//     logical junctor. As for built-in types, the types integer and string are allowed for
+//     x-kubernetes-int-or-string.
+//
+// A structural schema determines the type of every field of a custom resource unambiguously.
+func Validate(schema *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if schema == nil {
+		return allErrs
+	}
+
+	allErrs = append(allErrs, validateStructure(schema, fldPath)...)
+
+	if len(schema.Type) > 0 && schema.Type != "object" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("type"), schema.Type, "must be object at the root"))
+	} else if len(schema.Type) == 0 && mayOmitType(schema) {
+		allErrs = append(allErrs, field.Required(fldPath.Child("type"), "must be object at the root"))
+	}
+	if schema.XIntOrString {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("x-kubernetes-int-or-string"), "must be false at the root"))
+	}
+	if schema.XEmbeddedResource {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("x-kubernetes-embedded-resource"), "must be false at the root"))
+	}
+
+	if metadata, found := schema.Properties["metadata"]; found {
+		metadataPath := fldPath.Child("properties").Key("metadata")
+		if len(metadata.Type) > 0 && metadata.Type != "object" {
+			allErrs = append(allErrs, field.Invalid(metadataPath.Child("type"), metadata.Type, "must be object"))
+		}
+		for property := range metadata.Properties {
+			if !allowedMetadataFields[property] {
+				allErrs = append(allErrs, field.Forbidden(metadataPath.Child("properties").Key(property), "only name and generateName may be specified for metadata"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateStructure checks the structural properties of the given schema and of its nested schemas.
+func validateStructure(schema *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(schema.Type) == 0 && !mayOmitType(schema) {
+		allErrs = append(allErrs, field.Required(fldPath.Child("type"), "must not be empty to be structural"))
+	}
+	if schema.XIntOrString && len(schema.Type) > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("type"), schema.Type, "must be empty if x-kubernetes-int-or-string is true"))
+	}
+	if schema.XEmbeddedResource && len(schema.Type) > 0 && schema.Type != "object" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("type"), schema.Type, "must be object if x-kubernetes-embedded-resource is true"))
+	}
+
+	hasAdditionalProperties := schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil
+	if len(schema.Properties) > 0 && hasAdditionalProperties {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("additionalProperties"), "additionalProperties and properties are mutually exclusive"))
+	}
+	if (len(schema.Properties) > 0 || hasAdditionalProperties) && len(schema.Type) > 0 && schema.Type != "object" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("type"), schema.Type, "must be object if properties or additionalProperties are specified"))
+	}
+
+	hasItems := schema.Items != nil && schema.Items.Schema != nil
+	if schema.Type == "array" && !hasItems {
+		allErrs = append(allErrs, field.Required(fldPath.Child("items"), "must be specified for arrays"))
+	}
+	if hasItems && len(schema.Type) > 0 && schema.Type != "array" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("type"), schema.Type, "must be array if items are specified"))
+	}
+
+	for property, propertySchema := range schema.Properties {
+		allErrs = append(allErrs, validateStructure(&propertySchema, fldPath.Child("properties").Key(property))...)
+	}
+	if hasAdditionalProperties {
+		allErrs = append(allErrs, validateStructure(schema.AdditionalProperties.Schema, fldPath.Child("additionalProperties"))...)
+	}
+	if hasItems {
+		allErrs = append(allErrs, validateStructure(schema.Items.Schema, fldPath.Child("items"))...)
+	}
+
+	allErrs = append(allErrs, validateLogicalJunctors(schema, schema, fldPath)...)
+
+	return allErrs
+}
+
+// mayOmitType returns true if the vendor extensions of the schema determine the type of its values.
+func mayOmitType(schema *apiextensions.JSONSchemaProps) bool {
+	return schema.XIntOrString || schema.XEmbeddedResource || (schema.XPreserveUnknownFields != nil && *schema.XPreserveUnknownFields)
+}
+
+// validateLogicalJunctors checks the not, allOf, oneOf and anyOf schemas of the given schema
+// against the structure they are applied to.
+func validateLogicalJunctors(schema, structure *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if schema.Not != nil {
+		allErrs = append(allErrs, validateValueValidation(schema.Not, structure, fldPath.Child("not"))...)
+	}
+	for i := range schema.AllOf {
+		allErrs = append(allErrs, validateValueValidation(&schema.AllOf[i], structure, fldPath.Child("allOf").Index(i))...)
+	}
+	for i := range schema.OneOf {
+		allErrs = append(allErrs, validateValueValidation(&schema.OneOf[i], structure, fldPath.Child("oneOf").Index(i))...)
+	}
+	for i := range schema.AnyOf {
+		allErrs = append(allErrs, validateValueValidation(&schema.AnyOf[i], structure, fldPath.Child("anyOf").Index(i))...)
+	}
+
+	return allErrs
+}
+
+// validateValueValidation checks that a schema inside of a logical junctor only restricts values
+// of the given structure, but does not change it.
+func validateValueValidation(schema, structure *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(schema.Type) > 0 && !(structure.XIntOrString && (schema.Type == "integer" || schema.Type == "string")) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("type"), "must be empty to be structural"))
+	}
+	if len(schema.Description) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("description"), "must be empty to be structural"))
+	}
+	if len(schema.Title) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("title"), "must be empty to be structural"))
+	}
+	if schema.AdditionalProperties != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("additionalProperties"), "must be undefined to be structural"))
+	}
+	if schema.XPreserveUnknownFields != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("x-kubernetes-preserve-unknown-fields"), "must be undefined to be structural"))
+	}
+	if schema.XEmbeddedResource {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("x-kubernetes-embedded-resource"), "must be false to be structural"))
+	}
+	if schema.XIntOrString {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("x-kubernetes-int-or-string"), "must be false to be structural"))
+	}
+	if schema.XListType != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("x-kubernetes-list-type"), "must be undefined to be structural"))
+	}
+	if len(schema.XListMapKeys) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("x-kubernetes-list-map-keys"), "must be empty to be structural"))
+	}
+	if schema.XMapType != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("x-kubernetes-map-type"), "must be undefined to be structural"))
+	}
+	if schema.XImmutable {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("x-kubernetes-immutable"), "must be false to be structural"))
+	}
+
+	for property, propertySchema := range schema.Properties {
+		propertyPath := fldPath.Child("properties").Key(property)
+		propertyStructure, found := structure.Properties[property]
+		if !found {
+			allErrs = append(allErrs, field.Forbidden(propertyPath, "must also be specified outside of the logical junctor to be structural"))
+			continue
+		}
+		allErrs = append(allErrs, validateValueValidation(&propertySchema, &propertyStructure, propertyPath)...)
+	}
+	if schema.Items != nil && schema.Items.Schema != nil {
+		if structure.Items == nil || structure.Items.Schema == nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("items"), "must also be specified outside of the logical junctor to be structural"))
+		} else {
+			allErrs = append(allErrs, validateValueValidation(schema.Items.Schema, structure.Items.Schema, fldPath.Child("items"))...)
+		}
+	}
+
+	allErrs = append(allErrs, validateLogicalJunctors(schema, structure, fldPath)...)
+
+	return allErrs
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package structural
+
+import (
+	"sort"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   *apiextensions.JSONSchemaProps
+		expected []string
+	}{
+		{"empty", nil, nil},
+		{"structural", &apiextensions.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"metadata": {
+					Type: "object",
+					Properties: map[string]apiextensions.JSONSchemaProps{
+						"name": {Type: "string", MaxLength: int64Ptr(10)},
+					},
+				},
+				"spec": {
+					Type: "object",
+					Properties: map[string]apiextensions.JSONSchemaProps{
+						"replicas": {Type: "integer"},
+						"list": {
+							Type:  "array",
+							Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string"}},
+						},
+					},
+					AnyOf: []apiextensions.JSONSchemaProps{
+						{Required: []string{"replicas"}},
+						{Properties: map[string]apiextensions.JSONSchemaProps{
+							"list": {Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{MinLength: int64Ptr(1)}}},
+						}},
+					},
+				},
+				"labels": {
+					Type:                 "object",
+					AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{Allows: true, Schema: &apiextensions.JSONSchemaProps{Type: "string"}},
+				},
+			},
+		}, nil},
+		{"missing types", &apiextensions.JSONSchemaProps{
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"spec": {
+					Properties: map[string]apiextensions.JSONSchemaProps{
+						"replicas": {},
+					},
+				},
+			},
+		}, []string{
+			"root.properties[spec].properties[replicas].type",
+			"root.properties[spec].type",
+			"root.type",
+		}},
+		{"ambiguous structure", &apiextensions.JSONSchemaProps{
+			Type: "array",
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"metadata": {
+					Type: "string",
+					Properties: map[string]apiextensions.JSONSchemaProps{
+						"labels": {Type: "object"},
+					},
+				},
+				"both": {
+					Type:                 "object",
+					Properties:           map[string]apiextensions.JSONSchemaProps{"a": {Type: "string"}},
+					AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{Allows: true, Schema: &apiextensions.JSONSchemaProps{Type: "string"}},
+				},
+				"list":   {Type: "array"},
+				"string": {Type: "string", Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string"}}},
+			},
+		}, []string{
+			"root.items",
+			"root.properties[both].additionalProperties",
+			"root.properties[list].items",
+			"root.properties[metadata].properties[labels]",
+			"root.properties[metadata].type",
+			"root.properties[metadata].type",
+			"root.properties[string].type",
+			"root.type",
+			"root.type",
+		}},
+		{"logical junctors", &apiextensions.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"a": {Type: "string"},
+			},
+			AllOf: []apiextensions.JSONSchemaProps{
+				{
+					Type:        "object",
+					Description: "foo",
+					Title:       "foo",
+					Properties: map[string]apiextensions.JSONSchemaProps{
+						"a": {Type: "string"},
+						"b": {},
+					},
+				},
+			},
+			Not: &apiextensions.JSONSchemaProps{
+				AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{Allows: true},
+				Items:                &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{}},
+				OneOf: []apiextensions.JSONSchemaProps{
+					{Properties: map[string]apiextensions.JSONSchemaProps{"c": {}}},
+				},
+			},
+		}, []string{
+			"root.allOf[0].description",
+			"root.allOf[0].properties[a].type",
+			"root.allOf[0].properties[b]",
+			"root.allOf[0].title",
+			"root.allOf[0].type",
+			"root.not.additionalProperties",
+			"root.not.items",
+			"root.not.oneOf[0].properties[c]",
+		}},
+		{"vendor extensions", &apiextensions.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"port": {
+					XIntOrString: true,
+					AnyOf: []apiextensions.JSONSchemaProps{
+						{Type: "integer"},
+						{Type: "string"},
+					},
+				},
+				"template": {
+					XEmbeddedResource:      true,
+					XPreserveUnknownFields: boolPtr(true),
+				},
+				"raw":      {XPreserveUnknownFields: boolPtr(true)},
+				"object":   {Type: "object", XEmbeddedResource: true},
+				"badPort":  {Type: "integer", XIntOrString: true},
+				"badEmbed": {Type: "string", XEmbeddedResource: true},
+				"unknown":  {XPreserveUnknownFields: boolPtr(false)},
+				"junctor": {
+					Type: "string",
+					AnyOf: []apiextensions.JSONSchemaProps{
+						{Type: "string", XPreserveUnknownFields: boolPtr(true), XEmbeddedResource: true, XIntOrString: true},
+					},
+				},
+			},
+		}, []string{
+			"root.properties[badEmbed].type",
+			"root.properties[badPort].type",
+			"root.properties[junctor].anyOf[0].type",
+			"root.properties[junctor].anyOf[0].x-kubernetes-embedded-resource",
+			"root.properties[junctor].anyOf[0].x-kubernetes-int-or-string",
+			"root.properties[junctor].anyOf[0].x-kubernetes-preserve-unknown-fields",
+			"root.properties[unknown].type",
+		}},
+		{"list and map types in logical junctors", &apiextensions.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"list": {
+					Type:  "array",
+					Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string"}},
+					AnyOf: []apiextensions.JSONSchemaProps{
+						{XListType: strPtr("map"), XListMapKeys: []string{"name"}},
+					},
+				},
+				"map": {
+					Type:       "object",
+					Properties: map[string]apiextensions.JSONSchemaProps{"a": {Type: "string"}},
+					Not:        &apiextensions.JSONSchemaProps{XMapType: strPtr("atomic")},
+				},
+			},
+		}, []string{
+			"root.properties[list].anyOf[0].x-kubernetes-list-map-keys",
+			"root.properties[list].anyOf[0].x-kubernetes-list-type",
+			"root.properties[map].not.x-kubernetes-map-type",
+		}},
+		{"immutable values in logical junctors", &apiextensions.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"name": {
+					Type:  "string",
+					AllOf: []apiextensions.JSONSchemaProps{{XImmutable: true}},
+				},
+			},
+		}, []string{
+			"root.properties[name].allOf[0].x-kubernetes-immutable",
+		}},
+		{"vendor extensions at the root", &apiextensions.JSONSchemaProps{
+			XPreserveUnknownFields: boolPtr(true),
+			XEmbeddedResource:      true,
+			XIntOrString:           true,
+		}, []string{
+			"root.type",
+			"root.x-kubernetes-embedded-resource",
+			"root.x-kubernetes-int-or-string",
+		}},
+	}
+
+	for _, tc := range tests {
+		var paths []string
+		for _, err := range Validate(tc.schema, field.NewPath("root")) {
+			paths = append(paths, err.Field)
+		}
+		sort.Strings(paths)
+		if len(paths) != len(tc.expected) {
+			t.Errorf("%s: expected errors at %v, got %v", tc.name, tc.expected, paths)
+			continue
+		}
+		for i := range paths {
+			if paths[i] != tc.expected[i] {
+				t.Errorf("%s: expected errors at %v, got %v", tc.name, tc.expected, paths)
+				break
+			}
+		}
+	}
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validation validates values against the OpenAPI v3 validation schema of custom resources.
+package validation
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"unicode/utf8"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/decimal"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate checks x, the unstructured content of a custom resource or of a value inside of it,
+// against the schema: type, enum, the numeric, string, array and object constraints, and the
+// logical junctors allOf, anyOf, oneOf and not. Nested values are validated along properties,
+// additionalProperties and items. Of the formats, only decimal is validated.
+func Validate(x interface{}, s *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if s == nil {
+		return allErrs
+	}
+
+	if len(s.Type) > 0 && !hasType(x, s.Type) {
+		// the other constraints are meaningless for a value of the wrong type
+		return append(allErrs, field.Invalid(fldPath, x, fmt.Sprintf("must be of type %s", s.Type)))
+	}
+
+	if len(s.Enum) > 0 {
+		allErrs = append(allErrs, validateEnum(x, s.Enum, fldPath)...)
+	}
+
+	switch x := x.(type) {
+	case int64:
+		allErrs = append(allErrs, validateNumber(float64(x), s, fldPath)...)
+	case float64:
+		allErrs = append(allErrs, validateNumber(x, s, fldPath)...)
+	case string:
+		allErrs = append(allErrs, validateString(x, s, fldPath)...)
+	case []interface{}:
+		allErrs = append(allErrs, validateArray(x, s, fldPath)...)
+	case map[string]interface{}:
+		allErrs = append(allErrs, validateObject(x, s, fldPath)...)
+	}
+
+	allErrs = append(allErrs, validateJunctors(x, s, fldPath)...)
+
+	return allErrs
+}
+
+// hasType returns true if x is a value of the given OpenAPI type.
+func hasType(x interface{}, t string) bool {
+	switch x := x.(type) {
+	case map[string]interface{}:
+		return t == "object"
+	case []interface{}:
+		return t == "array"
+	case string:
+		return t == "string"
+	case bool:
+		return t == "boolean"
+	case int64:
+		return t == "integer" || t == "number"
+	case float64:
+		return t == "number" || (t == "integer" && x == math.Trunc(x))
+	}
+	return false
+}
+
+func validateEnum(x interface{}, enum []apiextensions.JSON, fldPath *field.Path) field.ErrorList {
+	values := make([]string, 0, len(enum))
+	for _, v := range enum {
+		if equalJSON(x, v) {
+			return nil
+		}
+		values = append(values, fmt.Sprintf("%v", v))
+	}
+	return field.ErrorList{field.NotSupported(fldPath, x, values)}
+}
+
+// equalJSON compares JSON values, treating integers and floats with the same value as equal.
+func equalJSON(x, y interface{}) bool {
+	switch x := x.(type) {
+	case int64:
+		switch y := y.(type) {
+		case int64:
+			return x == y
+		case float64:
+			return float64(x) == y
+		}
+		return false
+	case float64:
+		switch y := y.(type) {
+		case int64:
+			return x == float64(y)
+		case float64:
+			return x == y
+		}
+		return false
+	case map[string]interface{}:
+		y, ok := y.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, v := range x {
+			if w, found := y[k]; !found || !equalJSON(v, w) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		y, ok := y.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equalJSON(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+func validateNumber(x float64, s *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if s.Maximum != nil {
+		if s.ExclusiveMaximum && x >= *s.Maximum {
+			allErrs = append(allErrs, field.Invalid(fldPath, x, fmt.Sprintf("must be less than %v", *s.Maximum)))
+		} else if x > *s.Maximum {
+			allErrs = append(allErrs, field.Invalid(fldPath, x, fmt.Sprintf("must be less than or equal to %v", *s.Maximum)))
+		}
+	}
+	if s.Minimum != nil {
+		if s.ExclusiveMinimum && x <= *s.Minimum {
+			allErrs = append(allErrs, field.Invalid(fldPath, x, fmt.Sprintf("must be greater than %v", *s.Minimum)))
+		} else if x < *s.Minimum {
+			allErrs = append(allErrs, field.Invalid(fldPath, x, fmt.Sprintf("must be greater than or equal to %v", *s.Minimum)))
+		}
+	}
+	if s.MultipleOf != nil && *s.MultipleOf > 0 {
+		if q := x / *s.MultipleOf; q != math.Trunc(q) {
+			allErrs = append(allErrs, field.Invalid(fldPath, x, fmt.Sprintf("must be a multiple of %v", *s.MultipleOf)))
+		}
+	}
+
+	return allErrs
+}
+
+func validateString(x string, s *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	length := int64(utf8.RuneCountInString(x))
+	if s.MaxLength != nil && length > *s.MaxLength {
+		allErrs = append(allErrs, field.TooLong(fldPath, x, int(*s.MaxLength)))
+	}
+	if s.MinLength != nil && length < *s.MinLength {
+		allErrs = append(allErrs, field.Invalid(fldPath, x, fmt.Sprintf("must have at least %d characters", *s.MinLength)))
+	}
+	if len(s.Pattern) > 0 {
+		// invalid patterns are not enforced at all
+		if re, err := regexp.Compile(s.Pattern); err == nil && !re.MatchString(x) {
+			allErrs = append(allErrs, field.Invalid(fldPath, x, fmt.Sprintf("must match the pattern %q", s.Pattern)))
+		}
+	}
+	if s.Format == decimal.Format && !decimal.IsDecimal(x) {
+		allErrs = append(allErrs, field.Invalid(fldPath, x, "must be a decimal number"))
+	}
+
+	return allErrs
+}
+
+func validateArray(x []interface{}, s *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if s.MaxItems != nil && int64(len(x)) > *s.MaxItems {
+		allErrs = append(allErrs, field.Invalid(fldPath, len(x), fmt.Sprintf("must have at most %d items", *s.MaxItems)))
+	}
+	if s.MinItems != nil && int64(len(x)) < *s.MinItems {
+		allErrs = append(allErrs, field.Invalid(fldPath, len(x), fmt.Sprintf("must have at least %d items", *s.MinItems)))
+	}
+	if s.Items != nil && s.Items.Schema != nil {
+		for i, v := range x {
+			allErrs = append(allErrs, Validate(v, s.Items.Schema, fldPath.Index(i))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateObject(x map[string]interface{}, s *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if s.MaxProperties != nil && int64(len(x)) > *s.MaxProperties {
+		allErrs = append(allErrs, field.Invalid(fldPath, len(x), fmt.Sprintf("must have at most %d properties", *s.MaxProperties)))
+	}
+	if s.MinProperties != nil && int64(len(x)) < *s.MinProperties {
+		allErrs = append(allErrs, field.Invalid(fldPath, len(x), fmt.Sprintf("must have at least %d properties", *s.MinProperties)))
+	}
+	for _, k := range s.Required {
+		if _, found := x[k]; !found {
+			allErrs = append(allErrs, field.Required(fldPath.Child(k), ""))
+		}
+	}
+
+	// iterate in a stable order to return the errors deterministically
+	keys := make([]string, 0, len(x))
+	for k := range x {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if prop, found := s.Properties[k]; found {
+			allErrs = append(allErrs, Validate(x[k], &prop, fldPath.Child(k))...)
+		} else if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+			allErrs = append(allErrs, Validate(x[k], s.AdditionalProperties.Schema, fldPath.Key(k))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateJunctors(x interface{}, s *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i := range s.AllOf {
+		allErrs = append(allErrs, Validate(x, &s.AllOf[i], fldPath)...)
+	}
+	if len(s.AnyOf) > 0 && countValid(x, s.AnyOf, fldPath) == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, x, "must validate against at least one schema of anyOf"))
+	}
+	if len(s.OneOf) > 0 && countValid(x, s.OneOf, fldPath) != 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath, x, "must validate against exactly one schema of oneOf"))
+	}
+	if s.Not != nil && len(Validate(x, s.Not, fldPath)) == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, x, "must not validate against the schema of not"))
+	}
+
+	return allErrs
+}
+
+// countValid returns the number of schemas x validates against.
+func countValid(x interface{}, schemas []apiextensions.JSONSchemaProps, fldPath *field.Path) int {
+	n := 0
+	for i := range schemas {
+		if len(Validate(x, &schemas[i], fldPath)) == 0 {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/util/json"
+)
+
+func TestValidate(t *testing.T) {
+	schema := &apiextensions.JSONSchemaProps{
+		Type:          "object",
+		Required:      []string{"name"},
+		MaxProperties: int64Ptr(9),
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"name": {
+				Type:      "string",
+				MinLength: int64Ptr(2),
+				MaxLength: int64Ptr(5),
+				Pattern:   "^[a-zä]+$",
+			},
+			"replicas": {
+				Type:       "integer",
+				Minimum:    float64Ptr(0),
+				Maximum:    float64Ptr(10),
+				MultipleOf: float64Ptr(2),
+			},
+			"ratio": {
+				Type:             "number",
+				Minimum:          float64Ptr(0),
+				ExclusiveMinimum: true,
+			},
+			"amount": {
+				Type:   "string",
+				Format: "decimal",
+			},
+			"mode": {
+				Enum: []apiextensions.JSON{"fast", int64(1), map[string]interface{}{"a": float64(1)}},
+			},
+			"tags": {
+				Type:     "array",
+				MinItems: int64Ptr(1),
+				Items:    &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string"}},
+			},
+			"labels": {
+				Type: "object",
+				AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{
+					Schema: &apiextensions.JSONSchemaProps{Type: "boolean"},
+				},
+			},
+			"port": {
+				AnyOf: []apiextensions.JSONSchemaProps{{Type: "integer"}, {Type: "string"}},
+				Not:   &apiextensions.JSONSchemaProps{Enum: []apiextensions.JSON{int64(0)}},
+			},
+			"protocol": {
+				OneOf: []apiextensions.JSONSchemaProps{{Type: "string"}, {Enum: []apiextensions.JSON{"tcp"}}},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		json     string
+		expected []string
+	}{
+		{"valid", `{"name":"fooä","replicas":4.0,"ratio":0.5,"amount":"-12345678901234567890.123456789e-3","mode":{"a":1},"tags":["a"],"labels":{"a":true},"port":"http","protocol":"udp"}`, nil},
+		{"wrong type", `[]`, []string{""}},
+		{"missing required", `{}`, []string{"name"}},
+		{"too many properties", `{"name":"foo","replicas":2,"ratio":1,"mode":1,"tags":["a"],"labels":{},"port":1,"protocol":"udp","amount":"1","extra":1}`, []string{""}},
+		{"invalid strings", `{"name":"fooBarBaz"}`, []string{"name", "name"}},
+		{"invalid numbers", `{"name":"foo","replicas":3,"ratio":0}`, []string{"ratio", "replicas"}},
+		{"invalid decimal", `{"name":"foo","amount":"1.5.0"}`, []string{"amount"}},
+		{"out of range", `{"name":"foo","replicas":12}`, []string{"replicas"}},
+		{"not an integer", `{"name":"foo","replicas":2.5}`, []string{"replicas"}},
+		{"not in enum", `{"name":"foo","mode":{"a":2}}`, []string{"mode"}},
+		{"invalid nested values", `{"name":"foo","tags":[1],"labels":{"a":"b"}}`, []string{"labels[a]", "tags[0]"}},
+		{"too few items", `{"name":"foo","tags":[]}`, []string{"tags"}},
+		{"junctors", `{"name":"foo","port":0,"protocol":"tcp"}`, []string{"port", "protocol"}},
+		{"no junctor matches", `{"name":"foo","port":true}`, []string{"port"}},
+	}
+	for _, tt := range tests {
+		var in interface{}
+		if err := json.Unmarshal([]byte(tt.json), &in); err != nil {
+			t.Fatal(err)
+		}
+		var paths []string
+		for _, err := range Validate(in, schema, nil) {
+			paths = append(paths, err.Field)
+		}
+		if !reflect.DeepEqual(paths, tt.expected) {
+			t.Errorf("%s: expected errors at %q, got %q", tt.name, tt.expected, paths)
+		}
+	}
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
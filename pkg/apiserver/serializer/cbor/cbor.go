@@ -0,0 +1,256 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cbor implements a CBOR (RFC 7049) serializer for custom resources.
+package cbor
+
+import (
+	"bytes"
+	"encoding/base64"
+	encodingjson "encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/ugorji/go/codec"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+)
+
+// ContentTypeCBOR is the media type of the CBOR encoding.
+const ContentTypeCBOR = "application/cbor"
+
+// Serializer encodes custom resources and the objects served along with them, like Status and
+// WatchEvent, in CBOR. The encoding has the same structure as the JSON encoding.
+type Serializer struct {
+	handle *codec.CborHandle
+	json   *json.Serializer
+}
+
+var _ runtime.Serializer = &Serializer{}
+
+// NewSerializer returns a CBOR serializer. The creater and typer are used to decode objects other than
+// custom resources.
+func NewSerializer(creater runtime.ObjectCreater, typer runtime.ObjectTyper) *Serializer {
+	handle := &codec.CborHandle{}
+	handle.MapType = reflect.TypeOf(map[string]interface{}(nil))
+	handle.SignedInteger = true
+	return &Serializer{
+		handle: handle,
+		json:   json.NewSerializer(json.DefaultMetaFactory, creater, typer, false),
+	}
+}
+
+// Encode writes the CBOR encoding of obj to w.
+func (s *Serializer) Encode(obj runtime.Object, w io.Writer) error {
+	content, err := s.toContent(obj)
+	if err != nil {
+		return err
+	}
+	return codec.NewEncoder(w, s.handle).Encode(content)
+}
+
+// toContent returns the JSON-compatible content of obj.
+func (s *Serializer) toContent(obj runtime.Object) (interface{}, error) {
+	switch t := obj.(type) {
+	case *unstructured.UnstructuredList:
+		content := make(map[string]interface{}, len(t.Object)+1)
+		for k, v := range t.Object {
+			content[k] = v
+		}
+		items := make([]interface{}, 0, len(t.Items))
+		for i := range t.Items {
+			items = append(items, t.Items[i].Object)
+		}
+		content["items"] = items
+		return content, nil
+	case runtime.Unstructured:
+		return t.UnstructuredContent(), nil
+	case *metav1.WatchEvent:
+		// the object of a watch event is already encoded by the embedded encoder
+		var object interface{}
+		switch {
+		case t.Object.Object != nil:
+			var err error
+			if object, err = s.toContent(t.Object.Object); err != nil {
+				return nil, err
+			}
+		case len(t.Object.Raw) > 0:
+			if err := codec.NewDecoderBytes(t.Object.Raw, s.handle).Decode(&object); err != nil {
+				return nil, fmt.Errorf("unable to decode the object of the watch event: %v", err)
+			}
+		}
+		return map[string]interface{}{
+			"type":   t.Type,
+			"object": object,
+		}, nil
+	default:
+		// other types are encoded like their JSON representation
+		data, err := encodingjson.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		decoder := encodingjson.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		var content interface{}
+		if err := decoder.Decode(&content); err != nil {
+			return nil, err
+		}
+		return fromJSONNumbers(content)
+	}
+}
+
+// fromJSONNumbers replaces the json.Numbers in x by int64 or float64 values.
+func fromJSONNumbers(x interface{}) (interface{}, error) {
+	switch x := x.(type) {
+	case map[string]interface{}:
+		for k, v := range x {
+			var err error
+			if x[k], err = fromJSONNumbers(v); err != nil {
+				return nil, err
+			}
+		}
+	case []interface{}:
+		for i, v := range x {
+			var err error
+			if x[i], err = fromJSONNumbers(v); err != nil {
+				return nil, err
+			}
+		}
+	case encodingjson.Number:
+		if i, err := x.Int64(); err == nil {
+			return i, nil
+		}
+		return x.Float64()
+	}
+	return x, nil
+}
+
+// Decode decodes the CBOR encoding in data. Custom resources are decoded directly into Unstructured
+// objects, with integers as int64 like the JSON decoding, other objects into the types of the creater.
+func (s *Serializer) Decode(data []byte, gvk *schema.GroupVersionKind, into runtime.Object) (runtime.Object, *schema.GroupVersionKind, error) {
+	var content map[string]interface{}
+	if err := codec.NewDecoderBytes(data, s.handle).Decode(&content); err != nil {
+		return nil, nil, fmt.Errorf("unable to decode CBOR: %v", err)
+	}
+	if content == nil {
+		return nil, nil, fmt.Errorf("expected an object, got null")
+	}
+	for k, v := range content {
+		var err error
+		if content[k], err = toJSONValue(v); err != nil {
+			return nil, nil, fmt.Errorf("unable to decode CBOR: %s: %v", k, err)
+		}
+	}
+
+	var obj runtime.Object
+	switch t := into.(type) {
+	case nil:
+		if _, found := content["items"]; found {
+			list := &unstructured.UnstructuredList{}
+			if err := setListContent(list, content); err != nil {
+				return nil, nil, err
+			}
+			obj = list
+		} else {
+			obj = &unstructured.Unstructured{Object: content}
+		}
+	case *unstructured.Unstructured:
+		t.Object = content
+		obj = t
+	case *unstructured.UnstructuredList:
+		if err := setListContent(t, content); err != nil {
+			return nil, nil, err
+		}
+		obj = t
+	default:
+		js, err := encodingjson.Marshal(content)
+		if err != nil {
+			return nil, nil, err
+		}
+		return s.json.Decode(js, gvk, into)
+	}
+
+	actual := obj.GetObjectKind().GroupVersionKind()
+	if len(actual.Kind) == 0 {
+		return nil, &actual, runtime.NewMissingKindErr(fmt.Sprintf("%v", content))
+	}
+	return obj, &actual, nil
+}
+
+// setListContent sets the content of list, like the JSON decoding of unstructured lists. Items without
+// apiVersion and kind get those of the list.
+func setListContent(list *unstructured.UnstructuredList, content map[string]interface{}) error {
+	var items []interface{}
+	if content["items"] != nil {
+		var ok bool
+		if items, ok = content["items"].([]interface{}); !ok {
+			return fmt.Errorf("expected a list of items, got %T", content["items"])
+		}
+	}
+	delete(content, "items")
+	list.Object = content
+	list.Items = nil
+	itemKind := strings.TrimSuffix(list.GetKind(), "List")
+	for i, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("expected an object at index %d of the items, got %T", i, item)
+		}
+		u := unstructured.Unstructured{Object: m}
+		if len(u.GetKind()) == 0 && len(u.GetAPIVersion()) == 0 {
+			u.SetKind(itemKind)
+			u.SetAPIVersion(list.GetAPIVersion())
+		}
+		list.Items = append(list.Items, u)
+	}
+	return nil
+}
+
+// toJSONValue returns the decoded CBOR value x as JSON value, i.e. one of bool, int64, float64, string,
+// []interface{}, map[string]interface{} or nil. The handle decodes integers as int64 and floats as
+// float64; byte strings become base64 strings like in their JSON encoding.
+func toJSONValue(x interface{}) (interface{}, error) {
+	switch x := x.(type) {
+	case map[string]interface{}:
+		for k, v := range x {
+			var err error
+			if x[k], err = toJSONValue(v); err != nil {
+				return nil, err
+			}
+		}
+		return x, nil
+	case []interface{}:
+		for i, v := range x {
+			var err error
+			if x[i], err = toJSONValue(v); err != nil {
+				return nil, err
+			}
+		}
+		return x, nil
+	case bool, int64, float64, string, nil:
+		return x, nil
+	case []byte:
+		return base64.StdEncoding.EncodeToString(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported value of type %T", x)
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cbor
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/ugorji/go/codec"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newTestSerializer() *Serializer {
+	scheme := runtime.NewScheme()
+	scheme.AddUnversionedTypes(metav1.SchemeGroupVersion, &metav1.Status{})
+	return NewSerializer(scheme, scheme)
+}
+
+func newTestCustomResource(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "mygroup.example.com/v1beta1",
+		"kind":       "Noxu",
+		"metadata":   map[string]interface{}{"name": name},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+			"ratio":    float64(0.5),
+			"enabled":  true,
+			"tags":     []interface{}{"a", int64(-1), nil},
+		},
+	}
+}
+
+func TestRoundtrip(t *testing.T) {
+	s := newTestSerializer()
+
+	cr := &unstructured.Unstructured{Object: newTestCustomResource("foo")}
+	buf := &bytes.Buffer{}
+	if err := s.Encode(cr, buf); err != nil {
+		t.Fatal(err)
+	}
+	obj, gvk, err := s.Decode(buf.Bytes(), nil, &unstructured.Unstructured{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(obj, cr) {
+		t.Errorf("expected %v, got %v", cr, obj)
+	}
+	if gvk.Kind != "Noxu" {
+		t.Errorf("expected kind Noxu, got %v", gvk)
+	}
+
+	list := &unstructured.UnstructuredList{
+		Object: map[string]interface{}{"apiVersion": "mygroup.example.com/v1beta1", "kind": "NoxuList", "metadata": map[string]interface{}{"resourceVersion": "42"}},
+		Items:  []unstructured.Unstructured{{Object: newTestCustomResource("foo")}, {Object: newTestCustomResource("bar")}},
+	}
+	buf.Reset()
+	if err := s.Encode(list, buf); err != nil {
+		t.Fatal(err)
+	}
+	if obj, _, err = s.Decode(buf.Bytes(), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(obj, list) {
+		t.Errorf("expected %v, got %v", list, obj)
+	}
+
+	status := &metav1.Status{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Status"}, Status: metav1.StatusFailure, Code: 404, Reason: metav1.StatusReasonNotFound}
+	buf.Reset()
+	if err := s.Encode(status, buf); err != nil {
+		t.Fatal(err)
+	}
+	if obj, _, err = s.Decode(buf.Bytes(), nil, &metav1.Status{}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(obj, status) {
+		t.Errorf("expected %#v, got %#v", status, obj)
+	}
+}
+
+func TestEncodeWatchEvent(t *testing.T) {
+	s := newTestSerializer()
+
+	raw := &bytes.Buffer{}
+	if err := s.Encode(&unstructured.Unstructured{Object: newTestCustomResource("foo")}, raw); err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	if err := s.Encode(&metav1.WatchEvent{Type: "ADDED", Object: runtime.RawExtension{Raw: raw.Bytes()}}, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var content interface{}
+	if err := codec.NewDecoderBytes(buf.Bytes(), s.handle).Decode(&content); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{"type": "ADDED", "object": newTestCustomResource("foo")}
+	if !reflect.DeepEqual(content, expected) {
+		t.Errorf("expected %v, got %v", expected, content)
+	}
+}
+
+func TestDecodeValues(t *testing.T) {
+	s := newTestSerializer()
+
+	buf := &bytes.Buffer{}
+	content := map[string]interface{}{
+		"apiVersion": "mygroup.example.com/v1beta1",
+		"kind":       "Noxu",
+		"spec": map[string]interface{}{
+			"small": uint64(42),
+			"ratio": float32(0.5),
+			"data":  []byte("foo"),
+			"items": []interface{}{int64(-1), map[string]interface{}{"a": uint64(1)}},
+		},
+	}
+	if err := codec.NewEncoder(buf, &codec.CborHandle{}).Encode(content); err != nil {
+		t.Fatal(err)
+	}
+	obj, _, err := s.Decode(buf.Bytes(), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "mygroup.example.com/v1beta1",
+		"kind":       "Noxu",
+		"spec": map[string]interface{}{
+			"small": int64(42),
+			"ratio": float64(0.5),
+			"data":  "Zm9v",
+			"items": []interface{}{int64(-1), map[string]interface{}{"a": int64(1)}},
+		},
+	}}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Errorf("expected %#v, got %#v", expected, obj)
+	}
+
+	buf.Reset()
+	if err := codec.NewEncoder(buf, s.handle).Encode(map[string]interface{}{"apiVersion": "v1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.Decode(buf.Bytes(), nil, &unstructured.Unstructured{}); !runtime.IsMissingKind(err) {
+		t.Errorf("expected a missing kind error, got %v", err)
+	}
+
+	buf.Reset()
+	if err := codec.NewEncoder(buf, s.handle).Encode([]interface{}{"foo"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.Decode(buf.Bytes(), nil, nil); err == nil {
+		t.Errorf("expected an error for an array")
+	}
+}
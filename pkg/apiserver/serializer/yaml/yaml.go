@@ -0,0 +1,228 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package yaml implements a YAML serializer for custom resources. Unlike a plain conversion of YAML to
+// JSON, unquoted scalars are decoded according to the schema of the custom resources: in string fields,
+// e.g. of versions, and in fields with the format decimal, values like 1.10 or yes stay strings instead
+// of becoming numbers or booleans.
+package yaml
+
+import (
+	"bytes"
+	encodingjson "encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ghodss/yaml"
+	yamlv2 "gopkg.in/yaml.v2"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/decimal"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+)
+
+// ContentTypeYAML is the media type of the YAML encoding.
+const ContentTypeYAML = "application/yaml"
+
+// Serializer encodes custom resources and the objects served along with them, like Status and
+// WatchEvent, in YAML. The encoding has the same structure as the JSON encoding.
+type Serializer struct {
+	schema *apiextensions.JSONSchemaProps
+	json   *json.Serializer
+}
+
+var _ runtime.Serializer = &Serializer{}
+
+// NewSerializer returns a YAML serializer. Custom resources are decoded according to the schema, which
+// may be nil. The creater and typer are used to decode objects other than custom resources.
+func NewSerializer(creater runtime.ObjectCreater, typer runtime.ObjectTyper, s *apiextensions.JSONSchemaProps) *Serializer {
+	return &Serializer{
+		schema: withMetadataSchema(s),
+		json:   json.NewSerializer(json.DefaultMetaFactory, creater, typer, false),
+	}
+}
+
+// Encode writes the YAML encoding of obj to w.
+func (s *Serializer) Encode(obj runtime.Object, w io.Writer) error {
+	if event, ok := obj.(*metav1.WatchEvent); ok && len(event.Object.Raw) > 0 {
+		// the object of a watch event is already encoded in YAML by the embedded encoder
+		js, err := yaml.YAMLToJSON(event.Object.Raw)
+		if err != nil {
+			return fmt.Errorf("unable to convert the object of the watch event to JSON: %v", err)
+		}
+		copied := *event
+		copied.Object.Raw = js
+		obj = &copied
+	}
+
+	buf := &bytes.Buffer{}
+	if _, ok := obj.(runtime.Unstructured); ok {
+		if err := unstructured.UnstructuredJSONScheme.Encode(obj, buf); err != nil {
+			return err
+		}
+	} else if err := s.json.Encode(obj, buf); err != nil {
+		return err
+	}
+	data, err := yaml.JSONToYAML(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Decode decodes the YAML encoding in data. Custom resources are decoded into Unstructured objects
+// according to the schema, other objects into the types of the creater.
+func (s *Serializer) Decode(data []byte, gvk *schema.GroupVersionKind, into runtime.Object) (runtime.Object, *schema.GroupVersionKind, error) {
+	if _, ok := into.(runtime.Unstructured); !ok && into != nil {
+		js, err := yaml.YAMLToJSON(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error decoding YAML: %v", err)
+		}
+		return s.json.Decode(js, gvk, into)
+	}
+
+	var doc node
+	if err := yamlv2.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("error decoding YAML: %v", err)
+	}
+	content := doc.content(s.schema)
+	if _, ok := content.(map[string]interface{}); !ok {
+		return nil, nil, fmt.Errorf("expected an object, got %T", content)
+	}
+	js, err := encodingjson.Marshal(content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to convert YAML to JSON: %v", err)
+	}
+	return unstructured.UnstructuredJSONScheme.Decode(js, gvk, into)
+}
+
+// node is a decoded YAML value, which keeps the text of scalars.
+type node struct {
+	// value is a []node for sequences, a map[string]node for mappings and the resolved value for scalars
+	value interface{}
+	// text is the text of a scalar which is not null
+	text string
+}
+
+// UnmarshalYAML decodes a YAML value.
+func (n *node) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var sequence []node
+	if err := unmarshal(&sequence); err == nil {
+		// null is decoded as a nil sequence
+		if sequence != nil {
+			n.value = sequence
+		}
+		return nil
+	}
+	var mapping map[string]node
+	mappingErr := unmarshal(&mapping)
+	if mappingErr == nil {
+		n.value = mapping
+		return nil
+	}
+	if err := unmarshal(&n.value); err != nil {
+		return err
+	}
+	if _, ok := n.value.(map[interface{}]interface{}); ok {
+		// a mapping with keys which are not strings
+		return mappingErr
+	}
+	unmarshal(&n.text)
+	return nil
+}
+
+// content returns the JSON-compatible value of n. Scalars which are not strings in YAML are
+// strings if the schema s has the type string or the format decimal.
+func (n node) content(s *apiextensions.JSONSchemaProps) interface{} {
+	switch v := n.value.(type) {
+	case []node:
+		var items *apiextensions.JSONSchemaProps
+		if s != nil && s.Items != nil {
+			items = s.Items.Schema
+		}
+		ret := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			ret = append(ret, item.content(items))
+		}
+		return ret
+	case map[string]node:
+		ret := make(map[string]interface{}, len(v))
+		for k, property := range v {
+			ret[k] = property.content(propertySchema(s, k))
+		}
+		return ret
+	case string, nil:
+		return v
+	default:
+		if s != nil && (s.Type == "string" || s.Format == decimal.Format) && len(n.text) > 0 {
+			return n.text
+		}
+		return v
+	}
+}
+
+// propertySchema returns the schema of the property k of an object with the schema s.
+func propertySchema(s *apiextensions.JSONSchemaProps, k string) *apiextensions.JSONSchemaProps {
+	if s == nil {
+		return nil
+	}
+	if prop, found := s.Properties[k]; found {
+		return &prop
+	}
+	if s.AdditionalProperties != nil {
+		return s.AdditionalProperties.Schema
+	}
+	return nil
+}
+
+var stringSchema = apiextensions.JSONSchemaProps{Type: "string"}
+
+// metadataSchema types the string fields of the metadata, which is not part of the schemas of custom
+// resources, e.g. label values like 1.10.
+var metadataSchema = apiextensions.JSONSchemaProps{
+	Type: "object",
+	Properties: map[string]apiextensions.JSONSchemaProps{
+		"name":         stringSchema,
+		"generateName": stringSchema,
+		"namespace":    stringSchema,
+		"labels":       {Type: "object", AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{Allows: true, Schema: &stringSchema}},
+		"annotations":  {Type: "object", AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{Allows: true, Schema: &stringSchema}},
+	},
+}
+
+// withMetadataSchema returns a copy of the schema of custom resources s, which may be nil, with the
+// schemas of apiVersion, kind and metadata.
+func withMetadataSchema(s *apiextensions.JSONSchemaProps) *apiextensions.JSONSchemaProps {
+	ret := &apiextensions.JSONSchemaProps{}
+	if s != nil {
+		*ret = *s
+	}
+	ret.Properties = make(map[string]apiextensions.JSONSchemaProps, len(ret.Properties)+3)
+	if s != nil {
+		for k, v := range s.Properties {
+			ret.Properties[k] = v
+		}
+	}
+	ret.Properties["apiVersion"] = stringSchema
+	ret.Properties["kind"] = stringSchema
+	ret.Properties["metadata"] = metadataSchema
+	return ret
+}
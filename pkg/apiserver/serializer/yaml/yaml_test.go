@@ -0,0 +1,213 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yaml
+
+import (
+	"bytes"
+	encodingjson "encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newTestSerializer(s *apiextensions.JSONSchemaProps) *Serializer {
+	scheme := runtime.NewScheme()
+	scheme.AddUnversionedTypes(metav1.SchemeGroupVersion, &metav1.Status{})
+	return NewSerializer(scheme, scheme, s)
+}
+
+func newTestCustomResource(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "mygroup.example.com/v1beta1",
+		"kind":       "Noxu",
+		"metadata":   map[string]interface{}{"name": name},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+			"ratio":    float64(0.5),
+			"enabled":  true,
+			"version":  "1.10",
+			"tags":     []interface{}{"a", int64(-1), nil},
+		},
+	}
+}
+
+func TestRoundtrip(t *testing.T) {
+	s := newTestSerializer(nil)
+
+	cr := &unstructured.Unstructured{Object: newTestCustomResource("foo")}
+	buf := &bytes.Buffer{}
+	if err := s.Encode(cr, buf); err != nil {
+		t.Fatal(err)
+	}
+	obj, gvk, err := s.Decode(buf.Bytes(), nil, &unstructured.Unstructured{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(obj, cr) {
+		t.Errorf("expected %v, got %v", cr, obj)
+	}
+	if gvk.Kind != "Noxu" {
+		t.Errorf("expected kind Noxu, got %v", gvk)
+	}
+
+	list := &unstructured.UnstructuredList{
+		Object: map[string]interface{}{"apiVersion": "mygroup.example.com/v1beta1", "kind": "NoxuList", "metadata": map[string]interface{}{"resourceVersion": "42"}},
+		Items:  []unstructured.Unstructured{{Object: newTestCustomResource("foo")}, {Object: newTestCustomResource("bar")}},
+	}
+	buf.Reset()
+	if err := s.Encode(list, buf); err != nil {
+		t.Fatal(err)
+	}
+	if obj, _, err = s.Decode(buf.Bytes(), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(obj, list) {
+		t.Errorf("expected %v, got %v", list, obj)
+	}
+
+	status := &metav1.Status{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Status"}, Status: metav1.StatusFailure, Code: 404, Reason: metav1.StatusReasonNotFound}
+	buf.Reset()
+	if err := s.Encode(status, buf); err != nil {
+		t.Fatal(err)
+	}
+	if obj, _, err = s.Decode(buf.Bytes(), nil, &metav1.Status{}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(obj, status) {
+		t.Errorf("expected %#v, got %#v", status, obj)
+	}
+}
+
+func TestDecodeWithSchema(t *testing.T) {
+	stringSchema := apiextensions.JSONSchemaProps{Type: "string"}
+	s := newTestSerializer(&apiextensions.JSONSchemaProps{
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"spec": {
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"version":  stringSchema,
+					"price":    {Type: "string", Format: "decimal"},
+					"cost":     {Format: "decimal"},
+					"replicas": {Type: "integer"},
+					"flags": {
+						AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{Allows: true, Schema: &stringSchema},
+					},
+					"versions": {
+						Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &stringSchema},
+					},
+				},
+			},
+		},
+	})
+
+	tests := []struct {
+		name     string
+		data     string
+		expected map[string]interface{}
+	}{
+		{
+			name: "unquoted scalars in string fields",
+			data: "spec:\n  version: 1.10\n  price: 10.50\n  cost: 1e3\n  replicas: 3\n  flags:\n    debug: yes\n  versions: [1.0, 2, \"3\"]",
+			expected: map[string]interface{}{
+				"version":  "1.10",
+				"price":    "10.50",
+				"cost":     "1e3",
+				"replicas": int64(3),
+				"flags":    map[string]interface{}{"debug": "yes"},
+				"versions": []interface{}{"1.0", "2", "3"},
+			},
+		},
+		{
+			name: "null in string fields",
+			data: "spec:\n  version: null\n  price: ~",
+			expected: map[string]interface{}{
+				"version": nil,
+				"price":   nil,
+			},
+		},
+		{
+			name: "fields without schema",
+			data: "spec:\n  other: 1.10\n  replicas: true",
+			expected: map[string]interface{}{
+				"other":    float64(1.1),
+				"replicas": true,
+			},
+		},
+	}
+	for _, tc := range tests {
+		data := "apiVersion: mygroup.example.com/v1beta1\nkind: Noxu\nmetadata:\n  name: foo\n  labels:\n    release: 1.10\n" + tc.data
+		obj, _, err := s.Decode([]byte(data), nil, &unstructured.Unstructured{})
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		u := obj.(*unstructured.Unstructured)
+		if labels := u.GetLabels(); labels["release"] != "1.10" {
+			t.Errorf("%s: expected the label release to be 1.10, got %v", tc.name, labels)
+		}
+		if !reflect.DeepEqual(u.Object["spec"], tc.expected) {
+			t.Errorf("%s: expected spec %v, got %v", tc.name, tc.expected, u.Object["spec"])
+		}
+	}
+
+	for _, data := range []string{"- foo", "foo", "{1: foo}", "spec: ["} {
+		if _, _, err := s.Decode([]byte(data), nil, &unstructured.Unstructured{}); err == nil {
+			t.Errorf("expected an error decoding %q", data)
+		}
+	}
+}
+
+func TestEncodeWatchEvent(t *testing.T) {
+	s := newTestSerializer(nil)
+
+	raw := &bytes.Buffer{}
+	if err := s.Encode(&unstructured.Unstructured{Object: newTestCustomResource("foo")}, raw); err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	if err := s.Encode(&metav1.WatchEvent{Type: "ADDED", Object: runtime.RawExtension{Raw: raw.Bytes()}}, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	js, err := yaml.YAMLToJSON(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var event struct {
+		Type   string
+		Object encodingjson.RawMessage
+	}
+	if err := encodingjson.Unmarshal(js, &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.Type != "ADDED" {
+		t.Errorf("expected type ADDED, got %v", event.Type)
+	}
+	obj, _, err := s.Decode(event.Object, nil, &unstructured.Unstructured{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &unstructured.Unstructured{Object: newTestCustomResource("foo")}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Errorf("expected %v, got %v", expected, obj)
+	}
+}
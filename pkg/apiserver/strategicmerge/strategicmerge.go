@@ -0,0 +1,419 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package strategicmerge implements strategic merge patches of custom resources. Instead of the patch
+// tags of Go types, the merge strategies are taken from the x-kubernetes-list-type,
+// x-kubernetes-list-map-keys and x-kubernetes-map-type extensions of the validation schema.
+package strategicmerge
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+const (
+	directiveMarker               = "$patch"
+	retainKeysDirective           = "$retainKeys"
+	deleteFromPrimitiveListPrefix = "$deleteFromPrimitiveList/"
+	setElementOrderPrefix         = "$setElementOrder/"
+
+	mergeDirective   = "merge"
+	replaceDirective = "replace"
+	deleteDirective  = "delete"
+)
+
+// metadataSchema declares the merge strategies of the object metadata, which are the same as those
+// of the patch tags of metav1.ObjectMeta.
+var metadataSchema = apiextensions.JSONSchemaProps{
+	Type: "object",
+	Properties: map[string]apiextensions.JSONSchemaProps{
+		"finalizers": {
+			Type:      "array",
+			XListType: strPtr("set"),
+		},
+		"ownerReferences": {
+			Type:         "array",
+			XListType:    strPtr("map"),
+			XListMapKeys: []string{"uid"},
+		},
+	},
+}
+
+// Patch returns obj with the strategic merge patch applied, for custom resources of the given validation
+// schema, which may be nil. Neither obj nor patch are mutated.
+//
+// Objects are merged field by field, unless their schema sets x-kubernetes-map-type to atomic, and null
+// values remove fields. Arrays with x-kubernetes-list-type map are merged item by item, matching items by
+// their x-kubernetes-list-map-keys, and those with x-kubernetes-list-type set are merged value by value.
+// All other arrays are replaced. The $patch, $retainKeys, $deleteFromPrimitiveList and $setElementOrder
+// directives are supported like for built-in resources.
+func Patch(obj, patch map[string]interface{}, s *apiextensions.JSONSchemaProps) (map[string]interface{}, error) {
+	root := apiextensions.JSONSchemaProps{}
+	if s != nil {
+		root = *s
+	}
+	properties := make(map[string]apiextensions.JSONSchemaProps, len(root.Properties)+1)
+	for k, v := range root.Properties {
+		properties[k] = v
+	}
+	properties["metadata"] = metadataSchema
+	root.Properties = properties
+
+	if directive, found := patch[directiveMarker]; found && directive == deleteDirective {
+		return nil, fmt.Errorf("the %s directive is not allowed at the root of the patch", deleteDirective)
+	}
+	clone, err := apiextensions.DeepCopyJSONValue(obj)
+	if err != nil {
+		return nil, err
+	}
+	return mergeMap(clone.(map[string]interface{}), patch, &root)
+}
+
+// mergeValue returns patch merged into val, which may be mutated. It returns false if the patch removes
+// the value.
+func mergeValue(val, patch interface{}, s *apiextensions.JSONSchemaProps) (interface{}, bool, error) {
+	switch patch := patch.(type) {
+	case nil:
+		return nil, false, nil
+	case map[string]interface{}:
+		if directive, found := patch[directiveMarker]; found && directive == deleteDirective {
+			return nil, false, nil
+		}
+		m, ok := val.(map[string]interface{})
+		if !ok || isAtomicMap(s) {
+			m = map[string]interface{}{}
+		}
+		ret, err := mergeMap(m, patch, s)
+		return ret, true, err
+	case []interface{}:
+		l, _ := val.([]interface{})
+		ret, err := mergeList(l, patch, s)
+		return ret, true, err
+	default:
+		return patch, true, nil
+	}
+}
+
+// mergeMap returns patch merged into obj, which is mutated.
+func mergeMap(obj, patch map[string]interface{}, s *apiextensions.JSONSchemaProps) (map[string]interface{}, error) {
+	switch directive := patch[directiveMarker]; directive {
+	case nil, mergeDirective:
+	case replaceDirective:
+		obj = map[string]interface{}{}
+	default:
+		return nil, fmt.Errorf("invalid %s directive %v", directiveMarker, directive)
+	}
+
+	// values are removed from primitive lists before the patch is merged, and lists are ordered after
+	for k, v := range patch {
+		if !strings.HasPrefix(k, deleteFromPrimitiveListPrefix) {
+			continue
+		}
+		field := k[len(deleteFromPrimitiveListPrefix):]
+		values, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s must be a list", k)
+		}
+		if listType(propertySchema(s, field)) != "set" {
+			return nil, fmt.Errorf("%s is only supported for fields of x-kubernetes-list-type set", k)
+		}
+		if list, ok := obj[field].([]interface{}); ok {
+			obj[field] = removeValues(list, values)
+		}
+	}
+	for k, v := range patch {
+		if k == directiveMarker || k == retainKeysDirective || strings.HasPrefix(k, deleteFromPrimitiveListPrefix) || strings.HasPrefix(k, setElementOrderPrefix) {
+			continue
+		}
+		val, keep, err := mergeValue(obj[k], v, propertySchema(s, k))
+		if err != nil {
+			return nil, err
+		}
+		if keep {
+			obj[k] = val
+		} else {
+			delete(obj, k)
+		}
+	}
+	for k, v := range patch {
+		if !strings.HasPrefix(k, setElementOrderPrefix) {
+			continue
+		}
+		field := k[len(setElementOrderPrefix):]
+		order, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s must be a list", k)
+		}
+		fieldSchema := propertySchema(s, field)
+		if t := listType(fieldSchema); t != "map" && t != "set" {
+			return nil, fmt.Errorf("%s is only supported for fields of x-kubernetes-list-type map or set", k)
+		}
+		if list, ok := obj[field].([]interface{}); ok {
+			sortList(list, order, fieldSchema)
+		}
+	}
+	if v, found := patch[retainKeysDirective]; found {
+		keys, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s must be a list", retainKeysDirective)
+		}
+		retain := make(map[interface{}]bool, len(keys))
+		for _, k := range keys {
+			retain[k] = true
+		}
+		for k := range obj {
+			if !retain[k] {
+				delete(obj, k)
+			}
+		}
+	}
+	return obj, nil
+}
+
+// mergeList returns patch merged into list, which may be mutated.
+func mergeList(list, patch []interface{}, s *apiextensions.JSONSchemaProps) ([]interface{}, error) {
+	switch listType(s) {
+	case "map":
+		return mergeMapList(list, patch, s)
+	case "set":
+		for _, v := range patch {
+			if indexOf(list, v) >= 0 {
+				continue
+			}
+			clone, err := apiextensions.DeepCopyJSONValue(v)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, clone)
+		}
+		if list == nil {
+			list = []interface{}{}
+		}
+		return list, nil
+	default:
+		clone, err := apiextensions.DeepCopyJSONValue(patch)
+		if err != nil {
+			return nil, err
+		}
+		return clone.([]interface{}), nil
+	}
+}
+
+// mergeMapList returns patch merged into list, whose items are identified by their list map keys.
+func mergeMapList(list, patch []interface{}, s *apiextensions.JSONSchemaProps) ([]interface{}, error) {
+	items := make([]map[string]interface{}, 0, len(patch))
+	for i, v := range patch {
+		item, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected an object at index %d, got %T", i, v)
+		}
+		if len(item) == 1 && item[directiveMarker] == replaceDirective {
+			// the remaining items replace the list
+			list = nil
+			continue
+		}
+		if !hasAnyKey(item, s.XListMapKeys) {
+			return nil, fmt.Errorf("the item at index %d sets none of the keys %s", i, strings.Join(s.XListMapKeys, ", "))
+		}
+		items = append(items, item)
+	}
+
+	ret := make([]interface{}, 0, len(list)+len(items))
+	ret = append(ret, list...)
+	for _, item := range items {
+		i := indexOfKeys(ret, item, s.XListMapKeys)
+		var current interface{}
+		if i >= 0 {
+			current = ret[i]
+		}
+		merged, keep, err := mergeValue(current, item, itemsSchema(s))
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case !keep && i >= 0:
+			ret = append(ret[:i], ret[i+1:]...)
+		case keep && i >= 0:
+			ret[i] = merged
+		case keep:
+			ret = append(ret, merged)
+		}
+	}
+	return ret, nil
+}
+
+// sortList orders the items of list which are in order like there, followed by the others in their
+// current order.
+func sortList(list, order []interface{}, s *apiextensions.JSONSchemaProps) {
+	position := func(item interface{}) int {
+		for i, v := range order {
+			if listType(s) == "map" {
+				m, _ := v.(map[string]interface{})
+				if hasAnyKey(m, s.XListMapKeys) && indexOfKeys([]interface{}{item}, m, s.XListMapKeys) == 0 {
+					return i
+				}
+			} else if reflect.DeepEqual(item, v) {
+				return i
+			}
+		}
+		return len(order)
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return position(list[i]) < position(list[j])
+	})
+}
+
+// removeValues returns list without the given values.
+func removeValues(list, values []interface{}) []interface{} {
+	ret := make([]interface{}, 0, len(list))
+	for _, v := range list {
+		if indexOf(values, v) < 0 {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// indexOf returns the index of val in list, or -1 if it is not in list.
+func indexOf(list []interface{}, val interface{}) int {
+	for i, v := range list {
+		if reflect.DeepEqual(v, val) {
+			return i
+		}
+	}
+	return -1
+}
+
+// indexOfKeys returns the index of the item of list with the same values of the given keys as item, or
+// -1 if there is none.
+func indexOfKeys(list []interface{}, item map[string]interface{}, keys []string) int {
+	for i, v := range list {
+		m, _ := v.(map[string]interface{})
+		if equalKeys(m, item, keys) {
+			return i
+		}
+	}
+	return -1
+}
+
+// equalKeys returns true if a and b have equal values of the given keys. Absent keys equal null.
+func equalKeys(a, b map[string]interface{}, keys []string) bool {
+	for _, k := range keys {
+		if !equalJSON(a[k], b[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalJSON returns true if the JSON values a and b are equal. Like in their JSON encoding, integers
+// equal floats of the same value.
+func equalJSON(a, b interface{}) bool {
+	switch a := a.(type) {
+	case int64:
+		switch b := b.(type) {
+		case int64:
+			return a == b
+		case float64:
+			return float64(a) == b
+		}
+		return false
+	case float64:
+		switch b := b.(type) {
+		case int64:
+			return a == float64(b)
+		case float64:
+			return a == b
+		}
+		return false
+	case map[string]interface{}:
+		m, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(m) {
+			return false
+		}
+		for k, v := range a {
+			if w, found := m[k]; !found || !equalJSON(v, w) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		l, ok := b.([]interface{})
+		if !ok || len(a) != len(l) {
+			return false
+		}
+		for i := range a {
+			if !equalJSON(a[i], l[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// hasAnyKey returns true if item sets at least one of the given keys.
+func hasAnyKey(item map[string]interface{}, keys []string) bool {
+	for _, k := range keys {
+		if _, found := item[k]; found {
+			return true
+		}
+	}
+	return false
+}
+
+// isAtomicMap returns true if objects of the given schema are replaced instead of merged.
+func isAtomicMap(s *apiextensions.JSONSchemaProps) bool {
+	return s != nil && s.XMapType != nil && *s.XMapType == "atomic"
+}
+
+// listType returns the x-kubernetes-list-type of arrays of the given schema. Without a schema arrays are atomic.
+func listType(s *apiextensions.JSONSchemaProps) string {
+	if s == nil || s.XListType == nil {
+		return "atomic"
+	}
+	return *s.XListType
+}
+
+// itemsSchema returns the schema of the items of arrays of schema s, or nil if it is not specified.
+func itemsSchema(s *apiextensions.JSONSchemaProps) *apiextensions.JSONSchemaProps {
+	if s == nil || s.Items == nil {
+		return nil
+	}
+	return s.Items.Schema
+}
+
+// propertySchema returns the schema of the given property of objects of schema s, or nil if it is not specified.
+func propertySchema(s *apiextensions.JSONSchemaProps, property string) *apiextensions.JSONSchemaProps {
+	if s == nil {
+		return nil
+	}
+	if prop, ok := s.Properties[property]; ok {
+		return &prop
+	}
+	if s.AdditionalProperties != nil {
+		return s.AdditionalProperties.Schema
+	}
+	return nil
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategicmerge
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+var testSchema = &apiextensions.JSONSchemaProps{
+	Type: "object",
+	Properties: map[string]apiextensions.JSONSchemaProps{
+		"spec": {
+			Type: "object",
+			Properties: map[string]apiextensions.JSONSchemaProps{
+				"replicas": {Type: "integer"},
+				"ports": {
+					Type:  "array",
+					Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "integer"}},
+				},
+				"containers": {
+					Type:         "array",
+					XListType:    strPtr("map"),
+					XListMapKeys: []string{"name"},
+					Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiextensions.JSONSchemaProps{
+							"name":  {Type: "string"},
+							"image": {Type: "string"},
+							"args": {
+								Type:  "array",
+								Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string"}},
+							},
+						},
+					}},
+				},
+				"endpoints": {
+					Type:         "array",
+					XListType:    strPtr("map"),
+					XListMapKeys: []string{"port", "protocol"},
+				},
+				"tags": {
+					Type:      "array",
+					XListType: strPtr("set"),
+					Items:     &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string"}},
+				},
+				"config": {
+					Type:       "object",
+					XMapType:   strPtr("atomic"),
+					Properties: map[string]apiextensions.JSONSchemaProps{"a": {Type: "string"}, "b": {Type: "string"}},
+				},
+			},
+		},
+	},
+}
+
+func mustUnmarshal(t *testing.T, s string) map[string]interface{} {
+	var ret map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &ret); err != nil {
+		t.Fatal(err)
+	}
+	return ret
+}
+
+func TestPatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		obj         string
+		patch       string
+		noSchema    bool
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "merge fields",
+			obj:      `{"spec": {"replicas": 1, "other": {"x": 1, "y": 2}}}`,
+			patch:    `{"spec": {"replicas": 2, "other": {"y": null, "z": 3}}}`,
+			expected: `{"spec": {"replicas": 2, "other": {"x": 1, "z": 3}}}`,
+		},
+		{
+			name:     "replace atomic list",
+			obj:      `{"spec": {"ports": [80, 443]}}`,
+			patch:    `{"spec": {"ports": [8080]}}`,
+			expected: `{"spec": {"ports": [8080]}}`,
+		},
+		{
+			name:     "replace atomic map",
+			obj:      `{"spec": {"config": {"a": "x", "b": "y"}}}`,
+			patch:    `{"spec": {"config": {"a": "z"}}}`,
+			expected: `{"spec": {"config": {"a": "z"}}}`,
+		},
+		{
+			name:     "merge list map items by key",
+			obj:      `{"spec": {"containers": [{"name": "a", "image": "x", "args": ["1"]}, {"name": "b", "image": "y"}]}}`,
+			patch:    `{"spec": {"containers": [{"name": "a", "image": "z"}, {"name": "c", "image": "w"}]}}`,
+			expected: `{"spec": {"containers": [{"name": "a", "image": "z", "args": ["1"]}, {"name": "b", "image": "y"}, {"name": "c", "image": "w"}]}}`,
+		},
+		{
+			name:     "merge list map items by multiple keys",
+			obj:      `{"spec": {"endpoints": [{"port": 80, "protocol": "TCP", "name": "a"}, {"port": 80, "protocol": "UDP", "name": "b"}]}}`,
+			patch:    `{"spec": {"endpoints": [{"port": 80, "protocol": "UDP", "name": "c"}]}}`,
+			expected: `{"spec": {"endpoints": [{"port": 80, "protocol": "TCP", "name": "a"}, {"port": 80, "protocol": "UDP", "name": "c"}]}}`,
+		},
+		{
+			name:     "delete list map item",
+			obj:      `{"spec": {"containers": [{"name": "a", "image": "x"}, {"name": "b", "image": "y"}]}}`,
+			patch:    `{"spec": {"containers": [{"name": "a", "$patch": "delete"}]}}`,
+			expected: `{"spec": {"containers": [{"name": "b", "image": "y"}]}}`,
+		},
+		{
+			name:     "replace list map item",
+			obj:      `{"spec": {"containers": [{"name": "a", "image": "x", "args": ["1"]}]}}`,
+			patch:    `{"spec": {"containers": [{"name": "a", "image": "z", "$patch": "replace"}]}}`,
+			expected: `{"spec": {"containers": [{"name": "a", "image": "z"}]}}`,
+		},
+		{
+			name:     "replace list map",
+			obj:      `{"spec": {"containers": [{"name": "a", "image": "x"}, {"name": "b", "image": "y"}]}}`,
+			patch:    `{"spec": {"containers": [{"name": "c", "image": "z"}, {"$patch": "replace"}]}}`,
+			expected: `{"spec": {"containers": [{"name": "c", "image": "z"}]}}`,
+		},
+		{
+			name:        "list map item without keys",
+			obj:         `{"spec": {"containers": [{"name": "a", "image": "x"}]}}`,
+			patch:       `{"spec": {"containers": [{"image": "z"}]}}`,
+			expectError: true,
+		},
+		{
+			name:     "merge set",
+			obj:      `{"spec": {"tags": ["a", "b"]}}`,
+			patch:    `{"spec": {"tags": ["b", "c"]}}`,
+			expected: `{"spec": {"tags": ["a", "b", "c"]}}`,
+		},
+		{
+			name:     "delete from set",
+			obj:      `{"spec": {"tags": ["a", "b", "c"]}}`,
+			patch:    `{"spec": {"$deleteFromPrimitiveList/tags": ["a", "c"]}}`,
+			expected: `{"spec": {"tags": ["b"]}}`,
+		},
+		{
+			name:        "delete from atomic list",
+			obj:         `{"spec": {"ports": [80]}}`,
+			patch:       `{"spec": {"$deleteFromPrimitiveList/ports": [80]}}`,
+			expectError: true,
+		},
+		{
+			name:     "set element order",
+			obj:      `{"spec": {"containers": [{"name": "a"}, {"name": "b"}, {"name": "c"}], "tags": ["x", "y"]}}`,
+			patch:    `{"spec": {"$setElementOrder/containers": [{"name": "c"}, {"name": "a"}], "$setElementOrder/tags": ["y", "x"]}}`,
+			expected: `{"spec": {"containers": [{"name": "c"}, {"name": "a"}, {"name": "b"}], "tags": ["y", "x"]}}`,
+		},
+		{
+			name:     "retain keys",
+			obj:      `{"spec": {"replicas": 1, "ports": [80], "other": "x"}}`,
+			patch:    `{"spec": {"$retainKeys": ["replicas", "tags"], "tags": ["a"]}}`,
+			expected: `{"spec": {"replicas": 1, "tags": ["a"]}}`,
+		},
+		{
+			name:     "replace map",
+			obj:      `{"spec": {"replicas": 1, "ports": [80]}}`,
+			patch:    `{"spec": {"$patch": "replace", "replicas": 2}}`,
+			expected: `{"spec": {"replicas": 2}}`,
+		},
+		{
+			name:     "delete map",
+			obj:      `{"spec": {"replicas": 1}, "status": {"x": 1}}`,
+			patch:    `{"status": {"$patch": "delete"}}`,
+			expected: `{"spec": {"replicas": 1}}`,
+		},
+		{
+			name:        "invalid directive",
+			obj:         `{"spec": {"replicas": 1}}`,
+			patch:       `{"spec": {"$patch": "unknown"}}`,
+			expectError: true,
+		},
+		{
+			name:     "metadata",
+			obj:      `{"metadata": {"name": "foo", "finalizers": ["a"], "ownerReferences": [{"uid": "1", "name": "x"}], "labels": {"a": "b"}}}`,
+			patch:    `{"metadata": {"finalizers": ["b"], "ownerReferences": [{"uid": "2", "name": "y"}], "labels": {"c": "d"}}}`,
+			expected: `{"metadata": {"name": "foo", "finalizers": ["a", "b"], "ownerReferences": [{"uid": "1", "name": "x"}, {"uid": "2", "name": "y"}], "labels": {"a": "b", "c": "d"}}}`,
+		},
+		{
+			name:     "without schema",
+			obj:      `{"spec": {"list": [1, 2], "map": {"a": 1}}}`,
+			patch:    `{"spec": {"list": [3], "map": {"b": 2}}}`,
+			noSchema: true,
+			expected: `{"spec": {"list": [3], "map": {"a": 1, "b": 2}}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		obj := mustUnmarshal(t, tc.obj)
+		patch := mustUnmarshal(t, tc.patch)
+		s := testSchema
+		if tc.noSchema {
+			s = nil
+		}
+
+		result, err := Patch(obj, patch, s)
+		if tc.expectError {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %v", tc.name, result)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if expected := mustUnmarshal(t, tc.expected); !reflect.DeepEqual(result, expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, expected, result)
+		}
+		if !reflect.DeepEqual(obj, mustUnmarshal(t, tc.obj)) {
+			t.Errorf("%s: the object was mutated", tc.name)
+		}
+		if !reflect.DeepEqual(patch, mustUnmarshal(t, tc.patch)) {
+			t.Errorf("%s: the patch was mutated", tc.name)
+		}
+	}
+}
+
+func TestPatchUnstructuredValues(t *testing.T) {
+	// integer keys of unstructured objects match float keys of the patch
+	obj := map[string]interface{}{"spec": map[string]interface{}{"endpoints": []interface{}{
+		map[string]interface{}{"port": int64(80), "protocol": "TCP", "name": "a"},
+	}}}
+	patch := map[string]interface{}{"spec": map[string]interface{}{"endpoints": []interface{}{
+		map[string]interface{}{"port": float64(80), "protocol": "TCP", "name": "b"},
+	}}}
+	result, err := Patch(obj, patch, testSchema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]interface{}{"spec": map[string]interface{}{"endpoints": []interface{}{
+		map[string]interface{}{"port": float64(80), "protocol": "TCP", "name": "b"},
+	}}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+
+	// values which are not JSON fail the patch
+	obj = map[string]interface{}{"spec": map[string]interface{}{"replicas": 1}}
+	if _, err := Patch(obj, map[string]interface{}{}, testSchema); err == nil {
+		t.Errorf("expected an error for a value of type int")
+	}
+	patch = map[string]interface{}{"spec": map[string]interface{}{"tags": []interface{}{int32(1)}}}
+	if _, err := Patch(map[string]interface{}{}, patch, testSchema); err == nil {
+		t.Errorf("expected an error for a set item of type int32")
+	}
+}
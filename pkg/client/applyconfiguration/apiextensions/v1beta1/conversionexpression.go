@@ -0,0 +1,62 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was automatically generated by applyconfiguration-gen
+
+package v1beta1
+
+// ConversionExpressionApplyConfiguration represents a declarative configuration of the ConversionExpression type for use
+// with apply.
+type ConversionExpressionApplyConfiguration struct {
+	FromVersion   *string                                    `json:"fromVersion,omitempty"`
+	ToVersion     *string                                    `json:"toVersion,omitempty"`
+	FieldMappings []ConversionFieldMappingApplyConfiguration `json:"fieldMappings,omitempty"`
+}
+
+// ConversionExpressionApplyConfiguration constructs a declarative configuration of the ConversionExpression type for use with
+// apply.
+func ConversionExpression() *ConversionExpressionApplyConfiguration {
+	return &ConversionExpressionApplyConfiguration{}
+}
+
+// WithFromVersion sets the FromVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FromVersion field is set to the value of the last call.
+func (b *ConversionExpressionApplyConfiguration) WithFromVersion(value string) *ConversionExpressionApplyConfiguration {
+	b.FromVersion = &value
+	return b
+}
+
+// WithToVersion sets the ToVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ToVersion field is set to the value of the last call.
+func (b *ConversionExpressionApplyConfiguration) WithToVersion(value string) *ConversionExpressionApplyConfiguration {
+	b.ToVersion = &value
+	return b
+}
+
+// WithFieldMappings adds the given value to the FieldMappings field in the declarative configuration
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FieldMappings field.
+func (b *ConversionExpressionApplyConfiguration) WithFieldMappings(values ...*ConversionFieldMappingApplyConfiguration) *ConversionExpressionApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFieldMappings")
+		}
+		b.FieldMappings = append(b.FieldMappings, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was automatically generated by applyconfiguration-gen
+
+package v1beta1
+
+// ConversionFieldMappingApplyConfiguration represents a declarative configuration of the ConversionFieldMapping type for use
+// with apply.
+type ConversionFieldMappingApplyConfiguration struct {
+	Path       *string `json:"path,omitempty"`
+	Expression *string `json:"expression,omitempty"`
+}
+
+// ConversionFieldMappingApplyConfiguration constructs a declarative configuration of the ConversionFieldMapping type for use with
+// apply.
+func ConversionFieldMapping() *ConversionFieldMappingApplyConfiguration {
+	return &ConversionFieldMappingApplyConfiguration{}
+}
+
+// WithPath sets the Path field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Path field is set to the value of the last call.
+func (b *ConversionFieldMappingApplyConfiguration) WithPath(value string) *ConversionFieldMappingApplyConfiguration {
+	b.Path = &value
+	return b
+}
+
+// WithExpression sets the Expression field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Expression field is set to the value of the last call.
+func (b *ConversionFieldMappingApplyConfiguration) WithExpression(value string) *ConversionFieldMappingApplyConfiguration {
+	b.Expression = &value
+	return b
+}
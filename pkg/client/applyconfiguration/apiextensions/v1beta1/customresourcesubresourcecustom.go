@@ -0,0 +1,70 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was automatically generated by applyconfiguration-gen
+
+package v1beta1
+
+// CustomResourceSubresourceCustomApplyConfiguration represents a declarative configuration of the CustomResourceSubresourceCustom type for use
+// with apply.
+type CustomResourceSubresourceCustomApplyConfiguration struct {
+	Name       *string                                     `json:"name,omitempty"`
+	FieldPaths []string                                    `json:"fieldPaths,omitempty"`
+	Verbs      []string                                    `json:"verbs,omitempty"`
+	Validation *CustomResourceValidationApplyConfiguration `json:"validation,omitempty"`
+}
+
+// CustomResourceSubresourceCustomApplyConfiguration constructs a declarative configuration of the CustomResourceSubresourceCustom type for use with
+// apply.
+func CustomResourceSubresourceCustom() *CustomResourceSubresourceCustomApplyConfiguration {
+	return &CustomResourceSubresourceCustomApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *CustomResourceSubresourceCustomApplyConfiguration) WithName(value string) *CustomResourceSubresourceCustomApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithFieldPaths adds the given value to the FieldPaths field in the declarative configuration
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FieldPaths field.
+func (b *CustomResourceSubresourceCustomApplyConfiguration) WithFieldPaths(values ...string) *CustomResourceSubresourceCustomApplyConfiguration {
+	for i := range values {
+		b.FieldPaths = append(b.FieldPaths, values[i])
+	}
+	return b
+}
+
+// WithVerbs adds the given value to the Verbs field in the declarative configuration
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Verbs field.
+func (b *CustomResourceSubresourceCustomApplyConfiguration) WithVerbs(values ...string) *CustomResourceSubresourceCustomApplyConfiguration {
+	for i := range values {
+		b.Verbs = append(b.Verbs, values[i])
+	}
+	return b
+}
+
+// WithValidation sets the Validation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Validation field is set to the value of the last call.
+func (b *CustomResourceSubresourceCustomApplyConfiguration) WithValidation(value *CustomResourceValidationApplyConfiguration) *CustomResourceSubresourceCustomApplyConfiguration {
+	b.Validation = value
+	return b
+}
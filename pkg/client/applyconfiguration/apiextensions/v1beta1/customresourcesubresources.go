@@ -0,0 +1,62 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was automatically generated by applyconfiguration-gen
+
+package v1beta1
+
+// CustomResourceSubresourcesApplyConfiguration represents a declarative configuration of the CustomResourceSubresources type for use
+// with apply.
+type CustomResourceSubresourcesApplyConfiguration struct {
+	Scale  *CustomResourceSubresourceScaleApplyConfiguration   `json:"scale,omitempty"`
+	Status *CustomResourceSubresourceStatusApplyConfiguration  `json:"status,omitempty"`
+	Custom []CustomResourceSubresourceCustomApplyConfiguration `json:"custom,omitempty"`
+}
+
+// CustomResourceSubresourcesApplyConfiguration constructs a declarative configuration of the CustomResourceSubresources type for use with
+// apply.
+func CustomResourceSubresources() *CustomResourceSubresourcesApplyConfiguration {
+	return &CustomResourceSubresourcesApplyConfiguration{}
+}
+
+// WithScale sets the Scale field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Scale field is set to the value of the last call.
+func (b *CustomResourceSubresourcesApplyConfiguration) WithScale(value *CustomResourceSubresourceScaleApplyConfiguration) *CustomResourceSubresourcesApplyConfiguration {
+	b.Scale = value
+	return b
+}
+
+// WithStatus sets the Status field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Status field is set to the value of the last call.
+func (b *CustomResourceSubresourcesApplyConfiguration) WithStatus(value *CustomResourceSubresourceStatusApplyConfiguration) *CustomResourceSubresourcesApplyConfiguration {
+	b.Status = value
+	return b
+}
+
+// WithCustom adds the given value to the Custom field in the declarative configuration
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Custom field.
+func (b *CustomResourceSubresourcesApplyConfiguration) WithCustom(values ...*CustomResourceSubresourceCustomApplyConfiguration) *CustomResourceSubresourcesApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithCustom")
+		}
+		b.Custom = append(b.Custom, *values[i])
+	}
+	return b
+}
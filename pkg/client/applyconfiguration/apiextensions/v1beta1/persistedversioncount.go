@@ -0,0 +1,48 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was automatically generated by applyconfiguration-gen
+
+package v1beta1
+
+// PersistedVersionCountApplyConfiguration represents a declarative configuration of the PersistedVersionCount type for use
+// with apply.
+type PersistedVersionCountApplyConfiguration struct {
+	Version *string `json:"version,omitempty"`
+	Count   *int64  `json:"count,omitempty"`
+}
+
+// PersistedVersionCountApplyConfiguration constructs a declarative configuration of the PersistedVersionCount type for use with
+// apply.
+func PersistedVersionCount() *PersistedVersionCountApplyConfiguration {
+	return &PersistedVersionCountApplyConfiguration{}
+}
+
+// WithVersion sets the Version field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Version field is set to the value of the last call.
+func (b *PersistedVersionCountApplyConfiguration) WithVersion(value string) *PersistedVersionCountApplyConfiguration {
+	b.Version = &value
+	return b
+}
+
+// WithCount sets the Count field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Count field is set to the value of the last call.
+func (b *PersistedVersionCountApplyConfiguration) WithCount(value int64) *PersistedVersionCountApplyConfiguration {
+	b.Count = &value
+	return b
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"fmt"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	typedv1beta1 "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/testing"
+)
+
+var (
+	customResourceDefinitionsResource = apiextensionsv1beta1.SchemeGroupVersion.WithResource("customresourcedefinitions")
+	customResourceDefinitionsKind     = apiextensionsv1beta1.SchemeGroupVersion.WithKind("CustomResourceDefinition")
+)
+
+// NewSimpleClientsetWithControllers returns a clientset like NewSimpleClientset which also simulates
+// the naming and establishing controllers of the server. Created CustomResourceDefinitions, and
+// CustomResourceDefinitions whose spec is updated, get their names accepted and become Established
+// right away, unless their names conflict with the accepted names of another CustomResourceDefinition
+// of the same group. The status of the provided objects is kept as it is.
+func NewSimpleClientsetWithControllers(objects ...runtime.Object) *Clientset {
+	o := testing.NewObjectTracker(scheme, codecs.UniversalDecoder())
+	for _, obj := range objects {
+		if err := o.Add(obj); err != nil {
+			panic(err)
+		}
+	}
+
+	fakePtr := testing.Fake{}
+	fakePtr.AddReactor("create", "customresourcedefinitions", namingReaction(o))
+	fakePtr.AddReactor("update", "customresourcedefinitions", namingReaction(o))
+	fakePtr.AddReactor("*", "*", testing.ObjectReaction(o))
+
+	fakePtr.AddWatchReactor("*", testing.DefaultWatchReactor(watch.NewFake(), nil))
+
+	return &Clientset{fakePtr}
+}
+
+// namingReaction stores created and updated CustomResourceDefinitions with the names and conditions
+// the naming controller would set. Status updates are left to the other reactors.
+func namingReaction(tracker testing.ObjectTracker) testing.ReactionFunc {
+	return func(action testing.Action) (bool, runtime.Object, error) {
+		if len(action.GetSubresource()) > 0 {
+			return false, nil, nil
+		}
+		var obj runtime.Object
+		switch action := action.(type) {
+		case testing.CreateAction:
+			obj = action.GetObject()
+		case testing.UpdateAction:
+			obj = action.GetObject()
+		default:
+			return false, nil, nil
+		}
+		in, ok := obj.(*apiextensionsv1beta1.CustomResourceDefinition)
+		if !ok {
+			return false, nil, nil
+		}
+		crd := in.DeepCopy()
+
+		if action.GetVerb() == "update" {
+			// like the server, spec updates keep the status
+			existing, err := tracker.Get(customResourceDefinitionsResource, "", crd.Name)
+			if err != nil {
+				return true, nil, err
+			}
+			crd.Status = *existing.(*apiextensionsv1beta1.CustomResourceDefinition).Status.DeepCopy()
+		}
+
+		list, err := tracker.List(customResourceDefinitionsResource, customResourceDefinitionsKind, "")
+		if err != nil {
+			return true, nil, err
+		}
+		acceptNames(crd, list.(*apiextensionsv1beta1.CustomResourceDefinitionList).Items)
+
+		if action.GetVerb() == "create" {
+			err = tracker.Create(customResourceDefinitionsResource, crd, "")
+		} else {
+			err = tracker.Update(customResourceDefinitionsResource, crd, "")
+		}
+		if err != nil {
+			return true, nil, err
+		}
+		obj, err = tracker.Get(customResourceDefinitionsResource, "", crd.Name)
+		return true, obj, err
+	}
+}
+
+// acceptNames sets the accepted names and the NamesAccepted and Established conditions of crd like
+// the naming controller, given all CustomResourceDefinitions. Built-in resources are not considered.
+func acceptNames(crd *apiextensionsv1beta1.CustomResourceDefinition, all []apiextensionsv1beta1.CustomResourceDefinition) {
+	usedResources, usedKinds := sets.String{}, sets.String{}
+	for _, other := range all {
+		if other.Name == crd.Name || other.Spec.Group != crd.Spec.Group {
+			continue
+		}
+		usedResources.Insert(other.Status.AcceptedNames.Plural, other.Status.AcceptedNames.Singular)
+		usedResources.Insert(other.Status.AcceptedNames.ShortNames...)
+		usedKinds.Insert(other.Status.AcceptedNames.Kind, other.Status.AcceptedNames.ListKind)
+	}
+
+	namesAccepted := apiextensionsv1beta1.CustomResourceDefinitionCondition{
+		Type:               apiextensionsv1beta1.NamesAccepted,
+		Status:             apiextensionsv1beta1.ConditionTrue,
+		Reason:             "NoConflicts",
+		Message:            "no conflicts found",
+		ObservedGeneration: crd.Generation,
+	}
+	requested, accepted := crd.Spec.Names, &crd.Status.AcceptedNames
+	conflict := func(reason string, err error) {
+		namesAccepted.Status = apiextensionsv1beta1.ConditionFalse
+		namesAccepted.Reason = reason
+		namesAccepted.Message = err.Error()
+	}
+	accept := func(requested string, accepted *string, used sets.String, reason string) {
+		if requested != *accepted && used.Has(requested) {
+			conflict(reason, fmt.Errorf("%q is already in use", requested))
+			return
+		}
+		*accepted = requested
+	}
+	accept(requested.Plural, &accepted.Plural, usedResources, "PluralConflict")
+	accept(requested.Singular, &accepted.Singular, usedResources, "SingularConflict")
+	shortNamesAccepted := true
+	for _, shortName := range requested.ShortNames {
+		if usedResources.Has(shortName) && !sets.NewString(accepted.ShortNames...).Has(shortName) {
+			conflict("ShortNamesConflict", fmt.Errorf("%q is already in use", shortName))
+			shortNamesAccepted = false
+		}
+	}
+	if shortNamesAccepted {
+		accepted.ShortNames = requested.ShortNames
+	}
+	accept(requested.Kind, &accepted.Kind, usedKinds, "KindConflict")
+	accept(requested.ListKind, &accepted.ListKind, usedKinds, "ListKindConflict")
+	accepted.Categories = requested.Categories
+	typedv1beta1.SetCondition(crd, namesAccepted)
+
+	// like the establishing controller, never set Established back to false
+	established := apiextensionsv1beta1.CustomResourceDefinitionCondition{
+		Type:               apiextensionsv1beta1.Established,
+		Status:             apiextensionsv1beta1.ConditionFalse,
+		Reason:             "NotAccepted",
+		Message:            "not all names are accepted",
+		ObservedGeneration: crd.Generation,
+	}
+	for _, c := range crd.Status.Conditions {
+		if c.Type == apiextensionsv1beta1.Established && c.Status == apiextensionsv1beta1.ConditionTrue {
+			established = c
+			established.ObservedGeneration = crd.Generation
+		}
+	}
+	if namesAccepted.Status == apiextensionsv1beta1.ConditionTrue && established.Status != apiextensionsv1beta1.ConditionTrue {
+		established.Status = apiextensionsv1beta1.ConditionTrue
+		established.Reason = "InitialNamesAccepted"
+		established.Message = "the initial names have been accepted"
+	}
+	typedv1beta1.SetCondition(crd, established)
+}
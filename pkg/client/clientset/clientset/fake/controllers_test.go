@@ -0,0 +1,100 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"testing"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewSimpleClientsetWithControllers(t *testing.T) {
+	newCRD := func(name, plural, kind string) *apiextensionsv1beta1.CustomResourceDefinition {
+		return &apiextensionsv1beta1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
+				Group:   "example.com",
+				Version: "v1",
+				Names:   apiextensionsv1beta1.CustomResourceDefinitionNames{Plural: plural, Kind: kind, ListKind: kind + "List"},
+			},
+		}
+	}
+	condition := func(crd *apiextensionsv1beta1.CustomResourceDefinition, conditionType apiextensionsv1beta1.CustomResourceDefinitionConditionType) apiextensionsv1beta1.CustomResourceDefinitionCondition {
+		for _, c := range crd.Status.Conditions {
+			if c.Type == conditionType {
+				return c
+			}
+		}
+		t.Fatalf("%s: missing condition %s in %v", crd.Name, conditionType, crd.Status.Conditions)
+		return apiextensionsv1beta1.CustomResourceDefinitionCondition{}
+	}
+
+	client := NewSimpleClientsetWithControllers()
+	crds := client.ApiextensionsV1beta1().CustomResourceDefinitions()
+
+	noxus, err := crds.Create(newCRD("noxus.example.com", "noxus", "Noxu"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if noxus.Status.AcceptedNames.Plural != "noxus" || noxus.Status.AcceptedNames.Kind != "Noxu" {
+		t.Errorf("expected the names of noxus.example.com to be accepted, got %v", noxus.Status.AcceptedNames)
+	}
+	if c := condition(noxus, apiextensionsv1beta1.Established); c.Status != apiextensionsv1beta1.ConditionTrue {
+		t.Errorf("expected noxus.example.com to be established, got %v", c)
+	}
+
+	// a conflicting kind is not accepted and the CustomResourceDefinition is not established
+	other := newCRD("others.example.com", "others", "Noxu")
+	other.Spec.Names.ListKind = "OtherList"
+	other, err = crds.Create(other)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c := condition(other, apiextensionsv1beta1.NamesAccepted); c.Status != apiextensionsv1beta1.ConditionFalse || c.Reason != "KindConflict" {
+		t.Errorf("expected a KindConflict, got %v", c)
+	}
+	if c := condition(other, apiextensionsv1beta1.Established); c.Status != apiextensionsv1beta1.ConditionFalse {
+		t.Errorf("expected others.example.com not to be established, got %v", c)
+	}
+	if other.Status.AcceptedNames.Plural != "others" || other.Status.AcceptedNames.Kind != "" {
+		t.Errorf("expected all names but the kind of others.example.com to be accepted, got %v", other.Status.AcceptedNames)
+	}
+
+	// resolving the conflict with a spec update establishes it
+	other.Spec.Names.Kind = "Other"
+	other.Status = apiextensionsv1beta1.CustomResourceDefinitionStatus{}
+	other, err = crds.Update(other)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c := condition(other, apiextensionsv1beta1.Established); c.Status != apiextensionsv1beta1.ConditionTrue {
+		t.Errorf("expected others.example.com to be established after the update, got %v", c)
+	}
+	if other.Status.AcceptedNames.Plural != "others" || other.Status.AcceptedNames.Kind != "Other" {
+		t.Errorf("expected the updated names of others.example.com to be accepted, got %v", other.Status.AcceptedNames)
+	}
+
+	// status updates are stored as they are
+	noxus.Status.Conditions = nil
+	if noxus, err = crds.UpdateStatus(noxus); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(noxus.Status.Conditions) != 0 {
+		t.Errorf("expected the status update to be stored as it is, got %v", noxus.Status.Conditions)
+	}
+}
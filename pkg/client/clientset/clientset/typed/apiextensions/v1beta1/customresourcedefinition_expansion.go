@@ -0,0 +1,149 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	v1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// The CustomResourceDefinitionExpansion interface allows manually adding extra methods to the CustomResourceDefinitionInterface.
+type CustomResourceDefinitionExpansion interface {
+	// PatchStatusCondition sets the condition in the status of the named CustomResourceDefinition,
+	// replacing an existing condition of the same type. The lastTransitionTime is only changed if the
+	// status of the condition changes. Conflicting updates are retried with the latest object.
+	PatchStatusCondition(name string, condition v1beta1.CustomResourceDefinitionCondition) (*v1beta1.CustomResourceDefinition, error)
+	// EnsureFinalizer adds the finalizer to the named CustomResourceDefinition unless it is present.
+	// Conflicting updates are retried with the latest object.
+	EnsureFinalizer(name, finalizer string) (*v1beta1.CustomResourceDefinition, error)
+	// RemoveFinalizer removes the finalizer from the named CustomResourceDefinition if it is present.
+	// Conflicting updates are retried with the latest object.
+	RemoveFinalizer(name, finalizer string) (*v1beta1.CustomResourceDefinition, error)
+}
+
+// ConflictBackoff is the backoff of the retries of the CustomResourceDefinitionExpansion methods
+// after conflicting updates.
+var ConflictBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 10 * time.Millisecond,
+	Factor:   1.0,
+	Jitter:   0.1,
+}
+
+func (c *customResourceDefinitions) PatchStatusCondition(name string, condition v1beta1.CustomResourceDefinitionCondition) (*v1beta1.CustomResourceDefinition, error) {
+	return UpdateOnConflict(c, name, func(crd *v1beta1.CustomResourceDefinition) bool {
+		return SetCondition(crd, condition)
+	}, c.UpdateStatus)
+}
+
+func (c *customResourceDefinitions) EnsureFinalizer(name, finalizer string) (*v1beta1.CustomResourceDefinition, error) {
+	return UpdateOnConflict(c, name, func(crd *v1beta1.CustomResourceDefinition) bool {
+		return AddFinalizer(crd, finalizer)
+	}, c.Update)
+}
+
+func (c *customResourceDefinitions) RemoveFinalizer(name, finalizer string) (*v1beta1.CustomResourceDefinition, error) {
+	return UpdateOnConflict(c, name, func(crd *v1beta1.CustomResourceDefinition) bool {
+		return RemoveFinalizer(crd, finalizer)
+	}, c.Update)
+}
+
+// UpdateOnConflict gets the named CustomResourceDefinition, applies mutate to it and stores it with
+// update. The whole cycle is repeated with ConflictBackoff while the update conflicts. If mutate
+// returns false, nothing is updated and the CustomResourceDefinition is returned as it was read.
+func UpdateOnConflict(c CustomResourceDefinitionInterface, name string, mutate func(*v1beta1.CustomResourceDefinition) bool, update func(*v1beta1.CustomResourceDefinition) (*v1beta1.CustomResourceDefinition, error)) (*v1beta1.CustomResourceDefinition, error) {
+	var result *v1beta1.CustomResourceDefinition
+	var conflict error
+	err := wait.ExponentialBackoff(ConflictBackoff, func() (bool, error) {
+		crd, err := c.Get(name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		if !mutate(crd) {
+			result = crd
+			return true, nil
+		}
+		result, err = update(crd)
+		if errors.IsConflict(err) {
+			conflict = err
+			return false, nil
+		}
+		return err == nil, err
+	})
+	if err == wait.ErrWaitTimeout {
+		err = conflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SetCondition sets the condition in the status of crd, replacing an existing condition of the same
+// type. The lastTransitionTime is only changed if the status changes, to the lastTransitionTime of
+// condition or to the current time if that is not set. It returns false if nothing changed.
+func SetCondition(crd *v1beta1.CustomResourceDefinition, condition v1beta1.CustomResourceDefinitionCondition) bool {
+	if condition.LastTransitionTime.IsZero() {
+		condition.LastTransitionTime = metav1.NewTime(time.Now())
+	}
+	for i := range crd.Status.Conditions {
+		existing := &crd.Status.Conditions[i]
+		if existing.Type != condition.Type {
+			continue
+		}
+		if existing.Status == condition.Status {
+			if existing.Reason == condition.Reason && existing.Message == condition.Message && existing.ObservedGeneration == condition.ObservedGeneration {
+				return false
+			}
+			condition.LastTransitionTime = existing.LastTransitionTime
+		}
+		*existing = condition
+		return true
+	}
+	crd.Status.Conditions = append(crd.Status.Conditions, condition)
+	return true
+}
+
+// AddFinalizer adds the finalizer to crd unless it is present. It returns false if it was present.
+func AddFinalizer(crd *v1beta1.CustomResourceDefinition, finalizer string) bool {
+	for _, f := range crd.Finalizers {
+		if f == finalizer {
+			return false
+		}
+	}
+	crd.Finalizers = append(crd.Finalizers, finalizer)
+	return true
+}
+
+// RemoveFinalizer removes the finalizer from crd. It returns false if it was not present.
+func RemoveFinalizer(crd *v1beta1.CustomResourceDefinition, finalizer string) bool {
+	finalizers := make([]string, 0, len(crd.Finalizers))
+	for _, f := range crd.Finalizers {
+		if f != finalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+	if len(finalizers) == len(crd.Finalizers) {
+		return false
+	}
+	crd.Finalizers = finalizers
+	return true
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	v1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+// crdServer serves a single CustomResourceDefinition and rejects the first conflicts updates.
+type crdServer struct {
+	crd       *v1beta1.CustomResourceDefinition
+	conflicts int
+	updates   []string
+}
+
+func (s *crdServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch req.Method {
+	case "GET":
+		json.NewEncoder(w).Encode(s.crd)
+	case "PUT":
+		s.updates = append(s.updates, req.URL.Path)
+		if s.conflicts > 0 {
+			s.conflicts--
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(errors.NewConflict(schema.GroupResource{Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions"}, s.crd.Name, nil).ErrStatus)
+			return
+		}
+		body, _ := ioutil.ReadAll(req.Body)
+		crd := &v1beta1.CustomResourceDefinition{}
+		json.Unmarshal(body, crd)
+		s.crd = crd
+		w.Write(body)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestCustomResourceDefinitionExpansion(t *testing.T) {
+	const path = "/apis/apiextensions.k8s.io/v1beta1/customresourcedefinitions/noxus.example.com"
+	established := v1beta1.CustomResourceDefinitionCondition{Type: v1beta1.Established, Status: v1beta1.ConditionTrue, Reason: "InitialNamesAccepted"}
+	newCRD := func() *v1beta1.CustomResourceDefinition {
+		return &v1beta1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "noxus.example.com", Finalizers: []string{"a"}},
+		}
+	}
+	tests := []struct {
+		name      string
+		conflicts int
+		call      func(CustomResourceDefinitionInterface) (*v1beta1.CustomResourceDefinition, error)
+
+		expectFinalizers []string
+		expectConditions int
+		expectUpdates    []string
+		expectConflict   bool
+	}{
+		{
+			name: "status condition",
+			call: func(c CustomResourceDefinitionInterface) (*v1beta1.CustomResourceDefinition, error) {
+				return c.PatchStatusCondition("noxus.example.com", established)
+			},
+			expectFinalizers: []string{"a"},
+			expectConditions: 1,
+			expectUpdates:    []string{path + "/status"},
+		},
+		{
+			name:      "status condition after conflict",
+			conflicts: 1,
+			call: func(c CustomResourceDefinitionInterface) (*v1beta1.CustomResourceDefinition, error) {
+				return c.PatchStatusCondition("noxus.example.com", established)
+			},
+			expectFinalizers: []string{"a"},
+			expectConditions: 1,
+			expectUpdates:    []string{path + "/status", path + "/status"},
+		},
+		{
+			name: "new finalizer",
+			call: func(c CustomResourceDefinitionInterface) (*v1beta1.CustomResourceDefinition, error) {
+				return c.EnsureFinalizer("noxus.example.com", "b")
+			},
+			expectFinalizers: []string{"a", "b"},
+			expectUpdates:    []string{path},
+		},
+		{
+			name: "present finalizer",
+			call: func(c CustomResourceDefinitionInterface) (*v1beta1.CustomResourceDefinition, error) {
+				return c.EnsureFinalizer("noxus.example.com", "a")
+			},
+			expectFinalizers: []string{"a"},
+		},
+		{
+			name: "removed finalizer",
+			call: func(c CustomResourceDefinitionInterface) (*v1beta1.CustomResourceDefinition, error) {
+				return c.RemoveFinalizer("noxus.example.com", "a")
+			},
+			expectUpdates: []string{path},
+		},
+		{
+			name: "absent finalizer",
+			call: func(c CustomResourceDefinitionInterface) (*v1beta1.CustomResourceDefinition, error) {
+				return c.RemoveFinalizer("noxus.example.com", "b")
+			},
+			expectFinalizers: []string{"a"},
+		},
+		{
+			name:      "persistent conflicts",
+			conflicts: ConflictBackoff.Steps,
+			call: func(c CustomResourceDefinitionInterface) (*v1beta1.CustomResourceDefinition, error) {
+				return c.EnsureFinalizer("noxus.example.com", "b")
+			},
+			expectUpdates:  []string{path, path, path, path, path},
+			expectConflict: true,
+		},
+	}
+	for _, tc := range tests {
+		s := &crdServer{crd: newCRD(), conflicts: tc.conflicts}
+		server := httptest.NewServer(s)
+		client, err := NewForConfig(&rest.Config{Host: server.URL})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+
+		crd, err := tc.call(client.CustomResourceDefinitions())
+		server.Close()
+		if !reflect.DeepEqual(s.updates, tc.expectUpdates) {
+			t.Errorf("%s: expected updates %v, got %v", tc.name, tc.expectUpdates, s.updates)
+		}
+		if tc.expectConflict {
+			if !errors.IsConflict(err) {
+				t.Errorf("%s: expected a conflict, got %v", tc.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(crd.Finalizers, tc.expectFinalizers) {
+			t.Errorf("%s: expected finalizers %v, got %v", tc.name, tc.expectFinalizers, crd.Finalizers)
+		}
+		if len(crd.Status.Conditions) != tc.expectConditions {
+			t.Errorf("%s: expected %d conditions, got %v", tc.name, tc.expectConditions, crd.Status.Conditions)
+		}
+	}
+}
+
+func TestSetCondition(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2017, 9, 1, 0, 0, 0, 0, time.UTC))
+	crd := &v1beta1.CustomResourceDefinition{}
+	crd.Status.Conditions = []v1beta1.CustomResourceDefinitionCondition{
+		{Type: v1beta1.Established, Status: v1beta1.ConditionTrue, Reason: "InitialNamesAccepted", LastTransitionTime: earlier},
+	}
+
+	if SetCondition(crd, v1beta1.CustomResourceDefinitionCondition{Type: v1beta1.Established, Status: v1beta1.ConditionTrue, Reason: "InitialNamesAccepted"}) {
+		t.Errorf("expected an unchanged condition not to be set")
+	}
+	if !SetCondition(crd, v1beta1.CustomResourceDefinitionCondition{Type: v1beta1.Established, Status: v1beta1.ConditionTrue, Reason: "Other"}) {
+		t.Errorf("expected a condition with a new reason to be set")
+	}
+	if c := crd.Status.Conditions[0]; c.Reason != "Other" || !c.LastTransitionTime.Time.Equal(earlier.Time) {
+		t.Errorf("expected the reason to change and the lastTransitionTime to be kept, got %v", c)
+	}
+	if !SetCondition(crd, v1beta1.CustomResourceDefinitionCondition{Type: v1beta1.Established, Status: v1beta1.ConditionFalse}) {
+		t.Errorf("expected a condition with a new status to be set")
+	}
+	if c := crd.Status.Conditions[0]; c.Status != v1beta1.ConditionFalse || !c.LastTransitionTime.After(earlier.Time) {
+		t.Errorf("expected the status and the lastTransitionTime to change, got %v", c)
+	}
+	if !SetCondition(crd, v1beta1.CustomResourceDefinitionCondition{Type: v1beta1.NamesAccepted, Status: v1beta1.ConditionTrue}) || len(crd.Status.Conditions) != 2 {
+		t.Errorf("expected a condition of a new type to be appended, got %v", crd.Status.Conditions)
+	}
+	if crd.Status.Conditions[1].LastTransitionTime.IsZero() {
+		t.Errorf("expected the lastTransitionTime of a new condition to be set")
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	v1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	typedv1beta1 "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1"
+)
+
+func (c *FakeCustomResourceDefinitions) PatchStatusCondition(name string, condition v1beta1.CustomResourceDefinitionCondition) (*v1beta1.CustomResourceDefinition, error) {
+	return typedv1beta1.UpdateOnConflict(c, name, func(crd *v1beta1.CustomResourceDefinition) bool {
+		return typedv1beta1.SetCondition(crd, condition)
+	}, c.UpdateStatus)
+}
+
+func (c *FakeCustomResourceDefinitions) EnsureFinalizer(name, finalizer string) (*v1beta1.CustomResourceDefinition, error) {
+	return typedv1beta1.UpdateOnConflict(c, name, func(crd *v1beta1.CustomResourceDefinition) bool {
+		return typedv1beta1.AddFinalizer(crd, finalizer)
+	}, c.Update)
+}
+
+func (c *FakeCustomResourceDefinitions) RemoveFinalizer(name, finalizer string) (*v1beta1.CustomResourceDefinition, error) {
+	return typedv1beta1.UpdateOnConflict(c, name, func(crd *v1beta1.CustomResourceDefinition) bool {
+		return typedv1beta1.RemoveFinalizer(crd, finalizer)
+	}, c.Update)
+}
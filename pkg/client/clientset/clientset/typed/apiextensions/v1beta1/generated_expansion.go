@@ -15,5 +15,3 @@ limitations under the License.
 */
 
 package v1beta1
-
-type CustomResourceDefinitionExpansion interface{}
@@ -38,20 +38,43 @@ type CustomResourceDefinitionInformer interface {
 }
 
 type customResourceDefinitionInformer struct {
-	factory internalinterfaces.SharedInformerFactory
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	transform        internalinterfaces.TransformFunc
 }
 
 // NewCustomResourceDefinitionInformer constructs a new informer for CustomResourceDefinition type.
 // Always prefer using an informer factory to get a shared informer instead of getting an independent
 // one. This reduces memory footprint and number of connections to the server.
 func NewCustomResourceDefinitionInformer(client clientset.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredCustomResourceDefinitionInformer(client, resyncPeriod, indexers, nil, nil)
+}
+
+// NewFilteredCustomResourceDefinitionInformer constructs a new informer for CustomResourceDefinition type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredCustomResourceDefinitionInformer(client clientset.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc, transform internalinterfaces.TransformFunc) cache.SharedIndexInformer {
 	return cache.NewSharedIndexInformer(
 		&cache.ListWatch{
 			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
-				return client.ApiextensionsV1beta1().CustomResourceDefinitions().List(options)
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				list, err := client.ApiextensionsV1beta1().CustomResourceDefinitions().List(options)
+				if err != nil || transform == nil {
+					return list, err
+				}
+				return internalinterfaces.TransformList(list, transform)
 			},
 			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
-				return client.ApiextensionsV1beta1().CustomResourceDefinitions().Watch(options)
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				w, err := client.ApiextensionsV1beta1().CustomResourceDefinitions().Watch(options)
+				if err != nil || transform == nil {
+					return w, err
+				}
+				return internalinterfaces.TransformWatch(w, transform), nil
 			},
 		},
 		&apiextensions_v1beta1.CustomResourceDefinition{},
@@ -60,12 +83,12 @@ func NewCustomResourceDefinitionInformer(client clientset.Interface, resyncPerio
 	)
 }
 
-func defaultCustomResourceDefinitionInformer(client clientset.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
-	return NewCustomResourceDefinitionInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+func (f *customResourceDefinitionInformer) defaultInformer(client clientset.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredCustomResourceDefinitionInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions, f.transform)
 }
 
 func (f *customResourceDefinitionInformer) Informer() cache.SharedIndexInformer {
-	return f.factory.InformerFor(&apiextensions_v1beta1.CustomResourceDefinition{}, defaultCustomResourceDefinitionInformer)
+	return f.factory.InformerFor(&apiextensions_v1beta1.CustomResourceDefinition{}, f.defaultInformer)
 }
 
 func (f *customResourceDefinitionInformer) Lister() v1beta1.CustomResourceDefinitionLister {
@@ -0,0 +1,124 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalversions
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newTestCRD(name string, labels map[string]string) *v1beta1.CustomResourceDefinition {
+	return &v1beta1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec: v1beta1.CustomResourceDefinitionSpec{
+			Group:      "mygroup.example.com",
+			Version:    "v1beta1",
+			Validation: &v1beta1.CustomResourceValidation{OpenAPIV3Schema: &v1beta1.JSONSchemaProps{Type: "object"}},
+		},
+	}
+}
+
+// startInformer starts the CustomResourceDefinition informer of the factory, returning the fake watch
+// the informer watches and the indexer once it is synced.
+func startInformer(t *testing.T, client *fake.Clientset, options []SharedInformerOption, stopCh <-chan struct{}) (*watch.FakeWatcher, cache.Indexer) {
+	watcher := watch.NewFake()
+	client.PrependWatchReactor("customresourcedefinitions", clienttesting.DefaultWatchReactor(watcher, nil))
+
+	factory := NewSharedInformerFactoryWithOptions(client, 0, options...)
+	informer := factory.Apiextensions().V1beta1().CustomResourceDefinitions().Informer()
+	factory.Start(stopCh)
+	if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
+		t.Fatalf("informer did not sync")
+	}
+	return watcher, informer.GetIndexer()
+}
+
+func TestWithTweakListOptions(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	client := fake.NewSimpleClientset(
+		newTestCRD("noxus.mygroup.example.com", map[string]string{"tier": "frontend"}),
+		newTestCRD("widgets.mygroup.example.com", nil),
+	)
+	tweak := func(options *metav1.ListOptions) {
+		options.LabelSelector = "tier=frontend"
+		options.FieldSelector = "metadata.name=noxus.mygroup.example.com"
+	}
+	startInformer(t, client, []SharedInformerOption{WithTweakListOptions(tweak)}, stopCh)
+
+	var lists, watches int
+	for _, action := range client.Actions() {
+		var restrictions clienttesting.ListRestrictions
+		switch action := action.(type) {
+		case clienttesting.ListAction:
+			lists++
+			restrictions = action.GetListRestrictions()
+		case clienttesting.WatchAction:
+			watches++
+			restrictions = clienttesting.ListRestrictions{Labels: action.GetWatchRestrictions().Labels, Fields: action.GetWatchRestrictions().Fields}
+		default:
+			continue
+		}
+		if restrictions.Labels.String() != "tier=frontend" || restrictions.Fields.String() != "metadata.name=noxus.mygroup.example.com" {
+			t.Errorf("%s: expected the tweaked selectors, got labels %q and fields %q", action.GetVerb(), restrictions.Labels, restrictions.Fields)
+		}
+	}
+	if lists == 0 || watches == 0 {
+		t.Errorf("expected a list and a watch, got %d lists and %d watches", lists, watches)
+	}
+}
+
+func TestWithTransform(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	stripSchema := func(obj interface{}) (interface{}, error) {
+		crd := obj.(*v1beta1.CustomResourceDefinition).DeepCopy()
+		crd.Spec.Validation = nil
+		return crd, nil
+	}
+	client := fake.NewSimpleClientset(newTestCRD("noxus.mygroup.example.com", nil))
+	watcher, indexer := startInformer(t, client, []SharedInformerOption{WithTransform(stripSchema)}, stopCh)
+
+	// objects are transformed when they are listed and watched
+	watcher.Add(newTestCRD("widgets.mygroup.example.com", nil))
+	for _, name := range []string{"noxus.mygroup.example.com", "widgets.mygroup.example.com"} {
+		var obj interface{}
+		err := wait.PollImmediate(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+			var exists bool
+			var err error
+			obj, exists, err = indexer.GetByKey(name)
+			return exists, err
+		})
+		if err != nil {
+			t.Errorf("%s: not in the indexer: %v", name, err)
+			continue
+		}
+		if obj.(*v1beta1.CustomResourceDefinition).Spec.Validation != nil {
+			t.Errorf("%s: expected the transformed object in the indexer, got the schema", name)
+		}
+	}
+}
@@ -19,8 +19,13 @@ limitations under the License.
 package internalinterfaces
 
 import (
+	fmt "fmt"
 	internalclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/internalclientset"
+	errors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
 	cache "k8s.io/client-go/tools/cache"
 	time "time"
 )
@@ -32,3 +37,54 @@ type SharedInformerFactory interface {
 	Start(stopCh <-chan struct{})
 	InformerFor(obj runtime.Object, newFunc NewInformerFunc) cache.SharedIndexInformer
 }
+
+// TweakListOptionsFunc is a function that transforms a v1.ListOptions.
+type TweakListOptionsFunc func(*v1.ListOptions)
+
+// TransformFunc transforms an object before it is stored in the cache of an informer, e.g. to drop
+// fields which are not needed. It must return a runtime.Object of the same type.
+type TransformFunc func(interface{}) (interface{}, error)
+
+// TransformList applies transform to the items of list.
+func TransformList(list runtime.Object, transform TransformFunc) (runtime.Object, error) {
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		out, err := transform(items[i])
+		if err != nil {
+			return nil, err
+		}
+		obj, ok := out.(runtime.Object)
+		if !ok {
+			return nil, fmt.Errorf("transform returned %T instead of a runtime.Object", out)
+		}
+		items[i] = obj
+	}
+	if err := meta.SetList(list, items); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// TransformWatch applies transform to the objects of the events of w. Objects which cannot be
+// transformed are replaced by an error event, which makes the informer list and watch again.
+func TransformWatch(w watch.Interface, transform TransformFunc) watch.Interface {
+	return watch.Filter(w, func(in watch.Event) (watch.Event, bool) {
+		if in.Type == watch.Error {
+			return in, true
+		}
+		out, err := transform(in.Object)
+		if err != nil {
+			return watch.Event{Type: watch.Error, Object: &errors.NewInternalError(err).ErrStatus}, true
+		}
+		obj, ok := out.(runtime.Object)
+		if !ok {
+			err := fmt.Errorf("transform returned %T instead of a runtime.Object", out)
+			return watch.Event{Type: watch.Error, Object: &errors.NewInternalError(err).ErrStatus}, true
+		}
+		in.Object = obj
+		return in, true
+	})
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package server provides the command and the options of the standalone apiextensions-apiserver,
+// and the constructor for embedding it into another server:
+//
+//	o := server.NewCustomResourceDefinitionsServerOptions(os.Stdout, os.Stderr)
+//	// set or bind o like the flags of the standalone server, then
+//	crds, err := o.NewServer(delegate,
+//		server.WithAuthentication(authn),
+//		server.WithAuthorization(authz),
+//		server.WithAdmission(admissionChain),
+//		server.WithEtcdClient(etcdClient),
+//	)
+//
+// The options are optional, without them the embedded server behaves like the standalone server.
+package server
@@ -0,0 +1,157 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"github.com/coreos/etcd/clientv3"
+
+	"k8s.io/apiextensions-apiserver/pkg/apiserver"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/conversion"
+	"k8s.io/apiextensions-apiserver/pkg/controller/status"
+	"k8s.io/apiextensions-apiserver/pkg/registry/customresourcedefinition"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericregistry "k8s.io/apiserver/pkg/registry/generic"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+)
+
+// Option customizes an apiextensions-apiserver embedded in-process into another server. Options are
+// passed to Config or NewServer.
+type Option func(*embedOptions)
+
+type embedOptions struct {
+	authenticator authenticator.Request
+	authorizer    authorizer.Authorizer
+	admission     admission.Interface
+	etcdClient    *clientv3.Client
+	backends      map[string]apiserver.CustomResourceStorageBackend
+	hooks         *apiserver.CustomResourceHooks
+	validators    []customresourcedefinition.Validator
+
+	converterFactory *conversion.CRConverterFactory
+	eventRecorder    status.EventRecorder
+}
+
+// WithAuthentication authenticates requests with the given authenticator of the embedding server,
+// instead of delegating authentication to the kube-apiserver. The --authentication-* flags are ignored.
+func WithAuthentication(a authenticator.Request) Option {
+	return func(o *embedOptions) {
+		o.authenticator = a
+	}
+}
+
+// WithAuthorization authorizes requests with the given authorizer of the embedding server, instead of
+// delegating authorization to the kube-apiserver. The --authorization-* flags are ignored.
+func WithAuthorization(a authorizer.Authorizer) Option {
+	return func(o *embedOptions) {
+		o.authorizer = a
+	}
+}
+
+// WithAdmission runs the given admission chain of the embedding server for CustomResourceDefinitions
+// and custom resources.
+func WithAdmission(a admission.Interface) Option {
+	return func(o *embedOptions) {
+		o.admission = a
+	}
+}
+
+// WithEtcdClient stores CustomResourceDefinitions and custom resources with an existing etcd3 client
+// of the embedding server instead of connecting to --etcd-servers. Custom resources selected by
+// --etcd-servers-overrides still use their own clients. The client is owned by the embedding server,
+// which is responsible for compacting and closing it.
+func WithEtcdClient(client *clientv3.Client) Option {
+	return func(o *embedOptions) {
+		o.etcdClient = client
+	}
+}
+
+// WithCustomResourceStorageBackend stores the custom resources of the given API group with the backend
+// instead of etcd, e.g. in a SQL database or in memory. CustomResourceDefinitions are still stored in
+// etcd. The watch cache and --etcd-servers-overrides do not apply to these custom resources.
+func WithCustomResourceStorageBackend(group string, backend apiserver.CustomResourceStorageBackend) Option {
+	return func(o *embedOptions) {
+		if o.backends == nil {
+			o.backends = map[string]apiserver.CustomResourceStorageBackend{}
+		}
+		o.backends[group] = backend
+	}
+}
+
+// WithCustomResourceHooks registers in-process mutators and validators of custom resources, which run
+// after the admission chain.
+func WithCustomResourceHooks(hooks *apiserver.CustomResourceHooks) Option {
+	return func(o *embedOptions) {
+		o.hooks = hooks
+	}
+}
+
+// WithCustomResourceDefinitionValidators validates created and updated CustomResourceDefinitions
+// with the given in-process validators, e.g. for naming policies of the embedding server, after the
+// built-in validation. Options given multiple times add to the validators.
+func WithCustomResourceDefinitionValidators(validators ...customresourcedefinition.Validator) Option {
+	return func(o *embedOptions) {
+		o.validators = append(o.validators, validators...)
+	}
+}
+
+// WithConverterFactory converts custom resources with the in-process converters registered with the
+// given factory, instead of the conversion strategies of their CustomResourceDefinitions.
+func WithConverterFactory(f *conversion.CRConverterFactory) Option {
+	return func(o *embedOptions) {
+		o.converterFactory = f
+	}
+}
+
+// WithEventRecorder records events about CustomResourceDefinitions, e.g. when the cluster passes the
+// removal release of a served version, with the given recorder of the embedding server.
+func WithEventRecorder(r status.EventRecorder) Option {
+	return func(o *embedOptions) {
+		o.eventRecorder = r
+	}
+}
+
+// NewServer returns an apiextensions-apiserver configured by the options and opts, which serves
+// CustomResourceDefinitions and custom resources and delegates all other requests to
+// delegationTarget. The options must have been completed and validated. The returned server is
+// started by the embedding server, e.g. with server.GenericAPIServer.PrepareRun().Run(stopCh), and
+// its watches are ended with server.TerminateWatches when the embedding server shuts down.
+func (o CustomResourceDefinitionsServerOptions) NewServer(delegationTarget genericapiserver.DelegationTarget, opts ...Option) (*apiserver.CustomResourceDefinitions, error) {
+	config, err := o.Config(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return config.Complete().New(delegationTarget)
+}
+
+// etcdClientRESTOptionsGetter stores the resources of a RESTOptionsGetter with an existing etcd client.
+type etcdClientRESTOptionsGetter struct {
+	genericregistry.RESTOptionsGetter
+	client         *clientv3.Client
+	watchCacheSize int
+}
+
+func (t etcdClientRESTOptionsGetter) GetRESTOptions(resource schema.GroupResource) (genericregistry.RESTOptions, error) {
+	ret, err := t.RESTOptionsGetter.GetRESTOptions(resource)
+	if err != nil {
+		return ret, err
+	}
+	ret.Decorator = apiserver.NewEtcdClientStorageDecorator(t.client, t.watchCacheSize)
+	return ret, nil
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/coreos/etcd/clientv3"
+
+	"k8s.io/apiextensions-apiserver/pkg/apiserver"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/registry/generic"
+	"k8s.io/apiserver/pkg/storage"
+	"k8s.io/apiserver/pkg/storage/storagebackend/factory"
+)
+
+// newTestServerOptions returns options which do not serve and whose delegating authentication and
+// authorization fail, as there is no kube-apiserver to delegate to.
+func newTestServerOptions() *CustomResourceDefinitionsServerOptions {
+	o := NewCustomResourceDefinitionsServerOptions(ioutil.Discard, ioutil.Discard)
+	o.RecommendedOptions.SecureServing.BindPort = 0
+	o.RecommendedOptions.Authentication.RemoteKubeConfigFile = "/nonexistent/kubeconfig"
+	o.RecommendedOptions.Authorization.RemoteKubeConfigFile = "/nonexistent/kubeconfig"
+	o.RecommendedOptions.Etcd.StorageConfig.ServerList = []string{"http://127.0.0.1:2379"}
+	return o
+}
+
+type fakeAuthenticator struct{}
+
+func (*fakeAuthenticator) AuthenticateRequest(req *http.Request) (user.Info, bool, error) {
+	return nil, false, nil
+}
+
+type fakeAuthorizer struct{}
+
+func (*fakeAuthorizer) Authorize(a authorizer.Attributes) (bool, string, error) {
+	return false, "", nil
+}
+
+func TestConfigWithAuthenticationAndAuthorization(t *testing.T) {
+	authn, authz := &fakeAuthenticator{}, &fakeAuthorizer{}
+	tests := []struct {
+		name string
+		opts []Option
+		err  bool
+	}{
+		{
+			name: "delegating",
+			err:  true,
+		},
+		{
+			name: "authentication only",
+			opts: []Option{WithAuthentication(authn)},
+			err:  true,
+		},
+		{
+			name: "authorization only",
+			opts: []Option{WithAuthorization(authz)},
+			err:  true,
+		},
+		{
+			name: "authentication and authorization",
+			opts: []Option{WithAuthentication(authn), WithAuthorization(authz)},
+		},
+	}
+	for _, tc := range tests {
+		config, err := newTestServerOptions().Config(tc.opts...)
+		if tc.err {
+			if err == nil {
+				t.Errorf("%s: expected the delegating options to fail", tc.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if config.GenericConfig.Authenticator != authn {
+			t.Errorf("%s: expected the given authenticator, got %#v", tc.name, config.GenericConfig.Authenticator)
+		}
+		if config.GenericConfig.Authorizer != authz {
+			t.Errorf("%s: expected the given authorizer, got %#v", tc.name, config.GenericConfig.Authorizer)
+		}
+	}
+}
+
+// usesClient returns whether s is an etcd3 storage which uses the client.
+func usesClient(s storage.Interface, client *clientv3.Client) bool {
+	if fmt.Sprintf("%T", s) != "*etcd3.store" {
+		return false
+	}
+	return reflect.ValueOf(s).Elem().FieldByName("client").Pointer() == reflect.ValueOf(client).Pointer()
+}
+
+func newList() runtime.Object {
+	return &unstructured.UnstructuredList{}
+}
+
+func TestConfigWithEtcdClient(t *testing.T) {
+	client := &clientv3.Client{}
+	crds := schema.GroupResource{Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions"}
+	noxus := schema.GroupResource{Group: "mygroup.example.com", Resource: "noxus"}
+
+	for _, withClient := range []bool{false, true} {
+		opts := []Option{WithAuthentication(&fakeAuthenticator{}), WithAuthorization(&fakeAuthorizer{})}
+		if withClient {
+			opts = append(opts, WithEtcdClient(client))
+		}
+		o := newTestServerOptions()
+		o.RecommendedOptions.Etcd.DefaultWatchCacheSize = 50
+		config, err := o.Config(opts...)
+		if err != nil {
+			t.Fatalf("with client %v: unexpected error: %v", withClient, err)
+		}
+
+		if getter, ok := config.GenericConfig.RESTOptionsGetter.(etcdClientRESTOptionsGetter); ok != withClient {
+			t.Errorf("with client %v: unexpected RESTOptionsGetter %#v", withClient, config.GenericConfig.RESTOptionsGetter)
+		} else if ok && (getter.client != client || getter.watchCacheSize != 50) {
+			t.Errorf("with client %v: expected the client with watch cache size 50, got %p with %d", withClient, getter.client, getter.watchCacheSize)
+		}
+		if crdGetter := config.CRDRESTOptionsGetter.(apiserver.CRDRESTOptionsGetter); (crdGetter.EtcdClient == client) != withClient {
+			t.Errorf("with client %v: unexpected client %p of the custom resources", withClient, crdGetter.EtcdClient)
+		}
+
+		for _, tc := range []struct {
+			resource schema.GroupResource
+			getter   generic.RESTOptionsGetter
+		}{
+			{crds, config.GenericConfig.RESTOptionsGetter},
+			{noxus, config.CRDRESTOptionsGetter},
+		} {
+			restOptions, err := tc.getter.GetRESTOptions(tc.resource)
+			if err != nil {
+				t.Errorf("with client %v: %v: unexpected error: %v", withClient, tc.resource, err)
+				continue
+			}
+			if !withClient {
+				// the default decorators connect to --etcd-servers
+				continue
+			}
+			// without watch cache, which would start listing
+			noCache := 0
+			s, destroy := restOptions.Decorator(apiserver.UnstructuredCopier{}, restOptions.StorageConfig, &noCache, &unstructured.Unstructured{}, restOptions.ResourcePrefix, nil, newList, nil, nil)
+			destroy()
+			if !usesClient(s, client) {
+				t.Errorf("with client %v: %v: expected storage using the client, got %#v", withClient, tc.resource, s)
+			}
+		}
+	}
+}
+
+// recordingStorageBackend records the resources it creates storage for.
+type recordingStorageBackend struct {
+	resources []schema.GroupResource
+}
+
+func (b *recordingStorageBackend) NewStorage(config apiserver.CustomResourceStorageConfig) (storage.Interface, factory.DestroyFunc, error) {
+	b.resources = append(b.resources, config.Resource)
+	return nil, nil, fmt.Errorf("not implemented")
+}
+
+func TestConfigWithCustomResourceStorageBackend(t *testing.T) {
+	sql, memory := &recordingStorageBackend{}, &recordingStorageBackend{}
+	o := newTestServerOptions()
+	o.RecommendedOptions.Etcd.EnableWatchCache = false
+	config, err := o.Config(
+		WithAuthentication(&fakeAuthenticator{}),
+		WithAuthorization(&fakeAuthorizer{}),
+		// the etcd client keeps the other groups from connecting to etcd
+		WithEtcdClient(&clientv3.Client{}),
+		WithCustomResourceStorageBackend("sql.example.com", sql),
+		WithCustomResourceStorageBackend("memory.example.com", memory),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resources := []schema.GroupResource{
+		{Group: "sql.example.com", Resource: "noxus"},
+		{Group: "memory.example.com", Resource: "noxus"},
+		{Group: "mygroup.example.com", Resource: "noxus"},
+		{Group: "sql.example.com", Resource: "widgets"},
+	}
+	for _, resource := range resources {
+		restOptions, err := config.CRDRESTOptionsGetter.GetRESTOptions(resource)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", resource, err)
+		}
+		restOptions.Decorator(apiserver.UnstructuredCopier{}, restOptions.StorageConfig, nil, &unstructured.Unstructured{}, restOptions.ResourcePrefix, nil, newList, nil, nil)
+	}
+
+	if expected := []schema.GroupResource{resources[0], resources[3]}; !reflect.DeepEqual(sql.resources, expected) {
+		t.Errorf("expected the sql backend to store %v, got %v", expected, sql.resources)
+	}
+	if expected := []schema.GroupResource{resources[1]}; !reflect.DeepEqual(memory.resources, expected) {
+		t.Errorf("expected the memory backend to store %v, got %v", expected, memory.resources)
+	}
+}
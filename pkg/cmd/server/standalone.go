@@ -0,0 +1,120 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"crypto/x509"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/authenticatorfactory"
+	"k8s.io/apiserver/pkg/authentication/request/union"
+	x509request "k8s.io/apiserver/pkg/authentication/request/x509"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizerfactory"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+// applyStandaloneAuth sets up the authentication and authorization of the standalone mode: clients
+// are authenticated by the bearer tokens of --standalone-token-auth-file and the client certificates
+// signed by --client-ca-file, and every authenticated user is authorized.
+func (o CustomResourceDefinitionsServerOptions) applyStandaloneAuth(serverConfig *genericapiserver.Config) error {
+	var authenticators []authenticator.Request
+	if len(o.StandaloneTokenAuthFile) > 0 {
+		f, err := os.Open(o.StandaloneTokenAuthFile)
+		if err != nil {
+			return fmt.Errorf("unable to read --standalone-token-auth-file: %v", err)
+		}
+		defer f.Close()
+		tokens, err := parseTokenAuthFile(f)
+		if err != nil {
+			return fmt.Errorf("invalid --standalone-token-auth-file: %v", err)
+		}
+		authenticators = append(authenticators, authenticatorfactory.NewFromTokens(tokens))
+	}
+	if clientCA := o.RecommendedOptions.Authentication.ClientCert.ClientCA; len(clientCA) > 0 {
+		certs, err := certutil.CertsFromFile(clientCA)
+		if err != nil {
+			return fmt.Errorf("unable to load client CA file: %v", err)
+		}
+		if _, err := serverConfig.ApplyClientCert(clientCA); err != nil {
+			return err
+		}
+		opts := x509request.DefaultVerifyOptions()
+		opts.Roots = x509.NewCertPool()
+		for _, cert := range certs {
+			opts.Roots.AddCert(cert)
+		}
+		authenticators = append(authenticators, x509request.New(opts, x509request.CommonNameUserConversion))
+	}
+	if len(authenticators) == 0 {
+		return fmt.Errorf("--standalone requires --standalone-token-auth-file or --client-ca-file")
+	}
+
+	serverConfig.Authenticator = union.New(authenticators...)
+	serverConfig.Authorizer = authorizerfactory.NewAlwaysAllowAuthorizer()
+	serverConfig.SupportsBasicAuth = false
+	return nil
+}
+
+// parseTokenAuthFile parses a static token file. Each line has the format token,user,uid and an
+// optional fourth column with the comma separated groups of the user, which must be quoted.
+func parseTokenAuthFile(r io.Reader) (map[string]*user.DefaultInfo, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	tokens := map[string]*user.DefaultInfo{}
+	n := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		n++
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 3 || len(record) > 4 {
+			return nil, fmt.Errorf("entry %d: expected token,user,uid[,groups]", n)
+		}
+		token := strings.TrimSpace(record[0])
+		if len(token) == 0 {
+			return nil, fmt.Errorf("entry %d: empty token", n)
+		}
+		if _, found := tokens[token]; found {
+			return nil, fmt.Errorf("entry %d: duplicate token", n)
+		}
+		info := &user.DefaultInfo{Name: strings.TrimSpace(record[1]), UID: strings.TrimSpace(record[2])}
+		if len(info.Name) == 0 {
+			return nil, fmt.Errorf("entry %d: empty user", n)
+		}
+		if len(record) == 4 {
+			for _, group := range strings.Split(record[3], ",") {
+				if group = strings.TrimSpace(group); len(group) > 0 {
+					info.Groups = append(info.Groups, group)
+				}
+			}
+		}
+		tokens[token] = info
+	}
+	return tokens, nil
+}
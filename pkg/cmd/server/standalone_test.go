@@ -0,0 +1,84 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+)
+
+func TestParseTokenAuthFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		expected map[string]*user.DefaultInfo
+		err      bool
+	}{
+		{
+			name: "users with and without groups",
+			file: "# comment\nabc,alice,1\ndef,bob,2,\"admins, devs\"\n",
+			expected: map[string]*user.DefaultInfo{
+				"abc": {Name: "alice", UID: "1"},
+				"def": {Name: "bob", UID: "2", Groups: []string{"admins", "devs"}},
+			},
+		},
+		{
+			name:     "empty",
+			file:     "",
+			expected: map[string]*user.DefaultInfo{},
+		},
+		{
+			name: "missing uid",
+			file: "abc,alice\n",
+			err:  true,
+		},
+		{
+			name: "empty token",
+			file: ",alice,1\n",
+			err:  true,
+		},
+		{
+			name: "empty user",
+			file: "abc,,1\n",
+			err:  true,
+		},
+		{
+			name: "duplicate token",
+			file: "abc,alice,1\nabc,bob,2\n",
+			err:  true,
+		},
+	}
+	for _, tc := range tests {
+		got, err := parseTokenAuthFile(strings.NewReader(tc.file))
+		if tc.err {
+			if err == nil {
+				t.Errorf("%s: expected an error", tc.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+}
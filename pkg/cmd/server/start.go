@@ -20,22 +20,85 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/validation"
 	"k8s.io/apiextensions-apiserver/pkg/apiserver"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/conversion"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/encryption"
+	"k8s.io/apiextensions-apiserver/pkg/registry/customresource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	genericregistry "k8s.io/apiserver/pkg/registry/generic"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	genericoptions "k8s.io/apiserver/pkg/server/options"
+	"k8s.io/apiserver/pkg/storage/value"
 )
 
 const defaultEtcdPathPrefix = "/registry/apiextensions.kubernetes.io"
 
+// defaultEncryptionProvider is the name of the encryption provider of the custom resources which are
+// not selected by --custom-resource-encryption-providers.
+const defaultEncryptionProvider = "default"
+
 type CustomResourceDefinitionsServerOptions struct {
 	RecommendedOptions *genericoptions.RecommendedOptions
 
+	// AllowedStoragePrefixes are the etcd key prefixes which CustomResourceDefinitions may use in spec.storage.
+	AllowedStoragePrefixes []string
+	// SchemaLimits bound the size of the validation schemas of CustomResourceDefinitions.
+	SchemaLimits validation.SchemaLimits
+	// RequireAPIApproval rejects CustomResourceDefinitions in groups owned by the Kubernetes project
+	// without a valid api-approved.kubernetes.io annotation.
+	RequireAPIApproval bool
+	// SchemaCompatibilityPolicy is the handling of breaking changes of the schemas of served versions
+	// by updates of CustomResourceDefinitions: Ignore, Warn or Reject.
+	SchemaCompatibilityPolicy string
+	// GenerateNameRetries is how often the creation of a custom resource is retried with a new name
+	// generated from metadata.generateName if the generated name is already taken.
+	GenerateNameRetries int
+	// ListCompressionThreshold is the size in bytes above which the responses of custom resource list
+	// requests are compressed with gzip for clients accepting it.
+	ListCompressionThreshold int
+	// OptimisticServing serves the custom resources of new CustomResourceDefinitions as soon as their
+	// names are accepted. Before, requests get ServiceUnavailable and a Retry-After header.
+	OptimisticServing bool
+	// ClusterRelease is the Kubernetes release of the cluster, e.g. 1.25, which the removedInRelease of
+	// the versions of CustomResourceDefinitions is checked against. Empty disables the check.
+	ClusterRelease string
+	// CustomResourceLimits bound the number and size of the custom resources of each CustomResourceDefinition.
+	CustomResourceLimits customresource.Limits
+	// WatchCacheSizes override the default watch cache size for the custom resources of individual
+	// CustomResourceDefinitions, in the format group/resource#size.
+	WatchCacheSizes []string
+	// ConsistentListFromCache serves lists of custom resources without resourceVersion from the watch
+	// cache if it is verified to be up to date with etcd.
+	ConsistentListFromCache bool
+	// EncryptionProviders select the encryption providers of the custom resources of individual
+	// CustomResourceDefinitions, in the format group/resource#provider.
+	EncryptionProviders []string
+	// PriorityLevels are the maximum numbers of concurrent requests of priority levels, in the format
+	// name#limit.
+	PriorityLevels []string
+	// FlowSchemas classify the requests for the custom resources of individual CustomResourceDefinitions
+	// into the PriorityLevels, in the format group/resource#level or group/resource/verb#level.
+	FlowSchemas []string
+	// ConversionWebhookOptions configure the clients of conversion webhooks.
+	ConversionWebhookOptions conversion.WebhookOptions
+	// Standalone serves without a kube-apiserver, which authentication and authorization are
+	// otherwise delegated to.
+	Standalone bool
+	// StandaloneTokenAuthFile is the static token file clients are authenticated by in standalone mode.
+	StandaloneTokenAuthFile string
+
 	StdOut io.Writer
 	StdErr io.Writer
 }
@@ -43,6 +106,26 @@ type CustomResourceDefinitionsServerOptions struct {
 func NewCustomResourceDefinitionsServerOptions(out, errOut io.Writer) *CustomResourceDefinitionsServerOptions {
 	o := &CustomResourceDefinitionsServerOptions{
 		RecommendedOptions: genericoptions.NewRecommendedOptions(defaultEtcdPathPrefix, apiserver.Scheme, apiserver.Codecs.LegacyCodec(v1beta1.SchemeGroupVersion)),
+		SchemaLimits: validation.SchemaLimits{
+			MaxBytes:       1024 * 1024,
+			MaxDepth:       64,
+			MaxProperties:  10000,
+			MaxRuleCost:    1000000,
+			RuleCostBudget: 10000000,
+		},
+		RequireAPIApproval:        true,
+		SchemaCompatibilityPolicy: string(validation.SchemaCompatibilityIgnore),
+		ListCompressionThreshold:  128 * 1024,
+		ConversionWebhookOptions: conversion.WebhookOptions{
+			Timeout:          30 * time.Second,
+			Retries:          2,
+			RetryBackoff:     100 * time.Millisecond,
+			FailureThreshold: 5,
+			DegradedCooldown: 30 * time.Second,
+			MaxInFlight:      16,
+			QPS:              50,
+			Burst:            100,
+		},
 
 		StdOut: out,
 		StdErr: errOut,
@@ -73,60 +156,491 @@ func NewCommandStartCustomResourceDefinitionsServer(out, errOut io.Writer, stopC
 
 	flags := cmd.Flags()
 	o.RecommendedOptions.AddFlags(flags)
+	flags.StringSliceVar(&o.AllowedStoragePrefixes, "allowed-custom-resource-storage-prefixes", o.AllowedStoragePrefixes, ""+
+		"Comma separated etcd key prefixes, relative to --etcd-prefix, under which CustomResourceDefinitions may store "+
+		"their custom resources with spec.storage.prefix. If empty, spec.storage is forbidden for new CustomResourceDefinitions.")
+	flags.IntVar(&o.SchemaLimits.MaxBytes, "max-custom-resource-schema-bytes", o.SchemaLimits.MaxBytes, ""+
+		"The maximum size in bytes of the JSON encoding of each validation schema of a CustomResourceDefinition. Zero means no limit.")
+	flags.IntVar(&o.SchemaLimits.MaxDepth, "max-custom-resource-schema-depth", o.SchemaLimits.MaxDepth, ""+
+		"The maximum nesting depth of each validation schema of a CustomResourceDefinition. Zero means no limit.")
+	flags.IntVar(&o.SchemaLimits.MaxProperties, "max-custom-resource-schema-properties", o.SchemaLimits.MaxProperties, ""+
+		"The maximum number of properties, over all nesting levels, of each validation schema of a CustomResourceDefinition. Zero means no limit.")
+	flags.Uint64Var(&o.SchemaLimits.MaxRuleCost, "max-custom-resource-schema-rule-cost", o.SchemaLimits.MaxRuleCost, ""+
+		"The maximum estimated cost of the x-kubernetes-validations rules, over all nesting levels, of each validation schema "+
+		"of a CustomResourceDefinition. Zero means no limit.")
+	flags.Uint64Var(&o.SchemaLimits.RuleCostBudget, "custom-resource-rule-cost-budget", o.SchemaLimits.RuleCostBudget, ""+
+		"The runtime cost budget of all x-kubernetes-validations rules evaluated for a single custom resource request, "+
+		"in evaluated expression nodes. Requests exhausting it are rejected, and rules whose estimated cost exceeds it "+
+		"are rejected in CustomResourceDefinitions. Zero means no limit.")
+	flags.BoolVar(&o.RequireAPIApproval, "require-kubernetes-api-approval", o.RequireAPIApproval, ""+
+		"If true, CustomResourceDefinitions in groups owned by the Kubernetes project, like *.k8s.io and *.kubernetes.io, "+
+		"are rejected without an api-approved.kubernetes.io annotation linking their approval or starting with \"unapproved\". "+
+		"Test clusters may disable it.")
+	flags.StringVar(&o.SchemaCompatibilityPolicy, "custom-resource-schema-compatibility-policy", o.SchemaCompatibilityPolicy, ""+
+		"The handling of breaking changes of the schemas of served versions by updates of CustomResourceDefinitions, "+
+		"like changed types, removed properties, newly required fields and narrowed enums. Ignore accepts them, Warn "+
+		"accepts them and lists them in the BreakingSchemaChange condition, and Reject rejects the update.")
+	flags.IntVar(&o.GenerateNameRetries, "custom-resource-generate-name-retries", o.GenerateNameRetries, ""+
+		"The number of times the creation of a custom resource with metadata.generateName is retried with a newly "+
+		"generated name if the generated name is already taken. Zero disables retries.")
+	flags.IntVar(&o.ListCompressionThreshold, "custom-resource-list-compression-threshold", o.ListCompressionThreshold, ""+
+		"The size in bytes above which the responses of custom resource list requests are compressed with gzip "+
+		"if the client accepts it with the Accept-Encoding header. Zero disables compression.")
+	flags.BoolVar(&o.OptimisticServing, "custom-resource-optimistic-serving", o.OptimisticServing, ""+
+		"If true, the custom resources of new CustomResourceDefinitions are served as soon as their names are accepted, "+
+		"without waiting until the CustomResourceDefinitions are established. Until the names are accepted, requests get "+
+		"a 503 response with a Retry-After header instead of a 404 response. Clients like controllers started right after "+
+		"creating a CustomResourceDefinition then retry shortly instead of failing.")
+	flags.StringVar(&o.ClusterRelease, "cluster-release", o.ClusterRelease, ""+
+		"The Kubernetes release of the cluster in the format <major>.<minor>, e.g. 1.25. If set, the RemovalReleasePassed "+
+		"condition of CustomResourceDefinitions reports served versions whose removedInRelease is this release or an "+
+		"earlier one.")
+	flags.Int64Var(&o.CustomResourceLimits.MaxObjects, "max-custom-resources-per-definition", o.CustomResourceLimits.MaxObjects, ""+
+		"The maximum number of custom resources of each CustomResourceDefinition. Creations beyond it are forbidden. "+
+		"The apiextensions.k8s.io/max-custom-resources annotation of a CustomResourceDefinition overrides it. Zero means no limit.")
+	flags.Int64Var(&o.CustomResourceLimits.MaxObjectBytes, "max-custom-resource-bytes", o.CustomResourceLimits.MaxObjectBytes, ""+
+		"The maximum size in bytes of the JSON encoding of a custom resource. Larger custom resources are rejected on "+
+		"create and update. The apiextensions.k8s.io/max-custom-resource-bytes annotation of a CustomResourceDefinition "+
+		"overrides it. Zero means no limit.")
+	flags.StringSliceVar(&o.WatchCacheSizes, "custom-resource-watch-cache-sizes", o.WatchCacheSizes, ""+
+		"Comma separated watch cache sizes of the custom resources of individual CustomResourceDefinitions, overriding "+
+		"the default watch cache size. The format is group/resource#size, where resource is the plural name of the "+
+		"CustomResourceDefinition. A size of zero disables the watch cache of the custom resources.")
+	flags.BoolVar(&o.ConsistentListFromCache, "custom-resource-consistent-list-from-cache", o.ConsistentListFromCache, ""+
+		"If true, lists of custom resources without resourceVersion are served from the watch cache instead of etcd "+
+		"if no custom resource of the list was created, updated or deleted since the last event of the watch cache, "+
+		"which is verified with range requests returning no custom resources. This reduces the etcd load of relisting "+
+		"controllers. It only applies to custom resources stored in etcd3 with a watch cache.")
+	flags.StringSliceVar(&o.EncryptionProviders, "custom-resource-encryption-providers", o.EncryptionProviders, ""+
+		"Comma separated encryption providers of the custom resources of individual CustomResourceDefinitions. The format "+
+		"is group/resource#provider, where provider is the name of a provider in --experimental-encryption-provider-config. "+
+		"Other custom resources are encrypted by the provider named default, or stored unencrypted if there is none.")
+	flags.StringSliceVar(&o.PriorityLevels, "custom-resource-priority-levels", o.PriorityLevels, ""+
+		"Comma separated priority levels of custom resource requests in the format name#limit, where limit is the maximum "+
+		"number of concurrent requests of the priority level. Further requests are rejected with 429 Too Many Requests. "+
+		"Watches and requests of system:masters are not limited.")
+	flags.StringSliceVar(&o.FlowSchemas, "custom-resource-flow-schemas", o.FlowSchemas, ""+
+		"Comma separated classifications of the requests for the custom resources of individual CustomResourceDefinitions "+
+		"into --custom-resource-priority-levels. The format is group/resource#level, or group/resource/verb#level to only "+
+		"classify the requests of one verb, e.g. list, which takes precedence. Other requests are only limited by the "+
+		"limits of the server.")
+	flags.DurationVar(&o.ConversionWebhookOptions.Timeout, "conversion-webhook-timeout", o.ConversionWebhookOptions.Timeout, ""+
+		"The time after which a call of a conversion webhook is aborted.")
+	flags.IntVar(&o.ConversionWebhookOptions.Retries, "conversion-webhook-retries", o.ConversionWebhookOptions.Retries, ""+
+		"The number of times a call of a conversion webhook is retried if the webhook cannot be reached or responds "+
+		"with a server error. Zero disables retries.")
+	flags.DurationVar(&o.ConversionWebhookOptions.RetryBackoff, "conversion-webhook-retry-backoff", o.ConversionWebhookOptions.RetryBackoff, ""+
+		"The wait before the first retry of a call of a conversion webhook. It doubles with every further retry.")
+	flags.IntVar(&o.ConversionWebhookOptions.FailureThreshold, "conversion-webhook-failure-threshold", o.ConversionWebhookOptions.FailureThreshold, ""+
+		"The number of consecutive failed conversions after which a conversion webhook is considered degraded. "+
+		"Conversions fail without calling a degraded webhook for --conversion-webhook-degraded-cooldown, and the "+
+		"ConversionDegraded condition of the CustomResourceDefinition is set. Zero disables this.")
+	flags.DurationVar(&o.ConversionWebhookOptions.DegradedCooldown, "conversion-webhook-degraded-cooldown", o.ConversionWebhookOptions.DegradedCooldown, ""+
+		"The time conversions fail without calling a degraded conversion webhook.")
+	flags.IntVar(&o.ConversionWebhookOptions.MaxInFlight, "conversion-webhook-max-in-flight", o.ConversionWebhookOptions.MaxInFlight, ""+
+		"The maximum number of concurrent calls of the conversion webhook of each CustomResourceDefinition. Further "+
+		"conversions wait up to --conversion-webhook-timeout for a free slot before they fail. Zero means no limit.")
+	flags.Float32Var(&o.ConversionWebhookOptions.QPS, "conversion-webhook-qps", o.ConversionWebhookOptions.QPS, ""+
+		"The maximum rate of calls per second of the conversion webhook of each CustomResourceDefinition. Conversions "+
+		"exceeding it fail without calling the webhook. Zero means no limit.")
+	flags.IntVar(&o.ConversionWebhookOptions.Burst, "conversion-webhook-burst", o.ConversionWebhookOptions.Burst, ""+
+		"The number of calls of the conversion webhook of each CustomResourceDefinition which may exceed "+
+		"--conversion-webhook-qps at once.")
+	flags.BoolVar(&o.Standalone, "standalone", o.Standalone, ""+
+		"If true, the server runs without a kube-apiserver, e.g. as a lightweight API server for custom resources in "+
+		"edge or test environments. Clients are authenticated by --standalone-token-auth-file and by client certificates "+
+		"signed by --client-ca-file, instead of delegating to the kube-apiserver, and every authenticated user is "+
+		"authorized for every request.")
+	flags.StringVar(&o.StandaloneTokenAuthFile, "standalone-token-auth-file", o.StandaloneTokenAuthFile, ""+
+		"The file of the bearer tokens clients are authenticated by with --standalone. Each line has the format "+
+		"token,user,uid,\"group1,group2\", where the groups are optional.")
 
 	return cmd
 }
 
 func (o CustomResourceDefinitionsServerOptions) Validate(args []string) error {
-	return nil
+	errs := []error{}
+	for _, prefix := range o.AllowedStoragePrefixes {
+		for _, err := range validation.ValidateCustomResourceStorage(&apiextensions.CustomResourceStorage{Prefix: prefix}, field.NewPath("prefix")) {
+			errs = append(errs, fmt.Errorf("--allowed-custom-resource-storage-prefixes: %v", err))
+		}
+	}
+	if o.SchemaLimits.MaxBytes < 0 {
+		errs = append(errs, fmt.Errorf("--max-custom-resource-schema-bytes must not be negative"))
+	}
+	if o.SchemaLimits.MaxDepth < 0 {
+		errs = append(errs, fmt.Errorf("--max-custom-resource-schema-depth must not be negative"))
+	}
+	if o.SchemaLimits.MaxProperties < 0 {
+		errs = append(errs, fmt.Errorf("--max-custom-resource-schema-properties must not be negative"))
+	}
+	if o.CustomResourceLimits.MaxObjects < 0 {
+		errs = append(errs, fmt.Errorf("--max-custom-resources-per-definition must not be negative"))
+	}
+	if o.CustomResourceLimits.MaxObjectBytes < 0 {
+		errs = append(errs, fmt.Errorf("--max-custom-resource-bytes must not be negative"))
+	}
+	if o.GenerateNameRetries < 0 {
+		errs = append(errs, fmt.Errorf("--custom-resource-generate-name-retries must not be negative"))
+	}
+	if o.ListCompressionThreshold < 0 {
+		errs = append(errs, fmt.Errorf("--custom-resource-list-compression-threshold must not be negative"))
+	}
+	switch validation.SchemaCompatibilityPolicy(o.SchemaCompatibilityPolicy) {
+	case validation.SchemaCompatibilityIgnore, validation.SchemaCompatibilityWarn, validation.SchemaCompatibilityReject:
+	default:
+		errs = append(errs, fmt.Errorf("--custom-resource-schema-compatibility-policy must be one of Ignore, Warn or Reject"))
+	}
+	if len(o.ClusterRelease) > 0 {
+		if _, _, err := apiextensions.ParseRelease(o.ClusterRelease); err != nil {
+			errs = append(errs, fmt.Errorf("--cluster-release %v", err))
+		}
+	}
+	if o.ConversionWebhookOptions.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("--conversion-webhook-timeout must be positive"))
+	}
+	if o.ConversionWebhookOptions.Retries < 0 {
+		errs = append(errs, fmt.Errorf("--conversion-webhook-retries must not be negative"))
+	}
+	if o.ConversionWebhookOptions.RetryBackoff < 0 {
+		errs = append(errs, fmt.Errorf("--conversion-webhook-retry-backoff must not be negative"))
+	}
+	if o.ConversionWebhookOptions.FailureThreshold < 0 {
+		errs = append(errs, fmt.Errorf("--conversion-webhook-failure-threshold must not be negative"))
+	}
+	if o.ConversionWebhookOptions.DegradedCooldown < 0 {
+		errs = append(errs, fmt.Errorf("--conversion-webhook-degraded-cooldown must not be negative"))
+	}
+	if o.ConversionWebhookOptions.MaxInFlight < 0 {
+		errs = append(errs, fmt.Errorf("--conversion-webhook-max-in-flight must not be negative"))
+	}
+	if o.ConversionWebhookOptions.QPS < 0 {
+		errs = append(errs, fmt.Errorf("--conversion-webhook-qps must not be negative"))
+	}
+	if o.ConversionWebhookOptions.QPS > 0 && o.ConversionWebhookOptions.Burst < 1 {
+		errs = append(errs, fmt.Errorf("--conversion-webhook-burst must be positive with --conversion-webhook-qps"))
+	}
+	if _, err := parseEtcdServersOverrides(o.RecommendedOptions.Etcd.EtcdServersOverrides); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := parseWatchCacheSizes(o.WatchCacheSizes); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := parseEncryptionProviders(o.EncryptionProviders); err != nil {
+		errs = append(errs, err)
+	}
+	if _, _, err := parsePriorityLevels(o.PriorityLevels, o.FlowSchemas); err != nil {
+		errs = append(errs, err)
+	}
+	if o.Standalone && len(o.StandaloneTokenAuthFile) == 0 && len(o.RecommendedOptions.Authentication.ClientCert.ClientCA) == 0 {
+		errs = append(errs, fmt.Errorf("--standalone requires --standalone-token-auth-file or --client-ca-file"))
+	}
+	if !o.Standalone && len(o.StandaloneTokenAuthFile) > 0 {
+		errs = append(errs, fmt.Errorf("--standalone-token-auth-file requires --standalone"))
+	}
+	if len(o.EncryptionProviders) > 0 && len(o.RecommendedOptions.Etcd.EncryptionProviderConfigFilepath) == 0 {
+		errs = append(errs, fmt.Errorf("--custom-resource-encryption-providers requires --experimental-encryption-provider-config"))
+	}
+	return utilerrors.NewAggregate(errs)
 }
 
 func (o *CustomResourceDefinitionsServerOptions) Complete() error {
 	return nil
 }
 
-func (o CustomResourceDefinitionsServerOptions) Config() (*apiserver.Config, error) {
+// Config returns the configuration of the apiextensions-apiserver. The opts customize it for
+// embedding into another server.
+func (o CustomResourceDefinitionsServerOptions) Config(opts ...Option) (*apiserver.Config, error) {
+	embed := &embedOptions{}
+	for _, opt := range opts {
+		opt(embed)
+	}
+
 	// TODO have a "real" external address
 	if err := o.RecommendedOptions.SecureServing.MaybeDefaultWithSelfSignedCerts("localhost", nil, []net.IP{net.ParseIP("127.0.0.1")}); err != nil {
 		return nil, fmt.Errorf("error creating self-signed certificates: %v", err)
 	}
 
 	serverConfig := genericapiserver.NewConfig(apiserver.Codecs)
-	if err := o.RecommendedOptions.ApplyTo(serverConfig); err != nil {
+	if err := o.applyRecommendedOptions(serverConfig, embed); err != nil {
 		return nil, err
 	}
+	// serve the metrics of custom resource validation, pruning and conversion at /metrics
+	serverConfig.EnableMetrics = true
 
+	priorityLevels, flowSchemas, err := parsePriorityLevels(o.PriorityLevels, o.FlowSchemas)
+	if err != nil {
+		return nil, err
+	}
+	crdRESTOptionsGetter, err := newCRDRESTOptionsGetter(*o.RecommendedOptions.Etcd, o.WatchCacheSizes, o.EncryptionProviders)
+	if err != nil {
+		return nil, err
+	}
+	crdRESTOptionsGetter.ConsistentListFromCache = o.ConsistentListFromCache
+	crdRESTOptionsGetter.StorageBackends = embed.backends
+	if embed.etcdClient != nil {
+		crdRESTOptionsGetter.EtcdClient = embed.etcdClient
+		watchCacheSize := 0
+		if o.RecommendedOptions.Etcd.EnableWatchCache {
+			watchCacheSize = o.RecommendedOptions.Etcd.DefaultWatchCacheSize
+		}
+		serverConfig.RESTOptionsGetter = etcdClientRESTOptionsGetter{
+			RESTOptionsGetter: serverConfig.RESTOptionsGetter,
+			client:            embed.etcdClient,
+			watchCacheSize:    watchCacheSize,
+		}
+	}
 	config := &apiserver.Config{
-		GenericConfig:        serverConfig,
-		CRDRESTOptionsGetter: NewCRDRESTOptionsGetter(*o.RecommendedOptions.Etcd),
+		GenericConfig:             serverConfig,
+		CRDRESTOptionsGetter:      *crdRESTOptionsGetter,
+		AllowedStoragePrefixes:    o.AllowedStoragePrefixes,
+		SchemaLimits:              o.SchemaLimits,
+		RequireAPIApproval:        o.RequireAPIApproval,
+		SchemaCompatibilityPolicy: validation.SchemaCompatibilityPolicy(o.SchemaCompatibilityPolicy),
+		GenerateNameRetries:       o.GenerateNameRetries,
+		ListCompressionThreshold:  o.ListCompressionThreshold,
+		OptimisticServing:         o.OptimisticServing,
+		ClusterRelease:            o.ClusterRelease,
+		CustomResourceLimits:      o.CustomResourceLimits,
+		PriorityLevels:            priorityLevels,
+
+		ConversionWebhookOptions:           o.ConversionWebhookOptions,
+		CustomResourceHooks:                embed.hooks,
+		CustomResourceDefinitionValidators: embed.validators,
+		ConverterFactory:                   embed.converterFactory,
+		EventRecorder:                      embed.eventRecorder,
+	}
+	if len(flowSchemas) > 0 {
+		config.PriorityClassifier = flowSchemas
 	}
 	return config, nil
 }
 
-func NewCRDRESTOptionsGetter(etcdOptions genericoptions.EtcdOptions) genericregistry.RESTOptionsGetter {
-	ret := apiserver.CRDRESTOptionsGetter{
+// applyRecommendedOptions applies the recommended options to serverConfig. The delegating
+// authentication and authorization options are skipped in standalone mode and if the embedding
+// server injects its own.
+func (o CustomResourceDefinitionsServerOptions) applyRecommendedOptions(serverConfig *genericapiserver.Config, embed *embedOptions) error {
+	if err := o.RecommendedOptions.Etcd.ApplyTo(serverConfig); err != nil {
+		return err
+	}
+	if err := o.RecommendedOptions.SecureServing.ApplyTo(serverConfig); err != nil {
+		return err
+	}
+	if o.Standalone {
+		if err := o.applyStandaloneAuth(serverConfig); err != nil {
+			return err
+		}
+	}
+	if embed.authenticator == nil && !o.Standalone {
+		if err := o.RecommendedOptions.Authentication.ApplyTo(serverConfig); err != nil {
+			return err
+		}
+	}
+	if embed.authorizer == nil && !o.Standalone {
+		if err := o.RecommendedOptions.Authorization.ApplyTo(serverConfig); err != nil {
+			return err
+		}
+	}
+	if err := o.RecommendedOptions.Audit.ApplyTo(serverConfig); err != nil {
+		return err
+	}
+	if err := o.RecommendedOptions.Features.ApplyTo(serverConfig); err != nil {
+		return err
+	}
+
+	if embed.authenticator != nil {
+		serverConfig.Authenticator = embed.authenticator
+	}
+	if embed.authorizer != nil {
+		serverConfig.Authorizer = embed.authorizer
+	}
+	if embed.admission != nil {
+		serverConfig.AdmissionControl = embed.admission
+	}
+	return nil
+}
+
+func NewCRDRESTOptionsGetter(etcdOptions genericoptions.EtcdOptions, watchCacheSizes, encryptionProviders []string) (genericregistry.RESTOptionsGetter, error) {
+	ret, err := newCRDRESTOptionsGetter(etcdOptions, watchCacheSizes, encryptionProviders)
+	if err != nil {
+		return nil, err
+	}
+	return *ret, nil
+}
+
+func newCRDRESTOptionsGetter(etcdOptions genericoptions.EtcdOptions, watchCacheSizes, encryptionProviders []string) (*apiserver.CRDRESTOptionsGetter, error) {
+	etcdServersOverrides, err := parseEtcdServersOverrides(etcdOptions.EtcdServersOverrides)
+	if err != nil {
+		return nil, err
+	}
+	watchCacheSizesByResource, err := parseWatchCacheSizes(watchCacheSizes)
+	if err != nil {
+		return nil, err
+	}
+	ret := &apiserver.CRDRESTOptionsGetter{
 		StorageConfig:           etcdOptions.StorageConfig,
 		StoragePrefix:           etcdOptions.StorageConfig.Prefix,
 		EnableWatchCache:        etcdOptions.EnableWatchCache,
 		DefaultWatchCacheSize:   etcdOptions.DefaultWatchCacheSize,
 		EnableGarbageCollection: etcdOptions.EnableGarbageCollection,
 		DeleteCollectionWorkers: etcdOptions.DeleteCollectionWorkers,
+		EtcdServersOverrides:    etcdServersOverrides,
+		WatchCacheSizes:         watchCacheSizesByResource,
 	}
 	ret.StorageConfig.Codec = unstructured.UnstructuredJSONScheme
 	ret.StorageConfig.Copier = apiserver.UnstructuredCopier{}
 
-	return ret
+	if len(etcdOptions.EncryptionProviderConfigFilepath) > 0 {
+		providers, err := encryption.LoadProviders(etcdOptions.EncryptionProviderConfigFilepath)
+		if err != nil {
+			return nil, err
+		}
+		if ret.StorageConfig.Transformer, ret.Transformers, err = encryptionTransformers(providers, encryptionProviders); err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
 }
 
-func (o CustomResourceDefinitionsServerOptions) RunCustomResourceDefinitionsServer(stopCh <-chan struct{}) error {
-	config, err := o.Config()
+// encryptionTransformers returns the transformer of the default encryption provider, which is nil if
+// there is none, and the transformers of the providers selected by --custom-resource-encryption-providers.
+func encryptionTransformers(providers *encryption.Providers, encryptionProviders []string) (value.Transformer, map[schema.GroupResource]value.Transformer, error) {
+	providersByResource, err := parseEncryptionProviders(encryptionProviders)
 	if err != nil {
-		return err
+		return nil, nil, err
+	}
+	var defaultTransformer value.Transformer
+	if providers.Has(defaultEncryptionProvider) {
+		if defaultTransformer, err = providers.TransformerFor(defaultEncryptionProvider); err != nil {
+			return nil, nil, err
+		}
+	}
+	transformers := map[schema.GroupResource]value.Transformer{}
+	for resource, provider := range providersByResource {
+		if transformers[resource], err = providers.TransformerFor(provider); err != nil {
+			return nil, nil, fmt.Errorf("invalid value of --custom-resource-encryption-providers for %s: %v", resource, err)
+		}
+	}
+	return defaultTransformer, transformers, nil
+}
+
+// parseEncryptionProviders parses the --custom-resource-encryption-providers flag. Each provider has
+// the format group/resource#provider.
+func parseEncryptionProviders(providers []string) (map[schema.GroupResource]string, error) {
+	ret := map[schema.GroupResource]string{}
+	for _, p := range providers {
+		tokens := strings.Split(p, "#")
+		if len(tokens) != 2 || len(tokens[1]) == 0 {
+			return nil, fmt.Errorf("invalid value of --custom-resource-encryption-providers %q: expected group/resource#provider", p)
+		}
+		groupResource := strings.Split(tokens[0], "/")
+		if len(groupResource) != 2 || len(groupResource[0]) == 0 || len(groupResource[1]) == 0 {
+			return nil, fmt.Errorf("invalid value of --custom-resource-encryption-providers %q: expected group/resource#provider", p)
+		}
+		ret[schema.GroupResource{Group: groupResource[0], Resource: groupResource[1]}] = tokens[1]
+	}
+	return ret, nil
+}
+
+// parseEtcdServersOverrides parses the --etcd-servers-overrides flag. Each override has the format
+// group/resource#servers, where the servers are semicolon separated.
+func parseEtcdServersOverrides(overrides []string) (map[schema.GroupResource][]string, error) {
+	ret := map[schema.GroupResource][]string{}
+	for _, override := range overrides {
+		tokens := strings.Split(override, "#")
+		if len(tokens) != 2 {
+			return nil, fmt.Errorf("invalid value of --etcd-servers-overrides %q: expected group/resource#servers", override)
+		}
+		groupResource := strings.Split(tokens[0], "/")
+		if len(groupResource) != 2 || len(groupResource[0]) == 0 || len(groupResource[1]) == 0 {
+			return nil, fmt.Errorf("invalid value of --etcd-servers-overrides %q: expected group/resource#servers", override)
+		}
+		servers := strings.Split(tokens[1], ";")
+		for _, server := range servers {
+			if len(server) == 0 {
+				return nil, fmt.Errorf("invalid value of --etcd-servers-overrides %q: empty server", override)
+			}
+		}
+		ret[schema.GroupResource{Group: groupResource[0], Resource: groupResource[1]}] = servers
+	}
+	return ret, nil
+}
+
+// parseWatchCacheSizes parses the --custom-resource-watch-cache-sizes flag. Each size has the format
+// group/resource#size.
+func parseWatchCacheSizes(sizes []string) (map[schema.GroupResource]int, error) {
+	ret := map[schema.GroupResource]int{}
+	for _, s := range sizes {
+		tokens := strings.Split(s, "#")
+		if len(tokens) != 2 {
+			return nil, fmt.Errorf("invalid value of --custom-resource-watch-cache-sizes %q: expected group/resource#size", s)
+		}
+		groupResource := strings.Split(tokens[0], "/")
+		if len(groupResource) != 2 || len(groupResource[0]) == 0 || len(groupResource[1]) == 0 {
+			return nil, fmt.Errorf("invalid value of --custom-resource-watch-cache-sizes %q: expected group/resource#size", s)
+		}
+		size, err := strconv.Atoi(tokens[1])
+		if err != nil || size < 0 {
+			return nil, fmt.Errorf("invalid value of --custom-resource-watch-cache-sizes %q: the size must be a non-negative integer", s)
+		}
+		ret[schema.GroupResource{Group: groupResource[0], Resource: groupResource[1]}] = size
+	}
+	return ret, nil
+}
+
+// parsePriorityLevels parses the --custom-resource-priority-levels and --custom-resource-flow-schemas
+// flags. Each priority level has the format name#limit, each flow schema the format group/resource#level
+// or group/resource/verb#level.
+func parsePriorityLevels(levels, flowSchemas []string) (map[string]int, apiserver.FlowSchemaClassifier, error) {
+	limits := map[string]int{}
+	for _, l := range levels {
+		tokens := strings.Split(l, "#")
+		if len(tokens) != 2 || len(tokens[0]) == 0 {
+			return nil, nil, fmt.Errorf("invalid value of --custom-resource-priority-levels %q: expected name#limit", l)
+		}
+		limit, err := strconv.Atoi(tokens[1])
+		if err != nil || limit < 1 {
+			return nil, nil, fmt.Errorf("invalid value of --custom-resource-priority-levels %q: the limit must be a positive integer", l)
+		}
+		limits[tokens[0]] = limit
+	}
+	classifier := apiserver.FlowSchemaClassifier{}
+	for _, s := range flowSchemas {
+		tokens := strings.Split(s, "#")
+		if len(tokens) != 2 {
+			return nil, nil, fmt.Errorf("invalid value of --custom-resource-flow-schemas %q: expected group/resource#level or group/resource/verb#level", s)
+		}
+		parts := strings.Split(tokens[0], "/")
+		if len(parts) < 2 || len(parts) > 3 || len(parts[0]) == 0 || len(parts[1]) == 0 || (len(parts) == 3 && len(parts[2]) == 0) {
+			return nil, nil, fmt.Errorf("invalid value of --custom-resource-flow-schemas %q: expected group/resource#level or group/resource/verb#level", s)
+		}
+		if _, ok := limits[tokens[1]]; !ok {
+			return nil, nil, fmt.Errorf("invalid value of --custom-resource-flow-schemas %q: unknown priority level %q", s, tokens[1])
+		}
+		flowSchema := apiserver.FlowSchema{Resource: schema.GroupResource{Group: parts[0], Resource: parts[1]}}
+		if len(parts) == 3 {
+			flowSchema.Verb = parts[2]
+		}
+		classifier[flowSchema] = tokens[1]
 	}
+	return limits, classifier, nil
+}
+
+// watchTerminationTimeout is how long the server waits on shutdown for the streams of the watches of
+// custom resources to end.
+const watchTerminationTimeout = 5 * time.Second
 
-	server, err := config.Complete().New(genericapiserver.EmptyDelegate)
+func (o CustomResourceDefinitionsServerOptions) RunCustomResourceDefinitionsServer(stopCh <-chan struct{}) error {
+	server, err := o.NewServer(genericapiserver.EmptyDelegate)
 	if err != nil {
 		return err
 	}
-	return server.GenericAPIServer.PrepareRun().Run(stopCh)
+	if err := server.GenericAPIServer.PrepareRun().NonBlockingRun(stopCh); err != nil {
+		return err
+	}
+	<-stopCh
+	// the listeners are closed, the established watches end gracefully
+	server.TerminateWatches(watchTerminationTimeout)
+	return nil
 }
@@ -54,6 +54,9 @@ type CRDFinalizer struct {
 
 	// To allow injection for testing.
 	syncFn func(key string) error
+	// pollInterval and pollTimeout bound the wait for the instances to be removed after the deletes were issued.
+	pollInterval time.Duration
+	pollTimeout  time.Duration
 
 	queue workqueue.RateLimitingInterface
 }
@@ -83,6 +86,8 @@ func NewCRDFinalizer(
 		crdSynced:      crdInformer.Informer().HasSynced,
 		crClientGetter: crClientGetter,
 		queue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "CustomResourceDefinition-CRDFinalizer"),
+		pollInterval:   5 * time.Second,
+		pollTimeout:    1 * time.Minute,
 	}
 
 	crdInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -113,10 +118,11 @@ func (c *CRDFinalizer) sync(key string) error {
 
 	// update the status condition.  This cleanup could take a while.
 	apiextensions.SetCRDCondition(crd, apiextensions.CustomResourceDefinitionCondition{
-		Type:    apiextensions.Terminating,
-		Status:  apiextensions.ConditionTrue,
-		Reason:  "InstanceDeletionInProgress",
-		Message: "CustomResource deletion is in progress",
+		Type:               apiextensions.Terminating,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             "InstanceDeletionInProgress",
+		Message:            "CustomResource deletion is in progress",
+		ObservedGeneration: crd.Generation,
 	})
 	crd, err = c.crdClient.CustomResourceDefinitions().UpdateStatus(crd)
 	if err != nil {
@@ -126,7 +132,10 @@ func (c *CRDFinalizer) sync(key string) error {
 	// Now we can start deleting items.  We should use the REST API to ensure that all normal admission runs.
 	// Since we control the endpoints, we know that delete collection works. No need to delete if not established.
 	if apiextensions.IsCRDConditionTrue(crd, apiextensions.Established) {
-		cond, deleteErr := c.deleteInstances(crd)
+		var cond apiextensions.CustomResourceDefinitionCondition
+		var deleteErr error
+		crd, cond, deleteErr = c.deleteInstances(crd)
+		cond.ObservedGeneration = crd.Generation
 		apiextensions.SetCRDCondition(crd, cond)
 		if deleteErr != nil {
 			crd, err = c.crdClient.CustomResourceDefinitions().UpdateStatus(crd)
@@ -137,10 +146,11 @@ func (c *CRDFinalizer) sync(key string) error {
 		}
 	} else {
 		apiextensions.SetCRDCondition(crd, apiextensions.CustomResourceDefinitionCondition{
-			Type:    apiextensions.Terminating,
-			Status:  apiextensions.ConditionFalse,
-			Reason:  "NeverEstablished",
-			Message: "resource was never established",
+			Type:               apiextensions.Terminating,
+			Status:             apiextensions.ConditionFalse,
+			Reason:             "NeverEstablished",
+			Message:            "resource was never established",
+			ObservedGeneration: crd.Generation,
 		})
 	}
 
@@ -154,14 +164,16 @@ func (c *CRDFinalizer) sync(key string) error {
 	return c.crdClient.CustomResourceDefinitions().Delete(crd.Name, nil)
 }
 
-func (c *CRDFinalizer) deleteInstances(crd *apiextensions.CustomResourceDefinition) (apiextensions.CustomResourceDefinitionCondition, error) {
+// deleteInstances deletes the instances of the CRD in all namespaces and waits until they are gone. The progress is
+// reported in the Terminating condition. It returns the latest CRD written and the final Terminating condition.
+func (c *CRDFinalizer) deleteInstances(crd *apiextensions.CustomResourceDefinition) (*apiextensions.CustomResourceDefinition, apiextensions.CustomResourceDefinitionCondition, error) {
 	// Now we can start deleting items. While it would be ideal to use a REST API client, doing so
 	// could incorrectly delete a ThirdPartyResource with the same URL as the CustomResource, so we go
 	// directly to the storage instead. Since we control the storage, we know that delete collection works.
 	crClient := c.crClientGetter.GetCustomResourceListerCollectionDeleter(crd)
 	if crClient == nil {
 		err := fmt.Errorf("unable to find a custom resource client for %s.%s", crd.Status.AcceptedNames.Plural, crd.Spec.Group)
-		return apiextensions.CustomResourceDefinitionCondition{
+		return crd, apiextensions.CustomResourceDefinitionCondition{
 			Type:    apiextensions.Terminating,
 			Status:  apiextensions.ConditionTrue,
 			Reason:  "InstanceDeletionFailed",
@@ -172,7 +184,7 @@ func (c *CRDFinalizer) deleteInstances(crd *apiextensions.CustomResourceDefiniti
 	ctx := genericapirequest.NewContext()
 	allResources, err := crClient.List(ctx, nil)
 	if err != nil {
-		return apiextensions.CustomResourceDefinitionCondition{
+		return crd, apiextensions.CustomResourceDefinitionCondition{
 			Type:    apiextensions.Terminating,
 			Status:  apiextensions.ConditionTrue,
 			Reason:  "InstanceDeletionFailed",
@@ -200,7 +212,7 @@ func (c *CRDFinalizer) deleteInstances(crd *apiextensions.CustomResourceDefiniti
 		}
 	}
 	if deleteError := utilerrors.NewAggregate(deleteErrors); deleteError != nil {
-		return apiextensions.CustomResourceDefinitionCondition{
+		return crd, apiextensions.CustomResourceDefinitionCondition{
 			Type:    apiextensions.Terminating,
 			Status:  apiextensions.ConditionTrue,
 			Reason:  "InstanceDeletionFailed",
@@ -211,26 +223,32 @@ func (c *CRDFinalizer) deleteInstances(crd *apiextensions.CustomResourceDefiniti
 	// now we need to wait until all the resources are deleted.  Start with a simple poll before we do anything fancy.
 	// TODO not all servers are synchronized on caches.  It is possible for a stale one to still be creating things.
 	// Once we have a mechanism for servers to indicate their states, we should check that for concurrence.
-	err = wait.PollImmediate(5*time.Second, 1*time.Minute, func() (bool, error) {
+	reported := -1
+	err = wait.PollImmediate(c.pollInterval, c.pollTimeout, func() (bool, error) {
 		listObj, err := crClient.List(ctx, nil)
 		if err != nil {
 			return false, err
 		}
-		if len(listObj.(*unstructured.UnstructuredList).Items) == 0 {
+		remaining := len(listObj.(*unstructured.UnstructuredList).Items)
+		if remaining == 0 {
 			return true, nil
 		}
-		glog.V(2).Infof("%s.%s waiting for %d items to be removed", crd.Status.AcceptedNames.Plural, crd.Spec.Group, len(listObj.(*unstructured.UnstructuredList).Items))
+		glog.V(2).Infof("%s.%s waiting for %d items to be removed", crd.Status.AcceptedNames.Plural, crd.Spec.Group, remaining)
+		if remaining != reported {
+			reported = remaining
+			crd = c.reportProgress(crd, fmt.Sprintf("waiting for %d of %d instances in %d namespaces to be removed", remaining, len(allResources.(*unstructured.UnstructuredList).Items), len(deletedNamespaces)))
+		}
 		return false, nil
 	})
 	if err != nil {
-		return apiextensions.CustomResourceDefinitionCondition{
+		return crd, apiextensions.CustomResourceDefinitionCondition{
 			Type:    apiextensions.Terminating,
 			Status:  apiextensions.ConditionTrue,
 			Reason:  "InstanceDeletionCheck",
 			Message: fmt.Sprintf("could not confirm zero CustomResources remaining: %v", err),
 		}, err
 	}
-	return apiextensions.CustomResourceDefinitionCondition{
+	return crd, apiextensions.CustomResourceDefinitionCondition{
 		Type:    apiextensions.Terminating,
 		Status:  apiextensions.ConditionFalse,
 		Reason:  "InstanceDeletionCompleted",
@@ -238,6 +256,25 @@ func (c *CRDFinalizer) deleteInstances(crd *apiextensions.CustomResourceDefiniti
 	}, nil
 }
 
+// reportProgress sets the Terminating condition of the CRD to the given progress message. Failures to update
+// the status are only logged, in which case the CRD is returned unchanged.
+func (c *CRDFinalizer) reportProgress(crd *apiextensions.CustomResourceDefinition, message string) *apiextensions.CustomResourceDefinition {
+	updated := crd.DeepCopy()
+	apiextensions.SetCRDCondition(updated, apiextensions.CustomResourceDefinitionCondition{
+		Type:               apiextensions.Terminating,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             "InstanceDeletionInProgress",
+		Message:            message,
+		ObservedGeneration: updated.Generation,
+	})
+	updated, err := c.crdClient.CustomResourceDefinitions().UpdateStatus(updated)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("unable to report the deletion progress of %s: %v", crd.Name, err))
+		return crd
+	}
+	return updated
+}
+
 func (c *CRDFinalizer) Run(workers int, stopCh <-chan struct{}) {
 	defer utilruntime.HandleCrash()
 	defer c.queue.ShutDown()
@@ -0,0 +1,241 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finalizer
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/client/clientset/internalclientset/fake"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+// fakeStorage lists the given items and removes them on delete collection, except for the stuck namespaces.
+type fakeStorage struct {
+	items     []unstructured.Unstructured
+	stuck     map[string]bool
+	deleteErr error
+
+	deletedNamespaces []string
+}
+
+func (s *fakeStorage) NewList() runtime.Object {
+	return &unstructured.UnstructuredList{}
+}
+
+func (s *fakeStorage) List(ctx genericapirequest.Context, options *metainternalversion.ListOptions) (runtime.Object, error) {
+	return &unstructured.UnstructuredList{Items: s.items}, nil
+}
+
+func (s *fakeStorage) DeleteCollection(ctx genericapirequest.Context, options *metav1.DeleteOptions, listOptions *metainternalversion.ListOptions) (runtime.Object, error) {
+	if s.deleteErr != nil {
+		return nil, s.deleteErr
+	}
+	namespace, _ := genericapirequest.NamespaceFrom(ctx)
+	s.deletedNamespaces = append(s.deletedNamespaces, namespace)
+	if s.stuck[namespace] {
+		return &unstructured.UnstructuredList{}, nil
+	}
+	var remaining []unstructured.Unstructured
+	for _, item := range s.items {
+		if item.GetNamespace() != namespace {
+			remaining = append(remaining, item)
+		}
+	}
+	s.items = remaining
+	return &unstructured.UnstructuredList{}, nil
+}
+
+type fakeStorageGetter struct {
+	storage *fakeStorage
+}
+
+func (g fakeStorageGetter) GetCustomResourceListerCollectionDeleter(crd *apiextensions.CustomResourceDefinition) ListerCollectionDeleter {
+	return g.storage
+}
+
+func newCR(namespace, name string) unstructured.Unstructured {
+	u := unstructured.Unstructured{}
+	u.SetAPIVersion("group.com/v1")
+	u.SetKind("Kind")
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u
+}
+
+func newDeletedCRD(deleted, established bool) *apiextensions.CustomResourceDefinition {
+	crd := &apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "kinds.group.com",
+			Finalizers: []string{apiextensions.CustomResourceCleanupFinalizer},
+		},
+		Spec: apiextensions.CustomResourceDefinitionSpec{Group: "group.com"},
+		Status: apiextensions.CustomResourceDefinitionStatus{
+			AcceptedNames: apiextensions.CustomResourceDefinitionNames{Plural: "kinds"},
+		},
+	}
+	if deleted {
+		now := metav1.Now()
+		crd.DeletionTimestamp = &now
+	}
+	if established {
+		apiextensions.SetCRDCondition(crd, apiextensions.CustomResourceDefinitionCondition{
+			Type:   apiextensions.Established,
+			Status: apiextensions.ConditionTrue,
+		})
+	}
+	return crd
+}
+
+func TestSync(t *testing.T) {
+	tests := []struct {
+		name      string
+		crd       *apiextensions.CustomResourceDefinition
+		items     []unstructured.Unstructured
+		stuck     []string
+		deleteErr error
+
+		expectError               bool
+		expectedDeletedNamespaces []string
+		// expectedProgress are the reasons and messages of the Terminating conditions written, in order
+		expectedProgress []string
+		expectFinalized  bool
+	}{
+		{
+			name:  "not deleted",
+			crd:   newDeletedCRD(false, true),
+			items: []unstructured.Unstructured{newCR("ns", "a")},
+		},
+		{
+			name:  "never established",
+			crd:   newDeletedCRD(true, false),
+			items: []unstructured.Unstructured{newCR("ns", "a")},
+			expectedProgress: []string{
+				"InstanceDeletionInProgress: CustomResource deletion is in progress",
+				"NeverEstablished: resource was never established",
+			},
+			expectFinalized: true,
+		},
+		{
+			name:                      "delete instances in all namespaces",
+			crd:                       newDeletedCRD(true, true),
+			items:                     []unstructured.Unstructured{newCR("ns", "a"), newCR("ns", "b"), newCR("other", "c")},
+			expectedDeletedNamespaces: []string{"ns", "other"},
+			expectedProgress: []string{
+				"InstanceDeletionInProgress: CustomResource deletion is in progress",
+				"InstanceDeletionCompleted: removed all instances",
+			},
+			expectFinalized: true,
+		},
+		{
+			name:                      "cluster scoped instances",
+			crd:                       newDeletedCRD(true, true),
+			items:                     []unstructured.Unstructured{newCR("", "a"), newCR("", "b")},
+			expectedDeletedNamespaces: []string{""},
+			expectedProgress: []string{
+				"InstanceDeletionInProgress: CustomResource deletion is in progress",
+				"InstanceDeletionCompleted: removed all instances",
+			},
+			expectFinalized: true,
+		},
+		{
+			name:                      "instances remaining",
+			crd:                       newDeletedCRD(true, true),
+			items:                     []unstructured.Unstructured{newCR("ns", "a"), newCR("stuck", "b")},
+			stuck:                     []string{"stuck"},
+			expectError:               true,
+			expectedDeletedNamespaces: []string{"ns", "stuck"},
+			expectedProgress: []string{
+				"InstanceDeletionInProgress: CustomResource deletion is in progress",
+				"InstanceDeletionInProgress: waiting for 1 of 2 instances in 2 namespaces to be removed",
+				"InstanceDeletionCheck: could not confirm zero CustomResources remaining: timed out waiting for the condition",
+			},
+		},
+		{
+			name:        "delete error",
+			crd:         newDeletedCRD(true, true),
+			items:       []unstructured.Unstructured{newCR("ns", "a")},
+			deleteErr:   fmt.Errorf("boom"),
+			expectError: true,
+			expectedProgress: []string{
+				"InstanceDeletionInProgress: CustomResource deletion is in progress",
+				"InstanceDeletionFailed: could not issue all deletes: boom",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		crdIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		crdIndexer.Add(tc.crd)
+		storage := &fakeStorage{items: tc.items, stuck: map[string]bool{}, deleteErr: tc.deleteErr}
+		for _, ns := range tc.stuck {
+			storage.stuck[ns] = true
+		}
+		client := fake.NewSimpleClientset(tc.crd)
+
+		c := &CRDFinalizer{
+			crdClient:      client.Apiextensions(),
+			crClientGetter: fakeStorageGetter{storage},
+			crdLister:      listers.NewCustomResourceDefinitionLister(crdIndexer),
+			pollInterval:   time.Millisecond,
+			pollTimeout:    10 * time.Millisecond,
+		}
+		err := c.sync(tc.crd.Name)
+		if tc.expectError != (err != nil) {
+			t.Errorf("%s: expected error %v, got %v", tc.name, tc.expectError, err)
+		}
+		if !reflect.DeepEqual(tc.expectedDeletedNamespaces, storage.deletedNamespaces) {
+			t.Errorf("%s: expected deleted namespaces %v, got %v", tc.name, tc.expectedDeletedNamespaces, storage.deletedNamespaces)
+		}
+
+		var progress []string
+		finalized := false
+		for _, action := range client.Actions() {
+			switch {
+			case action.GetVerb() == "update" && action.GetSubresource() == "status":
+				crd := action.(core.UpdateAction).GetObject().(*apiextensions.CustomResourceDefinition)
+				cond := apiextensions.FindCRDCondition(crd, apiextensions.Terminating)
+				if cond == nil {
+					t.Errorf("%s: missing Terminating condition in status update", tc.name)
+					continue
+				}
+				progress = append(progress, cond.Reason+": "+cond.Message)
+				finalized = !apiextensions.CRDHasFinalizer(crd, apiextensions.CustomResourceCleanupFinalizer)
+			case action.GetVerb() == "delete":
+				if !finalized {
+					t.Errorf("%s: unexpected delete before the finalizer was removed", tc.name)
+				}
+			}
+		}
+		if !reflect.DeepEqual(tc.expectedProgress, progress) {
+			t.Errorf("%s: expected progress %q, got %q", tc.name, tc.expectedProgress, progress)
+		}
+		if tc.expectFinalized != finalized {
+			t.Errorf("%s: expected finalized %v, got %v", tc.name, tc.expectFinalized, finalized)
+		}
+	}
+}
@@ -0,0 +1,357 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"strings"
+
+	"github.com/go-openapi/spec"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/fieldmanager"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/structural"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/serializer/cbor"
+)
+
+// versionSpec holds the OpenAPI definitions and operations of one version of a CRD.
+type versionSpec struct {
+	groupVersion schema.GroupVersion
+	// definitionsV2 and definitionsV3 map definition names to the schemas of the kind and list kind.
+	definitionsV2 map[string]spec.Schema
+	definitionsV3 map[string]spec.Schema
+	// resource is the plural name of the custom resources, which their JSON Schema is published under.
+	resource   string
+	jsonSchema *spec.Schema
+	// operations are sorted by path.
+	operations []operation
+}
+
+// operation is an operation on a path of a custom resource, independent of the OpenAPI version.
+type operation struct {
+	path        string
+	method      string
+	id          string
+	description string
+	// action is the verb published as x-kubernetes-action.
+	action string
+	// gvk is the kind of the custom resources of the operation, published as x-kubernetes-group-version-kind.
+	gvk schema.GroupVersionKind
+
+	parameters []parameter
+	// body is the definition name of the request body. It is empty if the operation has no body,
+	// and "object" if it is an arbitrary object.
+	body     string
+	consumes []string
+	// responses maps response codes to definition names, or "object" for arbitrary objects.
+	responses map[int]string
+}
+
+// parameter is a path or query parameter of an operation.
+type parameter struct {
+	name        string
+	in          string
+	typ         string
+	description string
+}
+
+const objectDefinition = "object"
+
+var (
+	produces      = []string{"application/json", cbor.ContentTypeCBOR}
+	patchConsumes = []string{"application/json-patch+json", "application/merge-patch+json", string(fieldmanager.ApplyPatchType)}
+
+	listParameters = []parameter{
+		{name: "labelSelector", in: "query", typ: "string", description: "A selector to restrict the list of returned objects by their labels. Defaults to everything."},
+		{name: "fieldSelector", in: "query", typ: "string", description: "A selector to restrict the list of returned objects by their fields. Defaults to everything."},
+		{name: "resourceVersion", in: "query", typ: "string", description: "When specified with a watch call, shows changes that occur after that particular version of a resource. Defaults to changes from the beginning of history."},
+		{name: "timeoutSeconds", in: "query", typ: "integer", description: "Timeout for the list/watch call."},
+		{name: "watch", in: "query", typ: "boolean", description: "Watch for changes to the described resources and return them as a stream of add, update, and remove notifications."},
+	}
+	patchParameters = []parameter{
+		{name: "fieldManager", in: "query", typ: "string", description: "The name of the actor making the change. It is required for apply patches."},
+		{name: "force", in: "query", typ: "boolean", description: "Force is going to \"force\" apply requests, i.e. take ownership of the conflicting fields."},
+	}
+)
+
+// definitionName returns the name of the OpenAPI definition of the given kind. Like for built-in
+// types, the group is reversed, e.g. com.example.stable.v1.CronTab for stable.example.com/v1.
+func definitionName(gv schema.GroupVersion, kind string) string {
+	parts := strings.Split(gv.Group, ".")
+	for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+		parts[i], parts[j] = parts[j], parts[i]
+	}
+	return strings.Join(parts, ".") + "." + gv.Version + "." + kind
+}
+
+// toCamel converts a group or version to the camel case used in operation ids.
+func toCamel(s string) string {
+	parts := strings.FieldsFunc(s, func(r rune) bool { return r == '.' || r == '-' })
+	for i, p := range parts {
+		parts[i] = strings.ToUpper(p[:1]) + p[1:]
+	}
+	return strings.Join(parts, "")
+}
+
+// buildVersionSpec builds the OpenAPI definitions and operations of the given version of the CRD.
+func buildVersionSpec(crd *apiextensions.CustomResourceDefinition, version string) (*versionSpec, error) {
+	validation, err := apiextensions.GetSchemaForVersion(crd, version)
+	if err != nil {
+		return nil, err
+	}
+	subresources, err := apiextensions.GetSubresourcesForVersion(crd, version)
+	if err != nil {
+		return nil, err
+	}
+	var openAPIV3Schema *apiextensions.JSONSchemaProps
+	if validation != nil && len(structural.Validate(validation.OpenAPIV3Schema, nil)) == 0 {
+		// non-structural schemas are not published, they do not determine the fields unambiguously
+		openAPIV3Schema = validation.OpenAPIV3Schema
+	}
+
+	gv := schema.GroupVersion{Group: crd.Spec.Group, Version: version}
+	return &versionSpec{
+		groupVersion:  gv,
+		definitionsV2: buildDefinitions(crd, gv, openAPIV3Schema, true),
+		definitionsV3: buildDefinitions(crd, gv, openAPIV3Schema, false),
+		operations:    buildOperations(crd, gv, subresources),
+		resource:      crd.Status.AcceptedNames.Plural,
+		jsonSchema:    buildJSONSchema(crd, gv, openAPIV3Schema),
+	}, nil
+}
+
+// nonStructuralErrors returns the violations of the rules for structural schemas by the schemas of
+// the served versions, which keep these versions from being published with their schema.
+func nonStructuralErrors(crd *apiextensions.CustomResourceDefinition) field.ErrorList {
+	allErrs := field.ErrorList{}
+	perVersion := apiextensions.HasPerVersionSchema(crd.Spec.Versions)
+	for i, v := range crd.Spec.Versions {
+		if !v.Served {
+			continue
+		}
+		validation, err := apiextensions.GetSchemaForVersion(crd, v.Name)
+		if err != nil || validation == nil {
+			continue
+		}
+		if !perVersion {
+			// the top-level schema is shared by all versions
+			return structural.Validate(validation.OpenAPIV3Schema, field.NewPath("spec", "validation", "openAPIV3Schema"))
+		}
+		allErrs = append(allErrs, structural.Validate(validation.OpenAPIV3Schema, field.NewPath("spec", "versions").Index(i).Child("schema", "openAPIV3Schema"))...)
+	}
+	return allErrs
+}
+
+// buildDefinitions returns the definitions of the kind and the list kind of the CRD, with the given
+// schema of the version, which may be nil.
+func buildDefinitions(crd *apiextensions.CustomResourceDefinition, gv schema.GroupVersion, openAPIV3Schema *apiextensions.JSONSchemaProps, v2 bool) map[string]spec.Schema {
+	kind := crd.Status.AcceptedNames.Kind
+	listKind := crd.Status.AcceptedNames.ListKind
+
+	var object *spec.Schema
+	if openAPIV3Schema != nil {
+		object = convertJSONSchemaProps(openAPIV3Schema, v2)
+	} else {
+		object = &spec.Schema{}
+		object.Type = spec.StringOrArray{"object"}
+	}
+	if object.Properties == nil {
+		object.Properties = map[string]spec.Schema{}
+	}
+	// apiVersion, kind and metadata are documented like for built-in types
+	addTypeMetaProperties(object)
+	object.Properties["metadata"] = *spec.MapProperty(nil).WithDescription("Standard object's metadata. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata")
+	object.AddExtension("x-kubernetes-group-version-kind", []interface{}{gvkExtension(gv, kind)})
+
+	list := &spec.Schema{}
+	list.Type = spec.StringOrArray{"object"}
+	list.Description = listKind + " is a list of " + kind
+	list.Required = []string{"items"}
+	list.Properties = map[string]spec.Schema{
+		"items":    *spec.ArrayProperty(spec.RefSchema(definitionRef(definitionName(gv, kind), v2))).WithDescription("List of " + crd.Status.AcceptedNames.Plural),
+		"metadata": *spec.MapProperty(nil).WithDescription("Standard list metadata. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds"),
+	}
+	addTypeMetaProperties(list)
+	list.AddExtension("x-kubernetes-group-version-kind", []interface{}{gvkExtension(gv, listKind)})
+
+	return map[string]spec.Schema{
+		definitionName(gv, kind):     *object,
+		definitionName(gv, listKind): *list,
+	}
+}
+
+func addTypeMetaProperties(s *spec.Schema) {
+	s.Properties["apiVersion"] = *spec.StringProperty().WithDescription("APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources")
+	s.Properties["kind"] = *spec.StringProperty().WithDescription("Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds")
+}
+
+func gvkExtension(gv schema.GroupVersion, kind string) map[string]interface{} {
+	return map[string]interface{}{
+		"group":   gv.Group,
+		"version": gv.Version,
+		"kind":    kind,
+	}
+}
+
+// definitionRef returns the reference to the given definition.
+func definitionRef(name string, v2 bool) string {
+	if v2 {
+		return "#/definitions/" + name
+	}
+	return "#/components/schemas/" + name
+}
+
+// buildOperations returns the operations on the collection, the objects and the status subresource
+// of the custom resources of the given version.
+func buildOperations(crd *apiextensions.CustomResourceDefinition, gv schema.GroupVersion, subresources *apiextensions.CustomResourceSubresources) []operation {
+	names := crd.Status.AcceptedNames
+	namespaced := crd.Spec.Scope == apiextensions.NamespaceScoped
+	kindDefinition := definitionName(gv, names.Kind)
+	listDefinition := definitionName(gv, names.ListKind)
+
+	base := "/apis/" + gv.Group + "/" + gv.Version
+	collectionPath := base + "/" + names.Plural
+	scope := ""
+	var pathParameters []parameter
+	if namespaced {
+		collectionPath = base + "/namespaces/{namespace}/" + names.Plural
+		scope = "Namespaced"
+		pathParameters = append(pathParameters, parameter{name: "namespace", in: "path", typ: "string", description: "object name and auth scope, such as for teams and projects"})
+	}
+	itemPath := collectionPath + "/{name}"
+	itemParameters := append(append([]parameter{}, pathParameters...), parameter{name: "name", in: "path", typ: "string", description: "name of the " + names.Kind})
+
+	id := func(verb, suffix string) string {
+		return verb + toCamel(gv.Group) + toCamel(gv.Version) + scope + names.Kind + suffix
+	}
+
+	ops := []operation{
+		{
+			path: collectionPath, method: "get", id: id("list", ""), action: "list",
+			description: "list or watch objects of kind " + names.Kind,
+			parameters:  append(append([]parameter{}, pathParameters...), listParameters...),
+			responses:   map[int]string{200: listDefinition},
+		},
+		{
+			path: collectionPath, method: "post", id: id("create", ""), action: "post",
+			description: "create " + names.Kind,
+			parameters:  pathParameters, body: kindDefinition,
+			responses: map[int]string{200: kindDefinition, 201: kindDefinition},
+		},
+		{
+			path: collectionPath, method: "delete", id: id("deleteCollection", ""), action: "deletecollection",
+			description: "delete collection of " + names.Kind,
+			parameters:  append(append([]parameter{}, pathParameters...), listParameters...),
+			responses:   map[int]string{200: objectDefinition},
+		},
+		{
+			path: itemPath, method: "get", id: id("read", ""), action: "get",
+			description: "read the specified " + names.Kind,
+			parameters:  itemParameters,
+			responses:   map[int]string{200: kindDefinition},
+		},
+		{
+			path: itemPath, method: "put", id: id("replace", ""), action: "put",
+			description: "replace the specified " + names.Kind,
+			parameters:  itemParameters, body: kindDefinition,
+			responses: map[int]string{200: kindDefinition, 201: kindDefinition},
+		},
+		{
+			path: itemPath, method: "patch", id: id("patch", ""), action: "patch",
+			description: "partially update the specified " + names.Kind,
+			parameters:  append(append([]parameter{}, itemParameters...), patchParameters...), body: objectDefinition, consumes: patchConsumes,
+			responses: map[int]string{200: kindDefinition, 201: kindDefinition},
+		},
+		{
+			path: itemPath, method: "delete", id: id("delete", ""), action: "delete",
+			description: "delete a " + names.Kind,
+			parameters:  itemParameters, body: objectDefinition,
+			responses: map[int]string{200: objectDefinition},
+		},
+	}
+
+	if namespaced {
+		ops = append(ops, operation{
+			path: base + "/" + names.Plural, method: "get", id: "list" + toCamel(gv.Group) + toCamel(gv.Version) + names.Kind + "ForAllNamespaces", action: "list",
+			description: "list or watch objects of kind " + names.Kind,
+			parameters:  listParameters,
+			responses:   map[int]string{200: listDefinition},
+		})
+	}
+
+	if subresources != nil && subresources.Status != nil {
+		statusPath := itemPath + "/status"
+		ops = append(ops,
+			operation{
+				path: statusPath, method: "get", id: id("read", "Status"), action: "get",
+				description: "read status of the specified " + names.Kind,
+				parameters:  itemParameters,
+				responses:   map[int]string{200: kindDefinition},
+			},
+			operation{
+				path: statusPath, method: "put", id: id("replace", "Status"), action: "put",
+				description: "replace status of the specified " + names.Kind,
+				parameters:  itemParameters, body: kindDefinition,
+				responses: map[int]string{200: kindDefinition, 201: kindDefinition},
+			},
+			operation{
+				path: statusPath, method: "patch", id: id("patch", "Status"), action: "patch",
+				description: "partially update status of the specified " + names.Kind,
+				parameters:  itemParameters, body: objectDefinition, consumes: patchConsumes[:2],
+				responses: map[int]string{200: kindDefinition},
+			},
+		)
+	}
+
+	if subresources != nil {
+		for _, custom := range subresources.Custom {
+			customPath := itemPath + "/" + custom.Name
+			for _, verb := range custom.Verbs {
+				switch verb {
+				case "get":
+					ops = append(ops, operation{
+						path: customPath, method: "get", id: id("read", toCamel(custom.Name)), action: "get",
+						description: "read " + custom.Name + " of the specified " + names.Kind,
+						parameters:  itemParameters,
+						responses:   map[int]string{200: kindDefinition},
+					})
+				case "update":
+					ops = append(ops, operation{
+						path: customPath, method: "put", id: id("replace", toCamel(custom.Name)), action: "put",
+						description: "replace " + custom.Name + " of the specified " + names.Kind,
+						parameters:  itemParameters, body: kindDefinition,
+						responses: map[int]string{200: kindDefinition, 201: kindDefinition},
+					})
+				case "patch":
+					ops = append(ops, operation{
+						path: customPath, method: "patch", id: id("patch", toCamel(custom.Name)), action: "patch",
+						description: "partially update " + custom.Name + " of the specified " + names.Kind,
+						parameters:  itemParameters, body: objectDefinition, consumes: patchConsumes[:2],
+						responses: map[int]string{200: kindDefinition},
+					})
+				}
+			}
+		}
+	}
+
+	for i := range ops {
+		ops[i].gvk = gv.WithKind(names.Kind)
+	}
+	return ops
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/go-openapi/spec"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+func TestDefinitionName(t *testing.T) {
+	tests := []struct {
+		gv       schema.GroupVersion
+		kind     string
+		expected string
+	}{
+		{schema.GroupVersion{Group: "stable.example.com", Version: "v1"}, "CronTab", "com.example.stable.v1.CronTab"},
+		{schema.GroupVersion{Group: "example", Version: "v1beta1"}, "Foo", "example.v1beta1.Foo"},
+	}
+	for _, tc := range tests {
+		if actual := definitionName(tc.gv, tc.kind); actual != tc.expected {
+			t.Errorf("%v %s: expected %q, got %q", tc.gv, tc.kind, tc.expected, actual)
+		}
+	}
+}
+
+func TestBuildVersionSpec(t *testing.T) {
+	v1 := apiextensions.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true}
+	tests := []struct {
+		name       string
+		crd        *apiextensions.CustomResourceDefinition
+		operations []string
+	}{
+		{
+			name: "namespaced",
+			crd:  newCRD("crontabs.stable.example.com", "stable.example.com", "crontabs", "CronTab", apiextensions.NamespaceScoped, true, v1),
+			operations: []string{
+				"createStableExampleComV1NamespacedCronTab",
+				"deleteCollectionStableExampleComV1NamespacedCronTab",
+				"deleteStableExampleComV1NamespacedCronTab",
+				"listStableExampleComV1CronTabForAllNamespaces",
+				"listStableExampleComV1NamespacedCronTab",
+				"patchStableExampleComV1NamespacedCronTab",
+				"readStableExampleComV1NamespacedCronTab",
+				"replaceStableExampleComV1NamespacedCronTab",
+			},
+		},
+		{
+			name: "cluster scoped with status",
+			crd: func() *apiextensions.CustomResourceDefinition {
+				crd := newCRD("clusters.infra.example.com", "infra.example.com", "clusters", "Cluster", apiextensions.ClusterScoped, true, v1)
+				crd.Spec.Subresources = &apiextensions.CustomResourceSubresources{
+					Status: &apiextensions.CustomResourceSubresourceStatus{},
+					Scale:  &apiextensions.CustomResourceSubresourceScale{},
+				}
+				return crd
+			}(),
+			operations: []string{
+				"createInfraExampleComV1Cluster",
+				"deleteCollectionInfraExampleComV1Cluster",
+				"deleteInfraExampleComV1Cluster",
+				"listInfraExampleComV1Cluster",
+				"patchInfraExampleComV1Cluster",
+				"patchInfraExampleComV1ClusterStatus",
+				"readInfraExampleComV1Cluster",
+				"readInfraExampleComV1ClusterStatus",
+				"replaceInfraExampleComV1Cluster",
+				"replaceInfraExampleComV1ClusterStatus",
+			},
+		},
+		{
+			name: "per-version status",
+			crd: func() *apiextensions.CustomResourceDefinition {
+				v := v1
+				v.Subresources = &apiextensions.CustomResourceSubresources{
+					Status: &apiextensions.CustomResourceSubresourceStatus{},
+				}
+				return newCRD("clusters.infra.example.com", "infra.example.com", "clusters", "Cluster", apiextensions.ClusterScoped, true, v)
+			}(),
+			operations: []string{
+				"createInfraExampleComV1Cluster",
+				"deleteCollectionInfraExampleComV1Cluster",
+				"deleteInfraExampleComV1Cluster",
+				"listInfraExampleComV1Cluster",
+				"patchInfraExampleComV1Cluster",
+				"patchInfraExampleComV1ClusterStatus",
+				"readInfraExampleComV1Cluster",
+				"readInfraExampleComV1ClusterStatus",
+				"replaceInfraExampleComV1Cluster",
+				"replaceInfraExampleComV1ClusterStatus",
+			},
+		},
+		{
+			name: "custom subresource",
+			crd: func() *apiextensions.CustomResourceDefinition {
+				crd := newCRD("clusters.infra.example.com", "infra.example.com", "clusters", "Cluster", apiextensions.ClusterScoped, true, v1)
+				crd.Spec.Subresources = &apiextensions.CustomResourceSubresources{
+					Custom: []apiextensions.CustomResourceSubresourceCustom{
+						{Name: "approve", FieldPaths: []string{".spec.approved"}, Verbs: []string{"get", "update"}},
+					},
+				}
+				return crd
+			}(),
+			operations: []string{
+				"createInfraExampleComV1Cluster",
+				"deleteCollectionInfraExampleComV1Cluster",
+				"deleteInfraExampleComV1Cluster",
+				"listInfraExampleComV1Cluster",
+				"patchInfraExampleComV1Cluster",
+				"readInfraExampleComV1Cluster",
+				"readInfraExampleComV1ClusterApprove",
+				"replaceInfraExampleComV1Cluster",
+				"replaceInfraExampleComV1ClusterApprove",
+			},
+		},
+	}
+	for _, tc := range tests {
+		s, err := buildVersionSpec(tc.crd, "v1")
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+
+		operations := []string{}
+		for _, op := range s.operations {
+			operations = append(operations, op.id)
+		}
+		sort.Strings(operations)
+		if !reflect.DeepEqual(operations, tc.operations) {
+			t.Errorf("%s: expected operations %v, got %v", tc.name, tc.operations, operations)
+		}
+
+		kind := definitionName(s.groupVersion, tc.crd.Status.AcceptedNames.Kind)
+		for _, defs := range []map[string]spec.Schema{s.definitionsV2, s.definitionsV3} {
+			def, ok := defs[kind]
+			if !ok {
+				t.Errorf("%s: missing definition %s", tc.name, kind)
+				continue
+			}
+			for _, property := range []string{"apiVersion", "kind", "metadata", "spec"} {
+				if _, ok := def.Properties[property]; !ok {
+					t.Errorf("%s: missing property %s", tc.name, property)
+				}
+			}
+			gvk, _ := def.Extensions["x-kubernetes-group-version-kind"].([]interface{})
+			if len(gvk) != 1 || !reflect.DeepEqual(gvk[0], gvkExtension(s.groupVersion, tc.crd.Status.AcceptedNames.Kind)) {
+				t.Errorf("%s: unexpected x-kubernetes-group-version-kind %v", tc.name, def.Extensions["x-kubernetes-group-version-kind"])
+			}
+		}
+		list := s.definitionsV3[definitionName(s.groupVersion, tc.crd.Status.AcceptedNames.ListKind)]
+		if ref := list.Properties["items"].Items.Schema.Ref.String(); ref != "#/components/schemas/"+kind {
+			t.Errorf("%s: unexpected items reference %q", tc.name, ref)
+		}
+	}
+}
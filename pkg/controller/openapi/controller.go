@@ -0,0 +1,269 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	client "k8s.io/apiextensions-apiserver/pkg/client/clientset/internalclientset/typed/apiextensions/internalversion"
+	informers "k8s.io/apiextensions-apiserver/pkg/client/informers/internalversion/apiextensions/internalversion"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+)
+
+// Controller keeps the OpenAPI documents of a Service up to date with the established CRDs, and the
+// NonStructuralSchema condition of the CRDs up to date with the schemas which cannot be published.
+type Controller struct {
+	service   *Service
+	crdClient client.CustomResourceDefinitionsGetter
+
+	crdLister listers.CustomResourceDefinitionLister
+	crdSynced cache.InformerSynced
+
+	// specsLock protects specs.
+	specsLock sync.Mutex
+	// specs maps the names of the published CRDs to the specs of their served versions.
+	specs map[string][]*versionSpec
+	// publishedVersions maps the names of the published CRDs to the resource versions which their
+	// specs were built from.
+	publishedVersions map[string]string
+
+	// To allow injection for testing.
+	syncFn func(key string) error
+
+	queue workqueue.RateLimitingInterface
+}
+
+// NewController creates a new Controller which publishes the OpenAPI documents with the given Service.
+func NewController(crdInformer informers.CustomResourceDefinitionInformer, crdClient client.CustomResourceDefinitionsGetter, service *Service) *Controller {
+	c := &Controller{
+		service:           service,
+		crdClient:         crdClient,
+		crdLister:         crdInformer.Lister(),
+		crdSynced:         crdInformer.Informer().HasSynced,
+		specs:             map[string][]*versionSpec{},
+		publishedVersions: map[string]string{},
+		queue:             workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "CustomResourceDefinition-OpenAPIController"),
+	}
+
+	crdInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addCustomResourceDefinition,
+		UpdateFunc: c.updateCustomResourceDefinition,
+		DeleteFunc: c.deleteCustomResourceDefinition,
+	})
+
+	c.syncFn = c.sync
+
+	return c
+}
+
+// buildSpecs returns the specs of the served versions of the CRD, or nil if the CRD is not served.
+func buildSpecs(crd *apiextensions.CustomResourceDefinition) ([]*versionSpec, error) {
+	if !crd.DeletionTimestamp.IsZero() || !apiextensions.IsCRDConditionTrue(crd, apiextensions.Established) {
+		return nil, nil
+	}
+	var specs []*versionSpec
+	for _, v := range crd.Spec.Versions {
+		if !v.Served {
+			continue
+		}
+		s, err := buildVersionSpec(crd, v.Name)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, s)
+	}
+	return specs, nil
+}
+
+// calculateNonStructuralCondition returns the NonStructuralSchema condition of the CRD, or nil if
+// the schemas of all served versions are structural.
+func calculateNonStructuralCondition(crd *apiextensions.CustomResourceDefinition) *apiextensions.CustomResourceDefinitionCondition {
+	errs := nonStructuralErrors(crd)
+	if len(errs) == 0 {
+		return nil
+	}
+	return &apiextensions.CustomResourceDefinitionCondition{
+		Type:               apiextensions.NonStructuralSchema,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             "Violations",
+		Message:            errs.ToAggregate().Error(),
+		ObservedGeneration: crd.Generation,
+	}
+}
+
+// updateNonStructuralCondition updates the NonStructuralSchema condition of the CRD if it changed and
+// returns true if it did.
+func (c *Controller) updateNonStructuralCondition(inCustomResourceDefinition *apiextensions.CustomResourceDefinition) (bool, error) {
+	condition := calculateNonStructuralCondition(inCustomResourceDefinition)
+	existing := apiextensions.FindCRDCondition(inCustomResourceDefinition, apiextensions.NonStructuralSchema)
+	if (condition == nil && existing == nil) || (condition != nil && apiextensions.IsCRDConditionEquivalent(condition, existing)) {
+		return false, nil
+	}
+	crd := inCustomResourceDefinition.DeepCopy()
+	if condition == nil {
+		apiextensions.RemoveCRDCondition(crd, apiextensions.NonStructuralSchema)
+	} else {
+		apiextensions.SetCRDCondition(crd, *condition)
+	}
+	_, err := c.crdClient.CustomResourceDefinitions().UpdateStatus(crd)
+	return true, err
+}
+
+func (c *Controller) sync(key string) error {
+	crd, err := c.crdLister.Get(key)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil && crd.DeletionTimestamp.IsZero() {
+		// the documents are built once the CRD with the updated condition is observed
+		if updated, err := c.updateNonStructuralCondition(crd); updated || err != nil {
+			return err
+		}
+	}
+	var specs []*versionSpec
+	if err == nil {
+		if specs, err = buildSpecs(crd); err != nil {
+			return err
+		}
+	}
+
+	c.specsLock.Lock()
+	defer c.specsLock.Unlock()
+
+	if specs == nil {
+		if _, found := c.specs[key]; !found {
+			return nil
+		}
+		delete(c.specs, key)
+		delete(c.publishedVersions, key)
+	} else {
+		c.specs[key] = specs
+		// the CRD only counts as published once the documents are updated below
+		delete(c.publishedVersions, key)
+	}
+
+	// only the specs of this CRD are encoded again and merged with the other CRDs
+	if err := c.service.updateCRD(key, specs); err != nil {
+		return err
+	}
+	if specs != nil {
+		c.publishedVersions[key] = crd.ResourceVersion
+	}
+	return nil
+}
+
+// IsPublished returns true if the OpenAPI documents contain the served versions of the CRD as of
+// its resource version.
+func (c *Controller) IsPublished(crd *apiextensions.CustomResourceDefinition) bool {
+	c.specsLock.Lock()
+	defer c.specsLock.Unlock()
+	resourceVersion, found := c.publishedVersions[crd.Name]
+	return found && resourceVersion == crd.ResourceVersion
+}
+
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	glog.Infof("Starting OpenAPIController")
+	defer glog.Infof("Shutting down OpenAPIController")
+
+	if !cache.WaitForCacheSync(stopCh, c.crdSynced) {
+		return
+	}
+
+	// only start one worker thread since its a slow moving API
+	go wait.Until(c.runWorker, time.Second, stopCh)
+
+	<-stopCh
+}
+
+func (c *Controller) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+// processNextWorkItem deals with one key off the queue.  It returns false when it's time to quit.
+func (c *Controller) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	err := c.syncFn(key.(string))
+	if err == nil {
+		c.queue.Forget(key)
+		return true
+	}
+
+	utilruntime.HandleError(fmt.Errorf("%v failed with: %v", key, err))
+	c.queue.AddRateLimited(key)
+
+	return true
+}
+
+func (c *Controller) enqueue(obj *apiextensions.CustomResourceDefinition) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("Couldn't get key for object %#v: %v", obj, err))
+		return
+	}
+
+	c.queue.Add(key)
+}
+
+func (c *Controller) addCustomResourceDefinition(obj interface{}) {
+	castObj := obj.(*apiextensions.CustomResourceDefinition)
+	glog.V(4).Infof("Adding customresourcedefinition %s", castObj.Name)
+	c.enqueue(castObj)
+}
+
+func (c *Controller) updateCustomResourceDefinition(oldObj, newObj interface{}) {
+	castNewObj := newObj.(*apiextensions.CustomResourceDefinition)
+	glog.V(4).Infof("Updating customresourcedefinition %s", castNewObj.Name)
+	c.enqueue(castNewObj)
+}
+
+func (c *Controller) deleteCustomResourceDefinition(obj interface{}) {
+	castObj, ok := obj.(*apiextensions.CustomResourceDefinition)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			glog.Errorf("Couldn't get object from tombstone %#v", obj)
+			return
+		}
+		castObj, ok = tombstone.Obj.(*apiextensions.CustomResourceDefinition)
+		if !ok {
+			glog.Errorf("Tombstone contained object that is not expected %#v", obj)
+			return
+		}
+	}
+	glog.V(4).Infof("Deleting customresourcedefinition %q", castObj.Name)
+	c.enqueue(castObj)
+}
@@ -0,0 +1,322 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/client/clientset/internalclientset/fake"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newCRD(name, group, plural, kind string, scope apiextensions.ResourceScope, established bool, versions ...apiextensions.CustomResourceDefinitionVersion) *apiextensions.CustomResourceDefinition {
+	crd := &apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: apiextensions.CustomResourceDefinitionSpec{
+			Group:    group,
+			Version:  versions[0].Name,
+			Versions: versions,
+			Scope:    scope,
+			Names:    apiextensions.CustomResourceDefinitionNames{Plural: plural, Kind: kind, ListKind: kind + "List"},
+			Validation: &apiextensions.CustomResourceValidation{
+				OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+					Type: "object",
+					Properties: map[string]apiextensions.JSONSchemaProps{
+						"spec": {Type: "object", Description: "spec of the " + kind},
+					},
+				},
+			},
+		},
+		Status: apiextensions.CustomResourceDefinitionStatus{
+			AcceptedNames: apiextensions.CustomResourceDefinitionNames{Plural: plural, Kind: kind, ListKind: kind + "List"},
+		},
+	}
+	if established {
+		crd.Status.Conditions = []apiextensions.CustomResourceDefinitionCondition{{Type: apiextensions.Established, Status: apiextensions.ConditionTrue}}
+	}
+	return crd
+}
+
+// get returns the JSON document served at path, or nil if it is not found.
+func get(t *testing.T, service *Service, path string) map[string]interface{} {
+	w := httptest.NewRecorder()
+	service.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+	if w.Code == http.StatusNotFound {
+		return nil
+	}
+	if w.Code != http.StatusOK {
+		t.Fatalf("%s: unexpected status %d", path, w.Code)
+	}
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+	return doc
+}
+
+func keys(m interface{}) []string {
+	ret := []string{}
+	for k := range m.(map[string]interface{}) {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func TestSync(t *testing.T) {
+	v1 := apiextensions.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true}
+	v2beta1 := apiextensions.CustomResourceDefinitionVersion{Name: "v2beta1", Served: true}
+	unserved := apiextensions.CustomResourceDefinitionVersion{Name: "v1alpha1"}
+
+	crontabs := newCRD("crontabs.stable.example.com", "stable.example.com", "crontabs", "CronTab", apiextensions.NamespaceScoped, true, v1, v2beta1, unserved)
+	crontabs.Spec.Subresources = &apiextensions.CustomResourceSubresources{Status: &apiextensions.CustomResourceSubresourceStatus{}}
+	clusters := newCRD("clusters.infra.example.com", "infra.example.com", "clusters", "Cluster", apiextensions.ClusterScoped, true, v1)
+	pending := newCRD("pending.example.com", "example.com", "pending", "Pending", apiextensions.ClusterScoped, false, v1)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, crd := range []*apiextensions.CustomResourceDefinition{crontabs, clusters, pending} {
+		indexer.Add(crd)
+	}
+	service := NewService()
+	c := &Controller{
+		service:           service,
+		crdLister:         listers.NewCustomResourceDefinitionLister(indexer),
+		specs:             map[string][]*versionSpec{},
+		publishedVersions: map[string]string{},
+	}
+
+	if doc := get(t, service, V2Path); !reflect.DeepEqual(keys(doc["definitions"]), []string{}) {
+		t.Errorf("expected no definitions, got %v", keys(doc["definitions"]))
+	}
+
+	for _, name := range []string{crontabs.Name, clusters.Name, pending.Name} {
+		if err := c.sync(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	doc := get(t, service, V2Path)
+	expectedDefinitions := []string{
+		"com.example.infra.v1.Cluster",
+		"com.example.infra.v1.ClusterList",
+		"com.example.stable.v1.CronTab",
+		"com.example.stable.v1.CronTabList",
+		"com.example.stable.v2beta1.CronTab",
+		"com.example.stable.v2beta1.CronTabList",
+	}
+	if actual := keys(doc["definitions"]); !reflect.DeepEqual(actual, expectedDefinitions) {
+		t.Errorf("expected definitions %v, got %v", expectedDefinitions, actual)
+	}
+	expectedPaths := []string{
+		"/apis/infra.example.com/v1/clusters",
+		"/apis/infra.example.com/v1/clusters/{name}",
+		"/apis/stable.example.com/v1/crontabs",
+		"/apis/stable.example.com/v1/namespaces/{namespace}/crontabs",
+		"/apis/stable.example.com/v1/namespaces/{namespace}/crontabs/{name}",
+		"/apis/stable.example.com/v1/namespaces/{namespace}/crontabs/{name}/status",
+		"/apis/stable.example.com/v2beta1/crontabs",
+		"/apis/stable.example.com/v2beta1/namespaces/{namespace}/crontabs",
+		"/apis/stable.example.com/v2beta1/namespaces/{namespace}/crontabs/{name}",
+		"/apis/stable.example.com/v2beta1/namespaces/{namespace}/crontabs/{name}/status",
+	}
+	if actual := keys(doc["paths"]); !reflect.DeepEqual(actual, expectedPaths) {
+		t.Errorf("expected paths %v, got %v", expectedPaths, actual)
+	}
+
+	// every served version is published with its group, version and kind, not only the storage version
+	v2beta1GVK := map[string]interface{}{"group": "stable.example.com", "version": "v2beta1", "kind": "CronTab"}
+	definition := doc["definitions"].(map[string]interface{})["com.example.stable.v2beta1.CronTab"].(map[string]interface{})
+	if gvk := definition["x-kubernetes-group-version-kind"]; !reflect.DeepEqual(gvk, []interface{}{v2beta1GVK}) {
+		t.Errorf("unexpected x-kubernetes-group-version-kind of the v2beta1 definition: %v", gvk)
+	}
+	list := doc["paths"].(map[string]interface{})["/apis/stable.example.com/v2beta1/crontabs"].(map[string]interface{})["get"].(map[string]interface{})
+	if gvk := list["x-kubernetes-group-version-kind"]; !reflect.DeepEqual(gvk, v2beta1GVK) {
+		t.Errorf("unexpected x-kubernetes-group-version-kind of the v2beta1 list operation: %v", gvk)
+	}
+
+	index := get(t, service, V3Path)
+	expectedGroupVersions := []string{
+		"apis/infra.example.com/v1",
+		"apis/stable.example.com/v1",
+		"apis/stable.example.com/v2beta1",
+	}
+	if actual := keys(index["paths"]); !reflect.DeepEqual(actual, expectedGroupVersions) {
+		t.Errorf("expected group versions %v, got %v", expectedGroupVersions, actual)
+	}
+	v3 := get(t, service, V3Path+"/apis/stable.example.com/v1")
+	if v3 == nil {
+		t.Fatalf("expected OpenAPI v3 document of stable.example.com/v1")
+	}
+	if v3["openapi"] != "3.0.0" {
+		t.Errorf("unexpected openapi version %v", v3["openapi"])
+	}
+	expectedSchemas := []string{"com.example.stable.v1.CronTab", "com.example.stable.v1.CronTabList"}
+	if actual := keys(v3["components"].(map[string]interface{})["schemas"]); !reflect.DeepEqual(actual, expectedSchemas) {
+		t.Errorf("expected schemas %v, got %v", expectedSchemas, actual)
+	}
+	if doc := get(t, service, V3Path+"/apis/example.com/v1"); doc != nil {
+		t.Errorf("expected no OpenAPI v3 document of the CRD which is not established, got %v", doc)
+	}
+
+	jsonSchemaIndex := get(t, service, JSONSchemaPath)
+	expectedResources := []string{
+		"apis/infra.example.com/v1/clusters",
+		"apis/stable.example.com/v1/crontabs",
+		"apis/stable.example.com/v2beta1/crontabs",
+	}
+	if actual := keys(jsonSchemaIndex["paths"]); !reflect.DeepEqual(actual, expectedResources) {
+		t.Errorf("expected JSON Schemas of %v, got %v", expectedResources, actual)
+	}
+	jsonSchema := get(t, service, JSONSchemaPath+"/apis/stable.example.com/v2beta1/crontabs")
+	if jsonSchema == nil {
+		t.Fatalf("expected JSON Schema of stable.example.com/v2beta1 crontabs")
+	}
+	if description := jsonSchema["properties"].(map[string]interface{})["spec"].(map[string]interface{})["description"]; description != "spec of the CronTab" {
+		t.Errorf("unexpected description of spec in the JSON Schema: %v", description)
+	}
+
+	if !c.IsPublished(crontabs) || !c.IsPublished(clusters) || c.IsPublished(pending) {
+		t.Errorf("expected exactly the established CRDs to be published")
+	}
+	updated := crontabs.DeepCopy()
+	updated.ResourceVersion = "2"
+	if c.IsPublished(updated) {
+		t.Errorf("expected a CRD not to be published before its update is synced")
+	}
+
+	// removed CRDs are unpublished
+	indexer.Delete(crontabs)
+	if err := c.sync(crontabs.Name); err != nil {
+		t.Fatal(err)
+	}
+	if c.IsPublished(crontabs) {
+		t.Errorf("expected the removed CRD not to be published")
+	}
+	expectedDefinitions = []string{"com.example.infra.v1.Cluster", "com.example.infra.v1.ClusterList"}
+	if actual := keys(get(t, service, V2Path)["definitions"]); !reflect.DeepEqual(actual, expectedDefinitions) {
+		t.Errorf("expected definitions %v, got %v", expectedDefinitions, actual)
+	}
+	if doc := get(t, service, V3Path+"/apis/stable.example.com/v1"); doc != nil {
+		t.Errorf("expected no OpenAPI v3 document of the removed CRD, got %v", doc)
+	}
+	if doc := get(t, service, JSONSchemaPath+"/apis/stable.example.com/v1/crontabs"); doc != nil {
+		t.Errorf("expected no JSON Schema of the removed CRD, got %v", doc)
+	}
+}
+
+func TestNonStructuralSchemaCondition(t *testing.T) {
+	v1 := apiextensions.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true}
+	structuralCRD := newCRD("crontabs.stable.example.com", "stable.example.com", "crontabs", "CronTab", apiextensions.NamespaceScoped, true, v1)
+	structuralCRD.Generation = 2
+	nonStructuralCRD := structuralCRD.DeepCopy()
+	nonStructuralCRD.Spec.Validation.OpenAPIV3Schema.Properties["spec"] = apiextensions.JSONSchemaProps{Description: "spec of the CronTab"}
+	expectedMessage := "spec.validation.openAPIV3Schema.properties[spec].type: Required value: must not be empty to be structural"
+	condition := calculateNonStructuralCondition(nonStructuralCRD)
+	if condition == nil || condition.Status != apiextensions.ConditionTrue || condition.Reason != "Violations" || condition.Message != expectedMessage || condition.ObservedGeneration != 2 {
+		t.Fatalf("unexpected condition %#v", condition)
+	}
+	withCondition := func(crd *apiextensions.CustomResourceDefinition) *apiextensions.CustomResourceDefinition {
+		crd = crd.DeepCopy()
+		apiextensions.SetCRDCondition(crd, *condition)
+		return crd
+	}
+
+	tests := []struct {
+		name              string
+		crd               *apiextensions.CustomResourceDefinition
+		expectedUpdate    bool
+		expectedCondition bool
+		expectedPublished bool
+	}{
+		{
+			name:              "structural",
+			crd:               structuralCRD,
+			expectedPublished: true,
+		},
+		{
+			name:              "non-structural",
+			crd:               nonStructuralCRD,
+			expectedUpdate:    true,
+			expectedCondition: true,
+		},
+		{
+			name:              "non-structural with condition",
+			crd:               withCondition(nonStructuralCRD),
+			expectedPublished: true,
+		},
+		{
+			name:           "structural with condition",
+			crd:            withCondition(structuralCRD),
+			expectedUpdate: true,
+		},
+	}
+
+	for _, tc := range tests {
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		indexer.Add(tc.crd)
+		client := fake.NewSimpleClientset(tc.crd)
+		c := &Controller{
+			service:           NewService(),
+			crdClient:         client.Apiextensions(),
+			crdLister:         listers.NewCustomResourceDefinitionLister(indexer),
+			specs:             map[string][]*versionSpec{},
+			publishedVersions: map[string]string{},
+		}
+
+		if err := c.sync(tc.crd.Name); err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+
+		var updated *apiextensions.CustomResourceDefinition
+		for _, action := range client.Actions() {
+			if update, ok := action.(core.UpdateAction); ok && update.GetSubresource() == "status" {
+				updated = update.GetObject().(*apiextensions.CustomResourceDefinition)
+			}
+		}
+		if updated == nil && tc.expectedUpdate {
+			t.Errorf("%s: expected an update", tc.name)
+		} else if updated != nil && !tc.expectedUpdate {
+			t.Errorf("%s: unexpected update: %#v", tc.name, updated.Status)
+		} else if updated != nil {
+			if found := apiextensions.FindCRDCondition(updated, apiextensions.NonStructuralSchema) != nil; found != tc.expectedCondition {
+				t.Errorf("%s: expected condition %v, got %#v", tc.name, tc.expectedCondition, updated.Status.Conditions)
+			}
+		}
+		if published := c.IsPublished(tc.crd); published != tc.expectedPublished {
+			t.Errorf("%s: expected published %v, got %v", tc.name, tc.expectedPublished, published)
+		}
+	}
+
+	// the fields of a non-structural schema are not published
+	spec, err := buildVersionSpec(nonStructuralCRD, "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found := spec.definitionsV2["com.example.stable.v1.CronTab"].Properties["spec"]; found {
+		t.Errorf("expected the non-structural spec not to be published")
+	}
+}
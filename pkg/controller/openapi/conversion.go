@@ -0,0 +1,177 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"github.com/go-openapi/spec"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+// convertJSONSchemaProps converts the validation schema of a CRD to an OpenAPI schema. OpenAPI v2
+// does not support oneOf, anyOf and not, so they are dropped if v2 is true.
+func convertJSONSchemaProps(in *apiextensions.JSONSchemaProps, v2 bool) *spec.Schema {
+	if in == nil {
+		return nil
+	}
+
+	out := &spec.Schema{}
+	out.ID = in.ID
+	out.Description = in.Description
+	if len(in.Type) > 0 {
+		out.Type = spec.StringOrArray{in.Type}
+	}
+	out.Format = in.Format
+	out.Title = in.Title
+	if in.Default != nil {
+		out.Default = *in.Default
+	}
+	out.Maximum = in.Maximum
+	out.ExclusiveMaximum = in.ExclusiveMaximum
+	out.Minimum = in.Minimum
+	out.ExclusiveMinimum = in.ExclusiveMinimum
+	out.MaxLength = in.MaxLength
+	out.MinLength = in.MinLength
+	out.Pattern = in.Pattern
+	out.MaxItems = in.MaxItems
+	out.MinItems = in.MinItems
+	out.UniqueItems = in.UniqueItems
+	out.MultipleOf = in.MultipleOf
+	for _, e := range in.Enum {
+		out.Enum = append(out.Enum, e)
+	}
+	out.MaxProperties = in.MaxProperties
+	out.MinProperties = in.MinProperties
+	out.Required = in.Required
+	if in.Example != nil {
+		out.Example = *in.Example
+	}
+	if in.ExternalDocs != nil {
+		out.ExternalDocs = &spec.ExternalDocumentation{
+			Description: in.ExternalDocs.Description,
+			URL:         in.ExternalDocs.URL,
+		}
+	}
+
+	if in.Items != nil {
+		out.Items = &spec.SchemaOrArray{}
+		if in.Items.Schema != nil {
+			out.Items.Schema = convertJSONSchemaProps(in.Items.Schema, v2)
+		}
+		for i := range in.Items.JSONSchemas {
+			out.Items.Schemas = append(out.Items.Schemas, *convertJSONSchemaProps(&in.Items.JSONSchemas[i], v2))
+		}
+	}
+	out.AllOf = convertSlice(in.AllOf, v2)
+	if !v2 {
+		out.OneOf = convertSlice(in.OneOf, v2)
+		out.AnyOf = convertSlice(in.AnyOf, v2)
+		out.Not = convertJSONSchemaProps(in.Not, v2)
+	}
+	out.Properties = convertMap(in.Properties, v2)
+	out.AdditionalProperties = convertSchemaOrBool(in.AdditionalProperties, v2)
+	out.PatternProperties = convertMap(in.PatternProperties, v2)
+	if in.Dependencies != nil {
+		out.Dependencies = spec.Dependencies{}
+		for k, v := range in.Dependencies {
+			out.Dependencies[k] = spec.SchemaOrStringArray{
+				Schema:   convertJSONSchemaProps(v.Schema, v2),
+				Property: v.Property,
+			}
+		}
+	}
+	out.AdditionalItems = convertSchemaOrBool(in.AdditionalItems, v2)
+	if in.Definitions != nil {
+		out.Definitions = spec.Definitions(convertMap(in.Definitions, v2))
+	}
+
+	if len(in.XValidations) > 0 {
+		rules := make([]interface{}, 0, len(in.XValidations))
+		for _, r := range in.XValidations {
+			rule := map[string]interface{}{"rule": r.Rule}
+			if len(r.Message) > 0 {
+				rule["message"] = r.Message
+			}
+			if len(r.MessageExpression) > 0 {
+				rule["messageExpression"] = r.MessageExpression
+			}
+			if len(r.Reason) > 0 {
+				rule["reason"] = string(r.Reason)
+			}
+			if len(r.FieldPath) > 0 {
+				rule["fieldPath"] = r.FieldPath
+			}
+			rules = append(rules, rule)
+		}
+		out.AddExtension("x-kubernetes-validations", rules)
+	}
+	if in.XPreserveUnknownFields != nil {
+		out.AddExtension("x-kubernetes-preserve-unknown-fields", *in.XPreserveUnknownFields)
+	}
+	if in.XEmbeddedResource {
+		out.AddExtension("x-kubernetes-embedded-resource", true)
+	}
+	if in.XIntOrString {
+		out.AddExtension("x-kubernetes-int-or-string", true)
+	}
+	if in.XListType != nil {
+		out.AddExtension("x-kubernetes-list-type", *in.XListType)
+	}
+	if len(in.XListMapKeys) > 0 {
+		out.AddExtension("x-kubernetes-list-map-keys", in.XListMapKeys)
+	}
+	if in.XMapType != nil {
+		out.AddExtension("x-kubernetes-map-type", *in.XMapType)
+	}
+	if in.XImmutable {
+		out.AddExtension("x-kubernetes-immutable", true)
+	}
+
+	return out
+}
+
+func convertSlice(in []apiextensions.JSONSchemaProps, v2 bool) []spec.Schema {
+	if in == nil {
+		return nil
+	}
+	out := make([]spec.Schema, 0, len(in))
+	for i := range in {
+		out = append(out, *convertJSONSchemaProps(&in[i], v2))
+	}
+	return out
+}
+
+func convertMap(in map[string]apiextensions.JSONSchemaProps, v2 bool) map[string]spec.Schema {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]spec.Schema, len(in))
+	for k, v := range in {
+		out[k] = *convertJSONSchemaProps(&v, v2)
+	}
+	return out
+}
+
+func convertSchemaOrBool(in *apiextensions.JSONSchemaPropsOrBool, v2 bool) *spec.SchemaOrBool {
+	if in == nil {
+		return nil
+	}
+	return &spec.SchemaOrBool{
+		Allows: in.Allows,
+		Schema: convertJSONSchemaProps(in.Schema, v2),
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+func TestConvertJSONSchemaProps(t *testing.T) {
+	minimum := float64(1)
+	example := apiextensions.JSON("a")
+	preserveUnknownFields := true
+	listType, mapType := "map", "atomic"
+	tests := []struct {
+		name     string
+		in       *apiextensions.JSONSchemaProps
+		v2       bool
+		expected string
+	}{
+		{
+			name:     "nil",
+			in:       nil,
+			expected: `null`,
+		},
+		{
+			name: "scalars",
+			in: &apiextensions.JSONSchemaProps{
+				Type:        "integer",
+				Format:      "int32",
+				Description: "replicas",
+				Minimum:     &minimum,
+				Enum:        []apiextensions.JSON{float64(1), float64(2)},
+			},
+			expected: `{"description":"replicas","type":"integer","format":"int32","minimum":1,"enum":[1,2]}`,
+		},
+		{
+			name: "nested",
+			in: &apiextensions.JSONSchemaProps{
+				Type:     "object",
+				Required: []string{"spec"},
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"spec": {
+						Type: "object",
+						AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{
+							Schema: &apiextensions.JSONSchemaProps{Type: "string", Example: &example},
+						},
+					},
+					"list": {
+						Type:  "array",
+						Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{Type: "string"}},
+					},
+				},
+			},
+			expected: `{"required":["spec"],"type":"object","properties":{"list":{"type":"array","items":{"type":"string"}},"spec":{"type":"object","additionalProperties":{"type":"string","example":"a"}}}}`,
+		},
+		{
+			name: "oneOf v3",
+			in: &apiextensions.JSONSchemaProps{
+				OneOf: []apiextensions.JSONSchemaProps{{Type: "string"}, {Type: "integer"}},
+				Not:   &apiextensions.JSONSchemaProps{Type: "boolean"},
+			},
+			expected: `{"oneOf":[{"type":"string"},{"type":"integer"}],"not":{"type":"boolean"}}`,
+		},
+		{
+			name: "oneOf v2",
+			in: &apiextensions.JSONSchemaProps{
+				OneOf: []apiextensions.JSONSchemaProps{{Type: "string"}, {Type: "integer"}},
+				Not:   &apiextensions.JSONSchemaProps{Type: "boolean"},
+			},
+			v2:       true,
+			expected: `{}`,
+		},
+		{
+			name: "validation rules",
+			in: &apiextensions.JSONSchemaProps{
+				Type: "object",
+				XValidations: apiextensions.ValidationRules{
+					{Rule: "self.a > 0"},
+					{Rule: "self.b > 0", Message: "b must be positive"},
+					{Rule: "self.c > 0", MessageExpression: "'c is ' + string(self.c)", Reason: apiextensions.FieldValueForbidden, FieldPath: ".c"},
+				},
+			},
+			expected: `{"type":"object","x-kubernetes-validations":[{"rule":"self.a > 0"},{"message":"b must be positive","rule":"self.b > 0"},{"fieldPath":".c","messageExpression":"'c is ' + string(self.c)","reason":"FieldValueForbidden","rule":"self.c > 0"}]}`,
+		},
+		{
+			name: "vendor extensions",
+			in: &apiextensions.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"port":     {XIntOrString: true},
+					"template": {Type: "object", XEmbeddedResource: true, XPreserveUnknownFields: &preserveUnknownFields},
+				},
+			},
+			v2:       true,
+			expected: `{"type":"object","properties":{"port":{"x-kubernetes-int-or-string":true},"template":{"type":"object","x-kubernetes-embedded-resource":true,"x-kubernetes-preserve-unknown-fields":true}}}`,
+		},
+		{
+			name: "list and map types",
+			in: &apiextensions.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"ports":    {Type: "array", XListType: &listType, XListMapKeys: []string{"port", "protocol"}},
+					"selector": {Type: "object", XMapType: &mapType},
+				},
+			},
+			expected: `{"type":"object","properties":{"ports":{"type":"array","x-kubernetes-list-type":"map","x-kubernetes-list-map-keys":["port","protocol"]},"selector":{"type":"object","x-kubernetes-map-type":"atomic"}}}`,
+		},
+		{
+			name: "immutable values",
+			in: &apiextensions.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"storageClass": {Type: "string", XImmutable: true},
+				},
+			},
+			expected: `{"type":"object","properties":{"storageClass":{"type":"string","x-kubernetes-immutable":true}}}`,
+		},
+	}
+	for _, tc := range tests {
+		out, err := json.Marshal(convertJSONSchemaProps(tc.in, tc.v2))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		var actual, expected interface{}
+		if err := json.Unmarshal(out, &actual); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal([]byte(tc.expected), &expected); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.expected, out)
+		}
+	}
+}
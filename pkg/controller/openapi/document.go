@@ -0,0 +1,274 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-openapi/spec"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// buildSwagger returns the OpenAPI v2 document with the definitions and operations of the given versions.
+func buildSwagger(specs []*versionSpec) *spec.Swagger {
+	swagger := &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Swagger: "2.0",
+			Info: &spec.Info{
+				InfoProps: spec.InfoProps{
+					Title:   "Custom Resources",
+					Version: "v1",
+				},
+			},
+			Paths:       &spec.Paths{Paths: map[string]spec.PathItem{}},
+			Definitions: spec.Definitions{},
+		},
+	}
+	for _, s := range specs {
+		for name, def := range s.definitionsV2 {
+			swagger.Definitions[name] = def
+		}
+		for _, op := range s.operations {
+			item := swagger.Paths.Paths[op.path]
+			setPathOperation(&item, op.method, buildOperationV2(op))
+			swagger.Paths.Paths[op.path] = item
+		}
+	}
+	return swagger
+}
+
+func setPathOperation(item *spec.PathItem, method string, op *spec.Operation) {
+	switch method {
+	case "get":
+		item.Get = op
+	case "put":
+		item.Put = op
+	case "post":
+		item.Post = op
+	case "delete":
+		item.Delete = op
+	case "patch":
+		item.Patch = op
+	}
+}
+
+func buildOperationV2(op operation) *spec.Operation {
+	out := &spec.Operation{
+		OperationProps: spec.OperationProps{
+			ID:          op.id,
+			Description: op.description,
+			Consumes:    op.consumes,
+			Produces:    produces,
+			Schemes:     []string{"https"},
+			Responses: &spec.Responses{
+				ResponsesProps: spec.ResponsesProps{StatusCodeResponses: map[int]spec.Response{}},
+			},
+		},
+	}
+	out.AddExtension("x-kubernetes-action", op.action)
+	out.AddExtension("x-kubernetes-group-version-kind", gvkExtension(op.gvk.GroupVersion(), op.gvk.Kind))
+	for _, p := range op.parameters {
+		param := spec.Parameter{
+			ParamProps: spec.ParamProps{
+				Name:        p.name,
+				In:          p.in,
+				Description: p.description,
+				Required:    p.in == "path",
+			},
+			SimpleSchema: spec.SimpleSchema{Type: p.typ},
+		}
+		out.Parameters = append(out.Parameters, param)
+	}
+	if len(op.body) > 0 {
+		out.Parameters = append(out.Parameters, spec.Parameter{
+			ParamProps: spec.ParamProps{
+				Name:     "body",
+				In:       "body",
+				Required: op.body != objectDefinition,
+				Schema:   schemaV2(op.body),
+			},
+		})
+	}
+	for code, def := range op.responses {
+		out.Responses.StatusCodeResponses[code] = spec.Response{
+			ResponseProps: spec.ResponseProps{
+				Description: http.StatusText(code),
+				Schema:      schemaV2(def),
+			},
+		}
+	}
+	return out
+}
+
+func schemaV2(definition string) *spec.Schema {
+	if definition == objectDefinition {
+		s := &spec.Schema{}
+		s.Type = spec.StringOrArray{"object"}
+		return s
+	}
+	return spec.RefSchema(definitionRef(definition, true))
+}
+
+// openAPIV3 is an OpenAPI v3 document. go-openapi/spec only supports OpenAPI v2, so the parts
+// used by custom resources are defined here.
+type openAPIV3 struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       *spec.Info            `json:"info"`
+	Paths      map[string]pathItemV3 `json:"paths"`
+	Components componentsV3          `json:"components"`
+}
+
+type componentsV3 struct {
+	Schemas map[string]spec.Schema `json:"schemas"`
+}
+
+type pathItemV3 struct {
+	Get    *operationV3 `json:"get,omitempty"`
+	Put    *operationV3 `json:"put,omitempty"`
+	Post   *operationV3 `json:"post,omitempty"`
+	Delete *operationV3 `json:"delete,omitempty"`
+	Patch  *operationV3 `json:"patch,omitempty"`
+}
+
+type operationV3 struct {
+	OperationID string                `json:"operationId"`
+	Description string                `json:"description,omitempty"`
+	Parameters  []parameterV3         `json:"parameters,omitempty"`
+	RequestBody *requestBodyV3        `json:"requestBody,omitempty"`
+	Responses   map[string]responseV3 `json:"responses"`
+	Action      string                `json:"x-kubernetes-action,omitempty"`
+	// GroupVersionKind is the kind of the operation, which is a map of group, version and kind.
+	GroupVersionKind map[string]interface{} `json:"x-kubernetes-group-version-kind,omitempty"`
+}
+
+type parameterV3 struct {
+	Name        string       `json:"name"`
+	In          string       `json:"in"`
+	Description string       `json:"description,omitempty"`
+	Required    bool         `json:"required,omitempty"`
+	Schema      *spec.Schema `json:"schema"`
+}
+
+type requestBodyV3 struct {
+	Content  map[string]mediaTypeV3 `json:"content"`
+	Required bool                   `json:"required,omitempty"`
+}
+
+type responseV3 struct {
+	Description string                 `json:"description"`
+	Content     map[string]mediaTypeV3 `json:"content,omitempty"`
+}
+
+type mediaTypeV3 struct {
+	Schema *spec.Schema `json:"schema"`
+}
+
+// buildOpenAPIV3 returns the OpenAPI v3 document of the given group version.
+func buildOpenAPIV3(gv schema.GroupVersion, specs []*versionSpec) *openAPIV3 {
+	doc := &openAPIV3{
+		OpenAPI: "3.0.0",
+		Info: &spec.Info{
+			InfoProps: spec.InfoProps{
+				Title:   "Custom Resources",
+				Version: gv.Version,
+			},
+		},
+		Paths:      map[string]pathItemV3{},
+		Components: componentsV3{Schemas: map[string]spec.Schema{}},
+	}
+	for _, s := range specs {
+		if s.groupVersion != gv {
+			continue
+		}
+		for name, def := range s.definitionsV3 {
+			doc.Components.Schemas[name] = def
+		}
+		for _, op := range s.operations {
+			item := doc.Paths[op.path]
+			out := buildOperationV3(op)
+			switch op.method {
+			case "get":
+				item.Get = out
+			case "put":
+				item.Put = out
+			case "post":
+				item.Post = out
+			case "delete":
+				item.Delete = out
+			case "patch":
+				item.Patch = out
+			}
+			doc.Paths[op.path] = item
+		}
+	}
+	return doc
+}
+
+func buildOperationV3(op operation) *operationV3 {
+	out := &operationV3{
+		OperationID:      op.id,
+		Description:      op.description,
+		Responses:        map[string]responseV3{},
+		Action:           op.action,
+		GroupVersionKind: gvkExtension(op.gvk.GroupVersion(), op.gvk.Kind),
+	}
+	for _, p := range op.parameters {
+		s := &spec.Schema{}
+		s.Type = spec.StringOrArray{p.typ}
+		out.Parameters = append(out.Parameters, parameterV3{
+			Name:        p.name,
+			In:          p.in,
+			Description: p.description,
+			Required:    p.in == "path",
+			Schema:      s,
+		})
+	}
+	if len(op.body) > 0 {
+		consumes := op.consumes
+		if len(consumes) == 0 {
+			consumes = produces
+		}
+		out.RequestBody = &requestBodyV3{
+			Content:  contentV3(consumes, op.body),
+			Required: op.body != objectDefinition,
+		}
+	}
+	for code, def := range op.responses {
+		out.Responses[strconv.Itoa(code)] = responseV3{
+			Description: http.StatusText(code),
+			Content:     contentV3(produces, def),
+		}
+	}
+	return out
+}
+
+func contentV3(mediaTypes []string, definition string) map[string]mediaTypeV3 {
+	var s *spec.Schema
+	if definition == objectDefinition {
+		s = &spec.Schema{}
+		s.Type = spec.StringOrArray{"object"}
+	} else {
+		s = spec.RefSchema(definitionRef(definition, false))
+	}
+	content := make(map[string]mediaTypeV3, len(mediaTypes))
+	for _, mediaType := range mediaTypes {
+		content[mediaType] = mediaTypeV3{Schema: s}
+	}
+	return content
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"github.com/go-openapi/spec"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+// jsonSchemaDraft4 is the JSON Schema version of the published schemas. It is the version which the
+// validation schemas of CRDs follow.
+const jsonSchemaDraft4 = "http://json-schema.org/draft-04/schema#"
+
+// buildJSONSchema returns the standalone JSON Schema of the objects of the given version of the CRD,
+// with the given schema, which may be nil. The vendor extensions are dropped, as generic JSON Schema
+// validators do not understand them, but integer-or-string values are expressed by anyOf.
+// apiVersion and kind are restricted to the values of the version.
+func buildJSONSchema(crd *apiextensions.CustomResourceDefinition, gv schema.GroupVersion, openAPIV3Schema *apiextensions.JSONSchemaProps) *spec.Schema {
+	var s *spec.Schema
+	if openAPIV3Schema != nil {
+		s = convertJSONSchemaProps(openAPIV3Schema, false)
+		toJSONSchema(s)
+	} else {
+		s = &spec.Schema{}
+		s.Type = spec.StringOrArray{"object"}
+	}
+	s.Schema = jsonSchemaDraft4
+
+	if s.Properties == nil {
+		s.Properties = map[string]spec.Schema{}
+	}
+	addTypeMetaProperties(s)
+	apiVersion, kind := s.Properties["apiVersion"], s.Properties["kind"]
+	apiVersion.Enum = []interface{}{gv.String()}
+	kind.Enum = []interface{}{crd.Status.AcceptedNames.Kind}
+	s.Properties["apiVersion"], s.Properties["kind"] = apiVersion, kind
+	if _, ok := s.Properties["metadata"]; !ok {
+		s.Properties["metadata"] = *spec.MapProperty(nil).WithDescription("Standard object's metadata. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata")
+	}
+	// the required properties may be shared with the schema of the CRD
+	s.Required = append([]string{}, s.Required...)
+	for _, required := range []string{"apiVersion", "kind"} {
+		if !hasString(s.Required, required) {
+			s.Required = append(s.Required, required)
+		}
+	}
+	return s
+}
+
+// toJSONSchema drops the vendor extensions of s and its nested schemas and replaces
+// x-kubernetes-int-or-string by the equivalent anyOf.
+func toJSONSchema(s *spec.Schema) {
+	if s == nil {
+		return
+	}
+	if intOrString, _ := s.Extensions["x-kubernetes-int-or-string"].(bool); intOrString {
+		alternatives := []spec.Schema{{}, {}}
+		alternatives[0].Type = spec.StringOrArray{"integer"}
+		alternatives[1].Type = spec.StringOrArray{"string"}
+		if len(s.AnyOf) == 0 {
+			s.AnyOf = alternatives
+		} else {
+			s.AllOf = append(s.AllOf, spec.Schema{SchemaProps: spec.SchemaProps{AnyOf: alternatives}})
+		}
+	}
+	s.Extensions = nil
+
+	all := func(schemas []spec.Schema) {
+		for i := range schemas {
+			toJSONSchema(&schemas[i])
+		}
+	}
+	each := func(schemas map[string]spec.Schema) {
+		for k, nested := range schemas {
+			toJSONSchema(&nested)
+			schemas[k] = nested
+		}
+	}
+	if s.Items != nil {
+		toJSONSchema(s.Items.Schema)
+		all(s.Items.Schemas)
+	}
+	all(s.AllOf)
+	all(s.OneOf)
+	all(s.AnyOf)
+	toJSONSchema(s.Not)
+	each(s.Properties)
+	each(s.PatternProperties)
+	each(s.Definitions)
+	if s.AdditionalProperties != nil {
+		toJSONSchema(s.AdditionalProperties.Schema)
+	}
+	if s.AdditionalItems != nil {
+		toJSONSchema(s.AdditionalItems.Schema)
+	}
+	for k, dependency := range s.Dependencies {
+		toJSONSchema(dependency.Schema)
+		s.Dependencies[k] = dependency
+	}
+}
+
+func hasString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+func TestBuildJSONSchema(t *testing.T) {
+	preserveUnknownFields := true
+	crd := newCRD("crontabs.stable.example.com", "stable.example.com", "crontabs", "CronTab", apiextensions.NamespaceScoped, true, apiextensions.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true})
+	openAPIV3Schema := &apiextensions.JSONSchemaProps{
+		Type:     "object",
+		Required: []string{"spec"},
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"port":     {XIntOrString: true},
+					"template": {Type: "object", XPreserveUnknownFields: &preserveUnknownFields},
+				},
+				XValidations: apiextensions.ValidationRules{{Rule: "true"}},
+			},
+		},
+	}
+
+	s := buildJSONSchema(crd, schema.GroupVersion{Group: "stable.example.com", Version: "v1"}, openAPIV3Schema)
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if doc["$schema"] != jsonSchemaDraft4 {
+		t.Errorf("unexpected $schema %v", doc["$schema"])
+	}
+	if expected := []interface{}{"spec", "apiVersion", "kind"}; !reflect.DeepEqual(doc["required"], expected) {
+		t.Errorf("expected required %v, got %v", expected, doc["required"])
+	}
+	if !reflect.DeepEqual(openAPIV3Schema.Required, []string{"spec"}) {
+		t.Errorf("expected the schema of the CRD to be unchanged, got required %v", openAPIV3Schema.Required)
+	}
+	properties := doc["properties"].(map[string]interface{})
+	if enum := properties["apiVersion"].(map[string]interface{})["enum"]; !reflect.DeepEqual(enum, []interface{}{"stable.example.com/v1"}) {
+		t.Errorf("unexpected apiVersion enum %v", enum)
+	}
+	if enum := properties["kind"].(map[string]interface{})["enum"]; !reflect.DeepEqual(enum, []interface{}{"CronTab"}) {
+		t.Errorf("unexpected kind enum %v", enum)
+	}
+
+	spec := properties["spec"].(map[string]interface{})
+	if _, found := spec["x-kubernetes-validations"]; found {
+		t.Errorf("expected the vendor extensions to be dropped, got %v", spec)
+	}
+	specProperties := spec["properties"].(map[string]interface{})
+	expectedPort := map[string]interface{}{"anyOf": []interface{}{map[string]interface{}{"type": "integer"}, map[string]interface{}{"type": "string"}}}
+	if port := specProperties["port"]; !reflect.DeepEqual(port, expectedPort) {
+		t.Errorf("expected port %v, got %v", expectedPort, port)
+	}
+	if template := specProperties["template"]; !reflect.DeepEqual(template, map[string]interface{}{"type": "object"}) {
+		t.Errorf("unexpected template %v", template)
+	}
+}
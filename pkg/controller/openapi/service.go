@@ -0,0 +1,378 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-openapi/spec"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// V2Path is the path of the OpenAPI v2 document.
+	V2Path = "/openapi/v2"
+	// V3Path is the path of the index of the OpenAPI v3 documents, which are served per group version below it.
+	V3Path = "/openapi/v3"
+	// JSONSchemaPath is the path of the index of the JSON Schemas of the custom resources, which are
+	// served per resource below it, e.g. at /openapi/jsonschema/apis/stable.example.com/v1/crontabs.
+	JSONSchemaPath = "/openapi/jsonschema"
+)
+
+// Service serves the OpenAPI documents and the JSON Schemas of the custom resources.
+type Service struct {
+	lock sync.RWMutex
+	v2   []byte
+	// v3 maps the paths of group versions below V3Path, e.g. apis/stable.example.com/v1, to their documents.
+	v3      map[string][]byte
+	v3Index []byte
+	// jsonSchemas maps the paths of resources below JSONSchemaPath, e.g.
+	// apis/stable.example.com/v1/crontabs, to their JSON Schemas.
+	jsonSchemas     map[string][]byte
+	jsonSchemaIndex []byte
+
+	// updateLock serializes the updates of crds and of the documents merged from them.
+	updateLock sync.Mutex
+	// crds maps the names of the published CRDs to the encoded parts of the documents contributed
+	// by their served versions, such that the documents are merged without encoding the other
+	// CRDs again when a CRD changes.
+	crds map[string]*encodedCRD
+}
+
+// encodedCRD is the JSON encoding of the parts of the documents contributed by the served versions
+// of a CRD.
+type encodedCRD struct {
+	// v2Definitions and v2Paths are the definitions and path items of the OpenAPI v2 document.
+	v2Definitions map[string]json.RawMessage
+	v2Paths       map[string]json.RawMessage
+	// v3 maps the paths of the group versions of the CRD to their parts of the OpenAPI v3 documents.
+	v3 map[string]*encodedV3
+	// jsonSchemas maps the paths of the resources of the CRD to their JSON Schemas.
+	jsonSchemas map[string][]byte
+}
+
+// encodedV3 is the JSON encoding of the schemas and path items of an OpenAPI v3 document.
+type encodedV3 struct {
+	groupVersion schema.GroupVersion
+	schemas      map[string]json.RawMessage
+	paths        map[string]json.RawMessage
+}
+
+// NewService returns a Service without any custom resources.
+func NewService() *Service {
+	s := &Service{crds: map[string]*encodedCRD{}}
+	if err := s.updateCRD("", nil); err != nil {
+		// the empty documents can always be marshalled
+		panic(err)
+	}
+	return s
+}
+
+// v3Index is the index of the OpenAPI v3 documents, and of the JSON Schemas.
+type v3Index struct {
+	Paths map[string]v3IndexEntry `json:"paths"`
+}
+
+type v3IndexEntry struct {
+	ServerRelativeURL string `json:"serverRelativeURL"`
+}
+
+// mergedSwagger is the OpenAPI v2 document merged from the encoded parts of the CRDs. It is
+// encoded like the spec.Swagger returned by buildSwagger.
+type mergedSwagger struct {
+	Swagger     string                     `json:"swagger"`
+	Info        *spec.Info                 `json:"info"`
+	Paths       map[string]json.RawMessage `json:"paths"`
+	Definitions map[string]json.RawMessage `json:"definitions"`
+}
+
+// mergedOpenAPIV3 is an OpenAPI v3 document merged from the encoded parts of the CRDs. It is
+// encoded like the openAPIV3 returned by buildOpenAPIV3.
+type mergedOpenAPIV3 struct {
+	OpenAPI    string                     `json:"openapi"`
+	Info       *spec.Info                 `json:"info"`
+	Paths      map[string]json.RawMessage `json:"paths"`
+	Components mergedComponentsV3         `json:"components"`
+}
+
+type mergedComponentsV3 struct {
+	Schemas map[string]json.RawMessage `json:"schemas"`
+}
+
+func groupVersionPath(gv schema.GroupVersion) string {
+	return "apis/" + gv.Group + "/" + gv.Version
+}
+
+// encodeCRD returns the encoded parts of the documents with the given versions of a CRD.
+func encodeCRD(specs []*versionSpec) (*encodedCRD, error) {
+	ret := &encodedCRD{v3: map[string]*encodedV3{}, jsonSchemas: make(map[string][]byte, len(specs))}
+
+	swagger := buildSwagger(specs)
+	var err error
+	if ret.v2Definitions, err = encodeDefinitions(swagger.Definitions); err != nil {
+		return nil, err
+	}
+	if ret.v2Paths, err = encodePathItems(swagger.Paths.Paths); err != nil {
+		return nil, err
+	}
+
+	for _, spec := range specs {
+		path := groupVersionPath(spec.groupVersion)
+		if _, found := ret.v3[path]; !found {
+			doc := buildOpenAPIV3(spec.groupVersion, specs)
+			v3 := &encodedV3{groupVersion: spec.groupVersion}
+			if v3.schemas, err = encodeDefinitions(doc.Components.Schemas); err != nil {
+				return nil, err
+			}
+			if v3.paths, err = encodePathItemsV3(doc.Paths); err != nil {
+				return nil, err
+			}
+			ret.v3[path] = v3
+		}
+
+		doc, err := json.Marshal(spec.jsonSchema)
+		if err != nil {
+			return nil, err
+		}
+		ret.jsonSchemas[path+"/"+spec.resource] = doc
+	}
+	return ret, nil
+}
+
+func encodeDefinitions(definitions map[string]spec.Schema) (map[string]json.RawMessage, error) {
+	ret := make(map[string]json.RawMessage, len(definitions))
+	for name, def := range definitions {
+		data, err := json.Marshal(def)
+		if err != nil {
+			return nil, err
+		}
+		ret[name] = data
+	}
+	return ret, nil
+}
+
+func encodePathItems(items map[string]spec.PathItem) (map[string]json.RawMessage, error) {
+	ret := make(map[string]json.RawMessage, len(items))
+	for path, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		ret[path] = data
+	}
+	return ret, nil
+}
+
+func encodePathItemsV3(items map[string]pathItemV3) (map[string]json.RawMessage, error) {
+	ret := make(map[string]json.RawMessage, len(items))
+	for path, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		ret[path] = data
+	}
+	return ret, nil
+}
+
+// updateCRD replaces the versions of the CRD with the given name in the served documents by the
+// given versions, removing the CRD if there are none. Only the versions of the CRD are encoded, the
+// documents are merged from the encoded parts of all CRDs. Only the OpenAPI v3 documents of the group
+// versions of the CRD are merged again.
+func (s *Service) updateCRD(name string, specs []*versionSpec) error {
+	var crd *encodedCRD
+	if len(specs) > 0 {
+		var err error
+		if crd, err = encodeCRD(specs); err != nil {
+			return err
+		}
+	}
+
+	s.updateLock.Lock()
+	defer s.updateLock.Unlock()
+
+	old := s.crds[name]
+	if crd == nil {
+		delete(s.crds, name)
+	} else {
+		s.crds[name] = crd
+	}
+
+	// later CRDs in this order win if parts have the same name, like when the documents were built from
+	// all versions
+	names := make([]string, 0, len(s.crds))
+	for name := range s.crds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	v2, err := s.mergeV2(names)
+	if err != nil {
+		return err
+	}
+
+	// the served maps are only replaced while holding updateLock, never changed
+	v3 := make(map[string][]byte, len(s.v3))
+	for path, doc := range s.v3 {
+		v3[path] = doc
+	}
+	jsonSchemas := make(map[string][]byte, len(s.jsonSchemas))
+	for path, doc := range s.jsonSchemas {
+		jsonSchemas[path] = doc
+	}
+
+	changedGroupVersions := map[string]bool{}
+	if old != nil {
+		for path := range old.v3 {
+			changedGroupVersions[path] = true
+		}
+		for path := range old.jsonSchemas {
+			delete(jsonSchemas, path)
+		}
+	}
+	if crd != nil {
+		for path := range crd.v3 {
+			changedGroupVersions[path] = true
+		}
+		for path, doc := range crd.jsonSchemas {
+			jsonSchemas[path] = doc
+		}
+	}
+	for path := range changedGroupVersions {
+		doc, err := s.mergeV3(path, names)
+		if err != nil {
+			return err
+		}
+		if doc == nil {
+			delete(v3, path)
+		} else {
+			v3[path] = doc
+		}
+	}
+
+	index := v3Index{Paths: make(map[string]v3IndexEntry, len(v3))}
+	for path := range v3 {
+		index.Paths[path] = v3IndexEntry{ServerRelativeURL: V3Path + "/" + path}
+	}
+	v3IndexDoc, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+
+	index = v3Index{Paths: make(map[string]v3IndexEntry, len(jsonSchemas))}
+	for path := range jsonSchemas {
+		index.Paths[path] = v3IndexEntry{ServerRelativeURL: JSONSchemaPath + "/" + path}
+	}
+	jsonSchemaIndex, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.v2 = v2
+	s.v3 = v3
+	s.v3Index = v3IndexDoc
+	s.jsonSchemas = jsonSchemas
+	s.jsonSchemaIndex = jsonSchemaIndex
+	return nil
+}
+
+// mergeV2 returns the OpenAPI v2 document merged from the CRDs with the given names.
+func (s *Service) mergeV2(names []string) ([]byte, error) {
+	doc := mergedSwagger{
+		Paths:       map[string]json.RawMessage{},
+		Definitions: map[string]json.RawMessage{},
+	}
+	swagger := buildSwagger(nil)
+	doc.Swagger, doc.Info = swagger.Swagger, swagger.Info
+	for _, name := range names {
+		crd := s.crds[name]
+		for path, item := range crd.v2Paths {
+			doc.Paths[path] = item
+		}
+		for definition, def := range crd.v2Definitions {
+			doc.Definitions[definition] = def
+		}
+	}
+	return json.Marshal(doc)
+}
+
+// mergeV3 returns the OpenAPI v3 document of the group version with the given path merged from the
+// CRDs with the given names, or nil if none of them has the group version.
+func (s *Service) mergeV3(path string, names []string) ([]byte, error) {
+	var doc *mergedOpenAPIV3
+	var gv schema.GroupVersion
+	for _, name := range names {
+		v3, found := s.crds[name].v3[path]
+		if !found {
+			continue
+		}
+		if doc == nil {
+			gv = v3.groupVersion
+			doc = &mergedOpenAPIV3{
+				Paths:      map[string]json.RawMessage{},
+				Components: mergedComponentsV3{Schemas: map[string]json.RawMessage{}},
+			}
+		}
+		for p, item := range v3.paths {
+			doc.Paths[p] = item
+		}
+		for definition, def := range v3.schemas {
+			doc.Components.Schemas[definition] = def
+		}
+	}
+	if doc == nil {
+		return nil, nil
+	}
+	empty := buildOpenAPIV3(gv, nil)
+	doc.OpenAPI, doc.Info = empty.OpenAPI, empty.Info
+	return json.Marshal(doc)
+}
+
+// ServeHTTP serves the OpenAPI v2 document, the OpenAPI v3 index and documents, and the JSON Schema
+// index and schemas.
+func (s *Service) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.lock.RLock()
+	var doc []byte
+	switch path := strings.TrimSuffix(req.URL.Path, "/"); {
+	case path == V2Path:
+		doc = s.v2
+	case path == V3Path:
+		doc = s.v3Index
+	case strings.HasPrefix(path, V3Path+"/"):
+		doc = s.v3[strings.TrimPrefix(path, V3Path+"/")]
+	case path == JSONSchemaPath:
+		doc = s.jsonSchemaIndex
+	case strings.HasPrefix(path, JSONSchemaPath+"/"):
+		doc = s.jsonSchemas[strings.TrimPrefix(path, JSONSchemaPath+"/")]
+	}
+	s.lock.RUnlock()
+
+	if doc == nil {
+		http.NotFound(w, req)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(doc)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// serve returns the body served at path.
+func serve(service *Service, path string) string {
+	w := httptest.NewRecorder()
+	service.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+	return w.Body.String()
+}
+
+func TestUpdateCRD(t *testing.T) {
+	v1 := apiextensions.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true}
+	v2 := apiextensions.CustomResourceDefinitionVersion{Name: "v2", Served: true}
+	crds := []*apiextensions.CustomResourceDefinition{
+		newCRD("clusters.infra.example.com", "infra.example.com", "clusters", "Cluster", apiextensions.ClusterScoped, true, v1),
+		newCRD("crontabs.stable.example.com", "stable.example.com", "crontabs", "CronTab", apiextensions.NamespaceScoped, true, v1, v2),
+		newCRD("jobs.stable.example.com", "stable.example.com", "jobs", "Job", apiextensions.NamespaceScoped, true, v1),
+	}
+	specs := map[string][]*versionSpec{}
+	for _, crd := range crds {
+		s, err := buildSpecs(crd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		specs[crd.Name] = s
+	}
+
+	// expect checks that the documents are the ones built from all versions of the CRDs
+	// with the given names.
+	expect := func(step string, service *Service, names ...string) {
+		var all []*versionSpec
+		for _, name := range names {
+			all = append(all, specs[name]...)
+		}
+		v2, err := json.Marshal(buildSwagger(all))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := serve(service, V2Path); actual != string(v2) {
+			t.Errorf("%s: expected OpenAPI v2 document %s, got %s", step, v2, actual)
+		}
+
+		groupVersions := map[schema.GroupVersion]bool{}
+		for _, s := range all {
+			groupVersions[s.groupVersion] = true
+		}
+		service.lock.RLock()
+		served := len(service.v3)
+		service.lock.RUnlock()
+		if served != len(groupVersions) {
+			t.Errorf("%s: expected %d OpenAPI v3 documents, got %d", step, len(groupVersions), served)
+		}
+		for gv := range groupVersions {
+			v3, err := json.Marshal(buildOpenAPIV3(gv, all))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual := serve(service, V3Path+"/"+groupVersionPath(gv)); actual != string(v3) {
+				t.Errorf("%s: expected OpenAPI v3 document %s of %v, got %s", step, v3, gv, actual)
+			}
+		}
+	}
+
+	service := NewService()
+	expect("empty", service)
+
+	for _, crd := range []string{crds[2].Name, crds[0].Name, crds[1].Name} {
+		if err := service.updateCRD(crd, specs[crd]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expect("added", service, crds[0].Name, crds[1].Name, crds[2].Name)
+
+	// the served versions of an updated CRD replace its old ones
+	updated := crds[1].DeepCopy()
+	updated.Spec.Versions[1].Served = false
+	updatedSpecs, err := buildSpecs(updated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := service.updateCRD(updated.Name, updatedSpecs); err != nil {
+		t.Fatal(err)
+	}
+	specs[updated.Name] = updatedSpecs
+	expect("updated", service, crds[0].Name, crds[1].Name, crds[2].Name)
+	if doc := serve(service, JSONSchemaPath+"/apis/stable.example.com/v2/crontabs"); doc != "404 page not found\n" {
+		t.Errorf("expected no JSON Schema of the version which is not served anymore, got %s", doc)
+	}
+
+	if err := service.updateCRD(crds[1].Name, nil); err != nil {
+		t.Fatal(err)
+	}
+	expect("removed", service, crds[0].Name, crds[2].Name)
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+)
+
+type fakeRegistry struct {
+	evaluators map[schema.GroupResource]Evaluator
+}
+
+func (r *fakeRegistry) Add(e Evaluator) {
+	r.evaluators[e.GroupResource()] = e
+}
+
+func (r *fakeRegistry) Remove(gr schema.GroupResource) {
+	delete(r.evaluators, gr)
+}
+
+// fakeLister lists custom resources by namespace.
+type fakeLister struct {
+	items map[string][]unstructured.Unstructured
+}
+
+func (l *fakeLister) NewList() runtime.Object {
+	return &unstructured.UnstructuredList{}
+}
+
+func (l *fakeLister) List(ctx genericapirequest.Context, options *metainternalversion.ListOptions) (runtime.Object, error) {
+	namespace, _ := genericapirequest.NamespaceFrom(ctx)
+	return &unstructured.UnstructuredList{Items: l.items[namespace]}, nil
+}
+
+type fakeCRListerGetter struct {
+	lister *fakeLister
+}
+
+func (g *fakeCRListerGetter) GetCustomResourceLister(crd *apiextensions.CustomResourceDefinition) (rest.Lister, error) {
+	return g.lister, nil
+}
+
+func newCRD(plural string, established bool) *apiextensions.CustomResourceDefinition {
+	crd := &apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "foos.example.com"},
+		Spec:       apiextensions.CustomResourceDefinitionSpec{Group: "example.com"},
+		Status: apiextensions.CustomResourceDefinitionStatus{
+			AcceptedNames: apiextensions.CustomResourceDefinitionNames{Plural: plural},
+		},
+	}
+	if established {
+		crd.Status.Conditions = []apiextensions.CustomResourceDefinitionCondition{{Type: apiextensions.Established, Status: apiextensions.ConditionTrue}}
+	}
+	return crd
+}
+
+func TestSync(t *testing.T) {
+	crdIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	registry := &fakeRegistry{evaluators: map[schema.GroupResource]Evaluator{}}
+	c := &Controller{
+		registry:       registry,
+		crListerGetter: &fakeCRListerGetter{lister: &fakeLister{}},
+		crdLister:      listers.NewCustomResourceDefinitionLister(crdIndexer),
+		registered:     map[string]schema.GroupResource{},
+	}
+
+	foos := schema.GroupResource{Group: "example.com", Resource: "foos"}
+	bars := schema.GroupResource{Group: "example.com", Resource: "bars"}
+	steps := []struct {
+		name string
+		// crd is the CRD in the lister, nil if it is deleted
+		crd      *apiextensions.CustomResourceDefinition
+		expected []schema.GroupResource
+	}{
+		{name: "not established", crd: newCRD("foos", false)},
+		{name: "established", crd: newCRD("foos", true), expected: []schema.GroupResource{foos}},
+		{name: "unchanged", crd: newCRD("foos", true), expected: []schema.GroupResource{foos}},
+		{name: "renamed", crd: newCRD("bars", true), expected: []schema.GroupResource{bars}},
+		{name: "no longer established", crd: newCRD("bars", false)},
+		{name: "established again", crd: newCRD("bars", true), expected: []schema.GroupResource{bars}},
+		{name: "deleted"},
+	}
+	for _, step := range steps {
+		crdIndexer.Replace(nil, "")
+		if step.crd != nil {
+			crdIndexer.Add(step.crd)
+		}
+		if err := c.sync("foos.example.com"); err != nil {
+			t.Fatalf("%s: unexpected error: %v", step.name, err)
+		}
+		var registered []schema.GroupResource
+		for gr := range registry.evaluators {
+			registered = append(registered, gr)
+		}
+		if !reflect.DeepEqual(registered, step.expected) {
+			t.Errorf("%s: expected evaluators for %v, got %v", step.name, step.expected, registered)
+		}
+	}
+}
+
+func TestObjectCountEvaluator(t *testing.T) {
+	lister := &fakeLister{items: map[string][]unstructured.Unstructured{
+		"ns1": {{}, {}},
+		"ns2": {{}},
+	}}
+	e := newObjectCountEvaluator(newCRD("foos", true), &fakeCRListerGetter{lister: lister})
+
+	name := corev1.ResourceName("count/foos.example.com")
+	if got := e.MatchingResources([]corev1.ResourceName{"pods", name}); !reflect.DeepEqual(got, []corev1.ResourceName{name}) {
+		t.Errorf("expected matching resources %v, got %v", []corev1.ResourceName{name}, got)
+	}
+	if got := e.MatchingResources([]corev1.ResourceName{"count/bars.example.com"}); len(got) != 0 {
+		t.Errorf("expected no matching resources, got %v", got)
+	}
+
+	usage, err := e.Usage(&unstructured.Unstructured{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q := usage[name]; q.Value() != 1 {
+		t.Errorf("expected usage 1, got %v", usage)
+	}
+
+	for namespace, expected := range map[string]int64{"ns1": 2, "ns2": 1, "ns3": 0} {
+		usage, err := e.UsageStats(namespace)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if q := usage[name]; q.Value() != expected {
+			t.Errorf("%s: expected usage %d, got %v", namespace, expected, usage)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+// Registry is a registry of quota evaluators, e.g. the one shared by the ResourceQuota admission
+// plugin and the resource quota controller of kube-apiserver.
+type Registry interface {
+	// Add registers the evaluator, replacing any evaluator of the same group resource.
+	Add(e Evaluator)
+	// Remove unregisters the evaluator of the given group resource.
+	Remove(gr schema.GroupResource)
+}
+
+// Evaluator computes the quota usage of the objects of a group resource.
+type Evaluator interface {
+	// GroupResource returns the group resource whose objects are evaluated.
+	GroupResource() schema.GroupResource
+	// MatchingResources returns the subset of the given quota resource names the evaluator computes.
+	MatchingResources(names []corev1.ResourceName) []corev1.ResourceName
+	// Usage returns the usage of the given object.
+	Usage(obj runtime.Object) (corev1.ResourceList, error)
+	// UsageStats returns the usage of all objects in the given namespace.
+	UsageStats(namespace string) (corev1.ResourceList, error)
+}
+
+// CRListerGetter knows how to get a lister for the custom resources of a CRD.
+type CRListerGetter interface {
+	GetCustomResourceLister(crd *apiextensions.CustomResourceDefinition) (rest.Lister, error)
+}
+
+// ObjectCountName returns the quota resource name of the object count of the given group resource,
+// i.e. count/<resource>.<group>.
+func ObjectCountName(gr schema.GroupResource) corev1.ResourceName {
+	return corev1.ResourceName(fmt.Sprintf("count/%s", gr.String()))
+}
+
+// objectCountEvaluator counts the custom resources of a CRD.
+type objectCountEvaluator struct {
+	groupResource schema.GroupResource
+	name          corev1.ResourceName
+
+	crd            *apiextensions.CustomResourceDefinition
+	crListerGetter CRListerGetter
+}
+
+var _ Evaluator = &objectCountEvaluator{}
+
+func newObjectCountEvaluator(crd *apiextensions.CustomResourceDefinition, crListerGetter CRListerGetter) *objectCountEvaluator {
+	gr := schema.GroupResource{Group: crd.Spec.Group, Resource: crd.Status.AcceptedNames.Plural}
+	return &objectCountEvaluator{
+		groupResource:  gr,
+		name:           ObjectCountName(gr),
+		crd:            crd,
+		crListerGetter: crListerGetter,
+	}
+}
+
+func (e *objectCountEvaluator) GroupResource() schema.GroupResource {
+	return e.groupResource
+}
+
+func (e *objectCountEvaluator) MatchingResources(names []corev1.ResourceName) []corev1.ResourceName {
+	for _, name := range names {
+		if name == e.name {
+			return []corev1.ResourceName{e.name}
+		}
+	}
+	return nil
+}
+
+func (e *objectCountEvaluator) Usage(obj runtime.Object) (corev1.ResourceList, error) {
+	return corev1.ResourceList{e.name: *resource.NewQuantity(1, resource.DecimalSI)}, nil
+}
+
+func (e *objectCountEvaluator) UsageStats(namespace string) (corev1.ResourceList, error) {
+	lister, err := e.crListerGetter.GetCustomResourceLister(e.crd)
+	if err != nil {
+		return nil, err
+	}
+	list, err := lister.List(genericapirequest.WithNamespace(genericapirequest.NewContext(), namespace), nil)
+	if err != nil {
+		return nil, err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	return corev1.ResourceList{e.name: *resource.NewQuantity(int64(len(items)), resource.DecimalSI)}, nil
+}
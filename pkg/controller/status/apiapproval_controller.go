@@ -0,0 +1,176 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	client "k8s.io/apiextensions-apiserver/pkg/client/clientset/internalclientset/typed/apiextensions/internalversion"
+	informers "k8s.io/apiextensions-apiserver/pkg/client/informers/internalversion/apiextensions/internalversion"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+)
+
+// APIApprovalConditionController sets the KubernetesAPIApprovalPolicyConformant condition of
+// CustomResourceDefinitions in groups owned by the Kubernetes project to whether they carry a valid
+// api-approved.kubernetes.io annotation.
+type APIApprovalConditionController struct {
+	crdClient client.CustomResourceDefinitionsGetter
+
+	crdLister listers.CustomResourceDefinitionLister
+	crdSynced cache.InformerSynced
+
+	// To allow injection for testing.
+	syncFn func(key string) error
+
+	queue workqueue.RateLimitingInterface
+}
+
+func NewAPIApprovalConditionController(
+	crdInformer informers.CustomResourceDefinitionInformer,
+	crdClient client.CustomResourceDefinitionsGetter,
+) *APIApprovalConditionController {
+	c := &APIApprovalConditionController{
+		crdClient: crdClient,
+		crdLister: crdInformer.Lister(),
+		crdSynced: crdInformer.Informer().HasSynced,
+		queue:     workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "CustomResourceDefinition-APIApprovalConditionController"),
+	}
+
+	crdInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addCustomResourceDefinition,
+		UpdateFunc: c.updateCustomResourceDefinition,
+	})
+
+	c.syncFn = c.sync
+
+	return c
+}
+
+// calculateAPIApprovalCondition returns the KubernetesAPIApprovalPolicyConformant condition of the
+// given CustomResourceDefinition, or nil if it must not have one.
+func calculateAPIApprovalCondition(crd *apiextensions.CustomResourceDefinition) *apiextensions.CustomResourceDefinitionCondition {
+	if !apiextensions.IsProtectedGroup(crd.Spec.Group) {
+		return nil
+	}
+	condition := &apiextensions.CustomResourceDefinitionCondition{
+		Type:               apiextensions.KubernetesAPIApprovalPolicyConformant,
+		Status:             apiextensions.ConditionFalse,
+		ObservedGeneration: crd.Generation,
+	}
+	approval := crd.Annotations[apiextensions.KubernetesAPIApprovedAnnotation]
+	switch apiextensions.GetAPIApprovalState(crd.Annotations) {
+	case apiextensions.APIApprovalMissing:
+		condition.Reason = "MissingAnnotation"
+		condition.Message = fmt.Sprintf("protected groups must have the approval annotation %q", apiextensions.KubernetesAPIApprovedAnnotation)
+	case apiextensions.APIApprovalBypassed:
+		condition.Reason = "UnapprovedAnnotation"
+		condition.Message = fmt.Sprintf("not approved: %q", approval)
+	case apiextensions.APIApprovalInvalid:
+		condition.Reason = "InvalidAnnotation"
+		condition.Message = fmt.Sprintf("the approval annotation %q must be a URL or start with \"unapproved\", not %q", apiextensions.KubernetesAPIApprovedAnnotation, approval)
+	default:
+		condition.Status = apiextensions.ConditionTrue
+		condition.Reason = "ApprovedAnnotation"
+		condition.Message = fmt.Sprintf("approved in %s", approval)
+	}
+	return condition
+}
+
+func (c *APIApprovalConditionController) sync(key string) error {
+	inCustomResourceDefinition, err := c.crdLister.Get(key)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	crd := inCustomResourceDefinition.DeepCopy()
+	if !updateCondition(crd, apiextensions.KubernetesAPIApprovalPolicyConformant, calculateAPIApprovalCondition(inCustomResourceDefinition)) {
+		return nil
+	}
+	_, err = c.crdClient.CustomResourceDefinitions().UpdateStatus(crd)
+	return err
+}
+
+func (c *APIApprovalConditionController) Run(stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	glog.Infof("Starting APIApprovalConditionController")
+	defer glog.Infof("Shutting down APIApprovalConditionController")
+
+	if !cache.WaitForCacheSync(stopCh, c.crdSynced) {
+		return
+	}
+
+	go wait.Until(c.runWorker, time.Second, stopCh)
+
+	<-stopCh
+}
+
+func (c *APIApprovalConditionController) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+// processNextWorkItem deals with one key off the queue.  It returns false when it's time to quit.
+func (c *APIApprovalConditionController) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	err := c.syncFn(key.(string))
+	if err == nil {
+		c.queue.Forget(key)
+		return true
+	}
+
+	utilruntime.HandleError(fmt.Errorf("%v failed with: %v", key, err))
+	c.queue.AddRateLimited(key)
+
+	return true
+}
+
+func (c *APIApprovalConditionController) addCustomResourceDefinition(obj interface{}) {
+	castObj := obj.(*apiextensions.CustomResourceDefinition)
+	glog.V(4).Infof("Adding %s", castObj.Name)
+	c.queue.Add(castObj.Name)
+}
+
+func (c *APIApprovalConditionController) updateCustomResourceDefinition(oldObj, newObj interface{}) {
+	oldCRD := oldObj.(*apiextensions.CustomResourceDefinition)
+	newCRD := newObj.(*apiextensions.CustomResourceDefinition)
+	// the condition only depends on the annotation and observes the generation
+	if oldCRD.Annotations[apiextensions.KubernetesAPIApprovedAnnotation] == newCRD.Annotations[apiextensions.KubernetesAPIApprovedAnnotation] && oldCRD.Generation == newCRD.Generation {
+		return
+	}
+	glog.V(4).Infof("Updating %s", newCRD.Name)
+	c.queue.Add(newCRD.Name)
+}
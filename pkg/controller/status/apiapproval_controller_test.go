@@ -0,0 +1,146 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/client/clientset/internalclientset/fake"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+)
+
+func newAPIApprovalCRD(group string, annotations map[string]string, conditions ...apiextensions.CustomResourceDefinitionCondition) *apiextensions.CustomResourceDefinition {
+	return &apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "foos." + group, Annotations: annotations, Generation: 2},
+		Spec:       apiextensions.CustomResourceDefinitionSpec{Group: group},
+		Status:     apiextensions.CustomResourceDefinitionStatus{Conditions: conditions},
+	}
+}
+
+func TestAPIApprovalSync(t *testing.T) {
+	approved := map[string]string{apiextensions.KubernetesAPIApprovedAnnotation: "https://github.com/kubernetes/kubernetes/pull/78458"}
+	tests := []struct {
+		name string
+		crd  *apiextensions.CustomResourceDefinition
+		// expectedStatus and expectedReason describe the condition after the update, an empty status
+		// means no condition
+		expectedStatus apiextensions.ConditionStatus
+		expectedReason string
+		expectedUpdate bool
+	}{
+		{
+			name: "unprotected group",
+			crd:  newAPIApprovalCRD("example.com", nil),
+		},
+		{
+			name:           "missing annotation",
+			crd:            newAPIApprovalCRD("sigs.k8s.io", nil),
+			expectedStatus: apiextensions.ConditionFalse,
+			expectedReason: "MissingAnnotation",
+			expectedUpdate: true,
+		},
+		{
+			name:           "unapproved",
+			crd:            newAPIApprovalCRD("kubernetes.io", map[string]string{apiextensions.KubernetesAPIApprovedAnnotation: "unapproved, experimental"}),
+			expectedStatus: apiextensions.ConditionFalse,
+			expectedReason: "UnapprovedAnnotation",
+			expectedUpdate: true,
+		},
+		{
+			name:           "invalid annotation",
+			crd:            newAPIApprovalCRD("x.k8s.io", map[string]string{apiextensions.KubernetesAPIApprovedAnnotation: "yes"}),
+			expectedStatus: apiextensions.ConditionFalse,
+			expectedReason: "InvalidAnnotation",
+			expectedUpdate: true,
+		},
+		{
+			name:           "approved",
+			crd:            newAPIApprovalCRD("storage.k8s.io", approved),
+			expectedStatus: apiextensions.ConditionTrue,
+			expectedReason: "ApprovedAnnotation",
+			expectedUpdate: true,
+		},
+		{
+			name: "unchanged",
+			crd: newAPIApprovalCRD("storage.k8s.io", approved, apiextensions.CustomResourceDefinitionCondition{
+				Type:               apiextensions.KubernetesAPIApprovalPolicyConformant,
+				Status:             apiextensions.ConditionTrue,
+				Reason:             "ApprovedAnnotation",
+				Message:            "approved in https://github.com/kubernetes/kubernetes/pull/78458",
+				ObservedGeneration: 2,
+			}),
+		},
+		{
+			name: "outdated generation",
+			crd: newAPIApprovalCRD("storage.k8s.io", approved, apiextensions.CustomResourceDefinitionCondition{
+				Type:               apiextensions.KubernetesAPIApprovalPolicyConformant,
+				Status:             apiextensions.ConditionTrue,
+				Reason:             "ApprovedAnnotation",
+				Message:            "approved in https://github.com/kubernetes/kubernetes/pull/78458",
+				ObservedGeneration: 1,
+			}),
+			expectedStatus: apiextensions.ConditionTrue,
+			expectedReason: "ApprovedAnnotation",
+			expectedUpdate: true,
+		},
+	}
+
+	for _, tc := range tests {
+		crdIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		crdIndexer.Add(tc.crd)
+		client := fake.NewSimpleClientset(tc.crd)
+		c := &APIApprovalConditionController{
+			crdClient: client.Apiextensions(),
+			crdLister: listers.NewCustomResourceDefinitionLister(crdIndexer),
+		}
+
+		if err := c.sync(tc.crd.Name); err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+
+		var updated *apiextensions.CustomResourceDefinition
+		for _, action := range client.Actions() {
+			if update, ok := action.(core.UpdateAction); ok && update.GetSubresource() == "status" {
+				updated = update.GetObject().(*apiextensions.CustomResourceDefinition)
+			}
+		}
+		if !tc.expectedUpdate {
+			if updated != nil {
+				t.Errorf("%s: unexpected update: %#v", tc.name, updated.Status)
+			}
+			continue
+		}
+		if updated == nil {
+			t.Errorf("%s: expected an update", tc.name)
+			continue
+		}
+		condition := apiextensions.FindCRDCondition(updated, apiextensions.KubernetesAPIApprovalPolicyConformant)
+		if condition == nil {
+			t.Errorf("%s: expected a condition", tc.name)
+			continue
+		}
+		if condition.Status != tc.expectedStatus || condition.Reason != tc.expectedReason || condition.ObservedGeneration != 2 {
+			t.Errorf("%s: unexpected condition %#v", tc.name, condition)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	client "k8s.io/apiextensions-apiserver/pkg/client/clientset/internalclientset/typed/apiextensions/internalversion"
+	informers "k8s.io/apiextensions-apiserver/pkg/client/informers/internalversion/apiextensions/internalversion"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+)
+
+// ConversionReviewConditionController sets the ConversionReviewNegotiated condition of
+// CustomResourceDefinitions to the ConversionReview version negotiated with their conversion
+// webhook, and the ConversionDegraded condition to whether the webhook is degraded. It is notified
+// of negotiated versions and degradation by the converters of the custom resources.
+type ConversionReviewConditionController struct {
+	crdClient client.CustomResourceDefinitionsGetter
+
+	crdLister listers.CustomResourceDefinitionLister
+	crdSynced cache.InformerSynced
+
+	lock sync.Mutex
+	// negotiated contains the last negotiated version per CustomResourceDefinition name
+	negotiated map[string]negotiatedReviewVersion
+	// degraded contains the last recorded degradation per CustomResourceDefinition name
+	degraded map[string]webhookDegradation
+
+	// To allow injection for testing.
+	syncFn func(key string) error
+
+	queue workqueue.RateLimitingInterface
+}
+
+type negotiatedReviewVersion struct {
+	uid     types.UID
+	version string
+}
+
+type webhookDegradation struct {
+	uid      types.UID
+	degraded bool
+	message  string
+}
+
+func NewConversionReviewConditionController(
+	crdInformer informers.CustomResourceDefinitionInformer,
+	crdClient client.CustomResourceDefinitionsGetter,
+) *ConversionReviewConditionController {
+	c := &ConversionReviewConditionController{
+		crdClient:  crdClient,
+		crdLister:  crdInformer.Lister(),
+		crdSynced:  crdInformer.Informer().HasSynced,
+		negotiated: map[string]negotiatedReviewVersion{},
+		degraded:   map[string]webhookDegradation{},
+		queue:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "CustomResourceDefinition-ConversionReviewConditionController"),
+	}
+
+	crdInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: c.updateCustomResourceDefinition,
+		DeleteFunc: c.deleteCustomResourceDefinition,
+	})
+
+	c.syncFn = c.sync
+
+	return c
+}
+
+// RecordConversionReviewVersion records the ConversionReview version negotiated with the
+// conversion webhook of the given CustomResourceDefinition.
+func (c *ConversionReviewConditionController) RecordConversionReviewVersion(name string, uid types.UID, version string) {
+	c.lock.Lock()
+	c.negotiated[name] = negotiatedReviewVersion{uid: uid, version: version}
+	c.lock.Unlock()
+
+	c.queue.Add(name)
+}
+
+// RecordConversionDegraded records whether the conversion webhook of the given
+// CustomResourceDefinition is degraded.
+func (c *ConversionReviewConditionController) RecordConversionDegraded(name string, uid types.UID, degraded bool, message string) {
+	c.lock.Lock()
+	c.degraded[name] = webhookDegradation{uid: uid, degraded: degraded, message: message}
+	c.lock.Unlock()
+
+	c.queue.Add(name)
+}
+
+// calculateConversionReviewCondition returns the ConversionReviewNegotiated condition of the given
+// CustomResourceDefinition, or nil if it must not have one.
+func calculateConversionReviewCondition(crd *apiextensions.CustomResourceDefinition, negotiated negotiatedReviewVersion) *apiextensions.CustomResourceDefinitionCondition {
+	if crd.Spec.Conversion == nil || crd.Spec.Conversion.Strategy != apiextensions.WebhookConverter {
+		return nil
+	}
+	if negotiated.uid != crd.UID {
+		// nothing was negotiated yet, keep what we have
+		return apiextensions.FindCRDCondition(crd, apiextensions.ConversionReviewNegotiated)
+	}
+	return &apiextensions.CustomResourceDefinitionCondition{
+		Type:               apiextensions.ConversionReviewNegotiated,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             "Negotiated",
+		Message:            fmt.Sprintf("the conversion webhook accepts ConversionReview %s", negotiated.version),
+		ObservedGeneration: crd.Generation,
+	}
+}
+
+// calculateConversionDegradedCondition returns the ConversionDegraded condition of the given
+// CustomResourceDefinition, or nil if it must not have one.
+func calculateConversionDegradedCondition(crd *apiextensions.CustomResourceDefinition, degradation webhookDegradation) *apiextensions.CustomResourceDefinitionCondition {
+	if crd.Spec.Conversion == nil || crd.Spec.Conversion.Strategy != apiextensions.WebhookConverter {
+		return nil
+	}
+	if degradation.uid != crd.UID {
+		// nothing was recorded yet, keep what we have
+		return apiextensions.FindCRDCondition(crd, apiextensions.ConversionDegraded)
+	}
+	if !degradation.degraded {
+		return &apiextensions.CustomResourceDefinitionCondition{
+			Type:               apiextensions.ConversionDegraded,
+			Status:             apiextensions.ConditionFalse,
+			Reason:             "WebhookResponding",
+			Message:            "the conversion webhook responds",
+			ObservedGeneration: crd.Generation,
+		}
+	}
+	return &apiextensions.CustomResourceDefinitionCondition{
+		Type:               apiextensions.ConversionDegraded,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             "WebhookFailing",
+		Message:            degradation.message,
+		ObservedGeneration: crd.Generation,
+	}
+}
+
+// updateCondition sets the condition of the given type in crd, or removes it if condition is nil.
+// It returns true if crd was changed.
+func updateCondition(crd *apiextensions.CustomResourceDefinition, conditionType apiextensions.CustomResourceDefinitionConditionType, condition *apiextensions.CustomResourceDefinitionCondition) bool {
+	existing := apiextensions.FindCRDCondition(crd, conditionType)
+	if (condition == nil && existing == nil) || (condition != nil && apiextensions.IsCRDConditionEquivalent(condition, existing)) {
+		return false
+	}
+	if condition == nil {
+		apiextensions.RemoveCRDCondition(crd, conditionType)
+	} else {
+		apiextensions.SetCRDCondition(crd, *condition)
+	}
+	return true
+}
+
+func (c *ConversionReviewConditionController) sync(key string) error {
+	inCustomResourceDefinition, err := c.crdLister.Get(key)
+	if apierrors.IsNotFound(err) {
+		c.lock.Lock()
+		delete(c.negotiated, key)
+		delete(c.degraded, key)
+		c.lock.Unlock()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	negotiated := c.negotiated[key]
+	degradation := c.degraded[key]
+	c.lock.Unlock()
+
+	crd := inCustomResourceDefinition.DeepCopy()
+	reviewChanged := updateCondition(crd, apiextensions.ConversionReviewNegotiated, calculateConversionReviewCondition(inCustomResourceDefinition, negotiated))
+	degradedChanged := updateCondition(crd, apiextensions.ConversionDegraded, calculateConversionDegradedCondition(inCustomResourceDefinition, degradation))
+	if !reviewChanged && !degradedChanged {
+		return nil
+	}
+	_, err = c.crdClient.CustomResourceDefinitions().UpdateStatus(crd)
+	return err
+}
+
+func (c *ConversionReviewConditionController) Run(stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	glog.Infof("Starting ConversionReviewConditionController")
+	defer glog.Infof("Shutting down ConversionReviewConditionController")
+
+	if !cache.WaitForCacheSync(stopCh, c.crdSynced) {
+		return
+	}
+
+	go wait.Until(c.runWorker, time.Second, stopCh)
+
+	<-stopCh
+}
+
+func (c *ConversionReviewConditionController) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+// processNextWorkItem deals with one key off the queue.  It returns false when it's time to quit.
+func (c *ConversionReviewConditionController) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	err := c.syncFn(key.(string))
+	if err == nil {
+		c.queue.Forget(key)
+		return true
+	}
+
+	utilruntime.HandleError(fmt.Errorf("%v failed with: %v", key, err))
+	c.queue.AddRateLimited(key)
+
+	return true
+}
+
+func (c *ConversionReviewConditionController) updateCustomResourceDefinition(obj, _ interface{}) {
+	castObj := obj.(*apiextensions.CustomResourceDefinition)
+	// only CustomResourceDefinitions which stopped using a conversion webhook have to be updated
+	if apiextensions.FindCRDCondition(castObj, apiextensions.ConversionReviewNegotiated) == nil && apiextensions.FindCRDCondition(castObj, apiextensions.ConversionDegraded) == nil {
+		return
+	}
+	if castObj.Spec.Conversion != nil && castObj.Spec.Conversion.Strategy == apiextensions.WebhookConverter {
+		return
+	}
+	glog.V(4).Infof("Updating %s", castObj.Name)
+	c.queue.Add(castObj.Name)
+}
+
+func (c *ConversionReviewConditionController) deleteCustomResourceDefinition(obj interface{}) {
+	castObj, ok := obj.(*apiextensions.CustomResourceDefinition)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			glog.Errorf("Couldn't get object from tombstone %#v", obj)
+			return
+		}
+		castObj, ok = tombstone.Obj.(*apiextensions.CustomResourceDefinition)
+		if !ok {
+			glog.Errorf("Tombstone contained object that is not expected %#v", obj)
+			return
+		}
+	}
+	glog.V(4).Infof("Deleting %q", castObj.Name)
+	c.queue.Add(castObj.Name)
+}
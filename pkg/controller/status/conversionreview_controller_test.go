@@ -0,0 +1,241 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/client/clientset/internalclientset/fake"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+)
+
+func newConversionReviewCRD(strategy apiextensions.ConversionStrategyType, conditions ...apiextensions.CustomResourceDefinitionCondition) *apiextensions.CustomResourceDefinition {
+	return &apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "foos.example.com", UID: "uid"},
+		Spec: apiextensions.CustomResourceDefinitionSpec{
+			Conversion: &apiextensions.CustomResourceConversion{Strategy: strategy},
+		},
+		Status: apiextensions.CustomResourceDefinitionStatus{Conditions: conditions},
+	}
+}
+
+func negotiatedCondition(version string) apiextensions.CustomResourceDefinitionCondition {
+	return apiextensions.CustomResourceDefinitionCondition{
+		Type:    apiextensions.ConversionReviewNegotiated,
+		Status:  apiextensions.ConditionTrue,
+		Reason:  "Negotiated",
+		Message: "the conversion webhook accepts ConversionReview " + version,
+	}
+}
+
+func TestConversionReviewSync(t *testing.T) {
+	tests := []struct {
+		name       string
+		crd        *apiextensions.CustomResourceDefinition
+		negotiated *negotiatedReviewVersion
+		// expected is the expected condition after the update, nil if the condition is removed
+		expected       *apiextensions.CustomResourceDefinitionCondition
+		expectedUpdate bool
+	}{
+		{
+			name:           "negotiated",
+			crd:            newConversionReviewCRD(apiextensions.WebhookConverter),
+			negotiated:     &negotiatedReviewVersion{uid: "uid", version: "v1"},
+			expected:       &apiextensions.CustomResourceDefinitionCondition{Type: apiextensions.ConversionReviewNegotiated, Status: apiextensions.ConditionTrue, Reason: "Negotiated", Message: "the conversion webhook accepts ConversionReview v1"},
+			expectedUpdate: true,
+		},
+		{
+			name:       "unchanged",
+			crd:        newConversionReviewCRD(apiextensions.WebhookConverter, negotiatedCondition("v1")),
+			negotiated: &negotiatedReviewVersion{uid: "uid", version: "v1"},
+		},
+		{
+			name:           "changed",
+			crd:            newConversionReviewCRD(apiextensions.WebhookConverter, negotiatedCondition("v1")),
+			negotiated:     &negotiatedReviewVersion{uid: "uid", version: "v1beta1"},
+			expected:       &apiextensions.CustomResourceDefinitionCondition{Type: apiextensions.ConversionReviewNegotiated, Status: apiextensions.ConditionTrue, Reason: "Negotiated", Message: "the conversion webhook accepts ConversionReview v1beta1"},
+			expectedUpdate: true,
+		},
+		{
+			name:       "negotiated for previous incarnation",
+			crd:        newConversionReviewCRD(apiextensions.WebhookConverter),
+			negotiated: &negotiatedReviewVersion{uid: "old", version: "v1"},
+		},
+		{
+			name:           "webhook removed",
+			crd:            newConversionReviewCRD(apiextensions.NoneConverter, negotiatedCondition("v1")),
+			negotiated:     &negotiatedReviewVersion{uid: "uid", version: "v1"},
+			expectedUpdate: true,
+		},
+	}
+
+	for _, tc := range tests {
+		crdIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		crdIndexer.Add(tc.crd)
+		client := fake.NewSimpleClientset(tc.crd)
+		c := &ConversionReviewConditionController{
+			crdClient:  client.Apiextensions(),
+			crdLister:  listers.NewCustomResourceDefinitionLister(crdIndexer),
+			negotiated: map[string]negotiatedReviewVersion{},
+			degraded:   map[string]webhookDegradation{},
+			queue:      workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		}
+		if tc.negotiated != nil {
+			c.RecordConversionReviewVersion(tc.crd.Name, tc.negotiated.uid, tc.negotiated.version)
+		}
+
+		if err := c.sync(tc.crd.Name); err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+
+		var updated *apiextensions.CustomResourceDefinition
+		for _, action := range client.Actions() {
+			if update, ok := action.(core.UpdateAction); ok && update.GetSubresource() == "status" {
+				updated = update.GetObject().(*apiextensions.CustomResourceDefinition)
+			}
+		}
+		if !tc.expectedUpdate {
+			if updated != nil {
+				t.Errorf("%s: unexpected update: %#v", tc.name, updated.Status)
+			}
+			continue
+		}
+		if updated == nil {
+			t.Errorf("%s: expected a status update", tc.name)
+			continue
+		}
+		actual := apiextensions.FindCRDCondition(updated, apiextensions.ConversionReviewNegotiated)
+		if tc.expected == nil {
+			if actual != nil {
+				t.Errorf("%s: expected condition to be removed, got %#v", tc.name, actual)
+			}
+			continue
+		}
+		if !apiextensions.IsCRDConditionEquivalent(tc.expected, actual) {
+			t.Errorf("%s: expected %#v, got %#v", tc.name, tc.expected, actual)
+		}
+	}
+}
+
+func TestConversionDegradedSync(t *testing.T) {
+	degradedCondition := apiextensions.CustomResourceDefinitionCondition{
+		Type:    apiextensions.ConversionDegraded,
+		Status:  apiextensions.ConditionTrue,
+		Reason:  "WebhookFailing",
+		Message: "the conversion webhook failed 5 times in a row: timeout",
+	}
+	respondingCondition := apiextensions.CustomResourceDefinitionCondition{
+		Type:    apiextensions.ConversionDegraded,
+		Status:  apiextensions.ConditionFalse,
+		Reason:  "WebhookResponding",
+		Message: "the conversion webhook responds",
+	}
+
+	tests := []struct {
+		name        string
+		crd         *apiextensions.CustomResourceDefinition
+		degradation *webhookDegradation
+		// expected is the expected condition after the update, nil if the condition is removed
+		expected       *apiextensions.CustomResourceDefinitionCondition
+		expectedUpdate bool
+	}{
+		{
+			name:           "degraded",
+			crd:            newConversionReviewCRD(apiextensions.WebhookConverter),
+			degradation:    &webhookDegradation{uid: "uid", degraded: true, message: degradedCondition.Message},
+			expected:       &degradedCondition,
+			expectedUpdate: true,
+		},
+		{
+			name:        "still degraded",
+			crd:         newConversionReviewCRD(apiextensions.WebhookConverter, degradedCondition),
+			degradation: &webhookDegradation{uid: "uid", degraded: true, message: degradedCondition.Message},
+		},
+		{
+			name:           "responding again",
+			crd:            newConversionReviewCRD(apiextensions.WebhookConverter, degradedCondition),
+			degradation:    &webhookDegradation{uid: "uid"},
+			expected:       &respondingCondition,
+			expectedUpdate: true,
+		},
+		{
+			name:        "degraded in previous incarnation",
+			crd:         newConversionReviewCRD(apiextensions.WebhookConverter),
+			degradation: &webhookDegradation{uid: "old", degraded: true},
+		},
+		{
+			name:           "webhook removed",
+			crd:            newConversionReviewCRD(apiextensions.NoneConverter, degradedCondition),
+			expectedUpdate: true,
+		},
+	}
+
+	for _, tc := range tests {
+		crdIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		crdIndexer.Add(tc.crd)
+		client := fake.NewSimpleClientset(tc.crd)
+		c := &ConversionReviewConditionController{
+			crdClient:  client.Apiextensions(),
+			crdLister:  listers.NewCustomResourceDefinitionLister(crdIndexer),
+			negotiated: map[string]negotiatedReviewVersion{},
+			degraded:   map[string]webhookDegradation{},
+			queue:      workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		}
+		if tc.degradation != nil {
+			c.RecordConversionDegraded(tc.crd.Name, tc.degradation.uid, tc.degradation.degraded, tc.degradation.message)
+		}
+
+		if err := c.sync(tc.crd.Name); err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+
+		var updated *apiextensions.CustomResourceDefinition
+		for _, action := range client.Actions() {
+			if update, ok := action.(core.UpdateAction); ok && update.GetSubresource() == "status" {
+				updated = update.GetObject().(*apiextensions.CustomResourceDefinition)
+			}
+		}
+		if !tc.expectedUpdate {
+			if updated != nil {
+				t.Errorf("%s: unexpected update: %#v", tc.name, updated.Status)
+			}
+			continue
+		}
+		if updated == nil {
+			t.Errorf("%s: expected a status update", tc.name)
+			continue
+		}
+		actual := apiextensions.FindCRDCondition(updated, apiextensions.ConversionDegraded)
+		if tc.expected == nil {
+			if actual != nil {
+				t.Errorf("%s: expected condition to be removed, got %#v", tc.name, actual)
+			}
+			continue
+		}
+		if !apiextensions.IsCRDConditionEquivalent(tc.expected, actual) {
+			t.Errorf("%s: expected %#v, got %#v", tc.name, tc.expected, actual)
+		}
+	}
+}
@@ -25,13 +25,14 @@ import (
 	"github.com/golang/glog"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/conversion"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 
@@ -47,6 +48,8 @@ var cloner = conversion.NewCloner()
 // This could eventually be lifted, but starting simple.
 type NamingConditionController struct {
 	crdClient client.CustomResourceDefinitionsGetter
+	// discoveryClient is used to find the names of built-in resources. It is optional.
+	discoveryClient discovery.ServerResourcesInterface
 
 	crdLister listers.CustomResourceDefinitionLister
 	crdSynced cache.InformerSynced
@@ -64,12 +67,14 @@ type NamingConditionController struct {
 func NewNamingConditionController(
 	crdInformer informers.CustomResourceDefinitionInformer,
 	crdClient client.CustomResourceDefinitionsGetter,
+	discoveryClient discovery.ServerResourcesInterface,
 ) *NamingConditionController {
 	c := &NamingConditionController{
-		crdClient: crdClient,
-		crdLister: crdInformer.Lister(),
-		crdSynced: crdInformer.Informer().HasSynced,
-		queue:     workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "CustomResourceDefinition-NamingConditionController"),
+		crdClient:       crdClient,
+		discoveryClient: discoveryClient,
+		crdLister:       crdInformer.Lister(),
+		crdSynced:       crdInformer.Informer().HasSynced,
+		queue:           workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "CustomResourceDefinition-NamingConditionController"),
 	}
 
 	informerIndexer := crdInformer.Informer().GetIndexer()
@@ -120,13 +125,94 @@ func (c *NamingConditionController) getAcceptedNamesForGroup(group string) (allR
 	return allResources, allKinds
 }
 
-func (c *NamingConditionController) calculateNamesAndConditions(in *apiextensions.CustomResourceDefinition) (apiextensions.CustomResourceDefinitionNames, apiextensions.CustomResourceDefinitionCondition, apiextensions.CustomResourceDefinitionCondition) {
+// getShortNameOwners returns the names which cannot be used as short names of a CRD in the given group,
+// mapped to the CRD using them. Short names are resolved by clients across groups, so they must not
+// collide with the short names of CRDs in other groups either.
+func (c *NamingConditionController) getShortNameOwners(group string) map[string]string {
+	owners := map[string]string{}
+
+	list, err := c.crdLister.List(labels.Everything())
+	if err != nil {
+		panic(err)
+	}
+
+	for _, curr := range list {
+		// for each item here, see if we have a mutation cache entry that is more recent
+		item := curr
+		obj, exists, err := c.crdMutationCache.GetByKey(curr.Name)
+		if exists && err == nil {
+			item = obj.(*apiextensions.CustomResourceDefinition)
+		}
+
+		names := item.Status.AcceptedNames.ShortNames
+		if item.Spec.Group == group {
+			names = append([]string{item.Status.AcceptedNames.Plural, item.Status.AcceptedNames.Singular}, names...)
+		}
+		for _, n := range names {
+			if len(n) > 0 {
+				owners[n] = item.Name
+			}
+		}
+	}
+
+	return owners
+}
+
+// getBuiltInResourceNames returns the names, singular names and short names of the resources served
+// by the API server which are not CRDs, mapped to the resource.
+func (c *NamingConditionController) getBuiltInResourceNames() (map[string]string, error) {
+	owners := map[string]string{}
+	if c.discoveryClient == nil {
+		return owners, nil
+	}
+
+	resourceLists, err := c.discoveryClient.ServerResources()
+	if err != nil {
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return nil, err
+		}
+		// the groups that failed are most likely served by unavailable aggregated API servers
+		glog.V(2).Infof("Ignoring groups that failed discovery: %v", err)
+	}
+
+	for _, resourceList := range resourceLists {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			utilruntime.HandleError(err)
+			continue
+		}
+		for _, resource := range resourceList.APIResources {
+			// skip subresources
+			if strings.Contains(resource.Name, "/") {
+				continue
+			}
+			owner := resource.Name
+			if len(gv.Group) > 0 {
+				owner = resource.Name + "." + gv.Group
+			}
+			// resources of CRDs are served too, but are not built-in
+			if _, err := c.crdLister.Get(resource.Name + "." + gv.Group); err == nil {
+				continue
+			}
+			for _, n := range append([]string{resource.Name, resource.SingularName}, resource.ShortNames...) {
+				if len(n) > 0 {
+					owners[n] = "built-in resource " + owner
+				}
+			}
+		}
+	}
+
+	return owners, nil
+}
+
+func (c *NamingConditionController) calculateNamesAndConditions(in *apiextensions.CustomResourceDefinition, builtInNames map[string]string) (apiextensions.CustomResourceDefinitionNames, apiextensions.CustomResourceDefinitionCondition, apiextensions.CustomResourceDefinitionCondition) {
 	// Get the names that have already been claimed
 	allResources, allKinds := c.getAcceptedNamesForGroup(in.Spec.Group)
 
 	namesAcceptedCondition := apiextensions.CustomResourceDefinitionCondition{
-		Type:   apiextensions.NamesAccepted,
-		Status: apiextensions.ConditionUnknown,
+		Type:               apiextensions.NamesAccepted,
+		Status:             apiextensions.ConditionUnknown,
+		ObservedGeneration: in.Generation,
 	}
 
 	requestedNames := in.Spec.Names
@@ -152,15 +238,17 @@ func (c *NamingConditionController) calculateNamesAndConditions(in *apiextension
 	if !reflect.DeepEqual(requestedNames.ShortNames, acceptedNames.ShortNames) {
 		errs := []error{}
 		existingShortNames := sets.NewString(acceptedNames.ShortNames...)
+		shortNameOwners := c.getShortNameOwners(in.Spec.Group)
 		for _, shortName := range requestedNames.ShortNames {
 			// if the shortname is already ours, then we're fine
 			if existingShortNames.Has(shortName) {
 				continue
 			}
-			if err := equalToAcceptedOrFresh(shortName, "", allResources); err != nil {
-				errs = append(errs, err)
+			if owner, found := shortNameOwners[shortName]; found {
+				errs = append(errs, fmt.Errorf("%q is already in use by %s", shortName, owner))
+			} else if owner, found := builtInNames[shortName]; found {
+				errs = append(errs, fmt.Errorf("%q is already in use by %s", shortName, owner))
 			}
-
 		}
 		if err := utilerrors.NewAggregate(errs); err != nil {
 			namesAcceptedCondition.Status = apiextensions.ConditionFalse
@@ -186,6 +274,9 @@ func (c *NamingConditionController) calculateNamesAndConditions(in *apiextension
 		newNames.ListKind = requestedNames.ListKind
 	}
 
+	// categories are shared between resources, so they never conflict
+	newNames.Categories = requestedNames.Categories
+
 	// if we haven't changed the condition, then our names must be good.
 	if namesAcceptedCondition.Status == apiextensions.ConditionUnknown {
 		namesAcceptedCondition.Status = apiextensions.ConditionTrue
@@ -195,24 +286,23 @@ func (c *NamingConditionController) calculateNamesAndConditions(in *apiextension
 
 	// set EstablishedCondition to true if all names are accepted. Never set it back to false.
 	establishedCondition := apiextensions.CustomResourceDefinitionCondition{
-		Type:               apiextensions.Established,
-		Status:             apiextensions.ConditionFalse,
-		Reason:             "NotAccepted",
-		Message:            "not all names are accepted",
-		LastTransitionTime: metav1.NewTime(time.Now()),
+		Type:    apiextensions.Established,
+		Status:  apiextensions.ConditionFalse,
+		Reason:  "NotAccepted",
+		Message: "not all names are accepted",
 	}
 	if old := apiextensions.FindCRDCondition(in, apiextensions.Established); old != nil {
 		establishedCondition = *old
 	}
 	if establishedCondition.Status != apiextensions.ConditionTrue && namesAcceptedCondition.Status == apiextensions.ConditionTrue {
 		establishedCondition = apiextensions.CustomResourceDefinitionCondition{
-			Type:               apiextensions.Established,
-			Status:             apiextensions.ConditionTrue,
-			Reason:             "InitialNamesAccepted",
-			Message:            "the initial names have been accepted",
-			LastTransitionTime: metav1.NewTime(time.Now()),
+			Type:    apiextensions.Established,
+			Status:  apiextensions.ConditionTrue,
+			Reason:  "InitialNamesAccepted",
+			Message: "the initial names have been accepted",
 		}
 	}
+	establishedCondition.ObservedGeneration = in.Generation
 
 	return newNames, namesAcceptedCondition, establishedCondition
 }
@@ -242,7 +332,15 @@ func (c *NamingConditionController) sync(key string) error {
 		return err
 	}
 
-	acceptedNames, namingCondition, establishedCondition := c.calculateNamesAndConditions(inCustomResourceDefinition)
+	// built-in resources are only looked up if new short names have to be checked
+	var builtInNames map[string]string
+	if !reflect.DeepEqual(inCustomResourceDefinition.Spec.Names.ShortNames, inCustomResourceDefinition.Status.AcceptedNames.ShortNames) {
+		if builtInNames, err = c.getBuiltInResourceNames(); err != nil {
+			return err
+		}
+	}
+
+	acceptedNames, namingCondition, establishedCondition := c.calculateNamesAndConditions(inCustomResourceDefinition, builtInNames)
 
 	// nothing to do if accepted names and NamesAccepted condition didn't change
 	if reflect.DeepEqual(inCustomResourceDefinition.Status.AcceptedNames, acceptedNames) &&
@@ -355,6 +453,8 @@ func (c *NamingConditionController) deleteCustomResourceDefinition(obj interface
 	c.enqueue(castObj)
 }
 
+// requeueAllOtherGroupCRDs requeues the other CRDs of the group of the given CRD, and the CRDs of
+// other groups that are waiting for short names, which might have been released by the given CRD.
 func (c *NamingConditionController) requeueAllOtherGroupCRDs(name string) error {
 	pluralGroup := strings.SplitN(name, ".", 2)
 	list, err := c.crdLister.List(labels.Everything())
@@ -362,7 +462,10 @@ func (c *NamingConditionController) requeueAllOtherGroupCRDs(name string) error
 		return err
 	}
 	for _, curr := range list {
-		if curr.Spec.Group == pluralGroup[1] && curr.Name != name {
+		if curr.Name == name {
+			continue
+		}
+		if curr.Spec.Group == pluralGroup[1] || !reflect.DeepEqual(curr.Spec.Names.ShortNames, curr.Status.AcceptedNames.ShortNames) {
 			c.queue.Add(curr.Name)
 		}
 	}
@@ -25,6 +25,8 @@ import (
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	core "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -67,6 +69,18 @@ func (b *crdBuilder) StatusNames(plural, singular, kind, listKind string, shortN
 	return b
 }
 
+func (b *crdBuilder) SpecCategories(categories ...string) *crdBuilder {
+	b.curr.Spec.Names.Categories = categories
+
+	return b
+}
+
+func (b *crdBuilder) StatusCategories(categories ...string) *crdBuilder {
+	b.curr.Status.AcceptedNames.Categories = categories
+
+	return b
+}
+
 func (b *crdBuilder) Condition(c apiextensions.CustomResourceDefinitionCondition) *crdBuilder {
 	b.curr.Status.Conditions = append(b.curr.Status.Conditions, c)
 
@@ -124,6 +138,7 @@ func TestSync(t *testing.T) {
 
 		in                            *apiextensions.CustomResourceDefinition
 		existing                      []*apiextensions.CustomResourceDefinition
+		builtInResources              []*metav1.APIResourceList
 		expectedNames                 apiextensions.CustomResourceDefinitionNames
 		expectedNameConflictCondition apiextensions.CustomResourceDefinitionCondition
 		expectedEstablishedCondition  apiextensions.CustomResourceDefinitionCondition
@@ -142,12 +157,56 @@ func TestSync(t *testing.T) {
 			name: "different groups",
 			in:   newCRD("alfa.bravo.com").SpecNames("alfa", "delta-singular", "echo-kind", "foxtrot-listkind", "golf-shortname-1", "hotel-shortname-2").NewOrDie(),
 			existing: []*apiextensions.CustomResourceDefinition{
-				newCRD("alfa.charlie.com").StatusNames("alfa", "delta-singular", "echo-kind", "foxtrot-listkind", "golf-shortname-1", "hotel-shortname-2").NewOrDie(),
+				newCRD("alfa.charlie.com").StatusNames("alfa", "delta-singular", "echo-kind", "foxtrot-listkind", "india-shortname").NewOrDie(),
 			},
 			expectedNames:                 names("alfa", "delta-singular", "echo-kind", "foxtrot-listkind", "golf-shortname-1", "hotel-shortname-2"),
 			expectedNameConflictCondition: acceptedCondition,
 			expectedEstablishedCondition:  establishedCondition,
 		},
+		{
+			name: "conflict on shortName across groups",
+			in:   newCRD("alfa.bravo.com").SpecNames("alfa", "delta-singular", "echo-kind", "foxtrot-listkind", "golf-shortname-1", "hotel-shortname-2").NewOrDie(),
+			existing: []*apiextensions.CustomResourceDefinition{
+				newCRD("alfa.charlie.com").StatusNames("alfa", "delta-singular", "echo-kind", "foxtrot-listkind", "hotel-shortname-2").NewOrDie(),
+			},
+			expectedNames:                 names("alfa", "delta-singular", "echo-kind", "foxtrot-listkind"),
+			expectedNameConflictCondition: nameConflictCondition("ShortNamesConflict", `"hotel-shortname-2" is already in use by alfa.charlie.com`),
+			expectedEstablishedCondition:  notEstablishedCondition,
+		},
+		{
+			name: "conflict on shortName with built-in resource",
+			in:   newCRD("alfa.bravo.com").SpecNames("alfa", "delta-singular", "echo-kind", "foxtrot-listkind", "golf-shortname-1", "po").NewOrDie(),
+			builtInResources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "v1",
+					APIResources: []metav1.APIResource{
+						{Name: "pods", SingularName: "pod", ShortNames: []string{"po"}},
+						{Name: "pods/status"},
+					},
+				},
+			},
+			expectedNames:                 names("alfa", "delta-singular", "echo-kind", "foxtrot-listkind"),
+			expectedNameConflictCondition: nameConflictCondition("ShortNamesConflict", `"po" is already in use by built-in resource pods`),
+			expectedEstablishedCondition:  notEstablishedCondition,
+		},
+		{
+			name: "no conflict with discovered custom resources",
+			in:   newCRD("alfa.bravo.com").SpecNames("alfa", "delta-singular", "echo-kind", "foxtrot-listkind", "golf-shortname-1").StatusNames("alfa", "delta-singular", "echo-kind", "foxtrot-listkind").NewOrDie(),
+			existing: []*apiextensions.CustomResourceDefinition{
+				newCRD("alfa.bravo.com").SpecNames("alfa", "delta-singular", "echo-kind", "foxtrot-listkind", "golf-shortname-1").StatusNames("alfa", "delta-singular", "echo-kind", "foxtrot-listkind").NewOrDie(),
+			},
+			builtInResources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "bravo.com/v1",
+					APIResources: []metav1.APIResource{
+						{Name: "alfa", SingularName: "delta-singular", ShortNames: []string{"golf-shortname-1"}},
+					},
+				},
+			},
+			expectedNames:                 names("alfa", "delta-singular", "echo-kind", "foxtrot-listkind", "golf-shortname-1"),
+			expectedNameConflictCondition: acceptedCondition,
+			expectedEstablishedCondition:  establishedCondition,
+		},
 		{
 			name: "conflict plural to singular",
 			in:   newCRD("alfa.bravo.com").SpecNames("alfa", "delta-singular", "echo-kind", "foxtrot-listkind", "golf-shortname-1", "hotel-shortname-2").NewOrDie(),
@@ -175,7 +234,7 @@ func TestSync(t *testing.T) {
 				newCRD("india.bravo.com").StatusNames("india", "indias", "", "", "hotel-shortname-2").NewOrDie(),
 			},
 			expectedNames:                 names("alfa", "delta-singular", "echo-kind", "foxtrot-listkind"),
-			expectedNameConflictCondition: nameConflictCondition("ShortNamesConflict", `"hotel-shortname-2" is already in use`),
+			expectedNameConflictCondition: nameConflictCondition("ShortNamesConflict", `"hotel-shortname-2" is already in use by india.bravo.com`),
 			expectedEstablishedCondition:  notEstablishedCondition,
 		},
 		{
@@ -310,6 +369,37 @@ func TestSync(t *testing.T) {
 			expectedNameConflictCondition: nameConflictCondition("PluralConflict", `"alfa" is already in use`),
 			expectedEstablishedCondition:  notEstablishedCondition,
 		},
+		{
+			name: "shared categories",
+			in:   newCRD("alfa.bravo.com").SpecNames("alfa", "delta-singular", "echo-kind", "foxtrot-listkind").SpecCategories("all", "bravo").NewOrDie(),
+			existing: []*apiextensions.CustomResourceDefinition{
+				newCRD("india.bravo.com").StatusNames("india", "indias", "", "").StatusCategories("all", "bravo").NewOrDie(),
+			},
+			expectedNames: apiextensions.CustomResourceDefinitionNames{
+				Plural:     "alfa",
+				Singular:   "delta-singular",
+				Kind:       "echo-kind",
+				ListKind:   "foxtrot-listkind",
+				Categories: []string{"all", "bravo"},
+			},
+			expectedNameConflictCondition: acceptedCondition,
+			expectedEstablishedCondition:  establishedCondition,
+		},
+		{
+			name: "categories accepted despite conflicts",
+			in:   newCRD("alfa.bravo.com").SpecNames("alfa", "delta-singular", "echo-kind", "foxtrot-listkind").SpecCategories("all").NewOrDie(),
+			existing: []*apiextensions.CustomResourceDefinition{
+				newCRD("india.bravo.com").StatusNames("india", "alfa", "", "").NewOrDie(),
+			},
+			expectedNames: apiextensions.CustomResourceDefinitionNames{
+				Singular:   "delta-singular",
+				Kind:       "echo-kind",
+				ListKind:   "foxtrot-listkind",
+				Categories: []string{"all"},
+			},
+			expectedNameConflictCondition: nameConflictCondition("PluralConflict", `"alfa" is already in use`),
+			expectedEstablishedCondition:  notEstablishedCondition,
+		},
 	}
 
 	for _, tc := range tests {
@@ -319,10 +409,15 @@ func TestSync(t *testing.T) {
 		}
 
 		c := NamingConditionController{
+			discoveryClient:  &fakediscovery.FakeDiscovery{Fake: &core.Fake{Resources: tc.builtInResources}},
 			crdLister:        listers.NewCustomResourceDefinitionLister(crdIndexer),
 			crdMutationCache: cache.NewIntegerResourceVersionMutationCache(crdIndexer, crdIndexer, 60*time.Second, false),
 		}
-		actualNames, actualNameConflictCondition, actualEstablishedCondition := c.calculateNamesAndConditions(tc.in)
+		builtInNames, err := c.getBuiltInResourceNames()
+		if err != nil {
+			t.Fatalf("%v unexpected error: %v", tc.name, err)
+		}
+		actualNames, actualNameConflictCondition, actualEstablishedCondition := c.calculateNamesAndConditions(tc.in, builtInNames)
 
 		if e, a := tc.expectedNames, actualNames; !reflect.DeepEqual(e, a) {
 			t.Errorf("%v expected %v, got %#v", tc.name, e, a)
@@ -0,0 +1,217 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	client "k8s.io/apiextensions-apiserver/pkg/client/clientset/internalclientset/typed/apiextensions/internalversion"
+	informers "k8s.io/apiextensions-apiserver/pkg/client/informers/internalversion/apiextensions/internalversion"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+)
+
+// PersistedVersionCounter knows how to count the custom resources of a CustomResourceDefinition by
+// the version they are persisted in.
+type PersistedVersionCounter interface {
+	// CountPersistedVersions returns the number of custom resources persisted in each version.
+	CountPersistedVersions(crd *apiextensions.CustomResourceDefinition) (map[string]int64, error)
+}
+
+// PersistedVersionController periodically counts the persisted custom resources of established
+// CustomResourceDefinitions per version and reports the counts in status.persistedVersions.
+type PersistedVersionController struct {
+	crdClient client.CustomResourceDefinitionsGetter
+	counter   PersistedVersionCounter
+
+	crdLister listers.CustomResourceDefinitionLister
+	crdSynced cache.InformerSynced
+
+	// interval is the time between two counts of the same CustomResourceDefinition
+	interval time.Duration
+
+	// To allow injection for testing.
+	syncFn func(key string) error
+
+	queue workqueue.RateLimitingInterface
+}
+
+func NewPersistedVersionController(
+	crdInformer informers.CustomResourceDefinitionInformer,
+	crdClient client.CustomResourceDefinitionsGetter,
+	counter PersistedVersionCounter,
+	interval time.Duration,
+) *PersistedVersionController {
+	c := &PersistedVersionController{
+		crdClient: crdClient,
+		counter:   counter,
+		crdLister: crdInformer.Lister(),
+		crdSynced: crdInformer.Informer().HasSynced,
+		interval:  interval,
+		queue:     workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "CustomResourceDefinition-PersistedVersionController"),
+	}
+
+	crdInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addCustomResourceDefinition,
+		UpdateFunc: c.updateCustomResourceDefinition,
+	})
+
+	c.syncFn = c.sync
+
+	return c
+}
+
+// calculatePersistedVersions returns the persisted version counts in the order of spec.versions,
+// followed by versions which are no longer in the spec ordered by name.
+func calculatePersistedVersions(crd *apiextensions.CustomResourceDefinition, counts map[string]int64) []apiextensions.PersistedVersionCount {
+	var persisted []apiextensions.PersistedVersionCount
+	specVersions := map[string]bool{}
+	for _, v := range crd.Spec.Versions {
+		specVersions[v.Name] = true
+		if count := counts[v.Name]; count > 0 {
+			persisted = append(persisted, apiextensions.PersistedVersionCount{Version: v.Name, Count: count})
+		}
+	}
+	var others []string
+	for version, count := range counts {
+		if !specVersions[version] && count > 0 {
+			others = append(others, version)
+		}
+	}
+	sort.Strings(others)
+	for _, version := range others {
+		persisted = append(persisted, apiextensions.PersistedVersionCount{Version: version, Count: counts[version]})
+	}
+	return persisted
+}
+
+func (c *PersistedVersionController) sync(key string) error {
+	inCustomResourceDefinition, err := c.crdLister.Get(key)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// the custom resources are only served, and hence counted, while established and not terminating
+	if !apiextensions.IsCRDConditionTrue(inCustomResourceDefinition, apiextensions.Established) || inCustomResourceDefinition.DeletionTimestamp != nil {
+		return nil
+	}
+
+	counts, err := c.counter.CountPersistedVersions(inCustomResourceDefinition)
+	if err != nil {
+		return err
+	}
+
+	persisted := calculatePersistedVersions(inCustomResourceDefinition, counts)
+	if apiequality.Semantic.DeepEqual(persisted, inCustomResourceDefinition.Status.PersistedVersions) {
+		return nil
+	}
+
+	crd := inCustomResourceDefinition.DeepCopy()
+	crd.Status.PersistedVersions = persisted
+	_, err = c.crdClient.CustomResourceDefinitions().UpdateStatus(crd)
+	return err
+}
+
+func (c *PersistedVersionController) Run(stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	glog.Infof("Starting PersistedVersionController")
+	defer glog.Infof("Shutting down PersistedVersionController")
+
+	if !cache.WaitForCacheSync(stopCh, c.crdSynced) {
+		return
+	}
+
+	go wait.Until(c.runWorker, time.Second, stopCh)
+	go wait.Until(c.enqueueAll, c.interval, stopCh)
+
+	<-stopCh
+}
+
+// enqueueAll queues all CustomResourceDefinitions to be counted again.
+func (c *PersistedVersionController) enqueueAll() {
+	crds, err := c.crdLister.List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	for _, crd := range crds {
+		c.queue.Add(crd.Name)
+	}
+}
+
+func (c *PersistedVersionController) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+// processNextWorkItem deals with one key off the queue.  It returns false when it's time to quit.
+func (c *PersistedVersionController) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	err := c.syncFn(key.(string))
+	if err == nil {
+		c.queue.Forget(key)
+		return true
+	}
+
+	utilruntime.HandleError(fmt.Errorf("%v failed with: %v", key, err))
+	c.queue.AddRateLimited(key)
+
+	return true
+}
+
+func (c *PersistedVersionController) addCustomResourceDefinition(obj interface{}) {
+	castObj := obj.(*apiextensions.CustomResourceDefinition)
+	glog.V(4).Infof("Adding %s", castObj.Name)
+	c.queue.Add(castObj.Name)
+}
+
+func (c *PersistedVersionController) updateCustomResourceDefinition(oldObj, newObj interface{}) {
+	oldCRD := oldObj.(*apiextensions.CustomResourceDefinition)
+	newCRD := newObj.(*apiextensions.CustomResourceDefinition)
+	// count right away when the storage version might have changed, the stored versions were migrated
+	// or the CRD became established. Other updates, like those of the counts themselves, wait for the
+	// next periodic count.
+	if apiequality.Semantic.DeepEqual(oldCRD.Spec, newCRD.Spec) &&
+		reflect.DeepEqual(oldCRD.Status.StoredVersions, newCRD.Status.StoredVersions) &&
+		apiextensions.IsCRDConditionTrue(oldCRD, apiextensions.Established) == apiextensions.IsCRDConditionTrue(newCRD, apiextensions.Established) {
+		return
+	}
+	glog.V(4).Infof("Updating %s", newCRD.Name)
+	c.queue.Add(newCRD.Name)
+}
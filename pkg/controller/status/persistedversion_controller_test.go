@@ -0,0 +1,133 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/client/clientset/internalclientset/fake"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+)
+
+type fakeCounter struct {
+	counts map[string]int64
+	err    error
+}
+
+func (c fakeCounter) CountPersistedVersions(crd *apiextensions.CustomResourceDefinition) (map[string]int64, error) {
+	return c.counts, c.err
+}
+
+func newPersistedVersionsCRD(established bool, persisted ...apiextensions.PersistedVersionCount) *apiextensions.CustomResourceDefinition {
+	crd := &apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "foos.example.com"},
+		Spec: apiextensions.CustomResourceDefinitionSpec{
+			Versions: []apiextensions.CustomResourceDefinitionVersion{{Name: "v2", Served: true, Storage: true}, {Name: "v1", Served: true}},
+		},
+		Status: apiextensions.CustomResourceDefinitionStatus{PersistedVersions: persisted},
+	}
+	if established {
+		apiextensions.SetCRDCondition(crd, apiextensions.CustomResourceDefinitionCondition{Type: apiextensions.Established, Status: apiextensions.ConditionTrue})
+	}
+	return crd
+}
+
+func TestPersistedVersionSync(t *testing.T) {
+	tests := []struct {
+		name    string
+		crd     *apiextensions.CustomResourceDefinition
+		counter fakeCounter
+		// expected are the expected persisted versions after the update, nil if no update is expected
+		expected []apiextensions.PersistedVersionCount
+		wantErr  bool
+	}{
+		{
+			name:     "counted",
+			crd:      newPersistedVersionsCRD(true),
+			counter:  fakeCounter{counts: map[string]int64{"v1": 2, "v2": 3, "v0": 1, "v3": 0}},
+			expected: []apiextensions.PersistedVersionCount{{Version: "v2", Count: 3}, {Version: "v1", Count: 2}, {Version: "v0", Count: 1}},
+		},
+		{
+			name:    "unchanged",
+			crd:     newPersistedVersionsCRD(true, apiextensions.PersistedVersionCount{Version: "v2", Count: 3}),
+			counter: fakeCounter{counts: map[string]int64{"v2": 3}},
+		},
+		{
+			name:     "migrated",
+			crd:      newPersistedVersionsCRD(true, apiextensions.PersistedVersionCount{Version: "v2", Count: 1}, apiextensions.PersistedVersionCount{Version: "v1", Count: 2}),
+			counter:  fakeCounter{counts: map[string]int64{"v2": 3}},
+			expected: []apiextensions.PersistedVersionCount{{Version: "v2", Count: 3}},
+		},
+		{
+			name:    "not established",
+			crd:     newPersistedVersionsCRD(false),
+			counter: fakeCounter{counts: map[string]int64{"v2": 3}},
+		},
+		{
+			name:    "counting fails",
+			crd:     newPersistedVersionsCRD(true),
+			counter: fakeCounter{err: fmt.Errorf("storage is unavailable")},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		crdIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		crdIndexer.Add(tc.crd)
+		client := fake.NewSimpleClientset(tc.crd)
+		c := &PersistedVersionController{
+			crdClient: client.Apiextensions(),
+			counter:   tc.counter,
+			crdLister: listers.NewCustomResourceDefinitionLister(crdIndexer),
+			queue:     workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		}
+
+		err := c.sync(tc.crd.Name)
+		if tc.wantErr != (err != nil) {
+			t.Errorf("%s: expected error %v, got: %v", tc.name, tc.wantErr, err)
+			continue
+		}
+
+		var updated *apiextensions.CustomResourceDefinition
+		for _, action := range client.Actions() {
+			if update, ok := action.(core.UpdateAction); ok && update.GetSubresource() == "status" {
+				updated = update.GetObject().(*apiextensions.CustomResourceDefinition)
+			}
+		}
+		if tc.expected == nil {
+			if updated != nil {
+				t.Errorf("%s: unexpected update: %#v", tc.name, updated.Status)
+			}
+			continue
+		}
+		if updated == nil {
+			t.Errorf("%s: expected a status update", tc.name)
+			continue
+		}
+		if !reflect.DeepEqual(updated.Status.PersistedVersions, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, updated.Status.PersistedVersions)
+		}
+	}
+}
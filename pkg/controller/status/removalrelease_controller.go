@@ -0,0 +1,219 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	client "k8s.io/apiextensions-apiserver/pkg/client/clientset/internalclientset/typed/apiextensions/internalversion"
+	informers "k8s.io/apiextensions-apiserver/pkg/client/informers/internalversion/apiextensions/internalversion"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+)
+
+// EventRecorder records events about CustomResourceDefinitions. The EventRecorder of
+// k8s.io/client-go/tools/record implements it.
+type EventRecorder interface {
+	Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{})
+}
+
+// RemovalReleaseConditionController sets the RemovalReleasePassed condition of CustomResourceDefinitions
+// with served versions which declare a removedInRelease, and records a warning event when the cluster
+// release passes the removal release of a version.
+type RemovalReleaseConditionController struct {
+	crdClient client.CustomResourceDefinitionsGetter
+	recorder  EventRecorder
+
+	crdLister listers.CustomResourceDefinitionLister
+	crdSynced cache.InformerSynced
+
+	// clusterMajor and clusterMinor are the release the cluster runs
+	clusterMajor, clusterMinor int
+
+	// To allow injection for testing.
+	syncFn func(key string) error
+
+	queue workqueue.RateLimitingInterface
+}
+
+// NewRemovalReleaseConditionController returns a controller which checks the removedInRelease of the
+// served versions against the given cluster release, e.g. 1.25. The recorder is optional.
+func NewRemovalReleaseConditionController(
+	crdInformer informers.CustomResourceDefinitionInformer,
+	crdClient client.CustomResourceDefinitionsGetter,
+	clusterRelease string,
+	recorder EventRecorder,
+) (*RemovalReleaseConditionController, error) {
+	major, minor, err := apiextensions.ParseRelease(clusterRelease)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cluster release %q: %v", clusterRelease, err)
+	}
+	c := &RemovalReleaseConditionController{
+		crdClient:    crdClient,
+		recorder:     recorder,
+		crdLister:    crdInformer.Lister(),
+		crdSynced:    crdInformer.Informer().HasSynced,
+		clusterMajor: major,
+		clusterMinor: minor,
+		queue:        workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "CustomResourceDefinition-RemovalReleaseConditionController"),
+	}
+
+	crdInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addCustomResourceDefinition,
+		UpdateFunc: c.updateCustomResourceDefinition,
+	})
+
+	c.syncFn = c.sync
+
+	return c, nil
+}
+
+// calculateRemovalReleaseCondition returns the RemovalReleasePassed condition of the given
+// CustomResourceDefinition, or nil if no served version declares a removal release.
+func calculateRemovalReleaseCondition(crd *apiextensions.CustomResourceDefinition, clusterMajor, clusterMinor int) *apiextensions.CustomResourceDefinitionCondition {
+	var passed, pending []string
+	for _, v := range crd.Spec.Versions {
+		if !v.Served || v.RemovedInRelease == nil {
+			continue
+		}
+		major, minor, err := apiextensions.ParseRelease(*v.RemovedInRelease)
+		if err != nil {
+			// rejected by validation
+			continue
+		}
+		scheduled := fmt.Sprintf("%s (removed in %s)", v.Name, *v.RemovedInRelease)
+		if clusterMajor > major || (clusterMajor == major && clusterMinor >= minor) {
+			passed = append(passed, scheduled)
+		} else {
+			pending = append(pending, scheduled)
+		}
+	}
+	if len(passed) == 0 && len(pending) == 0 {
+		return nil
+	}
+
+	condition := &apiextensions.CustomResourceDefinitionCondition{
+		Type:               apiextensions.RemovalReleasePassed,
+		ObservedGeneration: crd.Generation,
+	}
+	if len(passed) > 0 {
+		condition.Status = apiextensions.ConditionTrue
+		condition.Reason = "RemovalReleasePassed"
+		condition.Message = fmt.Sprintf("the cluster runs %d.%d, which is past the removal release of the served versions %s", clusterMajor, clusterMinor, strings.Join(passed, ", "))
+	} else {
+		condition.Status = apiextensions.ConditionFalse
+		condition.Reason = "RemovalReleasePending"
+		condition.Message = fmt.Sprintf("the cluster runs %d.%d, before the removal release of the served versions %s", clusterMajor, clusterMinor, strings.Join(pending, ", "))
+	}
+	return condition
+}
+
+func (c *RemovalReleaseConditionController) sync(key string) error {
+	inCustomResourceDefinition, err := c.crdLister.Get(key)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	condition := calculateRemovalReleaseCondition(inCustomResourceDefinition, c.clusterMajor, c.clusterMinor)
+	crd := inCustomResourceDefinition.DeepCopy()
+	if !updateCondition(crd, apiextensions.RemovalReleasePassed, condition) {
+		return nil
+	}
+	updated, err := c.crdClient.CustomResourceDefinitions().UpdateStatus(crd)
+	if err != nil {
+		return err
+	}
+
+	// warn once per cluster release and set of passed versions, i.e. when the message changes
+	existing := apiextensions.FindCRDCondition(inCustomResourceDefinition, apiextensions.RemovalReleasePassed)
+	if c.recorder != nil && condition != nil && condition.Status == apiextensions.ConditionTrue && (existing == nil || existing.Status != apiextensions.ConditionTrue || existing.Message != condition.Message) {
+		c.recorder.Eventf(updated, "Warning", condition.Reason, "%s", condition.Message)
+	}
+	return nil
+}
+
+func (c *RemovalReleaseConditionController) Run(stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	glog.Infof("Starting RemovalReleaseConditionController")
+	defer glog.Infof("Shutting down RemovalReleaseConditionController")
+
+	if !cache.WaitForCacheSync(stopCh, c.crdSynced) {
+		return
+	}
+
+	go wait.Until(c.runWorker, time.Second, stopCh)
+
+	<-stopCh
+}
+
+func (c *RemovalReleaseConditionController) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+// processNextWorkItem deals with one key off the queue.  It returns false when it's time to quit.
+func (c *RemovalReleaseConditionController) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	err := c.syncFn(key.(string))
+	if err == nil {
+		c.queue.Forget(key)
+		return true
+	}
+
+	utilruntime.HandleError(fmt.Errorf("%v failed with: %v", key, err))
+	c.queue.AddRateLimited(key)
+
+	return true
+}
+
+func (c *RemovalReleaseConditionController) addCustomResourceDefinition(obj interface{}) {
+	castObj := obj.(*apiextensions.CustomResourceDefinition)
+	glog.V(4).Infof("Adding %s", castObj.Name)
+	c.queue.Add(castObj.Name)
+}
+
+func (c *RemovalReleaseConditionController) updateCustomResourceDefinition(oldObj, newObj interface{}) {
+	oldCRD := oldObj.(*apiextensions.CustomResourceDefinition)
+	newCRD := newObj.(*apiextensions.CustomResourceDefinition)
+	// the condition only depends on the spec and observes the generation
+	if oldCRD.Generation == newCRD.Generation {
+		return
+	}
+	glog.V(4).Infof("Updating %s", newCRD.Name)
+	c.queue.Add(newCRD.Name)
+}
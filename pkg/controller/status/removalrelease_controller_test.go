@@ -0,0 +1,178 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"fmt"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/client/clientset/internalclientset/fake"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+)
+
+type fakeEventRecorder struct {
+	events []string
+}
+
+func (r *fakeEventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.events = append(r.events, eventtype+" "+reason+" "+fmt.Sprintf(messageFmt, args...))
+}
+
+func newRemovalReleaseCRD(versions []apiextensions.CustomResourceDefinitionVersion, conditions ...apiextensions.CustomResourceDefinitionCondition) *apiextensions.CustomResourceDefinition {
+	return &apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "foos.example.com", Generation: 2},
+		Spec:       apiextensions.CustomResourceDefinitionSpec{Group: "example.com", Versions: versions},
+		Status:     apiextensions.CustomResourceDefinitionStatus{Conditions: conditions},
+	}
+}
+
+func TestRemovalReleaseSync(t *testing.T) {
+	release := func(r string) *string { return &r }
+	v1 := apiextensions.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true}
+	v1beta1 := apiextensions.CustomResourceDefinitionVersion{Name: "v1beta1", Served: true, Deprecated: true, RemovedInRelease: release("1.25")}
+	v1alpha1 := apiextensions.CustomResourceDefinitionVersion{Name: "v1alpha1", Served: true, Deprecated: true, RemovedInRelease: release("1.22")}
+	unserved := v1alpha1
+	unserved.Served = false
+	passedMessage := "the cluster runs 1.24, which is past the removal release of the served versions v1alpha1 (removed in 1.22)"
+
+	tests := []struct {
+		name string
+		crd  *apiextensions.CustomResourceDefinition
+		// expectedStatus and expectedReason describe the condition after the update, an empty status
+		// means no condition
+		expectedStatus apiextensions.ConditionStatus
+		expectedReason string
+		expectedUpdate bool
+		expectedEvent  bool
+	}{
+		{
+			name: "no removal release",
+			crd:  newRemovalReleaseCRD([]apiextensions.CustomResourceDefinitionVersion{v1}),
+		},
+		{
+			name:           "pending",
+			crd:            newRemovalReleaseCRD([]apiextensions.CustomResourceDefinitionVersion{v1, v1beta1}),
+			expectedStatus: apiextensions.ConditionFalse,
+			expectedReason: "RemovalReleasePending",
+			expectedUpdate: true,
+		},
+		{
+			name:           "passed",
+			crd:            newRemovalReleaseCRD([]apiextensions.CustomResourceDefinitionVersion{v1, v1beta1, v1alpha1}),
+			expectedStatus: apiextensions.ConditionTrue,
+			expectedReason: "RemovalReleasePassed",
+			expectedUpdate: true,
+			expectedEvent:  true,
+		},
+		{
+			name: "passed version not served",
+			crd: newRemovalReleaseCRD([]apiextensions.CustomResourceDefinitionVersion{v1, unserved}, apiextensions.CustomResourceDefinitionCondition{
+				Type:               apiextensions.RemovalReleasePassed,
+				Status:             apiextensions.ConditionTrue,
+				Reason:             "RemovalReleasePassed",
+				Message:            passedMessage,
+				ObservedGeneration: 1,
+			}),
+			expectedUpdate: true,
+		},
+		{
+			name: "unchanged",
+			crd: newRemovalReleaseCRD([]apiextensions.CustomResourceDefinitionVersion{v1, v1alpha1}, apiextensions.CustomResourceDefinitionCondition{
+				Type:               apiextensions.RemovalReleasePassed,
+				Status:             apiextensions.ConditionTrue,
+				Reason:             "RemovalReleasePassed",
+				Message:            passedMessage,
+				ObservedGeneration: 2,
+			}),
+		},
+		{
+			name: "outdated generation",
+			crd: newRemovalReleaseCRD([]apiextensions.CustomResourceDefinitionVersion{v1, v1alpha1}, apiextensions.CustomResourceDefinitionCondition{
+				Type:               apiextensions.RemovalReleasePassed,
+				Status:             apiextensions.ConditionTrue,
+				Reason:             "RemovalReleasePassed",
+				Message:            passedMessage,
+				ObservedGeneration: 1,
+			}),
+			expectedStatus: apiextensions.ConditionTrue,
+			expectedReason: "RemovalReleasePassed",
+			expectedUpdate: true,
+		},
+	}
+
+	for _, tc := range tests {
+		crdIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		crdIndexer.Add(tc.crd)
+		client := fake.NewSimpleClientset(tc.crd)
+		recorder := &fakeEventRecorder{}
+		c := &RemovalReleaseConditionController{
+			crdClient:    client.Apiextensions(),
+			recorder:     recorder,
+			crdLister:    listers.NewCustomResourceDefinitionLister(crdIndexer),
+			clusterMajor: 1,
+			clusterMinor: 24,
+		}
+
+		if err := c.sync(tc.crd.Name); err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+
+		if tc.expectedEvent && len(recorder.events) != 1 {
+			t.Errorf("%s: expected one event, got %v", tc.name, recorder.events)
+		} else if !tc.expectedEvent && len(recorder.events) != 0 {
+			t.Errorf("%s: unexpected events %v", tc.name, recorder.events)
+		}
+
+		var updated *apiextensions.CustomResourceDefinition
+		for _, action := range client.Actions() {
+			if update, ok := action.(core.UpdateAction); ok && update.GetSubresource() == "status" {
+				updated = update.GetObject().(*apiextensions.CustomResourceDefinition)
+			}
+		}
+		if !tc.expectedUpdate {
+			if updated != nil {
+				t.Errorf("%s: unexpected update: %#v", tc.name, updated.Status)
+			}
+			continue
+		}
+		if updated == nil {
+			t.Errorf("%s: expected an update", tc.name)
+			continue
+		}
+		condition := apiextensions.FindCRDCondition(updated, apiextensions.RemovalReleasePassed)
+		if len(tc.expectedStatus) == 0 {
+			if condition != nil {
+				t.Errorf("%s: unexpected condition %#v", tc.name, condition)
+			}
+			continue
+		}
+		if condition == nil {
+			t.Errorf("%s: expected a condition", tc.name)
+			continue
+		}
+		if condition.Status != tc.expectedStatus || condition.Reason != tc.expectedReason || condition.ObservedGeneration != 2 {
+			t.Errorf("%s: unexpected condition %#v", tc.name, condition)
+		}
+	}
+}
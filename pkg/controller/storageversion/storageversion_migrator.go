@@ -0,0 +1,276 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storageversion
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	client "k8s.io/apiextensions-apiserver/pkg/client/clientset/internalclientset/typed/apiextensions/internalversion"
+	informers "k8s.io/apiextensions-apiserver/pkg/client/informers/internalversion/apiextensions/internalversion"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+)
+
+// StorageVersionMigrator is a controller that migrates the persisted CRs of a CRD to its storage
+// version and then removes all other versions from the stored versions of the CRD.
+type StorageVersionMigrator struct {
+	crdClient       client.CustomResourceDefinitionsGetter
+	crStorageGetter CRStorageGetter
+
+	crdLister listers.CustomResourceDefinitionLister
+	crdSynced cache.InformerSynced
+
+	// To allow injection for testing.
+	syncFn func(key string) error
+
+	queue workqueue.RateLimitingInterface
+}
+
+// ListerUpdater combines rest.Lister and rest.Updater.
+type ListerUpdater interface {
+	rest.Lister
+	rest.Updater
+}
+
+// CRStorageGetter knows how to get a ListerUpdater for the CRs of a given CRD.
+type CRStorageGetter interface {
+	// GetCustomResourceListerUpdater gets the ListerUpdater for the given CRD. It fails if the
+	// storage does not write the storage version of the given CRD yet.
+	GetCustomResourceListerUpdater(crd *apiextensions.CustomResourceDefinition) (ListerUpdater, error)
+}
+
+// NewStorageVersionMigrator creates a new StorageVersionMigrator.
+func NewStorageVersionMigrator(
+	crdInformer informers.CustomResourceDefinitionInformer,
+	crdClient client.CustomResourceDefinitionsGetter,
+	crStorageGetter CRStorageGetter,
+) *StorageVersionMigrator {
+	c := &StorageVersionMigrator{
+		crdClient:       crdClient,
+		crdLister:       crdInformer.Lister(),
+		crdSynced:       crdInformer.Informer().HasSynced,
+		crStorageGetter: crStorageGetter,
+		queue:           workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "CustomResourceDefinition-StorageVersionMigrator"),
+	}
+
+	crdInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addCustomResourceDefinition,
+		UpdateFunc: c.updateCustomResourceDefinition,
+	})
+
+	c.syncFn = c.sync
+
+	return c
+}
+
+// needsMigration returns true if objects of the CRD might be persisted in versions other than the
+// storage version.
+func needsMigration(crd *apiextensions.CustomResourceDefinition) bool {
+	storageVersion, err := apiextensions.GetCRDStorageVersion(crd)
+	if err != nil {
+		return false
+	}
+	storedVersions := crd.Status.StoredVersions
+	return len(storedVersions) != 1 || storedVersions[0] != storageVersion
+}
+
+func (c *StorageVersionMigrator) sync(key string) error {
+	cachedCRD, err := c.crdLister.Get(key)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// no work to do. CRs can only be rewritten while the CRD is served.
+	if !needsMigration(cachedCRD) || !cachedCRD.DeletionTimestamp.IsZero() || !apiextensions.IsCRDConditionTrue(cachedCRD, apiextensions.Established) {
+		return nil
+	}
+	storageVersion, err := apiextensions.GetCRDStorageVersion(cachedCRD)
+	if err != nil {
+		return err
+	}
+
+	// We go directly to the storage instead of using a REST API client to avoid admission, which
+	// could reject rewriting unchanged objects.
+	crStorage, err := c.crStorageGetter.GetCustomResourceListerUpdater(cachedCRD)
+	if err != nil {
+		return err
+	}
+	if err := c.migrateInstances(cachedCRD, crStorage); err != nil {
+		c.reportMigrationFailure(cachedCRD, err)
+		return err
+	}
+
+	// All objects are persisted in the storage version now. The update fails with a conflict if
+	// the CRD changed in the meantime, and the migration is retried.
+	// TODO not all servers are synchronized on caches. It is possible for a stale one to still
+	// write objects in an old version.
+	crd := cachedCRD.DeepCopy()
+	crd.Status.StoredVersions = []string{storageVersion}
+	apiextensions.SetCRDCondition(crd, apiextensions.CustomResourceDefinitionCondition{
+		Type:               apiextensions.StoredVersionsMigrated,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             "MigrationCompleted",
+		Message:            fmt.Sprintf("all custom resources are persisted in the storage version %s", storageVersion),
+		ObservedGeneration: crd.Generation,
+	})
+	_, err = c.crdClient.CustomResourceDefinitions().UpdateStatus(crd)
+	return err
+}
+
+// reportMigrationFailure sets the StoredVersionsMigrated condition of the CRD to false with the
+// given error. Failures to update the status are only logged.
+func (c *StorageVersionMigrator) reportMigrationFailure(cachedCRD *apiextensions.CustomResourceDefinition, migrateErr error) {
+	condition := apiextensions.CustomResourceDefinitionCondition{
+		Type:               apiextensions.StoredVersionsMigrated,
+		Status:             apiextensions.ConditionFalse,
+		Reason:             "MigrationFailed",
+		Message:            migrateErr.Error(),
+		ObservedGeneration: cachedCRD.Generation,
+	}
+	if apiextensions.IsCRDConditionEquivalent(&condition, apiextensions.FindCRDCondition(cachedCRD, apiextensions.StoredVersionsMigrated)) {
+		return
+	}
+	crd := cachedCRD.DeepCopy()
+	apiextensions.SetCRDCondition(crd, condition)
+	if _, err := c.crdClient.CustomResourceDefinitions().UpdateStatus(crd); err != nil {
+		utilruntime.HandleError(fmt.Errorf("unable to report the failed migration of %s: %v", crd.Name, err))
+	}
+}
+
+// migrateInstances rewrites all CRs of the CRD, which persists them in the storage version.
+func (c *StorageVersionMigrator) migrateInstances(crd *apiextensions.CustomResourceDefinition, crStorage ListerUpdater) error {
+	ctx := genericapirequest.NewContext()
+	allResources, err := crStorage.List(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not list instances: %v", err)
+	}
+
+	unchanged := func(_ genericapirequest.Context, _, currentObject runtime.Object) (runtime.Object, error) {
+		current, ok := currentObject.(*unstructured.Unstructured)
+		if !ok {
+			return nil, fmt.Errorf("unexpected object type %T", currentObject)
+		}
+		if len(current.GetUID()) == 0 {
+			// the object is gone, don't recreate it
+			return nil, apierrors.NewNotFound(schema.GroupResource{Group: crd.Spec.Group, Resource: crd.Status.AcceptedNames.Plural}, current.GetName())
+		}
+		return current.DeepCopy(), nil
+	}
+
+	migrateErrors := []error{}
+	items := allResources.(*unstructured.UnstructuredList).Items
+	for i := range items {
+		item := &items[i]
+		nsCtx := genericapirequest.WithNamespace(ctx, item.GetNamespace())
+		_, _, err := crStorage.Update(nsCtx, item.GetName(), rest.DefaultUpdatedObjectInfo(nil, nil, unchanged))
+		if err != nil && !apierrors.IsNotFound(err) {
+			migrateErrors = append(migrateErrors, err)
+		}
+	}
+	if migrateError := utilerrors.NewAggregate(migrateErrors); migrateError != nil {
+		return fmt.Errorf("could not migrate all instances: %v", migrateError)
+	}
+
+	glog.V(2).Infof("%s.%s migrated %d items to the storage version", crd.Status.AcceptedNames.Plural, crd.Spec.Group, len(items))
+	return nil
+}
+
+func (c *StorageVersionMigrator) Run(workers int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	glog.Infof("Starting StorageVersionMigrator")
+	defer glog.Infof("Shutting down StorageVersionMigrator")
+
+	if !cache.WaitForCacheSync(stopCh, c.crdSynced) {
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+func (c *StorageVersionMigrator) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+// processNextWorkItem deals with one key off the queue.  It returns false when it's time to quit.
+func (c *StorageVersionMigrator) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	err := c.syncFn(key.(string))
+	if err == nil {
+		c.queue.Forget(key)
+		return true
+	}
+
+	utilruntime.HandleError(fmt.Errorf("%v failed with: %v", key, err))
+	c.queue.AddRateLimited(key)
+
+	return true
+}
+
+func (c *StorageVersionMigrator) enqueue(obj *apiextensions.CustomResourceDefinition) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("Couldn't get key for object %#v: %v", obj, err))
+		return
+	}
+
+	c.queue.Add(key)
+}
+
+func (c *StorageVersionMigrator) addCustomResourceDefinition(obj interface{}) {
+	castObj := obj.(*apiextensions.CustomResourceDefinition)
+	if needsMigration(castObj) {
+		c.enqueue(castObj)
+	}
+}
+
+func (c *StorageVersionMigrator) updateCustomResourceDefinition(oldObj, newObj interface{}) {
+	newCRD := newObj.(*apiextensions.CustomResourceDefinition)
+	// the Established condition might have just been set, so check every update
+	if needsMigration(newCRD) {
+		c.enqueue(newCRD)
+	}
+}
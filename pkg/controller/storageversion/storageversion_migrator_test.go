@@ -0,0 +1,227 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storageversion
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/client/clientset/internalclientset/fake"
+	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/internalversion"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+// fakeStorage lists the given items and records the objects written by updates.
+type fakeStorage struct {
+	items     []unstructured.Unstructured
+	deleted   map[string]bool
+	updateErr error
+
+	updated []string
+}
+
+func (s *fakeStorage) New() runtime.Object {
+	return &unstructured.Unstructured{}
+}
+
+func (s *fakeStorage) NewList() runtime.Object {
+	return &unstructured.UnstructuredList{}
+}
+
+func (s *fakeStorage) List(ctx genericapirequest.Context, options *metainternalversion.ListOptions) (runtime.Object, error) {
+	return &unstructured.UnstructuredList{Items: s.items}, nil
+}
+
+func (s *fakeStorage) Update(ctx genericapirequest.Context, name string, objInfo rest.UpdatedObjectInfo) (runtime.Object, bool, error) {
+	if s.updateErr != nil {
+		return nil, false, s.updateErr
+	}
+	namespace, _ := genericapirequest.NamespaceFrom(ctx)
+	key := namespace + "/" + name
+	current := &unstructured.Unstructured{}
+	for i := range s.items {
+		if s.items[i].GetNamespace() == namespace && s.items[i].GetName() == name && !s.deleted[key] {
+			current = s.items[i].DeepCopy()
+		}
+	}
+	obj, err := objInfo.UpdatedObject(ctx, current)
+	if err != nil {
+		return nil, false, err
+	}
+	if !reflect.DeepEqual(obj, current) {
+		return nil, false, fmt.Errorf("unexpected change of %s: %v", key, obj)
+	}
+	s.updated = append(s.updated, key)
+	return obj, false, nil
+}
+
+type fakeStorageGetter struct {
+	storage *fakeStorage
+}
+
+func (g fakeStorageGetter) GetCustomResourceListerUpdater(crd *apiextensions.CustomResourceDefinition) (ListerUpdater, error) {
+	return g.storage, nil
+}
+
+func newCR(namespace, name string) unstructured.Unstructured {
+	u := unstructured.Unstructured{}
+	u.SetAPIVersion("group.com/v1")
+	u.SetKind("Kind")
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	u.SetUID(types.UID("uid-" + name))
+	return u
+}
+
+func newMigrationCRD(established bool, storedVersions ...string) *apiextensions.CustomResourceDefinition {
+	crd := &apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "kinds.group.com", ResourceVersion: "1"},
+		Spec: apiextensions.CustomResourceDefinitionSpec{
+			Group: "group.com",
+			Versions: []apiextensions.CustomResourceDefinitionVersion{
+				{Name: "v1", Served: true},
+				{Name: "v2", Served: true, Storage: true},
+			},
+		},
+		Status: apiextensions.CustomResourceDefinitionStatus{
+			AcceptedNames:  apiextensions.CustomResourceDefinitionNames{Plural: "kinds"},
+			StoredVersions: storedVersions,
+		},
+	}
+	if established {
+		apiextensions.SetCRDCondition(crd, apiextensions.CustomResourceDefinitionCondition{
+			Type:   apiextensions.Established,
+			Status: apiextensions.ConditionTrue,
+		})
+	}
+	return crd
+}
+
+func TestSync(t *testing.T) {
+	tests := []struct {
+		name      string
+		crd       *apiextensions.CustomResourceDefinition
+		items     []unstructured.Unstructured
+		deleted   []string
+		updateErr error
+
+		expectError            bool
+		expectedUpdated        []string
+		expectedStoredVersions []string
+		// expectedMigrated is the status of the StoredVersionsMigrated condition after the update
+		expectedMigrated apiextensions.ConditionStatus
+	}{
+		{
+			name:  "already migrated",
+			crd:   newMigrationCRD(true, "v2"),
+			items: []unstructured.Unstructured{newCR("ns", "a")},
+		},
+		{
+			name:  "not established",
+			crd:   newMigrationCRD(false, "v1", "v2"),
+			items: []unstructured.Unstructured{newCR("ns", "a")},
+		},
+		{
+			name:                   "migrate old versions",
+			crd:                    newMigrationCRD(true, "v1", "v2"),
+			items:                  []unstructured.Unstructured{newCR("ns", "a"), newCR("other", "b")},
+			expectedUpdated:        []string{"ns/a", "other/b"},
+			expectedStoredVersions: []string{"v2"},
+			expectedMigrated:       apiextensions.ConditionTrue,
+		},
+		{
+			name:                   "set missing stored versions",
+			crd:                    newMigrationCRD(true),
+			items:                  []unstructured.Unstructured{newCR("ns", "a")},
+			expectedUpdated:        []string{"ns/a"},
+			expectedStoredVersions: []string{"v2"},
+			expectedMigrated:       apiextensions.ConditionTrue,
+		},
+		{
+			name:                   "ignore deleted objects",
+			crd:                    newMigrationCRD(true, "v1", "v2"),
+			items:                  []unstructured.Unstructured{newCR("ns", "a"), newCR("ns", "b")},
+			deleted:                []string{"ns/a"},
+			expectedUpdated:        []string{"ns/b"},
+			expectedStoredVersions: []string{"v2"},
+			expectedMigrated:       apiextensions.ConditionTrue,
+		},
+		{
+			name:        "update error",
+			crd:         newMigrationCRD(true, "v1", "v2"),
+			items:       []unstructured.Unstructured{newCR("ns", "a")},
+			updateErr:   apierrors.NewConflict(schema.GroupResource{Group: "group.com", Resource: "kinds"}, "a", fmt.Errorf("conflict")),
+			expectError: true,
+			// only the failure is reported
+			expectedStoredVersions: []string{"v1", "v2"},
+			expectedMigrated:       apiextensions.ConditionFalse,
+		},
+	}
+
+	for _, tc := range tests {
+		crdIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		crdIndexer.Add(tc.crd)
+		storage := &fakeStorage{items: tc.items, deleted: map[string]bool{}, updateErr: tc.updateErr}
+		for _, key := range tc.deleted {
+			storage.deleted[key] = true
+		}
+		client := fake.NewSimpleClientset(tc.crd)
+
+		c := &StorageVersionMigrator{
+			crdClient:       client.Apiextensions(),
+			crStorageGetter: fakeStorageGetter{storage},
+			crdLister:       listers.NewCustomResourceDefinitionLister(crdIndexer),
+		}
+		err := c.sync(tc.crd.Name)
+		if tc.expectError != (err != nil) {
+			t.Errorf("%s: expected error %v, got %v", tc.name, tc.expectError, err)
+		}
+		if !reflect.DeepEqual(tc.expectedUpdated, storage.updated) {
+			t.Errorf("%s: expected updated objects %v, got %v", tc.name, tc.expectedUpdated, storage.updated)
+		}
+
+		var storedVersions []string
+		var migrated apiextensions.ConditionStatus
+		for _, action := range client.Actions() {
+			if action.GetVerb() == "update" && action.GetSubresource() == "status" {
+				updated := action.(core.UpdateAction).GetObject().(*apiextensions.CustomResourceDefinition)
+				storedVersions = updated.Status.StoredVersions
+				if condition := apiextensions.FindCRDCondition(updated, apiextensions.StoredVersionsMigrated); condition != nil {
+					migrated = condition.Status
+				}
+			}
+		}
+		if !reflect.DeepEqual(tc.expectedStoredVersions, storedVersions) {
+			t.Errorf("%s: expected stored versions %v, got %v", tc.name, tc.expectedStoredVersions, storedVersions)
+		}
+		if migrated != tc.expectedMigrated {
+			t.Errorf("%s: expected the StoredVersionsMigrated condition %q, got %q", tc.name, tc.expectedMigrated, migrated)
+		}
+	}
+}
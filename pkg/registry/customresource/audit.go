@@ -0,0 +1,98 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+const (
+	// ValidationFailuresAnnotationKey is the key of the audit annotation listing the schema violations
+	// and failed x-kubernetes-validations rules a custom resource was rejected for, as a JSON array of
+	// ValidationFailure.
+	ValidationFailuresAnnotationKey = "validation.apiextensions.k8s.io/failures"
+	// ValidationFailureCountAnnotationKey is the key of the audit annotation with the total number of
+	// failures, which can be larger than the number of listed failures.
+	ValidationFailureCountAnnotationKey = "validation.apiextensions.k8s.io/failure-count"
+
+	// maxAuditedValidationFailures bounds the size of the failures annotation.
+	maxAuditedValidationFailures = 20
+)
+
+// ValidationFailureSource is the part of the validation which reported a ValidationFailure.
+type ValidationFailureSource string
+
+const (
+	// ValidationFailureSourceSchema is the OpenAPI schema, including the vendor extensions.
+	ValidationFailureSourceSchema ValidationFailureSource = "Schema"
+	// ValidationFailureSourceRule is an x-kubernetes-validations rule.
+	ValidationFailureSourceRule ValidationFailureSource = "Rule"
+)
+
+// ValidationFailure is an entry of the failures audit annotation.
+type ValidationFailure struct {
+	Source ValidationFailureSource `json:"source"`
+	// Rule is the expression of the failed rule. It is empty for schema violations and for errors
+	// which are not caused by a single rule, like an exceeded cost budget.
+	Rule    string          `json:"rule,omitempty"`
+	Field   string          `json:"field"`
+	Type    field.ErrorType `json:"type"`
+	Message string          `json:"message"`
+}
+
+// annotateValidationFailures records the schema and rule errors of a request in annotations of the
+// audit event in ctx, if any. The audit event of this apiserver does not have annotations of its
+// own, hence they are set on its object metadata, which the JSON log and webhook backends include.
+func annotateValidationFailures(ctx genericapirequest.Context, schemaErrs, ruleErrs field.ErrorList, failedRules cel.RuleFailures) {
+	count := len(schemaErrs) + len(ruleErrs)
+	if count == 0 || ctx == nil {
+		return
+	}
+	ae := genericapirequest.AuditEventFrom(ctx)
+	if ae == nil {
+		return
+	}
+
+	failures := make([]ValidationFailure, 0, count)
+	for _, err := range schemaErrs {
+		failures = append(failures, ValidationFailure{Source: ValidationFailureSourceSchema, Field: err.Field, Type: err.Type, Message: err.Detail})
+	}
+	for _, err := range ruleErrs {
+		failures = append(failures, ValidationFailure{Source: ValidationFailureSourceRule, Rule: failedRules[err].Rule, Field: err.Field, Type: err.Type, Message: err.Detail})
+	}
+	if len(failures) > maxAuditedValidationFailures {
+		failures = failures[:maxAuditedValidationFailures]
+	}
+	value, err := json.Marshal(failures)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("unable to encode the validation failures audit annotation: %v", err))
+		return
+	}
+
+	if ae.Annotations == nil {
+		ae.Annotations = map[string]string{}
+	}
+	ae.Annotations[ValidationFailuresAnnotationKey] = string(value)
+	ae.Annotations[ValidationFailureCountAnnotationKey] = strconv.Itoa(count)
+}
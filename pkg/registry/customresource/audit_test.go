@@ -0,0 +1,113 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/apis/audit"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func TestValidationFailureAuditAnnotations(t *testing.T) {
+	kind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1beta1", Kind: "Noxu"}
+	openAPIV3Schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"image":    {Type: "string"},
+					"replicas": {Type: "integer"},
+				},
+				XValidations: apiextensions.ValidationRules{
+					{Rule: "self.replicas <= 5", Message: "too many replicas"},
+					{Rule: "self.image != 'latest'"},
+				},
+			},
+		},
+	}
+	strategy := NewStrategy(nil, StrategyOptions{Kind: kind, Plural: "noxus", Schema: openAPIV3Schema, PreserveUnknownFields: true}, nil, 0)
+	valid := newTestCustomResource(0, map[string]interface{}{"image": "busybox", "replicas": int64(1)}, nil)
+
+	tests := []struct {
+		name     string
+		spec     map[string]interface{}
+		update   bool
+		expected []ValidationFailure
+	}{
+		{
+			name: "valid",
+			spec: map[string]interface{}{"image": "busybox", "replicas": int64(1)},
+		},
+		{
+			name: "rules on create",
+			spec: map[string]interface{}{"image": "latest", "replicas": int64(6)},
+			expected: []ValidationFailure{
+				{Source: ValidationFailureSourceRule, Rule: "self.replicas <= 5", Field: "spec", Type: field.ErrorTypeInvalid, Message: "too many replicas"},
+				{Source: ValidationFailureSourceRule, Rule: "self.image != 'latest'", Field: "spec", Type: field.ErrorTypeInvalid, Message: "failed rule: self.image != 'latest'"},
+			},
+		},
+		{
+			name:   "schema and rule on update",
+			spec:   map[string]interface{}{"image": int64(42), "replicas": int64(6)},
+			update: true,
+			expected: []ValidationFailure{
+				{Source: ValidationFailureSourceSchema, Field: "spec.image", Type: field.ErrorTypeInvalid, Message: "must be of type string"},
+				{Source: ValidationFailureSourceRule, Rule: "self.replicas <= 5", Field: "spec", Type: field.ErrorTypeInvalid, Message: "too many replicas"},
+			},
+		},
+	}
+	for _, tc := range tests {
+		ae := &audit.Event{}
+		ctx := genericapirequest.WithAuditEvent(genericapirequest.NewContext(), ae)
+		cr := newTestCustomResource(0, tc.spec, nil)
+		if tc.update {
+			strategy.ValidateUpdate(ctx, cr, valid)
+		} else {
+			strategy.Validate(ctx, cr)
+		}
+
+		if len(tc.expected) == 0 {
+			if len(ae.Annotations) != 0 {
+				t.Errorf("%s: unexpected annotations %v", tc.name, ae.Annotations)
+			}
+			continue
+		}
+		var got []ValidationFailure
+		if err := json.Unmarshal([]byte(ae.Annotations[ValidationFailuresAnnotationKey]), &got); err != nil {
+			t.Errorf("%s: unable to decode the failures annotation: %v", tc.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%s: expected failures %#v, got %#v", tc.name, tc.expected, got)
+		}
+		if count := ae.Annotations[ValidationFailureCountAnnotationKey]; count != "2" {
+			t.Errorf("%s: expected a failure count of 2, got %q", tc.name, count)
+		}
+	}
+
+	// without an audit event nothing is recorded
+	if errs := strategy.Validate(genericapirequest.NewContext(), newTestCustomResource(0, map[string]interface{}{"image": "busybox", "replicas": int64(6)}, nil)); len(errs) != 1 {
+		t.Errorf("expected one error, got %v", errs)
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+// watchBookmarkInterval is the interval in which a watch with allowWatchBookmarks sends a bookmark
+// if its resourceVersion advanced.
+const watchBookmarkInterval = time.Minute
+
+// allowWatchBookmarks validates the allowWatchBookmarks parameter of a watch request and returns
+// whether bookmarks may be sent.
+func (p ListParameters) allowWatchBookmarks() (bool, error) {
+	switch p.AllowWatchBookmarks {
+	case "", "false":
+		return false, nil
+	case "true":
+		return true, nil
+	}
+	return false, errors.NewBadRequest(fmt.Sprintf("invalid allowWatchBookmarks %q: must be true or false", p.AllowWatchBookmarks))
+}
+
+// watch watches the custom resources with the options from the given resourceVersion on, after the
+// given number of initial events. With bookmarks, watches of a namespace send bookmarks at the
+// resourceVersion of the watch cache, see bookmarkWatcher. Without watch cache no bookmarks are sent.
+// Watches from resourceVersion 0 or without resourceVersion start with the current objects, so they
+// only send bookmarks with sendInitialEvents, which passes the resourceVersion of the initial events.
+func (r *REST) watch(ctx genericapirequest.Context, options *metainternalversion.ListOptions, bookmarks bool, initial int, resourceVersion string) (watch.Interface, error) {
+	delegate, err := r.Store.Watch(ctx, options)
+	if err != nil || !bookmarks {
+		return delegate, err
+	}
+	if namespace, _ := genericapirequest.NamespaceFrom(ctx); len(namespace) == 0 {
+		return delegate, nil
+	}
+	start, err := strconv.ParseUint(resourceVersion, 10, 64)
+	if err != nil || start == 0 {
+		return delegate, nil
+	}
+	cache, ok := unwrapStorage(r.Store.Storage).(watchCache)
+	if !ok {
+		return delegate, nil
+	}
+	return newBookmarkWatcher(delegate, cache.LastSyncResourceVersion, initial, start, r.kind, watchBookmarkInterval), nil
+}
+
+// bookmarkWatcher sends the events of a watch of the watch cache, and periodically a bookmark with
+// the resourceVersion of the cache if it is newer than the last event sent. The cache does not tell
+// its watchers about the events it filters out, and it dispatches its events to the watchers
+// asynchronously. When the resourceVersion of the cache is read, its events up to that
+// resourceVersion are queued for dispatching, though. The cache terminates watchers which do not
+// keep up with its events, so they arrive within an interval: the resourceVersion read in one
+// interval is sent in a bookmark at the end of the next interval, after the pending events of the
+// delegate, so that a bookmark is never ahead of the events which are sent.
+//
+// The given number of initial events is sent before any bookmark. They are older than the
+// resourceVersion which the watcher starts from.
+type bookmarkWatcher struct {
+	*proxyWatcher
+}
+
+func newBookmarkWatcher(delegate watch.Interface, resourceVersion func() (uint64, error), initial int, start uint64, kind schema.GroupVersionKind, interval time.Duration) *bookmarkWatcher {
+	w := &bookmarkWatcher{newProxyWatcher(delegate)}
+	go w.run(resourceVersion, initial, start, kind, interval)
+	return w
+}
+
+func (w *bookmarkWatcher) run(resourceVersion func() (uint64, error), initial int, sent uint64, kind schema.GroupVersionKind, interval time.Duration) {
+	defer close(w.result)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	// the resourceVersion of the cache is read asynchronously, since it blocks while the cache is
+	// initialized
+	read := make(chan uint64, 1)
+	reading := false
+	// last is the resourceVersion read in the current interval, confirmed the one read in the
+	// previous interval, which is sent in the next bookmark.
+	var last, confirmed uint64
+	handle := func(event watch.Event) bool {
+		if !w.send(event) {
+			return false
+		}
+		if initial > 0 {
+			initial--
+			return true
+		}
+		if accessor, err := meta.Accessor(event.Object); event.Type != watch.Error && err == nil {
+			if rv, err := strconv.ParseUint(accessor.GetResourceVersion(), 10, 64); err == nil && rv > sent {
+				sent = rv
+			}
+		}
+		return true
+	}
+	for {
+		select {
+		case event, ok := <-w.delegate.ResultChan():
+			if !ok || !handle(event) {
+				return
+			}
+		case rv := <-read:
+			reading = false
+			if rv > last {
+				last = rv
+			}
+		case <-ticker.C:
+			for pending := true; pending; {
+				select {
+				case event, ok := <-w.delegate.ResultChan():
+					if !ok || !handle(event) {
+						return
+					}
+				default:
+					pending = false
+				}
+			}
+			if initial == 0 && confirmed > sent {
+				if !w.send(watch.Event{Type: bookmarkEventType, Object: newBookmark(kind, strconv.FormatUint(confirmed, 10))}) {
+					return
+				}
+				sent = confirmed
+			}
+			confirmed = last
+			if !reading {
+				reading = true
+				go func() {
+					rv, err := resourceVersion()
+					if err != nil {
+						rv = 0
+					}
+					select {
+					case read <- rv:
+					case <-w.done:
+					}
+				}()
+			}
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// newBookmark returns a bookmark of the given kind at the given resourceVersion.
+func newBookmark(kind schema.GroupVersionKind, resourceVersion string) *unstructured.Unstructured {
+	bookmark := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	bookmark.SetGroupVersionKind(kind)
+	bookmark.SetResourceVersion(resourceVersion)
+	return bookmark
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/storage"
+)
+
+func TestAllowWatchBookmarksParameter(t *testing.T) {
+	tests := []struct {
+		value      string
+		allow      bool
+		badRequest bool
+	}{
+		{value: ""},
+		{value: "false"},
+		{value: "true", allow: true},
+		{value: "yes", badRequest: true},
+	}
+	for _, tt := range tests {
+		allow, err := ListParameters{AllowWatchBookmarks: tt.value}.allowWatchBookmarks()
+		if tt.badRequest {
+			if !errors.IsBadRequest(err) {
+				t.Errorf("%q: expected a bad request error, got %v", tt.value, err)
+			}
+			continue
+		}
+		if err != nil || allow != tt.allow {
+			t.Errorf("%q: expected %v, got %v, %v", tt.value, tt.allow, allow, err)
+		}
+	}
+}
+
+// cacheVersion is the resourceVersion of a fake watch cache.
+type cacheVersion struct {
+	lock            sync.Mutex
+	resourceVersion uint64
+}
+
+func (c *cacheVersion) set(resourceVersion uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.resourceVersion = resourceVersion
+}
+
+func (c *cacheVersion) get() (uint64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.resourceVersion, nil
+}
+
+func TestBookmarkWatcher(t *testing.T) {
+	kind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1beta1", Kind: "Noxu"}
+	newObject := func(namespace, name, resourceVersion string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		obj.SetResourceVersion(resourceVersion)
+		return obj
+	}
+
+	delegate := watch.NewFake()
+	// the cache is at an event of another namespace, which it filtered out
+	cache := &cacheVersion{resourceVersion: 7}
+	w := newBookmarkWatcher(delegate, cache.get, 1, 5, kind, 10*time.Millisecond)
+	defer w.Stop()
+
+	initial := newObject("ns", "foo", "4")
+	go delegate.Add(initial)
+	if event := <-w.ResultChan(); event.Type != watch.Added || event.Object != initial {
+		t.Fatalf("expected the initial event of foo, got %s %#v", event.Type, event.Object)
+	}
+	event := <-w.ResultChan()
+	bookmark, ok := event.Object.(*unstructured.Unstructured)
+	if event.Type != "BOOKMARK" || !ok || bookmark.GetResourceVersion() != "7" || bookmark.GroupVersionKind() != kind {
+		t.Fatalf("expected a bookmark at the resourceVersion of the cache, got %s %#v", event.Type, event.Object)
+	}
+
+	modified := newObject("ns", "foo", "8")
+	go delegate.Modify(modified)
+	if event := <-w.ResultChan(); event.Type != watch.Modified || event.Object != modified {
+		t.Fatalf("expected the MODIFIED event of foo, got %s %#v", event.Type, event.Object)
+	}
+	cache.set(8)
+	select {
+	case event := <-w.ResultChan():
+		t.Errorf("expected no bookmark at the resourceVersion of the last event, got %s %#v", event.Type, event.Object)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	w.Stop()
+	if _, ok := <-w.ResultChan(); ok {
+		t.Errorf("expected the result channel to be closed after Stop")
+	}
+	if !delegate.IsStopped() {
+		t.Errorf("expected the watch to be stopped")
+	}
+}
+
+// watchingCache is a fake watch cache recording the keys of its watches.
+type watchingCache struct {
+	storage.Interface
+	keys []string
+}
+
+func (c *watchingCache) WatchList(ctx context.Context, key string, resourceVersion string, p storage.SelectionPredicate) (watch.Interface, error) {
+	c.keys = append(c.keys, key)
+	return watch.NewFake(), nil
+}
+
+func (c *watchingCache) LastSyncResourceVersion() (uint64, error) {
+	return 5, nil
+}
+
+func TestBookmarksWatchNamespace(t *testing.T) {
+	listKind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1beta1", Kind: "NoxuList"}
+	cache := &watchingCache{}
+	r := newReadREST(listKind, cache)
+	r.Store.KeyRootFunc = func(ctx genericapirequest.Context) string {
+		namespace, _ := genericapirequest.NamespaceFrom(ctx)
+		return "/noxus/" + namespace
+	}
+
+	ctx := genericapirequest.WithNamespace(genericapirequest.NewContext(), "ns")
+	w, err := r.watch(ctx, &metainternalversion.ListOptions{ResourceVersion: "5"}, true, 0, "5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+	if _, ok := w.(*bookmarkWatcher); !ok {
+		t.Errorf("expected a bookmark watcher, got %T", w)
+	}
+	if len(cache.keys) != 1 || cache.keys[0] != "/noxus/ns" {
+		t.Errorf("expected a watch of the namespace, got %v", cache.keys)
+	}
+}
@@ -0,0 +1,325 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+// CauseTypeFieldValueChanged is the type of the causes of a rejected update which describe how it
+// changed a field that is immutable or protected by a transition rule. The message of the cause is
+// an RFC 6902 JSON patch of the old custom resource with the changes below the field of the cause.
+// Every replaced or removed value is preceded by a test operation with the old value.
+const CauseTypeFieldValueChanged metav1.CauseType = "FieldValueChanged"
+
+type changedFieldsContextKey int
+
+const changedFieldsKey changedFieldsContextKey = iota
+
+// changedFields records the causes describing the changes of the fields which are immutable or
+// protected by transition rules during the validation of an update.
+type changedFields struct {
+	causes []metav1.StatusCause
+}
+
+// updateWithChangedFields calls update, adding the changes of the fields which are immutable or
+// protected by transition rules to the causes of the Invalid error if the update is rejected.
+func updateWithChangedFields(ctx genericapirequest.Context, update func(ctx genericapirequest.Context) (runtime.Object, bool, error)) (runtime.Object, bool, error) {
+	changes := &changedFields{}
+	obj, created, err := update(genericapirequest.WithValue(ctx, changedFieldsKey, changes))
+	if statusErr, ok := err.(*errors.StatusError); ok && errors.IsInvalid(err) && statusErr.ErrStatus.Details != nil && len(changes.causes) > 0 {
+		statusErr.ErrStatus.Details.Causes = append(statusErr.ErrStatus.Details.Causes, changes.causes...)
+	}
+	return obj, created, err
+}
+
+// recordChangedFields records the changes from old to obj below the fields of the violations of
+// x-kubernetes-immutable in immutableErrs and of the transition rules among ruleErrs, replacing
+// the changes recorded by a previous validation of the update unless add is set. Nothing is
+// recorded unless ctx comes from updateWithChangedFields.
+func recordChangedFields(ctx genericapirequest.Context, add bool, immutableErrs, ruleErrs field.ErrorList, failedRules cel.RuleFailures, obj, old runtime.Object, s *apiextensions.JSONSchemaProps) {
+	if ctx == nil {
+		return
+	}
+	changes, ok := ctx.Value(changedFieldsKey).(*changedFields)
+	if !ok {
+		return
+	}
+	if !add {
+		changes.causes = nil
+	}
+	u, ok := obj.(runtime.Unstructured)
+	if !ok {
+		return
+	}
+	oldU, ok := old.(runtime.Unstructured)
+	if !ok {
+		return
+	}
+
+	var fields []string
+	for _, err := range immutableErrs {
+		fields = append(fields, err.Field)
+	}
+	for _, err := range ruleErrs {
+		if failedRules[err].Transition {
+			fields = append(fields, err.Field)
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, c := range changes.causes {
+		seen[c.Field] = true
+	}
+	for _, f := range fields {
+		if seen[f] {
+			continue
+		}
+		seen[f] = true
+		patch, ok := changedFieldPatch(f, u.UnstructuredContent(), oldU.UnstructuredContent(), s)
+		if !ok || len(patch) == 0 {
+			continue
+		}
+		message, err := json.Marshal(patch)
+		if err != nil {
+			utilruntime.HandleError(fmt.Errorf("unable to encode the changes of %s: %v", f, err))
+			continue
+		}
+		changes.causes = append(changes.causes, metav1.StatusCause{Type: CauseTypeFieldValueChanged, Field: f, Message: string(message)})
+	}
+}
+
+// jsonPatchOperation is an operation of an RFC 6902 JSON patch. Value is nil for remove operations.
+type jsonPatchOperation struct {
+	Op    string       `json:"op"`
+	Path  string       `json:"path"`
+	Value *interface{} `json:"value,omitempty"`
+}
+
+func newJSONPatchOperation(op, path string, value interface{}) jsonPatchOperation {
+	return jsonPatchOperation{Op: op, Path: path, Value: &value}
+}
+
+func removeOperations(path string, old interface{}) []jsonPatchOperation {
+	return []jsonPatchOperation{newJSONPatchOperation("test", path, old), {Op: "remove", Path: path}}
+}
+
+// changedFieldPatch returns the JSON patch changing the value at the field path of old to its
+// value in obj. The field path is the string of a field.Path with indexes of the items of obj. Items
+// of arrays with x-kubernetes-list-type map are correlated with the old items by their
+// x-kubernetes-list-map-keys, other items by their index. ok is false if the field path cannot be
+// resolved.
+func changedFieldPatch(fieldPath string, obj, old interface{}, s *apiextensions.JSONSchemaProps) ([]jsonPatchOperation, bool) {
+	elements, ok := parseFieldPathString(fieldPath)
+	if !ok {
+		return nil, false
+	}
+
+	pointer := ""
+	oldFound := true
+	for _, e := range elements {
+		// removed objects are traversed in the old object
+		container := obj
+		if container == nil {
+			container = old
+		}
+		switch container.(type) {
+		case map[string]interface{}:
+			m, _ := obj.(map[string]interface{})
+			oldMap, _ := old.(map[string]interface{})
+			var found bool
+			obj = m[e.name]
+			old, found = oldMap[e.name]
+			oldFound = oldFound && found
+			pointer += "/" + escapeJSONPointer(e.name)
+			s = propertySchema(s, e.name, e.subscript)
+		case []interface{}:
+			// the indexes are the ones of the items of obj
+			x, ok := obj.([]interface{})
+			if !ok {
+				return nil, false
+			}
+			i, err := strconv.Atoi(e.name)
+			if !e.subscript || err != nil || i < 0 || i >= len(x) {
+				return nil, false
+			}
+			var itemSchema *apiextensions.JSONSchemaProps
+			if s != nil && s.Items != nil {
+				itemSchema = s.Items.Schema
+			}
+			oldItems, _ := old.([]interface{})
+			j := correlatedIndex(x[i], oldItems, i, s)
+			if j < 0 {
+				obj, old, oldFound = x[i], nil, false
+				pointer += "/" + strconv.Itoa(i)
+			} else {
+				obj, old = x[i], oldItems[j]
+				pointer += "/" + strconv.Itoa(j)
+			}
+			s = itemSchema
+		default:
+			return nil, false
+		}
+	}
+
+	return diff(pointer, obj, old, oldFound), true
+}
+
+// diff returns the JSON patch changing old to x at the given JSON pointer. Objects are compared
+// by property, arrays and scalars are replaced as a whole.
+func diff(pointer string, x, old interface{}, oldFound bool) []jsonPatchOperation {
+	if !oldFound {
+		return []jsonPatchOperation{newJSONPatchOperation("add", pointer, x)}
+	}
+	m, isMap := x.(map[string]interface{})
+	oldM, oldIsMap := old.(map[string]interface{})
+	if isMap && oldIsMap {
+		var ret []jsonPatchOperation
+		for _, k := range sortedKeys(oldM, m) {
+			v, found := m[k]
+			oldV, oldFound := oldM[k]
+			p := pointer + "/" + escapeJSONPointer(k)
+			if found {
+				ret = append(ret, diff(p, v, oldV, oldFound)...)
+			} else {
+				ret = append(ret, removeOperations(p, oldV)...)
+			}
+		}
+		return ret
+	}
+	if apiequality.Semantic.DeepEqual(x, old) {
+		return nil
+	}
+	if x == nil {
+		return removeOperations(pointer, old)
+	}
+	return []jsonPatchOperation{newJSONPatchOperation("test", pointer, old), newJSONPatchOperation("replace", pointer, x)}
+}
+
+// fieldPathStringElement is a field name or a subscript, i.e. an index or key, of a field path.
+type fieldPathStringElement struct {
+	name      string
+	subscript bool
+}
+
+// parseFieldPathString parses the string of a field.Path, e.g. spec.ports[0].name.
+func parseFieldPathString(fieldPath string) ([]fieldPathStringElement, bool) {
+	var ret []fieldPathStringElement
+	rest := fieldPath
+	for len(rest) > 0 {
+		switch {
+		case rest[0] == '[':
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, false
+			}
+			ret = append(ret, fieldPathStringElement{name: rest[1:end], subscript: true})
+			rest = rest[end+1:]
+		default:
+			if rest[0] == '.' {
+				if len(ret) == 0 {
+					return nil, false
+				}
+				rest = rest[1:]
+			} else if len(ret) > 0 {
+				return nil, false
+			}
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, false
+			}
+			ret = append(ret, fieldPathStringElement{name: rest[:end]})
+			rest = rest[end:]
+		}
+	}
+	return ret, true
+}
+
+// propertySchema returns the schema of the property or, for subscripts, the key of
+// additionalProperties with the given name, or nil if s does not describe it.
+func propertySchema(s *apiextensions.JSONSchemaProps, name string, subscript bool) *apiextensions.JSONSchemaProps {
+	if s == nil {
+		return nil
+	}
+	if prop, found := s.Properties[name]; found && !subscript {
+		return &prop
+	}
+	if s.AdditionalProperties != nil {
+		return s.AdditionalProperties.Schema
+	}
+	return nil
+}
+
+// correlatedIndex returns the index of the old item correlated with item, the item at index i of
+// the array described by s: the old item with the same x-kubernetes-list-map-keys for arrays with
+// x-kubernetes-list-type map, the old item at index i otherwise. It returns -1 if there is none.
+func correlatedIndex(item interface{}, oldItems []interface{}, i int, s *apiextensions.JSONSchemaProps) int {
+	if s == nil || s.XListType == nil || *s.XListType != "map" || len(s.XListMapKeys) == 0 {
+		if i < len(oldItems) {
+			return i
+		}
+		return -1
+	}
+	m, _ := item.(map[string]interface{})
+	for j, oldItem := range oldItems {
+		oldM, _ := oldItem.(map[string]interface{})
+		correlated := true
+		for _, k := range s.XListMapKeys {
+			if !apiequality.Semantic.DeepEqual(m[k], oldM[k]) {
+				correlated = false
+				break
+			}
+		}
+		if correlated {
+			return j
+		}
+	}
+	return -1
+}
+
+// sortedKeys returns the union of the keys of the maps, sorted.
+func sortedKeys(maps ...map[string]interface{}) []string {
+	keys := sets.NewString()
+	for _, m := range maps {
+		for k := range m {
+			keys.Insert(k)
+		}
+	}
+	return keys.List()
+}
+
+// escapeJSONPointer escapes a reference token of an RFC 6901 JSON pointer.
+func escapeJSONPointer(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
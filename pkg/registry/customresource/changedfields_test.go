@@ -0,0 +1,168 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func TestChangedFieldPatch(t *testing.T) {
+	mapList := "map"
+	s := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"ports": {
+						Type:         "array",
+						XListType:    &mapList,
+						XListMapKeys: []string{"name"},
+						Items: &apiextensions.JSONSchemaPropsOrArray{
+							Schema: &apiextensions.JSONSchemaProps{Type: "object"},
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name      string
+		fieldPath string
+		obj, old  string
+		expected  string
+	}{
+		{"replaced", "spec.image", `{"spec":{"image":"b"}}`, `{"spec":{"image":"a"}}`,
+			`[{"op":"test","path":"/spec/image","value":"a"},{"op":"replace","path":"/spec/image","value":"b"}]`},
+		{"removed", "spec.image", `{"spec":{}}`, `{"spec":{"image":"a"}}`,
+			`[{"op":"test","path":"/spec/image","value":"a"},{"op":"remove","path":"/spec/image"}]`},
+		{"removed parent", "spec.image", `{}`, `{"spec":{"image":"a"}}`,
+			`[{"op":"test","path":"/spec/image","value":"a"},{"op":"remove","path":"/spec/image"}]`},
+		{"added", "spec.image", `{"spec":{"image":"b"}}`, `{"spec":{}}`,
+			`[{"op":"add","path":"/spec/image","value":"b"}]`},
+		{"unchanged", "spec.image", `{"spec":{"image":"a"}}`, `{"spec":{"image":"a"}}`, `null`},
+		{"object", "spec", `{"spec":{"a":1,"b":{"c":2},"e":4}}`, `{"spec":{"a":1,"b":{"c":3},"d":null}}`,
+			`[{"op":"test","path":"/spec/b/c","value":3},{"op":"replace","path":"/spec/b/c","value":2},{"op":"test","path":"/spec/d","value":null},{"op":"remove","path":"/spec/d"},{"op":"add","path":"/spec/e","value":4}]`},
+		{"root", "", `{"spec":{"image":"b"}}`, `{"spec":{"image":"a"}}`,
+			`[{"op":"test","path":"/spec/image","value":"a"},{"op":"replace","path":"/spec/image","value":"b"}]`},
+		{"map list item", "spec.ports[0].port",
+			`{"spec":{"ports":[{"name":"https","port":8443},{"name":"http","port":8080}]}}`,
+			`{"spec":{"ports":[{"name":"http","port":80},{"name":"https","port":443}]}}`,
+			`[{"op":"test","path":"/spec/ports/1/port","value":443},{"op":"replace","path":"/spec/ports/1/port","value":8443}]`},
+		{"array", "spec.args", `{"spec":{"args":["a","b"]}}`, `{"spec":{"args":["a"]}}`,
+			`[{"op":"test","path":"/spec/args","value":["a"]},{"op":"replace","path":"/spec/args","value":["a","b"]}]`},
+		{"key", "spec.labels[a/b~c]", `{"spec":{"labels":{"a/b~c":"y"}}}`, `{"spec":{"labels":{"a/b~c":"x"}}}`,
+			`[{"op":"test","path":"/spec/labels/a~1b~0c","value":"x"},{"op":"replace","path":"/spec/labels/a~1b~0c","value":"y"}]`},
+		{"unresolvable", "spec.ports[2]", `{"spec":{"ports":[]}}`, `{"spec":{"ports":[]}}`, ``},
+		{"invalid", "spec..image", `{}`, `{}`, ``},
+	}
+	for _, tc := range tests {
+		var obj, old interface{}
+		if err := json.Unmarshal([]byte(tc.obj), &obj); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal([]byte(tc.old), &old); err != nil {
+			t.Fatal(err)
+		}
+		patch, ok := changedFieldPatch(tc.fieldPath, obj, old, s)
+		if !ok {
+			if len(tc.expected) > 0 {
+				t.Errorf("%s: unexpected failure to resolve %q", tc.name, tc.fieldPath)
+			}
+			continue
+		}
+		if len(tc.expected) == 0 {
+			t.Errorf("%s: expected %q not to be resolved, got %v", tc.name, tc.fieldPath, patch)
+			continue
+		}
+		got, err := json.Marshal(patch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.expected {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestUpdateWithChangedFields(t *testing.T) {
+	kind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1beta1", Kind: "Noxu"}
+	openAPIV3Schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"image":    {Type: "string", XImmutable: true},
+					"replicas": {Type: "integer", XValidations: apiextensions.ValidationRules{{Rule: "self >= oldSelf"}}},
+					"mode":     {Type: "string", XValidations: apiextensions.ValidationRules{{Rule: "self != 'broken'"}}},
+				},
+			},
+		},
+	}
+	strategy := NewStrategy(nil, StrategyOptions{Kind: kind, Plural: "noxus", Schema: openAPIV3Schema, PreserveUnknownFields: true}, nil, 0)
+	old := newTestCustomResource(1, map[string]interface{}{"image": "busybox", "replicas": int64(3), "mode": "fast"}, nil)
+	obj := newTestCustomResource(1, map[string]interface{}{"image": "nginx", "replicas": int64(2), "mode": "broken"}, nil)
+
+	update := func(ctx genericapirequest.Context) (runtime.Object, bool, error) {
+		// like the store, validating twice because of a conflict
+		strategy.ValidateUpdate(ctx, obj, old)
+		errs := strategy.ValidateUpdate(ctx, obj, old)
+		return nil, false, errors.NewInvalid(kind.GroupKind(), "foo", errs)
+	}
+	_, _, err := updateWithChangedFields(genericapirequest.NewContext(), update)
+	statusErr, ok := err.(*errors.StatusError)
+	if !ok {
+		t.Fatalf("expected a StatusError, got %v", err)
+	}
+
+	var changed []metav1.StatusCause
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		if cause.Type == CauseTypeFieldValueChanged {
+			changed = append(changed, cause)
+		}
+	}
+	expected := []metav1.StatusCause{
+		{
+			Type:    CauseTypeFieldValueChanged,
+			Field:   "spec.image",
+			Message: `[{"op":"test","path":"/spec/image","value":"busybox"},{"op":"replace","path":"/spec/image","value":"nginx"}]`,
+		},
+		{
+			Type:    CauseTypeFieldValueChanged,
+			Field:   "spec.replicas",
+			Message: `[{"op":"test","path":"/spec/replicas","value":3},{"op":"replace","path":"/spec/replicas","value":2}]`,
+		},
+	}
+	if !reflect.DeepEqual(changed, expected) {
+		t.Errorf("expected causes %v, got %v", expected, changed)
+	}
+
+	// without updateWithChangedFields nothing is recorded
+	if errs := strategy.ValidateUpdate(genericapirequest.NewContext(), obj, old); len(errs) != 3 {
+		t.Errorf("expected 3 errors, got %v", errs)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"fmt"
+
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/conversion"
+)
+
+type warningContextKey int
+
+const warningHandlerContextKey warningContextKey = iota
+
+// WithWarningHandler returns a copy of ctx with a handler which receives the warnings returned to
+// the client of the request.
+func WithWarningHandler(ctx genericapirequest.Context, handler func(text string)) genericapirequest.Context {
+	return genericapirequest.WithValue(ctx, warningHandlerContextKey, handler)
+}
+
+func addWarning(ctx genericapirequest.Context, text string) {
+	if handler, ok := ctx.Value(warningHandlerContextKey).(func(string)); ok && handler != nil {
+		handler(text)
+	}
+}
+
+// SetConversionFallback makes Get and List read the custom resources from fallback if their
+// conversion webhook fails. The fallback must return the custom resources in the versions they are
+// persisted in. A warning is passed to the warning handler of the request then. Writes and
+// watches are not affected and fail as before.
+func (r *REST) SetConversionFallback(fallback *REST) {
+	r.conversionFallback = fallback
+}
+
+// Get returns the custom resource with the given name, in the version it is persisted in if the
+// conversion webhook fails and a conversion fallback is set.
+func (r *REST) Get(ctx genericapirequest.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	obj, err := r.Store.Get(ctx, name, options)
+	if err == nil || r.conversionFallback == nil || !conversion.IsWebhookError(err) {
+		return obj, err
+	}
+	obj, fallbackErr := r.conversionFallback.Store.Get(ctx, name, options)
+	if fallbackErr != nil {
+		return nil, err
+	}
+	addWarning(ctx, fmt.Sprintf("the custom resource is served unconverted in the version it is persisted in: %v", err))
+	return obj, nil
+}
+
+// list lists the custom resources, in the versions they are persisted in if the conversion webhook
+// fails and a conversion fallback is set.
+func (r *REST) list(ctx genericapirequest.Context, options *metainternalversion.ListOptions) (runtime.Object, error) {
+	obj, err := r.Store.List(ctx, options)
+	if err == nil || r.conversionFallback == nil || !conversion.IsWebhookError(err) {
+		return obj, err
+	}
+	obj, fallbackErr := r.conversionFallback.Store.List(ctx, options)
+	if fallbackErr != nil {
+		return nil, err
+	}
+	list, ok := obj.(*unstructured.UnstructuredList)
+	if !ok {
+		return nil, fmt.Errorf("unexpected list type %T", obj)
+	}
+	// the items keep their versions, only the list is of the requested kind
+	list.SetGroupVersionKind(r.NewListFunc().GetObjectKind().GroupVersionKind())
+	addWarning(ctx, fmt.Sprintf("the custom resources are served unconverted in the versions they are persisted in: %v", err))
+	return list, nil
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/conversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/apiserver/pkg/storage"
+)
+
+// readStorage returns obj from Get and List, or err if it is set.
+type readStorage struct {
+	storage.Interface
+	obj *unstructured.Unstructured
+	err error
+}
+
+func (s *readStorage) Get(ctx context.Context, key string, resourceVersion string, objPtr runtime.Object, ignoreNotFound bool) error {
+	if s.err != nil {
+		return s.err
+	}
+	objPtr.(*unstructured.Unstructured).Object = s.obj.DeepCopy().Object
+	return nil
+}
+
+func (s *readStorage) List(ctx context.Context, key string, resourceVersion string, p storage.SelectionPredicate, listObj runtime.Object) error {
+	if s.err != nil {
+		return s.err
+	}
+	list := listObj.(*unstructured.UnstructuredList)
+	list.Items = append(list.Items, *s.obj.DeepCopy())
+	return nil
+}
+
+func newReadREST(listKind schema.GroupVersionKind, s storage.Interface) *REST {
+	return &REST{Store: &genericregistry.Store{
+		NewFunc: func() runtime.Object { return &unstructured.Unstructured{} },
+		NewListFunc: func() runtime.Object {
+			ret := &unstructured.UnstructuredList{}
+			ret.SetGroupVersionKind(listKind)
+			return ret
+		},
+		PredicateFunc: func(label labels.Selector, field fields.Selector) storage.SelectionPredicate {
+			return storage.SelectionPredicate{Label: label, Field: field}
+		},
+		QualifiedResource: schema.GroupResource{Group: listKind.Group, Resource: "noxus"},
+		KeyFunc: func(ctx genericapirequest.Context, name string) (string, error) {
+			return "/noxus/" + name, nil
+		},
+		KeyRootFunc: func(ctx genericapirequest.Context) string {
+			return "/noxus"
+		},
+		Storage: s,
+	}}
+}
+
+// newWebhookError returns the error of a conversion webhook which cannot be reached.
+func newWebhookError(t *testing.T) error {
+	server := httptest.NewTLSServer(nil)
+	url := server.URL
+	server.Close()
+	converter, err := conversion.NewConverter(&apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "noxus.mygroup.example.com"},
+		Spec: apiextensions.CustomResourceDefinitionSpec{
+			Group: "mygroup.example.com",
+			Versions: []apiextensions.CustomResourceDefinitionVersion{
+				{Name: "v1beta1", Served: true, Storage: true},
+				{Name: "v1", Served: true},
+			},
+			Conversion: &apiextensions.CustomResourceConversion{
+				Strategy:            apiextensions.WebhookConverter,
+				WebhookClientConfig: &apiextensions.WebhookClientConfig{URL: &url},
+			},
+		},
+	}, conversion.WebhookOptions{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cr := &unstructured.Unstructured{}
+	cr.SetAPIVersion("mygroup.example.com/v1beta1")
+	cr.SetName("foo")
+	_, err = converter.Convert(cr, schema.GroupVersion{Group: "mygroup.example.com", Version: "v1"})
+	if !conversion.IsWebhookError(err) {
+		t.Fatalf("expected a webhook error, got %v", err)
+	}
+	return err
+}
+
+func TestConversionFallback(t *testing.T) {
+	listKind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1", Kind: "NoxuList"}
+	persisted := &unstructured.Unstructured{}
+	persisted.SetAPIVersion("mygroup.example.com/v1beta1")
+	persisted.SetKind("Noxu")
+	persisted.SetName("foo")
+	webhookErr := newWebhookError(t)
+
+	tests := []struct {
+		name        string
+		err         error
+		fallback    bool
+		wantErr     bool
+		wantWarning bool
+	}{
+		{name: "webhook error with fallback", err: webhookErr, fallback: true, wantWarning: true},
+		{name: "webhook error without fallback", err: webhookErr, wantErr: true},
+		{name: "other error with fallback", err: fmt.Errorf("storage failed"), fallback: true, wantErr: true},
+	}
+	for _, tc := range tests {
+		r := newReadREST(listKind, &readStorage{err: tc.err})
+		if tc.fallback {
+			r.SetConversionFallback(newReadREST(listKind, &readStorage{obj: persisted}))
+		}
+		var warnings []string
+		ctx := WithWarningHandler(genericapirequest.NewContext(), func(text string) {
+			warnings = append(warnings, text)
+		})
+
+		obj, err := r.Get(ctx, "foo", &metav1.GetOptions{})
+		if tc.wantErr {
+			if err != tc.err {
+				t.Errorf("%s: expected error %v from get, got %v", tc.name, tc.err, err)
+			}
+		} else if err != nil {
+			t.Errorf("%s: unexpected error from get: %v", tc.name, err)
+		} else if apiVersion := obj.(*unstructured.Unstructured).GetAPIVersion(); apiVersion != "mygroup.example.com/v1beta1" {
+			t.Errorf("%s: expected the persisted version from get, got %q", tc.name, apiVersion)
+		}
+
+		obj, err = r.List(ctx, nil)
+		if tc.wantErr {
+			if err != tc.err {
+				t.Errorf("%s: expected error %v from list, got %v", tc.name, tc.err, err)
+			}
+		} else if err != nil {
+			t.Errorf("%s: unexpected error from list: %v", tc.name, err)
+		} else {
+			list := obj.(*unstructured.UnstructuredList)
+			if gvk := list.GroupVersionKind(); gvk != listKind {
+				t.Errorf("%s: expected list kind %v, got %v", tc.name, listKind, gvk)
+			}
+			if len(list.Items) != 1 || list.Items[0].GetAPIVersion() != "mygroup.example.com/v1beta1" {
+				t.Errorf("%s: expected the persisted item from list, got %v", tc.name, list.Items)
+			}
+		}
+
+		if !tc.wantWarning {
+			if len(warnings) > 0 {
+				t.Errorf("%s: unexpected warnings: %v", tc.name, warnings)
+			}
+			continue
+		}
+		if len(warnings) != 2 {
+			t.Errorf("%s: expected a warning for get and list, got %v", tc.name, warnings)
+			continue
+		}
+		for _, warning := range warnings {
+			if !strings.Contains(warning, "unconverted") || !strings.Contains(warning, webhookErr.Error()) {
+				t.Errorf("%s: unexpected warning %q", tc.name, warning)
+			}
+		}
+	}
+}
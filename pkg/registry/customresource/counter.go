@@ -0,0 +1,246 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/tools/cache"
+)
+
+// counterSyncTimeout is how long the counts wait for the initial list of the custom resources.
+const counterSyncTimeout = 10 * time.Second
+
+// Counter counts the custom resources in each version they are persisted in. The counts are kept
+// up to date by a watch, hence they might lag behind the storage slightly.
+type Counter struct {
+	store    *countingStore
+	resource schema.GroupResource
+	rest     *REST
+}
+
+// NewCounter returns a Counter of the custom resources listed and watched by rest in all
+// namespaces. It counts once Run is called.
+func NewCounter(rest *REST) *Counter {
+	return &Counter{
+		store:    newCountingStore(),
+		resource: rest.Store.QualifiedResource,
+		rest:     rest,
+	}
+}
+
+// Run lists and watches the custom resources until stopCh is closed.
+func (c *Counter) Run(stopCh <-chan struct{}) {
+	ctx := genericapirequest.WithNamespace(genericapirequest.NewContext(), metav1.NamespaceAll)
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return c.rest.Store.List(ctx, &metainternalversion.ListOptions{ResourceVersion: options.ResourceVersion})
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return c.rest.Store.Watch(ctx, &metainternalversion.ListOptions{ResourceVersion: options.ResourceVersion, Watch: true})
+		},
+	}
+	cache.NewNamedReflector(fmt.Sprintf("%s counter", c.resource), lw, &unstructured.Unstructured{}, c.store, 0).Run(stopCh)
+}
+
+// Counts returns the number of custom resources persisted in each version. Versions without
+// persisted custom resources are omitted. It fails if the custom resources were not listed yet.
+func (c *Counter) Counts() (map[string]int64, error) {
+	if err := c.wait(); err != nil {
+		return nil, err
+	}
+	return c.store.counts(), nil
+}
+
+// Total returns the number of custom resources in all versions. It fails if the custom resources
+// were not listed yet.
+func (c *Counter) Total() (int64, error) {
+	if err := c.wait(); err != nil {
+		return 0, err
+	}
+	return c.store.total(), nil
+}
+
+func (c *Counter) wait() error {
+	select {
+	case <-c.store.synced:
+		return nil
+	case <-time.After(counterSyncTimeout):
+		return errors.NewServiceUnavailable(fmt.Sprintf("the %s are not counted yet", c.resource))
+	}
+}
+
+// countingStore is the store of the reflector of a Counter. It keeps the persisted version of each
+// custom resource only, hence List and Get return nothing; the reflector only adds, updates,
+// deletes and replaces.
+type countingStore struct {
+	lock sync.RWMutex
+	// versions are keyed by namespace/name
+	versions map[string]string
+	// byVersion counts the custom resources of each version
+	byVersion map[string]int64
+
+	// synced is closed once the custom resources were listed
+	synced     chan struct{}
+	syncedOnce sync.Once
+}
+
+var _ cache.Store = &countingStore{}
+
+func newCountingStore() *countingStore {
+	return &countingStore{
+		versions:  map[string]string{},
+		byVersion: map[string]int64{},
+		synced:    make(chan struct{}),
+	}
+}
+
+// keyAndVersion returns the key and the persisted version of the custom resource obj.
+func keyAndVersion(obj interface{}) (string, string, error) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return "", "", err
+	}
+	typeAccessor, err := meta.TypeAccessor(obj)
+	if err != nil {
+		return "", "", err
+	}
+	gv, err := schema.ParseGroupVersion(typeAccessor.GetAPIVersion())
+	if err != nil {
+		return "", "", err
+	}
+	return key, gv.Version, nil
+}
+
+// set records the version of the custom resource with key, replacing any version recorded before.
+// The lock must be held.
+func (s *countingStore) set(key, version string) {
+	s.remove(key)
+	s.versions[key] = version
+	s.byVersion[version]++
+}
+
+// remove forgets the custom resource with key. The lock must be held.
+func (s *countingStore) remove(key string) {
+	version, ok := s.versions[key]
+	if !ok {
+		return
+	}
+	delete(s.versions, key)
+	if s.byVersion[version]--; s.byVersion[version] <= 0 {
+		delete(s.byVersion, version)
+	}
+}
+
+func (s *countingStore) Add(obj interface{}) error {
+	key, version, err := keyAndVersion(obj)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.set(key, version)
+	return nil
+}
+
+func (s *countingStore) Update(obj interface{}) error {
+	return s.Add(obj)
+}
+
+func (s *countingStore) Delete(obj interface{}) error {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.remove(key)
+	return nil
+}
+
+func (s *countingStore) Replace(list []interface{}, resourceVersion string) error {
+	versions := make(map[string]string, len(list))
+	for _, obj := range list {
+		key, version, err := keyAndVersion(obj)
+		if err != nil {
+			return err
+		}
+		versions[key] = version
+	}
+	s.lock.Lock()
+	s.versions = map[string]string{}
+	s.byVersion = map[string]int64{}
+	for key, version := range versions {
+		s.set(key, version)
+	}
+	s.lock.Unlock()
+	s.syncedOnce.Do(func() { close(s.synced) })
+	return nil
+}
+
+func (s *countingStore) Resync() error {
+	return nil
+}
+
+func (s *countingStore) List() []interface{} {
+	return nil
+}
+
+func (s *countingStore) ListKeys() []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	keys := make([]string, 0, len(s.versions))
+	for key := range s.versions {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (s *countingStore) Get(obj interface{}) (interface{}, bool, error) {
+	return nil, false, nil
+}
+
+func (s *countingStore) GetByKey(key string) (interface{}, bool, error) {
+	return nil, false, nil
+}
+
+func (s *countingStore) counts() map[string]int64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	counts := make(map[string]int64, len(s.byVersion))
+	for version, count := range s.byVersion {
+		counts[version] = count
+	}
+	return counts
+}
+
+func (s *countingStore) total() int64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return int64(len(s.versions))
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/storage"
+)
+
+// countedStorage lists items and watches the events sent to watcher.
+type countedStorage struct {
+	storage.Interface
+	items   []unstructured.Unstructured
+	watcher *watch.FakeWatcher
+}
+
+func (s *countedStorage) List(ctx context.Context, key string, resourceVersion string, p storage.SelectionPredicate, listObj runtime.Object) error {
+	list := listObj.(*unstructured.UnstructuredList)
+	list.Items = append(list.Items, s.items...)
+	list.SetResourceVersion("1")
+	return nil
+}
+
+func (s *countedStorage) WatchList(ctx context.Context, key string, resourceVersion string, p storage.SelectionPredicate) (watch.Interface, error) {
+	return s.watcher, nil
+}
+
+func newCounted(apiVersion, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind("Noxu")
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func TestCounter(t *testing.T) {
+	listKind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1", Kind: "NoxuList"}
+	s := &countedStorage{
+		items: []unstructured.Unstructured{
+			*newCounted("mygroup.example.com/v1beta1", "default", "foo"),
+			*newCounted("mygroup.example.com/v1", "other", "foo"),
+		},
+		watcher: watch.NewFake(),
+	}
+	counter := NewCounter(newReadREST(listKind, s))
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go counter.Run(stopCh)
+
+	expectCounts := func(step string, expected map[string]int64) {
+		var counts map[string]int64
+		err := wait.Poll(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+			var err error
+			counts, err = counter.Counts()
+			if err != nil {
+				return false, err
+			}
+			return reflect.DeepEqual(counts, expected), nil
+		})
+		if err != nil {
+			t.Fatalf("%s: expected counts %v, got %v: %v", step, expected, counts, err)
+		}
+		total, err := counter.Total()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", step, err)
+		}
+		var expectedTotal int64
+		for _, count := range expected {
+			expectedTotal += count
+		}
+		if total != expectedTotal {
+			t.Errorf("%s: expected a total of %d, got %d", step, expectedTotal, total)
+		}
+	}
+
+	expectCounts("listed", map[string]int64{"v1beta1": 1, "v1": 1})
+	s.watcher.Add(newCounted("mygroup.example.com/v1", "default", "bar"))
+	expectCounts("added", map[string]int64{"v1beta1": 1, "v1": 2})
+	s.watcher.Modify(newCounted("mygroup.example.com/v1", "default", "foo"))
+	expectCounts("migrated", map[string]int64{"v1": 3})
+	s.watcher.Delete(newCounted("mygroup.example.com/v1", "other", "foo"))
+	expectCounts("deleted", map[string]int64{"v1": 2})
+	s.watcher.Delete(newCounted("mygroup.example.com/v1", "other", "foo"))
+	expectCounts("deleted twice", map[string]int64{"v1": 2})
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/metrics"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	schemavalidation "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/validation"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+// customSubresourceStrategy is the update strategy of a custom subresource of custom resources.
+type customSubresourceStrategy struct {
+	CustomResourceDefinitionStorageStrategy
+
+	// fieldPaths are the fields updated through the subresource, split into their path components.
+	fieldPaths [][]string
+	// valuesSchema and celValidator validate the custom resources updated through the subresource
+	// in addition to the schema of the version. They are nil without a subresource schema.
+	valuesSchema *apiextensions.JSONSchemaProps
+	celValidator *cel.Validator
+}
+
+// NewCustomSubresourceStrategy returns the strategy for updates through the given custom subresource
+// of custom resources.
+func NewCustomSubresourceStrategy(strategy CustomResourceDefinitionStorageStrategy, subresource *apiextensions.CustomResourceSubresourceCustom) customSubresourceStrategy {
+	ret := customSubresourceStrategy{CustomResourceDefinitionStorageStrategy: strategy}
+	for _, path := range subresource.FieldPaths {
+		ret.fieldPaths = append(ret.fieldPaths, splitSimpleJSONPath(path))
+	}
+	if subresource.Validation != nil && subresource.Validation.OpenAPIV3Schema != nil {
+		ret.valuesSchema = withoutMetadataSchema(subresource.Validation.OpenAPIV3Schema)
+		ret.celValidator = cel.NewValidator(subresource.Validation.OpenAPIV3Schema, true)
+	}
+	return ret
+}
+
+// PrepareForUpdate only keeps the fields of the field paths of the new object. Everything else is
+// taken from the old object.
+func (a customSubresourceStrategy) PrepareForUpdate(ctx genericapirequest.Context, obj, old runtime.Object) {
+	newCustomResourceObject := obj.(*unstructured.Unstructured)
+	newCustomResource := newCustomResourceObject.UnstructuredContent()
+	values := make([]interface{}, len(a.fieldPaths))
+	found := make([]bool, len(a.fieldPaths))
+	for i, path := range a.fieldPaths {
+		values[i], found[i] = nestedField(newCustomResource, path...)
+	}
+
+	// copy old object into new object. Overriding the resourceVersion in metadata is safe here,
+	// the store has already checked that the new and the old object have the same resourceVersion.
+	oldCustomResourceObject := old.(*unstructured.Unstructured)
+	*newCustomResourceObject = *oldCustomResourceObject.DeepCopy()
+
+	// set the fields of the subresource
+	newCustomResource = newCustomResourceObject.UnstructuredContent()
+	for i, path := range a.fieldPaths {
+		if found[i] {
+			setNestedField(newCustomResource, values[i], path...)
+		} else {
+			removeNestedField(newCustomResource, path...)
+		}
+	}
+
+	a.pruneUnknownFields(obj)
+	a.applyDefaults(obj)
+	a.updateManagedFields(ctx, obj, old)
+
+	// like updates of the custom resource, changes to anything but the status increment the generation
+	oldCustomResource := oldCustomResourceObject.UnstructuredContent()
+	if a.status != nil && !apiequality.Semantic.DeepEqual(withoutMetadataAndStatus(newCustomResource), withoutMetadataAndStatus(oldCustomResource)) {
+		newCustomResourceObject.SetGeneration(oldCustomResourceObject.GetGeneration() + 1)
+	}
+}
+
+// ValidateUpdate validates the updated custom resource against the schema of the version and the
+// schema of the subresource.
+func (a customSubresourceStrategy) ValidateUpdate(ctx genericapirequest.Context, obj, old runtime.Object) field.ErrorList {
+	allErrs := a.CustomResourceDefinitionStorageStrategy.ValidateUpdate(ctx, obj, old)
+	if a.valuesSchema == nil {
+		return allErrs
+	}
+
+	defer metrics.ObserveValidation(a.resource, time.Now())
+	u, ok := obj.(runtime.Unstructured)
+	if !ok {
+		return append(allErrs, field.Invalid(nil, obj, fmt.Sprintf("has type %T. Must be a pointer to an Unstructured type", obj)))
+	}
+	oldU, ok := old.(runtime.Unstructured)
+	if !ok {
+		return append(allErrs, field.Invalid(nil, old, fmt.Sprintf("has type %T. Must be a pointer to an Unstructured type", old)))
+	}
+	allErrs = append(allErrs, schemavalidation.Validate(u.UnstructuredContent(), a.valuesSchema, nil)...)
+	failedRules := cel.RuleFailures{}
+	ruleErrs, budgetExceeded := a.celValidator.ValidateWithFailures(nil, u.UnstructuredContent(), oldU.UnstructuredContent(), a.validator.ruleCostBudget, failedRules)
+	if budgetExceeded {
+		metrics.IncRuleCostBudgetExceeded(a.resource)
+	}
+	recordChangedFields(ctx, true, nil, ruleErrs, failedRules, obj, old, a.valuesSchema)
+	return append(allErrs, ruleErrs...)
+}
+
+// withoutMetadataAndStatus returns a shallow copy of the content of a CustomResource without its
+// metadata and status.
+func withoutMetadataAndStatus(customResource map[string]interface{}) map[string]interface{} {
+	ret := withoutMetadata(customResource)
+	delete(ret, "status")
+	return ret
+}
+
+// removeNestedField removes the field at the given path from obj, if it exists.
+func removeNestedField(obj map[string]interface{}, fields ...string) {
+	m := obj
+	for _, field := range fields[:len(fields)-1] {
+		val, ok := m[field].(map[string]interface{})
+		if !ok {
+			return
+		}
+		m = val
+	}
+	delete(m, fields[len(fields)-1])
+}
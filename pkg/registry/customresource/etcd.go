@@ -17,20 +17,105 @@ limitations under the License.
 package customresource
 
 import (
+	"fmt"
+	"strings"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/watch"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/generic"
 	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/apiserver/pkg/registry/rest"
 )
 
+// CustomResourceStorage includes dummy storage for CustomResources, and their Status and Scale subresources.
+type CustomResourceStorage struct {
+	CustomResource *REST
+	Status         *StatusREST
+	Scale          *ScaleREST
+	// Custom are the storages of the custom subresources, keyed by their names.
+	Custom map[string]*CustomSubresourceREST
+}
+
+// NewStorage returns the storage for the custom resources and their subresources. The Status and
+// Scale storages are nil unless the respective subresource is enabled.
+func NewStorage(resource schema.GroupResource, listKind schema.GroupVersionKind, copier runtime.ObjectCopier, strategy CustomResourceDefinitionStorageStrategy, optsGetter generic.RESTOptionsGetter, subresources *apiextensions.CustomResourceSubresources, tableConvertor rest.TableConvertor, generateNameRetries int) CustomResourceStorage {
+	return newStorage(NewREST(resource, listKind, copier, strategy, optsGetter, tableConvertor, generateNameRetries), copier, strategy, subresources)
+}
+
+// newStorage returns the storage for the custom resources of customResourceREST and their
+// subresources.
+func newStorage(customResourceREST *REST, copier runtime.ObjectCopier, strategy CustomResourceDefinitionStorageStrategy, subresources *apiextensions.CustomResourceSubresources) CustomResourceStorage {
+	s := CustomResourceStorage{
+		CustomResource: customResourceREST,
+	}
+
+	if subresources != nil && subresources.Status != nil {
+		statusStore := *customResourceREST.Store
+		statusStore.UpdateStrategy = NewStatusStrategy(strategy)
+		s.Status = &StatusREST{store: &statusStore}
+	}
+
+	if subresources != nil && subresources.Scale != nil {
+		var labelSelectorPath string
+		if subresources.Scale.LabelSelectorPath != nil {
+			labelSelectorPath = *subresources.Scale.LabelSelectorPath
+		}
+
+		s.Scale = &ScaleREST{
+			store:              customResourceREST.Store,
+			copier:             copier,
+			specReplicasPath:   subresources.Scale.SpecReplicasPath,
+			statusReplicasPath: subresources.Scale.StatusReplicasPath,
+			labelSelectorPath:  labelSelectorPath,
+		}
+	}
+
+	if subresources != nil && len(subresources.Custom) > 0 {
+		s.Custom = make(map[string]*CustomSubresourceREST, len(subresources.Custom))
+		for i := range subresources.Custom {
+			customStore := *customResourceREST.Store
+			customStore.UpdateStrategy = NewCustomSubresourceStrategy(strategy, &subresources.Custom[i])
+			s.Custom[subresources.Custom[i].Name] = &CustomSubresourceREST{store: &customStore}
+		}
+	}
+
+	return s
+}
+
 // rest implements a RESTStorage for API services against etcd
 type REST struct {
 	*genericregistry.Store
+
+	// generateNameRetries is how often a new name is generated from metadata.generateName if the
+	// generated name is already taken.
+	generateNameRetries int
+	// listSnapshots holds the lists which the later pages of paginated lists are served from. It is
+	// optional.
+	listSnapshots *listSnapshots
+	// kind is the kind of the custom resources, set on the bookmarks of watches.
+	kind schema.GroupVersionKind
+	// conversionFallback serves reads if the conversion webhook fails. It is optional.
+	conversionFallback *REST
+	// limits bound the number and size of the custom resources.
+	limits Limits
+	// counter counts the custom resources for limits.MaxObjects. It is optional.
+	counter *Counter
 }
 
-// NewREST returns a RESTStorage object that will work against API services.
-func NewREST(resource schema.GroupResource, listKind schema.GroupVersionKind, copier runtime.ObjectCopier, strategy CustomResourceDefinitionStorageStrategy, optsGetter generic.RESTOptionsGetter) *REST {
+// NewREST returns a RESTStorage object that will work against API services. If generateNameRetries
+// is positive, creations with metadata.generateName are retried that often with a new name on
+// name conflicts.
+func NewREST(resource schema.GroupResource, listKind schema.GroupVersionKind, copier runtime.ObjectCopier, strategy CustomResourceDefinitionStorageStrategy, optsGetter generic.RESTOptionsGetter, tableConvertor rest.TableConvertor, generateNameRetries int) *REST {
 	store := &genericregistry.Store{
 		Copier:  copier,
 		NewFunc: func() runtime.Object { return &unstructured.Unstructured{} },
@@ -46,10 +131,376 @@ func NewREST(resource schema.GroupResource, listKind schema.GroupVersionKind, co
 		CreateStrategy: strategy,
 		UpdateStrategy: strategy,
 		DeleteStrategy: strategy,
+
+		TableConvertor: tableConvertor,
 	}
 	options := &generic.StoreOptions{RESTOptions: optsGetter, AttrFunc: strategy.GetAttrs}
+	if len(strategy.selectableFields) > 0 {
+		options.TriggerFunc = strategy.triggerFunc
+	}
 	if err := store.CompleteWithOptions(options); err != nil {
 		panic(err) // TODO: Propagate error up
 	}
-	return &REST{store}
+	kind := listKind
+	kind.Kind = strings.TrimSuffix(listKind.Kind, "List")
+	r := &REST{Store: store, generateNameRetries: generateNameRetries, listSnapshots: newListSnapshots(), kind: kind}
+	r.limitStorageSize()
+	return r
+}
+
+// Create creates the custom resource if it is within the limits. If its name is generated from
+// metadata.generateName and turns out to be taken, the creation is retried with a new name up to
+// generateNameRetries times.
+func (r *REST) Create(ctx genericapirequest.Context, obj runtime.Object, includeUninitialized bool) (runtime.Object, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkCreateLimits(ctx, obj); err != nil {
+		return nil, err
+	}
+	if len(accessor.GetName()) > 0 || len(accessor.GetGenerateName()) == 0 {
+		return r.Store.Create(ctx, obj, includeUninitialized)
+	}
+	for i := 0; ; i++ {
+		out, err := r.Store.Create(ctx, obj, includeUninitialized)
+		// a taken generated name is reported as ServerTimeout, see rest.CheckGeneratedNameError
+		if !errors.IsServerTimeout(err) || i >= r.generateNameRetries {
+			return out, err
+		}
+		accessor.SetName("")
+	}
+}
+
+// Update updates the custom resource. If the update changes immutable fields or fields protected
+// by transition rules, the changes are added to the causes of the Invalid error.
+func (r *REST) Update(ctx genericapirequest.Context, name string, objInfo rest.UpdatedObjectInfo) (runtime.Object, bool, error) {
+	return updateWithChangedFields(ctx, func(ctx genericapirequest.Context) (runtime.Object, bool, error) {
+		return r.Store.Update(ctx, name, objInfo)
+	})
+}
+
+// List lists the custom resources. If the context carries pagination parameters, at most limit
+// custom resources are returned, ordered by namespace and name, together with a continue token for
+// the next page. Later pages are served from a snapshot of the first page's list. If the snapshot
+// was dropped, they are listed in the resourceVersion of the first page or a newer one, from the
+// watch cache if it is enabled. Hence, custom resources created or deleted while paging might or
+// might not be returned then. With resourceVersionMatch=Exact, the custom resources are listed in
+// the given resourceVersion, or the resourceVersion is reported as gone.
+func (r *REST) List(ctx genericapirequest.Context, options *metainternalversion.ListOptions) (runtime.Object, error) {
+	parameters := listParametersFrom(ctx)
+	limit, token, err := parameters.parse(options)
+	if err != nil {
+		return nil, err
+	}
+	exact := parameters.ResourceVersionMatch == resourceVersionMatchExact
+	if limit == 0 && len(token.ResourceVersion) == 0 && !exact {
+		return r.list(ctx, options)
+	}
+
+	listOptions := metainternalversion.ListOptions{}
+	if options != nil {
+		listOptions = *options
+	}
+	resourceVersion := token.ResourceVersion
+	if len(resourceVersion) > 0 {
+		listOptions.ResourceVersion = resourceVersion
+	} else if exact {
+		resourceVersion = listOptions.ResourceVersion
+	}
+	if len(resourceVersion) > 0 && r.listSnapshots != nil {
+		if list, ok := r.listSnapshots.get(ctx, &listOptions, resourceVersion); ok && (!exact || list.GetResourceVersion() == resourceVersion) {
+			return paginate(list, limit, token)
+		}
+	}
+
+	obj, err := r.list(ctx, &listOptions)
+	if err != nil {
+		return nil, err
+	}
+	list, ok := obj.(*unstructured.UnstructuredList)
+	if !ok {
+		return nil, fmt.Errorf("unexpected list type %T", obj)
+	}
+	if exact && list.GetResourceVersion() != resourceVersion {
+		return nil, errors.NewGone(fmt.Sprintf("the custom resources cannot be listed in resourceVersion %s anymore, only in %s", resourceVersion, list.GetResourceVersion()))
+	}
+	if len(resourceVersion) == 0 {
+		resourceVersion = list.GetResourceVersion()
+	}
+	sortList(list)
+	page, err := paginate(list, limit, token)
+	if err != nil {
+		return nil, err
+	}
+	metadata, _ := page.Object["metadata"].(map[string]interface{})
+	if _, more := metadata["continue"]; more && r.listSnapshots != nil {
+		r.listSnapshots.add(ctx, &listOptions, resourceVersion, list)
+	}
+	return page, nil
+}
+
+// Watch watches the custom resources. If the context carries sendInitialEvents=true, the custom
+// resources listed with the options are sent as ADDED events first, followed by a bookmark with
+// the annotation k8s.io/initial-events-end, before the events of the watch. If it carries
+// allowWatchBookmarks=true, watches of a namespace periodically send bookmarks if the watch cache
+// is enabled.
+func (r *REST) Watch(ctx genericapirequest.Context, options *metainternalversion.ListOptions) (watch.Interface, error) {
+	parameters := listParametersFrom(ctx)
+	send, err := parameters.sendInitialEvents()
+	if err != nil {
+		return nil, err
+	}
+	bookmarks, err := parameters.allowWatchBookmarks()
+	if err != nil {
+		return nil, err
+	}
+	if !send {
+		var resourceVersion string
+		if options != nil {
+			resourceVersion = options.ResourceVersion
+		}
+		return r.watch(ctx, options, bookmarks, 0, resourceVersion)
+	}
+	if w, ok, err := r.watchCached(ctx, options, bookmarks); err != nil || ok {
+		return w, err
+	}
+	list := func(options *metainternalversion.ListOptions) (runtime.Object, error) {
+		return r.list(ctx, options)
+	}
+	watchFrom := func(options *metainternalversion.ListOptions) (watch.Interface, error) {
+		return r.watch(ctx, options, bookmarks, 0, options.ResourceVersion)
+	}
+	return watchList(list, watchFrom, options, r.kind)
+}
+
+// StatusREST implements the REST endpoint for changing the status of a CustomResource
+type StatusREST struct {
+	store *genericregistry.Store
+}
+
+var _ = rest.Patcher(&StatusREST{})
+
+func (r *StatusREST) New() runtime.Object {
+	return &unstructured.Unstructured{}
+}
+
+// Get retrieves the object from the storage. It is required to support Patch.
+func (r *StatusREST) Get(ctx genericapirequest.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	return r.store.Get(ctx, name, options)
+}
+
+// Update alters the status subset of an object. Changes of immutable fields or fields protected
+// by transition rules are added to the causes of the Invalid error.
+func (r *StatusREST) Update(ctx genericapirequest.Context, name string, objInfo rest.UpdatedObjectInfo) (runtime.Object, bool, error) {
+	return updateWithChangedFields(ctx, func(ctx genericapirequest.Context) (runtime.Object, bool, error) {
+		return r.store.Update(ctx, name, objInfo)
+	})
+}
+
+// CustomSubresourceREST implements the REST endpoint of a custom subresource, which changes the
+// fields of the subresource of a CustomResource.
+type CustomSubresourceREST struct {
+	store *genericregistry.Store
+}
+
+var _ = rest.Patcher(&CustomSubresourceREST{})
+
+func (r *CustomSubresourceREST) New() runtime.Object {
+	return &unstructured.Unstructured{}
+}
+
+// Get retrieves the object from the storage. It is required to support Patch.
+func (r *CustomSubresourceREST) Get(ctx genericapirequest.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	return r.store.Get(ctx, name, options)
+}
+
+// Update alters the fields of the subresource of an object. Changes of immutable fields or fields protected
+// by transition rules are added to the causes of the Invalid error.
+func (r *CustomSubresourceREST) Update(ctx genericapirequest.Context, name string, objInfo rest.UpdatedObjectInfo) (runtime.Object, bool, error) {
+	return updateWithChangedFields(ctx, func(ctx genericapirequest.Context) (runtime.Object, bool, error) {
+		return r.store.Update(ctx, name, objInfo)
+	})
+}
+
+// ScaleREST implements a Scale for CustomResources.
+type ScaleREST struct {
+	store              *genericregistry.Store
+	copier             runtime.ObjectCopier
+	specReplicasPath   string
+	statusReplicasPath string
+	labelSelectorPath  string
+}
+
+// ScaleREST implements Patcher
+var _ = rest.Patcher(&ScaleREST{})
+
+// New creates a new Scale object
+func (r *ScaleREST) New() runtime.Object {
+	return &autoscalingv1.Scale{}
+}
+
+func (r *ScaleREST) Get(ctx genericapirequest.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	obj, err := r.store.Get(ctx, name, options)
+	if err != nil {
+		return nil, err
+	}
+	cr := obj.(*unstructured.Unstructured)
+
+	scaleObject, err := scaleFromCustomResource(cr, r.specReplicasPath, r.statusReplicasPath, r.labelSelectorPath)
+	if err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	return scaleObject, nil
+}
+
+func (r *ScaleREST) Update(ctx genericapirequest.Context, name string, objInfo rest.UpdatedObjectInfo) (runtime.Object, bool, error) {
+	obj, err := r.store.Get(ctx, name, &metav1.GetOptions{})
+	if err != nil {
+		return nil, false, err
+	}
+	cr := obj.(*unstructured.Unstructured)
+
+	oldScale, err := scaleFromCustomResource(cr, r.specReplicasPath, r.statusReplicasPath, r.labelSelectorPath)
+	if err != nil {
+		return nil, false, errors.NewInternalError(err)
+	}
+
+	obj, err = objInfo.UpdatedObject(ctx, oldScale)
+	if err != nil {
+		return nil, false, err
+	}
+	if obj == nil {
+		return nil, false, errors.NewBadRequest("nil update passed to Scale")
+	}
+
+	scale, ok := obj.(*autoscalingv1.Scale)
+	if !ok {
+		return nil, false, errors.NewBadRequest(fmt.Sprintf("wrong object passed to Scale update: %v", obj))
+	}
+
+	if errs := validateScale(scale); len(errs) > 0 {
+		return nil, false, errors.NewInvalid(schema.GroupKind{Group: autoscalingv1.GroupName, Kind: "Scale"}, scale.Name, errs)
+	}
+
+	setNestedField(cr.Object, int64(scale.Spec.Replicas), splitSimpleJSONPath(r.specReplicasPath)...)
+	cr.SetResourceVersion(scale.ResourceVersion)
+
+	obj, _, err = updateWithChangedFields(ctx, func(ctx genericapirequest.Context) (runtime.Object, bool, error) {
+		return r.store.Update(ctx, cr.GetName(), rest.DefaultUpdatedObjectInfo(cr, r.copier))
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	cr = obj.(*unstructured.Unstructured)
+
+	newScale, err := scaleFromCustomResource(cr, r.specReplicasPath, r.statusReplicasPath, r.labelSelectorPath)
+	if err != nil {
+		return nil, false, errors.NewInternalError(err)
+	}
+	return newScale, false, nil
+}
+
+// scaleFromCustomResource returns a scale subresource for a customresource.
+func scaleFromCustomResource(cr *unstructured.Unstructured, specReplicasPath, statusReplicasPath, labelSelectorPath string) (*autoscalingv1.Scale, error) {
+	specReplicas, found, err := nestedInt64(cr.Object, splitSimpleJSONPath(specReplicasPath)...)
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return nil, fmt.Errorf("%s not found in %s/%s", specReplicasPath, cr.GetNamespace(), cr.GetName())
+	}
+
+	statusReplicas, _, err := nestedInt64(cr.Object, splitSimpleJSONPath(statusReplicasPath)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var labelSelector string
+	if len(labelSelectorPath) > 0 {
+		value, found := nestedField(cr.Object, splitSimpleJSONPath(labelSelectorPath)...)
+		if found {
+			s, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s accessor error: %v is of the type %T, expected string", labelSelectorPath, value, value)
+			}
+			labelSelector = s
+		}
+	}
+
+	scale := &autoscalingv1.Scale{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              cr.GetName(),
+			Namespace:         cr.GetNamespace(),
+			UID:               cr.GetUID(),
+			ResourceVersion:   cr.GetResourceVersion(),
+			CreationTimestamp: cr.GetCreationTimestamp(),
+		},
+		Spec: autoscalingv1.ScaleSpec{
+			Replicas: int32(specReplicas),
+		},
+		Status: autoscalingv1.ScaleStatus{
+			Replicas: int32(statusReplicas),
+			Selector: labelSelector,
+		},
+	}
+
+	return scale, nil
+}
+
+func validateScale(scale *autoscalingv1.Scale) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if scale.Spec.Replicas < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "replicas"), scale.Spec.Replicas, "must be greater than or equal to 0"))
+	}
+	return allErrs
+}
+
+// splitSimpleJSONPath splits a JSON path without array notation like .spec.replicas into its fields.
+func splitSimpleJSONPath(path string) []string {
+	return strings.Split(strings.TrimPrefix(path, "."), ".")
+}
+
+func nestedField(obj map[string]interface{}, fields ...string) (interface{}, bool) {
+	var val interface{} = obj
+	for _, field := range fields {
+		m, ok := val.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		val, ok = m[field]
+		if !ok {
+			return nil, false
+		}
+	}
+	return val, true
+}
+
+func nestedInt64(obj map[string]interface{}, fields ...string) (int64, bool, error) {
+	val, found := nestedField(obj, fields...)
+	if !found {
+		return 0, false, nil
+	}
+	switch val := val.(type) {
+	case int64:
+		return val, true, nil
+	case float64:
+		if val == float64(int64(val)) {
+			return int64(val), true, nil
+		}
+	}
+	return 0, true, fmt.Errorf(".%s accessor error: %v is of the type %T, expected int64", strings.Join(fields, "."), val, val)
+}
+
+func setNestedField(obj map[string]interface{}, value interface{}, fields ...string) {
+	m := obj
+	for _, field := range fields[:len(fields)-1] {
+		if val, ok := m[field].(map[string]interface{}); ok {
+			m = val
+		} else {
+			newVal := make(map[string]interface{})
+			m[field] = newVal
+			m = newVal
+		}
+	}
+	m[fields[len(fields)-1]] = value
 }
@@ -0,0 +1,232 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/apiserver/pkg/storage"
+)
+
+func TestScaleFromCustomResource(t *testing.T) {
+	newCR := func(spec, status map[string]interface{}) *unstructured.Unstructured {
+		cr := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "mygroup.example.com/v1beta1",
+			"kind":       "Noxu",
+			"metadata": map[string]interface{}{
+				"name":            "foo",
+				"namespace":       "bar",
+				"uid":             "1234",
+				"resourceVersion": "42",
+			},
+		}}
+		if spec != nil {
+			cr.Object["spec"] = spec
+		}
+		if status != nil {
+			cr.Object["status"] = status
+		}
+		return cr
+	}
+	meta := metav1.ObjectMeta{Name: "foo", Namespace: "bar", UID: "1234", ResourceVersion: "42"}
+
+	tests := []struct {
+		name              string
+		cr                *unstructured.Unstructured
+		labelSelectorPath string
+		expected          *autoscalingv1.Scale
+		wantErr           bool
+	}{
+		{
+			name:              "all fields",
+			cr:                newCR(map[string]interface{}{"replicas": int64(3)}, map[string]interface{}{"replicas": int64(2), "labelSelector": "a=b"}),
+			labelSelectorPath: ".status.labelSelector",
+			expected: &autoscalingv1.Scale{
+				ObjectMeta: meta,
+				Spec:       autoscalingv1.ScaleSpec{Replicas: 3},
+				Status:     autoscalingv1.ScaleStatus{Replicas: 2, Selector: "a=b"},
+			},
+		},
+		{
+			name: "missing status",
+			cr:   newCR(map[string]interface{}{"replicas": float64(3)}, nil),
+			expected: &autoscalingv1.Scale{
+				ObjectMeta: meta,
+				Spec:       autoscalingv1.ScaleSpec{Replicas: 3},
+			},
+		},
+		{
+			name:    "missing spec replicas",
+			cr:      newCR(nil, map[string]interface{}{"replicas": int64(2)}),
+			wantErr: true,
+		},
+		{
+			name:    "wrong spec replicas type",
+			cr:      newCR(map[string]interface{}{"replicas": "3"}, nil),
+			wantErr: true,
+		},
+		{
+			name:              "wrong label selector type",
+			cr:                newCR(map[string]interface{}{"replicas": int64(3)}, map[string]interface{}{"labelSelector": int64(1)}),
+			labelSelectorPath: ".status.labelSelector",
+			wantErr:           true,
+		},
+	}
+
+	for _, tc := range tests {
+		scale, err := scaleFromCustomResource(tc.cr, ".spec.replicas", ".status.replicas", tc.labelSelectorPath)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected error, got %#v", tc.name, scale)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(scale, tc.expected) {
+			t.Errorf("%s: expected %#v, got %#v", tc.name, tc.expected, scale)
+		}
+	}
+}
+
+func TestSetNestedField(t *testing.T) {
+	obj := map[string]interface{}{"spec": map[string]interface{}{"other": "value"}}
+	setNestedField(obj, int64(5), splitSimpleJSONPath(".spec.replicas")...)
+	setNestedField(obj, int64(6), splitSimpleJSONPath(".spec.nested.replicas")...)
+
+	expected := map[string]interface{}{"spec": map[string]interface{}{
+		"other":    "value",
+		"replicas": int64(5),
+		"nested":   map[string]interface{}{"replicas": int64(6)},
+	}}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Errorf("expected %v, got %v", expected, obj)
+	}
+}
+
+// unstructuredTyper returns the kind set in unstructured objects.
+type unstructuredTyper struct{}
+
+func (unstructuredTyper) ObjectKinds(obj runtime.Object) ([]schema.GroupVersionKind, bool, error) {
+	return []schema.GroupVersionKind{obj.GetObjectKind().GroupVersionKind()}, false, nil
+}
+
+func (unstructuredTyper) Recognizes(gvk schema.GroupVersionKind) bool {
+	return true
+}
+
+// conflictingStorage reports the first conflicts names as taken.
+type conflictingStorage struct {
+	storage.Interface
+	conflicts int
+	names     []string
+}
+
+func (s *conflictingStorage) Create(ctx context.Context, key string, obj, out runtime.Object, ttl uint64) error {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	s.names = append(s.names, accessor.GetName())
+	if len(s.names) <= s.conflicts {
+		return storage.NewKeyExistsError(key, 0)
+	}
+	out.(*unstructured.Unstructured).Object = obj.(*unstructured.Unstructured).UnstructuredContent()
+	return nil
+}
+
+func TestCreateRetriesGeneratedNames(t *testing.T) {
+	kind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1beta1", Kind: "Noxu"}
+	tests := []struct {
+		name                string
+		generateNameRetries int
+		conflicts           int
+		setName             bool
+		expectedAttempts    int
+		wantErr             bool
+	}{
+		{name: "no conflict", generateNameRetries: 3, expectedAttempts: 1},
+		{name: "conflicts within retries", generateNameRetries: 3, conflicts: 3, expectedAttempts: 4},
+		{name: "more conflicts than retries", generateNameRetries: 3, conflicts: 4, expectedAttempts: 4, wantErr: true},
+		{name: "retries disabled", conflicts: 1, expectedAttempts: 1, wantErr: true},
+		{name: "explicit name", generateNameRetries: 3, conflicts: 1, setName: true, expectedAttempts: 1, wantErr: true},
+	}
+	for _, tc := range tests {
+		strategy := NewStrategy(unstructuredTyper{}, StrategyOptions{Kind: kind, Plural: "noxus", PreserveUnknownFields: true}, nil, 0)
+		s := &conflictingStorage{conflicts: tc.conflicts}
+		r := &REST{
+			Store: &genericregistry.Store{
+				NewFunc:           func() runtime.Object { return &unstructured.Unstructured{} },
+				QualifiedResource: schema.GroupResource{Group: kind.Group, Resource: "noxus"},
+				KeyFunc: func(ctx genericapirequest.Context, name string) (string, error) {
+					return "/noxus/" + name, nil
+				},
+				ObjectNameFunc: func(obj runtime.Object) (string, error) {
+					accessor, err := meta.Accessor(obj)
+					if err != nil {
+						return "", err
+					}
+					return accessor.GetName(), nil
+				},
+				CreateStrategy: strategy,
+				Storage:        s,
+			},
+			generateNameRetries: tc.generateNameRetries,
+		}
+
+		cr := &unstructured.Unstructured{}
+		cr.SetGroupVersionKind(kind)
+		cr.SetGenerateName("foo-")
+		if tc.setName {
+			cr.SetName("foo-bar")
+		}
+		_, err := r.Create(genericapirequest.NewContext(), cr, true)
+		if tc.wantErr && err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+		if !tc.wantErr && err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		}
+		if len(s.names) != tc.expectedAttempts {
+			t.Errorf("%s: expected %d attempts, got %d: %v", tc.name, tc.expectedAttempts, len(s.names), s.names)
+		}
+		for i, name := range s.names {
+			if !strings.HasPrefix(name, "foo-") {
+				t.Errorf("%s: unexpected name %q", tc.name, name)
+			}
+			for _, other := range s.names[:i] {
+				if name == other {
+					t.Errorf("%s: name %q was not regenerated: %v", tc.name, name, s.names)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/net/context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/storage"
+)
+
+// statusReasonRequestEntityTooLarge is the reason of the error rejecting custom resources which
+// exceed the size limit.
+const statusReasonRequestEntityTooLarge metav1.StatusReason = "RequestEntityTooLarge"
+
+// Limits bound the custom resources of a CustomResourceDefinition. Zero values are not enforced.
+type Limits struct {
+	// MaxObjects is the maximum number of custom resources in all namespaces. It is checked against
+	// a count kept up to date by a watch, hence concurrent creations might exceed it slightly.
+	MaxObjects int64
+	// MaxObjectBytes is the maximum size of the JSON encoding of a custom resource as it is stored,
+	// i.e. including defaults and managedFields. It applies to writes through subresources as well.
+	MaxObjectBytes int64
+}
+
+// SetLimits sets the limits enforced when custom resources are created or updated. counter counts
+// the custom resources for MaxObjects.
+func (r *REST) SetLimits(limits Limits, counter *Counter) {
+	r.limits = limits
+	r.counter = counter
+}
+
+// checkCreateLimits returns a 403 error if the maximum number of custom resources exists already.
+// The size limit is enforced by the storage.
+func (r *REST) checkCreateLimits(ctx genericapirequest.Context, obj runtime.Object) error {
+	if r.limits.MaxObjects <= 0 || r.counter == nil {
+		return nil
+	}
+	count, err := r.counter.Total()
+	if err != nil {
+		return err
+	}
+	if count >= r.limits.MaxObjects {
+		name := ""
+		if accessor, err := meta.Accessor(obj); err == nil {
+			name = accessor.GetName()
+		}
+		return errors.NewForbidden(r.Store.QualifiedResource, name, fmt.Errorf("the CustomResourceDefinition allows at most %d custom resources", r.limits.MaxObjects))
+	}
+	return nil
+}
+
+// checkSize returns a 413 error if the JSON encoding of obj exceeds the size limit.
+func (r *REST) checkSize(obj runtime.Object) error {
+	if r.limits.MaxObjectBytes <= 0 {
+		return nil
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("unexpected object type %T", obj)
+	}
+	data, err := json.Marshal(u.Object)
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) <= r.limits.MaxObjectBytes {
+		return nil
+	}
+	return &errors.StatusError{ErrStatus: metav1.Status{
+		Status: metav1.StatusFailure,
+		Code:   http.StatusRequestEntityTooLarge,
+		Reason: statusReasonRequestEntityTooLarge,
+		Details: &metav1.StatusDetails{
+			Group: r.Store.QualifiedResource.Group,
+			Kind:  r.Store.QualifiedResource.Resource,
+			Name:  u.GetName(),
+		},
+		Message: fmt.Sprintf("the custom resource is %d bytes, the CustomResourceDefinition allows at most %d", len(data), r.limits.MaxObjectBytes),
+	}}
+}
+
+// limitStorageSize makes the storage of r reject custom resources which exceed the size limit.
+// Since the subresource storages share the storage of r, updates through the status and custom
+// subresources are limited as well.
+func (r *REST) limitStorageSize() {
+	r.Store.Storage = &sizeLimitedStorage{Interface: r.Store.Storage, rest: r}
+}
+
+// sizeLimitedStorage checks the size of the custom resources which are written to the storage, i.e.
+// after defaulting, the initial status and managedFields were set by the strategy.
+type sizeLimitedStorage struct {
+	storage.Interface
+	rest *REST
+}
+
+func (s *sizeLimitedStorage) Create(ctx context.Context, key string, obj, out runtime.Object, ttl uint64) error {
+	if err := s.rest.checkSize(obj); err != nil {
+		return err
+	}
+	return s.Interface.Create(ctx, key, obj, out, ttl)
+}
+
+func (s *sizeLimitedStorage) GuaranteedUpdate(ctx context.Context, key string, ptrToType runtime.Object, ignoreNotFound bool, preconditions *storage.Preconditions, tryUpdate storage.UpdateFunc, suggestion ...runtime.Object) error {
+	return s.Interface.GuaranteedUpdate(ctx, key, ptrToType, ignoreNotFound, preconditions, func(input runtime.Object, res storage.ResponseMeta) (runtime.Object, *uint64, error) {
+		obj, ttl, err := tryUpdate(input, res)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := s.rest.checkSize(obj); err != nil {
+			return nil, nil, err
+		}
+		return obj, ttl, nil
+	}, suggestion...)
+}
+
+// unwrapStorage returns the storage without the size limit.
+func unwrapStorage(s storage.Interface) storage.Interface {
+	if limited, ok := s.(*sizeLimitedStorage); ok {
+		return limited.Interface
+	}
+	return s
+}
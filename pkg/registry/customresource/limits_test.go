@@ -0,0 +1,227 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/storage"
+	"k8s.io/apiserver/pkg/storage/etcd"
+)
+
+func TestCheckCreateLimits(t *testing.T) {
+	listKind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1", Kind: "NoxuList"}
+	existing := &unstructured.Unstructured{}
+	existing.SetAPIVersion("mygroup.example.com/v1")
+	existing.SetKind("Noxu")
+	existing.SetName("existing")
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("mygroup.example.com/v1")
+	obj.SetKind("Noxu")
+	obj.SetName("foo")
+
+	tests := []struct {
+		name   string
+		limits Limits
+		code   int32
+	}{
+		{name: "no limits", limits: Limits{}},
+		{name: "below the object limit", limits: Limits{MaxObjects: 2}},
+		{name: "object limit reached", limits: Limits{MaxObjects: 1}, code: http.StatusForbidden},
+	}
+	for _, tc := range tests {
+		r := newReadREST(listKind, &readStorage{obj: existing})
+		counter := NewCounter(r)
+		if err := counter.store.Replace([]interface{}{existing}, "1"); err != nil {
+			t.Fatal(err)
+		}
+		r.SetLimits(tc.limits, counter)
+		err := r.checkCreateLimits(genericapirequest.WithNamespace(genericapirequest.NewContext(), "default"), obj)
+		if tc.code == 0 {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+		statusErr, ok := err.(*errors.StatusError)
+		if !ok {
+			t.Errorf("%s: expected a status error, got %v", tc.name, err)
+			continue
+		}
+		if statusErr.ErrStatus.Code != tc.code {
+			t.Errorf("%s: expected code %d, got %d: %v", tc.name, tc.code, statusErr.ErrStatus.Code, err)
+		}
+	}
+}
+
+// updatingStorage stores a single object, which Create and GuaranteedUpdate replace.
+type updatingStorage struct {
+	storage.Interface
+	obj *unstructured.Unstructured
+}
+
+func (s *updatingStorage) Versioner() storage.Versioner {
+	return etcd.APIObjectVersioner{}
+}
+
+func (s *updatingStorage) Create(ctx context.Context, key string, obj, out runtime.Object, ttl uint64) error {
+	s.obj = obj.(*unstructured.Unstructured).DeepCopy()
+	s.obj.SetResourceVersion("1")
+	out.(*unstructured.Unstructured).Object = s.obj.DeepCopy().Object
+	return nil
+}
+
+func (s *updatingStorage) GuaranteedUpdate(ctx context.Context, key string, ptrToType runtime.Object, ignoreNotFound bool, preconditions *storage.Preconditions, tryUpdate storage.UpdateFunc, suggestion ...runtime.Object) error {
+	obj, _, err := tryUpdate(s.obj.DeepCopy(), storage.ResponseMeta{ResourceVersion: 1})
+	if err != nil {
+		return err
+	}
+	s.obj = obj.(*unstructured.Unstructured).DeepCopy()
+	ptrToType.(*unstructured.Unstructured).Object = s.obj.DeepCopy().Object
+	return nil
+}
+
+// unstructuredCopier deep copies unstructured objects.
+type unstructuredCopier struct{}
+
+func (unstructuredCopier) Copy(obj runtime.Object) (runtime.Object, error) {
+	return obj.DeepCopyObject(), nil
+}
+
+func TestSizeLimit(t *testing.T) {
+	kind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1", Kind: "Noxu"}
+	listKind := kind
+	listKind.Kind = "NoxuList"
+	subresources := &apiextensions.CustomResourceSubresources{
+		Status: &apiextensions.CustomResourceSubresourceStatus{},
+		Custom: []apiextensions.CustomResourceSubresourceCustom{{Name: "approval", FieldPaths: []string{".spec.approved"}}},
+	}
+	strategy := NewStrategy(unstructuredTyper{}, StrategyOptions{NamespaceScoped: true, Kind: kind, Plural: "noxus", PreserveUnknownFields: true, Status: subresources.Status}, nil, 0)
+	newCustomResource := func(field string, value interface{}) *unstructured.Unstructured {
+		cr := &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{}, "status": map[string]interface{}{}}}
+		cr.SetGroupVersionKind(kind)
+		cr.SetNamespace("default")
+		cr.SetName("foo")
+		cr.SetResourceVersion("1")
+		if len(field) > 0 {
+			setNestedField(cr.Object, value, field, "value")
+		}
+		return cr
+	}
+	large := strings.Repeat("x", 1024)
+
+	tests := []struct {
+		name   string
+		update func(s CustomResourceStorage, ctx genericapirequest.Context, cr *unstructured.Unstructured) error
+		cr     *unstructured.Unstructured
+		code   int32
+	}{
+		{
+			name: "create",
+			update: func(s CustomResourceStorage, ctx genericapirequest.Context, cr *unstructured.Unstructured) error {
+				_, err := s.CustomResource.Create(ctx, cr, false)
+				return err
+			},
+			cr:   newCustomResource("spec", large),
+			code: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "update",
+			update: func(s CustomResourceStorage, ctx genericapirequest.Context, cr *unstructured.Unstructured) error {
+				_, _, err := s.CustomResource.Update(ctx, cr.GetName(), rest.DefaultUpdatedObjectInfo(cr, unstructuredCopier{}))
+				return err
+			},
+			cr:   newCustomResource("spec", large),
+			code: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "small status update",
+			update: func(s CustomResourceStorage, ctx genericapirequest.Context, cr *unstructured.Unstructured) error {
+				_, _, err := s.Status.Update(ctx, cr.GetName(), rest.DefaultUpdatedObjectInfo(cr, unstructuredCopier{}))
+				return err
+			},
+			cr: newCustomResource("status", "ok"),
+		},
+		{
+			name: "status update",
+			update: func(s CustomResourceStorage, ctx genericapirequest.Context, cr *unstructured.Unstructured) error {
+				_, _, err := s.Status.Update(ctx, cr.GetName(), rest.DefaultUpdatedObjectInfo(cr, unstructuredCopier{}))
+				return err
+			},
+			cr:   newCustomResource("status", large),
+			code: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "custom subresource update",
+			update: func(s CustomResourceStorage, ctx genericapirequest.Context, cr *unstructured.Unstructured) error {
+				setNestedField(cr.Object, large, "spec", "approved")
+				_, _, err := s.Custom["approval"].Update(ctx, cr.GetName(), rest.DefaultUpdatedObjectInfo(cr, unstructuredCopier{}))
+				return err
+			},
+			cr:   newCustomResource("", nil),
+			code: http.StatusRequestEntityTooLarge,
+		},
+	}
+	for _, tc := range tests {
+		s := &updatingStorage{obj: newCustomResource("", nil)}
+		r := newReadREST(listKind, s)
+		r.Store.ObjectNameFunc = func(obj runtime.Object) (string, error) {
+			accessor, err := meta.Accessor(obj)
+			if err != nil {
+				return "", err
+			}
+			return accessor.GetName(), nil
+		}
+		r.Store.CreateStrategy = strategy
+		r.Store.UpdateStrategy = strategy
+		r.limitStorageSize()
+		r.SetLimits(Limits{MaxObjectBytes: 512}, nil)
+		customResourceStorage := newStorage(r, nil, strategy, subresources)
+
+		ctx := genericapirequest.WithNamespace(genericapirequest.NewContext(), "default")
+		err := tc.update(customResourceStorage, ctx, tc.cr)
+		if tc.code == 0 {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+		statusErr, ok := err.(*errors.StatusError)
+		if !ok {
+			t.Errorf("%s: expected a status error, got %v", tc.name, err)
+			continue
+		}
+		if statusErr.ErrStatus.Code != tc.code {
+			t.Errorf("%s: expected code %d, got %d: %v", tc.name, tc.code, statusErr.ErrStatus.Code, err)
+		}
+		if _, found := nestedField(s.obj.Object, "spec", "approved"); found {
+			t.Errorf("%s: the custom resource was updated: %v", tc.name, s.obj.Object)
+		}
+	}
+}
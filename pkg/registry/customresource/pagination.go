@@ -0,0 +1,243 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+// ListParameters are the limit, continue, resourceVersionMatch, sendInitialEvents and
+// allowWatchBookmarks parameters of list and watch requests, which the ListOptions of this API
+// version cannot carry.
+type ListParameters struct {
+	Limit                string
+	Continue             string
+	ResourceVersionMatch string
+	SendInitialEvents    string
+	AllowWatchBookmarks  string
+}
+
+const (
+	// listSnapshotsSize is the number of paginated lists whose snapshot is kept for their next pages.
+	listSnapshotsSize = 32
+	// listSnapshotTTL is how long the snapshot of a paginated list is kept after a page was served.
+	listSnapshotTTL = 5 * time.Minute
+)
+
+// The supported values of resourceVersionMatch.
+const (
+	resourceVersionMatchNotOlderThan = "NotOlderThan"
+	resourceVersionMatchExact        = "Exact"
+)
+
+type parametersContextKey int
+
+const listParametersContextKey parametersContextKey = iota
+
+// WithListParameters returns a copy of ctx with the parameters of a list or watch request.
+func WithListParameters(ctx genericapirequest.Context, parameters ListParameters) genericapirequest.Context {
+	return genericapirequest.WithValue(ctx, listParametersContextKey, parameters)
+}
+
+func listParametersFrom(ctx genericapirequest.Context) ListParameters {
+	parameters, _ := ctx.Value(listParametersContextKey).(ListParameters)
+	return parameters
+}
+
+// continueToken is the state of a paginated list, passed to the client as opaque continue parameter.
+type continueToken struct {
+	// ResourceVersion is the resourceVersion of the first page. Later pages are listed in this or a
+	// newer resourceVersion.
+	ResourceVersion string `json:"rv"`
+	// Start is the key of the last custom resource returned so far.
+	Start string `json:"start"`
+}
+
+func encodeContinueToken(token continueToken) (string, error) {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeContinueToken(s string) (continueToken, error) {
+	var token continueToken
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return token, err
+	}
+	if err := json.Unmarshal(data, &token); err != nil {
+		return token, err
+	}
+	if len(token.ResourceVersion) == 0 {
+		return token, fmt.Errorf("missing resourceVersion")
+	}
+	return token, nil
+}
+
+// parse validates the pagination parameters against the list options and returns the limit and
+// the continue token, whose resourceVersion is empty if this is the first page.
+func (p ListParameters) parse(options *metainternalversion.ListOptions) (int64, continueToken, error) {
+	resourceVersion := ""
+	if options != nil {
+		resourceVersion = options.ResourceVersion
+	}
+
+	var limit int64
+	if len(p.Limit) > 0 {
+		var err error
+		if limit, err = strconv.ParseInt(p.Limit, 10, 64); err != nil || limit < 0 {
+			return 0, continueToken{}, errors.NewBadRequest(fmt.Sprintf("invalid limit %q: must be a non-negative integer", p.Limit))
+		}
+	}
+
+	switch p.ResourceVersionMatch {
+	case "":
+	case resourceVersionMatchNotOlderThan:
+		if len(resourceVersion) == 0 {
+			return 0, continueToken{}, errors.NewBadRequest("resourceVersionMatch requires resourceVersion to be set")
+		}
+	case resourceVersionMatchExact:
+		if len(resourceVersion) == 0 || resourceVersion == "0" {
+			return 0, continueToken{}, errors.NewBadRequest("resourceVersionMatch=Exact requires resourceVersion to be set to a value other than 0")
+		}
+	default:
+		return 0, continueToken{}, errors.NewBadRequest(fmt.Sprintf("unsupported resourceVersionMatch %q: must be %s or %s", p.ResourceVersionMatch, resourceVersionMatchNotOlderThan, resourceVersionMatchExact))
+	}
+
+	if len(p.Continue) == 0 {
+		return limit, continueToken{}, nil
+	}
+	if len(resourceVersion) > 0 || len(p.ResourceVersionMatch) > 0 {
+		return 0, continueToken{}, errors.NewBadRequest("resourceVersion and resourceVersionMatch must not be set together with continue")
+	}
+	token, err := decodeContinueToken(p.Continue)
+	if err != nil {
+		return 0, continueToken{}, errors.NewBadRequest(fmt.Sprintf("invalid continue parameter: %v", err))
+	}
+	return limit, token, nil
+}
+
+// listKey orders custom resources by namespace and name, across all namespaces.
+func listKey(u *unstructured.Unstructured) string {
+	return u.GetNamespace() + "/" + u.GetName()
+}
+
+// sortList sorts the custom resources of the list by their keys.
+func sortList(list *unstructured.UnstructuredList) {
+	items := list.Items
+	sort.Slice(items, func(i, j int) bool { return listKey(&items[i]) < listKey(&items[j]) })
+}
+
+// paginate returns the page of at most limit custom resources after the start key of the token from
+// the list, which is sorted by keys and not modified. If more custom resources follow, the continue
+// token of the next page is set in the list metadata of the page. A zero limit returns all remaining
+// custom resources.
+func paginate(list *unstructured.UnstructuredList, limit int64, token continueToken) (*unstructured.UnstructuredList, error) {
+	if len(token.ResourceVersion) == 0 {
+		token.ResourceVersion = list.GetResourceVersion()
+	}
+
+	items := list.Items
+	if len(token.Start) > 0 {
+		first := sort.Search(len(items), func(i int) bool { return listKey(&items[i]) > token.Start })
+		items = items[first:]
+	}
+	more := limit > 0 && int64(len(items)) > limit
+	if more {
+		items = items[:limit]
+	}
+
+	page := &unstructured.UnstructuredList{
+		Object: make(map[string]interface{}, len(list.Object)),
+		Items:  append([]unstructured.Unstructured(nil), items...),
+	}
+	for k, v := range list.Object {
+		page.Object[k] = v
+	}
+	metadata := map[string]interface{}{}
+	if listMetadata, ok := list.Object["metadata"].(map[string]interface{}); ok {
+		for k, v := range listMetadata {
+			metadata[k] = v
+		}
+	}
+	delete(metadata, "continue")
+	page.Object["metadata"] = metadata
+	if more {
+		next, err := encodeContinueToken(continueToken{ResourceVersion: token.ResourceVersion, Start: listKey(&items[len(items)-1])})
+		if err != nil {
+			return nil, err
+		}
+		metadata["continue"] = next
+	}
+	return page, nil
+}
+
+// listSnapshots holds the sorted lists which the later pages of paginated lists are served from, by
+// the list options and the resourceVersion of their continue token, instead of listing and sorting
+// all custom resources for every page.
+type listSnapshots struct {
+	cache *utilcache.LRUExpireCache
+}
+
+func newListSnapshots() *listSnapshots {
+	return &listSnapshots{cache: utilcache.NewLRUExpireCache(listSnapshotsSize)}
+}
+
+func listSnapshotKey(ctx genericapirequest.Context, options *metainternalversion.ListOptions, resourceVersion string) string {
+	namespace, _ := genericapirequest.NamespaceFrom(ctx)
+	var label, field string
+	var includeUninitialized bool
+	if options != nil {
+		if options.LabelSelector != nil {
+			label = options.LabelSelector.String()
+		}
+		if options.FieldSelector != nil {
+			field = options.FieldSelector.String()
+		}
+		includeUninitialized = options.IncludeUninitialized
+	}
+	return fmt.Sprintf("%q %q %q %q %v", resourceVersion, namespace, label, field, includeUninitialized)
+}
+
+// get returns the snapshot and keeps it for another listSnapshotTTL.
+func (s *listSnapshots) get(ctx genericapirequest.Context, options *metainternalversion.ListOptions, resourceVersion string) (*unstructured.UnstructuredList, bool) {
+	key := listSnapshotKey(ctx, options, resourceVersion)
+	value, ok := s.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	s.cache.Add(key, value, listSnapshotTTL)
+	return value.(*unstructured.UnstructuredList), true
+}
+
+// add keeps the sorted list, which must not be modified afterwards.
+func (s *listSnapshots) add(ctx genericapirequest.Context, options *metainternalversion.ListOptions, resourceVersion string, list *unstructured.UnstructuredList) {
+	s.cache.Add(listSnapshotKey(ctx, options, resourceVersion), list, listSnapshotTTL)
+}
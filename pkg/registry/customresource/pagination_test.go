@@ -0,0 +1,230 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/storage"
+)
+
+func TestPaginate(t *testing.T) {
+	newList := func(resourceVersion string, keys ...[2]string) *unstructured.UnstructuredList {
+		list := &unstructured.UnstructuredList{Object: map[string]interface{}{}}
+		list.SetResourceVersion(resourceVersion)
+		for _, key := range keys {
+			item := unstructured.Unstructured{Object: map[string]interface{}{}}
+			item.SetNamespace(key[0])
+			item.SetName(key[1])
+			list.Items = append(list.Items, item)
+		}
+		return list
+	}
+	names := func(list *unstructured.UnstructuredList) []string {
+		var ret []string
+		for i := range list.Items {
+			ret = append(ret, listKey(&list.Items[i]))
+		}
+		return ret
+	}
+	continueOf := func(list *unstructured.UnstructuredList) string {
+		s, _ := list.Object["metadata"].(map[string]interface{})["continue"].(string)
+		return s
+	}
+	all := [][2]string{{"b", "x"}, {"a", "y"}, {"a", "x"}, {"c", "z"}, {"b", "a"}}
+
+	// the pages cover all namespaces in the order of the keys
+	var pages [][]string
+	token := continueToken{}
+	for i := 0; i < 10; i++ {
+		resourceVersion := "10"
+		if i > 0 {
+			resourceVersion = "12"
+		}
+		list := newList(resourceVersion, all...)
+		sortList(list)
+		page, err := paginate(list, 2, token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pages = append(pages, names(page))
+		next := continueOf(page)
+		if len(next) == 0 {
+			break
+		}
+		if token, err = decodeContinueToken(next); err != nil {
+			t.Fatal(err)
+		}
+		if token.ResourceVersion != "10" {
+			t.Errorf("expected later pages to be listed at least at the resourceVersion of the first page, got %q", token.ResourceVersion)
+		}
+	}
+	expected := [][]string{{"a/x", "a/y"}, {"b/a", "b/x"}, {"c/z"}}
+	if !reflect.DeepEqual(pages, expected) {
+		t.Errorf("expected pages %v, got %v", expected, pages)
+	}
+
+	// a custom resource deleted since the last page does not hide the following ones
+	page, err := paginate(newList("12", [2]string{"a", "x"}, [2]string{"b", "x"}), 1, continueToken{ResourceVersion: "10", Start: "a/y"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := names(page); !reflect.DeepEqual(actual, []string{"b/x"}) || len(continueOf(page)) > 0 {
+		t.Errorf("expected the last page with b/x, got %v with continue %q", actual, continueOf(page))
+	}
+}
+
+func TestListParametersParse(t *testing.T) {
+	token, err := encodeContinueToken(continueToken{ResourceVersion: "10", Start: "a/x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		pagination      ListParameters
+		resourceVersion string
+		expectedLimit   int64
+		expectedToken   continueToken
+		wantErr         bool
+	}{
+		{name: "none"},
+		{name: "limit", pagination: ListParameters{Limit: "500"}, expectedLimit: 500},
+		{name: "continue", pagination: ListParameters{Limit: "500", Continue: token}, expectedLimit: 500, expectedToken: continueToken{ResourceVersion: "10", Start: "a/x"}},
+		{name: "not older than", pagination: ListParameters{ResourceVersionMatch: "NotOlderThan"}, resourceVersion: "5"},
+		{name: "negative limit", pagination: ListParameters{Limit: "-1"}, wantErr: true},
+		{name: "invalid limit", pagination: ListParameters{Limit: "many"}, wantErr: true},
+		{name: "invalid continue", pagination: ListParameters{Continue: "garbage"}, wantErr: true},
+		{name: "continue with resourceVersion", pagination: ListParameters{Continue: token}, resourceVersion: "5", wantErr: true},
+		{name: "not older than without resourceVersion", pagination: ListParameters{ResourceVersionMatch: "NotOlderThan"}, wantErr: true},
+		{name: "exact", pagination: ListParameters{ResourceVersionMatch: "Exact"}, resourceVersion: "5"},
+		{name: "exact without resourceVersion", pagination: ListParameters{ResourceVersionMatch: "Exact"}, wantErr: true},
+		{name: "exact at 0", pagination: ListParameters{ResourceVersionMatch: "Exact"}, resourceVersion: "0", wantErr: true},
+		{name: "unknown match", pagination: ListParameters{ResourceVersionMatch: "Newest"}, resourceVersion: "5", wantErr: true},
+	}
+	for _, tc := range tests {
+		limit, token, err := tc.pagination.parse(&metainternalversion.ListOptions{ResourceVersion: tc.resourceVersion})
+		if tc.wantErr {
+			if !errors.IsBadRequest(err) {
+				t.Errorf("%s: expected a bad request error, got %v", tc.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if limit != tc.expectedLimit || token != tc.expectedToken {
+			t.Errorf("%s: expected limit %d and token %#v, got %d and %#v", tc.name, tc.expectedLimit, tc.expectedToken, limit, token)
+		}
+	}
+}
+
+// listingStorage lists names at resourceVersion and counts the lists.
+type listingStorage struct {
+	storage.Interface
+	names           []string
+	resourceVersion string
+	lists           int
+}
+
+func (s *listingStorage) List(ctx context.Context, key string, resourceVersion string, p storage.SelectionPredicate, listObj runtime.Object) error {
+	s.lists++
+	list := listObj.(*unstructured.UnstructuredList)
+	for _, name := range s.names {
+		item := unstructured.Unstructured{Object: map[string]interface{}{}}
+		item.SetName(name)
+		list.Items = append(list.Items, item)
+	}
+	list.SetResourceVersion(s.resourceVersion)
+	return nil
+}
+
+func TestListPages(t *testing.T) {
+	kind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1beta1", Kind: "NoxuList"}
+	s := &listingStorage{names: []string{"c", "a", "b"}, resourceVersion: "10"}
+	r := newReadREST(kind, s)
+	r.listSnapshots = newListSnapshots()
+	list := func(parameters ListParameters, resourceVersion string) (*unstructured.UnstructuredList, error) {
+		ctx := WithListParameters(genericapirequest.NewContext(), parameters)
+		obj, err := r.List(ctx, &metainternalversion.ListOptions{ResourceVersion: resourceVersion})
+		if err != nil {
+			return nil, err
+		}
+		return obj.(*unstructured.UnstructuredList), nil
+	}
+	names := func(list *unstructured.UnstructuredList) []string {
+		var ret []string
+		for i := range list.Items {
+			ret = append(ret, list.Items[i].GetName())
+		}
+		return ret
+	}
+
+	first, err := list(ListParameters{Limit: "2"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"a", "b"}; !reflect.DeepEqual(names(first), expected) {
+		t.Errorf("expected the first page %v, got %v", expected, names(first))
+	}
+	next, _ := first.Object["metadata"].(map[string]interface{})["continue"].(string)
+
+	// the next page is served from the snapshot of the first one
+	s.names = append(s.names, "d")
+	s.resourceVersion = "11"
+	second, err := list(ListParameters{Limit: "2", Continue: next}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"c"}; !reflect.DeepEqual(names(second), expected) || second.GetResourceVersion() != "10" {
+		t.Errorf("expected the last page %v at resourceVersion 10, got %v at %s", expected, names(second), second.GetResourceVersion())
+	}
+	if s.lists != 1 {
+		t.Errorf("expected one list of the storage, got %d", s.lists)
+	}
+
+	// exact lists are served from the snapshot or the storage, if they are in the resourceVersion
+	exact, err := list(ListParameters{Limit: "1", ResourceVersionMatch: "Exact"}, "10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"a"}; !reflect.DeepEqual(names(exact), expected) || s.lists != 1 {
+		t.Errorf("expected %v from the snapshot, got %v after %d lists", expected, names(exact), s.lists)
+	}
+	exact, err = list(ListParameters{ResourceVersionMatch: "Exact"}, "11")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"a", "b", "c", "d"}; !reflect.DeepEqual(names(exact), expected) {
+		t.Errorf("expected %v, got %v", expected, names(exact))
+	}
+	_, err = list(ListParameters{ResourceVersionMatch: "Exact"}, "9")
+	if status, ok := err.(*errors.StatusError); !ok || status.ErrStatus.Reason != metav1.StatusReasonGone {
+		t.Errorf("expected a gone error for an old resourceVersion, got %v", err)
+	}
+}
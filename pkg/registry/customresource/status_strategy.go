@@ -0,0 +1,58 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+// statusStrategy is the update strategy of the status subresource of custom resources.
+type statusStrategy struct {
+	CustomResourceDefinitionStorageStrategy
+}
+
+// NewStatusStrategy returns the strategy for updates through the status subresource of custom resources.
+func NewStatusStrategy(strategy CustomResourceDefinitionStorageStrategy) statusStrategy {
+	return statusStrategy{strategy}
+}
+
+// PrepareForUpdate only keeps the status of the new object. Everything else is taken from the old object.
+func (a statusStrategy) PrepareForUpdate(ctx genericapirequest.Context, obj, old runtime.Object) {
+	// update is only allowed to set status
+	newCustomResourceObject := obj.(*unstructured.Unstructured)
+	newCustomResource := newCustomResourceObject.UnstructuredContent()
+	status, ok := newCustomResource["status"]
+
+	// copy old object into new object. Overriding the resourceVersion in metadata is safe here,
+	// the store has already checked that the new and the old object have the same resourceVersion.
+	oldCustomResourceObject := old.(*unstructured.Unstructured)
+	*newCustomResourceObject = *oldCustomResourceObject.DeepCopy()
+
+	// set status
+	newCustomResource = newCustomResourceObject.UnstructuredContent()
+	if ok {
+		newCustomResource["status"] = status
+	} else {
+		delete(newCustomResource, "status")
+	}
+
+	a.pruneUnknownFields(obj)
+	a.applyDefaults(obj)
+	a.updateManagedFields(ctx, obj, old)
+}
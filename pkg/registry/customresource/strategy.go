@@ -18,51 +18,304 @@ package customresource
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/fieldmanager"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/metrics"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/extensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	schemavalidation "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/validation"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/storage"
 	"k8s.io/apiserver/pkg/storage/names"
 )
 
+// OwnerMapper resolves the kinds of owner references of custom resources.
+type OwnerMapper interface {
+	// ServesGroup returns true if the kinds of the API group are served by CustomResourceDefinitions.
+	ServesGroup(group string) bool
+	// RESTMapping returns the mapping of the kind in the first of the versions which is served.
+	RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error)
+}
+
 type CustomResourceDefinitionStorageStrategy struct {
 	runtime.ObjectTyper
 	names.NameGenerator
 
-	namespaceScoped bool
-	validator       customResourceValidator
+	namespaceScoped       bool
+	resource              schema.GroupVersionResource
+	schema                *apiextensions.JSONSchemaProps
+	preserveUnknownFields bool
+	status                *apiextensions.CustomResourceSubresourceStatus
+	selectableFields      []apiextensions.SelectableField
+	fieldManager          *fieldmanager.FieldManager
+	validator             customResourceValidator
+}
+
+// StrategyOptions are the inputs of NewStrategy derived from the CustomResourceDefinition.
+type StrategyOptions struct {
+	// NamespaceScoped is true if the custom resources are namespaced.
+	NamespaceScoped bool
+	// Kind is the kind of the custom resources.
+	Kind schema.GroupVersionKind
+	// Plural is the plural name of the resource of the kind, which the validation and pruning metrics
+	// are recorded for.
+	Plural string
+	// Schema is the validation schema of the kind. It may be nil. Its defaults are applied and its
+	// x-kubernetes-validations rules and the values of its x-kubernetes-int-or-string and
+	// x-kubernetes-embedded-resource fields are enforced. Restrictions of metadata other than the
+	// patterns of name and generateName are ignored.
+	Schema *apiextensions.JSONSchemaProps
+	// PreserveUnknownFields disables the pruning of the fields not specified in the schema.
+	PreserveUnknownFields bool
+	// Status, if set, restricts writes of the status stanza to the status subresource.
+	Status *apiextensions.CustomResourceSubresourceStatus
+	// SelectableFields can be used in field selectors in addition to the metadata fields.
+	SelectableFields []apiextensions.SelectableField
+	// AllowedFinalizerDomains, if not empty, restricts the finalizers which can be added to those
+	// with one of these domains or their subdomains as prefix, besides the ones of the garbage
+	// collector.
+	AllowedFinalizerDomains []string
 }
 
-func NewStrategy(typer runtime.ObjectTyper, namespaceScoped bool, kind schema.GroupVersionKind) CustomResourceDefinitionStorageStrategy {
+// NewStrategy returns the strategy for custom resources with the given options. The managers of the
+// fields are tracked in metadata.managedFields. Owner references to kinds of groups served by
+// CustomResourceDefinitions are resolved with the ownerMapper and must refer to served kinds. It may
+// be nil. The x-kubernetes-validations rules evaluated for a single request share the
+// ruleCostBudget, zero meaning unlimited.
+func NewStrategy(typer runtime.ObjectTyper, options StrategyOptions, ownerMapper OwnerMapper, ruleCostBudget uint64) CustomResourceDefinitionStorageStrategy {
+	namespaceScoped, kind := options.NamespaceScoped, options.Kind
+	openAPIV3Schema := restrictMetadataSchema(options.Schema)
+	resource := kind.GroupVersion().WithResource(options.Plural)
 	return CustomResourceDefinitionStorageStrategy{
-		ObjectTyper:     typer,
-		NameGenerator:   names.SimpleNameGenerator,
-		namespaceScoped: namespaceScoped,
+		ObjectTyper:           typer,
+		NameGenerator:         names.SimpleNameGenerator,
+		namespaceScoped:       namespaceScoped,
+		resource:              resource,
+		schema:                openAPIV3Schema,
+		preserveUnknownFields: options.PreserveUnknownFields,
+		status:                options.Status,
+		selectableFields:      options.SelectableFields,
+		fieldManager:          fieldmanager.NewFieldManager(openAPIV3Schema),
 		validator: customResourceValidator{
-			namespaceScoped: namespaceScoped,
-			kind:            kind,
+			namespaceScoped:         namespaceScoped,
+			kind:                    kind,
+			schema:                  openAPIV3Schema,
+			valuesSchema:            withoutMetadataSchema(openAPIV3Schema),
+			celValidator:            cel.NewValidator(openAPIV3Schema, true),
+			ruleCostBudget:          ruleCostBudget,
+			resource:                resource,
+			metadataPatterns:        metadataPatterns(openAPIV3Schema),
+			ownerMapper:             ownerMapper,
+			allowedFinalizerDomains: options.AllowedFinalizerDomains,
 		},
 	}
 }
 
+// restrictMetadataSchema returns the schema without any restrictions of metadata other than the
+// patterns of name and generateName. Schemas of CRDs created before these restrictions were
+// validated might still constrain or default metadata, which must be validated as ObjectMeta only.
+func restrictMetadataSchema(s *apiextensions.JSONSchemaProps) *apiextensions.JSONSchemaProps {
+	if s == nil {
+		return nil
+	}
+	metadata, found := s.Properties["metadata"]
+	if !found {
+		return s
+	}
+
+	restricted := apiextensions.JSONSchemaProps{Type: "object"}
+	for _, property := range []string{"name", "generateName"} {
+		if propertySchema, found := metadata.Properties[property]; found && len(propertySchema.Pattern) > 0 {
+			if restricted.Properties == nil {
+				restricted.Properties = map[string]apiextensions.JSONSchemaProps{}
+			}
+			restricted.Properties[property] = apiextensions.JSONSchemaProps{Type: "string", Pattern: propertySchema.Pattern}
+		}
+	}
+
+	ret := *s
+	ret.Properties = make(map[string]apiextensions.JSONSchemaProps, len(s.Properties))
+	for k, v := range s.Properties {
+		ret.Properties[k] = v
+	}
+	ret.Properties["metadata"] = restricted
+	return &ret
+}
+
+// withoutMetadataSchema returns the schema without the schema of metadata, which is validated as
+// ObjectMeta and by the patterns of name and generateName instead.
+func withoutMetadataSchema(s *apiextensions.JSONSchemaProps) *apiextensions.JSONSchemaProps {
+	if s == nil {
+		return nil
+	}
+	if _, found := s.Properties["metadata"]; !found {
+		return s
+	}
+	ret := *s
+	ret.Properties = make(map[string]apiextensions.JSONSchemaProps, len(s.Properties))
+	for k, v := range s.Properties {
+		if k != "metadata" {
+			ret.Properties[k] = v
+		}
+	}
+	return &ret
+}
+
+// metadataPatterns returns the compiled patterns of metadata.name and metadata.generateName in the
+// schema. Invalid patterns are ignored.
+func metadataPatterns(s *apiextensions.JSONSchemaProps) map[string]*regexp.Regexp {
+	if s == nil {
+		return nil
+	}
+	var ret map[string]*regexp.Regexp
+	for property, propertySchema := range s.Properties["metadata"].Properties {
+		re, err := regexp.Compile(propertySchema.Pattern)
+		if err != nil {
+			continue
+		}
+		if ret == nil {
+			ret = map[string]*regexp.Regexp{}
+		}
+		ret[property] = re
+	}
+	return ret
+}
+
 func (a CustomResourceDefinitionStorageStrategy) NamespaceScoped() bool {
 	return a.namespaceScoped
 }
 
-func (CustomResourceDefinitionStorageStrategy) PrepareForCreate(ctx genericapirequest.Context, obj runtime.Object) {
+// PrepareForCreate clears the status of a CustomResource if the status subresource is enabled,
+// or sets it to the initial status of the status subresource if there is one.
+func (a CustomResourceDefinitionStorageStrategy) PrepareForCreate(ctx genericapirequest.Context, obj runtime.Object) {
+	if a.status != nil {
+		customResourceObject := obj.(*unstructured.Unstructured)
+		customResource := customResourceObject.UnstructuredContent()
+
+		// create cannot set status, it starts as the initial status
+		delete(customResource, "status")
+		if a.status.InitialStatus != nil {
+			customResource["status"] = *a.status.DeepCopy().InitialStatus
+		}
+
+		customResourceObject.SetGeneration(1)
+	}
+
+	a.pruneUnknownFields(obj)
+	a.applyDefaults(obj)
+	a.updateManagedFields(ctx, obj, nil)
 }
 
-func (CustomResourceDefinitionStorageStrategy) PrepareForUpdate(ctx genericapirequest.Context, obj, old runtime.Object) {
+// PrepareForUpdate keeps the old status of a CustomResource if the status subresource is enabled,
+// and increments the generation if anything else changed.
+func (a CustomResourceDefinitionStorageStrategy) PrepareForUpdate(ctx genericapirequest.Context, obj, old runtime.Object) {
+	a.pruneUnknownFields(obj)
+	a.applyDefaults(obj)
+	defer a.updateManagedFields(ctx, obj, old)
+
+	if a.status == nil {
+		return
+	}
+
+	newCustomResourceObject := obj.(*unstructured.Unstructured)
+	oldCustomResourceObject := old.(*unstructured.Unstructured)
+
+	newCustomResource := newCustomResourceObject.UnstructuredContent()
+	oldCustomResource := oldCustomResourceObject.UnstructuredContent()
+
+	// update cannot set status
+	if oldStatus, ok := oldCustomResource["status"]; ok {
+		newCustomResource["status"] = oldStatus
+	} else {
+		delete(newCustomResource, "status")
+	}
+
+	// any changes to the spec increment the generation number, any changes to the
+	// status should reflect the generation number of the corresponding object.
+	if !apiequality.Semantic.DeepEqual(withoutMetadata(newCustomResource), withoutMetadata(oldCustomResource)) {
+		newCustomResourceObject.SetGeneration(oldCustomResourceObject.GetGeneration() + 1)
+	}
+}
+
+// updateManagedFields records the fields changed by a create or update as managed by the field manager
+// of the request. The managed fields of apply requests are already set by the apply itself. The
+// custom resources are decoded from JSON, hence errors are only logged.
+func (a CustomResourceDefinitionStorageStrategy) updateManagedFields(ctx genericapirequest.Context, obj, old runtime.Object) {
+	if fieldmanager.IsApply(ctx) {
+		return
+	}
+	u, ok := obj.(runtime.Unstructured)
+	if !ok {
+		return
+	}
+	var oldContent map[string]interface{}
+	if old != nil {
+		if oldU, ok := old.(runtime.Unstructured); ok {
+			oldContent = oldU.UnstructuredContent()
+		}
+	}
+	if err := a.fieldManager.Update(oldContent, u.UnstructuredContent(), fieldmanager.ManagerFrom(ctx)); err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to update the managed fields of %s: %v", a.resource, err))
+	}
+}
+
+// withoutMetadata returns a shallow copy of the content of a CustomResource without its metadata.
+func withoutMetadata(customResource map[string]interface{}) map[string]interface{} {
+	ret := make(map[string]interface{}, len(customResource))
+	for k, v := range customResource {
+		if k != "metadata" {
+			ret[k] = v
+		}
+	}
+	return ret
+}
+
+// pruneUnknownFields drops the fields of obj which are not specified in the validation schema.
+func (a CustomResourceDefinitionStorageStrategy) pruneUnknownFields(obj runtime.Object) {
+	if a.preserveUnknownFields {
+		return
+	}
+	if u, ok := obj.(runtime.Unstructured); ok {
+		if pruning.Prune(u.UnstructuredContent(), a.schema, true) {
+			metrics.IncPrunedObjects(a.resource)
+		}
+	}
+}
+
+// applyDefaults sets the defaults of the validation schema on absent fields of obj. The defaults
+// are validated with the CustomResourceDefinition, hence errors are only logged.
+func (a CustomResourceDefinitionStorageStrategy) applyDefaults(obj runtime.Object) {
+	if a.schema == nil {
+		return
+	}
+	if u, ok := obj.(runtime.Unstructured); ok {
+		if err := defaulting.Default(u.UnstructuredContent(), a.schema); err != nil {
+			utilruntime.HandleError(fmt.Errorf("failed to default %s: %v", a.resource, err))
+		}
+	}
 }
 
 func (a CustomResourceDefinitionStorageStrategy) Validate(ctx genericapirequest.Context, obj runtime.Object) field.ErrorList {
+	defer metrics.ObserveValidation(a.resource, time.Now())
 	return a.validator.Validate(ctx, obj)
 }
 
@@ -78,6 +331,7 @@ func (CustomResourceDefinitionStorageStrategy) Canonicalize(obj runtime.Object)
 }
 
 func (a CustomResourceDefinitionStorageStrategy) ValidateUpdate(ctx genericapirequest.Context, obj, old runtime.Object) field.ErrorList {
+	defer metrics.ObserveValidation(a.resource, time.Now())
 	return a.validator.ValidateUpdate(ctx, obj, old)
 }
 
@@ -86,7 +340,64 @@ func (a CustomResourceDefinitionStorageStrategy) GetAttrs(obj runtime.Object) (l
 	if err != nil {
 		return nil, nil, false, err
 	}
-	return labels.Set(accessor.GetLabels()), objectMetaFieldsSet(accessor, a.namespaceScoped), accessor.GetInitializers() != nil, nil
+	fieldsSet := objectMetaFieldsSet(accessor, a.namespaceScoped)
+	if u, ok := obj.(runtime.Unstructured); ok {
+		for _, selectableField := range a.selectableFields {
+			fieldsSet[selectableFieldLabel(selectableField)] = selectableFieldValue(u.UnstructuredContent(), selectableField)
+		}
+	}
+	return labels.Set(accessor.GetLabels()), fieldsSet, accessor.GetInitializers() != nil, nil
+}
+
+// SelectableFieldLabels returns the field selector labels of the selectable fields.
+func (a CustomResourceDefinitionStorageStrategy) SelectableFieldLabels() []string {
+	var ret []string
+	for _, selectableField := range a.selectableFields {
+		ret = append(ret, selectableFieldLabel(selectableField))
+	}
+	return ret
+}
+
+// triggerFunc returns the value of the first selectable field of obj. The watch cache supports only
+// one index, so only the first selectable field is indexed.
+func (a CustomResourceDefinitionStorageStrategy) triggerFunc(obj runtime.Object) []storage.MatchValue {
+	u, ok := obj.(runtime.Unstructured)
+	if !ok || len(a.selectableFields) == 0 {
+		return nil
+	}
+	return []storage.MatchValue{{
+		IndexName: selectableFieldLabel(a.selectableFields[0]),
+		Value:     selectableFieldValue(u.UnstructuredContent(), a.selectableFields[0]),
+	}}
+}
+
+// selectableFieldLabel returns the field selector label of the selectable field, i.e. its JSON path
+// without the leading dot.
+func selectableFieldLabel(selectableField apiextensions.SelectableField) string {
+	return strings.TrimPrefix(selectableField.JSONPath, ".")
+}
+
+// selectableFieldValue returns the string representation of the selectable field of obj, or the empty
+// string if it is not set.
+func selectableFieldValue(obj map[string]interface{}, selectableField apiextensions.SelectableField) string {
+	val, found := nestedField(obj, splitSimpleJSONPath(selectableField.JSONPath)...)
+	if !found {
+		return ""
+	}
+	switch val := val.(type) {
+	case string:
+		return val
+	case bool:
+		return strconv.FormatBool(val)
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case float64:
+		if val == float64(int64(val)) {
+			return strconv.FormatInt(int64(val), 10)
+		}
+		return strconv.FormatFloat(val, 'g', -1, 64)
+	}
+	return ""
 }
 
 // objectMetaFieldsSet returns a fields that represent the ObjectMeta.
@@ -103,16 +414,35 @@ func objectMetaFieldsSet(objectMeta metav1.Object, namespaceScoped bool) fields.
 }
 
 func (a CustomResourceDefinitionStorageStrategy) MatchCustomResourceDefinitionStorage(label labels.Selector, field fields.Selector) storage.SelectionPredicate {
-	return storage.SelectionPredicate{
+	ret := storage.SelectionPredicate{
 		Label:    label,
 		Field:    field,
 		GetAttrs: a.GetAttrs,
 	}
+	if len(a.selectableFields) > 0 {
+		ret.IndexFields = []string{selectableFieldLabel(a.selectableFields[0])}
+	}
+	return ret
 }
 
 type customResourceValidator struct {
 	namespaceScoped bool
 	kind            schema.GroupVersionKind
+	schema          *apiextensions.JSONSchemaProps
+	// valuesSchema is the schema without metadata, which the values of custom resources are validated against.
+	valuesSchema *apiextensions.JSONSchemaProps
+	celValidator *cel.Validator
+	// ruleCostBudget is the runtime cost budget of the x-kubernetes-validations rules evaluated
+	// for a single request. Zero is unlimited.
+	ruleCostBudget uint64
+	// resource is the resource the metric of exceeded cost budgets is recorded for.
+	resource schema.GroupVersionResource
+	// metadataPatterns maps name and generateName to the patterns they must match, if any.
+	metadataPatterns map[string]*regexp.Regexp
+	// ownerMapper is optional.
+	ownerMapper OwnerMapper
+	// allowedFinalizerDomains restricts the finalizers which can be added, if not empty.
+	allowedFinalizerDomains []string
 }
 
 func (a customResourceValidator) Validate(ctx genericapirequest.Context, obj runtime.Object) field.ErrorList {
@@ -131,7 +461,114 @@ func (a customResourceValidator) Validate(ctx genericapirequest.Context, obj run
 		return field.ErrorList{field.Invalid(field.NewPath("apiVersion"), typeAccessor.GetKind(), fmt.Sprintf("must be %v", a.kind.Group+"/"+a.kind.Version))}
 	}
 
-	return validation.ValidateObjectMetaAccessor(accessor, a.namespaceScoped, validation.NameIsDNSSubdomain, field.NewPath("metadata"))
+	allErrs := validation.ValidateObjectMetaAccessor(accessor, a.namespaceScoped, validation.NameIsDNSSubdomain, field.NewPath("metadata"))
+	allErrs = append(allErrs, a.validateMetadataPatterns(accessor)...)
+	allErrs = append(allErrs, a.validateOwnerReferences(accessor.GetOwnerReferences(), nil)...)
+	allErrs = append(allErrs, a.validateFinalizers(accessor.GetFinalizers(), nil, false)...)
+	schemaErrs := append(a.validateSchema(obj), a.validateExtensions(obj)...)
+	failedRules := cel.RuleFailures{}
+	ruleErrs := a.validateRules(obj, failedRules)
+	annotateValidationFailures(ctx, schemaErrs, ruleErrs, failedRules)
+	allErrs = append(allErrs, schemaErrs...)
+	allErrs = append(allErrs, ruleErrs...)
+	return allErrs
+}
+
+// validateMetadataPatterns checks name and generateName against the patterns of the schema. The
+// name is immutable, so the patterns are only validated on create.
+func (a customResourceValidator) validateMetadataPatterns(accessor metav1.Object) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, property := range []string{"name", "generateName"} {
+		value := accessor.GetName()
+		if property == "generateName" {
+			value = accessor.GetGenerateName()
+		}
+		if re, found := a.metadataPatterns[property]; found && len(value) > 0 && !re.MatchString(value) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", property), value, fmt.Sprintf("must match the pattern %q", re.String())))
+		}
+	}
+	return allErrs
+}
+
+// validateOwnerReferences checks that owner references to kinds of groups served by
+// CustomResourceDefinitions refer to served kinds, and that cluster-scoped custom resources are not
+// owned by namespaced ones, which the garbage collector could not resolve. References which are
+// unchanged from the old ones are not checked, such that custom resources owned by custom resources
+// of a deleted CustomResourceDefinition can still be updated, e.g. to remove finalizers.
+func (a customResourceValidator) validateOwnerReferences(refs, oldRefs []metav1.OwnerReference) field.ErrorList {
+	if a.ownerMapper == nil {
+		return nil
+	}
+	allErrs := field.ErrorList{}
+	for i, ref := range refs {
+		unchanged := false
+		for _, old := range oldRefs {
+			if ref.APIVersion == old.APIVersion && ref.Kind == old.Kind && ref.UID == old.UID {
+				unchanged = true
+				break
+			}
+		}
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if unchanged || err != nil || len(ref.Kind) == 0 || !a.ownerMapper.ServesGroup(gv.Group) {
+			// malformed references are reported by the ObjectMeta validation
+			continue
+		}
+		fldPath := field.NewPath("metadata", "ownerReferences").Index(i)
+		mapping, err := a.ownerMapper.RESTMapping(schema.GroupKind{Group: gv.Group, Kind: ref.Kind}, gv.Version)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("kind"), ref.Kind, fmt.Sprintf("must be a kind served in %s", ref.APIVersion)))
+			continue
+		}
+		if !a.namespaceScoped && mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("kind"), ref.Kind, "must be cluster-scoped, a cluster-scoped custom resource cannot be owned by a namespaced one"))
+		}
+	}
+	return allErrs
+}
+
+// validateFinalizers checks the finalizers which are added to a custom resource, i.e. which are not
+// in oldFinalizers, such that a typo cannot strand it with a finalizer no controller removes. On
+// update their names must be qualified names like on create, where the ObjectMeta validation
+// checks them. If allowed finalizer domains are set, their prefix must be one of them or a
+// subdomain of one, unless they are finalizers of the garbage collector.
+func (a customResourceValidator) validateFinalizers(finalizers, oldFinalizers []string, update bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("metadata", "finalizers")
+	old := sets.NewString(oldFinalizers...)
+	for i, finalizer := range finalizers {
+		if old.Has(finalizer) {
+			continue
+		}
+		if update {
+			if errs := validation.ValidateFinalizerName(finalizer, fldPath.Index(i)); len(errs) > 0 {
+				allErrs = append(allErrs, errs...)
+				continue
+			}
+		}
+		if len(a.allowedFinalizerDomains) == 0 || finalizer == metav1.FinalizerOrphanDependents || finalizer == metav1.FinalizerDeleteDependents {
+			continue
+		}
+		if !a.hasAllowedFinalizerDomain(finalizer) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), finalizer, fmt.Sprintf("must have a domain prefix which is one of or a subdomain of %s", strings.Join(a.allowedFinalizerDomains, ", "))))
+		}
+	}
+	return allErrs
+}
+
+// hasAllowedFinalizerDomain returns true if the prefix of the finalizer is one of the allowed
+// finalizer domains or a subdomain of one.
+func (a customResourceValidator) hasAllowedFinalizerDomain(finalizer string) bool {
+	i := strings.Index(finalizer, "/")
+	if i < 0 {
+		return false
+	}
+	domain := finalizer[:i]
+	for _, allowed := range a.allowedFinalizerDomains {
+		if domain == allowed || strings.HasSuffix(domain, "."+allowed) {
+			return true
+		}
+	}
+	return false
 }
 
 func (a customResourceValidator) ValidateUpdate(ctx genericapirequest.Context, obj, old runtime.Object) field.ErrorList {
@@ -154,5 +591,97 @@ func (a customResourceValidator) ValidateUpdate(ctx genericapirequest.Context, o
 		return field.ErrorList{field.Invalid(field.NewPath("apiVersion"), typeAccessor.GetKind(), fmt.Sprintf("must be %v", a.kind.Group+"/"+a.kind.Version))}
 	}
 
-	return validation.ValidateObjectMetaAccessorUpdate(objAccessor, oldAccessor, field.NewPath("metadata"))
+	allErrs := validation.ValidateObjectMetaAccessorUpdate(objAccessor, oldAccessor, field.NewPath("metadata"))
+	allErrs = append(allErrs, a.validateOwnerReferences(objAccessor.GetOwnerReferences(), oldAccessor.GetOwnerReferences())...)
+	allErrs = append(allErrs, a.validateFinalizers(objAccessor.GetFinalizers(), oldAccessor.GetFinalizers(), true)...)
+	schemaErrs := append(a.validateSchema(obj), a.validateExtensions(obj)...)
+	immutableErrs := a.validateImmutableFields(obj, old)
+	schemaErrs = append(schemaErrs, immutableErrs...)
+	failedRules := cel.RuleFailures{}
+	ruleErrs := a.validateRulesUpdate(obj, old, failedRules)
+	annotateValidationFailures(ctx, schemaErrs, ruleErrs, failedRules)
+	recordChangedFields(ctx, false, immutableErrs, ruleErrs, failedRules, obj, old, a.schema)
+	allErrs = append(allErrs, schemaErrs...)
+	allErrs = append(allErrs, ruleErrs...)
+	return allErrs
+}
+
+// validateSchema checks obj against the validation schema. Every violation is reported as a separate
+// error with the path of the offending value, such that it is returned as a cause of the status.
+func (a customResourceValidator) validateSchema(obj runtime.Object) field.ErrorList {
+	if a.valuesSchema == nil {
+		return nil
+	}
+	u, ok := obj.(runtime.Unstructured)
+	if !ok {
+		return field.ErrorList{field.Invalid(nil, obj, fmt.Sprintf("has type %T. Must be a pointer to an Unstructured type", obj))}
+	}
+	return schemavalidation.Validate(u.UnstructuredContent(), a.valuesSchema, nil)
+}
+
+// validateExtensions checks obj against the x-kubernetes-int-or-string, x-kubernetes-embedded-resource
+// and x-kubernetes-list-type vendor extensions of the schema.
+func (a customResourceValidator) validateExtensions(obj runtime.Object) field.ErrorList {
+	if a.schema == nil {
+		return nil
+	}
+	u, ok := obj.(runtime.Unstructured)
+	if !ok {
+		return field.ErrorList{field.Invalid(nil, obj, fmt.Sprintf("has type %T. Must be a pointer to an Unstructured type", obj))}
+	}
+	return extensions.Validate(u.UnstructuredContent(), a.schema, nil)
+}
+
+// validateImmutableFields checks that obj keeps the values of old with x-kubernetes-immutable.
+func (a customResourceValidator) validateImmutableFields(obj, old runtime.Object) field.ErrorList {
+	if a.schema == nil {
+		return nil
+	}
+	u, ok := obj.(runtime.Unstructured)
+	if !ok {
+		return field.ErrorList{field.Invalid(nil, obj, fmt.Sprintf("has type %T. Must be a pointer to an Unstructured type", obj))}
+	}
+	oldU, ok := old.(runtime.Unstructured)
+	if !ok {
+		return field.ErrorList{field.Invalid(nil, old, fmt.Sprintf("has type %T. Must be a pointer to an Unstructured type", old))}
+	}
+	return extensions.ValidateUpdate(u.UnstructuredContent(), oldU.UnstructuredContent(), a.schema, nil)
+}
+
+// validateRules evaluates the x-kubernetes-validations rules of the schema against obj. The rules
+// of the errors of failed rules are recorded in failedRules.
+func (a customResourceValidator) validateRules(obj runtime.Object, failedRules cel.RuleFailures) field.ErrorList {
+	if a.celValidator == nil {
+		return nil
+	}
+	u, ok := obj.(runtime.Unstructured)
+	if !ok {
+		return field.ErrorList{field.Invalid(nil, obj, fmt.Sprintf("has type %T. Must be a pointer to an Unstructured type", obj))}
+	}
+	errs, budgetExceeded := a.celValidator.ValidateWithFailures(nil, u.UnstructuredContent(), nil, a.ruleCostBudget, failedRules)
+	if budgetExceeded {
+		metrics.IncRuleCostBudgetExceeded(a.resource)
+	}
+	return errs
+}
+
+// validateRulesUpdate evaluates the x-kubernetes-validations rules of the schema against obj.
+// Values which did not change compared to old are not validated again.
+func (a customResourceValidator) validateRulesUpdate(obj, old runtime.Object, failedRules cel.RuleFailures) field.ErrorList {
+	if a.celValidator == nil {
+		return nil
+	}
+	u, ok := obj.(runtime.Unstructured)
+	if !ok {
+		return field.ErrorList{field.Invalid(nil, obj, fmt.Sprintf("has type %T. Must be a pointer to an Unstructured type", obj))}
+	}
+	oldU, ok := old.(runtime.Unstructured)
+	if !ok {
+		return field.ErrorList{field.Invalid(nil, old, fmt.Sprintf("has type %T. Must be a pointer to an Unstructured type", old))}
+	}
+	errs, budgetExceeded := a.celValidator.ValidateWithFailures(nil, u.UnstructuredContent(), oldU.UnstructuredContent(), a.ruleCostBudget, failedRules)
+	if budgetExceeded {
+		metrics.IncRuleCostBudgetExceeded(a.resource)
+	}
+	return errs
 }
@@ -0,0 +1,608 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/fieldmanager"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/storage"
+)
+
+func newTestCustomResource(generation int64, spec, status interface{}) *unstructured.Unstructured {
+	cr := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "mygroup.example.com/v1beta1",
+		"kind":       "Noxu",
+		"metadata": map[string]interface{}{
+			"name":            "foo",
+			"resourceVersion": "42",
+		},
+	}}
+	if generation > 0 {
+		cr.SetGeneration(generation)
+	}
+	if spec != nil {
+		cr.Object["spec"] = spec
+	}
+	if status != nil {
+		cr.Object["status"] = status
+	}
+	return cr
+}
+
+func TestStatusSubresourceStrategy(t *testing.T) {
+	kind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1beta1", Kind: "Noxu"}
+	strategy := NewStrategy(nil, StrategyOptions{Kind: kind, Plural: "noxus", PreserveUnknownFields: true, Status: &apiextensions.CustomResourceSubresourceStatus{}}, nil, 0)
+	ctx := genericapirequest.NewContext()
+
+	cr := newTestCustomResource(0, "spec", "status")
+	strategy.PrepareForCreate(ctx, cr)
+	if expected := newTestCustomResource(1, "spec", nil); !reflect.DeepEqual(cr, expected) {
+		t.Errorf("create: expected %v, got %v", expected, cr)
+	}
+
+	tests := []struct {
+		name     string
+		old      *unstructured.Unstructured
+		obj      *unstructured.Unstructured
+		expected *unstructured.Unstructured
+	}{
+		{
+			name:     "status is ignored",
+			old:      newTestCustomResource(1, "spec", "status"),
+			obj:      newTestCustomResource(1, "spec", "new status"),
+			expected: newTestCustomResource(1, "spec", "status"),
+		},
+		{
+			name:     "new status is dropped",
+			old:      newTestCustomResource(1, "spec", nil),
+			obj:      newTestCustomResource(1, "spec", "new status"),
+			expected: newTestCustomResource(1, "spec", nil),
+		},
+		{
+			name:     "spec change increments generation",
+			old:      newTestCustomResource(1, "spec", "status"),
+			obj:      newTestCustomResource(1, "new spec", "status"),
+			expected: newTestCustomResource(2, "new spec", "status"),
+		},
+	}
+	for _, tc := range tests {
+		strategy.PrepareForUpdate(ctx, tc.obj, tc.old)
+		if !reflect.DeepEqual(tc.obj, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, tc.obj)
+		}
+	}
+
+	statusStrategy := NewStatusStrategy(strategy)
+	obj := newTestCustomResource(1, "new spec", "new status")
+	obj.SetLabels(map[string]string{"new": "label"})
+	statusStrategy.PrepareForUpdate(ctx, obj, newTestCustomResource(1, "spec", "status"))
+	if expected := newTestCustomResource(1, "spec", "new status"); !reflect.DeepEqual(obj, expected) {
+		t.Errorf("status update: expected %v, got %v", expected, obj)
+	}
+}
+
+func TestStrategyWithoutStatusSubresource(t *testing.T) {
+	kind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1beta1", Kind: "Noxu"}
+	strategy := NewStrategy(nil, StrategyOptions{Kind: kind, Plural: "noxus", PreserveUnknownFields: true}, nil, 0)
+	ctx := genericapirequest.NewContext()
+
+	cr := newTestCustomResource(0, "spec", "status")
+	strategy.PrepareForCreate(ctx, cr)
+	if expected := newTestCustomResource(0, "spec", "status"); !reflect.DeepEqual(cr, expected) {
+		t.Errorf("create: expected %v, got %v", expected, cr)
+	}
+
+	cr = newTestCustomResource(0, "new spec", "new status")
+	strategy.PrepareForUpdate(ctx, cr, newTestCustomResource(0, "spec", "status"))
+	if expected := newTestCustomResource(0, "new spec", "new status"); !reflect.DeepEqual(cr, expected) {
+		t.Errorf("update: expected %v, got %v", expected, cr)
+	}
+}
+
+func TestInitialStatus(t *testing.T) {
+	kind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1beta1", Kind: "Noxu"}
+	openAPIV3Schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"status": {
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"phase":    {Type: "string"},
+					"replicas": {Type: "integer", Default: jsonPtr(int64(0))},
+				},
+			},
+		},
+	}
+	var initialStatus apiextensions.JSON = map[string]interface{}{"phase": "Pending"}
+	status := &apiextensions.CustomResourceSubresourceStatus{InitialStatus: &initialStatus}
+	strategy := NewStrategy(nil, StrategyOptions{Kind: kind, Plural: "noxus", Schema: openAPIV3Schema, PreserveUnknownFields: true, Status: status}, nil, 0)
+	ctx := genericapirequest.NewContext()
+
+	cr := newTestCustomResource(0, "spec", map[string]interface{}{"phase": "Running"})
+	strategy.PrepareForCreate(ctx, cr)
+	if expected := newTestCustomResource(1, "spec", map[string]interface{}{"phase": "Pending", "replicas": int64(0)}); !reflect.DeepEqual(cr, expected) {
+		t.Errorf("create: expected %v, got %v", expected, cr)
+	}
+	if expected := map[string]interface{}{"phase": "Pending"}; !reflect.DeepEqual(initialStatus, expected) {
+		t.Errorf("expected the initial status to be unchanged, got %v", initialStatus)
+	}
+
+	cr = newTestCustomResource(1, "new spec", map[string]interface{}{"phase": "Running"})
+	strategy.PrepareForUpdate(ctx, cr, newTestCustomResource(1, "spec", map[string]interface{}{"phase": "Failed"}))
+	if expected := newTestCustomResource(2, "new spec", map[string]interface{}{"phase": "Failed"}); !reflect.DeepEqual(cr, expected) {
+		t.Errorf("update: expected %v, got %v", expected, cr)
+	}
+}
+
+func TestSelectableFields(t *testing.T) {
+	kind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1beta1", Kind: "Noxu"}
+	strategy := NewStrategy(nil, StrategyOptions{NamespaceScoped: true, Kind: kind, Plural: "noxus", PreserveUnknownFields: true, SelectableFields: []apiextensions.SelectableField{
+		{JSONPath: ".spec.color"},
+		{JSONPath: ".spec.replicas"},
+		{JSONPath: ".status.ready"},
+		{JSONPath: ".status.phase"},
+	}}, nil, 0)
+
+	cr := newTestCustomResource(0, map[string]interface{}{"color": "blue", "replicas": int64(3)}, map[string]interface{}{"ready": true})
+	cr.SetNamespace("default")
+	_, fieldsSet, _, err := strategy.GetAttrs(cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := fields.Set{
+		"metadata.name":      "foo",
+		"metadata.namespace": "default",
+		"spec.color":         "blue",
+		"spec.replicas":      "3",
+		"status.ready":       "true",
+		"status.phase":       "",
+	}
+	if !reflect.DeepEqual(fieldsSet, expected) {
+		t.Errorf("expected fields %v, got %v", expected, fieldsSet)
+	}
+
+	predicate := strategy.MatchCustomResourceDefinitionStorage(labels.Everything(), fields.OneTermEqualSelector("spec.color", "blue"))
+	if matches, err := predicate.Matches(cr); err != nil || !matches {
+		t.Errorf("expected the custom resource to match, got %v, %v", matches, err)
+	}
+	if expected := []storage.MatchValue{{IndexName: "spec.color", Value: "blue"}}; !reflect.DeepEqual(predicate.MatcherIndex(), expected) {
+		t.Errorf("expected matcher index %v, got %v", expected, predicate.MatcherIndex())
+	}
+	if expected := []storage.MatchValue{{IndexName: "spec.color", Value: "blue"}}; !reflect.DeepEqual(strategy.triggerFunc(cr), expected) {
+		t.Errorf("expected trigger values %v, got %v", expected, strategy.triggerFunc(cr))
+	}
+
+	predicate = strategy.MatchCustomResourceDefinitionStorage(labels.Everything(), fields.OneTermEqualSelector("status.ready", "false"))
+	if matches, err := predicate.Matches(cr); err != nil || matches {
+		t.Errorf("expected the custom resource to not match, got %v, %v", matches, err)
+	}
+}
+
+func TestManagedFieldsTracking(t *testing.T) {
+	kind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1beta1", Kind: "Noxu"}
+	strategy := NewStrategy(nil, StrategyOptions{Kind: kind, Plural: "noxus", PreserveUnknownFields: true}, nil, 0)
+	ctx := fieldmanager.WithManager(genericapirequest.NewContext(), "creator")
+
+	cr := newTestCustomResource(0, map[string]interface{}{"replicas": int64(1)}, nil)
+	strategy.PrepareForCreate(ctx, cr)
+	managedFields, ok := cr.Object["metadata"].(map[string]interface{})["managedFields"].([]interface{})
+	if !ok || len(managedFields) != 1 || managedFields[0].(map[string]interface{})["manager"] != "creator" {
+		t.Fatalf("expected the fields to be managed by the creator, got %v", cr.Object["metadata"])
+	}
+
+	// apply requests set the managed fields themselves
+	obj := newTestCustomResource(0, map[string]interface{}{"replicas": int64(2)}, nil)
+	strategy.PrepareForUpdate(fieldmanager.WithApply(fieldmanager.WithManager(ctx, "applier")), obj, cr)
+	if _, found := obj.Object["metadata"].(map[string]interface{})["managedFields"]; found {
+		t.Errorf("expected the managed fields of an apply request to be untouched, got %v", obj.Object["metadata"])
+	}
+}
+
+func TestMetadataSchemaRestrictions(t *testing.T) {
+	kind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1beta1", Kind: "Noxu"}
+	openAPIV3Schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"metadata": {
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"name":   {Type: "string", Pattern: "^f"},
+					"labels": {Type: "object", Default: jsonPtr(map[string]interface{}{"a": "b"})},
+				},
+				XValidations: apiextensions.ValidationRules{{Rule: "false"}},
+			},
+			"spec": {
+				Type:    "object",
+				Default: jsonPtr(map[string]interface{}{}),
+			},
+		},
+	}
+	strategy := NewStrategy(nil, StrategyOptions{Kind: kind, Plural: "noxus", Schema: openAPIV3Schema, PreserveUnknownFields: true}, nil, 0)
+	ctx := genericapirequest.NewContext()
+
+	// metadata is not defaulted and restrictions other than the name pattern are ignored
+	cr := newTestCustomResource(0, nil, nil)
+	strategy.PrepareForCreate(ctx, cr)
+	if labels := cr.GetLabels(); len(labels) != 0 {
+		t.Errorf("expected metadata not to be defaulted, got labels %v", labels)
+	}
+	if _, found := cr.Object["spec"]; !found {
+		t.Errorf("expected spec to be defaulted")
+	}
+	if errs := strategy.Validate(ctx, cr); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	cr.SetName("bar")
+	if errs := strategy.Validate(ctx, cr); len(errs) != 1 || errs[0].Field != "metadata.name" {
+		t.Errorf("expected an invalid name, got %v", errs)
+	}
+
+	// the schema of the CRD is not modified
+	if _, found := openAPIV3Schema.Properties["metadata"].Properties["labels"]; !found {
+		t.Errorf("expected the schema of the CRD to be unchanged")
+	}
+}
+
+func TestSchemaValidation(t *testing.T) {
+	kind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1beta1", Kind: "Noxu"}
+	minReplicas := float64(1)
+	openAPIV3Schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"metadata": {
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"name": {Type: "string", Pattern: "^f"},
+				},
+			},
+			"spec": {
+				Type:     "object",
+				Required: []string{"image"},
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"image":    {Type: "string"},
+					"replicas": {Type: "integer", Minimum: &minReplicas},
+					"mode":     {Type: "string", Enum: []apiextensions.JSON{"fast", "slow"}},
+				},
+			},
+		},
+	}
+	strategy := NewStrategy(nil, StrategyOptions{Kind: kind, Plural: "noxus", Schema: openAPIV3Schema, PreserveUnknownFields: true}, nil, 0)
+	ctx := genericapirequest.NewContext()
+
+	valid := newTestCustomResource(0, map[string]interface{}{"image": "busybox", "replicas": int64(1)}, nil)
+	if errs := strategy.Validate(ctx, valid); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	// every violation is reported separately with the path of the offending value
+	invalid := newTestCustomResource(0, map[string]interface{}{"replicas": int64(0), "mode": "medium"}, nil)
+	type fieldError struct {
+		Type  field.ErrorType
+		Field string
+	}
+	expected := []fieldError{
+		{field.ErrorTypeRequired, "spec.image"},
+		{field.ErrorTypeNotSupported, "spec.mode"},
+		{field.ErrorTypeInvalid, "spec.replicas"},
+	}
+	for _, errs := range []field.ErrorList{strategy.Validate(ctx, invalid), strategy.ValidateUpdate(ctx, invalid, valid)} {
+		var got []fieldError
+		for _, err := range errs {
+			got = append(got, fieldError{err.Type, err.Field})
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected errors %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestImmutableFieldValidation(t *testing.T) {
+	kind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1beta1", Kind: "Noxu"}
+	openAPIV3Schema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"image":    {Type: "string", XImmutable: true},
+					"replicas": {Type: "integer"},
+				},
+			},
+		},
+	}
+	strategy := NewStrategy(nil, StrategyOptions{Kind: kind, Plural: "noxus", Schema: openAPIV3Schema, PreserveUnknownFields: true}, nil, 0)
+	ctx := genericapirequest.NewContext()
+
+	old := newTestCustomResource(0, map[string]interface{}{"image": "busybox", "replicas": int64(1)}, nil)
+	scaled := newTestCustomResource(0, map[string]interface{}{"image": "busybox", "replicas": int64(2)}, nil)
+	if errs := strategy.ValidateUpdate(ctx, scaled, old); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	changed := newTestCustomResource(0, map[string]interface{}{"image": "nginx", "replicas": int64(1)}, nil)
+	errs := strategy.ValidateUpdate(ctx, changed, old)
+	if len(errs) != 1 || errs[0].Type != field.ErrorTypeInvalid || errs[0].Field != "spec.image" || errs[0].Detail != "field is immutable" {
+		t.Errorf("expected an immutable field error for spec.image, got %v", errs)
+	}
+	if errs := strategy.Validate(ctx, changed); len(errs) != 0 {
+		t.Errorf("unexpected errors on create: %v", errs)
+	}
+}
+
+func TestCustomSubresourceStrategy(t *testing.T) {
+	kind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1beta1", Kind: "Noxu"}
+	strategy := NewStrategy(nil, StrategyOptions{Kind: kind, Plural: "noxus", PreserveUnknownFields: true, Status: &apiextensions.CustomResourceSubresourceStatus{}}, nil, 0)
+	approveStrategy := NewCustomSubresourceStrategy(strategy, &apiextensions.CustomResourceSubresourceCustom{
+		Name:       "approve",
+		FieldPaths: []string{".spec.approved", ".spec.approver"},
+		Verbs:      []string{"update"},
+		Validation: &apiextensions.CustomResourceValidation{
+			OpenAPIV3Schema: &apiextensions.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"spec": {
+						Type: "object",
+						Properties: map[string]apiextensions.JSONSchemaProps{
+							"approved": {Type: "boolean"},
+						},
+					},
+				},
+			},
+		},
+	})
+	ctx := genericapirequest.NewContext()
+
+	tests := []struct {
+		name     string
+		old      *unstructured.Unstructured
+		obj      *unstructured.Unstructured
+		expected *unstructured.Unstructured
+	}{
+		{
+			name:     "only field paths are updated",
+			old:      newTestCustomResource(1, map[string]interface{}{"replicas": int64(1), "approver": "alice"}, "status"),
+			obj:      newTestCustomResource(1, map[string]interface{}{"replicas": int64(2), "approved": true}, "new status"),
+			expected: newTestCustomResource(2, map[string]interface{}{"replicas": int64(1), "approved": true}, "status"),
+		},
+		{
+			name:     "unchanged field paths keep generation",
+			old:      newTestCustomResource(1, map[string]interface{}{"approved": true}, "status"),
+			obj:      newTestCustomResource(1, map[string]interface{}{"approved": true, "replicas": int64(2)}, nil),
+			expected: newTestCustomResource(1, map[string]interface{}{"approved": true}, "status"),
+		},
+	}
+	for _, tc := range tests {
+		tc.obj.SetLabels(map[string]string{"new": "label"})
+		approveStrategy.PrepareForUpdate(ctx, tc.obj, tc.old)
+		if !reflect.DeepEqual(tc.obj, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, tc.obj)
+		}
+	}
+
+	old := newTestCustomResource(1, map[string]interface{}{}, nil)
+	if errs := approveStrategy.ValidateUpdate(ctx, newTestCustomResource(1, map[string]interface{}{"approved": true}, nil), old); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	errs := approveStrategy.ValidateUpdate(ctx, newTestCustomResource(1, map[string]interface{}{"approved": "yes"}, nil), old)
+	if len(errs) != 1 || errs[0].Field != "spec.approved" {
+		t.Errorf("expected an error for spec.approved, got %v", errs)
+	}
+}
+
+// fakeOwnerMapper serves the namespaced kind Parent in other.example.com/v1.
+type fakeOwnerMapper struct{}
+
+func (fakeOwnerMapper) ServesGroup(group string) bool {
+	return group == "other.example.com"
+}
+
+func (fakeOwnerMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	if gk != (schema.GroupKind{Group: "other.example.com", Kind: "Parent"}) || len(versions) != 1 || versions[0] != "v1" {
+		return nil, &meta.NoKindMatchError{PartialKind: gk.WithVersion("")}
+	}
+	return &meta.RESTMapping{Resource: "parents", GroupVersionKind: gk.WithVersion("v1"), Scope: meta.RESTScopeNamespace}, nil
+}
+
+func TestOwnerReferenceValidation(t *testing.T) {
+	kind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1beta1", Kind: "Noxu"}
+	ctx := genericapirequest.NewContext()
+	owner := func(apiVersion, kind, uid string) metav1.OwnerReference {
+		return metav1.OwnerReference{APIVersion: apiVersion, Kind: kind, Name: "owner", UID: types.UID(uid)}
+	}
+
+	tests := []struct {
+		name            string
+		namespaceScoped bool
+		owners          []metav1.OwnerReference
+		// oldOwners are the owner references before an update, if set
+		oldOwners      []metav1.OwnerReference
+		expectedFields []string
+	}{
+		{
+			name:            "served kind",
+			namespaceScoped: true,
+			owners:          []metav1.OwnerReference{owner("other.example.com/v1", "Parent", "1")},
+		},
+		{
+			name:            "group not served by CRDs",
+			namespaceScoped: true,
+			owners:          []metav1.OwnerReference{owner("apps/v1beta1", "Deployment", "1")},
+		},
+		{
+			name:            "unknown kind",
+			namespaceScoped: true,
+			owners:          []metav1.OwnerReference{owner("other.example.com/v1", "Parent", "1"), owner("other.example.com/v1", "Child", "2")},
+			expectedFields:  []string{"metadata.ownerReferences[1].kind"},
+		},
+		{
+			name:            "unserved version",
+			namespaceScoped: true,
+			owners:          []metav1.OwnerReference{owner("other.example.com/v2", "Parent", "1")},
+			expectedFields:  []string{"metadata.ownerReferences[0].kind"},
+		},
+		{
+			name:           "namespaced owner of cluster-scoped custom resource",
+			owners:         []metav1.OwnerReference{owner("other.example.com/v1", "Parent", "1")},
+			expectedFields: []string{"metadata.ownerReferences[0].kind"},
+		},
+		{
+			name:            "unchanged unknown kind on update",
+			namespaceScoped: true,
+			owners:          []metav1.OwnerReference{owner("other.example.com/v1", "Child", "2")},
+			oldOwners:       []metav1.OwnerReference{owner("other.example.com/v1", "Child", "2")},
+		},
+		{
+			name:            "added unknown kind on update",
+			namespaceScoped: true,
+			owners:          []metav1.OwnerReference{owner("other.example.com/v1", "Child", "2"), owner("other.example.com/v1", "Child", "3")},
+			oldOwners:       []metav1.OwnerReference{owner("other.example.com/v1", "Child", "2")},
+			expectedFields:  []string{"metadata.ownerReferences[1].kind"},
+		},
+	}
+
+	for _, tc := range tests {
+		strategy := NewStrategy(nil, StrategyOptions{NamespaceScoped: tc.namespaceScoped, Kind: kind, Plural: "noxus", PreserveUnknownFields: true}, fakeOwnerMapper{}, 0)
+		cr := newTestCustomResource(0, nil, nil)
+		if tc.namespaceScoped {
+			cr.SetNamespace("default")
+		}
+		cr.SetOwnerReferences(tc.owners)
+
+		var errs field.ErrorList
+		if tc.oldOwners != nil {
+			old := cr.DeepCopy()
+			old.SetOwnerReferences(tc.oldOwners)
+			errs = strategy.ValidateUpdate(ctx, cr, old)
+		} else {
+			errs = strategy.Validate(ctx, cr)
+		}
+		var fields []string
+		for _, err := range errs {
+			fields = append(fields, err.Field)
+		}
+		if !reflect.DeepEqual(fields, tc.expectedFields) {
+			t.Errorf("%s: expected errors for %v, got %v", tc.name, tc.expectedFields, errs)
+		}
+	}
+}
+
+func TestFinalizerValidation(t *testing.T) {
+	kind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1beta1", Kind: "Noxu"}
+	ctx := genericapirequest.NewContext()
+
+	tests := []struct {
+		name           string
+		allowedDomains []string
+		finalizers     []string
+		oldFinalizers  []string
+		update         bool
+		expectedFields []string
+	}{
+		{
+			name:       "any finalizer without allowed domains",
+			finalizers: []string{"cleanup", "other.com/cleanup"},
+		},
+		{
+			name:           "allowed domain and subdomain",
+			allowedDomains: []string{"example.com"},
+			finalizers:     []string{"example.com/cleanup", "backup.example.com/snapshot"},
+		},
+		{
+			name:           "orphan finalizer",
+			allowedDomains: []string{"example.com"},
+			finalizers:     []string{metav1.FinalizerOrphanDependents},
+		},
+		{
+			name:           "foreground deletion finalizer",
+			allowedDomains: []string{"example.com"},
+			finalizers:     []string{metav1.FinalizerDeleteDependents},
+		},
+		{
+			name:           "disallowed domains",
+			allowedDomains: []string{"example.com"},
+			finalizers:     []string{"example.com/cleanup", "cleanup", "notexample.com/cleanup", "example.com.evil.io/cleanup"},
+			expectedFields: []string{"metadata.finalizers[1]", "metadata.finalizers[2]", "metadata.finalizers[3]"},
+		},
+		{
+			name:           "existing disallowed finalizer on update",
+			allowedDomains: []string{"example.com"},
+			finalizers:     []string{"other.com/cleanup", "example.com/cleanup"},
+			oldFinalizers:  []string{"other.com/cleanup"},
+			update:         true,
+		},
+		{
+			name:           "added disallowed finalizer on update",
+			allowedDomains: []string{"example.com"},
+			finalizers:     []string{"example.com/cleanup", "exmaple.com/cleanup"},
+			oldFinalizers:  []string{"example.com/cleanup"},
+			update:         true,
+			expectedFields: []string{"metadata.finalizers[1]"},
+		},
+		{
+			name:           "added malformed finalizer on update",
+			finalizers:     []string{"example.com/clean up"},
+			update:         true,
+			expectedFields: []string{"metadata.finalizers[0]"},
+		},
+		{
+			name:          "existing malformed finalizer on update",
+			finalizers:    []string{"example.com/clean up"},
+			oldFinalizers: []string{"example.com/clean up"},
+			update:        true,
+		},
+	}
+
+	for _, tc := range tests {
+		strategy := NewStrategy(nil, StrategyOptions{Kind: kind, Plural: "noxus", PreserveUnknownFields: true, AllowedFinalizerDomains: tc.allowedDomains}, nil, 0)
+		cr := newTestCustomResource(0, nil, nil)
+		cr.SetFinalizers(tc.finalizers)
+
+		var errs field.ErrorList
+		if tc.update {
+			old := cr.DeepCopy()
+			old.SetFinalizers(tc.oldFinalizers)
+			errs = strategy.ValidateUpdate(ctx, cr, old)
+		} else {
+			errs = strategy.Validate(ctx, cr)
+		}
+		var fields []string
+		for _, err := range errs {
+			fields = append(fields, err.Field)
+		}
+		if !reflect.DeepEqual(fields, tc.expectedFields) {
+			t.Errorf("%s: expected errors for %v, got %v", tc.name, tc.expectedFields, errs)
+		}
+	}
+}
+
+func jsonPtr(x interface{}) *apiextensions.JSON {
+	ret := apiextensions.JSON(x)
+	return &ret
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"fmt"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1alpha1 "k8s.io/apimachinery/pkg/apis/meta/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+)
+
+var swaggerMetadataDescriptions = metav1.ObjectMeta{}.SwaggerDoc()
+
+// NewTableConvertor creates a table convertor which prints the name of a custom resource
+// followed by the given additional printer columns.
+func NewTableConvertor(crdColumns []apiextensions.CustomResourceColumnDefinition) rest.TableConvertor {
+	c := &tableConvertor{}
+	c.headers = append(c.headers, metav1alpha1.TableColumnDefinition{
+		Name:        "Name",
+		Type:        "string",
+		Format:      "name",
+		Description: swaggerMetadataDescriptions["name"],
+	})
+	for _, col := range crdColumns {
+		desc := fmt.Sprintf("Custom resource definition column (in JSONPath format): %s", col.JSONPath)
+		if len(col.Description) > 0 {
+			desc = col.Description
+		}
+		c.headers = append(c.headers, metav1alpha1.TableColumnDefinition{
+			Name:        col.Name,
+			Type:        col.Type,
+			Format:      col.Format,
+			Description: desc,
+			Priority:    col.Priority,
+		})
+		c.paths = append(c.paths, splitSimpleJSONPath(col.JSONPath))
+	}
+	return c
+}
+
+type tableConvertor struct {
+	headers []metav1alpha1.TableColumnDefinition
+	paths   [][]string
+}
+
+var _ rest.TableConvertor = &tableConvertor{}
+
+func (c *tableConvertor) ConvertToTable(ctx genericapirequest.Context, obj runtime.Object, tableOptions runtime.Object) (*metav1alpha1.Table, error) {
+	table := &metav1alpha1.Table{
+		ColumnDefinitions: c.headers,
+	}
+	if m, err := meta.ListAccessor(obj); err == nil {
+		table.ResourceVersion = m.GetResourceVersion()
+		table.SelfLink = m.GetSelfLink()
+	}
+
+	fn := func(obj runtime.Object) error {
+		m, err := meta.Accessor(obj)
+		if err != nil {
+			return err
+		}
+		u, ok := obj.(runtime.Unstructured)
+		if !ok {
+			return fmt.Errorf("unexpected object type %T", obj)
+		}
+
+		cells := make([]interface{}, 1, 1+len(c.paths))
+		cells[0] = m.GetName()
+		for i, path := range c.paths {
+			cells = append(cells, cellForColumn(c.headers[i+1].Type, u.UnstructuredContent(), path))
+		}
+		table.Rows = append(table.Rows, metav1alpha1.TableRow{
+			Cells:  cells,
+			Object: runtime.RawExtension{Object: obj},
+		})
+		return nil
+	}
+	if meta.IsListType(obj) {
+		if err := meta.EachListItem(obj, fn); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := fn(obj); err != nil {
+			return nil, err
+		}
+	}
+	return table, nil
+}
+
+// cellForColumn returns the value at the given path if it matches the column type, and nil otherwise.
+func cellForColumn(columnType string, obj map[string]interface{}, path []string) interface{} {
+	val, found := nestedField(obj, path...)
+	if !found {
+		return nil
+	}
+	switch columnType {
+	case "integer":
+		switch val := val.(type) {
+		case int64:
+			return val
+		case float64:
+			if val == float64(int64(val)) {
+				return int64(val)
+			}
+		}
+	case "number":
+		switch val.(type) {
+		case int64, float64:
+			return val
+		}
+	case "string", "date":
+		if val, ok := val.(string); ok {
+			return val
+		}
+	case "boolean":
+		if val, ok := val.(bool); ok {
+			return val
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func TestConvertToTable(t *testing.T) {
+	convertor := NewTableConvertor([]apiextensions.CustomResourceColumnDefinition{
+		{Name: "Replicas", Type: "integer", JSONPath: ".spec.replicas"},
+		{Name: "Ratio", Type: "number", JSONPath: ".status.ratio"},
+		{Name: "Phase", Type: "string", Description: "The phase", Priority: 1, JSONPath: ".status.phase"},
+		{Name: "Ready", Type: "boolean", JSONPath: ".status.ready"},
+		{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+	})
+
+	newCR := func(name string, spec, status map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "mygroup.example.com/v1beta1",
+			"kind":       "Noxu",
+			"metadata": map[string]interface{}{
+				"name":              name,
+				"creationTimestamp": "2017-10-01T00:00:00Z",
+			},
+			"spec":   spec,
+			"status": status,
+		}
+	}
+	list := &unstructured.UnstructuredList{
+		Object: map[string]interface{}{
+			"apiVersion": "mygroup.example.com/v1beta1",
+			"kind":       "NoxuList",
+			"metadata":   map[string]interface{}{"resourceVersion": "42"},
+		},
+		Items: []unstructured.Unstructured{
+			{Object: newCR("foo",
+				map[string]interface{}{"replicas": int64(3)},
+				map[string]interface{}{"ratio": float64(0.5), "phase": "Running", "ready": true},
+			)},
+			{Object: newCR("bar",
+				map[string]interface{}{"replicas": "3"},
+				map[string]interface{}{"ratio": int64(1), "phase": int64(1)},
+			)},
+		},
+	}
+
+	table, err := convertor.ConvertToTable(genericapirequest.NewContext(), list, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if table.ResourceVersion != "42" {
+		t.Errorf("expected resourceVersion 42, got %q", table.ResourceVersion)
+	}
+
+	var names []string
+	for _, col := range table.ColumnDefinitions {
+		names = append(names, col.Name)
+	}
+	if expected := []string{"Name", "Replicas", "Ratio", "Phase", "Ready", "Age"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected columns %v, got %v", expected, names)
+	}
+	if col := table.ColumnDefinitions[3]; col.Description != "The phase" || col.Priority != 1 {
+		t.Errorf("expected description and priority to be copied, got %#v", col)
+	}
+
+	expectedCells := [][]interface{}{
+		{"foo", int64(3), float64(0.5), "Running", true, "2017-10-01T00:00:00Z"},
+		{"bar", nil, int64(1), nil, nil, "2017-10-01T00:00:00Z"},
+	}
+	if len(table.Rows) != len(expectedCells) {
+		t.Fatalf("expected %d rows, got %d", len(expectedCells), len(table.Rows))
+	}
+	for i, row := range table.Rows {
+		if !reflect.DeepEqual(row.Cells, expectedCells[i]) {
+			t.Errorf("row %d: expected %#v, got %#v", i, expectedCells[i], row.Cells)
+		}
+	}
+}
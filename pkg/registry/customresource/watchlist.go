@@ -0,0 +1,320 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/storage"
+)
+
+const (
+	// bookmarkEventType is the type of the events which only carry the resourceVersion to resume a watch
+	// from, e.g. the event with the initialEventsEndAnnotation which ends the initial events of a watch
+	// with sendInitialEvents.
+	bookmarkEventType watch.EventType = "BOOKMARK"
+	// initialEventsEndAnnotation marks the bookmark after the initial events.
+	initialEventsEndAnnotation = "k8s.io/initial-events-end"
+	// cachedInitialEventsAttempts is the number of attempts to stream the initial events from the
+	// watch cache before they are listed instead.
+	cachedInitialEventsAttempts = 3
+)
+
+// watchCache is implemented by the watch cache of the storage, which serves lists and watches with a
+// resourceVersion from memory.
+type watchCache interface {
+	storage.Interface
+	LastSyncResourceVersion() (uint64, error)
+}
+
+// consistentWatchCache is implemented by watch caches which can verify that they are up to date with
+// etcd. ConsistentResourceVersion returns the resourceVersion of the cache if the objects below key
+// are the same as in etcd, or an empty string.
+type consistentWatchCache interface {
+	watchCache
+	ConsistentResourceVersion(ctx context.Context, key string) (string, error)
+}
+
+// sendInitialEvents validates the sendInitialEvents and resourceVersionMatch parameters of a watch
+// request and returns whether the initial events must be sent. They require resourceVersionMatch
+// NotOlderThan, and resourceVersionMatch is only allowed with sendInitialEvents.
+func (p ListParameters) sendInitialEvents() (bool, error) {
+	var send bool
+	switch p.SendInitialEvents {
+	case "", "false":
+	case "true":
+		send = true
+	default:
+		return false, errors.NewBadRequest(fmt.Sprintf("invalid sendInitialEvents %q: must be true or false", p.SendInitialEvents))
+	}
+	if send && p.ResourceVersionMatch != resourceVersionMatchNotOlderThan {
+		return false, errors.NewBadRequest(fmt.Sprintf("sendInitialEvents requires resourceVersionMatch=%s", resourceVersionMatchNotOlderThan))
+	}
+	if !send && len(p.ResourceVersionMatch) > 0 {
+		return false, errors.NewBadRequest("resourceVersionMatch is only allowed for watches with sendInitialEvents=true")
+	}
+	return send, nil
+}
+
+// watchCached streams the initial events of a watch with sendInitialEvents from the watch cache
+// instead of listing them: a watch of the cache from resourceVersion 0 starts with an ADDED event
+// for each cached object. The matching objects are counted, without copying them, before and after
+// the watch is started. If the resourceVersion of the cache is the same both times, the watch
+// started at that resourceVersion and the initial events end after the counted number of events.
+// Otherwise the watch is retried. Lists without resourceVersion must be consistent, so they are
+// only streamed if the cache is verified to be up to date.
+//
+// It returns false if the initial events cannot be streamed from the watch cache, e.g. without
+// watch cache or for a single object.
+func (r *REST) watchCached(ctx genericapirequest.Context, options *metainternalversion.ListOptions, bookmarks bool) (watch.Interface, bool, error) {
+	cache, ok := unwrapStorage(r.Store.Storage).(watchCache)
+	if !ok {
+		return nil, false, nil
+	}
+	p := r.predicate(options)
+	if _, ok := p.MatchesSingle(); ok {
+		return nil, false, nil
+	}
+	key := r.Store.KeyRootFunc(ctx)
+
+	watchOptions := metainternalversion.ListOptions{}
+	if options != nil {
+		watchOptions = *options
+	}
+	// unlike 0, any resourceVersion waits until the cache is initialized instead of listing from etcd
+	minimum := watchOptions.ResourceVersion
+	switch minimum {
+	case "0":
+		minimum = "1"
+	case "":
+		consistent, ok := cache.(consistentWatchCache)
+		if !ok {
+			return nil, false, nil
+		}
+		rv, err := consistent.ConsistentResourceVersion(ctx, key)
+		if err != nil {
+			glog.V(4).Infof("Unable to verify that the watch cache of %s is up to date, listing the initial events: %v", key, err)
+		}
+		if len(rv) == 0 {
+			return nil, false, nil
+		}
+		minimum = rv
+	}
+	watchOptions.Watch = true
+	watchOptions.ResourceVersion = "0"
+
+	for i := 0; i < cachedInitialEventsAttempts; i++ {
+		count, rv, err := countCached(ctx, cache, key, minimum, p, r.Store.NewListFunc)
+		if err != nil {
+			return nil, false, err
+		}
+		delegate, err := r.watch(ctx, &watchOptions, bookmarks, count, rv)
+		if err != nil {
+			return nil, false, err
+		}
+		_, started, err := countCached(ctx, cache, key, minimum, p, r.Store.NewListFunc)
+		if err != nil {
+			delegate.Stop()
+			return nil, false, err
+		}
+		if started == rv {
+			return newInitialEventsWatcher(nil, count, delegate, initialEventsEnd(r.kind, rv)), true, nil
+		}
+		delegate.Stop()
+	}
+	return nil, false, nil
+}
+
+// predicate returns the predicate of the options which the store watches with.
+func (r *REST) predicate(options *metainternalversion.ListOptions) storage.SelectionPredicate {
+	label := labels.Everything()
+	if options != nil && options.LabelSelector != nil {
+		label = options.LabelSelector
+	}
+	field := fields.Everything()
+	if options != nil && options.FieldSelector != nil {
+		field = options.FieldSelector
+	}
+	p := r.Store.PredicateFunc(label, field)
+	if options != nil {
+		p.IncludeUninitialized = options.IncludeUninitialized
+	}
+	return p
+}
+
+// countCached returns the number of objects below key in the watch cache which match the predicate,
+// and the resourceVersion of the cache, which is at least the given resourceVersion. The objects are
+// not copied into the list: the predicate of the list counts them and reports them uninitialized.
+func countCached(ctx context.Context, cache watchCache, key, resourceVersion string, p storage.SelectionPredicate, newListFunc func() runtime.Object) (int, string, error) {
+	count := 0
+	counting := storage.SelectionPredicate{
+		Label: labels.Everything(),
+		Field: fields.Everything(),
+		GetAttrs: func(obj runtime.Object) (labels.Set, fields.Set, bool, error) {
+			if matches, err := p.Matches(obj); err == nil && matches {
+				count++
+			}
+			return nil, nil, true, nil
+		},
+	}
+	list := newListFunc()
+	if err := cache.List(ctx, key, resourceVersion, counting, list); err != nil {
+		return 0, "", err
+	}
+	listMeta, err := meta.ListAccessor(list)
+	if err != nil {
+		return 0, "", err
+	}
+	return count, listMeta.GetResourceVersion(), nil
+}
+
+// watchList sends the custom resources listed with the options as ADDED events, one at a time,
+// followed by a bookmark with the resourceVersion of the list, and then the events of a watch from
+// that resourceVersion on. It is used if the initial events cannot be streamed from the watch
+// cache, see watchCached.
+func watchList(list func(*metainternalversion.ListOptions) (runtime.Object, error), watchFrom func(*metainternalversion.ListOptions) (watch.Interface, error), options *metainternalversion.ListOptions, kind schema.GroupVersionKind) (watch.Interface, error) {
+	listOptions := metainternalversion.ListOptions{}
+	if options != nil {
+		listOptions = *options
+	}
+	listOptions.Watch = false
+	obj, err := list(&listOptions)
+	if err != nil {
+		return nil, err
+	}
+	initial, ok := obj.(*unstructured.UnstructuredList)
+	if !ok {
+		return nil, fmt.Errorf("unexpected list type %T", obj)
+	}
+
+	watchOptions := listOptions
+	watchOptions.Watch = true
+	watchOptions.ResourceVersion = initial.GetResourceVersion()
+	delegate, err := watchFrom(&watchOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return newInitialEventsWatcher(initial.Items, 0, delegate, initialEventsEnd(kind, initial.GetResourceVersion())), nil
+}
+
+// initialEventsEnd returns the bookmark which ends the initial events at the given resourceVersion.
+func initialEventsEnd(kind schema.GroupVersionKind, resourceVersion string) *unstructured.Unstructured {
+	bookmark := newBookmark(kind, resourceVersion)
+	bookmark.SetAnnotations(map[string]string{initialEventsEndAnnotation: "true"})
+	return bookmark
+}
+
+// initialEventsWatcher sends the initial events, i.e. the listed items as ADDED events and the given
+// number of first events of the delegate, followed by the bookmark and the remaining events of the
+// delegate.
+type initialEventsWatcher struct {
+	*proxyWatcher
+}
+
+func newInitialEventsWatcher(items []unstructured.Unstructured, initial int, delegate watch.Interface, bookmark *unstructured.Unstructured) *initialEventsWatcher {
+	w := &initialEventsWatcher{newProxyWatcher(delegate)}
+	go w.run(items, initial, bookmark)
+	return w
+}
+
+func (w *initialEventsWatcher) run(items []unstructured.Unstructured, initial int, bookmark *unstructured.Unstructured) {
+	defer close(w.result)
+
+	for i := range items {
+		// listed items can share their content with the watch cache, and events are modified when
+		// they are served
+		if !w.send(watch.Event{Type: watch.Added, Object: items[i].DeepCopy()}) {
+			return
+		}
+	}
+	for ; initial > 0; initial-- {
+		if !w.forward() {
+			return
+		}
+	}
+	if !w.send(watch.Event{Type: bookmarkEventType, Object: bookmark}) {
+		return
+	}
+	for w.forward() {
+	}
+}
+
+// proxyWatcher sends events to its result channel from a goroutine, which closes it, until it is
+// stopped. Stop also stops the delegate.
+type proxyWatcher struct {
+	result   chan watch.Event
+	done     chan struct{}
+	stopOnce sync.Once
+	delegate watch.Interface
+}
+
+var _ watch.Interface = &proxyWatcher{}
+
+func newProxyWatcher(delegate watch.Interface) *proxyWatcher {
+	return &proxyWatcher{
+		result:   make(chan watch.Event),
+		done:     make(chan struct{}),
+		delegate: delegate,
+	}
+}
+
+// send returns false if the watcher was stopped.
+func (w *proxyWatcher) send(event watch.Event) bool {
+	select {
+	case w.result <- event:
+		return true
+	case <-w.done:
+		return false
+	}
+}
+
+// forward sends the next event of the delegate. It returns false if the delegate or the watcher
+// was stopped.
+func (w *proxyWatcher) forward() bool {
+	select {
+	case event, ok := <-w.delegate.ResultChan():
+		return ok && w.send(event)
+	case <-w.done:
+		return false
+	}
+}
+
+func (w *proxyWatcher) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+func (w *proxyWatcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.done)
+		w.delegate.Stop()
+	})
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresource
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/storage"
+)
+
+func TestSendInitialEventsParameters(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters ListParameters
+		send       bool
+		badRequest bool
+	}{
+		{name: "unset"},
+		{name: "false", parameters: ListParameters{SendInitialEvents: "false"}},
+		{name: "true", parameters: ListParameters{SendInitialEvents: "true", ResourceVersionMatch: "NotOlderThan"}, send: true},
+		{name: "invalid", parameters: ListParameters{SendInitialEvents: "yes", ResourceVersionMatch: "NotOlderThan"}, badRequest: true},
+		{name: "true without resourceVersionMatch", parameters: ListParameters{SendInitialEvents: "true"}, badRequest: true},
+		{name: "true with Exact", parameters: ListParameters{SendInitialEvents: "true", ResourceVersionMatch: "Exact"}, badRequest: true},
+		{name: "resourceVersionMatch without sendInitialEvents", parameters: ListParameters{ResourceVersionMatch: "NotOlderThan"}, badRequest: true},
+	}
+	for _, tt := range tests {
+		send, err := tt.parameters.sendInitialEvents()
+		if tt.badRequest {
+			if !errors.IsBadRequest(err) {
+				t.Errorf("%s: expected a bad request error, got %v", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if send != tt.send {
+			t.Errorf("%s: expected send=%v, got %v", tt.name, tt.send, send)
+		}
+	}
+}
+
+func TestWatchList(t *testing.T) {
+	kind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1beta1", Kind: "Noxu"}
+	initial := &unstructured.UnstructuredList{Object: map[string]interface{}{}}
+	initial.SetResourceVersion("42")
+	for _, name := range []string{"foo", "bar"} {
+		item := unstructured.Unstructured{Object: map[string]interface{}{}}
+		item.SetName(name)
+		initial.Items = append(initial.Items, item)
+	}
+
+	var listOptions, watchOptions metainternalversion.ListOptions
+	list := func(options *metainternalversion.ListOptions) (runtime.Object, error) {
+		listOptions = *options
+		return initial, nil
+	}
+	delegate := watch.NewFake()
+	watchFrom := func(options *metainternalversion.ListOptions) (watch.Interface, error) {
+		watchOptions = *options
+		return delegate, nil
+	}
+
+	w, err := watchList(list, watchFrom, &metainternalversion.ListOptions{Watch: true, ResourceVersion: "40"}, kind)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+	if listOptions.Watch || listOptions.ResourceVersion != "40" {
+		t.Errorf("unexpected list options %#v", listOptions)
+	}
+	if !watchOptions.Watch || watchOptions.ResourceVersion != "42" {
+		t.Errorf("expected a watch from the resourceVersion of the list, got %#v", watchOptions)
+	}
+
+	for _, name := range []string{"foo", "bar"} {
+		event := <-w.ResultChan()
+		if event.Type != watch.Added || event.Object.(*unstructured.Unstructured).GetName() != name {
+			t.Fatalf("expected ADDED event of %s, got %s %#v", name, event.Type, event.Object)
+		}
+	}
+	event := <-w.ResultChan()
+	bookmark, ok := event.Object.(*unstructured.Unstructured)
+	if event.Type != "BOOKMARK" || !ok {
+		t.Fatalf("expected a bookmark, got %s %#v", event.Type, event.Object)
+	}
+	if bookmark.GroupVersionKind() != kind || bookmark.GetResourceVersion() != "42" || bookmark.GetAnnotations()["k8s.io/initial-events-end"] != "true" {
+		t.Errorf("unexpected bookmark %#v", bookmark.Object)
+	}
+
+	modified := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	modified.SetName("foo")
+	go delegate.Modify(modified)
+	if event := <-w.ResultChan(); event.Type != watch.Modified || event.Object != modified {
+		t.Errorf("expected the MODIFIED event of the watch, got %s %#v", event.Type, event.Object)
+	}
+
+	w.Stop()
+	if _, ok := <-w.ResultChan(); ok {
+		t.Errorf("expected the result channel to be closed after Stop")
+	}
+	if !delegate.IsStopped() {
+		t.Errorf("expected the watch to be stopped")
+	}
+}
+
+// fakeWatchCache lists objs at the next of resourceVersions, and returns the next of watches.
+type fakeWatchCache struct {
+	storage.Interface
+	objs             []*unstructured.Unstructured
+	resourceVersions []string
+	watches          []*watch.FakeWatcher
+
+	listedFrom  []string
+	watchedFrom []string
+}
+
+func (c *fakeWatchCache) List(ctx context.Context, key string, resourceVersion string, p storage.SelectionPredicate, listObj runtime.Object) error {
+	list := listObj.(*unstructured.UnstructuredList)
+	for _, obj := range c.objs {
+		if matches, err := p.Matches(obj); err == nil && matches {
+			list.Items = append(list.Items, *obj)
+		}
+	}
+	list.SetResourceVersion(c.resourceVersions[len(c.listedFrom)])
+	c.listedFrom = append(c.listedFrom, resourceVersion)
+	return nil
+}
+
+func (c *fakeWatchCache) WatchList(ctx context.Context, key string, resourceVersion string, p storage.SelectionPredicate) (watch.Interface, error) {
+	w := c.watches[len(c.watchedFrom)]
+	c.watchedFrom = append(c.watchedFrom, resourceVersion)
+	return w, nil
+}
+
+func (c *fakeWatchCache) LastSyncResourceVersion() (uint64, error) {
+	return 0, nil
+}
+
+func TestWatchCached(t *testing.T) {
+	kind := schema.GroupVersionKind{Group: "mygroup.example.com", Version: "v1beta1", Kind: "Noxu"}
+	ctx := genericapirequest.NewContext()
+	foo := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	foo.SetName("foo")
+	foo.SetLabels(map[string]string{"color": "blue"})
+	bar := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	bar.SetName("bar")
+	selector := labels.SelectorFromSet(labels.Set{"color": "blue"})
+
+	// an object changed while the first watch was started
+	cache := &fakeWatchCache{
+		objs:             []*unstructured.Unstructured{foo, bar},
+		resourceVersions: []string{"5", "6", "6", "6"},
+		watches:          []*watch.FakeWatcher{watch.NewFake(), watch.NewFake()},
+	}
+	r := newReadREST(kind, cache)
+	r.kind = kind
+	r.Store.PredicateFunc = func(label labels.Selector, field fields.Selector) storage.SelectionPredicate {
+		return storage.SelectionPredicate{
+			Label: label,
+			Field: field,
+			GetAttrs: func(obj runtime.Object) (labels.Set, fields.Set, bool, error) {
+				return labels.Set(obj.(*unstructured.Unstructured).GetLabels()), nil, false, nil
+			},
+		}
+	}
+	w, ok, err := r.watchCached(ctx, &metainternalversion.ListOptions{Watch: true, ResourceVersion: "3", LabelSelector: selector}, false)
+	if err != nil || !ok {
+		t.Fatalf("expected the initial events to be streamed from the watch cache, got %v, %v", ok, err)
+	}
+	defer w.Stop()
+	if expected := []string{"3", "3", "3", "3"}; !equalStrings(cache.listedFrom, expected) {
+		t.Errorf("expected lists at least as fresh as %v, got %v", expected, cache.listedFrom)
+	}
+	if expected := []string{"0", "0"}; !equalStrings(cache.watchedFrom, expected) {
+		t.Errorf("expected watches from %v, got %v", expected, cache.watchedFrom)
+	}
+	if !cache.watches[0].IsStopped() {
+		t.Errorf("expected the first watch to be stopped")
+	}
+
+	delegate := cache.watches[1]
+	go func() {
+		delegate.Add(foo)
+		delegate.Modify(foo)
+	}()
+	if event := <-w.ResultChan(); event.Type != watch.Added || event.Object != foo {
+		t.Fatalf("expected the ADDED event of foo, got %s %#v", event.Type, event.Object)
+	}
+	event := <-w.ResultChan()
+	bookmark, ok := event.Object.(*unstructured.Unstructured)
+	if event.Type != "BOOKMARK" || !ok || bookmark.GetResourceVersion() != "6" || bookmark.GetAnnotations()["k8s.io/initial-events-end"] != "true" {
+		t.Fatalf("expected the bookmark at resourceVersion 6 after the initial events, got %s %#v", event.Type, event.Object)
+	}
+	if event := <-w.ResultChan(); event.Type != watch.Modified || event.Object != foo {
+		t.Errorf("expected the MODIFIED event of foo, got %s %#v", event.Type, event.Object)
+	}
+
+	// without resourceVersion, the cache must be verified to be up to date
+	if _, ok, err := r.watchCached(ctx, &metainternalversion.ListOptions{Watch: true}, false); err != nil || ok {
+		t.Errorf("expected a consistent watch not to be streamed from the watch cache, got %v, %v", ok, err)
+	}
+	// without watch cache
+	r = newReadREST(kind, &readStorage{obj: foo})
+	if _, ok, err := r.watchCached(ctx, &metainternalversion.ListOptions{Watch: true, ResourceVersion: "3"}, false); err != nil || ok {
+		t.Errorf("expected a storage without watch cache not to stream the initial events, got %v, %v", ok, err)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -18,11 +18,15 @@ package customresourcedefinition
 
 import (
 	"fmt"
+	"strings"
 
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/validation"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/generic"
 	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
@@ -36,9 +40,14 @@ type REST struct {
 	*genericregistry.Store
 }
 
-// NewREST returns a RESTStorage object that will work against API services.
-func NewREST(scheme *runtime.Scheme, optsGetter generic.RESTOptionsGetter) *REST {
-	strategy := NewStrategy(scheme)
+// NewREST returns a RESTStorage object that will work against API services. The instanceChecker
+// is used to allow scope changes of CRDs without custom resources. It is optional. With
+// requireAPIApproval, CRDs in groups owned by the Kubernetes project must carry a valid
+// api-approved.kubernetes.io annotation. The schemaCompatibilityPolicy decides whether breaking
+// changes of the schemas of served versions are accepted, reported or rejected. The validators of the embedding server run after the
+// built-in validation of created and updated CRDs.
+func NewREST(scheme *runtime.Scheme, optsGetter generic.RESTOptionsGetter, allowedStoragePrefixes []string, schemaLimits validation.SchemaLimits, instanceChecker InstanceChecker, requireAPIApproval bool, schemaCompatibilityPolicy validation.SchemaCompatibilityPolicy, validators []Validator) *REST {
+	strategy := NewStrategy(scheme, allowedStoragePrefixes, schemaLimits, instanceChecker, requireAPIApproval, schemaCompatibilityPolicy, validators)
 
 	store := &genericregistry.Store{
 		Copier:            scheme,
@@ -123,10 +132,11 @@ func (r *REST) Delete(ctx genericapirequest.Context, name string, options *metav
 				}
 				// update the status condition too
 				apiextensions.SetCRDCondition(existingCRD, apiextensions.CustomResourceDefinitionCondition{
-					Type:    apiextensions.Terminating,
-					Status:  apiextensions.ConditionTrue,
-					Reason:  "InstanceDeletionPending",
-					Message: "CustomResourceDefinition marked for deletion; CustomResource deletion will begin soon",
+					Type:               apiextensions.Terminating,
+					Status:             apiextensions.ConditionTrue,
+					Reason:             "InstanceDeletionPending",
+					Message:            "CustomResourceDefinition marked for deletion; CustomResource deletion will begin soon",
+					ObservedGeneration: existingCRD.Generation,
 				})
 				return existingCRD, nil
 			}),
@@ -147,6 +157,57 @@ func (r *REST) Delete(ctx genericapirequest.Context, name string, options *metav
 	return r.Store.Delete(ctx, name, options)
 }
 
+// Update records a rejected change of the scope in the ScopeChangeRejected condition.
+func (r *REST) Update(ctx genericapirequest.Context, name string, objInfo rest.UpdatedObjectInfo) (runtime.Object, bool, error) {
+	obj, created, err := r.Store.Update(ctx, name, objInfo)
+	if isScopeChangeRejection(err) {
+		if err := r.recordScopeChangeRejected(ctx, name); err != nil {
+			utilruntime.HandleError(fmt.Errorf("unable to record the rejected scope change of %s: %v", name, err))
+		}
+	}
+	return obj, created, err
+}
+
+// isScopeChangeRejection returns true if err is the validation error of a scope change which is
+// rejected because custom resources exist.
+func isScopeChangeRejection(err error) bool {
+	statusErr, ok := err.(*apierrors.StatusError)
+	if !ok || !apierrors.IsInvalid(err) || statusErr.ErrStatus.Details == nil {
+		return false
+	}
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		if cause.Field == "spec.scope" && cause.Type == metav1.CauseType(field.ErrorTypeForbidden) && strings.Contains(cause.Message, scopeChangeWithInstancesDetail) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *REST) recordScopeChangeRejected(ctx genericapirequest.Context, name string) error {
+	key, err := r.Store.KeyFunc(ctx, name)
+	if err != nil {
+		return err
+	}
+	return r.Store.Storage.GuaranteedUpdate(
+		ctx, key, r.Store.NewFunc(), false, nil,
+		storage.SimpleUpdate(func(existing runtime.Object) (runtime.Object, error) {
+			existingCRD, ok := existing.(*apiextensions.CustomResourceDefinition)
+			if !ok {
+				// wrong type
+				return nil, fmt.Errorf("expected *apiextensions.CustomResourceDefinition, got %v", existing)
+			}
+			apiextensions.SetCRDCondition(existingCRD, apiextensions.CustomResourceDefinitionCondition{
+				Type:               apiextensions.ScopeChangeRejected,
+				Status:             apiextensions.ConditionTrue,
+				Reason:             "InstancesExist",
+				Message:            "spec.scope " + scopeChangeWithInstancesDetail,
+				ObservedGeneration: existingCRD.Generation,
+			})
+			return existingCRD, nil
+		}),
+	)
+}
+
 // NewStatusREST makes a RESTStorage for status that has more limited options.
 // It is based on the original REST so that we can share the same underlying store
 func NewStatusREST(scheme *runtime.Scheme, rest *REST) *StatusREST {
@@ -18,7 +18,10 @@ package customresourcedefinition
 
 import (
 	"fmt"
+	"strings"
 
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	genericvalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -30,15 +33,59 @@ import (
 
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/validation"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
 )
 
+// scopeChangeWithInstancesDetail is the detail of the error rejecting a change of the scope while
+// custom resources exist.
+const scopeChangeWithInstancesDetail = "cannot be changed while custom resources exist"
+
+// InstanceChecker knows whether custom resources of a CRD exist.
+type InstanceChecker interface {
+	// HasCustomResources returns true if custom resources of the given CRD exist in any namespace.
+	HasCustomResources(crd *apiextensions.CustomResourceDefinition) (bool, error)
+}
+
+// Validator enforces policies on CustomResourceDefinitions in-process, e.g. the naming conventions or
+// the required categories of an organization. Validators are registered by servers embedding the
+// apiextensions-apiserver.
+type Validator interface {
+	// Validate returns the violations of the policy by crd. oldCRD is the CustomResourceDefinition
+	// before an update and nil on create. Status updates are not validated.
+	Validate(ctx genericapirequest.Context, crd, oldCRD *apiextensions.CustomResourceDefinition) field.ErrorList
+}
+
+// ValidatorFunc is a Validator implemented by a function.
+type ValidatorFunc func(ctx genericapirequest.Context, crd, oldCRD *apiextensions.CustomResourceDefinition) field.ErrorList
+
+// Validate calls f.
+func (f ValidatorFunc) Validate(ctx genericapirequest.Context, crd, oldCRD *apiextensions.CustomResourceDefinition) field.ErrorList {
+	return f(ctx, crd, oldCRD)
+}
+
 type strategy struct {
 	runtime.ObjectTyper
 	names.NameGenerator
+
+	// allowedStoragePrefixes are the storage prefixes which may be used by spec.storage of new CRDs.
+	allowedStoragePrefixes []string
+	// schemaLimits bound the size of the validation schemas of CRDs.
+	schemaLimits validation.SchemaLimits
+	// instanceChecker is used to allow scope changes of established CRDs without custom resources.
+	// If it is nil, the scope of established CRDs is immutable.
+	instanceChecker InstanceChecker
+	// requireAPIApproval rejects CRDs in groups owned by the Kubernetes project without a valid
+	// api-approved.kubernetes.io annotation.
+	requireAPIApproval bool
+	// schemaCompatibilityPolicy is the handling of breaking changes of the schemas of served versions.
+	schemaCompatibilityPolicy validation.SchemaCompatibilityPolicy
+	// validators are the in-process validators of the embedding server, which run after the
+	// built-in validation.
+	validators []Validator
 }
 
-func NewStrategy(typer runtime.ObjectTyper) strategy {
-	return strategy{typer, names.SimpleNameGenerator}
+func NewStrategy(typer runtime.ObjectTyper, allowedStoragePrefixes []string, schemaLimits validation.SchemaLimits, instanceChecker InstanceChecker, requireAPIApproval bool, schemaCompatibilityPolicy validation.SchemaCompatibilityPolicy, validators []Validator) strategy {
+	return strategy{typer, names.SimpleNameGenerator, allowedStoragePrefixes, schemaLimits, instanceChecker, requireAPIApproval, schemaCompatibilityPolicy, validators}
 }
 
 func (strategy) NamespaceScoped() bool {
@@ -46,13 +93,99 @@ func (strategy) NamespaceScoped() bool {
 }
 
 func (strategy) PrepareForCreate(ctx genericapirequest.Context, obj runtime.Object) {
+	crd := obj.(*apiextensions.CustomResourceDefinition)
+	crd.Generation = 1
+
+	// the storage version of a new CRD is the only version with persisted objects
+	if storageVersion, err := apiextensions.GetCRDStorageVersion(crd); err == nil {
+		crd.Status.StoredVersions = []string{storageVersion}
+	}
+	pruneDefaults(crd)
+}
+
+func (s strategy) PrepareForUpdate(ctx genericapirequest.Context, obj, old runtime.Object) {
+	newCRD := obj.(*apiextensions.CustomResourceDefinition)
+	oldCRD := old.(*apiextensions.CustomResourceDefinition)
+	// stored versions are only pruned through the status subresource, after objects have been migrated
+	newCRD.Status.StoredVersions = append([]string(nil), oldCRD.Status.StoredVersions...)
+	addStoredVersion(newCRD)
+	pruneDefaults(newCRD)
+	// the generation is observed by the conditions, it only changes with the spec
+	newCRD.Generation = oldCRD.Generation
+	if !apiequality.Semantic.DeepEqual(newCRD.Spec, oldCRD.Spec) {
+		newCRD.Generation = oldCRD.Generation + 1
+		// a rejected scope change is only reported until the spec is changed successfully
+		apiextensions.RemoveCRDCondition(newCRD, apiextensions.ScopeChangeRejected)
+		// accepted breaking changes are only reported until the next change of the spec
+		apiextensions.RemoveCRDCondition(newCRD, apiextensions.BreakingSchemaChange)
+		if s.schemaCompatibilityPolicy == validation.SchemaCompatibilityWarn {
+			setBreakingSchemaChange(newCRD, oldCRD)
+		}
+	}
+}
+
+// setBreakingSchemaChange sets the BreakingSchemaChange condition if the schemas of served versions
+// changed incompatibly.
+func setBreakingSchemaChange(newCRD, oldCRD *apiextensions.CustomResourceDefinition) {
+	errs := validation.ValidateSchemaCompatibility(newCRD, oldCRD)
+	if len(errs) == 0 {
+		return
+	}
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	apiextensions.SetCRDCondition(newCRD, apiextensions.CustomResourceDefinitionCondition{
+		Type:               apiextensions.BreakingSchemaChange,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             "IncompatibleSchema",
+		Message:            strings.Join(messages, "; "),
+		ObservedGeneration: newCRD.Generation,
+	})
 }
 
-func (strategy) PrepareForUpdate(ctx genericapirequest.Context, obj, old runtime.Object) {
+// addStoredVersion adds the storage version of the CRD to status.storedVersions, as objects
+// are going to be persisted in that version from now on.
+func addStoredVersion(crd *apiextensions.CustomResourceDefinition) {
+	storageVersion, err := apiextensions.GetCRDStorageVersion(crd)
+	if err != nil {
+		// validation fails for CRDs without storage version
+		return
+	}
+	if !apiextensions.IsStoredVersion(crd, storageVersion) {
+		crd.Status.StoredVersions = append(crd.Status.StoredVersions, storageVersion)
+	}
 }
 
-func (strategy) Validate(ctx genericapirequest.Context, obj runtime.Object) field.ErrorList {
-	return validation.ValidateCustomResourceDefinition(obj.(*apiextensions.CustomResourceDefinition))
+// pruneDefaults removes the fields of the default values in the validation schemas which the custom
+// resources are pruned of, such that defaulting does not reintroduce them.
+func pruneDefaults(crd *apiextensions.CustomResourceDefinition) {
+	if crd.Spec.PreserveUnknownFields == nil || *crd.Spec.PreserveUnknownFields {
+		return
+	}
+	if crd.Spec.Validation != nil {
+		defaulting.PruneDefaults(crd.Spec.Validation.OpenAPIV3Schema)
+	}
+	for _, version := range crd.Spec.Versions {
+		if version.Schema != nil {
+			defaulting.PruneDefaults(version.Schema.OpenAPIV3Schema)
+		}
+	}
+}
+
+func (s strategy) Validate(ctx genericapirequest.Context, obj runtime.Object) field.ErrorList {
+	crd := obj.(*apiextensions.CustomResourceDefinition)
+	allErrs := validation.ValidateCustomResourceDefinition(crd)
+	// the storage is immutable, hence existing CRDs keep their location if the server stops allowing it
+	allErrs = append(allErrs, validation.ValidateCustomResourceStorageAllowed(crd.Spec.Storage, s.allowedStoragePrefixes, field.NewPath("spec", "storage"))...)
+	allErrs = append(allErrs, validation.ValidateCustomResourceDefinitionSchemaLimits(&crd.Spec, nil, s.schemaLimits, field.NewPath("spec"))...)
+	if s.requireAPIApproval {
+		allErrs = append(allErrs, validation.ValidateAPIApproval(crd, nil)...)
+	}
+	for _, v := range s.validators {
+		allErrs = append(allErrs, v.Validate(ctx, crd, nil)...)
+	}
+	return allErrs
 }
 
 func (strategy) AllowCreateOnUpdate() bool {
@@ -66,8 +199,43 @@ func (strategy) AllowUnconditionalUpdate() bool {
 func (strategy) Canonicalize(obj runtime.Object) {
 }
 
-func (strategy) ValidateUpdate(ctx genericapirequest.Context, obj, old runtime.Object) field.ErrorList {
-	return validation.ValidateCustomResourceDefinitionUpdate(obj.(*apiextensions.CustomResourceDefinition), old.(*apiextensions.CustomResourceDefinition))
+func (s strategy) ValidateUpdate(ctx genericapirequest.Context, obj, old runtime.Object) field.ErrorList {
+	newCRD := obj.(*apiextensions.CustomResourceDefinition)
+	oldCRD := old.(*apiextensions.CustomResourceDefinition)
+	allErrs := validation.ValidateCustomResourceDefinitionUpdate(newCRD, oldCRD)
+	// unchanged schemas are not validated against the limits, which might have been lowered since
+	allErrs = append(allErrs, validation.ValidateCustomResourceDefinitionSchemaLimits(&newCRD.Spec, &oldCRD.Spec, s.schemaLimits, field.NewPath("spec"))...)
+	allErrs = append(allErrs, s.validateScopeUpdate(newCRD, oldCRD)...)
+	if s.requireAPIApproval {
+		allErrs = append(allErrs, validation.ValidateAPIApproval(newCRD, oldCRD)...)
+	}
+	if s.schemaCompatibilityPolicy == validation.SchemaCompatibilityReject {
+		allErrs = append(allErrs, validation.ValidateSchemaCompatibility(newCRD, oldCRD)...)
+	}
+	for _, v := range s.validators {
+		allErrs = append(allErrs, v.Validate(ctx, newCRD, oldCRD)...)
+	}
+	return allErrs
+}
+
+// validateScopeUpdate forbids changing the scope of an established CRD while custom resources
+// exist, because they would be orphaned at the storage location of the old scope.
+func (s strategy) validateScopeUpdate(newCRD, oldCRD *apiextensions.CustomResourceDefinition) field.ErrorList {
+	if newCRD.Spec.Scope == oldCRD.Spec.Scope || !apiextensions.IsCRDConditionTrue(oldCRD, apiextensions.Established) {
+		return nil
+	}
+	fldPath := field.NewPath("spec", "scope")
+	if s.instanceChecker == nil {
+		return genericvalidation.ValidateImmutableField(newCRD.Spec.Scope, oldCRD.Spec.Scope, fldPath)
+	}
+	exists, err := s.instanceChecker.HasCustomResources(oldCRD)
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath, fmt.Errorf("unable to check for custom resources: %v", err))}
+	}
+	if exists {
+		return field.ErrorList{field.Forbidden(fldPath, scopeChangeWithInstancesDetail)}
+	}
+	return nil
 }
 
 type statusStrategy struct {
@@ -87,9 +255,11 @@ func (statusStrategy) PrepareForUpdate(ctx genericapirequest.Context, obj, old r
 	newObj := obj.(*apiextensions.CustomResourceDefinition)
 	oldObj := old.(*apiextensions.CustomResourceDefinition)
 	newObj.Spec = oldObj.Spec
+	newObj.Generation = oldObj.Generation
 	newObj.Labels = oldObj.Labels
 	newObj.Annotations = oldObj.Annotations
 	newObj.OwnerReferences = oldObj.OwnerReferences
+	addStoredVersion(newObj)
 }
 
 func (statusStrategy) AllowCreateOnUpdate() bool {
@@ -0,0 +1,350 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresourcedefinition
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func newTestCRD(group string, scope apiextensions.ResourceScope) *apiextensions.CustomResourceDefinition {
+	names := apiextensions.CustomResourceDefinitionNames{
+		Plural:   "noxus",
+		Singular: "noxu",
+		Kind:     "Noxu",
+		ListKind: "NoxuList",
+	}
+	return &apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "noxus." + group, ResourceVersion: "1"},
+		Spec: apiextensions.CustomResourceDefinitionSpec{
+			Group:   group,
+			Version: "v1beta1",
+			Versions: []apiextensions.CustomResourceDefinitionVersion{
+				{Name: "v1beta1", Served: true, Storage: true},
+			},
+			Names: names,
+			Scope: scope,
+		},
+		Status: apiextensions.CustomResourceDefinitionStatus{
+			AcceptedNames:  names,
+			StoredVersions: []string{"v1beta1"},
+		},
+	}
+}
+
+func establishedCRD(crd *apiextensions.CustomResourceDefinition) *apiextensions.CustomResourceDefinition {
+	apiextensions.SetCRDCondition(crd, apiextensions.CustomResourceDefinitionCondition{
+		Type:   apiextensions.Established,
+		Status: apiextensions.ConditionTrue,
+	})
+	return crd
+}
+
+// recordingValidator records the CRDs it is called with and returns a fixed error.
+type recordingValidator struct {
+	calls   int
+	crd     *apiextensions.CustomResourceDefinition
+	oldCRD  *apiextensions.CustomResourceDefinition
+	message string
+}
+
+func (v *recordingValidator) Validate(ctx genericapirequest.Context, crd, oldCRD *apiextensions.CustomResourceDefinition) field.ErrorList {
+	v.calls++
+	v.crd, v.oldCRD = crd, oldCRD
+	return field.ErrorList{field.Forbidden(field.NewPath("spec"), v.message)}
+}
+
+func errorDetails(errs field.ErrorList) []string {
+	details := []string{}
+	for _, err := range errs {
+		details = append(details, err.Field+": "+err.Detail)
+	}
+	return details
+}
+
+func newTestStrategy(instanceChecker InstanceChecker, requireAPIApproval bool, validators ...Validator) strategy {
+	return NewStrategy(nil, nil, validation.SchemaLimits{}, instanceChecker, requireAPIApproval, validation.SchemaCompatibilityIgnore, validators)
+}
+
+func TestValidators(t *testing.T) {
+	ctx := genericapirequest.NewContext()
+	first := &recordingValidator{message: "first"}
+	second := &recordingValidator{message: "second"}
+	s := newTestStrategy(nil, false, first, second)
+
+	crd := newTestCRD("mygroup.example.com", apiextensions.NamespaceScoped)
+	crd.Spec.Names.Kind = ""
+	errs := s.Validate(ctx, crd)
+	// the errors of the validators are aggregated with the built-in validation
+	expected := []string{
+		"spec.names.kind: ",
+		"spec: first",
+		"spec: second",
+	}
+	if got := errorDetails(errs); !reflect.DeepEqual(got, expected) {
+		t.Errorf("create: expected errors %v, got %v", expected, got)
+	}
+	for _, v := range []*recordingValidator{first, second} {
+		if v.calls != 1 || v.crd != crd || v.oldCRD != nil {
+			t.Errorf("create: validator %q called %d times with %p, %p, expected once with %p, nil", v.message, v.calls, v.crd, v.oldCRD, crd)
+		}
+	}
+
+	first.calls, second.calls = 0, 0
+	oldCRD := newTestCRD("mygroup.example.com", apiextensions.NamespaceScoped)
+	newCRD := newTestCRD("mygroup.example.com", apiextensions.NamespaceScoped)
+	newCRD.Spec.Group = "othergroup.example.com"
+	errs = s.ValidateUpdate(ctx, newCRD, oldCRD)
+	expected = []string{
+		"spec.group: field is immutable",
+		"spec: first",
+		"spec: second",
+	}
+	if got := errorDetails(errs); !reflect.DeepEqual(got, expected) {
+		t.Errorf("update: expected errors %v, got %v", expected, got)
+	}
+	for _, v := range []*recordingValidator{first, second} {
+		if v.calls != 1 || v.crd != newCRD || v.oldCRD != oldCRD {
+			t.Errorf("update: validator %q called %d times with %p, %p, expected once with %p, %p", v.message, v.calls, v.crd, v.oldCRD, newCRD, oldCRD)
+		}
+	}
+
+	// the status subresource does not change the spec, which the validators are about
+	first.calls, second.calls = 0, 0
+	newCRD = newTestCRD("mygroup.example.com", apiextensions.NamespaceScoped)
+	if errs := NewStatusStrategy(nil).ValidateUpdate(ctx, newCRD, oldCRD); len(errs) != 0 {
+		t.Errorf("status update: unexpected errors %v", errs)
+	}
+	if first.calls != 0 || second.calls != 0 {
+		t.Errorf("status update: expected no validator calls, got %d and %d", first.calls, second.calls)
+	}
+}
+
+type fakeInstanceChecker struct {
+	exists bool
+	err    error
+}
+
+func (c fakeInstanceChecker) HasCustomResources(crd *apiextensions.CustomResourceDefinition) (bool, error) {
+	return c.exists, c.err
+}
+
+func TestValidateScopeUpdate(t *testing.T) {
+	tests := []struct {
+		name            string
+		established     bool
+		scope           apiextensions.ResourceScope
+		instanceChecker InstanceChecker
+		expected        []string
+	}{
+		{
+			name:        "unchanged scope",
+			established: true,
+			scope:       apiextensions.NamespaceScoped,
+			expected:    []string{},
+		},
+		{
+			name:     "not established",
+			scope:    apiextensions.ClusterScoped,
+			expected: []string{},
+		},
+		{
+			name:        "no instance checker",
+			established: true,
+			scope:       apiextensions.ClusterScoped,
+			expected:    []string{"spec.scope: field is immutable"},
+		},
+		{
+			name:            "without custom resources",
+			established:     true,
+			scope:           apiextensions.ClusterScoped,
+			instanceChecker: fakeInstanceChecker{},
+			expected:        []string{},
+		},
+		{
+			name:            "with custom resources",
+			established:     true,
+			scope:           apiextensions.ClusterScoped,
+			instanceChecker: fakeInstanceChecker{exists: true},
+			expected:        []string{"spec.scope: " + scopeChangeWithInstancesDetail},
+		},
+		{
+			name:            "check failing",
+			established:     true,
+			scope:           apiextensions.ClusterScoped,
+			instanceChecker: fakeInstanceChecker{err: fmt.Errorf("storage unavailable")},
+			expected:        []string{"spec.scope: unable to check for custom resources: storage unavailable"},
+		},
+	}
+
+	for _, tc := range tests {
+		oldCRD := newTestCRD("mygroup.example.com", apiextensions.NamespaceScoped)
+		if tc.established {
+			establishedCRD(oldCRD)
+		}
+		newCRD := newTestCRD("mygroup.example.com", tc.scope)
+		newCRD.Status = oldCRD.Status
+		errs := newTestStrategy(tc.instanceChecker, false).ValidateUpdate(genericapirequest.NewContext(), newCRD, oldCRD)
+		if got := errorDetails(errs); !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%s: expected errors %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestGeneration(t *testing.T) {
+	ctx := genericapirequest.NewContext()
+	s := newTestStrategy(nil, false)
+
+	crd := newTestCRD("mygroup.example.com", apiextensions.NamespaceScoped)
+	crd.Generation = 5
+	s.PrepareForCreate(ctx, crd)
+	if crd.Generation != 1 {
+		t.Errorf("create: expected generation 1, got %d", crd.Generation)
+	}
+
+	tests := []struct {
+		name     string
+		update   func(crd *apiextensions.CustomResourceDefinition)
+		status   bool
+		expected int64
+	}{
+		{
+			name:     "unchanged",
+			update:   func(crd *apiextensions.CustomResourceDefinition) {},
+			expected: 3,
+		},
+		{
+			name:     "metadata change",
+			update:   func(crd *apiextensions.CustomResourceDefinition) { crd.Labels = map[string]string{"foo": "bar"} },
+			expected: 3,
+		},
+		{
+			name:     "generation change",
+			update:   func(crd *apiextensions.CustomResourceDefinition) { crd.Generation = 10 },
+			expected: 3,
+		},
+		{
+			name:     "spec change",
+			update:   func(crd *apiextensions.CustomResourceDefinition) { crd.Spec.Names.ShortNames = []string{"nx"} },
+			expected: 4,
+		},
+		{
+			name:     "spec change through status",
+			update:   func(crd *apiextensions.CustomResourceDefinition) { crd.Spec.Names.ShortNames = []string{"nx"} },
+			status:   true,
+			expected: 3,
+		},
+	}
+
+	for _, tc := range tests {
+		oldCRD := newTestCRD("mygroup.example.com", apiextensions.NamespaceScoped)
+		oldCRD.Generation = 3
+		newCRD := newTestCRD("mygroup.example.com", apiextensions.NamespaceScoped)
+		newCRD.Generation = 3
+		tc.update(newCRD)
+		if tc.status {
+			NewStatusStrategy(nil).PrepareForUpdate(ctx, newCRD, oldCRD)
+		} else {
+			s.PrepareForUpdate(ctx, newCRD, oldCRD)
+		}
+		if newCRD.Generation != tc.expected {
+			t.Errorf("%s: expected generation %d, got %d", tc.name, tc.expected, newCRD.Generation)
+		}
+	}
+}
+
+func TestAPIApproval(t *testing.T) {
+	ctx := genericapirequest.NewContext()
+	required := "metadata.annotations[" + apiextensions.KubernetesAPIApprovedAnnotation + "]: " +
+		fmt.Sprintf("protected groups must have the approval annotation %q, see https://github.com/kubernetes/enhancements/pull/1111", apiextensions.KubernetesAPIApprovedAnnotation)
+	tests := []struct {
+		name               string
+		group              string
+		approval           string
+		requireAPIApproval bool
+		expected           []string
+	}{
+		{
+			name:     "not required",
+			group:    "mygroup.k8s.io",
+			expected: []string{},
+		},
+		{
+			name:               "unprotected group",
+			group:              "mygroup.example.com",
+			requireAPIApproval: true,
+			expected:           []string{},
+		},
+		{
+			name:               "missing approval",
+			group:              "mygroup.k8s.io",
+			requireAPIApproval: true,
+			expected:           []string{required},
+		},
+		{
+			name:               "invalid approval",
+			group:              "mygroup.k8s.io",
+			approval:           "approved",
+			requireAPIApproval: true,
+			expected:           []string{"metadata.annotations[" + apiextensions.KubernetesAPIApprovedAnnotation + "]: must be the URL of the approval or start with \"unapproved\""},
+		},
+		{
+			name:               "approved",
+			group:              "mygroup.k8s.io",
+			approval:           "https://github.com/kubernetes/kubernetes/pull/78458",
+			requireAPIApproval: true,
+			expected:           []string{},
+		},
+		{
+			name:               "unapproved",
+			group:              "mygroup.k8s.io",
+			approval:           "unapproved, experimental",
+			requireAPIApproval: true,
+			expected:           []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		s := newTestStrategy(nil, tc.requireAPIApproval)
+		crd := newTestCRD(tc.group, apiextensions.NamespaceScoped)
+		if len(tc.approval) > 0 {
+			crd.Annotations = map[string]string{apiextensions.KubernetesAPIApprovedAnnotation: tc.approval}
+		}
+		if got := errorDetails(s.Validate(ctx, crd)); !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%s: create: expected errors %v, got %v", tc.name, tc.expected, got)
+		}
+
+		// CRDs which were stored without approval can be updated as long as the annotation is unchanged
+		oldCRD := newTestCRD(tc.group, apiextensions.NamespaceScoped)
+		newCRD := crd.DeepCopy()
+		newCRD.Labels = map[string]string{"foo": "bar"}
+		expected := tc.expected
+		if len(tc.approval) == 0 {
+			expected = []string{}
+		}
+		if got := errorDetails(s.ValidateUpdate(ctx, newCRD, oldCRD)); !reflect.DeepEqual(got, expected) {
+			t.Errorf("%s: update: expected errors %v, got %v", tc.name, expected, got)
+		}
+	}
+}
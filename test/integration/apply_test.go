@@ -0,0 +1,215 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"testing"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/fieldmanager"
+	"k8s.io/apiextensions-apiserver/test/integration/testserver"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestApplyCustomResource(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.Validation = &apiextensionsv1beta1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1beta1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+				"spec": {
+					Type: "object",
+					Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+						"replicas": {Type: "integer"},
+						"color":    {Type: "string"},
+					},
+				},
+			},
+		},
+	}
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+	restClient := apiExtensionClient.Discovery().RESTClient()
+	path := "/apis/mygroup.example.com/v1beta1/namespaces/" + ns + "/noxus/foo"
+	apply := func(config string, force bool) error {
+		req := restClient.Patch(fieldmanager.ApplyPatchType).AbsPath(path).Param("fieldManager", "applier").Body([]byte(config))
+		if force {
+			req = req.Param("force", "true")
+		}
+		_, err := req.DoRaw()
+		return err
+	}
+
+	// apply creates the custom resource
+	if err := apply(`
+apiVersion: mygroup.example.com/v1beta1
+kind: WishIHadChosenNoxu
+metadata:
+  name: foo
+spec:
+  replicas: 1
+  color: red
+`, false); err != nil {
+		t.Fatalf("unexpected error applying: %v", err)
+	}
+	obj, err := noxuResourceClient.Get("foo", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if managedFields, ok := obj.Object["metadata"].(map[string]interface{})["managedFields"].([]interface{}); !ok || len(managedFields) != 1 {
+		t.Fatalf("expected the fields to be managed by the applier, got %v", obj.Object["metadata"])
+	}
+
+	// another manager updates the replicas
+	obj.Object["spec"].(map[string]interface{})["replicas"] = int64(2)
+	if _, err := noxuResourceClient.Update(obj); err != nil {
+		t.Fatalf("unexpected error updating: %v", err)
+	}
+
+	config := `
+apiVersion: mygroup.example.com/v1beta1
+kind: WishIHadChosenNoxu
+spec:
+  replicas: 3
+`
+	if err := apply(config, false); !apierrors.IsConflict(err) {
+		t.Fatalf("expected a conflict applying the replicas of another manager, got %v", err)
+	}
+	if err := apply(config, true); err != nil {
+		t.Fatalf("unexpected error force applying: %v", err)
+	}
+
+	// the color is not applied anymore and hence removed
+	obj, err = noxuResourceClient.Get("foo", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := obj.Object["spec"].(map[string]interface{})
+	if spec["replicas"] != int64(3) {
+		t.Errorf("expected replicas to be 3, got %v", spec["replicas"])
+	}
+	if _, found := spec["color"]; found {
+		t.Errorf("expected color to be removed, got %v", spec)
+	}
+
+	if err := restClient.Patch(fieldmanager.ApplyPatchType).AbsPath(path).Body([]byte(config)).Do().Error(); !apierrors.IsBadRequest(err) {
+		t.Errorf("expected apply without fieldManager to be rejected, got %v", err)
+	}
+}
+
+func TestApplyAssociativeList(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	listType := "map"
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.Validation = &apiextensionsv1beta1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1beta1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+				"ports": {
+					Type:         "array",
+					XListType:    &listType,
+					XListMapKeys: []string{"port"},
+					Items: &apiextensionsv1beta1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1beta1.JSONSchemaProps{
+						Type:     "object",
+						Required: []string{"port"},
+						Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+							"port": {Type: "integer"},
+							"name": {Type: "string"},
+						},
+					}},
+				},
+			},
+		},
+	}
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+	restClient := apiExtensionClient.Discovery().RESTClient()
+	path := "/apis/mygroup.example.com/v1beta1/namespaces/" + ns + "/noxus/foo"
+	apply := func(manager, config string) error {
+		_, err := restClient.Patch(fieldmanager.ApplyPatchType).AbsPath(path).Param("fieldManager", manager).Body([]byte(config)).DoRaw()
+		return err
+	}
+
+	// two managers apply different items of the list
+	if err := apply("first", `
+apiVersion: mygroup.example.com/v1beta1
+kind: WishIHadChosenNoxu
+ports:
+- port: 80
+  name: http
+`); err != nil {
+		t.Fatalf("unexpected error applying: %v", err)
+	}
+	if err := apply("second", `
+apiVersion: mygroup.example.com/v1beta1
+kind: WishIHadChosenNoxu
+ports:
+- port: 443
+  name: https
+`); err != nil {
+		t.Fatalf("unexpected error applying: %v", err)
+	}
+	obj, err := noxuResourceClient.Get("foo", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ports, _ := obj.Object["ports"].([]interface{}); len(ports) != 2 {
+		t.Errorf("expected the ports of both managers, got %v", obj.Object["ports"])
+	}
+
+	// changing the item of another manager conflicts
+	if err := apply("second", `
+apiVersion: mygroup.example.com/v1beta1
+kind: WishIHadChosenNoxu
+ports:
+- port: 80
+  name: web
+`); !apierrors.IsConflict(err) {
+		t.Errorf("expected a conflict applying the port of another manager, got %v", err)
+	}
+
+	// duplicate keys are rejected
+	obj.Object["ports"] = []interface{}{
+		map[string]interface{}{"port": int64(80)},
+		map[string]interface{}{"port": int64(80)},
+	}
+	if _, err := noxuResourceClient.Update(obj); !apierrors.IsInvalid(err) {
+		t.Errorf("expected duplicate ports to be rejected, got %v", err)
+	}
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package integration
 
 import (
+	"encoding/json"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -237,6 +239,10 @@ func TestDiscovery(t *testing.T) {
 		t.Fatalf("Expected exactly the shortnames `foo, bar, abc, def` in group version %v/%v via discovery, got: %v", group, version, r.ShortNames)
 	}
 
+	if !reflect.DeepEqual(r.Categories, []string{"all"}) {
+		t.Fatalf("Expected exactly the category `all` in group version %v/%v via discovery, got: %v", group, version, r.Categories)
+	}
+
 	sort.Strings(r.Verbs)
 	expectedVerbs := []string{"create", "delete", "deletecollection", "get", "list", "patch", "update", "watch"}
 	if !reflect.DeepEqual([]string(r.Verbs), expectedVerbs) {
@@ -244,6 +250,64 @@ func TestDiscovery(t *testing.T) {
 	}
 }
 
+func TestAggregatedDiscovery(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	if _, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool); err != nil {
+		t.Fatal(err)
+	}
+
+	var list struct {
+		Kind  string `json:"kind"`
+		Items []struct {
+			Metadata metav1.ObjectMeta `json:"metadata"`
+			Versions []struct {
+				Version   string `json:"version"`
+				Resources []struct {
+					Resource string   `json:"resource"`
+					Scope    string   `json:"scope"`
+					Verbs    []string `json:"verbs"`
+				} `json:"resources"`
+			} `json:"versions"`
+		} `json:"items"`
+	}
+	// the discovery controller publishes the group asynchronously
+	err = wait.PollImmediate(500*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		data, err := apiExtensionClient.Discovery().RESTClient().Get().AbsPath("/apis").
+			SetHeader("Accept", "application/json;g=apidiscovery.k8s.io;v=v2beta1;as=APIGroupDiscoveryList").DoRaw()
+		if err != nil {
+			return false, err
+		}
+		if err := json.Unmarshal(data, &list); err != nil {
+			return false, err
+		}
+		return len(list.Items) > 0, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if list.Kind != "APIGroupDiscoveryList" {
+		t.Fatalf("expected an APIGroupDiscoveryList, got %q", list.Kind)
+	}
+	if len(list.Items) != 1 || list.Items[0].Metadata.Name != "mygroup.example.com" {
+		t.Fatalf("expected exactly the group mygroup.example.com, got %v", list.Items)
+	}
+	versions := list.Items[0].Versions
+	if len(versions) != 1 || versions[0].Version != "v1beta1" || len(versions[0].Resources) != 1 {
+		t.Fatalf("expected exactly the version v1beta1 with one resource, got %v", versions)
+	}
+	r := versions[0].Resources[0]
+	if r.Resource != "noxus" || r.Scope != "Namespaced" || len(r.Verbs) != 8 {
+		t.Errorf("unexpected resource %v", r)
+	}
+}
+
 func TestNoNamespaceReject(t *testing.T) {
 	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
 	if err != nil {
@@ -597,3 +661,41 @@ func TestNameConflict(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestShortNameConflictWithBuiltInResource(t *testing.T) {
+	stopCh, apiExtensionClient, _, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	// "crd" is the short name of customresourcedefinitions
+	curletDefinition := testserver.NewCurletCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	curletDefinition.Spec.Names.ShortNames = []string{"crd"}
+	_, err = apiExtensionClient.Apiextensions().CustomResourceDefinitions().Create(curletDefinition)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var conflict *apiextensionsv1beta1.CustomResourceDefinitionCondition
+	err = wait.Poll(500*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		crd, err := testserver.GetCustomResourceDefinition(curletDefinition, apiExtensionClient)
+		if err != nil {
+			return false, err
+		}
+
+		for i, condition := range crd.Status.Conditions {
+			if condition.Type == apiextensionsv1beta1.NamesAccepted && condition.Status == apiextensionsv1beta1.ConditionFalse {
+				conflict = &crd.Status.Conditions[i]
+				return len(crd.Status.AcceptedNames.ShortNames) == 0, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conflict.Reason != "ShortNamesConflict" || !strings.Contains(conflict.Message, "built-in resource customresourcedefinitions.apiextensions.k8s.io") {
+		t.Errorf("expected a short name conflict with customresourcedefinitions, got %v: %v", conflict.Reason, conflict.Message)
+	}
+}
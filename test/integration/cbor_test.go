@@ -0,0 +1,77 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"bytes"
+	"testing"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/serializer/cbor"
+	"k8s.io/apiextensions-apiserver/test/integration/testserver"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestCBOREncoding(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	if _, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool); err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	collectionPath := "/apis/mygroup.example.com/v1beta1/namespaces/" + ns + "/noxus"
+	restClient := apiExtensionClient.Discovery().RESTClient()
+	serializer := cbor.NewSerializer(nil, nil)
+
+	instance := testserver.NewNoxuInstance(ns, "foo")
+	body := &bytes.Buffer{}
+	if err := serializer.Encode(instance, body); err != nil {
+		t.Fatal(err)
+	}
+	data, err := restClient.Post().AbsPath(collectionPath).Body(body.Bytes()).
+		SetHeader("Content-Type", cbor.ContentTypeCBOR).
+		SetHeader("Accept", cbor.ContentTypeCBOR).
+		DoRaw()
+	if err != nil {
+		t.Fatalf("unexpected error creating a CBOR encoded instance: %v", err)
+	}
+	created, _, err := serializer.Decode(data, nil, &unstructured.Unstructured{})
+	if err != nil {
+		t.Fatalf("unexpected error decoding the CBOR response: %v", err)
+	}
+	if name := created.(*unstructured.Unstructured).GetName(); name != "foo" {
+		t.Errorf("expected the created instance to be foo, got %q", name)
+	}
+
+	data, err = restClient.Get().AbsPath(collectionPath).SetHeader("Accept", cbor.ContentTypeCBOR).DoRaw()
+	if err != nil {
+		t.Fatalf("unexpected error listing: %v", err)
+	}
+	list, _, err := serializer.Decode(data, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error decoding the CBOR list: %v", err)
+	}
+	if items := list.(*unstructured.UnstructuredList).Items; len(items) != 1 || items[0].GetName() != "foo" {
+		t.Errorf("expected to list foo, got %v", items)
+	}
+}
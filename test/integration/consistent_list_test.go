@@ -0,0 +1,103 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"testing"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	extensionsapiserver "k8s.io/apiextensions-apiserver/pkg/apiserver"
+	"k8s.io/apiextensions-apiserver/test/integration/testserver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/dynamic"
+)
+
+// TestConsistentListFromCache checks that lists without resourceVersion served from the watch cache
+// see every preceding change of the custom resources, also while writes of other resources advance
+// the revision of etcd.
+func TestConsistentListFromCache(t *testing.T) {
+	config, err := testserver.DefaultServerConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	crdRESTOptionsGetter := config.CRDRESTOptionsGetter.(extensionsapiserver.CRDRESTOptionsGetter)
+	crdRESTOptionsGetter.ConsistentListFromCache = true
+	config.CRDRESTOptionsGetter = crdRESTOptionsGetter
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartServer(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	ns := "not-the-default"
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+	curletDefinition := testserver.NewCurletCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	curletVersionClient, err := testserver.CreateNewCustomResourceDefinition(curletDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	curletResourceClient := NewNamespacedCustomResourceClient(ns, curletVersionClient, curletDefinition)
+
+	expectNames := func(step string, client dynamic.ResourceInterface, expected ...string) {
+		list, err := client.List(metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", step, err)
+		}
+		// lists from the watch cache are not ordered
+		names := sets.NewString()
+		for _, item := range list.(*unstructured.UnstructuredList).Items {
+			names.Insert(item.GetName())
+		}
+		if !names.Equal(sets.NewString(expected...)) {
+			t.Fatalf("%s: expected %v, got %v", step, expected, names.List())
+		}
+	}
+
+	expectNames("empty", noxuResourceClient)
+	if _, err := noxuResourceClient.Create(testserver.NewNoxuInstance(ns, "bar")); err != nil {
+		t.Fatal(err)
+	}
+	expectNames("after create", noxuResourceClient, "bar")
+
+	// writes of other custom resources advance the revision of etcd, but not the watch cache
+	if _, err := curletResourceClient.Create(testserver.NewCurletInstance(ns, "baz")); err != nil {
+		t.Fatal(err)
+	}
+	expectNames("after other create", noxuResourceClient, "bar")
+	expectNames("other", curletResourceClient, "baz")
+
+	if _, err := noxuResourceClient.Create(testserver.NewNoxuInstance(ns, "foo")); err != nil {
+		t.Fatal(err)
+	}
+	expectNames("after second create", noxuResourceClient, "bar", "foo")
+
+	if err := noxuResourceClient.Delete("bar", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := curletResourceClient.Delete("baz", nil); err != nil {
+		t.Fatal(err)
+	}
+	expectNames("after delete", noxuResourceClient, "foo")
+	expectNames("other after delete", curletResourceClient)
+}
@@ -0,0 +1,346 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/test/integration/testserver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+func newMultiVersionNoxuDefinition(conversion *apiextensionsv1beta1.CustomResourceConversion) *apiextensionsv1beta1.CustomResourceDefinition {
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.Versions = []apiextensionsv1beta1.CustomResourceDefinitionVersion{
+		{Name: "v1beta1", Served: true, Storage: true},
+		{Name: "v1beta2", Served: true, Storage: false},
+	}
+	noxuDefinition.Spec.Conversion = conversion
+	return noxuDefinition
+}
+
+func newNoxuResourceClientForVersion(t *testing.T, clientPool dynamic.ClientPool, noxuDefinition *apiextensionsv1beta1.CustomResourceDefinition, ns, version string) dynamic.ResourceInterface {
+	client, err := clientPool.ClientForGroupVersionResource(schema.GroupVersionResource{Group: noxuDefinition.Spec.Group, Version: version, Resource: noxuDefinition.Spec.Names.Plural})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewNamespacedCustomResourceClient(ns, client, noxuDefinition)
+}
+
+func TestNoneConversion(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	noxuDefinition := newMultiVersionNoxuDefinition(&apiextensionsv1beta1.CustomResourceConversion{Strategy: apiextensionsv1beta1.NoneConverter})
+	if _, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool); err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	v1beta1Client := newNoxuResourceClientForVersion(t, clientPool, noxuDefinition, ns, "v1beta1")
+	v1beta2Client := newNoxuResourceClientForVersion(t, clientPool, noxuDefinition, ns, "v1beta2")
+
+	instance := testserver.NewNoxuInstance(ns, "foo")
+	instance.SetAPIVersion("mygroup.example.com/v1beta2")
+	if _, err := v1beta2Client.Create(instance); err != nil {
+		t.Fatalf("unexpected error creating an instance: %v", err)
+	}
+
+	for version, client := range map[string]dynamic.ResourceInterface{"v1beta1": v1beta1Client, "v1beta2": v1beta2Client} {
+		obj, err := client.Get("foo", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error getting the instance in %s: %v", version, err)
+		}
+		if expected := "mygroup.example.com/" + version; obj.GetAPIVersion() != expected {
+			t.Errorf("expected apiVersion %q, got %q", expected, obj.GetAPIVersion())
+		}
+		if _, found := obj.Object["content"]; !found {
+			t.Errorf("expected content to be kept in %s, got %v", version, obj.Object)
+		}
+	}
+}
+
+func TestExpressionConversion(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	// content.key in v1beta1 is content.newKey in v1beta2
+	noxuDefinition := newMultiVersionNoxuDefinition(&apiextensionsv1beta1.CustomResourceConversion{
+		Strategy: apiextensionsv1beta1.ExpressionConverter,
+		Expressions: []apiextensionsv1beta1.ConversionExpression{
+			{
+				FromVersion: "v1beta1",
+				ToVersion:   "v1beta2",
+				FieldMappings: []apiextensionsv1beta1.ConversionFieldMapping{
+					{Path: "content.newKey", Expression: "has(self.content.key) ? self.content.key : null"},
+					{Path: "content.key", Expression: "null"},
+				},
+			},
+			{
+				FromVersion: "v1beta2",
+				ToVersion:   "v1beta1",
+				FieldMappings: []apiextensionsv1beta1.ConversionFieldMapping{
+					{Path: "content.key", Expression: "has(self.content.newKey) ? self.content.newKey : null"},
+					{Path: "content.newKey", Expression: "null"},
+				},
+			},
+		},
+	})
+	if _, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool); err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	v1beta1Client := newNoxuResourceClientForVersion(t, clientPool, noxuDefinition, ns, "v1beta1")
+	v1beta2Client := newNoxuResourceClientForVersion(t, clientPool, noxuDefinition, ns, "v1beta2")
+
+	if _, err := v1beta1Client.Create(testserver.NewNoxuInstance(ns, "foo")); err != nil {
+		t.Fatalf("unexpected error creating an instance: %v", err)
+	}
+
+	obj, err := v1beta2Client.Get("foo", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := obj.Object["content"].(map[string]interface{})
+	if content["newKey"] != "value" || content["key"] != nil {
+		t.Errorf("expected content.key to be moved to content.newKey, got %v", content)
+	}
+
+	content["newKey"] = "otherValue"
+	if _, err := v1beta2Client.Update(obj); err != nil {
+		t.Fatalf("unexpected error updating the instance: %v", err)
+	}
+
+	obj, err = v1beta1Client.Get("foo", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	content = obj.Object["content"].(map[string]interface{})
+	if content["key"] != "otherValue" || content["newKey"] != nil {
+		t.Errorf("expected content.newKey to be moved back to content.key, got %v", content)
+	}
+}
+
+func TestWebhookConversion(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	// the webhook renames content.key in v1beta1 to content.newKey in v1beta2 and back. It only
+	// understands v1beta1 ConversionReviews.
+	webhook := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		review := &apiextensionsv1beta1.ConversionReview{}
+		if err := json.NewDecoder(r.Body).Decode(review); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if review.APIVersion != apiextensionsv1beta1.SchemeGroupVersion.String() {
+			http.Error(w, "unsupported ConversionReview version", http.StatusUnsupportedMediaType)
+			return
+		}
+		from, to := "key", "newKey"
+		if review.Request.DesiredAPIVersion == "mygroup.example.com/v1beta1" {
+			from, to = to, from
+		}
+		response := &apiextensionsv1beta1.ConversionResponse{
+			UID:    review.Request.UID,
+			Result: metav1.Status{Status: metav1.StatusSuccess},
+		}
+		for _, raw := range review.Request.Objects {
+			obj := map[string]interface{}{}
+			if err := json.Unmarshal(raw.Raw, &obj); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			obj["apiVersion"] = review.Request.DesiredAPIVersion
+			if content, ok := obj["content"].(map[string]interface{}); ok {
+				if v, found := content[from]; found {
+					delete(content, from)
+					content[to] = v
+				}
+			}
+			data, err := json.Marshal(obj)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: data})
+		}
+		review.Request = nil
+		review.Response = response
+		json.NewEncoder(w).Encode(review)
+	}))
+	defer webhook.Close()
+
+	url := webhook.URL
+	noxuDefinition := newMultiVersionNoxuDefinition(&apiextensionsv1beta1.CustomResourceConversion{
+		Strategy: apiextensionsv1beta1.WebhookConverter,
+		WebhookClientConfig: &apiextensionsv1beta1.WebhookClientConfig{
+			URL:      &url,
+			CABundle: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: webhook.TLS.Certificates[0].Certificate[0]}),
+		},
+		ConversionReviewVersions: []string{"v1", "v1beta1"},
+	})
+	if _, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool); err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	v1beta1Client := newNoxuResourceClientForVersion(t, clientPool, noxuDefinition, ns, "v1beta1")
+	v1beta2Client := newNoxuResourceClientForVersion(t, clientPool, noxuDefinition, ns, "v1beta2")
+
+	if _, err := v1beta1Client.Create(testserver.NewNoxuInstance(ns, "foo")); err != nil {
+		t.Fatalf("unexpected error creating an instance: %v", err)
+	}
+
+	obj, err := v1beta2Client.Get("foo", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj.GetAPIVersion() != "mygroup.example.com/v1beta2" {
+		t.Errorf("expected apiVersion mygroup.example.com/v1beta2, got %q", obj.GetAPIVersion())
+	}
+	content := obj.Object["content"].(map[string]interface{})
+	if content["newKey"] != "value" {
+		t.Errorf("expected content.newKey to be set by the webhook, got %v", content)
+	}
+
+	content["newKey"] = "otherValue"
+	if _, err := v1beta2Client.Update(obj); err != nil {
+		t.Fatalf("unexpected error updating the instance: %v", err)
+	}
+
+	obj, err = v1beta1Client.Get("foo", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	content = obj.Object["content"].(map[string]interface{})
+	if content["key"] != "otherValue" {
+		t.Errorf("expected content.key to be converted back by the webhook, got %v", content)
+	}
+
+	// the negotiated ConversionReview version is recorded in the status
+	err = wait.PollImmediate(500*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		crd, err := apiExtensionClient.ApiextensionsV1beta1().CustomResourceDefinitions().Get(noxuDefinition.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, condition := range crd.Status.Conditions {
+			if condition.Type == apiextensionsv1beta1.ConversionReviewNegotiated {
+				return condition.Status == apiextensionsv1beta1.ConditionTrue && strings.Contains(condition.Message, "ConversionReview v1beta1"), nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		t.Errorf("expected the ConversionReviewNegotiated condition to name v1beta1: %v", err)
+	}
+}
+
+func TestWebhookConversionFailurePolicy(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	// the webhook is not reachable
+	webhook := httptest.NewTLSServer(http.NotFoundHandler())
+	url := webhook.URL
+	webhook.Close()
+
+	ignore := apiextensionsv1beta1.ConversionFailurePolicyIgnore
+	noxuDefinition := newMultiVersionNoxuDefinition(&apiextensionsv1beta1.CustomResourceConversion{
+		Strategy:                 apiextensionsv1beta1.WebhookConverter,
+		WebhookClientConfig:      &apiextensionsv1beta1.WebhookClientConfig{URL: &url},
+		ConversionReviewVersions: []string{"v1beta1"},
+		FailurePolicy:            &ignore,
+	})
+	if _, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool); err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	v1beta1Client := newNoxuResourceClientForVersion(t, clientPool, noxuDefinition, ns, "v1beta1")
+	v1beta2Client := newNoxuResourceClientForVersion(t, clientPool, noxuDefinition, ns, "v1beta2")
+	if _, err := v1beta1Client.Create(testserver.NewNoxuInstance(ns, "foo")); err != nil {
+		t.Fatalf("unexpected error creating an instance: %v", err)
+	}
+
+	// reads are served in the persisted version, with a warning
+	obj, err := v1beta2Client.Get("foo", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the instance to be served unconverted: %v", err)
+	}
+	if obj.GetAPIVersion() != "mygroup.example.com/v1beta1" {
+		t.Errorf("expected apiVersion mygroup.example.com/v1beta1, got %q", obj.GetAPIVersion())
+	}
+	restClient := apiExtensionClient.Discovery().RESTClient().(*rest.RESTClient)
+	getURL := restClient.Get().AbsPath("/apis/mygroup.example.com/v1beta2/namespaces", ns, "noxus", "foo").URL().String()
+	resp, err := restClient.Client.Get(getURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if warning := resp.Header.Get("Warning"); !strings.Contains(warning, "unconverted") {
+		t.Errorf("expected a warning about the unconverted instance, got %q", warning)
+	}
+
+	// writes still fail
+	if _, err := v1beta2Client.Update(obj); err == nil {
+		t.Errorf("expected the update in v1beta2 to fail")
+	}
+
+	// with the Fail policy, reads fail too
+	fail := apiextensionsv1beta1.ConversionFailurePolicyFail
+	noxuDefinition, err = apiExtensionClient.ApiextensionsV1beta1().CustomResourceDefinitions().Get(noxuDefinition.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	noxuDefinition.Spec.Conversion.FailurePolicy = &fail
+	if _, err := apiExtensionClient.ApiextensionsV1beta1().CustomResourceDefinitions().Update(noxuDefinition); err != nil {
+		t.Fatal(err)
+	}
+	err = wait.PollImmediate(500*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		_, err := v1beta2Client.Get("foo", metav1.GetOptions{})
+		return err != nil, nil
+	})
+	if err != nil {
+		t.Errorf("expected reads in v1beta2 to fail with the Fail policy: %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/test/integration/testserver"
+)
+
+func TestCustomResourceDefaulting(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.Validation = &apiextensionsv1beta1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1beta1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+				"spec": {
+					Type: "object",
+					Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+						"a": {Type: "string", Default: &apiextensionsv1beta1.JSON{Raw: []byte(`"A"`)}},
+						"b": {Type: "string", Default: &apiextensionsv1beta1.JSON{Raw: []byte(`"B"`)}},
+					},
+				},
+			},
+		},
+	}
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+
+	instance := testserver.NewNoxuInstance(ns, "foo")
+	instance.Object["spec"] = map[string]interface{}{"a": "x"}
+	created, err := noxuResourceClient.Create(instance)
+	if err != nil {
+		t.Fatalf("unexpected error creating an instance: %v", err)
+	}
+	spec := created.Object["spec"].(map[string]interface{})
+	if spec["a"] != "x" || spec["b"] != "B" {
+		t.Errorf("expected spec.a to be kept and spec.b to be defaulted on create, got %v", spec)
+	}
+
+	created.Object["spec"] = map[string]interface{}{"b": "y"}
+	updated, err := noxuResourceClient.Update(created)
+	if err != nil {
+		t.Fatalf("unexpected error updating an instance: %v", err)
+	}
+	spec = updated.Object["spec"].(map[string]interface{})
+	if spec["a"] != "A" || spec["b"] != "y" {
+		t.Errorf("expected spec.a to be defaulted and spec.b to be kept on update, got %v", spec)
+	}
+}
+
+func TestDefaultValidationAndPruning(t *testing.T) {
+	stopCh, apiExtensionClient, _, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	preserveUnknownFields := false
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.PreserveUnknownFields = &preserveUnknownFields
+	noxuDefinition.Spec.Validation = &apiextensionsv1beta1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1beta1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+				"spec": {
+					Type:    "object",
+					Default: &apiextensionsv1beta1.JSON{Raw: []byte(`{"replicas":"one","unknown":true}`)},
+					Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+						"replicas": {Type: "integer"},
+					},
+				},
+			},
+		},
+	}
+
+	// a default which does not validate against its schema is rejected
+	_, err = apiExtensionClient.ApiextensionsV1beta1().CustomResourceDefinitions().Create(noxuDefinition)
+	if err == nil {
+		t.Fatalf("expected an invalid default to be rejected")
+	}
+	if path := "spec.validation.openAPIV3Schema.properties[spec].default.replicas"; !strings.Contains(err.Error(), path) {
+		t.Errorf("expected the error to point at %s, got: %v", path, err)
+	}
+
+	// unknown fields are pruned from defaults
+	spec := noxuDefinition.Spec.Validation.OpenAPIV3Schema.Properties["spec"]
+	spec.Default = &apiextensionsv1beta1.JSON{Raw: []byte(`{"replicas":1,"unknown":true}`)}
+	noxuDefinition.Spec.Validation.OpenAPIV3Schema.Properties["spec"] = spec
+	if _, err := apiExtensionClient.ApiextensionsV1beta1().CustomResourceDefinitions().Create(noxuDefinition); err != nil {
+		t.Fatal(err)
+	}
+	crd, err := apiExtensionClient.ApiextensionsV1beta1().CustomResourceDefinitions().Get(noxuDefinition.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected, got := `{"replicas":1}`, string(crd.Spec.Validation.OpenAPIV3Schema.Properties["spec"].Default.Raw); got != expected {
+		t.Errorf("expected the default %s, got %s", expected, got)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"testing"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/test/integration/testserver"
+	"k8s.io/client-go/rest"
+)
+
+func TestDeprecationWarnings(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	custom := "v1alpha1 is going away"
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.ClusterScoped)
+	noxuDefinition.Spec.Versions = []apiextensionsv1beta1.CustomResourceDefinitionVersion{
+		{Name: "v1beta1", Served: true, Storage: true, Deprecated: true},
+		{Name: "v1alpha1", Served: true, Deprecated: true, DeprecationWarning: &custom},
+		{Name: "v1", Served: true},
+	}
+	if _, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool); err != nil {
+		t.Fatal(err)
+	}
+
+	restClient := apiExtensionClient.Discovery().RESTClient().(*rest.RESTClient)
+	tests := []struct {
+		version  string
+		expected string
+	}{
+		{version: "v1beta1", expected: `299 - "mygroup.example.com/v1beta1 WishIHadChosenNoxu is deprecated; use mygroup.example.com/v1 WishIHadChosenNoxu"`},
+		{version: "v1alpha1", expected: `299 - "v1alpha1 is going away"`},
+		{version: "v1"},
+	}
+	for _, tt := range tests {
+		url := restClient.Get().AbsPath("/apis/mygroup.example.com", tt.version, "noxus").URL().String()
+		resp, err := restClient.Client.Get(url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if warning := resp.Header.Get("Warning"); warning != tt.expected {
+			t.Errorf("%s: expected the warning %q, got %q", tt.version, tt.expected, warning)
+		}
+	}
+}
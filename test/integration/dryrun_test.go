@@ -0,0 +1,116 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/test/integration/testserver"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDryRunCreate(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	minimum := float64(1)
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.Validation = &apiextensionsv1beta1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1beta1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+				"spec": {
+					Type:     "object",
+					Required: []string{"image"},
+					Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+						"image":    {Type: "string"},
+						"replicas": {Type: "integer", Minimum: &minimum, Default: &apiextensionsv1beta1.JSON{Raw: []byte(`1`)}},
+					},
+				},
+			},
+		},
+	}
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+	restClient := apiExtensionClient.Discovery().RESTClient()
+	path := "/apis/mygroup.example.com/v1beta1/namespaces/" + ns + "/noxus"
+
+	// a valid custom resource is returned defaulted but not persisted
+	instance := testserver.NewNoxuInstance(ns, "foo")
+	instance.Object["spec"] = map[string]interface{}{"image": "nginx"}
+	body, err := json.Marshal(instance.Object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := restClient.Post().AbsPath(path).Param("dryRun", "All").Body(body).DoRaw()
+	if err != nil {
+		t.Fatalf("unexpected error in dry run: %v", err)
+	}
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	if expected := map[string]interface{}{"image": "nginx", "replicas": float64(1)}; !reflect.DeepEqual(result["spec"], expected) {
+		t.Errorf("expected the defaulted spec %v, got %v", expected, result["spec"])
+	}
+	if _, err := noxuResourceClient.Get("foo", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the dry run not to persist the custom resource, got %v", err)
+	}
+
+	// all violations are returned
+	instance.Object["spec"] = map[string]interface{}{"replicas": 0}
+	body, err = json.Marshal(instance.Object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = restClient.Post().AbsPath(path).Param("dryRun", "All").Body(body).Do().Error()
+	status, ok := err.(apierrors.APIStatus)
+	if !ok || status.Status().Details == nil {
+		t.Fatalf("expected a status error with details, got %v", err)
+	}
+	expected := map[string]metav1.CauseType{
+		"spec.image":    metav1.CauseTypeFieldValueRequired,
+		"spec.replicas": metav1.CauseTypeFieldValueInvalid,
+	}
+	causes := map[string]metav1.CauseType{}
+	for _, cause := range status.Status().Details.Causes {
+		causes[cause.Field] = cause.Type
+	}
+	if !reflect.DeepEqual(causes, expected) {
+		t.Errorf("expected causes %v, got %v", expected, status.Status().Details.Causes)
+	}
+
+	// other requests cannot be dry run
+	if err := restClient.Delete().AbsPath(path, "foo").Param("dryRun", "All").Do().Error(); !apierrors.IsBadRequest(err) {
+		t.Errorf("expected a dry run delete to be rejected, got %v", err)
+	}
+	if err := restClient.Post().AbsPath(path).Param("dryRun", "Some").Body(body).Do().Error(); !apierrors.IsBadRequest(err) {
+		t.Errorf("expected an unsupported dryRun value to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"testing"
+	"time"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/test/integration/testserver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// newSelectableNoxuDefinition returns the noxu CRD with the selectable fields spec.color and
+// spec.replicas.
+func newSelectableNoxuDefinition() *apiextensionsv1beta1.CustomResourceDefinition {
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.Validation = &apiextensionsv1beta1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1beta1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+				"spec": {
+					Type: "object",
+					Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+						"color":    {Type: "string"},
+						"replicas": {Type: "integer"},
+					},
+				},
+			},
+		},
+	}
+	noxuDefinition.Spec.SelectableFields = []apiextensionsv1beta1.SelectableField{
+		{JSONPath: ".spec.color"},
+		{JSONPath: ".spec.replicas"},
+	}
+	return noxuDefinition
+}
+
+func TestSelectableFields(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	noxuDefinition := newSelectableNoxuDefinition()
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+
+	for name, spec := range map[string]map[string]interface{}{
+		"blue":  {"color": "blue", "replicas": int64(1)},
+		"red":   {"color": "red", "replicas": int64(1)},
+		"green": {"color": "green", "replicas": int64(2)},
+	} {
+		instance := testserver.NewNoxuInstance(ns, name)
+		instance.Object["spec"] = spec
+		if _, err := noxuResourceClient.Create(instance); err != nil {
+			t.Fatalf("unexpected error creating %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		selector string
+		expected []string
+	}{
+		{"spec.color=blue", []string{"blue"}},
+		{"spec.replicas=1,spec.color!=blue", []string{"red"}},
+		{"spec.replicas=2", []string{"green"}},
+		{"metadata.name=red", []string{"red"}},
+	}
+	for _, tc := range tests {
+		list, err := noxuResourceClient.List(metav1.ListOptions{FieldSelector: tc.selector})
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.selector, err)
+			continue
+		}
+		var names []string
+		for _, item := range list.(*unstructured.UnstructuredList).Items {
+			names = append(names, item.GetName())
+		}
+		if len(names) != len(tc.expected) || (len(names) > 0 && names[0] != tc.expected[0]) {
+			t.Errorf("%s: expected %v, got %v", tc.selector, tc.expected, names)
+		}
+	}
+
+	if _, err := noxuResourceClient.List(metav1.ListOptions{FieldSelector: "spec.unknown=foo"}); err == nil {
+		t.Errorf("expected field selector on undeclared field to be rejected")
+	}
+
+	w, err := noxuResourceClient.Watch(metav1.ListOptions{FieldSelector: "spec.color=red"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+	event := <-w.ResultChan()
+	if name := event.Object.(*unstructured.Unstructured).GetName(); name != "red" {
+		t.Errorf("expected to watch red, got %s", name)
+	}
+}
+
+// TestSelectableFieldsWatch checks that watches with a field selector on a selectable field only
+// receive the events of matching custom resources, and that custom resources changing into or out
+// of the selection are reported as added or deleted. spec.replicas is not the first selectable
+// field, so it is not indexed by the watch cache.
+func TestSelectableFieldsWatch(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	noxuDefinition := newSelectableNoxuDefinition()
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+
+	list, err := noxuResourceClient.List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := noxuResourceClient.Watch(metav1.ListOptions{
+		ResourceVersion: list.(*unstructured.UnstructuredList).GetResourceVersion(),
+		FieldSelector:   "spec.replicas=3",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	create := func(name string, replicas int64) *unstructured.Unstructured {
+		instance := testserver.NewNoxuInstance(ns, name)
+		instance.Object["spec"] = map[string]interface{}{"color": name, "replicas": replicas}
+		created, err := noxuResourceClient.Create(instance)
+		if err != nil {
+			t.Fatalf("unexpected error creating %s: %v", name, err)
+		}
+		return created
+	}
+	update := func(obj *unstructured.Unstructured, replicas int64) {
+		obj.Object["spec"].(map[string]interface{})["replicas"] = replicas
+		if _, err := noxuResourceClient.Update(obj); err != nil {
+			t.Fatalf("unexpected error updating %s: %v", obj.GetName(), err)
+		}
+	}
+	expectEvent := func(eventType watch.EventType, name string) {
+		select {
+		case event := <-w.ResultChan():
+			if event.Type != eventType {
+				t.Fatalf("expected %v event of %s, got %v: %#v", eventType, name, event.Type, event.Object)
+			}
+			if got := event.Object.(*unstructured.Unstructured).GetName(); got != name {
+				t.Fatalf("expected %v event of %s, got one of %s", eventType, name, got)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("missing %v event of %s", eventType, name)
+		}
+	}
+
+	// the events of yellow are filtered until it matches
+	yellow := create("yellow", 1)
+	purple := create("purple", 3)
+	expectEvent(watch.Added, "purple")
+
+	update(purple, 4)
+	expectEvent(watch.Deleted, "purple")
+
+	update(yellow, 3)
+	expectEvent(watch.Added, "yellow")
+}
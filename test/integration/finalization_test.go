@@ -18,6 +18,7 @@ package integration
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -25,6 +26,8 @@ import (
 	"k8s.io/apiextensions-apiserver/test/integration/testserver"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 func TestFinalization(t *testing.T) {
@@ -89,3 +92,61 @@ func TestFinalization(t *testing.T) {
 	require.Error(t, err)
 	require.True(t, errors.IsNotFound(err), "%#v", err)
 }
+
+func TestDeleteCustomResourceDefinitionWithInstances(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	require.NoError(t, err)
+	defer close(stopCh)
+
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	require.NoError(t, err)
+
+	ns := "not-the-default"
+	name := "foo123"
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+
+	// the finalizer keeps the instance, and with it the CustomResourceDefinition, around
+	instance := testserver.NewNoxuInstance(ns, name)
+	instance.SetFinalizers([]string{"noxu.example.com/finalizer"})
+	_, err = instantiateCustomResource(t, instance, noxuResourceClient, noxuDefinition)
+	require.NoError(t, err)
+
+	err = apiExtensionClient.Apiextensions().CustomResourceDefinitions().Delete(noxuDefinition.Name, nil)
+	require.NoError(t, err)
+
+	// new instances are rejected as soon as the server sees the deletion
+	err = wait.PollImmediate(500*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		_, err := noxuResourceClient.Create(testserver.NewNoxuInstance(ns, "bar"))
+		if errors.IsMethodNotSupported(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return false, noxuResourceClient.Delete("bar", nil)
+	})
+	require.NoError(t, err)
+
+	crd, err := testserver.GetCustomResourceDefinition(noxuDefinition, apiExtensionClient)
+	require.NoError(t, err)
+	require.NotNil(t, crd.DeletionTimestamp)
+	terminating := false
+	for _, cond := range crd.Status.Conditions {
+		if cond.Type == apiextensionsv1beta1.Terminating {
+			terminating = cond.Status == apiextensionsv1beta1.ConditionTrue
+		}
+	}
+	require.True(t, terminating, "expected a Terminating condition, got %#v", crd.Status.Conditions)
+
+	// reads are still served
+	gottenNoxuInstance, err := noxuResourceClient.Get(name, metav1.GetOptions{})
+	require.NoError(t, err)
+	list, err := noxuResourceClient.List(metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, list.(*unstructured.UnstructuredList).Items, 1)
+
+	// and updates are rejected like creates
+	_, err = noxuResourceClient.Update(gottenNoxuInstance)
+	require.True(t, errors.IsMethodNotSupported(err), "%#v", err)
+}
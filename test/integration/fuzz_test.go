@@ -0,0 +1,105 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/test/integration/testserver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFuzzedCustomResourceRoundTrip(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	minReplicas, maxReplicas, maxLength := 0.0, 10.0, int64(20)
+	preserveUnknownFields := false
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.PreserveUnknownFields = &preserveUnknownFields
+	noxuDefinition.Spec.Validation = &apiextensionsv1beta1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1beta1.JSONSchemaProps{
+			Type:     "object",
+			Required: []string{"spec"},
+			Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+				"spec": {
+					Type:     "object",
+					Required: []string{"image"},
+					Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+						"image":    {Type: "string", Pattern: `^[a-z]+(:[0-9]{1,3})?$`, MaxLength: &maxLength},
+						"replicas": {Type: "integer", Minimum: &minReplicas, Maximum: &maxReplicas},
+						"ratio":    {Type: "number"},
+						"policy":   {Type: "string", Enum: []apiextensionsv1beta1.JSON{{Raw: []byte(`"Always"`)}, {Raw: []byte(`"Never"`)}}},
+						"created":  {Type: "string", Format: "date-time"},
+						"labels": {
+							Type:                 "object",
+							AdditionalProperties: &apiextensionsv1beta1.JSONSchemaPropsOrBool{Schema: &apiextensionsv1beta1.JSONSchemaProps{Type: "string"}},
+						},
+						"args": {
+							Type:  "array",
+							Items: &apiextensionsv1beta1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1beta1.JSONSchemaProps{Type: "string"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+
+	for seed := int64(0); seed < 20; seed++ {
+		name := fmt.Sprintf("fuzzed-%d", seed)
+		instance, err := testserver.NewFuzzedInstance(noxuDefinition, "v1beta1", ns, name, seed)
+		if err != nil {
+			t.Fatalf("seed %d: unexpected error generating an instance: %v", seed, err)
+		}
+		if _, err := noxuResourceClient.Create(instance); err != nil {
+			t.Errorf("seed %d: unexpected error creating %v: %v", seed, instance.Object, err)
+			continue
+		}
+		stored, err := noxuResourceClient.Get(name, metav1.GetOptions{})
+		if err != nil {
+			t.Errorf("seed %d: unexpected error: %v", seed, err)
+			continue
+		}
+
+		// valid custom resources are neither pruned nor changed otherwise. Whole numbers are compared
+		// in their JSON encoding, which does not distinguish integers and floats.
+		expected, err := json.Marshal(instance.Object["spec"])
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := json.Marshal(stored.Object["spec"])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(expected) != string(actual) {
+			t.Errorf("seed %d: expected spec %s, got %s", seed, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/test/integration/testserver"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestOpenAPIPublishing(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.Validation = &apiextensionsv1beta1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1beta1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+				"spec": {
+					Type:        "object",
+					Description: "spec of the noxu",
+					Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+						"replicas": {Type: "integer"},
+					},
+				},
+			},
+		},
+	}
+	if _, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool); err != nil {
+		t.Fatal(err)
+	}
+
+	restClient := apiExtensionClient.Discovery().RESTClient()
+	definition := "com.example.mygroup.v1beta1.WishIHadChosenNoxu"
+	var doc struct {
+		Definitions map[string]struct {
+			Properties map[string]struct {
+				Description string `json:"description"`
+			} `json:"properties"`
+		} `json:"definitions"`
+		Paths map[string]interface{} `json:"paths"`
+	}
+	err = wait.PollImmediate(100*time.Millisecond, 30*time.Second, func() (bool, error) {
+		data, err := restClient.Get().AbsPath("/openapi/v2").DoRaw()
+		if err != nil {
+			return false, err
+		}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return false, err
+		}
+		_, found := doc.Definitions[definition]
+		return found, nil
+	})
+	if err != nil {
+		t.Fatalf("the definition %s was not published: %v", definition, err)
+	}
+	if description := doc.Definitions[definition].Properties["spec"].Description; description != "spec of the noxu" {
+		t.Errorf("unexpected description of spec %q", description)
+	}
+	if _, found := doc.Paths["/apis/mygroup.example.com/v1beta1/namespaces/{namespace}/noxus/{name}"]; !found {
+		t.Errorf("missing the path of noxus")
+	}
+
+	var index struct {
+		Paths map[string]struct {
+			ServerRelativeURL string `json:"serverRelativeURL"`
+		} `json:"paths"`
+	}
+	data, err := restClient.Get().AbsPath("/openapi/v3").DoRaw()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatal(err)
+	}
+	entry, found := index.Paths["apis/mygroup.example.com/v1beta1"]
+	if !found {
+		t.Fatalf("missing mygroup.example.com/v1beta1 in the OpenAPI v3 index: %s", data)
+	}
+	var v3 struct {
+		Components struct {
+			Schemas map[string]interface{} `json:"schemas"`
+		} `json:"components"`
+	}
+	data, err = restClient.Get().AbsPath(entry.ServerRelativeURL).DoRaw()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &v3); err != nil {
+		t.Fatal(err)
+	}
+	if _, found := v3.Components.Schemas[definition]; !found {
+		t.Errorf("missing the schema %s in the OpenAPI v3 document", definition)
+	}
+
+	var jsonSchema struct {
+		Schema     string `json:"$schema"`
+		Properties map[string]struct {
+			Description string `json:"description"`
+		} `json:"properties"`
+	}
+	data, err = restClient.Get().AbsPath("/openapi/jsonschema/apis/mygroup.example.com/v1beta1/noxus").DoRaw()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &jsonSchema); err != nil {
+		t.Fatal(err)
+	}
+	if len(jsonSchema.Schema) == 0 {
+		t.Errorf("expected the JSON Schema to specify $schema: %s", data)
+	}
+	if description := jsonSchema.Properties["spec"].Description; description != "spec of the noxu" {
+		t.Errorf("unexpected description of spec in the JSON Schema %q", description)
+	}
+
+	// deleted CRDs are unpublished
+	if err := testserver.DeleteCustomResourceDefinition(noxuDefinition, apiExtensionClient); err != nil {
+		t.Fatal(err)
+	}
+	err = wait.PollImmediate(100*time.Millisecond, 30*time.Second, func() (bool, error) {
+		data, err := restClient.Get().AbsPath("/openapi/v2").DoRaw()
+		if err != nil {
+			return false, err
+		}
+		doc.Definitions = nil
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return false, err
+		}
+		_, found := doc.Definitions[definition]
+		return !found, nil
+	})
+	if err != nil {
+		t.Errorf("the definition %s was not unpublished: %v", definition, err)
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/test/integration/testserver"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestListPagination(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected []string
+	for _, ns := range []string{"ns-a", "ns-b"} {
+		noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+		for i := 0; i < 3; i++ {
+			name := fmt.Sprintf("foo-%d", i)
+			if _, err := noxuResourceClient.Create(testserver.NewNoxuInstance(ns, name)); err != nil {
+				t.Fatal(err)
+			}
+			expected = append(expected, ns+"/"+name)
+		}
+	}
+
+	// list the custom resources of all namespaces in pages of two
+	restClient := apiExtensionClient.Discovery().RESTClient()
+	path := "/apis/mygroup.example.com/v1beta1/noxus"
+	var actual []string
+	continueToken := ""
+	for pages := 0; ; pages++ {
+		if pages > len(expected) {
+			t.Fatalf("too many pages: %v", actual)
+		}
+		req := restClient.Get().AbsPath(path).Param("limit", "2")
+		if len(continueToken) > 0 {
+			req = req.Param("continue", continueToken)
+		}
+		data, err := req.DoRaw()
+		if err != nil {
+			t.Fatal(err)
+		}
+		page := struct {
+			Metadata struct {
+				Continue string `json:"continue"`
+			} `json:"metadata"`
+			Items []struct {
+				Metadata struct {
+					Name      string `json:"name"`
+					Namespace string `json:"namespace"`
+				} `json:"metadata"`
+			} `json:"items"`
+		}{}
+		if err := json.Unmarshal(data, &page); err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Items) > 2 {
+			t.Errorf("expected at most 2 items per page, got %d", len(page.Items))
+		}
+		for _, item := range page.Items {
+			actual = append(actual, item.Metadata.Namespace+"/"+item.Metadata.Name)
+		}
+		if continueToken = page.Metadata.Continue; len(continueToken) == 0 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+
+	if err := restClient.Get().AbsPath(path).Param("resourceVersion", "1").Param("resourceVersionMatch", "Exact").Do().Error(); !apierrors.IsBadRequest(err) {
+		t.Errorf("expected resourceVersionMatch=Exact to be rejected, got %v", err)
+	}
+	if err := restClient.Get().AbsPath(path).Param("continue", "garbage").Do().Error(); !apierrors.IsBadRequest(err) {
+		t.Errorf("expected an invalid continue token to be rejected, got %v", err)
+	}
+}
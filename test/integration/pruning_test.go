@@ -0,0 +1,145 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"testing"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/test/integration/testserver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCustomResourcePruning(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	preserveUnknownFields := false
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.PreserveUnknownFields = &preserveUnknownFields
+	noxuDefinition.Spec.Validation = &apiextensionsv1beta1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1beta1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+				"spec": {
+					Type: "object",
+					Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+						"replicas": {Type: "integer"},
+					},
+				},
+			},
+		},
+	}
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+
+	instance := testserver.NewNoxuInstance(ns, "foo")
+	instance.Object["spec"] = map[string]interface{}{"replicas": 1, "replicsa": 2}
+	if _, err := noxuResourceClient.Create(instance); err != nil {
+		t.Fatalf("unexpected error creating an instance: %v", err)
+	}
+
+	stored, err := noxuResourceClient.Get("foo", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found := stored.Object["content"]; found {
+		t.Errorf("expected content to be pruned, got %v", stored.Object)
+	}
+	spec := stored.Object["spec"].(map[string]interface{})
+	if _, found := spec["replicsa"]; found {
+		t.Errorf("expected spec.replicsa to be pruned, got %v", spec)
+	}
+	if _, found := spec["replicas"]; !found {
+		t.Errorf("expected spec.replicas to be kept, got %v", spec)
+	}
+	if stored.GetName() != "foo" || stored.GetNamespace() != ns {
+		t.Errorf("expected metadata to be kept, got %v", stored.Object["metadata"])
+	}
+}
+
+func TestCustomResourceVendorExtensions(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	preserveUnknownFields := false
+	preserve := true
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.PreserveUnknownFields = &preserveUnknownFields
+	noxuDefinition.Spec.Validation = &apiextensionsv1beta1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1beta1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+				"spec": {
+					Type: "object",
+					Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+						"port":   {XIntOrString: true},
+						"raw":    {XPreserveUnknownFields: &preserve},
+						"object": {Type: "object", XEmbeddedResource: true, XPreserveUnknownFields: &preserve},
+					},
+				},
+			},
+		},
+	}
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+
+	instance := testserver.NewNoxuInstance(ns, "foo")
+	instance.Object["spec"] = map[string]interface{}{
+		"port":   "http",
+		"raw":    map[string]interface{}{"a": map[string]interface{}{"b": 1}},
+		"object": map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "metadata": map[string]interface{}{"name": "bar"}, "spec": map[string]interface{}{}},
+	}
+	if _, err := noxuResourceClient.Create(instance); err != nil {
+		t.Fatalf("unexpected error creating an instance: %v", err)
+	}
+	stored, err := noxuResourceClient.Get("foo", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := stored.Object["spec"].(map[string]interface{})
+	for _, field := range []string{"port", "raw", "object"} {
+		if _, found := spec[field]; !found {
+			t.Errorf("expected spec.%s to be kept, got %v", field, spec)
+		}
+	}
+
+	invalid := testserver.NewNoxuInstance(ns, "bar")
+	invalid.Object["spec"] = map[string]interface{}{
+		"port":   true,
+		"object": map[string]interface{}{"spec": map[string]interface{}{}},
+	}
+	if _, err := noxuResourceClient.Create(invalid); err == nil {
+		t.Errorf("expected an invalid port and object to be rejected")
+	}
+}
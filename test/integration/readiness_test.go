@@ -0,0 +1,66 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/test/integration/testserver"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+)
+
+func TestCRDReadiness(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	restClient := apiExtensionClient.Discovery().RESTClient().(*rest.RESTClient)
+	readinessCode := func(name string) int {
+		url := restClient.Get().AbsPath("/crds", name).URL().String()
+		resp, err := restClient.Client.Get(url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.ClusterScoped)
+	if code := readinessCode(noxuDefinition.Name); code != http.StatusNotFound {
+		t.Errorf("expected status %d before the CRD is created, got %d", http.StatusNotFound, code)
+	}
+	if _, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool); err != nil {
+		t.Fatal(err)
+	}
+
+	// the OpenAPI documents are published shortly after the CRD is established
+	err = wait.PollImmediate(100*time.Millisecond, 30*time.Second, func() (bool, error) {
+		return readinessCode(noxuDefinition.Name) == http.StatusOK, nil
+	})
+	if err != nil {
+		t.Fatalf("expected %s to become ready: %v", noxuDefinition.Name, err)
+	}
+	if code := readinessCode(""); code != http.StatusOK {
+		t.Errorf("expected status %d for the report of all CRDs, got %d", http.StatusOK, code)
+	}
+}
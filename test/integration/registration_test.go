@@ -35,6 +35,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
 )
@@ -342,6 +343,84 @@ func TestDeRegistrationAndReRegistration(t *testing.T) {
 	}()
 }
 
+func TestScopeChange(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ns := "not-the-default"
+	noxuNamespacedResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+	if _, err := instantiateCustomResource(t, testserver.NewNoxuInstance(ns, "foo"), noxuNamespacedResourceClient, noxuDefinition); err != nil {
+		t.Fatal(err)
+	}
+
+	changeScope := func() error {
+		crd, err := testserver.GetCustomResourceDefinition(noxuDefinition, apiExtensionClient)
+		if err != nil {
+			t.Fatal(err)
+		}
+		crd.Spec.Scope = apiextensionsv1beta1.ClusterScoped
+		_, err = apiExtensionClient.ApiextensionsV1beta1().CustomResourceDefinitions().Update(crd)
+		return err
+	}
+
+	// the scope cannot be changed while custom resources exist
+	if err := changeScope(); !errors.IsInvalid(err) {
+		t.Fatalf("expected an Invalid error, got: %v", err)
+	}
+	crd, err := testserver.GetCustomResourceDefinition(noxuDefinition, apiExtensionClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if crd.Spec.Scope != apiextensionsv1beta1.NamespaceScoped {
+		t.Fatalf("expected the scope to stay %s, got %s", apiextensionsv1beta1.NamespaceScoped, crd.Spec.Scope)
+	}
+	if !hasScopeChangeRejected(crd) {
+		t.Fatalf("expected the %s condition, got: %#v", apiextensionsv1beta1.ScopeChangeRejected, crd.Status.Conditions)
+	}
+
+	// without custom resources the scope can be changed
+	if err := noxuNamespacedResourceClient.Delete("foo", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := changeScope(); err != nil {
+		t.Fatal(err)
+	}
+	crd, err = testserver.GetCustomResourceDefinition(noxuDefinition, apiExtensionClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hasScopeChangeRejected(crd) {
+		t.Fatalf("expected the %s condition to be removed, got: %#v", apiextensionsv1beta1.ScopeChangeRejected, crd.Status.Conditions)
+	}
+
+	noxuDefinition.Spec.Scope = apiextensionsv1beta1.ClusterScoped
+	noxuClusterResourceClient := NewNamespacedCustomResourceClient("", noxuVersionClient, noxuDefinition)
+	err = wait.PollImmediate(500*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		_, err := noxuClusterResourceClient.Create(testserver.NewNoxuInstance("", "foo"))
+		return err == nil, nil
+	})
+	if err != nil {
+		t.Fatalf("unable to create a cluster scoped custom resource: %v", err)
+	}
+}
+
+func hasScopeChangeRejected(crd *apiextensionsv1beta1.CustomResourceDefinition) bool {
+	for _, condition := range crd.Status.Conditions {
+		if condition.Type == apiextensionsv1beta1.ScopeChangeRejected {
+			return condition.Status == apiextensionsv1beta1.ConditionTrue
+		}
+	}
+	return false
+}
+
 func TestEtcdStorage(t *testing.T) {
 	config, err := testserver.DefaultServerConfig()
 	if err != nil {
@@ -456,6 +535,59 @@ func TestEtcdStorage(t *testing.T) {
 	}
 }
 
+func TestEtcdStorageCustomPrefix(t *testing.T) {
+	config, err := testserver.DefaultServerConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.AllowedStoragePrefixes = []string{"isolated"}
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartServer(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	etcdPrefix := getPrefixFromConfig(t, config)
+
+	curletDefinition := testserver.NewCurletCustomResourceDefinition(apiextensionsv1beta1.ClusterScoped)
+	curletDefinition.Spec.Storage = &apiextensionsv1beta1.CustomResourceStorage{Prefix: "elsewhere/curlets"}
+	if _, err := testserver.CreateNewCustomResourceDefinition(curletDefinition, apiExtensionClient, clientPool); err == nil {
+		t.Fatalf("expected a storage prefix which is not allowed to be rejected")
+	}
+
+	ns := "the-cruel-default"
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.Storage = &apiextensionsv1beta1.CustomResourceStorage{Prefix: "isolated/noxus"}
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	noxuNamespacedResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+	if _, err := instantiateCustomResource(t, testserver.NewNoxuInstance(ns, "foo"), noxuNamespacedResourceClient, noxuDefinition); err != nil {
+		t.Fatalf("unable to create noxu namespace scoped Instance:%v", err)
+	}
+
+	etcdURL, ok := os.LookupEnv("KUBE_INTEGRATION_ETCD_URL")
+	if !ok {
+		etcdURL = "http://127.0.0.1:2379"
+	}
+	c, err := clientv3.New(clientv3.Config{Endpoints: []string{etcdURL}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kv := clientv3.NewKV(c)
+	output, err := getFromEtcd(kv, etcdPrefix, "isolated/noxus/the-cruel-default/foo")
+	if err != nil {
+		t.Fatalf("no path gotten from etcd:%v", err)
+	}
+	if output.Metadata.Name != "foo" || output.Metadata.Namespace != ns {
+		t.Errorf("unexpected object in etcd: %#v", output)
+	}
+	if _, err := getFromEtcd(kv, etcdPrefix, "mygroup.example.com/noxus/the-cruel-default/foo"); err == nil {
+		t.Errorf("expected no object at the default path")
+	}
+}
+
 func getPrefixFromConfig(t *testing.T, config *extensionsapiserver.Config) string {
 	extensionsOptionsGetter, ok := config.CRDRESTOptionsGetter.(extensionsapiserver.CRDRESTOptionsGetter)
 	if !ok {
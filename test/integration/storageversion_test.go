@@ -0,0 +1,140 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/test/integration/testserver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestStorageVersionMigration(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	noxuDefinition := newMultiVersionNoxuDefinition(&apiextensionsv1beta1.CustomResourceConversion{Strategy: apiextensionsv1beta1.NoneConverter})
+	if _, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool); err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	v1beta1Client := newNoxuResourceClientForVersion(t, clientPool, noxuDefinition, ns, "v1beta1")
+	if _, err := v1beta1Client.Create(testserver.NewNoxuInstance(ns, "foo")); err != nil {
+		t.Fatalf("unexpected error creating an instance: %v", err)
+	}
+
+	crd, err := testserver.GetCustomResourceDefinition(noxuDefinition, apiExtensionClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"v1beta1"}; !reflect.DeepEqual(crd.Status.StoredVersions, expected) {
+		t.Fatalf("expected stored versions %v, got %v", expected, crd.Status.StoredVersions)
+	}
+
+	// switch the storage version to v1beta2
+	crd.Spec.Versions[0].Storage = false
+	crd.Spec.Versions[1].Storage = true
+	crd, err = apiExtensionClient.ApiextensionsV1beta1().CustomResourceDefinitions().Update(crd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"v1beta1", "v1beta2"}; !reflect.DeepEqual(crd.Status.StoredVersions, expected) {
+		t.Fatalf("expected stored versions %v, got %v", expected, crd.Status.StoredVersions)
+	}
+
+	// removing a stored version from spec.versions is rejected until the objects are migrated
+	withoutV1beta1 := crd.DeepCopy()
+	withoutV1beta1.Spec.Versions = withoutV1beta1.Spec.Versions[1:]
+	withoutV1beta1.Spec.Version = "v1beta2"
+	if _, err := apiExtensionClient.ApiextensionsV1beta1().CustomResourceDefinitions().Update(withoutV1beta1); err == nil {
+		t.Errorf("expected an error removing a stored version")
+	}
+
+	// the migration controller rewrites the instance and prunes v1beta1
+	err = wait.PollImmediate(100*time.Millisecond, 30*time.Second, func() (bool, error) {
+		crd, err = testserver.GetCustomResourceDefinition(noxuDefinition, apiExtensionClient)
+		if err != nil {
+			return false, err
+		}
+		return reflect.DeepEqual(crd.Status.StoredVersions, []string{"v1beta2"}), nil
+	})
+	if err != nil {
+		t.Fatalf("stored versions were not migrated, got %v: %v", crd.Status.StoredVersions, err)
+	}
+
+	v1beta2Client := newNoxuResourceClientForVersion(t, clientPool, noxuDefinition, ns, "v1beta2")
+	obj, err := v1beta2Client.Get("foo", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error getting the migrated instance: %v", err)
+	}
+	if _, found := obj.Object["content"]; !found {
+		t.Errorf("expected content to be kept, got %v", obj.Object)
+	}
+}
+
+func TestPersistedVersions(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	noxuDefinition := newMultiVersionNoxuDefinition(&apiextensionsv1beta1.CustomResourceConversion{Strategy: apiextensionsv1beta1.NoneConverter})
+	if _, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool); err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	v1beta1Client := newNoxuResourceClientForVersion(t, clientPool, noxuDefinition, ns, "v1beta1")
+	for _, name := range []string{"foo", "bar"} {
+		if _, err := v1beta1Client.Create(testserver.NewNoxuInstance(ns, name)); err != nil {
+			t.Fatalf("unexpected error creating an instance: %v", err)
+		}
+	}
+
+	crd, err := testserver.GetCustomResourceDefinition(noxuDefinition, apiExtensionClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// switching the storage version recounts the instances once they are migrated
+	crd.Spec.Versions[0].Storage = false
+	crd.Spec.Versions[1].Storage = true
+	if _, err := apiExtensionClient.ApiextensionsV1beta1().CustomResourceDefinitions().Update(crd); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []apiextensionsv1beta1.PersistedVersionCount{{Version: "v1beta2", Count: 2}}
+	err = wait.PollImmediate(100*time.Millisecond, 30*time.Second, func() (bool, error) {
+		crd, err = testserver.GetCustomResourceDefinition(noxuDefinition, apiExtensionClient)
+		if err != nil {
+			return false, err
+		}
+		return reflect.DeepEqual(crd.Status.PersistedVersions, expected), nil
+	})
+	if err != nil {
+		t.Fatalf("expected persisted versions %v, got %v: %v", expected, crd.Status.PersistedVersions, err)
+	}
+}
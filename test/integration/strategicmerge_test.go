@@ -0,0 +1,105 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"reflect"
+	"testing"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/test/integration/testserver"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestStrategicMergePatch(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	listType := "map"
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.Validation = &apiextensionsv1beta1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1beta1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+				"ports": {
+					Type:         "array",
+					XListType:    &listType,
+					XListMapKeys: []string{"port"},
+					Items: &apiextensionsv1beta1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1beta1.JSONSchemaProps{
+						Type:     "object",
+						Required: []string{"port"},
+						Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+							"port": {Type: "integer"},
+							"name": {Type: "string"},
+						},
+					}},
+				},
+			},
+		},
+	}
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+	instance := testserver.NewNoxuInstance(ns, "foo")
+	instance.Object["ports"] = []interface{}{
+		map[string]interface{}{"port": int64(80), "name": "http"},
+		map[string]interface{}{"port": int64(443), "name": "https"},
+	}
+	if _, err := noxuResourceClient.Create(instance); err != nil {
+		t.Fatal(err)
+	}
+
+	// items are merged by their port and removed with the delete directive
+	patch := []byte(`{"metadata": {"labels": {"patched": "true"}}, "ports": [{"port": 80, "name": "web"}, {"port": 443, "$patch": "delete"}, {"port": 8080, "name": "alt"}]}`)
+	patched, err := noxuResourceClient.Patch("foo", types.StrategicMergePatchType, patch)
+	if err != nil {
+		t.Fatalf("unexpected error patching: %v", err)
+	}
+	expected := []interface{}{
+		map[string]interface{}{"port": int64(80), "name": "web"},
+		map[string]interface{}{"port": int64(8080), "name": "alt"},
+	}
+	if !reflect.DeepEqual(patched.Object["ports"], expected) {
+		t.Errorf("expected ports %v, got %v", expected, patched.Object["ports"])
+	}
+	if labels := patched.GetLabels(); labels["patched"] != "true" {
+		t.Errorf("expected the label to be added, got %v", labels)
+	}
+	obj, err := noxuResourceClient.Get("foo", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(obj.Object["ports"], expected) {
+		t.Errorf("expected stored ports %v, got %v", expected, obj.Object["ports"])
+	}
+
+	if _, err := noxuResourceClient.Patch("foo", types.StrategicMergePatchType, []byte(`{"ports": [{"name": "nokey"}]}`)); !apierrors.IsBadRequest(err) {
+		t.Errorf("expected a patch of an item without key to be rejected, got %v", err)
+	}
+	if _, err := noxuResourceClient.Patch("bar", types.StrategicMergePatchType, patch); !apierrors.IsNotFound(err) {
+		t.Errorf("expected a patch of a missing custom resource to fail with NotFound, got %v", err)
+	}
+}
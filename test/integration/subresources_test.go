@@ -0,0 +1,199 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"encoding/json"
+	"testing"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/test/integration/testserver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestScaleSubresource(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	labelSelectorPath := ".status.labelSelector"
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.Subresources = &apiextensionsv1beta1.CustomResourceSubresources{
+		Scale: &apiextensionsv1beta1.CustomResourceSubresourceScale{
+			SpecReplicasPath:   ".spec.replicas",
+			StatusReplicasPath: ".status.replicas",
+			LabelSelectorPath:  &labelSelectorPath,
+		},
+	}
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+
+	instance := testserver.NewNoxuInstance(ns, "foo")
+	instance.Object["spec"] = map[string]interface{}{"replicas": int64(3)}
+	instance.Object["status"] = map[string]interface{}{"replicas": int64(2), "labelSelector": "app=noxu"}
+	if _, err := noxuResourceClient.Create(instance); err != nil {
+		t.Fatalf("unexpected error creating an instance: %v", err)
+	}
+
+	restClient := apiExtensionClient.Discovery().RESTClient()
+	scalePath := "/apis/mygroup.example.com/v1beta1/namespaces/" + ns + "/noxus/foo/scale"
+
+	data, err := restClient.Get().AbsPath(scalePath).DoRaw()
+	if err != nil {
+		t.Fatalf("unexpected error getting scale: %v", err)
+	}
+	scale := &autoscalingv1.Scale{}
+	if err := json.Unmarshal(data, scale); err != nil {
+		t.Fatal(err)
+	}
+	if scale.Spec.Replicas != 3 || scale.Status.Replicas != 2 || scale.Status.Selector != "app=noxu" {
+		t.Fatalf("unexpected scale: %#v", scale)
+	}
+	if scale.Kind != "Scale" || scale.APIVersion != "autoscaling/v1" {
+		t.Errorf("expected autoscaling/v1 Scale, got %s %s", scale.APIVersion, scale.Kind)
+	}
+
+	scale.Spec.Replicas = 5
+	body, err := json.Marshal(scale)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := restClient.Put().AbsPath(scalePath).Body(body).DoRaw(); err != nil {
+		t.Fatalf("unexpected error updating scale: %v", err)
+	}
+
+	scale.Spec.Replicas = -1
+	body, err = json.Marshal(scale)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := restClient.Put().AbsPath(scalePath).Body(body).DoRaw(); err == nil {
+		t.Errorf("expected negative replicas to be rejected")
+	}
+
+	obj, err := noxuResourceClient.Get("foo", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	replicas := obj.Object["spec"].(map[string]interface{})["replicas"]
+	if replicas != int64(5) {
+		t.Errorf("expected spec.replicas to be 5, got %v", replicas)
+	}
+
+	// the scale subresource is not served for CRDs without it
+	noxu2Definition := testserver.NewNoxu2CustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxu2VersionClient, err := testserver.CreateNewCustomResourceDefinition(noxu2Definition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	noxu2Instance := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": noxu2Definition.Spec.Group + "/" + noxu2Definition.Spec.Version,
+		"kind":       noxu2Definition.Spec.Names.Kind,
+		"metadata":   map[string]interface{}{"name": "foo", "namespace": ns},
+	}}
+	if _, err := NewNamespacedCustomResourceClient(ns, noxu2VersionClient, noxu2Definition).Create(noxu2Instance); err != nil {
+		t.Fatal(err)
+	}
+	noxu2ScalePath := "/apis/" + noxu2Definition.Spec.Group + "/" + noxu2Definition.Spec.Version + "/namespaces/" + ns + "/" + noxu2Definition.Spec.Names.Plural + "/foo/scale"
+	if _, err := restClient.Get().AbsPath(noxu2ScalePath).DoRaw(); err == nil {
+		t.Errorf("expected scale subresource of %s to not be found", noxu2Definition.Name)
+	}
+}
+
+func TestStatusSubresource(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.Subresources = &apiextensionsv1beta1.CustomResourceSubresources{
+		Status: &apiextensionsv1beta1.CustomResourceSubresourceStatus{},
+	}
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+
+	instance := testserver.NewNoxuInstance(ns, "foo")
+	instance.Object["spec"] = map[string]interface{}{"num": int64(10)}
+	instance.Object["status"] = map[string]interface{}{"num": int64(20)}
+	obj, err := noxuResourceClient.Create(instance)
+	if err != nil {
+		t.Fatalf("unexpected error creating an instance: %v", err)
+	}
+	if _, found := obj.Object["status"]; found {
+		t.Errorf("expected status to be dropped on create, got %v", obj.Object["status"])
+	}
+	if obj.GetGeneration() != 1 {
+		t.Errorf("expected generation 1, got %d", obj.GetGeneration())
+	}
+
+	// updates to the main resource ignore the status and bump the generation on other changes
+	obj.Object["spec"] = map[string]interface{}{"num": int64(11)}
+	obj.Object["status"] = map[string]interface{}{"num": int64(21)}
+	obj, err = noxuResourceClient.Update(obj)
+	if err != nil {
+		t.Fatalf("unexpected error updating the instance: %v", err)
+	}
+	if _, found := obj.Object["status"]; found {
+		t.Errorf("expected status to be ignored on update, got %v", obj.Object["status"])
+	}
+	if obj.GetGeneration() != 2 {
+		t.Errorf("expected generation 2, got %d", obj.GetGeneration())
+	}
+
+	// updates to the status subresource only change the status
+	obj.Object["spec"] = map[string]interface{}{"num": int64(12)}
+	obj.Object["status"] = map[string]interface{}{"num": int64(22)}
+	body, err := json.Marshal(obj.Object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restClient := apiExtensionClient.Discovery().RESTClient()
+	statusPath := "/apis/mygroup.example.com/v1beta1/namespaces/" + ns + "/noxus/foo/status"
+	if _, err := restClient.Put().AbsPath(statusPath).Body(body).DoRaw(); err != nil {
+		t.Fatalf("unexpected error updating status: %v", err)
+	}
+
+	obj, err = noxuResourceClient.Get("foo", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if num := obj.Object["spec"].(map[string]interface{})["num"]; num != int64(11) {
+		t.Errorf("expected spec.num to be 11, got %v", num)
+	}
+	if num := obj.Object["status"].(map[string]interface{})["num"]; num != int64(22) {
+		t.Errorf("expected status.num to be 22, got %v", num)
+	}
+	if obj.GetGeneration() != 2 {
+		t.Errorf("expected generation 2, got %d", obj.GetGeneration())
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/test/integration/testserver"
+	metav1alpha1 "k8s.io/apimachinery/pkg/apis/meta/v1alpha1"
+)
+
+func TestTableGet(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.AdditionalPrinterColumns = []apiextensionsv1beta1.CustomResourceColumnDefinition{
+		{Name: "Replicas", Type: "integer", JSONPath: ".spec.replicas"},
+		{Name: "Phase", Type: "string", JSONPath: ".status.phase"},
+	}
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+
+	instance := testserver.NewNoxuInstance(ns, "foo")
+	instance.Object["spec"] = map[string]interface{}{"replicas": int64(3)}
+	instance.Object["status"] = map[string]interface{}{"phase": "Running"}
+	if _, err := noxuResourceClient.Create(instance); err != nil {
+		t.Fatalf("unexpected error creating an instance: %v", err)
+	}
+
+	data, err := apiExtensionClient.Discovery().RESTClient().Get().
+		AbsPath("/apis/mygroup.example.com/v1beta1/namespaces/"+ns+"/noxus").
+		SetHeader("Accept", "application/json;as=Table;v=v1alpha1;g=meta.k8s.io").
+		DoRaw()
+	if err != nil {
+		t.Fatalf("unexpected error getting the table: %v", err)
+	}
+	table := &metav1alpha1.Table{}
+	if err := json.Unmarshal(data, table); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, col := range table.ColumnDefinitions {
+		names = append(names, col.Name)
+	}
+	if expected := []string{"Name", "Replicas", "Phase"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected columns %v, got %v", expected, names)
+	}
+	if len(table.Rows) != 1 {
+		t.Fatalf("expected one row, got %d", len(table.Rows))
+	}
+	if expected := []interface{}{"foo", float64(3), "Running"}; !reflect.DeepEqual(table.Rows[0].Cells, expected) {
+		t.Errorf("expected cells %v, got %v", expected, table.Rows[0].Cells)
+	}
+}
@@ -20,7 +20,9 @@ import (
 	"fmt"
 	"time"
 
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/fuzzing"
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -33,7 +35,7 @@ import (
 	"k8s.io/client-go/dynamic"
 )
 
-//NewRandomNameCustomResourceDefinition generates a CRD with random name to avoid name conflict in e2e tests
+// NewRandomNameCustomResourceDefinition generates a CRD with random name to avoid name conflict in e2e tests
 func NewRandomNameCustomResourceDefinition(scope apiextensionsv1beta1.ResourceScope) *apiextensionsv1beta1.CustomResourceDefinition {
 	gName := names.SimpleNameGenerator.GenerateName("foo")
 	return &apiextensionsv1beta1.CustomResourceDefinition{
@@ -64,6 +66,7 @@ func NewNoxuCustomResourceDefinition(scope apiextensionsv1beta1.ResourceScope) *
 				Kind:       "WishIHadChosenNoxu",
 				ShortNames: []string{"foo", "bar", "abc", "def"},
 				ListKind:   "NoxuItemList",
+				Categories: []string{"all"},
 			},
 			Scope: scope,
 		},
@@ -141,6 +144,16 @@ func NewCurletInstance(namespace, name string) *unstructured.Unstructured {
 	}
 }
 
+// NewFuzzedInstance returns a random custom resource of the given version, which is valid against the
+// schema of the CRD. The same seed returns the same custom resource.
+func NewFuzzedInstance(crd *apiextensionsv1beta1.CustomResourceDefinition, version, namespace, name string, seed int64) (*unstructured.Unstructured, error) {
+	internalCRD := &apiextensions.CustomResourceDefinition{}
+	if err := apiextensionsv1beta1.Convert_v1beta1_CustomResourceDefinition_To_apiextensions_CustomResourceDefinition(crd, internalCRD, nil); err != nil {
+		return nil, err
+	}
+	return fuzzing.NewFuzzer(seed).CustomResource(internalCRD, version, namespace, name)
+}
+
 func CreateNewCustomResourceDefinition(crd *apiextensionsv1beta1.CustomResourceDefinition, apiExtensionsClient clientset.Interface, clientPool dynamic.ClientPool) (dynamic.Interface, error) {
 	_, err := apiExtensionsClient.Apiextensions().CustomResourceDefinitions().Create(crd)
 	if err != nil {
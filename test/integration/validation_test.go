@@ -17,12 +17,18 @@ limitations under the License.
 package integration
 
 import (
+	"encoding/json"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apiextensions-apiserver/test/integration/testserver"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 func TestForProperValidationErrors(t *testing.T) {
@@ -79,3 +85,355 @@ func TestForProperValidationErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestValidationErrorCauses(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	minReplicas := float64(1)
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.Validation = &apiextensionsv1beta1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1beta1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+				"spec": {
+					Type:     "object",
+					Required: []string{"image"},
+					Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+						"image":    {Type: "string"},
+						"replicas": {Type: "integer", Minimum: &minReplicas},
+					},
+				},
+			},
+		},
+	}
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+
+	instance := testserver.NewNoxuInstance(ns, "foo")
+	instance.Object["spec"] = map[string]interface{}{"replicas": 0}
+	_, err = noxuResourceClient.Create(instance)
+	status, ok := err.(apierrors.APIStatus)
+	if !ok || status.Status().Details == nil {
+		t.Fatalf("expected a status error with details, got %v", err)
+	}
+	expected := map[string]metav1.CauseType{
+		"spec.image":    metav1.CauseTypeFieldValueRequired,
+		"spec.replicas": metav1.CauseTypeFieldValueInvalid,
+	}
+	causes := map[string]metav1.CauseType{}
+	for _, cause := range status.Status().Details.Causes {
+		causes[cause.Field] = cause.Type
+	}
+	if !reflect.DeepEqual(causes, expected) {
+		t.Errorf("expected causes %v, got %v", expected, status.Status().Details.Causes)
+	}
+}
+
+func TestCustomResourceValidationRules(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.Validation = &apiextensionsv1beta1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1beta1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+				"spec": {
+					Type: "object",
+					Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+						"replicas":    {Type: "integer"},
+						"maxReplicas": {Type: "integer"},
+					},
+					XValidations: apiextensionsv1beta1.ValidationRules{
+						{Rule: "self.replicas <= self.maxReplicas", Message: "replicas must not exceed maxReplicas"},
+					},
+				},
+			},
+		},
+	}
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+
+	instance := testserver.NewNoxuInstance(ns, "foo")
+	instance.Object["spec"] = map[string]interface{}{"replicas": 1, "maxReplicas": 2}
+	created, err := noxuResourceClient.Create(instance)
+	if err != nil {
+		t.Fatalf("unexpected error creating a valid instance: %v", err)
+	}
+
+	created.Object["spec"] = map[string]interface{}{"replicas": 3, "maxReplicas": 2}
+	_, err = noxuResourceClient.Update(created)
+	if err == nil || !strings.Contains(err.Error(), "replicas must not exceed maxReplicas") {
+		t.Errorf("expected the update to violate the validation rule, got %v", err)
+	}
+
+	invalid := testserver.NewNoxuInstance(ns, "bar")
+	invalid.Object["spec"] = map[string]interface{}{"replicas": 3, "maxReplicas": 2}
+	_, err = noxuResourceClient.Create(invalid)
+	if err == nil || !strings.Contains(err.Error(), "spec: Invalid value: \"object\": replicas must not exceed maxReplicas") {
+		t.Errorf("expected the create to violate the validation rule, got %v", err)
+	}
+}
+
+func TestCustomResourceValidationRatcheting(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.Validation = &apiextensionsv1beta1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1beta1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+				"spec": {
+					Type: "object",
+					Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+						"name":     {Type: "string"},
+						"replicas": {Type: "integer"},
+					},
+				},
+			},
+		},
+	}
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+
+	instance := testserver.NewNoxuInstance(ns, "foo")
+	instance.Object["spec"] = map[string]interface{}{"name": "toolong", "replicas": 1}
+	if _, err := noxuResourceClient.Create(instance); err != nil {
+		t.Fatalf("unexpected error creating an instance: %v", err)
+	}
+
+	// tighten the schema after the instance has been persisted
+	crd, err := apiExtensionClient.ApiextensionsV1beta1().CustomResourceDefinitions().Get(noxuDefinition.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := crd.Spec.Validation.OpenAPIV3Schema.Properties["spec"].Properties["name"]
+	name.XValidations = apiextensionsv1beta1.ValidationRules{{Rule: "self.size() <= 3", Message: "name is too long"}}
+	crd.Spec.Validation.OpenAPIV3Schema.Properties["spec"].Properties["name"] = name
+	if _, err := apiExtensionClient.ApiextensionsV1beta1().CustomResourceDefinitions().Update(crd); err != nil {
+		t.Fatal(err)
+	}
+
+	// wait until the new rule is enforced
+	err = wait.Poll(500*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		invalid := testserver.NewNoxuInstance(ns, "bar")
+		invalid.Object["spec"] = map[string]interface{}{"name": "toolong"}
+		_, err := noxuResourceClient.Create(invalid)
+		if err == nil {
+			return false, noxuResourceClient.Delete("bar", nil)
+		}
+		return strings.Contains(err.Error(), "name is too long"), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the invalid name is kept, but other fields are updated
+	obj, err := noxuResourceClient.Get("foo", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj.Object["spec"] = map[string]interface{}{"name": "toolong", "replicas": 2}
+	obj, err = noxuResourceClient.Update(obj)
+	if err != nil {
+		t.Fatalf("expected update with unchanged invalid name to succeed, got %v", err)
+	}
+
+	obj.Object["spec"] = map[string]interface{}{"name": "stilltoolong", "replicas": 2}
+	if _, err := noxuResourceClient.Update(obj); err == nil || !strings.Contains(err.Error(), "name is too long") {
+		t.Errorf("expected update of the invalid name to violate the validation rule, got %v", err)
+	}
+}
+
+func TestCustomResourceValidationTransitionRules(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.Validation = &apiextensionsv1beta1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1beta1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+				"spec": {
+					Type: "object",
+					Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+						"id":       {Type: "string", XValidations: apiextensionsv1beta1.ValidationRules{{Rule: "self == oldSelf", Message: "id is immutable"}}},
+						"replicas": {Type: "integer", XValidations: apiextensionsv1beta1.ValidationRules{{Rule: "self >= oldSelf", Message: "replicas may only increase"}}},
+					},
+				},
+			},
+		},
+	}
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+
+	instance := testserver.NewNoxuInstance(ns, "foo")
+	instance.Object["spec"] = map[string]interface{}{"id": "a", "replicas": 2}
+	created, err := noxuResourceClient.Create(instance)
+	if err != nil {
+		t.Fatalf("unexpected error creating an instance: %v", err)
+	}
+
+	created.Object["spec"] = map[string]interface{}{"id": "a", "replicas": 3}
+	updated, err := noxuResourceClient.Update(created)
+	if err != nil {
+		t.Fatalf("expected increasing replicas to succeed, got %v", err)
+	}
+
+	updated.Object["spec"] = map[string]interface{}{"id": "b", "replicas": 3}
+	if _, err := noxuResourceClient.Update(updated); err == nil || !strings.Contains(err.Error(), "id is immutable") {
+		t.Errorf("expected changing the id to violate the transition rule, got %v", err)
+	}
+	updated.Object["spec"] = map[string]interface{}{"id": "a", "replicas": 1}
+	if _, err := noxuResourceClient.Update(updated); err == nil || !strings.Contains(err.Error(), "replicas may only increase") {
+		t.Errorf("expected decreasing replicas to violate the transition rule, got %v", err)
+	}
+}
+
+func TestCustomResourceValidationReferences(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	ref := func(name string) *string {
+		s := "#/definitions/" + name
+		return &s
+	}
+	minPort := 1.0
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.Validation = &apiextensionsv1beta1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1beta1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+				"spec": {
+					Type: "object",
+					Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+						"source":      {Ref: ref("endpoint")},
+						"destination": {Ref: ref("endpoint")},
+					},
+				},
+			},
+			Definitions: apiextensionsv1beta1.JSONSchemaDefinitions{
+				"endpoint": {
+					Type: "object",
+					Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+						"host": {Type: "string"},
+						"port": {Type: "integer", Minimum: &minPort},
+					},
+				},
+			},
+		},
+	}
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+
+	instance := testserver.NewNoxuInstance(ns, "foo")
+	instance.Object["spec"] = map[string]interface{}{
+		"source":      map[string]interface{}{"host": "a", "port": 80},
+		"destination": map[string]interface{}{"host": "b", "port": 8080},
+	}
+	if _, err := noxuResourceClient.Create(instance); err != nil {
+		t.Fatalf("unexpected error creating an instance: %v", err)
+	}
+
+	invalid := testserver.NewNoxuInstance(ns, "bar")
+	invalid.Object["spec"] = map[string]interface{}{
+		"source":      map[string]interface{}{"host": "a", "port": 80},
+		"destination": map[string]interface{}{"host": "b", "port": 0},
+	}
+	if _, err := noxuResourceClient.Create(invalid); err == nil || !strings.Contains(err.Error(), "spec.destination.port") {
+		t.Errorf("expected the referenced definition to be validated, got %v", err)
+	}
+}
+
+func TestCustomResourceDecimalFormat(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	noxuDefinition.Spec.Validation = &apiextensionsv1beta1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1beta1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+				"spec": {
+					Type: "object",
+					Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+						"amount": {Type: "string", Format: "decimal"},
+					},
+				},
+			},
+		},
+	}
+	noxuVersionClient, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	noxuResourceClient := NewNamespacedCustomResourceClient(ns, noxuVersionClient, noxuDefinition)
+
+	// the number is encoded as a JSON number which does not fit into a float64
+	amount := "123456789012345678901234567890.000000001"
+	instance := testserver.NewNoxuInstance(ns, "foo")
+	instance.Object["spec"] = map[string]interface{}{"amount": json.Number(amount)}
+	if _, err := noxuResourceClient.Create(instance); err != nil {
+		t.Fatalf("unexpected error creating an instance: %v", err)
+	}
+	stored, err := noxuResourceClient.Get("foo", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := stored.Object["spec"].(map[string]interface{})["amount"]; got != amount {
+		t.Errorf("expected spec.amount to be preserved as %q, got %#v", amount, got)
+	}
+
+	invalid := testserver.NewNoxuInstance(ns, "bar")
+	invalid.Object["spec"] = map[string]interface{}{"amount": "1.2.3"}
+	if _, err := noxuResourceClient.Create(invalid); !apierrors.IsInvalid(err) {
+		t.Errorf("expected an invalid error for a malformed decimal, got %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"testing"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/serializer/yaml"
+	"k8s.io/apiextensions-apiserver/test/integration/testserver"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestYAMLEncoding(t *testing.T) {
+	stopCh, apiExtensionClient, clientPool, err := testserver.StartDefaultServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(stopCh)
+
+	noxuDefinition := testserver.NewNoxuCustomResourceDefinition(apiextensionsv1beta1.NamespaceScoped)
+	if _, err := testserver.CreateNewCustomResourceDefinition(noxuDefinition, apiExtensionClient, clientPool); err != nil {
+		t.Fatal(err)
+	}
+
+	ns := "not-the-default"
+	collectionPath := "/apis/mygroup.example.com/v1beta1/namespaces/" + ns + "/noxus"
+	restClient := apiExtensionClient.Discovery().RESTClient()
+	serializer := yaml.NewSerializer(nil, nil, nil)
+
+	body := `apiVersion: mygroup.example.com/v1beta1
+kind: WishIHadChosenNoxu
+metadata:
+  name: foo
+  namespace: not-the-default
+  labels:
+    release: 1.10
+content:
+  key: value
+`
+	data, err := restClient.Post().AbsPath(collectionPath).Body([]byte(body)).
+		SetHeader("Content-Type", yaml.ContentTypeYAML).
+		SetHeader("Accept", yaml.ContentTypeYAML).
+		DoRaw()
+	if err != nil {
+		t.Fatalf("unexpected error creating a YAML encoded instance: %v", err)
+	}
+	created, _, err := serializer.Decode(data, nil, &unstructured.Unstructured{})
+	if err != nil {
+		t.Fatalf("unexpected error decoding the YAML response: %v", err)
+	}
+	if name := created.(*unstructured.Unstructured).GetName(); name != "foo" {
+		t.Errorf("expected the created instance to be foo, got %q", name)
+	}
+	if labels := created.(*unstructured.Unstructured).GetLabels(); labels["release"] != "1.10" {
+		t.Errorf("expected the label release to be 1.10, got %v", labels)
+	}
+
+	data, err = restClient.Get().AbsPath(collectionPath).SetHeader("Accept", yaml.ContentTypeYAML).DoRaw()
+	if err != nil {
+		t.Fatalf("unexpected error listing: %v", err)
+	}
+	list, _, err := serializer.Decode(data, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error decoding the YAML list: %v", err)
+	}
+	if items := list.(*unstructured.UnstructuredList).Items; len(items) != 1 || items[0].GetName() != "foo" {
+		t.Errorf("expected to list foo, got %v", items)
+	}
+}
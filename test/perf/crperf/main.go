@@ -0,0 +1,51 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// crperf runs the benchmarks of the serving path of custom resources outside of go test, e.g. to
+// compare releases on the same machine.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/test/perf"
+)
+
+func main() {
+	containers := flag.String("containers", "1,10,100", "Comma-separated numbers of containers of the benchmarked custom resources.")
+	run := flag.String("run", "", "Only run the benchmarks whose name contains this string.")
+	flag.Parse()
+
+	for _, s := range strings.Split(*containers, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil || n < 0 {
+			fmt.Fprintf(os.Stderr, "invalid number of containers %q\n", s)
+			os.Exit(2)
+		}
+		for _, bm := range perf.Benchmarks(n) {
+			if !strings.Contains(bm.Name, *run) {
+				continue
+			}
+			result := testing.Benchmark(bm.F)
+			fmt.Printf("%-30s %s\t%s\n", fmt.Sprintf("%s/containers=%d", bm.Name, n), result.String(), result.MemString())
+		}
+	}
+}
@@ -0,0 +1,331 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package perf benchmarks the serving path of custom resources, i.e. the decoding, pruning,
+// defaulting, validation, conversion and encoding of large representative custom resources.
+package perf
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/conversion"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	schemavalidation "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/validation"
+	"k8s.io/apiextensions-apiserver/pkg/registry/customresource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+var (
+	v1 = schema.GroupVersion{Group: "perf.example.com", Version: "v1"}
+	v2 = schema.GroupVersion{Group: "perf.example.com", Version: "v2"}
+)
+
+// Schema returns the schema of a representative workload: a spec with a pod template like list of
+// containers with ports, environment and resources, and a status with conditions. It has defaults,
+// enums, patterns, int-or-string fields and validation rules.
+func Schema() *apiextensions.JSONSchemaProps {
+	str := apiextensions.JSONSchemaProps{Type: "string"}
+	integer := apiextensions.JSONSchemaProps{Type: "integer"}
+	quantity := apiextensions.JSONSchemaProps{XIntOrString: true, AnyOf: []apiextensions.JSONSchemaProps{{Type: "integer"}, {Type: "string"}}}
+	resources := apiextensions.JSONSchemaProps{
+		Type:                 "object",
+		AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{Allows: true, Schema: &quantity},
+	}
+	container := apiextensions.JSONSchemaProps{
+		Type:     "object",
+		Required: []string{"name", "image"},
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"name":            {Type: "string", Pattern: "^[a-z0-9]([-a-z0-9]*[a-z0-9])?$", MaxLength: int64Ptr(63)},
+			"image":           str,
+			"imagePullPolicy": {Type: "string", Enum: []apiextensions.JSON{"Always", "IfNotPresent", "Never"}, Default: jsonPtr("IfNotPresent")},
+			"args":            {Type: "array", Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &str}},
+			"ports": {
+				Type: "array",
+				Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{
+					Type: "object",
+					Properties: map[string]apiextensions.JSONSchemaProps{
+						"name":          str,
+						"containerPort": {Type: "integer", Minimum: float64Ptr(1), Maximum: float64Ptr(65535)},
+						"protocol":      {Type: "string", Enum: []apiextensions.JSON{"TCP", "UDP", "SCTP"}, Default: jsonPtr("TCP")},
+					},
+				}},
+			},
+			"env": {
+				Type: "array",
+				Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{
+					Type:       "object",
+					Properties: map[string]apiextensions.JSONSchemaProps{"name": str, "value": str},
+				}},
+			},
+			"resources": {
+				Type:       "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{"limits": resources, "requests": resources},
+			},
+		},
+	}
+	return &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"replicas":    {Type: "integer", Minimum: float64Ptr(0), Default: jsonPtr(int64(1))},
+					"maxReplicas": integer,
+					"selector": {
+						Type:                 "object",
+						AdditionalProperties: &apiextensions.JSONSchemaPropsOrBool{Allows: true, Schema: &str},
+					},
+					"containers": {Type: "array", Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &container}},
+				},
+				XValidations: apiextensions.ValidationRules{
+					{Rule: "self.replicas <= self.maxReplicas", Message: "replicas must not exceed maxReplicas"},
+				},
+			},
+			"status": {
+				Type: "object",
+				Properties: map[string]apiextensions.JSONSchemaProps{
+					"observedGeneration": integer,
+					"conditions": {
+						Type: "array",
+						Items: &apiextensions.JSONSchemaPropsOrArray{Schema: &apiextensions.JSONSchemaProps{
+							Type:     "object",
+							Required: []string{"type", "status"},
+							Properties: map[string]apiextensions.JSONSchemaProps{
+								"type":    str,
+								"status":  {Type: "string", Enum: []apiextensions.JSON{"True", "False", "Unknown"}},
+								"reason":  str,
+								"message": str,
+							},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
+
+// CRD returns a CustomResourceDefinition with the versions v1 and v2 of the schema, which are
+// converted by expressions renaming spec.maxReplicas to spec.replicaLimit in v2.
+func CRD() *apiextensions.CustomResourceDefinition {
+	v2Schema := Schema()
+	spec := v2Schema.Properties["spec"]
+	spec.Properties["replicaLimit"] = spec.Properties["maxReplicas"]
+	delete(spec.Properties, "maxReplicas")
+	spec.XValidations = apiextensions.ValidationRules{
+		{Rule: "self.replicas <= self.replicaLimit", Message: "replicas must not exceed replicaLimit"},
+	}
+	v2Schema.Properties["spec"] = spec
+
+	return &apiextensions.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "workloads.perf.example.com"},
+		Spec: apiextensions.CustomResourceDefinitionSpec{
+			Group:   v1.Group,
+			Version: v1.Version,
+			Versions: []apiextensions.CustomResourceDefinitionVersion{
+				{Name: v1.Version, Served: true, Storage: true, Schema: &apiextensions.CustomResourceValidation{OpenAPIV3Schema: Schema()}},
+				{Name: v2.Version, Served: true, Schema: &apiextensions.CustomResourceValidation{OpenAPIV3Schema: v2Schema}},
+			},
+			Scope: apiextensions.NamespaceScoped,
+			Names: apiextensions.CustomResourceDefinitionNames{Plural: "workloads", Singular: "workload", Kind: "Workload", ListKind: "WorkloadList"},
+			Conversion: &apiextensions.CustomResourceConversion{
+				Strategy: apiextensions.ExpressionConverter,
+				Expressions: []apiextensions.ConversionExpression{
+					{
+						FromVersion: v1.Version,
+						ToVersion:   v2.Version,
+						FieldMappings: []apiextensions.ConversionFieldMapping{
+							{Path: "spec.replicaLimit", Expression: "self.spec.maxReplicas"},
+							{Path: "spec.maxReplicas", Expression: "null"},
+						},
+					},
+					{
+						FromVersion: v2.Version,
+						ToVersion:   v1.Version,
+						FieldMappings: []apiextensions.ConversionFieldMapping{
+							{Path: "spec.maxReplicas", Expression: "self.spec.replicaLimit"},
+							{Path: "spec.replicaLimit", Expression: "null"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// CustomResource returns a v1 custom resource of the schema with the given number of containers
+// and as many status conditions. Defaulted fields are left unset.
+func CustomResource(containers int) *unstructured.Unstructured {
+	items := make([]interface{}, 0, containers)
+	conditions := make([]interface{}, 0, containers)
+	for i := 0; i < containers; i++ {
+		name := fmt.Sprintf("container-%d", i)
+		items = append(items, map[string]interface{}{
+			"name":  name,
+			"image": "registry.example.com/perf/" + name + ":v1.2.3",
+			"args":  []interface{}{"--port=8080", "--verbose", "--config=/etc/" + name + "/config.yaml"},
+			"ports": []interface{}{
+				map[string]interface{}{"name": "http", "containerPort": int64(8080)},
+				map[string]interface{}{"name": "metrics", "containerPort": int64(9090), "protocol": "TCP"},
+			},
+			"env": []interface{}{
+				map[string]interface{}{"name": "POD_NAME", "value": name},
+				map[string]interface{}{"name": "LOG_LEVEL", "value": "info"},
+			},
+			"resources": map[string]interface{}{
+				"limits":   map[string]interface{}{"cpu": "500m", "memory": "256Mi"},
+				"requests": map[string]interface{}{"cpu": int64(1), "memory": "128Mi"},
+			},
+		})
+		conditions = append(conditions, map[string]interface{}{
+			"type":    fmt.Sprintf("Container%dReady", i),
+			"status":  "True",
+			"reason":  "Running",
+			"message": "the container " + name + " is running",
+		})
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": v1.String(),
+		"kind":       "Workload",
+		"metadata": map[string]interface{}{
+			"name":      "workload",
+			"namespace": "default",
+			"labels":    map[string]interface{}{"app": "perf", "tier": "backend"},
+		},
+		"spec": map[string]interface{}{
+			"maxReplicas": int64(10),
+			"selector":    map[string]interface{}{"app": "perf"},
+			"containers":  items,
+		},
+		"status": map[string]interface{}{
+			"observedGeneration": int64(1),
+			"conditions":         conditions,
+		},
+	}}
+}
+
+// Benchmark is a benchmark of a step of the serving path.
+type Benchmark struct {
+	Name string
+	F    func(b *testing.B)
+}
+
+// Benchmarks returns the benchmarks of the serving path for custom resources with the given number
+// of containers. Steps which mutate the custom resource run on copies made outside of the timer.
+func Benchmarks(containers int) []Benchmark {
+	crd := CRD()
+	s := crd.Spec.Versions[0].Schema.OpenAPIV3Schema
+	obj := CustomResource(containers)
+	kind := v1.WithKind("Workload")
+	ctx := genericapirequest.WithNamespace(genericapirequest.NewContext(), "default")
+
+	var data bytes.Buffer
+	if err := unstructured.UnstructuredJSONScheme.Encode(obj, &data); err != nil {
+		panic(err)
+	}
+
+	copies := func(b *testing.B) []*unstructured.Unstructured {
+		b.StopTimer()
+		defer b.StartTimer()
+		ret := make([]*unstructured.Unstructured, b.N)
+		for i := range ret {
+			ret[i] = obj.DeepCopy()
+		}
+		return ret
+	}
+
+	return []Benchmark{
+		{Name: "Decode", F: func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, _, err := unstructured.UnstructuredJSONScheme.Decode(data.Bytes(), nil, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}},
+		{Name: "Prune", F: func(b *testing.B) {
+			b.ReportAllocs()
+			for _, u := range copies(b) {
+				pruning.Prune(u.Object, s, true)
+			}
+		}},
+		{Name: "Default", F: func(b *testing.B) {
+			b.ReportAllocs()
+			for _, u := range copies(b) {
+				defaulting.Default(u.Object, s)
+			}
+		}},
+		{Name: "Validate", F: func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if errs := schemavalidation.Validate(obj.Object, s, nil); len(errs) > 0 {
+					b.Fatal(errs)
+				}
+			}
+		}},
+		{Name: "Strategy", F: func(b *testing.B) {
+			strategy := customresource.NewStrategy(nil, customresource.StrategyOptions{NamespaceScoped: true, Kind: kind, Plural: crd.Spec.Names.Plural, Schema: s}, nil, 0)
+			b.ReportAllocs()
+			for _, u := range copies(b) {
+				strategy.PrepareForCreate(ctx, u)
+				if errs := strategy.Validate(ctx, u); len(errs) > 0 {
+					b.Fatal(errs)
+				}
+			}
+		}},
+		{Name: "Convert", F: func(b *testing.B) {
+			converter, err := conversion.NewConverter(crd, conversion.WebhookOptions{}, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := converter.Convert(obj, v2); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}},
+		{Name: "Encode", F: func(b *testing.B) {
+			var buf bytes.Buffer
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				buf.Reset()
+				if err := unstructured.UnstructuredJSONScheme.Encode(obj, &buf); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}},
+	}
+}
+
+func jsonPtr(x interface{}) *apiextensions.JSON {
+	ret := apiextensions.JSON(x)
+	return &ret
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
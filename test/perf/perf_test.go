@@ -0,0 +1,39 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package perf
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/structural"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestSchemaIsStructural(t *testing.T) {
+	if errs := structural.Validate(Schema(), field.NewPath("openAPIV3Schema")); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+}
+
+func BenchmarkServingPath(b *testing.B) {
+	for _, containers := range []int{1, 10, 100} {
+		for _, bm := range Benchmarks(containers) {
+			b.Run(fmt.Sprintf("%s/containers=%d", bm.Name, containers), bm.F)
+		}
+	}
+}